@@ -13,16 +13,24 @@ const (
 
 var DefaultRoles = map[string]string{
 	atc.SaveConfig:                    MemberRole,
+	atc.PatchConfig:                   MemberRole,
 	atc.GetConfig:                     ViewerRole,
+	atc.GetResourceTypeClosure:        ViewerRole,
 	atc.GetCC:                         ViewerRole,
 	atc.GetBuild:                      ViewerRole,
 	atc.GetBuildPlan:                  ViewerRole,
+	atc.GetBuildProvenance:            ViewerRole,
 	atc.CreateBuild:                   MemberRole,
 	atc.ListBuilds:                    ViewerRole,
 	atc.BuildEvents:                   ViewerRole,
 	atc.BuildResources:                ViewerRole,
 	atc.AbortBuild:                    OperatorRole,
+	atc.ProtectBuild:                  OwnerRole,
+	atc.UnprotectBuild:                OwnerRole,
 	atc.GetBuildPreparation:           ViewerRole,
+	atc.GetBuildServerLogs:            OperatorRole,
+	atc.ListBuildApprovals:            ViewerRole,
+	atc.DecideBuildApproval:           OperatorRole,
 	atc.GetJob:                        ViewerRole,
 	atc.CreateJobBuild:                OperatorRole,
 	atc.RerunJobBuild:                 OperatorRole,
@@ -34,24 +42,37 @@ var DefaultRoles = map[string]string{
 	atc.PauseJob:                      OperatorRole,
 	atc.UnpauseJob:                    OperatorRole,
 	atc.ScheduleJob:                   OperatorRole,
+	atc.GetJobSchedulerDecision:       ViewerRole,
 	atc.GetVersionsDB:                 ViewerRole,
 	atc.JobBadge:                      ViewerRole,
 	atc.MainJobBadge:                  ViewerRole,
 	atc.ClearTaskCache:                OperatorRole,
 	atc.ListAllResources:              ViewerRole,
+	atc.ListResourceTypeUsages:        ViewerRole,
 	atc.ListResources:                 ViewerRole,
+	atc.ListResourceChecks:            ViewerRole,
+	atc.ListResourceCheckRecords:      ViewerRole,
+	atc.ListPendingChecks:             ViewerRole,
+	atc.CancelCheck:                   OperatorRole,
 	atc.ListResourceTypes:             ViewerRole,
 	atc.GetResource:                   ViewerRole,
 	atc.UnpinResource:                 OperatorRole,
 	atc.SetPinCommentOnResource:       OperatorRole,
+	atc.PauseResourceChecking:         OperatorRole,
+	atc.UnpauseResourceChecking:       OperatorRole,
 	atc.CheckResource:                 OperatorRole,
 	atc.CheckResourceWebHook:          OperatorRole,
 	atc.CheckResourceType:             OperatorRole,
 	atc.ListResourceVersions:          ViewerRole,
+	atc.ListResourceVersionToggles:    ViewerRole,
 	atc.GetResourceVersion:            ViewerRole,
 	atc.EnableResourceVersion:         OperatorRole,
 	atc.DisableResourceVersion:        OperatorRole,
+	atc.PromoteResourceVersion:        OperatorRole,
+	atc.UnpromoteResourceVersion:      OperatorRole,
 	atc.PinResourceVersion:            OperatorRole,
+	atc.BackfillResourceVersions:      OwnerRole,
+	atc.ExpireResourceVersionCaches:   OwnerRole,
 	atc.ListBuildsWithVersionAsInput:  ViewerRole,
 	atc.ListBuildsWithVersionAsOutput: ViewerRole,
 	atc.GetResourceCausality:          ViewerRole,
@@ -70,12 +91,22 @@ var DefaultRoles = map[string]string{
 	atc.ListPipelineBuilds:            ViewerRole,
 	atc.CreatePipelineBuild:           MemberRole,
 	atc.PipelineBadge:                 ViewerRole,
+	atc.ListSerialGroups:              ViewerRole,
+	atc.ListVersionSets:               ViewerRole,
+	atc.SaveVersionSet:                OperatorRole,
+	atc.ApplyVersionSet:               OperatorRole,
+	atc.ClearVersionSet:               OperatorRole,
+	atc.DestroyVersionSet:             OperatorRole,
 	atc.RegisterWorker:                MemberRole,
 	atc.LandWorker:                    MemberRole,
 	atc.RetireWorker:                  MemberRole,
 	atc.PruneWorker:                   MemberRole,
+	atc.PruneStalledWorkers:           MemberRole,
 	atc.HeartbeatWorker:               MemberRole,
+	atc.WarmWorker:                    MemberRole,
+	atc.ReportWorkerDiskUsage:         MemberRole,
 	atc.ListWorkers:                   ViewerRole,
+	atc.ListWorkerUsage:               ViewerRole,
 	atc.DeleteWorker:                  MemberRole,
 	atc.SetLogLevel:                   MemberRole,
 	atc.GetLogLevel:                   ViewerRole,
@@ -90,14 +121,25 @@ var DefaultRoles = map[string]string{
 	atc.ListVolumes:                   ViewerRole,
 	atc.ListDestroyingVolumes:         ViewerRole,
 	atc.ReportWorkerVolumes:           MemberRole,
+	atc.ReportWorkerVolumeSizes:       MemberRole,
 	atc.ListTeams:                     ViewerRole,
 	atc.GetTeam:                       ViewerRole,
 	atc.SetTeam:                       OwnerRole,
 	atc.RenameTeam:                    OwnerRole,
 	atc.DestroyTeam:                   OwnerRole,
 	atc.ListTeamBuilds:                ViewerRole,
+	atc.ListTeamUsage:                 ViewerRole,
+	atc.ListSharedArtifacts:           ViewerRole,
+	atc.GrantSharedArtifact:           MemberRole,
+	atc.RevokeSharedArtifact:          MemberRole,
+	atc.ListPipelineVars:              ViewerRole,
+	atc.SetPipelineVar:                MemberRole,
+	atc.DeletePipelineVar:             MemberRole,
+	atc.GetTeamResourceTypes:          ViewerRole,
+	atc.SetTeamResourceTypes:          MemberRole,
 	atc.CreateArtifact:                MemberRole,
 	atc.GetArtifact:                   MemberRole,
 	atc.ListBuildArtifacts:            ViewerRole,
+	atc.ListBuildVolumes:              ViewerRole,
 	atc.GetWall:                       ViewerRole,
 }
@@ -1,6 +1,8 @@
 package api_test
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -18,6 +20,7 @@ import (
 	"github.com/concourse/concourse/atc/api"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/concourse/atc/api/artifactserver"
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/api/containerserver/containerserverfakes"
 	"github.com/concourse/concourse/atc/api/policychecker/policycheckerfakes"
@@ -26,8 +29,10 @@ import (
 	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/eventarchive/eventarchivefakes"
 	"github.com/concourse/concourse/atc/gc/gcfakes"
 	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/provenance"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
 	"github.com/concourse/concourse/atc/wrappa"
 
@@ -38,10 +43,14 @@ import (
 var (
 	sink *lager.ReconfigurableSink
 
+	provenanceSigner *provenance.Signer
+
 	externalURL = "https://example.com"
 	clusterName = "Test Cluster"
 
 	fakeWorkerPool          *workerfakes.FakePool
+	fakeResourceCacheWarmer *workerfakes.FakeResourceCacheWarmer
+	fakeEventStore          *eventarchivefakes.FakeStore
 	fakeVolumeRepository    *dbfakes.FakeVolumeRepository
 	fakeContainerRepository *dbfakes.FakeContainerRepository
 	fakeDestroyer           *gcfakes.FakeDestroyer
@@ -50,6 +59,7 @@ var (
 	dbJobFactory            *dbfakes.FakeJobFactory
 	dbResourceFactory       *dbfakes.FakeResourceFactory
 	dbResourceConfigFactory *dbfakes.FakeResourceConfigFactory
+	dbResourceCacheFactory  *dbfakes.FakeResourceCacheFactory
 	fakePipeline            *dbfakes.FakePipeline
 	fakeAccess              *accessorfakes.FakeAccess
 	fakeAccessor            *accessorfakes.FakeAccessFactory
@@ -59,9 +69,12 @@ var (
 	build                   *dbfakes.FakeBuild
 	dbBuildFactory          *dbfakes.FakeBuildFactory
 	dbUserFactory           *dbfakes.FakeUserFactory
+	dbTeamUsageFactory      *dbfakes.FakeTeamUsageFactory
+	dbBuildServerLogFactory *dbfakes.FakeBuildServerLogFactory
 	dbCheckFactory          *dbfakes.FakeCheckFactory
 	dbTeam                  *dbfakes.FakeTeam
 	dbWall                  *dbfakes.FakeWall
+	dbEncryptionKeyRotation *dbfakes.FakeEncryptionKeyRotation
 	fakeSecretManager       *credsfakes.FakeSecrets
 	fakeVarSourcePool       *credsfakes.FakeVarSourcePool
 	fakePolicyChecker       *policycheckerfakes.FakePolicyChecker
@@ -70,6 +83,8 @@ var (
 	interceptTimeout        *containerserverfakes.FakeInterceptTimeout
 	isTLSEnabled            bool
 	cliDownloadsDir         string
+	artifactSpoolDir        string
+	artifactSpools          *artifactserver.SpoolCache
 	logger                  *lagertest.TestLogger
 	fakeClock               *fakeclock.FakeClock
 
@@ -99,6 +114,14 @@ func (f *fakeEventHandlerFactory) Construct(
 	})
 }
 
+var _ = BeforeSuite(func() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).ToNot(HaveOccurred())
+
+	provenanceSigner, err = provenance.NewSigner(key)
+	Expect(err).ToNot(HaveOccurred())
+})
+
 var _ = BeforeEach(func() {
 	dbTeamFactory = new(dbfakes.FakeTeamFactory)
 	dbWorkerTeamFactory = new(dbfakes.FakeTeamFactory)
@@ -106,10 +129,14 @@ var _ = BeforeEach(func() {
 	dbJobFactory = new(dbfakes.FakeJobFactory)
 	dbResourceFactory = new(dbfakes.FakeResourceFactory)
 	dbResourceConfigFactory = new(dbfakes.FakeResourceConfigFactory)
+	dbResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
 	dbBuildFactory = new(dbfakes.FakeBuildFactory)
 	dbUserFactory = new(dbfakes.FakeUserFactory)
+	dbTeamUsageFactory = new(dbfakes.FakeTeamUsageFactory)
+	dbBuildServerLogFactory = new(dbfakes.FakeBuildServerLogFactory)
 	dbCheckFactory = new(dbfakes.FakeCheckFactory)
 	dbWall = new(dbfakes.FakeWall)
+	dbEncryptionKeyRotation = new(dbfakes.FakeEncryptionKeyRotation)
 
 	interceptTimeoutFactory = new(containerserverfakes.FakeInterceptTimeoutFactory)
 	interceptTimeout = new(containerserverfakes.FakeInterceptTimeout)
@@ -133,6 +160,8 @@ var _ = BeforeEach(func() {
 	dbWorkerLifecycle = new(dbfakes.FakeWorkerLifecycle)
 
 	fakeWorkerPool = new(workerfakes.FakePool)
+	fakeResourceCacheWarmer = new(workerfakes.FakeResourceCacheWarmer)
+	fakeEventStore = new(eventarchivefakes.FakeStore)
 
 	fakeVolumeRepository = new(dbfakes.FakeVolumeRepository)
 	fakeContainerRepository = new(dbfakes.FakeContainerRepository)
@@ -148,6 +177,11 @@ var _ = BeforeEach(func() {
 	cliDownloadsDir, err = ioutil.TempDir("", "cli-downloads")
 	Expect(err).NotTo(HaveOccurred())
 
+	artifactSpoolDir, err = ioutil.TempDir("", "artifact-spools")
+	Expect(err).NotTo(HaveOccurred())
+
+	artifactSpools = artifactserver.NewSpoolCache(fakeClock, artifactSpoolDir, time.Minute, 1024*1024)
+
 	constructedEventHandler = &fakeEventHandlerFactory{}
 
 	logger = lagertest.NewTestLogger("api")
@@ -168,6 +202,7 @@ var _ = BeforeEach(func() {
 
 	fakePolicyChecker = new(policycheckerfakes.FakePolicyChecker)
 	fakePolicyChecker.CheckReturns(policy.PassedPolicyCheck(), nil)
+	fakePolicyChecker.CheckDataReturns(policy.PassedPolicyCheck(), nil)
 
 	apiWrapper := wrappa.MultiWrappa{
 		wrappa.NewPolicyCheckWrappa(logger, fakePolicyChecker),
@@ -199,13 +234,21 @@ var _ = BeforeEach(func() {
 		dbBuildFactory,
 		dbCheckFactory,
 		dbResourceConfigFactory,
+		dbResourceCacheFactory,
 		dbUserFactory,
+		dbTeamUsageFactory,
+		dbBuildServerLogFactory,
 
 		constructedEventHandler.Construct,
+		fakeEventStore,
 
 		fakeWorkerPool,
+		fakeResourceCacheWarmer,
+		artifactSpools,
 
 		sink,
+		provenanceSigner,
+		fakePolicyChecker,
 
 		isTLSEnabled,
 
@@ -218,6 +261,7 @@ var _ = BeforeEach(func() {
 		interceptTimeoutFactory,
 		time.Second,
 		dbWall,
+		dbEncryptionKeyRotation,
 		fakeClock,
 	)
 
@@ -248,6 +292,7 @@ var _ = BeforeEach(func() {
 
 var _ = AfterEach(func() {
 	os.Remove(cliDownloadsDir)
+	os.RemoveAll(artifactSpoolDir)
 	server.Close()
 })
 
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"os"
 	"time"
 
 	"github.com/concourse/baggageclaim"
@@ -18,6 +19,7 @@ import (
 	"github.com/concourse/concourse/atc/worker/workerfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
 )
 
 var _ = Describe("ArtifactRepository API", func() {
@@ -194,15 +196,24 @@ var _ = Describe("ArtifactRepository API", func() {
 	})
 
 	Describe("GET /api/v1/teams/:team_name/artifacts/:artifact_id", func() {
-		var response *http.Response
+		var (
+			requestHeaders http.Header
+			response       *http.Response
+		)
 
 		BeforeEach(func() {
+			requestHeaders = http.Header{}
+
 			fakeAccess.IsAuthenticatedReturns(true)
 		})
 
 		JustBeforeEach(func() {
-			var err error
-			response, err = http.Get(server.URL + "/api/v1/teams/some-team/artifacts/18")
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/some-team/artifacts/18", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header = requestHeaders
+
+			response, err = client.Do(request)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -327,11 +338,28 @@ var _ = Describe("ArtifactRepository API", func() {
 					})
 
 					Context("when streaming volume contents succeeds", func() {
+						var content []byte
+
 						BeforeEach(func() {
-							reader := ioutil.NopCloser(bytes.NewReader([]byte("some-content")))
-							fakeWorkerVolume.StreamOutReturns(reader, nil)
+							content = []byte("some-content")
+
+							fakeWorkerVolume.StreamOutStub = func(context.Context, string, baggageclaim.Encoding) (io.ReadCloser, error) {
+								return ioutil.NopCloser(bytes.NewReader(content)), nil
+							}
 						})
 
+						spools := func() []string {
+							files, err := ioutil.ReadDir(artifactSpoolDir)
+							Expect(err).ToNot(HaveOccurred())
+
+							names := []string{}
+							for _, file := range files {
+								names = append(names, file.Name())
+							}
+
+							return names
+						}
+
 						It("returns 200", func() {
 							Expect(response.StatusCode).To(Equal(http.StatusOK))
 						})
@@ -346,6 +374,181 @@ var _ = Describe("ArtifactRepository API", func() {
 						It("returns the contents of the volume", func() {
 							Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("some-content")))
 						})
+
+						It("returns a validator for the spooled contents", func() {
+							Expect(response.Header.Get("Accept-Ranges")).To(Equal("bytes"))
+							Expect(response.Header.Get("ETag")).To(MatchRegexp(`^"some-handle-[0-9a-f]{16}"$`))
+						})
+
+						It("reports the length of the contents", func() {
+							Expect(response.ContentLength).To(Equal(int64(len("some-content"))))
+							Expect(response.Header.Get("Content-Length")).To(Equal("12"))
+						})
+
+						It("spools the contents in the spool directory", func() {
+							Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("some-content")))
+							Expect(spools()).To(HaveLen(1))
+						})
+
+						Context("when a range of the contents is requested", func() {
+							BeforeEach(func() {
+								requestHeaders.Set("Range", "bytes=5-")
+							})
+
+							It("returns 206 with the range and a validator for it", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusPartialContent))
+								Expect(response.Header.Get("Content-Range")).To(Equal("bytes 5-11/12"))
+								Expect(response.Header.Get("ETag")).To(MatchRegexp(`^"some-handle-[0-9a-f]{16}"$`))
+								Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("content")))
+							})
+						})
+
+						Context("when the artifact is requested again", func() {
+							var (
+								etag           string
+								secondHeaders  http.Header
+								secondResponse *http.Response
+							)
+
+							BeforeEach(func() {
+								secondHeaders = http.Header{}
+							})
+
+							JustBeforeEach(func() {
+								Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("some-content")))
+								etag = response.Header.Get("ETag")
+
+								request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/some-team/artifacts/18", nil)
+								Expect(err).NotTo(HaveOccurred())
+
+								for name, values := range secondHeaders {
+									request.Header[name] = values
+								}
+
+								if request.Header.Get("If-Range") == "etag" {
+									request.Header.Set("If-Range", etag)
+								}
+
+								secondResponse, err = client.Do(request)
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("serves it from the spooled contents, with their length", func() {
+								Expect(secondResponse.StatusCode).To(Equal(http.StatusOK))
+								Expect(secondResponse.ContentLength).To(Equal(int64(len("some-content"))))
+								Expect(secondResponse.Header.Get("ETag")).To(Equal(etag))
+								Expect(ioutil.ReadAll(secondResponse.Body)).To(Equal([]byte("some-content")))
+
+								Expect(fakeWorkerVolume.StreamOutCallCount()).To(Equal(1))
+							})
+
+							Context("when a range of the contents is requested", func() {
+								BeforeEach(func() {
+									secondHeaders.Set("Range", "bytes=5-")
+								})
+
+								It("returns 206 with the range of the spooled contents", func() {
+									Expect(secondResponse.StatusCode).To(Equal(http.StatusPartialContent))
+									Expect(secondResponse.Header.Get("Content-Range")).To(Equal("bytes 5-11/12"))
+									Expect(ioutil.ReadAll(secondResponse.Body)).To(Equal([]byte("content")))
+
+									Expect(fakeWorkerVolume.StreamOutCallCount()).To(Equal(1))
+								})
+
+								Context("when the range is only wanted if the contents are unchanged", func() {
+									Context("and they are", func() {
+										BeforeEach(func() {
+											secondHeaders.Set("If-Range", "etag")
+										})
+
+										It("returns the range", func() {
+											Expect(secondResponse.StatusCode).To(Equal(http.StatusPartialContent))
+											Expect(ioutil.ReadAll(secondResponse.Body)).To(Equal([]byte("content")))
+										})
+									})
+
+									Context("and they are from another spool of the artifact", func() {
+										BeforeEach(func() {
+											secondHeaders.Set("If-Range", `"some-handle-0123456789abcdef"`)
+										})
+
+										It("returns all of the contents", func() {
+											Expect(secondResponse.StatusCode).To(Equal(http.StatusOK))
+											Expect(ioutil.ReadAll(secondResponse.Body)).To(Equal([]byte("some-content")))
+										})
+									})
+								})
+							})
+						})
+
+						Context("when the spool outlives its TTL", func() {
+							var process ifrit.Process
+
+							BeforeEach(func() {
+								process = ifrit.Invoke(artifactSpools)
+							})
+
+							AfterEach(func() {
+								process.Signal(os.Interrupt)
+								Eventually(process.Wait()).Should(Receive())
+							})
+
+							It("is removed", func() {
+								Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("some-content")))
+								Expect(spools()).To(HaveLen(1))
+
+								fakeClock.WaitForWatcherAndIncrement(2 * time.Minute)
+								Eventually(spools).Should(BeEmpty())
+							})
+						})
+
+						Context("when the spools are stopped", func() {
+							It("removes the spool directory", func() {
+								Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("some-content")))
+
+								process := ifrit.Invoke(artifactSpools)
+								process.Signal(os.Interrupt)
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+
+								_, err := os.Stat(artifactSpoolDir)
+								Expect(os.IsNotExist(err)).To(BeTrue())
+							})
+						})
+
+						Context("when the artifact is too large to spool", func() {
+							BeforeEach(func() {
+								content = bytes.Repeat([]byte("x"), 2*1024*1024)
+							})
+
+							It("streams all of it without spooling it, or knowing its length", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+								Expect(response.ContentLength).To(Equal(int64(-1)))
+								Expect(ioutil.ReadAll(response.Body)).To(Equal(content))
+								Expect(spools()).To(BeEmpty())
+							})
+
+							Context("when a range of the contents is requested", func() {
+								BeforeEach(func() {
+									requestHeaders.Set("Range", "bytes=5-")
+								})
+
+								It("returns all of the contents instead", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+									Expect(ioutil.ReadAll(response.Body)).To(Equal(content))
+									Expect(spools()).To(BeEmpty())
+								})
+							})
+						})
+
+						Context("when an unsatisfiable range is requested", func() {
+							BeforeEach(func() {
+								requestHeaders.Set("Range", "bytes=100-")
+							})
+
+							It("returns 416", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusRequestedRangeNotSatisfiable))
+							})
+						})
 					})
 				})
 			})
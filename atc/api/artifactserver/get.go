@@ -1,10 +1,13 @@
 package artifactserver
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -36,31 +39,112 @@ func (s *Server) GetArtifact(team db.Team) http.Handler {
 			return
 		}
 
-		workerVolume, found, err := s.workerPool.FindVolume(logger, team.ID(), artifactVolume.Handle())
+		handle := artifactVolume.Handle()
+
+		if !s.spools.enabled() {
+			err = s.streamArtifact(r.Context(), logger, team.ID(), handle, w)
+			if err == errVolumeNotFound {
+				logger.Error("failed-to-find-worker-volume", err)
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			if err != nil {
+				logger.Error("failed-to-stream-artifact", err)
+			}
+
+			return
+		}
+
+		// spool the artifact before responding, so that its length is known and
+		// ranges of it can be served
+		spool, isNew, err := s.spools.get(handle)
 		if err != nil {
-			logger.Error("failed-to-get-worker-volume", err)
+			logger.Error("failed-to-get-artifact-spool", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		if !found {
+		if isNew {
+			err = s.spools.fill(handle, spool, func(ctx context.Context, spoolWriter io.Writer) error {
+				return s.streamArtifact(ctx, logger, team.ID(), handle, spoolWriter)
+			})
+		} else {
+			select {
+			case <-spool.ready:
+				err = spool.err
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if err == errVolumeNotFound {
 			logger.Error("failed-to-find-worker-volume", err)
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
 
-		reader, err := workerVolume.StreamOut(r.Context(), "/", baggageclaim.GzipEncoding)
+		if err == errSpoolFull {
+			// too large to spool, so neither its length is known nor a range of
+			// it can be served; serve all of it instead, which the client is
+			// free to accept
+			err = s.streamArtifact(r.Context(), logger, team.ID(), handle, w)
+			if err != nil {
+				logger.Error("failed-to-stream-artifact", err)
+			}
+
+			return
+		}
+
 		if err != nil {
-			logger.Error("failed-to-stream-volume-contents", err)
+			logger.Error("failed-to-spool-artifact", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		defer reader.Close()
-
-		_, err = io.Copy(w, reader)
+		file, found, err := s.spools.open(handle, spool)
 		if err != nil {
-			logger.Error("failed-to-encode-artifact", err)
+			logger.Error("failed-to-open-artifact-spool", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			// removed to make room for another spool since
+			err = s.streamArtifact(r.Context(), logger, team.ID(), handle, w)
+			if err != nil {
+				logger.Error("failed-to-stream-artifact", err)
+			}
+
+			return
 		}
+
+		defer file.Close()
+
+		w.Header().Set("ETag", spool.etag(handle))
+
+		http.ServeContent(w, r, "", time.Time{}, file)
 	})
 }
+
+// streamArtifact streams the contents of the artifact's volume to w.
+func (s *Server) streamArtifact(ctx context.Context, logger lager.Logger, teamID int, handle string, w io.Writer) error {
+	workerVolume, found, err := s.workerPool.FindVolume(logger, teamID, handle)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errVolumeNotFound
+	}
+
+	reader, err := workerVolume.StreamOut(ctx, "/", baggageclaim.GzipEncoding)
+	if err != nil {
+		return err
+	}
+
+	defer reader.Close()
+
+	_, err = io.Copy(w, reader)
+	return err
+}
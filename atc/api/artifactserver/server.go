@@ -8,14 +8,17 @@ import (
 type Server struct {
 	logger     lager.Logger
 	workerPool worker.Pool
+	spools     *SpoolCache
 }
 
 func NewServer(
 	logger lager.Logger,
 	workerPool worker.Pool,
+	spools *SpoolCache,
 ) *Server {
 	return &Server{
 		logger:     logger,
 		workerPool: workerPool,
+		spools:     spools,
 	}
 }
@@ -0,0 +1,285 @@
+package artifactserver
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+)
+
+// SpoolTTL is how long the spooled contents of an artifact are kept after
+// they were last served.
+const SpoolTTL = 10 * time.Minute
+
+// spoolExpiryInterval is how often the spools which outlived their TTL are
+// removed.
+const spoolExpiryInterval = time.Minute
+
+var errVolumeNotFound = errors.New("volume not found")
+
+var errSpoolFull = errors.New("artifact spool is full")
+
+// SpoolCache holds the spooled contents of artifacts by the handles of their
+// volumes. The contents are spooled so that their length is known and ranges
+// of them can be served, letting clients resume partial downloads of large
+// artifacts; keeping them means each range requested doesn't stream the
+// whole artifact from its worker again.
+//
+// The spools are kept in dir, up to maxBytes in total. The least recently
+// used spools are removed to make room for new ones; an artifact which doesn't
+// fit is not spooled at all. A maxBytes of 0 disables spooling.
+type SpoolCache struct {
+	clock    clock.Clock
+	dir      string
+	ttl      time.Duration
+	maxBytes int64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	spools map[string]*spool
+	size   int64
+	mu     sync.Mutex
+}
+
+type spool struct {
+	id       string
+	path     string
+	size     int64
+	err      error
+	ready    chan struct{}
+	lastUsed time.Time
+}
+
+func NewSpoolCache(clock clock.Clock, dir string, ttl time.Duration, maxBytes int64) *SpoolCache {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &SpoolCache{
+		clock:    clock,
+		dir:      dir,
+		ttl:      ttl,
+		maxBytes: maxBytes,
+
+		ctx:    ctx,
+		cancel: cancel,
+
+		spools: map[string]*spool{},
+	}
+}
+
+// Run removes the spools which outlived their TTL every spoolExpiryInterval.
+// Once signalled, it stops any spooling in progress and removes dir.
+func (c *SpoolCache) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	ticker := c.clock.NewTicker(spoolExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			c.mu.Lock()
+			c.expire()
+			c.mu.Unlock()
+
+		case <-signals:
+			c.cancel()
+
+			c.mu.Lock()
+			c.spools = map[string]*spool{}
+			c.size = 0
+			c.mu.Unlock()
+
+			return os.RemoveAll(c.dir)
+		}
+	}
+}
+
+// enabled returns whether artifacts are spooled at all.
+func (c *SpoolCache) enabled() bool {
+	return c.maxBytes > 0
+}
+
+// get returns the spool of the artifact of the volume. If it is new, the
+// caller must fill it with a writer from its fill method. Concurrent requests
+// for an artifact wait on its ready channel for it to be spooled once.
+func (c *SpoolCache) get(handle string) (*spool, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, found := c.spools[handle]
+	if found {
+		s.lastUsed = c.clock.Now()
+		return s, false, nil
+	}
+
+	id := make([]byte, 8)
+	_, err := rand.Read(id)
+	if err != nil {
+		return nil, false, err
+	}
+
+	s = &spool{
+		id:       hex.EncodeToString(id),
+		ready:    make(chan struct{}),
+		lastUsed: c.clock.Now(),
+	}
+
+	c.spools[handle] = s
+
+	return s, true, nil
+}
+
+// fill spools the artifact of the volume with fill, which must stop once the
+// given context is done. The context outlives the request which spooled the
+// artifact, so that it's spooled for the requests resuming it after the first
+// one was cut off. Spools which failed are not kept.
+func (c *SpoolCache) fill(handle string, s *spool, fill func(context.Context, io.Writer) error) error {
+	file, err := ioutil.TempFile(c.dir, "artifact-")
+	if err != nil {
+		c.finish(handle, s, err)
+		return err
+	}
+
+	s.path = file.Name()
+
+	err = fill(c.ctx, &spoolWriter{cache: c, spool: s, file: file})
+
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	c.finish(handle, s, err)
+
+	return err
+}
+
+func (c *SpoolCache) finish(handle string, s *spool, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil {
+		c.remove(handle, s)
+	}
+
+	s.err = err
+	close(s.ready)
+}
+
+// open opens the spooled contents, or returns false if they have been
+// removed since they were spooled. Files which are open stay readable once
+// they are removed.
+func (c *SpoolCache) open(handle string, s *spool) (*os.File, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.spools[handle] != s {
+		return nil, false, nil
+	}
+
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return file, true, nil
+}
+
+// etag identifies the spooled contents of the artifact. The volume's handle
+// isn't enough on its own: the gzip stream of a volume is not guaranteed to
+// be byte-identical each time it's streamed, so the ranges of one spool can
+// only be resumed from that same spool.
+func (s *spool) etag(handle string) string {
+	return strconv.Quote(handle + "-" + s.id)
+}
+
+// reserve counts n more bytes of the spool against the limit, removing the
+// least recently used spools to make room for them.
+func (c *SpoolCache) reserve(s *spool, n int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.size+n > c.maxBytes {
+		handles := []string{}
+		for handle, other := range c.spools {
+			if other != s && isReady(other) {
+				handles = append(handles, handle)
+			}
+		}
+
+		sort.Slice(handles, func(i, j int) bool {
+			return c.spools[handles[i]].lastUsed.Before(c.spools[handles[j]].lastUsed)
+		})
+
+		for _, handle := range handles {
+			if c.size+n <= c.maxBytes {
+				break
+			}
+
+			c.remove(handle, c.spools[handle])
+		}
+
+		if c.size+n > c.maxBytes {
+			return errSpoolFull
+		}
+	}
+
+	c.size += n
+	s.size += n
+
+	return nil
+}
+
+// expire removes the spools which haven't been used for the TTL.
+func (c *SpoolCache) expire() {
+	for handle, s := range c.spools {
+		if isReady(s) && c.clock.Since(s.lastUsed) > c.ttl {
+			c.remove(handle, s)
+		}
+	}
+}
+
+func (c *SpoolCache) remove(handle string, s *spool) {
+	if s.path != "" {
+		os.Remove(s.path)
+	}
+
+	if c.spools[handle] == s {
+		c.size -= s.size
+		delete(c.spools, handle)
+	}
+}
+
+func isReady(s *spool) bool {
+	select {
+	case <-s.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+type spoolWriter struct {
+	cache *SpoolCache
+	spool *spool
+	file  *os.File
+}
+
+func (w *spoolWriter) Write(p []byte) (int, error) {
+	err := w.cache.reserve(w.spool, int64(len(p)))
+	if err != nil {
+		return 0, err
+	}
+
+	return w.file.Write(p)
+}
@@ -2,16 +2,22 @@ package api_test
 
 import (
 	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/provenance"
 	. "github.com/concourse/concourse/atc/testhelpers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -636,6 +642,121 @@ var _ = Describe("Builds API", func() {
 						"reap_time": 200
 					}`))
 						})
+
+						Context("when the build was aborted", func() {
+							BeforeEach(func() {
+								abortedBy := "some-user"
+								build.StatusReturns(db.BuildStatusAborted)
+								build.AbortedByReturns(&abortedBy)
+								build.AbortReasonReturns("some-reason")
+							})
+
+							It("returns who aborted the build and why", func() {
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(body).To(MatchJSON(`{
+						"id": 1,
+						"name": "1",
+						"status": "aborted",
+						"job_name": "job1",
+						"pipeline_id": 123,
+						"pipeline_name": "pipeline1",
+						"team_name": "some-team",
+						"api_url": "/api/v1/builds/1",
+						"start_time": 1,
+						"end_time": 100,
+						"reap_time": 200,
+						"aborted_by": "some-user",
+						"abort_reason": "some-reason"
+					}`))
+							})
+						})
+
+						Context("when the build is queued", func() {
+							BeforeEach(func() {
+								build.StatusReturns(db.BuildStatusPending)
+								build.QueuePositionReturns(3, true, nil)
+							})
+
+							It("returns its position in the queue", func() {
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(body).To(MatchJSON(`{
+						"id": 1,
+						"name": "1",
+						"status": "pending",
+						"job_name": "job1",
+						"pipeline_id": 123,
+						"pipeline_name": "pipeline1",
+						"team_name": "some-team",
+						"api_url": "/api/v1/builds/1",
+						"start_time": 1,
+						"end_time": 100,
+						"reap_time": 200,
+						"queue_position": 3
+					}`))
+							})
+						})
+
+						Context("when getting the queue position fails", func() {
+							BeforeEach(func() {
+								build.QueuePositionReturns(0, false, errors.New("nope"))
+							})
+
+							It("returns the build without its queue position", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(body).ToNot(ContainSubstring("queue_position"))
+							})
+						})
+
+						Context("when tasks of the build have exited", func() {
+							BeforeEach(func() {
+								build.TaskExitCodesReturns([]db.TaskExitCode{
+									{PlanID: "some-plan", Name: "unit", ExitCode: 137},
+								}, nil)
+							})
+
+							It("returns their exit codes", func() {
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(body).To(MatchJSON(`{
+						"id": 1,
+						"name": "1",
+						"status": "succeeded",
+						"job_name": "job1",
+						"pipeline_id": 123,
+						"pipeline_name": "pipeline1",
+						"team_name": "some-team",
+						"api_url": "/api/v1/builds/1",
+						"start_time": 1,
+						"end_time": 100,
+						"reap_time": 200,
+						"task_exit_codes": [
+							{"plan_id": "some-plan", "name": "unit", "exit_code": 137}
+						]
+					}`))
+							})
+						})
+
+						Context("when getting the task exit codes fails", func() {
+							BeforeEach(func() {
+								build.TaskExitCodesReturns(nil, errors.New("nope"))
+							})
+
+							It("returns the build without them", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(body).ToNot(ContainSubstring("task_exit_codes"))
+							})
+						})
 					})
 				})
 			})
@@ -789,6 +910,68 @@ var _ = Describe("Builds API", func() {
 					})
 				})
 
+				Context("when the versions have metadata or have been garbage collected", func() {
+					BeforeEach(func() {
+						build.ResourcesReturns([]db.BuildInput{
+							{
+								Name:            "input1",
+								Version:         atc.Version{"version": "value1"},
+								Metadata:        db.ResourceConfigMetadataFields{{Name: "commit", Value: "abc"}},
+								ResourceID:      1,
+								FirstOccurrence: true,
+							},
+							{
+								Name:           "input2",
+								Version:        atc.Version{},
+								ResourceID:     2,
+								VersionMissing: true,
+								VersionMD5:     "some-md5",
+							},
+						},
+							[]db.BuildOutput{
+								{
+									Name:           "myresource3",
+									Version:        atc.Version{},
+									VersionMissing: true,
+									VersionMD5:     "other-md5",
+								},
+							}, nil)
+					})
+
+					It("includes the metadata and whether the version is missing", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"inputs": [
+								{
+									"name": "input1",
+									"version": {"version": "value1"},
+									"metadata": [{"name": "commit", "value": "abc"}],
+									"pipeline_id": 42,
+									"first_occurrence": true
+								},
+								{
+									"name": "input2",
+									"version": {},
+									"pipeline_id": 42,
+									"first_occurrence": false,
+									"version_missing": true,
+									"version_md5": "some-md5"
+								}
+							],
+							"outputs": [
+								{
+									"name": "myresource3",
+									"version": {},
+									"version_missing": true,
+									"version_md5": "other-md5"
+								}
+							]
+						}`))
+					})
+				})
+
 				Context("when the build resources error", func() {
 					BeforeEach(func() {
 						build.ResourcesReturns([]db.BuildInput{}, []db.BuildOutput{}, errors.New("where are my feedback?"))
@@ -1021,12 +1204,17 @@ var _ = Describe("Builds API", func() {
 	Describe("PUT /api/v1/builds/:build_id/abort", func() {
 		var (
 			response *http.Response
+			query    string
 		)
 
+		BeforeEach(func() {
+			query = ""
+		})
+
 		JustBeforeEach(func() {
 			var err error
 
-			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/abort", nil)
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/abort"+query, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			response, err = client.Do(req)
@@ -1087,6 +1275,7 @@ var _ = Describe("Builds API", func() {
 				Context("when authorized", func() {
 					BeforeEach(func() {
 						fakeAccess.IsAuthorizedReturns(true)
+						fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
 					})
 
 					Context("when aborting the build fails", func() {
@@ -1107,144 +1296,547 @@ var _ = Describe("Builds API", func() {
 						It("returns 204", func() {
 							Expect(response.StatusCode).To(Equal(http.StatusNoContent))
 						})
+
+						It("records the user aborting the build", func() {
+							Expect(build.MarkAsAbortedCallCount()).To(Equal(1))
+							abortedBy, reason := build.MarkAsAbortedArgsForCall(0)
+							Expect(abortedBy).To(Equal("some-user"))
+							Expect(reason).To(BeEmpty())
+						})
+
+						Context("when a reason is given", func() {
+							BeforeEach(func() {
+								query = "?reason=taking+too+long"
+							})
+
+							It("records the reason", func() {
+								Expect(build.MarkAsAbortedCallCount()).To(Equal(1))
+								_, reason := build.MarkAsAbortedArgsForCall(0)
+								Expect(reason).To(Equal("taking too long"))
+							})
+						})
 					})
 				})
 			})
 		})
 	})
 
-	Describe("GET /api/v1/builds/:build_id/preparation", func() {
-		var response *http.Response
+	Describe("PUT /api/v1/builds/:build_id/protect", func() {
+		var (
+			response *http.Response
+			action   string
+		)
+
+		BeforeEach(func() {
+			action = "protect"
+		})
 
 		JustBeforeEach(func() {
-			var err error
-			response, err = http.Get(server.URL + "/api/v1/builds/42/preparation")
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/"+action, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
-		Context("when the build is found", func() {
-			var buildPrep db.BuildPreparation
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
 
+		Context("when authenticated", func() {
 			BeforeEach(func() {
-				buildPrep = db.BuildPreparation{
-					BuildID:          42,
-					PausedPipeline:   db.BuildPreparationStatusNotBlocking,
-					PausedJob:        db.BuildPreparationStatusNotBlocking,
-					MaxRunningBuilds: db.BuildPreparationStatusBlocking,
-					Inputs: map[string]db.BuildPreparationStatus{
-						"foo": db.BuildPreparationStatusNotBlocking,
-						"bar": db.BuildPreparationStatusBlocking,
-					},
-					InputsSatisfied:     db.BuildPreparationStatusBlocking,
-					MissingInputReasons: db.MissingInputReasons{"some-input": "some-reason"},
-				}
-				dbBuildFactory.BuildReturns(build, true, nil)
-				build.TeamNameReturns("some-team")
-				build.JobIDReturns(42)
-				build.JobNameReturns("job1")
-				build.PipelineIDReturns(42)
-				build.PreparationReturns(buildPrep, true, nil)
+				fakeAccess.IsAuthenticatedReturns(true)
 			})
 
-			Context("when authenticated, but not authorized", func() {
+			Context("when the build can not be found", func() {
 				BeforeEach(func() {
-					fakeAccess.IsAuthenticatedReturns(true)
-					fakeAccess.IsAuthorizedReturns(false)
-					build.PipelineReturns(fakePipeline, true, nil)
+					dbBuildFactory.BuildReturns(nil, false, nil)
 				})
 
-				It("returns 403", func() {
-					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
 				})
 			})
 
-			Context("when not authenticated", func() {
+			Context("when the build is found", func() {
 				BeforeEach(func() {
-					fakeAccess.IsAuthenticatedReturns(false)
+					build.TeamNameReturns("some-team")
+					dbBuildFactory.BuildReturns(build, true, nil)
 				})
 
-				Context("and build is one off", func() {
+				Context("when not authorized", func() {
 					BeforeEach(func() {
-						build.PipelineIDReturns(0)
-					})
-
-					It("returns 401", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+						fakeAccess.IsAuthorizedReturns(false)
 					})
-				})
 
-				Context("and the pipeline is private", func() {
-					BeforeEach(func() {
-						build.PipelineReturns(fakePipeline, true, nil)
-						fakePipeline.PublicReturns(false)
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
 					})
 
-					It("returns 401", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					It("does not protect the build", func() {
+						Expect(build.SetProtectedCallCount()).To(BeZero())
 					})
 				})
 
-				Context("and the pipeline is public", func() {
+				Context("when authorized", func() {
 					BeforeEach(func() {
-						build.PipelineReturns(fakePipeline, true, nil)
-						fakePipeline.PublicReturns(true)
+						fakeAccess.IsAuthorizedReturns(true)
 					})
 
-					Context("when the job is found", func() {
-						var fakeJob *dbfakes.FakeJob
-						BeforeEach(func() {
-							fakeJob = new(dbfakes.FakeJob)
-							fakePipeline.JobReturns(fakeJob, true, nil)
-						})
-
-						Context("when job is private", func() {
-							BeforeEach(func() {
-								fakeJob.PublicReturns(false)
-							})
-
-							It("returns 401", func() {
-								Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
-							})
-						})
-
-						Context("when job is public", func() {
-							BeforeEach(func() {
-								fakeJob.PublicReturns(true)
-							})
+					It("returns 204", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					})
 
-							It("returns 200", func() {
-								Expect(response.StatusCode).To(Equal(http.StatusOK))
-							})
-						})
+					It("protects the build", func() {
+						Expect(build.SetProtectedCallCount()).To(Equal(1))
+						Expect(build.SetProtectedArgsForCall(0)).To(BeTrue())
 					})
 
-					Context("when finding the job fails", func() {
+					Context("when unprotecting", func() {
 						BeforeEach(func() {
-							fakePipeline.JobReturns(nil, false, errors.New("nope"))
+							action = "unprotect"
 						})
 
-						It("returns Internal Server Error", func() {
-							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						It("unprotects the build", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+							Expect(build.SetProtectedCallCount()).To(Equal(1))
+							Expect(build.SetProtectedArgsForCall(0)).To(BeFalse())
 						})
 					})
 
-					Context("when the job cannot be found", func() {
+					Context("when protecting the build fails", func() {
 						BeforeEach(func() {
-							fakePipeline.JobReturns(nil, false, nil)
+							build.SetProtectedReturns(errors.New("nope"))
 						})
 
-						It("returns Not Found", func() {
-							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 						})
 					})
 				})
 			})
+		})
+	})
 
-			Context("when authenticated", func() {
-				BeforeEach(func() {
-					fakeAccess.IsAuthenticatedReturns(true)
-					fakeAccess.IsAuthorizedReturns(true)
-				})
+	Describe("PUT /api/v1/checks/:build_id/cancel", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/checks/128/cancel", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
+
+				build.TeamNameReturns("some-team")
+				build.NameReturns(db.CheckBuildName)
+				build.ResourceIDReturns(1)
+				build.IsRunningReturns(true)
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			It("returns 204", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+			})
+
+			It("aborts the check build", func() {
+				Expect(build.MarkAsAbortedCallCount()).To(Equal(1))
+				abortedBy, reason := build.MarkAsAbortedArgsForCall(0)
+				Expect(abortedBy).To(Equal("some-user"))
+				Expect(reason).To(Equal("check canceled"))
+			})
+
+			Context("when the build is not a check", func() {
+				BeforeEach(func() {
+					build.NameReturns("1")
+					build.ResourceIDReturns(0)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(build.MarkAsAbortedCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the check has already finished", func() {
+				BeforeEach(func() {
+					build.IsRunningReturns(false)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					Expect(build.MarkAsAbortedCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when aborting the build fails", func() {
+				BeforeEach(func() {
+					build.MarkAsAbortedReturns(errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/approvals", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/approvals")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				build.TeamNameReturns("some-team")
+				build.JobIDReturns(42)
+				build.JobNameReturns("job1")
+				build.PipelineIDReturns(42)
+				build.PipelineReturns(fakePipeline, true, nil)
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when authenticated, but not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when getting the approvals succeeds", func() {
+					BeforeEach(func() {
+						build.ApprovalsReturns([]db.BuildApproval{
+							{
+								PlanID:      "some-plan",
+								Name:        "deploy",
+								RequestedAt: time.Unix(100, 0),
+							},
+							{
+								PlanID:      "other-plan",
+								Name:        "release",
+								RequestedAt: time.Unix(100, 0),
+								Decided:     true,
+								Approved:    true,
+								DecidedBy:   "some-user",
+								DecidedAt:   time.Unix(200, 0),
+								Comment:     "ship it",
+							},
+						}, nil)
+					})
+
+					It("returns 200 with the approvals", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{
+								"plan_id": "some-plan",
+								"name": "deploy",
+								"status": "pending",
+								"requested_at": 100
+							},
+							{
+								"plan_id": "other-plan",
+								"name": "release",
+								"status": "approved",
+								"requested_at": 100,
+								"decided_by": "some-user",
+								"decided_at": 200,
+								"comment": "ship it"
+							}
+						]`))
+					})
+				})
+
+				Context("when getting the approvals fails", func() {
+					BeforeEach(func() {
+						build.ApprovalsReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/builds/:build_id/approvals/:plan_id", func() {
+		var (
+			response *http.Response
+			body     string
+		)
+
+		BeforeEach(func() {
+			body = `{"approved":true,"comment":"ship it"}`
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/approvals/some-plan", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				build.TeamNameReturns("some-team")
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(true)
+					fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
+				})
+
+				Context("when the approval is pending", func() {
+					BeforeEach(func() {
+						build.DecideApprovalReturns(true, nil)
+					})
+
+					It("returns 204", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					})
+
+					It("records the decision and who made it", func() {
+						Expect(build.DecideApprovalCallCount()).To(Equal(1))
+						planID, approved, decidedBy, comment := build.DecideApprovalArgsForCall(0)
+						Expect(planID).To(Equal(atc.PlanID("some-plan")))
+						Expect(approved).To(BeTrue())
+						Expect(decidedBy).To(Equal("some-user"))
+						Expect(comment).To(Equal("ship it"))
+					})
+				})
+
+				Context("when the approval is not pending", func() {
+					BeforeEach(func() {
+						build.DecideApprovalReturns(false, nil)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+				})
+
+				Context("when deciding the approval fails", func() {
+					BeforeEach(func() {
+						build.DecideApprovalReturns(false, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the body is malformed", func() {
+					BeforeEach(func() {
+						body = `{`
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(build.DecideApprovalCallCount()).To(Equal(0))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/preparation", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/preparation")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			var buildPrep db.BuildPreparation
+
+			BeforeEach(func() {
+				buildPrep = db.BuildPreparation{
+					BuildID:          42,
+					PausedPipeline:   db.BuildPreparationStatusNotBlocking,
+					PausedJob:        db.BuildPreparationStatusNotBlocking,
+					MaxRunningBuilds: db.BuildPreparationStatusBlocking,
+					Inputs: map[string]db.BuildPreparationStatus{
+						"foo": db.BuildPreparationStatusNotBlocking,
+						"bar": db.BuildPreparationStatusBlocking,
+					},
+					InputsSatisfied:     db.BuildPreparationStatusBlocking,
+					MissingInputReasons: db.MissingInputReasons{"some-input": "some-reason"},
+				}
+				dbBuildFactory.BuildReturns(build, true, nil)
+				build.TeamNameReturns("some-team")
+				build.JobIDReturns(42)
+				build.JobNameReturns("job1")
+				build.PipelineIDReturns(42)
+				build.PreparationReturns(buildPrep, true, nil)
+			})
+
+			Context("when authenticated, but not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(false)
+					build.PipelineReturns(fakePipeline, true, nil)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when not authenticated", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(false)
+				})
+
+				Context("and build is one off", func() {
+					BeforeEach(func() {
+						build.PipelineIDReturns(0)
+					})
+
+					It("returns 401", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
+				})
+
+				Context("and the pipeline is private", func() {
+					BeforeEach(func() {
+						build.PipelineReturns(fakePipeline, true, nil)
+						fakePipeline.PublicReturns(false)
+					})
+
+					It("returns 401", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
+				})
+
+				Context("and the pipeline is public", func() {
+					BeforeEach(func() {
+						build.PipelineReturns(fakePipeline, true, nil)
+						fakePipeline.PublicReturns(true)
+					})
+
+					Context("when the job is found", func() {
+						var fakeJob *dbfakes.FakeJob
+						BeforeEach(func() {
+							fakeJob = new(dbfakes.FakeJob)
+							fakePipeline.JobReturns(fakeJob, true, nil)
+						})
+
+						Context("when job is private", func() {
+							BeforeEach(func() {
+								fakeJob.PublicReturns(false)
+							})
+
+							It("returns 401", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+							})
+						})
+
+						Context("when job is public", func() {
+							BeforeEach(func() {
+								fakeJob.PublicReturns(true)
+							})
+
+							It("returns 200", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+							})
+						})
+					})
+
+					Context("when finding the job fails", func() {
+						BeforeEach(func() {
+							fakePipeline.JobReturns(nil, false, errors.New("nope"))
+						})
+
+						It("returns Internal Server Error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when the job cannot be found", func() {
+						BeforeEach(func() {
+							fakePipeline.JobReturns(nil, false, nil)
+						})
+
+						It("returns Not Found", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						})
+					})
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
+				})
 
 				It("fetches data from the db", func() {
 					Expect(build.PreparationCallCount()).To(Equal(1))
@@ -1265,63 +1857,339 @@ var _ = Describe("Builds API", func() {
 					body, err := ioutil.ReadAll(response.Body)
 					Expect(err).NotTo(HaveOccurred())
 
-					Expect(body).To(MatchJSON(`{
-					"build_id": 42,
-					"paused_pipeline": "not_blocking",
-					"paused_job": "not_blocking",
-					"max_running_builds": "blocking",
-					"inputs": {
-						"foo": "not_blocking",
-						"bar": "blocking"
-					},
-					"inputs_satisfied": "blocking",
-					"missing_input_reasons": {
-						"some-input": "some-reason"
-					}
-				}`))
+					Expect(body).To(MatchJSON(`{
+					"build_id": 42,
+					"paused_pipeline": "not_blocking",
+					"paused_job": "not_blocking",
+					"max_running_builds": "blocking",
+					"inputs": {
+						"foo": "not_blocking",
+						"bar": "blocking"
+					},
+					"inputs_satisfied": "blocking",
+					"missing_input_reasons": {
+						"some-input": "some-reason"
+					}
+				}`))
+				})
+
+				Context("when the build preparation is not found", func() {
+					BeforeEach(func() {
+						dbBuildFactory.BuildReturns(build, true, nil)
+						build.PreparationReturns(db.BuildPreparation{}, false, nil)
+					})
+
+					It("returns Not Found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when looking up the build preparation fails", func() {
+					BeforeEach(func() {
+						dbBuildFactory.BuildReturns(build, true, nil)
+						build.PreparationReturns(db.BuildPreparation{}, false, errors.New("ho ho ho merry festivus"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when looking up the build fails", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, errors.New("ho ho ho merry festivus"))
+			})
+
+			It("returns 500 Internal Server Error", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when build is not found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/server-logs", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/builds/128/server-logs")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the build is found", func() {
+				BeforeEach(func() {
+					build.IDReturns(128)
+					build.TeamNameReturns("some-team")
+					dbBuildFactory.BuildReturns(build, true, nil)
 				})
 
-				Context("when the build preparation is not found", func() {
+				Context("when not authorized", func() {
 					BeforeEach(func() {
-						dbBuildFactory.BuildReturns(build, true, nil)
-						build.PreparationReturns(db.BuildPreparation{}, false, nil)
+						fakeAccess.IsAuthorizedReturns(false)
 					})
 
-					It("returns Not Found", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
 					})
 				})
 
-				Context("when looking up the build preparation fails", func() {
+				Context("when authorized", func() {
 					BeforeEach(func() {
-						dbBuildFactory.BuildReturns(build, true, nil)
-						build.PreparationReturns(db.BuildPreparation{}, false, errors.New("ho ho ho merry festivus"))
+						fakeAccess.IsAuthorizedReturns(true)
+
+						dbBuildServerLogFactory.LogsForBuildReturns([]lager.LogFormat{
+							{
+								Timestamp: "2021-01-01T00:00:00.000000000Z",
+								Source:    "atc",
+								Message:   "atc.some-message",
+								LogLevel:  lager.INFO,
+								Data:      lager.Data{"build_id": float64(128), "some": "data"},
+							},
+						}, nil)
 					})
 
-					It("returns 500 Internal Server Error", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns Content-Type 'application/json'", func() {
+						expectedHeaderEntries := map[string]string{
+							"Content-Type": "application/json",
+						}
+						Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
+					})
+
+					It("returns the server logs for the build", func() {
+						Expect(dbBuildServerLogFactory.LogsForBuildCallCount()).To(Equal(1))
+						Expect(dbBuildServerLogFactory.LogsForBuildArgsForCall(0)).To(Equal(128))
+
+						var logs []atc.ServerLog
+						err := json.NewDecoder(response.Body).Decode(&logs)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(logs).To(HaveLen(1))
+						Expect(logs[0].Source).To(Equal("atc"))
+						Expect(logs[0].Message).To(Equal("atc.some-message"))
+						Expect(logs[0].LogLevel).To(Equal(atc.LogLevelInfo))
+						Expect(logs[0].Data).To(Equal(map[string]interface{}{
+							"build_id": float64(128),
+							"some":     "data",
+						}))
+					})
+
+					Context("when getting the server logs fails", func() {
+						BeforeEach(func() {
+							dbBuildServerLogFactory.LogsForBuildReturns(nil, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
 					})
 				})
 			})
 		})
+	})
 
-		Context("when looking up the build fails", func() {
+	Describe("GET /api/v1/builds/:build_id/volumes", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/builds/128/volumes")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
 			BeforeEach(func() {
-				dbBuildFactory.BuildReturns(nil, false, errors.New("ho ho ho merry festivus"))
+				fakeAccess.IsAuthenticatedReturns(false)
+				dbBuildFactory.BuildReturns(build, true, nil)
 			})
 
-			It("returns 500 Internal Server Error", func() {
-				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			Context("and the build is one off", func() {
+				BeforeEach(func() {
+					build.PipelineIDReturns(0)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
 			})
 		})
 
-		Context("when build is not found", func() {
+		Context("when authenticated", func() {
 			BeforeEach(func() {
-				dbBuildFactory.BuildReturns(nil, false, nil)
+				fakeAccess.IsAuthenticatedReturns(true)
 			})
 
-			It("returns 404", func() {
-				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the build is found", func() {
+				BeforeEach(func() {
+					build.IDReturns(128)
+					build.TeamNameReturns("some-team")
+					dbBuildFactory.BuildReturns(build, true, nil)
+				})
+
+				Context("when not authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(false)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+
+				Context("when authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(true)
+
+						fakeContainer := new(dbfakes.FakeCreatedContainer)
+						fakeContainer.HandleReturns("some-container")
+						fakeContainer.WorkerNameReturns("some-worker")
+						fakeContainer.StateReturns(atc.ContainerStateCreated)
+						fakeContainer.MetadataReturns(db.ContainerMetadata{
+							Type:     db.ContainerTypeTask,
+							StepName: "some-task",
+							BuildID:  128,
+						})
+						build.ContainersReturns([]db.Container{fakeContainer}, nil)
+
+						size := uint64(1024)
+						build.VolumesReturns([]db.BuildVolume{
+							{
+								Handle:     "some-cache",
+								WorkerName: "some-worker",
+								State:      db.VolumeStateCreated,
+								Type:       db.VolumeTypeResource,
+								Inherited:  true,
+							},
+							{
+								Handle:          "some-input",
+								WorkerName:      "some-worker",
+								State:           db.VolumeStateCreated,
+								Type:            db.VolumeTypeContainer,
+								ContainerHandle: "some-container",
+								ParentHandle:    "some-cache",
+								Path:            "/tmp/build/get",
+								Size:            &size,
+							},
+						}, nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns Content-Type 'application/json'", func() {
+						expectedHeaderEntries := map[string]string{
+							"Content-Type": "application/json",
+						}
+						Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
+					})
+
+					It("returns the containers and volumes of the build", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"containers": [
+								{
+									"id": "some-container",
+									"worker_name": "some-worker",
+									"state": "created",
+									"type": "task",
+									"step_name": "some-task",
+									"build_id": 128
+								}
+							],
+							"volumes": [
+								{
+									"id": "some-cache",
+									"worker_name": "some-worker",
+									"state": "created",
+									"type": "resource",
+									"inherited": true
+								},
+								{
+									"id": "some-input",
+									"worker_name": "some-worker",
+									"state": "created",
+									"type": "container",
+									"container_handle": "some-container",
+									"parent_handle": "some-cache",
+									"path": "/tmp/build/get",
+									"size_bytes": 1024
+								}
+							]
+						}`))
+					})
+
+					Context("when finding the volumes fails", func() {
+						BeforeEach(func() {
+							build.VolumesReturns(nil, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when finding the containers fails", func() {
+						BeforeEach(func() {
+							build.ContainersReturns(nil, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
 			})
 		})
 	})
@@ -1530,4 +2398,156 @@ var _ = Describe("Builds API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/builds/:build_id/provenance", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/provenance")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				build.IDReturns(42)
+				build.NameReturns("7")
+				build.TeamNameReturns("some-team")
+				build.PipelineIDReturns(42)
+				build.PipelineNameReturns("some-pipeline")
+				build.JobIDReturns(42)
+				build.JobNameReturns("some-job")
+				build.PipelineReturns(fakePipeline, true, nil)
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when not authenticated and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(false)
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when the build is running", func() {
+					BeforeEach(func() {
+						build.IsRunningReturns(true)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(Equal("build has not finished"))
+					})
+				})
+
+				Context("when the build has finished", func() {
+					BeforeEach(func() {
+						build.IsRunningReturns(false)
+						build.ResourcesReturns(nil, []db.BuildOutput{
+							{
+								Name:    "some-image",
+								Version: atc.Version{"digest": "sha256:" + strings.Repeat("ab", 32)},
+							},
+						}, nil)
+
+						events := new(dbfakes.FakeEventSource)
+						events.NextReturns(event.Envelope{}, db.ErrEndOfBuildEventStream)
+						build.EventsReturns(events, nil)
+					})
+
+					It("returns 200 with the signed provenance", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response).To(IncludeHeaderEntries(map[string]string{
+							"Content-Type": "application/json",
+						}))
+
+						var envelope provenance.Envelope
+						err := json.NewDecoder(response.Body).Decode(&envelope)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(envelope.PayloadType).To(Equal(provenance.PayloadType))
+						Expect(envelope.Signatures).To(HaveLen(1))
+
+						payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+						Expect(err).NotTo(HaveOccurred())
+
+						var statement provenance.Statement
+						err = json.Unmarshal(payload, &statement)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(statement.Subject).To(Equal([]provenance.Subject{
+							{
+								Name:   "some-image",
+								Digest: provenance.DigestSet{"sha256": strings.Repeat("ab", 32)},
+							},
+						}))
+						Expect(statement.Predicate.Metadata.BuildInvocationID).To(Equal("https://example.com/builds/42"))
+					})
+
+					Context("when the build's events have been archived", func() {
+						BeforeEach(func() {
+							build.EventsArchiveKeyReturns("builds/42/events.json.gz")
+
+							data := json.RawMessage(`{"origin":{"id":"some-step"},"payload":"hello"}`)
+
+							archived := new(bytes.Buffer)
+							gz := gzip.NewWriter(archived)
+							Expect(json.NewEncoder(gz).Encode(event.Envelope{
+								Data:    &data,
+								Event:   event.EventTypeLog,
+								Version: "5.1",
+								EventID: "0",
+							})).To(Succeed())
+							Expect(gz.Close()).To(Succeed())
+
+							fakeEventStore.GetReturns(ioutil.NopCloser(archived), nil)
+						})
+
+						It("reads the events from the archive", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+							Expect(fakeEventStore.GetCallCount()).To(Equal(1))
+							_, key := fakeEventStore.GetArgsForCall(0)
+							Expect(key).To(Equal("builds/42/events.json.gz"))
+
+							Expect(build.EventsCallCount()).To(BeZero())
+						})
+					})
+				})
+
+				Context("when reading the build's resources fails", func() {
+					BeforeEach(func() {
+						build.IsRunningReturns(false)
+						build.ResourcesReturns(nil, nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when the build is not found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, nil)
+			})
+
+			It("returns Not Found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
 })
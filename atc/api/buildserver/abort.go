@@ -3,6 +3,7 @@ package buildserver
 import (
 	"net/http"
 
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -10,7 +11,9 @@ func (s *Server) AbortBuild(build db.Build) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		aLog := s.logger.Session("abort", build.LagerData())
 
-		err := build.MarkAsAborted()
+		acc := accessor.GetAccessor(r)
+
+		err := build.MarkAsAborted(acc.UserInfo().DisplayUserId, r.URL.Query().Get("reason"))
 		if err != nil {
 			aLog.Error("failed-to-abort-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,71 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListBuildApprovals(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-build-approvals", build.LagerData())
+
+		approvals, err := build.Approvals()
+		if err != nil {
+			logger.Error("failed-to-get-build-approvals", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(present.BuildApprovals(approvals))
+		if err != nil {
+			logger.Error("failed-to-encode-build-approvals", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) DecideBuildApproval(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("decide-build-approval", build.LagerData())
+
+		planID := atc.PlanID(r.FormValue(":plan_id"))
+
+		var decision atc.BuildApprovalDecision
+		err := json.NewDecoder(r.Body).Decode(&decision)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		acc := accessor.GetAccessor(r)
+
+		decided, err := build.DecideApproval(planID, decision.Approved, acc.UserInfo().DisplayUserId, decision.Comment)
+		if err != nil {
+			logger.Error("failed-to-decide-build-approval", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !decided {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte("build is not waiting for this approval"))
+			return
+		}
+
+		logger.Info("decided", lager.Data{
+			"plan-id":  planID,
+			"approved": decision.Approved,
+		})
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,39 @@
+package buildserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// CancelCheck aborts a check build of a resource or resource type. The check
+// step notices the abort and ends the check without it counting as a failure.
+func (s *Server) CancelCheck(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("cancel-check", build.LagerData())
+
+		if build.Name() != db.CheckBuildName || (build.ResourceID() == 0 && build.ResourceTypeID() == 0) {
+			logger.Info("not-a-check")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if !build.IsRunning() {
+			logger.Info("check-already-finished")
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		acc := accessor.GetAccessor(r)
+
+		err := build.MarkAsAborted(acc.UserInfo().DisplayUserId, "check canceled")
+		if err != nil {
+			logger.Error("failed-to-cancel-check", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -27,6 +27,16 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 			}
 
 			eventID++
+		} else if r.URL.Query().Get("from") != "" {
+			// lets clients which can't set the header, or which keep track of
+			// events themselves, replay the stream starting at the given event
+			fromString := r.URL.Query().Get("from")
+			_, err := fmt.Sscanf(fromString, "%d", &eventID)
+			if err != nil {
+				logger.Info("failed-to-parse-from", lager.Data{"from": fromString})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
 		}
 
 		w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
@@ -179,6 +179,64 @@ var _ = Describe("Handler", func() {
 					Expect(actualFrom).To(Equal(uint(2)))
 				})
 			})
+
+			Context("when the from query param is given", func() {
+				BeforeEach(func() {
+					request.URL.RawQuery = "from=2"
+				})
+
+				It("starts subscribing from the id", func() {
+					_ = response.Body.Close()
+					Eventually(build.EventsCallCount).Should(Equal(1))
+					actualFrom := build.EventsArgsForCall(0)
+					Expect(actualFrom).To(Equal(uint(2)))
+				})
+
+				It("only replays the events from the id", func() {
+					defer db.Close(response.Body)
+					reader := sse.NewReadCloser(response.Body)
+
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "2",
+						Name: "event",
+						Data: []byte(`{"data":{"event":3},"event":"fake","version":"42.0","event_id":"3"}`),
+					}))
+
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "3",
+						Name: "end",
+						Data: []byte{},
+					}))
+				})
+
+				Context("when the Last-Event-ID header is also given", func() {
+					BeforeEach(func() {
+						request.Header.Set("Last-Event-ID", "0")
+					})
+
+					It("prefers the header", func() {
+						_ = response.Body.Close()
+						Eventually(build.EventsCallCount).Should(Equal(1))
+						actualFrom := build.EventsArgsForCall(0)
+						Expect(actualFrom).To(Equal(uint(1)))
+					})
+				})
+			})
+		})
+
+		Context("when the from query param is invalid", func() {
+			BeforeEach(func() {
+				request.URL.RawQuery = "from=bogus"
+			})
+
+			It("returns 400 without subscribing to the build", func() {
+				response, err := http.DefaultClient.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+				_ = response.Body.Close()
+
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(build.EventsCallCount()).To(BeZero())
+			})
 		})
 
 		Context("when the eventsource returns an error", func() {
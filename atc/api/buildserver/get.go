@@ -12,10 +12,28 @@ func (s *Server) GetBuild(build db.Build) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		logger := s.logger.Session("get-build")
 
+		presentedBuild := present.Build(build)
+
+		// the queue position is left out rather than failing the request, as
+		// the build can be shown without it
+		position, queued, err := build.QueuePosition()
+		if err != nil {
+			logger.Error("failed-to-get-queue-position", err)
+		} else if queued {
+			presentedBuild.QueuePosition = position
+		}
+
+		exitCodes, err := build.TaskExitCodes()
+		if err != nil {
+			logger.Error("failed-to-get-task-exit-codes", err)
+		} else if len(exitCodes) > 0 {
+			presentedBuild.TaskExitCodes = present.TaskExitCodes(exitCodes)
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 
-		err := json.NewEncoder(w).Encode(present.Build(build))
+		err = json.NewEncoder(w).Encode(presentedBuild)
 		if err != nil {
 			logger.Error("failed-to-encode-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
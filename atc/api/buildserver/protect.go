@@ -0,0 +1,30 @@
+package buildserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ProtectBuild(build db.Build) http.Handler {
+	return s.setProtected(build, true)
+}
+
+func (s *Server) UnprotectBuild(build db.Build) http.Handler {
+	return s.setProtected(build, false)
+}
+
+func (s *Server) setProtected(build db.Build, protected bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("set-protected", build.LagerData())
+
+		err := build.SetProtected(protected)
+		if err != nil {
+			logger.Error("failed-to-set-protected", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,58 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/eventarchive"
+	"github.com/concourse/concourse/atc/provenance"
+)
+
+func (s *Server) GetBuildProvenance(build db.Build) http.Handler {
+	hLog := s.logger.Session("get-build-provenance")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if build.IsRunning() {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "build has not finished")
+			return
+		}
+
+		// the events of archived builds have to be read from the archive for
+		// the steps and images of the build to be found
+		if s.eventStore != nil {
+			build = eventarchive.WithArchivedEvents(build, s.eventStore)
+		}
+
+		statement, err := s.provenanceCache.Generate(build, s.externalURL)
+		if err != nil {
+			hLog.Error("failed-to-generate-provenance", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		payload, err := json.Marshal(statement)
+		if err != nil {
+			hLog.Error("failed-to-encode-provenance", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		envelope, err := s.provenanceSigner.Sign(provenance.PayloadType, payload)
+		if err != nil {
+			hLog.Error("failed-to-sign-provenance", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(envelope)
+		if err != nil {
+			hLog.Error("failed-to-encode-envelope", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+}
@@ -6,8 +6,13 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/eventarchive"
+	"github.com/concourse/concourse/atc/provenance"
 )
 
+// provenanceCacheSize is how many builds the provenance of is cached.
+const provenanceCacheSize = 100
+
 type EventHandlerFactory func(lager.Logger, db.Build) http.Handler
 
 type Server struct {
@@ -18,6 +23,10 @@ type Server struct {
 	teamFactory         db.TeamFactory
 	buildFactory        db.BuildFactory
 	eventHandlerFactory EventHandlerFactory
+	eventStore          eventarchive.Store
+	serverLogFactory    db.BuildServerLogFactory
+	provenanceSigner    *provenance.Signer
+	provenanceCache     *provenance.Cache
 	rejector            auth.Rejector
 }
 
@@ -27,6 +36,9 @@ func NewServer(
 	teamFactory db.TeamFactory,
 	buildFactory db.BuildFactory,
 	eventHandlerFactory EventHandlerFactory,
+	eventStore eventarchive.Store,
+	serverLogFactory db.BuildServerLogFactory,
+	provenanceSigner *provenance.Signer,
 ) *Server {
 	return &Server{
 		logger: logger,
@@ -36,6 +48,10 @@ func NewServer(
 		teamFactory:         teamFactory,
 		buildFactory:        buildFactory,
 		eventHandlerFactory: eventHandlerFactory,
+		eventStore:          eventStore,
+		serverLogFactory:    serverLogFactory,
+		provenanceSigner:    provenanceSigner,
+		provenanceCache:     provenance.NewCache(provenanceCacheSize),
 
 		rejector: auth.UnauthorizedRejector{},
 	}
@@ -0,0 +1,34 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// GetBuildServerLogs returns the log lines pertaining to the build saved by
+// every ATC. Each ATC saves its lines every second or so, so the most recent
+// ones may not be returned yet. Lines are only saved by ATCs configured with
+// --build-server-log-lines, and never for check builds.
+func (s *Server) GetBuildServerLogs(build db.Build) http.Handler {
+	hLog := s.logger.Session("get-build-server-logs")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logs, err := s.serverLogFactory.LogsForBuild(build.ID())
+		if err != nil {
+			hLog.Error("failed-to-get-server-logs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(present.ServerLogs(logs))
+		if err != nil {
+			hLog.Error("failed-to-encode-server-logs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+}
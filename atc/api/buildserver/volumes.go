@@ -0,0 +1,37 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListBuildVolumes(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-build-volumes")
+
+		containers, err := build.Containers()
+		if err != nil {
+			logger.Error("failed-to-find-build-containers", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		volumes, err := build.Volumes()
+		if err != nil {
+			logger.Error("failed-to-find-build-volumes", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(present.BuildVolumes(containers, volumes))
+		if err != nil {
+			logger.Error("failed-to-encode-build-volumes", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
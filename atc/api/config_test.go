@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/concourse/atc/creds/noop"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/policy"
 	. "github.com/concourse/concourse/atc/testhelpers"
 	"github.com/onsi/gomega/gbytes"
 	"github.com/tedsuo/rata"
@@ -549,6 +550,37 @@ var _ = Describe("Config API", func() {
 							})
 						})
 
+						Context("when a resource type overrides one of the team's", func() {
+							BeforeEach(func() {
+								dbTeam.ResourceTypesReturns(atc.ResourceTypes{
+									{Name: "custom-resource", Type: "registry-image"},
+								}, nil)
+							})
+
+							It("saves it with a warning", func() {
+								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+
+								var saveResponse atc.SaveConfigResponse
+								err := json.NewDecoder(response.Body).Decode(&saveResponse)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(saveResponse.Warnings).To(ContainElement(atc.ConfigWarning{
+									Type:    "resource_type",
+									Message: "resource_types.custom-resource overrides the team's resource type of the same name",
+								}))
+							})
+						})
+
+						Context("when getting the team's resource types fails", func() {
+							BeforeEach(func() {
+								dbTeam.ResourceTypesReturns(nil, errors.New("oh no!"))
+							})
+
+							It("returns 500 without saving", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+								Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+							})
+						})
+
 						Context("when the config is invalid", func() {
 							BeforeEach(func() {
 								pipelineConfig.Groups[0].Resources = []string{"missing-resource"}
@@ -1319,4 +1351,570 @@ jobs:
 			})
 		})
 	})
+
+	Describe("PATCH /api/v1/teams/:team_name/pipelines/:name/config", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+
+			fakePipeline *dbfakes.FakePipeline
+		)
+
+		BeforeEach(func() {
+			var err error
+			request, err = requestGenerator.CreateRequest(atc.PatchConfig, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header.Set("Content-Type", atc.ConfigPatchContentType)
+			request.Header.Set(atc.ConfigVersionHeader, "42")
+			request.Body = gbytes.BufferWithBytes([]byte(`[
+				{"op": "test", "path": "/resources/0/name", "value": "some-resource"},
+				{"op": "replace", "path": "/resources/0/source/source-config", "value": "some-other-value"}
+			]`))
+
+			fakePipeline = new(dbfakes.FakePipeline)
+			fakePipeline.ConfigVersionReturns(42)
+			fakePipeline.ConfigReturns(pipelineConfig, nil)
+			dbTeam.PipelineReturns(fakePipeline, true, nil)
+
+			savedPipeline := new(dbfakes.FakePipeline)
+			savedPipeline.ConfigVersionReturns(43)
+			dbTeam.SavePipelineReturns(savedPipeline, false, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns 200 with the new config version", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("43"))
+			})
+
+			It("saves the patched config from the given version", func() {
+				Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+
+				ref, savedConfig, version, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+				Expect(ref).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
+				Expect(version).To(Equal(db.ConfigVersion(42)))
+				Expect(initiallyPaused).To(BeFalse())
+
+				expectedConfig := pipelineConfig
+				expectedConfig.Resources = atc.ResourceConfigs{
+					{
+						Name: "some-resource",
+						Type: "some-type",
+						Source: atc.Source{
+							"source-config": "some-other-value",
+						},
+					},
+				}
+				Expect(savedConfig).To(Equal(expectedConfig))
+			})
+
+			It("notifies the resource scanner", func() {
+				Expect(dbTeamFactory.NotifyResourceScannerCallCount()).To(Equal(1))
+			})
+
+			It("checks the policy against the patched config", func() {
+				Expect(fakePolicyChecker.CheckDataCallCount()).To(Equal(1))
+
+				action, _, _, data := fakePolicyChecker.CheckDataArgsForCall(0)
+				Expect(action).To(Equal(atc.PatchConfig))
+
+				resources := data.(map[string]interface{})["resources"].([]interface{})
+				Expect(resources[0].(map[string]interface{})["source"]).To(Equal(map[string]interface{}{
+					"source-config": "some-other-value",
+				}))
+			})
+
+			Context("when the patched config does not pass the policy check", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckDataReturns(policy.PolicyCheckOutput{
+						Allowed: false,
+						Reasons: []string{"no other values"},
+					}, nil)
+				})
+
+				It("returns 403 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("no other values"))
+					Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the policy check errors", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckDataReturns(policy.FailedPolicyCheck(), errors.New("disaster"))
+				})
+
+				It("returns 400 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when instance vars are given and pipeline instances are disabled", func() {
+				BeforeEach(func() {
+					atc.EnablePipelineInstances = false
+					request.URL.RawQuery = "vars.branch=%22master%22"
+				})
+
+				AfterEach(func() {
+					atc.EnablePipelineInstances = true
+				})
+
+				It("returns 400 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+						"errors": ["support for ` + "`instance vars`" + ` is disabled"]
+					}`))
+					Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the pipeline has been updated since the given version", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigVersionReturns(43)
+				})
+
+				It("returns 409 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the pipeline is updated while the patch is being saved", func() {
+				BeforeEach(func() {
+					dbTeam.SavePipelineReturns(nil, false, db.ErrConfigComparisonFailed)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+						"errors": ["pipeline config has been updated since the given version"]
+					}`))
+				})
+			})
+
+			Context("when no config version is given", func() {
+				BeforeEach(func() {
+					request.Header.Del(atc.ConfigVersionHeader)
+				})
+
+				It("returns 428 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusPreconditionRequired))
+					Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the patch is malformed", func() {
+				BeforeEach(func() {
+					request.Body = gbytes.BufferWithBytes([]byte(`{"op": "replace"}`))
+				})
+
+				It("returns 400 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the patch does not apply", func() {
+				BeforeEach(func() {
+					request.Body = gbytes.BufferWithBytes([]byte(`[
+						{"op": "test", "path": "/resources/0/name", "value": "other-resource"},
+						{"op": "remove", "path": "/resources/0"}
+					]`))
+				})
+
+				It("returns 400 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the patched config is invalid", func() {
+				BeforeEach(func() {
+					request.Body = gbytes.BufferWithBytes([]byte(`[
+						{"op": "remove", "path": "/resources/0"}
+					]`))
+				})
+
+				It("returns the validation errors without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					var saveConfigResponse atc.SaveConfigResponse
+					err := json.NewDecoder(response.Body).Decode(&saveConfigResponse)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(saveConfigResponse.Errors).ToNot(BeEmpty())
+
+					Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the content type is not a patch", func() {
+				BeforeEach(func() {
+					request.Header.Set("Content-Type", "application/x-yaml")
+				})
+
+				It("returns 415", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnsupportedMediaType))
+				})
+			})
+
+			Context("when the pipeline does not exist", func() {
+				BeforeEach(func() {
+					dbTeam.PipelineReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:name/resource-type-closure", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			pipelineConfig.ResourceTypes = atc.ResourceTypes{
+				{
+					Name:   "some-type",
+					Type:   "registry-image",
+					Source: atc.Source{"repository": "some/type"},
+				},
+				{
+					Name:   "cycle-a",
+					Type:   "cycle-b",
+					Source: atc.Source{"repository": "some/cycle-a"},
+				},
+				{
+					Name:   "cycle-b",
+					Type:   "cycle-a",
+					Source: atc.Source{"repository": "some/cycle-b"},
+				},
+			}
+
+			payload, err := json.Marshal(pipelineConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			request, err = requestGenerator.CreateRequest(atc.GetResourceTypeClosure, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, bytes.NewBuffer(payload))
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header.Set("Content-Type", "application/json")
+
+			dbTeam.PipelineReturns(nil, false, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				someWorker := new(dbfakes.FakeWorker)
+				someWorker.ResourceTypesReturns([]atc.WorkerResourceType{
+					{Type: "registry-image", Version: "1.0.0"},
+					{Type: "git", Version: "2.0.0"},
+				})
+
+				otherWorker := new(dbfakes.FakeWorker)
+				otherWorker.ResourceTypesReturns([]atc.WorkerResourceType{
+					{Type: "registry-image", Version: "1.1.0"},
+					{Type: "git", Version: "2.0.0"},
+				})
+
+				dbWorkerFactory.VisibleWorkersReturns([]db.Worker{someWorker, otherWorker}, nil)
+			})
+
+			It("returns 200 with the types the pipeline is built on and its cycles", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+					"types": [
+						{
+							"name": "some-type",
+							"type": "registry-image",
+							"source": {"repository": "some/type"},
+							"version_unavailable": "no check of the type with this source has found a version; sources with vars from a credential manager never match"
+						},
+						{
+							"name": "registry-image",
+							"base": true,
+							"worker_versions": ["1.0.0", "1.1.0"]
+						},
+						{
+							"name": "cycle-a",
+							"type": "cycle-b",
+							"source": {"repository": "some/cycle-a"},
+							"version_unavailable": "the version of cycle-b, which the type is built on, is unknown"
+						},
+						{
+							"name": "cycle-b",
+							"type": "cycle-a",
+							"source": {"repository": "some/cycle-b"},
+							"version_unavailable": "the version of cycle-a, which the type is built on, is unknown"
+						}
+					],
+					"cycles": [["cycle-a", "cycle-b", "cycle-a"]]
+				}`))
+			})
+
+			It("looks up the workers visible to the team", func() {
+				Expect(dbWorkerFactory.VisibleWorkersCallCount()).To(Equal(1))
+				Expect(dbWorkerFactory.VisibleWorkersArgsForCall(0)).To(Equal([]string{"a-team"}))
+			})
+
+			It("does not save the pipeline", func() {
+				Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+			})
+
+			Context("when the team has resource types", func() {
+				BeforeEach(func() {
+					dbTeam.ResourceTypesReturns(atc.ResourceTypes{
+						{
+							Name:   "some-type",
+							Type:   "git",
+							Source: atc.Source{"uri": "shadowed"},
+						},
+						{
+							Name:   "team-type",
+							Type:   "git",
+							Source: atc.Source{"uri": "team"},
+						},
+					}, nil)
+
+					pipelineConfig.ResourceTypes = atc.ResourceTypes{
+						{
+							Name:   "some-type",
+							Type:   "team-type",
+							Source: atc.Source{"repository": "some/type"},
+						},
+					}
+
+					payload, err := json.Marshal(pipelineConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					request.Body = ioutil.NopCloser(bytes.NewBuffer(payload))
+					request.ContentLength = int64(len(payload))
+				})
+
+				It("follows the chain through the types the pipeline does not shadow", func() {
+					var closure atc.ResourceTypeClosure
+					Expect(json.NewDecoder(response.Body).Decode(&closure)).To(Succeed())
+					Expect(closure.Types).To(Equal([]atc.ResourceTypeDependency{
+						{
+							Name:               "some-type",
+							Type:               "team-type",
+							Source:             atc.Source{"repository": "some/type"},
+							VersionUnavailable: "the version of team-type, which the type is built on, is unknown",
+						},
+						{
+							Name:               "team-type",
+							Type:               "git",
+							Source:             atc.Source{"uri": "team"},
+							VersionUnavailable: "no check of the type with this source has found a version; sources with vars from a credential manager never match",
+						},
+						{
+							Name:           "git",
+							Base:           true,
+							WorkerVersions: []string{"2.0.0"},
+						},
+					}))
+					Expect(closure.Cycles).To(BeEmpty())
+				})
+			})
+
+			Context("when the pipeline exists", func() {
+				BeforeEach(func() {
+					checkedType := new(dbfakes.FakeResourceType)
+					checkedType.NameReturns("some-type")
+					checkedType.TypeReturns("registry-image")
+					checkedType.SourceReturns(atc.Source{"repository": "some/type"})
+					checkedType.VersionReturns(atc.Version{"digest": "sha256:some-digest"})
+
+					changedType := new(dbfakes.FakeResourceType)
+					changedType.NameReturns("cycle-a")
+					changedType.TypeReturns("cycle-b")
+					changedType.SourceReturns(atc.Source{"repository": "some/old-cycle-a"})
+					changedType.VersionReturns(atc.Version{"digest": "sha256:old-digest"})
+
+					fakePipeline := new(dbfakes.FakePipeline)
+					fakePipeline.ResourceTypesReturns(db.ResourceTypes{checkedType, changedType}, nil)
+					dbTeam.PipelineReturns(fakePipeline, true, nil)
+				})
+
+				It("resolves the versions of the types the pipeline defines the same way", func() {
+					var closure atc.ResourceTypeClosure
+					Expect(json.NewDecoder(response.Body).Decode(&closure)).To(Succeed())
+					Expect(closure.Types[0].Name).To(Equal("some-type"))
+					Expect(closure.Types[0].Version).To(Equal(atc.Version{"digest": "sha256:some-digest"}))
+					Expect(closure.Types[2].Name).To(Equal("cycle-a"))
+					Expect(closure.Types[2].Version).To(BeNil())
+				})
+			})
+
+			Context("when the shared resource config of a type has been checked", func() {
+				BeforeEach(func() {
+					pipelineConfig.ResourceTypes = atc.ResourceTypes{
+						{
+							Name:   "some-type",
+							Type:   "registry-image",
+							Source: atc.Source{"repository": "some/type"},
+						},
+						{
+							Name:   "nested-type",
+							Type:   "some-type",
+							Source: atc.Source{"repository": "some/nested-type"},
+						},
+					}
+
+					payload, err := json.Marshal(pipelineConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					request.Body = ioutil.NopCloser(bytes.NewBuffer(payload))
+					request.ContentLength = int64(len(payload))
+
+					dbResourceConfigFactory.FindCheckedVersionStub = func(resourceType string, source atc.Source, _ atc.VersionedResourceTypes) (atc.Version, bool, error) {
+						if resourceType == "registry-image" {
+							return atc.Version{"digest": "sha256:shared-digest"}, true, nil
+						}
+
+						return nil, false, nil
+					}
+				})
+
+				It("resolves the version from it", func() {
+					var closure atc.ResourceTypeClosure
+					Expect(json.NewDecoder(response.Body).Decode(&closure)).To(Succeed())
+					Expect(closure.Types[0].Name).To(Equal("some-type"))
+					Expect(closure.Types[0].Version).To(Equal(atc.Version{"digest": "sha256:shared-digest"}))
+					Expect(closure.Types[0].VersionUnavailable).To(BeEmpty())
+
+					nested := closure.Types[len(closure.Types)-1]
+					Expect(nested.Name).To(Equal("nested-type"))
+					Expect(nested.Version).To(BeNil())
+					Expect(nested.VersionUnavailable).To(Equal("no check of the type with this source has found a version; sources with vars from a credential manager never match"))
+				})
+
+				It("looks up a nested type with the versions of the types it is built on", func() {
+					Expect(dbResourceConfigFactory.FindCheckedVersionCallCount()).To(Equal(2))
+
+					resourceType, source, resourceTypes := dbResourceConfigFactory.FindCheckedVersionArgsForCall(1)
+					Expect(resourceType).To(Equal("some-type"))
+					Expect(source).To(Equal(atc.Source{"repository": "some/nested-type"}))
+					Expect(resourceTypes).To(Equal(atc.VersionedResourceTypes{
+						{
+							ResourceType: pipelineConfig.ResourceTypes[0],
+							Version:      atc.Version{"digest": "sha256:shared-digest"},
+						},
+					}))
+				})
+			})
+
+			Context("when finding a shared version fails", func() {
+				BeforeEach(func() {
+					dbResourceConfigFactory.FindCheckedVersionReturns(nil, false, errors.New("disaster"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when finding the pipeline fails", func() {
+				BeforeEach(func() {
+					dbTeam.PipelineReturns(nil, false, errors.New("disaster"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when getting the workers fails", func() {
+				BeforeEach(func() {
+					dbWorkerFactory.VisibleWorkersReturns(nil, errors.New("disaster"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the config is malformed", func() {
+				BeforeEach(func() {
+					request.Body = gbytes.BufferWithBytes([]byte(`{"resource_types": "nope"}`))
+					request.ContentLength = -1
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the content type is not supported", func() {
+				BeforeEach(func() {
+					request.Header.Set("Content-Type", "application/x-toml")
+				})
+
+				It("returns 415", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnsupportedMediaType))
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
 })
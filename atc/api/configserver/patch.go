@@ -0,0 +1,215 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/configvalidate"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/policy"
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/tedsuo/rata"
+)
+
+// PatchConfig applies a JSON Patch (RFC 6902) to the config of an existing
+// pipeline. The patch is applied to the config at the version given in the
+// config version header; if the pipeline has been updated since, nothing is
+// saved and 409 is returned.
+func (s *Server) PatchConfig(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("patch-config")
+
+	configVersionStr := r.Header.Get(atc.ConfigVersionHeader)
+	if len(configVersionStr) == 0 {
+		w.WriteHeader(http.StatusPreconditionRequired)
+		fmt.Fprintf(w, "%s header is required", atc.ConfigVersionHeader)
+		return
+	}
+
+	var version db.ConfigVersion
+	_, err := fmt.Sscanf(configVersionStr, "%d", &version)
+	if err != nil {
+		session.Error("malformed-config-version", err)
+		s.handleBadRequest(w, fmt.Sprintf("config version is malformed: %s", err))
+		return
+	}
+
+	switch r.Header.Get("Content-Type") {
+	case atc.ConfigPatchContentType, "application/json":
+	default:
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		s.handleBadRequest(w, fmt.Sprintf("read failed: %s", err))
+		return
+	}
+
+	patch, err := jsonpatch.DecodePatch(body)
+	if err != nil {
+		session.Info("malformed-patch", lager.Data{"error": err.Error()})
+		s.handleBadRequest(w, fmt.Sprintf("malformed patch: %s", err))
+		return
+	}
+
+	teamName := rata.Param(r, "team_name")
+	pipelineName := rata.Param(r, "pipeline_name")
+	pipelineRef := atc.PipelineRef{Name: pipelineName}
+	pipelineRef.InstanceVars, err = atc.InstanceVarsFromQueryParams(r.URL.Query())
+	if atc.EnablePipelineInstances {
+		if err != nil {
+			session.Error("malformed-instance-vars", err)
+			s.handleBadRequest(w, fmt.Sprintf("instance vars are malformed: %v", err))
+			return
+		}
+	} else if pipelineRef.InstanceVars != nil {
+		s.handleBadRequest(w, "support for `instance vars` is disabled")
+		return
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		session.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		session.Debug("team-not-found", lager.Data{"team": teamName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	pipeline, found, err := team.Pipeline(pipelineRef)
+	if err != nil {
+		session.Error("failed-to-find-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		session.Debug("pipeline-not-found", lager.Data{"pipeline": pipelineName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if pipeline.ConfigVersion() != version {
+		session.Info("config-version-conflict", lager.Data{
+			"given":   version,
+			"current": pipeline.ConfigVersion(),
+		})
+		s.handleConfigVersionConflict(w)
+		return
+	}
+
+	config, err := pipeline.Config()
+	if err != nil {
+		session.Error("failed-to-get-pipeline-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		session.Error("failed-to-marshal-pipeline-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	patchedJSON, err := patch.Apply(configJSON)
+	if err != nil {
+		session.Info("failed-to-apply-patch", lager.Data{"error": err.Error()})
+		s.handleBadRequest(w, fmt.Sprintf("failed to apply patch: %s", err))
+		return
+	}
+
+	var patchedConfig atc.Config
+	err = atc.UnmarshalConfig(patchedJSON, &patchedConfig)
+	if err != nil {
+		session.Info("malformed-patched-config", lager.Data{"error": err.Error()})
+		s.handleBadRequest(w, fmt.Sprintf("malformed config: %s", err))
+		return
+	}
+
+	warnings, errorMessages := configvalidate.Validate(patchedConfig)
+	if len(errorMessages) > 0 {
+		session.Info("ignoring-invalid-config", lager.Data{"errors": errorMessages})
+		s.handleBadRequest(w, errorMessages...)
+		return
+	}
+
+	var patchedData interface{}
+	err = json.Unmarshal(patchedJSON, &patchedData)
+	if err != nil {
+		session.Error("failed-to-unmarshal-patched-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	result, err := s.policyChecker.CheckData(atc.PatchConfig, accessor.GetAccessor(r), r, patchedData)
+	if err != nil {
+		session.Error("failed-to-check-policy", err)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "policy check error: %s", err.Error())
+		return
+	}
+
+	if !result.Allowed {
+		session.Info("policy-check-not-passed", lager.Data{"reasons": result.Reasons})
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, policy.PolicyCheckNotPass{Reasons: result.Reasons}.Error())
+		return
+	}
+
+	teamResourceTypes, err := team.ResourceTypes()
+	if err != nil {
+		session.Error("failed-to-get-team-resource-types", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	warnings = append(warnings, configvalidate.ShadowedTeamResourceTypes(patchedConfig, teamResourceTypes)...)
+
+	session.Info("saving")
+
+	savedPipeline, _, err := team.SavePipeline(pipelineRef, patchedConfig, version, false)
+	if err != nil {
+		if err == db.ErrConfigComparisonFailed {
+			session.Info("config-version-conflict")
+			s.handleConfigVersionConflict(w)
+			return
+		}
+
+		session.Error("failed-to-save-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "failed to save config: %s", err)
+		return
+	}
+
+	err = s.teamFactory.NotifyResourceScanner()
+	if err != nil {
+		session.Error("failed-to-notify-resource-scanner", err)
+	}
+
+	session.Info("saved")
+
+	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", savedPipeline.ConfigVersion()))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	s.writeSaveConfigResponse(w, atc.SaveConfigResponse{Warnings: warnings})
+}
+
+func (s *Server) handleConfigVersionConflict(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusConflict)
+	s.writeSaveConfigResponse(w, atc.SaveConfigResponse{
+		Errors: []string{"pipeline config has been updated since the given version"},
+	})
+}
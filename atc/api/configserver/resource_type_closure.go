@@ -0,0 +1,264 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+// GetResourceTypeClosure returns every resource type the given pipeline
+// config's resources and custom types are built on. The versions of custom
+// types are those the pipeline has checked, if it exists and defines the type
+// the same way, or else the latest checked of the shared resource config of
+// the type's chain and source. Types neither gives a version of say why. The
+// versions of base types are those the team's workers provide. Nothing is
+// saved, so a config can be vetted before it is set.
+func (s *Server) GetResourceTypeClosure(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("get-resource-type-closure")
+
+	var config atc.Config
+	switch r.Header.Get("Content-type") {
+	case "application/json", "application/x-yaml":
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			s.handleBadRequest(w, fmt.Sprintf("read failed: %s", err))
+			return
+		}
+
+		err = atc.UnmarshalConfig(body, &config)
+		if err != nil {
+			session.Error("malformed-request-payload", err, lager.Data{
+				"content-type": r.Header.Get("Content-Type"),
+			})
+
+			s.handleBadRequest(w, fmt.Sprintf("malformed config: %s", err))
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	teamName := rata.Param(r, "team_name")
+	pipelineRef := atc.PipelineRef{Name: rata.Param(r, "pipeline_name")}
+
+	var err error
+	pipelineRef.InstanceVars, err = atc.InstanceVarsFromQueryParams(r.URL.Query())
+	if err != nil {
+		session.Error("malformed-instance-vars", err)
+		s.handleBadRequest(w, fmt.Sprintf("instance vars are malformed: %v", err))
+		return
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		session.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		session.Debug("team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	teamResourceTypes, err := team.ResourceTypes()
+	if err != nil {
+		session.Error("failed-to-get-team-resource-types", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var checkedResourceTypes db.ResourceTypes
+
+	pipeline, found, err := team.Pipeline(pipelineRef)
+	if err != nil {
+		session.Error("failed-to-find-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if found {
+		checkedResourceTypes, err = pipeline.ResourceTypes()
+		if err != nil {
+			session.Error("failed-to-get-pipeline-resource-types", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	resourceTypes := config.ResourceTypes
+	for _, resourceType := range teamResourceTypes {
+		if _, defined := config.ResourceTypes.Lookup(resourceType.Name); !defined {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+	}
+
+	var versionedResourceTypes atc.VersionedResourceTypes
+	for _, resourceType := range resourceTypes {
+		versionedResourceTypes = append(versionedResourceTypes, atc.VersionedResourceType{
+			ResourceType: resourceType,
+			Version:      checkedVersion(checkedResourceTypes, resourceType),
+		})
+	}
+
+	err = s.resolveSharedVersions(versionedResourceTypes)
+	if err != nil {
+		session.Error("failed-to-find-resource-type-versions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	closure := versionedResourceTypes.Closure(closureRoots(config)...)
+
+	for i, dependency := range closure.Types {
+		if !dependency.Base && dependency.Version == nil {
+			closure.Types[i].VersionUnavailable = versionUnavailable(versionedResourceTypes, dependency)
+		}
+	}
+
+	workers, err := s.workerFactory.VisibleWorkers([]string{teamName})
+	if err != nil {
+		session.Error("failed-to-get-workers", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	for i, dependency := range closure.Types {
+		if dependency.Base {
+			closure.Types[i].WorkerVersions = workerVersions(workers, dependency.Name)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(closure)
+	if err != nil {
+		session.Error("failed-to-encode-resource-type-closure", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// closureRoots returns the types of the config's resources and the images of
+// its embedded tasks, followed by its custom types, which are included even if
+// nothing uses them so that cycles among them are still found.
+func closureRoots(config atc.Config) []string {
+	var roots []string
+	for _, resource := range config.Resources {
+		roots = append(roots, resource.Type)
+	}
+
+	for _, job := range config.Jobs {
+		_ = job.StepConfig().Visit(atc.StepRecursor{
+			OnTask: func(step *atc.TaskStep) error {
+				if step.Config != nil && step.Config.ImageResource != nil {
+					roots = append(roots, step.Config.ImageResource.Type)
+				}
+
+				return nil
+			},
+		})
+	}
+
+	for _, resourceType := range config.ResourceTypes {
+		roots = append(roots, resourceType.Name)
+	}
+
+	return roots
+}
+
+// checkedVersion returns the version the pipeline has checked of the custom
+// type, provided the pipeline defines the type the same way.
+func checkedVersion(checked db.ResourceTypes, resourceType atc.ResourceType) atc.Version {
+	for _, t := range checked {
+		if t.Name() == resourceType.Name &&
+			t.Type() == resourceType.Type &&
+			reflect.DeepEqual(t.Source(), resourceType.Source) {
+			return t.Version()
+		}
+	}
+
+	return nil
+}
+
+// resolveSharedVersions fills in the versions of the custom types the
+// pipeline hasn't checked from the shared resource configs of their chains and
+// sources, which the pipeline's types or any other may have checked. A type's
+// resource config is built on the version of the type it is built on, so the
+// types are resolved over as many passes as it takes for no more to resolve,
+// looking each up once.
+func (s *Server) resolveSharedVersions(resourceTypes atc.VersionedResourceTypes) error {
+	looked := map[string]bool{}
+	for {
+		var resolved bool
+		for i, resourceType := range resourceTypes {
+			if resourceType.Version != nil || looked[resourceType.Name] {
+				continue
+			}
+
+			if !parentVersionKnown(resourceTypes, resourceType.Name, resourceType.Type) {
+				continue
+			}
+
+			looked[resourceType.Name] = true
+
+			version, found, err := s.resourceConfigFactory.FindCheckedVersion(
+				resourceType.Type,
+				resourceType.Source,
+				resourceTypes.Without(resourceType.Name),
+			)
+			if err != nil {
+				return err
+			}
+
+			if found {
+				resourceTypes[i].Version = version
+				resolved = true
+			}
+		}
+
+		if !resolved {
+			return nil
+		}
+	}
+}
+
+// parentVersionKnown returns whether the type a custom type is built on is a
+// base type or a custom type with a known version.
+func parentVersionKnown(resourceTypes atc.VersionedResourceTypes, name string, parentType string) bool {
+	parent, found := resourceTypes.Lookup(parentType)
+	return !found || parent.Name == name || parent.Version != nil
+}
+
+// versionUnavailable returns why the custom type has no version.
+func versionUnavailable(resourceTypes atc.VersionedResourceTypes, dependency atc.ResourceTypeDependency) string {
+	if !parentVersionKnown(resourceTypes, dependency.Name, dependency.Type) {
+		return fmt.Sprintf("the version of %s, which the type is built on, is unknown", dependency.Type)
+	}
+
+	return "no check of the type with this source has found a version; sources with vars from a credential manager never match"
+}
+
+func workerVersions(workers []db.Worker, baseType string) []string {
+	var versions []string
+	seen := map[string]bool{}
+	for _, worker := range workers {
+		for _, resourceType := range worker.ResourceTypes() {
+			if resourceType.Type == baseType && !seen[resourceType.Version] {
+				seen[resourceType.Version] = true
+				versions = append(versions, resourceType.Version)
+			}
+		}
+	}
+
+	return versions
+}
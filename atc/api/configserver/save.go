@@ -128,6 +128,15 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	teamResourceTypes, err := team.ResourceTypes()
+	if err != nil {
+		session.Error("failed-to-get-team-resource-types", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	warnings = append(warnings, configvalidate.ShadowedTeamResourceTypes(config, teamResourceTypes)...)
+
 	_, created, err := team.SavePipeline(pipelineRef, config, version, true)
 	if err != nil {
 		session.Error("failed-to-save-config", err)
@@ -2,6 +2,7 @@ package configserver
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/policychecker"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -9,17 +10,28 @@ import (
 type Server struct {
 	logger        lager.Logger
 	teamFactory   db.TeamFactory
+	workerFactory db.WorkerFactory
 	secretManager creds.Secrets
+	policyChecker policychecker.PolicyChecker
+
+	resourceConfigFactory db.ResourceConfigFactory
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
+	workerFactory db.WorkerFactory,
 	secretManager creds.Secrets,
+	policyChecker policychecker.PolicyChecker,
+	resourceConfigFactory db.ResourceConfigFactory,
 ) *Server {
 	return &Server{
 		logger:        logger,
 		teamFactory:   teamFactory,
+		workerFactory: workerFactory,
 		secretManager: secretManager,
+		policyChecker: policyChecker,
+
+		resourceConfigFactory: resourceConfigFactory,
 	}
 }
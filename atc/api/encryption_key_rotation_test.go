@@ -0,0 +1,197 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/concourse/concourse/atc/testhelpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Encryption Key Rotation API", func() {
+	var response *http.Response
+
+	BeforeEach(func() {
+		dbEncryptionKeyRotation.ProgressReturns([]db.EncryptionKeyRotationProgress{
+			{
+				Table:      "pipelines",
+				Column:     "config",
+				Rotated:    12,
+				Finished:   true,
+				UpdateTime: time.Unix(1000, 0),
+			},
+			{
+				Table:      "resources",
+				Column:     "config",
+				Rotated:    3,
+				Error:      "something went wrong",
+				UpdateTime: time.Unix(2000, 0),
+			},
+		}, nil)
+	})
+
+	Describe("PUT /api/v1/encryption-key/rotation", func() {
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/encryption-key/rotation", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("and is admin", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAdminReturns(true)
+				})
+
+				Context("when the rotation starts", func() {
+					BeforeEach(func() {
+						dbEncryptionKeyRotation.StartReturns(true, nil)
+					})
+
+					It("returns 202", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusAccepted))
+					})
+
+					It("returns Content-Type 'application/json'", func() {
+						Expect(response).Should(IncludeHeaderEntries(map[string]string{
+							"Content-Type": "application/json",
+						}))
+					})
+
+					It("starts the rotation", func() {
+						Expect(dbEncryptionKeyRotation.StartCallCount()).To(Equal(1))
+					})
+
+					It("returns the progress", func() {
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+							{"table":"pipelines","column":"config","rotated":12,"finished":true,"update_time":1000},
+							{"table":"resources","column":"config","rotated":3,"finished":false,"error":"something went wrong","update_time":2000}
+						]`))
+					})
+				})
+
+				Context("when a rotation is already under way", func() {
+					BeforeEach(func() {
+						dbEncryptionKeyRotation.StartReturns(false, nil)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+				})
+
+				Context("when both keys are not configured", func() {
+					BeforeEach(func() {
+						dbEncryptionKeyRotation.StartReturns(false, db.ErrEncryptionKeyRotationNotConfigured)
+					})
+
+					It("returns 400 with the reason", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte(db.ErrEncryptionKeyRotationNotConfigured.Error())))
+					})
+				})
+
+				Context("when starting the rotation fails", func() {
+					BeforeEach(func() {
+						dbEncryptionKeyRotation.StartReturns(false, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("and is not admin", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAdminReturns(false)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+
+				It("does not start the rotation", func() {
+					Expect(dbEncryptionKeyRotation.StartCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/encryption-key/rotation", func() {
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/encryption-key/rotation", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			It("returns 200 with the progress", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{"table":"pipelines","column":"config","rotated":12,"finished":true,"update_time":1000},
+					{"table":"resources","column":"config","rotated":3,"finished":false,"error":"something went wrong","update_time":2000}
+				]`))
+			})
+
+			Context("when there has been no rotation", func() {
+				BeforeEach(func() {
+					dbEncryptionKeyRotation.ProgressReturns(nil, nil)
+				})
+
+				It("returns an empty list", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[]`))
+				})
+			})
+
+			Context("when getting the progress fails", func() {
+				BeforeEach(func() {
+					dbEncryptionKeyRotation.ProgressReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+})
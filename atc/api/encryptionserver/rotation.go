@@ -0,0 +1,70 @@
+package encryptionserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) RotateEncryptionKey(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("rotate-encryption-key")
+
+	started, err := s.keyRotation.Start()
+	if err != nil {
+		if err == db.ErrEncryptionKeyRotationNotConfigured {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		logger.Error("failed-to-start-rotation", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !started {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte("a rotation of the encryption key is already under way"))
+		return
+	}
+
+	logger.Info("started")
+
+	s.writeProgress(w, http.StatusAccepted)
+}
+
+func (s *Server) GetEncryptionKeyRotation(w http.ResponseWriter, r *http.Request) {
+	s.writeProgress(w, http.StatusOK)
+}
+
+func (s *Server) writeProgress(w http.ResponseWriter, status int) {
+	logger := s.logger.Session("encryption-key-rotation-progress")
+
+	progress, err := s.keyRotation.Progress()
+	if err != nil {
+		logger.Error("failed-to-get-progress", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presented := []atc.EncryptionKeyRotationProgress{}
+	for _, p := range progress {
+		presented = append(presented, atc.EncryptionKeyRotationProgress{
+			Table:      p.Table,
+			Column:     p.Column,
+			Rotated:    p.Rotated,
+			Finished:   p.Finished,
+			Error:      p.Error,
+			UpdateTime: p.UpdateTime.Unix(),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err = json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-progress", err)
+	}
+}
@@ -0,0 +1,18 @@
+package encryptionserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger      lager.Logger
+	keyRotation db.EncryptionKeyRotation
+}
+
+func NewServer(logger lager.Logger, keyRotation db.EncryptionKeyRotation) *Server {
+	return &Server{
+		logger:      logger,
+		keyRotation: keyRotation,
+	}
+}
@@ -14,10 +14,12 @@ import (
 	"github.com/concourse/concourse/atc/api/cliserver"
 	"github.com/concourse/concourse/atc/api/configserver"
 	"github.com/concourse/concourse/atc/api/containerserver"
+	"github.com/concourse/concourse/atc/api/encryptionserver"
 	"github.com/concourse/concourse/atc/api/infoserver"
 	"github.com/concourse/concourse/atc/api/jobserver"
 	"github.com/concourse/concourse/atc/api/loglevelserver"
 	"github.com/concourse/concourse/atc/api/pipelineserver"
+	"github.com/concourse/concourse/atc/api/policychecker"
 	"github.com/concourse/concourse/atc/api/resourceserver"
 	"github.com/concourse/concourse/atc/api/resourceserver/versionserver"
 	"github.com/concourse/concourse/atc/api/teamserver"
@@ -27,8 +29,10 @@ import (
 	"github.com/concourse/concourse/atc/api/workerserver"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/eventarchive"
 	"github.com/concourse/concourse/atc/gc"
 	"github.com/concourse/concourse/atc/mainredirect"
+	"github.com/concourse/concourse/atc/provenance"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/wrappa"
 	"github.com/tedsuo/rata"
@@ -54,13 +58,21 @@ func NewHandler(
 	dbBuildFactory db.BuildFactory,
 	dbCheckFactory db.CheckFactory,
 	dbResourceConfigFactory db.ResourceConfigFactory,
+	dbResourceCacheFactory db.ResourceCacheFactory,
 	dbUserFactory db.UserFactory,
+	dbTeamUsageFactory db.TeamUsageFactory,
+	dbBuildServerLogFactory db.BuildServerLogFactory,
 
 	eventHandlerFactory buildserver.EventHandlerFactory,
+	eventStore eventarchive.Store,
 
 	workerPool worker.Pool,
+	resourceCacheWarmer worker.ResourceCacheWarmer,
+	artifactSpools *artifactserver.SpoolCache,
 
 	sink *lager.ReconfigurableSink,
+	provenanceSigner *provenance.Signer,
+	policyChecker policychecker.PolicyChecker,
 
 	isTLSEnabled bool,
 
@@ -73,6 +85,7 @@ func NewHandler(
 	interceptTimeoutFactory containerserver.InterceptTimeoutFactory,
 	interceptUpdateInterval time.Duration,
 	dbWall db.Wall,
+	dbEncryptionKeyRotation db.EncryptionKeyRotation,
 	clock clock.Clock,
 ) (http.Handler, error) {
 
@@ -85,28 +98,32 @@ func NewHandler(
 	buildHandlerFactory := buildserver.NewScopedHandlerFactory(logger)
 	teamHandlerFactory := NewTeamScopedHandlerFactory(logger, dbTeamFactory)
 
-	buildServer := buildserver.NewServer(logger, externalURL, dbTeamFactory, dbBuildFactory, eventHandlerFactory)
+	buildServer := buildserver.NewServer(logger, externalURL, dbTeamFactory, dbBuildFactory, eventHandlerFactory, eventStore, dbBuildServerLogFactory, provenanceSigner)
 	jobServer := jobserver.NewServer(logger, externalURL, secretManager, dbJobFactory, dbCheckFactory)
-	resourceServer := resourceserver.NewServer(logger, secretManager, varSourcePool, dbCheckFactory, dbResourceFactory, dbResourceConfigFactory)
+	resourceServer := resourceserver.NewServer(logger, externalURL, secretManager, varSourcePool, dbCheckFactory, dbResourceFactory, dbResourceConfigFactory)
 
-	versionServer := versionserver.NewServer(logger, externalURL)
-	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, externalURL)
-	configServer := configserver.NewServer(logger, dbTeamFactory, secretManager)
+	versionServer := versionserver.NewServer(logger, externalURL, dbResourceCacheFactory)
+	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, dbCheckFactory, externalURL)
+	configServer := configserver.NewServer(logger, dbTeamFactory, dbWorkerFactory, secretManager, policyChecker, dbResourceConfigFactory)
 	ccServer := ccserver.NewServer(logger, dbTeamFactory, externalURL)
-	workerServer := workerserver.NewServer(logger, workerTeamFactory, dbWorkerFactory)
+	workerServer := workerserver.NewServer(logger, workerTeamFactory, dbWorkerFactory, dbResourceCacheFactory, resourceCacheWarmer)
 	logLevelServer := loglevelserver.NewServer(logger, sink)
 	cliServer := cliserver.NewServer(logger, absCLIDownloadsDir)
 	containerServer := containerserver.NewServer(logger, workerPool, secretManager, varSourcePool, interceptTimeoutFactory, interceptUpdateInterval, containerRepository, destroyer, clock)
 	volumesServer := volumeserver.NewServer(logger, volumeRepository, destroyer)
-	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL)
+	teamServer := teamserver.NewServer(logger, dbTeamFactory, dbTeamUsageFactory, externalURL)
 	infoServer := infoserver.NewServer(logger, version, workerVersion, externalURL, clusterName, credsManagers)
-	artifactServer := artifactserver.NewServer(logger, workerPool)
+	artifactServer := artifactserver.NewServer(logger, workerPool, artifactSpools)
 	usersServer := usersserver.NewServer(logger, dbUserFactory)
 	wallServer := wallserver.NewServer(dbWall, logger)
+	encryptionServer := encryptionserver.NewServer(logger, dbEncryptionKeyRotation)
 
 	handlers := map[string]http.Handler{
-		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
-		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
+		atc.GetConfig:   http.HandlerFunc(configServer.GetConfig),
+		atc.SaveConfig:  http.HandlerFunc(configServer.SaveConfig),
+		atc.PatchConfig: http.HandlerFunc(configServer.PatchConfig),
+
+		atc.GetResourceTypeClosure: http.HandlerFunc(configServer.GetResourceTypeClosure),
 
 		atc.GetCC: http.HandlerFunc(ccServer.GetCC),
 
@@ -115,10 +132,18 @@ func NewHandler(
 		atc.GetBuild:            buildHandlerFactory.HandlerFor(buildServer.GetBuild),
 		atc.BuildResources:      buildHandlerFactory.HandlerFor(buildServer.BuildResources),
 		atc.AbortBuild:          buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
+		atc.ProtectBuild:        buildHandlerFactory.HandlerFor(buildServer.ProtectBuild),
+		atc.UnprotectBuild:      buildHandlerFactory.HandlerFor(buildServer.UnprotectBuild),
+		atc.CancelCheck:         buildHandlerFactory.HandlerFor(buildServer.CancelCheck),
 		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
 		atc.ListBuildArtifacts:  buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifacts),
+		atc.GetBuildServerLogs:  buildHandlerFactory.HandlerFor(buildServer.GetBuildServerLogs),
+		atc.ListBuildVolumes:    buildHandlerFactory.HandlerFor(buildServer.ListBuildVolumes),
+		atc.ListBuildApprovals:  buildHandlerFactory.HandlerFor(buildServer.ListBuildApprovals),
+		atc.DecideBuildApproval: buildHandlerFactory.HandlerFor(buildServer.DecideBuildApproval),
+		atc.GetBuildProvenance:  buildHandlerFactory.HandlerFor(buildServer.GetBuildProvenance),
 
 		atc.ListAllJobs:    http.HandlerFunc(jobServer.ListAllJobs),
 		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
@@ -137,6 +162,8 @@ func NewHandler(
 			Route:  atc.JobBadge,
 		},
 
+		atc.GetJobSchedulerDecision: pipelineHandlerFactory.HandlerFor(jobServer.GetSchedulerDecision),
+
 		atc.ClearTaskCache: pipelineHandlerFactory.HandlerFor(jobServer.ClearTaskCache),
 
 		atc.ListAllPipelines:          http.HandlerFunc(pipelineServer.ListAllPipelines),
@@ -156,33 +183,58 @@ func NewHandler(
 		atc.CreatePipelineBuild:       pipelineHandlerFactory.HandlerFor(pipelineServer.CreateBuild),
 		atc.PipelineBadge:             pipelineHandlerFactory.HandlerFor(pipelineServer.PipelineBadge),
 
-		atc.ListAllResources:        http.HandlerFunc(resourceServer.ListAllResources),
-		atc.ListResources:           pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
-		atc.ListResourceTypes:       pipelineHandlerFactory.HandlerFor(resourceServer.ListVersionedResourceTypes),
-		atc.GetResource:             pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
-		atc.UnpinResource:           pipelineHandlerFactory.HandlerFor(resourceServer.UnpinResource),
-		atc.SetPinCommentOnResource: pipelineHandlerFactory.HandlerFor(resourceServer.SetPinCommentOnResource),
-		atc.CheckResource:           pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
-		atc.CheckResourceWebHook:    pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebHook),
-		atc.CheckResourceType:       pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceType),
+		atc.ListSerialGroups: pipelineHandlerFactory.HandlerFor(pipelineServer.ListSerialGroups),
+
+		atc.ListVersionSets:   pipelineHandlerFactory.HandlerFor(pipelineServer.ListVersionSets),
+		atc.SaveVersionSet:    pipelineHandlerFactory.HandlerFor(pipelineServer.SaveVersionSet),
+		atc.ApplyVersionSet:   pipelineHandlerFactory.HandlerFor(pipelineServer.ApplyVersionSet),
+		atc.ClearVersionSet:   pipelineHandlerFactory.HandlerFor(pipelineServer.ClearVersionSet),
+		atc.DestroyVersionSet: pipelineHandlerFactory.HandlerFor(pipelineServer.DestroyVersionSet),
+
+		atc.ListAllResources:         http.HandlerFunc(resourceServer.ListAllResources),
+		atc.ListResourceTypeUsages:   http.HandlerFunc(resourceServer.ListResourceTypeUsages),
+		atc.ListResources:            pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
+		atc.ListResourceChecks:       pipelineHandlerFactory.HandlerFor(resourceServer.ListResourceChecks),
+		atc.ListResourceCheckRecords: pipelineHandlerFactory.HandlerFor(resourceServer.ListResourceCheckRecords),
+		atc.ListPendingChecks:        pipelineHandlerFactory.HandlerFor(resourceServer.ListPendingChecks),
+		atc.ListResourceTypes:        pipelineHandlerFactory.HandlerFor(resourceServer.ListVersionedResourceTypes),
+		atc.GetResource:              pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
+		atc.UnpinResource:            pipelineHandlerFactory.HandlerFor(resourceServer.UnpinResource),
+		atc.SetPinCommentOnResource:  pipelineHandlerFactory.HandlerFor(resourceServer.SetPinCommentOnResource),
+		atc.PauseResourceChecking:    pipelineHandlerFactory.HandlerFor(resourceServer.PauseResourceChecking),
+		atc.UnpauseResourceChecking:  pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResourceChecking),
+		atc.CheckResource:            pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
+		atc.CheckResourceWebHook:     pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebHook),
+		atc.CheckResourceType:        pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceType),
 
 		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
 		atc.GetResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.GetResourceVersion),
 		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
 		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
+		atc.PromoteResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.PromoteResourceVersion),
+		atc.UnpromoteResourceVersion:      pipelineHandlerFactory.HandlerFor(versionServer.UnpromoteResourceVersion),
 		atc.PinResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.PinResourceVersion),
+		atc.BackfillResourceVersions:      pipelineHandlerFactory.HandlerFor(versionServer.BackfillResourceVersions),
+		atc.ExpireResourceVersionCaches:   pipelineHandlerFactory.HandlerFor(versionServer.ExpireResourceVersionCaches),
+		atc.ListResourceVersionToggles:    pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersionToggles),
 		atc.ListBuildsWithVersionAsInput:  pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsInput),
 		atc.ListBuildsWithVersionAsOutput: pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
 		atc.GetResourceCausality:          pipelineHandlerFactory.HandlerFor(versionServer.GetCausality),
 
 		atc.ListWorkers:     http.HandlerFunc(workerServer.ListWorkers),
+		atc.ListWorkerUsage: http.HandlerFunc(workerServer.ListWorkerUsage),
 		atc.RegisterWorker:  http.HandlerFunc(workerServer.RegisterWorker),
 		atc.LandWorker:      http.HandlerFunc(workerServer.LandWorker),
 		atc.RetireWorker:    http.HandlerFunc(workerServer.RetireWorker),
 		atc.PruneWorker:     http.HandlerFunc(workerServer.PruneWorker),
 		atc.HeartbeatWorker: http.HandlerFunc(workerServer.HeartbeatWorker),
+		atc.WarmWorker:      http.HandlerFunc(workerServer.WarmWorker),
 		atc.DeleteWorker:    http.HandlerFunc(workerServer.DeleteWorker),
 
+		atc.PruneStalledWorkers: http.HandlerFunc(workerServer.PruneStalledWorkers),
+
+		atc.ReportWorkerDiskUsage: http.HandlerFunc(workerServer.ReportWorkerDiskUsage),
+
 		atc.SetLogLevel: http.HandlerFunc(logLevelServer.SetMinLevel),
 		atc.GetLogLevel: http.HandlerFunc(logLevelServer.GetMinLevel),
 
@@ -203,12 +255,26 @@ func NewHandler(
 		atc.ListDestroyingVolumes: http.HandlerFunc(volumesServer.ListDestroyingVolumes),
 		atc.ReportWorkerVolumes:   http.HandlerFunc(volumesServer.ReportWorkerVolumes),
 
+		atc.ReportWorkerVolumeSizes: http.HandlerFunc(volumesServer.ReportWorkerVolumeSizes),
+
 		atc.ListTeams:      http.HandlerFunc(teamServer.ListTeams),
 		atc.GetTeam:        teamHandlerFactory.HandlerFor(teamServer.GetTeam),
 		atc.SetTeam:        http.HandlerFunc(teamServer.SetTeam),
 		atc.RenameTeam:     teamHandlerFactory.HandlerFor(teamServer.RenameTeam),
 		atc.DestroyTeam:    teamHandlerFactory.HandlerFor(teamServer.DestroyTeam),
 		atc.ListTeamBuilds: teamHandlerFactory.HandlerFor(teamServer.ListTeamBuilds),
+		atc.ListTeamUsage:  http.HandlerFunc(teamServer.ListTeamUsage),
+
+		atc.ListSharedArtifacts:  teamHandlerFactory.HandlerFor(teamServer.ListSharedArtifacts),
+		atc.GrantSharedArtifact:  teamHandlerFactory.HandlerFor(teamServer.GrantSharedArtifact),
+		atc.RevokeSharedArtifact: teamHandlerFactory.HandlerFor(teamServer.RevokeSharedArtifact),
+
+		atc.ListPipelineVars:  teamHandlerFactory.HandlerFor(teamServer.ListPipelineVars),
+		atc.SetPipelineVar:    teamHandlerFactory.HandlerFor(teamServer.SetPipelineVar),
+		atc.DeletePipelineVar: teamHandlerFactory.HandlerFor(teamServer.DeletePipelineVar),
+
+		atc.GetTeamResourceTypes: teamHandlerFactory.HandlerFor(teamServer.GetTeamResourceTypes),
+		atc.SetTeamResourceTypes: teamHandlerFactory.HandlerFor(teamServer.SetTeamResourceTypes),
 
 		atc.CreateArtifact: teamHandlerFactory.HandlerFor(artifactServer.CreateArtifact),
 		atc.GetArtifact:    teamHandlerFactory.HandlerFor(artifactServer.GetArtifact),
@@ -216,6 +282,9 @@ func NewHandler(
 		atc.GetWall:   http.HandlerFunc(wallServer.GetWall),
 		atc.SetWall:   http.HandlerFunc(wallServer.SetWall),
 		atc.ClearWall: http.HandlerFunc(wallServer.ClearWall),
+
+		atc.RotateEncryptionKey:      http.HandlerFunc(encryptionServer.RotateEncryptionKey),
+		atc.GetEncryptionKeyRotation: http.HandlerFunc(encryptionServer.GetEncryptionKeyRotation),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
@@ -1563,6 +1563,55 @@ var _ = Describe("Jobs API", func() {
 							Expect(fakeJob.CreateBuildCallCount()).To(Equal(1))
 						})
 
+						Context("when vars are given", func() {
+							BeforeEach(func() {
+								var err error
+								request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", strings.NewReader(`{"vars":{"foo":"bar"},"allow_var_override":true}`))
+								Expect(err).NotTo(HaveOccurred())
+
+								fakeJob.CreateBuildWithVarsReturns(new(dbfakes.FakeBuild), nil)
+							})
+
+							It("triggers the build with the vars", func() {
+								Expect(fakeJob.CreateBuildCallCount()).To(Equal(0))
+								Expect(fakeJob.CreateBuildWithVarsCallCount()).To(Equal(1))
+
+								_, triggerVars := fakeJob.CreateBuildWithVarsArgsForCall(0)
+								Expect(triggerVars).To(Equal(db.TriggerVars{
+									Vars:          map[string]interface{}{"foo": "bar"},
+									AllowOverride: true,
+								}))
+							})
+
+							Context("when a var conflicts with an instance var of the pipeline", func() {
+								BeforeEach(func() {
+									fakeJob.CreateBuildWithVarsReturns(nil, db.TriggerVarConflictError{Name: "foo"})
+								})
+
+								It("returns a 400 saying which var conflicts", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+									body, err := ioutil.ReadAll(response.Body)
+									Expect(err).NotTo(HaveOccurred())
+									Expect(string(body)).To(Equal("trigger var 'foo' conflicts with a var defined by the pipeline"))
+								})
+							})
+						})
+
+						Context("when the body is malformed", func() {
+							BeforeEach(func() {
+								var err error
+								request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", strings.NewReader(`{`))
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("returns a 400 without triggering the build", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								Expect(fakeJob.CreateBuildCallCount()).To(Equal(0))
+								Expect(fakeJob.CreateBuildWithVarsCallCount()).To(Equal(0))
+							})
+						})
+
 						Context("when finding the pipeline resources fails", func() {
 							BeforeEach(func() {
 								fakePipeline.ResourcesReturns(nil, errors.New("nope"))
@@ -1836,7 +1885,7 @@ var _ = Describe("Jobs API", func() {
 													Resource: "some-other-resource",
 													Passed:   []string{"job-c", "job-d"},
 													Params:   atc.Params{"some": "other-params"},
-													Tags:     []string{"some-tag"},
+													Tags:     &atc.TagsConfig{All: atc.Tags{"some-tag"}},
 												},
 											},
 										},
@@ -1886,6 +1935,123 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/scheduler-decision", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/scheduler-decision")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when the job is not found", func() {
+					BeforeEach(func() {
+						fakePipeline.JobReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when getting the job succeeds", func() {
+					BeforeEach(func() {
+						fakePipeline.JobReturns(fakeJob, true, nil)
+					})
+
+					It("looks up the right job", func() {
+						Expect(fakePipeline.JobArgsForCall(0)).To(Equal("some-job"))
+					})
+
+					Context("when the job has a decision", func() {
+						BeforeEach(func() {
+							fakeJob.SchedulerDecisionReturns(atc.SchedulerDecision{
+								Time: 42,
+								Reasons: []atc.SchedulerDecisionReason{
+									{
+										Kind:    atc.SchedulerInputUnresolved,
+										Input:   "some-input",
+										Message: "no satisfiable builds from passed jobs found for set of inputs",
+									},
+								},
+							}, true, nil)
+						})
+
+						It("returns 200 with the decision", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"time": 42,
+								"reasons": [
+									{
+										"kind": "input-unresolved",
+										"input": "some-input",
+										"message": "no satisfiable builds from passed jobs found for set of inputs"
+									}
+								]
+							}`))
+						})
+					})
+
+					Context("when the job has not been scheduled", func() {
+						BeforeEach(func() {
+							fakeJob.SchedulerDecisionReturns(atc.SchedulerDecision{}, false, nil)
+						})
+
+						It("returns 404", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						})
+					})
+
+					Context("when getting the decision fails", func() {
+						BeforeEach(func() {
+							fakeJob.SchedulerDecisionReturns(atc.SchedulerDecision{}, false, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
 		var response *http.Response
 
@@ -1912,8 +2078,10 @@ var _ = Describe("Jobs API", func() {
 				})
 
 				Context("when getting the build succeeds", func() {
+					var dbBuild *dbfakes.FakeBuild
+
 					BeforeEach(func() {
-						dbBuild := new(dbfakes.FakeBuild)
+						dbBuild = new(dbfakes.FakeBuild)
 						dbBuild.IDReturns(1)
 						dbBuild.NameReturns("1")
 						dbBuild.JobNameReturns("some-job")
@@ -1960,6 +2128,45 @@ var _ = Describe("Jobs API", func() {
 				}`))
 
 					})
+
+					Context("when the build is queued", func() {
+						BeforeEach(func() {
+							dbBuild.StatusReturns(db.BuildStatusPending)
+							dbBuild.QueuePositionReturns(2, true, nil)
+						})
+
+						It("returns its position in the queue", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+					"id": 1,
+					"name": "1",
+					"job_name": "some-job",
+					"status": "pending",
+					"api_url": "/api/v1/builds/1",
+					"pipeline_name": "a-pipeline",
+					"team_name": "some-team",
+					"start_time": 1,
+					"end_time": 100,
+					"queue_position": 2
+				}`))
+						})
+					})
+
+					Context("when getting the queue position fails", func() {
+						BeforeEach(func() {
+							dbBuild.QueuePositionReturns(0, false, errors.New("oh no!"))
+						})
+
+						It("returns the build without its queue position", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(body).ToNot(ContainSubstring("queue_position"))
+						})
+					})
 				})
 
 				Context("when the build is not found", func() {
@@ -2207,6 +2414,57 @@ var _ = Describe("Jobs API", func() {
 						}`))
 							})
 						})
+
+						Context("when versions of some of its inputs are no longer available", func() {
+							BeforeEach(func() {
+								fakeBuild.UnavailableInputsReturns([]string{"some-input", "some-other-input"}, nil)
+							})
+
+							It("returns 409 Conflict naming the inputs", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusConflict))
+
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(string(body)).To(Equal("versions of inputs no longer available: some-input, some-other-input"))
+							})
+
+							It("does not rerun the build", func() {
+								Expect(fakeJob.RerunBuildCallCount()).To(BeZero())
+								Expect(fakeJob.RerunBuildWithLatestIfUnavailableCallCount()).To(BeZero())
+							})
+
+							Context("when asked to use the latest versions of them", func() {
+								BeforeEach(func() {
+									request.URL.RawQuery = "latest_if_unavailable=true"
+
+									build := new(dbfakes.FakeBuild)
+									build.IDReturns(2)
+									build.NameReturns("1.1")
+									fakeJob.RerunBuildWithLatestIfUnavailableReturns(build, nil)
+								})
+
+								It("reruns the build falling back to the latest versions", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+									Expect(fakeBuild.UnavailableInputsCallCount()).To(BeZero())
+									Expect(fakeJob.RerunBuildCallCount()).To(BeZero())
+									Expect(fakeJob.RerunBuildWithLatestIfUnavailableCallCount()).To(Equal(1))
+
+									buildToRerun, _ := fakeJob.RerunBuildWithLatestIfUnavailableArgsForCall(0)
+									Expect(buildToRerun).To(Equal(fakeBuild))
+								})
+							})
+						})
+
+						Context("when getting the unavailable inputs fails", func() {
+							BeforeEach(func() {
+								fakeBuild.UnavailableInputsReturns(nil, errors.New("nope"))
+							})
+
+							It("returns a 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
 					})
 				})
 			})
@@ -3,10 +3,14 @@ package jobserver
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
@@ -36,9 +40,35 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		// the body is optional; older clients trigger without one
+		var trigger atc.TriggerJobBuildRequest
+		err = json.NewDecoder(r.Body).Decode(&trigger)
+		if err != nil && err != io.EOF {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
 		acc := accessor.GetAccessor(r)
-		build, err := job.CreateBuild(acc.UserInfo().DisplayUserId)
+
+		var build db.Build
+		if len(trigger.Vars) == 0 {
+			build, err = job.CreateBuild(acc.UserInfo().DisplayUserId)
+		} else {
+			build, err = job.CreateBuildWithVars(acc.UserInfo().DisplayUserId, db.TriggerVars{
+				Vars:          trigger.Vars,
+				AllowOverride: trigger.AllowVarOverride,
+			})
+		}
 		if err != nil {
+			var conflictErr db.TriggerVarConflictError
+			if errors.As(err, &conflictErr) {
+				logger.Info("trigger-var-conflicts", lager.Data{"var": conflictErr.Name})
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(conflictErr.Error()))
+				return
+			}
+
 			logger.Error("failed-to-create-job-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
@@ -38,10 +38,21 @@ func (s *Server) GetJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		presentedBuild := present.Build(build)
+
+		// the queue position is left out rather than failing the request, as
+		// the build can be shown without it
+		position, queued, err := build.QueuePosition()
+		if err != nil {
+			logger.Error("failed-to-get-queue-position", err)
+		} else if queued {
+			presentedBuild.QueuePosition = position
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 
-		err = json.NewEncoder(w).Encode(present.Build(build))
+		err = json.NewEncoder(w).Encode(presentedBuild)
 		if err != nil {
 			logger.Error("failed-to-encode-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
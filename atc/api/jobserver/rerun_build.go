@@ -3,7 +3,9 @@ package jobserver
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
@@ -48,8 +50,31 @@ func (s *Server) RerunJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		latestIfUnavailable := r.URL.Query().Get("latest_if_unavailable") == "true"
+
+		if !latestIfUnavailable {
+			unavailable, err := buildToRerun.UnavailableInputs()
+			if err != nil {
+				logger.Error("failed-to-get-unavailable-inputs", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if len(unavailable) > 0 {
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprintf(w, "versions of inputs no longer available: %s", strings.Join(unavailable, ", "))
+				return
+			}
+		}
+
 		acc := accessor.GetAccessor(r)
-		build, err := job.RerunBuild(buildToRerun, acc.UserInfo().DisplayUserId)
+
+		var build db.Build
+		if latestIfUnavailable {
+			build, err = job.RerunBuildWithLatestIfUnavailable(buildToRerun, acc.UserInfo().DisplayUserId)
+		} else {
+			build, err = job.RerunBuild(buildToRerun, acc.UserInfo().DisplayUserId)
+		}
 		if err != nil {
 			logger.Error("failed-to-retrigger-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
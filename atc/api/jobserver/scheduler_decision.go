@@ -0,0 +1,46 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetSchedulerDecision(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("get-scheduler-decision")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		jobName := r.FormValue(":job_name")
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		decision, found, err := job.SchedulerDecision()
+		if err != nil {
+			logger.Error("failed-to-get-scheduler-decision", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		err = json.NewEncoder(w).Encode(decision)
+		if err != nil {
+			logger.Error("failed-to-encode-scheduler-decision", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -1005,12 +1005,19 @@ var _ = Describe("Pipelines API", func() {
 	})
 
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/unpause", func() {
-		var response *http.Response
+		var (
+			response *http.Response
+			query    string
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
 
 		JustBeforeEach(func() {
 			var err error
 
-			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/unpause", nil)
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/unpause"+query, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			response, err = client.Do(request)
@@ -1052,6 +1059,104 @@ var _ = Describe("Pipelines API", func() {
 					It("notifies the resource scanner", func() {
 						Expect(dbTeamFactory.NotifyResourceScannerCallCount()).To(Equal(1))
 					})
+
+					It("does not check any resources", func() {
+						Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+						Expect(dbPipeline.UnpauseAwaitingChecksCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when asked to check resources", func() {
+					var (
+						fakeResource      *dbfakes.FakeResource
+						fakeNeverResource *dbfakes.FakeResource
+						fakeResourceTypes db.ResourceTypes
+						fakeBuild         *dbfakes.FakeBuild
+					)
+
+					BeforeEach(func() {
+						query = "?check_resources=true"
+
+						fakeResource = new(dbfakes.FakeResource)
+						fakeResource.NameReturns("some-resource")
+						fakeResource.CurrentPinnedVersionReturns(atc.Version{"some": "version"})
+
+						fakeNeverResource = new(dbfakes.FakeResource)
+						fakeNeverResource.NameReturns("never-resource")
+						fakeNeverResource.CheckEveryReturns(&atc.CheckEvery{Never: true})
+
+						dbPipeline.ResourcesReturns(db.Resources{fakeResource, fakeNeverResource}, nil)
+
+						fakeResourceTypes = db.ResourceTypes{new(dbfakes.FakeResourceType)}
+						dbPipeline.ResourceTypesReturns(fakeResourceTypes, nil)
+
+						fakeBuild = new(dbfakes.FakeBuild)
+						fakeBuild.IDReturns(42)
+						dbCheckFactory.TryCreateCheckReturns(fakeBuild, true, nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("manually checks every resource that is checked at all", func() {
+						Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+						_, checkable, resourceTypes, from, manuallyTriggered := dbCheckFactory.TryCreateCheckArgsForCall(0)
+						Expect(checkable).To(Equal(fakeResource))
+						Expect(resourceTypes).To(Equal(fakeResourceTypes))
+						Expect(from).To(Equal(atc.Version{"some": "version"}))
+						Expect(manuallyTriggered).To(BeTrue())
+					})
+
+					It("unpauses the pipeline without waiting for the checks", func() {
+						Expect(dbPipeline.UnpauseCallCount()).To(Equal(1))
+						Expect(dbPipeline.UnpauseAwaitingChecksCallCount()).To(BeZero())
+					})
+
+					Context("when asked to wait for the checks", func() {
+						BeforeEach(func() {
+							query = "?wait_for_checks=true"
+						})
+
+						It("unpauses the pipeline awaiting the created check builds", func() {
+							Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+							Expect(dbPipeline.UnpauseCallCount()).To(BeZero())
+							Expect(dbPipeline.UnpauseAwaitingChecksCallCount()).To(Equal(1))
+							Expect(dbPipeline.UnpauseAwaitingChecksArgsForCall(0)).To(Equal([]int{42}))
+						})
+
+						Context("when a check is not created", func() {
+							BeforeEach(func() {
+								dbCheckFactory.TryCreateCheckReturns(nil, false, errors.New("parent type has no version"))
+							})
+
+							It("does not await it", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+								Expect(dbPipeline.UnpauseAwaitingChecksArgsForCall(0)).To(BeEmpty())
+							})
+						})
+
+						Context("when unpausing the pipeline fails", func() {
+							BeforeEach(func() {
+								dbPipeline.UnpauseAwaitingChecksReturns(errors.New("welp"))
+							})
+
+							It("returns 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+					})
+
+					Context("when getting the resources fails", func() {
+						BeforeEach(func() {
+							dbPipeline.ResourcesReturns(nil, errors.New("welp"))
+						})
+
+						It("returns 500 and leaves the pipeline paused", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							Expect(dbPipeline.UnpauseCallCount()).To(BeZero())
+						})
+					})
 				})
 
 				Context("when unpausing the pipeline fails for an unknown reason", func() {
@@ -1772,6 +1877,99 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/serial-groups", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/serial-groups")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated and the pipeline is private", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+				fakePipeline.PublicReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the pipeline has serial groups", func() {
+				BeforeEach(func() {
+					running := new(dbfakes.FakeBuild)
+					running.IDReturns(1)
+					running.NameReturns("4")
+					running.JobNameReturns("deploy")
+					running.StatusReturns(db.BuildStatusStarted)
+
+					pending := new(dbfakes.FakeBuild)
+					pending.IDReturns(2)
+					pending.NameReturns("7")
+					pending.JobNameReturns("smoke")
+					pending.StatusReturns(db.BuildStatusPending)
+
+					fakePipeline.SerialGroupsReturns([]db.SerialGroup{
+						{
+							Name:    "deployments",
+							Jobs:    []string{"deploy", "smoke"},
+							Running: []db.Build{running},
+							Pending: []db.Build{pending},
+						},
+						{
+							Name: "idle",
+							Jobs: []string{"other"},
+						},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns each group with its running and pending builds", func() {
+					var groups []atc.SerialGroup
+					Expect(json.NewDecoder(response.Body).Decode(&groups)).To(Succeed())
+
+					Expect(groups).To(HaveLen(2))
+
+					Expect(groups[0].Name).To(Equal("deployments"))
+					Expect(groups[0].Jobs).To(Equal([]string{"deploy", "smoke"}))
+					Expect(groups[0].RunningBuilds).To(HaveLen(1))
+					Expect(groups[0].RunningBuilds[0].ID).To(Equal(1))
+					Expect(groups[0].RunningBuilds[0].JobName).To(Equal("deploy"))
+					Expect(groups[0].PendingBuilds).To(HaveLen(1))
+					Expect(groups[0].PendingBuilds[0].ID).To(Equal(2))
+					Expect(groups[0].PendingBuilds[0].Status).To(Equal(atc.StatusPending))
+
+					Expect(groups[1].Name).To(Equal("idle"))
+					Expect(groups[1].RunningBuilds).To(BeEmpty())
+					Expect(groups[1].PendingBuilds).To(BeEmpty())
+				})
+			})
+
+			Context("when getting the serial groups fails", func() {
+				BeforeEach(func() {
+					fakePipeline.SerialGroupsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/builds", func() {
 		var response *http.Response
 		var queryParams string
@@ -2084,4 +2282,226 @@ var _ = Describe("Pipelines API", func() {
 			})
 		})
 	})
+
+	Describe("version sets", func() {
+		var (
+			fakeVersionSet *dbfakes.FakeVersionSet
+
+			method   string
+			path     string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			fakeVersionSet = new(dbfakes.FakeVersionSet)
+			fakeVersionSet.NameReturns("release-1.2")
+			fakeVersionSet.CreatedAtReturns(time.Unix(42, 0))
+			fakeVersionSet.VersionsReturns(map[string]atc.Version{
+				"some-resource": {"ref": "abc"},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest(method, server.URL+path, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				fakeTeam.PipelineReturns(dbPipeline, true, nil)
+			})
+
+			Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/version-sets", func() {
+				BeforeEach(func() {
+					method = "GET"
+					path = "/api/v1/teams/a-team/pipelines/a-pipeline/version-sets"
+
+					fakeVersionSet.AppliedReturns(true)
+					dbPipeline.VersionSetsReturns([]db.VersionSet{fakeVersionSet}, nil)
+				})
+
+				It("returns the version sets", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[{
+						"name": "release-1.2",
+						"created_at": 42,
+						"applied": true,
+						"versions": {"some-resource": {"ref": "abc"}}
+					}]`))
+				})
+
+				Context("when getting the version sets fails", func() {
+					BeforeEach(func() {
+						dbPipeline.VersionSetsReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/version-sets/:version_set_name", func() {
+				BeforeEach(func() {
+					method = "PUT"
+					path = "/api/v1/teams/a-team/pipelines/a-pipeline/version-sets/release-1.2"
+
+					dbPipeline.SaveVersionSetReturns(fakeVersionSet, nil)
+				})
+
+				It("captures the current versions under the given name", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					Expect(dbPipeline.SaveVersionSetArgsForCall(0)).To(Equal("release-1.2"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"name": "release-1.2",
+						"created_at": 42,
+						"applied": false,
+						"versions": {"some-resource": {"ref": "abc"}}
+					}`))
+				})
+
+				Context("when a version set with the name exists", func() {
+					BeforeEach(func() {
+						dbPipeline.SaveVersionSetReturns(nil, db.ErrVersionSetExists)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+				})
+			})
+
+			Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/version-sets/:version_set_name/apply", func() {
+				BeforeEach(func() {
+					method = "PUT"
+					path = "/api/v1/teams/a-team/pipelines/a-pipeline/version-sets/release-1.2/apply"
+
+					dbPipeline.VersionSetReturns(fakeVersionSet, true, nil)
+				})
+
+				It("applies the version set", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(dbPipeline.VersionSetArgsForCall(0)).To(Equal("release-1.2"))
+					Expect(fakeVersionSet.ApplyCallCount()).To(Equal(1))
+				})
+
+				Context("when some captured versions are missing", func() {
+					BeforeEach(func() {
+						fakeVersionSet.ApplyReturns(db.VersionSetMissingVersionsError{
+							Resources: []string{"some-resource", "other-resource"},
+						})
+					})
+
+					It("returns 409 with the resources lacking their version", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(Equal("captured version not found for resource(s): some-resource, other-resource"))
+					})
+				})
+
+				Context("when some resources are pinned through config", func() {
+					BeforeEach(func() {
+						fakeVersionSet.ApplyReturns(db.VersionSetPinnedThroughConfigError{
+							Resources: []string{"some-resource"},
+						})
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+				})
+
+				Context("when applying fails", func() {
+					BeforeEach(func() {
+						fakeVersionSet.ApplyReturns(errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the version set is not found", func() {
+					BeforeEach(func() {
+						dbPipeline.VersionSetReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+			})
+
+			Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/version-sets/:version_set_name/clear", func() {
+				BeforeEach(func() {
+					method = "PUT"
+					path = "/api/v1/teams/a-team/pipelines/a-pipeline/version-sets/release-1.2/clear"
+
+					dbPipeline.VersionSetReturns(fakeVersionSet, true, nil)
+				})
+
+				It("clears the version set", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeVersionSet.ClearCallCount()).To(Equal(1))
+				})
+			})
+
+			Describe("DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name/version-sets/:version_set_name", func() {
+				BeforeEach(func() {
+					method = "DELETE"
+					path = "/api/v1/teams/a-team/pipelines/a-pipeline/version-sets/release-1.2"
+
+					dbPipeline.VersionSetReturns(fakeVersionSet, true, nil)
+				})
+
+				It("destroys the version set", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					Expect(fakeVersionSet.DestroyCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				method = "PUT"
+				path = "/api/v1/teams/a-team/pipelines/a-pipeline/version-sets/release-1.2/apply"
+
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				method = "PUT"
+				path = "/api/v1/teams/a-team/pipelines/a-pipeline/version-sets/release-1.2/apply"
+
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
 })
@@ -0,0 +1,49 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListSerialGroups(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("list-serial-groups")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		groups, err := pipeline.SerialGroups()
+		if err != nil {
+			logger.Error("failed-to-get-serial-groups", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.SerialGroup{}
+		for _, group := range groups {
+			serialGroup := atc.SerialGroup{
+				Name:          group.Name,
+				Jobs:          group.Jobs,
+				RunningBuilds: []atc.Build{},
+				PendingBuilds: []atc.Build{},
+			}
+
+			for _, build := range group.Running {
+				serialGroup.RunningBuilds = append(serialGroup.RunningBuilds, present.Build(build))
+			}
+
+			for _, build := range group.Pending {
+				serialGroup.PendingBuilds = append(serialGroup.PendingBuilds, present.Build(build))
+			}
+
+			presented = append(presented, serialGroup)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-serial-groups", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -1,15 +1,37 @@
 package pipelineserver
 
 import (
+	"context"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc/db"
 )
 
 func (s *Server) UnpausePipeline(pipelineDB db.Pipeline) http.Handler {
 	logger := s.logger.Session("unpause-pipeline")
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		err := pipelineDB.Unpause()
+		waitForChecks := r.URL.Query().Get("wait_for_checks") == "true"
+		checkResources := waitForChecks || r.URL.Query().Get("check_resources") == "true"
+
+		var checkBuildIDs []int
+		if checkResources {
+			var err error
+			checkBuildIDs, err = s.checkResources(logger, pipelineDB)
+			if err != nil {
+				logger.Error("failed-to-check-resources", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		var err error
+		if waitForChecks {
+			err = pipelineDB.UnpauseAwaitingChecks(checkBuildIDs)
+		} else {
+			err = pipelineDB.Unpause()
+		}
 
 		if err != nil {
 			logger.Error("failed-to-unpause-pipeline", err)
@@ -25,3 +47,49 @@ func (s *Server) UnpausePipeline(pipelineDB db.Pipeline) http.Handler {
 		w.WriteHeader(http.StatusOK)
 	})
 }
+
+// checkResources enqueues a check of every resource of the pipeline,
+// regardless of its check interval, and returns the ids of the created check
+// builds. Resources which cannot be checked right now are skipped and left
+// to the resource scanner.
+func (s *Server) checkResources(logger lager.Logger, pipelineDB db.Pipeline) ([]int, error) {
+	resources, err := pipelineDB.Resources()
+	if err != nil {
+		return nil, err
+	}
+
+	resourceTypes, err := pipelineDB.ResourceTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	var checkBuildIDs []int
+	for _, resource := range resources {
+		if resource.CheckEvery() != nil && resource.CheckEvery().Never {
+			continue
+		}
+
+		rLogger := logger.Session("check", lager.Data{"resource": resource.Name()})
+
+		build, created, err := s.checkFactory.TryCreateCheck(
+			lagerctx.NewContext(context.Background(), rLogger),
+			resource,
+			resourceTypes,
+			resource.CurrentPinnedVersion(),
+			true,
+		)
+		if err != nil {
+			rLogger.Error("failed-to-create-check", err)
+			continue
+		}
+
+		if !created {
+			rLogger.Info("check-not-created")
+			continue
+		}
+
+		checkBuildIDs = append(checkBuildIDs, build.ID())
+	}
+
+	return checkBuildIDs, nil
+}
@@ -29,6 +29,7 @@ var _ = Describe("Unpause Handler", func() {
 			fakeLogger,
 			new(dbfakes.FakeTeamFactory),
 			new(dbfakes.FakePipelineFactory),
+			new(dbfakes.FakeCheckFactory),
 			"",
 		)
 		dbPipeline = new(dbfakes.FakePipeline)
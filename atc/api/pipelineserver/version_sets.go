@@ -0,0 +1,149 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListVersionSets(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("list-version-sets")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sets, err := pipeline.VersionSets()
+		if err != nil {
+			logger.Error("failed-to-get-version-sets", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.VersionSet{}
+		for _, set := range sets {
+			versions, err := set.Versions()
+			if err != nil {
+				logger.Error("failed-to-get-version-set-versions", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			presented = append(presented, present.VersionSet(set, versions))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-version-sets", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) SaveVersionSet(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("save-version-set")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.FormValue(":version_set_name")
+
+		set, err := pipeline.SaveVersionSet(name)
+		if err != nil {
+			if err == db.ErrVersionSetExists {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(err.Error()))
+				return
+			}
+
+			logger.Error("failed-to-save-version-set", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		versions, err := set.Versions()
+		if err != nil {
+			logger.Error("failed-to-get-version-set-versions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		logger.Info("saved", lager.Data{"version-set": name, "resources": len(versions)})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		err = json.NewEncoder(w).Encode(present.VersionSet(set, versions))
+		if err != nil {
+			logger.Error("failed-to-encode-version-set", err)
+		}
+	})
+}
+
+func (s *Server) ApplyVersionSet(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("apply-version-set")
+	return s.versionSetHandler(logger, pipeline, func(w http.ResponseWriter, set db.VersionSet) {
+		err := set.Apply()
+		if err != nil {
+			switch err.(type) {
+			case db.VersionSetMissingVersionsError, db.VersionSetPinnedThroughConfigError:
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(err.Error()))
+				return
+			}
+
+			logger.Error("failed-to-apply-version-set", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (s *Server) ClearVersionSet(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("clear-version-set")
+	return s.versionSetHandler(logger, pipeline, func(w http.ResponseWriter, set db.VersionSet) {
+		err := set.Clear()
+		if err != nil {
+			logger.Error("failed-to-clear-version-set", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (s *Server) DestroyVersionSet(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("destroy-version-set")
+	return s.versionSetHandler(logger, pipeline, func(w http.ResponseWriter, set db.VersionSet) {
+		err := set.Destroy()
+		if err != nil {
+			logger.Error("failed-to-destroy-version-set", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (s *Server) versionSetHandler(logger lager.Logger, pipeline db.Pipeline, handle func(http.ResponseWriter, db.VersionSet)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.FormValue(":version_set_name")
+
+		set, found, err := pipeline.VersionSet(name)
+		if err != nil {
+			logger.Error("failed-to-get-version-set", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("version-set-not-found", lager.Data{"version-set": name})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		handle(w, set)
+	})
+}
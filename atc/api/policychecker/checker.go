@@ -17,6 +17,11 @@ import (
 //counterfeiter:generate . PolicyChecker
 type PolicyChecker interface {
 	Check(string, accessor.Access, *http.Request) (policy.PolicyCheckOutput, error)
+
+	// CheckData checks the action against the given data rather than the
+	// request's body, for requests whose body is not what the action results
+	// in, such as a patch to a pipeline's config.
+	CheckData(string, accessor.Access, *http.Request, interface{}) (policy.PolicyCheckOutput, error)
 }
 
 type checker struct {
@@ -28,32 +33,11 @@ func NewApiPolicyChecker(policyChecker policy.Checker) PolicyChecker {
 }
 
 func (c *checker) Check(action string, acc accessor.Access, req *http.Request) (policy.PolicyCheckOutput, error) {
-	// Ignore self invoked API calls.
-	if acc.IsSystem() {
+	if !c.shouldCheck(action, acc, req) {
 		return policy.PassedPolicyCheck(), nil
 	}
 
-	// Actions in black will not go through policy check.
-	if c.policyChecker.ShouldSkipAction(action) {
-		return policy.PassedPolicyCheck(), nil
-	}
-
-	// Only actions with specified http method will go through policy check.
-	// But actions in white list will always go through policy check.
-	if !c.policyChecker.ShouldCheckHttpMethod(req.Method) &&
-		!c.policyChecker.ShouldCheckAction(action) {
-		return policy.PassedPolicyCheck(), nil
-	}
-
-	team := req.FormValue(":team_name")
-	input := policy.PolicyCheckInput{
-		HttpMethod: req.Method,
-		Action:     action,
-		User:       acc.Claims().UserName,
-		Roles:      acc.TeamRoles()[team],
-		Team:       team,
-		Pipeline:   req.FormValue(":pipeline_name"),
-	}
+	input := c.input(action, acc, req)
 
 	switch ct := req.Header.Get("Content-type"); ct {
 	case "application/json", "text/vnd.yaml", "text/yaml", "text/x-yaml", "application/x-yaml":
@@ -76,3 +60,43 @@ func (c *checker) Check(action string, acc accessor.Access, req *http.Request) (
 
 	return c.policyChecker.Check(input)
 }
+
+func (c *checker) CheckData(action string, acc accessor.Access, req *http.Request, data interface{}) (policy.PolicyCheckOutput, error) {
+	if !c.shouldCheck(action, acc, req) {
+		return policy.PassedPolicyCheck(), nil
+	}
+
+	input := c.input(action, acc, req)
+	input.Data = data
+
+	return c.policyChecker.Check(input)
+}
+
+func (c *checker) shouldCheck(action string, acc accessor.Access, req *http.Request) bool {
+	// Ignore self invoked API calls.
+	if acc.IsSystem() {
+		return false
+	}
+
+	// Actions in black will not go through policy check.
+	if c.policyChecker.ShouldSkipAction(action) {
+		return false
+	}
+
+	// Only actions with specified http method will go through policy check.
+	// But actions in white list will always go through policy check.
+	return c.policyChecker.ShouldCheckHttpMethod(req.Method) ||
+		c.policyChecker.ShouldCheckAction(action)
+}
+
+func (c *checker) input(action string, acc accessor.Access, req *http.Request) policy.PolicyCheckInput {
+	team := req.FormValue(":team_name")
+	return policy.PolicyCheckInput{
+		HttpMethod: req.Method,
+		Action:     action,
+		User:       acc.Claims().UserName,
+		Roles:      acc.TeamRoles()[team],
+		Team:       team,
+		Pipeline:   req.FormValue(":pipeline_name"),
+	}
+}
@@ -225,3 +225,79 @@ var _ = Describe("PolicyChecker", func() {
 		})
 	})
 })
+
+var _ = Describe("PolicyChecker CheckData", func() {
+	var (
+		policyFilter policy.Filter
+		fakeAccess   *accessorfakes.FakeAccess
+		fakeRequest  *http.Request
+		result       policy.PolicyCheckOutput
+		checkErr     error
+	)
+
+	BeforeEach(func() {
+		fakeAccess = new(accessorfakes.FakeAccess)
+		fakeAccess.TeamRolesReturns(map[string][]string{
+			"some-team": []string{"some-role"},
+		})
+		fakeAccess.ClaimsReturns(accessor.Claims{UserName: "some-user"})
+
+		fakePolicyAgent = new(policyfakes.FakeAgent)
+		fakePolicyAgent.CheckReturns(policy.PassedPolicyCheck(), nil)
+		fakePolicyAgentFactory.NewAgentReturns(fakePolicyAgent, nil)
+
+		policyFilter = policy.Filter{
+			HttpMethods: []string{"PATCH"},
+		}
+
+		body := bytes.NewBuffer([]byte(`[{"op": "remove", "path": "/jobs/0"}]`))
+		fakeRequest = httptest.NewRequest("PATCH", "/something?:team_name=some-team&:pipeline_name=some-pipeline", body)
+		fakeRequest.Header.Add("Content-type", "application/json")
+		fakeRequest.ParseForm()
+	})
+
+	JustBeforeEach(func() {
+		policyCheck, err := policy.Initialize(testLogger, "some-cluster", "some-version", policyFilter)
+		Expect(err).ToNot(HaveOccurred())
+		result, checkErr = policychecker.NewApiPolicyChecker(policyCheck).CheckData("some-action", fakeAccess, fakeRequest, map[string]interface{}{"a": "b"})
+	})
+
+	It("checks the given data instead of the request body", func() {
+		Expect(checkErr).ToNot(HaveOccurred())
+		Expect(result.Allowed).To(BeTrue())
+		Expect(fakePolicyAgent.CheckArgsForCall(0)).To(Equal(policy.PolicyCheckInput{
+			Service:        "concourse",
+			ClusterName:    "some-cluster",
+			ClusterVersion: "some-version",
+			HttpMethod:     "PATCH",
+			Action:         "some-action",
+			User:           "some-user",
+			Team:           "some-team",
+			Roles:          []string{"some-role"},
+			Pipeline:       "some-pipeline",
+			Data:           map[string]interface{}{"a": "b"},
+		}))
+	})
+
+	Context("when the action should be skipped", func() {
+		BeforeEach(func() {
+			policyFilter.ActionsToSkip = []string{"some-action"}
+		})
+
+		It("passes without calling the agent", func() {
+			Expect(result.Allowed).To(BeTrue())
+			Expect(fakePolicyAgent.CheckCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when system action", func() {
+		BeforeEach(func() {
+			fakeAccess.IsSystemReturns(true)
+		})
+
+		It("passes without calling the agent", func() {
+			Expect(result.Allowed).To(BeTrue())
+			Expect(fakePolicyAgent.CheckCallCount()).To(Equal(0))
+		})
+	})
+})
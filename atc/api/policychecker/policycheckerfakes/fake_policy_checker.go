@@ -26,6 +26,22 @@ type FakePolicyChecker struct {
 		result1 policy.PolicyCheckOutput
 		result2 error
 	}
+	CheckDataStub        func(string, accessor.Access, *http.Request, interface{}) (policy.PolicyCheckOutput, error)
+	checkDataMutex       sync.RWMutex
+	checkDataArgsForCall []struct {
+		arg1 string
+		arg2 accessor.Access
+		arg3 *http.Request
+		arg4 interface{}
+	}
+	checkDataReturns struct {
+		result1 policy.PolicyCheckOutput
+		result2 error
+	}
+	checkDataReturnsOnCall map[int]struct {
+		result1 policy.PolicyCheckOutput
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -96,11 +112,80 @@ func (fake *FakePolicyChecker) CheckReturnsOnCall(i int, result1 policy.PolicyCh
 	}{result1, result2}
 }
 
+func (fake *FakePolicyChecker) CheckData(arg1 string, arg2 accessor.Access, arg3 *http.Request, arg4 interface{}) (policy.PolicyCheckOutput, error) {
+	fake.checkDataMutex.Lock()
+	ret, specificReturn := fake.checkDataReturnsOnCall[len(fake.checkDataArgsForCall)]
+	fake.checkDataArgsForCall = append(fake.checkDataArgsForCall, struct {
+		arg1 string
+		arg2 accessor.Access
+		arg3 *http.Request
+		arg4 interface{}
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CheckDataStub
+	fakeReturns := fake.checkDataReturns
+	fake.recordInvocation("CheckData", []interface{}{arg1, arg2, arg3, arg4})
+	fake.checkDataMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePolicyChecker) CheckDataCallCount() int {
+	fake.checkDataMutex.RLock()
+	defer fake.checkDataMutex.RUnlock()
+	return len(fake.checkDataArgsForCall)
+}
+
+func (fake *FakePolicyChecker) CheckDataCalls(stub func(string, accessor.Access, *http.Request, interface{}) (policy.PolicyCheckOutput, error)) {
+	fake.checkDataMutex.Lock()
+	defer fake.checkDataMutex.Unlock()
+	fake.CheckDataStub = stub
+}
+
+func (fake *FakePolicyChecker) CheckDataArgsForCall(i int) (string, accessor.Access, *http.Request, interface{}) {
+	fake.checkDataMutex.RLock()
+	defer fake.checkDataMutex.RUnlock()
+	argsForCall := fake.checkDataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakePolicyChecker) CheckDataReturns(result1 policy.PolicyCheckOutput, result2 error) {
+	fake.checkDataMutex.Lock()
+	defer fake.checkDataMutex.Unlock()
+	fake.CheckDataStub = nil
+	fake.checkDataReturns = struct {
+		result1 policy.PolicyCheckOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePolicyChecker) CheckDataReturnsOnCall(i int, result1 policy.PolicyCheckOutput, result2 error) {
+	fake.checkDataMutex.Lock()
+	defer fake.checkDataMutex.Unlock()
+	fake.CheckDataStub = nil
+	if fake.checkDataReturnsOnCall == nil {
+		fake.checkDataReturnsOnCall = make(map[int]struct {
+			result1 policy.PolicyCheckOutput
+			result2 error
+		})
+	}
+	fake.checkDataReturnsOnCall[i] = struct {
+		result1 policy.PolicyCheckOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePolicyChecker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.checkMutex.RLock()
 	defer fake.checkMutex.RUnlock()
+	fake.checkDataMutex.RLock()
+	defer fake.checkDataMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -30,6 +30,9 @@ func Build(build db.Build) atc.Build {
 		Status:               atc.BuildStatus(build.Status()),
 		APIURL:               apiURL,
 		CreatedBy:            build.CreatedBy(),
+		AbortedBy:            build.AbortedBy(),
+		AbortReason:          build.AbortReason(),
+		Protected:            build.IsProtected(),
 	}
 
 	if build.RerunOf() != 0 {
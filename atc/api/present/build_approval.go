@@ -0,0 +1,36 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func BuildApprovals(approvals []db.BuildApproval) []atc.BuildApproval {
+	presented := []atc.BuildApproval{}
+	for _, a := range approvals {
+		presented = append(presented, BuildApproval(a))
+	}
+	return presented
+}
+
+func BuildApproval(approval db.BuildApproval) atc.BuildApproval {
+	presented := atc.BuildApproval{
+		PlanID:      approval.PlanID,
+		Name:        approval.Name,
+		Status:      atc.ApprovalPending,
+		RequestedAt: approval.RequestedAt.Unix(),
+	}
+
+	if approval.Decided {
+		presented.Status = atc.ApprovalRejected
+		if approval.Approved {
+			presented.Status = atc.ApprovalApproved
+		}
+
+		presented.DecidedBy = approval.DecidedBy
+		presented.DecidedAt = approval.DecidedAt.Unix()
+		presented.Comment = approval.Comment
+	}
+
+	return presented
+}
@@ -9,14 +9,20 @@ func PublicBuildInput(input db.BuildInput, pipelineID int) atc.PublicBuildInput
 	return atc.PublicBuildInput{
 		Name:            input.Name,
 		Version:         atc.Version(input.Version),
+		Metadata:        input.Metadata.ToATCMetadata(),
 		PipelineID:      pipelineID,
 		FirstOccurrence: input.FirstOccurrence,
+		VersionMissing:  input.VersionMissing,
+		VersionMD5:      input.VersionMD5,
 	}
 }
 
 func PublicBuildOutput(output db.BuildOutput) atc.PublicBuildOutput {
 	return atc.PublicBuildOutput{
-		Name:    output.Name,
-		Version: atc.Version(output.Version),
+		Name:           output.Name,
+		Version:        atc.Version(output.Version),
+		Metadata:       output.Metadata.ToATCMetadata(),
+		VersionMissing: output.VersionMissing,
+		VersionMD5:     output.VersionMD5,
 	}
 }
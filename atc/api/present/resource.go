@@ -15,8 +15,12 @@ func Resource(resource db.Resource) atc.Resource {
 		Type:                 resource.Type(),
 		Icon:                 resource.Icon(),
 
+		LastCheckContainer: resource.LastCheckContainerHandle(),
+
 		PinComment: resource.PinComment(),
 
+		CheckPaused: resource.CheckPaused(),
+
 		Build: resource.BuildSummary(),
 	}
 
@@ -0,0 +1,21 @@
+package present
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+)
+
+func ServerLogs(logs []lager.LogFormat) []atc.ServerLog {
+	presented := make([]atc.ServerLog, len(logs))
+	for i, log := range logs {
+		presented[i] = atc.ServerLog{
+			Timestamp: log.Timestamp,
+			Source:    log.Source,
+			Message:   log.Message,
+			LogLevel:  atc.LogLevel(log.LogLevel.String()),
+			Data:      log.Data,
+		}
+	}
+
+	return presented
+}
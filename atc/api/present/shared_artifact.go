@@ -0,0 +1,29 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func SharedArtifacts(artifacts []db.SharedArtifact) []atc.SharedArtifact {
+	presented := []atc.SharedArtifact{}
+	for _, a := range artifacts {
+		presented = append(presented, SharedArtifact(a))
+	}
+	return presented
+}
+
+func SharedArtifact(artifact db.SharedArtifact) atc.SharedArtifact {
+	presented := atc.SharedArtifact{
+		Name:         artifact.Name,
+		BuildID:      artifact.BuildID,
+		Size:         artifact.Size,
+		GrantedTeams: artifact.GrantedTeams,
+	}
+
+	if !artifact.PublishedAt.IsZero() {
+		presented.PublishedAt = artifact.PublishedAt.Unix()
+	}
+
+	return presented
+}
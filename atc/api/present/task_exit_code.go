@@ -0,0 +1,18 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func TaskExitCodes(exitCodes []db.TaskExitCode) []atc.TaskExitCode {
+	presented := []atc.TaskExitCode{}
+	for _, e := range exitCodes {
+		presented = append(presented, atc.TaskExitCode{
+			PlanID:   e.PlanID,
+			Name:     e.Name,
+			ExitCode: e.ExitCode,
+		})
+	}
+	return presented
+}
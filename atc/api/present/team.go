@@ -6,9 +6,15 @@ import (
 )
 
 func Team(team db.Team) atc.Team {
-	return atc.Team{
+	atcTeam := atc.Team{
 		ID:   team.ID(),
 		Name: team.Name(),
 		Auth: team.Auth(),
 	}
+
+	if limit := team.MaxConcurrentBuilds(); limit != 0 {
+		atcTeam.MaxConcurrentBuilds = &limit
+	}
+
+	return atcTeam
 }
@@ -0,0 +1,15 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func VersionSet(set db.VersionSet, versions map[string]atc.Version) atc.VersionSet {
+	return atc.VersionSet{
+		Name:      set.Name(),
+		CreatedAt: set.CreatedAt().Unix(),
+		Applied:   set.Applied(),
+		Versions:  versions,
+	}
+}
@@ -1,6 +1,8 @@
 package present
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -71,3 +73,30 @@ func toVolumeBaseResourceType(dbResourceType *db.UsedWorkerBaseResourceType) *at
 		Version: dbResourceType.Version,
 	}
 }
+
+func BuildVolumes(containers []db.Container, volumes []db.BuildVolume) atc.BuildVolumes {
+	presented := atc.BuildVolumes{
+		Containers: make([]atc.Container, len(containers)),
+		Volumes:    make([]atc.BuildVolume, len(volumes)),
+	}
+
+	for i, container := range containers {
+		presented.Containers[i] = Container(container, time.Time{})
+	}
+
+	for i, volume := range volumes {
+		presented.Volumes[i] = atc.BuildVolume{
+			ID:              volume.Handle,
+			WorkerName:      volume.WorkerName,
+			State:           string(volume.State),
+			Type:            string(volume.Type),
+			ContainerHandle: volume.ContainerHandle,
+			ParentHandle:    volume.ParentHandle,
+			Path:            volume.Path,
+			SizeBytes:       volume.Size,
+			Inherited:       volume.Inherited,
+		}
+	}
+
+	return presented
+}
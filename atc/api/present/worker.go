@@ -34,6 +34,7 @@ func Worker(workerInfo db.Worker) atc.Worker {
 		ActiveTasks:      activeTasks,
 		ResourceTypes:    workerInfo.ResourceTypes(),
 		Platform:         workerInfo.Platform(),
+		Runtime:          workerInfo.Runtime(),
 		Tags:             workerInfo.Tags(),
 		Name:             workerInfo.Name(),
 		Team:             workerInfo.TeamName(),
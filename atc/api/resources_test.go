@@ -198,6 +198,135 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/resource-type-usages", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = "?type=git"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/resource-type-usages" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when getting the usages succeeds", func() {
+			BeforeEach(func() {
+				fakeAccess.TeamNamesReturns([]string{"some-team"})
+
+				dbResourceFactory.VisibleResourceTypeUsagesReturns([]db.ResourceTypeUsage{
+					{
+						TeamName:     "some-team",
+						PipelineID:   1,
+						PipelineName: "some-pipeline",
+						ResourceName: "some-repo",
+						Type:         "git",
+					},
+					{
+						TeamName:             "some-team",
+						PipelineID:           2,
+						PipelineName:         "other-pipeline",
+						PipelineInstanceVars: atc.InstanceVars{"branch": "main"},
+						ResourceTypeName:     "git-with-extras",
+						Type:                 "git",
+					},
+				}, nil)
+			})
+
+			It("returns 200 OK", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("returns each resource and resource type referencing the type", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"team_name": "some-team",
+						"pipeline_id": 1,
+						"pipeline_name": "some-pipeline",
+						"resource_name": "some-repo",
+						"type": "git"
+					},
+					{
+						"team_name": "some-team",
+						"pipeline_id": 2,
+						"pipeline_name": "other-pipeline",
+						"pipeline_instance_vars": {"branch": "main"},
+						"resource_type_name": "git-with-extras",
+						"type": "git"
+					}
+				]`))
+			})
+
+			It("looks up the usages of the type in the user's teams' pipelines", func() {
+				Expect(dbResourceFactory.VisibleResourceTypeUsagesCallCount()).To(Equal(1))
+				teamNames, filter := dbResourceFactory.VisibleResourceTypeUsagesArgsForCall(0)
+				Expect(teamNames).To(Equal([]string{"some-team"}))
+				Expect(filter).To(Equal(db.ResourceTypeUsageFilter{Type: "git"}))
+			})
+
+			Context("when given an image", func() {
+				BeforeEach(func() {
+					query = "?image=concourse/git-resource"
+				})
+
+				It("looks up the usages of the image", func() {
+					_, filter := dbResourceFactory.VisibleResourceTypeUsagesArgsForCall(0)
+					Expect(filter).To(Equal(db.ResourceTypeUsageFilter{Image: "concourse/git-resource"}))
+				})
+			})
+
+			Context("when the user is an admin", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAdminReturns(true)
+				})
+
+				It("looks up the usages in every pipeline", func() {
+					Expect(dbResourceFactory.VisibleResourceTypeUsagesCallCount()).To(BeZero())
+					Expect(dbResourceFactory.AllResourceTypeUsagesCallCount()).To(Equal(1))
+					Expect(dbResourceFactory.AllResourceTypeUsagesArgsForCall(0)).To(Equal(db.ResourceTypeUsageFilter{Type: "git"}))
+				})
+			})
+		})
+
+		Context("when there are no usages", func() {
+			It("returns an empty array", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`[]`))
+			})
+		})
+
+		Context("when neither a type nor an image is given", func() {
+			BeforeEach(func() {
+				query = ""
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(dbResourceFactory.VisibleResourceTypeUsagesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when getting the usages fails", func() {
+			BeforeEach(func() {
+				dbResourceFactory.VisibleResourceTypeUsagesReturns(nil, errors.New("oh no!"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources", func() {
 		var response *http.Response
 
@@ -396,6 +525,393 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resource-checks", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/resource-checks")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+				fakePipeline.PublicReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				dbCheckFactory.CheckIntervalReturns(time.Minute)
+
+				checked := new(dbfakes.FakeResource)
+				checked.NameReturns("checked")
+				checked.LastCheckEndTimeReturns(time.Unix(1513364881, 0))
+				checked.LastCheckSucceededReturns(true)
+				checked.LastCheckSuccessTimeReturns(time.Unix(1513364881, 0))
+
+				failing := new(dbfakes.FakeResource)
+				failing.NameReturns("failing")
+				failing.LastCheckEndTimeReturns(time.Unix(1513364881, 0))
+				failing.LastCheckSuccessTimeReturns(time.Unix(1513300000, 0))
+				failing.CheckFailuresReturns(3)
+
+				never := new(dbfakes.FakeResource)
+				never.NameReturns("never")
+				never.CheckEveryReturns(&atc.CheckEvery{Never: true})
+
+				onDemand := new(dbfakes.FakeResource)
+				onDemand.NameReturns("on-demand")
+				onDemand.CheckOnDemandReturns(true)
+
+				ttl := new(dbfakes.FakeResource)
+				ttl.NameReturns("ttl")
+				ttl.CheckEveryReturns(&atc.CheckEvery{Never: true})
+				ttl.CheckTTLReturns(time.Hour)
+				ttl.LastCheckEndTimeReturns(time.Unix(1513364881, 0))
+				ttl.LastCheckSucceededReturns(true)
+				ttl.LastScheduledCheckEndTimeReturns(time.Unix(1513300000, 0))
+				ttl.LastScheduledCheckSucceededReturns(true)
+
+				fakePipeline.ResourcesReturns([]db.Resource{checked, failing, never, onDemand, ttl}, nil)
+			})
+
+			It("returns 200 OK", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("returns the check status of each resource", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"name": "checked",
+						"last_checked": 1513364881,
+						"succeeded": true,
+						"last_succeeded": 1513364881,
+						"consecutive_failures": 0,
+						"next_check": 1513364941
+					},
+					{
+						"name": "failing",
+						"last_checked": 1513364881,
+						"succeeded": false,
+						"last_succeeded": 1513300000,
+						"consecutive_failures": 3,
+						"next_check": 1513364941
+					},
+					{
+						"name": "never",
+						"succeeded": false,
+						"consecutive_failures": 0,
+						"unscheduled": "never"
+					},
+					{
+						"name": "on-demand",
+						"succeeded": false,
+						"consecutive_failures": 0,
+						"unscheduled": "on_demand"
+					},
+					{
+						"name": "ttl",
+						"last_checked": 1513364881,
+						"succeeded": true,
+						"consecutive_failures": 0,
+						"next_check": 1513303600,
+						"check_ttl": "1h0m0s",
+						"last_scheduled_check": 1513300000,
+						"last_scheduled_check_succeeded": true
+					}
+				]`))
+			})
+
+			Context("when the pipeline is paused", func() {
+				BeforeEach(func() {
+					fakePipeline.PausedReturns(true)
+				})
+
+				It("does not schedule any checks", func() {
+					var statuses []atc.ResourceCheckStatus
+					Expect(json.NewDecoder(response.Body).Decode(&statuses)).To(Succeed())
+
+					Expect(statuses[0].NextCheck).To(BeZero())
+					Expect(statuses[0].Unscheduled).To(Equal(atc.CheckUnscheduledPaused))
+				})
+			})
+
+			Context("when getting the resources fails", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourcesReturns(nil, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/checks", func() {
+		var (
+			response *http.Response
+			query    string
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/resources/some-resource/checks" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+				fakePipeline.PublicReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			var fakeResource *dbfakes.FakeResource
+
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				fakeResource = new(dbfakes.FakeResource)
+				fakePipeline.NameReturns("a-pipeline")
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+			})
+
+			Context("when the resource has check records", func() {
+				BeforeEach(func() {
+					fakeResource.CheckRecordsReturns([]db.CheckRecord{
+						{
+							ID:        3,
+							Status:    atc.StatusStarted,
+							StartTime: time.Unix(1513364900, 0),
+						},
+						{
+							ID:            2,
+							Status:        atc.StatusSucceeded,
+							StartTime:     time.Unix(1513364881, 0),
+							EndTime:       time.Unix(1513364890, 0),
+							VersionsAdded: 2,
+						},
+						{
+							ID:        1,
+							Status:    atc.StatusFailed,
+							StartTime: time.Unix(1513364800, 0),
+							EndTime:   time.Unix(1513364810, 0),
+							Error:     "exit status 1",
+						},
+					}, db.Pagination{
+						Older: &db.Page{To: db.NewIntPtr(0), Limit: 3},
+						Newer: &db.Page{From: db.NewIntPtr(4), Limit: 3},
+					}, nil)
+				})
+
+				It("looks up the resource", func() {
+					Expect(fakePipeline.ResourceCallCount()).To(Equal(1))
+					Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("some-resource"))
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the check records", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 3,
+							"status": "started",
+							"start_time": 1513364900,
+							"versions_added": 0
+						},
+						{
+							"id": 2,
+							"status": "succeeded",
+							"start_time": 1513364881,
+							"end_time": 1513364890,
+							"versions_added": 2
+						},
+						{
+							"id": 1,
+							"status": "failed",
+							"start_time": 1513364800,
+							"end_time": 1513364810,
+							"error": "exit status 1",
+							"versions_added": 0
+						}
+					]`))
+				})
+
+				It("returns the pagination links", func() {
+					Expect(response.Header["Link"]).To(ConsistOf([]string{
+						`<https://example.com/api/v1/teams/a-team/pipelines/a-pipeline/resources/some-resource/checks?limit=3&to=0>; rel="next"`,
+						`<https://example.com/api/v1/teams/a-team/pipelines/a-pipeline/resources/some-resource/checks?from=4&limit=3>; rel="previous"`,
+					}))
+				})
+
+				Context("when the pipeline has instance vars", func() {
+					BeforeEach(func() {
+						fakePipeline.InstanceVarsReturns(atc.InstanceVars{"branch": "master"})
+					})
+
+					It("includes them in the pagination links", func() {
+						Expect(response.Header["Link"]).To(ConsistOf([]string{
+							`<https://example.com/api/v1/teams/a-team/pipelines/a-pipeline/resources/some-resource/checks?limit=3&to=0&vars.branch=%22master%22>; rel="next"`,
+							`<https://example.com/api/v1/teams/a-team/pipelines/a-pipeline/resources/some-resource/checks?from=4&limit=3&vars.branch=%22master%22>; rel="previous"`,
+						}))
+					})
+				})
+			})
+
+			Context("when a page is requested", func() {
+				BeforeEach(func() {
+					query = "?to=10&limit=2"
+				})
+
+				It("passes it along", func() {
+					Expect(fakeResource.CheckRecordsCallCount()).To(Equal(1))
+					Expect(fakeResource.CheckRecordsArgsForCall(0)).To(Equal(db.Page{To: db.NewIntPtr(10), Limit: 2}))
+				})
+			})
+
+			Context("when no page is requested", func() {
+				It("uses the default limit", func() {
+					Expect(fakeResource.CheckRecordsArgsForCall(0)).To(Equal(db.Page{Limit: atc.PaginationAPIDefaultLimit}))
+				})
+
+				It("returns an empty list", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`[]`))
+				})
+			})
+
+			Context("when the resource does not exist", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the check records fails", func() {
+				BeforeEach(func() {
+					fakeResource.CheckRecordsReturns(nil, db.Pagination{}, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/pending-checks", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/pending-checks")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+				fakePipeline.PublicReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				fakePipeline.PendingChecksReturns([]db.PendingCheck{
+					{
+						BuildID:      1,
+						ResourceName: "some-resource",
+						CreateTime:   time.Unix(1513364881, 0),
+						Running:      true,
+					},
+					{
+						BuildID:           2,
+						ResourceTypeName:  "some-type",
+						CreateTime:        time.Unix(1513364882, 0),
+						ManuallyTriggered: true,
+					},
+				}, nil)
+			})
+
+			It("returns the pending checks", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"build_id": 1,
+						"resource_name": "some-resource",
+						"status": "running",
+						"create_time": 1513364881
+					},
+					{
+						"build_id": 2,
+						"resource_type_name": "some-type",
+						"status": "queued",
+						"create_time": 1513364882,
+						"manually_triggered": true
+					}
+				]`))
+			})
+
+			Context("when getting the pending checks fails", func() {
+				BeforeEach(func() {
+					fakePipeline.PendingChecksReturns(nil, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", func() {
 		var response *http.Response
 		var fakeResource *dbfakes.FakeResource
@@ -494,6 +1010,160 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pause_checking", func() {
+		var response *http.Response
+		var fakeResource *dbfakes.FakeResource
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/pause_checking", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated and authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when finding the resource succeeds", func() {
+				BeforeEach(func() {
+					fakeResource = new(dbfakes.FakeResource)
+					fakeResource.IDReturns(1)
+					fakePipeline.ResourceReturns(fakeResource, true, nil)
+				})
+
+				It("pauses checking of the resource", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("resource-name"))
+					Expect(fakeResource.PauseCheckingCallCount()).To(Equal(1))
+				})
+
+				Context("when pausing checking fails", func() {
+					BeforeEach(func() {
+						fakeResource.PauseCheckingReturns(errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the resource is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns not found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpause_checking", func() {
+		var response *http.Response
+		var fakeResource *dbfakes.FakeResource
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/unpause_checking", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated and authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when finding the resource succeeds", func() {
+				BeforeEach(func() {
+					fakeResource = new(dbfakes.FakeResource)
+					fakeResource.IDReturns(1)
+					fakePipeline.ResourceReturns(fakeResource, true, nil)
+				})
+
+				It("unpauses checking of the resource", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("resource-name"))
+					Expect(fakeResource.UnpauseCheckingCallCount()).To(Equal(1))
+				})
+
+				Context("when unpausing checking fails", func() {
+					BeforeEach(func() {
+						fakeResource.UnpauseCheckingReturns(errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the resource is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns not found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pin_comment", func() {
 		var response *http.Response
 		var pinCommentRequestBody atc.SetPinCommentRequestBody
@@ -659,6 +1329,17 @@ var _ = Describe("Resources API", func() {
 					fakePipeline.ResourceReturns(fakeResource, true, nil)
 				})
 
+				Context("when checking of the resource is paused", func() {
+					BeforeEach(func() {
+						fakeResource.CheckPausedReturns(true)
+					})
+
+					It("returns 409 without creating a check", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(0))
+					})
+				})
+
 				Context("when looking up the resource types fails", func() {
 					BeforeEach(func() {
 						fakePipeline.ResourceTypesReturns(nil, errors.New("nope"))
@@ -1000,6 +1681,7 @@ var _ = Describe("Resources API", func() {
 					resource1.NameReturns("resource-1")
 					resource1.TypeReturns("type-1")
 					resource1.LastCheckEndTimeReturns(time.Unix(1513364881, 0))
+					resource1.LastCheckContainerHandleReturns("some-handle")
 					resource1.BuildSummaryReturns(&atc.BuildSummary{
 						ID:                   123,
 						Name:                 "123",
@@ -1038,6 +1720,7 @@ var _ = Describe("Resources API", func() {
 						"team_name": "a-team",
 						"type": "type-1",
 						"last_checked": 1513364881,
+						"last_check_container": "some-handle",
 						"build": {
 							"id": 123,
 							"name": "123",
@@ -42,6 +42,13 @@ func (s *Server) CheckResource(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		if dbResource.CheckPaused() {
+			logger.Info("check-paused")
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte("checking of the resource is paused"))
+			return
+		}
+
 		dbResourceTypes, err := dbPipeline.ResourceTypes()
 		if err != nil {
 			logger.Error("failed-to-get-resource-types", err)
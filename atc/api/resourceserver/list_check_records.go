@@ -0,0 +1,119 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListResourceCheckRecords(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-resource-check-records")
+
+		resourceName := r.FormValue(":resource_name")
+		teamName := r.FormValue(":team_name")
+
+		limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+		if limit == 0 {
+			limit = atc.PaginationAPIDefaultLimit
+		}
+
+		page := db.Page{Limit: limit}
+		if urlFrom := r.FormValue(atc.PaginationQueryFrom); urlFrom != "" {
+			from, _ := strconv.Atoi(urlFrom)
+			page.From = db.NewIntPtr(from)
+		}
+		if urlTo := r.FormValue(atc.PaginationQueryTo); urlTo != "" {
+			to, _ := strconv.Atoi(urlTo)
+			page.To = db.NewIntPtr(to)
+		}
+
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err, lager.Data{"resource-name": resourceName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("resource-not-found", lager.Data{"resource-name": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		records, pagination, err := resource.CheckRecords(page)
+		if err != nil {
+			logger.Error("failed-to-get-check-records", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		pipelineRef := atc.PipelineRef{
+			Name:         pipeline.Name(),
+			InstanceVars: pipeline.InstanceVars(),
+		}
+		if pagination.Older != nil {
+			s.addCheckRecordsLink(w, teamName, pipelineRef, resourceName, *pagination.Older, atc.LinkRelNext)
+		}
+
+		if pagination.Newer != nil {
+			s.addCheckRecordsLink(w, teamName, pipelineRef, resourceName, *pagination.Newer, atc.LinkRelPrevious)
+		}
+
+		presented := []atc.CheckRecord{}
+		for _, record := range records {
+			check := atc.CheckRecord{
+				ID:            record.ID,
+				Status:        record.Status,
+				StartTime:     record.StartTime.Unix(),
+				Error:         record.Error,
+				VersionsAdded: record.VersionsAdded,
+			}
+
+			if !record.EndTime.IsZero() {
+				check.EndTime = record.EndTime.Unix()
+			}
+
+			presented = append(presented, check)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-check-records", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) addCheckRecordsLink(w http.ResponseWriter, teamName string, pipelineRef atc.PipelineRef, resourceName string, page db.Page, rel string) {
+	query := url.Values{}
+	if page.From != nil {
+		query.Set(atc.PaginationQueryFrom, strconv.Itoa(*page.From))
+	}
+	if page.To != nil {
+		query.Set(atc.PaginationQueryTo, strconv.Itoa(*page.To))
+	}
+	query.Set(atc.PaginationQueryLimit, strconv.Itoa(page.Limit))
+
+	for k, vs := range pipelineRef.QueryParams() {
+		query[k] = vs
+	}
+
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/teams/%s/pipelines/%s/resources/%s/checks?%s>; rel="%s"`,
+		s.externalURL,
+		teamName,
+		pipelineRef.Name,
+		resourceName,
+		query.Encode(),
+		rel,
+	))
+}
@@ -0,0 +1,98 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListResourceChecks(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-resource-checks")
+
+		resources, err := pipeline.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		statuses := []atc.ResourceCheckStatus{}
+		for _, resource := range resources {
+			statuses = append(statuses, s.checkStatus(pipeline, resource))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(w).Encode(statuses)
+		if err != nil {
+			logger.Error("failed-to-encode-resource-checks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) checkStatus(pipeline db.Pipeline, resource db.Resource) atc.ResourceCheckStatus {
+	status := atc.ResourceCheckStatus{
+		Name:                resource.Name(),
+		Succeeded:           resource.LastCheckSucceeded(),
+		ConsecutiveFailures: resource.CheckFailures(),
+	}
+
+	lastChecked := resource.LastCheckEndTime()
+	if !lastChecked.IsZero() {
+		status.LastChecked = lastChecked.Unix()
+	}
+
+	if !resource.LastCheckSuccessTime().IsZero() {
+		status.LastSucceeded = resource.LastCheckSuccessTime().Unix()
+	}
+
+	lastScheduled := resource.LastScheduledCheckEndTime()
+	if !lastScheduled.IsZero() {
+		status.LastScheduledCheck = lastScheduled.Unix()
+		status.LastScheduledCheckSucceeded = resource.LastScheduledCheckSucceeded()
+	}
+
+	// a check TTL schedules checks even when they are otherwise left to
+	// webhooks or to being run on demand
+	ttl := resource.CheckTTL()
+	if ttl > 0 {
+		status.CheckTTL = ttl.String()
+	}
+
+	never := resource.CheckEvery() != nil && resource.CheckEvery().Never
+
+	switch {
+	case never && ttl == 0:
+		status.Unscheduled = atc.CheckUnscheduledNever
+	case resource.CheckOnDemand() && ttl == 0:
+		status.Unscheduled = atc.CheckUnscheduledOnDemand
+	case pipeline.Paused():
+		status.Unscheduled = atc.CheckUnscheduledPaused
+	default:
+		// never checked, so due right away
+		nextCheck := time.Now()
+		if !lastChecked.IsZero() {
+			nextCheck = lastChecked.Add(s.checkFactory.CheckInterval(resource))
+		}
+
+		if ttl > 0 {
+			expiry := time.Now()
+			if !lastScheduled.IsZero() {
+				expiry = lastScheduled.Add(ttl)
+			}
+
+			if never || resource.CheckOnDemand() || expiry.Before(nextCheck) {
+				nextCheck = expiry
+			}
+		}
+
+		status.NextCheck = nextCheck.Unix()
+	}
+
+	return status
+}
@@ -0,0 +1,47 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListPendingChecks(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-pending-checks")
+
+		checks, err := pipeline.PendingChecks()
+		if err != nil {
+			logger.Error("failed-to-get-pending-checks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.PendingCheck{}
+		for _, check := range checks {
+			status := atc.PendingCheckQueued
+			if check.Running {
+				status = atc.PendingCheckRunning
+			}
+
+			presented = append(presented, atc.PendingCheck{
+				BuildID:           check.BuildID,
+				ResourceName:      check.ResourceName,
+				ResourceTypeName:  check.ResourceTypeName,
+				Status:            status,
+				CreateTime:        check.CreateTime.Unix(),
+				ManuallyTriggered: check.ManuallyTriggered,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-pending-checks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
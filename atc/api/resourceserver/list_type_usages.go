@@ -0,0 +1,63 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListResourceTypeUsages(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-resource-type-usages")
+
+	filter := db.ResourceTypeUsageFilter{
+		Type:  r.URL.Query().Get("type"),
+		Image: r.URL.Query().Get("image"),
+	}
+
+	if filter.Type == "" && filter.Image == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("either a type or an image must be given"))
+		return
+	}
+
+	acc := accessor.GetAccessor(r)
+
+	var (
+		dbUsages []db.ResourceTypeUsage
+		err      error
+	)
+	if acc.IsAdmin() {
+		dbUsages, err = s.resourceFactory.AllResourceTypeUsages(filter)
+	} else {
+		dbUsages, err = s.resourceFactory.VisibleResourceTypeUsages(acc.TeamNames(), filter)
+	}
+	if err != nil {
+		logger.Error("failed-to-get-resource-type-usages", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	usages := []atc.ResourceTypeUsage{}
+	for _, usage := range dbUsages {
+		usages = append(usages, atc.ResourceTypeUsage{
+			TeamName:             usage.TeamName,
+			PipelineID:           usage.PipelineID,
+			PipelineName:         usage.PipelineName,
+			PipelineInstanceVars: usage.PipelineInstanceVars,
+			ResourceName:         usage.ResourceName,
+			ResourceTypeName:     usage.ResourceTypeName,
+			Type:                 usage.Type,
+			Image:                usage.Image,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(usages)
+	if err != nil {
+		logger.Error("failed-to-encode-resource-type-usages", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 type Server struct {
 	logger                lager.Logger
+	externalURL           string
 	secretManager         creds.Secrets
 	varSourcePool         creds.VarSourcePool
 	checkFactory          db.CheckFactory
@@ -17,6 +18,7 @@ type Server struct {
 
 func NewServer(
 	logger lager.Logger,
+	externalURL string,
 	secretManager creds.Secrets,
 	varSourcePool creds.VarSourcePool,
 	checkFactory db.CheckFactory,
@@ -25,6 +27,7 @@ func NewServer(
 ) *Server {
 	return &Server{
 		logger:                logger,
+		externalURL:           externalURL,
 		secretManager:         secretManager,
 		varSourcePool:         varSourcePool,
 		checkFactory:          checkFactory,
@@ -0,0 +1,66 @@
+package versionserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) BackfillResourceVersions(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("backfill-resource-versions")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+
+		var versions []atc.Version
+		err := json.NewDecoder(r.Body).Decode(&versions)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		newVersions, err := resource.BackfillVersions(versions)
+		if err != nil {
+			if err == db.ErrResourceNotChecked {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(err.Error()))
+				return
+			}
+
+			logger.Error("failed-to-backfill-resource-versions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		logger.Info("backfilled-resource-versions", lager.Data{
+			"resource":     resourceName,
+			"new_versions": newVersions,
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(atc.BackfillVersionsResponse{
+			NewVersions: newVersions,
+		})
+		if err != nil {
+			logger.Error("failed-to-encode-response", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -30,7 +31,9 @@ func (s *Server) DisableResourceVersion(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		err = resource.DisableVersion(resourceConfigVersionID)
+		acc := accessor.GetAccessor(r)
+
+		err = resource.DisableVersion(resourceConfigVersionID, acc.UserInfo().DisplayUserId, r.URL.Query().Get("reason"))
 		if err != nil {
 			logger.Error("failed-to-disable-resource-version", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -30,7 +31,9 @@ func (s *Server) EnableResourceVersion(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		err = resource.EnableVersion(resourceConfigVersionID)
+		acc := accessor.GetAccessor(r)
+
+		err = resource.EnableVersion(resourceConfigVersionID, acc.UserInfo().DisplayUserId, r.URL.Query().Get("reason"))
 		if err != nil {
 			logger.Error("failed-to-enable-resource-version", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,79 @@
+package versionserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ExpireResourceVersionCaches(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("expire-resource-version-caches")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+
+		versionID, err := strconv.Atoi(r.FormValue(":resource_config_version_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		resourceCaches, err := s.resourceCacheFactory.FindResourceCachesForVersion(versionID)
+		if err != nil {
+			logger.Error("failed-to-find-resource-caches", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var response atc.ExpireResourceCachesResponse
+		for _, resourceCache := range resourceCaches {
+			// only expire caches fetched with this resource's config, so that a
+			// version id of another pipeline cannot be used to expire its caches
+			if resourceCache.ResourceConfig().ID() != resource.ResourceConfigID() {
+				continue
+			}
+
+			volumes, err := s.resourceCacheFactory.ExpireResourceCache(resourceCache)
+			if err != nil {
+				logger.Error("failed-to-expire-resource-cache", err, lager.Data{"resource-cache": resourceCache.ID()})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			response.ResourceCaches++
+			response.Volumes += volumes
+		}
+
+		logger.Info("expired-resource-caches", lager.Data{
+			"resource":        resourceName,
+			"version":         versionID,
+			"resource-caches": response.ResourceCaches,
+			"volumes":         response.Volumes,
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			logger.Error("failed-to-encode-response", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
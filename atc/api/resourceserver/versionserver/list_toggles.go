@@ -0,0 +1,56 @@
+package versionserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListResourceVersionToggles(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("list-resource-version-toggles")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		toggles, err := resource.VersionToggles()
+		if err != nil {
+			logger.Error("failed-to-get-version-toggles", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.ResourceVersionToggle{}
+		for _, toggle := range toggles {
+			presented = append(presented, atc.ResourceVersionToggle{
+				ID:      toggle.ID,
+				Version: toggle.Version,
+				Enabled: toggle.Enabled,
+				Actor:   toggle.Actor,
+				Reason:  toggle.Reason,
+				Time:    toggle.Time.Unix(),
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-version-toggles", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -1,10 +1,13 @@
 package versionserver
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
 	"strconv"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -30,7 +33,15 @@ func (s *Server) PinResourceVersion(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		found, err = resource.PinVersion(resourceConfigVersionID)
+		var reqBody atc.PinVersionRequestBody
+		err = json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil && err != io.EOF {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		found, err = resource.PinVersion(resourceConfigVersionID, reqBody.PinComment)
 		if err != nil {
 			logger.Error("failed-to-pin-resource-version", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,54 @@
+package versionserver
+
+import (
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) PromoteResourceVersion(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("promote-resource-version")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		resourceConfigVersionID, err := strconv.Atoi(r.FormValue(":resource_config_version_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		found, err = resource.PromoteVersion(resourceConfigVersionID)
+		if err != nil {
+			if err == db.ErrVersionDisabled {
+				logger.Info("resource-version-disabled", lager.Data{"resource_config_version_id": resourceConfigVersionID})
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
+			logger.Error("failed-to-promote-resource-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-version-id-not-found", lager.Data{"resource_config_version_id": resourceConfigVersionID})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
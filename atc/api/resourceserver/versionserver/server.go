@@ -2,16 +2,19 @@ package versionserver
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
 )
 
 type Server struct {
-	logger      lager.Logger
-	externalURL string
+	logger               lager.Logger
+	externalURL          string
+	resourceCacheFactory db.ResourceCacheFactory
 }
 
-func NewServer(logger lager.Logger, externalURL string) *Server {
+func NewServer(logger lager.Logger, externalURL string, resourceCacheFactory db.ResourceCacheFactory) *Server {
 	return &Server{
-		logger:      logger,
-		externalURL: externalURL,
+		logger:               logger,
+		externalURL:          externalURL,
+		resourceCacheFactory: resourceCacheFactory,
 	}
 }
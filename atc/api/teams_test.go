@@ -314,6 +314,71 @@ var _ = Describe("Teams API", func() {
 
 			authorizedTeamTests()
 
+			Context("when the max concurrent builds is given", func() {
+				BeforeEach(func() {
+					limit := 5
+					atcTeam.MaxConcurrentBuilds = &limit
+				})
+
+				Context("when the team exists", func() {
+					BeforeEach(func() {
+						dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+					})
+
+					It("updates the max concurrent builds", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateMaxConcurrentBuildsCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdateMaxConcurrentBuildsArgsForCall(0)).To(Equal(5))
+					})
+
+					Context("when updating it fails", func() {
+						BeforeEach(func() {
+							fakeTeam.UpdateMaxConcurrentBuildsReturns(errors.New("nope"))
+						})
+
+						It("returns 500 Internal Server error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when the team is not found", func() {
+					BeforeEach(func() {
+						dbTeamFactory.FindTeamReturns(nil, false, nil)
+						dbTeamFactory.CreateTeamReturns(fakeTeam, nil)
+					})
+
+					It("creates the team with it", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+						Expect(*dbTeamFactory.CreateTeamArgsForCall(0).MaxConcurrentBuilds).To(Equal(5))
+					})
+				})
+
+				Context("when it is negative", func() {
+					BeforeEach(func() {
+						limit := -1
+						atcTeam.MaxConcurrentBuilds = &limit
+						dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(fakeTeam.UpdateMaxConcurrentBuildsCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			Context("when the max concurrent builds is omitted", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("leaves it as is", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeTeam.UpdateMaxConcurrentBuildsCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("when the team is not found", func() {
 				BeforeEach(func() {
 					dbTeamFactory.FindTeamReturns(nil, false, nil)
@@ -382,6 +447,20 @@ var _ = Describe("Teams API", func() {
 
 			authorizedTeamTests()
 
+			Context("when the max concurrent builds is given", func() {
+				BeforeEach(func() {
+					limit := 5
+					atcTeam.MaxConcurrentBuilds = &limit
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(0))
+					Expect(fakeTeam.UpdateMaxConcurrentBuildsCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("when the team is not found", func() {
 				BeforeEach(func() {
 					dbTeamFactory.FindTeamReturns(nil, false, nil)
@@ -789,4 +868,691 @@ var _ = Describe("Teams API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/usage/teams", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/usage/teams", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.TeamNamesReturns([]string{"some-team"})
+
+				dbTeamUsageFactory.TeamUsageReturns([]db.TeamUsage{
+					{
+						TeamName:   "some-team",
+						Pipelines:  2,
+						Resources:  10,
+						Containers: 5,
+						Volumes:    7,
+						UpdatedAt:  time.Unix(42, 0),
+					},
+				}, nil)
+			})
+
+			It("returns 200 with Content-Type 'application/json'", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response).Should(IncludeHeaderEntries(map[string]string{
+					"Content-Type": "application/json",
+				}))
+			})
+
+			It("returns the usage of the teams the user belongs to", func() {
+				Expect(dbTeamUsageFactory.TeamUsageCallCount()).To(Equal(1))
+				Expect(dbTeamUsageFactory.TeamUsageArgsForCall(0)).To(ConsistOf("some-team"))
+				Expect(dbTeamUsageFactory.AllTeamUsageCallCount()).To(BeZero())
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`[
+					{
+						"team": "some-team",
+						"pipelines": 2,
+						"resources": 10,
+						"containers": 5,
+						"volumes": 7,
+						"updated_at": 42
+					}
+				]`))
+			})
+
+			Context("when the user is an admin", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAdminReturns(true)
+					dbTeamUsageFactory.AllTeamUsageReturns([]db.TeamUsage{}, nil)
+				})
+
+				It("returns the usage of every team", func() {
+					Expect(dbTeamUsageFactory.AllTeamUsageCallCount()).To(Equal(1))
+					Expect(dbTeamUsageFactory.TeamUsageCallCount()).To(BeZero())
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`[]`))
+				})
+			})
+
+			Context("when getting the usage fails", func() {
+				BeforeEach(func() {
+					dbTeamUsageFactory.TeamUsageReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/shared-artifacts", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/shared-artifacts")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				fakeTeam.SharedArtifactsReturns([]db.SharedArtifact{
+					{
+						Name:         "some-artifact",
+						BuildID:      42,
+						Size:         1024,
+						PublishedAt:  time.Unix(100, 0),
+						GrantedTeams: []string{"other-team"},
+					},
+					{
+						Name:         "future-artifact",
+						GrantedTeams: []string{"other-team"},
+					},
+				}, nil)
+			})
+
+			It("returns 200 OK with the team's shared artifacts", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{
+						"name": "some-artifact",
+						"build_id": 42,
+						"size": 1024,
+						"published_at": 100,
+						"granted_teams": ["other-team"]
+					},
+					{
+						"name": "future-artifact",
+						"granted_teams": ["other-team"]
+					}
+				]`))
+			})
+
+			Context("when getting the shared artifacts fails", func() {
+				BeforeEach(func() {
+					fakeTeam.SharedArtifactsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("PUT and DELETE /api/v1/teams/:team_name/shared-artifacts/:artifact_name/grants/:grantee_team_name", func() {
+		var (
+			method      string
+			response    *http.Response
+			granteeTeam *dbfakes.FakeTeam
+		)
+
+		BeforeEach(func() {
+			method = "PUT"
+
+			granteeTeam = new(dbfakes.FakeTeam)
+			granteeTeam.IDReturns(2)
+			granteeTeam.NameReturns("other-team")
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest(method, server.URL+"/api/v1/teams/some-team/shared-artifacts/some-artifact/grants/other-team", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.GrantSharedArtifactCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				dbTeamFactory.FindTeamStub = func(name string) (db.Team, bool, error) {
+					switch name {
+					case "some-team":
+						return fakeTeam, true, nil
+					case "other-team":
+						return granteeTeam, true, nil
+					default:
+						return nil, false, nil
+					}
+				}
+			})
+
+			It("grants the artifact to the other team", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				Expect(fakeTeam.GrantSharedArtifactCallCount()).To(Equal(1))
+				name, granteeTeamID := fakeTeam.GrantSharedArtifactArgsForCall(0)
+				Expect(name).To(Equal("some-artifact"))
+				Expect(granteeTeamID).To(Equal(2))
+			})
+
+			Context("when revoking", func() {
+				BeforeEach(func() {
+					method = "DELETE"
+				})
+
+				It("revokes the other team's grant", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+					Expect(fakeTeam.RevokeSharedArtifactCallCount()).To(Equal(1))
+					name, granteeTeamID := fakeTeam.RevokeSharedArtifactArgsForCall(0)
+					Expect(name).To(Equal("some-artifact"))
+					Expect(granteeTeamID).To(Equal(2))
+				})
+			})
+
+			Context("when the other team does not exist", func() {
+				BeforeEach(func() {
+					granteeTeam = nil
+					dbTeamFactory.FindTeamStub = func(name string) (db.Team, bool, error) {
+						if name == "some-team" {
+							return fakeTeam, true, nil
+						}
+						return nil, false, nil
+					}
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(fakeTeam.GrantSharedArtifactCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when granting fails", func() {
+				BeforeEach(func() {
+					fakeTeam.GrantSharedArtifactReturns(errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipeline-vars/:var_name", func() {
+		var (
+			setRequest atc.SetPipelineVarRequest
+			response   *http.Response
+
+			pipeline1 *dbfakes.FakePipeline
+			pipeline2 *dbfakes.FakePipeline
+		)
+
+		BeforeEach(func() {
+			setRequest = atc.SetPipelineVarRequest{Value: "new-password"}
+
+			pipeline1 = new(dbfakes.FakePipeline)
+			pipeline1.IDReturns(1)
+			pipeline1.NameReturns("pipeline-1")
+
+			pipeline2 = new(dbfakes.FakePipeline)
+			pipeline2.NameReturns("pipeline-2")
+			pipeline2.IDReturns(2)
+			pipeline2.InstanceVarsReturns(atc.InstanceVars{"branch": "main"})
+
+			fakeTeam.PipelinesReturns([]db.Pipeline{pipeline1, pipeline2}, nil)
+			fakeTeam.SetPipelinesVarReturns(map[int]bool{1: true, 2: false}, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/pipeline-vars/registry-password", jsonEncode(setRequest))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.SetPipelinesVarCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("sets the var of every pipeline at once without creating it", func() {
+				Expect(fakeTeam.SetPipelinesVarCallCount()).To(Equal(1))
+				pipelineIDs, name, value, create := fakeTeam.SetPipelinesVarArgsForCall(0)
+				Expect(pipelineIDs).To(Equal([]int{1, 2}))
+				Expect(name).To(Equal("registry-password"))
+				Expect(value).To(Equal("new-password"))
+				Expect(create).To(BeFalse())
+			})
+
+			It("returns which pipelines were updated", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`[
+					{"pipeline": {"name": "pipeline-1"}, "updated": true},
+					{"pipeline": {"name": "pipeline-2", "instance_vars": {"branch": "main"}}, "updated": false}
+				]`))
+			})
+
+			Context("when creating the var", func() {
+				BeforeEach(func() {
+					setRequest.Create = true
+				})
+
+				It("asks the pipelines to create it", func() {
+					_, _, _, create := fakeTeam.SetPipelinesVarArgsForCall(0)
+					Expect(create).To(BeTrue())
+				})
+			})
+
+			Context("when pipelines are named", func() {
+				BeforeEach(func() {
+					setRequest.Pipelines = []string{"pipeline-2"}
+				})
+
+				It("only sets the var of those pipelines", func() {
+					pipelineIDs, _, _, _ := fakeTeam.SetPipelinesVarArgsForCall(0)
+					Expect(pipelineIDs).To(Equal([]int{2}))
+				})
+			})
+
+			Context("when the value is missing", func() {
+				BeforeEach(func() {
+					setRequest.Value = nil
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.SetPipelinesVarCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when setting the var fails", func() {
+				BeforeEach(func() {
+					fakeTeam.SetPipelinesVarReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipeline-vars", func() {
+		var (
+			response *http.Response
+
+			pipeline1 *dbfakes.FakePipeline
+			pipeline2 *dbfakes.FakePipeline
+		)
+
+		BeforeEach(func() {
+			pipeline1 = new(dbfakes.FakePipeline)
+			pipeline1.NameReturns("pipeline-1")
+			pipeline1.VarNamesReturns([]string{"registry-password", "some-var"}, nil)
+
+			pipeline2 = new(dbfakes.FakePipeline)
+			pipeline2.NameReturns("pipeline-2")
+			pipeline2.InstanceVarsReturns(atc.InstanceVars{"branch": "main"})
+			pipeline2.VarNamesReturns([]string{}, nil)
+
+			fakeTeam.PipelinesReturns([]db.Pipeline{pipeline1, pipeline2}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipeline-vars")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns the names of each pipeline's vars", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`[
+					{"pipeline": {"name": "pipeline-1"}, "vars": ["registry-password", "some-var"]},
+					{"pipeline": {"name": "pipeline-2", "instance_vars": {"branch": "main"}}, "vars": []}
+				]`))
+			})
+
+			Context("when getting the var names fails", func() {
+				BeforeEach(func() {
+					pipeline1.VarNamesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/pipeline-vars/:var_name", func() {
+		var (
+			query    string
+			response *http.Response
+
+			pipeline1 *dbfakes.FakePipeline
+			pipeline2 *dbfakes.FakePipeline
+		)
+
+		BeforeEach(func() {
+			query = ""
+
+			pipeline1 = new(dbfakes.FakePipeline)
+			pipeline1.NameReturns("pipeline-1")
+			pipeline1.DeleteVarReturns(true, nil)
+
+			pipeline2 = new(dbfakes.FakePipeline)
+			pipeline2.NameReturns("pipeline-2")
+			pipeline2.DeleteVarReturns(false, nil)
+
+			fakeTeam.PipelinesReturns([]db.Pipeline{pipeline1, pipeline2}, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/pipeline-vars/registry-password"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(pipeline1.DeleteVarCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("deletes the var of every pipeline", func() {
+				Expect(pipeline1.DeleteVarCallCount()).To(Equal(1))
+				Expect(pipeline1.DeleteVarArgsForCall(0)).To(Equal("registry-password"))
+				Expect(pipeline2.DeleteVarCallCount()).To(Equal(1))
+			})
+
+			It("returns which pipelines had it", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`[
+					{"pipeline": {"name": "pipeline-1"}, "updated": true},
+					{"pipeline": {"name": "pipeline-2"}, "updated": false}
+				]`))
+			})
+
+			Context("when pipelines are named", func() {
+				BeforeEach(func() {
+					query = "?pipeline=pipeline-2"
+				})
+
+				It("only deletes the var of those pipelines", func() {
+					Expect(pipeline1.DeleteVarCallCount()).To(BeZero())
+					Expect(pipeline2.DeleteVarCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when deleting the var fails", func() {
+				BeforeEach(func() {
+					pipeline1.DeleteVarReturns(false, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/resource-types", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/resource-types")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				fakeTeam.ResourceTypesReturns(atc.ResourceTypes{
+					{
+						Name:   "some-type",
+						Type:   "registry-image",
+						Source: atc.Source{"repository": "some/image"},
+					},
+				}, nil)
+			})
+
+			It("returns 200 OK with the team's resource types", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{
+						"name": "some-type",
+						"type": "registry-image",
+						"source": {"repository": "some/image"}
+					}
+				]`))
+			})
+
+			Context("when getting the resource types fails", func() {
+				BeforeEach(func() {
+					fakeTeam.ResourceTypesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/resource-types", func() {
+		var (
+			resourceTypes atc.ResourceTypes
+			response      *http.Response
+		)
+
+		BeforeEach(func() {
+			resourceTypes = atc.ResourceTypes{
+				{
+					Name:   "some-type",
+					Type:   "registry-image",
+					Source: atc.Source{"repository": "some/image"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/resource-types", jsonEncode(resourceTypes))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.SaveResourceTypesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("saves the resource types", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(fakeTeam.SaveResourceTypesCallCount()).To(Equal(1))
+				Expect(fakeTeam.SaveResourceTypesArgsForCall(0)).To(Equal(resourceTypes))
+			})
+
+			It("notifies the resource scanner", func() {
+				Expect(dbTeamFactory.NotifyResourceScannerCallCount()).To(Equal(1))
+			})
+
+			Context("when the resource types are invalid", func() {
+				BeforeEach(func() {
+					resourceTypes = atc.ResourceTypes{{Name: "some-type"}}
+				})
+
+				It("returns 400 Bad Request with the errors", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					var saveResponse atc.SaveConfigResponse
+					err := json.NewDecoder(response.Body).Decode(&saveResponse)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(saveResponse.Errors).To(ConsistOf(ContainSubstring("resource_types.some-type has no type")))
+
+					Expect(fakeTeam.SaveResourceTypesCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when saving fails", func() {
+				BeforeEach(func() {
+					fakeTeam.SaveResourceTypesReturns(errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,188 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// ListPipelineVars lists the names of the local vars of every pipeline of the
+// team.
+func (s *Server) ListPipelineVars(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-pipeline-vars")
+
+		pipelines, err := team.Pipelines()
+		if err != nil {
+			logger.Error("failed-to-get-pipelines", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.PipelineVars{}
+		for _, pipeline := range pipelines {
+			names, err := pipeline.VarNames()
+			if err != nil {
+				logger.Error("failed-to-get-var-names", err, lager.Data{"pipeline": pipeline.Name()})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			presented = append(presented, atc.PipelineVars{
+				Pipeline: atc.PipelineRef{
+					Name:         pipeline.Name(),
+					InstanceVars: pipeline.InstanceVars(),
+				},
+				Vars: names,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-pipeline-vars", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// SetPipelineVar sets a local var of every pipeline of the team, or of the
+// ones named in the request. Pipelines which don't have the var yet are
+// skipped unless the request asks for it to be created.
+func (s *Server) SetPipelineVar(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("set-pipeline-var")
+
+		varName := r.FormValue(":var_name")
+
+		var request atc.SetPipelineVarRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if request.Value == nil {
+			logger.Info("missing-value")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		pipelines, err := team.Pipelines()
+		if err != nil {
+			logger.Error("failed-to-get-pipelines", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// the var is set on all of the pipelines at once, so that a failure
+		// half way through doesn't leave some with the old value
+		named := namedPipelines(pipelines, request.Pipelines)
+
+		var pipelineIDs []int
+		for _, pipeline := range named {
+			pipelineIDs = append(pipelineIDs, pipeline.ID())
+		}
+
+		set, err := team.SetPipelinesVar(pipelineIDs, varName, request.Value, request.Create)
+		if err != nil {
+			logger.Error("failed-to-set-var", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		updates := []atc.PipelineVarUpdate{}
+		for _, pipeline := range named {
+			updates = append(updates, atc.PipelineVarUpdate{
+				Pipeline: atc.PipelineRef{
+					Name:         pipeline.Name(),
+					InstanceVars: pipeline.InstanceVars(),
+				},
+				Updated: set[pipeline.ID()],
+			})
+		}
+
+		// the value is a credential; only its name is logged
+		logger.Info("set", lager.Data{"var": varName, "pipelines": len(updates)})
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(updates)
+		if err != nil {
+			logger.Error("failed-to-encode-updates", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// DeletePipelineVar deletes a local var of every pipeline of the team, or of
+// the ones named by the pipeline query params.
+func (s *Server) DeletePipelineVar(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("delete-pipeline-var")
+
+		varName := r.FormValue(":var_name")
+
+		pipelines, err := team.Pipelines()
+		if err != nil {
+			logger.Error("failed-to-get-pipelines", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		updates := []atc.PipelineVarUpdate{}
+		for _, pipeline := range namedPipelines(pipelines, r.URL.Query()["pipeline"]) {
+			deleted, err := pipeline.DeleteVar(varName)
+			if err != nil {
+				logger.Error("failed-to-delete-var", err, lager.Data{"pipeline": pipeline.Name()})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			updates = append(updates, atc.PipelineVarUpdate{
+				Pipeline: atc.PipelineRef{
+					Name:         pipeline.Name(),
+					InstanceVars: pipeline.InstanceVars(),
+				},
+				Updated: deleted,
+			})
+		}
+
+		logger.Info("deleted", lager.Data{"var": varName, "pipelines": len(updates)})
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(updates)
+		if err != nil {
+			logger.Error("failed-to-encode-updates", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// namedPipelines returns the pipelines with the given names, including all of
+// their instances, or every pipeline if no names are given.
+func namedPipelines(pipelines []db.Pipeline, names []string) []db.Pipeline {
+	if len(names) == 0 {
+		return pipelines
+	}
+
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var named []db.Pipeline
+	for _, pipeline := range pipelines {
+		if wanted[pipeline.Name()] {
+			named = append(named, pipeline)
+		}
+	}
+
+	return named
+}
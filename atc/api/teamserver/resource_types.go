@@ -0,0 +1,86 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configvalidate"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetTeamResourceTypes(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-team-resource-types")
+
+		resourceTypes, err := team.ResourceTypes()
+		if err != nil {
+			logger.Error("failed-to-get-resource-types", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(resourceTypes)
+		if err != nil {
+			logger.Error("failed-to-encode-resource-types", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) SetTeamResourceTypes(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("set-team-resource-types")
+
+		var resourceTypes atc.ResourceTypes
+		err := json.NewDecoder(r.Body).Decode(&resourceTypes)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			s.writeSaveResourceTypesResponse(w, http.StatusBadRequest, atc.SaveConfigResponse{
+				Errors: []string{fmt.Sprintf("malformed resource types: %s", err)},
+			})
+			return
+		}
+
+		warnings, errorMessages := configvalidate.ValidateTeamResourceTypes(resourceTypes)
+		if len(errorMessages) > 0 {
+			logger.Info("ignoring-invalid-resource-types", lager.Data{"errors": errorMessages})
+			s.writeSaveResourceTypesResponse(w, http.StatusBadRequest, atc.SaveConfigResponse{
+				Errors: errorMessages,
+			})
+			return
+		}
+
+		err = team.SaveResourceTypes(resourceTypes)
+		if err != nil {
+			logger.Error("failed-to-save-resource-types", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		err = s.teamFactory.NotifyResourceScanner()
+		if err != nil {
+			logger.Error("failed-to-notify-resource-scanner", err)
+		}
+
+		logger.Info("saved", lager.Data{"resource-types": len(resourceTypes)})
+
+		s.writeSaveResourceTypesResponse(w, http.StatusOK, atc.SaveConfigResponse{
+			Warnings: warnings,
+		})
+	})
+}
+
+func (s *Server) writeSaveResourceTypesResponse(w http.ResponseWriter, status int, response atc.SaveConfigResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		s.logger.Error("failed-to-encode-response", err)
+	}
+}
@@ -6,19 +6,22 @@ import (
 )
 
 type Server struct {
-	logger      lager.Logger
-	teamFactory db.TeamFactory
-	externalURL string
+	logger           lager.Logger
+	teamFactory      db.TeamFactory
+	teamUsageFactory db.TeamUsageFactory
+	externalURL      string
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
+	teamUsageFactory db.TeamUsageFactory,
 	externalURL string,
 ) *Server {
 	return &Server{
-		logger:      logger,
-		teamFactory: teamFactory,
-		externalURL: externalURL,
+		logger:           logger,
+		teamFactory:      teamFactory,
+		teamUsageFactory: teamUsageFactory,
+		externalURL:      externalURL,
 	}
 }
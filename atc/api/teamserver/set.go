@@ -42,6 +42,12 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 
 	atcTeam.Name = teamName
 
+	if atcTeam.MaxConcurrentBuilds != nil && !acc.IsAdmin() {
+		hLog.Info("non-admin-setting-max-concurrent-builds", lager.Data{"teamName": teamName})
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	team, found, err := s.teamFactory.FindTeam(teamName)
 	if err != nil {
 		hLog.Error("failed-to-lookup-team", err, lager.Data{"teamName": teamName})
@@ -59,6 +65,15 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if atcTeam.MaxConcurrentBuilds != nil {
+			err = team.UpdateMaxConcurrentBuilds(*atcTeam.MaxConcurrentBuilds)
+			if err != nil {
+				hLog.Error("failed-to-update-max-concurrent-builds", err, lager.Data{"teamName": teamName})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 	} else if acc.IsAdmin() {
@@ -0,0 +1,104 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListSharedArtifacts(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-shared-artifacts")
+
+		artifacts, err := team.SharedArtifacts()
+		if err != nil {
+			logger.Error("failed-to-get-shared-artifacts", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(present.SharedArtifacts(artifacts))
+		if err != nil {
+			logger.Error("failed-to-encode-shared-artifacts", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) GrantSharedArtifact(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("grant-shared-artifact")
+
+		artifactName := r.FormValue(":artifact_name")
+
+		grantee, found := s.findGrantee(logger, w, r)
+		if !found {
+			return
+		}
+
+		err := team.GrantSharedArtifact(artifactName, grantee.ID())
+		if err != nil {
+			logger.Error("failed-to-grant-shared-artifact", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		logger.Info("granted", lager.Data{
+			"artifact": artifactName,
+			"grantee":  grantee.Name(),
+		})
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (s *Server) RevokeSharedArtifact(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("revoke-shared-artifact")
+
+		artifactName := r.FormValue(":artifact_name")
+
+		grantee, found := s.findGrantee(logger, w, r)
+		if !found {
+			return
+		}
+
+		err := team.RevokeSharedArtifact(artifactName, grantee.ID())
+		if err != nil {
+			logger.Error("failed-to-revoke-shared-artifact", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		logger.Info("revoked", lager.Data{
+			"artifact": artifactName,
+			"grantee":  grantee.Name(),
+		})
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (s *Server) findGrantee(logger lager.Logger, w http.ResponseWriter, r *http.Request) (db.Team, bool) {
+	granteeName := r.FormValue(":grantee_team_name")
+
+	grantee, found, err := s.teamFactory.FindTeam(granteeName)
+	if err != nil {
+		logger.Error("failed-to-find-grantee-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if !found {
+		logger.Debug("grantee-team-not-found", lager.Data{"team": granteeName})
+		w.WriteHeader(http.StatusNotFound)
+		return nil, false
+	}
+
+	return grantee, true
+}
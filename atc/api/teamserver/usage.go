@@ -0,0 +1,52 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListTeamUsage(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-team-usage")
+
+	var (
+		usage []db.TeamUsage
+		err   error
+	)
+
+	acc := accessor.GetAccessor(r)
+
+	if acc.IsAdmin() {
+		usage, err = s.teamUsageFactory.AllTeamUsage()
+	} else {
+		usage, err = s.teamUsageFactory.TeamUsage(acc.TeamNames())
+	}
+
+	if err != nil {
+		logger.Error("failed-to-get-team-usage", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presentedUsage := make([]atc.TeamUsage, len(usage))
+	for i, u := range usage {
+		presentedUsage[i] = atc.TeamUsage{
+			Team:       u.TeamName,
+			Pipelines:  u.Pipelines,
+			Resources:  u.Resources,
+			Containers: u.Containers,
+			Volumes:    u.Volumes,
+			UpdatedAt:  u.UpdatedAt.Unix(),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(presentedUsage)
+	if err != nil {
+		logger.Error("failed-to-encode-team-usage", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -3,8 +3,10 @@ package api_test
 import (
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/concourse/concourse/atc"
@@ -478,7 +480,7 @@ var _ = Describe("Versions API", func() {
 		JustBeforeEach(func() {
 			var err error
 
-			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/enable", nil)
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/enable?reason=bad+build", nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			response, err = client.Do(request)
@@ -505,13 +507,21 @@ var _ = Describe("Versions API", func() {
 						fakeResource = new(dbfakes.FakeResource)
 						fakeResource.IDReturns(1)
 						fakePipeline.ResourceReturns(fakeResource, true, nil)
+
+						fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
 					})
 
 					It("tries to enable the right resource config version", func() {
-						resourceConfigVersionID := fakeResource.EnableVersionArgsForCall(0)
+						resourceConfigVersionID, _, _ := fakeResource.EnableVersionArgsForCall(0)
 						Expect(resourceConfigVersionID).To(Equal(42))
 					})
 
+					It("records who enabled it and why", func() {
+						_, actor, reason := fakeResource.EnableVersionArgsForCall(0)
+						Expect(actor).To(Equal("some-user"))
+						Expect(reason).To(Equal("bad build"))
+					})
+
 					Context("when enabling the resource succeeds", func() {
 						BeforeEach(func() {
 							fakeResource.EnableVersionReturns(nil)
@@ -582,7 +592,7 @@ var _ = Describe("Versions API", func() {
 		JustBeforeEach(func() {
 			var err error
 
-			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/disable", nil)
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/disable?reason=bad+build", nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			response, err = client.Do(request)
@@ -609,13 +619,21 @@ var _ = Describe("Versions API", func() {
 						fakeResource = new(dbfakes.FakeResource)
 						fakeResource.IDReturns(1)
 						fakePipeline.ResourceReturns(fakeResource, true, nil)
+
+						fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
 					})
 
 					It("tries to disable the right resource config version", func() {
-						resourceConfigVersionID := fakeResource.DisableVersionArgsForCall(0)
+						resourceConfigVersionID, _, _ := fakeResource.DisableVersionArgsForCall(0)
 						Expect(resourceConfigVersionID).To(Equal(42))
 					})
 
+					It("records who disabled it and why", func() {
+						_, actor, reason := fakeResource.DisableVersionArgsForCall(0)
+						Expect(actor).To(Equal("some-user"))
+						Expect(reason).To(Equal("bad build"))
+					})
+
 					Context("when disabling the resource version succeeds", func() {
 						BeforeEach(func() {
 							fakeResource.DisableVersionReturns(nil)
@@ -678,14 +696,372 @@ var _ = Describe("Versions API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/version-toggles", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/resources/some-resource/version-toggles")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+				fakePipeline.PublicReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			var fakeResource *dbfakes.FakeResource
+
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				fakeResource = new(dbfakes.FakeResource)
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+			})
+
+			Context("when versions of the resource were toggled", func() {
+				BeforeEach(func() {
+					fakeResource.VersionTogglesReturns([]db.VersionToggle{
+						{
+							ID:      2,
+							Version: atc.Version{"some": "version"},
+							Enabled: true,
+							Actor:   "other-user",
+							Time:    time.Unix(1513364900, 0),
+						},
+						{
+							ID:      1,
+							Version: atc.Version{"some": "version"},
+							Enabled: false,
+							Actor:   "some-user",
+							Reason:  "bad build",
+							Time:    time.Unix(1513364800, 0),
+						},
+					}, nil)
+				})
+
+				It("looks up the resource", func() {
+					Expect(fakePipeline.ResourceCallCount()).To(Equal(1))
+					Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("some-resource"))
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the toggles", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 2,
+							"version": {"some": "version"},
+							"enabled": true,
+							"actor": "other-user",
+							"time": 1513364900
+						},
+						{
+							"id": 1,
+							"version": {"some": "version"},
+							"enabled": false,
+							"actor": "some-user",
+							"reason": "bad build",
+							"time": 1513364800
+						}
+					]`))
+				})
+			})
+
+			Context("when no version of the resource was toggled", func() {
+				It("returns an empty list", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`[]`))
+				})
+			})
+
+			Context("when the resource does not exist", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the toggles fails", func() {
+				BeforeEach(func() {
+					fakeResource.VersionTogglesReturns(nil, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/promote", func() {
+		var response *http.Response
+		var fakeResource *dbfakes.FakeResource
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/promote", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated ", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				It("tries to find the resource", func() {
+					resourceName := fakePipeline.ResourceArgsForCall(0)
+					Expect(resourceName).To(Equal("resource-name"))
+				})
+
+				Context("when finding the resource succeeds", func() {
+					BeforeEach(func() {
+						fakeResource = new(dbfakes.FakeResource)
+						fakeResource.IDReturns(1)
+						fakePipeline.ResourceReturns(fakeResource, true, nil)
+					})
+
+					It("tries to promote the right resource config version", func() {
+						resourceConfigVersionID := fakeResource.PromoteVersionArgsForCall(0)
+						Expect(resourceConfigVersionID).To(Equal(42))
+					})
+
+					Context("when promoting the resource version succeeds", func() {
+						BeforeEach(func() {
+							fakeResource.PromoteVersionReturns(true, nil)
+						})
+
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+					})
+
+					Context("when the resource version is not found", func() {
+						BeforeEach(func() {
+							fakeResource.PromoteVersionReturns(false, nil)
+						})
+
+						It("returns 404", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						})
+					})
+
+					Context("when the resource version is disabled", func() {
+						BeforeEach(func() {
+							fakeResource.PromoteVersionReturns(false, db.ErrVersionDisabled)
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+					})
+
+					Context("when promoting the resource version fails", func() {
+						BeforeEach(func() {
+							fakeResource.PromoteVersionReturns(false, errors.New("welp"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when it fails to find the resource", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, errors.New("welp"))
+					})
+
+					It("returns Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the resource is not found", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, nil)
+					})
+
+					It("returns not found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+			})
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/unpromote", func() {
+		var response *http.Response
+		var fakeResource *dbfakes.FakeResource
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/unpromote", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated ", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				It("tries to find the resource", func() {
+					resourceName := fakePipeline.ResourceArgsForCall(0)
+					Expect(resourceName).To(Equal("resource-name"))
+				})
+
+				Context("when finding the resource succeeds", func() {
+					BeforeEach(func() {
+						fakeResource = new(dbfakes.FakeResource)
+						fakeResource.IDReturns(1)
+						fakePipeline.ResourceReturns(fakeResource, true, nil)
+					})
+
+					It("tries to unpromote the right resource config version", func() {
+						resourceConfigVersionID := fakeResource.UnpromoteVersionArgsForCall(0)
+						Expect(resourceConfigVersionID).To(Equal(42))
+					})
+
+					Context("when unpromoting the resource version succeeds", func() {
+						BeforeEach(func() {
+							fakeResource.UnpromoteVersionReturns(true, nil)
+						})
+
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+					})
+
+					Context("when the resource version is not found", func() {
+						BeforeEach(func() {
+							fakeResource.UnpromoteVersionReturns(false, nil)
+						})
+
+						It("returns 404", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						})
+					})
+
+					Context("when unpromoting the resource version fails", func() {
+						BeforeEach(func() {
+							fakeResource.UnpromoteVersionReturns(false, errors.New("welp"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when it fails to find the resource", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, errors.New("welp"))
+					})
+
+					It("returns Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the resource is not found", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, nil)
+					})
+
+					It("returns not found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+			})
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", func() {
 		var response *http.Response
 		var fakeResource *dbfakes.FakeResource
+		var requestBody io.Reader
+
+		BeforeEach(func() {
+			requestBody = nil
+		})
 
 		JustBeforeEach(func() {
 			var err error
 
-			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/pin", nil)
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/pin", requestBody)
 			Expect(err).NotTo(HaveOccurred())
 
 			response, err = client.Do(request)
@@ -715,8 +1091,31 @@ var _ = Describe("Versions API", func() {
 					})
 
 					It("tries to pin the right resource config version", func() {
-						resourceConfigVersionID := fakeResource.PinVersionArgsForCall(0)
+						resourceConfigVersionID, comment := fakeResource.PinVersionArgsForCall(0)
 						Expect(resourceConfigVersionID).To(Equal(42))
+						Expect(comment).To(BeEmpty())
+					})
+
+					Context("when a pin comment is given", func() {
+						BeforeEach(func() {
+							requestBody = strings.NewReader(`{"pin_comment":"investigating an incident"}`)
+						})
+
+						It("pins the version with the comment", func() {
+							_, comment := fakeResource.PinVersionArgsForCall(0)
+							Expect(comment).To(Equal("investigating an incident"))
+						})
+					})
+
+					Context("when the request body is malformed", func() {
+						BeforeEach(func() {
+							requestBody = strings.NewReader(`{`)
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(fakeResource.PinVersionCallCount()).To(BeZero())
+						})
 					})
 
 					Context("when pinning the resource succeeds", func() {
@@ -792,6 +1191,247 @@ var _ = Describe("Versions API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", func() {
+		var response *http.Response
+		var fakeResource *dbfakes.FakeResource
+		var requestBody string
+
+		BeforeEach(func() {
+			requestBody = `[{"ref":"old1"},{"ref":"old2"}]`
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions", strings.NewReader(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+
+				fakeResource = new(dbfakes.FakeResource)
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+			})
+
+			Context("when backfilling succeeds", func() {
+				BeforeEach(func() {
+					fakeResource.BackfillVersionsReturns(2, nil)
+				})
+
+				It("backfills the versions of the resource", func() {
+					Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("resource-name"))
+					Expect(fakeResource.BackfillVersionsCallCount()).To(Equal(1))
+					Expect(fakeResource.BackfillVersionsArgsForCall(0)).To(Equal([]atc.Version{
+						{"ref": "old1"},
+						{"ref": "old2"},
+					}))
+				})
+
+				It("returns the number of new versions", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`{"new_versions":2}`))
+				})
+			})
+
+			Context("when the resource has not been checked yet", func() {
+				BeforeEach(func() {
+					fakeResource.BackfillVersionsReturns(0, db.ErrResourceNotChecked)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when backfilling fails", func() {
+				BeforeEach(func() {
+					fakeResource.BackfillVersionsReturns(0, errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the request body is malformed", func() {
+				BeforeEach(func() {
+					requestBody = `{`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeResource.BackfillVersionsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the resource is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/caches", func() {
+		var response *http.Response
+		var fakeResource *dbfakes.FakeResource
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/caches", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+
+				fakeResource = new(dbfakes.FakeResource)
+				fakeResource.ResourceConfigIDReturns(1)
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+			})
+
+			Context("when the version has resource caches", func() {
+				var resourceCache, otherResourceCache, foreignResourceCache *dbfakes.FakeUsedResourceCache
+
+				BeforeEach(func() {
+					resourceConfig := new(dbfakes.FakeResourceConfig)
+					resourceConfig.IDReturns(1)
+
+					foreignResourceConfig := new(dbfakes.FakeResourceConfig)
+					foreignResourceConfig.IDReturns(2)
+
+					resourceCache = new(dbfakes.FakeUsedResourceCache)
+					resourceCache.IDReturns(10)
+					resourceCache.ResourceConfigReturns(resourceConfig)
+
+					otherResourceCache = new(dbfakes.FakeUsedResourceCache)
+					otherResourceCache.IDReturns(11)
+					otherResourceCache.ResourceConfigReturns(resourceConfig)
+
+					foreignResourceCache = new(dbfakes.FakeUsedResourceCache)
+					foreignResourceCache.IDReturns(12)
+					foreignResourceCache.ResourceConfigReturns(foreignResourceConfig)
+
+					dbResourceCacheFactory.FindResourceCachesForVersionReturns([]db.UsedResourceCache{
+						resourceCache,
+						otherResourceCache,
+						foreignResourceCache,
+					}, nil)
+
+					dbResourceCacheFactory.ExpireResourceCacheReturnsOnCall(0, 2, nil)
+					dbResourceCacheFactory.ExpireResourceCacheReturnsOnCall(1, 1, nil)
+				})
+
+				It("expires the caches of the resource's version", func() {
+					Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("resource-name"))
+					Expect(dbResourceCacheFactory.FindResourceCachesForVersionArgsForCall(0)).To(Equal(42))
+
+					Expect(dbResourceCacheFactory.ExpireResourceCacheCallCount()).To(Equal(2))
+					Expect(dbResourceCacheFactory.ExpireResourceCacheArgsForCall(0)).To(Equal(resourceCache))
+					Expect(dbResourceCacheFactory.ExpireResourceCacheArgsForCall(1)).To(Equal(otherResourceCache))
+				})
+
+				It("returns the number of expired caches and volumes", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`{"resource_caches":2,"volumes":3}`))
+				})
+
+				Context("when expiring a cache fails", func() {
+					BeforeEach(func() {
+						dbResourceCacheFactory.ExpireResourceCacheReturnsOnCall(0, 0, errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when finding the resource caches fails", func() {
+				BeforeEach(func() {
+					dbResourceCacheFactory.FindResourceCachesForVersionReturns(nil, errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the resource is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbResourceCacheFactory.ExpireResourceCacheCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", func() {
 		var response *http.Response
 		var stringVersionID string
@@ -0,0 +1,45 @@
+package volumeserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// ReportWorkerVolumeSizes provides an API endpoint for workers to report the
+// sizes of their volumes, keyed by handle
+func (s *Server) ReportWorkerVolumeSizes(w http.ResponseWriter, r *http.Request) {
+	workerName := r.URL.Query().Get("worker_name")
+
+	logger := s.logger.Session("report-volume-sizes-for-worker", lager.Data{"name": workerName})
+
+	if workerName == "" {
+		logger.Info("missing-worker-name")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	defer r.Body.Close()
+
+	var sizes map[string]uint64
+	err := json.NewDecoder(r.Body).Decode(&sizes)
+	if err != nil {
+		logger.Error("failed-to-decode-volume-sizes", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	logger.Debug("sizes-info", lager.Data{
+		"volumes-count": len(sizes),
+	})
+
+	err = s.repository.UpdateVolumeSizes(workerName, sizes)
+	if err != nil {
+		logger.Error("failed-to-update-volume-sizes", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
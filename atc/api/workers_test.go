@@ -2,6 +2,7 @@ package api_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
@@ -158,6 +159,156 @@ var _ = Describe("Workers API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/workers/usage", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/workers/usage", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			var (
+				teamWorker1 *dbfakes.FakeWorker
+				teamWorker2 *dbfakes.FakeWorker
+			)
+
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				fakeAccess.TeamNamesReturns([]string{"some-team"})
+
+				teamWorker1 = new(dbfakes.FakeWorker)
+				teamWorker1.NameReturns("worker-1")
+				teamWorker1.StateReturns(db.WorkerStateRunning)
+				teamWorker1.TeamNameReturns("some-team")
+				teamWorker1.ActiveContainersReturns(5)
+				teamWorker1.ActiveVolumesReturns(12)
+				teamWorker1.ActiveTasksReturns(2, nil)
+				teamWorker1.DiskUsageReturns(atc.WorkerDiskUsage{UsedBytes: 600, TotalBytes: 1000}, true)
+
+				teamWorker2 = new(dbfakes.FakeWorker)
+				teamWorker2.NameReturns("worker-2")
+				teamWorker2.StateReturns(db.WorkerStateLanding)
+				teamWorker2.ActiveContainersReturns(1)
+				teamWorker2.ActiveVolumesReturns(3)
+
+				dbWorkerFactory.VisibleWorkersReturns([]db.Worker{teamWorker1, teamWorker2}, nil)
+				dbWorkerFactory.BuildContainersCountPerWorkerReturns(map[string]int{"worker-1": 4}, nil)
+				dbWorkerFactory.VolumesCountPerWorkerReturns(map[string]int{"worker-1": 10, "worker-2": 3}, nil)
+			})
+
+			It("fetches workers by team name from worker user context", func() {
+				Expect(dbWorkerFactory.VisibleWorkersCallCount()).To(Equal(1))
+
+				teamNames := dbWorkerFactory.VisibleWorkersArgsForCall(0)
+				Expect(teamNames).To(ConsistOf("some-team"))
+			})
+
+			It("returns 200 with Content-Type 'application/json'", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				expectedHeaderEntries := map[string]string{
+					"Content-Type": "application/json",
+				}
+				Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
+			})
+
+			It("returns the usage of each worker", func() {
+				var usage []atc.WorkerUsage
+				err := json.NewDecoder(response.Body).Decode(&usage)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(usage).To(Equal([]atc.WorkerUsage{
+					{
+						Name:             "worker-1",
+						State:            "running",
+						Team:             "some-team",
+						ActiveContainers: 5,
+						ActiveVolumes:    12,
+						ActiveTasks:      2,
+						BuildContainers:  4,
+						Volumes:          10,
+						DiskUsage:        &atc.WorkerDiskUsage{UsedBytes: 600, TotalBytes: 1000},
+					},
+					{
+						Name:             "worker-2",
+						State:            "landing",
+						ActiveContainers: 1,
+						ActiveVolumes:    3,
+						Volumes:          3,
+					},
+				}))
+			})
+
+			Context("when the usage is requested again", func() {
+				JustBeforeEach(func() {
+					req, err := http.NewRequest("GET", server.URL+"/api/v1/workers/usage", nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					response, err = client.Do(req)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("reuses the cached counts", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(dbWorkerFactory.VisibleWorkersCallCount()).To(Equal(2))
+					Expect(dbWorkerFactory.BuildContainersCountPerWorkerCallCount()).To(Equal(1))
+					Expect(dbWorkerFactory.VolumesCountPerWorkerCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when user is an admin", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAdminReturns(true)
+					dbWorkerFactory.WorkersReturns([]db.Worker{teamWorker1}, nil)
+				})
+
+				It("returns the usage of all the workers", func() {
+					Expect(dbWorkerFactory.WorkersCallCount()).To(Equal(1))
+					Expect(dbWorkerFactory.VisibleWorkersCallCount()).To(Equal(0))
+
+					var usage []atc.WorkerUsage
+					err := json.NewDecoder(response.Body).Decode(&usage)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(usage).To(HaveLen(1))
+				})
+			})
+
+			Context("when getting the workers fails", func() {
+				BeforeEach(func() {
+					dbWorkerFactory.VisibleWorkersReturns(nil, errors.New("error!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when counting the volumes fails", func() {
+				BeforeEach(func() {
+					dbWorkerFactory.VolumesCountPerWorkerReturns(nil, errors.New("error!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("POST /api/v1/workers", func() {
 		var (
 			worker    atc.Worker
@@ -771,6 +922,155 @@ var _ = Describe("Workers API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/workers/prune-stalled", func() {
+		var (
+			response *http.Response
+			query    string
+
+			stalledWorker     *dbfakes.FakeWorker
+			busyWorker        *dbfakes.FakeWorker
+			otherTeamWorker   *dbfakes.FakeWorker
+			globalWorker      *dbfakes.FakeWorker
+			runningTeamWorker *dbfakes.FakeWorker
+		)
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/workers/prune-stalled"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			query = ""
+
+			stalledWorker = new(dbfakes.FakeWorker)
+			stalledWorker.NameReturns("stalled-worker")
+			stalledWorker.StateReturns(db.WorkerStateStalled)
+			stalledWorker.TeamNameReturns("some-team")
+
+			busyWorker = new(dbfakes.FakeWorker)
+			busyWorker.NameReturns("busy-worker")
+			busyWorker.StateReturns(db.WorkerStateStalled)
+			busyWorker.TeamNameReturns("some-team")
+
+			otherTeamWorker = new(dbfakes.FakeWorker)
+			otherTeamWorker.NameReturns("other-team-worker")
+			otherTeamWorker.StateReturns(db.WorkerStateStalled)
+			otherTeamWorker.TeamNameReturns("other-team")
+
+			globalWorker = new(dbfakes.FakeWorker)
+			globalWorker.NameReturns("global-worker")
+			globalWorker.StateReturns(db.WorkerStateStalled)
+
+			runningTeamWorker = new(dbfakes.FakeWorker)
+			runningTeamWorker.NameReturns("running-worker")
+			runningTeamWorker.StateReturns(db.WorkerStateRunning)
+			runningTeamWorker.TeamNameReturns("some-team")
+
+			dbWorkerFactory.WorkersReturns([]db.Worker{stalledWorker, busyWorker, otherTeamWorker, globalWorker, runningTeamWorker}, nil)
+			dbWorkerFactory.RunningBuildContainersCountPerWorkerReturns(map[string]int{"busy-worker": 2, "running-worker": 1}, nil)
+
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedStub = func(team string) bool {
+				return team == "some-team"
+			}
+		})
+
+		It("prunes the stalled workers of the requester's teams which have no running builds", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+				"pruned": ["stalled-worker"],
+				"busy": ["busy-worker"]
+			}`))
+
+			Expect(stalledWorker.PruneCallCount()).To(Equal(1))
+			Expect(busyWorker.PruneCallCount()).To(BeZero())
+			Expect(otherTeamWorker.PruneCallCount()).To(BeZero())
+			Expect(globalWorker.PruneCallCount()).To(BeZero())
+			Expect(runningTeamWorker.PruneCallCount()).To(BeZero())
+		})
+
+		Context("when forced", func() {
+			BeforeEach(func() {
+				query = "?force=true"
+			})
+
+			It("prunes the stalled workers with running builds too", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+					"pruned": ["stalled-worker", "busy-worker"]
+				}`))
+
+				Expect(busyWorker.PruneCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the requester is an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			It("prunes the stalled workers of every team and the global ones", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+					"pruned": ["stalled-worker", "other-team-worker", "global-worker"],
+					"busy": ["busy-worker"]
+				}`))
+			})
+		})
+
+		Context("when a worker is no longer stalled by the time it's pruned", func() {
+			BeforeEach(func() {
+				stalledWorker.PruneReturns(db.ErrCannotPruneRunningWorker)
+			})
+
+			It("leaves it out of the pruned workers", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+					"pruned": [],
+					"busy": ["busy-worker"]
+				}`))
+			})
+		})
+
+		Context("when pruning a worker fails", func() {
+			BeforeEach(func() {
+				stalledWorker.PruneReturns(errors.New("some-error"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when counting the running build containers fails", func() {
+			BeforeEach(func() {
+				dbWorkerFactory.RunningBuildContainersCountPerWorkerReturns(nil, errors.New("some-error"))
+			})
+
+			It("returns 500 without pruning anything", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				Expect(stalledWorker.PruneCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not prune anything", func() {
+				Expect(dbWorkerFactory.WorkersCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/workers/:worker_name/heartbeat", func() {
 		var (
 			response   *http.Response
@@ -916,6 +1216,324 @@ var _ = Describe("Workers API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/workers/:worker_name/warm", func() {
+		var (
+			response   *http.Response
+			workerName string
+			body       string
+			fakeWorker *dbfakes.FakeWorker
+
+			fakeResourceCache1 *dbfakes.FakeUsedResourceCache
+			fakeResourceCache2 *dbfakes.FakeUsedResourceCache
+		)
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/workers/"+workerName+"/warm", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakeWorker = new(dbfakes.FakeWorker)
+			workerName = "some-worker"
+			fakeWorker.NameReturns(workerName)
+			fakeWorker.TeamNameReturns("some-team")
+
+			body = `{"resource_config_version_ids":[1,2]}`
+
+			fakeResourceCache1 = new(dbfakes.FakeUsedResourceCache)
+			fakeResourceCache1.IDReturns(11)
+			fakeResourceCache2 = new(dbfakes.FakeUsedResourceCache)
+			fakeResourceCache2.IDReturns(12)
+
+			dbResourceCacheFactory.FindResourceCachesForVersionStub = func(versionID int) ([]db.UsedResourceCache, error) {
+				if versionID == 1 {
+					return []db.UsedResourceCache{fakeResourceCache1, fakeResourceCache2}, nil
+				}
+				return nil, nil
+			}
+
+			fakeResourceCacheWarmer.WarmStub = func(_ context.Context, _ string, resourceCache db.UsedResourceCache) (bool, error) {
+				if resourceCache.ID() == 12 {
+					return false, errors.New("no worker has a volume for the resource cache")
+				}
+				return true, nil
+			}
+
+			fakeAccess.IsAuthenticatedReturns(true)
+			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
+		})
+
+		Context("when the request is authenticated as system", func() {
+			BeforeEach(func() {
+				fakeAccess.IsSystemReturns(true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("warms the worker with the resource caches of each version", func() {
+				Expect(fakeResourceCacheWarmer.WarmCallCount()).To(Equal(2))
+
+				_, actualWorkerName, resourceCache := fakeResourceCacheWarmer.WarmArgsForCall(0)
+				Expect(actualWorkerName).To(Equal(workerName))
+				Expect(resourceCache).To(Equal(fakeResourceCache1))
+
+				_, _, resourceCache = fakeResourceCacheWarmer.WarmArgsForCall(1)
+				Expect(resourceCache).To(Equal(fakeResourceCache2))
+			})
+
+			It("returns the outcome for each resource cache", func() {
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{"resource_config_version_id": 1, "resource_cache_id": 11, "status": "streamed"},
+					{"resource_config_version_id": 1, "resource_cache_id": 12, "status": "failed", "error": "no worker has a volume for the resource cache"},
+					{"resource_config_version_id": 2, "status": "failed", "error": "no resource caches found for version"}
+				]`))
+			})
+
+			Context("when the worker already has a resource cache", func() {
+				BeforeEach(func() {
+					body = `{"resource_config_version_ids":[1]}`
+					fakeResourceCacheWarmer.WarmStub = nil
+					fakeResourceCacheWarmer.WarmReturns(false, nil)
+				})
+
+				It("reports it as already warm", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+						{"resource_config_version_id": 1, "resource_cache_id": 11, "status": "already-warm"},
+						{"resource_config_version_id": 1, "resource_cache_id": 12, "status": "already-warm"}
+					]`))
+				})
+			})
+
+			Context("when the request body is invalid", func() {
+				BeforeEach(func() {
+					body = `{`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when finding the resource caches fails", func() {
+				BeforeEach(func() {
+					dbResourceCacheFactory.FindResourceCachesForVersionStub = nil
+					dbResourceCacheFactory.FindResourceCachesForVersionReturns(nil, errors.New("disaster"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the worker does not exist", func() {
+				BeforeEach(func() {
+					dbWorkerFactory.GetWorkerReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+
+				It("does not warm anything", func() {
+					Expect(fakeResourceCacheWarmer.WarmCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the request is authorized as the worker's owner", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(true)
+
+				fakeWorker.TeamIDReturns(3)
+				dbResourceCacheFactory.ResourceConfigVersionVisibleToTeamReturns(true, nil)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("checks that each version belongs to a resource of the worker's team", func() {
+				Expect(dbResourceCacheFactory.ResourceConfigVersionVisibleToTeamCallCount()).To(Equal(2))
+
+				versionID, teamID := dbResourceCacheFactory.ResourceConfigVersionVisibleToTeamArgsForCall(0)
+				Expect(versionID).To(Equal(1))
+				Expect(teamID).To(Equal(3))
+
+				versionID, teamID = dbResourceCacheFactory.ResourceConfigVersionVisibleToTeamArgsForCall(1)
+				Expect(versionID).To(Equal(2))
+				Expect(teamID).To(Equal(3))
+			})
+
+			Context("when a version belongs to another team", func() {
+				BeforeEach(func() {
+					dbResourceCacheFactory.ResourceConfigVersionVisibleToTeamStub = func(versionID int, _ int) (bool, error) {
+						return versionID != 2, nil
+					}
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("resource config version 2 does not belong to a resource of team 'some-team'"))
+				})
+
+				It("does not warm anything", func() {
+					Expect(fakeResourceCacheWarmer.WarmCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when checking the versions fails", func() {
+				BeforeEach(func() {
+					dbResourceCacheFactory.ResourceConfigVersionVisibleToTeamReturns(false, errors.New("disaster"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the request is authorized as the wrong team", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not warm anything", func() {
+				Expect(fakeResourceCacheWarmer.WarmCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/workers/:worker_name/disk-usage", func() {
+		var (
+			response   *http.Response
+			workerName string
+			body       string
+			fakeWorker *dbfakes.FakeWorker
+		)
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/workers/"+workerName+"/disk-usage", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakeWorker = new(dbfakes.FakeWorker)
+			workerName = "some-worker"
+			fakeWorker.NameReturns(workerName)
+			fakeWorker.TeamNameReturns("some-team")
+
+			body = `{"used_bytes":80,"total_bytes":100}`
+
+			fakeAccess.IsAuthenticatedReturns(true)
+			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
+		})
+
+		Context("when the request is authenticated as system", func() {
+			BeforeEach(func() {
+				fakeAccess.IsSystemReturns(true)
+			})
+
+			It("returns 204", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+			})
+
+			It("records the worker's disk usage", func() {
+				Expect(dbWorkerFactory.GetWorkerArgsForCall(0)).To(Equal(workerName))
+				Expect(fakeWorker.ReportDiskUsageCallCount()).To(Equal(1))
+				Expect(fakeWorker.ReportDiskUsageArgsForCall(0)).To(Equal(atc.WorkerDiskUsage{
+					UsedBytes:  80,
+					TotalBytes: 100,
+				}))
+			})
+
+			Context("when more is used than the disk holds", func() {
+				BeforeEach(func() {
+					body = `{"used_bytes":101,"total_bytes":100}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not record it", func() {
+					Expect(fakeWorker.ReportDiskUsageCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the body is not JSON", func() {
+				BeforeEach(func() {
+					body = `nope`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when recording the disk usage fails", func() {
+				BeforeEach(func() {
+					fakeWorker.ReportDiskUsageReturns(errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the worker does not exist", func() {
+				BeforeEach(func() {
+					dbWorkerFactory.GetWorkerReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when the request is authorized as the wrong team", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("DELETE /api/v1/workers/:worker_name", func() {
 		var (
 			response   *http.Response
@@ -0,0 +1,52 @@
+package workerserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+)
+
+// ReportWorkerDiskUsage records how much of its disk a worker is using, which
+// the volume collector uses to evict caches from workers under disk pressure.
+func (s *Server) ReportWorkerDiskUsage(w http.ResponseWriter, r *http.Request) {
+	workerName := r.FormValue(":worker_name")
+	logger := s.logger.Session("report-worker-disk-usage", lager.Data{"name": workerName})
+
+	var usage atc.WorkerDiskUsage
+	err := json.NewDecoder(r.Body).Decode(&usage)
+	if err != nil {
+		logger.Error("failed-to-decode-disk-usage", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if usage.UsedBytes > usage.TotalBytes {
+		logger.Info("used-exceeds-total")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	worker, found, err := s.dbWorkerFactory.GetWorker(workerName)
+	if err != nil {
+		logger.Error("failed-finding-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Info("worker-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	err = worker.ReportDiskUsage(usage)
+	if err != nil {
+		logger.Error("failed-to-save-disk-usage", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
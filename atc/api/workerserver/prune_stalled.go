@@ -0,0 +1,77 @@
+package workerserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// PruneStalledWorkers prunes every stalled worker the requester may prune.
+// Workers which still have containers of running builds are left alone,
+// unless the request forces them to be pruned too.
+func (s *Server) PruneStalledWorkers(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("prune-stalled-workers")
+
+	force := r.URL.Query().Get("force") == "true"
+
+	workers, err := s.dbWorkerFactory.Workers()
+	if err != nil {
+		logger.Error("failed-to-get-workers", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	runningBuildContainers, err := s.dbWorkerFactory.RunningBuildContainersCountPerWorker()
+	if err != nil {
+		logger.Error("failed-to-count-running-build-containers", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	acc := accessor.GetAccessor(r)
+
+	response := atc.PruneStalledWorkersResponse{
+		Pruned: []string{},
+	}
+
+	for _, worker := range workers {
+		if worker.State() != db.WorkerStateStalled {
+			continue
+		}
+
+		if !acc.IsSystem() && !acc.IsAdmin() && (worker.TeamName() == "" || !acc.IsAuthorized(worker.TeamName())) {
+			continue
+		}
+
+		if !force && runningBuildContainers[worker.Name()] > 0 {
+			response.Busy = append(response.Busy, worker.Name())
+			continue
+		}
+
+		err = worker.Prune()
+		if err == db.ErrWorkerNotPresent || err == db.ErrCannotPruneRunningWorker {
+			// the worker went away or came back since it was listed
+			logger.Info("skipped-worker", lager.Data{"worker": worker.Name(), "reason": err.Error()})
+			continue
+		}
+
+		if err != nil {
+			logger.Error("failed-to-prune-worker", err, lager.Data{"worker": worker.Name()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		response.Pruned = append(response.Pruned, worker.Name())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		logger.Error("failed-to-encode-pruned-workers", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -1,26 +1,43 @@
 package workerserver
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/patrickmn/go-cache"
 )
 
+// workerUsageCacheDuration is how long the per-worker counts served by
+// ListWorkerUsage are reused for, so that polling it is cheap.
+const workerUsageCacheDuration = 10 * time.Second
+
 type Server struct {
 	logger lager.Logger
 
-	teamFactory     db.TeamFactory
-	dbWorkerFactory db.WorkerFactory
+	teamFactory            db.TeamFactory
+	dbWorkerFactory        db.WorkerFactory
+	dbResourceCacheFactory db.ResourceCacheFactory
+	resourceCacheWarmer    worker.ResourceCacheWarmer
+
+	usageCache *cache.Cache
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
 	dbWorkerFactory db.WorkerFactory,
-
+	dbResourceCacheFactory db.ResourceCacheFactory,
+	resourceCacheWarmer worker.ResourceCacheWarmer,
 ) *Server {
 	return &Server{
-		logger:          logger,
-		teamFactory:     teamFactory,
-		dbWorkerFactory: dbWorkerFactory,
+		logger:                 logger,
+		teamFactory:            teamFactory,
+		dbWorkerFactory:        dbWorkerFactory,
+		dbResourceCacheFactory: dbResourceCacheFactory,
+		resourceCacheWarmer:    resourceCacheWarmer,
+
+		usageCache: cache.New(workerUsageCacheDuration, workerUsageCacheDuration),
 	}
 }
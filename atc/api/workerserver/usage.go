@@ -0,0 +1,105 @@
+package workerserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/patrickmn/go-cache"
+)
+
+const workerUsageCacheKey = "worker-usage"
+
+type workerCounts struct {
+	buildContainers map[string]int
+	volumes         map[string]int
+}
+
+func (s *Server) ListWorkerUsage(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-worker-usage")
+
+	var (
+		workers []db.Worker
+		err     error
+	)
+
+	acc := accessor.GetAccessor(r)
+
+	if acc.IsAdmin() {
+		workers, err = s.dbWorkerFactory.Workers()
+	} else {
+		workers, err = s.dbWorkerFactory.VisibleWorkers(acc.TeamNames())
+	}
+
+	if err != nil {
+		logger.Error("failed-to-get-workers", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	counts, err := s.workerCounts()
+	if err != nil {
+		logger.Error("failed-to-count-worker-usage", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	usage := make([]atc.WorkerUsage, len(workers))
+	for i, savedWorker := range workers {
+		activeTasks, err := savedWorker.ActiveTasks()
+		if err != nil {
+			logger.Error("failed-to-get-active-tasks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		usage[i] = atc.WorkerUsage{
+			Name:             savedWorker.Name(),
+			State:            string(savedWorker.State()),
+			Team:             savedWorker.TeamName(),
+			ActiveContainers: savedWorker.ActiveContainers(),
+			ActiveVolumes:    savedWorker.ActiveVolumes(),
+			ActiveTasks:      activeTasks,
+			BuildContainers:  counts.buildContainers[savedWorker.Name()],
+			Volumes:          counts.volumes[savedWorker.Name()],
+		}
+
+		if diskUsage, reported := savedWorker.DiskUsage(); reported {
+			usage[i].DiskUsage = &diskUsage
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(usage)
+	if err != nil {
+		logger.Error("failed-to-encode-worker-usage", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) workerCounts() (workerCounts, error) {
+	if counts, found := s.usageCache.Get(workerUsageCacheKey); found {
+		return counts.(workerCounts), nil
+	}
+
+	buildContainers, err := s.dbWorkerFactory.BuildContainersCountPerWorker()
+	if err != nil {
+		return workerCounts{}, err
+	}
+
+	volumes, err := s.dbWorkerFactory.VolumesCountPerWorker()
+	if err != nil {
+		return workerCounts{}, err
+	}
+
+	counts := workerCounts{
+		buildContainers: buildContainers,
+		volumes:         volumes,
+	}
+
+	s.usageCache.Set(workerUsageCacheKey, counts, cache.DefaultExpiration)
+
+	return counts, nil
+}
@@ -0,0 +1,116 @@
+package workerserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+)
+
+func (s *Server) WarmWorker(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("warming-worker")
+	workerName := r.FormValue(":worker_name")
+
+	var request atc.WarmWorkerRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		logger.Error("failed-to-decode-request", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	savedWorker, found, err := s.dbWorkerFactory.GetWorker(workerName)
+	if err != nil {
+		logger.Error("failed-finding-worker-to-warm", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Info("worker-not-found", lager.Data{"worker": workerName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// a team's worker may only be warmed with versions the team could have
+	// fetched itself, as the caches are copied from whichever worker has them
+	if savedWorker.TeamID() != 0 {
+		for _, versionID := range request.ResourceConfigVersionIDs {
+			visible, err := s.dbResourceCacheFactory.ResourceConfigVersionVisibleToTeam(versionID, savedWorker.TeamID())
+			if err != nil {
+				logger.Error("failed-to-check-resource-config-version", err, lager.Data{"resource-config-version-id": versionID})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if !visible {
+				logger.Info("resource-config-version-not-visible-to-team", lager.Data{
+					"resource-config-version-id": versionID,
+					"team":                       savedWorker.TeamName(),
+				})
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprintf(w, "resource config version %d does not belong to a resource of team '%s'", versionID, savedWorker.TeamName())
+				return
+			}
+		}
+	}
+
+	ctx := lagerctx.NewContext(r.Context(), logger)
+
+	results := []atc.WarmedResourceCache{}
+	for _, versionID := range request.ResourceConfigVersionIDs {
+		resourceCaches, err := s.dbResourceCacheFactory.FindResourceCachesForVersion(versionID)
+		if err != nil {
+			logger.Error("failed-to-find-resource-caches", err, lager.Data{"resource-config-version-id": versionID})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if len(resourceCaches) == 0 {
+			results = append(results, atc.WarmedResourceCache{
+				ResourceConfigVersionID: versionID,
+				Status:                  atc.WarmStatusFailed,
+				Error:                   "no resource caches found for version",
+			})
+			continue
+		}
+
+		for _, resourceCache := range resourceCaches {
+			result := atc.WarmedResourceCache{
+				ResourceConfigVersionID: versionID,
+				ResourceCacheID:         resourceCache.ID(),
+				Status:                  atc.WarmStatusStreamed,
+			}
+
+			streamed, err := s.resourceCacheWarmer.Warm(ctx, workerName, resourceCache)
+			if err != nil {
+				result.Status = atc.WarmStatusFailed
+				result.Error = err.Error()
+			} else if !streamed {
+				result.Status = atc.WarmStatusAlreadyWarm
+			}
+
+			logger.Info("warmed-resource-cache", lager.Data{
+				"resource-config-version-id": versionID,
+				"resource-cache-id":          resourceCache.ID(),
+				"status":                     result.Status,
+			})
+
+			results = append(results, result)
+		}
+	}
+
+	payload, err := json.Marshal(results)
+	if err != nil {
+		logger.Error("failed-to-encode-results", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(payload)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api"
 	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/artifactserver"
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/api/buildserver"
 	"github.com/concourse/concourse/atc/api/containerserver"
@@ -30,6 +31,7 @@ import (
 	"github.com/concourse/concourse/atc/api/policychecker"
 	"github.com/concourse/concourse/atc/auditor"
 	"github.com/concourse/concourse/atc/builds"
+	"github.com/concourse/concourse/atc/buildspans"
 	"github.com/concourse/concourse/atc/component"
 	"github.com/concourse/concourse/atc/compression"
 	"github.com/concourse/concourse/atc/creds"
@@ -39,10 +41,14 @@ import (
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/db/migration"
 	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/eventarchive"
 	"github.com/concourse/concourse/atc/gc"
+	"github.com/concourse/concourse/atc/idtoken"
 	"github.com/concourse/concourse/atc/lidar"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/notifications"
 	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/provenance"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/scheduler"
 	"github.com/concourse/concourse/atc/scheduler/algorithm"
@@ -111,6 +117,7 @@ type RunCommand struct {
 	Logger flag.Lager
 
 	varSourcePool creds.VarSourcePool
+	idTokenIssuer *idtoken.Issuer
 
 	BindIP   flag.IP `long:"bind-ip"   default:"0.0.0.0" description:"IP address on which to listen for web traffic."`
 	BindPort uint16  `long:"bind-port" default:"8080"    description:"Port on which to listen for HTTP traffic."`
@@ -139,6 +146,12 @@ type RunCommand struct {
 	EncryptionKey    flag.Cipher `long:"encryption-key"     description:"A 16 or 32 length key used to encrypt sensitive information before storing it in the database."`
 	OldEncryptionKey flag.Cipher `long:"old-encryption-key" description:"Encryption key previously used for encrypting sensitive information. If provided without a new key, data is encrypted. If provided with a new key, data is re-encrypted."`
 
+	EncryptionKeyRotation struct {
+		Online    bool          `long:"online" description:"When given both an encryption key and an old encryption key, start up without re-encrypting data and decrypt it with either key instead. Data is then re-encrypted in the background once requested through the API."`
+		Interval  time.Duration `long:"interval" default:"1m" description:"Interval on which to resume a rotation of the encryption key that is under way."`
+		BatchSize int           `long:"batch-size" default:"500" description:"Number of rows re-encrypted in each transaction while rotating the encryption key."`
+	} `group:"Encryption Key Rotation" namespace:"encryption-key-rotation"`
+
 	DebugBindIP   flag.IP `long:"debug-bind-ip"   default:"127.0.0.1" description:"IP address on which to listen for the pprof debugger endpoints."`
 	DebugBindPort uint16  `long:"debug-bind-port" default:"8079"      description:"Port on which to listen for the pprof debugger endpoints."`
 
@@ -153,6 +166,12 @@ type RunCommand struct {
 	ResourceWithWebhookCheckingInterval time.Duration `long:"resource-with-webhook-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources that has webhook defined."`
 	MaxChecksPerSecond                  int           `long:"max-checks-per-second" description:"Maximum number of checks that can be started per second. If not specified, this will be calculated as (# of resources)/(resource checking interval). -1 value will remove this maximum limit of checks per second."`
 
+	MaxVersionsPerCheck int `long:"max-versions-per-check" description:"Maximum number of versions saved by a single check. A check emitting more is stopped, and the next check picks up from the last version saved. Only suitable for resource types which emit versions oldest first, starting from the given version. 0 means no limit."`
+
+	CheckPoolRates map[string]float64 `long:"check-pool-rate" value-name:"POOL:RATE" description:"Maximum number of checks per second for all resources in the given check_pool combined. Checks over the rate are queued rather than failed. Can be specified multiple times."`
+
+	MaxConcurrentChecks int `long:"max-concurrent-checks" description:"Maximum number of checks this ATC runs at once. Checks over the limit are queued until a running check finishes. 0 means no limit."`
+
 	ContainerPlacementStrategyOptions worker.ContainerPlacementStrategyOptions `group:"Container Placement Strategy"`
 
 	BaggageclaimResponseHeaderTimeout time.Duration `long:"baggageclaim-response-header-timeout" default:"1m" description:"How long to wait for Baggageclaim to send the response header."`
@@ -168,6 +187,8 @@ type RunCommand struct {
 		Attributes          map[string]string `long:"metrics-attribute" description:"A key-value attribute to attach to emitted metrics. Can be specified multiple times." value-name:"NAME:VALUE"`
 		BufferSize          uint32            `long:"metrics-buffer-size" default:"1000" description:"The size of the buffer used in emitting event metrics."`
 		CaptureErrorMetrics bool              `long:"capture-error-metrics" description:"Enable capturing of error log metrics"`
+
+		VersionsDiscoveredPipelines []string `long:"metrics-versions-discovered-pipeline" description:"Pipeline whose checks emit the number of versions they discovered per resource. Can be specified multiple times." value-name:"TEAM/PIPELINE"`
 	} `group:"Metrics & Diagnostics"`
 
 	Tracing tracing.Config `group:"Tracing" namespace:"tracing"`
@@ -196,9 +217,19 @@ type RunCommand struct {
 		FailedGracePeriod      time.Duration `long:"failed-grace-period" default:"120h" description:"Period after which failed containers will be garbage collected"`
 		CheckRecyclePeriod     time.Duration `long:"check-recycle-period" default:"1m" description:"Period after which to reap checks that are completed."`
 		VarSourceRecyclePeriod time.Duration `long:"var-source-recycle-period" default:"5m" description:"Period after which to reap var_sources that are not used."`
+
+		DestroyMaxInFlight          uint16 `long:"destroy-max-in-flight" default:"16" description:"Maximum number of containers or volumes to be destroyed at the same time by each collector."`
+		DestroyMaxInFlightPerWorker uint16 `long:"destroy-max-in-flight-per-worker" default:"4" description:"Maximum number of containers or volumes of a single worker to be destroyed at the same time by each collector, so as not to overwhelm its garden or baggageclaim server."`
+
+		DiskPressureHighWaterMark     float64 `long:"disk-pressure-high-water-mark" description:"Percentage of a worker's disk in use from which its least recently used resource caches are evicted. 0 disables eviction."`
+		DiskPressureLowWaterMark      float64 `long:"disk-pressure-low-water-mark" default:"75" description:"Percentage of a worker's disk in use down to which resource caches are evicted once the high water mark is crossed."`
+		DiskPressureEvictionBatchSize int     `long:"disk-pressure-eviction-batch-size" default:"10" description:"Maximum number of resource caches evicted from a worker under disk pressure on each garbage collection."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
+	BuildServerLogLines  uint64        `long:"build-server-log-lines" default:"0" description:"Number of log lines pertaining to each build, other than check builds, to save in the database for looking up by build. Saving them adds to the database's write load, so it is disabled (0) by default."`
+
+	BuildLogRateLimit int `long:"build-log-rate-limit" value-name:"BYTES" description:"Maximum number of bytes per second a build can log, in bursts of up to ten seconds worth. Output over the limit is dropped, and marked as such in the build log. 0 means no limit."`
 
 	TelemetryOptIn bool `long:"telemetry-opt-in" hidden:"true" description:"Enable anonymous concourse version reporting."`
 
@@ -210,8 +241,14 @@ type RunCommand struct {
 
 	JobSchedulingMaxInFlight uint64 `long:"job-scheduling-max-in-flight" default:"32" description:"Maximum number of jobs to be scheduling at the same time"`
 
-	DefaultCpuLimit    *int    `long:"default-task-cpu-limit" description:"Default max number of cpu shares per task, 0 means unlimited"`
-	DefaultMemoryLimit *string `long:"default-task-memory-limit" description:"Default maximum memory per task, 0 means unlimited"`
+	DefaultCpuLimit        *int    `long:"default-task-cpu-limit" description:"Default max number of cpu shares per task, 0 means unlimited"`
+	DefaultMemoryLimit     *string `long:"default-task-memory-limit" description:"Default maximum memory per task, 0 means unlimited"`
+	DefaultOutputSizeLimit *string `long:"default-task-output-size-limit" description:"Default maximum size of each output of a task, 0 means unlimited"`
+
+	OutputSizeCheckInterval time.Duration `long:"task-output-size-check-interval" default:"10s" description:"Interval on which the outputs of tasks with an output size limit are checked against the sizes last reported by their workers."`
+
+	ArtifactSpoolSize string   `long:"artifact-spool-size" default:"0" description:"Maximum total size of the artifacts spooled to disk, so that their downloads report a Content-Length and can be resumed with Range requests. 0 disables spooling. Artifacts which don't fit are downloaded in full without a Content-Length, so this must be raised past the size of the largest artifacts, e.g. multi-GB build outputs, for their downloads to be resumable."`
+	ArtifactSpoolDir  flag.Dir `long:"artifact-spool-dir" description:"Directory to spool artifacts in, under which a directory is created and removed on shutdown. Defaults to the OS temp directory."`
 
 	Auditor struct {
 		EnableBuildAuditLog     bool `long:"enable-build-auditing" description:"Enable auditing for all api requests connected to builds."`
@@ -233,6 +270,18 @@ type RunCommand struct {
 		CACerts       []string      `long:"syslog-ca-cert"              description:"Paths to PEM-encoded CA cert files to use to verify the Syslog server SSL cert."`
 	} ` group:"Syslog Drainer Configuration"`
 
+	BuildEventArchive eventarchive.Config `group:"Build Event Archive" namespace:"build-event-archive"`
+
+	BuildSpanExport buildspans.Config `group:"Build Span Export" namespace:"build-span-export"`
+
+	BuildIDTokens idtoken.Config `group:"Build Identity Tokens" namespace:"build-id-token"`
+
+	WebhookNotifications struct {
+		Interval    time.Duration `long:"interval" default:"10s" description:"Interval on which to deliver the build notifications queued for pipeline webhooks."`
+		Timeout     time.Duration `long:"timeout" default:"30s" description:"Timeout for a single delivery of a build notification."`
+		MaxAttempts int           `long:"max-attempts" default:"10" description:"Number of failed deliveries after which a build notification is given up on and logged."`
+	} `group:"Webhook Notifications" namespace:"webhook-notification"`
+
 	Auth struct {
 		AuthFlags     skycmd.AuthFlags
 		MainTeamFlags skycmd.AuthTeamFlags `group:"Authentication (Main Team)" namespace:"main-team"`
@@ -251,6 +300,7 @@ type RunCommand struct {
 		EnablePipelineInstances              bool `long:"enable-pipeline-instances" description:"Enable pipeline instances"`
 		EnableP2PVolumeStreaming             bool `long:"enable-p2p-volume-streaming" description:"Enable P2P volume streaming"`
 		DisableCacheStreamedVolumes          bool `long:"disable-cache-streamed-volumes" description:"By default, streamed resource volumes will be automatically cached on the destination worker. This flag opts out of that behaviour"`
+		EnableStepWorkerName                 bool `long:"enable-step-worker-name" description:"Enable pinning task steps to a worker by name using worker_name. Intended for debugging, as it bypasses the container placement strategy."`
 	} `group:"Feature Flags"`
 
 	BaseResourceTypeDefaults flag.File `long:"base-resource-type-defaults" description:"Base resource type defaults"`
@@ -522,6 +572,9 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 	atc.EnableAcrossStep = cmd.FeatureFlags.EnableAcrossStep
 	atc.EnablePipelineInstances = cmd.FeatureFlags.EnablePipelineInstances
 	atc.EnableCacheStreamedVolumes = !cmd.FeatureFlags.DisableCacheStreamedVolumes
+	atc.EnableStepWorkerName = cmd.FeatureFlags.EnableStepWorkerName
+
+	worker.OutputSizeCheckInterval = cmd.OutputSizeCheckInterval
 
 	if cmd.BaseResourceTypeDefaults.Path() != "" {
 		content, err := ioutil.ReadFile(cmd.BaseResourceTypeDefaults.Path())
@@ -592,6 +645,16 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		return nil, err
 	}
 
+	// Register the sink that saves log lines for looking up by build
+	serverLogSink := builds.NewServerLogSink(
+		logger.Session("build-server-logs"),
+		reconfigurableSink,
+		db.NewBuildServerLogFactory(backendConn),
+		clock.NewClock(),
+		int(cmd.BuildServerLogLines),
+	)
+	logger.RegisterSink(serverLogSink)
+
 	workerConn, err := cmd.constructDBConn(retryingDriverName, logger, 1, 1, "worker", lockFactory)
 	if err != nil {
 		return nil, err
@@ -615,7 +678,19 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		clock.NewClock(),
 	)
 
-	members, err := cmd.constructMembers(logger, reconfigurableSink, apiConn, workerConn, backendConn, gcConn, storage, lockFactory, secretManager)
+	if cmd.BuildIDTokens.Enable {
+		cmd.idTokenIssuer, err = idtoken.NewIssuer(
+			cmd.ExternalURL.String(),
+			cmd.Auth.AuthFlags.SigningKey.PrivateKey,
+			cmd.BuildIDTokens,
+			clock.NewClock(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("build identity tokens: %w", err)
+		}
+	}
+
+	members, err := cmd.constructMembers(logger, reconfigurableSink, serverLogSink, apiConn, workerConn, backendConn, gcConn, storage, lockFactory, secretManager)
 	if err != nil {
 		return nil, err
 	}
@@ -656,6 +731,7 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 func (cmd *RunCommand) constructMembers(
 	logger lager.Logger,
 	reconfigurableSink *lager.ReconfigurableSink,
+	serverLogSink *builds.ServerLogSink,
 	apiConn db.Conn,
 	workerConn db.Conn,
 	backendConn db.Conn,
@@ -699,7 +775,11 @@ func (cmd *RunCommand) constructMembers(
 	componentFactory := db.NewComponentFactory(backendConn)
 	bus := backendConn.Bus()
 
-	members := apiMembers
+	members := append(apiMembers, grouper.Member{
+		Name:   "build-server-logs",
+		Runner: serverLogSink,
+	})
+
 	components := append(backendComponents, gcComponents...)
 	for _, c := range components {
 		dbComponent, err := componentFactory.CreateOrUpdate(c.Component)
@@ -768,6 +848,7 @@ func (cmd *RunCommand) constructAPIMembers(
 	}
 
 	userFactory := db.NewUserFactory(dbConn)
+	teamUsageFactory := db.NewTeamUsageFactory(dbConn)
 
 	dbResourceCacheFactory := db.NewResourceCacheFactory(dbConn, lockFactory)
 	fetchSourceFactory := worker.NewFetchSourceFactory(dbResourceCacheFactory)
@@ -803,6 +884,22 @@ func (cmd *RunCommand) constructAPIMembers(
 	)
 
 	pool := worker.NewPool(workerProvider)
+	resourceCacheWarmer := worker.NewResourceCacheWarmer(workerProvider, cmd.compression(), cmd.FeatureFlags.EnableP2PVolumeStreaming, cmd.P2pVolumeStreamingTimeout)
+
+	artifactSpoolSize, err := atc.ParseSizeLimit(cmd.ArtifactSpoolSize)
+	if err != nil {
+		return nil, fmt.Errorf("artifact spool size: %w", err)
+	}
+
+	var artifactSpoolDir string
+	if artifactSpoolSize > 0 {
+		artifactSpoolDir, err = ioutil.TempDir(cmd.ArtifactSpoolDir.Path(), "artifact-spools-")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	artifactSpools := artifactserver.NewSpoolCache(clock.NewClock(), artifactSpoolDir, artifactserver.SpoolTTL, int64(artifactSpoolSize))
 
 	credsManagers := cmd.CredentialManagers
 	dbPipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
@@ -817,8 +914,10 @@ func (cmd *RunCommand) constructAPIMembers(
 		Timeout:             cmd.GlobalResourceCheckTimeout,
 	})
 	dbAccessTokenFactory := db.NewAccessTokenFactory(dbConn)
+	dbBuildServerLogFactory := db.NewBuildServerLogFactory(dbConn)
 	dbClock := db.NewClock()
 	dbWall := db.NewWall(dbConn, &dbClock)
+	dbEncryptionKeyRotation := db.NewEncryptionKeyRotation(dbConn, cmd.newKey(), cmd.oldKey(), cmd.EncryptionKeyRotation.BatchSize)
 
 	tokenVerifier := cmd.constructTokenVerifier(dbAccessTokenFactory)
 
@@ -860,12 +959,18 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbBuildFactory,
 		dbCheckFactory,
 		dbResourceConfigFactory,
+		dbResourceCacheFactory,
 		userFactory,
+		teamUsageFactory,
+		dbBuildServerLogFactory,
 		pool,
+		resourceCacheWarmer,
+		artifactSpools,
 		secretManager,
 		credsManagers,
 		accessFactory,
 		dbWall,
+		dbEncryptionKeyRotation,
 		policyChecker,
 	)
 	if err != nil {
@@ -972,6 +1077,7 @@ func (cmd *RunCommand) constructAPIMembers(
 			cmd.nonTLSBindAddr(),
 			httpHandler,
 		)},
+		{Name: "artifact-spools", Runner: artifactSpools},
 	}
 
 	if httpsHandler != nil {
@@ -1007,6 +1113,7 @@ func (cmd *RunCommand) backendComponents(
 	}
 
 	teamFactory := db.NewTeamFactory(dbConn, lockFactory)
+	teamUsageFactory := db.NewTeamUsageFactory(dbConn)
 
 	resourceFactory := resource.NewResourceFactory()
 	dbResourceCacheFactory := db.NewResourceCacheFactory(dbConn, lockFactory)
@@ -1036,12 +1143,7 @@ func (cmd *RunCommand) backendComponents(
 		return nil, err
 	}
 
-	var compressionLib compression.Compression
-	if cmd.StreamingArtifactsCompression == "zstd" {
-		compressionLib = compression.NewZstdCompression()
-	} else {
-		compressionLib = compression.NewGzipCompression()
-	}
+	compressionLib := cmd.compression()
 	workerProvider := worker.NewDBWorkerProvider(
 		lockFactory,
 		retryhttp.NewExponentialBackOffFactory(5*time.Minute),
@@ -1092,11 +1194,14 @@ func (cmd *RunCommand) backendComponents(
 		dbBuildFactory,
 		dbResourceCacheFactory,
 		dbResourceConfigFactory,
+		db.NewTaskOutputCacheFactory(dbConn),
 		secretManager,
 		defaultLimits,
 		buildContainerStrategy,
 		lockFactory,
 		rateLimiter,
+		engine.NewCheckPoolRateLimiter(cmd.CheckPoolRates),
+		engine.NewCheckLimiter(cmd.MaxConcurrentChecks),
 		policyChecker,
 	)
 
@@ -1146,7 +1251,31 @@ func (cmd *RunCommand) backendComponents(
 				Name:     atc.ComponentBuildTracker,
 				Interval: cmd.BuildTrackerInterval,
 			},
-			Runnable: builds.NewTracker(dbBuildFactory, engine),
+			Runnable: builds.NewTracker(dbBuildFactory, engine, dbConn.Bus()),
+		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentWebhookNotifier,
+				Interval: cmd.WebhookNotifications.Interval,
+			},
+			Runnable: notifications.NewDispatcher(
+				db.NewWebhookNotificationFactory(dbConn),
+				dbBuildFactory,
+				secretManager,
+				cmd.varSourcePool,
+				&http.Client{Timeout: cmd.WebhookNotifications.Timeout},
+				clock.NewClock(),
+				cmd.WebhookNotifications.MaxAttempts,
+			),
+		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentTeamUsage,
+				Interval: time.Minute,
+			},
+			Runnable: component.RunFunc(func(context.Context) error {
+				return teamUsageFactory.RefreshUsage()
+			}),
 		},
 		{
 			Component: atc.Component{
@@ -1168,6 +1297,20 @@ func (cmd *RunCommand) backendComponents(
 		},
 	}
 
+	if cmd.newKey() != nil && cmd.oldKey() != nil {
+		keyRotation := db.NewEncryptionKeyRotation(dbConn, cmd.newKey(), cmd.oldKey(), cmd.EncryptionKeyRotation.BatchSize)
+
+		components = append(components, RunnableComponent{
+			Component: atc.Component{
+				Name:     atc.ComponentEncryptionKeyRotator,
+				Interval: cmd.EncryptionKeyRotation.Interval,
+			},
+			Runnable: component.RunFunc(func(ctx context.Context) error {
+				return keyRotation.Rotate(ctx, lagerctx.FromContext(ctx))
+			}),
+		})
+	}
+
 	if syslogDrainConfigured {
 		components = append(components, RunnableComponent{
 			Component: atc.Component{
@@ -1184,6 +1327,51 @@ func (cmd *RunCommand) backendComponents(
 		})
 	}
 
+	if cmd.BuildEventArchive.IsConfigured() {
+		eventStore, err := cmd.BuildEventArchive.Store()
+		if err != nil {
+			return nil, fmt.Errorf("build event archive: %w", err)
+		}
+
+		components = append(components, RunnableComponent{
+			Component: atc.Component{
+				Name:     atc.ComponentBuildEventArchiver,
+				Interval: cmd.BuildEventArchive.Interval,
+			},
+			Runnable: eventarchive.NewArchiver(
+				eventStore,
+				dbBuildFactory,
+				syslogDrainConfigured,
+			),
+		})
+	}
+
+	if cmd.BuildSpanExport.Enable {
+		if !tracing.Configured {
+			return nil, errors.New("build span export requires a tracing provider to be configured")
+		}
+
+		var eventStore eventarchive.Store
+		if cmd.BuildEventArchive.IsConfigured() {
+			eventStore, err = cmd.BuildEventArchive.Store()
+			if err != nil {
+				return nil, fmt.Errorf("build event archive: %w", err)
+			}
+		}
+
+		components = append(components, RunnableComponent{
+			Component: atc.Component{
+				Name:     atc.ComponentBuildSpanExporter,
+				Interval: cmd.BuildSpanExport.Interval,
+			},
+			Runnable: buildspans.NewExporter(
+				dbBuildFactory,
+				eventStore,
+				cmd.BuildSpanExport.BatchSize,
+			),
+		})
+	}
+
 	return components, err
 }
 
@@ -1204,6 +1392,22 @@ func (cmd *RunCommand) gcComponents(
 	dbCheckLifecycle := db.NewCheckLifecycle(gcConn)
 
 	dbVolumeRepository := db.NewVolumeRepository(gcConn)
+	dbWorkerFactory := db.NewWorkerFactory(gcConn)
+
+	// workers sweep whatever is left destroying, so destroying it on them
+	// from here isn't retried for long
+	workerDestroyer := worker.NewDestroyer(
+		dbWorkerFactory,
+		retryhttp.NewExponentialBackOffFactory(30*time.Second),
+		cmd.GardenRequestTimeout,
+		cmd.BaggageclaimResponseHeaderTimeout,
+	)
+
+	diskPressure := gc.DiskPressure{
+		HighWaterMark:     cmd.GC.DiskPressureHighWaterMark,
+		LowWaterMark:      cmd.GC.DiskPressureLowWaterMark,
+		EvictionBatchSize: cmd.GC.DiskPressureEvictionBatchSize,
+	}
 
 	// set the 'unreferenced resource config' grace period to be the longer than
 	// the check timeout, just to make sure it doesn't get removed out from under
@@ -1221,8 +1425,8 @@ func (cmd *RunCommand) gcComponents(
 		atc.ComponentCollectorResourceCaches:    gc.NewResourceCacheCollector(dbResourceCacheLifecycle),
 		atc.ComponentCollectorResourceCacheUses: gc.NewResourceCacheUseCollector(dbResourceCacheLifecycle),
 		atc.ComponentCollectorArtifacts:         gc.NewArtifactCollector(dbArtifactLifecycle),
-		atc.ComponentCollectorVolumes:           gc.NewVolumeCollector(dbVolumeRepository, cmd.GC.MissingGracePeriod),
-		atc.ComponentCollectorContainers:        gc.NewContainerCollector(dbContainerRepository, cmd.GC.MissingGracePeriod, cmd.GC.HijackGracePeriod),
+		atc.ComponentCollectorVolumes:           gc.NewVolumeCollector(dbVolumeRepository, dbWorkerFactory, workerDestroyer, cmd.GC.MissingGracePeriod, diskPressure, cmd.GC.DestroyMaxInFlight, cmd.GC.DestroyMaxInFlightPerWorker),
+		atc.ComponentCollectorContainers:        gc.NewContainerCollector(dbContainerRepository, dbWorkerFactory, workerDestroyer, cmd.GC.MissingGracePeriod, cmd.GC.HijackGracePeriod, cmd.GC.DestroyMaxInFlight, cmd.GC.DestroyMaxInFlightPerWorker),
 		atc.ComponentCollectorCheckSessions:     gc.NewResourceConfigCheckSessionCollector(resourceConfigCheckSessionLifecycle),
 		atc.ComponentCollectorPipelines:         gc.NewPipelineCollector(dbPipelineLifecycle),
 		atc.ComponentCollectorAccessTokens:      gc.NewAccessTokensCollector(dbAccessTokenLifecycle, jwt.DefaultLeeway),
@@ -1311,37 +1515,77 @@ func workerVersion() (version.Version, error) {
 }
 
 func (cmd *RunCommand) secretManager(logger lager.Logger) (creds.Secrets, error) {
+	if len(cmd.CredentialManagement.Managers) > 0 {
+		return cmd.compositeSecretManager(logger)
+	}
+
 	var secretsFactory creds.SecretsFactory = noop.NewNoopFactory()
 	for name, manager := range cmd.CredentialManagers {
 		if !manager.IsConfigured() {
 			continue
 		}
 
-		credsLogger := logger.Session("credential-manager", lager.Data{
-			"name": name,
-		})
-
-		credsLogger.Info("configured credentials manager")
-
-		err := manager.Init(credsLogger)
+		var err error
+		secretsFactory, err = cmd.secretsFactory(logger, name, manager)
 		if err != nil {
 			return nil, err
 		}
 
-		err = manager.Validate()
-		if err != nil {
-			return nil, fmt.Errorf("credential manager '%s' misconfigured: %s", name, err)
+		break
+	}
+
+	return cmd.CredentialManagement.NewSecrets(secretsFactory), nil
+}
+
+// compositeSecretManager looks up vars in each of the credential managers
+// named by --credential-manager in turn. The retries and caching wrap the
+// composite as a whole rather than each manager.
+func (cmd *RunCommand) compositeSecretManager(logger lager.Logger) (creds.Secrets, error) {
+	var factories []creds.SecretsFactory
+	for _, name := range cmd.CredentialManagement.Managers {
+		manager, found := cmd.CredentialManagers[name]
+		if !found {
+			return nil, fmt.Errorf("unknown credential manager '%s'", name)
+		}
+
+		if !manager.IsConfigured() {
+			return nil, fmt.Errorf("credential manager '%s' is not configured", name)
 		}
 
-		secretsFactory, err = manager.NewSecretsFactory(credsLogger)
+		secretsFactory, err := cmd.secretsFactory(logger, name, manager)
 		if err != nil {
 			return nil, err
 		}
 
-		break
+		factories = append(factories, secretsFactory)
 	}
 
-	return cmd.CredentialManagement.NewSecrets(secretsFactory), nil
+	return cmd.CredentialManagement.NewSecrets(creds.NewCompositeSecretsFactory(factories)), nil
+}
+
+func (cmd *RunCommand) secretsFactory(logger lager.Logger, name string, manager creds.Manager) (creds.SecretsFactory, error) {
+	credsLogger := logger.Session("credential-manager", lager.Data{
+		"name": name,
+	})
+
+	credsLogger.Info("configured credentials manager")
+
+	err := manager.Init(credsLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	err = manager.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("credential manager '%s' misconfigured: %s", name, err)
+	}
+
+	secretsFactory, err := manager.NewSecretsFactory(credsLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	return metric.MeterSecrets(credsLogger, name, secretsFactory), nil
 }
 
 func (cmd *RunCommand) newKey() *encryption.Key {
@@ -1485,6 +1729,13 @@ func (cmd *RunCommand) parseDefaultLimits() (atc.ContainerLimits, error) {
 		}
 		limits.Memory = &memory
 	}
+	if cmd.DefaultOutputSizeLimit != nil {
+		size, err := atc.ParseSizeLimit(*cmd.DefaultOutputSizeLimit)
+		if err != nil {
+			return atc.ContainerLimits{}, err
+		}
+		limits.OutputSize = &size
+	}
 	return limits, nil
 }
 
@@ -1565,6 +1816,15 @@ func (cmd *RunCommand) validate() error {
 		errs = multierror.Append(errs, err)
 	}
 
+	if cmd.GC.DiskPressureHighWaterMark > 0 {
+		if cmd.GC.DiskPressureHighWaterMark > 100 || cmd.GC.DiskPressureLowWaterMark > cmd.GC.DiskPressureHighWaterMark {
+			errs = multierror.Append(
+				errs,
+				errors.New("--gc-disk-pressure-high-water-mark must be at most 100 and no lower than --gc-disk-pressure-low-water-mark"),
+			)
+		}
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -1586,6 +1846,15 @@ func (cmd *RunCommand) configureMetrics(logger lager.Logger) error {
 		host, _ = os.Hostname()
 	}
 
+	metric.Metrics.VersionsDiscoveredPipelines = map[string]bool{}
+	for _, pipeline := range cmd.Metrics.VersionsDiscoveredPipelines {
+		if !strings.Contains(pipeline, "/") {
+			return fmt.Errorf("invalid pipeline '%s' for versions discovered metric: must be TEAM/PIPELINE", pipeline)
+		}
+
+		metric.Metrics.VersionsDiscoveredPipelines[pipeline] = true
+	}
+
 	return metric.Metrics.Initialize(logger.Session("metrics"), host, cmd.Metrics.Attributes, cmd.Metrics.BufferSize)
 }
 
@@ -1597,7 +1866,12 @@ func (cmd *RunCommand) constructDBConn(
 	connectionName string,
 	lockFactory lock.LockFactory,
 ) (db.Conn, error) {
-	dbConn, err := db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), cmd.newKey(), cmd.oldKey(), connectionName, lockFactory)
+	open := db.Open
+	if cmd.EncryptionKeyRotation.Online {
+		open = db.OpenWithOnlineKeyRotation
+	}
+
+	dbConn, err := open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), cmd.newKey(), cmd.oldKey(), connectionName, lockFactory)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %s", err)
 	}
@@ -1672,11 +1946,14 @@ func (cmd *RunCommand) constructEngine(
 	buildFactory db.BuildFactory,
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	taskOutputCache db.TaskOutputCacheFactory,
 	secretManager creds.Secrets,
 	defaultLimits atc.ContainerLimits,
 	strategy worker.ContainerPlacementStrategy,
 	lockFactory lock.LockFactory,
 	rateLimiter engine.RateLimiter,
+	poolLimiter engine.CheckPoolRateLimiter,
+	checkLimiter engine.CheckLimiter,
 	policyChecker policy.Checker,
 ) engine.Engine {
 	return engine.NewEngine(
@@ -1690,19 +1967,25 @@ func (cmd *RunCommand) constructEngine(
 				buildFactory,
 				resourceCacheFactory,
 				resourceConfigFactory,
+				taskOutputCache,
 				defaultLimits,
 				strategy,
 				cmd.GlobalResourceCheckTimeout,
+				cmd.MaxVersionsPerCheck,
 			),
 			cmd.ExternalURL.String(),
 			rateLimiter,
+			poolLimiter,
+			checkLimiter,
 			policyChecker,
 			artifactSourcer,
 			workerFactory,
 			lockFactory,
+			cmd.BuildLogRateLimit,
 		),
 		secretManager,
 		cmd.varSourcePool,
+		cmd.idTokenIssuer,
 	)
 }
 
@@ -1730,6 +2013,12 @@ func (cmd *RunCommand) constructHTTPHandler(
 	webMux.Handle("/logout", legacyHandler)
 	webMux.Handle("/", webHandler)
 
+	if cmd.idTokenIssuer != nil {
+		idTokenHandler := cmd.idTokenIssuer.Handler()
+		webMux.Handle(idtoken.DiscoveryPath, idTokenHandler)
+		webMux.Handle(idtoken.KeySetPath, idTokenHandler)
+	}
+
 	httpHandler := wrappa.LoggerHandler{
 		Logger: logger,
 
@@ -1855,6 +2144,14 @@ func (cmd *RunCommand) constructTokenVerifier(accessTokenFactory db.AccessTokenF
 	return accessor.NewVerifier(claimsCacher, validClients)
 }
 
+func (cmd *RunCommand) compression() compression.Compression {
+	if cmd.StreamingArtifactsCompression == "zstd" {
+		return compression.NewZstdCompression()
+	}
+
+	return compression.NewGzipCompression()
+}
+
 func (cmd *RunCommand) constructAPIHandler(
 	logger lager.Logger,
 	reconfigurableSink *lager.ReconfigurableSink,
@@ -1870,12 +2167,18 @@ func (cmd *RunCommand) constructAPIHandler(
 	dbBuildFactory db.BuildFactory,
 	dbCheckFactory db.CheckFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	resourceCacheFactory db.ResourceCacheFactory,
 	dbUserFactory db.UserFactory,
+	dbTeamUsageFactory db.TeamUsageFactory,
+	dbBuildServerLogFactory db.BuildServerLogFactory,
 	workerPool worker.Pool,
+	resourceCacheWarmer worker.ResourceCacheWarmer,
+	artifactSpools *artifactserver.SpoolCache,
 	secretManager creds.Secrets,
 	credsManagers creds.Managers,
 	accessFactory accessor.AccessFactory,
 	dbWall db.Wall,
+	dbEncryptionKeyRotation db.EncryptionKeyRotation,
 	policyChecker policy.Checker,
 ) (http.Handler, error) {
 
@@ -1904,13 +2207,28 @@ func (cmd *RunCommand) constructAPIHandler(
 		return nil, err
 	}
 
+	var eventStore eventarchive.Store
+	eventHandlerFactory := buildserver.NewEventHandler
+	if cmd.BuildEventArchive.IsConfigured() {
+		eventStore, err = cmd.BuildEventArchive.Store()
+		if err != nil {
+			return nil, fmt.Errorf("build event archive: %w", err)
+		}
+
+		eventHandlerFactory = func(logger lager.Logger, build db.Build) http.Handler {
+			return buildserver.NewEventHandler(logger, eventarchive.WithArchivedEvents(build, eventStore))
+		}
+	}
+
+	apiPolicyChecker := policychecker.NewApiPolicyChecker(policyChecker)
+
 	apiWrapper := wrappa.MultiWrappa{
 		wrappa.NewConcurrentRequestLimitsWrappa(
 			logger,
 			wrappa.NewConcurrentRequestPolicy(cmd.ConcurrentRequestLimits),
 		),
 		wrappa.NewAPIMetricsWrappa(logger),
-		wrappa.NewPolicyCheckWrappa(logger, policychecker.NewApiPolicyChecker(policyChecker)),
+		wrappa.NewPolicyCheckWrappa(logger, apiPolicyChecker),
 		wrappa.NewAPIAuthWrappa(
 			checkPipelineAccessHandlerFactory,
 			checkBuildReadAccessHandlerFactory,
@@ -1928,6 +2246,11 @@ func (cmd *RunCommand) constructAPIHandler(
 		wrappa.NewCompressionWrappa(logger),
 	}
 
+	provenanceSigner, err := provenance.NewSigner(cmd.Auth.AuthFlags.SigningKey.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("provenance signer: %w", err)
+	}
+
 	return api.NewHandler(
 		logger,
 		cmd.ExternalURL.String(),
@@ -1946,13 +2269,21 @@ func (cmd *RunCommand) constructAPIHandler(
 		dbBuildFactory,
 		dbCheckFactory,
 		resourceConfigFactory,
+		resourceCacheFactory,
 		dbUserFactory,
+		dbTeamUsageFactory,
+		dbBuildServerLogFactory,
 
-		buildserver.NewEventHandler,
+		eventHandlerFactory,
+		eventStore,
 
 		workerPool,
+		resourceCacheWarmer,
+		artifactSpools,
 
 		reconfigurableSink,
+		provenanceSigner,
+		apiPolicyChecker,
 
 		cmd.isTLSEnabled(),
 
@@ -1965,6 +2296,7 @@ func (cmd *RunCommand) constructAPIHandler(
 		containerserver.NewInterceptTimeoutFactory(cmd.InterceptIdleTimeout),
 		time.Minute,
 		dbWall,
+		dbEncryptionKeyRotation,
 		clock.NewClock(),
 	)
 }
@@ -58,18 +58,25 @@ func (a *auditor) ValidateAction(action string) bool {
 	switch action {
 	case atc.GetBuild,
 		atc.GetBuildPlan,
+		atc.GetBuildProvenance,
 		atc.CreateBuild,
 		atc.RerunJobBuild,
 		atc.ListBuilds,
 		atc.BuildEvents,
 		atc.BuildResources,
 		atc.AbortBuild,
+		atc.ProtectBuild,
+		atc.UnprotectBuild,
 		atc.GetBuildPreparation,
+		atc.GetBuildServerLogs,
+		atc.ListBuildApprovals,
+		atc.DecideBuildApproval,
 		atc.ListBuildsWithVersionAsInput,
 		atc.ListBuildsWithVersionAsOutput,
 		atc.CreateArtifact,
 		atc.GetArtifact,
-		atc.ListBuildArtifacts:
+		atc.ListBuildArtifacts,
+		atc.ListBuildVolumes:
 		return a.EnableBuildAuditLog
 	case atc.ListContainers,
 		atc.GetContainer,
@@ -87,6 +94,7 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.PauseJob,
 		atc.UnpauseJob,
 		atc.ScheduleJob,
+		atc.GetJobSchedulerDecision,
 		atc.JobBadge,
 		atc.MainJobBadge:
 		return a.EnableJobAuditLog
@@ -104,27 +112,47 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.RenamePipeline,
 		atc.ListPipelineBuilds,
 		atc.CreatePipelineBuild,
-		atc.PipelineBadge:
+		atc.PipelineBadge,
+		atc.ListSerialGroups,
+		atc.ListVersionSets,
+		atc.SaveVersionSet,
+		atc.ApplyVersionSet,
+		atc.ClearVersionSet,
+		atc.DestroyVersionSet:
 		return a.EnablePipelineAuditLog
 	case atc.ListAllResources,
+		atc.ListResourceTypeUsages,
 		atc.ListResources,
+		atc.ListResourceChecks,
+		atc.ListResourceCheckRecords,
+		atc.ListPendingChecks,
+		atc.CancelCheck,
 		atc.ListResourceTypes,
 		atc.GetResource,
 		atc.UnpinResource,
 		atc.SetPinCommentOnResource,
+		atc.PauseResourceChecking,
+		atc.UnpauseResourceChecking,
 		atc.CheckResource,
 		atc.CheckResourceWebHook,
 		atc.CheckResourceType,
 		atc.ListResourceVersions,
+		atc.ListResourceVersionToggles,
 		atc.GetResourceVersion,
 		atc.EnableResourceVersion,
 		atc.DisableResourceVersion,
+		atc.PromoteResourceVersion,
+		atc.UnpromoteResourceVersion,
 		atc.PinResourceVersion,
+		atc.BackfillResourceVersions,
+		atc.ExpireResourceVersionCaches,
 		atc.GetResourceCausality:
 		return a.EnableResourceAuditLog
 	case
 		atc.SaveConfig,
+		atc.PatchConfig,
 		atc.GetConfig,
+		atc.GetResourceTypeClosure,
 		atc.GetCC,
 		atc.GetVersionsDB,
 		atc.ClearTaskCache,
@@ -137,26 +165,42 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.GetUser,
 		atc.GetWall,
 		atc.SetWall,
-		atc.ClearWall:
+		atc.ClearWall,
+		atc.RotateEncryptionKey,
+		atc.GetEncryptionKeyRotation:
 		return a.EnableSystemAuditLog
 	case atc.ListTeams,
 		atc.SetTeam,
 		atc.RenameTeam,
 		atc.DestroyTeam,
 		atc.ListTeamBuilds,
+		atc.ListTeamUsage,
+		atc.ListSharedArtifacts,
+		atc.GrantSharedArtifact,
+		atc.RevokeSharedArtifact,
+		atc.ListPipelineVars,
+		atc.SetPipelineVar,
+		atc.DeletePipelineVar,
+		atc.GetTeamResourceTypes,
+		atc.SetTeamResourceTypes,
 		atc.GetTeam:
 		return a.EnableTeamAuditLog
 	case atc.RegisterWorker,
 		atc.LandWorker,
 		atc.RetireWorker,
 		atc.PruneWorker,
+		atc.PruneStalledWorkers,
 		atc.HeartbeatWorker,
+		atc.WarmWorker,
+		atc.ReportWorkerDiskUsage,
 		atc.ListWorkers,
+		atc.ListWorkerUsage,
 		atc.DeleteWorker:
 		return a.EnableWorkerAuditLog
 	case atc.ListVolumes,
 		atc.ListDestroyingVolumes,
-		atc.ReportWorkerVolumes:
+		atc.ReportWorkerVolumes,
+		atc.ReportWorkerVolumeSizes:
 		return a.EnableVolumeAuditLog
 	default:
 		panic(fmt.Sprintf("unhandled action: %s", action))
@@ -32,6 +32,46 @@ type Build struct {
 	RerunNumber          int           `json:"rerun_number,omitempty"`
 	RerunOf              *RerunOfBuild `json:"rerun_of,omitempty"`
 	CreatedBy            *string       `json:"created_by,omitempty"`
+	AbortedBy            *string       `json:"aborted_by,omitempty"`
+	AbortReason          string        `json:"abort_reason,omitempty"`
+	Protected            bool          `json:"protected,omitempty"`
+
+	// QueuePosition is the position of a pending job build in the queue of
+	// builds waiting to be started, starting from 1. It is only reported when
+	// a single build is fetched.
+	QueuePosition int `json:"queue_position,omitempty"`
+
+	// TaskExitCodes are the exit codes the build's task steps exited with so
+	// far. They are only reported when a single build is fetched.
+	TaskExitCodes []TaskExitCode `json:"task_exit_codes,omitempty"`
+}
+
+// TaskExitCode is the exit code a task step of a build exited with.
+type TaskExitCode struct {
+	PlanID   PlanID `json:"plan_id"`
+	Name     string `json:"name"`
+	ExitCode int    `json:"exit_code"`
+}
+
+// BuildNotification is the payload sent to a pipeline's webhooks when one of
+// its job builds finishes.
+type BuildNotification struct {
+	BuildID              int                      `json:"build_id"`
+	BuildName            string                   `json:"build_name"`
+	TeamName             string                   `json:"team_name"`
+	PipelineName         string                   `json:"pipeline_name"`
+	PipelineInstanceVars InstanceVars             `json:"pipeline_instance_vars,omitempty"`
+	JobName              string                   `json:"job_name"`
+	Status               BuildStatus              `json:"status"`
+	PreviousStatus       BuildStatus              `json:"previous_status,omitempty"`
+	StartTime            int64                    `json:"start_time,omitempty"`
+	EndTime              int64                    `json:"end_time"`
+	Inputs               []BuildNotificationInput `json:"inputs"`
+}
+
+type BuildNotificationInput struct {
+	Name    string  `json:"name"`
+	Version Version `json:"version"`
 }
 
 type RerunOfBuild struct {
@@ -75,3 +115,12 @@ type BuildPreparation struct {
 	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
 	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
 }
+
+// ServerLog is a line logged by an ATC while running a build.
+type ServerLog struct {
+	Timestamp string                 `json:"timestamp"`
+	Source    string                 `json:"source"`
+	Message   string                 `json:"message"`
+	LogLevel  LogLevel               `json:"log_level"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+}
@@ -0,0 +1,24 @@
+package atc
+
+type ApprovalStatus string
+
+const (
+	ApprovalPending  ApprovalStatus = "pending"
+	ApprovalApproved ApprovalStatus = "approved"
+	ApprovalRejected ApprovalStatus = "rejected"
+)
+
+type BuildApproval struct {
+	PlanID      PlanID         `json:"plan_id"`
+	Name        string         `json:"name"`
+	Status      ApprovalStatus `json:"status"`
+	RequestedAt int64          `json:"requested_at"`
+	DecidedBy   string         `json:"decided_by,omitempty"`
+	DecidedAt   int64          `json:"decided_at,omitempty"`
+	Comment     string         `json:"comment,omitempty"`
+}
+
+type BuildApprovalDecision struct {
+	Approved bool   `json:"approved"`
+	Comment  string `json:"comment,omitempty"`
+}
@@ -6,15 +6,25 @@ type BuildInputsOutputs struct {
 }
 
 type PublicBuildInput struct {
-	Name            string  `json:"name"`
-	Version         Version `json:"version"`
-	PipelineID      int     `json:"pipeline_id"`
-	FirstOccurrence bool    `json:"first_occurrence"`
+	Name            string          `json:"name"`
+	Version         Version         `json:"version"`
+	Metadata        []MetadataField `json:"metadata,omitempty"`
+	PipelineID      int             `json:"pipeline_id"`
+	FirstOccurrence bool            `json:"first_occurrence"`
+
+	// VersionMissing is set when the version has since been garbage
+	// collected, in which case Version and Metadata are empty and only the
+	// md5 of the version is known.
+	VersionMissing bool   `json:"version_missing,omitempty"`
+	VersionMD5     string `json:"version_md5,omitempty"`
 }
 
 type PublicBuildOutput struct {
-	Name    string  `json:"name"`
-	Version Version `json:"version"`
+	Name           string          `json:"name"`
+	Version        Version         `json:"version"`
+	Metadata       []MetadataField `json:"metadata,omitempty"`
+	VersionMissing bool            `json:"version_missing,omitempty"`
+	VersionMD5     string          `json:"version_md5,omitempty"`
 }
 
 type ResourceVersion struct {
@@ -22,4 +32,5 @@ type ResourceVersion struct {
 	Metadata []MetadataField `json:"metadata,omitempty"`
 	Version  Version         `json:"version"`
 	Enabled  bool            `json:"enabled"`
+	Promoted bool            `json:"promoted,omitempty"`
 }
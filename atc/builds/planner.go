@@ -1,6 +1,9 @@
 package builds
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -19,6 +22,7 @@ func (planner Planner) Create(
 	planConfig atc.StepConfig,
 	resources db.SchedulerResources,
 	resourceTypes atc.VersionedResourceTypes,
+	taskDefaults *atc.TaskDefaults,
 	inputs []db.BuildInput,
 ) (atc.Plan, error) {
 	visitor := &planVisitor{
@@ -26,6 +30,7 @@ func (planner Planner) Create(
 
 		resources:     resources,
 		resourceTypes: resourceTypes,
+		taskDefaults:  taskDefaults,
 		inputs:        inputs,
 	}
 
@@ -34,33 +39,225 @@ func (planner Planner) Create(
 		return atc.Plan{}, err
 	}
 
+	bindInputMetadataVars(&visitor.plan)
+	colocateSoleConsumers(&visitor.plan)
+
 	return visitor.plan, nil
 }
 
+// bindInputMetadataVars adds the fields of the ((.:INPUT.FIELD)) vars in the
+// params of the tasks to the metadata_vars of the INPUT get step, so that the
+// get binds them as a local var before the tasks run. Vars named after a local
+// var set by some other step, e.g. a load_var, are left alone.
+func bindInputMetadataVars(plan *atc.Plan) {
+	localVars := map[string]bool{}
+	fields := map[string][]string{}
+
+	plan.Each(func(p *atc.Plan) {
+		switch {
+		case p.LoadVar != nil:
+			localVars[p.LoadVar.Name] = true
+
+		case p.LoadBuildOutputs != nil:
+			localVars[p.LoadBuildOutputs.Name] = true
+
+		case p.Across != nil:
+			for _, v := range p.Across.Vars {
+				localVars[v.Var] = true
+			}
+
+		case p.Task != nil:
+			names := make([]string, 0, len(p.Task.Params))
+			for name := range p.Task.Params {
+				names = append(names, name)
+			}
+
+			sort.Strings(names)
+
+			for _, name := range names {
+				for _, metadataVar := range atc.ParseInputMetadataVars(p.Task.Params[name]) {
+					fields[metadataVar.Input] = append(fields[metadataVar.Input], metadataVar.Field)
+				}
+			}
+		}
+	})
+
+	if len(fields) == 0 {
+		return
+	}
+
+	plan.Each(func(p *atc.Plan) {
+		// only the gets of the job's inputs; the get after a put has no
+		// version of its own
+		if p.Get == nil || p.Get.Version == nil || localVars[p.Get.Name] {
+			return
+		}
+
+		if len(fields[p.Get.Name]) == 0 {
+			return
+		}
+
+		// copied so as not to append to the step config's keys
+		metadataVars := append([]string(nil), p.Get.MetadataVars...)
+		for _, field := range fields[p.Get.Name] {
+			if !containsString(metadataVars, field) {
+				metadataVars = append(metadataVars, field)
+			}
+		}
+
+		p.Get.MetadataVars = metadataVars
+	})
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// colocateSoleConsumers marks each artifact fetched by a get which exactly one
+// task consumes as one of the task's ColocatedInputs, so that the task can be
+// placed on the worker the get ran on and mount it without streaming it.
+//
+// Nothing is marked when an artifact may be produced by more than one step or
+// consumed by more than one, nor when a step's inputs can't be known until it
+// runs, e.g. a task whose config is read from a file or a put without an
+// explicit list of inputs.
+func colocateSoleConsumers(plan *atc.Plan) {
+	gets := map[string]int{}
+	producers := map[string]int{}
+	consumers := map[string]int{}
+	unknownInputs := false
+
+	artifactOf := func(path string) string {
+		return strings.SplitN(path, "/", 2)[0]
+	}
+
+	plan.Each(func(p *atc.Plan) {
+		switch {
+		case p.Get != nil:
+			gets[p.Get.Name]++
+			producers[p.Get.Name]++
+
+		case p.Task != nil:
+			if p.Task.ConfigPath != "" || p.Task.Config == nil {
+				unknownInputs = true
+				return
+			}
+
+			for _, input := range p.Task.Config.Inputs {
+				consumers[mappedName(p.Task.InputMapping, input.Name)]++
+			}
+
+			for _, output := range p.Task.Config.Outputs {
+				producers[mappedName(p.Task.OutputMapping, output.Name)]++
+			}
+
+			if p.Task.ImageArtifactName != "" {
+				consumers[p.Task.ImageArtifactName]++
+			}
+
+		case p.Put != nil:
+			if p.Put.Inputs == nil || p.Put.Inputs.All || p.Put.Inputs.Detect {
+				unknownInputs = true
+				return
+			}
+
+			for _, input := range p.Put.Inputs.Specified {
+				consumers[input]++
+			}
+
+		case p.SetPipeline != nil:
+			consumers[artifactOf(p.SetPipeline.File)]++
+
+			for _, varFile := range p.SetPipeline.VarFiles {
+				consumers[artifactOf(varFile)]++
+			}
+
+		case p.LoadVar != nil:
+			consumers[artifactOf(p.LoadVar.File)]++
+
+		case p.PublishArtifact != nil:
+			consumers[p.PublishArtifact.From]++
+
+		case p.ConsumeArtifact != nil:
+			producers[p.ConsumeArtifact.Name]++
+
+		case p.ArtifactInput != nil:
+			producers[p.ArtifactInput.Name]++
+
+		case p.ArtifactOutput != nil:
+			consumers[p.ArtifactOutput.Name]++
+		}
+	})
+
+	if unknownInputs {
+		return
+	}
+
+	plan.Each(func(p *atc.Plan) {
+		if p.Task == nil {
+			return
+		}
+
+		for _, input := range p.Task.Config.Inputs {
+			name := mappedName(p.Task.InputMapping, input.Name)
+			if gets[name] == 1 && producers[name] == 1 && consumers[name] == 1 {
+				p.Task.ColocatedInputs = append(p.Task.ColocatedInputs, name)
+			}
+		}
+	})
+}
+
+func mappedName(mapping map[string]string, name string) string {
+	if mappedName, ok := mapping[name]; ok {
+		return mappedName
+	}
+
+	return name
+}
+
 type planVisitor struct {
 	planFactory atc.PlanFactory
 
 	resources     db.SchedulerResources
 	resourceTypes atc.VersionedResourceTypes
+	taskDefaults  *atc.TaskDefaults
 	inputs        []db.BuildInput
 
 	plan atc.Plan
 }
 
 func (visitor *planVisitor) VisitTask(step *atc.TaskStep) error {
+	var defaultLimits *atc.ContainerLimits
+	if visitor.taskDefaults != nil {
+		defaultLimits = visitor.taskDefaults.Limits
+	}
+
 	visitor.plan = visitor.planFactory.NewPlan(atc.TaskPlan{
 		Name:              step.Name,
 		Privileged:        step.Privileged,
 		Config:            step.Config,
 		Limits:            step.Limits,
+		DefaultLimits:     defaultLimits,
 		ConfigPath:        step.ConfigPath,
 		Vars:              step.Vars,
-		Tags:              step.Tags,
+		Tags:              step.Tags.AllTags(),
+		AnyTags:           step.Tags.AnyTags(),
+		Runtime:           step.Runtime,
 		Params:            step.Params,
 		InputMapping:      step.InputMapping,
 		OutputMapping:     step.OutputMapping,
 		ImageArtifactName: step.ImageArtifactName,
 		Timeout:           step.Timeout,
+		WorkerName:        step.WorkerName,
+		CacheOutputs:      step.CacheOutputs,
+		Init:              step.Init,
+		Hosts:             step.Hosts,
 
 		VersionedResourceTypes: visitor.resourceTypes,
 	})
@@ -101,9 +298,16 @@ func (visitor *planVisitor) VisitGet(step *atc.GetStep) error {
 		Source:   resource.Source,
 		Params:   step.Params,
 		Version:  &version,
-		Tags:     step.Tags,
+		Tags:     step.Tags.AllTags(),
+		AnyTags:  step.Tags.AnyTags(),
+		Runtime:  step.Runtime,
 		Timeout:  step.Timeout,
 
+		MetadataVars: step.MetadataVars,
+		Unpack:       step.Unpack,
+		Proxy:        resource.Proxy,
+		Hosts:        step.Hosts,
+
 		VersionedResourceTypes: visitor.resourceTypes,
 	})
 
@@ -132,10 +336,14 @@ func (visitor *planVisitor) VisitPut(step *atc.PutStep) error {
 		Source:               resource.Source,
 		Params:               step.Params,
 		ExposeBuildCreatedBy: resource.ExposeBuildCreatedBy,
+		Proxy:                resource.Proxy,
+		Hosts:                step.Hosts,
 
 		Inputs: step.Inputs,
 
-		Tags:    step.Tags,
+		Tags:    step.Tags.AllTags(),
+		AnyTags: step.Tags.AnyTags(),
+		Runtime: step.Runtime,
 		Timeout: step.Timeout,
 
 		VersionedResourceTypes: visitor.resourceTypes,
@@ -150,8 +358,12 @@ func (visitor *planVisitor) VisitPut(step *atc.PutStep) error {
 		Source:      resource.Source,
 		Params:      step.GetParams,
 		VersionFrom: &putPlan.ID,
+		Proxy:       resource.Proxy,
+		Hosts:       step.Hosts,
 
-		Tags:    step.Tags,
+		Tags:    step.Tags.AllTags(),
+		AnyTags: step.Tags.AnyTags(),
+		Runtime: step.Runtime,
 		Timeout: step.Timeout,
 
 		VersionedResourceTypes: visitor.resourceTypes,
@@ -182,6 +394,25 @@ func (visitor *planVisitor) VisitDo(step *atc.DoStep) error {
 	return nil
 }
 
+func (visitor *planVisitor) VisitAtomic(step *atc.AtomicStep) error {
+	var steps []atc.Plan
+
+	for _, sub := range step.Steps {
+		err := sub.Config.Visit(visitor)
+		if err != nil {
+			return err
+		}
+
+		steps = append(steps, visitor.plan)
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.AtomicPlan{
+		Steps: steps,
+	})
+
+	return nil
+}
+
 func (visitor *planVisitor) VisitInParallel(step *atc.InParallelStep) error {
 	var steps []atc.Plan
 
@@ -198,6 +429,10 @@ func (visitor *planVisitor) VisitInParallel(step *atc.InParallelStep) error {
 		Steps:    steps,
 		Limit:    step.Config.Limit,
 		FailFast: step.Config.FailFast,
+
+		FailFastGracePeriod: step.Config.FailFastGracePeriod,
+
+		OutputPriority: step.Config.PrioritizedSteps(),
 	})
 
 	return nil
@@ -263,6 +498,58 @@ func (visitor *planVisitor) VisitLoadVar(step *atc.LoadVarStep) error {
 		File:   step.File,
 		Format: step.Format,
 		Reveal: step.Reveal,
+
+		SetPipelineVar: step.SetPipelineVar,
+	})
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitApproval(step *atc.ApprovalStep) error {
+	visitor.plan = visitor.planFactory.NewPlan(atc.ApprovalPlan{
+		Name:    step.Name,
+		Timeout: step.Timeout,
+	})
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitPublishArtifact(step *atc.PublishArtifactStep) error {
+	visitor.plan = visitor.planFactory.NewPlan(atc.PublishArtifactPlan{
+		Name: step.Name,
+		From: step.ArtifactName(),
+	})
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitConsumeArtifact(step *atc.ConsumeArtifactStep) error {
+	visitor.plan = visitor.planFactory.NewPlan(atc.ConsumeArtifactPlan{
+		Name: step.Name,
+		Team: step.Team,
+	})
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitLoadBuildOutputs(step *atc.LoadBuildOutputsStep) error {
+	visitor.plan = visitor.planFactory.NewPlan(atc.LoadBuildOutputsPlan{
+		Name:    step.Name,
+		Job:     step.Job,
+		Build:   step.Build,
+		Outputs: step.Outputs,
+		Reveal:  step.Reveal,
+	})
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitAssertGreen(step *atc.AssertGreenStep) error {
+	visitor.plan = visitor.planFactory.NewPlan(atc.AssertGreenPlan{
+		Job:          step.Job,
+		Pipeline:     step.Pipeline,
+		InstanceVars: step.InstanceVars,
+		Team:         step.Team,
 	})
 
 	return nil
@@ -296,7 +583,11 @@ func (visitor *planVisitor) VisitTimeout(step *atc.TimeoutStep) error {
 }
 
 func (visitor *planVisitor) VisitRetry(step *atc.RetryStep) error {
-	retryStep := make(atc.RetryPlan, step.Attempts)
+	retryStep := atc.RetryPlan{
+		Steps:       make([]atc.Plan, step.Attempts),
+		Backoff:     step.Backoff,
+		MaxDuration: step.MaxDuration,
+	}
 
 	for i := 0; i < step.Attempts; i++ {
 		err := step.Step.Visit(visitor)
@@ -304,7 +595,7 @@ func (visitor *planVisitor) VisitRetry(step *atc.RetryStep) error {
 			return err
 		}
 
-		retryStep[i] = visitor.plan
+		retryStep.Steps[i] = visitor.plan
 	}
 
 	visitor.plan = visitor.planFactory.NewPlan(retryStep)
@@ -0,0 +1,152 @@
+package builds
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// ServerLogFlushInterval is how often the log lines pertaining to builds are
+// saved.
+var ServerLogFlushInterval = time.Second
+
+// buildIDKeys are the lager.Data keys under which log lines pertaining to a
+// build carry its id.
+var buildIDKeys = []string{"build_id", "build-id"}
+
+// ServerLogSink is a lager.Sink which saves the log lines pertaining to builds
+// so that they can be looked up by build id, whichever ATC logged them. Lines
+// of check builds are not saved.
+//
+// Logging must not wait on the database, so lines are buffered and saved
+// every ServerLogFlushInterval by Run. If they can't be saved as fast as they
+// are logged, the oldest buffered lines are dropped.
+type ServerLogSink struct {
+	logger     lager.Logger
+	levelSink  *lager.ReconfigurableSink
+	logFactory db.BuildServerLogFactory
+	clock      clock.Clock
+	size       int
+
+	pendingL sync.Mutex
+	pending  []db.BuildServerLog
+}
+
+// NewServerLogSink constructs a ServerLogSink saving the lines logged at or
+// above the minimum level of the given sink, keeping up to size lines per
+// build.
+func NewServerLogSink(
+	logger lager.Logger,
+	levelSink *lager.ReconfigurableSink,
+	logFactory db.BuildServerLogFactory,
+	clock clock.Clock,
+	size int,
+) *ServerLogSink {
+	return &ServerLogSink{
+		logger:     logger,
+		levelSink:  levelSink,
+		logFactory: logFactory,
+		clock:      clock,
+		size:       size,
+	}
+}
+
+func (sink *ServerLogSink) Log(log lager.LogFormat) {
+	if sink.size == 0 {
+		return
+	}
+
+	if log.LogLevel < sink.levelSink.GetMinLevel() {
+		return
+	}
+
+	buildID, found := buildIDFromData(log.Data)
+	if !found {
+		return
+	}
+
+	if isCheckBuild(log.Data) {
+		return
+	}
+
+	sink.pendingL.Lock()
+	defer sink.pendingL.Unlock()
+
+	if len(sink.pending) == sink.size {
+		sink.pending = sink.pending[1:]
+	}
+
+	sink.pending = append(sink.pending, db.BuildServerLog{
+		BuildID: buildID,
+		Log:     log,
+	})
+}
+
+// Run saves the buffered lines every ServerLogFlushInterval, and once more
+// when signalled.
+func (sink *ServerLogSink) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	ticker := sink.clock.NewTicker(ServerLogFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			err := sink.Flush()
+			if err != nil {
+				sink.logger.Error("failed-to-save-server-logs", err)
+			}
+
+		case <-signals:
+			return sink.Flush()
+		}
+	}
+}
+
+// Flush saves the buffered lines. Lines which fail to be saved are dropped.
+func (sink *ServerLogSink) Flush() error {
+	sink.pendingL.Lock()
+	logs := sink.pending
+	sink.pending = nil
+	sink.pendingL.Unlock()
+
+	if len(logs) == 0 {
+		return nil
+	}
+
+	return sink.logFactory.SaveLogs(logs, sink.size)
+}
+
+// isCheckBuild tells whether the line pertains to a check build, from the
+// data of db.Build.LagerData: only check builds belong to a pipeline without
+// belonging to a job. They log more than any other builds and are short-lived,
+// so their lines aren't worth saving.
+func isCheckBuild(data lager.Data) bool {
+	_, hasPipeline := data["pipeline"]
+	_, hasJob := data["job"]
+	return hasPipeline && !hasJob
+}
+
+func buildIDFromData(data lager.Data) (int, bool) {
+	for _, key := range buildIDKeys {
+		switch id := data[key].(type) {
+		case int:
+			return id, true
+		case float64:
+			return int(id), true
+		case string:
+			buildID, err := strconv.Atoi(id)
+			if err == nil {
+				return buildID, true
+			}
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,166 @@
+package builds_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/builds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/tedsuo/ifrit"
+)
+
+type ServerLogSinkSuite struct {
+	suite.Suite
+	*require.Assertions
+
+	levelSink      *lager.ReconfigurableSink
+	logger         lager.Logger
+	fakeLogFactory *dbfakes.FakeBuildServerLogFactory
+	fakeClock      *fakeclock.FakeClock
+}
+
+func TestServerLogSink(t *testing.T) {
+	suite.Run(t, &ServerLogSinkSuite{
+		Assertions: require.New(t),
+	})
+}
+
+func (s *ServerLogSinkSuite) SetupTest() {
+	s.levelSink = lager.NewReconfigurableSink(lager.NewWriterSink(ioutil.Discard, lager.DEBUG), lager.INFO)
+	s.logger = lager.NewLogger("test")
+	s.fakeLogFactory = new(dbfakes.FakeBuildServerLogFactory)
+	s.fakeClock = fakeclock.NewFakeClock(time.Now())
+}
+
+func (s *ServerLogSinkSuite) newSink(size int) *builds.ServerLogSink {
+	sink := builds.NewServerLogSink(lagertest.NewTestLogger("sink"), s.levelSink, s.fakeLogFactory, s.fakeClock, size)
+	s.logger.RegisterSink(sink)
+	return sink
+}
+
+func (s *ServerLogSinkSuite) savedLogs() []db.BuildServerLog {
+	saved := []db.BuildServerLog{}
+	for i := 0; i < s.fakeLogFactory.SaveLogsCallCount(); i++ {
+		logs, _ := s.fakeLogFactory.SaveLogsArgsForCall(i)
+		saved = append(saved, logs...)
+	}
+
+	return saved
+}
+
+func (s *ServerLogSinkSuite) TestSavesLinesForBuild() {
+	sink := s.newSink(10)
+
+	s.logger.Info("some-message", lager.Data{"build_id": 1})
+	s.logger.Info("other-build", lager.Data{"build_id": 2})
+	s.logger.Info("no-build")
+	s.logger.Session("session", lager.Data{"build-id": 1}).Info("other-message")
+
+	s.NoError(sink.Flush())
+
+	s.Equal(1, s.fakeLogFactory.SaveLogsCallCount())
+	logs, limit := s.fakeLogFactory.SaveLogsArgsForCall(0)
+	s.Equal(10, limit)
+	s.Len(logs, 3)
+	s.Equal(1, logs[0].BuildID)
+	s.Equal("test.some-message", logs[0].Log.Message)
+	s.Equal(2, logs[1].BuildID)
+	s.Equal("test.other-build", logs[1].Log.Message)
+	s.Equal(1, logs[2].BuildID)
+	s.Equal("test.session.other-message", logs[2].Log.Message)
+
+	s.NoError(sink.Flush())
+	s.Equal(1, s.fakeLogFactory.SaveLogsCallCount())
+}
+
+func (s *ServerLogSinkSuite) TestRespectsMinimumLevel() {
+	sink := s.newSink(10)
+
+	s.logger.Debug("debug-message", lager.Data{"build_id": 1})
+	s.NoError(sink.Flush())
+	s.Empty(s.savedLogs())
+
+	s.levelSink.SetMinLevel(lager.DEBUG)
+
+	s.logger.Debug("debug-message", lager.Data{"build_id": 1})
+	s.NoError(sink.Flush())
+	s.Len(s.savedLogs(), 1)
+}
+
+func (s *ServerLogSinkSuite) TestDropsOldestBufferedLines() {
+	sink := s.newSink(2)
+
+	s.logger.Info("first", lager.Data{"build_id": 1})
+	s.logger.Info("second", lager.Data{"build_id": 1})
+	s.logger.Info("third", lager.Data{"build_id": 1})
+
+	s.NoError(sink.Flush())
+
+	logs := s.savedLogs()
+	s.Len(logs, 2)
+	s.Equal("test.second", logs[0].Log.Message)
+	s.Equal("test.third", logs[1].Log.Message)
+}
+
+func (s *ServerLogSinkSuite) TestReturnsSaveErrors() {
+	sink := s.newSink(10)
+	s.fakeLogFactory.SaveLogsReturns(errors.New("nope"))
+
+	s.logger.Info("some-message", lager.Data{"build_id": 1})
+	s.EqualError(sink.Flush(), "nope")
+}
+
+func (s *ServerLogSinkSuite) TestSkipsCheckBuilds() {
+	sink := s.newSink(10)
+
+	s.logger.Info("check", lager.Data{"build_id": 1, "pipeline": "some-pipeline", "resource": "some-resource"})
+	s.logger.Info("job-build", lager.Data{"build_id": 2, "pipeline": "some-pipeline", "job": "some-job", "resource": "some-resource"})
+	s.logger.Info("one-off-build", lager.Data{"build_id": 3, "resource": "some-resource"})
+
+	s.NoError(sink.Flush())
+
+	logs := s.savedLogs()
+	s.Len(logs, 2)
+	s.Equal(2, logs[0].BuildID)
+	s.Equal(3, logs[1].BuildID)
+}
+
+func (s *ServerLogSinkSuite) TestDisabled() {
+	sink := s.newSink(0)
+
+	s.logger.Info("some-message", lager.Data{"build_id": 1})
+	s.NoError(sink.Flush())
+	s.Zero(s.fakeLogFactory.SaveLogsCallCount())
+}
+
+func (s *ServerLogSinkSuite) TestRunSavesPeriodically() {
+	sink := s.newSink(10)
+
+	process := ifrit.Invoke(sink)
+
+	s.logger.Info("some-message", lager.Data{"build_id": 1})
+
+	s.fakeClock.WaitForWatcherAndIncrement(builds.ServerLogFlushInterval)
+	s.Eventually(func() bool {
+		return s.fakeLogFactory.SaveLogsCallCount() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	s.logger.Info("other-message", lager.Data{"build_id": 1})
+
+	process.Signal(os.Interrupt)
+	s.NoError(<-process.Wait())
+
+	logs := s.savedLogs()
+	s.Len(logs, 2)
+	s.Equal("test.some-message", logs[0].Log.Message)
+	s.Equal("test.other-message", logs[1].Log.Message)
+}
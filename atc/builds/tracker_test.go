@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/builds"
 	"github.com/concourse/concourse/atc/component"
 	"github.com/concourse/concourse/atc/db"
@@ -27,6 +28,7 @@ type TrackerSuite struct {
 
 	fakeBuildFactory *dbfakes.FakeBuildFactory
 	fakeEngine       *enginefakes.FakeEngine
+	fakeBus          *dbfakes.FakeNotificationsBus
 
 	tracker *builds.Tracker
 }
@@ -40,10 +42,12 @@ func TestTracker(t *testing.T) {
 func (s *TrackerSuite) SetupTest() {
 	s.fakeBuildFactory = new(dbfakes.FakeBuildFactory)
 	s.fakeEngine = new(enginefakes.FakeEngine)
+	s.fakeBus = new(dbfakes.FakeNotificationsBus)
 
 	s.tracker = builds.NewTracker(
 		s.fakeBuildFactory,
 		s.fakeEngine,
+		s.fakeBus,
 	)
 }
 
@@ -172,3 +176,13 @@ func (s *TrackerSuite) TestTrackerDrainsEngine() {
 	s.Equal(1, s.fakeEngine.DrainCallCount())
 	s.Equal(ctx, s.fakeEngine.DrainArgsForCall(0))
 }
+
+func (s *TrackerSuite) TestTrackerNotifiesOtherTrackersOnceDrained() {
+	s.fakeEngine.DrainStub = func(context.Context) {
+		s.Zero(s.fakeBus.NotifyCallCount())
+	}
+
+	s.tracker.Drain(context.TODO())
+	s.Equal(1, s.fakeBus.NotifyCallCount())
+	s.Equal(atc.ComponentBuildTracker, s.fakeBus.NotifyArgsForCall(0))
+}
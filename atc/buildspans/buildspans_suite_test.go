@@ -0,0 +1,88 @@
+package buildspans_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/tracing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/oteltest"
+
+	"testing"
+)
+
+func TestBuildSpans(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Build Spans Suite")
+}
+
+var spanRecorder *oteltest.SpanRecorder
+
+var _ = BeforeEach(func() {
+	spanRecorder = new(oteltest.SpanRecorder)
+	tracing.ConfigureTraceProvider(oteltest.NewTracerProvider(oteltest.WithSpanRecorder(spanRecorder)))
+})
+
+var _ = AfterEach(func() {
+	tracing.Configured = false
+})
+
+func envelope(ev atc.Event, id int) event.Envelope {
+	payload, err := json.Marshal(ev)
+	Expect(err).ToNot(HaveOccurred())
+
+	data := json.RawMessage(payload)
+	return event.Envelope{
+		Data:    &data,
+		Event:   ev.EventType(),
+		Version: ev.Version(),
+		EventID: strconv.Itoa(id),
+	}
+}
+
+func fakeEventSource(events ...atc.Event) *dbfakes.FakeEventSource {
+	source := new(dbfakes.FakeEventSource)
+	for i, ev := range events {
+		source.NextReturnsOnCall(i, envelope(ev, i), nil)
+	}
+	source.NextReturns(event.Envelope{}, db.ErrEndOfBuildEventStream)
+	return source
+}
+
+func archived(events ...atc.Event) []byte {
+	buf := new(bytes.Buffer)
+	gz := gzip.NewWriter(buf)
+	encoder := json.NewEncoder(gz)
+	for i, ev := range events {
+		Expect(encoder.Encode(envelope(ev, i))).To(Succeed())
+	}
+	Expect(gz.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+// recordedSpan returns the completed span of the given component, and of the
+// step with the given name if it is not empty.
+func recordedSpan(component string, name string) *oteltest.Span {
+	for _, span := range spanRecorder.Completed() {
+		if span.Name() != component {
+			continue
+		}
+
+		if name != "" && span.Attributes()["name"] != attribute.StringValue(name) {
+			continue
+		}
+
+		return span
+	}
+
+	Fail("no " + component + " span named '" + name + "' was recorded")
+	return nil
+}
@@ -0,0 +1,79 @@
+package buildspans
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/component"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/eventarchive"
+)
+
+type Config struct {
+	Enable    bool          `long:"enable" description:"Export the spans of completed builds, reconstructed from their events, to the configured tracing provider. Builds which completed before this was enabled are backfilled, oldest first."`
+	Interval  time.Duration `long:"interval" default:"1m" description:"Interval on which the spans of completed builds are exported."`
+	BatchSize int           `long:"batch-size" default:"100" description:"Maximum number of builds exported each interval."`
+}
+
+type exporter struct {
+	buildFactory db.BuildFactory
+	eventStore   eventarchive.Store
+	batchSize    int
+}
+
+// NewExporter returns a component which exports the spans of completed builds
+// which haven't been exported yet. Each build is exported at most once, by
+// whichever ATC claims it.
+//
+// If the build event archive is configured, eventStore is where the events of
+// archived builds are read from. It may be nil otherwise.
+func NewExporter(buildFactory db.BuildFactory, eventStore eventarchive.Store, batchSize int) component.Runnable {
+	return &exporter{
+		buildFactory: buildFactory,
+		eventStore:   eventStore,
+		batchSize:    batchSize,
+	}
+}
+
+func (e *exporter) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("span-exporter")
+
+	builds, err := e.buildFactory.ClaimSpanExportableBuilds(e.batchSize)
+	if err != nil {
+		logger.Error("failed-to-claim-span-exportable-builds", err)
+		return err
+	}
+
+	// the builds are claimed as exported, so one which fails to export is
+	// skipped rather than holding up the rest of the backfill on every run
+	for _, build := range builds {
+		e.exportBuild(ctx, logger, build)
+	}
+
+	return nil
+}
+
+func (e *exporter) exportBuild(ctx context.Context, logger lager.Logger, build db.Build) {
+	logger = logger.Session("export-build", build.LagerData())
+
+	// e.g. a build which was aborted while pending has no events to
+	// reconstruct spans from
+	if build.StartTime().IsZero() {
+		logger.Debug("never-started")
+		return
+	}
+
+	if e.eventStore != nil {
+		build = eventarchive.WithArchivedEvents(build, e.eventStore)
+	}
+
+	err := Export(ctx, build)
+	if err != nil {
+		logger.Error("failed-to-export-spans", err)
+		return
+	}
+
+	logger.Debug("exported")
+}
@@ -0,0 +1,123 @@
+package buildspans_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/buildspans"
+	"github.com/concourse/concourse/atc/component"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/eventarchive"
+	"github.com/concourse/concourse/atc/eventarchive/eventarchivefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Exporter", func() {
+	var (
+		fakeBuildFactory *dbfakes.FakeBuildFactory
+		fakeBuild        *dbfakes.FakeBuild
+		eventStore       eventarchive.Store
+
+		exporter component.Runnable
+		runErr   error
+	)
+
+	BeforeEach(func() {
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.StatusReturns(db.BuildStatusSucceeded)
+		fakeBuild.StartTimeReturns(time.Unix(1000, 0))
+		fakeBuild.EventsReturns(fakeEventSource(), nil)
+
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeBuildFactory.ClaimSpanExportableBuildsReturns([]db.Build{fakeBuild}, nil)
+
+		eventStore = nil
+	})
+
+	JustBeforeEach(func() {
+		exporter = buildspans.NewExporter(fakeBuildFactory, eventStore, 10)
+		runErr = exporter.Run(context.TODO())
+	})
+
+	It("exports a batch of builds", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+		Expect(fakeBuildFactory.ClaimSpanExportableBuildsCallCount()).To(Equal(1))
+		Expect(fakeBuildFactory.ClaimSpanExportableBuildsArgsForCall(0)).To(Equal(10))
+
+		Expect(recordedSpan("build", "")).ToNot(BeNil())
+	})
+
+	Context("when a build never started", func() {
+		BeforeEach(func() {
+			fakeBuild.StartTimeReturns(time.Time{})
+		})
+
+		It("skips it", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeBuild.EventsCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the build's events have been archived", func() {
+		var fakeStore *eventarchivefakes.FakeStore
+
+		BeforeEach(func() {
+			fakeBuild.EventsArchiveKeyReturns("builds/42/events.json.gz")
+			fakeBuild.PrivatePlanReturns(atc.Plan{ID: "get", Get: &atc.GetPlan{Name: "a"}})
+
+			fakeStore = new(eventarchivefakes.FakeStore)
+			fakeStore.GetReturns(ioutil.NopCloser(bytes.NewReader(archived(
+				event.InitializeGet{Origin: event.Origin{ID: "get"}, Time: 1000},
+			))), nil)
+
+			eventStore = fakeStore
+		})
+
+		It("reads the events from the store", func() {
+			Expect(fakeStore.GetCallCount()).To(Equal(1))
+			_, key := fakeStore.GetArgsForCall(0)
+			Expect(key).To(Equal("builds/42/events.json.gz"))
+
+			Expect(recordedSpan("get", "a")).ToNot(BeNil())
+		})
+	})
+
+	Context("when exporting a build fails", func() {
+		var otherBuild *dbfakes.FakeBuild
+
+		BeforeEach(func() {
+			fakeBuild.EventsReturns(nil, errors.New("nope"))
+
+			otherBuild = new(dbfakes.FakeBuild)
+			otherBuild.IDReturns(43)
+			otherBuild.StatusReturns(db.BuildStatusSucceeded)
+			otherBuild.StartTimeReturns(time.Unix(1000, 0))
+			otherBuild.EventsReturns(fakeEventSource(), nil)
+
+			fakeBuildFactory.ClaimSpanExportableBuildsReturns([]db.Build{fakeBuild, otherBuild}, nil)
+		})
+
+		It("skips it and exports the rest", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(otherBuild.EventsCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when getting the builds fails", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.ClaimSpanExportableBuildsReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+})
@@ -0,0 +1,409 @@
+package buildspans
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/tracing"
+)
+
+// Export reconstructs the spans of a completed build from its plan and events
+// and records them with the configured trace provider.
+//
+// The build is the root span. Every step which ran is a span nested the way
+// it is in the plan, so e.g. the steps of an in_parallel step are children of
+// the in_parallel span, and a hook is a child of the on_success (etc.) span
+// alongside the step it is attached to. Steps get their times and errors from
+// their events; steps made of other steps span their children.
+func Export(ctx context.Context, build db.Build) error {
+	timeline, err := readTimeline(build)
+	if err != nil {
+		return err
+	}
+
+	attrs := tracing.Attrs{"status": string(build.Status())}
+	for k, v := range build.TracingAttrs() {
+		attrs[k] = v
+	}
+
+	root := &span{
+		component: "build",
+		attrs:     attrs,
+		start:     build.StartTime(),
+		end:       build.EndTime(),
+	}
+
+	if build.Status() != db.BuildStatusSucceeded {
+		root.err = fmt.Errorf("build %s", build.Status())
+	}
+
+	if step := timeline.span(build.PrivatePlan()); step != nil {
+		root.children = []*span{step}
+
+		if root.start.IsZero() {
+			root.start = step.start
+		}
+	}
+
+	if root.end.IsZero() {
+		root.end = timeline.end
+	}
+
+	root.record(ctx)
+
+	return nil
+}
+
+type span struct {
+	component string
+	attrs     tracing.Attrs
+	start     time.Time
+	end       time.Time
+	err       error
+	children  []*span
+}
+
+func (s *span) record(ctx context.Context) {
+	ctx, otelSpan := tracing.StartSpanAt(ctx, s.component, s.attrs, s.start)
+
+	for _, child := range s.children {
+		child.record(ctx)
+	}
+
+	tracing.EndAt(otelSpan, s.err, s.end)
+}
+
+// origin is what a build's events tell about one of its steps.
+type origin struct {
+	start time.Time
+	end   time.Time
+	err   error
+}
+
+func (o *origin) saw(unix int64) {
+	if unix == 0 {
+		return
+	}
+
+	t := time.Unix(unix, 0)
+
+	if o.start.IsZero() || t.Before(o.start) {
+		o.start = t
+	}
+
+	if t.After(o.end) {
+		o.end = t
+	}
+}
+
+func (o *origin) failed(err error) {
+	if o.err == nil {
+		o.err = err
+	}
+}
+
+type timeline struct {
+	origins map[atc.PlanID]*origin
+	end     time.Time
+}
+
+func readTimeline(build db.Build) (timeline, error) {
+	t := timeline{
+		origins: map[atc.PlanID]*origin{},
+	}
+
+	events, err := build.Events(0)
+	if err != nil {
+		return t, fmt.Errorf("get events: %w", err)
+	}
+
+	defer db.Close(events)
+
+	for {
+		envelope, err := events.Next()
+		if err != nil {
+			if errors.Is(err, db.ErrEndOfBuildEventStream) {
+				break
+			}
+
+			return t, fmt.Errorf("read events: %w", err)
+		}
+
+		if envelope.Data == nil {
+			continue
+		}
+
+		ev, err := event.ParseEvent(envelope.Version, envelope.Event, *envelope.Data)
+		if err != nil {
+			// events of a type or version we don't know of have no bearing on
+			// the spans we can tell
+			continue
+		}
+
+		t.add(ev)
+	}
+
+	return t, nil
+}
+
+func (t timeline) origin(id event.OriginID) *origin {
+	o, found := t.origins[atc.PlanID(id)]
+	if !found {
+		o = &origin{}
+		t.origins[atc.PlanID(id)] = o
+	}
+
+	return o
+}
+
+func (t *timeline) add(ev atc.Event) {
+	switch e := ev.(type) {
+	case event.Status:
+		if e.Time != 0 {
+			t.end = time.Unix(e.Time, 0)
+		}
+	case event.Error:
+		o := t.origin(e.Origin.ID)
+		o.saw(e.Time)
+		o.failed(errors.New(e.Message))
+	case event.FinishTask:
+		o := t.origin(e.Origin.ID)
+		o.saw(e.Time)
+		if e.ExitStatus != 0 {
+			o.failed(fmt.Errorf("exit status %d", e.ExitStatus))
+		}
+	case event.FinishGet:
+		o := t.origin(e.Origin.ID)
+		o.saw(e.Time)
+		if e.ExitStatus != 0 {
+			o.failed(fmt.Errorf("exit status %d", e.ExitStatus))
+		}
+	case event.FinishPut:
+		o := t.origin(e.Origin.ID)
+		o.saw(e.Time)
+		if e.ExitStatus != 0 {
+			o.failed(fmt.Errorf("exit status %d", e.ExitStatus))
+		}
+	case event.Finish:
+		o := t.origin(e.Origin.ID)
+		o.saw(e.Time)
+		if !e.Succeeded {
+			o.failed(errors.New("failed"))
+		}
+	case event.InitializeTask:
+		t.origin(e.Origin.ID).saw(e.Time)
+	case event.StartTask:
+		t.origin(e.Origin.ID).saw(e.Time)
+	case event.InitializeGet:
+		t.origin(e.Origin.ID).saw(e.Time)
+	case event.StartGet:
+		t.origin(e.Origin.ID).saw(e.Time)
+	case event.InitializePut:
+		t.origin(e.Origin.ID).saw(e.Time)
+	case event.StartPut:
+		t.origin(e.Origin.ID).saw(e.Time)
+	case event.Initialize:
+		t.origin(e.Origin.ID).saw(e.Time)
+	case event.Start:
+		t.origin(e.Origin.ID).saw(e.Time)
+	case event.WaitingForWorker:
+		t.origin(e.Origin.ID).saw(e.Time)
+	case event.SelectedWorker:
+		t.origin(e.Origin.ID).saw(e.Time)
+	case event.Log:
+		t.origin(e.Origin.ID).saw(e.Time)
+	case event.ImageCheck:
+		t.origin(e.Origin.ID).saw(e.Time)
+	case event.ImageGet:
+		t.origin(e.Origin.ID).saw(e.Time)
+	case event.ImageFetched:
+		t.origin(e.Origin.ID).saw(e.Time)
+	}
+}
+
+// span returns the span of the step, or nil if it never ran.
+func (t timeline) span(plan atc.Plan) *span {
+	switch {
+	case plan.Do != nil:
+		return t.parent("do", nil, *plan.Do...)
+
+	case plan.InParallel != nil:
+		return t.parent("in_parallel", nil, plan.InParallel.Steps...)
+
+	case plan.Atomic != nil:
+		return t.parent("atomic", nil, plan.Atomic.Steps...)
+
+	case plan.Across != nil:
+		var children []*span
+		for _, step := range plan.Across.Steps {
+			child := t.span(step.Step)
+			if child == nil {
+				continue
+			}
+
+			values, err := json.Marshal(step.Values)
+			if err == nil {
+				child.attrs["across_values"] = string(values)
+			}
+
+			children = append(children, child)
+		}
+
+		return spanning("across", nil, children)
+
+	case plan.OnSuccess != nil:
+		return t.hook("on_success", plan.OnSuccess.Step, plan.OnSuccess.Next)
+
+	case plan.OnFailure != nil:
+		return t.hook("on_failure", plan.OnFailure.Step, plan.OnFailure.Next)
+
+	case plan.OnAbort != nil:
+		return t.hook("on_abort", plan.OnAbort.Step, plan.OnAbort.Next)
+
+	case plan.OnError != nil:
+		return t.hook("on_error", plan.OnError.Step, plan.OnError.Next)
+
+	case plan.Ensure != nil:
+		return t.hook("ensure", plan.Ensure.Step, plan.Ensure.Next)
+
+	case plan.Try != nil:
+		return t.parent("try", nil, plan.Try.Step)
+
+	case plan.Timeout != nil:
+		return t.parent("timeout", tracing.Attrs{"duration": plan.Timeout.Duration}, plan.Timeout.Step)
+
+	case plan.Retry != nil:
+		var children []*span
+		for i, attempt := range plan.Retry.Steps {
+			child := t.span(attempt)
+			if child == nil {
+				continue
+			}
+
+			child.attrs["attempt"] = strconv.Itoa(i + 1)
+
+			children = append(children, child)
+		}
+
+		return spanning("retry", nil, children)
+	}
+
+	component, name := leaf(plan)
+	if component == "" {
+		return nil
+	}
+
+	o, found := t.origins[plan.ID]
+	if !found || o.start.IsZero() {
+		return nil
+	}
+
+	return &span{
+		component: component,
+		attrs:     tracing.Attrs{"name": name},
+		start:     o.start,
+		end:       o.end,
+		err:       o.err,
+	}
+}
+
+func (t timeline) parent(component string, attrs tracing.Attrs, steps ...atc.Plan) *span {
+	var children []*span
+	for _, step := range steps {
+		if child := t.span(step); child != nil {
+			children = append(children, child)
+		}
+	}
+
+	return spanning(component, attrs, children)
+}
+
+// hook returns the span of a step and the hook attached to it, which are both
+// its children.
+func (t timeline) hook(component string, step atc.Plan, hook atc.Plan) *span {
+	var children []*span
+	if child := t.span(step); child != nil {
+		children = append(children, child)
+	}
+
+	if child := t.span(hook); child != nil {
+		child.attrs["hook"] = component
+		children = append(children, child)
+	}
+
+	return spanning(component, nil, children)
+}
+
+// spanning returns a span from the start of the first child to the end of the
+// last one, or nil if there are none.
+func spanning(component string, attrs tracing.Attrs, children []*span) *span {
+	if len(children) == 0 {
+		return nil
+	}
+
+	if attrs == nil {
+		attrs = tracing.Attrs{}
+	}
+
+	s := &span{
+		component: component,
+		attrs:     attrs,
+		start:     children[0].start,
+		end:       children[0].end,
+		children:  children,
+	}
+
+	for _, child := range children {
+		if child.start.Before(s.start) {
+			s.start = child.start
+		}
+
+		if child.end.After(s.end) {
+			s.end = child.end
+		}
+	}
+
+	return s
+}
+
+// leaf returns the component and name of a step which doesn't contain other
+// steps, named the same as the spans of running builds.
+func leaf(plan atc.Plan) (string, string) {
+	switch {
+	case plan.Get != nil:
+		return "get", plan.Get.Name
+	case plan.Put != nil:
+		return "put", plan.Put.Name
+	case plan.Task != nil:
+		return "task", plan.Task.Name
+	case plan.Check != nil:
+		return "check", plan.Check.Name
+	case plan.SetPipeline != nil:
+		return "set_pipeline", plan.SetPipeline.Name
+	case plan.LoadVar != nil:
+		return "load_var", plan.LoadVar.Name
+	case plan.Approval != nil:
+		return "approval", plan.Approval.Name
+	case plan.PublishArtifact != nil:
+		return "publish_artifact", plan.PublishArtifact.Name
+	case plan.ConsumeArtifact != nil:
+		return "consume_artifact", plan.ConsumeArtifact.Name
+	case plan.LoadBuildOutputs != nil:
+		return "load_build_outputs", plan.LoadBuildOutputs.Name
+	case plan.ArtifactInput != nil:
+		return "artifact_input", plan.ArtifactInput.Name
+	case plan.ArtifactOutput != nil:
+		return "artifact_output", plan.ArtifactOutput.Name
+	case plan.DependentGet != nil:
+		return "get", plan.DependentGet.Name
+	}
+
+	return "", ""
+}
@@ -0,0 +1,270 @@
+package buildspans_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/buildspans"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/tracing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/oteltest"
+)
+
+var _ = Describe("Export", func() {
+	var (
+		fakeBuild *dbfakes.FakeBuild
+		events    []atc.Event
+		eventsErr error
+
+		exportErr error
+	)
+
+	origin := func(id string) event.Origin {
+		return event.Origin{ID: event.OriginID(id)}
+	}
+
+	at := func(unix int64) time.Time {
+		return time.Unix(unix, 0)
+	}
+
+	expectSpan := func(span *oteltest.Span, start, end int64) {
+		ExpectWithOffset(1, span.StartTime()).To(Equal(at(start)))
+
+		endTime, ended := span.EndTime()
+		ExpectWithOffset(1, ended).To(BeTrue())
+		ExpectWithOffset(1, endTime).To(Equal(at(end)))
+	}
+
+	expectChildOf := func(child, parent *oteltest.Span) {
+		ExpectWithOffset(1, child.ParentSpanID()).To(Equal(parent.SpanContext().SpanID()))
+		ExpectWithOffset(1, child.SpanContext().TraceID()).To(Equal(parent.SpanContext().TraceID()))
+	}
+
+	BeforeEach(func() {
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.StartTimeReturns(at(999))
+		fakeBuild.EndTimeReturns(at(1090))
+		fakeBuild.StatusReturns(db.BuildStatusSucceeded)
+		fakeBuild.TracingAttrsReturns(tracing.Attrs{"build_id": "42", "team_name": "some-team"})
+		fakeBuild.PrivatePlanReturns(atc.Plan{
+			ID: "do",
+			Do: &atc.DoPlan{
+				{
+					ID: "in-parallel",
+					InParallel: &atc.InParallelPlan{
+						Steps: []atc.Plan{
+							{ID: "get-a", Get: &atc.GetPlan{Name: "a"}},
+							{ID: "get-b", Get: &atc.GetPlan{Name: "b"}},
+						},
+					},
+				},
+				{
+					ID: "on-success",
+					OnSuccess: &atc.OnSuccessPlan{
+						Step: atc.Plan{ID: "unit", Task: &atc.TaskPlan{Name: "unit"}},
+						Next: atc.Plan{ID: "notify", Put: &atc.PutPlan{Name: "notify"}},
+					},
+				},
+				{ID: "never-ran", Task: &atc.TaskPlan{Name: "never-ran"}},
+			},
+		})
+
+		eventsErr = nil
+		events = []atc.Event{
+			event.InitializeGet{Origin: origin("get-a"), Time: 1000},
+			event.InitializeGet{Origin: origin("get-b"), Time: 1002},
+			event.FinishGet{Origin: origin("get-a"), Time: 1010},
+			event.FinishGet{Origin: origin("get-b"), Time: 1020},
+			event.InitializeTask{Origin: origin("unit"), Time: 1030},
+			event.Log{Origin: origin("unit"), Time: 1040, Payload: "testing"},
+			event.FinishTask{Origin: origin("unit"), Time: 1060},
+			event.InitializePut{Origin: origin("notify"), Time: 1070},
+			event.FinishPut{Origin: origin("notify"), Time: 1080},
+			event.Status{Status: atc.StatusSucceeded, Time: 1090},
+		}
+	})
+
+	JustBeforeEach(func() {
+		if eventsErr != nil {
+			fakeBuild.EventsReturns(nil, eventsErr)
+		} else {
+			fakeBuild.EventsReturns(fakeEventSource(events...), nil)
+		}
+
+		exportErr = buildspans.Export(context.Background(), fakeBuild)
+	})
+
+	It("succeeds", func() {
+		Expect(exportErr).ToNot(HaveOccurred())
+	})
+
+	It("records the build as the root span", func() {
+		build := recordedSpan("build", "")
+		Expect(build.ParentSpanID().IsValid()).To(BeFalse())
+		expectSpan(build, 999, 1090)
+
+		Expect(build.Attributes()).To(Equal(map[attribute.Key]attribute.Value{
+			"build_id":  attribute.StringValue("42"),
+			"team_name": attribute.StringValue("some-team"),
+			"status":    attribute.StringValue("succeeded"),
+		}))
+		Expect(build.StatusCode()).To(Equal(codes.Unset))
+	})
+
+	It("nests the steps the way they are in the plan", func() {
+		build := recordedSpan("build", "")
+		do := recordedSpan("do", "")
+		inParallel := recordedSpan("in_parallel", "")
+		onSuccess := recordedSpan("on_success", "")
+
+		expectChildOf(do, build)
+		expectChildOf(inParallel, do)
+		expectChildOf(recordedSpan("get", "a"), inParallel)
+		expectChildOf(recordedSpan("get", "b"), inParallel)
+		expectChildOf(onSuccess, do)
+		expectChildOf(recordedSpan("task", "unit"), onSuccess)
+		expectChildOf(recordedSpan("put", "notify"), onSuccess)
+	})
+
+	It("marks the hook", func() {
+		Expect(recordedSpan("put", "notify").Attributes()).To(HaveKeyWithValue(attribute.Key("hook"), attribute.StringValue("on_success")))
+		Expect(recordedSpan("task", "unit").Attributes()).ToNot(HaveKey(attribute.Key("hook")))
+	})
+
+	It("times steps by their events", func() {
+		expectSpan(recordedSpan("get", "a"), 1000, 1010)
+		expectSpan(recordedSpan("get", "b"), 1002, 1020)
+		expectSpan(recordedSpan("task", "unit"), 1030, 1060)
+		expectSpan(recordedSpan("put", "notify"), 1070, 1080)
+	})
+
+	It("times steps made of other steps by their children", func() {
+		expectSpan(recordedSpan("in_parallel", ""), 1000, 1020)
+		expectSpan(recordedSpan("on_success", ""), 1030, 1080)
+		expectSpan(recordedSpan("do", ""), 1000, 1080)
+	})
+
+	It("does not record steps which never ran", func() {
+		Expect(spanRecorder.Completed()).To(HaveLen(8))
+	})
+
+	Context("when a step fails", func() {
+		BeforeEach(func() {
+			events = []atc.Event{
+				event.InitializeGet{Origin: origin("get-a"), Time: 1000},
+				event.FinishGet{Origin: origin("get-a"), Time: 1010},
+				event.InitializeGet{Origin: origin("get-b"), Time: 1000},
+				event.FinishGet{Origin: origin("get-b"), Time: 1010},
+				event.InitializeTask{Origin: origin("unit"), Time: 1030},
+				event.FinishTask{Origin: origin("unit"), Time: 1060, ExitStatus: 1},
+				event.Status{Status: atc.StatusFailed, Time: 1061},
+			}
+
+			fakeBuild.StatusReturns(db.BuildStatusFailed)
+		})
+
+		It("records the step's failure", func() {
+			unit := recordedSpan("task", "unit")
+			Expect(unit.StatusCode()).To(Equal(codes.Error))
+			Expect(unit.Attributes()).To(HaveKeyWithValue(attribute.Key("error-message"), attribute.StringValue("exit status 1")))
+		})
+
+		It("records the build's failure", func() {
+			build := recordedSpan("build", "")
+			Expect(build.StatusCode()).To(Equal(codes.Error))
+			Expect(build.Attributes()).To(HaveKeyWithValue(attribute.Key("status"), attribute.StringValue("failed")))
+		})
+	})
+
+	Context("when a step errors", func() {
+		BeforeEach(func() {
+			events = []atc.Event{
+				event.InitializeGet{Origin: origin("get-a"), Time: 1000},
+				event.Error{Origin: origin("get-a"), Time: 1005, Message: "no versions"},
+				event.Status{Status: atc.StatusErrored, Time: 1006},
+			}
+
+			fakeBuild.StatusReturns(db.BuildStatusErrored)
+		})
+
+		It("records the error", func() {
+			get := recordedSpan("get", "a")
+			expectSpan(get, 1000, 1005)
+			Expect(get.StatusCode()).To(Equal(codes.Error))
+			Expect(get.Attributes()).To(HaveKeyWithValue(attribute.Key("error-message"), attribute.StringValue("no versions")))
+		})
+	})
+
+	Context("with across and retry steps", func() {
+		BeforeEach(func() {
+			fakeBuild.PrivatePlanReturns(atc.Plan{
+				ID: "across",
+				Across: &atc.AcrossPlan{
+					Steps: []atc.VarScopedPlan{
+						{
+							Values: []interface{}{"linux"},
+							Step: atc.Plan{
+								ID: "retry",
+								Retry: &atc.RetryPlan{
+									Steps: []atc.Plan{
+										{ID: "attempt-1", Task: &atc.TaskPlan{Name: "flaky"}},
+										{ID: "attempt-2", Task: &atc.TaskPlan{Name: "flaky"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			})
+
+			events = []atc.Event{
+				event.InitializeTask{Origin: origin("attempt-1"), Time: 1000},
+				event.FinishTask{Origin: origin("attempt-1"), Time: 1010, ExitStatus: 1},
+				event.InitializeTask{Origin: origin("attempt-2"), Time: 1011},
+				event.FinishTask{Origin: origin("attempt-2"), Time: 1020},
+			}
+		})
+
+		It("nests each attempt under the retry, under the across", func() {
+			across := recordedSpan("across", "")
+			retry := recordedSpan("retry", "")
+			expectChildOf(retry, across)
+			Expect(retry.Attributes()).To(HaveKeyWithValue(attribute.Key("across_values"), attribute.StringValue(`["linux"]`)))
+
+			var attempts []*oteltest.Span
+			for _, span := range spanRecorder.Completed() {
+				if span.Name() == "task" {
+					expectChildOf(span, retry)
+					attempts = append(attempts, span)
+				}
+			}
+
+			Expect(attempts).To(HaveLen(2))
+			Expect(attempts[0].Attributes()).To(HaveKeyWithValue(attribute.Key("attempt"), attribute.StringValue("1")))
+			Expect(attempts[0].StatusCode()).To(Equal(codes.Error))
+			Expect(attempts[1].Attributes()).To(HaveKeyWithValue(attribute.Key("attempt"), attribute.StringValue("2")))
+			Expect(attempts[1].StatusCode()).To(Equal(codes.Unset))
+
+			expectSpan(retry, 1000, 1020)
+		})
+	})
+
+	Context("when the events can't be read", func() {
+		BeforeEach(func() {
+			eventsErr = errors.New("nope")
+		})
+
+		It("returns the error without recording any spans", func() {
+			Expect(exportErr).To(MatchError(ContainSubstring("nope")))
+			Expect(spanRecorder.Completed()).To(BeEmpty())
+		})
+	})
+})
@@ -8,6 +8,11 @@ const (
 	ComponentLidarScanner               = "scanner"
 	ComponentBuildReaper                = "reaper"
 	ComponentSyslogDrainer              = "drainer"
+	ComponentBuildEventArchiver         = "archiver"
+	ComponentBuildSpanExporter          = "span_exporter"
+	ComponentWebhookNotifier            = "webhook_notifier"
+	ComponentTeamUsage                  = "team_usage"
+	ComponentEncryptionKeyRotator       = "encryption_key_rotator"
 	ComponentCollectorAccessTokens      = "collector_access_tokens"
 	ComponentCollectorArtifacts         = "collector_artifacts"
 	ComponentCollectorBuilds            = "collector_builds"
@@ -15,6 +15,7 @@ import (
 )
 
 const ConfigVersionHeader = "X-Concourse-Config-Version"
+const ConfigPatchContentType = "application/json-patch+json"
 const DefaultTeamName = "main"
 
 type Tags []string
@@ -26,6 +27,12 @@ type Config struct {
 	ResourceTypes ResourceTypes    `json:"resource_types,omitempty"`
 	Jobs          JobConfigs       `json:"jobs,omitempty"`
 	Display       *DisplayConfig   `json:"display,omitempty"`
+	TaskDefaults  *TaskDefaults    `json:"task_defaults,omitempty"`
+	Webhooks      WebhookConfigs   `json:"webhooks,omitempty"`
+
+	// MaxBuildDuration is how long any build of the pipeline may run before
+	// it is aborted, e.g. "2h".
+	MaxBuildDuration string `json:"max_build_duration,omitempty"`
 }
 
 func UnmarshalConfig(payload []byte, config interface{}) error {
@@ -37,6 +44,10 @@ func UnmarshalConfig(payload []byte, config interface{}) error {
 		ResourceTypes interface{} `json:"resource_types,omitempty"`
 		Jobs          interface{} `json:"jobs,omitempty"`
 		Display       interface{} `json:"display,omitempty"`
+		TaskDefaults  interface{} `json:"task_defaults,omitempty"`
+		Webhooks      interface{} `json:"webhooks,omitempty"`
+
+		MaxBuildDuration interface{} `json:"max_build_duration,omitempty"`
 	}
 
 	var stripped skeletonConfig
@@ -188,6 +199,62 @@ type ResourceConfig struct {
 	Version              Version     `json:"version,omitempty"`
 	Icon                 string      `json:"icon,omitempty"`
 	ExposeBuildCreatedBy bool        `json:"expose_build_created_by,omitempty"`
+
+	// CheckLimits are set on the resource's check containers, for checks
+	// which need more than the default limits.
+	CheckLimits *ContainerLimits `json:"check_limits,omitempty"`
+
+	// CheckOnDemand suppresses periodic checking of the resource. Instead, it
+	// is only checked once a pending build of a job using it as an input
+	// needs it, at the cost of that build waiting for the check to finish.
+	// Jobs are never triggered by new versions of the resource alone.
+	CheckOnDemand bool `json:"check_on_demand,omitempty"`
+
+	// CheckPool groups resources whose checks hit the same backend, so that
+	// they can share the rate limit configured for the pool.
+	CheckPool string `json:"check_pool,omitempty"`
+
+	// Proxy configures the proxies the resource's check, get and put
+	// containers egress through, in place of the worker's.
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+
+	// CheckTTL is the longest the resource may go without a scheduled check,
+	// even if it's checked on demand, never, or through webhooks instead.
+	// Checks triggered by webhooks or by hand don't count towards it.
+	CheckTTL string `json:"check_ttl,omitempty"`
+
+	// TriggerWindow restricts the new versions of the resource which trigger
+	// builds to those created within a daily time window.
+	TriggerWindow *TriggerWindowConfig `json:"trigger_window,omitempty"`
+}
+
+// ProxyConfig configures the proxies of a container. Credentials in the proxy
+// URLs should be given as vars, which are resolved from the credential
+// manager and redacted from build logs.
+type ProxyConfig struct {
+	HTTP    string `json:"http,omitempty"`
+	HTTPS   string `json:"https,omitempty"`
+	NoProxy string `json:"no_proxy,omitempty"`
+}
+
+// Env returns the env vars which point a container's processes to the
+// proxies.
+func (config ProxyConfig) Env() []string {
+	var env []string
+
+	if config.HTTP != "" {
+		env = append(env, "http_proxy="+config.HTTP)
+	}
+
+	if config.HTTPS != "" {
+		env = append(env, "https_proxy="+config.HTTPS)
+	}
+
+	if config.NoProxy != "" {
+		env = append(env, "no_proxy="+config.NoProxy)
+	}
+
+	return env
 }
 
 type ResourceType struct {
@@ -199,12 +266,84 @@ type ResourceType struct {
 	CheckEvery *CheckEvery `json:"check_every,omitempty"`
 	Tags       Tags        `json:"tags,omitempty"`
 	Params     Params      `json:"params,omitempty"`
+
+	// TwoPhaseCommit is set for types whose puts can be staged, then
+	// committed or rolled back, which the atomic step relies on.
+	TwoPhaseCommit bool `json:"two_phase_commit,omitempty"`
+
+	// IdentityFields are the fields which identify the type's versions, for
+	// types whose checks vary the other fields of the same version. A checked
+	// version with the same values for them as a saved version is that version
+	// rather than a new one.
+	IdentityFields []string `json:"identity_fields,omitempty"`
+
+	// NotRetryableExitCodes are the exit statuses with which the type's get
+	// and put scripts report failures retrying can't fix, e.g. a request its
+	// API rejected as invalid. Steps failing with them skip their remaining
+	// attempts.
+	NotRetryableExitCodes []int `json:"not_retryable_exit_codes,omitempty"`
+
+	// ReuseImageFor opts the type in to reusing the version of its image
+	// checked within the duration, rather than checking for the image every
+	// time it's used. The check is keyed on the image's source, so a change to
+	// its repository, tag or credentials is checked for right away.
+	ReuseImageFor string `json:"reuse_image_for,omitempty"`
 }
 
 type DisplayConfig struct {
 	BackgroundImage string `json:"background_image,omitempty"`
 }
 
+// TaskDefaults configures defaults applied to every task step in a pipeline.
+type TaskDefaults struct {
+	// Limits applied to task containers which do not set their own.
+	Limits *ContainerLimits `json:"limits,omitempty"`
+}
+
+// WebhookConfig configures an endpoint which is sent a BuildNotification
+// whenever a build of one of the pipeline's jobs finishes.
+type WebhookConfig struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+
+	// Headers are sent along with every notification. Both these and the URL
+	// may use ((vars)), which are only resolved when a notification is sent.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Notify is either WebhookNotifyAlways, the default, or
+	// WebhookNotifyOnChange to only be notified of builds whose status differs
+	// from that of the job's previous build.
+	Notify string `json:"notify,omitempty"`
+}
+
+const (
+	WebhookNotifyAlways   = "always"
+	WebhookNotifyOnChange = "change"
+)
+
+// NotifiesOf returns whether the webhook is to be notified of a build with the
+// given status. The previous status is empty if the job has no previous
+// build.
+func (w WebhookConfig) NotifiesOf(status BuildStatus, previousStatus BuildStatus) bool {
+	if w.Notify == WebhookNotifyOnChange {
+		return status != previousStatus
+	}
+
+	return true
+}
+
+type WebhookConfigs []WebhookConfig
+
+func (c WebhookConfigs) Lookup(name string) (WebhookConfig, bool) {
+	for _, webhook := range c {
+		if webhook.Name == name {
+			return webhook, true
+		}
+	}
+
+	return WebhookConfig{}, false
+}
+
 type CheckEvery struct {
 	Never    bool
 	Interval time.Duration
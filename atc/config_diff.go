@@ -30,6 +30,11 @@ type DisplayDiff struct {
 	After  *DisplayConfig
 }
 
+type TaskDefaultsDiff struct {
+	Before *TaskDefaults
+	After  *TaskDefaults
+}
+
 func name(v interface{}) string {
 	return reflect.ValueOf(v).FieldByName("Name").String()
 }
@@ -76,6 +81,24 @@ func (diff DisplayDiff) Render(to io.Writer) {
 	}
 }
 
+func (diff TaskDefaultsDiff) Render(to io.Writer) {
+	label := "task defaults"
+	if diff.Before != nil && diff.After != nil {
+		fmt.Fprintf(to, ansi.Color("%s have changed:", "yellow")+"\n", label)
+		payloadA, _ := yaml.Marshal(diff.Before)
+		payloadB, _ := yaml.Marshal(diff.After)
+		renderDiff(to, string(payloadA), string(payloadB))
+	} else if diff.Before != nil {
+		fmt.Fprintf(to, ansi.Color("%s have been removed:", "yellow")+"\n", label)
+		payloadA, _ := yaml.Marshal(diff.Before)
+		renderDiff(to, string(payloadA), "")
+	} else {
+		fmt.Fprintf(to, ansi.Color("%s have been added:", "yellow")+"\n", label)
+		payloadB, _ := yaml.Marshal(diff.After)
+		renderDiff(to, "", string(payloadB))
+	}
+}
+
 type GroupIndex GroupConfigs
 
 func (index GroupIndex) Slice() []interface{} {
@@ -106,6 +129,21 @@ func (index VarSourceIndex) FindEquivalent(obj interface{}) (interface{}, bool)
 	return VarSourceConfigs(index).Lookup(name(obj))
 }
 
+type WebhookIndex WebhookConfigs
+
+func (index WebhookIndex) Slice() []interface{} {
+	slice := make([]interface{}, len(index))
+	for i, object := range index {
+		slice[i] = object
+	}
+
+	return slice
+}
+
+func (index WebhookIndex) FindEquivalent(obj interface{}) (interface{}, bool) {
+	return WebhookConfigs(index).Lookup(name(obj))
+}
+
 type JobIndex JobConfigs
 
 func (index JobIndex) Slice() []interface{} {
@@ -242,6 +280,17 @@ func diffDisplay(oldDisplay, newDisplay *DisplayConfig) (DisplayDiff, bool) {
 	}, practicallyDifferent(oldDisplay, newDisplay)
 }
 
+func diffTaskDefaults(oldDefaults, newDefaults *TaskDefaults) (TaskDefaultsDiff, bool) {
+	if oldDefaults == nil && newDefaults == nil {
+		return TaskDefaultsDiff{}, false
+	}
+
+	return TaskDefaultsDiff{
+		Before: oldDefaults,
+		After:  newDefaults,
+	}, practicallyDifferent(oldDefaults, newDefaults)
+}
+
 func renderDiff(to io.Writer, a, b string) {
 	diffs := difflib.Diff(strings.Split(a, "\n"), strings.Split(b, "\n"))
 	indent := gexec.NewPrefixedWriter("\b\b", to)
@@ -335,5 +384,21 @@ func (c Config) Diff(out io.Writer, newConfig Config) bool {
 		displayDiff.Render(indent)
 	}
 
+	taskDefaultsDiff, diff := diffTaskDefaults(c.TaskDefaults, newConfig.TaskDefaults)
+	if diff {
+		diffExists = true
+		taskDefaultsDiff.Render(indent)
+	}
+
+	webhookDiffs := diffIndices(WebhookIndex(c.Webhooks), WebhookIndex(newConfig.Webhooks))
+	if len(webhookDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "webhooks:")
+
+		for _, diff := range webhookDiffs {
+			diff.Render(indent, "webhook")
+		}
+	}
+
 	return diffExists
 }
@@ -216,4 +216,91 @@ var _ = Describe("Config diff", func() {
 			})
 		})
 	})
+
+	Describe("task defaults", func() {
+		var taskDefaults TaskDefaults
+		BeforeEach(func() {
+			memory := MemoryLimit(1024)
+			taskDefaults = TaskDefaults{
+				Limits: &ContainerLimits{Memory: &memory},
+			}
+		})
+
+		Context("when there are no task defaults", func() {
+			It("does not print anything about task defaults", func() {
+				buffer := NewBuffer()
+				diff := Config{}.Diff(buffer, Config{})
+				Expect(diff).To(BeFalse())
+				Consistently(buffer).ShouldNot(Say("task defaults"))
+			})
+		})
+
+		Context("when task defaults are added", func() {
+			It("says they have been added", func() {
+				buffer := NewBuffer()
+				diff := Config{}.Diff(buffer, Config{TaskDefaults: &taskDefaults})
+				Expect(diff).To(BeTrue())
+				Eventually(buffer).Should(Say("task defaults have been added:"))
+				Eventually(buffer).Should(Say(`\+.*memory: 1024`))
+			})
+		})
+
+		Context("when task defaults are unchanged", func() {
+			It("says there are no changes to apply", func() {
+				oldConfig := Config{TaskDefaults: &taskDefaults}
+				newConfig := Config{TaskDefaults: &taskDefaults}
+
+				diff := oldConfig.Diff(GinkgoWriter, newConfig)
+				Expect(diff).To(BeFalse())
+			})
+		})
+
+		Context("when the limits change", func() {
+			It("says they have changed", func() {
+				memory := MemoryLimit(2048)
+				oldConfig := Config{TaskDefaults: &taskDefaults}
+				newConfig := Config{TaskDefaults: &TaskDefaults{
+					Limits: &ContainerLimits{Memory: &memory},
+				}}
+
+				buffer := NewBuffer()
+				diff := oldConfig.Diff(buffer, newConfig)
+				Expect(diff).To(BeTrue())
+				Eventually(buffer).Should(Say("task defaults have changed:"))
+				Eventually(buffer).Should(Say("-.*memory: 1024"))
+				Eventually(buffer).Should(Say(`\+.*memory: 2048`))
+			})
+		})
+	})
+
+	Describe("webhooks", func() {
+		webhook := WebhookConfig{
+			Name: "some-webhook",
+			URL:  "https://example.com/hook",
+		}
+
+		Context("when a webhook is added", func() {
+			It("says it has been added", func() {
+				buffer := NewBuffer()
+				diff := Config{}.Diff(buffer, Config{Webhooks: WebhookConfigs{webhook}})
+				Expect(diff).To(BeTrue())
+				Eventually(buffer).Should(Say("webhook some-webhook has been added:"))
+				Eventually(buffer).Should(Say(`\+.*url: https://example.com/hook`))
+			})
+		})
+
+		Context("when a webhook's url changes", func() {
+			It("says it has changed", func() {
+				newWebhook := webhook
+				newWebhook.URL = "https://example.com/other-hook"
+
+				buffer := NewBuffer()
+				diff := Config{Webhooks: WebhookConfigs{webhook}}.Diff(buffer, Config{Webhooks: WebhookConfigs{newWebhook}})
+				Expect(diff).To(BeTrue())
+				Eventually(buffer).Should(Say("webhook some-webhook has changed:"))
+				Eventually(buffer).Should(Say("-.*url: https://example.com/hook"))
+				Eventually(buffer).Should(Say(`\+.*url: https://example.com/other-hook`))
+			})
+		})
+	})
 })
@@ -40,6 +40,21 @@ var _ = Describe("Config", func() {
 				})
 			})
 		})
+
+		Context("when unmarshaling latest-stable from JSON", func() {
+			It("selects the latest promoted version", func() {
+				var versionConfig VersionConfig
+				err := json.Unmarshal([]byte(`"latest-stable"`), &versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionConfig).To(Equal(VersionConfig{LatestStable: true}))
+			})
+
+			It("marshals back to latest-stable", func() {
+				bs, err := json.Marshal(&VersionConfig{LatestStable: true})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bs).To(MatchJSON(`"latest-stable"`))
+			})
+		})
 	})
 
 	Describe("VarSourceConfigs.OrderByDependency", func() {
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
@@ -62,9 +64,52 @@ func Validate(c atc.Config) ([]atc.ConfigWarning, []string) {
 	}
 	warnings = append(warnings, displayWarnings...)
 
+	webhooksWarnings, webhooksErr := validateWebhooks(c)
+	if webhooksErr != nil {
+		errorMessages = append(errorMessages, formatErr("webhooks", webhooksErr))
+	}
+	warnings = append(warnings, webhooksWarnings...)
+
+	if c.MaxBuildDuration != "" {
+		maxBuildDuration, err := time.ParseDuration(c.MaxBuildDuration)
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("invalid max_build_duration: %s", err))
+		} else if maxBuildDuration <= 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("max_build_duration must be positive: %s", c.MaxBuildDuration))
+		}
+	}
+
 	return warnings, errorMessages
 }
 
+// ValidateTeamResourceTypes validates the resource types registered for a
+// team, which follow the same rules as the resource types of a pipeline.
+func ValidateTeamResourceTypes(resourceTypes atc.ResourceTypes) ([]atc.ConfigWarning, []string) {
+	warnings, err := validateResourceTypes(atc.Config{ResourceTypes: resourceTypes})
+	if err != nil {
+		return warnings, []string{formatErr("resource types", err)}
+	}
+
+	return warnings, nil
+}
+
+// ShadowedTeamResourceTypes warns about the resource types of the pipeline
+// which take precedence over a team resource type of the same name.
+func ShadowedTeamResourceTypes(c atc.Config, teamResourceTypes atc.ResourceTypes) []atc.ConfigWarning {
+	var warnings []atc.ConfigWarning
+
+	for _, resourceType := range c.ResourceTypes {
+		if _, found := teamResourceTypes.Lookup(resourceType.Name); found {
+			warnings = append(warnings, atc.ConfigWarning{
+				Type:    "resource_type",
+				Message: fmt.Sprintf("resource_types.%s overrides the team's resource type of the same name", resourceType.Name),
+			})
+		}
+	}
+
+	return warnings
+}
+
 func validateGroups(c atc.Config) ([]atc.ConfigWarning, error) {
 	var warnings []atc.ConfigWarning
 	var errorMessages []string
@@ -184,6 +229,21 @@ func validateResources(c atc.Config) ([]atc.ConfigWarning, error) {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resource.CheckTTL != "" {
+			ttl, err := time.ParseDuration(resource.CheckTTL)
+			if err != nil {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has invalid check_ttl: %s", err))
+			} else if ttl <= 0 {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has non-positive check_ttl: %s", resource.CheckTTL))
+			}
+		}
+
+		if resource.TriggerWindow != nil {
+			for _, message := range resource.TriggerWindow.Validate() {
+				errorMessages = append(errorMessages, identifier+" has invalid trigger_window: "+message)
+			}
+		}
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -229,6 +289,22 @@ func validateResourceTypes(c atc.Config) ([]atc.ConfigWarning, error) {
 		if resourceType.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		for _, code := range resourceType.NotRetryableExitCodes {
+			if code < 1 || code > 255 {
+				errorMessages = append(errorMessages,
+					fmt.Sprintf("%s has an invalid not-retryable exit code %d: must be between 1 and 255", identifier, code))
+			}
+		}
+
+		if resourceType.ReuseImageFor != "" {
+			reuseImageFor, err := time.ParseDuration(resourceType.ReuseImageFor)
+			if err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s has invalid reuse_image_for: %s", identifier, err))
+			} else if reuseImageFor <= 0 {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s has reuse_image_for which must be positive: %s", identifier, resourceType.ReuseImageFor))
+			}
+		}
 	}
 
 	return warnings, compositeErr(errorMessages)
@@ -319,6 +395,57 @@ func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 			)
 		}
 
+		if job.RawMaxInFlight < 0 {
+			errorMessages = append(
+				errorMessages,
+				identifier+fmt.Sprintf(" has negative max_in_flight: %d", job.RawMaxInFlight),
+			)
+		}
+
+		if job.SerialGroupsMaxInFlight < 0 {
+			errorMessages = append(
+				errorMessages,
+				identifier+fmt.Sprintf(" has negative serial_groups_max_in_flight: %d", job.SerialGroupsMaxInFlight),
+			)
+		} else if job.SerialGroupsMaxInFlight > 0 && len(job.SerialGroups) == 0 {
+			errorMessages = append(
+				errorMessages,
+				identifier+" has serial_groups_max_in_flight but no serial_groups",
+			)
+		}
+
+		if job.TriggerDebounce != "" {
+			debounce, err := time.ParseDuration(job.TriggerDebounce)
+			if err != nil {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(" has invalid trigger_debounce: %s", err),
+				)
+			} else if debounce < 0 {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(" has negative trigger_debounce: %s", job.TriggerDebounce),
+				)
+			}
+		}
+
+		if job.SupersededBy != "" {
+			isInput := false
+			for _, input := range job.Inputs() {
+				if input.Resource == job.SupersededBy {
+					isInput = true
+					break
+				}
+			}
+
+			if !isInput {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(" has superseded_by '%s' which is not an input of the job", job.SupersededBy),
+				)
+			}
+		}
+
 		if job.BuildLogRetention != nil {
 			if job.BuildLogRetention.Builds < 0 {
 				errorMessages = append(
@@ -357,9 +484,87 @@ func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 		errorMessages = append(errorMessages, validator.Errors...)
 	}
 
+	warnings = append(warnings, validateJobsReachable(c)...)
+
 	return warnings, compositeErr(errorMessages)
 }
 
+// validateJobsReachable warns about jobs that can never run because their
+// passed constraints depend, directly or transitively, on a cycle of jobs
+// that all wait on each other. These are only warnings as the pipeline is
+// still valid, e.g. while it is being built up.
+func validateJobsReachable(c atc.Config) []atc.ConfigWarning {
+	var warnings []atc.ConfigWarning
+
+	upstream := map[string][]string{}
+	for _, job := range c.Jobs {
+		upstream[job.Name] = []string{}
+
+		for _, input := range job.Inputs() {
+			for _, passed := range input.Passed {
+				// unknown jobs are reported as errors by the step validator
+				if _, found := c.Jobs.Lookup(passed); found {
+					upstream[job.Name] = append(upstream[job.Name], passed)
+				}
+			}
+		}
+	}
+
+	reachable := map[string]bool{}
+	for changed := true; changed; {
+		changed = false
+
+		for _, job := range c.Jobs {
+			if reachable[job.Name] {
+				continue
+			}
+
+			satisfied := true
+			for _, passed := range upstream[job.Name] {
+				if !reachable[passed] {
+					satisfied = false
+					break
+				}
+			}
+
+			if satisfied {
+				reachable[job.Name] = true
+				changed = true
+			}
+		}
+	}
+
+	for _, job := range c.Jobs {
+		if reachable[job.Name] {
+			continue
+		}
+
+		blocking := map[string]bool{}
+		for _, passed := range upstream[job.Name] {
+			if !reachable[passed] {
+				blocking[passed] = true
+			}
+		}
+
+		var blockingNames []string
+		for name := range blocking {
+			blockingNames = append(blockingNames, name)
+		}
+		sort.Strings(blockingNames)
+
+		warnings = append(warnings, atc.ConfigWarning{
+			Type: "pipeline",
+			Message: fmt.Sprintf(
+				"jobs.%s can never run: its passed constraints depend on jobs that can never run (%s)",
+				job.Name,
+				strings.Join(blockingNames, ", "),
+			),
+		})
+	}
+
+	return warnings
+}
+
 func compositeErr(errorMessages []string) error {
 	if len(errorMessages) == 0 {
 		return nil
@@ -448,3 +653,62 @@ func validateDisplay(c atc.Config) ([]atc.ConfigWarning, error) {
 
 	return warnings, nil
 }
+
+func validateWebhooks(c atc.Config) ([]atc.ConfigWarning, error) {
+	var warnings []atc.ConfigWarning
+	var errorMessages []string
+
+	names := map[string]interface{}{}
+
+	for i, webhook := range c.Webhooks {
+		var identifier string
+		if webhook.Name == "" {
+			identifier = fmt.Sprintf("webhooks[%d]", i)
+		} else {
+			identifier = fmt.Sprintf("webhooks.%s", webhook.Name)
+		}
+
+		warning, err := atc.ValidateIdentifier(webhook.Name, identifier)
+		if err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		}
+		if warning != nil {
+			warnings = append(warnings, *warning)
+		}
+
+		if _, ok := names[webhook.Name]; ok {
+			errorMessages = append(errorMessages, fmt.Sprintf("duplicate webhook name: %s", webhook.Name))
+		}
+		names[webhook.Name] = 0
+
+		switch webhook.Notify {
+		case "", atc.WebhookNotifyAlways, atc.WebhookNotifyOnChange:
+		default:
+			errorMessages = append(errorMessages, fmt.Sprintf("%s notify must be either %s or %s", identifier, atc.WebhookNotifyAlways, atc.WebhookNotifyOnChange))
+		}
+
+		if webhook.URL == "" {
+			errorMessages = append(errorMessages, identifier+" has no url")
+			continue
+		}
+
+		// urls using ((vars)) can only be checked once they are resolved
+		if strings.Contains(webhook.URL, "((") {
+			continue
+		}
+
+		webhookURL, err := url.Parse(webhook.URL)
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s has an invalid url: %s", identifier, webhook.URL))
+			continue
+		}
+
+		switch webhookURL.Scheme {
+		case "http", "https":
+		default:
+			errorMessages = append(errorMessages, fmt.Sprintf("%s url scheme must be either http or https", identifier))
+		}
+	}
+
+	return warnings, compositeErr(errorMessages)
+}
@@ -497,6 +497,51 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource has an invalid check_ttl", func() {
+			BeforeEach(func() {
+				config.Resources[0].CheckTTL = "daily"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has invalid check_ttl"))
+			})
+		})
+
+		Context("when a resource has a non-positive check_ttl", func() {
+			BeforeEach(func() {
+				config.Resources[0].CheckTTL = "0s"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has non-positive check_ttl: 0s"))
+			})
+		})
+
+		Context("when a resource has an invalid trigger_window", func() {
+			BeforeEach(func() {
+				config.Resources[0].TriggerWindow = &atc.TriggerWindowConfig{
+					Field:    "committed_at",
+					Layout:   "15:04",
+					Start:    "9am",
+					Stop:     "17:00",
+					Location: "Nowhere/Bogus",
+					Days:     []string{"monday", "someday"},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has invalid trigger_window: invalid layout '15:04' (must give the date and time of day)"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has invalid trigger_window: invalid start '9am' (must be HH:MM)"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has invalid trigger_window: invalid location 'Nowhere/Bogus'"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has invalid trigger_window: invalid day 'someday'"))
+				Expect(errorMessages[0]).ToNot(ContainSubstring("invalid stop"))
+			})
+		})
+
 		Context("when a resource has no name or type", func() {
 			BeforeEach(func() {
 				config.Resources = append(config.Resources, atc.ResourceConfig{
@@ -815,6 +860,116 @@ var _ = Describe("ValidateConfig", func() {
 				Expect(errorMessages[0]).To(ContainSubstring("resource_types[0] and resource_types[1] have the same name ('some-resource-type')"))
 			})
 		})
+
+		Context("when a resource type has an invalid not-retryable exit code", func() {
+			BeforeEach(func() {
+				config.ResourceTypes[0].NotRetryableExitCodes = []int{65, 0, 256}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resource types:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resource_types.some-resource-type has an invalid not-retryable exit code 0: must be between 1 and 255"))
+				Expect(errorMessages[0]).To(ContainSubstring("resource_types.some-resource-type has an invalid not-retryable exit code 256: must be between 1 and 255"))
+				Expect(errorMessages[0]).ToNot(ContainSubstring("exit code 65"))
+			})
+		})
+
+		Context("when a resource type has an invalid reuse_image_for", func() {
+			BeforeEach(func() {
+				config.ResourceTypes[0].ReuseImageFor = "forever"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resource types:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resource_types.some-resource-type has invalid reuse_image_for"))
+			})
+		})
+
+		Context("when a resource type has a negative reuse_image_for", func() {
+			BeforeEach(func() {
+				config.ResourceTypes[0].ReuseImageFor = "-1m"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("resource_types.some-resource-type has reuse_image_for which must be positive: -1m"))
+			})
+		})
+	})
+
+	Describe("unreachable jobs", func() {
+		getPassed := func(passed ...string) atc.Step {
+			return atc.Step{
+				Config: &atc.GetStep{
+					Name:     "some-resource",
+					Resource: "some-resource",
+					Passed:   passed,
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			config.Groups = nil
+		})
+
+		Context("when the passed constraints form a chain", func() {
+			BeforeEach(func() {
+				config.Jobs = append(config.Jobs,
+					atc.JobConfig{Name: "job-a", PlanSequence: []atc.Step{getPassed("some-job")}},
+					atc.JobConfig{Name: "job-b", PlanSequence: []atc.Step{getPassed("job-a", "some-job")}},
+				)
+			})
+
+			It("does not return a warning", func() {
+				Expect(errorMessages).To(BeEmpty())
+				Expect(warnings).To(BeEmpty())
+			})
+		})
+
+		Context("when the passed constraints form a cycle", func() {
+			BeforeEach(func() {
+				config.Jobs = append(config.Jobs,
+					atc.JobConfig{Name: "job-a", PlanSequence: []atc.Step{getPassed("job-b")}},
+					atc.JobConfig{Name: "job-b", PlanSequence: []atc.Step{getPassed("job-a")}},
+					atc.JobConfig{Name: "job-c", PlanSequence: []atc.Step{getPassed("job-a", "some-job")}},
+				)
+			})
+
+			It("warns about the jobs in and downstream of the cycle", func() {
+				Expect(errorMessages).To(BeEmpty())
+				Expect(warnings).To(Equal([]atc.ConfigWarning{
+					{
+						Type:    "pipeline",
+						Message: "jobs.job-a can never run: its passed constraints depend on jobs that can never run (job-b)",
+					},
+					{
+						Type:    "pipeline",
+						Message: "jobs.job-b can never run: its passed constraints depend on jobs that can never run (job-a)",
+					},
+					{
+						Type:    "pipeline",
+						Message: "jobs.job-c can never run: its passed constraints depend on jobs that can never run (job-a)",
+					},
+				}))
+			})
+		})
+
+		Context("when a job's passed constraints include itself", func() {
+			BeforeEach(func() {
+				config.Jobs = append(config.Jobs,
+					atc.JobConfig{Name: "job-a", PlanSequence: []atc.Step{getPassed("job-a")}},
+				)
+			})
+
+			It("warns about the job", func() {
+				Expect(warnings).To(ConsistOf(atc.ConfigWarning{
+					Type:    "pipeline",
+					Message: "jobs.job-a can never run: its passed constraints depend on jobs that can never run (job-a)",
+				}))
+			})
+		})
 	})
 
 	Describe("validating a job", func() {
@@ -840,6 +995,87 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has an invalid trigger_debounce", func() {
+			BeforeEach(func() {
+				job.TriggerDebounce = "soon"
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has invalid trigger_debounce"))
+			})
+		})
+
+		Context("when a job has a negative trigger_debounce", func() {
+			BeforeEach(func() {
+				job.TriggerDebounce = "-30s"
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has negative trigger_debounce: -30s"))
+			})
+		})
+
+		Context("when a job is superseded by one of its inputs", func() {
+			BeforeEach(func() {
+				job.PlanSequence = []atc.Step{
+					{
+						Config: &atc.GetStep{
+							Name: "some-resource",
+						},
+					},
+				}
+				job.SupersededBy = "some-resource"
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+		})
+
+		Context("when a job is superseded by a resource that is not an input", func() {
+			BeforeEach(func() {
+				job.SupersededBy = "some-resource"
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has superseded_by 'some-resource' which is not an input of the job"))
+			})
+		})
+
+		Context("when a job has a negative max_in_flight", func() {
+			BeforeEach(func() {
+				job.RawMaxInFlight = -1
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has negative max_in_flight: -1"))
+			})
+		})
+
+		Context("when a job has serial_groups_max_in_flight without serial_groups", func() {
+			BeforeEach(func() {
+				job.SerialGroupsMaxInFlight = 2
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has serial_groups_max_in_flight but no serial_groups"))
+			})
+		})
+
 		Context("when a job has a negative build_logs_to_retain", func() {
 			BeforeEach(func() {
 				job.BuildLogsToRetain = -1
@@ -935,36 +1171,429 @@ var _ = Describe("ValidateConfig", func() {
 			It("returns no errors", func() {
 				Expect(errorMessages).To(HaveLen(0))
 			})
-		})
+		})
+
+		Describe("plans", func() {
+			Context("when a task plan has neither a config, path, or name set set", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(): must specify either `file:` or `config:`"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(): identifier cannot be an empty string"))
+				})
+			})
+
+			Context("when a task plan's params refer to the metadata of an input", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence,
+						atc.Step{
+							Config: &atc.GetStep{
+								Name: "some-resource",
+							},
+						},
+						atc.Step{
+							Config: &atc.TaskStep{
+								Name:       "some-task",
+								ConfigPath: "some-task-file",
+								Params:     atc.TaskEnv{"SHA": "((.:some-resource.commit))"},
+							},
+						},
+					)
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a task plan's params refer to the metadata of something other than an input", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{
+							Name:       "some-task",
+							ConfigPath: "some-task-file",
+							Params: atc.TaskEnv{
+								"SHA":   "((.:some-resource.commit))",
+								"OTHER": "((.:some-var.some-field))",
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(some-task).params.SHA: var '((.:some-resource.commit))' refers to the metadata of 'some-resource', which is not a get step before the task"))
+					Expect(errorMessages[0]).ToNot(ContainSubstring("params.OTHER"))
+				})
+			})
+
+			Context("when a task plan's params refer to a local var named after a resource", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence,
+						atc.Step{
+							Config: &atc.LoadVarStep{
+								Name: "some-resource",
+								File: "some-file",
+							},
+						},
+						atc.Step{
+							Config: &atc.TaskStep{
+								Name:       "some-task",
+								ConfigPath: "some-task-file",
+								Params:     atc.TaskEnv{"SHA": "((.:some-resource.commit))"},
+							},
+						},
+					)
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when an atomic step has a step other than a put", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AtomicStep{
+							Steps: []atc.Step{
+								{
+									Config: &atc.PutStep{
+										Name: "some-resource",
+									},
+								},
+								{
+									Config: &atc.GetStep{
+										Name: "some-resource",
+									},
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].atomic[1]: only put steps can be published atomically"))
+				})
+			})
+
+			Context("when a task plan has config path and config specified", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{
+							Name:       "lol",
+							ConfigPath: "task.yml",
+							Config: &atc.TaskConfig{
+								Params: atc.TaskEnv{
+									"param1": "value1",
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(lol): must specify one of `file:` or `config:`, not both"))
+				})
+			})
+
+			Context("when a task plan specifies a worker name", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{
+							Name:       "lol",
+							ConfigPath: "task.yml",
+							WorkerName: "some-worker",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(lol): `worker_name:` must be explicitly opted-in to using the `--enable-step-worker-name` flag"))
+				})
+
+				Context("when pinning steps to workers is enabled", func() {
+					BeforeEach(func() {
+						atc.EnableStepWorkerName = true
+					})
+
+					AfterEach(func() {
+						atc.EnableStepWorkerName = false
+					})
+
+					It("returns no error", func() {
+						Expect(errorMessages).To(BeEmpty())
+					})
+				})
+			})
+
+			Context("when a task plan is invalid", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{
+							Name: "some-resource",
+							Config: &atc.TaskConfig{
+								Params: atc.TaskEnv{
+									"param1": "value1",
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(some-resource).config: missing 'platform'"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(some-resource).config: missing path to executable to run"))
+				})
+			})
+
+			Context("when a put plan has refers to a resource that does exist", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.PutStep{
+							Name: "some-resource",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a get plan has refers to a resource that does not exist", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name: "some-nonexistent-resource",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-nonexistent-resource): unknown resource 'some-nonexistent-resource'"))
+				})
+			})
+
+			Context("when a get plan has a valid version constraint", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name:              "some-resource",
+							VersionConstraint: ">= 2.0.0, < 3.0.0",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a get plan has repeated or empty metadata vars", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name:         "some-resource",
+							MetadataVars: []string{"message", "", "message"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource).metadata_vars: empty metadata key"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource).metadata_vars: repeated metadata key 'message'"))
+				})
+			})
+
+			Context("when a get plan has invalid hosts", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name:  "some-resource",
+							Hosts: atc.HostsConfig{"some_host": "10.0.0.1", "other-host": "bogus"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource).hosts: invalid IP address 'bogus' for hostname 'other-host'"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource).hosts: invalid hostname 'some_host'"))
+				})
+			})
+
+			Context("when a get plan has an unknown runtime", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name:    "some-resource",
+							Runtime: "garden",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource).runtime: unknown runtime 'garden' (must be one of: guardian, containerd, houdini)"))
+				})
+			})
+
+			Context("when a get plan has an unsupported unpack format", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name:   "some-resource",
+							Unpack: "zip",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource).unpack: unsupported unpack format 'zip' (must be one of: tar.gz, tar.zst)"))
+				})
+			})
+
+			Context("when a get plan with metadata vars has the same name as a local var", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence,
+						atc.Step{
+							Config: &atc.LoadVarStep{
+								Name: "some-resource",
+								File: "some-file",
+							},
+						},
+						atc.Step{
+							Config: &atc.GetStep{
+								Name:         "some-resource",
+								MetadataVars: []string{"message"},
+							},
+						},
+					)
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[1].get(some-resource): repeated var name"))
+				})
+			})
+
+			Context("when a get plan has an invalid version constraint", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name:              "some-resource",
+							VersionConstraint: "latest",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource).version_constraint: invalid version constraint 'latest'"))
+				})
+			})
+
+			Context("when a get plan has a version constraint and every version", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name:              "some-resource",
+							Version:           &atc.VersionConfig{Every: true},
+							VersionConstraint: "2.x",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource).version_constraint: cannot be used with a version other than latest"))
+				})
+			})
 
-		Describe("plans", func() {
-			Context("when a task plan has neither a config, path, or name set set", func() {
+			Context("when a get plan has a version constraint and passed constraints", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.TaskStep{},
+						Config: &atc.GetStep{
+							Name:              "some-resource",
+							Passed:            []string{"some-job"},
+							VersionConstraint: "2.x",
+						},
 					})
 
 					config.Jobs = append(config.Jobs, job)
 				})
 
 				It("returns an error", func() {
-					Expect(errorMessages).To(HaveLen(1))
-					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(): must specify either `file:` or `config:`"))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(): identifier cannot be an empty string"))
+					Expect(errorMessages).To(ContainElement(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource).version_constraint: cannot be used with passed constraints")))
 				})
 			})
 
-			Context("when a task plan has config path and config specified", func() {
+			Context("when an in_parallel step prioritizes the output of an unknown step", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.TaskStep{
-							Name:       "lol",
-							ConfigPath: "task.yml",
-							Config: &atc.TaskConfig{
-								Params: atc.TaskEnv{
-									"param1": "value1",
+						Config: &atc.InParallelStep{
+							Config: atc.InParallelConfig{
+								Steps: []atc.Step{
+									{
+										Config: &atc.GetStep{
+											Name: "some-resource",
+										},
+									},
 								},
+								OutputPriority: []string{"some-resource", "bogus-step"},
 							},
 						},
 					})
@@ -974,20 +1603,23 @@ var _ = Describe("ValidateConfig", func() {
 
 				It("returns an error", func() {
 					Expect(errorMessages).To(HaveLen(1))
-					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(lol): must specify one of `file:` or `config:`, not both"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].in_parallel.output_priority[1]: unknown step 'bogus-step'"))
 				})
 			})
 
-			Context("when a task plan is invalid", func() {
+			Context("when an in_parallel step prioritizes the same step more than once", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.TaskStep{
-							Name: "some-resource",
-							Config: &atc.TaskConfig{
-								Params: atc.TaskEnv{
-									"param1": "value1",
+						Config: &atc.InParallelStep{
+							Config: atc.InParallelConfig{
+								Steps: []atc.Step{
+									{
+										Config: &atc.GetStep{
+											Name: "some-resource",
+										},
+									},
 								},
+								OutputPriority: []string{"some-resource", "some-resource"},
 							},
 						},
 					})
@@ -997,33 +1629,51 @@ var _ = Describe("ValidateConfig", func() {
 
 				It("returns an error", func() {
 					Expect(errorMessages).To(HaveLen(1))
-					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(some-resource).config: missing 'platform'"))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(some-resource).config: missing path to executable to run"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].in_parallel.output_priority[1]: step 'some-resource' is listed more than once"))
 				})
 			})
 
-			Context("when a put plan has refers to a resource that does exist", func() {
+			Context("when an in_parallel step has a grace period without failing fast", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.PutStep{
-							Name: "some-resource",
+						Config: &atc.InParallelStep{
+							Config: atc.InParallelConfig{
+								Steps: []atc.Step{
+									{
+										Config: &atc.GetStep{
+											Name: "some-resource",
+										},
+									},
+								},
+								FailFastGracePeriod: "1m",
+							},
 						},
 					})
 
 					config.Jobs = append(config.Jobs, job)
 				})
 
-				It("does not return an error", func() {
-					Expect(errorMessages).To(HaveLen(0))
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].in_parallel.fail_fast_grace_period: only applies when fail_fast is set"))
 				})
 			})
 
-			Context("when a get plan has refers to a resource that does not exist", func() {
+			Context("when an in_parallel step has an invalid grace period", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
-						Config: &atc.GetStep{
-							Name: "some-nonexistent-resource",
+						Config: &atc.InParallelStep{
+							Config: atc.InParallelConfig{
+								Steps: []atc.Step{
+									{
+										Config: &atc.GetStep{
+											Name: "some-resource",
+										},
+									},
+								},
+								FailFast:            true,
+								FailFastGracePeriod: "bogus",
+							},
 						},
 					})
 
@@ -1032,8 +1682,7 @@ var _ = Describe("ValidateConfig", func() {
 
 				It("returns an error", func() {
 					Expect(errorMessages).To(HaveLen(1))
-					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-nonexistent-resource): unknown resource 'some-nonexistent-resource'"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].in_parallel.fail_fast_grace_period: invalid duration 'bogus'"))
 				})
 			})
 
@@ -1791,6 +2440,129 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when an approval step has an invalid timeout", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.ApprovalStep{
+							Name:    "deploy",
+							Timeout: "soon",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].approval(deploy): invalid timeout 'soon'"))
+				})
+			})
+
+			Context("when a consume_artifact step has an invalid name", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.ConsumeArtifactStep{
+							Name: "_some-artifact",
+							Team: "other-team",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns a warning", func() {
+					Expect(warnings).To(HaveLen(1))
+					Expect(warnings[0].Message).To(ContainSubstring("jobs.some-other-job.plan.do[0].consume_artifact(_some-artifact): '_some-artifact' is not a valid identifier"))
+				})
+			})
+
+			Context("when a load_build_outputs step refers to an unknown job", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.LoadBuildOutputsStep{
+							Name: "some-outputs",
+							Job:  "bogus-job",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].load_build_outputs(some-outputs): unknown job 'bogus-job'"))
+				})
+			})
+
+			Context("when a load_build_outputs step has no job", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.LoadBuildOutputsStep{
+							Name: "some-outputs",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].load_build_outputs(some-outputs): no job specified"))
+				})
+			})
+
+			Context("when an assert_green step refers to an unknown job of the pipeline", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AssertGreenStep{
+							Job: "bogus-job",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].assert_green(bogus-job): unknown job 'bogus-job'"))
+				})
+			})
+
+			Context("when an assert_green step refers to a job of another pipeline", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AssertGreenStep{
+							Job:      "upstream-job",
+							Pipeline: "upstream",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(BeEmpty())
+				})
+			})
+
+			Context("when an assert_green step has a team but no pipeline", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AssertGreenStep{
+							Job:  "some-job",
+							Team: "other-team",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].assert_green(some-job): pipeline must be specified along with team"))
+				})
+			})
+
 			Context("when a step has unknown fields", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -1952,6 +2724,28 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a task is named after a local var", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence,
+						atc.Step{Config: &atc.LoadVarStep{
+							Name: "a",
+							File: "unused",
+						}},
+						atc.Step{Config: &atc.TaskStep{
+							Name:       "a",
+							ConfigPath: "unused",
+						}})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns a warning", func() {
+					Expect(errorMessages).To(BeEmpty())
+					Expect(warnings).To(HaveLen(1))
+					Expect(warnings[0].Message).To(ContainSubstring("jobs.some-other-job.plan.do[1].task(a): sets its exit code as a local var which shadows local var 'a'"))
+				})
+			})
+
 			Context("when an across step has a non-positive limit", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -2111,6 +2905,110 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("validating webhooks", func() {
+		Context("when the webhooks are valid", func() {
+			BeforeEach(func() {
+				config.Webhooks = atc.WebhookConfigs{
+					{Name: "some-webhook", URL: "https://example.com/hook"},
+					{Name: "other-webhook", URL: "((webhook-url))"},
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when two webhooks have the same name", func() {
+			BeforeEach(func() {
+				config.Webhooks = atc.WebhookConfigs{
+					{Name: "some-webhook", URL: "https://example.com/hook"},
+					{Name: "some-webhook", URL: "https://example.com/other-hook"},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid webhooks:"))
+				Expect(errorMessages[0]).To(ContainSubstring("duplicate webhook name: some-webhook"))
+			})
+		})
+
+		Context("when a webhook notifies on an unknown condition", func() {
+			BeforeEach(func() {
+				config.Webhooks = atc.WebhookConfigs{
+					{Name: "some-webhook", URL: "https://example.com/hook", Notify: atc.WebhookNotifyOnChange},
+					{Name: "other-webhook", URL: "https://example.com/hook", Notify: "sometimes"},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("webhooks.other-webhook notify must be either always or change"))
+			})
+		})
+
+		Context("when a webhook has no url", func() {
+			BeforeEach(func() {
+				config.Webhooks = atc.WebhookConfigs{
+					{Name: "some-webhook"},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("webhooks.some-webhook has no url"))
+			})
+		})
+
+		Context("when a webhook url uses an unsupported scheme", func() {
+			BeforeEach(func() {
+				config.Webhooks = atc.WebhookConfigs{
+					{Name: "some-webhook", URL: "ftp://example.com/hook"},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("webhooks.some-webhook url scheme must be either http or https"))
+			})
+		})
+	})
+
+	Describe("validating max_build_duration", func() {
+		Context("when it is a valid duration", func() {
+			BeforeEach(func() {
+				config.MaxBuildDuration = "2h"
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when it is not a duration", func() {
+			BeforeEach(func() {
+				config.MaxBuildDuration = "forever"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid max_build_duration"))
+			})
+		})
+
+		Context("when it is not positive", func() {
+			BeforeEach(func() {
+				config.MaxBuildDuration = "0s"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("max_build_duration must be positive: 0s"))
+			})
+		})
+	})
+
 	Describe("invalid pipeline", func() {
 		Context("contains zero jobs", func() {
 			BeforeEach(func() {
@@ -2124,3 +3022,53 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 })
+
+var _ = Describe("ValidateTeamResourceTypes", func() {
+	It("accepts valid resource types", func() {
+		warnings, errorMessages := configvalidate.ValidateTeamResourceTypes(atc.ResourceTypes{
+			{Name: "some-type", Type: "registry-image", Source: atc.Source{"repository": "some/image"}},
+		})
+		Expect(warnings).To(BeEmpty())
+		Expect(errorMessages).To(BeEmpty())
+	})
+
+	It("rejects resource types without a type", func() {
+		_, errorMessages := configvalidate.ValidateTeamResourceTypes(atc.ResourceTypes{
+			{Name: "some-type"},
+		})
+		Expect(errorMessages).To(HaveLen(1))
+		Expect(errorMessages[0]).To(ContainSubstring("invalid resource types:"))
+		Expect(errorMessages[0]).To(ContainSubstring("resource_types.some-type has no type"))
+	})
+
+	It("rejects resource types with the same name", func() {
+		_, errorMessages := configvalidate.ValidateTeamResourceTypes(atc.ResourceTypes{
+			{Name: "some-type", Type: "registry-image"},
+			{Name: "some-type", Type: "registry-image"},
+		})
+		Expect(errorMessages).To(HaveLen(1))
+		Expect(errorMessages[0]).To(ContainSubstring("resource_types[0] and resource_types[1] have the same name ('some-type')"))
+	})
+})
+
+var _ = Describe("ShadowedTeamResourceTypes", func() {
+	It("warns about pipeline resource types overriding a team resource type", func() {
+		warnings := configvalidate.ShadowedTeamResourceTypes(
+			atc.Config{
+				ResourceTypes: atc.ResourceTypes{
+					{Name: "some-type", Type: "registry-image"},
+					{Name: "other-type", Type: "registry-image"},
+				},
+			},
+			atc.ResourceTypes{
+				{Name: "some-type", Type: "registry-image"},
+			},
+		)
+		Expect(warnings).To(Equal([]atc.ConfigWarning{
+			{
+				Type:    "resource_type",
+				Message: "resource_types.some-type overrides the team's resource type of the same name",
+			},
+		}))
+	})
+})
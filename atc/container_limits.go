@@ -3,16 +3,24 @@ package atc
 import (
 	"encoding/json"
 	"errors"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
 )
 
 var memoryRegex = regexp.MustCompile(`^([0-9]+)([GMK]?[B])?$`)
+var sizeRegex = regexp.MustCompile(`^([0-9]+)([TGMK]?[B])?$`)
 
 type ContainerLimits struct {
 	CPU    *CPULimit    `json:"cpu,omitempty"`
 	Memory *MemoryLimit `json:"memory,omitempty"`
+
+	// OutputSize is the largest each of the task's outputs may grow to while
+	// it runs. Unlike the other limits it is not enforced by the container
+	// runtime; it is checked periodically against the sizes reported by the
+	// worker.
+	OutputSize *SizeLimit `json:"output_size,omitempty"`
 }
 
 type CPULimit uint64
@@ -74,3 +82,62 @@ func ParseMemoryLimit(limit string) (MemoryLimit, error) {
 
 	return MemoryLimit(value * (1 << power)), nil
 }
+
+type SizeLimit uint64
+
+func (s *SizeLimit) UnmarshalJSON(data []byte) error {
+	var dst interface{}
+	if err := json.Unmarshal(data, &dst); err != nil {
+		return err
+	}
+	switch v := dst.(type) {
+	case float64:
+		if v < 0 || v >= float64(math.MaxUint64) {
+			return errors.New("output size limit is out of range")
+		}
+
+		*s = SizeLimit(v)
+	case string:
+		var err error
+		*s, err = ParseSizeLimit(v)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ParseSizeLimit(limit string) (SizeLimit, error) {
+	limit = strings.ToUpper(limit)
+	matches := sizeRegex.FindStringSubmatch(limit)
+
+	if len(matches) != 3 {
+		return 0, errors.New("could not parse output size limit")
+	}
+
+	value, err := strconv.ParseUint(matches[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	unit := matches[2]
+	var power int
+	switch unit {
+	case "KB":
+		power = 10
+	case "MB":
+		power = 20
+	case "GB":
+		power = 30
+	case "TB":
+		power = 40
+	default:
+		power = 0
+	}
+
+	if value > math.MaxUint64>>power {
+		return 0, errors.New("output size limit is out of range")
+	}
+
+	return SizeLimit(value << power), nil
+}
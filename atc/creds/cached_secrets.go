@@ -8,8 +8,9 @@ import (
 
 type SecretCacheConfig struct {
 	Enabled          bool          `long:"secret-cache-enabled" description:"Enable in-memory cache for secrets"`
+	EnabledNotFound  bool          `long:"secret-cache-notfound-enabled" description:"Enable in-memory cache for secret not found responses only. Implied by --secret-cache-enabled"`
 	Duration         time.Duration `long:"secret-cache-duration" default:"1m" description:"If the cache is enabled, secret values will be cached for not longer than this duration (it can be less, if underlying secret lease time is smaller)"`
-	DurationNotFound time.Duration `long:"secret-cache-duration-notfound" default:"10s" description:"If the cache is enabled, secret not found responses will be cached for this duration"`
+	DurationNotFound time.Duration `long:"secret-cache-duration-notfound" default:"10s" description:"If the cache is enabled, secret not found responses will be cached for this duration. Set to 0 to disable caching of not found responses"`
 	PurgeInterval    time.Duration `long:"secret-cache-purge-interval" default:"10m" description:"If the cache is enabled, expired items will be removed on this interval"`
 }
 
@@ -66,14 +67,25 @@ func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool,
 				duration = itemDuration
 			}
 		}
-		cs.cache.Set(secretPath, entry, duration)
+		cs.set(secretPath, entry, duration)
 	} else {
-		cs.cache.Set(secretPath, entry, cs.cacheConfig.DurationNotFound)
+		cs.set(secretPath, entry, cs.cacheConfig.DurationNotFound)
 	}
 
 	return value, expiration, found, nil
 }
 
+// set stores the entry for the given duration. A non-positive duration means
+// the entry is not cached at all, rather than go-cache's behaviour of falling
+// back to the default expiration.
+func (cs *CachedSecrets) set(secretPath string, entry interface{}, duration time.Duration) {
+	if duration <= 0 {
+		return
+	}
+
+	cs.cache.Set(secretPath, entry, duration)
+}
+
 func (cs *CachedSecrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []SecretLookupPath {
 	return cs.secrets.NewSecretLookupPaths(teamName, pipelineName, allowRootPath)
 }
@@ -162,4 +162,39 @@ var _ = Describe("Caching of secrets", func() {
 		Expect(underlyingMisses).To(BeIdenticalTo(4))
 	})
 
+	Context("when the not found duration is zero", func() {
+		BeforeEach(func() {
+			cacheConfig.DurationNotFound = 0
+			cachedSecretManager = creds.NewCachedSecrets(secretManager, cacheConfig)
+		})
+
+		It("should not cache negative responses", func() {
+			secretManager.GetStub = makeGetStub("foo", "value", nil, true, nil, &underlyingReads, &underlyingMisses)
+
+			_, _, _, _ = cachedSecretManager.Get("foo")
+			_, _, _, _ = cachedSecretManager.Get("bar")
+			_, _, _, _ = cachedSecretManager.Get("foo")
+			_, _, _, _ = cachedSecretManager.Get("bar")
+			Expect(underlyingReads).To(BeIdenticalTo(1))
+			Expect(underlyingMisses).To(BeIdenticalTo(2))
+		})
+	})
+
+	Context("when the duration is zero", func() {
+		BeforeEach(func() {
+			cacheConfig.Duration = 0
+			cachedSecretManager = creds.NewCachedSecrets(secretManager, cacheConfig)
+		})
+
+		It("should only cache negative responses", func() {
+			secretManager.GetStub = makeGetStub("foo", "value", nil, true, nil, &underlyingReads, &underlyingMisses)
+
+			_, _, _, _ = cachedSecretManager.Get("foo")
+			_, _, _, _ = cachedSecretManager.Get("bar")
+			_, _, _, _ = cachedSecretManager.Get("foo")
+			_, _, _, _ = cachedSecretManager.Get("bar")
+			Expect(underlyingReads).To(BeIdenticalTo(2))
+			Expect(underlyingMisses).To(BeIdenticalTo(1))
+		})
+	})
 })
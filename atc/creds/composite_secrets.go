@@ -0,0 +1,136 @@
+package creds
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type compositeSecretsFactory struct {
+	factories []SecretsFactory
+}
+
+// NewCompositeSecretsFactory returns a factory of secrets which look up each
+// var in the secrets of every factory in turn, in the order given, taking the
+// first one found.
+func NewCompositeSecretsFactory(factories []SecretsFactory) SecretsFactory {
+	return &compositeSecretsFactory{
+		factories: factories,
+	}
+}
+
+func (f *compositeSecretsFactory) NewSecrets() Secrets {
+	members := make([]Secrets, len(f.factories))
+	for i, factory := range f.factories {
+		members[i] = factory.NewSecrets()
+	}
+
+	return &CompositeSecrets{
+		members: members,
+	}
+}
+
+// CompositeSecrets looks up secrets in several credential managers in order of
+// precedence. Each manager has its own lookup paths, so rather than a path of
+// any one manager, the composite's secret paths carry the var along with the
+// team and pipeline it is looked up for.
+type CompositeSecrets struct {
+	members []Secrets
+}
+
+type compositeSecretPath struct {
+	Team          string `json:"team"`
+	Pipeline      string `json:"pipeline"`
+	AllowRootPath bool   `json:"allow_root_path"`
+	Var           string `json:"var"`
+}
+
+// Get looks up the secret in each manager in turn and returns the first one
+// found. A manager erroring doesn't stop the lookup; the errors are only
+// returned if no manager found the secret.
+func (cs *CompositeSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	var path compositeSecretPath
+	err := json.Unmarshal([]byte(secretPath), &path)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("invalid secret path: %w", err)
+	}
+
+	var errs []string
+	for _, member := range cs.members {
+		value, expiration, found, err := getMemberSecret(member, path)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+
+		if found {
+			return value, expiration, true, nil
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, false, fmt.Errorf("all credential managers failed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil, nil, false, nil
+}
+
+// getMemberSecret looks up the var in a single manager the same way vars are
+// looked up in secrets outside of a composite.
+func getMemberSecret(member Secrets, path compositeSecretPath) (interface{}, *time.Time, bool, error) {
+	lookupPaths := member.NewSecretLookupPaths(path.Team, path.Pipeline, path.AllowRootPath)
+	if len(lookupPaths) == 0 {
+		return member.Get(path.Var)
+	}
+
+	for _, rule := range lookupPaths {
+		secretPath, err := rule.VariableToSecretPath(path.Var)
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		value, expiration, found, err := member.Get(secretPath)
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		if found {
+			return value, expiration, true, nil
+		}
+	}
+
+	return nil, nil, false, nil
+}
+
+// NewSecretLookupPaths returns a single lookup path, which keeps the team and
+// pipeline so that Get can use the lookup paths of each manager.
+func (cs *CompositeSecrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []SecretLookupPath {
+	return []SecretLookupPath{
+		compositeLookupPath{
+			Team:          teamName,
+			Pipeline:      pipelineName,
+			AllowRootPath: allowRootPath,
+		},
+	}
+}
+
+type compositeLookupPath struct {
+	Team          string
+	Pipeline      string
+	AllowRootPath bool
+}
+
+func (lp compositeLookupPath) VariableToSecretPath(varName string) (string, error) {
+	path, err := json.Marshal(compositeSecretPath{
+		Team:          lp.Team,
+		Pipeline:      lp.Pipeline,
+		AllowRootPath: lp.AllowRootPath,
+		Var:           varName,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(path), nil
+}
@@ -0,0 +1,146 @@
+package creds_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CompositeSecrets", func() {
+	var (
+		storeA *credsfakes.FakeSecrets
+		storeB *credsfakes.FakeSecrets
+
+		variables vars.Variables
+	)
+
+	newFactory := func(secrets creds.Secrets) creds.SecretsFactory {
+		factory := new(credsfakes.FakeSecretsFactory)
+		factory.NewSecretsReturns(secrets)
+		return factory
+	}
+
+	BeforeEach(func() {
+		storeA = new(credsfakes.FakeSecrets)
+		storeA.NewSecretLookupPathsStub = func(team string, pipeline string, allowRootPath bool) []creds.SecretLookupPath {
+			return []creds.SecretLookupPath{
+				creds.NewSecretLookupWithPrefix("/a/" + team + "/" + pipeline + "/"),
+				creds.NewSecretLookupWithPrefix("/a/" + team + "/"),
+			}
+		}
+
+		storeB = new(credsfakes.FakeSecrets)
+		storeB.NewSecretLookupPathsStub = func(team string, pipeline string, allowRootPath bool) []creds.SecretLookupPath {
+			return []creds.SecretLookupPath{
+				creds.NewSecretLookupWithPrefix("/b/" + team + "/"),
+			}
+		}
+	})
+
+	JustBeforeEach(func() {
+		factory := creds.NewCompositeSecretsFactory([]creds.SecretsFactory{
+			newFactory(storeA),
+			newFactory(storeB),
+		})
+
+		variables = creds.NewVariables(factory.NewSecrets(), "some-team", "some-pipeline", false)
+	})
+
+	Context("when the first manager has the secret", func() {
+		BeforeEach(func() {
+			storeA.GetStub = func(path string) (interface{}, *time.Time, bool, error) {
+				if path == "/a/some-team/some-var" {
+					return "a-value", nil, true, nil
+				}
+
+				return nil, nil, false, nil
+			}
+		})
+
+		It("returns it using the manager's lookup paths", func() {
+			value, found, err := variables.Get(vars.Reference{Path: "some-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("a-value"))
+
+			Expect(storeA.GetCallCount()).To(Equal(2))
+			Expect(storeA.GetArgsForCall(0)).To(Equal("/a/some-team/some-pipeline/some-var"))
+			Expect(storeA.GetArgsForCall(1)).To(Equal("/a/some-team/some-var"))
+		})
+
+		It("does not look in the other manager", func() {
+			_, _, err := variables.Get(vars.Reference{Path: "some-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(storeB.GetCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when only a later manager has the secret", func() {
+		BeforeEach(func() {
+			storeB.GetReturns("b-value", nil, true, nil)
+		})
+
+		It("returns it", func() {
+			value, found, err := variables.Get(vars.Reference{Path: "some-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("b-value"))
+
+			Expect(storeB.GetArgsForCall(0)).To(Equal("/b/some-team/some-var"))
+		})
+
+		Context("when an earlier manager errors", func() {
+			BeforeEach(func() {
+				storeA.GetReturns(nil, nil, false, errors.New("a is down"))
+			})
+
+			It("still returns it", func() {
+				value, found, err := variables.Get(vars.Reference{Path: "some-var"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("b-value"))
+			})
+		})
+	})
+
+	Context("when no manager has the secret", func() {
+		It("is not found", func() {
+			_, found, err := variables.Get(vars.Reference{Path: "some-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when managers error", func() {
+			BeforeEach(func() {
+				storeA.GetReturns(nil, nil, false, errors.New("a is down"))
+				storeB.GetReturns(nil, nil, false, errors.New("b is down"))
+			})
+
+			It("returns all of their errors", func() {
+				_, _, err := variables.Get(vars.Reference{Path: "some-var"})
+				Expect(err).To(MatchError("all credential managers failed: a is down; b is down"))
+			})
+		})
+	})
+
+	Context("when a manager has no lookup paths", func() {
+		BeforeEach(func() {
+			storeA.NewSecretLookupPathsReturns(nil)
+			storeA.GetReturns("a-value", nil, true, nil)
+		})
+
+		It("looks up the var by its name", func() {
+			value, found, err := variables.Get(vars.Reference{Path: "some-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("a-value"))
+
+			Expect(storeA.GetArgsForCall(0)).To(Equal("some-var"))
+		})
+	})
+})
@@ -0,0 +1,57 @@
+package creds
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/vars"
+)
+
+type Hosts struct {
+	variablesResolver vars.Variables
+	rawHosts          atc.HostsConfig
+}
+
+func NewHosts(variables vars.Variables, hosts atc.HostsConfig) Hosts {
+	return Hosts{
+		variablesResolver: variables,
+		rawHosts:          hosts,
+	}
+}
+
+// Evaluate returns the hosts entries with their vars resolved. Entries with
+// vars can't be validated along with the config, so they are validated once
+// resolved. As their vars may be credentials, invalid entries are reported as
+// they were configured rather than as they resolved.
+func (h Hosts) Evaluate() (atc.HostsConfig, error) {
+	if len(h.rawHosts) == 0 {
+		return h.rawHosts, nil
+	}
+
+	hosts := atc.HostsConfig{}
+	var invalid []string
+	for rawHostname, rawIP := range h.rawHosts {
+		var entry atc.HostsConfig
+		err := evaluate(h.variablesResolver, atc.HostsConfig{rawHostname: rawIP}, &entry)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(entry.Validate()) > 0 {
+			invalid = append(invalid, fmt.Sprintf("'%s: %s'", rawHostname, rawIP))
+		}
+
+		for hostname, ip := range entry {
+			hosts[hostname] = ip
+		}
+	}
+
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return nil, fmt.Errorf("invalid hosts entries once their vars are resolved: %s", strings.Join(invalid, ", "))
+	}
+
+	return hosts, nil
+}
@@ -0,0 +1,57 @@
+package creds_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hosts", func() {
+	var variables vars.StaticVariables
+
+	BeforeEach(func() {
+		variables = vars.StaticVariables{
+			"registry-ip":   "10.0.0.1",
+			"registry-host": "registry.internal",
+			"bad-ip":        "not-an-ip",
+		}
+	})
+
+	Describe("Evaluate", func() {
+		It("resolves the vars of the hostnames and addresses", func() {
+			hosts, err := creds.NewHosts(variables, atc.HostsConfig{
+				"((registry-host))": "((registry-ip))",
+				"db.internal":       "10.0.0.2",
+			}).Evaluate()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(hosts).To(Equal(atc.HostsConfig{
+				"registry.internal": "10.0.0.1",
+				"db.internal":       "10.0.0.2",
+			}))
+		})
+
+		It("returns no entries when there are none", func() {
+			hosts, err := creds.NewHosts(variables, nil).Evaluate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hosts).To(BeEmpty())
+		})
+
+		It("errors when a resolved entry is invalid", func() {
+			_, err := creds.NewHosts(variables, atc.HostsConfig{
+				"registry.internal": "((bad-ip))",
+			}).Evaluate()
+			Expect(err).To(MatchError("invalid hosts entries once their vars are resolved: 'registry.internal: ((bad-ip))'"))
+		})
+
+		It("errors when a var is missing", func() {
+			_, err := creds.NewHosts(variables, atc.HostsConfig{
+				"registry.internal": "((missing))",
+			}).Evaluate()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -25,6 +25,8 @@ type Managers map[string]Manager
 type CredentialManagementConfig struct {
 	RetryConfig SecretRetryConfig
 	CacheConfig SecretCacheConfig
+
+	Managers []string `long:"credential-manager" description:"The name of a configured credential manager to look up vars in. Can be specified multiple times to look vars up in each manager in turn, in the order given, e.g. while migrating from one to another."`
 }
 
 // NewSecrets creates a Secrets object from secretsFactory based on configs.
@@ -33,6 +35,10 @@ func (c CredentialManagementConfig) NewSecrets(secretsFactory SecretsFactory) Se
 	result = NewRetryableSecrets(result, c.RetryConfig)
 	if c.CacheConfig.Enabled {
 		result = NewCachedSecrets(result, c.CacheConfig)
+	} else if c.CacheConfig.EnabledNotFound {
+		notFoundOnly := c.CacheConfig
+		notFoundOnly.Duration = 0
+		result = NewCachedSecrets(result, notFoundOnly)
 	}
 	return result
 }
@@ -0,0 +1,34 @@
+package creds
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/vars"
+)
+
+type Proxy struct {
+	variablesResolver vars.Variables
+	rawProxy          *atc.ProxyConfig
+}
+
+func NewProxy(variables vars.Variables, proxy *atc.ProxyConfig) Proxy {
+	return Proxy{
+		variablesResolver: variables,
+		rawProxy:          proxy,
+	}
+}
+
+// Evaluate returns the proxy config with its vars resolved, or an empty config
+// if there is none.
+func (p Proxy) Evaluate() (atc.ProxyConfig, error) {
+	var proxy atc.ProxyConfig
+	if p.rawProxy == nil {
+		return proxy, nil
+	}
+
+	err := evaluate(p.variablesResolver, p.rawProxy, &proxy)
+	if err != nil {
+		return atc.ProxyConfig{}, err
+	}
+
+	return proxy, nil
+}
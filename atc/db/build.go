@@ -33,6 +33,19 @@ type BuildInput struct {
 	Version    atc.Version
 	ResourceID int
 
+	// Only populated by (Build).Resources, when adopting the build's inputs
+	// and with a job's next build inputs.
+	Metadata       ResourceConfigMetadataFields
+	VersionMissing bool
+
+	// Only populated by (Build).Resources when the version is missing, as it
+	// then only identifies the version which was used.
+	VersionMD5 string
+
+	// Only populated with a job's next build inputs: when the version was
+	// first saved.
+	VersionCreatedAt time.Time
+
 	FirstOccurrence bool
 	ResolveError    string
 
@@ -46,6 +59,12 @@ func (bi BuildInput) SpanContext() propagation.TextMapCarrier {
 type BuildOutput struct {
 	Name    string
 	Version atc.Version
+
+	// Only populated by (Build).Resources. VersionMD5 is only populated when
+	// the version is missing.
+	Metadata       ResourceConfigMetadataFields
+	VersionMissing bool
+	VersionMD5     string
 }
 
 type BuildStatus string
@@ -63,6 +82,39 @@ func (status BuildStatus) String() string {
 	return string(status)
 }
 
+// TriggerVars are the vars given when a build was manually triggered. They
+// take precedence over the pipeline's vars when the build is run. They are
+// stored encrypted, as they may hold credentials.
+type TriggerVars struct {
+	Vars map[string]interface{} `json:"vars,omitempty"`
+
+	// AllowOverride permits vars that are also defined by the pipeline: its
+	// instance vars, vars, var sources or credential manager.
+	AllowOverride bool `json:"allow_override,omitempty"`
+}
+
+// TriggerVarConflictError is returned when a build is triggered with a var
+// that is also defined by its pipeline and overriding isn't allowed. Conflicts
+// with instance vars are found when the build is triggered, and conflicts with
+// the pipeline's other vars, which may come from a credential manager, when
+// the build resolves its vars.
+type TriggerVarConflictError struct {
+	Name string
+}
+
+func (e TriggerVarConflictError) Error() string {
+	return fmt.Sprintf("trigger var '%s' conflicts with a var defined by the pipeline", e.Name)
+}
+
+func encryptTriggerVars(es encryption.Strategy, triggerVars TriggerVars) (string, *string, error) {
+	payload, err := json.Marshal(triggerVars)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return es.Encrypt(payload)
+}
+
 var buildsQuery = psql.Select(`
 		b.id,
 		b.name,
@@ -96,7 +148,13 @@ var buildsQuery = psql.Select(`
 		b.rerun_of,
 		rb.name,
 		b.rerun_number,
-		b.span_context
+		b.span_context,
+		b.aborted_by,
+		b.abort_reason,
+		b.events_archive_key,
+		b.trigger_vars,
+		b.trigger_vars_nonce,
+		b.protected
 	`).
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
@@ -151,6 +209,8 @@ type Build interface {
 	RerunOfName() string
 	RerunNumber() int
 	CreatedBy() *string
+	AbortedBy() *string
+	AbortReason() string
 
 	LagerData() lager.Data
 	TracingAttrs() tracing.Attrs
@@ -160,6 +220,9 @@ type Build interface {
 	Reload() (bool, error)
 
 	ResourcesChecked() (bool, error)
+	OnDemandResourcesChecked(resourceNames []string) (bool, error)
+
+	QueuePosition() (int, bool, error)
 
 	AcquireTrackingLock(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error)
 
@@ -169,31 +232,74 @@ type Build interface {
 	Start(atc.Plan) (bool, error)
 	Finish(BuildStatus) error
 
+	// FinishWithWebhookNotifications finishes the build and, in the same
+	// transaction, queues the payload to be delivered to each of the given
+	// webhooks of the build's pipeline.
+	FinishWithWebhookNotifications(status BuildStatus, webhooks []string, payload json.RawMessage) error
+
 	Variables(lager.Logger, creds.Secrets, creds.VarSourcePool) (vars.Variables, error)
+	TriggerVars() TriggerVars
 
 	SetInterceptible(bool) error
 
 	Events(uint) (EventSource, error)
 	SaveEvent(event atc.Event) error
+	SaveEvents(events []atc.Event) error
 
 	Artifacts() ([]WorkerArtifact, error)
 	Artifact(artifactID int) (WorkerArtifact, error)
 
+	Containers() ([]Container, error)
+	Volumes() ([]BuildVolume, error)
+
 	SaveOutput(string, atc.Source, atc.VersionedResourceTypes, atc.Version, ResourceConfigMetadataFields, string, string) error
 	AdoptInputsAndPipes() ([]BuildInput, bool, error)
 	AdoptRerunInputsAndPipes() ([]BuildInput, bool, error)
+	UnavailableInputs() ([]string, error)
 
 	Resources() ([]BuildInput, []BuildOutput, error)
 	SaveImageResourceVersion(UsedResourceCache) error
 
 	Delete() (bool, error)
-	MarkAsAborted() error
+	MarkAsAborted(abortedBy string, reason string) error
 	IsAborted() bool
 	AbortNotifier() (Notifier, error)
 
+	// SupersedingVersion returns the newest version of the given resource
+	// that is newer than the one the build used as an input.
+	SupersedingVersion(resourceName string) (atc.Version, bool, error)
+	// SupersededNotifier notifies when the given resource may have a version
+	// newer than the one the build used as an input.
+	SupersededNotifier(resourceName string) (Notifier, error)
+
+	// FailingStreakStart returns when the first of the failed or errored builds
+	// of the job since its last succeeded build before this one finished. It
+	// returns false if there are none, i.e. the job wasn't failing.
+	FailingStreakStart() (time.Time, bool, error)
+	// PreviousStatus returns the status of the job's last completed build
+	// before this one, ignoring aborted builds. It returns false if there is
+	// none.
+	PreviousStatus() (BuildStatus, bool, error)
+
+	RequestApproval(planID atc.PlanID, name string) (BuildApproval, error)
+	DecideApproval(planID atc.PlanID, approved bool, decidedBy string, comment string) (bool, error)
+	ApprovalNotifier(planID atc.PlanID) (Notifier, error)
+	Approvals() ([]BuildApproval, error)
+
+	SaveTaskExitCode(planID atc.PlanID, name string, exitCode int) error
+	TaskExitCodes() ([]TaskExitCode, error)
+
 	IsDrained() bool
 	SetDrained(bool) error
 
+	// IsProtected reports whether the build is exempt from build log
+	// retention and keeps the versions it used from being garbage collected.
+	IsProtected() bool
+	SetProtected(bool) error
+
+	EventsArchiveKey() string
+	ArchiveEvents(key string) error
+
 	SpanContext() propagation.TextMapCarrier
 
 	SavePipeline(
@@ -230,6 +336,9 @@ type build struct {
 
 	createdBy *string
 
+	abortedBy   *string
+	abortReason string
+
 	rerunOf     int
 	rerunOfName string
 	rerunNumber int
@@ -246,6 +355,11 @@ type build struct {
 	drained   bool
 	aborted   bool
 	completed bool
+	protected bool
+
+	eventsArchiveKey string
+
+	triggerVars TriggerVars
 
 	spanContext SpanContext
 }
@@ -373,6 +487,7 @@ func (b *build) ReapTime() time.Time   { return b.reapTime }
 func (b *build) Status() BuildStatus   { return b.status }
 func (b *build) IsScheduled() bool     { return b.scheduled }
 func (b *build) IsDrained() bool       { return b.drained }
+func (b *build) IsProtected() bool     { return b.protected }
 func (b *build) IsRunning() bool       { return !b.completed }
 func (b *build) IsAborted() bool       { return b.aborted }
 func (b *build) IsCompleted() bool     { return b.completed }
@@ -381,6 +496,8 @@ func (b *build) RerunOf() int          { return b.rerunOf }
 func (b *build) RerunOfName() string   { return b.rerunOfName }
 func (b *build) RerunNumber() int      { return b.rerunNumber }
 func (b *build) CreatedBy() *string    { return b.createdBy }
+func (b *build) AbortedBy() *string    { return b.abortedBy }
+func (b *build) AbortReason() string   { return b.abortReason }
 
 func (b *build) Reload() (bool, error) {
 	row := buildsQuery.Where(sq.Eq{"b.id": b.id}).
@@ -463,6 +580,118 @@ func (b *build) ResourcesChecked() (bool, error) {
 	return !notChecked, nil
 }
 
+// QueuePosition returns the position of a pending job build in the order the
+// scheduler starts builds in, which is by their original build. The build at
+// the front of the queue is at position 1. Ahead of it are the pending builds
+// which haven't been scheduled yet of:
+//
+//   - its job,
+//   - if its job has a max in flight, the unpaused jobs sharing a serial group
+//     with it whose inputs are determined, as only the oldest build of the
+//     group may be scheduled, and
+//   - if its team has a max concurrent builds, the team's unpaused jobs which
+//     haven't reached their max in flight, as the team's builds are scheduled
+//     oldest first, counting only reruns and builds of jobs whose inputs are
+//     determined.
+//
+// One-off builds and builds which are scheduled or no longer pending have no
+// position.
+func (b *build) QueuePosition() (int, bool, error) {
+	if b.jobID == 0 || b.status != BuildStatusPending || b.scheduled {
+		return 0, false, nil
+	}
+
+	original := b.id
+	if b.rerunOf != 0 {
+		original = b.rerunOf
+	}
+
+	var queued bool
+	var ahead int
+	err := b.conn.QueryRow(`
+		SELECT
+			EXISTS (
+				SELECT 1
+				FROM builds
+				WHERE id = $3
+				AND status = $1
+				AND NOT scheduled
+			),
+			(
+				SELECT COUNT(*)
+				FROM builds b
+				JOIN jobs j ON j.id = b.job_id
+				JOIN pipelines p ON p.id = j.pipeline_id
+				WHERE b.status = $1
+				AND NOT b.scheduled
+				AND (COALESCE(b.rerun_of, b.id), b.id) < ($2, $3)
+				AND (
+					b.job_id = $4
+					OR (
+						NOT j.paused
+						AND NOT p.paused
+						AND (
+							(
+								j.pipeline_id = $5
+								AND j.inputs_determined
+								AND (SELECT max_in_flight FROM jobs WHERE id = $4) > 0
+								AND EXISTS (
+									SELECT 1
+									FROM jobs_serial_groups jsg
+									JOIN jobs_serial_groups own ON own.serial_group = jsg.serial_group
+									WHERE jsg.job_id = j.id
+									AND own.job_id = $4
+								)
+							)
+							OR (
+								b.team_id = $6
+								AND NOT j.max_in_flight_reached
+								AND (b.rerun_of IS NOT NULL OR j.inputs_determined)
+								AND (SELECT max_concurrent_builds FROM teams WHERE id = $6) > 0
+							)
+						)
+					)
+				)
+			)`, BuildStatusPending, original, b.id, b.jobID, b.pipelineID, b.teamID).Scan(&queued, &ahead)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if !queued {
+		// the build was scheduled or finished after it was loaded
+		return 0, false, nil
+	}
+
+	return ahead + 1, true, nil
+}
+
+// OnDemandResourcesChecked returns whether the given input resources of the
+// build's job have been checked since the build was created, ignoring pinned
+// resources.
+func (b *build) OnDemandResourcesChecked(resourceNames []string) (bool, error) {
+	var notChecked bool
+	err := b.conn.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM resources r
+			JOIN job_inputs ji ON ji.resource_id = r.id
+			LEFT JOIN resource_config_scopes rs ON r.resource_config_scope_id = rs.id
+			WHERE ji.job_id = $1
+			AND r.name = ANY($3)
+			AND (rs.last_check_end_time IS NULL OR rs.last_check_end_time < $2)
+			AND NOT EXISTS (
+				SELECT
+				FROM resource_pins
+				WHERE resource_id = r.id
+			)
+		)`, b.jobID, b.createTime, pq.Array(resourceNames)).Scan(&notChecked)
+	if err != nil {
+		return false, err
+	}
+
+	return !notChecked, nil
+}
+
 func (b *build) Start(plan atc.Plan) (bool, error) {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -545,6 +774,10 @@ func (b *build) start(tx Tx, plan atc.Plan) (bool, error) {
 }
 
 func (b *build) Finish(status BuildStatus) error {
+	return b.FinishWithWebhookNotifications(status, nil, nil)
+}
+
+func (b *build) FinishWithWebhookNotifications(status BuildStatus, webhooks []string, payload json.RawMessage) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
 		return err
@@ -569,6 +802,20 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
+	if len(webhooks) != 0 {
+		insert := psql.Insert("webhook_notifications").
+			Columns("build_id", "webhook", "payload")
+
+		for _, webhook := range webhooks {
+			insert = insert.Values(b.id, webhook, string(payload))
+		}
+
+		_, err = insert.RunWith(tx).Exec()
+		if err != nil {
+			return err
+		}
+	}
+
 	err = b.saveEvent(tx, event.Status{
 		Status: atc.BuildStatus(status),
 		Time:   endTime.Unix(),
@@ -774,9 +1021,35 @@ func (b *build) Variables(logger lager.Logger, globalSecrets creds.Secrets, varS
 		return nil, errors.New("pipeline not found")
 	}
 
-	return pipeline.Variables(logger, globalSecrets, varSourcePool)
+	pipelineVars, err := pipeline.Variables(logger, globalSecrets, varSourcePool)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b.triggerVars.Vars) == 0 {
+		return pipelineVars, nil
+	}
+
+	if !b.triggerVars.AllowOverride {
+		for name := range b.triggerVars.Vars {
+			_, isInstanceVar := pipeline.InstanceVars()[name]
+
+			_, found, err := pipelineVars.Get(vars.Reference{Path: name})
+			if err != nil {
+				return nil, fmt.Errorf("look up trigger var '%s': %w", name, err)
+			}
+
+			if isInstanceVar || found {
+				return nil, TriggerVarConflictError{Name: name}
+			}
+		}
+	}
+
+	return vars.NewMultiVars([]vars.Variables{vars.StaticVariables(b.triggerVars.Vars), pipelineVars}), nil
 }
 
+func (b *build) TriggerVars() TriggerVars { return b.triggerVars }
+
 func (b *build) SetDrained(drained bool) error {
 	_, err := psql.Update("builds").
 		Set("drained", drained).
@@ -790,6 +1063,102 @@ func (b *build) SetDrained(drained bool) error {
 	return err
 }
 
+// SetProtected protects or unprotects the build. Protecting it records the
+// resource config scopes holding the versions it used as inputs or outputs,
+// so that they are kept around for as long as it is protected.
+func (b *build) SetProtected(protected bool) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Update("builds").
+		Set("protected", protected).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	if protected {
+		// a version is looked up by its md5 in every scope, as the scope it
+		// was saved in may no longer be the one of its resource
+		_, err = tx.Exec(`
+			INSERT INTO build_protected_resource_config_scopes (build_id, resource_config_scope_id)
+			SELECT DISTINCT $1::integer, v.resource_config_scope_id
+			FROM (
+				SELECT version_md5 FROM build_resource_config_version_inputs WHERE build_id = $1
+				UNION
+				SELECT version_md5 FROM build_resource_config_version_outputs WHERE build_id = $1
+			) bv
+			JOIN resource_config_versions v ON v.version_md5 = bv.version_md5
+			ON CONFLICT DO NOTHING
+		`, b.id)
+	} else {
+		_, err = psql.Delete("build_protected_resource_config_scopes").
+			Where(sq.Eq{"build_id": b.id}).
+			RunWith(tx).
+			Exec()
+	}
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	b.protected = protected
+
+	return nil
+}
+
+func (b *build) EventsArchiveKey() string { return b.eventsArchiveKey }
+
+// ArchiveEvents records where the build's events were archived and deletes
+// them from the database.
+func (b *build) ArchiveEvents(key string) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Update("builds").
+		Set("events_archive_key", key).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Delete(b.eventsTable()).
+		Where(sq.Or{
+			sq.Eq{"build_id": b.id},
+			sq.Eq{"build_id_old": b.id},
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	b.eventsArchiveKey = key
+
+	return nil
+}
+
 func (b *build) Delete() (bool, error) {
 	rows, err := psql.Delete("builds").
 		Where(sq.Eq{
@@ -819,7 +1188,9 @@ func (b *build) Delete() (bool, error) {
 // notification on abort channel.
 // Setting status as aborted will also make Start() return false in case where
 // build was aborted before it was started.
-func (b *build) MarkAsAborted() error {
+// An empty abortedBy means the build was aborted by the system rather than
+// by a user.
+func (b *build) MarkAsAborted(abortedBy string, reason string) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
 		return err
@@ -827,8 +1198,15 @@ func (b *build) MarkAsAborted() error {
 
 	defer Rollback(tx)
 
+	var abortedByValue sql.NullString
+	if abortedBy != "" {
+		abortedByValue = sql.NullString{String: abortedBy, Valid: true}
+	}
+
 	_, err = psql.Update("builds").
 		Set("aborted", true).
+		Set("aborted_by", abortedByValue).
+		Set("abort_reason", reason).
 		Where(sq.Eq{"id": b.id}).
 		RunWith(tx).
 		Exec()
@@ -868,6 +1246,370 @@ func (b *build) AbortNotifier() (Notifier, error) {
 	})
 }
 
+func (b *build) SupersedingVersion(resourceName string) (atc.Version, bool, error) {
+	inputCheckOrder := sq.Select("MAX(iv.check_order)").
+		From("build_resource_config_version_inputs i").
+		Join("resource_config_versions iv ON iv.version_md5 = i.version_md5").
+		Where(sq.Expr("i.resource_id = r.id")).
+		Where(sq.Expr("iv.resource_config_scope_id = r.resource_config_scope_id")).
+		Where(sq.Eq{"i.build_id": b.id})
+
+	inputCheckOrderSQL, inputCheckOrderArgs, err := inputCheckOrder.ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var versionJSON string
+	err = psql.Select("v.version").
+		From("resource_config_versions v").
+		Join("resources r ON r.resource_config_scope_id = v.resource_config_scope_id").
+		Where(sq.Eq{
+			"r.pipeline_id": b.pipelineID,
+			"r.name":        resourceName,
+		}).
+		Where(sq.Expr("v.check_order > ("+inputCheckOrderSQL+")", inputCheckOrderArgs...)).
+		// a pinned resource keeps using its pinned version, so newer versions
+		// do not supersede it
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM resource_pins p WHERE p.resource_id = r.id)")).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM resource_disabled_versions d WHERE d.resource_id = r.id AND d.version_md5 = v.version_md5)")).
+		OrderBy("v.check_order DESC").
+		Limit(1).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&versionJSON)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	var version atc.Version
+	err = json.Unmarshal([]byte(versionJSON), &version)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return version, true, nil
+}
+
+func (b *build) FailingStreakStart() (time.Time, bool, error) {
+	if b.jobID == 0 {
+		return time.Time{}, false, nil
+	}
+
+	lastSucceeded := sq.Select("COALESCE(MAX(id), 0)").
+		From("builds").
+		Where(sq.Eq{
+			"job_id": b.jobID,
+			"status": BuildStatusSucceeded,
+		}).
+		Where(sq.Lt{"id": b.id})
+
+	lastSucceededSQL, lastSucceededArgs, err := lastSucceeded.ToSql()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	var start pq.NullTime
+	err = psql.Select("MIN(end_time)").
+		From("builds").
+		Where(sq.Eq{
+			"job_id": b.jobID,
+			"status": []BuildStatus{BuildStatusFailed, BuildStatusErrored},
+		}).
+		Where(sq.Lt{"id": b.id}).
+		Where(sq.Expr("id > ("+lastSucceededSQL+")", lastSucceededArgs...)).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&start)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	if !start.Valid {
+		return time.Time{}, false, nil
+	}
+
+	return start.Time, true, nil
+}
+
+func (b *build) PreviousStatus() (BuildStatus, bool, error) {
+	if b.jobID == 0 {
+		return "", false, nil
+	}
+
+	var status BuildStatus
+	err := psql.Select("status").
+		From("builds").
+		Where(sq.Eq{
+			"job_id":    b.jobID,
+			"completed": true,
+		}).
+		Where(sq.Lt{"id": b.id}).
+		Where(sq.NotEq{"status": BuildStatusAborted}).
+		OrderBy("id DESC").
+		Limit(1).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+
+		return "", false, err
+	}
+
+	return status, true, nil
+}
+
+func (b *build) SupersededNotifier(resourceName string) (Notifier, error) {
+	var scopeID sql.NullInt64
+	err := psql.Select("resource_config_scope_id").
+		From("resources").
+		Where(sq.Eq{
+			"pipeline_id": b.pipelineID,
+			"name":        resourceName,
+			"active":      true,
+		}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&scopeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ResourceNotFoundInPipeline{resourceName, b.pipelineName}
+		}
+
+		return nil, err
+	}
+
+	return newConditionNotifier(b.conn.Bus(), resourceConfigScopeVersionsChannel(int(scopeID.Int64)), func() (bool, error) {
+		_, found, err := b.SupersedingVersion(resourceName)
+		return found, err
+	})
+}
+
+// BuildApproval is the state of an approval step of a build. It is persisted
+// so that a build waiting on an approval can pick up where it left off when
+// it is resumed by another ATC.
+type BuildApproval struct {
+	PlanID      atc.PlanID
+	Name        string
+	RequestedAt time.Time
+
+	Decided   bool
+	Approved  bool
+	DecidedBy string
+	DecidedAt time.Time
+	Comment   string
+}
+
+var buildApprovalsQuery = psql.Select(
+	"plan_id",
+	"name",
+	"requested_at",
+	"approved",
+	"decided_by",
+	"decided_at",
+	"comment",
+).From("build_approvals")
+
+// RequestApproval records that the approval step with the given plan ID is
+// waiting for a decision. If it was already requested, e.g. before the ATC
+// running the build restarted, the existing approval is returned as-is.
+func (b *build) RequestApproval(planID atc.PlanID, name string) (BuildApproval, error) {
+	_, err := psql.Insert("build_approvals").
+		Columns("build_id", "plan_id", "name").
+		Values(b.id, string(planID), name).
+		Suffix("ON CONFLICT (build_id, plan_id) DO NOTHING").
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return BuildApproval{}, err
+	}
+
+	row := buildApprovalsQuery.
+		Where(sq.Eq{
+			"build_id": b.id,
+			"plan_id":  string(planID),
+		}).
+		RunWith(b.conn).
+		QueryRow()
+
+	return scanBuildApproval(row)
+}
+
+// DecideApproval approves or rejects a requested approval. It returns false if
+// no approval is pending for the given plan ID, either because it was never
+// requested or because it has already been decided.
+//
+// An empty decidedBy means the decision was made by the system, e.g. because
+// the approval timed out.
+func (b *build) DecideApproval(planID atc.PlanID, approved bool, decidedBy string, comment string) (bool, error) {
+	var decidedByValue sql.NullString
+	if decidedBy != "" {
+		decidedByValue = sql.NullString{String: decidedBy, Valid: true}
+	}
+
+	result, err := psql.Update("build_approvals").
+		Set("approved", approved).
+		Set("decided_by", decidedByValue).
+		Set("decided_at", sq.Expr("now()")).
+		Set("comment", comment).
+		Where(sq.Eq{
+			"build_id":   b.id,
+			"plan_id":    string(planID),
+			"decided_at": nil,
+		}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	err = b.conn.Bus().Notify(buildApprovalChannel(b.id))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ApprovalNotifier notifies once the approval with the given plan ID has been
+// decided.
+func (b *build) ApprovalNotifier(planID atc.PlanID) (Notifier, error) {
+	return newConditionNotifier(b.conn.Bus(), buildApprovalChannel(b.id), func() (bool, error) {
+		var decided bool
+		err := psql.Select("decided_at IS NOT NULL").
+			From("build_approvals").
+			Where(sq.Eq{
+				"build_id": b.id,
+				"plan_id":  string(planID),
+			}).
+			RunWith(b.conn).
+			QueryRow().
+			Scan(&decided)
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return decided, err
+	})
+}
+
+func (b *build) Approvals() ([]BuildApproval, error) {
+	rows, err := buildApprovalsQuery.
+		Where(sq.Eq{"build_id": b.id}).
+		OrderBy("requested_at ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	approvals := []BuildApproval{}
+	for rows.Next() {
+		approval, err := scanBuildApproval(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		approvals = append(approvals, approval)
+	}
+
+	return approvals, nil
+}
+
+func scanBuildApproval(row scannable) (BuildApproval, error) {
+	var (
+		approval  BuildApproval
+		planID    string
+		approved  sql.NullBool
+		decidedBy sql.NullString
+		decidedAt pq.NullTime
+		comment   sql.NullString
+	)
+
+	err := row.Scan(&planID, &approval.Name, &approval.RequestedAt, &approved, &decidedBy, &decidedAt, &comment)
+	if err != nil {
+		return BuildApproval{}, err
+	}
+
+	approval.PlanID = atc.PlanID(planID)
+	approval.Decided = decidedAt.Valid
+	approval.Approved = approved.Bool
+	approval.DecidedBy = decidedBy.String
+	approval.DecidedAt = decidedAt.Time
+	approval.Comment = comment.String
+
+	return approval, nil
+}
+
+// TaskExitCode is the exit code a task step of a build exited with.
+type TaskExitCode struct {
+	PlanID   atc.PlanID
+	Name     string
+	ExitCode int
+}
+
+// SaveTaskExitCode records the exit code of the task step with the given plan
+// ID. A task which is run again, e.g. by an attempts step, replaces the exit
+// code its earlier attempt recorded.
+func (b *build) SaveTaskExitCode(planID atc.PlanID, name string, exitCode int) error {
+	_, err := psql.Insert("build_task_exit_codes").
+		Columns("build_id", "plan_id", "name", "exit_code").
+		Values(b.id, string(planID), name, exitCode).
+		Suffix("ON CONFLICT (build_id, plan_id) DO UPDATE SET name = EXCLUDED.name, exit_code = EXCLUDED.exit_code").
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+func (b *build) TaskExitCodes() ([]TaskExitCode, error) {
+	rows, err := psql.Select("plan_id", "name", "exit_code").
+		From("build_task_exit_codes").
+		Where(sq.Eq{"build_id": b.id}).
+		OrderBy("plan_id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	exitCodes := []TaskExitCode{}
+	for rows.Next() {
+		var (
+			exitCode TaskExitCode
+			planID   string
+		)
+
+		err := rows.Scan(&planID, &exitCode.Name, &exitCode.ExitCode)
+		if err != nil {
+			return nil, err
+		}
+
+		exitCode.PlanID = atc.PlanID(planID)
+		exitCodes = append(exitCodes, exitCode)
+	}
+
+	return exitCodes, nil
+}
+
 func (b *build) SaveImageResourceVersion(rc UsedResourceCache) error {
 	var jobID sql.NullInt64
 	if b.jobID != 0 {
@@ -1105,6 +1847,45 @@ func (b *build) SaveEvent(event atc.Event) error {
 	return b.conn.Bus().Notify(buildEventsChannel(b.id))
 }
 
+// SaveEvents saves the events in order in a single transaction, notifying
+// the build's event subscribers once.
+func (b *build) SaveEvents(events []atc.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	insert := psql.Insert(b.eventsTable()).
+		Columns("event_id", "build_id", "type", "version", "payload")
+
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		insert = insert.Values(sq.Expr("nextval('"+buildEventSeq(b.id)+"')"), b.id, string(event.EventType()), string(event.Version()), payload)
+	}
+
+	_, err = insert.RunWith(tx).Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return b.conn.Bus().Notify(buildEventsChannel(b.id))
+}
+
 func (b *build) Artifact(artifactID int) (WorkerArtifact, error) {
 
 	artifact := artifact{
@@ -1211,7 +1992,25 @@ func (b *build) SaveOutput(
 		return err
 	}
 
-	newVersion, err := saveResourceVersion(tx, resourceConfigScope.ID(), version, metadata, nil)
+	// a put's version is deduplicated like a checked one, so that the output
+	// is recorded as the saved version it's the same as
+	var newVersion bool
+	identityFields := resourceTypeIdentityFields(resourceTypes, resourceType)
+	if len(identityFields) > 0 {
+		err = hashVersionIdentities(tx, resourceConfigScope.ID(), identityFields)
+		if err != nil {
+			return err
+		}
+
+		version, newVersion, err = saveIdentifiedResourceVersion(tx, resourceConfigScope.ID(), version, metadata, identityFields, nil)
+	} else {
+		err = forgetVersionIdentities(tx, resourceConfigScope.ID())
+		if err != nil {
+			return err
+		}
+
+		newVersion, err = saveResourceVersion(tx, resourceConfigScope.ID(), version, metadata, nil)
+	}
 	if err != nil {
 		return err
 	}
@@ -1236,8 +2035,8 @@ func (b *build) SaveOutput(
 	}
 
 	_, err = psql.Insert("build_resource_config_version_outputs").
-		Columns("resource_id", "build_id", "version_md5", "name").
-		Values(theResource.ID(), strconv.Itoa(b.id), sq.Expr("md5(?)", versionJSON), outputName).
+		Columns("resource_id", "build_id", "version_md5", "name", "resource_config_scope_id").
+		Values(theResource.ID(), strconv.Itoa(b.id), sq.Expr("md5(?)", versionJSON), outputName, resourceConfigScope.ID()).
 		Suffix("ON CONFLICT DO NOTHING").
 		RunWith(tx).
 		Exec()
@@ -1257,6 +2056,10 @@ func (b *build) SaveOutput(
 		return err
 	}
 
+	if newVersion {
+		return b.conn.Bus().Notify(resourceConfigScopeVersionsChannel(resourceConfigScope.ID()))
+	}
+
 	return nil
 }
 
@@ -1294,12 +2097,14 @@ func (b *build) AdoptInputsAndPipes() ([]BuildInput, bool, error) {
 	}
 
 	rows, err := psql.Insert("build_resource_config_version_inputs").
-		Columns("resource_id", "version_md5", "name", "first_occurrence", "build_id").
+		Columns("resource_id", "version_md5", "name", "first_occurrence", "build_id", "resource_config_scope_id").
 		Select(psql.Select("i.resource_id", "i.version_md5", "i.input_name", "i.first_occurrence").
 			Column("?", b.id).
+			Column("r.resource_config_scope_id").
 			From("next_build_inputs i").
+			Join("resources r ON r.id = i.resource_id").
 			Where(sq.Eq{"i.job_id": b.jobID})).
-		Suffix("ON CONFLICT (build_id, resource_id, version_md5, name) DO UPDATE SET first_occurrence = EXCLUDED.first_occurrence").
+		Suffix("ON CONFLICT (build_id, resource_id, version_md5, name) DO UPDATE SET first_occurrence = EXCLUDED.first_occurrence, resource_config_scope_id = EXCLUDED.resource_config_scope_id").
 		Suffix("RETURNING name, resource_id, version_md5, first_occurrence").
 		RunWith(tx).
 		Query()
@@ -1335,9 +2140,12 @@ func (b *build) AdoptInputsAndPipes() ([]BuildInput, bool, error) {
 	buildInputs := []BuildInput{}
 
 	for inputName, input := range inputs {
-		var versionBlob string
+		var (
+			versionBlob  string
+			metadataBlob sql.NullString
+		)
 
-		err = psql.Select("v.version").
+		err = psql.Select("v.version", "v.metadata").
 			From("resource_config_versions v").
 			Join("resources r ON r.resource_config_scope_id = v.resource_config_scope_id").
 			Where(sq.Eq{
@@ -1346,7 +2154,7 @@ func (b *build) AdoptInputsAndPipes() ([]BuildInput, bool, error) {
 			}).
 			RunWith(tx).
 			QueryRow().
-			Scan(&versionBlob)
+			Scan(&versionBlob, &metadataBlob)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				tx.Rollback()
@@ -1370,10 +2178,16 @@ func (b *build) AdoptInputsAndPipes() ([]BuildInput, bool, error) {
 			return nil, false, err
 		}
 
+		metadata, err := scanResourceVersionMetadata(metadataBlob)
+		if err != nil {
+			return nil, false, err
+		}
+
 		buildInputs = append(buildInputs, BuildInput{
 			Name:            inputName,
 			ResourceID:      input.Input.ResourceID,
 			Version:         version,
+			Metadata:        metadata,
 			FirstOccurrence: input.Input.FirstOccurrence,
 		})
 	}
@@ -1443,6 +2257,19 @@ func (b *build) AdoptRerunInputsAndPipes() ([]BuildInput, bool, error) {
 		return nil, false, nil
 	}
 
+	var latestIfUnavailable bool
+	err = psql.Select("rerun_latest_if_unavailable").
+		From("builds").
+		Where(sq.Eq{
+			"id": b.id,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&latestIfUnavailable)
+	if err != nil {
+		return nil, false, err
+	}
+
 	_, err = psql.Delete("build_resource_config_version_inputs").
 		Where(sq.Eq{"build_id": b.id}).
 		RunWith(tx).
@@ -1452,9 +2279,10 @@ func (b *build) AdoptRerunInputsAndPipes() ([]BuildInput, bool, error) {
 	}
 
 	rows, err := psql.Insert("build_resource_config_version_inputs").
-		Columns("resource_id", "version_md5", "name", "first_occurrence", "build_id").
+		Columns("resource_id", "version_md5", "name", "first_occurrence", "build_id", "resource_config_scope_id").
 		Select(psql.Select("i.resource_id", "i.version_md5", "i.name", "false").
 			Column("?", b.id).
+			Column("i.resource_config_scope_id").
 			From("build_resource_config_version_inputs i").
 			Where(sq.Eq{"i.build_id": b.rerunOf})).
 		Suffix("ON CONFLICT (build_id, resource_id, version_md5, name) DO NOTHING").
@@ -1492,9 +2320,12 @@ func (b *build) AdoptRerunInputsAndPipes() ([]BuildInput, bool, error) {
 
 	buildInputs := []BuildInput{}
 	for inputName, input := range inputs {
-		var versionBlob string
+		var (
+			versionBlob  string
+			metadataBlob sql.NullString
+		)
 
-		err = psql.Select("v.version").
+		err = psql.Select("v.version", "v.metadata").
 			From("resource_config_versions v").
 			Join("resources r ON r.resource_config_scope_id = v.resource_config_scope_id").
 			Where(sq.Eq{
@@ -1503,7 +2334,10 @@ func (b *build) AdoptRerunInputsAndPipes() ([]BuildInput, bool, error) {
 			}).
 			RunWith(tx).
 			QueryRow().
-			Scan(&versionBlob)
+			Scan(&versionBlob, &metadataBlob)
+		if err == sql.ErrNoRows && latestIfUnavailable {
+			input.Input.AlgorithmVersion, versionBlob, metadataBlob, err = b.adoptLatestRerunInput(tx, inputName)
+		}
 		if err != nil {
 			if err == sql.ErrNoRows {
 				tx.Rollback()
@@ -1520,7 +2354,7 @@ func (b *build) AdoptRerunInputsAndPipes() ([]BuildInput, bool, error) {
 					return nil, false, err
 				}
 
-				err = b.MarkAsAborted()
+				err = b.MarkAsAborted("", fmt.Sprintf("chosen version of input %s not available", inputName))
 				if err != nil {
 					return nil, false, err
 				}
@@ -1535,10 +2369,16 @@ func (b *build) AdoptRerunInputsAndPipes() ([]BuildInput, bool, error) {
 			return nil, false, err
 		}
 
+		metadata, err := scanResourceVersionMetadata(metadataBlob)
+		if err != nil {
+			return nil, false, err
+		}
+
 		buildInputs = append(buildInputs, BuildInput{
 			Name:            inputName,
 			ResourceID:      input.Input.ResourceID,
 			Version:         version,
+			Metadata:        metadata,
 			FirstOccurrence: input.Input.FirstOccurrence,
 		})
 	}
@@ -1583,6 +2423,85 @@ func (b *build) AdoptRerunInputsAndPipes() ([]BuildInput, bool, error) {
 	return buildInputs, true, nil
 }
 
+// adoptLatestRerunInput replaces the version of an input of the rerun which is
+// no longer available with the job's latest version of the input.
+func (b *build) adoptLatestRerunInput(tx Tx, inputName string) (AlgorithmVersion, string, sql.NullString, error) {
+	var (
+		resourceID   int
+		versionMD5   string
+		versionBlob  string
+		metadataBlob sql.NullString
+	)
+
+	err := psql.Select("i.resource_id", "i.version_md5", "v.version", "v.metadata").
+		From("next_build_inputs i").
+		Join("resources r ON r.id = i.resource_id").
+		Join("resource_config_versions v ON v.version_md5 = i.version_md5 AND v.resource_config_scope_id = r.resource_config_scope_id").
+		Where(sq.Eq{
+			"i.job_id":     b.jobID,
+			"i.input_name": inputName,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&resourceID, &versionMD5, &versionBlob, &metadataBlob)
+	if err != nil {
+		return AlgorithmVersion{}, "", sql.NullString{}, err
+	}
+
+	_, err = psql.Update("build_resource_config_version_inputs").
+		Set("resource_id", resourceID).
+		Set("version_md5", versionMD5).
+		Where(sq.Eq{
+			"build_id": b.id,
+			"name":     inputName,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return AlgorithmVersion{}, "", sql.NullString{}, err
+	}
+
+	return AlgorithmVersion{
+		ResourceID: resourceID,
+		Version:    ResourceVersion(versionMD5),
+	}, versionBlob, metadataBlob, nil
+}
+
+// UnavailableInputs returns the names of the build's inputs whose versions
+// are no longer available, e.g. as the resource's source has changed since,
+// and so could not be used by a rerun of the build.
+func (b *build) UnavailableInputs() ([]string, error) {
+	rows, err := psql.Select("DISTINCT i.name").
+		From("build_resource_config_version_inputs i").
+		LeftJoin("resources r ON r.id = i.resource_id").
+		LeftJoin("resource_config_versions v ON v.version_md5 = i.version_md5 AND v.resource_config_scope_id = r.resource_config_scope_id").
+		Where(sq.Eq{
+			"i.build_id": b.id,
+			"v.id":       nil,
+		}).
+		OrderBy("i.name").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var names []string
+	for rows.Next() {
+		var name string
+		err := rows.Scan(&name)
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
 func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 	inputs := []BuildInput{}
 	outputs := []BuildOutput{}
@@ -1594,27 +2513,24 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 
 	defer Rollback(tx)
 
-	rows, err := psql.Select("inputs.name", "resources.id", "versions.version", `COALESCE(inputs.first_occurrence, NOT EXISTS (
+	rows, err := psql.Select("inputs.name", "resources.id", "inputs.version_md5", "versions.version", "versions.metadata", `COALESCE(inputs.first_occurrence, NOT EXISTS (
 			SELECT 1
 			FROM build_resource_config_version_inputs i, builds b
-			WHERE versions.version_md5 = i.version_md5
-			AND resources.resource_config_scope_id = versions.resource_config_scope_id
+			WHERE inputs.version_md5 = i.version_md5
 			AND resources.id = i.resource_id
 			AND b.job_id = builds.job_id
 			AND i.build_id = b.id
 			AND i.build_id < builds.id
 		))`).
-		From("resource_config_versions versions, build_resource_config_version_inputs inputs, builds, resources").
+		From("build_resource_config_version_inputs inputs").
+		Join("builds ON builds.id = inputs.build_id").
+		Join("resources ON resources.id = inputs.resource_id").
+		LeftJoin("resource_config_versions versions ON versions.version_md5 = inputs.version_md5 AND versions.resource_config_scope_id = COALESCE(inputs.resource_config_scope_id, resources.resource_config_scope_id)").
 		Where(sq.Eq{"builds.id": b.id}).
-		Where(sq.Expr("inputs.build_id = builds.id")).
-		Where(sq.Expr("inputs.version_md5 = versions.version_md5")).
-		Where(sq.Expr("resources.resource_config_scope_id = versions.resource_config_scope_id")).
-		Where(sq.Expr("resources.id = inputs.resource_id")).
 		Where(sq.Expr(`NOT EXISTS (
 			SELECT 1
 			FROM build_resource_config_version_outputs outputs
-			WHERE outputs.version_md5 = versions.version_md5
-			AND versions.resource_config_scope_id = resources.resource_config_scope_id
+			WHERE outputs.version_md5 = inputs.version_md5
 			AND outputs.resource_id = resources.id
 			AND outputs.build_id = inputs.build_id
 		)`)).
@@ -1628,38 +2544,43 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 
 	for rows.Next() {
 		var (
-			inputName   string
-			firstOcc    bool
-			versionBlob string
-			version     atc.Version
-			resourceID  int
+			inputName    string
+			firstOcc     bool
+			versionMD5   string
+			versionBlob  sql.NullString
+			metadataBlob sql.NullString
+			resourceID   int
 		)
 
-		err = rows.Scan(&inputName, &resourceID, &versionBlob, &firstOcc)
+		err = rows.Scan(&inputName, &resourceID, &versionMD5, &versionBlob, &metadataBlob, &firstOcc)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		err = json.Unmarshal([]byte(versionBlob), &version)
+		input := BuildInput{
+			Name:            inputName,
+			ResourceID:      resourceID,
+			FirstOccurrence: firstOcc,
+		}
+
+		input.Version, input.Metadata, input.VersionMissing, err = scanBuildResourceVersion(versionBlob, metadataBlob)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		inputs = append(inputs, BuildInput{
-			Name:            inputName,
-			Version:         version,
-			ResourceID:      resourceID,
-			FirstOccurrence: firstOcc,
-		})
+		if input.VersionMissing {
+			input.VersionMD5 = versionMD5
+		}
+
+		inputs = append(inputs, input)
 	}
 
-	rows, err = psql.Select("outputs.name", "versions.version").
-		From("resource_config_versions versions, build_resource_config_version_outputs outputs, builds, resources").
+	rows, err = psql.Select("outputs.name", "outputs.version_md5", "versions.version", "versions.metadata").
+		From("build_resource_config_version_outputs outputs").
+		Join("builds ON builds.id = outputs.build_id").
+		Join("resources ON resources.id = outputs.resource_id").
+		LeftJoin("resource_config_versions versions ON versions.version_md5 = outputs.version_md5 AND versions.resource_config_scope_id = COALESCE(outputs.resource_config_scope_id, resources.resource_config_scope_id)").
 		Where(sq.Eq{"builds.id": b.id}).
-		Where(sq.Expr("outputs.build_id = builds.id")).
-		Where(sq.Expr("outputs.version_md5 = versions.version_md5")).
-		Where(sq.Expr("outputs.resource_id = resources.id")).
-		Where(sq.Expr("resources.resource_config_scope_id = versions.resource_config_scope_id")).
 		RunWith(tx).
 		Query()
 
@@ -1671,25 +2592,31 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 
 	for rows.Next() {
 		var (
-			outputName  string
-			versionBlob string
-			version     atc.Version
+			outputName   string
+			versionMD5   string
+			versionBlob  sql.NullString
+			metadataBlob sql.NullString
 		)
 
-		err := rows.Scan(&outputName, &versionBlob)
+		err := rows.Scan(&outputName, &versionMD5, &versionBlob, &metadataBlob)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		err = json.Unmarshal([]byte(versionBlob), &version)
+		output := BuildOutput{
+			Name: outputName,
+		}
+
+		output.Version, output.Metadata, output.VersionMissing, err = scanBuildResourceVersion(versionBlob, metadataBlob)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		outputs = append(outputs, BuildOutput{
-			Name:    outputName,
-			Version: version,
-		})
+		if output.VersionMissing {
+			output.VersionMD5 = versionMD5
+		}
+
+		outputs = append(outputs, output)
 	}
 
 	err = tx.Commit()
@@ -1700,6 +2627,40 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 	return inputs, outputs, nil
 }
 
+// scanBuildResourceVersion decodes the version and metadata of a build input
+// or output. The version row is missing once it has been garbage collected,
+// in which case only the missing flag is returned.
+func scanBuildResourceVersion(versionBlob, metadataBlob sql.NullString) (atc.Version, ResourceConfigMetadataFields, bool, error) {
+	if !versionBlob.Valid {
+		return atc.Version{}, nil, true, nil
+	}
+
+	var version atc.Version
+	err := json.Unmarshal([]byte(versionBlob.String), &version)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	metadata, err := scanResourceVersionMetadata(metadataBlob)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	return version, metadata, false, nil
+}
+
+func scanResourceVersionMetadata(metadataBlob sql.NullString) (ResourceConfigMetadataFields, error) {
+	var metadata ResourceConfigMetadataFields
+	if metadataBlob.Valid {
+		err := json.Unmarshal([]byte(metadataBlob.String), &metadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return metadata, nil
+}
+
 func (b *build) SpanContext() propagation.TextMapCarrier {
 	return b.spanContext
 }
@@ -1768,10 +2729,10 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		jobID, resourceID, resourceTypeID, pipelineID, rerunOf, rerunNumber                                 sql.NullInt64
 		schema, privatePlan, jobName, resourceName, resourceTypeName, pipelineName, publicPlan, rerunOfName sql.NullString
 		createTime, startTime, endTime, reapTime                                                            pq.NullTime
-		nonce, spanContext, createdBy                                                                       sql.NullString
+		nonce, spanContext, createdBy, abortedBy, abortReason, eventsArchiveKey                             sql.NullString
 		drained, aborted, completed                                                                         bool
 		status                                                                                              string
-		pipelineInstanceVars                                                                                sql.NullString
+		pipelineInstanceVars, triggerVars, triggerVarsNonce                                                 sql.NullString
 	)
 
 	err := row.Scan(
@@ -1808,6 +2769,12 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		&rerunOfName,
 		&rerunNumber,
 		&spanContext,
+		&abortedBy,
+		&abortReason,
+		&eventsArchiveKey,
+		&triggerVars,
+		&triggerVarsNonce,
+		&b.protected,
 	)
 	if err != nil {
 		return err
@@ -1828,6 +2795,7 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.endTime = endTime.Time
 	b.reapTime = reapTime.Time
 	b.drained = drained
+	b.eventsArchiveKey = eventsArchiveKey.String
 	b.aborted = aborted
 	b.completed = completed
 	b.rerunOf = int(rerunOf.Int64)
@@ -1877,10 +2845,33 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		}
 	}
 
+	if triggerVars.Valid {
+		var decryptedTriggerVars []byte
+		if triggerVarsNonce.Valid {
+			decryptedTriggerVars, err = encryptionStrategy.Decrypt(triggerVars.String, &triggerVarsNonce.String)
+			if err != nil {
+				return err
+			}
+		} else {
+			decryptedTriggerVars = []byte(triggerVars.String)
+		}
+
+		err = json.Unmarshal(decryptedTriggerVars, &b.triggerVars)
+		if err != nil {
+			return err
+		}
+	}
+
 	if createdBy.Valid {
 		b.createdBy = &createdBy.String
 	}
 
+	if abortedBy.Valid {
+		b.abortedBy = &abortedBy.String
+	}
+
+	b.abortReason = abortReason.String
+
 	return nil
 }
 
@@ -2017,6 +3008,10 @@ func buildAbortChannel(buildID int) string {
 	return fmt.Sprintf("build_abort_%d", buildID)
 }
 
+func buildApprovalChannel(buildID int) string {
+	return fmt.Sprintf("build_approval_%d", buildID)
+}
+
 func latestCompletedNonRerunBuild(tx Tx, jobID int) (int, error) {
 	var latestNonRerunId int
 	err := latestCompletedBuildQuery.
@@ -18,6 +18,10 @@ type BuildFactory interface {
 	PublicBuilds(Page) ([]Build, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
 	GetDrainableBuilds() ([]Build, error)
+	GetArchivableBuilds(afterID int, limit int) ([]Build, error)
+	DeletedEventsArchiveKeys(limit int) ([]string, error)
+	ForgetDeletedEventsArchiveKeys(keys []string) error
+	ClaimSpanExportableBuilds(limit int) ([]Build, error)
 	// TODO: move to BuildLifecycle, new interface (see WorkerLifecycle)
 	MarkNonInterceptibleBuilds() error
 }
@@ -124,6 +128,140 @@ func (f *buildFactory) GetDrainableBuilds() ([]Build, error) {
 	return getBuilds(query, f.conn, f.lockFactory)
 }
 
+// GetArchivableBuilds returns up to limit of the completed builds whose events
+// are still in the database and whose ID is greater than afterID, oldest
+// first, so that they can be paged through. Check builds and reaped builds are
+// left alone.
+func (f *buildFactory) GetArchivableBuilds(afterID int, limit int) ([]Build, error) {
+	query := buildsQuery.Where(sq.Eq{
+		"b.completed":          true,
+		"b.events_archive_key": nil,
+		"b.reap_time":          nil,
+		"b.resource_id":        nil,
+		"b.resource_type_id":   nil,
+	}).
+		Where(sq.Gt{"b.id": afterID}).
+		OrderBy("b.id ASC").
+		Limit(uint64(limit))
+
+	return getBuilds(query, f.conn, f.lockFactory)
+}
+
+// DeletedEventsArchiveKeys returns up to limit of the keys of archived events
+// whose build has since been deleted or reaped, so that they can be removed
+// from the archive.
+func (f *buildFactory) DeletedEventsArchiveKeys(limit int) ([]string, error) {
+	rows, err := psql.Select("key").
+		From("deleted_events_archives").
+		OrderBy("deleted_at ASC").
+		Limit(uint64(limit)).
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		err = rows.Scan(&key)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// ForgetDeletedEventsArchiveKeys stops tracking the given keys once their
+// archived events have been removed.
+func (f *buildFactory) ForgetDeletedEventsArchiveKeys(keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	_, err := psql.Delete("deleted_events_archives").
+		Where(sq.Eq{"key": keys}).
+		RunWith(f.conn).
+		Exec()
+
+	return err
+}
+
+// ClaimSpanExportableBuilds claims up to limit completed builds whose spans
+// have not been exported yet, oldest first, by marking them as exported, so
+// that no other ATC exports them too. Builds claimed by a concurrent call are
+// skipped rather than waited on. Check builds and reaped builds, which no
+// longer have their events, are left alone.
+func (f *buildFactory) ClaimSpanExportableBuilds(limit int) ([]Build, error) {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := psql.Select("id").
+		From("builds").
+		Where(sq.Eq{
+			"completed":        true,
+			"spans_exported":   false,
+			"reap_time":        nil,
+			"resource_id":      nil,
+			"resource_type_id": nil,
+		}).
+		OrderBy("id ASC").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			Close(rows)
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	Close(rows)
+
+	if len(ids) == 0 {
+		return []Build{}, nil
+	}
+
+	_, err = psql.Update("builds").
+		Set("spans_exported", true).
+		Where(sq.Eq{"id": ids}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	query := buildsQuery.
+		Where(sq.Eq{"b.id": ids}).
+		OrderBy("b.id ASC")
+
+	return getBuilds(query, f.conn, f.lockFactory)
+}
+
 func (f *buildFactory) GetAllStartedBuilds() ([]Build, error) {
 	query := buildsQuery.Where(sq.Eq{
 		"b.status": BuildStatusStarted,
@@ -444,6 +444,181 @@ var _ = Describe("BuildFactory", func() {
 		})
 	})
 
+	Describe("GetArchivableBuilds", func() {
+		var archivedBuild, completedBuild db.Build
+
+		BeforeEach(func() {
+			_, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			archivedBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			completedBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = archivedBuild.Finish("succeeded")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = archivedBuild.ArchiveEvents("some-key")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = completedBuild.Finish("failed")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the completed builds whose events have not been archived", func() {
+			builds, err := buildFactory.GetArchivableBuilds(0, 10)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = completedBuild.Reload()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(builds).To(ConsistOf(completedBuild))
+		})
+
+		It("pages through the builds by ID", func() {
+			otherBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = otherBuild.Finish("succeeded")
+			Expect(err).NotTo(HaveOccurred())
+
+			builds, err := buildFactory.GetArchivableBuilds(0, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(completedBuild.ID()))
+
+			builds, err = buildFactory.GetArchivableBuilds(completedBuild.ID(), 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(otherBuild.ID()))
+		})
+	})
+
+	Describe("DeletedEventsArchiveKeys", func() {
+		var archivedBuild db.Build
+
+		BeforeEach(func() {
+			var err error
+			archivedBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = archivedBuild.Finish("succeeded")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = archivedBuild.ArchiveEvents("some-key")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does not return the keys of builds which still exist", func() {
+			keys, err := buildFactory.DeletedEventsArchiveKeys(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(BeEmpty())
+		})
+
+		Context("when the build is deleted", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`DELETE FROM builds WHERE id = $1`, archivedBuild.ID())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns its key until it is forgotten", func() {
+				keys, err := buildFactory.DeletedEventsArchiveKeys(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys).To(ConsistOf("some-key"))
+
+				err = buildFactory.ForgetDeletedEventsArchiveKeys(keys)
+				Expect(err).NotTo(HaveOccurred())
+
+				keys, err = buildFactory.DeletedEventsArchiveKeys(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys).To(BeEmpty())
+			})
+		})
+
+		Context("when the build's archive key is cleared", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`UPDATE builds SET events_archive_key = NULL WHERE id = $1`, archivedBuild.ID())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns its key", func() {
+				keys, err := buildFactory.DeletedEventsArchiveKeys(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys).To(ConsistOf("some-key"))
+			})
+		})
+	})
+
+	Describe("ClaimSpanExportableBuilds", func() {
+		var exportedBuild, oldestBuild, newestBuild db.Build
+
+		BeforeEach(func() {
+			_, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			exportedBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			oldestBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			newestBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, build := range []db.Build{exportedBuild, oldestBuild, newestBuild} {
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			claimed, err := buildFactory.ClaimSpanExportableBuilds(1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(claimed).To(HaveLen(1))
+			Expect(claimed[0].ID()).To(Equal(exportedBuild.ID()))
+		})
+
+		It("claims the completed builds whose spans have not been exported, oldest first", func() {
+			builds, err := buildFactory.ClaimSpanExportableBuilds(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(2))
+			Expect(builds[0].ID()).To(Equal(oldestBuild.ID()))
+			Expect(builds[1].ID()).To(Equal(newestBuild.ID()))
+		})
+
+		It("claims no more than the limit", func() {
+			builds, err := buildFactory.ClaimSpanExportableBuilds(1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(oldestBuild.ID()))
+		})
+
+		It("does not claim builds which were already claimed", func() {
+			_, err := buildFactory.ClaimSpanExportableBuilds(10)
+			Expect(err).NotTo(HaveOccurred())
+
+			builds, err := buildFactory.ClaimSpanExportableBuilds(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+
+		Context("when another ATC is claiming builds", func() {
+			It("skips the builds it has locked", func() {
+				tx, err := dbConn.Begin()
+				Expect(err).NotTo(HaveOccurred())
+				defer db.Rollback(tx)
+
+				_, err = tx.Exec(`SELECT id FROM builds WHERE id = $1 FOR UPDATE`, oldestBuild.ID())
+				Expect(err).NotTo(HaveOccurred())
+
+				builds, err := buildFactory.ClaimSpanExportableBuilds(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID()).To(Equal(newestBuild.ID()))
+			})
+		})
+	})
+
 	Describe("GetAllStartedBuilds", func() {
 		var build1DB db.Build
 		var build2DB db.Build
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/lager"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+// BuildServerLog is a log line of an ATC pertaining to a build.
+type BuildServerLog struct {
+	BuildID int
+	Log     lager.LogFormat
+}
+
+// BuildServerLogFactory saves the log lines pertaining to builds logged by each
+// ATC, so that they can be looked up by build whichever ATC serves the lookup.
+// The lines of a build are deleted along with it.
+//
+//counterfeiter:generate . BuildServerLogFactory
+type BuildServerLogFactory interface {
+	// SaveLogs saves the lines in one go, keeping only the most recent limit
+	// lines of each of their builds. Lines of builds which have since been
+	// deleted are dropped.
+	SaveLogs(logs []BuildServerLog, limit int) error
+
+	// LogsForBuild returns the saved lines of the build, oldest first.
+	LogsForBuild(buildID int) ([]lager.LogFormat, error)
+}
+
+type buildServerLogFactory struct {
+	conn Conn
+}
+
+func NewBuildServerLogFactory(conn Conn) BuildServerLogFactory {
+	return &buildServerLogFactory{
+		conn: conn,
+	}
+}
+
+func (f *buildServerLogFactory) SaveLogs(logs []BuildServerLog, limit int) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	buildIDs := make([]int64, len(logs))
+	payloads := make([]string, len(logs))
+	for i, log := range logs {
+		payload, err := json.Marshal(log.Log)
+		if err != nil {
+			return err
+		}
+
+		buildIDs[i] = int64(log.BuildID)
+		payloads[i] = string(payload)
+	}
+
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = tx.Exec(`
+		INSERT INTO build_server_logs (build_id, log)
+		SELECT b.id, l.log::jsonb
+		FROM unnest($1::integer[], $2::text[]) WITH ORDINALITY AS l (build_id, log, n)
+		JOIN builds b ON b.id = l.build_id
+		ORDER BY l.n
+	`, pq.Array(buildIDs), pq.Array(payloads))
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM build_server_logs
+		WHERE id IN (
+			SELECT id FROM (
+				SELECT id, row_number() OVER (PARTITION BY build_id ORDER BY id DESC) AS n
+				FROM build_server_logs
+				WHERE build_id = ANY($1::integer[])
+			) l
+			WHERE l.n > $2
+		)
+	`, pq.Array(buildIDs), limit)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (f *buildServerLogFactory) LogsForBuild(buildID int) ([]lager.LogFormat, error) {
+	rows, err := psql.Select("log").
+		From("build_server_logs").
+		Where(sq.Eq{"build_id": buildID}).
+		OrderBy("id ASC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	logs := []lager.LogFormat{}
+	for rows.Next() {
+		var payload string
+		err := rows.Scan(&payload)
+		if err != nil {
+			return nil, err
+		}
+
+		var log lager.LogFormat
+		err = json.Unmarshal([]byte(payload), &log)
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, log)
+	}
+
+	return logs, rows.Err()
+}
@@ -0,0 +1,117 @@
+package db_test
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildServerLogFactory", func() {
+	var (
+		logFactory db.BuildServerLogFactory
+
+		build      db.Build
+		otherBuild db.Build
+	)
+
+	BeforeEach(func() {
+		logFactory = db.NewBuildServerLogFactory(dbConn)
+
+		var err error
+		build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+
+		otherBuild, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	logLine := func(buildID int, message string) db.BuildServerLog {
+		return db.BuildServerLog{
+			BuildID: buildID,
+			Log: lager.LogFormat{
+				Timestamp: "2021-01-01T00:00:00.000000000Z",
+				Source:    "atc",
+				Message:   message,
+				LogLevel:  lager.INFO,
+				Data:      lager.Data{"build_id": float64(buildID)},
+			},
+		}
+	}
+
+	It("returns the saved lines of the build, oldest first", func() {
+		err := logFactory.SaveLogs([]db.BuildServerLog{
+			logLine(build.ID(), "atc.first"),
+			logLine(otherBuild.ID(), "atc.other-build"),
+		}, 10)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = logFactory.SaveLogs([]db.BuildServerLog{
+			logLine(build.ID(), "atc.second"),
+		}, 10)
+		Expect(err).ToNot(HaveOccurred())
+
+		logs, err := logFactory.LogsForBuild(build.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(logs).To(Equal([]lager.LogFormat{
+			logLine(build.ID(), "atc.first").Log,
+			logLine(build.ID(), "atc.second").Log,
+		}))
+	})
+
+	It("keeps only the most recent lines of each build", func() {
+		err := logFactory.SaveLogs([]db.BuildServerLog{
+			logLine(build.ID(), "atc.first"),
+			logLine(build.ID(), "atc.second"),
+			logLine(otherBuild.ID(), "atc.other-build"),
+		}, 2)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = logFactory.SaveLogs([]db.BuildServerLog{
+			logLine(build.ID(), "atc.third"),
+		}, 2)
+		Expect(err).ToNot(HaveOccurred())
+
+		logs, err := logFactory.LogsForBuild(build.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(logs).To(Equal([]lager.LogFormat{
+			logLine(build.ID(), "atc.second").Log,
+			logLine(build.ID(), "atc.third").Log,
+		}))
+
+		logs, err = logFactory.LogsForBuild(otherBuild.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(logs).To(HaveLen(1))
+	})
+
+	It("drops the lines of builds which don't exist", func() {
+		err := logFactory.SaveLogs([]db.BuildServerLog{
+			logLine(build.ID(), "atc.some-message"),
+			logLine(otherBuild.ID()+1000, "atc.deleted-build"),
+		}, 10)
+		Expect(err).ToNot(HaveOccurred())
+
+		logs, err := logFactory.LogsForBuild(otherBuild.ID() + 1000)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(logs).To(BeEmpty())
+
+		logs, err = logFactory.LogsForBuild(build.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(logs).To(HaveLen(1))
+	})
+
+	It("deletes the lines of deleted builds", func() {
+		err := logFactory.SaveLogs([]db.BuildServerLog{
+			logLine(build.ID(), "atc.some-message"),
+		}, 10)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = dbConn.Exec("DELETE FROM builds WHERE id = $1", build.ID())
+		Expect(err).ToNot(HaveOccurred())
+
+		logs, err := logFactory.LogsForBuild(build.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(logs).To(BeEmpty())
+	})
+})
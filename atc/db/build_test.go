@@ -346,6 +346,39 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("ArchiveEvents", func() {
+		BeforeEach(func() {
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("is not archived in the beginning", func() {
+			Expect(build.EventsArchiveKey()).To(BeEmpty())
+		})
+
+		It("records the key and deletes the events from the database", func() {
+			err := build.ArchiveEvents("builds/1/events.json.gz")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.EventsArchiveKey()).To(Equal("builds/1/events.json.gz"))
+
+			_, err = build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.EventsArchiveKey()).To(Equal("builds/1/events.json.gz"))
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			_, err = events.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+	})
+
 	Describe("Start", func() {
 		var err error
 		var started bool
@@ -385,7 +418,7 @@ var _ = Describe("Build", func() {
 
 		Context("build has been aborted", func() {
 			BeforeEach(func() {
-				err = build.MarkAsAborted()
+				err = build.MarkAsAborted("", "")
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -895,6 +928,56 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("FinishWithWebhookNotifications", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("finishes the build and queues a notification for each webhook", func() {
+			err := build.FinishWithWebhookNotifications(db.BuildStatusFailed, []string{"some-webhook", "other-webhook"}, json.RawMessage(`{"status":"failed"}`))
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Status()).To(Equal(db.BuildStatusFailed))
+
+			var webhooks []string
+			rows, err := dbConn.Query(`SELECT webhook FROM webhook_notifications WHERE build_id = $1 ORDER BY id`, build.ID())
+			Expect(err).ToNot(HaveOccurred())
+			defer rows.Close()
+
+			for rows.Next() {
+				var webhook string
+				Expect(rows.Scan(&webhook)).To(Succeed())
+				webhooks = append(webhooks, webhook)
+			}
+
+			Expect(webhooks).To(Equal([]string{"some-webhook", "other-webhook"}))
+		})
+
+		Context("when the build has already been deleted", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`DELETE FROM builds WHERE id = $1`, build.ID())
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("queues no notifications", func() {
+				err := build.FinishWithWebhookNotifications(db.BuildStatusFailed, []string{"some-webhook"}, json.RawMessage(`{}`))
+				Expect(err).To(HaveOccurred())
+
+				var count int
+				err = dbConn.QueryRow(`SELECT COUNT(*) FROM webhook_notifications`).Scan(&count)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(BeZero())
+			})
+		})
+	})
+
 	Describe("Variables", func() {
 		var (
 			globalSecrets creds.Secrets
@@ -983,11 +1066,68 @@ var _ = Describe("Build", func() {
 				Expect(val).To(Equal("caz"))
 			})
 		})
+
+		Context("when the build was triggered with vars", func() {
+			var triggerVars db.TriggerVars
+
+			BeforeEach(func() {
+				triggerVars = db.TriggerVars{
+					Vars: map[string]interface{}{"version": "1.2.3"},
+				}
+			})
+
+			JustBeforeEach(func() {
+				var err error
+				build, err = defaultJob.CreateBuildWithVars(defaultBuildCreatedBy, triggerVars)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("stores the vars on the build", func() {
+				Expect(build.TriggerVars()).To(Equal(triggerVars))
+			})
+
+			It("fetches from the trigger vars", func() {
+				v, err := build.Variables(logger, globalSecrets, varSourcePool)
+				Expect(err).ToNot(HaveOccurred())
+
+				val, found, err := v.Get(vars.Reference{Path: "version"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(val).To(Equal("1.2.3"))
+			})
+
+			Context("when a var is also defined by a credential manager", func() {
+				BeforeEach(func() {
+					triggerVars.Vars["foo"] = "override"
+				})
+
+				It("fails with a conflict", func() {
+					_, err := build.Variables(logger, globalSecrets, varSourcePool)
+					Expect(err).To(Equal(db.TriggerVarConflictError{Name: "foo"}))
+				})
+
+				Context("when overriding is allowed", func() {
+					BeforeEach(func() {
+						triggerVars.AllowOverride = true
+					})
+
+					It("takes precedence over the credential manager's var", func() {
+						v, err := build.Variables(logger, globalSecrets, varSourcePool)
+						Expect(err).ToNot(HaveOccurred())
+
+						val, found, err := v.Get(vars.Reference{Path: "foo"})
+						Expect(err).ToNot(HaveOccurred())
+						Expect(found).To(BeTrue())
+						Expect(val).To(Equal("override"))
+					})
+				})
+			})
+		})
 	})
 
 	Describe("Abort", func() {
 		JustBeforeEach(func() {
-			err := build.MarkAsAborted()
+			err := build.MarkAsAborted("some-user", "some-reason")
 			Expect(err).NotTo(HaveOccurred())
 
 			found, err := build.Reload()
@@ -999,6 +1139,12 @@ var _ = Describe("Build", func() {
 			Expect(build.IsAborted()).To(BeTrue())
 		})
 
+		It("records who aborted the build and why", func() {
+			Expect(build.AbortedBy()).ToNot(BeNil())
+			Expect(*build.AbortedBy()).To(Equal("some-user"))
+			Expect(build.AbortReason()).To(Equal("some-reason"))
+		})
+
 		Context("request job rescheudle", func() {
 			JustBeforeEach(func() {
 				found, err := job.Reload()
@@ -1039,155 +1185,499 @@ var _ = Describe("Build", func() {
 		})
 	})
 
-	Describe("Events", func() {
-		It("saves and emits status events", func() {
-			By("allowing you to subscribe when no events have yet occurred")
-			events, err := build.Events(0)
-			Expect(err).NotTo(HaveOccurred())
+	Describe("Approvals", func() {
+		It("has no approvals to begin with", func() {
+			approvals, err := build.Approvals()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(approvals).To(BeEmpty())
+		})
 
-			defer db.Close(events)
+		Context("when an approval is requested", func() {
+			var approval db.BuildApproval
 
-			By("emitting a status event when started")
-			started, err := build.Start(atc.Plan{})
-			Expect(err).NotTo(HaveOccurred())
-			Expect(started).To(BeTrue())
+			BeforeEach(func() {
+				var err error
+				approval, err = build.RequestApproval("some-plan", "deploy")
+				Expect(err).ToNot(HaveOccurred())
+			})
 
-			found, err := build.Reload()
-			Expect(err).NotTo(HaveOccurred())
-			Expect(found).To(BeTrue())
+			It("is pending", func() {
+				Expect(approval.PlanID).To(Equal(atc.PlanID("some-plan")))
+				Expect(approval.Name).To(Equal("deploy"))
+				Expect(approval.RequestedAt).To(BeTemporally("~", time.Now(), time.Second))
+				Expect(approval.Decided).To(BeFalse())
 
-			Expect(events.Next()).To(Equal(envelope(event.Status{
-				Status: atc.StatusStarted,
-				Time:   build.StartTime().Unix(),
-			}, "0")))
+				approvals, err := build.Approvals()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(approvals).To(Equal([]db.BuildApproval{approval}))
+			})
 
-			By("emitting a status event when finished")
-			err = build.Finish(db.BuildStatusSucceeded)
-			Expect(err).NotTo(HaveOccurred())
+			It("returns the same approval when requested again", func() {
+				again, err := build.RequestApproval("some-plan", "deploy")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(again).To(Equal(approval))
+			})
 
-			found, err = build.Reload()
-			Expect(err).NotTo(HaveOccurred())
-			Expect(found).To(BeTrue())
+			Context("when it is decided", func() {
+				var decided bool
 
-			Expect(events.Next()).To(Equal(envelope(event.Status{
-				Status: atc.StatusSucceeded,
-				Time:   build.EndTime().Unix(),
-			}, "1")))
+				BeforeEach(func() {
+					var err error
+					decided, err = build.DecideApproval("some-plan", true, "some-user", "ship it")
+					Expect(err).ToNot(HaveOccurred())
+				})
 
-			By("ending the stream when finished")
-			_, err = events.Next()
-			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
-		})
+				It("records the decision and who made it", func() {
+					Expect(decided).To(BeTrue())
 
-		It("emits pre-bigint migration events", func() {
-			started, err := build.Start(atc.Plan{})
-			Expect(err).NotTo(HaveOccurred())
-			Expect(started).To(BeTrue())
+					approval, err := build.RequestApproval("some-plan", "deploy")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(approval.Decided).To(BeTrue())
+					Expect(approval.Approved).To(BeTrue())
+					Expect(approval.DecidedBy).To(Equal("some-user"))
+					Expect(approval.DecidedAt).To(BeTemporally("~", time.Now(), time.Second))
+					Expect(approval.Comment).To(Equal("ship it"))
+				})
 
-			found, err := build.Reload()
-			Expect(err).NotTo(HaveOccurred())
-			Expect(found).To(BeTrue())
+				It("can not be decided again", func() {
+					decided, err := build.DecideApproval("some-plan", false, "other-user", "")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(decided).To(BeFalse())
 
-			_, err = dbConn.Exec(`UPDATE build_events SET build_id_old = build_id, build_id = NULL WHERE build_id = $1`, build.ID())
-			Expect(err).NotTo(HaveOccurred())
+					approval, err := build.RequestApproval("some-plan", "deploy")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(approval.Approved).To(BeTrue())
+					Expect(approval.DecidedBy).To(Equal("some-user"))
+				})
 
-			events, err := build.Events(0)
-			Expect(err).NotTo(HaveOccurred())
+				It("notifies the approval notifier", func() {
+					notifier, err := build.ApprovalNotifier("some-plan")
+					Expect(err).ToNot(HaveOccurred())
 
-			defer db.Close(events)
-			Expect(events.Next()).To(Equal(envelope(event.Status{
-				Status: atc.StatusStarted,
-				Time:   build.StartTime().Unix(),
-			}, "0")))
+					defer notifier.Close()
+
+					Eventually(notifier.Notify()).Should(Receive())
+				})
+			})
+		})
+
+		It("can not decide an approval that was never requested", func() {
+			decided, err := build.DecideApproval("some-plan", true, "some-user", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decided).To(BeFalse())
 		})
 	})
 
-	Describe("SaveEvent", func() {
-		It("saves and propagates events correctly", func() {
-			By("allowing you to subscribe when no events have yet occurred")
-			events, err := build.Events(0)
-			Expect(err).NotTo(HaveOccurred())
+	Describe("TaskExitCodes", func() {
+		It("has no exit codes to begin with", func() {
+			exitCodes, err := build.TaskExitCodes()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exitCodes).To(BeEmpty())
+		})
 
-			defer db.Close(events)
+		Context("when exit codes are saved", func() {
+			BeforeEach(func() {
+				err := build.SaveTaskExitCode("some-plan", "unit", 1)
+				Expect(err).ToNot(HaveOccurred())
 
-			By("saving them in order")
-			err = build.SaveEvent(event.Log{
-				Payload: "some ",
+				err = build.SaveTaskExitCode("other-plan", "lint", 0)
+				Expect(err).ToNot(HaveOccurred())
 			})
-			Expect(err).NotTo(HaveOccurred())
-
-			Expect(events.Next()).To(Equal(envelope(event.Log{
-				Payload: "some ",
-			}, "0")))
 
-			err = build.SaveEvent(event.Log{
-				Payload: "log",
+			It("returns them", func() {
+				exitCodes, err := build.TaskExitCodes()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(exitCodes).To(Equal([]db.TaskExitCode{
+					{PlanID: "other-plan", Name: "lint", ExitCode: 0},
+					{PlanID: "some-plan", Name: "unit", ExitCode: 1},
+				}))
 			})
-			Expect(err).NotTo(HaveOccurred())
 
-			Expect(events.Next()).To(Equal(envelope(event.Log{
-				Payload: "log",
-			}, "1")))
+			It("replaces the exit code of a task which runs again", func() {
+				err := build.SaveTaskExitCode("some-plan", "unit", 137)
+				Expect(err).ToNot(HaveOccurred())
 
-			By("allowing you to subscribe from an offset")
-			eventsFrom1, err := build.Events(1)
-			Expect(err).NotTo(HaveOccurred())
+				exitCodes, err := build.TaskExitCodes()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(exitCodes).To(ContainElement(db.TaskExitCode{PlanID: "some-plan", Name: "unit", ExitCode: 137}))
+				Expect(exitCodes).To(HaveLen(2))
+			})
+		})
+	})
 
-			defer db.Close(eventsFrom1)
+	Describe("FailingStreakStart", func() {
+		finishedBuild := func(status db.BuildStatus) db.Build {
+			build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
 
-			Expect(eventsFrom1.Next()).To(Equal(envelope(event.Log{
-				Payload: "log",
-			}, "1")))
+			Expect(build.Finish(status)).To(Succeed())
 
-			By("notifying those waiting on events as soon as they're saved")
-			nextEvent := make(chan event.Envelope)
-			nextErr := make(chan error)
+			found, err := build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
 
-			go func() {
-				event, err := events.Next()
-				if err != nil {
-					nextErr <- err
-				} else {
-					nextEvent <- event
-				}
-			}()
+			return build
+		}
 
-			Consistently(nextEvent).ShouldNot(Receive())
-			Consistently(nextErr).ShouldNot(Receive())
+		It("returns when the first failing build since the last success finished", func() {
+			finishedBuild(db.BuildStatusFailed)
+			finishedBuild(db.BuildStatusSucceeded)
+			firstFailure := finishedBuild(db.BuildStatusFailed)
+			finishedBuild(db.BuildStatusAborted)
+			finishedBuild(db.BuildStatusErrored)
+			build := finishedBuild(db.BuildStatusSucceeded)
 
-			err = build.SaveEvent(event.Log{
-				Payload: "log 2",
-			})
-			Expect(err).NotTo(HaveOccurred())
+			start, found, err := build.FailingStreakStart()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(start).To(BeTemporally("==", firstFailure.EndTime()))
+		})
 
-			Eventually(nextEvent).Should(Receive(Equal(envelope(event.Log{
-				Payload: "log 2",
-			}, "2"))))
+		It("finds no streak when the job was not failing", func() {
+			finishedBuild(db.BuildStatusSucceeded)
+			finishedBuild(db.BuildStatusAborted)
+			build := finishedBuild(db.BuildStatusSucceeded)
 
-			By("returning ErrBuildEventStreamClosed for Next calls after Close")
-			events3, err := build.Events(0)
-			Expect(err).NotTo(HaveOccurred())
+			_, found, err := build.FailingStreakStart()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
 
-			err = events3.Close()
-			Expect(err).NotTo(HaveOccurred())
+		It("finds no streak for one-off builds", func() {
+			build, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
 
-			Eventually(func() error {
-				_, err := events3.Next()
-				return err
-			}).Should(Equal(db.ErrBuildEventStreamClosed))
+			_, found, err := build.FailingStreakStart()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
 		})
 	})
 
-	Describe("SaveOutput", func() {
-		var pipelineConfig atc.Config
+	Describe("PreviousStatus", func() {
+		finishedBuild := func(status db.BuildStatus) db.Build {
+			build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
 
-		var scenario *dbtest.Scenario
-		var build db.Build
+			Expect(build.Finish(status)).To(Succeed())
 
-		var outputVersion atc.Version
+			return build
+		}
 
-		BeforeEach(func() {
-			atc.EnableGlobalResources = true
+		It("returns the status of the last completed build before it, ignoring aborted builds", func() {
+			finishedBuild(db.BuildStatusSucceeded)
+			finishedBuild(db.BuildStatusFailed)
+			finishedBuild(db.BuildStatusAborted)
+
+			build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			finishedBuild(db.BuildStatusSucceeded)
+
+			status, found, err := build.PreviousStatus()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(status).To(Equal(db.BuildStatusFailed))
+		})
+
+		It("finds nothing for the first build of a job", func() {
+			build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := build.PreviousStatus()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("finds nothing for one-off builds", func() {
+			build, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := build.PreviousStatus()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("SupersedingVersion", func() {
+		var scenario *dbtest.Scenario
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name:         "some-job",
+							SupersededBy: "some-resource",
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.GetStep{
+										Name: "some-resource",
+									},
+								},
+							},
+						},
+					},
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   dbtest.BaseResourceType,
+							Source: atc.Source{"some": "source"},
+						},
+					},
+				}),
+				builder.WithResourceVersions(
+					"some-resource",
+					atc.Version{"ver": "1"},
+				),
+				builder.WithJobBuild(&build, "some-job", dbtest.JobInputs{
+					{
+						Name:    "some-resource",
+						Version: atc.Version{"ver": "1"},
+					},
+				}, dbtest.JobOutputs{}),
+			)
+		})
+
+		It("finds nothing while the input is the latest version", func() {
+			_, found, err := build.SupersedingVersion("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when a newer version is found", func() {
+			BeforeEach(func() {
+				scenario.Run(builder.WithResourceVersions(
+					"some-resource",
+					atc.Version{"ver": "1"},
+					atc.Version{"ver": "2"},
+				))
+			})
+
+			It("returns the newer version", func() {
+				version, found, err := build.SupersedingVersion("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(version).To(Equal(atc.Version{"ver": "2"}))
+			})
+
+			Context("when the newer version is disabled", func() {
+				BeforeEach(func() {
+					scenario.Run(builder.WithDisabledVersion("some-resource", atc.Version{"ver": "2"}))
+				})
+
+				It("finds nothing", func() {
+					_, found, err := build.SupersedingVersion("some-resource")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+
+			Context("when the resource is pinned", func() {
+				BeforeEach(func() {
+					scenario.Run(builder.WithPinnedVersion("some-resource", atc.Version{"ver": "1"}))
+				})
+
+				It("finds nothing", func() {
+					_, found, err := build.SupersedingVersion("some-resource")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+		})
+
+		It("notifies the superseded notifier when a newer version is saved", func() {
+			notifier, err := build.SupersededNotifier("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+
+			defer notifier.Close()
+
+			Consistently(notifier.Notify()).ShouldNot(Receive())
+
+			scenario.Run(builder.WithResourceVersions(
+				"some-resource",
+				atc.Version{"ver": "2"},
+			))
+
+			Eventually(notifier.Notify()).Should(Receive())
+		})
+	})
+
+	Describe("Events", func() {
+		It("saves and emits status events", func() {
+			By("allowing you to subscribe when no events have yet occurred")
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			By("emitting a status event when started")
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(events.Next()).To(Equal(envelope(event.Status{
+				Status: atc.StatusStarted,
+				Time:   build.StartTime().Unix(),
+			}, "0")))
+
+			By("emitting a status event when finished")
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err = build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(events.Next()).To(Equal(envelope(event.Status{
+				Status: atc.StatusSucceeded,
+				Time:   build.EndTime().Unix(),
+			}, "1")))
+
+			By("ending the stream when finished")
+			_, err = events.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+
+		It("emits pre-bigint migration events", func() {
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, err = dbConn.Exec(`UPDATE build_events SET build_id_old = build_id, build_id = NULL WHERE build_id = $1`, build.ID())
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+			Expect(events.Next()).To(Equal(envelope(event.Status{
+				Status: atc.StatusStarted,
+				Time:   build.StartTime().Unix(),
+			}, "0")))
+		})
+	})
+
+	Describe("SaveEvent", func() {
+		It("saves and propagates events correctly", func() {
+			By("allowing you to subscribe when no events have yet occurred")
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			By("saving them in order")
+			err = build.SaveEvent(event.Log{
+				Payload: "some ",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{
+				Payload: "some ",
+			}, "0")))
+
+			err = build.SaveEvent(event.Log{
+				Payload: "log",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{
+				Payload: "log",
+			}, "1")))
+
+			By("allowing you to subscribe from an offset")
+			eventsFrom1, err := build.Events(1)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(eventsFrom1)
+
+			Expect(eventsFrom1.Next()).To(Equal(envelope(event.Log{
+				Payload: "log",
+			}, "1")))
+
+			By("notifying those waiting on events as soon as they're saved")
+			nextEvent := make(chan event.Envelope)
+			nextErr := make(chan error)
+
+			go func() {
+				event, err := events.Next()
+				if err != nil {
+					nextErr <- err
+				} else {
+					nextEvent <- event
+				}
+			}()
+
+			Consistently(nextEvent).ShouldNot(Receive())
+			Consistently(nextErr).ShouldNot(Receive())
+
+			err = build.SaveEvent(event.Log{
+				Payload: "log 2",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(nextEvent).Should(Receive(Equal(envelope(event.Log{
+				Payload: "log 2",
+			}, "2"))))
+
+			By("returning ErrBuildEventStreamClosed for Next calls after Close")
+			events3, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = events3.Close()
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() error {
+				_, err := events3.Next()
+				return err
+			}).Should(Equal(db.ErrBuildEventStreamClosed))
+		})
+	})
+
+	Describe("SaveEvents", func() {
+		It("saves the events in order and notifies subscribers", func() {
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			err = build.SaveEvents([]atc.Event{
+				event.Log{Payload: "some "},
+				event.Log{Payload: "log"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{
+				Payload: "some ",
+			}, "0")))
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{
+				Payload: "log",
+			}, "1")))
+		})
+
+		It("does nothing when there are no events", func() {
+			Expect(build.SaveEvents(nil)).To(Succeed())
+		})
+	})
+
+	Describe("SaveOutput", func() {
+		var pipelineConfig atc.Config
+
+		var scenario *dbtest.Scenario
+		var build db.Build
+
+		var outputVersion atc.Version
+
+		BeforeEach(func() {
+			atc.EnableGlobalResources = true
 
 			pipelineConfig = atc.Config{
 				Jobs: atc.JobConfigs{
@@ -1359,17 +1849,67 @@ var _ = Describe("Build", func() {
 				})
 			})
 		})
+
+		Context("when the resource type identifies versions by some of their fields", func() {
+			var customTypes atc.VersionedResourceTypes
+
+			BeforeEach(func() {
+				customTypes = atc.VersionedResourceTypes{
+					{
+						ResourceType: atc.ResourceType{
+							Name:           "some-custom-type",
+							Type:           dbtest.BaseResourceType,
+							Source:         atc.Source{"some": "type-source"},
+							IdentityFields: []string{"ref"},
+						},
+						Version: atc.Version{"some": "type-version"},
+					},
+				}
+			})
+
+			It("records an output with the same identity as a saved version as that version, updating its metadata in place", func() {
+				err := build.SaveOutput("some-custom-type", atc.Source{"some": "source"}, customTypes, atc.Version{"ref": "v1", "pushed_at": "1"}, nil, "first", "some-resource")
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SaveOutput("some-custom-type", atc.Source{"some": "source"}, customTypes, atc.Version{"ref": "v1", "pushed_at": "2"}, []db.ResourceConfigMetadataField{{Name: "url", Value: "some-url"}}, "second", "some-resource")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, outputs, err := build.Resources()
+				Expect(err).ToNot(HaveOccurred())
+
+				var second db.BuildOutput
+				for _, output := range outputs {
+					if output.Name == "second" {
+						second = output
+					}
+				}
+				Expect(second.Version).To(Equal(atc.Version{"ref": "v1", "pushed_at": "1"}))
+				Expect(second.Metadata).To(Equal(db.ResourceConfigMetadataFields{
+					{Name: "url", Value: "some-url"},
+					{Name: "pushed_at", Value: "2"},
+				}))
+
+				resource, found, err := scenario.Pipeline.Resource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, found, err = resource.FindVersion(atc.Version{"ref": "v1", "pushed_at": "2"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
 	})
 
 	Describe("Resources", func() {
 		var (
-			scenario      *dbtest.Scenario
-			build         db.Build
-			inputResource db.Resource
+			scenario       *dbtest.Scenario
+			build          db.Build
+			inputResource  db.Resource
+			pipelineConfig atc.Config
 		)
 
 		BeforeEach(func() {
-			pipelineConfig := atc.Config{
+			pipelineConfig = atc.Config{
 				Jobs: atc.JobConfigs{
 					{
 						Name: "some-job",
@@ -1459,6 +1999,87 @@ var _ = Describe("Build", func() {
 			}))
 		})
 
+		Context("when the versions have metadata", func() {
+			BeforeEach(func() {
+				_, err := inputResource.UpdateMetadata(
+					atc.Version{"ver": "1"},
+					db.NewResourceConfigMetadataFields([]atc.MetadataField{{Name: "commit", Value: "abc"}}),
+				)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the metadata of each version", func() {
+				inputs, _, err := build.Resources()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(inputs).To(ConsistOf([]db.BuildInput{
+					{
+						Name:            "some-input",
+						Version:         atc.Version{"ver": "1"},
+						Metadata:        db.ResourceConfigMetadataFields{{Name: "commit", Value: "abc"}},
+						ResourceID:      inputResource.ID(),
+						FirstOccurrence: true,
+					},
+				}))
+			})
+		})
+
+		Context("when a version has been garbage collected", func() {
+			BeforeEach(func() {
+				_, err := psql.Delete("resource_config_versions").
+					Where(sq.Eq{"version_md5": convertToMD5(atc.Version{"ver": "1"})}).
+					RunWith(dbConn).
+					Exec()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("still returns the input, marking the version as missing", func() {
+				inputs, _, err := build.Resources()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(inputs).To(ConsistOf([]db.BuildInput{
+					{
+						Name:            "some-input",
+						Version:         atc.Version{},
+						ResourceID:      inputResource.ID(),
+						FirstOccurrence: true,
+						VersionMissing:  true,
+						VersionMD5:      convertToMD5(atc.Version{"ver": "1"}),
+					},
+				}))
+			})
+		})
+
+		Context("when the resource has moved on to another config", func() {
+			BeforeEach(func() {
+				pipelineConfig.Resources[0].Source = atc.Source{"some": "other-source"}
+
+				scenario.Run(
+					builder.WithPipeline(pipelineConfig),
+					builder.WithResourceVersions("some-resource", atc.Version{"ver": "3"}),
+				)
+
+				Expect(scenario.Resource("some-resource").ResourceConfigScopeID()).ToNot(Equal(inputResource.ResourceConfigScopeID()))
+			})
+
+			It("returns the versions of the config they were used with", func() {
+				inputs, outputs, err := build.Resources()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(inputs).To(ConsistOf([]db.BuildInput{
+					{
+						Name:            "some-input",
+						Version:         atc.Version{"ver": "1"},
+						ResourceID:      inputResource.ID(),
+						FirstOccurrence: true,
+					},
+				}))
+
+				Expect(outputs).To(ContainElement(db.BuildOutput{
+					Name:    "some-resource",
+					Version: atc.Version{"ver": "2"},
+				}))
+			})
+		})
+
 		Context("when the first occurrence is empty", func() {
 			BeforeEach(func() {
 				res, err := psql.Update("build_resource_config_version_inputs").
@@ -1990,6 +2611,31 @@ var _ = Describe("Build", func() {
 					Expect(upstreamBuild.InputsReady()).To(BeTrue())
 				})
 
+				Context("when the versions have metadata", func() {
+					BeforeEach(func() {
+						_, err := scenario.Resource("some-resource").UpdateMetadata(
+							atc.Version{"version": "v3"},
+							db.NewResourceConfigMetadataFields([]atc.MetadataField{{Name: "commit", Value: "abc"}}),
+						)
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("adopts the inputs with their metadata", func() {
+						inputs, adopted, err := upstreamBuild.AdoptInputsAndPipes()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(adopted).To(BeTrue())
+						Expect(inputs).To(ConsistOf([]db.BuildInput{
+							{
+								Name:            "some-input",
+								ResourceID:      scenario.Resource("some-resource").ID(),
+								Version:         atc.Version{"version": "v3"},
+								Metadata:        db.ResourceConfigMetadataFields{{Name: "commit", Value: "abc"}},
+								FirstOccurrence: false,
+							},
+						}))
+					})
+				})
+
 				Context("followed by a downstream job", func() {
 					var downstreamBuild db.Build
 
@@ -2272,6 +2918,58 @@ var _ = Describe("Build", func() {
 					Expect(reloaded).To(BeTrue())
 					Expect(retriggerBuild.IsAborted()).To(BeTrue())
 				})
+
+				It("reports the input as unavailable on the build to retrigger", func() {
+					unavailable, err := downstreamBuild.UnavailableInputs()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(unavailable).To(Equal([]string{"some-input"}))
+				})
+
+				Context("when the build is rerun with the latest versions of unavailable inputs", func() {
+					BeforeEach(func() {
+						scenario.Run(
+							builder.WithNextInputMapping("downstream-job", dbtest.JobInputs{
+								{
+									Name:    "some-input",
+									Version: atc.Version{"some": "new-version"},
+								},
+								{
+									Name:    "some-other-input",
+									Version: atc.Version{"version": "v1"},
+								},
+							}),
+						)
+
+						var err error
+						retriggerBuild, err = job.RerunBuildWithLatestIfUnavailable(downstreamBuild, defaultBuildCreatedBy)
+						Expect(err).ToNot(HaveOccurred())
+					})
+
+					It("adopts the latest version for the unavailable input only", func() {
+						Expect(adoptFound).To(BeTrue())
+
+						Expect(buildInputs).To(ConsistOf(
+							db.BuildInput{
+								Name:       "some-input",
+								ResourceID: scenario.Resource("some-resource").ID(),
+								Version:    atc.Version{"some": "new-version"},
+							},
+							db.BuildInput{
+								Name:       "some-other-input",
+								ResourceID: scenario.Resource("some-other-resource").ID(),
+								Version:    atc.Version{"version": "v1"},
+							},
+						))
+					})
+
+					It("does not abort the build", func() {
+						reloaded, err := retriggerBuild.Reload()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(reloaded).To(BeTrue())
+						Expect(retriggerBuild.IsAborted()).To(BeFalse())
+						Expect(retriggerBuild.InputsReady()).To(BeTrue())
+					})
+				})
 			})
 		})
 
@@ -2373,7 +3071,7 @@ var _ = Describe("Build", func() {
 
 				rcv := scenario.ResourceVersion("some-other-resource", atc.Version{"some": "other-version"})
 
-				found, err := scenario.Resource("some-other-resource").PinVersion(rcv.ID())
+				found, err := scenario.Resource("some-other-resource").PinVersion(rcv.ID(), "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 			})
@@ -2384,6 +3082,265 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("QueuePosition", func() {
+		var scenario *dbtest.Scenario
+
+		createBuild := func(jobName string) db.Build {
+			build, err := scenario.Job(jobName).CreateBuild("some-user")
+			Expect(err).ToNot(HaveOccurred())
+			return build
+		}
+
+		queuePosition := func(build db.Build) int {
+			position, queued, err := build.QueuePosition()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(queued).To(BeTrue())
+			return position
+		}
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Jobs: atc.JobConfigs{
+						{Name: "some-job", SerialGroups: []string{"some-group"}},
+						{Name: "other-job", SerialGroups: []string{"some-group"}},
+						{Name: "unrelated-job"},
+					},
+				}),
+				builder.WithNextInputMapping("other-job", dbtest.JobInputs{}),
+			)
+		})
+
+		It("orders the pending builds of the jobs in the build's serial groups", func() {
+			first := createBuild("some-job")
+			second := createBuild("other-job")
+			third := createBuild("some-job")
+			unrelated := createBuild("unrelated-job")
+
+			Expect(queuePosition(first)).To(Equal(1))
+			Expect(queuePosition(second)).To(Equal(2))
+			Expect(queuePosition(third)).To(Equal(3))
+			Expect(queuePosition(unrelated)).To(Equal(1))
+		})
+
+		It("moves the builds up once the builds ahead of them are no longer pending", func() {
+			first := createBuild("some-job")
+			second := createBuild("some-job")
+
+			err := first.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(queuePosition(second)).To(Equal(1))
+		})
+
+		It("skips the builds of jobs sharing a serial group whose inputs aren't determined", func() {
+			_, err := dbConn.Exec(`UPDATE jobs SET inputs_determined = false WHERE id = $1`, scenario.Job("other-job").ID())
+			Expect(err).ToNot(HaveOccurred())
+
+			createBuild("other-job")
+			build := createBuild("some-job")
+
+			Expect(queuePosition(build)).To(Equal(1))
+		})
+
+		It("skips the builds which have been scheduled", func() {
+			first := createBuild("some-job")
+			second := createBuild("some-job")
+
+			scheduled, err := scenario.Job("some-job").ScheduleBuild(first)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(scheduled).To(BeTrue())
+
+			Expect(queuePosition(second)).To(Equal(1))
+		})
+
+		Context("when the team has a max concurrent builds", func() {
+			BeforeEach(func() {
+				err := scenario.Team.UpdateMaxConcurrentBuilds(1)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("orders the pending builds of the team's jobs whose inputs are determined", func() {
+				scenario.Run(builder.WithNextInputMapping("unrelated-job", dbtest.JobInputs{}))
+
+				first := createBuild("unrelated-job")
+				second := createBuild("some-job")
+
+				Expect(queuePosition(first)).To(Equal(1))
+				Expect(queuePosition(second)).To(Equal(2))
+			})
+
+			It("skips the builds of jobs whose inputs aren't determined", func() {
+				createBuild("unrelated-job")
+				build := createBuild("some-job")
+
+				Expect(queuePosition(build)).To(Equal(1))
+			})
+
+			It("skips the builds of jobs which reached their max in flight", func() {
+				running := createBuild("other-job")
+				createBuild("other-job")
+				build := createBuild("unrelated-job")
+
+				scheduled, err := scenario.Job("other-job").ScheduleBuild(running)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+
+				waiting, err := scenario.Job("other-job").GetPendingBuilds()
+				Expect(err).ToNot(HaveOccurred())
+
+				scheduled, err = scenario.Job("other-job").ScheduleBuild(waiting[1])
+				Expect(err).ToNot(HaveOccurred())
+				Expect(scheduled).To(BeFalse())
+
+				Expect(queuePosition(build)).To(Equal(1))
+			})
+		})
+
+		It("has no position for builds which have been scheduled", func() {
+			build := createBuild("some-job")
+
+			scheduled, err := scenario.Job("some-job").ScheduleBuild(build)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(scheduled).To(BeTrue())
+
+			found, err := build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, queued, err := build.QueuePosition()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(queued).To(BeFalse())
+		})
+
+		It("skips the builds of paused jobs sharing a serial group", func() {
+			createBuild("other-job")
+			build := createBuild("some-job")
+
+			err := scenario.Job("other-job").Pause()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(queuePosition(build)).To(Equal(1))
+		})
+
+		It("orders reruns by the build they rerun", func() {
+			first := createBuild("some-job")
+			second := createBuild("other-job")
+
+			err := first.Finish(db.BuildStatusFailed)
+			Expect(err).ToNot(HaveOccurred())
+
+			rerun, err := scenario.Job("some-job").RerunBuild(first, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(queuePosition(rerun)).To(Equal(1))
+			Expect(queuePosition(second)).To(Equal(2))
+		})
+
+		It("has no position for builds which are not pending", func() {
+			build := createBuild("some-job")
+
+			err := build.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, queued, err := build.QueuePosition()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(queued).To(BeFalse())
+		})
+
+		It("has no position for one-off builds", func() {
+			build, err := scenario.Team.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, queued, err := build.QueuePosition()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(queued).To(BeFalse())
+		})
+	})
+
+	Describe("OnDemandResourcesChecked", func() {
+		var scenario *dbtest.Scenario
+
+		var build db.Build
+		var checked bool
+
+		BeforeEach(func() {
+			pipelineConfig := atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						PlanSequence: []atc.Step{
+							{
+								Config: &atc.GetStep{
+									Name: "some-resource",
+								},
+							},
+							{
+								Config: &atc.GetStep{
+									Name: "some-on-demand-resource",
+								},
+							},
+						},
+					},
+				},
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   dbtest.BaseResourceType,
+						Source: atc.Source{"some": "source"},
+					},
+					{
+						Name:          "some-on-demand-resource",
+						Type:          dbtest.BaseResourceType,
+						Source:        atc.Source{"some": "on-demand-source"},
+						CheckOnDemand: true,
+					},
+				},
+			}
+
+			scenario = dbtest.Setup(
+				builder.WithPipeline(pipelineConfig),
+				builder.WithResourceVersions("some-resource", atc.Version{"some": "version"}),
+				builder.WithResourceVersions("some-on-demand-resource", atc.Version{"some": "on-demand-version"}),
+				builder.WithPendingJobBuild(&build, "some-job"),
+			)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			checked, err = build.OnDemandResourcesChecked([]string{"some-on-demand-resource"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the on demand resource has been checked since the build was created", func() {
+			BeforeEach(func() {
+				scenario.Run(
+					builder.WithResourceVersions("some-on-demand-resource"),
+				)
+			})
+
+			It("returns true, regardless of the other resources", func() {
+				Expect(checked).To(BeTrue())
+			})
+		})
+
+		Context("when the on demand resource has not been checked since the build was created", func() {
+			BeforeEach(func() {
+				scenario.Run(
+					builder.WithResourceVersions("some-resource"),
+				)
+			})
+
+			It("returns false", func() {
+				Expect(checked).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("SavePipeline", func() {
 		It("saves the parent job and build ids", func() {
 			By("creating a build")
@@ -2501,6 +3458,108 @@ var _ = Describe("Build", func() {
 			})
 		})
 	})
+
+	Describe("SetProtected", func() {
+		var (
+			scenario *dbtest.Scenario
+			build    db.Build
+
+			protectedScopeID int
+		)
+
+		pipelineConfig := func(source atc.Source) atc.Config {
+			return atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						PlanSequence: []atc.Step{
+							{
+								Config: &atc.GetStep{
+									Name: "some-resource",
+								},
+							},
+						},
+					},
+				},
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   dbtest.BaseResourceType,
+						Source: source,
+					},
+				},
+			}
+		}
+
+		versionsInScope := func(scopeID int) int {
+			var count int
+			err := psql.Select("COUNT(*)").
+				From("resource_config_versions").
+				Where(sq.Eq{"resource_config_scope_id": scopeID}).
+				RunWith(dbConn).
+				QueryRow().
+				Scan(&count)
+			Expect(err).ToNot(HaveOccurred())
+			return count
+		}
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(pipelineConfig(atc.Source{"some": "source"})),
+				builder.WithResourceVersions("some-resource", atc.Version{"ver": "1"}),
+				builder.WithJobBuild(&build, "some-job", dbtest.JobInputs{
+					{
+						Name:    "some-resource",
+						Version: atc.Version{"ver": "1"},
+					},
+				}, dbtest.JobOutputs{}),
+			)
+
+			Expect(build.Finish(db.BuildStatusSucceeded)).To(Succeed())
+
+			protectedScopeID = scenario.Resource("some-resource").ResourceConfigScopeID()
+
+			Expect(build.SetProtected(true)).To(Succeed())
+		})
+
+		It("marks the build as protected", func() {
+			Expect(build.IsProtected()).To(BeTrue())
+
+			found, err := build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.IsProtected()).To(BeTrue())
+		})
+
+		Context("when the resource moves on to another config", func() {
+			BeforeEach(func() {
+				scenario.Run(
+					builder.WithPipeline(pipelineConfig(atc.Source{"some": "other-source"})),
+					builder.WithResourceVersions("some-resource", atc.Version{"ver": "2"}),
+				)
+
+				Expect(resourceConfigFactory.CleanUnreferencedConfigs(0)).To(Succeed())
+			})
+
+			It("keeps the versions used by the build", func() {
+				Expect(scenario.Resource("some-resource").ResourceConfigScopeID()).ToNot(Equal(protectedScopeID))
+				Expect(versionsInScope(protectedScopeID)).To(Equal(1))
+			})
+
+			Context("once the build is unprotected", func() {
+				BeforeEach(func() {
+					Expect(build.SetProtected(false)).To(Succeed())
+					Expect(build.IsProtected()).To(BeFalse())
+
+					Expect(resourceConfigFactory.CleanUnreferencedConfigs(0)).To(Succeed())
+				})
+
+				It("lets them be garbage collected", func() {
+					Expect(versionsInScope(protectedScopeID)).To(BeZero())
+				})
+			})
+		})
+	})
 })
 
 func envelope(ev atc.Event, eventID string) event.Envelope {
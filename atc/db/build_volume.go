@@ -0,0 +1,114 @@
+package db
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// BuildVolume is a volume created on a worker for a build, or one of the
+// volumes such a volume was copied on write from.
+type BuildVolume struct {
+	Handle          string
+	WorkerName      string
+	State           VolumeState
+	Type            VolumeType
+	ContainerHandle string
+	ParentHandle    string
+	Path            string
+
+	// Size is the size of the volume as last reported by its worker, if it
+	// has been.
+	Size *uint64
+
+	// Inherited is set for the volumes which weren't created for the build,
+	// e.g. resource caches fetched by an earlier build, but which volumes of
+	// the build were copied on write from.
+	Inherited bool
+}
+
+func (b *build) Containers() ([]Container, error) {
+	rows, err := selectContainers().
+		Where(sq.Eq{"build_id": b.id}).
+		OrderBy("id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanContainers(rows, b.conn, nil)
+}
+
+// Volumes returns the volumes of the build's containers and the artifacts
+// uploaded for it, followed through their parents so that the full
+// copy-on-write lineage of each volume is included.
+func (b *build) Volumes() ([]BuildVolume, error) {
+	rows, err := b.conn.Query(`
+		WITH RECURSIVE build_volumes (id, parent_id, inherited) AS (
+			SELECT v.id, v.parent_id, false
+			FROM volumes v
+			JOIN containers c ON c.id = v.container_id
+			WHERE c.build_id = $1
+		UNION
+			SELECT v.id, v.parent_id, false
+			FROM volumes v
+			JOIN worker_artifacts wa ON wa.id = v.worker_artifact_id
+			WHERE wa.build_id = $1
+		UNION
+			SELECT pv.id, pv.parent_id, true
+			FROM volumes pv
+			JOIN build_volumes bv ON bv.parent_id = pv.id
+		)
+		SELECT v.handle, v.worker_name, v.state, `+volumeTypeColumn+`, c.handle, pv.handle, v.path, v.size, bv.inherited
+		FROM (
+			SELECT id, bool_and(inherited) AS inherited
+			FROM build_volumes
+			GROUP BY id
+		) bv
+		JOIN volumes v ON v.id = bv.id
+		LEFT JOIN containers c ON c.id = v.container_id
+		LEFT JOIN volumes pv ON pv.id = v.parent_id
+		ORDER BY v.id ASC
+	`, b.id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var volumes []BuildVolume
+	for rows.Next() {
+		var volume BuildVolume
+		var containerHandle, parentHandle, path sql.NullString
+		var size sql.NullInt64
+
+		err = rows.Scan(
+			&volume.Handle,
+			&volume.WorkerName,
+			&volume.State,
+			&volume.Type,
+			&containerHandle,
+			&parentHandle,
+			&path,
+			&size,
+			&volume.Inherited,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		volume.ContainerHandle = containerHandle.String
+		volume.ParentHandle = parentHandle.String
+		volume.Path = path.String
+
+		if size.Valid {
+			bytes := uint64(size.Int64)
+			volume.Size = &bytes
+		}
+
+		volumes = append(volumes, volume)
+	}
+
+	return volumes, rows.Err()
+}
@@ -0,0 +1,138 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("Build containers and volumes", func() {
+	var (
+		build      db.Build
+		otherBuild db.Build
+
+		buildContainer db.CreatingContainer
+	)
+
+	BeforeEach(func() {
+		var err error
+		build, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		otherBuild, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		buildContainer, err = defaultWorker.CreateContainer(
+			db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()),
+			db.ContainerMetadata{Type: "task", StepName: "some-task"},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = defaultWorker.CreateContainer(
+			db.NewBuildStepContainerOwner(otherBuild.ID(), "some-plan", defaultTeam.ID()),
+			db.ContainerMetadata{Type: "task", StepName: "other-task"},
+		)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("Containers", func() {
+		It("returns the containers of the build", func() {
+			containers, err := build.Containers()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(containers).To(HaveLen(1))
+			Expect(containers[0].Handle()).To(Equal(buildContainer.Handle()))
+		})
+	})
+
+	Describe("Volumes", func() {
+		var (
+			cacheVolume db.CreatedVolume
+			childVolume db.CreatedVolume
+			ownVolume   db.CreatedVolume
+		)
+
+		BeforeEach(func() {
+			otherContainer, err := defaultWorker.CreateContainer(
+				db.NewBuildStepContainerOwner(otherBuild.ID(), "some-get", defaultTeam.ID()),
+				db.ContainerMetadata{Type: "get", StepName: "some-get"},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingCacheVolume, err := volumeRepository.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), otherContainer, "some-get-path")
+			Expect(err).ToNot(HaveOccurred())
+
+			cacheVolume, err = creatingCacheVolume.Created()
+			Expect(err).ToNot(HaveOccurred())
+
+			resourceCache, err := resourceCacheFactory.FindOrCreateResourceCache(
+				db.ForBuild(otherBuild.ID()),
+				"some-base-resource-type",
+				atc.Version{"some": "version"},
+				atc.Source{"some": "source"},
+				atc.Params{},
+				atc.VersionedResourceTypes{},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = cacheVolume.InitializeResourceCache(resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingChildVolume, err := cacheVolume.CreateChildForContainer(buildContainer, "some-input-path")
+			Expect(err).ToNot(HaveOccurred())
+
+			childVolume, err = creatingChildVolume.Created()
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingOwnVolume, err := volumeRepository.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), buildContainer, "some-output-path")
+			Expect(err).ToNot(HaveOccurred())
+
+			ownVolume, err = creatingOwnVolume.Created()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = volumeRepository.UpdateVolumeSizes(defaultWorker.Name(), map[string]uint64{
+				ownVolume.Handle(): 1024,
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the volumes of the build's containers along with the volumes they were copied from", func() {
+			volumes, err := build.Volumes()
+			Expect(err).ToNot(HaveOccurred())
+
+			size := uint64(1024)
+			Expect(volumes).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{
+					"Handle":          Equal(cacheVolume.Handle()),
+					"Type":            Equal(db.VolumeTypeResource),
+					"ContainerHandle": Equal(cacheVolume.ContainerHandle()),
+					"Size":            BeNil(),
+					"Inherited":       BeTrue(),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Handle":          Equal(childVolume.Handle()),
+					"Type":            Equal(db.VolumeTypeContainer),
+					"ContainerHandle": Equal(buildContainer.Handle()),
+					"ParentHandle":    Equal(cacheVolume.Handle()),
+					"Inherited":       BeFalse(),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Handle":     Equal(ownVolume.Handle()),
+					"WorkerName": Equal(defaultWorker.Name()),
+					"State":      Equal(db.VolumeStateCreated),
+					"Size":       Equal(&size),
+					"Inherited":  BeFalse(),
+				}),
+			))
+		})
+
+		It("does not return the volumes of other builds as their own", func() {
+			volumes, err := otherBuild.Volumes()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(volumes).To(HaveLen(1))
+			Expect(volumes[0].Handle).To(Equal(cacheVolume.Handle()))
+			Expect(volumes[0].Inherited).To(BeFalse())
+		})
+	})
+})
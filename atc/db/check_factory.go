@@ -26,6 +26,8 @@ type Checkable interface {
 	CheckEvery() *atc.CheckEvery
 	CheckTimeout() string
 	LastCheckEndTime() time.Time
+	CheckTTL() time.Duration
+	LastScheduledCheckEndTime() time.Time
 	CurrentPinnedVersion() atc.Version
 
 	HasWebhook() bool
@@ -37,6 +39,7 @@ type Checkable interface {
 //counterfeiter:generate . CheckFactory
 type CheckFactory interface {
 	TryCreateCheck(context.Context, Checkable, ResourceTypes, atc.Version, bool) (Build, bool, error)
+	CheckInterval(Checkable) time.Duration
 	Resources() ([]Resource, error)
 	ResourceTypes() ([]ResourceType, error)
 }
@@ -102,15 +105,9 @@ func (c *checkFactory) TryCreateCheck(ctx context.Context, checkable Checkable,
 		}
 	}
 
-	interval := c.defaultCheckInterval
-	if checkable.HasWebhook() {
-		interval = c.defaultWithWebhookCheckInterval
-	}
-	if checkable.CheckEvery() != nil && !checkable.CheckEvery().Never {
-		interval = checkable.CheckEvery().Interval
-	}
+	interval := c.CheckInterval(checkable)
 
-	if !manuallyTriggered && time.Now().Before(checkable.LastCheckEndTime().Add(interval)) {
+	if !manuallyTriggered && time.Now().Before(checkable.LastCheckEndTime().Add(interval)) && !CheckTTLExpired(checkable) {
 		// skip creating the check if its interval hasn't elapsed yet
 		return nil, false, nil
 	}
@@ -133,6 +130,28 @@ func (c *checkFactory) TryCreateCheck(ctx context.Context, checkable Checkable,
 	return build, true, nil
 }
 
+// CheckTTLExpired returns whether the checkable has a check TTL which has
+// elapsed since its last scheduled check, in which case a scheduled check is
+// due no matter its interval or how recently it was checked otherwise.
+func CheckTTLExpired(checkable Checkable) bool {
+	ttl := checkable.CheckTTL()
+	return ttl > 0 && !time.Now().Before(checkable.LastScheduledCheckEndTime().Add(ttl))
+}
+
+// CheckInterval returns how long after its last check the checkable is
+// periodically checked again.
+func (c *checkFactory) CheckInterval(checkable Checkable) time.Duration {
+	if checkable.CheckEvery() != nil && !checkable.CheckEvery().Never {
+		return checkable.CheckEvery().Interval
+	}
+
+	if checkable.HasWebhook() {
+		return c.defaultWithWebhookCheckInterval
+	}
+
+	return c.defaultCheckInterval
+}
+
 func (c *checkFactory) Resources() ([]Resource, error) {
 	var resources []Resource
 
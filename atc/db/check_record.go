@@ -0,0 +1,201 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+)
+
+// checkRecordsRetained is the number of check records kept for each resource
+// config scope; older ones are removed as new checks start.
+const checkRecordsRetained = 1000
+
+// CheckRecord is a check run for a resource config scope, as recorded by the
+// updates to its last check.
+type CheckRecord struct {
+	ID            int
+	Status        atc.BuildStatus
+	StartTime     time.Time
+	EndTime       time.Time
+	Error         string
+	VersionsAdded int
+}
+
+// startCheckRecord records the start of a check of the scope. A check of the
+// scope which never finished, e.g. because its ATC went away, is recorded as
+// aborted.
+func startCheckRecord(tx Tx, rcsID int) error {
+	_, err := psql.Update("check_records").
+		Set("status", atc.StatusAborted).
+		Set("end_time", sq.Expr("now()")).
+		Where(sq.Eq{
+			"resource_config_scope_id": rcsID,
+			"end_time":                 nil,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("check_records").
+		Columns("resource_config_scope_id", "status").
+		Values(rcsID, atc.StatusStarted).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM check_records
+		WHERE resource_config_scope_id = $1
+		AND id <= (
+			SELECT id
+			FROM check_records
+			WHERE resource_config_scope_id = $1
+			ORDER BY id DESC
+			OFFSET $2
+			LIMIT 1
+		)
+	`, rcsID, checkRecordsRetained)
+	return err
+}
+
+// updateCheckRecord sets the given columns on the record of the running check
+// of the scope, if there is one.
+func updateCheckRecord(tx Tx, rcsID int, values map[string]interface{}) error {
+	_, err := psql.Update("check_records").
+		SetMap(values).
+		Where(sq.Eq{
+			"resource_config_scope_id": rcsID,
+			"end_time":                 nil,
+		}).
+		RunWith(tx).
+		Exec()
+	return err
+}
+
+func finishCheckRecord(tx Tx, rcsID int, status atc.BuildStatus) error {
+	return updateCheckRecord(tx, rcsID, map[string]interface{}{
+		"status":   status,
+		"end_time": sq.Expr("now()"),
+	})
+}
+
+// CheckRecords returns the checks of the resource's current config scope,
+// newest first, paginated by their IDs.
+func (r *resource) CheckRecords(page Page) ([]CheckRecord, Pagination, error) {
+	if r.resourceConfigScopeID == 0 {
+		return nil, Pagination{}, nil
+	}
+
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer Rollback(tx)
+
+	query := psql.Select("id", "status", "start_time", "end_time", "error", "versions_added").
+		From("check_records").
+		Where(sq.Eq{"resource_config_scope_id": r.resourceConfigScopeID}).
+		Limit(uint64(page.Limit))
+
+	reverse := false
+	if page.From != nil {
+		query = query.Where(sq.GtOrEq{"id": *page.From}).OrderBy("id ASC")
+		reverse = true
+	} else if page.To != nil {
+		query = query.Where(sq.LtOrEq{"id": *page.To}).OrderBy("id DESC")
+	} else {
+		query = query.OrderBy("id DESC")
+	}
+
+	rows, err := query.RunWith(tx).Query()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer Close(rows)
+
+	var records []CheckRecord
+	for rows.Next() {
+		var record CheckRecord
+		var endTime pq.NullTime
+		var checkErr sql.NullString
+
+		err = rows.Scan(&record.ID, &record.Status, &record.StartTime, &endTime, &checkErr, &record.VersionsAdded)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		record.EndTime = endTime.Time
+		record.Error = checkErr.String
+
+		records = append(records, record)
+	}
+
+	if len(records) == 0 {
+		return nil, Pagination{}, nil
+	}
+
+	if reverse {
+		for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+			records[i], records[j] = records[j], records[i]
+		}
+	}
+
+	newestID := records[0].ID
+	oldestID := records[len(records)-1].ID
+
+	var pagination Pagination
+
+	var olderID int
+	err = psql.Select("id").
+		From("check_records").
+		Where(sq.Eq{"resource_config_scope_id": r.resourceConfigScopeID}).
+		Where(sq.Lt{"id": oldestID}).
+		OrderBy("id DESC").
+		Limit(1).
+		RunWith(tx).
+		QueryRow().
+		Scan(&olderID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, Pagination{}, err
+	} else if err == nil {
+		pagination.Older = &Page{
+			To:    &olderID,
+			Limit: page.Limit,
+		}
+	}
+
+	var newerID int
+	err = psql.Select("id").
+		From("check_records").
+		Where(sq.Eq{"resource_config_scope_id": r.resourceConfigScopeID}).
+		Where(sq.Gt{"id": newestID}).
+		OrderBy("id ASC").
+		Limit(1).
+		RunWith(tx).
+		QueryRow().
+		Scan(&newerID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, Pagination{}, err
+	} else if err == nil {
+		pagination.Newer = &Page{
+			From:  &newerID,
+			Limit: page.Limit,
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	return records, pagination, nil
+}
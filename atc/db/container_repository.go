@@ -1,6 +1,7 @@
 package db
 
 import (
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -14,6 +15,7 @@ type ContainerRepository interface {
 	FindOrphanedContainers() ([]CreatingContainer, []CreatedContainer, []DestroyingContainer, error)
 	DestroyFailedContainers() (int, error)
 	FindDestroyingContainers(workerName string) ([]string, error)
+	CountDestroyingContainersByWorker() (map[string]int, error)
 	RemoveDestroyingContainers(workerName string, currentHandles []string) (int, error)
 	UpdateContainersMissingSince(workerName string, handles []string) error
 	RemoveMissingContainers(time.Duration) (int, error)
@@ -46,6 +48,25 @@ func diff(a, b []string) (diff []string) {
 	return
 }
 
+func scanCountsByWorker(rows *sql.Rows) (map[string]int, error) {
+	defer Close(rows)
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var workerName string
+		var count int
+
+		err := rows.Scan(&workerName, &count)
+		if err != nil {
+			return nil, err
+		}
+
+		counts[workerName] = count
+	}
+
+	return counts, rows.Err()
+}
+
 func (repository *containerRepository) queryContainerHandles(tx Tx, cond sq.Eq) ([]string, error) {
 	query, args, err := psql.Select("handle").From("containers").Where(cond).ToSql()
 	if err != nil {
@@ -167,6 +188,20 @@ func (repository *containerRepository) FindDestroyingContainers(workerName strin
 	return destroyingContainers, err
 }
 
+func (repository *containerRepository) CountDestroyingContainersByWorker() (map[string]int, error) {
+	rows, err := psql.Select("w.name", "COUNT(c.id)").
+		From("workers w").
+		LeftJoin(fmt.Sprintf("containers c ON c.worker_name = w.name AND c.state = '%s'", atc.ContainerStateDestroying)).
+		GroupBy("w.name").
+		RunWith(repository.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanCountsByWorker(rows)
+}
+
 func (repository *containerRepository) RemoveMissingContainers(gracePeriod time.Duration) (int, error) {
 	result, err := psql.Delete("containers c USING workers w").
 		Where(sq.Expr("c.worker_name = w.name")).
@@ -582,6 +582,35 @@ var _ = Describe("ContainerRepository", func() {
 		})
 	})
 
+	Describe("CountDestroyingContainersByWorker", func() {
+		BeforeEach(func() {
+			for _, handle := range []string{"destroying-1", "destroying-2"} {
+				_, err := psql.Insert("containers").SetMap(map[string]interface{}{
+					"state":       atc.ContainerStateDestroying,
+					"handle":      handle,
+					"worker_name": defaultWorker.Name(),
+				}).RunWith(dbConn).Exec()
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			_, err := psql.Insert("containers").SetMap(map[string]interface{}{
+				"state":       atc.ContainerStateCreated,
+				"handle":      "created",
+				"worker_name": defaultWorker.Name(),
+			}).RunWith(dbConn).Exec()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("counts the destroying containers on every worker", func() {
+			counts, err := containerRepository.CountDestroyingContainersByWorker()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(counts).To(Equal(map[string]int{
+				defaultWorker.Name(): 2,
+				otherWorker.Name():   0,
+			}))
+		})
+	})
+
 	Describe("RemoveMissingContainers", func() {
 		var (
 			today        time.Time
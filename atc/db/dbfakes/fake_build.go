@@ -30,6 +30,26 @@ type FakeBuild struct {
 		result1 db.Notifier
 		result2 error
 	}
+	AbortReasonStub        func() string
+	abortReasonMutex       sync.RWMutex
+	abortReasonArgsForCall []struct {
+	}
+	abortReasonReturns struct {
+		result1 string
+	}
+	abortReasonReturnsOnCall map[int]struct {
+		result1 string
+	}
+	AbortedByStub        func() *string
+	abortedByMutex       sync.RWMutex
+	abortedByArgsForCall []struct {
+	}
+	abortedByReturns struct {
+		result1 *string
+	}
+	abortedByReturnsOnCall map[int]struct {
+		result1 *string
+	}
 	AcquireTrackingLockStub        func(lager.Logger, time.Duration) (lock.Lock, bool, error)
 	acquireTrackingLockMutex       sync.RWMutex
 	acquireTrackingLockArgsForCall []struct {
@@ -74,6 +94,42 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	ApprovalNotifierStub        func(atc.PlanID) (db.Notifier, error)
+	approvalNotifierMutex       sync.RWMutex
+	approvalNotifierArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	approvalNotifierReturns struct {
+		result1 db.Notifier
+		result2 error
+	}
+	approvalNotifierReturnsOnCall map[int]struct {
+		result1 db.Notifier
+		result2 error
+	}
+	ApprovalsStub        func() ([]db.BuildApproval, error)
+	approvalsMutex       sync.RWMutex
+	approvalsArgsForCall []struct {
+	}
+	approvalsReturns struct {
+		result1 []db.BuildApproval
+		result2 error
+	}
+	approvalsReturnsOnCall map[int]struct {
+		result1 []db.BuildApproval
+		result2 error
+	}
+	ArchiveEventsStub        func(string) error
+	archiveEventsMutex       sync.RWMutex
+	archiveEventsArgsForCall []struct {
+		arg1 string
+	}
+	archiveEventsReturns struct {
+		result1 error
+	}
+	archiveEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ArtifactStub        func(int) (db.WorkerArtifact, error)
 	artifactMutex       sync.RWMutex
 	artifactArgsForCall []struct {
@@ -99,6 +155,18 @@ type FakeBuild struct {
 		result1 []db.WorkerArtifact
 		result2 error
 	}
+	ContainersStub        func() ([]db.Container, error)
+	containersMutex       sync.RWMutex
+	containersArgsForCall []struct {
+	}
+	containersReturns struct {
+		result1 []db.Container
+		result2 error
+	}
+	containersReturnsOnCall map[int]struct {
+		result1 []db.Container
+		result2 error
+	}
 	CreateTimeStub        func() time.Time
 	createTimeMutex       sync.RWMutex
 	createTimeArgsForCall []struct {
@@ -119,6 +187,22 @@ type FakeBuild struct {
 	createdByReturnsOnCall map[int]struct {
 		result1 *string
 	}
+	DecideApprovalStub        func(atc.PlanID, bool, string, string) (bool, error)
+	decideApprovalMutex       sync.RWMutex
+	decideApprovalArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 bool
+		arg3 string
+		arg4 string
+	}
+	decideApprovalReturns struct {
+		result1 bool
+		result2 error
+	}
+	decideApprovalReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	DeleteStub        func() (bool, error)
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -154,6 +238,30 @@ type FakeBuild struct {
 		result1 db.EventSource
 		result2 error
 	}
+	EventsArchiveKeyStub        func() string
+	eventsArchiveKeyMutex       sync.RWMutex
+	eventsArchiveKeyArgsForCall []struct {
+	}
+	eventsArchiveKeyReturns struct {
+		result1 string
+	}
+	eventsArchiveKeyReturnsOnCall map[int]struct {
+		result1 string
+	}
+	FailingStreakStartStub        func() (time.Time, bool, error)
+	failingStreakStartMutex       sync.RWMutex
+	failingStreakStartArgsForCall []struct {
+	}
+	failingStreakStartReturns struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}
+	failingStreakStartReturnsOnCall map[int]struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}
 	FinishStub        func(db.BuildStatus) error
 	finishMutex       sync.RWMutex
 	finishArgsForCall []struct {
@@ -165,6 +273,19 @@ type FakeBuild struct {
 	finishReturnsOnCall map[int]struct {
 		result1 error
 	}
+	FinishWithWebhookNotificationsStub        func(db.BuildStatus, []string, json.RawMessage) error
+	finishWithWebhookNotificationsMutex       sync.RWMutex
+	finishWithWebhookNotificationsArgsForCall []struct {
+		arg1 db.BuildStatus
+		arg2 []string
+		arg3 json.RawMessage
+	}
+	finishWithWebhookNotificationsReturns struct {
+		result1 error
+	}
+	finishWithWebhookNotificationsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	HasPlanStub        func() bool
 	hasPlanMutex       sync.RWMutex
 	hasPlanArgsForCall []struct {
@@ -258,6 +379,16 @@ type FakeBuild struct {
 	isNewerThanLastCheckOfReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	IsProtectedStub        func() bool
+	isProtectedMutex       sync.RWMutex
+	isProtectedArgsForCall []struct {
+	}
+	isProtectedReturns struct {
+		result1 bool
+	}
+	isProtectedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	IsRunningStub        func() bool
 	isRunningMutex       sync.RWMutex
 	isRunningArgsForCall []struct {
@@ -308,9 +439,11 @@ type FakeBuild struct {
 	lagerDataReturnsOnCall map[int]struct {
 		result1 lager.Data
 	}
-	MarkAsAbortedStub        func() error
+	MarkAsAbortedStub        func(string, string) error
 	markAsAbortedMutex       sync.RWMutex
 	markAsAbortedArgsForCall []struct {
+		arg1 string
+		arg2 string
 	}
 	markAsAbortedReturns struct {
 		result1 error
@@ -328,6 +461,19 @@ type FakeBuild struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	OnDemandResourcesCheckedStub        func([]string) (bool, error)
+	onDemandResourcesCheckedMutex       sync.RWMutex
+	onDemandResourcesCheckedArgsForCall []struct {
+		arg1 []string
+	}
+	onDemandResourcesCheckedReturns struct {
+		result1 bool
+		result2 error
+	}
+	onDemandResourcesCheckedReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	PipelineStub        func() (db.Pipeline, bool, error)
 	pipelineMutex       sync.RWMutex
 	pipelineArgsForCall []struct {
@@ -396,6 +542,20 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	PreviousStatusStub        func() (db.BuildStatus, bool, error)
+	previousStatusMutex       sync.RWMutex
+	previousStatusArgsForCall []struct {
+	}
+	previousStatusReturns struct {
+		result1 db.BuildStatus
+		result2 bool
+		result3 error
+	}
+	previousStatusReturnsOnCall map[int]struct {
+		result1 db.BuildStatus
+		result2 bool
+		result3 error
+	}
 	PrivatePlanStub        func() atc.Plan
 	privatePlanMutex       sync.RWMutex
 	privatePlanArgsForCall []struct {
@@ -416,6 +576,20 @@ type FakeBuild struct {
 	publicPlanReturnsOnCall map[int]struct {
 		result1 *json.RawMessage
 	}
+	QueuePositionStub        func() (int, bool, error)
+	queuePositionMutex       sync.RWMutex
+	queuePositionArgsForCall []struct {
+	}
+	queuePositionReturns struct {
+		result1 int
+		result2 bool
+		result3 error
+	}
+	queuePositionReturnsOnCall map[int]struct {
+		result1 int
+		result2 bool
+		result3 error
+	}
 	ReapTimeStub        func() time.Time
 	reapTimeMutex       sync.RWMutex
 	reapTimeArgsForCall []struct {
@@ -438,6 +612,20 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	RequestApprovalStub        func(atc.PlanID, string) (db.BuildApproval, error)
+	requestApprovalMutex       sync.RWMutex
+	requestApprovalArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 string
+	}
+	requestApprovalReturns struct {
+		result1 db.BuildApproval
+		result2 error
+	}
+	requestApprovalReturnsOnCall map[int]struct {
+		result1 db.BuildApproval
+		result2 error
+	}
 	RerunNumberStub        func() int
 	rerunNumberMutex       sync.RWMutex
 	rerunNumberArgsForCall []struct {
@@ -545,6 +733,17 @@ type FakeBuild struct {
 	saveEventReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveEventsStub        func([]atc.Event) error
+	saveEventsMutex       sync.RWMutex
+	saveEventsArgsForCall []struct {
+		arg1 []atc.Event
+	}
+	saveEventsReturns struct {
+		result1 error
+	}
+	saveEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveImageResourceVersionStub        func(db.UsedResourceCache) error
 	saveImageResourceVersionMutex       sync.RWMutex
 	saveImageResourceVersionArgsForCall []struct {
@@ -592,6 +791,19 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	SaveTaskExitCodeStub        func(atc.PlanID, string, int) error
+	saveTaskExitCodeMutex       sync.RWMutex
+	saveTaskExitCodeArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 string
+		arg3 int
+	}
+	saveTaskExitCodeReturns struct {
+		result1 error
+	}
+	saveTaskExitCodeReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SchemaStub        func() string
 	schemaMutex       sync.RWMutex
 	schemaArgsForCall []struct {
@@ -624,6 +836,17 @@ type FakeBuild struct {
 	setInterceptibleReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetProtectedStub        func(bool) error
+	setProtectedMutex       sync.RWMutex
+	setProtectedArgsForCall []struct {
+		arg1 bool
+	}
+	setProtectedReturns struct {
+		result1 error
+	}
+	setProtectedReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SpanContextStub        func() propagation.TextMapCarrier
 	spanContextMutex       sync.RWMutex
 	spanContextArgsForCall []struct {
@@ -667,6 +890,34 @@ type FakeBuild struct {
 	statusReturnsOnCall map[int]struct {
 		result1 db.BuildStatus
 	}
+	SupersededNotifierStub        func(string) (db.Notifier, error)
+	supersededNotifierMutex       sync.RWMutex
+	supersededNotifierArgsForCall []struct {
+		arg1 string
+	}
+	supersededNotifierReturns struct {
+		result1 db.Notifier
+		result2 error
+	}
+	supersededNotifierReturnsOnCall map[int]struct {
+		result1 db.Notifier
+		result2 error
+	}
+	SupersedingVersionStub        func(string) (atc.Version, bool, error)
+	supersedingVersionMutex       sync.RWMutex
+	supersedingVersionArgsForCall []struct {
+		arg1 string
+	}
+	supersedingVersionReturns struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}
+	supersedingVersionReturnsOnCall map[int]struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}
 	SyslogTagStub        func(event.OriginID) string
 	syslogTagMutex       sync.RWMutex
 	syslogTagArgsForCall []struct {
@@ -678,6 +929,18 @@ type FakeBuild struct {
 	syslogTagReturnsOnCall map[int]struct {
 		result1 string
 	}
+	TaskExitCodesStub        func() ([]db.TaskExitCode, error)
+	taskExitCodesMutex       sync.RWMutex
+	taskExitCodesArgsForCall []struct {
+	}
+	taskExitCodesReturns struct {
+		result1 []db.TaskExitCode
+		result2 error
+	}
+	taskExitCodesReturnsOnCall map[int]struct {
+		result1 []db.TaskExitCode
+		result2 error
+	}
 	TeamIDStub        func() int
 	teamIDMutex       sync.RWMutex
 	teamIDArgsForCall []struct {
@@ -708,6 +971,28 @@ type FakeBuild struct {
 	tracingAttrsReturnsOnCall map[int]struct {
 		result1 tracing.Attrs
 	}
+	TriggerVarsStub        func() db.TriggerVars
+	triggerVarsMutex       sync.RWMutex
+	triggerVarsArgsForCall []struct {
+	}
+	triggerVarsReturns struct {
+		result1 db.TriggerVars
+	}
+	triggerVarsReturnsOnCall map[int]struct {
+		result1 db.TriggerVars
+	}
+	UnavailableInputsStub        func() ([]string, error)
+	unavailableInputsMutex       sync.RWMutex
+	unavailableInputsArgsForCall []struct {
+	}
+	unavailableInputsReturns struct {
+		result1 []string
+		result2 error
+	}
+	unavailableInputsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	VariablesStub        func(lager.Logger, creds.Secrets, creds.VarSourcePool) (vars.Variables, error)
 	variablesMutex       sync.RWMutex
 	variablesArgsForCall []struct {
@@ -723,6 +1008,18 @@ type FakeBuild struct {
 		result1 vars.Variables
 		result2 error
 	}
+	VolumesStub        func() ([]db.BuildVolume, error)
+	volumesMutex       sync.RWMutex
+	volumesArgsForCall []struct {
+	}
+	volumesReturns struct {
+		result1 []db.BuildVolume
+		result2 error
+	}
+	volumesReturnsOnCall map[int]struct {
+		result1 []db.BuildVolume
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -783,6 +1080,112 @@ func (fake *FakeBuild) AbortNotifierReturnsOnCall(i int, result1 db.Notifier, re
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) AbortReason() string {
+	fake.abortReasonMutex.Lock()
+	ret, specificReturn := fake.abortReasonReturnsOnCall[len(fake.abortReasonArgsForCall)]
+	fake.abortReasonArgsForCall = append(fake.abortReasonArgsForCall, struct {
+	}{})
+	stub := fake.AbortReasonStub
+	fakeReturns := fake.abortReasonReturns
+	fake.recordInvocation("AbortReason", []interface{}{})
+	fake.abortReasonMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) AbortReasonCallCount() int {
+	fake.abortReasonMutex.RLock()
+	defer fake.abortReasonMutex.RUnlock()
+	return len(fake.abortReasonArgsForCall)
+}
+
+func (fake *FakeBuild) AbortReasonCalls(stub func() string) {
+	fake.abortReasonMutex.Lock()
+	defer fake.abortReasonMutex.Unlock()
+	fake.AbortReasonStub = stub
+}
+
+func (fake *FakeBuild) AbortReasonReturns(result1 string) {
+	fake.abortReasonMutex.Lock()
+	defer fake.abortReasonMutex.Unlock()
+	fake.AbortReasonStub = nil
+	fake.abortReasonReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) AbortReasonReturnsOnCall(i int, result1 string) {
+	fake.abortReasonMutex.Lock()
+	defer fake.abortReasonMutex.Unlock()
+	fake.AbortReasonStub = nil
+	if fake.abortReasonReturnsOnCall == nil {
+		fake.abortReasonReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.abortReasonReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) AbortedBy() *string {
+	fake.abortedByMutex.Lock()
+	ret, specificReturn := fake.abortedByReturnsOnCall[len(fake.abortedByArgsForCall)]
+	fake.abortedByArgsForCall = append(fake.abortedByArgsForCall, struct {
+	}{})
+	stub := fake.AbortedByStub
+	fakeReturns := fake.abortedByReturns
+	fake.recordInvocation("AbortedBy", []interface{}{})
+	fake.abortedByMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) AbortedByCallCount() int {
+	fake.abortedByMutex.RLock()
+	defer fake.abortedByMutex.RUnlock()
+	return len(fake.abortedByArgsForCall)
+}
+
+func (fake *FakeBuild) AbortedByCalls(stub func() *string) {
+	fake.abortedByMutex.Lock()
+	defer fake.abortedByMutex.Unlock()
+	fake.AbortedByStub = stub
+}
+
+func (fake *FakeBuild) AbortedByReturns(result1 *string) {
+	fake.abortedByMutex.Lock()
+	defer fake.abortedByMutex.Unlock()
+	fake.AbortedByStub = nil
+	fake.abortedByReturns = struct {
+		result1 *string
+	}{result1}
+}
+
+func (fake *FakeBuild) AbortedByReturnsOnCall(i int, result1 *string) {
+	fake.abortedByMutex.Lock()
+	defer fake.abortedByMutex.Unlock()
+	fake.AbortedByStub = nil
+	if fake.abortedByReturnsOnCall == nil {
+		fake.abortedByReturnsOnCall = make(map[int]struct {
+			result1 *string
+		})
+	}
+	fake.abortedByReturnsOnCall[i] = struct {
+		result1 *string
+	}{result1}
+}
+
 func (fake *FakeBuild) AcquireTrackingLock(arg1 lager.Logger, arg2 time.Duration) (lock.Lock, bool, error) {
 	fake.acquireTrackingLockMutex.Lock()
 	ret, specificReturn := fake.acquireTrackingLockReturnsOnCall[len(fake.acquireTrackingLockArgsForCall)]
@@ -969,6 +1372,187 @@ func (fake *FakeBuild) AdoptRerunInputsAndPipesReturnsOnCall(i int, result1 []db
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) ApprovalNotifier(arg1 atc.PlanID) (db.Notifier, error) {
+	fake.approvalNotifierMutex.Lock()
+	ret, specificReturn := fake.approvalNotifierReturnsOnCall[len(fake.approvalNotifierArgsForCall)]
+	fake.approvalNotifierArgsForCall = append(fake.approvalNotifierArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	stub := fake.ApprovalNotifierStub
+	fakeReturns := fake.approvalNotifierReturns
+	fake.recordInvocation("ApprovalNotifier", []interface{}{arg1})
+	fake.approvalNotifierMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ApprovalNotifierCallCount() int {
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	return len(fake.approvalNotifierArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalNotifierCalls(stub func(atc.PlanID) (db.Notifier, error)) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = stub
+}
+
+func (fake *FakeBuild) ApprovalNotifierArgsForCall(i int) atc.PlanID {
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	argsForCall := fake.approvalNotifierArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ApprovalNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = nil
+	fake.approvalNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ApprovalNotifierReturnsOnCall(i int, result1 db.Notifier, result2 error) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = nil
+	if fake.approvalNotifierReturnsOnCall == nil {
+		fake.approvalNotifierReturnsOnCall = make(map[int]struct {
+			result1 db.Notifier
+			result2 error
+		})
+	}
+	fake.approvalNotifierReturnsOnCall[i] = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) Approvals() ([]db.BuildApproval, error) {
+	fake.approvalsMutex.Lock()
+	ret, specificReturn := fake.approvalsReturnsOnCall[len(fake.approvalsArgsForCall)]
+	fake.approvalsArgsForCall = append(fake.approvalsArgsForCall, struct {
+	}{})
+	stub := fake.ApprovalsStub
+	fakeReturns := fake.approvalsReturns
+	fake.recordInvocation("Approvals", []interface{}{})
+	fake.approvalsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ApprovalsCallCount() int {
+	fake.approvalsMutex.RLock()
+	defer fake.approvalsMutex.RUnlock()
+	return len(fake.approvalsArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalsCalls(stub func() ([]db.BuildApproval, error)) {
+	fake.approvalsMutex.Lock()
+	defer fake.approvalsMutex.Unlock()
+	fake.ApprovalsStub = stub
+}
+
+func (fake *FakeBuild) ApprovalsReturns(result1 []db.BuildApproval, result2 error) {
+	fake.approvalsMutex.Lock()
+	defer fake.approvalsMutex.Unlock()
+	fake.ApprovalsStub = nil
+	fake.approvalsReturns = struct {
+		result1 []db.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ApprovalsReturnsOnCall(i int, result1 []db.BuildApproval, result2 error) {
+	fake.approvalsMutex.Lock()
+	defer fake.approvalsMutex.Unlock()
+	fake.ApprovalsStub = nil
+	if fake.approvalsReturnsOnCall == nil {
+		fake.approvalsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildApproval
+			result2 error
+		})
+	}
+	fake.approvalsReturnsOnCall[i] = struct {
+		result1 []db.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ArchiveEvents(arg1 string) error {
+	fake.archiveEventsMutex.Lock()
+	ret, specificReturn := fake.archiveEventsReturnsOnCall[len(fake.archiveEventsArgsForCall)]
+	fake.archiveEventsArgsForCall = append(fake.archiveEventsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ArchiveEventsStub
+	fakeReturns := fake.archiveEventsReturns
+	fake.recordInvocation("ArchiveEvents", []interface{}{arg1})
+	fake.archiveEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ArchiveEventsCallCount() int {
+	fake.archiveEventsMutex.RLock()
+	defer fake.archiveEventsMutex.RUnlock()
+	return len(fake.archiveEventsArgsForCall)
+}
+
+func (fake *FakeBuild) ArchiveEventsCalls(stub func(string) error) {
+	fake.archiveEventsMutex.Lock()
+	defer fake.archiveEventsMutex.Unlock()
+	fake.ArchiveEventsStub = stub
+}
+
+func (fake *FakeBuild) ArchiveEventsArgsForCall(i int) string {
+	fake.archiveEventsMutex.RLock()
+	defer fake.archiveEventsMutex.RUnlock()
+	argsForCall := fake.archiveEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ArchiveEventsReturns(result1 error) {
+	fake.archiveEventsMutex.Lock()
+	defer fake.archiveEventsMutex.Unlock()
+	fake.ArchiveEventsStub = nil
+	fake.archiveEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ArchiveEventsReturnsOnCall(i int, result1 error) {
+	fake.archiveEventsMutex.Lock()
+	defer fake.archiveEventsMutex.Unlock()
+	fake.ArchiveEventsStub = nil
+	if fake.archiveEventsReturnsOnCall == nil {
+		fake.archiveEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.archiveEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Artifact(arg1 int) (db.WorkerArtifact, error) {
 	fake.artifactMutex.Lock()
 	ret, specificReturn := fake.artifactReturnsOnCall[len(fake.artifactArgsForCall)]
@@ -1089,6 +1673,62 @@ func (fake *FakeBuild) ArtifactsReturnsOnCall(i int, result1 []db.WorkerArtifact
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) Containers() ([]db.Container, error) {
+	fake.containersMutex.Lock()
+	ret, specificReturn := fake.containersReturnsOnCall[len(fake.containersArgsForCall)]
+	fake.containersArgsForCall = append(fake.containersArgsForCall, struct {
+	}{})
+	stub := fake.ContainersStub
+	fakeReturns := fake.containersReturns
+	fake.recordInvocation("Containers", []interface{}{})
+	fake.containersMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ContainersCallCount() int {
+	fake.containersMutex.RLock()
+	defer fake.containersMutex.RUnlock()
+	return len(fake.containersArgsForCall)
+}
+
+func (fake *FakeBuild) ContainersCalls(stub func() ([]db.Container, error)) {
+	fake.containersMutex.Lock()
+	defer fake.containersMutex.Unlock()
+	fake.ContainersStub = stub
+}
+
+func (fake *FakeBuild) ContainersReturns(result1 []db.Container, result2 error) {
+	fake.containersMutex.Lock()
+	defer fake.containersMutex.Unlock()
+	fake.ContainersStub = nil
+	fake.containersReturns = struct {
+		result1 []db.Container
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ContainersReturnsOnCall(i int, result1 []db.Container, result2 error) {
+	fake.containersMutex.Lock()
+	defer fake.containersMutex.Unlock()
+	fake.ContainersStub = nil
+	if fake.containersReturnsOnCall == nil {
+		fake.containersReturnsOnCall = make(map[int]struct {
+			result1 []db.Container
+			result2 error
+		})
+	}
+	fake.containersReturnsOnCall[i] = struct {
+		result1 []db.Container
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) CreateTime() time.Time {
 	fake.createTimeMutex.Lock()
 	ret, specificReturn := fake.createTimeReturnsOnCall[len(fake.createTimeArgsForCall)]
@@ -1190,9 +1830,76 @@ func (fake *FakeBuild) CreatedByReturnsOnCall(i int, result1 *string) {
 			result1 *string
 		})
 	}
-	fake.createdByReturnsOnCall[i] = struct {
-		result1 *string
-	}{result1}
+	fake.createdByReturnsOnCall[i] = struct {
+		result1 *string
+	}{result1}
+}
+
+func (fake *FakeBuild) DecideApproval(arg1 atc.PlanID, arg2 bool, arg3 string, arg4 string) (bool, error) {
+	fake.decideApprovalMutex.Lock()
+	ret, specificReturn := fake.decideApprovalReturnsOnCall[len(fake.decideApprovalArgsForCall)]
+	fake.decideApprovalArgsForCall = append(fake.decideApprovalArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 bool
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.DecideApprovalStub
+	fakeReturns := fake.decideApprovalReturns
+	fake.recordInvocation("DecideApproval", []interface{}{arg1, arg2, arg3, arg4})
+	fake.decideApprovalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) DecideApprovalCallCount() int {
+	fake.decideApprovalMutex.RLock()
+	defer fake.decideApprovalMutex.RUnlock()
+	return len(fake.decideApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) DecideApprovalCalls(stub func(atc.PlanID, bool, string, string) (bool, error)) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = stub
+}
+
+func (fake *FakeBuild) DecideApprovalArgsForCall(i int) (atc.PlanID, bool, string, string) {
+	fake.decideApprovalMutex.RLock()
+	defer fake.decideApprovalMutex.RUnlock()
+	argsForCall := fake.decideApprovalArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeBuild) DecideApprovalReturns(result1 bool, result2 error) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = nil
+	fake.decideApprovalReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) DecideApprovalReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = nil
+	if fake.decideApprovalReturnsOnCall == nil {
+		fake.decideApprovalReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.decideApprovalReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakeBuild) Delete() (bool, error) {
@@ -1368,6 +2075,118 @@ func (fake *FakeBuild) EventsReturnsOnCall(i int, result1 db.EventSource, result
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) EventsArchiveKey() string {
+	fake.eventsArchiveKeyMutex.Lock()
+	ret, specificReturn := fake.eventsArchiveKeyReturnsOnCall[len(fake.eventsArchiveKeyArgsForCall)]
+	fake.eventsArchiveKeyArgsForCall = append(fake.eventsArchiveKeyArgsForCall, struct {
+	}{})
+	stub := fake.EventsArchiveKeyStub
+	fakeReturns := fake.eventsArchiveKeyReturns
+	fake.recordInvocation("EventsArchiveKey", []interface{}{})
+	fake.eventsArchiveKeyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) EventsArchiveKeyCallCount() int {
+	fake.eventsArchiveKeyMutex.RLock()
+	defer fake.eventsArchiveKeyMutex.RUnlock()
+	return len(fake.eventsArchiveKeyArgsForCall)
+}
+
+func (fake *FakeBuild) EventsArchiveKeyCalls(stub func() string) {
+	fake.eventsArchiveKeyMutex.Lock()
+	defer fake.eventsArchiveKeyMutex.Unlock()
+	fake.EventsArchiveKeyStub = stub
+}
+
+func (fake *FakeBuild) EventsArchiveKeyReturns(result1 string) {
+	fake.eventsArchiveKeyMutex.Lock()
+	defer fake.eventsArchiveKeyMutex.Unlock()
+	fake.EventsArchiveKeyStub = nil
+	fake.eventsArchiveKeyReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) EventsArchiveKeyReturnsOnCall(i int, result1 string) {
+	fake.eventsArchiveKeyMutex.Lock()
+	defer fake.eventsArchiveKeyMutex.Unlock()
+	fake.EventsArchiveKeyStub = nil
+	if fake.eventsArchiveKeyReturnsOnCall == nil {
+		fake.eventsArchiveKeyReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.eventsArchiveKeyReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) FailingStreakStart() (time.Time, bool, error) {
+	fake.failingStreakStartMutex.Lock()
+	ret, specificReturn := fake.failingStreakStartReturnsOnCall[len(fake.failingStreakStartArgsForCall)]
+	fake.failingStreakStartArgsForCall = append(fake.failingStreakStartArgsForCall, struct {
+	}{})
+	stub := fake.FailingStreakStartStub
+	fakeReturns := fake.failingStreakStartReturns
+	fake.recordInvocation("FailingStreakStart", []interface{}{})
+	fake.failingStreakStartMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) FailingStreakStartCallCount() int {
+	fake.failingStreakStartMutex.RLock()
+	defer fake.failingStreakStartMutex.RUnlock()
+	return len(fake.failingStreakStartArgsForCall)
+}
+
+func (fake *FakeBuild) FailingStreakStartCalls(stub func() (time.Time, bool, error)) {
+	fake.failingStreakStartMutex.Lock()
+	defer fake.failingStreakStartMutex.Unlock()
+	fake.FailingStreakStartStub = stub
+}
+
+func (fake *FakeBuild) FailingStreakStartReturns(result1 time.Time, result2 bool, result3 error) {
+	fake.failingStreakStartMutex.Lock()
+	defer fake.failingStreakStartMutex.Unlock()
+	fake.FailingStreakStartStub = nil
+	fake.failingStreakStartReturns = struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) FailingStreakStartReturnsOnCall(i int, result1 time.Time, result2 bool, result3 error) {
+	fake.failingStreakStartMutex.Lock()
+	defer fake.failingStreakStartMutex.Unlock()
+	fake.FailingStreakStartStub = nil
+	if fake.failingStreakStartReturnsOnCall == nil {
+		fake.failingStreakStartReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+			result2 bool
+			result3 error
+		})
+	}
+	fake.failingStreakStartReturnsOnCall[i] = struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuild) Finish(arg1 db.BuildStatus) error {
 	fake.finishMutex.Lock()
 	ret, specificReturn := fake.finishReturnsOnCall[len(fake.finishArgsForCall)]
@@ -1429,6 +2248,74 @@ func (fake *FakeBuild) FinishReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) FinishWithWebhookNotifications(arg1 db.BuildStatus, arg2 []string, arg3 json.RawMessage) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.finishWithWebhookNotificationsMutex.Lock()
+	ret, specificReturn := fake.finishWithWebhookNotificationsReturnsOnCall[len(fake.finishWithWebhookNotificationsArgsForCall)]
+	fake.finishWithWebhookNotificationsArgsForCall = append(fake.finishWithWebhookNotificationsArgsForCall, struct {
+		arg1 db.BuildStatus
+		arg2 []string
+		arg3 json.RawMessage
+	}{arg1, arg2Copy, arg3})
+	stub := fake.FinishWithWebhookNotificationsStub
+	fakeReturns := fake.finishWithWebhookNotificationsReturns
+	fake.recordInvocation("FinishWithWebhookNotifications", []interface{}{arg1, arg2Copy, arg3})
+	fake.finishWithWebhookNotificationsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) FinishWithWebhookNotificationsCallCount() int {
+	fake.finishWithWebhookNotificationsMutex.RLock()
+	defer fake.finishWithWebhookNotificationsMutex.RUnlock()
+	return len(fake.finishWithWebhookNotificationsArgsForCall)
+}
+
+func (fake *FakeBuild) FinishWithWebhookNotificationsCalls(stub func(db.BuildStatus, []string, json.RawMessage) error) {
+	fake.finishWithWebhookNotificationsMutex.Lock()
+	defer fake.finishWithWebhookNotificationsMutex.Unlock()
+	fake.FinishWithWebhookNotificationsStub = stub
+}
+
+func (fake *FakeBuild) FinishWithWebhookNotificationsArgsForCall(i int) (db.BuildStatus, []string, json.RawMessage) {
+	fake.finishWithWebhookNotificationsMutex.RLock()
+	defer fake.finishWithWebhookNotificationsMutex.RUnlock()
+	argsForCall := fake.finishWithWebhookNotificationsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuild) FinishWithWebhookNotificationsReturns(result1 error) {
+	fake.finishWithWebhookNotificationsMutex.Lock()
+	defer fake.finishWithWebhookNotificationsMutex.Unlock()
+	fake.FinishWithWebhookNotificationsStub = nil
+	fake.finishWithWebhookNotificationsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) FinishWithWebhookNotificationsReturnsOnCall(i int, result1 error) {
+	fake.finishWithWebhookNotificationsMutex.Lock()
+	defer fake.finishWithWebhookNotificationsMutex.Unlock()
+	fake.FinishWithWebhookNotificationsStub = nil
+	if fake.finishWithWebhookNotificationsReturnsOnCall == nil {
+		fake.finishWithWebhookNotificationsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.finishWithWebhookNotificationsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) HasPlan() bool {
 	fake.hasPlanMutex.Lock()
 	ret, specificReturn := fake.hasPlanReturnsOnCall[len(fake.hasPlanArgsForCall)]
@@ -1917,6 +2804,59 @@ func (fake *FakeBuild) IsNewerThanLastCheckOfReturnsOnCall(i int, result1 bool)
 	}{result1}
 }
 
+func (fake *FakeBuild) IsProtected() bool {
+	fake.isProtectedMutex.Lock()
+	ret, specificReturn := fake.isProtectedReturnsOnCall[len(fake.isProtectedArgsForCall)]
+	fake.isProtectedArgsForCall = append(fake.isProtectedArgsForCall, struct {
+	}{})
+	stub := fake.IsProtectedStub
+	fakeReturns := fake.isProtectedReturns
+	fake.recordInvocation("IsProtected", []interface{}{})
+	fake.isProtectedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) IsProtectedCallCount() int {
+	fake.isProtectedMutex.RLock()
+	defer fake.isProtectedMutex.RUnlock()
+	return len(fake.isProtectedArgsForCall)
+}
+
+func (fake *FakeBuild) IsProtectedCalls(stub func() bool) {
+	fake.isProtectedMutex.Lock()
+	defer fake.isProtectedMutex.Unlock()
+	fake.IsProtectedStub = stub
+}
+
+func (fake *FakeBuild) IsProtectedReturns(result1 bool) {
+	fake.isProtectedMutex.Lock()
+	defer fake.isProtectedMutex.Unlock()
+	fake.IsProtectedStub = nil
+	fake.isProtectedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) IsProtectedReturnsOnCall(i int, result1 bool) {
+	fake.isProtectedMutex.Lock()
+	defer fake.isProtectedMutex.Unlock()
+	fake.IsProtectedStub = nil
+	if fake.isProtectedReturnsOnCall == nil {
+		fake.isProtectedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isProtectedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeBuild) IsRunning() bool {
 	fake.isRunningMutex.Lock()
 	ret, specificReturn := fake.isRunningReturnsOnCall[len(fake.isRunningArgsForCall)]
@@ -2182,17 +3122,19 @@ func (fake *FakeBuild) LagerDataReturnsOnCall(i int, result1 lager.Data) {
 	}{result1}
 }
 
-func (fake *FakeBuild) MarkAsAborted() error {
+func (fake *FakeBuild) MarkAsAborted(arg1 string, arg2 string) error {
 	fake.markAsAbortedMutex.Lock()
 	ret, specificReturn := fake.markAsAbortedReturnsOnCall[len(fake.markAsAbortedArgsForCall)]
 	fake.markAsAbortedArgsForCall = append(fake.markAsAbortedArgsForCall, struct {
-	}{})
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.MarkAsAbortedStub
 	fakeReturns := fake.markAsAbortedReturns
-	fake.recordInvocation("MarkAsAborted", []interface{}{})
+	fake.recordInvocation("MarkAsAborted", []interface{}{arg1, arg2})
 	fake.markAsAbortedMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -2206,12 +3148,19 @@ func (fake *FakeBuild) MarkAsAbortedCallCount() int {
 	return len(fake.markAsAbortedArgsForCall)
 }
 
-func (fake *FakeBuild) MarkAsAbortedCalls(stub func() error) {
+func (fake *FakeBuild) MarkAsAbortedCalls(stub func(string, string) error) {
 	fake.markAsAbortedMutex.Lock()
 	defer fake.markAsAbortedMutex.Unlock()
 	fake.MarkAsAbortedStub = stub
 }
 
+func (fake *FakeBuild) MarkAsAbortedArgsForCall(i int) (string, string) {
+	fake.markAsAbortedMutex.RLock()
+	defer fake.markAsAbortedMutex.RUnlock()
+	argsForCall := fake.markAsAbortedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeBuild) MarkAsAbortedReturns(result1 error) {
 	fake.markAsAbortedMutex.Lock()
 	defer fake.markAsAbortedMutex.Unlock()
@@ -2288,6 +3237,75 @@ func (fake *FakeBuild) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) OnDemandResourcesChecked(arg1 []string) (bool, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.onDemandResourcesCheckedMutex.Lock()
+	ret, specificReturn := fake.onDemandResourcesCheckedReturnsOnCall[len(fake.onDemandResourcesCheckedArgsForCall)]
+	fake.onDemandResourcesCheckedArgsForCall = append(fake.onDemandResourcesCheckedArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.OnDemandResourcesCheckedStub
+	fakeReturns := fake.onDemandResourcesCheckedReturns
+	fake.recordInvocation("OnDemandResourcesChecked", []interface{}{arg1Copy})
+	fake.onDemandResourcesCheckedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) OnDemandResourcesCheckedCallCount() int {
+	fake.onDemandResourcesCheckedMutex.RLock()
+	defer fake.onDemandResourcesCheckedMutex.RUnlock()
+	return len(fake.onDemandResourcesCheckedArgsForCall)
+}
+
+func (fake *FakeBuild) OnDemandResourcesCheckedCalls(stub func([]string) (bool, error)) {
+	fake.onDemandResourcesCheckedMutex.Lock()
+	defer fake.onDemandResourcesCheckedMutex.Unlock()
+	fake.OnDemandResourcesCheckedStub = stub
+}
+
+func (fake *FakeBuild) OnDemandResourcesCheckedArgsForCall(i int) []string {
+	fake.onDemandResourcesCheckedMutex.RLock()
+	defer fake.onDemandResourcesCheckedMutex.RUnlock()
+	argsForCall := fake.onDemandResourcesCheckedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) OnDemandResourcesCheckedReturns(result1 bool, result2 error) {
+	fake.onDemandResourcesCheckedMutex.Lock()
+	defer fake.onDemandResourcesCheckedMutex.Unlock()
+	fake.OnDemandResourcesCheckedStub = nil
+	fake.onDemandResourcesCheckedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) OnDemandResourcesCheckedReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.onDemandResourcesCheckedMutex.Lock()
+	defer fake.onDemandResourcesCheckedMutex.Unlock()
+	fake.OnDemandResourcesCheckedStub = nil
+	if fake.onDemandResourcesCheckedReturnsOnCall == nil {
+		fake.onDemandResourcesCheckedReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.onDemandResourcesCheckedReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Pipeline() (db.Pipeline, bool, error) {
 	fake.pipelineMutex.Lock()
 	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
@@ -2618,6 +3636,65 @@ func (fake *FakeBuild) PreparationReturnsOnCall(i int, result1 db.BuildPreparati
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) PreviousStatus() (db.BuildStatus, bool, error) {
+	fake.previousStatusMutex.Lock()
+	ret, specificReturn := fake.previousStatusReturnsOnCall[len(fake.previousStatusArgsForCall)]
+	fake.previousStatusArgsForCall = append(fake.previousStatusArgsForCall, struct {
+	}{})
+	stub := fake.PreviousStatusStub
+	fakeReturns := fake.previousStatusReturns
+	fake.recordInvocation("PreviousStatus", []interface{}{})
+	fake.previousStatusMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) PreviousStatusCallCount() int {
+	fake.previousStatusMutex.RLock()
+	defer fake.previousStatusMutex.RUnlock()
+	return len(fake.previousStatusArgsForCall)
+}
+
+func (fake *FakeBuild) PreviousStatusCalls(stub func() (db.BuildStatus, bool, error)) {
+	fake.previousStatusMutex.Lock()
+	defer fake.previousStatusMutex.Unlock()
+	fake.PreviousStatusStub = stub
+}
+
+func (fake *FakeBuild) PreviousStatusReturns(result1 db.BuildStatus, result2 bool, result3 error) {
+	fake.previousStatusMutex.Lock()
+	defer fake.previousStatusMutex.Unlock()
+	fake.PreviousStatusStub = nil
+	fake.previousStatusReturns = struct {
+		result1 db.BuildStatus
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) PreviousStatusReturnsOnCall(i int, result1 db.BuildStatus, result2 bool, result3 error) {
+	fake.previousStatusMutex.Lock()
+	defer fake.previousStatusMutex.Unlock()
+	fake.PreviousStatusStub = nil
+	if fake.previousStatusReturnsOnCall == nil {
+		fake.previousStatusReturnsOnCall = make(map[int]struct {
+			result1 db.BuildStatus
+			result2 bool
+			result3 error
+		})
+	}
+	fake.previousStatusReturnsOnCall[i] = struct {
+		result1 db.BuildStatus
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuild) PrivatePlan() atc.Plan {
 	fake.privatePlanMutex.Lock()
 	ret, specificReturn := fake.privatePlanReturnsOnCall[len(fake.privatePlanArgsForCall)]
@@ -2724,6 +3801,65 @@ func (fake *FakeBuild) PublicPlanReturnsOnCall(i int, result1 *json.RawMessage)
 	}{result1}
 }
 
+func (fake *FakeBuild) QueuePosition() (int, bool, error) {
+	fake.queuePositionMutex.Lock()
+	ret, specificReturn := fake.queuePositionReturnsOnCall[len(fake.queuePositionArgsForCall)]
+	fake.queuePositionArgsForCall = append(fake.queuePositionArgsForCall, struct {
+	}{})
+	stub := fake.QueuePositionStub
+	fakeReturns := fake.queuePositionReturns
+	fake.recordInvocation("QueuePosition", []interface{}{})
+	fake.queuePositionMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) QueuePositionCallCount() int {
+	fake.queuePositionMutex.RLock()
+	defer fake.queuePositionMutex.RUnlock()
+	return len(fake.queuePositionArgsForCall)
+}
+
+func (fake *FakeBuild) QueuePositionCalls(stub func() (int, bool, error)) {
+	fake.queuePositionMutex.Lock()
+	defer fake.queuePositionMutex.Unlock()
+	fake.QueuePositionStub = stub
+}
+
+func (fake *FakeBuild) QueuePositionReturns(result1 int, result2 bool, result3 error) {
+	fake.queuePositionMutex.Lock()
+	defer fake.queuePositionMutex.Unlock()
+	fake.QueuePositionStub = nil
+	fake.queuePositionReturns = struct {
+		result1 int
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) QueuePositionReturnsOnCall(i int, result1 int, result2 bool, result3 error) {
+	fake.queuePositionMutex.Lock()
+	defer fake.queuePositionMutex.Unlock()
+	fake.QueuePositionStub = nil
+	if fake.queuePositionReturnsOnCall == nil {
+		fake.queuePositionReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 bool
+			result3 error
+		})
+	}
+	fake.queuePositionReturnsOnCall[i] = struct {
+		result1 int
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuild) ReapTime() time.Time {
 	fake.reapTimeMutex.Lock()
 	ret, specificReturn := fake.reapTimeReturnsOnCall[len(fake.reapTimeArgsForCall)]
@@ -2817,18 +3953,83 @@ func (fake *FakeBuild) ReloadReturns(result1 bool, result2 error) {
 	}{result1, result2}
 }
 
-func (fake *FakeBuild) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.reloadMutex.Lock()
-	defer fake.reloadMutex.Unlock()
-	fake.ReloadStub = nil
-	if fake.reloadReturnsOnCall == nil {
-		fake.reloadReturnsOnCall = make(map[int]struct {
-			result1 bool
+func (fake *FakeBuild) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.reloadMutex.Lock()
+	defer fake.reloadMutex.Unlock()
+	fake.ReloadStub = nil
+	if fake.reloadReturnsOnCall == nil {
+		fake.reloadReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.reloadReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) RequestApproval(arg1 atc.PlanID, arg2 string) (db.BuildApproval, error) {
+	fake.requestApprovalMutex.Lock()
+	ret, specificReturn := fake.requestApprovalReturnsOnCall[len(fake.requestApprovalArgsForCall)]
+	fake.requestApprovalArgsForCall = append(fake.requestApprovalArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RequestApprovalStub
+	fakeReturns := fake.requestApprovalReturns
+	fake.recordInvocation("RequestApproval", []interface{}{arg1, arg2})
+	fake.requestApprovalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) RequestApprovalCallCount() int {
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	return len(fake.requestApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) RequestApprovalCalls(stub func(atc.PlanID, string) (db.BuildApproval, error)) {
+	fake.requestApprovalMutex.Lock()
+	defer fake.requestApprovalMutex.Unlock()
+	fake.RequestApprovalStub = stub
+}
+
+func (fake *FakeBuild) RequestApprovalArgsForCall(i int) (atc.PlanID, string) {
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	argsForCall := fake.requestApprovalArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) RequestApprovalReturns(result1 db.BuildApproval, result2 error) {
+	fake.requestApprovalMutex.Lock()
+	defer fake.requestApprovalMutex.Unlock()
+	fake.RequestApprovalStub = nil
+	fake.requestApprovalReturns = struct {
+		result1 db.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) RequestApprovalReturnsOnCall(i int, result1 db.BuildApproval, result2 error) {
+	fake.requestApprovalMutex.Lock()
+	defer fake.requestApprovalMutex.Unlock()
+	fake.RequestApprovalStub = nil
+	if fake.requestApprovalReturnsOnCall == nil {
+		fake.requestApprovalReturnsOnCall = make(map[int]struct {
+			result1 db.BuildApproval
 			result2 error
 		})
 	}
-	fake.reloadReturnsOnCall[i] = struct {
-		result1 bool
+	fake.requestApprovalReturnsOnCall[i] = struct {
+		result1 db.BuildApproval
 		result2 error
 	}{result1, result2}
 }
@@ -3380,6 +4581,72 @@ func (fake *FakeBuild) SaveEventReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SaveEvents(arg1 []atc.Event) error {
+	var arg1Copy []atc.Event
+	if arg1 != nil {
+		arg1Copy = make([]atc.Event, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.saveEventsMutex.Lock()
+	ret, specificReturn := fake.saveEventsReturnsOnCall[len(fake.saveEventsArgsForCall)]
+	fake.saveEventsArgsForCall = append(fake.saveEventsArgsForCall, struct {
+		arg1 []atc.Event
+	}{arg1Copy})
+	stub := fake.SaveEventsStub
+	fakeReturns := fake.saveEventsReturns
+	fake.recordInvocation("SaveEvents", []interface{}{arg1Copy})
+	fake.saveEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveEventsCallCount() int {
+	fake.saveEventsMutex.RLock()
+	defer fake.saveEventsMutex.RUnlock()
+	return len(fake.saveEventsArgsForCall)
+}
+
+func (fake *FakeBuild) SaveEventsCalls(stub func([]atc.Event) error) {
+	fake.saveEventsMutex.Lock()
+	defer fake.saveEventsMutex.Unlock()
+	fake.SaveEventsStub = stub
+}
+
+func (fake *FakeBuild) SaveEventsArgsForCall(i int) []atc.Event {
+	fake.saveEventsMutex.RLock()
+	defer fake.saveEventsMutex.RUnlock()
+	argsForCall := fake.saveEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveEventsReturns(result1 error) {
+	fake.saveEventsMutex.Lock()
+	defer fake.saveEventsMutex.Unlock()
+	fake.SaveEventsStub = nil
+	fake.saveEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveEventsReturnsOnCall(i int, result1 error) {
+	fake.saveEventsMutex.Lock()
+	defer fake.saveEventsMutex.Unlock()
+	fake.SaveEventsStub = nil
+	if fake.saveEventsReturnsOnCall == nil {
+		fake.saveEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SaveImageResourceVersion(arg1 db.UsedResourceCache) error {
 	fake.saveImageResourceVersionMutex.Lock()
 	ret, specificReturn := fake.saveImageResourceVersionReturnsOnCall[len(fake.saveImageResourceVersionArgsForCall)]
@@ -3579,6 +4846,69 @@ func (fake *FakeBuild) SavePipelineReturnsOnCall(i int, result1 db.Pipeline, res
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) SaveTaskExitCode(arg1 atc.PlanID, arg2 string, arg3 int) error {
+	fake.saveTaskExitCodeMutex.Lock()
+	ret, specificReturn := fake.saveTaskExitCodeReturnsOnCall[len(fake.saveTaskExitCodeArgsForCall)]
+	fake.saveTaskExitCodeArgsForCall = append(fake.saveTaskExitCodeArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.SaveTaskExitCodeStub
+	fakeReturns := fake.saveTaskExitCodeReturns
+	fake.recordInvocation("SaveTaskExitCode", []interface{}{arg1, arg2, arg3})
+	fake.saveTaskExitCodeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveTaskExitCodeCallCount() int {
+	fake.saveTaskExitCodeMutex.RLock()
+	defer fake.saveTaskExitCodeMutex.RUnlock()
+	return len(fake.saveTaskExitCodeArgsForCall)
+}
+
+func (fake *FakeBuild) SaveTaskExitCodeCalls(stub func(atc.PlanID, string, int) error) {
+	fake.saveTaskExitCodeMutex.Lock()
+	defer fake.saveTaskExitCodeMutex.Unlock()
+	fake.SaveTaskExitCodeStub = stub
+}
+
+func (fake *FakeBuild) SaveTaskExitCodeArgsForCall(i int) (atc.PlanID, string, int) {
+	fake.saveTaskExitCodeMutex.RLock()
+	defer fake.saveTaskExitCodeMutex.RUnlock()
+	argsForCall := fake.saveTaskExitCodeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuild) SaveTaskExitCodeReturns(result1 error) {
+	fake.saveTaskExitCodeMutex.Lock()
+	defer fake.saveTaskExitCodeMutex.Unlock()
+	fake.SaveTaskExitCodeStub = nil
+	fake.saveTaskExitCodeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveTaskExitCodeReturnsOnCall(i int, result1 error) {
+	fake.saveTaskExitCodeMutex.Lock()
+	defer fake.saveTaskExitCodeMutex.Unlock()
+	fake.SaveTaskExitCodeStub = nil
+	if fake.saveTaskExitCodeReturnsOnCall == nil {
+		fake.saveTaskExitCodeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveTaskExitCodeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Schema() string {
 	fake.schemaMutex.Lock()
 	ret, specificReturn := fake.schemaReturnsOnCall[len(fake.schemaArgsForCall)]
@@ -3754,6 +5084,67 @@ func (fake *FakeBuild) SetInterceptibleReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SetProtected(arg1 bool) error {
+	fake.setProtectedMutex.Lock()
+	ret, specificReturn := fake.setProtectedReturnsOnCall[len(fake.setProtectedArgsForCall)]
+	fake.setProtectedArgsForCall = append(fake.setProtectedArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	stub := fake.SetProtectedStub
+	fakeReturns := fake.setProtectedReturns
+	fake.recordInvocation("SetProtected", []interface{}{arg1})
+	fake.setProtectedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SetProtectedCallCount() int {
+	fake.setProtectedMutex.RLock()
+	defer fake.setProtectedMutex.RUnlock()
+	return len(fake.setProtectedArgsForCall)
+}
+
+func (fake *FakeBuild) SetProtectedCalls(stub func(bool) error) {
+	fake.setProtectedMutex.Lock()
+	defer fake.setProtectedMutex.Unlock()
+	fake.SetProtectedStub = stub
+}
+
+func (fake *FakeBuild) SetProtectedArgsForCall(i int) bool {
+	fake.setProtectedMutex.RLock()
+	defer fake.setProtectedMutex.RUnlock()
+	argsForCall := fake.setProtectedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SetProtectedReturns(result1 error) {
+	fake.setProtectedMutex.Lock()
+	defer fake.setProtectedMutex.Unlock()
+	fake.SetProtectedStub = nil
+	fake.setProtectedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetProtectedReturnsOnCall(i int, result1 error) {
+	fake.setProtectedMutex.Lock()
+	defer fake.setProtectedMutex.Unlock()
+	fake.SetProtectedStub = nil
+	if fake.setProtectedReturnsOnCall == nil {
+		fake.setProtectedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setProtectedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SpanContext() propagation.TextMapCarrier {
 	fake.spanContextMutex.Lock()
 	ret, specificReturn := fake.spanContextReturnsOnCall[len(fake.spanContextArgsForCall)]
@@ -3977,6 +5368,137 @@ func (fake *FakeBuild) StatusReturnsOnCall(i int, result1 db.BuildStatus) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SupersededNotifier(arg1 string) (db.Notifier, error) {
+	fake.supersededNotifierMutex.Lock()
+	ret, specificReturn := fake.supersededNotifierReturnsOnCall[len(fake.supersededNotifierArgsForCall)]
+	fake.supersededNotifierArgsForCall = append(fake.supersededNotifierArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SupersededNotifierStub
+	fakeReturns := fake.supersededNotifierReturns
+	fake.recordInvocation("SupersededNotifier", []interface{}{arg1})
+	fake.supersededNotifierMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) SupersededNotifierCallCount() int {
+	fake.supersededNotifierMutex.RLock()
+	defer fake.supersededNotifierMutex.RUnlock()
+	return len(fake.supersededNotifierArgsForCall)
+}
+
+func (fake *FakeBuild) SupersededNotifierCalls(stub func(string) (db.Notifier, error)) {
+	fake.supersededNotifierMutex.Lock()
+	defer fake.supersededNotifierMutex.Unlock()
+	fake.SupersededNotifierStub = stub
+}
+
+func (fake *FakeBuild) SupersededNotifierArgsForCall(i int) string {
+	fake.supersededNotifierMutex.RLock()
+	defer fake.supersededNotifierMutex.RUnlock()
+	argsForCall := fake.supersededNotifierArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SupersededNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.supersededNotifierMutex.Lock()
+	defer fake.supersededNotifierMutex.Unlock()
+	fake.SupersededNotifierStub = nil
+	fake.supersededNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SupersededNotifierReturnsOnCall(i int, result1 db.Notifier, result2 error) {
+	fake.supersededNotifierMutex.Lock()
+	defer fake.supersededNotifierMutex.Unlock()
+	fake.SupersededNotifierStub = nil
+	if fake.supersededNotifierReturnsOnCall == nil {
+		fake.supersededNotifierReturnsOnCall = make(map[int]struct {
+			result1 db.Notifier
+			result2 error
+		})
+	}
+	fake.supersededNotifierReturnsOnCall[i] = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SupersedingVersion(arg1 string) (atc.Version, bool, error) {
+	fake.supersedingVersionMutex.Lock()
+	ret, specificReturn := fake.supersedingVersionReturnsOnCall[len(fake.supersedingVersionArgsForCall)]
+	fake.supersedingVersionArgsForCall = append(fake.supersedingVersionArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SupersedingVersionStub
+	fakeReturns := fake.supersedingVersionReturns
+	fake.recordInvocation("SupersedingVersion", []interface{}{arg1})
+	fake.supersedingVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) SupersedingVersionCallCount() int {
+	fake.supersedingVersionMutex.RLock()
+	defer fake.supersedingVersionMutex.RUnlock()
+	return len(fake.supersedingVersionArgsForCall)
+}
+
+func (fake *FakeBuild) SupersedingVersionCalls(stub func(string) (atc.Version, bool, error)) {
+	fake.supersedingVersionMutex.Lock()
+	defer fake.supersedingVersionMutex.Unlock()
+	fake.SupersedingVersionStub = stub
+}
+
+func (fake *FakeBuild) SupersedingVersionArgsForCall(i int) string {
+	fake.supersedingVersionMutex.RLock()
+	defer fake.supersedingVersionMutex.RUnlock()
+	argsForCall := fake.supersedingVersionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SupersedingVersionReturns(result1 atc.Version, result2 bool, result3 error) {
+	fake.supersedingVersionMutex.Lock()
+	defer fake.supersedingVersionMutex.Unlock()
+	fake.SupersedingVersionStub = nil
+	fake.supersedingVersionReturns = struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) SupersedingVersionReturnsOnCall(i int, result1 atc.Version, result2 bool, result3 error) {
+	fake.supersedingVersionMutex.Lock()
+	defer fake.supersedingVersionMutex.Unlock()
+	fake.SupersedingVersionStub = nil
+	if fake.supersedingVersionReturnsOnCall == nil {
+		fake.supersedingVersionReturnsOnCall = make(map[int]struct {
+			result1 atc.Version
+			result2 bool
+			result3 error
+		})
+	}
+	fake.supersedingVersionReturnsOnCall[i] = struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuild) SyslogTag(arg1 event.OriginID) string {
 	fake.syslogTagMutex.Lock()
 	ret, specificReturn := fake.syslogTagReturnsOnCall[len(fake.syslogTagArgsForCall)]
@@ -4038,6 +5560,62 @@ func (fake *FakeBuild) SyslogTagReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) TaskExitCodes() ([]db.TaskExitCode, error) {
+	fake.taskExitCodesMutex.Lock()
+	ret, specificReturn := fake.taskExitCodesReturnsOnCall[len(fake.taskExitCodesArgsForCall)]
+	fake.taskExitCodesArgsForCall = append(fake.taskExitCodesArgsForCall, struct {
+	}{})
+	stub := fake.TaskExitCodesStub
+	fakeReturns := fake.taskExitCodesReturns
+	fake.recordInvocation("TaskExitCodes", []interface{}{})
+	fake.taskExitCodesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) TaskExitCodesCallCount() int {
+	fake.taskExitCodesMutex.RLock()
+	defer fake.taskExitCodesMutex.RUnlock()
+	return len(fake.taskExitCodesArgsForCall)
+}
+
+func (fake *FakeBuild) TaskExitCodesCalls(stub func() ([]db.TaskExitCode, error)) {
+	fake.taskExitCodesMutex.Lock()
+	defer fake.taskExitCodesMutex.Unlock()
+	fake.TaskExitCodesStub = stub
+}
+
+func (fake *FakeBuild) TaskExitCodesReturns(result1 []db.TaskExitCode, result2 error) {
+	fake.taskExitCodesMutex.Lock()
+	defer fake.taskExitCodesMutex.Unlock()
+	fake.TaskExitCodesStub = nil
+	fake.taskExitCodesReturns = struct {
+		result1 []db.TaskExitCode
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) TaskExitCodesReturnsOnCall(i int, result1 []db.TaskExitCode, result2 error) {
+	fake.taskExitCodesMutex.Lock()
+	defer fake.taskExitCodesMutex.Unlock()
+	fake.TaskExitCodesStub = nil
+	if fake.taskExitCodesReturnsOnCall == nil {
+		fake.taskExitCodesReturnsOnCall = make(map[int]struct {
+			result1 []db.TaskExitCode
+			result2 error
+		})
+	}
+	fake.taskExitCodesReturnsOnCall[i] = struct {
+		result1 []db.TaskExitCode
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) TeamID() int {
 	fake.teamIDMutex.Lock()
 	ret, specificReturn := fake.teamIDReturnsOnCall[len(fake.teamIDArgsForCall)]
@@ -4197,6 +5775,115 @@ func (fake *FakeBuild) TracingAttrsReturnsOnCall(i int, result1 tracing.Attrs) {
 	}{result1}
 }
 
+func (fake *FakeBuild) TriggerVars() db.TriggerVars {
+	fake.triggerVarsMutex.Lock()
+	ret, specificReturn := fake.triggerVarsReturnsOnCall[len(fake.triggerVarsArgsForCall)]
+	fake.triggerVarsArgsForCall = append(fake.triggerVarsArgsForCall, struct {
+	}{})
+	stub := fake.TriggerVarsStub
+	fakeReturns := fake.triggerVarsReturns
+	fake.recordInvocation("TriggerVars", []interface{}{})
+	fake.triggerVarsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) TriggerVarsCallCount() int {
+	fake.triggerVarsMutex.RLock()
+	defer fake.triggerVarsMutex.RUnlock()
+	return len(fake.triggerVarsArgsForCall)
+}
+
+func (fake *FakeBuild) TriggerVarsCalls(stub func() db.TriggerVars) {
+	fake.triggerVarsMutex.Lock()
+	defer fake.triggerVarsMutex.Unlock()
+	fake.TriggerVarsStub = stub
+}
+
+func (fake *FakeBuild) TriggerVarsReturns(result1 db.TriggerVars) {
+	fake.triggerVarsMutex.Lock()
+	defer fake.triggerVarsMutex.Unlock()
+	fake.TriggerVarsStub = nil
+	fake.triggerVarsReturns = struct {
+		result1 db.TriggerVars
+	}{result1}
+}
+
+func (fake *FakeBuild) TriggerVarsReturnsOnCall(i int, result1 db.TriggerVars) {
+	fake.triggerVarsMutex.Lock()
+	defer fake.triggerVarsMutex.Unlock()
+	fake.TriggerVarsStub = nil
+	if fake.triggerVarsReturnsOnCall == nil {
+		fake.triggerVarsReturnsOnCall = make(map[int]struct {
+			result1 db.TriggerVars
+		})
+	}
+	fake.triggerVarsReturnsOnCall[i] = struct {
+		result1 db.TriggerVars
+	}{result1}
+}
+
+func (fake *FakeBuild) UnavailableInputs() ([]string, error) {
+	fake.unavailableInputsMutex.Lock()
+	ret, specificReturn := fake.unavailableInputsReturnsOnCall[len(fake.unavailableInputsArgsForCall)]
+	fake.unavailableInputsArgsForCall = append(fake.unavailableInputsArgsForCall, struct {
+	}{})
+	stub := fake.UnavailableInputsStub
+	fakeReturns := fake.unavailableInputsReturns
+	fake.recordInvocation("UnavailableInputs", []interface{}{})
+	fake.unavailableInputsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) UnavailableInputsCallCount() int {
+	fake.unavailableInputsMutex.RLock()
+	defer fake.unavailableInputsMutex.RUnlock()
+	return len(fake.unavailableInputsArgsForCall)
+}
+
+func (fake *FakeBuild) UnavailableInputsCalls(stub func() ([]string, error)) {
+	fake.unavailableInputsMutex.Lock()
+	defer fake.unavailableInputsMutex.Unlock()
+	fake.UnavailableInputsStub = stub
+}
+
+func (fake *FakeBuild) UnavailableInputsReturns(result1 []string, result2 error) {
+	fake.unavailableInputsMutex.Lock()
+	defer fake.unavailableInputsMutex.Unlock()
+	fake.UnavailableInputsStub = nil
+	fake.unavailableInputsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) UnavailableInputsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.unavailableInputsMutex.Lock()
+	defer fake.unavailableInputsMutex.Unlock()
+	fake.UnavailableInputsStub = nil
+	if fake.unavailableInputsReturnsOnCall == nil {
+		fake.unavailableInputsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.unavailableInputsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Variables(arg1 lager.Logger, arg2 creds.Secrets, arg3 creds.VarSourcePool) (vars.Variables, error) {
 	fake.variablesMutex.Lock()
 	ret, specificReturn := fake.variablesReturnsOnCall[len(fake.variablesArgsForCall)]
@@ -4263,33 +5950,109 @@ func (fake *FakeBuild) VariablesReturnsOnCall(i int, result1 vars.Variables, res
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) Volumes() ([]db.BuildVolume, error) {
+	fake.volumesMutex.Lock()
+	ret, specificReturn := fake.volumesReturnsOnCall[len(fake.volumesArgsForCall)]
+	fake.volumesArgsForCall = append(fake.volumesArgsForCall, struct {
+	}{})
+	stub := fake.VolumesStub
+	fakeReturns := fake.volumesReturns
+	fake.recordInvocation("Volumes", []interface{}{})
+	fake.volumesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) VolumesCallCount() int {
+	fake.volumesMutex.RLock()
+	defer fake.volumesMutex.RUnlock()
+	return len(fake.volumesArgsForCall)
+}
+
+func (fake *FakeBuild) VolumesCalls(stub func() ([]db.BuildVolume, error)) {
+	fake.volumesMutex.Lock()
+	defer fake.volumesMutex.Unlock()
+	fake.VolumesStub = stub
+}
+
+func (fake *FakeBuild) VolumesReturns(result1 []db.BuildVolume, result2 error) {
+	fake.volumesMutex.Lock()
+	defer fake.volumesMutex.Unlock()
+	fake.VolumesStub = nil
+	fake.volumesReturns = struct {
+		result1 []db.BuildVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) VolumesReturnsOnCall(i int, result1 []db.BuildVolume, result2 error) {
+	fake.volumesMutex.Lock()
+	defer fake.volumesMutex.Unlock()
+	fake.VolumesStub = nil
+	if fake.volumesReturnsOnCall == nil {
+		fake.volumesReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildVolume
+			result2 error
+		})
+	}
+	fake.volumesReturnsOnCall[i] = struct {
+		result1 []db.BuildVolume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.abortNotifierMutex.RLock()
 	defer fake.abortNotifierMutex.RUnlock()
+	fake.abortReasonMutex.RLock()
+	defer fake.abortReasonMutex.RUnlock()
+	fake.abortedByMutex.RLock()
+	defer fake.abortedByMutex.RUnlock()
 	fake.acquireTrackingLockMutex.RLock()
 	defer fake.acquireTrackingLockMutex.RUnlock()
 	fake.adoptInputsAndPipesMutex.RLock()
 	defer fake.adoptInputsAndPipesMutex.RUnlock()
 	fake.adoptRerunInputsAndPipesMutex.RLock()
 	defer fake.adoptRerunInputsAndPipesMutex.RUnlock()
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	fake.approvalsMutex.RLock()
+	defer fake.approvalsMutex.RUnlock()
+	fake.archiveEventsMutex.RLock()
+	defer fake.archiveEventsMutex.RUnlock()
 	fake.artifactMutex.RLock()
 	defer fake.artifactMutex.RUnlock()
 	fake.artifactsMutex.RLock()
 	defer fake.artifactsMutex.RUnlock()
+	fake.containersMutex.RLock()
+	defer fake.containersMutex.RUnlock()
 	fake.createTimeMutex.RLock()
 	defer fake.createTimeMutex.RUnlock()
 	fake.createdByMutex.RLock()
 	defer fake.createdByMutex.RUnlock()
+	fake.decideApprovalMutex.RLock()
+	defer fake.decideApprovalMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.endTimeMutex.RLock()
 	defer fake.endTimeMutex.RUnlock()
 	fake.eventsMutex.RLock()
 	defer fake.eventsMutex.RUnlock()
+	fake.eventsArchiveKeyMutex.RLock()
+	defer fake.eventsArchiveKeyMutex.RUnlock()
+	fake.failingStreakStartMutex.RLock()
+	defer fake.failingStreakStartMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
+	fake.finishWithWebhookNotificationsMutex.RLock()
+	defer fake.finishWithWebhookNotificationsMutex.RUnlock()
 	fake.hasPlanMutex.RLock()
 	defer fake.hasPlanMutex.RUnlock()
 	fake.iDMutex.RLock()
@@ -4308,6 +6071,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.isManuallyTriggeredMutex.RUnlock()
 	fake.isNewerThanLastCheckOfMutex.RLock()
 	defer fake.isNewerThanLastCheckOfMutex.RUnlock()
+	fake.isProtectedMutex.RLock()
+	defer fake.isProtectedMutex.RUnlock()
 	fake.isRunningMutex.RLock()
 	defer fake.isRunningMutex.RUnlock()
 	fake.isScheduledMutex.RLock()
@@ -4322,6 +6087,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.markAsAbortedMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.onDemandResourcesCheckedMutex.RLock()
+	defer fake.onDemandResourcesCheckedMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
@@ -4334,14 +6101,20 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.pipelineRefMutex.RUnlock()
 	fake.preparationMutex.RLock()
 	defer fake.preparationMutex.RUnlock()
+	fake.previousStatusMutex.RLock()
+	defer fake.previousStatusMutex.RUnlock()
 	fake.privatePlanMutex.RLock()
 	defer fake.privatePlanMutex.RUnlock()
 	fake.publicPlanMutex.RLock()
 	defer fake.publicPlanMutex.RUnlock()
+	fake.queuePositionMutex.RLock()
+	defer fake.queuePositionMutex.RUnlock()
 	fake.reapTimeMutex.RLock()
 	defer fake.reapTimeMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
 	fake.rerunNumberMutex.RLock()
 	defer fake.rerunNumberMutex.RUnlock()
 	fake.rerunOfMutex.RLock()
@@ -4362,18 +6135,24 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.resourcesCheckedMutex.RUnlock()
 	fake.saveEventMutex.RLock()
 	defer fake.saveEventMutex.RUnlock()
+	fake.saveEventsMutex.RLock()
+	defer fake.saveEventsMutex.RUnlock()
 	fake.saveImageResourceVersionMutex.RLock()
 	defer fake.saveImageResourceVersionMutex.RUnlock()
 	fake.saveOutputMutex.RLock()
 	defer fake.saveOutputMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
+	fake.saveTaskExitCodeMutex.RLock()
+	defer fake.saveTaskExitCodeMutex.RUnlock()
 	fake.schemaMutex.RLock()
 	defer fake.schemaMutex.RUnlock()
 	fake.setDrainedMutex.RLock()
 	defer fake.setDrainedMutex.RUnlock()
 	fake.setInterceptibleMutex.RLock()
 	defer fake.setInterceptibleMutex.RUnlock()
+	fake.setProtectedMutex.RLock()
+	defer fake.setProtectedMutex.RUnlock()
 	fake.spanContextMutex.RLock()
 	defer fake.spanContextMutex.RUnlock()
 	fake.startMutex.RLock()
@@ -4382,16 +6161,28 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.startTimeMutex.RUnlock()
 	fake.statusMutex.RLock()
 	defer fake.statusMutex.RUnlock()
+	fake.supersededNotifierMutex.RLock()
+	defer fake.supersededNotifierMutex.RUnlock()
+	fake.supersedingVersionMutex.RLock()
+	defer fake.supersedingVersionMutex.RUnlock()
 	fake.syslogTagMutex.RLock()
 	defer fake.syslogTagMutex.RUnlock()
+	fake.taskExitCodesMutex.RLock()
+	defer fake.taskExitCodesMutex.RUnlock()
 	fake.teamIDMutex.RLock()
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
 	fake.tracingAttrsMutex.RLock()
 	defer fake.tracingAttrsMutex.RUnlock()
+	fake.triggerVarsMutex.RLock()
+	defer fake.triggerVarsMutex.RUnlock()
+	fake.unavailableInputsMutex.RLock()
+	defer fake.unavailableInputsMutex.RUnlock()
 	fake.variablesMutex.RLock()
 	defer fake.variablesMutex.RUnlock()
+	fake.volumesMutex.RLock()
+	defer fake.volumesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
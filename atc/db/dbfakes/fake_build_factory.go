@@ -38,6 +38,43 @@ type FakeBuildFactory struct {
 		result2 bool
 		result3 error
 	}
+	ClaimSpanExportableBuildsStub        func(int) ([]db.Build, error)
+	claimSpanExportableBuildsMutex       sync.RWMutex
+	claimSpanExportableBuildsArgsForCall []struct {
+		arg1 int
+	}
+	claimSpanExportableBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	claimSpanExportableBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
+	DeletedEventsArchiveKeysStub        func(int) ([]string, error)
+	deletedEventsArchiveKeysMutex       sync.RWMutex
+	deletedEventsArchiveKeysArgsForCall []struct {
+		arg1 int
+	}
+	deletedEventsArchiveKeysReturns struct {
+		result1 []string
+		result2 error
+	}
+	deletedEventsArchiveKeysReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	ForgetDeletedEventsArchiveKeysStub        func([]string) error
+	forgetDeletedEventsArchiveKeysMutex       sync.RWMutex
+	forgetDeletedEventsArchiveKeysArgsForCall []struct {
+		arg1 []string
+	}
+	forgetDeletedEventsArchiveKeysReturns struct {
+		result1 error
+	}
+	forgetDeletedEventsArchiveKeysReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetAllStartedBuildsStub        func() ([]db.Build, error)
 	getAllStartedBuildsMutex       sync.RWMutex
 	getAllStartedBuildsArgsForCall []struct {
@@ -50,6 +87,20 @@ type FakeBuildFactory struct {
 		result1 []db.Build
 		result2 error
 	}
+	GetArchivableBuildsStub        func(int, int) ([]db.Build, error)
+	getArchivableBuildsMutex       sync.RWMutex
+	getArchivableBuildsArgsForCall []struct {
+		arg1 int
+		arg2 int
+	}
+	getArchivableBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	getArchivableBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
 	GetDrainableBuildsStub        func() ([]db.Build, error)
 	getDrainableBuildsMutex       sync.RWMutex
 	getDrainableBuildsArgsForCall []struct {
@@ -241,6 +292,200 @@ func (fake *FakeBuildFactory) BuildReturnsOnCall(i int, result1 db.Build, result
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuildFactory) ClaimSpanExportableBuilds(arg1 int) ([]db.Build, error) {
+	fake.claimSpanExportableBuildsMutex.Lock()
+	ret, specificReturn := fake.claimSpanExportableBuildsReturnsOnCall[len(fake.claimSpanExportableBuildsArgsForCall)]
+	fake.claimSpanExportableBuildsArgsForCall = append(fake.claimSpanExportableBuildsArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.ClaimSpanExportableBuildsStub
+	fakeReturns := fake.claimSpanExportableBuildsReturns
+	fake.recordInvocation("ClaimSpanExportableBuilds", []interface{}{arg1})
+	fake.claimSpanExportableBuildsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) ClaimSpanExportableBuildsCallCount() int {
+	fake.claimSpanExportableBuildsMutex.RLock()
+	defer fake.claimSpanExportableBuildsMutex.RUnlock()
+	return len(fake.claimSpanExportableBuildsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) ClaimSpanExportableBuildsCalls(stub func(int) ([]db.Build, error)) {
+	fake.claimSpanExportableBuildsMutex.Lock()
+	defer fake.claimSpanExportableBuildsMutex.Unlock()
+	fake.ClaimSpanExportableBuildsStub = stub
+}
+
+func (fake *FakeBuildFactory) ClaimSpanExportableBuildsArgsForCall(i int) int {
+	fake.claimSpanExportableBuildsMutex.RLock()
+	defer fake.claimSpanExportableBuildsMutex.RUnlock()
+	argsForCall := fake.claimSpanExportableBuildsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildFactory) ClaimSpanExportableBuildsReturns(result1 []db.Build, result2 error) {
+	fake.claimSpanExportableBuildsMutex.Lock()
+	defer fake.claimSpanExportableBuildsMutex.Unlock()
+	fake.ClaimSpanExportableBuildsStub = nil
+	fake.claimSpanExportableBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) ClaimSpanExportableBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.claimSpanExportableBuildsMutex.Lock()
+	defer fake.claimSpanExportableBuildsMutex.Unlock()
+	fake.ClaimSpanExportableBuildsStub = nil
+	if fake.claimSpanExportableBuildsReturnsOnCall == nil {
+		fake.claimSpanExportableBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.claimSpanExportableBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) DeletedEventsArchiveKeys(arg1 int) ([]string, error) {
+	fake.deletedEventsArchiveKeysMutex.Lock()
+	ret, specificReturn := fake.deletedEventsArchiveKeysReturnsOnCall[len(fake.deletedEventsArchiveKeysArgsForCall)]
+	fake.deletedEventsArchiveKeysArgsForCall = append(fake.deletedEventsArchiveKeysArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.DeletedEventsArchiveKeysStub
+	fakeReturns := fake.deletedEventsArchiveKeysReturns
+	fake.recordInvocation("DeletedEventsArchiveKeys", []interface{}{arg1})
+	fake.deletedEventsArchiveKeysMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) DeletedEventsArchiveKeysCallCount() int {
+	fake.deletedEventsArchiveKeysMutex.RLock()
+	defer fake.deletedEventsArchiveKeysMutex.RUnlock()
+	return len(fake.deletedEventsArchiveKeysArgsForCall)
+}
+
+func (fake *FakeBuildFactory) DeletedEventsArchiveKeysCalls(stub func(int) ([]string, error)) {
+	fake.deletedEventsArchiveKeysMutex.Lock()
+	defer fake.deletedEventsArchiveKeysMutex.Unlock()
+	fake.DeletedEventsArchiveKeysStub = stub
+}
+
+func (fake *FakeBuildFactory) DeletedEventsArchiveKeysArgsForCall(i int) int {
+	fake.deletedEventsArchiveKeysMutex.RLock()
+	defer fake.deletedEventsArchiveKeysMutex.RUnlock()
+	argsForCall := fake.deletedEventsArchiveKeysArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildFactory) DeletedEventsArchiveKeysReturns(result1 []string, result2 error) {
+	fake.deletedEventsArchiveKeysMutex.Lock()
+	defer fake.deletedEventsArchiveKeysMutex.Unlock()
+	fake.DeletedEventsArchiveKeysStub = nil
+	fake.deletedEventsArchiveKeysReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) DeletedEventsArchiveKeysReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.deletedEventsArchiveKeysMutex.Lock()
+	defer fake.deletedEventsArchiveKeysMutex.Unlock()
+	fake.DeletedEventsArchiveKeysStub = nil
+	if fake.deletedEventsArchiveKeysReturnsOnCall == nil {
+		fake.deletedEventsArchiveKeysReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.deletedEventsArchiveKeysReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) ForgetDeletedEventsArchiveKeys(arg1 []string) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.forgetDeletedEventsArchiveKeysMutex.Lock()
+	ret, specificReturn := fake.forgetDeletedEventsArchiveKeysReturnsOnCall[len(fake.forgetDeletedEventsArchiveKeysArgsForCall)]
+	fake.forgetDeletedEventsArchiveKeysArgsForCall = append(fake.forgetDeletedEventsArchiveKeysArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.ForgetDeletedEventsArchiveKeysStub
+	fakeReturns := fake.forgetDeletedEventsArchiveKeysReturns
+	fake.recordInvocation("ForgetDeletedEventsArchiveKeys", []interface{}{arg1Copy})
+	fake.forgetDeletedEventsArchiveKeysMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildFactory) ForgetDeletedEventsArchiveKeysCallCount() int {
+	fake.forgetDeletedEventsArchiveKeysMutex.RLock()
+	defer fake.forgetDeletedEventsArchiveKeysMutex.RUnlock()
+	return len(fake.forgetDeletedEventsArchiveKeysArgsForCall)
+}
+
+func (fake *FakeBuildFactory) ForgetDeletedEventsArchiveKeysCalls(stub func([]string) error) {
+	fake.forgetDeletedEventsArchiveKeysMutex.Lock()
+	defer fake.forgetDeletedEventsArchiveKeysMutex.Unlock()
+	fake.ForgetDeletedEventsArchiveKeysStub = stub
+}
+
+func (fake *FakeBuildFactory) ForgetDeletedEventsArchiveKeysArgsForCall(i int) []string {
+	fake.forgetDeletedEventsArchiveKeysMutex.RLock()
+	defer fake.forgetDeletedEventsArchiveKeysMutex.RUnlock()
+	argsForCall := fake.forgetDeletedEventsArchiveKeysArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildFactory) ForgetDeletedEventsArchiveKeysReturns(result1 error) {
+	fake.forgetDeletedEventsArchiveKeysMutex.Lock()
+	defer fake.forgetDeletedEventsArchiveKeysMutex.Unlock()
+	fake.ForgetDeletedEventsArchiveKeysStub = nil
+	fake.forgetDeletedEventsArchiveKeysReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildFactory) ForgetDeletedEventsArchiveKeysReturnsOnCall(i int, result1 error) {
+	fake.forgetDeletedEventsArchiveKeysMutex.Lock()
+	defer fake.forgetDeletedEventsArchiveKeysMutex.Unlock()
+	fake.ForgetDeletedEventsArchiveKeysStub = nil
+	if fake.forgetDeletedEventsArchiveKeysReturnsOnCall == nil {
+		fake.forgetDeletedEventsArchiveKeysReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.forgetDeletedEventsArchiveKeysReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuildFactory) GetAllStartedBuilds() ([]db.Build, error) {
 	fake.getAllStartedBuildsMutex.Lock()
 	ret, specificReturn := fake.getAllStartedBuildsReturnsOnCall[len(fake.getAllStartedBuildsArgsForCall)]
@@ -297,6 +542,71 @@ func (fake *FakeBuildFactory) GetAllStartedBuildsReturnsOnCall(i int, result1 []
 	}{result1, result2}
 }
 
+func (fake *FakeBuildFactory) GetArchivableBuilds(arg1 int, arg2 int) ([]db.Build, error) {
+	fake.getArchivableBuildsMutex.Lock()
+	ret, specificReturn := fake.getArchivableBuildsReturnsOnCall[len(fake.getArchivableBuildsArgsForCall)]
+	fake.getArchivableBuildsArgsForCall = append(fake.getArchivableBuildsArgsForCall, struct {
+		arg1 int
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.GetArchivableBuildsStub
+	fakeReturns := fake.getArchivableBuildsReturns
+	fake.recordInvocation("GetArchivableBuilds", []interface{}{arg1, arg2})
+	fake.getArchivableBuildsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsCallCount() int {
+	fake.getArchivableBuildsMutex.RLock()
+	defer fake.getArchivableBuildsMutex.RUnlock()
+	return len(fake.getArchivableBuildsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsCalls(stub func(int, int) ([]db.Build, error)) {
+	fake.getArchivableBuildsMutex.Lock()
+	defer fake.getArchivableBuildsMutex.Unlock()
+	fake.GetArchivableBuildsStub = stub
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsArgsForCall(i int) (int, int) {
+	fake.getArchivableBuildsMutex.RLock()
+	defer fake.getArchivableBuildsMutex.RUnlock()
+	argsForCall := fake.getArchivableBuildsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsReturns(result1 []db.Build, result2 error) {
+	fake.getArchivableBuildsMutex.Lock()
+	defer fake.getArchivableBuildsMutex.Unlock()
+	fake.GetArchivableBuildsStub = nil
+	fake.getArchivableBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.getArchivableBuildsMutex.Lock()
+	defer fake.getArchivableBuildsMutex.Unlock()
+	fake.GetArchivableBuildsStub = nil
+	if fake.getArchivableBuildsReturnsOnCall == nil {
+		fake.getArchivableBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.getArchivableBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildFactory) GetDrainableBuilds() ([]db.Build, error) {
 	fake.getDrainableBuildsMutex.Lock()
 	ret, specificReturn := fake.getDrainableBuildsReturnsOnCall[len(fake.getDrainableBuildsArgsForCall)]
@@ -553,8 +863,16 @@ func (fake *FakeBuildFactory) Invocations() map[string][][]interface{} {
 	defer fake.allBuildsMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
+	fake.claimSpanExportableBuildsMutex.RLock()
+	defer fake.claimSpanExportableBuildsMutex.RUnlock()
+	fake.deletedEventsArchiveKeysMutex.RLock()
+	defer fake.deletedEventsArchiveKeysMutex.RUnlock()
+	fake.forgetDeletedEventsArchiveKeysMutex.RLock()
+	defer fake.forgetDeletedEventsArchiveKeysMutex.RUnlock()
 	fake.getAllStartedBuildsMutex.RLock()
 	defer fake.getAllStartedBuildsMutex.RUnlock()
+	fake.getArchivableBuildsMutex.RLock()
+	defer fake.getArchivableBuildsMutex.RUnlock()
 	fake.getDrainableBuildsMutex.RLock()
 	defer fake.getDrainableBuildsMutex.RUnlock()
 	fake.markNonInterceptibleBuildsMutex.RLock()
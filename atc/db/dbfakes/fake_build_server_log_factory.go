@@ -0,0 +1,198 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeBuildServerLogFactory struct {
+	LogsForBuildStub        func(int) ([]lager.LogFormat, error)
+	logsForBuildMutex       sync.RWMutex
+	logsForBuildArgsForCall []struct {
+		arg1 int
+	}
+	logsForBuildReturns struct {
+		result1 []lager.LogFormat
+		result2 error
+	}
+	logsForBuildReturnsOnCall map[int]struct {
+		result1 []lager.LogFormat
+		result2 error
+	}
+	SaveLogsStub        func([]db.BuildServerLog, int) error
+	saveLogsMutex       sync.RWMutex
+	saveLogsArgsForCall []struct {
+		arg1 []db.BuildServerLog
+		arg2 int
+	}
+	saveLogsReturns struct {
+		result1 error
+	}
+	saveLogsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBuildServerLogFactory) LogsForBuild(arg1 int) ([]lager.LogFormat, error) {
+	fake.logsForBuildMutex.Lock()
+	ret, specificReturn := fake.logsForBuildReturnsOnCall[len(fake.logsForBuildArgsForCall)]
+	fake.logsForBuildArgsForCall = append(fake.logsForBuildArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.LogsForBuildStub
+	fakeReturns := fake.logsForBuildReturns
+	fake.recordInvocation("LogsForBuild", []interface{}{arg1})
+	fake.logsForBuildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildServerLogFactory) LogsForBuildCallCount() int {
+	fake.logsForBuildMutex.RLock()
+	defer fake.logsForBuildMutex.RUnlock()
+	return len(fake.logsForBuildArgsForCall)
+}
+
+func (fake *FakeBuildServerLogFactory) LogsForBuildCalls(stub func(int) ([]lager.LogFormat, error)) {
+	fake.logsForBuildMutex.Lock()
+	defer fake.logsForBuildMutex.Unlock()
+	fake.LogsForBuildStub = stub
+}
+
+func (fake *FakeBuildServerLogFactory) LogsForBuildArgsForCall(i int) int {
+	fake.logsForBuildMutex.RLock()
+	defer fake.logsForBuildMutex.RUnlock()
+	argsForCall := fake.logsForBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildServerLogFactory) LogsForBuildReturns(result1 []lager.LogFormat, result2 error) {
+	fake.logsForBuildMutex.Lock()
+	defer fake.logsForBuildMutex.Unlock()
+	fake.LogsForBuildStub = nil
+	fake.logsForBuildReturns = struct {
+		result1 []lager.LogFormat
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildServerLogFactory) LogsForBuildReturnsOnCall(i int, result1 []lager.LogFormat, result2 error) {
+	fake.logsForBuildMutex.Lock()
+	defer fake.logsForBuildMutex.Unlock()
+	fake.LogsForBuildStub = nil
+	if fake.logsForBuildReturnsOnCall == nil {
+		fake.logsForBuildReturnsOnCall = make(map[int]struct {
+			result1 []lager.LogFormat
+			result2 error
+		})
+	}
+	fake.logsForBuildReturnsOnCall[i] = struct {
+		result1 []lager.LogFormat
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildServerLogFactory) SaveLogs(arg1 []db.BuildServerLog, arg2 int) error {
+	var arg1Copy []db.BuildServerLog
+	if arg1 != nil {
+		arg1Copy = make([]db.BuildServerLog, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.saveLogsMutex.Lock()
+	ret, specificReturn := fake.saveLogsReturnsOnCall[len(fake.saveLogsArgsForCall)]
+	fake.saveLogsArgsForCall = append(fake.saveLogsArgsForCall, struct {
+		arg1 []db.BuildServerLog
+		arg2 int
+	}{arg1Copy, arg2})
+	stub := fake.SaveLogsStub
+	fakeReturns := fake.saveLogsReturns
+	fake.recordInvocation("SaveLogs", []interface{}{arg1Copy, arg2})
+	fake.saveLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildServerLogFactory) SaveLogsCallCount() int {
+	fake.saveLogsMutex.RLock()
+	defer fake.saveLogsMutex.RUnlock()
+	return len(fake.saveLogsArgsForCall)
+}
+
+func (fake *FakeBuildServerLogFactory) SaveLogsCalls(stub func([]db.BuildServerLog, int) error) {
+	fake.saveLogsMutex.Lock()
+	defer fake.saveLogsMutex.Unlock()
+	fake.SaveLogsStub = stub
+}
+
+func (fake *FakeBuildServerLogFactory) SaveLogsArgsForCall(i int) ([]db.BuildServerLog, int) {
+	fake.saveLogsMutex.RLock()
+	defer fake.saveLogsMutex.RUnlock()
+	argsForCall := fake.saveLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuildServerLogFactory) SaveLogsReturns(result1 error) {
+	fake.saveLogsMutex.Lock()
+	defer fake.saveLogsMutex.Unlock()
+	fake.SaveLogsStub = nil
+	fake.saveLogsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildServerLogFactory) SaveLogsReturnsOnCall(i int, result1 error) {
+	fake.saveLogsMutex.Lock()
+	defer fake.saveLogsMutex.Unlock()
+	fake.SaveLogsStub = nil
+	if fake.saveLogsReturnsOnCall == nil {
+		fake.saveLogsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveLogsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildServerLogFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.logsForBuildMutex.RLock()
+	defer fake.logsForBuildMutex.RUnlock()
+	fake.saveLogsMutex.RLock()
+	defer fake.saveLogsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeBuildServerLogFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.BuildServerLogFactory = new(FakeBuildServerLogFactory)
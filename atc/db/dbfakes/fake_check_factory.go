@@ -4,12 +4,24 @@ package dbfakes
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
 type FakeCheckFactory struct {
+	CheckIntervalStub        func(db.Checkable) time.Duration
+	checkIntervalMutex       sync.RWMutex
+	checkIntervalArgsForCall []struct {
+		arg1 db.Checkable
+	}
+	checkIntervalReturns struct {
+		result1 time.Duration
+	}
+	checkIntervalReturnsOnCall map[int]struct {
+		result1 time.Duration
+	}
 	ResourceTypesStub        func() ([]db.ResourceType, error)
 	resourceTypesMutex       sync.RWMutex
 	resourceTypesArgsForCall []struct {
@@ -57,6 +69,67 @@ type FakeCheckFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeCheckFactory) CheckInterval(arg1 db.Checkable) time.Duration {
+	fake.checkIntervalMutex.Lock()
+	ret, specificReturn := fake.checkIntervalReturnsOnCall[len(fake.checkIntervalArgsForCall)]
+	fake.checkIntervalArgsForCall = append(fake.checkIntervalArgsForCall, struct {
+		arg1 db.Checkable
+	}{arg1})
+	stub := fake.CheckIntervalStub
+	fakeReturns := fake.checkIntervalReturns
+	fake.recordInvocation("CheckInterval", []interface{}{arg1})
+	fake.checkIntervalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckFactory) CheckIntervalCallCount() int {
+	fake.checkIntervalMutex.RLock()
+	defer fake.checkIntervalMutex.RUnlock()
+	return len(fake.checkIntervalArgsForCall)
+}
+
+func (fake *FakeCheckFactory) CheckIntervalCalls(stub func(db.Checkable) time.Duration) {
+	fake.checkIntervalMutex.Lock()
+	defer fake.checkIntervalMutex.Unlock()
+	fake.CheckIntervalStub = stub
+}
+
+func (fake *FakeCheckFactory) CheckIntervalArgsForCall(i int) db.Checkable {
+	fake.checkIntervalMutex.RLock()
+	defer fake.checkIntervalMutex.RUnlock()
+	argsForCall := fake.checkIntervalArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheckFactory) CheckIntervalReturns(result1 time.Duration) {
+	fake.checkIntervalMutex.Lock()
+	defer fake.checkIntervalMutex.Unlock()
+	fake.CheckIntervalStub = nil
+	fake.checkIntervalReturns = struct {
+		result1 time.Duration
+	}{result1}
+}
+
+func (fake *FakeCheckFactory) CheckIntervalReturnsOnCall(i int, result1 time.Duration) {
+	fake.checkIntervalMutex.Lock()
+	defer fake.checkIntervalMutex.Unlock()
+	fake.CheckIntervalStub = nil
+	if fake.checkIntervalReturnsOnCall == nil {
+		fake.checkIntervalReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+		})
+	}
+	fake.checkIntervalReturnsOnCall[i] = struct {
+		result1 time.Duration
+	}{result1}
+}
+
 func (fake *FakeCheckFactory) ResourceTypes() ([]db.ResourceType, error) {
 	fake.resourceTypesMutex.Lock()
 	ret, specificReturn := fake.resourceTypesReturnsOnCall[len(fake.resourceTypesArgsForCall)]
@@ -243,6 +316,8 @@ func (fake *FakeCheckFactory) TryCreateCheckReturnsOnCall(i int, result1 db.Buil
 func (fake *FakeCheckFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.checkIntervalMutex.RLock()
+	defer fake.checkIntervalMutex.RUnlock()
 	fake.resourceTypesMutex.RLock()
 	defer fake.resourceTypesMutex.RUnlock()
 	fake.resourcesMutex.RLock()
@@ -35,6 +35,16 @@ type FakeCheckable struct {
 	checkPlanReturnsOnCall map[int]struct {
 		result1 atc.CheckPlan
 	}
+	CheckTTLStub        func() time.Duration
+	checkTTLMutex       sync.RWMutex
+	checkTTLArgsForCall []struct {
+	}
+	checkTTLReturns struct {
+		result1 time.Duration
+	}
+	checkTTLReturnsOnCall map[int]struct {
+		result1 time.Duration
+	}
 	CheckTimeoutStub        func() string
 	checkTimeoutMutex       sync.RWMutex
 	checkTimeoutArgsForCall []struct {
@@ -92,6 +102,16 @@ type FakeCheckable struct {
 	lastCheckEndTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	LastScheduledCheckEndTimeStub        func() time.Time
+	lastScheduledCheckEndTimeMutex       sync.RWMutex
+	lastScheduledCheckEndTimeArgsForCall []struct {
+	}
+	lastScheduledCheckEndTimeReturns struct {
+		result1 time.Time
+	}
+	lastScheduledCheckEndTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -337,6 +357,59 @@ func (fake *FakeCheckable) CheckPlanReturnsOnCall(i int, result1 atc.CheckPlan)
 	}{result1}
 }
 
+func (fake *FakeCheckable) CheckTTL() time.Duration {
+	fake.checkTTLMutex.Lock()
+	ret, specificReturn := fake.checkTTLReturnsOnCall[len(fake.checkTTLArgsForCall)]
+	fake.checkTTLArgsForCall = append(fake.checkTTLArgsForCall, struct {
+	}{})
+	stub := fake.CheckTTLStub
+	fakeReturns := fake.checkTTLReturns
+	fake.recordInvocation("CheckTTL", []interface{}{})
+	fake.checkTTLMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckable) CheckTTLCallCount() int {
+	fake.checkTTLMutex.RLock()
+	defer fake.checkTTLMutex.RUnlock()
+	return len(fake.checkTTLArgsForCall)
+}
+
+func (fake *FakeCheckable) CheckTTLCalls(stub func() time.Duration) {
+	fake.checkTTLMutex.Lock()
+	defer fake.checkTTLMutex.Unlock()
+	fake.CheckTTLStub = stub
+}
+
+func (fake *FakeCheckable) CheckTTLReturns(result1 time.Duration) {
+	fake.checkTTLMutex.Lock()
+	defer fake.checkTTLMutex.Unlock()
+	fake.CheckTTLStub = nil
+	fake.checkTTLReturns = struct {
+		result1 time.Duration
+	}{result1}
+}
+
+func (fake *FakeCheckable) CheckTTLReturnsOnCall(i int, result1 time.Duration) {
+	fake.checkTTLMutex.Lock()
+	defer fake.checkTTLMutex.Unlock()
+	fake.CheckTTLStub = nil
+	if fake.checkTTLReturnsOnCall == nil {
+		fake.checkTTLReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+		})
+	}
+	fake.checkTTLReturnsOnCall[i] = struct {
+		result1 time.Duration
+	}{result1}
+}
+
 func (fake *FakeCheckable) CheckTimeout() string {
 	fake.checkTimeoutMutex.Lock()
 	ret, specificReturn := fake.checkTimeoutReturnsOnCall[len(fake.checkTimeoutArgsForCall)]
@@ -618,6 +691,59 @@ func (fake *FakeCheckable) LastCheckEndTimeReturnsOnCall(i int, result1 time.Tim
 	}{result1}
 }
 
+func (fake *FakeCheckable) LastScheduledCheckEndTime() time.Time {
+	fake.lastScheduledCheckEndTimeMutex.Lock()
+	ret, specificReturn := fake.lastScheduledCheckEndTimeReturnsOnCall[len(fake.lastScheduledCheckEndTimeArgsForCall)]
+	fake.lastScheduledCheckEndTimeArgsForCall = append(fake.lastScheduledCheckEndTimeArgsForCall, struct {
+	}{})
+	stub := fake.LastScheduledCheckEndTimeStub
+	fakeReturns := fake.lastScheduledCheckEndTimeReturns
+	fake.recordInvocation("LastScheduledCheckEndTime", []interface{}{})
+	fake.lastScheduledCheckEndTimeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckable) LastScheduledCheckEndTimeCallCount() int {
+	fake.lastScheduledCheckEndTimeMutex.RLock()
+	defer fake.lastScheduledCheckEndTimeMutex.RUnlock()
+	return len(fake.lastScheduledCheckEndTimeArgsForCall)
+}
+
+func (fake *FakeCheckable) LastScheduledCheckEndTimeCalls(stub func() time.Time) {
+	fake.lastScheduledCheckEndTimeMutex.Lock()
+	defer fake.lastScheduledCheckEndTimeMutex.Unlock()
+	fake.LastScheduledCheckEndTimeStub = stub
+}
+
+func (fake *FakeCheckable) LastScheduledCheckEndTimeReturns(result1 time.Time) {
+	fake.lastScheduledCheckEndTimeMutex.Lock()
+	defer fake.lastScheduledCheckEndTimeMutex.Unlock()
+	fake.LastScheduledCheckEndTimeStub = nil
+	fake.lastScheduledCheckEndTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeCheckable) LastScheduledCheckEndTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.lastScheduledCheckEndTimeMutex.Lock()
+	defer fake.lastScheduledCheckEndTimeMutex.Unlock()
+	fake.LastScheduledCheckEndTimeStub = nil
+	if fake.lastScheduledCheckEndTimeReturnsOnCall == nil {
+		fake.lastScheduledCheckEndTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.lastScheduledCheckEndTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeCheckable) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -1267,6 +1393,8 @@ func (fake *FakeCheckable) Invocations() map[string][][]interface{} {
 	defer fake.checkEveryMutex.RUnlock()
 	fake.checkPlanMutex.RLock()
 	defer fake.checkPlanMutex.RUnlock()
+	fake.checkTTLMutex.RLock()
+	defer fake.checkTTLMutex.RUnlock()
 	fake.checkTimeoutMutex.RLock()
 	defer fake.checkTimeoutMutex.RUnlock()
 	fake.createBuildMutex.RLock()
@@ -1277,6 +1405,8 @@ func (fake *FakeCheckable) Invocations() map[string][][]interface{} {
 	defer fake.hasWebhookMutex.RUnlock()
 	fake.lastCheckEndTimeMutex.RLock()
 	defer fake.lastCheckEndTimeMutex.RUnlock()
+	fake.lastScheduledCheckEndTimeMutex.RLock()
+	defer fake.lastScheduledCheckEndTimeMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.pipelineMutex.RLock()
@@ -9,6 +9,18 @@ import (
 )
 
 type FakeContainerRepository struct {
+	CountDestroyingContainersByWorkerStub        func() (map[string]int, error)
+	countDestroyingContainersByWorkerMutex       sync.RWMutex
+	countDestroyingContainersByWorkerArgsForCall []struct {
+	}
+	countDestroyingContainersByWorkerReturns struct {
+		result1 map[string]int
+		result2 error
+	}
+	countDestroyingContainersByWorkerReturnsOnCall map[int]struct {
+		result1 map[string]int
+		result2 error
+	}
 	DestroyFailedContainersStub        func() (int, error)
 	destroyFailedContainersMutex       sync.RWMutex
 	destroyFailedContainersArgsForCall []struct {
@@ -107,6 +119,62 @@ type FakeContainerRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeContainerRepository) CountDestroyingContainersByWorker() (map[string]int, error) {
+	fake.countDestroyingContainersByWorkerMutex.Lock()
+	ret, specificReturn := fake.countDestroyingContainersByWorkerReturnsOnCall[len(fake.countDestroyingContainersByWorkerArgsForCall)]
+	fake.countDestroyingContainersByWorkerArgsForCall = append(fake.countDestroyingContainersByWorkerArgsForCall, struct {
+	}{})
+	stub := fake.CountDestroyingContainersByWorkerStub
+	fakeReturns := fake.countDestroyingContainersByWorkerReturns
+	fake.recordInvocation("CountDestroyingContainersByWorker", []interface{}{})
+	fake.countDestroyingContainersByWorkerMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeContainerRepository) CountDestroyingContainersByWorkerCallCount() int {
+	fake.countDestroyingContainersByWorkerMutex.RLock()
+	defer fake.countDestroyingContainersByWorkerMutex.RUnlock()
+	return len(fake.countDestroyingContainersByWorkerArgsForCall)
+}
+
+func (fake *FakeContainerRepository) CountDestroyingContainersByWorkerCalls(stub func() (map[string]int, error)) {
+	fake.countDestroyingContainersByWorkerMutex.Lock()
+	defer fake.countDestroyingContainersByWorkerMutex.Unlock()
+	fake.CountDestroyingContainersByWorkerStub = stub
+}
+
+func (fake *FakeContainerRepository) CountDestroyingContainersByWorkerReturns(result1 map[string]int, result2 error) {
+	fake.countDestroyingContainersByWorkerMutex.Lock()
+	defer fake.countDestroyingContainersByWorkerMutex.Unlock()
+	fake.CountDestroyingContainersByWorkerStub = nil
+	fake.countDestroyingContainersByWorkerReturns = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContainerRepository) CountDestroyingContainersByWorkerReturnsOnCall(i int, result1 map[string]int, result2 error) {
+	fake.countDestroyingContainersByWorkerMutex.Lock()
+	defer fake.countDestroyingContainersByWorkerMutex.Unlock()
+	fake.CountDestroyingContainersByWorkerStub = nil
+	if fake.countDestroyingContainersByWorkerReturnsOnCall == nil {
+		fake.countDestroyingContainersByWorkerReturnsOnCall = make(map[int]struct {
+			result1 map[string]int
+			result2 error
+		})
+	}
+	fake.countDestroyingContainersByWorkerReturnsOnCall[i] = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeContainerRepository) DestroyFailedContainers() (int, error) {
 	fake.destroyFailedContainersMutex.Lock()
 	ret, specificReturn := fake.destroyFailedContainersReturnsOnCall[len(fake.destroyFailedContainersArgsForCall)]
@@ -563,6 +631,8 @@ func (fake *FakeContainerRepository) UpdateContainersMissingSinceReturnsOnCall(i
 func (fake *FakeContainerRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.countDestroyingContainersByWorkerMutex.RLock()
+	defer fake.countDestroyingContainersByWorkerMutex.RUnlock()
 	fake.destroyFailedContainersMutex.RLock()
 	defer fake.destroyFailedContainersMutex.RUnlock()
 	fake.destroyUnknownContainersMutex.RLock()
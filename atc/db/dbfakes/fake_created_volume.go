@@ -159,6 +159,20 @@ type FakeCreatedVolume struct {
 		result1 *db.VolumeResourceType
 		result2 error
 	}
+	SizeStub        func() (uint64, bool, error)
+	sizeMutex       sync.RWMutex
+	sizeArgsForCall []struct {
+	}
+	sizeReturns struct {
+		result1 uint64
+		result2 bool
+		result3 error
+	}
+	sizeReturnsOnCall map[int]struct {
+		result1 uint64
+		result2 bool
+		result3 error
+	}
 	TaskIdentifierStub        func() (int, atc.PipelineRef, string, string, error)
 	taskIdentifierMutex       sync.RWMutex
 	taskIdentifierArgsForCall []struct {
@@ -970,6 +984,65 @@ func (fake *FakeCreatedVolume) ResourceTypeReturnsOnCall(i int, result1 *db.Volu
 	}{result1, result2}
 }
 
+func (fake *FakeCreatedVolume) Size() (uint64, bool, error) {
+	fake.sizeMutex.Lock()
+	ret, specificReturn := fake.sizeReturnsOnCall[len(fake.sizeArgsForCall)]
+	fake.sizeArgsForCall = append(fake.sizeArgsForCall, struct {
+	}{})
+	stub := fake.SizeStub
+	fakeReturns := fake.sizeReturns
+	fake.recordInvocation("Size", []interface{}{})
+	fake.sizeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeCreatedVolume) SizeCallCount() int {
+	fake.sizeMutex.RLock()
+	defer fake.sizeMutex.RUnlock()
+	return len(fake.sizeArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) SizeCalls(stub func() (uint64, bool, error)) {
+	fake.sizeMutex.Lock()
+	defer fake.sizeMutex.Unlock()
+	fake.SizeStub = stub
+}
+
+func (fake *FakeCreatedVolume) SizeReturns(result1 uint64, result2 bool, result3 error) {
+	fake.sizeMutex.Lock()
+	defer fake.sizeMutex.Unlock()
+	fake.SizeStub = nil
+	fake.sizeReturns = struct {
+		result1 uint64
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCreatedVolume) SizeReturnsOnCall(i int, result1 uint64, result2 bool, result3 error) {
+	fake.sizeMutex.Lock()
+	defer fake.sizeMutex.Unlock()
+	fake.SizeStub = nil
+	if fake.sizeReturnsOnCall == nil {
+		fake.sizeReturnsOnCall = make(map[int]struct {
+			result1 uint64
+			result2 bool
+			result3 error
+		})
+	}
+	fake.sizeReturnsOnCall[i] = struct {
+		result1 uint64
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeCreatedVolume) TaskIdentifier() (int, atc.PipelineRef, string, string, error) {
 	fake.taskIdentifierMutex.Lock()
 	ret, specificReturn := fake.taskIdentifierReturnsOnCall[len(fake.taskIdentifierArgsForCall)]
@@ -1276,6 +1349,8 @@ func (fake *FakeCreatedVolume) Invocations() map[string][][]interface{} {
 	defer fake.pathMutex.RUnlock()
 	fake.resourceTypeMutex.RLock()
 	defer fake.resourceTypeMutex.RUnlock()
+	fake.sizeMutex.RLock()
+	defer fake.sizeMutex.RUnlock()
 	fake.taskIdentifierMutex.RLock()
 	defer fake.taskIdentifierMutex.RUnlock()
 	fake.teamIDMutex.RLock()
@@ -0,0 +1,255 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeEncryptionKeyRotation struct {
+	ProgressStub        func() ([]db.EncryptionKeyRotationProgress, error)
+	progressMutex       sync.RWMutex
+	progressArgsForCall []struct {
+	}
+	progressReturns struct {
+		result1 []db.EncryptionKeyRotationProgress
+		result2 error
+	}
+	progressReturnsOnCall map[int]struct {
+		result1 []db.EncryptionKeyRotationProgress
+		result2 error
+	}
+	RotateStub        func(context.Context, lager.Logger) error
+	rotateMutex       sync.RWMutex
+	rotateArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+	}
+	rotateReturns struct {
+		result1 error
+	}
+	rotateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StartStub        func() (bool, error)
+	startMutex       sync.RWMutex
+	startArgsForCall []struct {
+	}
+	startReturns struct {
+		result1 bool
+		result2 error
+	}
+	startReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeEncryptionKeyRotation) Progress() ([]db.EncryptionKeyRotationProgress, error) {
+	fake.progressMutex.Lock()
+	ret, specificReturn := fake.progressReturnsOnCall[len(fake.progressArgsForCall)]
+	fake.progressArgsForCall = append(fake.progressArgsForCall, struct {
+	}{})
+	stub := fake.ProgressStub
+	fakeReturns := fake.progressReturns
+	fake.recordInvocation("Progress", []interface{}{})
+	fake.progressMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeEncryptionKeyRotation) ProgressCallCount() int {
+	fake.progressMutex.RLock()
+	defer fake.progressMutex.RUnlock()
+	return len(fake.progressArgsForCall)
+}
+
+func (fake *FakeEncryptionKeyRotation) ProgressCalls(stub func() ([]db.EncryptionKeyRotationProgress, error)) {
+	fake.progressMutex.Lock()
+	defer fake.progressMutex.Unlock()
+	fake.ProgressStub = stub
+}
+
+func (fake *FakeEncryptionKeyRotation) ProgressReturns(result1 []db.EncryptionKeyRotationProgress, result2 error) {
+	fake.progressMutex.Lock()
+	defer fake.progressMutex.Unlock()
+	fake.ProgressStub = nil
+	fake.progressReturns = struct {
+		result1 []db.EncryptionKeyRotationProgress
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEncryptionKeyRotation) ProgressReturnsOnCall(i int, result1 []db.EncryptionKeyRotationProgress, result2 error) {
+	fake.progressMutex.Lock()
+	defer fake.progressMutex.Unlock()
+	fake.ProgressStub = nil
+	if fake.progressReturnsOnCall == nil {
+		fake.progressReturnsOnCall = make(map[int]struct {
+			result1 []db.EncryptionKeyRotationProgress
+			result2 error
+		})
+	}
+	fake.progressReturnsOnCall[i] = struct {
+		result1 []db.EncryptionKeyRotationProgress
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEncryptionKeyRotation) Rotate(arg1 context.Context, arg2 lager.Logger) error {
+	fake.rotateMutex.Lock()
+	ret, specificReturn := fake.rotateReturnsOnCall[len(fake.rotateArgsForCall)]
+	fake.rotateArgsForCall = append(fake.rotateArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+	}{arg1, arg2})
+	stub := fake.RotateStub
+	fakeReturns := fake.rotateReturns
+	fake.recordInvocation("Rotate", []interface{}{arg1, arg2})
+	fake.rotateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeEncryptionKeyRotation) RotateCallCount() int {
+	fake.rotateMutex.RLock()
+	defer fake.rotateMutex.RUnlock()
+	return len(fake.rotateArgsForCall)
+}
+
+func (fake *FakeEncryptionKeyRotation) RotateCalls(stub func(context.Context, lager.Logger) error) {
+	fake.rotateMutex.Lock()
+	defer fake.rotateMutex.Unlock()
+	fake.RotateStub = stub
+}
+
+func (fake *FakeEncryptionKeyRotation) RotateArgsForCall(i int) (context.Context, lager.Logger) {
+	fake.rotateMutex.RLock()
+	defer fake.rotateMutex.RUnlock()
+	argsForCall := fake.rotateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeEncryptionKeyRotation) RotateReturns(result1 error) {
+	fake.rotateMutex.Lock()
+	defer fake.rotateMutex.Unlock()
+	fake.RotateStub = nil
+	fake.rotateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEncryptionKeyRotation) RotateReturnsOnCall(i int, result1 error) {
+	fake.rotateMutex.Lock()
+	defer fake.rotateMutex.Unlock()
+	fake.RotateStub = nil
+	if fake.rotateReturnsOnCall == nil {
+		fake.rotateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.rotateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEncryptionKeyRotation) Start() (bool, error) {
+	fake.startMutex.Lock()
+	ret, specificReturn := fake.startReturnsOnCall[len(fake.startArgsForCall)]
+	fake.startArgsForCall = append(fake.startArgsForCall, struct {
+	}{})
+	stub := fake.StartStub
+	fakeReturns := fake.startReturns
+	fake.recordInvocation("Start", []interface{}{})
+	fake.startMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeEncryptionKeyRotation) StartCallCount() int {
+	fake.startMutex.RLock()
+	defer fake.startMutex.RUnlock()
+	return len(fake.startArgsForCall)
+}
+
+func (fake *FakeEncryptionKeyRotation) StartCalls(stub func() (bool, error)) {
+	fake.startMutex.Lock()
+	defer fake.startMutex.Unlock()
+	fake.StartStub = stub
+}
+
+func (fake *FakeEncryptionKeyRotation) StartReturns(result1 bool, result2 error) {
+	fake.startMutex.Lock()
+	defer fake.startMutex.Unlock()
+	fake.StartStub = nil
+	fake.startReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEncryptionKeyRotation) StartReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.startMutex.Lock()
+	defer fake.startMutex.Unlock()
+	fake.StartStub = nil
+	if fake.startReturnsOnCall == nil {
+		fake.startReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.startReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEncryptionKeyRotation) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.progressMutex.RLock()
+	defer fake.progressMutex.RUnlock()
+	fake.rotateMutex.RLock()
+	defer fake.rotateMutex.RUnlock()
+	fake.startMutex.RLock()
+	defer fake.startMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeEncryptionKeyRotation) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.EncryptionKeyRotation = new(FakeEncryptionKeyRotation)
@@ -40,6 +40,18 @@ type FakeJob struct {
 		result1 db.InputConfigs
 		result2 error
 	}
+	AwaitingResourceChecksStub        func() (bool, error)
+	awaitingResourceChecksMutex       sync.RWMutex
+	awaitingResourceChecksArgsForCall []struct {
+	}
+	awaitingResourceChecksReturns struct {
+		result1 bool
+		result2 error
+	}
+	awaitingResourceChecksReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	BuildStub        func(string) (db.Build, bool, error)
 	buildMutex       sync.RWMutex
 	buildArgsForCall []struct {
@@ -124,6 +136,20 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateBuildWithVarsStub        func(string, db.TriggerVars) (db.Build, error)
+	createBuildWithVarsMutex       sync.RWMutex
+	createBuildWithVarsArgsForCall []struct {
+		arg1 string
+		arg2 db.TriggerVars
+	}
+	createBuildWithVarsReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createBuildWithVarsReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	DisableManualTriggerStub        func() bool
 	disableManualTriggerMutex       sync.RWMutex
 	disableManualTriggerArgsForCall []struct {
@@ -239,6 +265,20 @@ type FakeJob struct {
 		result1 []atc.JobInput
 		result2 error
 	}
+	LatestSucceededBuildStub        func() (db.Build, bool, error)
+	latestSucceededBuildMutex       sync.RWMutex
+	latestSucceededBuildArgsForCall []struct {
+	}
+	latestSucceededBuildReturns struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	latestSucceededBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
 	MaxInFlightStub        func() int
 	maxInFlightMutex       sync.RWMutex
 	maxInFlightArgsForCall []struct {
@@ -391,6 +431,20 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	RerunBuildWithLatestIfUnavailableStub        func(db.Build, string) (db.Build, error)
+	rerunBuildWithLatestIfUnavailableMutex       sync.RWMutex
+	rerunBuildWithLatestIfUnavailableArgsForCall []struct {
+		arg1 db.Build
+		arg2 string
+	}
+	rerunBuildWithLatestIfUnavailableReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	rerunBuildWithLatestIfUnavailableReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	SaveNextInputMappingStub        func(db.InputMapping, bool) error
 	saveNextInputMappingMutex       sync.RWMutex
 	saveNextInputMappingArgsForCall []struct {
@@ -403,6 +457,17 @@ type FakeJob struct {
 	saveNextInputMappingReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveSchedulerDecisionStub        func(atc.SchedulerDecision) error
+	saveSchedulerDecisionMutex       sync.RWMutex
+	saveSchedulerDecisionArgsForCall []struct {
+		arg1 atc.SchedulerDecision
+	}
+	saveSchedulerDecisionReturns struct {
+		result1 error
+	}
+	saveSchedulerDecisionReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ScheduleBuildStub        func(db.Build) (bool, error)
 	scheduleBuildMutex       sync.RWMutex
 	scheduleBuildArgsForCall []struct {
@@ -426,6 +491,20 @@ type FakeJob struct {
 	scheduleRequestedTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	SchedulerDecisionStub        func() (atc.SchedulerDecision, bool, error)
+	schedulerDecisionMutex       sync.RWMutex
+	schedulerDecisionArgsForCall []struct {
+	}
+	schedulerDecisionReturns struct {
+		result1 atc.SchedulerDecision
+		result2 bool
+		result3 error
+	}
+	schedulerDecisionReturnsOnCall map[int]struct {
+		result1 atc.SchedulerDecision
+		result2 bool
+		result3 error
+	}
 	SetHasNewInputsStub        func(bool) error
 	setHasNewInputsMutex       sync.RWMutex
 	setHasNewInputsArgsForCall []struct {
@@ -457,6 +536,19 @@ type FakeJob struct {
 	teamIDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	TeamMaxConcurrentBuildsReachedStub        func(db.Build) (bool, error)
+	teamMaxConcurrentBuildsReachedMutex       sync.RWMutex
+	teamMaxConcurrentBuildsReachedArgsForCall []struct {
+		arg1 db.Build
+	}
+	teamMaxConcurrentBuildsReachedReturns struct {
+		result1 bool
+		result2 error
+	}
+	teamMaxConcurrentBuildsReachedReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	TeamNameStub        func() string
 	teamNameMutex       sync.RWMutex
 	teamNameArgsForCall []struct {
@@ -626,6 +718,62 @@ func (fake *FakeJob) AlgorithmInputsReturnsOnCall(i int, result1 db.InputConfigs
 	}{result1, result2}
 }
 
+func (fake *FakeJob) AwaitingResourceChecks() (bool, error) {
+	fake.awaitingResourceChecksMutex.Lock()
+	ret, specificReturn := fake.awaitingResourceChecksReturnsOnCall[len(fake.awaitingResourceChecksArgsForCall)]
+	fake.awaitingResourceChecksArgsForCall = append(fake.awaitingResourceChecksArgsForCall, struct {
+	}{})
+	stub := fake.AwaitingResourceChecksStub
+	fakeReturns := fake.awaitingResourceChecksReturns
+	fake.recordInvocation("AwaitingResourceChecks", []interface{}{})
+	fake.awaitingResourceChecksMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) AwaitingResourceChecksCallCount() int {
+	fake.awaitingResourceChecksMutex.RLock()
+	defer fake.awaitingResourceChecksMutex.RUnlock()
+	return len(fake.awaitingResourceChecksArgsForCall)
+}
+
+func (fake *FakeJob) AwaitingResourceChecksCalls(stub func() (bool, error)) {
+	fake.awaitingResourceChecksMutex.Lock()
+	defer fake.awaitingResourceChecksMutex.Unlock()
+	fake.AwaitingResourceChecksStub = stub
+}
+
+func (fake *FakeJob) AwaitingResourceChecksReturns(result1 bool, result2 error) {
+	fake.awaitingResourceChecksMutex.Lock()
+	defer fake.awaitingResourceChecksMutex.Unlock()
+	fake.AwaitingResourceChecksStub = nil
+	fake.awaitingResourceChecksReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) AwaitingResourceChecksReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.awaitingResourceChecksMutex.Lock()
+	defer fake.awaitingResourceChecksMutex.Unlock()
+	fake.AwaitingResourceChecksStub = nil
+	if fake.awaitingResourceChecksReturnsOnCall == nil {
+		fake.awaitingResourceChecksReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.awaitingResourceChecksReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) Build(arg1 string) (db.Build, bool, error) {
 	fake.buildMutex.Lock()
 	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
@@ -1012,6 +1160,71 @@ func (fake *FakeJob) CreateBuildReturnsOnCall(i int, result1 db.Build, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeJob) CreateBuildWithVars(arg1 string, arg2 db.TriggerVars) (db.Build, error) {
+	fake.createBuildWithVarsMutex.Lock()
+	ret, specificReturn := fake.createBuildWithVarsReturnsOnCall[len(fake.createBuildWithVarsArgsForCall)]
+	fake.createBuildWithVarsArgsForCall = append(fake.createBuildWithVarsArgsForCall, struct {
+		arg1 string
+		arg2 db.TriggerVars
+	}{arg1, arg2})
+	stub := fake.CreateBuildWithVarsStub
+	fakeReturns := fake.createBuildWithVarsReturns
+	fake.recordInvocation("CreateBuildWithVars", []interface{}{arg1, arg2})
+	fake.createBuildWithVarsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) CreateBuildWithVarsCallCount() int {
+	fake.createBuildWithVarsMutex.RLock()
+	defer fake.createBuildWithVarsMutex.RUnlock()
+	return len(fake.createBuildWithVarsArgsForCall)
+}
+
+func (fake *FakeJob) CreateBuildWithVarsCalls(stub func(string, db.TriggerVars) (db.Build, error)) {
+	fake.createBuildWithVarsMutex.Lock()
+	defer fake.createBuildWithVarsMutex.Unlock()
+	fake.CreateBuildWithVarsStub = stub
+}
+
+func (fake *FakeJob) CreateBuildWithVarsArgsForCall(i int) (string, db.TriggerVars) {
+	fake.createBuildWithVarsMutex.RLock()
+	defer fake.createBuildWithVarsMutex.RUnlock()
+	argsForCall := fake.createBuildWithVarsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeJob) CreateBuildWithVarsReturns(result1 db.Build, result2 error) {
+	fake.createBuildWithVarsMutex.Lock()
+	defer fake.createBuildWithVarsMutex.Unlock()
+	fake.CreateBuildWithVarsStub = nil
+	fake.createBuildWithVarsReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithVarsReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createBuildWithVarsMutex.Lock()
+	defer fake.createBuildWithVarsMutex.Unlock()
+	fake.CreateBuildWithVarsStub = nil
+	if fake.createBuildWithVarsReturnsOnCall == nil {
+		fake.createBuildWithVarsReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createBuildWithVarsReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) DisableManualTrigger() bool {
 	fake.disableManualTriggerMutex.Lock()
 	ret, specificReturn := fake.disableManualTriggerReturnsOnCall[len(fake.disableManualTriggerArgsForCall)]
@@ -1571,6 +1784,65 @@ func (fake *FakeJob) InputsReturnsOnCall(i int, result1 []atc.JobInput, result2
 	}{result1, result2}
 }
 
+func (fake *FakeJob) LatestSucceededBuild() (db.Build, bool, error) {
+	fake.latestSucceededBuildMutex.Lock()
+	ret, specificReturn := fake.latestSucceededBuildReturnsOnCall[len(fake.latestSucceededBuildArgsForCall)]
+	fake.latestSucceededBuildArgsForCall = append(fake.latestSucceededBuildArgsForCall, struct {
+	}{})
+	stub := fake.LatestSucceededBuildStub
+	fakeReturns := fake.latestSucceededBuildReturns
+	fake.recordInvocation("LatestSucceededBuild", []interface{}{})
+	fake.latestSucceededBuildMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeJob) LatestSucceededBuildCallCount() int {
+	fake.latestSucceededBuildMutex.RLock()
+	defer fake.latestSucceededBuildMutex.RUnlock()
+	return len(fake.latestSucceededBuildArgsForCall)
+}
+
+func (fake *FakeJob) LatestSucceededBuildCalls(stub func() (db.Build, bool, error)) {
+	fake.latestSucceededBuildMutex.Lock()
+	defer fake.latestSucceededBuildMutex.Unlock()
+	fake.LatestSucceededBuildStub = stub
+}
+
+func (fake *FakeJob) LatestSucceededBuildReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.latestSucceededBuildMutex.Lock()
+	defer fake.latestSucceededBuildMutex.Unlock()
+	fake.LatestSucceededBuildStub = nil
+	fake.latestSucceededBuildReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJob) LatestSucceededBuildReturnsOnCall(i int, result1 db.Build, result2 bool, result3 error) {
+	fake.latestSucceededBuildMutex.Lock()
+	defer fake.latestSucceededBuildMutex.Unlock()
+	fake.LatestSucceededBuildStub = nil
+	if fake.latestSucceededBuildReturnsOnCall == nil {
+		fake.latestSucceededBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.latestSucceededBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeJob) MaxInFlight() int {
 	fake.maxInFlightMutex.Lock()
 	ret, specificReturn := fake.maxInFlightReturnsOnCall[len(fake.maxInFlightArgsForCall)]
@@ -2337,6 +2609,71 @@ func (fake *FakeJob) RerunBuildReturnsOnCall(i int, result1 db.Build, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeJob) RerunBuildWithLatestIfUnavailable(arg1 db.Build, arg2 string) (db.Build, error) {
+	fake.rerunBuildWithLatestIfUnavailableMutex.Lock()
+	ret, specificReturn := fake.rerunBuildWithLatestIfUnavailableReturnsOnCall[len(fake.rerunBuildWithLatestIfUnavailableArgsForCall)]
+	fake.rerunBuildWithLatestIfUnavailableArgsForCall = append(fake.rerunBuildWithLatestIfUnavailableArgsForCall, struct {
+		arg1 db.Build
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RerunBuildWithLatestIfUnavailableStub
+	fakeReturns := fake.rerunBuildWithLatestIfUnavailableReturns
+	fake.recordInvocation("RerunBuildWithLatestIfUnavailable", []interface{}{arg1, arg2})
+	fake.rerunBuildWithLatestIfUnavailableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) RerunBuildWithLatestIfUnavailableCallCount() int {
+	fake.rerunBuildWithLatestIfUnavailableMutex.RLock()
+	defer fake.rerunBuildWithLatestIfUnavailableMutex.RUnlock()
+	return len(fake.rerunBuildWithLatestIfUnavailableArgsForCall)
+}
+
+func (fake *FakeJob) RerunBuildWithLatestIfUnavailableCalls(stub func(db.Build, string) (db.Build, error)) {
+	fake.rerunBuildWithLatestIfUnavailableMutex.Lock()
+	defer fake.rerunBuildWithLatestIfUnavailableMutex.Unlock()
+	fake.RerunBuildWithLatestIfUnavailableStub = stub
+}
+
+func (fake *FakeJob) RerunBuildWithLatestIfUnavailableArgsForCall(i int) (db.Build, string) {
+	fake.rerunBuildWithLatestIfUnavailableMutex.RLock()
+	defer fake.rerunBuildWithLatestIfUnavailableMutex.RUnlock()
+	argsForCall := fake.rerunBuildWithLatestIfUnavailableArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeJob) RerunBuildWithLatestIfUnavailableReturns(result1 db.Build, result2 error) {
+	fake.rerunBuildWithLatestIfUnavailableMutex.Lock()
+	defer fake.rerunBuildWithLatestIfUnavailableMutex.Unlock()
+	fake.RerunBuildWithLatestIfUnavailableStub = nil
+	fake.rerunBuildWithLatestIfUnavailableReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) RerunBuildWithLatestIfUnavailableReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.rerunBuildWithLatestIfUnavailableMutex.Lock()
+	defer fake.rerunBuildWithLatestIfUnavailableMutex.Unlock()
+	fake.RerunBuildWithLatestIfUnavailableStub = nil
+	if fake.rerunBuildWithLatestIfUnavailableReturnsOnCall == nil {
+		fake.rerunBuildWithLatestIfUnavailableReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.rerunBuildWithLatestIfUnavailableReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) SaveNextInputMapping(arg1 db.InputMapping, arg2 bool) error {
 	fake.saveNextInputMappingMutex.Lock()
 	ret, specificReturn := fake.saveNextInputMappingReturnsOnCall[len(fake.saveNextInputMappingArgsForCall)]
@@ -2399,6 +2736,67 @@ func (fake *FakeJob) SaveNextInputMappingReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeJob) SaveSchedulerDecision(arg1 atc.SchedulerDecision) error {
+	fake.saveSchedulerDecisionMutex.Lock()
+	ret, specificReturn := fake.saveSchedulerDecisionReturnsOnCall[len(fake.saveSchedulerDecisionArgsForCall)]
+	fake.saveSchedulerDecisionArgsForCall = append(fake.saveSchedulerDecisionArgsForCall, struct {
+		arg1 atc.SchedulerDecision
+	}{arg1})
+	stub := fake.SaveSchedulerDecisionStub
+	fakeReturns := fake.saveSchedulerDecisionReturns
+	fake.recordInvocation("SaveSchedulerDecision", []interface{}{arg1})
+	fake.saveSchedulerDecisionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) SaveSchedulerDecisionCallCount() int {
+	fake.saveSchedulerDecisionMutex.RLock()
+	defer fake.saveSchedulerDecisionMutex.RUnlock()
+	return len(fake.saveSchedulerDecisionArgsForCall)
+}
+
+func (fake *FakeJob) SaveSchedulerDecisionCalls(stub func(atc.SchedulerDecision) error) {
+	fake.saveSchedulerDecisionMutex.Lock()
+	defer fake.saveSchedulerDecisionMutex.Unlock()
+	fake.SaveSchedulerDecisionStub = stub
+}
+
+func (fake *FakeJob) SaveSchedulerDecisionArgsForCall(i int) atc.SchedulerDecision {
+	fake.saveSchedulerDecisionMutex.RLock()
+	defer fake.saveSchedulerDecisionMutex.RUnlock()
+	argsForCall := fake.saveSchedulerDecisionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) SaveSchedulerDecisionReturns(result1 error) {
+	fake.saveSchedulerDecisionMutex.Lock()
+	defer fake.saveSchedulerDecisionMutex.Unlock()
+	fake.SaveSchedulerDecisionStub = nil
+	fake.saveSchedulerDecisionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) SaveSchedulerDecisionReturnsOnCall(i int, result1 error) {
+	fake.saveSchedulerDecisionMutex.Lock()
+	defer fake.saveSchedulerDecisionMutex.Unlock()
+	fake.SaveSchedulerDecisionStub = nil
+	if fake.saveSchedulerDecisionReturnsOnCall == nil {
+		fake.saveSchedulerDecisionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveSchedulerDecisionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeJob) ScheduleBuild(arg1 db.Build) (bool, error) {
 	fake.scheduleBuildMutex.Lock()
 	ret, specificReturn := fake.scheduleBuildReturnsOnCall[len(fake.scheduleBuildArgsForCall)]
@@ -2516,6 +2914,65 @@ func (fake *FakeJob) ScheduleRequestedTimeReturnsOnCall(i int, result1 time.Time
 	}{result1}
 }
 
+func (fake *FakeJob) SchedulerDecision() (atc.SchedulerDecision, bool, error) {
+	fake.schedulerDecisionMutex.Lock()
+	ret, specificReturn := fake.schedulerDecisionReturnsOnCall[len(fake.schedulerDecisionArgsForCall)]
+	fake.schedulerDecisionArgsForCall = append(fake.schedulerDecisionArgsForCall, struct {
+	}{})
+	stub := fake.SchedulerDecisionStub
+	fakeReturns := fake.schedulerDecisionReturns
+	fake.recordInvocation("SchedulerDecision", []interface{}{})
+	fake.schedulerDecisionMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeJob) SchedulerDecisionCallCount() int {
+	fake.schedulerDecisionMutex.RLock()
+	defer fake.schedulerDecisionMutex.RUnlock()
+	return len(fake.schedulerDecisionArgsForCall)
+}
+
+func (fake *FakeJob) SchedulerDecisionCalls(stub func() (atc.SchedulerDecision, bool, error)) {
+	fake.schedulerDecisionMutex.Lock()
+	defer fake.schedulerDecisionMutex.Unlock()
+	fake.SchedulerDecisionStub = stub
+}
+
+func (fake *FakeJob) SchedulerDecisionReturns(result1 atc.SchedulerDecision, result2 bool, result3 error) {
+	fake.schedulerDecisionMutex.Lock()
+	defer fake.schedulerDecisionMutex.Unlock()
+	fake.SchedulerDecisionStub = nil
+	fake.schedulerDecisionReturns = struct {
+		result1 atc.SchedulerDecision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJob) SchedulerDecisionReturnsOnCall(i int, result1 atc.SchedulerDecision, result2 bool, result3 error) {
+	fake.schedulerDecisionMutex.Lock()
+	defer fake.schedulerDecisionMutex.Unlock()
+	fake.SchedulerDecisionStub = nil
+	if fake.schedulerDecisionReturnsOnCall == nil {
+		fake.schedulerDecisionReturnsOnCall = make(map[int]struct {
+			result1 atc.SchedulerDecision
+			result2 bool
+			result3 error
+		})
+	}
+	fake.schedulerDecisionReturnsOnCall[i] = struct {
+		result1 atc.SchedulerDecision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeJob) SetHasNewInputs(arg1 bool) error {
 	fake.setHasNewInputsMutex.Lock()
 	ret, specificReturn := fake.setHasNewInputsReturnsOnCall[len(fake.setHasNewInputsArgsForCall)]
@@ -2683,6 +3140,70 @@ func (fake *FakeJob) TeamIDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeJob) TeamMaxConcurrentBuildsReached(arg1 db.Build) (bool, error) {
+	fake.teamMaxConcurrentBuildsReachedMutex.Lock()
+	ret, specificReturn := fake.teamMaxConcurrentBuildsReachedReturnsOnCall[len(fake.teamMaxConcurrentBuildsReachedArgsForCall)]
+	fake.teamMaxConcurrentBuildsReachedArgsForCall = append(fake.teamMaxConcurrentBuildsReachedArgsForCall, struct {
+		arg1 db.Build
+	}{arg1})
+	stub := fake.TeamMaxConcurrentBuildsReachedStub
+	fakeReturns := fake.teamMaxConcurrentBuildsReachedReturns
+	fake.recordInvocation("TeamMaxConcurrentBuildsReached", []interface{}{arg1})
+	fake.teamMaxConcurrentBuildsReachedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) TeamMaxConcurrentBuildsReachedCallCount() int {
+	fake.teamMaxConcurrentBuildsReachedMutex.RLock()
+	defer fake.teamMaxConcurrentBuildsReachedMutex.RUnlock()
+	return len(fake.teamMaxConcurrentBuildsReachedArgsForCall)
+}
+
+func (fake *FakeJob) TeamMaxConcurrentBuildsReachedCalls(stub func(db.Build) (bool, error)) {
+	fake.teamMaxConcurrentBuildsReachedMutex.Lock()
+	defer fake.teamMaxConcurrentBuildsReachedMutex.Unlock()
+	fake.TeamMaxConcurrentBuildsReachedStub = stub
+}
+
+func (fake *FakeJob) TeamMaxConcurrentBuildsReachedArgsForCall(i int) db.Build {
+	fake.teamMaxConcurrentBuildsReachedMutex.RLock()
+	defer fake.teamMaxConcurrentBuildsReachedMutex.RUnlock()
+	argsForCall := fake.teamMaxConcurrentBuildsReachedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) TeamMaxConcurrentBuildsReachedReturns(result1 bool, result2 error) {
+	fake.teamMaxConcurrentBuildsReachedMutex.Lock()
+	defer fake.teamMaxConcurrentBuildsReachedMutex.Unlock()
+	fake.TeamMaxConcurrentBuildsReachedStub = nil
+	fake.teamMaxConcurrentBuildsReachedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) TeamMaxConcurrentBuildsReachedReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.teamMaxConcurrentBuildsReachedMutex.Lock()
+	defer fake.teamMaxConcurrentBuildsReachedMutex.Unlock()
+	fake.TeamMaxConcurrentBuildsReachedStub = nil
+	if fake.teamMaxConcurrentBuildsReachedReturnsOnCall == nil {
+		fake.teamMaxConcurrentBuildsReachedReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.teamMaxConcurrentBuildsReachedReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) TeamName() string {
 	fake.teamNameMutex.Lock()
 	ret, specificReturn := fake.teamNameReturnsOnCall[len(fake.teamNameArgsForCall)]
@@ -2918,6 +3439,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.acquireSchedulingLockMutex.RUnlock()
 	fake.algorithmInputsMutex.RLock()
 	defer fake.algorithmInputsMutex.RUnlock()
+	fake.awaitingResourceChecksMutex.RLock()
+	defer fake.awaitingResourceChecksMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
 	fake.buildsMutex.RLock()
@@ -2930,6 +3453,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.configMutex.RUnlock()
 	fake.createBuildMutex.RLock()
 	defer fake.createBuildMutex.RUnlock()
+	fake.createBuildWithVarsMutex.RLock()
+	defer fake.createBuildWithVarsMutex.RUnlock()
 	fake.disableManualTriggerMutex.RLock()
 	defer fake.disableManualTriggerMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
@@ -2950,6 +3475,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.iDMutex.RUnlock()
 	fake.inputsMutex.RLock()
 	defer fake.inputsMutex.RUnlock()
+	fake.latestSucceededBuildMutex.RLock()
+	defer fake.latestSucceededBuildMutex.RUnlock()
 	fake.maxInFlightMutex.RLock()
 	defer fake.maxInFlightMutex.RUnlock()
 	fake.nameMutex.RLock()
@@ -2978,18 +3505,26 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.requestScheduleMutex.RUnlock()
 	fake.rerunBuildMutex.RLock()
 	defer fake.rerunBuildMutex.RUnlock()
+	fake.rerunBuildWithLatestIfUnavailableMutex.RLock()
+	defer fake.rerunBuildWithLatestIfUnavailableMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.saveSchedulerDecisionMutex.RLock()
+	defer fake.saveSchedulerDecisionMutex.RUnlock()
 	fake.scheduleBuildMutex.RLock()
 	defer fake.scheduleBuildMutex.RUnlock()
 	fake.scheduleRequestedTimeMutex.RLock()
 	defer fake.scheduleRequestedTimeMutex.RUnlock()
+	fake.schedulerDecisionMutex.RLock()
+	defer fake.schedulerDecisionMutex.RUnlock()
 	fake.setHasNewInputsMutex.RLock()
 	defer fake.setHasNewInputsMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
 	fake.teamIDMutex.RLock()
 	defer fake.teamIDMutex.RUnlock()
+	fake.teamMaxConcurrentBuildsReachedMutex.RLock()
+	defer fake.teamMaxConcurrentBuildsReachedMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
 	fake.unpauseMutex.RLock()
@@ -0,0 +1,331 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeNotificationsBus struct {
+	CloseStub        func() error
+	closeMutex       sync.RWMutex
+	closeArgsForCall []struct {
+	}
+	closeReturns struct {
+		result1 error
+	}
+	closeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListenStub        func(string) (chan bool, error)
+	listenMutex       sync.RWMutex
+	listenArgsForCall []struct {
+		arg1 string
+	}
+	listenReturns struct {
+		result1 chan bool
+		result2 error
+	}
+	listenReturnsOnCall map[int]struct {
+		result1 chan bool
+		result2 error
+	}
+	NotifyStub        func(string) error
+	notifyMutex       sync.RWMutex
+	notifyArgsForCall []struct {
+		arg1 string
+	}
+	notifyReturns struct {
+		result1 error
+	}
+	notifyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UnlistenStub        func(string, chan bool) error
+	unlistenMutex       sync.RWMutex
+	unlistenArgsForCall []struct {
+		arg1 string
+		arg2 chan bool
+	}
+	unlistenReturns struct {
+		result1 error
+	}
+	unlistenReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeNotificationsBus) Close() error {
+	fake.closeMutex.Lock()
+	ret, specificReturn := fake.closeReturnsOnCall[len(fake.closeArgsForCall)]
+	fake.closeArgsForCall = append(fake.closeArgsForCall, struct {
+	}{})
+	stub := fake.CloseStub
+	fakeReturns := fake.closeReturns
+	fake.recordInvocation("Close", []interface{}{})
+	fake.closeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationsBus) CloseCallCount() int {
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	return len(fake.closeArgsForCall)
+}
+
+func (fake *FakeNotificationsBus) CloseCalls(stub func() error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = stub
+}
+
+func (fake *FakeNotificationsBus) CloseReturns(result1 error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = nil
+	fake.closeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) CloseReturnsOnCall(i int, result1 error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = nil
+	if fake.closeReturnsOnCall == nil {
+		fake.closeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.closeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) Listen(arg1 string) (chan bool, error) {
+	fake.listenMutex.Lock()
+	ret, specificReturn := fake.listenReturnsOnCall[len(fake.listenArgsForCall)]
+	fake.listenArgsForCall = append(fake.listenArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ListenStub
+	fakeReturns := fake.listenReturns
+	fake.recordInvocation("Listen", []interface{}{arg1})
+	fake.listenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNotificationsBus) ListenCallCount() int {
+	fake.listenMutex.RLock()
+	defer fake.listenMutex.RUnlock()
+	return len(fake.listenArgsForCall)
+}
+
+func (fake *FakeNotificationsBus) ListenCalls(stub func(string) (chan bool, error)) {
+	fake.listenMutex.Lock()
+	defer fake.listenMutex.Unlock()
+	fake.ListenStub = stub
+}
+
+func (fake *FakeNotificationsBus) ListenArgsForCall(i int) string {
+	fake.listenMutex.RLock()
+	defer fake.listenMutex.RUnlock()
+	argsForCall := fake.listenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNotificationsBus) ListenReturns(result1 chan bool, result2 error) {
+	fake.listenMutex.Lock()
+	defer fake.listenMutex.Unlock()
+	fake.ListenStub = nil
+	fake.listenReturns = struct {
+		result1 chan bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNotificationsBus) ListenReturnsOnCall(i int, result1 chan bool, result2 error) {
+	fake.listenMutex.Lock()
+	defer fake.listenMutex.Unlock()
+	fake.ListenStub = nil
+	if fake.listenReturnsOnCall == nil {
+		fake.listenReturnsOnCall = make(map[int]struct {
+			result1 chan bool
+			result2 error
+		})
+	}
+	fake.listenReturnsOnCall[i] = struct {
+		result1 chan bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNotificationsBus) Notify(arg1 string) error {
+	fake.notifyMutex.Lock()
+	ret, specificReturn := fake.notifyReturnsOnCall[len(fake.notifyArgsForCall)]
+	fake.notifyArgsForCall = append(fake.notifyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.NotifyStub
+	fakeReturns := fake.notifyReturns
+	fake.recordInvocation("Notify", []interface{}{arg1})
+	fake.notifyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationsBus) NotifyCallCount() int {
+	fake.notifyMutex.RLock()
+	defer fake.notifyMutex.RUnlock()
+	return len(fake.notifyArgsForCall)
+}
+
+func (fake *FakeNotificationsBus) NotifyCalls(stub func(string) error) {
+	fake.notifyMutex.Lock()
+	defer fake.notifyMutex.Unlock()
+	fake.NotifyStub = stub
+}
+
+func (fake *FakeNotificationsBus) NotifyArgsForCall(i int) string {
+	fake.notifyMutex.RLock()
+	defer fake.notifyMutex.RUnlock()
+	argsForCall := fake.notifyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNotificationsBus) NotifyReturns(result1 error) {
+	fake.notifyMutex.Lock()
+	defer fake.notifyMutex.Unlock()
+	fake.NotifyStub = nil
+	fake.notifyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) NotifyReturnsOnCall(i int, result1 error) {
+	fake.notifyMutex.Lock()
+	defer fake.notifyMutex.Unlock()
+	fake.NotifyStub = nil
+	if fake.notifyReturnsOnCall == nil {
+		fake.notifyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.notifyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) Unlisten(arg1 string, arg2 chan bool) error {
+	fake.unlistenMutex.Lock()
+	ret, specificReturn := fake.unlistenReturnsOnCall[len(fake.unlistenArgsForCall)]
+	fake.unlistenArgsForCall = append(fake.unlistenArgsForCall, struct {
+		arg1 string
+		arg2 chan bool
+	}{arg1, arg2})
+	stub := fake.UnlistenStub
+	fakeReturns := fake.unlistenReturns
+	fake.recordInvocation("Unlisten", []interface{}{arg1, arg2})
+	fake.unlistenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationsBus) UnlistenCallCount() int {
+	fake.unlistenMutex.RLock()
+	defer fake.unlistenMutex.RUnlock()
+	return len(fake.unlistenArgsForCall)
+}
+
+func (fake *FakeNotificationsBus) UnlistenCalls(stub func(string, chan bool) error) {
+	fake.unlistenMutex.Lock()
+	defer fake.unlistenMutex.Unlock()
+	fake.UnlistenStub = stub
+}
+
+func (fake *FakeNotificationsBus) UnlistenArgsForCall(i int) (string, chan bool) {
+	fake.unlistenMutex.RLock()
+	defer fake.unlistenMutex.RUnlock()
+	argsForCall := fake.unlistenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNotificationsBus) UnlistenReturns(result1 error) {
+	fake.unlistenMutex.Lock()
+	defer fake.unlistenMutex.Unlock()
+	fake.UnlistenStub = nil
+	fake.unlistenReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) UnlistenReturnsOnCall(i int, result1 error) {
+	fake.unlistenMutex.Lock()
+	defer fake.unlistenMutex.Unlock()
+	fake.UnlistenStub = nil
+	if fake.unlistenReturnsOnCall == nil {
+		fake.unlistenReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unlistenReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	fake.listenMutex.RLock()
+	defer fake.listenMutex.RUnlock()
+	fake.notifyMutex.RLock()
+	defer fake.notifyMutex.RUnlock()
+	fake.unlistenMutex.RLock()
+	defer fake.unlistenMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeNotificationsBus) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.NotificationsBus = new(FakeNotificationsBus)
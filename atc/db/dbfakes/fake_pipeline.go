@@ -158,6 +158,19 @@ type FakePipeline struct {
 	deleteBuildEventsByBuildIDsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteVarStub        func(string) (bool, error)
+	deleteVarMutex       sync.RWMutex
+	deleteVarArgsForCall []struct {
+		arg1 string
+	}
+	deleteVarReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteVarReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	DestroyStub        func() error
 	destroyMutex       sync.RWMutex
 	destroyArgsForCall []struct {
@@ -305,6 +318,16 @@ type FakePipeline struct {
 		result1 *atc.DebugVersionsDB
 		result2 error
 	}
+	MaxBuildDurationStub        func() string
+	maxBuildDurationMutex       sync.RWMutex
+	maxBuildDurationArgsForCall []struct {
+	}
+	maxBuildDurationReturns struct {
+		result1 string
+	}
+	maxBuildDurationReturnsOnCall map[int]struct {
+		result1 string
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -355,6 +378,18 @@ type FakePipeline struct {
 	pausedReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	PendingChecksStub        func() ([]db.PendingCheck, error)
+	pendingChecksMutex       sync.RWMutex
+	pendingChecksArgsForCall []struct {
+	}
+	pendingChecksReturns struct {
+		result1 []db.PendingCheck
+		result2 error
+	}
+	pendingChecksReturnsOnCall map[int]struct {
+		result1 []db.PendingCheck
+		result2 error
+	}
 	PublicStub        func() bool
 	publicMutex       sync.RWMutex
 	publicArgsForCall []struct {
@@ -476,6 +511,31 @@ type FakePipeline struct {
 		result1 db.Resources
 		result2 error
 	}
+	SaveVersionSetStub        func(string) (db.VersionSet, error)
+	saveVersionSetMutex       sync.RWMutex
+	saveVersionSetArgsForCall []struct {
+		arg1 string
+	}
+	saveVersionSetReturns struct {
+		result1 db.VersionSet
+		result2 error
+	}
+	saveVersionSetReturnsOnCall map[int]struct {
+		result1 db.VersionSet
+		result2 error
+	}
+	SerialGroupsStub        func() ([]db.SerialGroup, error)
+	serialGroupsMutex       sync.RWMutex
+	serialGroupsArgsForCall []struct {
+	}
+	serialGroupsReturns struct {
+		result1 []db.SerialGroup
+		result2 error
+	}
+	serialGroupsReturnsOnCall map[int]struct {
+		result1 []db.SerialGroup
+		result2 error
+	}
 	SetParentIDsStub        func(int, int) error
 	setParentIDsMutex       sync.RWMutex
 	setParentIDsArgsForCall []struct {
@@ -488,6 +548,31 @@ type FakePipeline struct {
 	setParentIDsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetVarStub        func(string, interface{}, bool) (bool, error)
+	setVarMutex       sync.RWMutex
+	setVarArgsForCall []struct {
+		arg1 string
+		arg2 interface{}
+		arg3 bool
+	}
+	setVarReturns struct {
+		result1 bool
+		result2 error
+	}
+	setVarReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	TaskDefaultsStub        func() *atc.TaskDefaults
+	taskDefaultsMutex       sync.RWMutex
+	taskDefaultsArgsForCall []struct {
+	}
+	taskDefaultsReturns struct {
+		result1 *atc.TaskDefaults
+	}
+	taskDefaultsReturnsOnCall map[int]struct {
+		result1 *atc.TaskDefaults
+	}
 	TeamIDStub        func() int
 	teamIDMutex       sync.RWMutex
 	teamIDArgsForCall []struct {
@@ -518,6 +603,29 @@ type FakePipeline struct {
 	unpauseReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UnpauseAwaitingChecksStub        func([]int) error
+	unpauseAwaitingChecksMutex       sync.RWMutex
+	unpauseAwaitingChecksArgsForCall []struct {
+		arg1 []int
+	}
+	unpauseAwaitingChecksReturns struct {
+		result1 error
+	}
+	unpauseAwaitingChecksReturnsOnCall map[int]struct {
+		result1 error
+	}
+	VarNamesStub        func() ([]string, error)
+	varNamesMutex       sync.RWMutex
+	varNamesArgsForCall []struct {
+	}
+	varNamesReturns struct {
+		result1 []string
+		result2 error
+	}
+	varNamesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	VarSourcesStub        func() atc.VarSourceConfigs
 	varSourcesMutex       sync.RWMutex
 	varSourcesArgsForCall []struct {
@@ -543,6 +651,55 @@ type FakePipeline struct {
 		result1 vars.Variables
 		result2 error
 	}
+	VarsStub        func() (vars.StaticVariables, error)
+	varsMutex       sync.RWMutex
+	varsArgsForCall []struct {
+	}
+	varsReturns struct {
+		result1 vars.StaticVariables
+		result2 error
+	}
+	varsReturnsOnCall map[int]struct {
+		result1 vars.StaticVariables
+		result2 error
+	}
+	VersionSetStub        func(string) (db.VersionSet, bool, error)
+	versionSetMutex       sync.RWMutex
+	versionSetArgsForCall []struct {
+		arg1 string
+	}
+	versionSetReturns struct {
+		result1 db.VersionSet
+		result2 bool
+		result3 error
+	}
+	versionSetReturnsOnCall map[int]struct {
+		result1 db.VersionSet
+		result2 bool
+		result3 error
+	}
+	VersionSetsStub        func() ([]db.VersionSet, error)
+	versionSetsMutex       sync.RWMutex
+	versionSetsArgsForCall []struct {
+	}
+	versionSetsReturns struct {
+		result1 []db.VersionSet
+		result2 error
+	}
+	versionSetsReturnsOnCall map[int]struct {
+		result1 []db.VersionSet
+		result2 error
+	}
+	WebhooksStub        func() atc.WebhookConfigs
+	webhooksMutex       sync.RWMutex
+	webhooksArgsForCall []struct {
+	}
+	webhooksReturns struct {
+		result1 atc.WebhookConfigs
+	}
+	webhooksReturnsOnCall map[int]struct {
+		result1 atc.WebhookConfigs
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1258,6 +1415,70 @@ func (fake *FakePipeline) DeleteBuildEventsByBuildIDsReturnsOnCall(i int, result
 	}{result1}
 }
 
+func (fake *FakePipeline) DeleteVar(arg1 string) (bool, error) {
+	fake.deleteVarMutex.Lock()
+	ret, specificReturn := fake.deleteVarReturnsOnCall[len(fake.deleteVarArgsForCall)]
+	fake.deleteVarArgsForCall = append(fake.deleteVarArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteVarStub
+	fakeReturns := fake.deleteVarReturns
+	fake.recordInvocation("DeleteVar", []interface{}{arg1})
+	fake.deleteVarMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) DeleteVarCallCount() int {
+	fake.deleteVarMutex.RLock()
+	defer fake.deleteVarMutex.RUnlock()
+	return len(fake.deleteVarArgsForCall)
+}
+
+func (fake *FakePipeline) DeleteVarCalls(stub func(string) (bool, error)) {
+	fake.deleteVarMutex.Lock()
+	defer fake.deleteVarMutex.Unlock()
+	fake.DeleteVarStub = stub
+}
+
+func (fake *FakePipeline) DeleteVarArgsForCall(i int) string {
+	fake.deleteVarMutex.RLock()
+	defer fake.deleteVarMutex.RUnlock()
+	argsForCall := fake.deleteVarArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) DeleteVarReturns(result1 bool, result2 error) {
+	fake.deleteVarMutex.Lock()
+	defer fake.deleteVarMutex.Unlock()
+	fake.DeleteVarStub = nil
+	fake.deleteVarReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) DeleteVarReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.deleteVarMutex.Lock()
+	defer fake.deleteVarMutex.Unlock()
+	fake.DeleteVarStub = nil
+	if fake.deleteVarReturnsOnCall == nil {
+		fake.deleteVarReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteVarReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) Destroy() error {
 	fake.destroyMutex.Lock()
 	ret, specificReturn := fake.destroyReturnsOnCall[len(fake.destroyArgsForCall)]
@@ -1991,6 +2212,59 @@ func (fake *FakePipeline) LoadDebugVersionsDBReturnsOnCall(i int, result1 *atc.D
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) MaxBuildDuration() string {
+	fake.maxBuildDurationMutex.Lock()
+	ret, specificReturn := fake.maxBuildDurationReturnsOnCall[len(fake.maxBuildDurationArgsForCall)]
+	fake.maxBuildDurationArgsForCall = append(fake.maxBuildDurationArgsForCall, struct {
+	}{})
+	stub := fake.MaxBuildDurationStub
+	fakeReturns := fake.maxBuildDurationReturns
+	fake.recordInvocation("MaxBuildDuration", []interface{}{})
+	fake.maxBuildDurationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) MaxBuildDurationCallCount() int {
+	fake.maxBuildDurationMutex.RLock()
+	defer fake.maxBuildDurationMutex.RUnlock()
+	return len(fake.maxBuildDurationArgsForCall)
+}
+
+func (fake *FakePipeline) MaxBuildDurationCalls(stub func() string) {
+	fake.maxBuildDurationMutex.Lock()
+	defer fake.maxBuildDurationMutex.Unlock()
+	fake.MaxBuildDurationStub = stub
+}
+
+func (fake *FakePipeline) MaxBuildDurationReturns(result1 string) {
+	fake.maxBuildDurationMutex.Lock()
+	defer fake.maxBuildDurationMutex.Unlock()
+	fake.MaxBuildDurationStub = nil
+	fake.maxBuildDurationReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakePipeline) MaxBuildDurationReturnsOnCall(i int, result1 string) {
+	fake.maxBuildDurationMutex.Lock()
+	defer fake.maxBuildDurationMutex.Unlock()
+	fake.MaxBuildDurationStub = nil
+	if fake.maxBuildDurationReturnsOnCall == nil {
+		fake.maxBuildDurationReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.maxBuildDurationReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakePipeline) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -2256,6 +2530,62 @@ func (fake *FakePipeline) PausedReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakePipeline) PendingChecks() ([]db.PendingCheck, error) {
+	fake.pendingChecksMutex.Lock()
+	ret, specificReturn := fake.pendingChecksReturnsOnCall[len(fake.pendingChecksArgsForCall)]
+	fake.pendingChecksArgsForCall = append(fake.pendingChecksArgsForCall, struct {
+	}{})
+	stub := fake.PendingChecksStub
+	fakeReturns := fake.pendingChecksReturns
+	fake.recordInvocation("PendingChecks", []interface{}{})
+	fake.pendingChecksMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) PendingChecksCallCount() int {
+	fake.pendingChecksMutex.RLock()
+	defer fake.pendingChecksMutex.RUnlock()
+	return len(fake.pendingChecksArgsForCall)
+}
+
+func (fake *FakePipeline) PendingChecksCalls(stub func() ([]db.PendingCheck, error)) {
+	fake.pendingChecksMutex.Lock()
+	defer fake.pendingChecksMutex.Unlock()
+	fake.PendingChecksStub = stub
+}
+
+func (fake *FakePipeline) PendingChecksReturns(result1 []db.PendingCheck, result2 error) {
+	fake.pendingChecksMutex.Lock()
+	defer fake.pendingChecksMutex.Unlock()
+	fake.PendingChecksStub = nil
+	fake.pendingChecksReturns = struct {
+		result1 []db.PendingCheck
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) PendingChecksReturnsOnCall(i int, result1 []db.PendingCheck, result2 error) {
+	fake.pendingChecksMutex.Lock()
+	defer fake.pendingChecksMutex.Unlock()
+	fake.PendingChecksStub = nil
+	if fake.pendingChecksReturnsOnCall == nil {
+		fake.pendingChecksReturnsOnCall = make(map[int]struct {
+			result1 []db.PendingCheck
+			result2 error
+		})
+	}
+	fake.pendingChecksReturnsOnCall[i] = struct {
+		result1 []db.PendingCheck
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) Public() bool {
 	fake.publicMutex.Lock()
 	ret, specificReturn := fake.publicReturnsOnCall[len(fake.publicArgsForCall)]
@@ -2812,6 +3142,126 @@ func (fake *FakePipeline) ResourcesReturnsOnCall(i int, result1 db.Resources, re
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) SaveVersionSet(arg1 string) (db.VersionSet, error) {
+	fake.saveVersionSetMutex.Lock()
+	ret, specificReturn := fake.saveVersionSetReturnsOnCall[len(fake.saveVersionSetArgsForCall)]
+	fake.saveVersionSetArgsForCall = append(fake.saveVersionSetArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SaveVersionSetStub
+	fakeReturns := fake.saveVersionSetReturns
+	fake.recordInvocation("SaveVersionSet", []interface{}{arg1})
+	fake.saveVersionSetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) SaveVersionSetCallCount() int {
+	fake.saveVersionSetMutex.RLock()
+	defer fake.saveVersionSetMutex.RUnlock()
+	return len(fake.saveVersionSetArgsForCall)
+}
+
+func (fake *FakePipeline) SaveVersionSetCalls(stub func(string) (db.VersionSet, error)) {
+	fake.saveVersionSetMutex.Lock()
+	defer fake.saveVersionSetMutex.Unlock()
+	fake.SaveVersionSetStub = stub
+}
+
+func (fake *FakePipeline) SaveVersionSetArgsForCall(i int) string {
+	fake.saveVersionSetMutex.RLock()
+	defer fake.saveVersionSetMutex.RUnlock()
+	argsForCall := fake.saveVersionSetArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) SaveVersionSetReturns(result1 db.VersionSet, result2 error) {
+	fake.saveVersionSetMutex.Lock()
+	defer fake.saveVersionSetMutex.Unlock()
+	fake.SaveVersionSetStub = nil
+	fake.saveVersionSetReturns = struct {
+		result1 db.VersionSet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SaveVersionSetReturnsOnCall(i int, result1 db.VersionSet, result2 error) {
+	fake.saveVersionSetMutex.Lock()
+	defer fake.saveVersionSetMutex.Unlock()
+	fake.SaveVersionSetStub = nil
+	if fake.saveVersionSetReturnsOnCall == nil {
+		fake.saveVersionSetReturnsOnCall = make(map[int]struct {
+			result1 db.VersionSet
+			result2 error
+		})
+	}
+	fake.saveVersionSetReturnsOnCall[i] = struct {
+		result1 db.VersionSet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SerialGroups() ([]db.SerialGroup, error) {
+	fake.serialGroupsMutex.Lock()
+	ret, specificReturn := fake.serialGroupsReturnsOnCall[len(fake.serialGroupsArgsForCall)]
+	fake.serialGroupsArgsForCall = append(fake.serialGroupsArgsForCall, struct {
+	}{})
+	stub := fake.SerialGroupsStub
+	fakeReturns := fake.serialGroupsReturns
+	fake.recordInvocation("SerialGroups", []interface{}{})
+	fake.serialGroupsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) SerialGroupsCallCount() int {
+	fake.serialGroupsMutex.RLock()
+	defer fake.serialGroupsMutex.RUnlock()
+	return len(fake.serialGroupsArgsForCall)
+}
+
+func (fake *FakePipeline) SerialGroupsCalls(stub func() ([]db.SerialGroup, error)) {
+	fake.serialGroupsMutex.Lock()
+	defer fake.serialGroupsMutex.Unlock()
+	fake.SerialGroupsStub = stub
+}
+
+func (fake *FakePipeline) SerialGroupsReturns(result1 []db.SerialGroup, result2 error) {
+	fake.serialGroupsMutex.Lock()
+	defer fake.serialGroupsMutex.Unlock()
+	fake.SerialGroupsStub = nil
+	fake.serialGroupsReturns = struct {
+		result1 []db.SerialGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SerialGroupsReturnsOnCall(i int, result1 []db.SerialGroup, result2 error) {
+	fake.serialGroupsMutex.Lock()
+	defer fake.serialGroupsMutex.Unlock()
+	fake.SerialGroupsStub = nil
+	if fake.serialGroupsReturnsOnCall == nil {
+		fake.serialGroupsReturnsOnCall = make(map[int]struct {
+			result1 []db.SerialGroup
+			result2 error
+		})
+	}
+	fake.serialGroupsReturnsOnCall[i] = struct {
+		result1 []db.SerialGroup
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) SetParentIDs(arg1 int, arg2 int) error {
 	fake.setParentIDsMutex.Lock()
 	ret, specificReturn := fake.setParentIDsReturnsOnCall[len(fake.setParentIDsArgsForCall)]
@@ -2874,6 +3324,125 @@ func (fake *FakePipeline) SetParentIDsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipeline) SetVar(arg1 string, arg2 interface{}, arg3 bool) (bool, error) {
+	fake.setVarMutex.Lock()
+	ret, specificReturn := fake.setVarReturnsOnCall[len(fake.setVarArgsForCall)]
+	fake.setVarArgsForCall = append(fake.setVarArgsForCall, struct {
+		arg1 string
+		arg2 interface{}
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.SetVarStub
+	fakeReturns := fake.setVarReturns
+	fake.recordInvocation("SetVar", []interface{}{arg1, arg2, arg3})
+	fake.setVarMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) SetVarCallCount() int {
+	fake.setVarMutex.RLock()
+	defer fake.setVarMutex.RUnlock()
+	return len(fake.setVarArgsForCall)
+}
+
+func (fake *FakePipeline) SetVarCalls(stub func(string, interface{}, bool) (bool, error)) {
+	fake.setVarMutex.Lock()
+	defer fake.setVarMutex.Unlock()
+	fake.SetVarStub = stub
+}
+
+func (fake *FakePipeline) SetVarArgsForCall(i int) (string, interface{}, bool) {
+	fake.setVarMutex.RLock()
+	defer fake.setVarMutex.RUnlock()
+	argsForCall := fake.setVarArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePipeline) SetVarReturns(result1 bool, result2 error) {
+	fake.setVarMutex.Lock()
+	defer fake.setVarMutex.Unlock()
+	fake.SetVarStub = nil
+	fake.setVarReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SetVarReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.setVarMutex.Lock()
+	defer fake.setVarMutex.Unlock()
+	fake.SetVarStub = nil
+	if fake.setVarReturnsOnCall == nil {
+		fake.setVarReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.setVarReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) TaskDefaults() *atc.TaskDefaults {
+	fake.taskDefaultsMutex.Lock()
+	ret, specificReturn := fake.taskDefaultsReturnsOnCall[len(fake.taskDefaultsArgsForCall)]
+	fake.taskDefaultsArgsForCall = append(fake.taskDefaultsArgsForCall, struct {
+	}{})
+	stub := fake.TaskDefaultsStub
+	fakeReturns := fake.taskDefaultsReturns
+	fake.recordInvocation("TaskDefaults", []interface{}{})
+	fake.taskDefaultsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) TaskDefaultsCallCount() int {
+	fake.taskDefaultsMutex.RLock()
+	defer fake.taskDefaultsMutex.RUnlock()
+	return len(fake.taskDefaultsArgsForCall)
+}
+
+func (fake *FakePipeline) TaskDefaultsCalls(stub func() *atc.TaskDefaults) {
+	fake.taskDefaultsMutex.Lock()
+	defer fake.taskDefaultsMutex.Unlock()
+	fake.TaskDefaultsStub = stub
+}
+
+func (fake *FakePipeline) TaskDefaultsReturns(result1 *atc.TaskDefaults) {
+	fake.taskDefaultsMutex.Lock()
+	defer fake.taskDefaultsMutex.Unlock()
+	fake.TaskDefaultsStub = nil
+	fake.taskDefaultsReturns = struct {
+		result1 *atc.TaskDefaults
+	}{result1}
+}
+
+func (fake *FakePipeline) TaskDefaultsReturnsOnCall(i int, result1 *atc.TaskDefaults) {
+	fake.taskDefaultsMutex.Lock()
+	defer fake.taskDefaultsMutex.Unlock()
+	fake.TaskDefaultsStub = nil
+	if fake.taskDefaultsReturnsOnCall == nil {
+		fake.taskDefaultsReturnsOnCall = make(map[int]struct {
+			result1 *atc.TaskDefaults
+		})
+	}
+	fake.taskDefaultsReturnsOnCall[i] = struct {
+		result1 *atc.TaskDefaults
+	}{result1}
+}
+
 func (fake *FakePipeline) TeamID() int {
 	fake.teamIDMutex.Lock()
 	ret, specificReturn := fake.teamIDReturnsOnCall[len(fake.teamIDArgsForCall)]
@@ -3033,6 +3602,128 @@ func (fake *FakePipeline) UnpauseReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipeline) UnpauseAwaitingChecks(arg1 []int) error {
+	var arg1Copy []int
+	if arg1 != nil {
+		arg1Copy = make([]int, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.unpauseAwaitingChecksMutex.Lock()
+	ret, specificReturn := fake.unpauseAwaitingChecksReturnsOnCall[len(fake.unpauseAwaitingChecksArgsForCall)]
+	fake.unpauseAwaitingChecksArgsForCall = append(fake.unpauseAwaitingChecksArgsForCall, struct {
+		arg1 []int
+	}{arg1Copy})
+	stub := fake.UnpauseAwaitingChecksStub
+	fakeReturns := fake.unpauseAwaitingChecksReturns
+	fake.recordInvocation("UnpauseAwaitingChecks", []interface{}{arg1Copy})
+	fake.unpauseAwaitingChecksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) UnpauseAwaitingChecksCallCount() int {
+	fake.unpauseAwaitingChecksMutex.RLock()
+	defer fake.unpauseAwaitingChecksMutex.RUnlock()
+	return len(fake.unpauseAwaitingChecksArgsForCall)
+}
+
+func (fake *FakePipeline) UnpauseAwaitingChecksCalls(stub func([]int) error) {
+	fake.unpauseAwaitingChecksMutex.Lock()
+	defer fake.unpauseAwaitingChecksMutex.Unlock()
+	fake.UnpauseAwaitingChecksStub = stub
+}
+
+func (fake *FakePipeline) UnpauseAwaitingChecksArgsForCall(i int) []int {
+	fake.unpauseAwaitingChecksMutex.RLock()
+	defer fake.unpauseAwaitingChecksMutex.RUnlock()
+	argsForCall := fake.unpauseAwaitingChecksArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) UnpauseAwaitingChecksReturns(result1 error) {
+	fake.unpauseAwaitingChecksMutex.Lock()
+	defer fake.unpauseAwaitingChecksMutex.Unlock()
+	fake.UnpauseAwaitingChecksStub = nil
+	fake.unpauseAwaitingChecksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) UnpauseAwaitingChecksReturnsOnCall(i int, result1 error) {
+	fake.unpauseAwaitingChecksMutex.Lock()
+	defer fake.unpauseAwaitingChecksMutex.Unlock()
+	fake.UnpauseAwaitingChecksStub = nil
+	if fake.unpauseAwaitingChecksReturnsOnCall == nil {
+		fake.unpauseAwaitingChecksReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unpauseAwaitingChecksReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) VarNames() ([]string, error) {
+	fake.varNamesMutex.Lock()
+	ret, specificReturn := fake.varNamesReturnsOnCall[len(fake.varNamesArgsForCall)]
+	fake.varNamesArgsForCall = append(fake.varNamesArgsForCall, struct {
+	}{})
+	stub := fake.VarNamesStub
+	fakeReturns := fake.varNamesReturns
+	fake.recordInvocation("VarNames", []interface{}{})
+	fake.varNamesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) VarNamesCallCount() int {
+	fake.varNamesMutex.RLock()
+	defer fake.varNamesMutex.RUnlock()
+	return len(fake.varNamesArgsForCall)
+}
+
+func (fake *FakePipeline) VarNamesCalls(stub func() ([]string, error)) {
+	fake.varNamesMutex.Lock()
+	defer fake.varNamesMutex.Unlock()
+	fake.VarNamesStub = stub
+}
+
+func (fake *FakePipeline) VarNamesReturns(result1 []string, result2 error) {
+	fake.varNamesMutex.Lock()
+	defer fake.varNamesMutex.Unlock()
+	fake.VarNamesStub = nil
+	fake.varNamesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) VarNamesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.varNamesMutex.Lock()
+	defer fake.varNamesMutex.Unlock()
+	fake.VarNamesStub = nil
+	if fake.varNamesReturnsOnCall == nil {
+		fake.varNamesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.varNamesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) VarSources() atc.VarSourceConfigs {
 	fake.varSourcesMutex.Lock()
 	ret, specificReturn := fake.varSourcesReturnsOnCall[len(fake.varSourcesArgsForCall)]
@@ -3152,6 +3843,238 @@ func (fake *FakePipeline) VariablesReturnsOnCall(i int, result1 vars.Variables,
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) Vars() (vars.StaticVariables, error) {
+	fake.varsMutex.Lock()
+	ret, specificReturn := fake.varsReturnsOnCall[len(fake.varsArgsForCall)]
+	fake.varsArgsForCall = append(fake.varsArgsForCall, struct {
+	}{})
+	stub := fake.VarsStub
+	fakeReturns := fake.varsReturns
+	fake.recordInvocation("Vars", []interface{}{})
+	fake.varsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) VarsCallCount() int {
+	fake.varsMutex.RLock()
+	defer fake.varsMutex.RUnlock()
+	return len(fake.varsArgsForCall)
+}
+
+func (fake *FakePipeline) VarsCalls(stub func() (vars.StaticVariables, error)) {
+	fake.varsMutex.Lock()
+	defer fake.varsMutex.Unlock()
+	fake.VarsStub = stub
+}
+
+func (fake *FakePipeline) VarsReturns(result1 vars.StaticVariables, result2 error) {
+	fake.varsMutex.Lock()
+	defer fake.varsMutex.Unlock()
+	fake.VarsStub = nil
+	fake.varsReturns = struct {
+		result1 vars.StaticVariables
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) VarsReturnsOnCall(i int, result1 vars.StaticVariables, result2 error) {
+	fake.varsMutex.Lock()
+	defer fake.varsMutex.Unlock()
+	fake.VarsStub = nil
+	if fake.varsReturnsOnCall == nil {
+		fake.varsReturnsOnCall = make(map[int]struct {
+			result1 vars.StaticVariables
+			result2 error
+		})
+	}
+	fake.varsReturnsOnCall[i] = struct {
+		result1 vars.StaticVariables
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) VersionSet(arg1 string) (db.VersionSet, bool, error) {
+	fake.versionSetMutex.Lock()
+	ret, specificReturn := fake.versionSetReturnsOnCall[len(fake.versionSetArgsForCall)]
+	fake.versionSetArgsForCall = append(fake.versionSetArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.VersionSetStub
+	fakeReturns := fake.versionSetReturns
+	fake.recordInvocation("VersionSet", []interface{}{arg1})
+	fake.versionSetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipeline) VersionSetCallCount() int {
+	fake.versionSetMutex.RLock()
+	defer fake.versionSetMutex.RUnlock()
+	return len(fake.versionSetArgsForCall)
+}
+
+func (fake *FakePipeline) VersionSetCalls(stub func(string) (db.VersionSet, bool, error)) {
+	fake.versionSetMutex.Lock()
+	defer fake.versionSetMutex.Unlock()
+	fake.VersionSetStub = stub
+}
+
+func (fake *FakePipeline) VersionSetArgsForCall(i int) string {
+	fake.versionSetMutex.RLock()
+	defer fake.versionSetMutex.RUnlock()
+	argsForCall := fake.versionSetArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) VersionSetReturns(result1 db.VersionSet, result2 bool, result3 error) {
+	fake.versionSetMutex.Lock()
+	defer fake.versionSetMutex.Unlock()
+	fake.VersionSetStub = nil
+	fake.versionSetReturns = struct {
+		result1 db.VersionSet
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) VersionSetReturnsOnCall(i int, result1 db.VersionSet, result2 bool, result3 error) {
+	fake.versionSetMutex.Lock()
+	defer fake.versionSetMutex.Unlock()
+	fake.VersionSetStub = nil
+	if fake.versionSetReturnsOnCall == nil {
+		fake.versionSetReturnsOnCall = make(map[int]struct {
+			result1 db.VersionSet
+			result2 bool
+			result3 error
+		})
+	}
+	fake.versionSetReturnsOnCall[i] = struct {
+		result1 db.VersionSet
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) VersionSets() ([]db.VersionSet, error) {
+	fake.versionSetsMutex.Lock()
+	ret, specificReturn := fake.versionSetsReturnsOnCall[len(fake.versionSetsArgsForCall)]
+	fake.versionSetsArgsForCall = append(fake.versionSetsArgsForCall, struct {
+	}{})
+	stub := fake.VersionSetsStub
+	fakeReturns := fake.versionSetsReturns
+	fake.recordInvocation("VersionSets", []interface{}{})
+	fake.versionSetsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) VersionSetsCallCount() int {
+	fake.versionSetsMutex.RLock()
+	defer fake.versionSetsMutex.RUnlock()
+	return len(fake.versionSetsArgsForCall)
+}
+
+func (fake *FakePipeline) VersionSetsCalls(stub func() ([]db.VersionSet, error)) {
+	fake.versionSetsMutex.Lock()
+	defer fake.versionSetsMutex.Unlock()
+	fake.VersionSetsStub = stub
+}
+
+func (fake *FakePipeline) VersionSetsReturns(result1 []db.VersionSet, result2 error) {
+	fake.versionSetsMutex.Lock()
+	defer fake.versionSetsMutex.Unlock()
+	fake.VersionSetsStub = nil
+	fake.versionSetsReturns = struct {
+		result1 []db.VersionSet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) VersionSetsReturnsOnCall(i int, result1 []db.VersionSet, result2 error) {
+	fake.versionSetsMutex.Lock()
+	defer fake.versionSetsMutex.Unlock()
+	fake.VersionSetsStub = nil
+	if fake.versionSetsReturnsOnCall == nil {
+		fake.versionSetsReturnsOnCall = make(map[int]struct {
+			result1 []db.VersionSet
+			result2 error
+		})
+	}
+	fake.versionSetsReturnsOnCall[i] = struct {
+		result1 []db.VersionSet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) Webhooks() atc.WebhookConfigs {
+	fake.webhooksMutex.Lock()
+	ret, specificReturn := fake.webhooksReturnsOnCall[len(fake.webhooksArgsForCall)]
+	fake.webhooksArgsForCall = append(fake.webhooksArgsForCall, struct {
+	}{})
+	stub := fake.WebhooksStub
+	fakeReturns := fake.webhooksReturns
+	fake.recordInvocation("Webhooks", []interface{}{})
+	fake.webhooksMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) WebhooksCallCount() int {
+	fake.webhooksMutex.RLock()
+	defer fake.webhooksMutex.RUnlock()
+	return len(fake.webhooksArgsForCall)
+}
+
+func (fake *FakePipeline) WebhooksCalls(stub func() atc.WebhookConfigs) {
+	fake.webhooksMutex.Lock()
+	defer fake.webhooksMutex.Unlock()
+	fake.WebhooksStub = stub
+}
+
+func (fake *FakePipeline) WebhooksReturns(result1 atc.WebhookConfigs) {
+	fake.webhooksMutex.Lock()
+	defer fake.webhooksMutex.Unlock()
+	fake.WebhooksStub = nil
+	fake.webhooksReturns = struct {
+		result1 atc.WebhookConfigs
+	}{result1}
+}
+
+func (fake *FakePipeline) WebhooksReturnsOnCall(i int, result1 atc.WebhookConfigs) {
+	fake.webhooksMutex.Lock()
+	defer fake.webhooksMutex.Unlock()
+	fake.WebhooksStub = nil
+	if fake.webhooksReturnsOnCall == nil {
+		fake.webhooksReturnsOnCall = make(map[int]struct {
+			result1 atc.WebhookConfigs
+		})
+	}
+	fake.webhooksReturnsOnCall[i] = struct {
+		result1 atc.WebhookConfigs
+	}{result1}
+}
+
 func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -3179,6 +4102,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.dashboardMutex.RUnlock()
 	fake.deleteBuildEventsByBuildIDsMutex.RLock()
 	defer fake.deleteBuildEventsByBuildIDsMutex.RUnlock()
+	fake.deleteVarMutex.RLock()
+	defer fake.deleteVarMutex.RUnlock()
 	fake.destroyMutex.RLock()
 	defer fake.destroyMutex.RUnlock()
 	fake.displayMutex.RLock()
@@ -3205,6 +4130,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.lastUpdatedMutex.RUnlock()
 	fake.loadDebugVersionsDBMutex.RLock()
 	defer fake.loadDebugVersionsDBMutex.RUnlock()
+	fake.maxBuildDurationMutex.RLock()
+	defer fake.maxBuildDurationMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.parentBuildIDMutex.RLock()
@@ -3215,6 +4142,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.pauseMutex.RUnlock()
 	fake.pausedMutex.RLock()
 	defer fake.pausedMutex.RUnlock()
+	fake.pendingChecksMutex.RLock()
+	defer fake.pendingChecksMutex.RUnlock()
 	fake.publicMutex.RLock()
 	defer fake.publicMutex.RUnlock()
 	fake.reloadMutex.RLock()
@@ -3233,18 +4162,38 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.resourceVersionMutex.RUnlock()
 	fake.resourcesMutex.RLock()
 	defer fake.resourcesMutex.RUnlock()
+	fake.saveVersionSetMutex.RLock()
+	defer fake.saveVersionSetMutex.RUnlock()
+	fake.serialGroupsMutex.RLock()
+	defer fake.serialGroupsMutex.RUnlock()
 	fake.setParentIDsMutex.RLock()
 	defer fake.setParentIDsMutex.RUnlock()
+	fake.setVarMutex.RLock()
+	defer fake.setVarMutex.RUnlock()
+	fake.taskDefaultsMutex.RLock()
+	defer fake.taskDefaultsMutex.RUnlock()
 	fake.teamIDMutex.RLock()
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
 	fake.unpauseMutex.RLock()
 	defer fake.unpauseMutex.RUnlock()
+	fake.unpauseAwaitingChecksMutex.RLock()
+	defer fake.unpauseAwaitingChecksMutex.RUnlock()
+	fake.varNamesMutex.RLock()
+	defer fake.varNamesMutex.RUnlock()
 	fake.varSourcesMutex.RLock()
 	defer fake.varSourcesMutex.RUnlock()
 	fake.variablesMutex.RLock()
 	defer fake.variablesMutex.RUnlock()
+	fake.varsMutex.RLock()
+	defer fake.varsMutex.RUnlock()
+	fake.versionSetMutex.RLock()
+	defer fake.versionSetMutex.RUnlock()
+	fake.versionSetsMutex.RLock()
+	defer fake.versionSetsMutex.RUnlock()
+	fake.webhooksMutex.RLock()
+	defer fake.webhooksMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
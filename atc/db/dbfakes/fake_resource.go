@@ -21,6 +21,19 @@ type FakeResource struct {
 	aPIPinnedVersionReturnsOnCall map[int]struct {
 		result1 atc.Version
 	}
+	BackfillVersionsStub        func([]atc.Version) (int, error)
+	backfillVersionsMutex       sync.RWMutex
+	backfillVersionsArgsForCall []struct {
+		arg1 []atc.Version
+	}
+	backfillVersionsReturns struct {
+		result1 int
+		result2 error
+	}
+	backfillVersionsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	BuildSummaryStub        func() *atc.BuildSummary
 	buildSummaryMutex       sync.RWMutex
 	buildSummaryArgsForCall []struct {
@@ -41,6 +54,36 @@ type FakeResource struct {
 	checkEveryReturnsOnCall map[int]struct {
 		result1 *atc.CheckEvery
 	}
+	CheckFailuresStub        func() int
+	checkFailuresMutex       sync.RWMutex
+	checkFailuresArgsForCall []struct {
+	}
+	checkFailuresReturns struct {
+		result1 int
+	}
+	checkFailuresReturnsOnCall map[int]struct {
+		result1 int
+	}
+	CheckOnDemandStub        func() bool
+	checkOnDemandMutex       sync.RWMutex
+	checkOnDemandArgsForCall []struct {
+	}
+	checkOnDemandReturns struct {
+		result1 bool
+	}
+	checkOnDemandReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	CheckPausedStub        func() bool
+	checkPausedMutex       sync.RWMutex
+	checkPausedArgsForCall []struct {
+	}
+	checkPausedReturns struct {
+		result1 bool
+	}
+	checkPausedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	CheckPlanStub        func(atc.Version, time.Duration, db.ResourceTypes, atc.Source) atc.CheckPlan
 	checkPlanMutex       sync.RWMutex
 	checkPlanArgsForCall []struct {
@@ -55,6 +98,31 @@ type FakeResource struct {
 	checkPlanReturnsOnCall map[int]struct {
 		result1 atc.CheckPlan
 	}
+	CheckRecordsStub        func(db.Page) ([]db.CheckRecord, db.Pagination, error)
+	checkRecordsMutex       sync.RWMutex
+	checkRecordsArgsForCall []struct {
+		arg1 db.Page
+	}
+	checkRecordsReturns struct {
+		result1 []db.CheckRecord
+		result2 db.Pagination
+		result3 error
+	}
+	checkRecordsReturnsOnCall map[int]struct {
+		result1 []db.CheckRecord
+		result2 db.Pagination
+		result3 error
+	}
+	CheckTTLStub        func() time.Duration
+	checkTTLMutex       sync.RWMutex
+	checkTTLArgsForCall []struct {
+	}
+	checkTTLReturns struct {
+		result1 time.Duration
+	}
+	checkTTLReturnsOnCall map[int]struct {
+		result1 time.Duration
+	}
 	CheckTimeoutStub        func() string
 	checkTimeoutMutex       sync.RWMutex
 	checkTimeoutArgsForCall []struct {
@@ -112,10 +180,12 @@ type FakeResource struct {
 	currentPinnedVersionReturnsOnCall map[int]struct {
 		result1 atc.Version
 	}
-	DisableVersionStub        func(int) error
+	DisableVersionStub        func(int, string, string) error
 	disableVersionMutex       sync.RWMutex
 	disableVersionArgsForCall []struct {
 		arg1 int
+		arg2 string
+		arg3 string
 	}
 	disableVersionReturns struct {
 		result1 error
@@ -123,10 +193,12 @@ type FakeResource struct {
 	disableVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
-	EnableVersionStub        func(int) error
+	EnableVersionStub        func(int, string, string) error
 	enableVersionMutex       sync.RWMutex
 	enableVersionArgsForCall []struct {
 		arg1 int
+		arg2 string
+		arg3 string
 	}
 	enableVersionReturns struct {
 		result1 error
@@ -179,6 +251,16 @@ type FakeResource struct {
 	iconReturnsOnCall map[int]struct {
 		result1 string
 	}
+	LastCheckContainerHandleStub        func() string
+	lastCheckContainerHandleMutex       sync.RWMutex
+	lastCheckContainerHandleArgsForCall []struct {
+	}
+	lastCheckContainerHandleReturns struct {
+		result1 string
+	}
+	lastCheckContainerHandleReturnsOnCall map[int]struct {
+		result1 string
+	}
 	LastCheckEndTimeStub        func() time.Time
 	lastCheckEndTimeMutex       sync.RWMutex
 	lastCheckEndTimeArgsForCall []struct {
@@ -199,6 +281,46 @@ type FakeResource struct {
 	lastCheckStartTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	LastCheckSucceededStub        func() bool
+	lastCheckSucceededMutex       sync.RWMutex
+	lastCheckSucceededArgsForCall []struct {
+	}
+	lastCheckSucceededReturns struct {
+		result1 bool
+	}
+	lastCheckSucceededReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	LastCheckSuccessTimeStub        func() time.Time
+	lastCheckSuccessTimeMutex       sync.RWMutex
+	lastCheckSuccessTimeArgsForCall []struct {
+	}
+	lastCheckSuccessTimeReturns struct {
+		result1 time.Time
+	}
+	lastCheckSuccessTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	LastScheduledCheckEndTimeStub        func() time.Time
+	lastScheduledCheckEndTimeMutex       sync.RWMutex
+	lastScheduledCheckEndTimeArgsForCall []struct {
+	}
+	lastScheduledCheckEndTimeReturns struct {
+		result1 time.Time
+	}
+	lastScheduledCheckEndTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	LastScheduledCheckSucceededStub        func() bool
+	lastScheduledCheckSucceededMutex       sync.RWMutex
+	lastScheduledCheckSucceededArgsForCall []struct {
+	}
+	lastScheduledCheckSucceededReturns struct {
+		result1 bool
+	}
+	lastScheduledCheckSucceededReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -219,6 +341,28 @@ type FakeResource struct {
 	notifyScanReturnsOnCall map[int]struct {
 		result1 error
 	}
+	OnDemandCheckNeededStub        func() (bool, error)
+	onDemandCheckNeededMutex       sync.RWMutex
+	onDemandCheckNeededArgsForCall []struct {
+	}
+	onDemandCheckNeededReturns struct {
+		result1 bool
+		result2 error
+	}
+	onDemandCheckNeededReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	PauseCheckingStub        func() error
+	pauseCheckingMutex       sync.RWMutex
+	pauseCheckingArgsForCall []struct {
+	}
+	pauseCheckingReturns struct {
+		result1 error
+	}
+	pauseCheckingReturnsOnCall map[int]struct {
+		result1 error
+	}
 	PinCommentStub        func() string
 	pinCommentMutex       sync.RWMutex
 	pinCommentArgsForCall []struct {
@@ -229,10 +373,11 @@ type FakeResource struct {
 	pinCommentReturnsOnCall map[int]struct {
 		result1 string
 	}
-	PinVersionStub        func(int) (bool, error)
+	PinVersionStub        func(int, string) (bool, error)
 	pinVersionMutex       sync.RWMutex
 	pinVersionArgsForCall []struct {
 		arg1 int
+		arg2 string
 	}
 	pinVersionReturns struct {
 		result1 bool
@@ -296,6 +441,19 @@ type FakeResource struct {
 	pipelineRefReturnsOnCall map[int]struct {
 		result1 atc.PipelineRef
 	}
+	PromoteVersionStub        func(int) (bool, error)
+	promoteVersionMutex       sync.RWMutex
+	promoteVersionArgsForCall []struct {
+		arg1 int
+	}
+	promoteVersionReturns struct {
+		result1 bool
+		result2 error
+	}
+	promoteVersionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	PublicStub        func() bool
 	publicMutex       sync.RWMutex
 	publicArgsForCall []struct {
@@ -410,6 +568,16 @@ type FakeResource struct {
 	typeReturnsOnCall map[int]struct {
 		result1 string
 	}
+	UnpauseCheckingStub        func() error
+	unpauseCheckingMutex       sync.RWMutex
+	unpauseCheckingArgsForCall []struct {
+	}
+	unpauseCheckingReturns struct {
+		result1 error
+	}
+	unpauseCheckingReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UnpinVersionStub        func() error
 	unpinVersionMutex       sync.RWMutex
 	unpinVersionArgsForCall []struct {
@@ -420,6 +588,19 @@ type FakeResource struct {
 	unpinVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UnpromoteVersionStub        func(int) (bool, error)
+	unpromoteVersionMutex       sync.RWMutex
+	unpromoteVersionArgsForCall []struct {
+		arg1 int
+	}
+	unpromoteVersionReturns struct {
+		result1 bool
+		result2 error
+	}
+	unpromoteVersionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	UpdateMetadataStub        func(atc.Version, db.ResourceConfigMetadataFields) (bool, error)
 	updateMetadataMutex       sync.RWMutex
 	updateMetadataArgsForCall []struct {
@@ -434,6 +615,18 @@ type FakeResource struct {
 		result1 bool
 		result2 error
 	}
+	VersionTogglesStub        func() ([]db.VersionToggle, error)
+	versionTogglesMutex       sync.RWMutex
+	versionTogglesArgsForCall []struct {
+	}
+	versionTogglesReturns struct {
+		result1 []db.VersionToggle
+		result2 error
+	}
+	versionTogglesReturnsOnCall map[int]struct {
+		result1 []db.VersionToggle
+		result2 error
+	}
 	VersionsStub        func(db.Page, atc.Version) ([]atc.ResourceVersion, db.Pagination, bool, error)
 	versionsMutex       sync.RWMutex
 	versionsArgsForCall []struct {
@@ -519,6 +712,75 @@ func (fake *FakeResource) APIPinnedVersionReturnsOnCall(i int, result1 atc.Versi
 	}{result1}
 }
 
+func (fake *FakeResource) BackfillVersions(arg1 []atc.Version) (int, error) {
+	var arg1Copy []atc.Version
+	if arg1 != nil {
+		arg1Copy = make([]atc.Version, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.backfillVersionsMutex.Lock()
+	ret, specificReturn := fake.backfillVersionsReturnsOnCall[len(fake.backfillVersionsArgsForCall)]
+	fake.backfillVersionsArgsForCall = append(fake.backfillVersionsArgsForCall, struct {
+		arg1 []atc.Version
+	}{arg1Copy})
+	stub := fake.BackfillVersionsStub
+	fakeReturns := fake.backfillVersionsReturns
+	fake.recordInvocation("BackfillVersions", []interface{}{arg1Copy})
+	fake.backfillVersionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) BackfillVersionsCallCount() int {
+	fake.backfillVersionsMutex.RLock()
+	defer fake.backfillVersionsMutex.RUnlock()
+	return len(fake.backfillVersionsArgsForCall)
+}
+
+func (fake *FakeResource) BackfillVersionsCalls(stub func([]atc.Version) (int, error)) {
+	fake.backfillVersionsMutex.Lock()
+	defer fake.backfillVersionsMutex.Unlock()
+	fake.BackfillVersionsStub = stub
+}
+
+func (fake *FakeResource) BackfillVersionsArgsForCall(i int) []atc.Version {
+	fake.backfillVersionsMutex.RLock()
+	defer fake.backfillVersionsMutex.RUnlock()
+	argsForCall := fake.backfillVersionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) BackfillVersionsReturns(result1 int, result2 error) {
+	fake.backfillVersionsMutex.Lock()
+	defer fake.backfillVersionsMutex.Unlock()
+	fake.BackfillVersionsStub = nil
+	fake.backfillVersionsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) BackfillVersionsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.backfillVersionsMutex.Lock()
+	defer fake.backfillVersionsMutex.Unlock()
+	fake.BackfillVersionsStub = nil
+	if fake.backfillVersionsReturnsOnCall == nil {
+		fake.backfillVersionsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.backfillVersionsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) BuildSummary() *atc.BuildSummary {
 	fake.buildSummaryMutex.Lock()
 	ret, specificReturn := fake.buildSummaryReturnsOnCall[len(fake.buildSummaryArgsForCall)]
@@ -625,6 +887,165 @@ func (fake *FakeResource) CheckEveryReturnsOnCall(i int, result1 *atc.CheckEvery
 	}{result1}
 }
 
+func (fake *FakeResource) CheckFailures() int {
+	fake.checkFailuresMutex.Lock()
+	ret, specificReturn := fake.checkFailuresReturnsOnCall[len(fake.checkFailuresArgsForCall)]
+	fake.checkFailuresArgsForCall = append(fake.checkFailuresArgsForCall, struct {
+	}{})
+	stub := fake.CheckFailuresStub
+	fakeReturns := fake.checkFailuresReturns
+	fake.recordInvocation("CheckFailures", []interface{}{})
+	fake.checkFailuresMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) CheckFailuresCallCount() int {
+	fake.checkFailuresMutex.RLock()
+	defer fake.checkFailuresMutex.RUnlock()
+	return len(fake.checkFailuresArgsForCall)
+}
+
+func (fake *FakeResource) CheckFailuresCalls(stub func() int) {
+	fake.checkFailuresMutex.Lock()
+	defer fake.checkFailuresMutex.Unlock()
+	fake.CheckFailuresStub = stub
+}
+
+func (fake *FakeResource) CheckFailuresReturns(result1 int) {
+	fake.checkFailuresMutex.Lock()
+	defer fake.checkFailuresMutex.Unlock()
+	fake.CheckFailuresStub = nil
+	fake.checkFailuresReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeResource) CheckFailuresReturnsOnCall(i int, result1 int) {
+	fake.checkFailuresMutex.Lock()
+	defer fake.checkFailuresMutex.Unlock()
+	fake.CheckFailuresStub = nil
+	if fake.checkFailuresReturnsOnCall == nil {
+		fake.checkFailuresReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.checkFailuresReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeResource) CheckOnDemand() bool {
+	fake.checkOnDemandMutex.Lock()
+	ret, specificReturn := fake.checkOnDemandReturnsOnCall[len(fake.checkOnDemandArgsForCall)]
+	fake.checkOnDemandArgsForCall = append(fake.checkOnDemandArgsForCall, struct {
+	}{})
+	stub := fake.CheckOnDemandStub
+	fakeReturns := fake.checkOnDemandReturns
+	fake.recordInvocation("CheckOnDemand", []interface{}{})
+	fake.checkOnDemandMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) CheckOnDemandCallCount() int {
+	fake.checkOnDemandMutex.RLock()
+	defer fake.checkOnDemandMutex.RUnlock()
+	return len(fake.checkOnDemandArgsForCall)
+}
+
+func (fake *FakeResource) CheckOnDemandCalls(stub func() bool) {
+	fake.checkOnDemandMutex.Lock()
+	defer fake.checkOnDemandMutex.Unlock()
+	fake.CheckOnDemandStub = stub
+}
+
+func (fake *FakeResource) CheckOnDemandReturns(result1 bool) {
+	fake.checkOnDemandMutex.Lock()
+	defer fake.checkOnDemandMutex.Unlock()
+	fake.CheckOnDemandStub = nil
+	fake.checkOnDemandReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResource) CheckOnDemandReturnsOnCall(i int, result1 bool) {
+	fake.checkOnDemandMutex.Lock()
+	defer fake.checkOnDemandMutex.Unlock()
+	fake.CheckOnDemandStub = nil
+	if fake.checkOnDemandReturnsOnCall == nil {
+		fake.checkOnDemandReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.checkOnDemandReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResource) CheckPaused() bool {
+	fake.checkPausedMutex.Lock()
+	ret, specificReturn := fake.checkPausedReturnsOnCall[len(fake.checkPausedArgsForCall)]
+	fake.checkPausedArgsForCall = append(fake.checkPausedArgsForCall, struct {
+	}{})
+	stub := fake.CheckPausedStub
+	fakeReturns := fake.checkPausedReturns
+	fake.recordInvocation("CheckPaused", []interface{}{})
+	fake.checkPausedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) CheckPausedCallCount() int {
+	fake.checkPausedMutex.RLock()
+	defer fake.checkPausedMutex.RUnlock()
+	return len(fake.checkPausedArgsForCall)
+}
+
+func (fake *FakeResource) CheckPausedCalls(stub func() bool) {
+	fake.checkPausedMutex.Lock()
+	defer fake.checkPausedMutex.Unlock()
+	fake.CheckPausedStub = stub
+}
+
+func (fake *FakeResource) CheckPausedReturns(result1 bool) {
+	fake.checkPausedMutex.Lock()
+	defer fake.checkPausedMutex.Unlock()
+	fake.CheckPausedStub = nil
+	fake.checkPausedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResource) CheckPausedReturnsOnCall(i int, result1 bool) {
+	fake.checkPausedMutex.Lock()
+	defer fake.checkPausedMutex.Unlock()
+	fake.CheckPausedStub = nil
+	if fake.checkPausedReturnsOnCall == nil {
+		fake.checkPausedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.checkPausedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeResource) CheckPlan(arg1 atc.Version, arg2 time.Duration, arg3 db.ResourceTypes, arg4 atc.Source) atc.CheckPlan {
 	fake.checkPlanMutex.Lock()
 	ret, specificReturn := fake.checkPlanReturnsOnCall[len(fake.checkPlanArgsForCall)]
@@ -689,6 +1110,126 @@ func (fake *FakeResource) CheckPlanReturnsOnCall(i int, result1 atc.CheckPlan) {
 	}{result1}
 }
 
+func (fake *FakeResource) CheckRecords(arg1 db.Page) ([]db.CheckRecord, db.Pagination, error) {
+	fake.checkRecordsMutex.Lock()
+	ret, specificReturn := fake.checkRecordsReturnsOnCall[len(fake.checkRecordsArgsForCall)]
+	fake.checkRecordsArgsForCall = append(fake.checkRecordsArgsForCall, struct {
+		arg1 db.Page
+	}{arg1})
+	stub := fake.CheckRecordsStub
+	fakeReturns := fake.checkRecordsReturns
+	fake.recordInvocation("CheckRecords", []interface{}{arg1})
+	fake.checkRecordsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeResource) CheckRecordsCallCount() int {
+	fake.checkRecordsMutex.RLock()
+	defer fake.checkRecordsMutex.RUnlock()
+	return len(fake.checkRecordsArgsForCall)
+}
+
+func (fake *FakeResource) CheckRecordsCalls(stub func(db.Page) ([]db.CheckRecord, db.Pagination, error)) {
+	fake.checkRecordsMutex.Lock()
+	defer fake.checkRecordsMutex.Unlock()
+	fake.CheckRecordsStub = stub
+}
+
+func (fake *FakeResource) CheckRecordsArgsForCall(i int) db.Page {
+	fake.checkRecordsMutex.RLock()
+	defer fake.checkRecordsMutex.RUnlock()
+	argsForCall := fake.checkRecordsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) CheckRecordsReturns(result1 []db.CheckRecord, result2 db.Pagination, result3 error) {
+	fake.checkRecordsMutex.Lock()
+	defer fake.checkRecordsMutex.Unlock()
+	fake.CheckRecordsStub = nil
+	fake.checkRecordsReturns = struct {
+		result1 []db.CheckRecord
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeResource) CheckRecordsReturnsOnCall(i int, result1 []db.CheckRecord, result2 db.Pagination, result3 error) {
+	fake.checkRecordsMutex.Lock()
+	defer fake.checkRecordsMutex.Unlock()
+	fake.CheckRecordsStub = nil
+	if fake.checkRecordsReturnsOnCall == nil {
+		fake.checkRecordsReturnsOnCall = make(map[int]struct {
+			result1 []db.CheckRecord
+			result2 db.Pagination
+			result3 error
+		})
+	}
+	fake.checkRecordsReturnsOnCall[i] = struct {
+		result1 []db.CheckRecord
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeResource) CheckTTL() time.Duration {
+	fake.checkTTLMutex.Lock()
+	ret, specificReturn := fake.checkTTLReturnsOnCall[len(fake.checkTTLArgsForCall)]
+	fake.checkTTLArgsForCall = append(fake.checkTTLArgsForCall, struct {
+	}{})
+	stub := fake.CheckTTLStub
+	fakeReturns := fake.checkTTLReturns
+	fake.recordInvocation("CheckTTL", []interface{}{})
+	fake.checkTTLMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) CheckTTLCallCount() int {
+	fake.checkTTLMutex.RLock()
+	defer fake.checkTTLMutex.RUnlock()
+	return len(fake.checkTTLArgsForCall)
+}
+
+func (fake *FakeResource) CheckTTLCalls(stub func() time.Duration) {
+	fake.checkTTLMutex.Lock()
+	defer fake.checkTTLMutex.Unlock()
+	fake.CheckTTLStub = stub
+}
+
+func (fake *FakeResource) CheckTTLReturns(result1 time.Duration) {
+	fake.checkTTLMutex.Lock()
+	defer fake.checkTTLMutex.Unlock()
+	fake.CheckTTLStub = nil
+	fake.checkTTLReturns = struct {
+		result1 time.Duration
+	}{result1}
+}
+
+func (fake *FakeResource) CheckTTLReturnsOnCall(i int, result1 time.Duration) {
+	fake.checkTTLMutex.Lock()
+	defer fake.checkTTLMutex.Unlock()
+	fake.CheckTTLStub = nil
+	if fake.checkTTLReturnsOnCall == nil {
+		fake.checkTTLReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+		})
+	}
+	fake.checkTTLReturnsOnCall[i] = struct {
+		result1 time.Duration
+	}{result1}
+}
+
 func (fake *FakeResource) CheckTimeout() string {
 	fake.checkTimeoutMutex.Lock()
 	ret, specificReturn := fake.checkTimeoutReturnsOnCall[len(fake.checkTimeoutArgsForCall)]
@@ -970,18 +1511,20 @@ func (fake *FakeResource) CurrentPinnedVersionReturnsOnCall(i int, result1 atc.V
 	}{result1}
 }
 
-func (fake *FakeResource) DisableVersion(arg1 int) error {
+func (fake *FakeResource) DisableVersion(arg1 int, arg2 string, arg3 string) error {
 	fake.disableVersionMutex.Lock()
 	ret, specificReturn := fake.disableVersionReturnsOnCall[len(fake.disableVersionArgsForCall)]
 	fake.disableVersionArgsForCall = append(fake.disableVersionArgsForCall, struct {
 		arg1 int
-	}{arg1})
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.DisableVersionStub
 	fakeReturns := fake.disableVersionReturns
-	fake.recordInvocation("DisableVersion", []interface{}{arg1})
+	fake.recordInvocation("DisableVersion", []interface{}{arg1, arg2, arg3})
 	fake.disableVersionMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -995,17 +1538,17 @@ func (fake *FakeResource) DisableVersionCallCount() int {
 	return len(fake.disableVersionArgsForCall)
 }
 
-func (fake *FakeResource) DisableVersionCalls(stub func(int) error) {
+func (fake *FakeResource) DisableVersionCalls(stub func(int, string, string) error) {
 	fake.disableVersionMutex.Lock()
 	defer fake.disableVersionMutex.Unlock()
 	fake.DisableVersionStub = stub
 }
 
-func (fake *FakeResource) DisableVersionArgsForCall(i int) int {
+func (fake *FakeResource) DisableVersionArgsForCall(i int) (int, string, string) {
 	fake.disableVersionMutex.RLock()
 	defer fake.disableVersionMutex.RUnlock()
 	argsForCall := fake.disableVersionArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeResource) DisableVersionReturns(result1 error) {
@@ -1031,18 +1574,20 @@ func (fake *FakeResource) DisableVersionReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeResource) EnableVersion(arg1 int) error {
+func (fake *FakeResource) EnableVersion(arg1 int, arg2 string, arg3 string) error {
 	fake.enableVersionMutex.Lock()
 	ret, specificReturn := fake.enableVersionReturnsOnCall[len(fake.enableVersionArgsForCall)]
 	fake.enableVersionArgsForCall = append(fake.enableVersionArgsForCall, struct {
 		arg1 int
-	}{arg1})
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.EnableVersionStub
 	fakeReturns := fake.enableVersionReturns
-	fake.recordInvocation("EnableVersion", []interface{}{arg1})
+	fake.recordInvocation("EnableVersion", []interface{}{arg1, arg2, arg3})
 	fake.enableVersionMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1056,17 +1601,17 @@ func (fake *FakeResource) EnableVersionCallCount() int {
 	return len(fake.enableVersionArgsForCall)
 }
 
-func (fake *FakeResource) EnableVersionCalls(stub func(int) error) {
+func (fake *FakeResource) EnableVersionCalls(stub func(int, string, string) error) {
 	fake.enableVersionMutex.Lock()
 	defer fake.enableVersionMutex.Unlock()
 	fake.EnableVersionStub = stub
 }
 
-func (fake *FakeResource) EnableVersionArgsForCall(i int) int {
+func (fake *FakeResource) EnableVersionArgsForCall(i int) (int, string, string) {
 	fake.enableVersionMutex.RLock()
 	defer fake.enableVersionMutex.RUnlock()
 	argsForCall := fake.enableVersionArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeResource) EnableVersionReturns(result1 error) {
@@ -1295,38 +1840,303 @@ func (fake *FakeResource) IconCalls(stub func() string) {
 	fake.IconStub = stub
 }
 
-func (fake *FakeResource) IconReturns(result1 string) {
-	fake.iconMutex.Lock()
-	defer fake.iconMutex.Unlock()
-	fake.IconStub = nil
-	fake.iconReturns = struct {
-		result1 string
+func (fake *FakeResource) IconReturns(result1 string) {
+	fake.iconMutex.Lock()
+	defer fake.iconMutex.Unlock()
+	fake.IconStub = nil
+	fake.iconReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResource) IconReturnsOnCall(i int, result1 string) {
+	fake.iconMutex.Lock()
+	defer fake.iconMutex.Unlock()
+	fake.IconStub = nil
+	if fake.iconReturnsOnCall == nil {
+		fake.iconReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.iconReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResource) LastCheckContainerHandle() string {
+	fake.lastCheckContainerHandleMutex.Lock()
+	ret, specificReturn := fake.lastCheckContainerHandleReturnsOnCall[len(fake.lastCheckContainerHandleArgsForCall)]
+	fake.lastCheckContainerHandleArgsForCall = append(fake.lastCheckContainerHandleArgsForCall, struct {
+	}{})
+	stub := fake.LastCheckContainerHandleStub
+	fakeReturns := fake.lastCheckContainerHandleReturns
+	fake.recordInvocation("LastCheckContainerHandle", []interface{}{})
+	fake.lastCheckContainerHandleMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) LastCheckContainerHandleCallCount() int {
+	fake.lastCheckContainerHandleMutex.RLock()
+	defer fake.lastCheckContainerHandleMutex.RUnlock()
+	return len(fake.lastCheckContainerHandleArgsForCall)
+}
+
+func (fake *FakeResource) LastCheckContainerHandleCalls(stub func() string) {
+	fake.lastCheckContainerHandleMutex.Lock()
+	defer fake.lastCheckContainerHandleMutex.Unlock()
+	fake.LastCheckContainerHandleStub = stub
+}
+
+func (fake *FakeResource) LastCheckContainerHandleReturns(result1 string) {
+	fake.lastCheckContainerHandleMutex.Lock()
+	defer fake.lastCheckContainerHandleMutex.Unlock()
+	fake.LastCheckContainerHandleStub = nil
+	fake.lastCheckContainerHandleReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResource) LastCheckContainerHandleReturnsOnCall(i int, result1 string) {
+	fake.lastCheckContainerHandleMutex.Lock()
+	defer fake.lastCheckContainerHandleMutex.Unlock()
+	fake.LastCheckContainerHandleStub = nil
+	if fake.lastCheckContainerHandleReturnsOnCall == nil {
+		fake.lastCheckContainerHandleReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.lastCheckContainerHandleReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResource) LastCheckEndTime() time.Time {
+	fake.lastCheckEndTimeMutex.Lock()
+	ret, specificReturn := fake.lastCheckEndTimeReturnsOnCall[len(fake.lastCheckEndTimeArgsForCall)]
+	fake.lastCheckEndTimeArgsForCall = append(fake.lastCheckEndTimeArgsForCall, struct {
+	}{})
+	stub := fake.LastCheckEndTimeStub
+	fakeReturns := fake.lastCheckEndTimeReturns
+	fake.recordInvocation("LastCheckEndTime", []interface{}{})
+	fake.lastCheckEndTimeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) LastCheckEndTimeCallCount() int {
+	fake.lastCheckEndTimeMutex.RLock()
+	defer fake.lastCheckEndTimeMutex.RUnlock()
+	return len(fake.lastCheckEndTimeArgsForCall)
+}
+
+func (fake *FakeResource) LastCheckEndTimeCalls(stub func() time.Time) {
+	fake.lastCheckEndTimeMutex.Lock()
+	defer fake.lastCheckEndTimeMutex.Unlock()
+	fake.LastCheckEndTimeStub = stub
+}
+
+func (fake *FakeResource) LastCheckEndTimeReturns(result1 time.Time) {
+	fake.lastCheckEndTimeMutex.Lock()
+	defer fake.lastCheckEndTimeMutex.Unlock()
+	fake.LastCheckEndTimeStub = nil
+	fake.lastCheckEndTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeResource) LastCheckEndTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.lastCheckEndTimeMutex.Lock()
+	defer fake.lastCheckEndTimeMutex.Unlock()
+	fake.LastCheckEndTimeStub = nil
+	if fake.lastCheckEndTimeReturnsOnCall == nil {
+		fake.lastCheckEndTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.lastCheckEndTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeResource) LastCheckStartTime() time.Time {
+	fake.lastCheckStartTimeMutex.Lock()
+	ret, specificReturn := fake.lastCheckStartTimeReturnsOnCall[len(fake.lastCheckStartTimeArgsForCall)]
+	fake.lastCheckStartTimeArgsForCall = append(fake.lastCheckStartTimeArgsForCall, struct {
+	}{})
+	stub := fake.LastCheckStartTimeStub
+	fakeReturns := fake.lastCheckStartTimeReturns
+	fake.recordInvocation("LastCheckStartTime", []interface{}{})
+	fake.lastCheckStartTimeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) LastCheckStartTimeCallCount() int {
+	fake.lastCheckStartTimeMutex.RLock()
+	defer fake.lastCheckStartTimeMutex.RUnlock()
+	return len(fake.lastCheckStartTimeArgsForCall)
+}
+
+func (fake *FakeResource) LastCheckStartTimeCalls(stub func() time.Time) {
+	fake.lastCheckStartTimeMutex.Lock()
+	defer fake.lastCheckStartTimeMutex.Unlock()
+	fake.LastCheckStartTimeStub = stub
+}
+
+func (fake *FakeResource) LastCheckStartTimeReturns(result1 time.Time) {
+	fake.lastCheckStartTimeMutex.Lock()
+	defer fake.lastCheckStartTimeMutex.Unlock()
+	fake.LastCheckStartTimeStub = nil
+	fake.lastCheckStartTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeResource) LastCheckStartTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.lastCheckStartTimeMutex.Lock()
+	defer fake.lastCheckStartTimeMutex.Unlock()
+	fake.LastCheckStartTimeStub = nil
+	if fake.lastCheckStartTimeReturnsOnCall == nil {
+		fake.lastCheckStartTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.lastCheckStartTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeResource) LastCheckSucceeded() bool {
+	fake.lastCheckSucceededMutex.Lock()
+	ret, specificReturn := fake.lastCheckSucceededReturnsOnCall[len(fake.lastCheckSucceededArgsForCall)]
+	fake.lastCheckSucceededArgsForCall = append(fake.lastCheckSucceededArgsForCall, struct {
+	}{})
+	stub := fake.LastCheckSucceededStub
+	fakeReturns := fake.lastCheckSucceededReturns
+	fake.recordInvocation("LastCheckSucceeded", []interface{}{})
+	fake.lastCheckSucceededMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) LastCheckSucceededCallCount() int {
+	fake.lastCheckSucceededMutex.RLock()
+	defer fake.lastCheckSucceededMutex.RUnlock()
+	return len(fake.lastCheckSucceededArgsForCall)
+}
+
+func (fake *FakeResource) LastCheckSucceededCalls(stub func() bool) {
+	fake.lastCheckSucceededMutex.Lock()
+	defer fake.lastCheckSucceededMutex.Unlock()
+	fake.LastCheckSucceededStub = stub
+}
+
+func (fake *FakeResource) LastCheckSucceededReturns(result1 bool) {
+	fake.lastCheckSucceededMutex.Lock()
+	defer fake.lastCheckSucceededMutex.Unlock()
+	fake.LastCheckSucceededStub = nil
+	fake.lastCheckSucceededReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResource) LastCheckSucceededReturnsOnCall(i int, result1 bool) {
+	fake.lastCheckSucceededMutex.Lock()
+	defer fake.lastCheckSucceededMutex.Unlock()
+	fake.LastCheckSucceededStub = nil
+	if fake.lastCheckSucceededReturnsOnCall == nil {
+		fake.lastCheckSucceededReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.lastCheckSucceededReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResource) LastCheckSuccessTime() time.Time {
+	fake.lastCheckSuccessTimeMutex.Lock()
+	ret, specificReturn := fake.lastCheckSuccessTimeReturnsOnCall[len(fake.lastCheckSuccessTimeArgsForCall)]
+	fake.lastCheckSuccessTimeArgsForCall = append(fake.lastCheckSuccessTimeArgsForCall, struct {
+	}{})
+	stub := fake.LastCheckSuccessTimeStub
+	fakeReturns := fake.lastCheckSuccessTimeReturns
+	fake.recordInvocation("LastCheckSuccessTime", []interface{}{})
+	fake.lastCheckSuccessTimeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) LastCheckSuccessTimeCallCount() int {
+	fake.lastCheckSuccessTimeMutex.RLock()
+	defer fake.lastCheckSuccessTimeMutex.RUnlock()
+	return len(fake.lastCheckSuccessTimeArgsForCall)
+}
+
+func (fake *FakeResource) LastCheckSuccessTimeCalls(stub func() time.Time) {
+	fake.lastCheckSuccessTimeMutex.Lock()
+	defer fake.lastCheckSuccessTimeMutex.Unlock()
+	fake.LastCheckSuccessTimeStub = stub
+}
+
+func (fake *FakeResource) LastCheckSuccessTimeReturns(result1 time.Time) {
+	fake.lastCheckSuccessTimeMutex.Lock()
+	defer fake.lastCheckSuccessTimeMutex.Unlock()
+	fake.LastCheckSuccessTimeStub = nil
+	fake.lastCheckSuccessTimeReturns = struct {
+		result1 time.Time
 	}{result1}
 }
 
-func (fake *FakeResource) IconReturnsOnCall(i int, result1 string) {
-	fake.iconMutex.Lock()
-	defer fake.iconMutex.Unlock()
-	fake.IconStub = nil
-	if fake.iconReturnsOnCall == nil {
-		fake.iconReturnsOnCall = make(map[int]struct {
-			result1 string
+func (fake *FakeResource) LastCheckSuccessTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.lastCheckSuccessTimeMutex.Lock()
+	defer fake.lastCheckSuccessTimeMutex.Unlock()
+	fake.LastCheckSuccessTimeStub = nil
+	if fake.lastCheckSuccessTimeReturnsOnCall == nil {
+		fake.lastCheckSuccessTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
 		})
 	}
-	fake.iconReturnsOnCall[i] = struct {
-		result1 string
+	fake.lastCheckSuccessTimeReturnsOnCall[i] = struct {
+		result1 time.Time
 	}{result1}
 }
 
-func (fake *FakeResource) LastCheckEndTime() time.Time {
-	fake.lastCheckEndTimeMutex.Lock()
-	ret, specificReturn := fake.lastCheckEndTimeReturnsOnCall[len(fake.lastCheckEndTimeArgsForCall)]
-	fake.lastCheckEndTimeArgsForCall = append(fake.lastCheckEndTimeArgsForCall, struct {
+func (fake *FakeResource) LastScheduledCheckEndTime() time.Time {
+	fake.lastScheduledCheckEndTimeMutex.Lock()
+	ret, specificReturn := fake.lastScheduledCheckEndTimeReturnsOnCall[len(fake.lastScheduledCheckEndTimeArgsForCall)]
+	fake.lastScheduledCheckEndTimeArgsForCall = append(fake.lastScheduledCheckEndTimeArgsForCall, struct {
 	}{})
-	stub := fake.LastCheckEndTimeStub
-	fakeReturns := fake.lastCheckEndTimeReturns
-	fake.recordInvocation("LastCheckEndTime", []interface{}{})
-	fake.lastCheckEndTimeMutex.Unlock()
+	stub := fake.LastScheduledCheckEndTimeStub
+	fakeReturns := fake.lastScheduledCheckEndTimeReturns
+	fake.recordInvocation("LastScheduledCheckEndTime", []interface{}{})
+	fake.lastScheduledCheckEndTimeMutex.Unlock()
 	if stub != nil {
 		return stub()
 	}
@@ -1336,50 +2146,50 @@ func (fake *FakeResource) LastCheckEndTime() time.Time {
 	return fakeReturns.result1
 }
 
-func (fake *FakeResource) LastCheckEndTimeCallCount() int {
-	fake.lastCheckEndTimeMutex.RLock()
-	defer fake.lastCheckEndTimeMutex.RUnlock()
-	return len(fake.lastCheckEndTimeArgsForCall)
+func (fake *FakeResource) LastScheduledCheckEndTimeCallCount() int {
+	fake.lastScheduledCheckEndTimeMutex.RLock()
+	defer fake.lastScheduledCheckEndTimeMutex.RUnlock()
+	return len(fake.lastScheduledCheckEndTimeArgsForCall)
 }
 
-func (fake *FakeResource) LastCheckEndTimeCalls(stub func() time.Time) {
-	fake.lastCheckEndTimeMutex.Lock()
-	defer fake.lastCheckEndTimeMutex.Unlock()
-	fake.LastCheckEndTimeStub = stub
+func (fake *FakeResource) LastScheduledCheckEndTimeCalls(stub func() time.Time) {
+	fake.lastScheduledCheckEndTimeMutex.Lock()
+	defer fake.lastScheduledCheckEndTimeMutex.Unlock()
+	fake.LastScheduledCheckEndTimeStub = stub
 }
 
-func (fake *FakeResource) LastCheckEndTimeReturns(result1 time.Time) {
-	fake.lastCheckEndTimeMutex.Lock()
-	defer fake.lastCheckEndTimeMutex.Unlock()
-	fake.LastCheckEndTimeStub = nil
-	fake.lastCheckEndTimeReturns = struct {
+func (fake *FakeResource) LastScheduledCheckEndTimeReturns(result1 time.Time) {
+	fake.lastScheduledCheckEndTimeMutex.Lock()
+	defer fake.lastScheduledCheckEndTimeMutex.Unlock()
+	fake.LastScheduledCheckEndTimeStub = nil
+	fake.lastScheduledCheckEndTimeReturns = struct {
 		result1 time.Time
 	}{result1}
 }
 
-func (fake *FakeResource) LastCheckEndTimeReturnsOnCall(i int, result1 time.Time) {
-	fake.lastCheckEndTimeMutex.Lock()
-	defer fake.lastCheckEndTimeMutex.Unlock()
-	fake.LastCheckEndTimeStub = nil
-	if fake.lastCheckEndTimeReturnsOnCall == nil {
-		fake.lastCheckEndTimeReturnsOnCall = make(map[int]struct {
+func (fake *FakeResource) LastScheduledCheckEndTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.lastScheduledCheckEndTimeMutex.Lock()
+	defer fake.lastScheduledCheckEndTimeMutex.Unlock()
+	fake.LastScheduledCheckEndTimeStub = nil
+	if fake.lastScheduledCheckEndTimeReturnsOnCall == nil {
+		fake.lastScheduledCheckEndTimeReturnsOnCall = make(map[int]struct {
 			result1 time.Time
 		})
 	}
-	fake.lastCheckEndTimeReturnsOnCall[i] = struct {
+	fake.lastScheduledCheckEndTimeReturnsOnCall[i] = struct {
 		result1 time.Time
 	}{result1}
 }
 
-func (fake *FakeResource) LastCheckStartTime() time.Time {
-	fake.lastCheckStartTimeMutex.Lock()
-	ret, specificReturn := fake.lastCheckStartTimeReturnsOnCall[len(fake.lastCheckStartTimeArgsForCall)]
-	fake.lastCheckStartTimeArgsForCall = append(fake.lastCheckStartTimeArgsForCall, struct {
+func (fake *FakeResource) LastScheduledCheckSucceeded() bool {
+	fake.lastScheduledCheckSucceededMutex.Lock()
+	ret, specificReturn := fake.lastScheduledCheckSucceededReturnsOnCall[len(fake.lastScheduledCheckSucceededArgsForCall)]
+	fake.lastScheduledCheckSucceededArgsForCall = append(fake.lastScheduledCheckSucceededArgsForCall, struct {
 	}{})
-	stub := fake.LastCheckStartTimeStub
-	fakeReturns := fake.lastCheckStartTimeReturns
-	fake.recordInvocation("LastCheckStartTime", []interface{}{})
-	fake.lastCheckStartTimeMutex.Unlock()
+	stub := fake.LastScheduledCheckSucceededStub
+	fakeReturns := fake.lastScheduledCheckSucceededReturns
+	fake.recordInvocation("LastScheduledCheckSucceeded", []interface{}{})
+	fake.lastScheduledCheckSucceededMutex.Unlock()
 	if stub != nil {
 		return stub()
 	}
@@ -1389,38 +2199,38 @@ func (fake *FakeResource) LastCheckStartTime() time.Time {
 	return fakeReturns.result1
 }
 
-func (fake *FakeResource) LastCheckStartTimeCallCount() int {
-	fake.lastCheckStartTimeMutex.RLock()
-	defer fake.lastCheckStartTimeMutex.RUnlock()
-	return len(fake.lastCheckStartTimeArgsForCall)
+func (fake *FakeResource) LastScheduledCheckSucceededCallCount() int {
+	fake.lastScheduledCheckSucceededMutex.RLock()
+	defer fake.lastScheduledCheckSucceededMutex.RUnlock()
+	return len(fake.lastScheduledCheckSucceededArgsForCall)
 }
 
-func (fake *FakeResource) LastCheckStartTimeCalls(stub func() time.Time) {
-	fake.lastCheckStartTimeMutex.Lock()
-	defer fake.lastCheckStartTimeMutex.Unlock()
-	fake.LastCheckStartTimeStub = stub
+func (fake *FakeResource) LastScheduledCheckSucceededCalls(stub func() bool) {
+	fake.lastScheduledCheckSucceededMutex.Lock()
+	defer fake.lastScheduledCheckSucceededMutex.Unlock()
+	fake.LastScheduledCheckSucceededStub = stub
 }
 
-func (fake *FakeResource) LastCheckStartTimeReturns(result1 time.Time) {
-	fake.lastCheckStartTimeMutex.Lock()
-	defer fake.lastCheckStartTimeMutex.Unlock()
-	fake.LastCheckStartTimeStub = nil
-	fake.lastCheckStartTimeReturns = struct {
-		result1 time.Time
+func (fake *FakeResource) LastScheduledCheckSucceededReturns(result1 bool) {
+	fake.lastScheduledCheckSucceededMutex.Lock()
+	defer fake.lastScheduledCheckSucceededMutex.Unlock()
+	fake.LastScheduledCheckSucceededStub = nil
+	fake.lastScheduledCheckSucceededReturns = struct {
+		result1 bool
 	}{result1}
 }
 
-func (fake *FakeResource) LastCheckStartTimeReturnsOnCall(i int, result1 time.Time) {
-	fake.lastCheckStartTimeMutex.Lock()
-	defer fake.lastCheckStartTimeMutex.Unlock()
-	fake.LastCheckStartTimeStub = nil
-	if fake.lastCheckStartTimeReturnsOnCall == nil {
-		fake.lastCheckStartTimeReturnsOnCall = make(map[int]struct {
-			result1 time.Time
+func (fake *FakeResource) LastScheduledCheckSucceededReturnsOnCall(i int, result1 bool) {
+	fake.lastScheduledCheckSucceededMutex.Lock()
+	defer fake.lastScheduledCheckSucceededMutex.Unlock()
+	fake.LastScheduledCheckSucceededStub = nil
+	if fake.lastScheduledCheckSucceededReturnsOnCall == nil {
+		fake.lastScheduledCheckSucceededReturnsOnCall = make(map[int]struct {
+			result1 bool
 		})
 	}
-	fake.lastCheckStartTimeReturnsOnCall[i] = struct {
-		result1 time.Time
+	fake.lastScheduledCheckSucceededReturnsOnCall[i] = struct {
+		result1 bool
 	}{result1}
 }
 
@@ -1530,6 +2340,115 @@ func (fake *FakeResource) NotifyScanReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeResource) OnDemandCheckNeeded() (bool, error) {
+	fake.onDemandCheckNeededMutex.Lock()
+	ret, specificReturn := fake.onDemandCheckNeededReturnsOnCall[len(fake.onDemandCheckNeededArgsForCall)]
+	fake.onDemandCheckNeededArgsForCall = append(fake.onDemandCheckNeededArgsForCall, struct {
+	}{})
+	stub := fake.OnDemandCheckNeededStub
+	fakeReturns := fake.onDemandCheckNeededReturns
+	fake.recordInvocation("OnDemandCheckNeeded", []interface{}{})
+	fake.onDemandCheckNeededMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) OnDemandCheckNeededCallCount() int {
+	fake.onDemandCheckNeededMutex.RLock()
+	defer fake.onDemandCheckNeededMutex.RUnlock()
+	return len(fake.onDemandCheckNeededArgsForCall)
+}
+
+func (fake *FakeResource) OnDemandCheckNeededCalls(stub func() (bool, error)) {
+	fake.onDemandCheckNeededMutex.Lock()
+	defer fake.onDemandCheckNeededMutex.Unlock()
+	fake.OnDemandCheckNeededStub = stub
+}
+
+func (fake *FakeResource) OnDemandCheckNeededReturns(result1 bool, result2 error) {
+	fake.onDemandCheckNeededMutex.Lock()
+	defer fake.onDemandCheckNeededMutex.Unlock()
+	fake.OnDemandCheckNeededStub = nil
+	fake.onDemandCheckNeededReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) OnDemandCheckNeededReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.onDemandCheckNeededMutex.Lock()
+	defer fake.onDemandCheckNeededMutex.Unlock()
+	fake.OnDemandCheckNeededStub = nil
+	if fake.onDemandCheckNeededReturnsOnCall == nil {
+		fake.onDemandCheckNeededReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.onDemandCheckNeededReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) PauseChecking() error {
+	fake.pauseCheckingMutex.Lock()
+	ret, specificReturn := fake.pauseCheckingReturnsOnCall[len(fake.pauseCheckingArgsForCall)]
+	fake.pauseCheckingArgsForCall = append(fake.pauseCheckingArgsForCall, struct {
+	}{})
+	stub := fake.PauseCheckingStub
+	fakeReturns := fake.pauseCheckingReturns
+	fake.recordInvocation("PauseChecking", []interface{}{})
+	fake.pauseCheckingMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) PauseCheckingCallCount() int {
+	fake.pauseCheckingMutex.RLock()
+	defer fake.pauseCheckingMutex.RUnlock()
+	return len(fake.pauseCheckingArgsForCall)
+}
+
+func (fake *FakeResource) PauseCheckingCalls(stub func() error) {
+	fake.pauseCheckingMutex.Lock()
+	defer fake.pauseCheckingMutex.Unlock()
+	fake.PauseCheckingStub = stub
+}
+
+func (fake *FakeResource) PauseCheckingReturns(result1 error) {
+	fake.pauseCheckingMutex.Lock()
+	defer fake.pauseCheckingMutex.Unlock()
+	fake.PauseCheckingStub = nil
+	fake.pauseCheckingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) PauseCheckingReturnsOnCall(i int, result1 error) {
+	fake.pauseCheckingMutex.Lock()
+	defer fake.pauseCheckingMutex.Unlock()
+	fake.PauseCheckingStub = nil
+	if fake.pauseCheckingReturnsOnCall == nil {
+		fake.pauseCheckingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pauseCheckingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResource) PinComment() string {
 	fake.pinCommentMutex.Lock()
 	ret, specificReturn := fake.pinCommentReturnsOnCall[len(fake.pinCommentArgsForCall)]
@@ -1583,18 +2502,19 @@ func (fake *FakeResource) PinCommentReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
-func (fake *FakeResource) PinVersion(arg1 int) (bool, error) {
+func (fake *FakeResource) PinVersion(arg1 int, arg2 string) (bool, error) {
 	fake.pinVersionMutex.Lock()
 	ret, specificReturn := fake.pinVersionReturnsOnCall[len(fake.pinVersionArgsForCall)]
 	fake.pinVersionArgsForCall = append(fake.pinVersionArgsForCall, struct {
 		arg1 int
-	}{arg1})
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.PinVersionStub
 	fakeReturns := fake.pinVersionReturns
-	fake.recordInvocation("PinVersion", []interface{}{arg1})
+	fake.recordInvocation("PinVersion", []interface{}{arg1, arg2})
 	fake.pinVersionMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1608,17 +2528,17 @@ func (fake *FakeResource) PinVersionCallCount() int {
 	return len(fake.pinVersionArgsForCall)
 }
 
-func (fake *FakeResource) PinVersionCalls(stub func(int) (bool, error)) {
+func (fake *FakeResource) PinVersionCalls(stub func(int, string) (bool, error)) {
 	fake.pinVersionMutex.Lock()
 	defer fake.pinVersionMutex.Unlock()
 	fake.PinVersionStub = stub
 }
 
-func (fake *FakeResource) PinVersionArgsForCall(i int) int {
+func (fake *FakeResource) PinVersionArgsForCall(i int) (int, string) {
 	fake.pinVersionMutex.RLock()
 	defer fake.pinVersionMutex.RUnlock()
 	argsForCall := fake.pinVersionArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeResource) PinVersionReturns(result1 bool, result2 error) {
@@ -1918,6 +2838,70 @@ func (fake *FakeResource) PipelineRefReturnsOnCall(i int, result1 atc.PipelineRe
 	}{result1}
 }
 
+func (fake *FakeResource) PromoteVersion(arg1 int) (bool, error) {
+	fake.promoteVersionMutex.Lock()
+	ret, specificReturn := fake.promoteVersionReturnsOnCall[len(fake.promoteVersionArgsForCall)]
+	fake.promoteVersionArgsForCall = append(fake.promoteVersionArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.PromoteVersionStub
+	fakeReturns := fake.promoteVersionReturns
+	fake.recordInvocation("PromoteVersion", []interface{}{arg1})
+	fake.promoteVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) PromoteVersionCallCount() int {
+	fake.promoteVersionMutex.RLock()
+	defer fake.promoteVersionMutex.RUnlock()
+	return len(fake.promoteVersionArgsForCall)
+}
+
+func (fake *FakeResource) PromoteVersionCalls(stub func(int) (bool, error)) {
+	fake.promoteVersionMutex.Lock()
+	defer fake.promoteVersionMutex.Unlock()
+	fake.PromoteVersionStub = stub
+}
+
+func (fake *FakeResource) PromoteVersionArgsForCall(i int) int {
+	fake.promoteVersionMutex.RLock()
+	defer fake.promoteVersionMutex.RUnlock()
+	argsForCall := fake.promoteVersionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) PromoteVersionReturns(result1 bool, result2 error) {
+	fake.promoteVersionMutex.Lock()
+	defer fake.promoteVersionMutex.Unlock()
+	fake.PromoteVersionStub = nil
+	fake.promoteVersionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) PromoteVersionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.promoteVersionMutex.Lock()
+	defer fake.promoteVersionMutex.Unlock()
+	fake.PromoteVersionStub = nil
+	if fake.promoteVersionReturnsOnCall == nil {
+		fake.promoteVersionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.promoteVersionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) Public() bool {
 	fake.publicMutex.Lock()
 	ret, specificReturn := fake.publicReturnsOnCall[len(fake.publicArgsForCall)]
@@ -2520,6 +3504,59 @@ func (fake *FakeResource) TypeReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResource) UnpauseChecking() error {
+	fake.unpauseCheckingMutex.Lock()
+	ret, specificReturn := fake.unpauseCheckingReturnsOnCall[len(fake.unpauseCheckingArgsForCall)]
+	fake.unpauseCheckingArgsForCall = append(fake.unpauseCheckingArgsForCall, struct {
+	}{})
+	stub := fake.UnpauseCheckingStub
+	fakeReturns := fake.unpauseCheckingReturns
+	fake.recordInvocation("UnpauseChecking", []interface{}{})
+	fake.unpauseCheckingMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) UnpauseCheckingCallCount() int {
+	fake.unpauseCheckingMutex.RLock()
+	defer fake.unpauseCheckingMutex.RUnlock()
+	return len(fake.unpauseCheckingArgsForCall)
+}
+
+func (fake *FakeResource) UnpauseCheckingCalls(stub func() error) {
+	fake.unpauseCheckingMutex.Lock()
+	defer fake.unpauseCheckingMutex.Unlock()
+	fake.UnpauseCheckingStub = stub
+}
+
+func (fake *FakeResource) UnpauseCheckingReturns(result1 error) {
+	fake.unpauseCheckingMutex.Lock()
+	defer fake.unpauseCheckingMutex.Unlock()
+	fake.UnpauseCheckingStub = nil
+	fake.unpauseCheckingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) UnpauseCheckingReturnsOnCall(i int, result1 error) {
+	fake.unpauseCheckingMutex.Lock()
+	defer fake.unpauseCheckingMutex.Unlock()
+	fake.UnpauseCheckingStub = nil
+	if fake.unpauseCheckingReturnsOnCall == nil {
+		fake.unpauseCheckingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unpauseCheckingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResource) UnpinVersion() error {
 	fake.unpinVersionMutex.Lock()
 	ret, specificReturn := fake.unpinVersionReturnsOnCall[len(fake.unpinVersionArgsForCall)]
@@ -2573,6 +3610,70 @@ func (fake *FakeResource) UnpinVersionReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeResource) UnpromoteVersion(arg1 int) (bool, error) {
+	fake.unpromoteVersionMutex.Lock()
+	ret, specificReturn := fake.unpromoteVersionReturnsOnCall[len(fake.unpromoteVersionArgsForCall)]
+	fake.unpromoteVersionArgsForCall = append(fake.unpromoteVersionArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.UnpromoteVersionStub
+	fakeReturns := fake.unpromoteVersionReturns
+	fake.recordInvocation("UnpromoteVersion", []interface{}{arg1})
+	fake.unpromoteVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) UnpromoteVersionCallCount() int {
+	fake.unpromoteVersionMutex.RLock()
+	defer fake.unpromoteVersionMutex.RUnlock()
+	return len(fake.unpromoteVersionArgsForCall)
+}
+
+func (fake *FakeResource) UnpromoteVersionCalls(stub func(int) (bool, error)) {
+	fake.unpromoteVersionMutex.Lock()
+	defer fake.unpromoteVersionMutex.Unlock()
+	fake.UnpromoteVersionStub = stub
+}
+
+func (fake *FakeResource) UnpromoteVersionArgsForCall(i int) int {
+	fake.unpromoteVersionMutex.RLock()
+	defer fake.unpromoteVersionMutex.RUnlock()
+	argsForCall := fake.unpromoteVersionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) UnpromoteVersionReturns(result1 bool, result2 error) {
+	fake.unpromoteVersionMutex.Lock()
+	defer fake.unpromoteVersionMutex.Unlock()
+	fake.UnpromoteVersionStub = nil
+	fake.unpromoteVersionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) UnpromoteVersionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.unpromoteVersionMutex.Lock()
+	defer fake.unpromoteVersionMutex.Unlock()
+	fake.UnpromoteVersionStub = nil
+	if fake.unpromoteVersionReturnsOnCall == nil {
+		fake.unpromoteVersionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.unpromoteVersionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) UpdateMetadata(arg1 atc.Version, arg2 db.ResourceConfigMetadataFields) (bool, error) {
 	fake.updateMetadataMutex.Lock()
 	ret, specificReturn := fake.updateMetadataReturnsOnCall[len(fake.updateMetadataArgsForCall)]
@@ -2638,6 +3739,62 @@ func (fake *FakeResource) UpdateMetadataReturnsOnCall(i int, result1 bool, resul
 	}{result1, result2}
 }
 
+func (fake *FakeResource) VersionToggles() ([]db.VersionToggle, error) {
+	fake.versionTogglesMutex.Lock()
+	ret, specificReturn := fake.versionTogglesReturnsOnCall[len(fake.versionTogglesArgsForCall)]
+	fake.versionTogglesArgsForCall = append(fake.versionTogglesArgsForCall, struct {
+	}{})
+	stub := fake.VersionTogglesStub
+	fakeReturns := fake.versionTogglesReturns
+	fake.recordInvocation("VersionToggles", []interface{}{})
+	fake.versionTogglesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) VersionTogglesCallCount() int {
+	fake.versionTogglesMutex.RLock()
+	defer fake.versionTogglesMutex.RUnlock()
+	return len(fake.versionTogglesArgsForCall)
+}
+
+func (fake *FakeResource) VersionTogglesCalls(stub func() ([]db.VersionToggle, error)) {
+	fake.versionTogglesMutex.Lock()
+	defer fake.versionTogglesMutex.Unlock()
+	fake.VersionTogglesStub = stub
+}
+
+func (fake *FakeResource) VersionTogglesReturns(result1 []db.VersionToggle, result2 error) {
+	fake.versionTogglesMutex.Lock()
+	defer fake.versionTogglesMutex.Unlock()
+	fake.VersionTogglesStub = nil
+	fake.versionTogglesReturns = struct {
+		result1 []db.VersionToggle
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) VersionTogglesReturnsOnCall(i int, result1 []db.VersionToggle, result2 error) {
+	fake.versionTogglesMutex.Lock()
+	defer fake.versionTogglesMutex.Unlock()
+	fake.VersionTogglesStub = nil
+	if fake.versionTogglesReturnsOnCall == nil {
+		fake.versionTogglesReturnsOnCall = make(map[int]struct {
+			result1 []db.VersionToggle
+			result2 error
+		})
+	}
+	fake.versionTogglesReturnsOnCall[i] = struct {
+		result1 []db.VersionToggle
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) Versions(arg1 db.Page, arg2 atc.Version) ([]atc.ResourceVersion, db.Pagination, bool, error) {
 	fake.versionsMutex.Lock()
 	ret, specificReturn := fake.versionsReturnsOnCall[len(fake.versionsArgsForCall)]
@@ -2767,12 +3924,24 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.aPIPinnedVersionMutex.RLock()
 	defer fake.aPIPinnedVersionMutex.RUnlock()
+	fake.backfillVersionsMutex.RLock()
+	defer fake.backfillVersionsMutex.RUnlock()
 	fake.buildSummaryMutex.RLock()
 	defer fake.buildSummaryMutex.RUnlock()
 	fake.checkEveryMutex.RLock()
 	defer fake.checkEveryMutex.RUnlock()
+	fake.checkFailuresMutex.RLock()
+	defer fake.checkFailuresMutex.RUnlock()
+	fake.checkOnDemandMutex.RLock()
+	defer fake.checkOnDemandMutex.RUnlock()
+	fake.checkPausedMutex.RLock()
+	defer fake.checkPausedMutex.RUnlock()
 	fake.checkPlanMutex.RLock()
 	defer fake.checkPlanMutex.RUnlock()
+	fake.checkRecordsMutex.RLock()
+	defer fake.checkRecordsMutex.RUnlock()
+	fake.checkTTLMutex.RLock()
+	defer fake.checkTTLMutex.RUnlock()
 	fake.checkTimeoutMutex.RLock()
 	defer fake.checkTimeoutMutex.RUnlock()
 	fake.configMutex.RLock()
@@ -2795,14 +3964,28 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.iDMutex.RUnlock()
 	fake.iconMutex.RLock()
 	defer fake.iconMutex.RUnlock()
+	fake.lastCheckContainerHandleMutex.RLock()
+	defer fake.lastCheckContainerHandleMutex.RUnlock()
 	fake.lastCheckEndTimeMutex.RLock()
 	defer fake.lastCheckEndTimeMutex.RUnlock()
 	fake.lastCheckStartTimeMutex.RLock()
 	defer fake.lastCheckStartTimeMutex.RUnlock()
+	fake.lastCheckSucceededMutex.RLock()
+	defer fake.lastCheckSucceededMutex.RUnlock()
+	fake.lastCheckSuccessTimeMutex.RLock()
+	defer fake.lastCheckSuccessTimeMutex.RUnlock()
+	fake.lastScheduledCheckEndTimeMutex.RLock()
+	defer fake.lastScheduledCheckEndTimeMutex.RUnlock()
+	fake.lastScheduledCheckSucceededMutex.RLock()
+	defer fake.lastScheduledCheckSucceededMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.notifyScanMutex.RLock()
 	defer fake.notifyScanMutex.RUnlock()
+	fake.onDemandCheckNeededMutex.RLock()
+	defer fake.onDemandCheckNeededMutex.RUnlock()
+	fake.pauseCheckingMutex.RLock()
+	defer fake.pauseCheckingMutex.RUnlock()
 	fake.pinCommentMutex.RLock()
 	defer fake.pinCommentMutex.RUnlock()
 	fake.pinVersionMutex.RLock()
@@ -2817,6 +4000,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.pipelineNameMutex.RUnlock()
 	fake.pipelineRefMutex.RLock()
 	defer fake.pipelineRefMutex.RUnlock()
+	fake.promoteVersionMutex.RLock()
+	defer fake.promoteVersionMutex.RUnlock()
 	fake.publicMutex.RLock()
 	defer fake.publicMutex.RUnlock()
 	fake.reloadMutex.RLock()
@@ -2839,10 +4024,16 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.teamNameMutex.RUnlock()
 	fake.typeMutex.RLock()
 	defer fake.typeMutex.RUnlock()
+	fake.unpauseCheckingMutex.RLock()
+	defer fake.unpauseCheckingMutex.RUnlock()
 	fake.unpinVersionMutex.RLock()
 	defer fake.unpinVersionMutex.RUnlock()
+	fake.unpromoteVersionMutex.RLock()
+	defer fake.unpromoteVersionMutex.RUnlock()
 	fake.updateMetadataMutex.RLock()
 	defer fake.updateMetadataMutex.RUnlock()
+	fake.versionTogglesMutex.RLock()
+	defer fake.versionTogglesMutex.RUnlock()
 	fake.versionsMutex.RLock()
 	defer fake.versionsMutex.RUnlock()
 	fake.webhookTokenMutex.RLock()
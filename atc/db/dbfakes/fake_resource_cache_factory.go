@@ -9,6 +9,19 @@ import (
 )
 
 type FakeResourceCacheFactory struct {
+	ExpireResourceCacheStub        func(db.UsedResourceCache) (int, error)
+	expireResourceCacheMutex       sync.RWMutex
+	expireResourceCacheArgsForCall []struct {
+		arg1 db.UsedResourceCache
+	}
+	expireResourceCacheReturns struct {
+		result1 int
+		result2 error
+	}
+	expireResourceCacheReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	FindOrCreateResourceCacheStub        func(db.ResourceCacheUser, string, atc.Version, atc.Source, atc.Params, atc.VersionedResourceTypes) (db.UsedResourceCache, error)
 	findOrCreateResourceCacheMutex       sync.RWMutex
 	findOrCreateResourceCacheArgsForCall []struct {
@@ -42,6 +55,19 @@ type FakeResourceCacheFactory struct {
 		result2 bool
 		result3 error
 	}
+	FindResourceCachesForVersionStub        func(int) ([]db.UsedResourceCache, error)
+	findResourceCachesForVersionMutex       sync.RWMutex
+	findResourceCachesForVersionArgsForCall []struct {
+		arg1 int
+	}
+	findResourceCachesForVersionReturns struct {
+		result1 []db.UsedResourceCache
+		result2 error
+	}
+	findResourceCachesForVersionReturnsOnCall map[int]struct {
+		result1 []db.UsedResourceCache
+		result2 error
+	}
 	ResourceCacheMetadataStub        func(db.UsedResourceCache) (db.ResourceConfigMetadataFields, error)
 	resourceCacheMetadataMutex       sync.RWMutex
 	resourceCacheMetadataArgsForCall []struct {
@@ -55,6 +81,20 @@ type FakeResourceCacheFactory struct {
 		result1 db.ResourceConfigMetadataFields
 		result2 error
 	}
+	ResourceConfigVersionVisibleToTeamStub        func(int, int) (bool, error)
+	resourceConfigVersionVisibleToTeamMutex       sync.RWMutex
+	resourceConfigVersionVisibleToTeamArgsForCall []struct {
+		arg1 int
+		arg2 int
+	}
+	resourceConfigVersionVisibleToTeamReturns struct {
+		result1 bool
+		result2 error
+	}
+	resourceConfigVersionVisibleToTeamReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	UpdateResourceCacheMetadataStub        func(db.UsedResourceCache, []atc.MetadataField) error
 	updateResourceCacheMetadataMutex       sync.RWMutex
 	updateResourceCacheMetadataArgsForCall []struct {
@@ -71,6 +111,70 @@ type FakeResourceCacheFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeResourceCacheFactory) ExpireResourceCache(arg1 db.UsedResourceCache) (int, error) {
+	fake.expireResourceCacheMutex.Lock()
+	ret, specificReturn := fake.expireResourceCacheReturnsOnCall[len(fake.expireResourceCacheArgsForCall)]
+	fake.expireResourceCacheArgsForCall = append(fake.expireResourceCacheArgsForCall, struct {
+		arg1 db.UsedResourceCache
+	}{arg1})
+	stub := fake.ExpireResourceCacheStub
+	fakeReturns := fake.expireResourceCacheReturns
+	fake.recordInvocation("ExpireResourceCache", []interface{}{arg1})
+	fake.expireResourceCacheMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceCacheFactory) ExpireResourceCacheCallCount() int {
+	fake.expireResourceCacheMutex.RLock()
+	defer fake.expireResourceCacheMutex.RUnlock()
+	return len(fake.expireResourceCacheArgsForCall)
+}
+
+func (fake *FakeResourceCacheFactory) ExpireResourceCacheCalls(stub func(db.UsedResourceCache) (int, error)) {
+	fake.expireResourceCacheMutex.Lock()
+	defer fake.expireResourceCacheMutex.Unlock()
+	fake.ExpireResourceCacheStub = stub
+}
+
+func (fake *FakeResourceCacheFactory) ExpireResourceCacheArgsForCall(i int) db.UsedResourceCache {
+	fake.expireResourceCacheMutex.RLock()
+	defer fake.expireResourceCacheMutex.RUnlock()
+	argsForCall := fake.expireResourceCacheArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResourceCacheFactory) ExpireResourceCacheReturns(result1 int, result2 error) {
+	fake.expireResourceCacheMutex.Lock()
+	defer fake.expireResourceCacheMutex.Unlock()
+	fake.ExpireResourceCacheStub = nil
+	fake.expireResourceCacheReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceCacheFactory) ExpireResourceCacheReturnsOnCall(i int, result1 int, result2 error) {
+	fake.expireResourceCacheMutex.Lock()
+	defer fake.expireResourceCacheMutex.Unlock()
+	fake.ExpireResourceCacheStub = nil
+	if fake.expireResourceCacheReturnsOnCall == nil {
+		fake.expireResourceCacheReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.expireResourceCacheReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResourceCacheFactory) FindOrCreateResourceCache(arg1 db.ResourceCacheUser, arg2 string, arg3 atc.Version, arg4 atc.Source, arg5 atc.Params, arg6 atc.VersionedResourceTypes) (db.UsedResourceCache, error) {
 	fake.findOrCreateResourceCacheMutex.Lock()
 	ret, specificReturn := fake.findOrCreateResourceCacheReturnsOnCall[len(fake.findOrCreateResourceCacheArgsForCall)]
@@ -207,6 +311,70 @@ func (fake *FakeResourceCacheFactory) FindResourceCacheByIDReturnsOnCall(i int,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeResourceCacheFactory) FindResourceCachesForVersion(arg1 int) ([]db.UsedResourceCache, error) {
+	fake.findResourceCachesForVersionMutex.Lock()
+	ret, specificReturn := fake.findResourceCachesForVersionReturnsOnCall[len(fake.findResourceCachesForVersionArgsForCall)]
+	fake.findResourceCachesForVersionArgsForCall = append(fake.findResourceCachesForVersionArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.FindResourceCachesForVersionStub
+	fakeReturns := fake.findResourceCachesForVersionReturns
+	fake.recordInvocation("FindResourceCachesForVersion", []interface{}{arg1})
+	fake.findResourceCachesForVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceCacheFactory) FindResourceCachesForVersionCallCount() int {
+	fake.findResourceCachesForVersionMutex.RLock()
+	defer fake.findResourceCachesForVersionMutex.RUnlock()
+	return len(fake.findResourceCachesForVersionArgsForCall)
+}
+
+func (fake *FakeResourceCacheFactory) FindResourceCachesForVersionCalls(stub func(int) ([]db.UsedResourceCache, error)) {
+	fake.findResourceCachesForVersionMutex.Lock()
+	defer fake.findResourceCachesForVersionMutex.Unlock()
+	fake.FindResourceCachesForVersionStub = stub
+}
+
+func (fake *FakeResourceCacheFactory) FindResourceCachesForVersionArgsForCall(i int) int {
+	fake.findResourceCachesForVersionMutex.RLock()
+	defer fake.findResourceCachesForVersionMutex.RUnlock()
+	argsForCall := fake.findResourceCachesForVersionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResourceCacheFactory) FindResourceCachesForVersionReturns(result1 []db.UsedResourceCache, result2 error) {
+	fake.findResourceCachesForVersionMutex.Lock()
+	defer fake.findResourceCachesForVersionMutex.Unlock()
+	fake.FindResourceCachesForVersionStub = nil
+	fake.findResourceCachesForVersionReturns = struct {
+		result1 []db.UsedResourceCache
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceCacheFactory) FindResourceCachesForVersionReturnsOnCall(i int, result1 []db.UsedResourceCache, result2 error) {
+	fake.findResourceCachesForVersionMutex.Lock()
+	defer fake.findResourceCachesForVersionMutex.Unlock()
+	fake.FindResourceCachesForVersionStub = nil
+	if fake.findResourceCachesForVersionReturnsOnCall == nil {
+		fake.findResourceCachesForVersionReturnsOnCall = make(map[int]struct {
+			result1 []db.UsedResourceCache
+			result2 error
+		})
+	}
+	fake.findResourceCachesForVersionReturnsOnCall[i] = struct {
+		result1 []db.UsedResourceCache
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResourceCacheFactory) ResourceCacheMetadata(arg1 db.UsedResourceCache) (db.ResourceConfigMetadataFields, error) {
 	fake.resourceCacheMetadataMutex.Lock()
 	ret, specificReturn := fake.resourceCacheMetadataReturnsOnCall[len(fake.resourceCacheMetadataArgsForCall)]
@@ -271,6 +439,71 @@ func (fake *FakeResourceCacheFactory) ResourceCacheMetadataReturnsOnCall(i int,
 	}{result1, result2}
 }
 
+func (fake *FakeResourceCacheFactory) ResourceConfigVersionVisibleToTeam(arg1 int, arg2 int) (bool, error) {
+	fake.resourceConfigVersionVisibleToTeamMutex.Lock()
+	ret, specificReturn := fake.resourceConfigVersionVisibleToTeamReturnsOnCall[len(fake.resourceConfigVersionVisibleToTeamArgsForCall)]
+	fake.resourceConfigVersionVisibleToTeamArgsForCall = append(fake.resourceConfigVersionVisibleToTeamArgsForCall, struct {
+		arg1 int
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.ResourceConfigVersionVisibleToTeamStub
+	fakeReturns := fake.resourceConfigVersionVisibleToTeamReturns
+	fake.recordInvocation("ResourceConfigVersionVisibleToTeam", []interface{}{arg1, arg2})
+	fake.resourceConfigVersionVisibleToTeamMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceCacheFactory) ResourceConfigVersionVisibleToTeamCallCount() int {
+	fake.resourceConfigVersionVisibleToTeamMutex.RLock()
+	defer fake.resourceConfigVersionVisibleToTeamMutex.RUnlock()
+	return len(fake.resourceConfigVersionVisibleToTeamArgsForCall)
+}
+
+func (fake *FakeResourceCacheFactory) ResourceConfigVersionVisibleToTeamCalls(stub func(int, int) (bool, error)) {
+	fake.resourceConfigVersionVisibleToTeamMutex.Lock()
+	defer fake.resourceConfigVersionVisibleToTeamMutex.Unlock()
+	fake.ResourceConfigVersionVisibleToTeamStub = stub
+}
+
+func (fake *FakeResourceCacheFactory) ResourceConfigVersionVisibleToTeamArgsForCall(i int) (int, int) {
+	fake.resourceConfigVersionVisibleToTeamMutex.RLock()
+	defer fake.resourceConfigVersionVisibleToTeamMutex.RUnlock()
+	argsForCall := fake.resourceConfigVersionVisibleToTeamArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResourceCacheFactory) ResourceConfigVersionVisibleToTeamReturns(result1 bool, result2 error) {
+	fake.resourceConfigVersionVisibleToTeamMutex.Lock()
+	defer fake.resourceConfigVersionVisibleToTeamMutex.Unlock()
+	fake.ResourceConfigVersionVisibleToTeamStub = nil
+	fake.resourceConfigVersionVisibleToTeamReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceCacheFactory) ResourceConfigVersionVisibleToTeamReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.resourceConfigVersionVisibleToTeamMutex.Lock()
+	defer fake.resourceConfigVersionVisibleToTeamMutex.Unlock()
+	fake.ResourceConfigVersionVisibleToTeamStub = nil
+	if fake.resourceConfigVersionVisibleToTeamReturnsOnCall == nil {
+		fake.resourceConfigVersionVisibleToTeamReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.resourceConfigVersionVisibleToTeamReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResourceCacheFactory) UpdateResourceCacheMetadata(arg1 db.UsedResourceCache, arg2 []atc.MetadataField) error {
 	var arg2Copy []atc.MetadataField
 	if arg2 != nil {
@@ -341,12 +574,18 @@ func (fake *FakeResourceCacheFactory) UpdateResourceCacheMetadataReturnsOnCall(i
 func (fake *FakeResourceCacheFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.expireResourceCacheMutex.RLock()
+	defer fake.expireResourceCacheMutex.RUnlock()
 	fake.findOrCreateResourceCacheMutex.RLock()
 	defer fake.findOrCreateResourceCacheMutex.RUnlock()
 	fake.findResourceCacheByIDMutex.RLock()
 	defer fake.findResourceCacheByIDMutex.RUnlock()
+	fake.findResourceCachesForVersionMutex.RLock()
+	defer fake.findResourceCachesForVersionMutex.RUnlock()
 	fake.resourceCacheMetadataMutex.RLock()
 	defer fake.resourceCacheMetadataMutex.RUnlock()
+	fake.resourceConfigVersionVisibleToTeamMutex.RLock()
+	defer fake.resourceConfigVersionVisibleToTeamMutex.RUnlock()
 	fake.updateResourceCacheMetadataMutex.RLock()
 	defer fake.updateResourceCacheMetadataMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
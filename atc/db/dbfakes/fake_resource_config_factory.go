@@ -21,6 +21,23 @@ type FakeResourceConfigFactory struct {
 	cleanUnreferencedConfigsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	FindCheckedVersionStub        func(string, atc.Source, atc.VersionedResourceTypes) (atc.Version, bool, error)
+	findCheckedVersionMutex       sync.RWMutex
+	findCheckedVersionArgsForCall []struct {
+		arg1 string
+		arg2 atc.Source
+		arg3 atc.VersionedResourceTypes
+	}
+	findCheckedVersionReturns struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}
+	findCheckedVersionReturnsOnCall map[int]struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}
 	FindOrCreateResourceConfigStub        func(string, atc.Source, atc.VersionedResourceTypes) (db.ResourceConfig, error)
 	findOrCreateResourceConfigMutex       sync.RWMutex
 	findOrCreateResourceConfigArgsForCall []struct {
@@ -116,6 +133,75 @@ func (fake *FakeResourceConfigFactory) CleanUnreferencedConfigsReturnsOnCall(i i
 	}{result1}
 }
 
+func (fake *FakeResourceConfigFactory) FindCheckedVersion(arg1 string, arg2 atc.Source, arg3 atc.VersionedResourceTypes) (atc.Version, bool, error) {
+	fake.findCheckedVersionMutex.Lock()
+	ret, specificReturn := fake.findCheckedVersionReturnsOnCall[len(fake.findCheckedVersionArgsForCall)]
+	fake.findCheckedVersionArgsForCall = append(fake.findCheckedVersionArgsForCall, struct {
+		arg1 string
+		arg2 atc.Source
+		arg3 atc.VersionedResourceTypes
+	}{arg1, arg2, arg3})
+	stub := fake.FindCheckedVersionStub
+	fakeReturns := fake.findCheckedVersionReturns
+	fake.recordInvocation("FindCheckedVersion", []interface{}{arg1, arg2, arg3})
+	fake.findCheckedVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeResourceConfigFactory) FindCheckedVersionCallCount() int {
+	fake.findCheckedVersionMutex.RLock()
+	defer fake.findCheckedVersionMutex.RUnlock()
+	return len(fake.findCheckedVersionArgsForCall)
+}
+
+func (fake *FakeResourceConfigFactory) FindCheckedVersionCalls(stub func(string, atc.Source, atc.VersionedResourceTypes) (atc.Version, bool, error)) {
+	fake.findCheckedVersionMutex.Lock()
+	defer fake.findCheckedVersionMutex.Unlock()
+	fake.FindCheckedVersionStub = stub
+}
+
+func (fake *FakeResourceConfigFactory) FindCheckedVersionArgsForCall(i int) (string, atc.Source, atc.VersionedResourceTypes) {
+	fake.findCheckedVersionMutex.RLock()
+	defer fake.findCheckedVersionMutex.RUnlock()
+	argsForCall := fake.findCheckedVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeResourceConfigFactory) FindCheckedVersionReturns(result1 atc.Version, result2 bool, result3 error) {
+	fake.findCheckedVersionMutex.Lock()
+	defer fake.findCheckedVersionMutex.Unlock()
+	fake.FindCheckedVersionStub = nil
+	fake.findCheckedVersionReturns = struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeResourceConfigFactory) FindCheckedVersionReturnsOnCall(i int, result1 atc.Version, result2 bool, result3 error) {
+	fake.findCheckedVersionMutex.Lock()
+	defer fake.findCheckedVersionMutex.Unlock()
+	fake.FindCheckedVersionStub = nil
+	if fake.findCheckedVersionReturnsOnCall == nil {
+		fake.findCheckedVersionReturnsOnCall = make(map[int]struct {
+			result1 atc.Version
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findCheckedVersionReturnsOnCall[i] = struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeResourceConfigFactory) FindOrCreateResourceConfig(arg1 string, arg2 atc.Source, arg3 atc.VersionedResourceTypes) (db.ResourceConfig, error) {
 	fake.findOrCreateResourceConfigMutex.Lock()
 	ret, specificReturn := fake.findOrCreateResourceConfigReturnsOnCall[len(fake.findOrCreateResourceConfigArgsForCall)]
@@ -254,6 +340,8 @@ func (fake *FakeResourceConfigFactory) Invocations() map[string][][]interface{}
 	defer fake.invocationsMutex.RUnlock()
 	fake.cleanUnreferencedConfigsMutex.RLock()
 	defer fake.cleanUnreferencedConfigsMutex.RUnlock()
+	fake.findCheckedVersionMutex.RLock()
+	defer fake.findCheckedVersionMutex.RUnlock()
 	fake.findOrCreateResourceConfigMutex.RLock()
 	defer fake.findOrCreateResourceConfigMutex.RUnlock()
 	fake.findResourceConfigByIDMutex.RLock()
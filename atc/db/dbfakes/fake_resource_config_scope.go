@@ -97,17 +97,59 @@ type FakeResourceConfigScope struct {
 	resourceConfigReturnsOnCall map[int]struct {
 		result1 db.ResourceConfig
 	}
-	SaveVersionsStub        func(db.SpanContext, []atc.Version) error
+	SaveVersionsStub        func(db.SpanContext, []atc.Version) (int, error)
 	saveVersionsMutex       sync.RWMutex
 	saveVersionsArgsForCall []struct {
 		arg1 db.SpanContext
 		arg2 []atc.Version
 	}
 	saveVersionsReturns struct {
-		result1 error
+		result1 int
+		result2 error
 	}
 	saveVersionsReturnsOnCall map[int]struct {
-		result1 error
+		result1 int
+		result2 error
+	}
+	SaveVersionsIdentifiedByStub        func(db.SpanContext, []atc.Version, []string) (int, error)
+	saveVersionsIdentifiedByMutex       sync.RWMutex
+	saveVersionsIdentifiedByArgsForCall []struct {
+		arg1 db.SpanContext
+		arg2 []atc.Version
+		arg3 []string
+	}
+	saveVersionsIdentifiedByReturns struct {
+		result1 int
+		result2 error
+	}
+	saveVersionsIdentifiedByReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	UpdateLastCheckCanceledStub        func() (bool, error)
+	updateLastCheckCanceledMutex       sync.RWMutex
+	updateLastCheckCanceledArgsForCall []struct {
+	}
+	updateLastCheckCanceledReturns struct {
+		result1 bool
+		result2 error
+	}
+	updateLastCheckCanceledReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	UpdateLastCheckContainerHandleStub        func(string) (bool, error)
+	updateLastCheckContainerHandleMutex       sync.RWMutex
+	updateLastCheckContainerHandleArgsForCall []struct {
+		arg1 string
+	}
+	updateLastCheckContainerHandleReturns struct {
+		result1 bool
+		result2 error
+	}
+	updateLastCheckContainerHandleReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
 	}
 	UpdateLastCheckEndTimeStub        func(bool) (bool, error)
 	updateLastCheckEndTimeMutex       sync.RWMutex
@@ -122,6 +164,19 @@ type FakeResourceConfigScope struct {
 		result1 bool
 		result2 error
 	}
+	UpdateLastCheckErrorStub        func(string) (bool, error)
+	updateLastCheckErrorMutex       sync.RWMutex
+	updateLastCheckErrorArgsForCall []struct {
+		arg1 string
+	}
+	updateLastCheckErrorReturns struct {
+		result1 bool
+		result2 error
+	}
+	updateLastCheckErrorReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	UpdateLastCheckStartTimeStub        func() (bool, error)
 	updateLastCheckStartTimeMutex       sync.RWMutex
 	updateLastCheckStartTimeArgsForCall []struct {
@@ -134,6 +189,19 @@ type FakeResourceConfigScope struct {
 		result1 bool
 		result2 error
 	}
+	UpdateLastScheduledCheckEndTimeStub        func(bool) (bool, error)
+	updateLastScheduledCheckEndTimeMutex       sync.RWMutex
+	updateLastScheduledCheckEndTimeArgsForCall []struct {
+		arg1 bool
+	}
+	updateLastScheduledCheckEndTimeReturns struct {
+		result1 bool
+		result2 error
+	}
+	updateLastScheduledCheckEndTimeReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -546,7 +614,7 @@ func (fake *FakeResourceConfigScope) ResourceConfigReturnsOnCall(i int, result1
 	}{result1}
 }
 
-func (fake *FakeResourceConfigScope) SaveVersions(arg1 db.SpanContext, arg2 []atc.Version) error {
+func (fake *FakeResourceConfigScope) SaveVersions(arg1 db.SpanContext, arg2 []atc.Version) (int, error) {
 	var arg2Copy []atc.Version
 	if arg2 != nil {
 		arg2Copy = make([]atc.Version, len(arg2))
@@ -566,9 +634,9 @@ func (fake *FakeResourceConfigScope) SaveVersions(arg1 db.SpanContext, arg2 []at
 		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeResourceConfigScope) SaveVersionsCallCount() int {
@@ -577,7 +645,7 @@ func (fake *FakeResourceConfigScope) SaveVersionsCallCount() int {
 	return len(fake.saveVersionsArgsForCall)
 }
 
-func (fake *FakeResourceConfigScope) SaveVersionsCalls(stub func(db.SpanContext, []atc.Version) error) {
+func (fake *FakeResourceConfigScope) SaveVersionsCalls(stub func(db.SpanContext, []atc.Version) (int, error)) {
 	fake.saveVersionsMutex.Lock()
 	defer fake.saveVersionsMutex.Unlock()
 	fake.SaveVersionsStub = stub
@@ -590,27 +658,226 @@ func (fake *FakeResourceConfigScope) SaveVersionsArgsForCall(i int) (db.SpanCont
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeResourceConfigScope) SaveVersionsReturns(result1 error) {
+func (fake *FakeResourceConfigScope) SaveVersionsReturns(result1 int, result2 error) {
 	fake.saveVersionsMutex.Lock()
 	defer fake.saveVersionsMutex.Unlock()
 	fake.SaveVersionsStub = nil
 	fake.saveVersionsReturns = struct {
-		result1 error
-	}{result1}
+		result1 int
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeResourceConfigScope) SaveVersionsReturnsOnCall(i int, result1 error) {
+func (fake *FakeResourceConfigScope) SaveVersionsReturnsOnCall(i int, result1 int, result2 error) {
 	fake.saveVersionsMutex.Lock()
 	defer fake.saveVersionsMutex.Unlock()
 	fake.SaveVersionsStub = nil
 	if fake.saveVersionsReturnsOnCall == nil {
 		fake.saveVersionsReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 int
+			result2 error
 		})
 	}
 	fake.saveVersionsReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceConfigScope) SaveVersionsIdentifiedBy(arg1 db.SpanContext, arg2 []atc.Version, arg3 []string) (int, error) {
+	var arg2Copy []atc.Version
+	if arg2 != nil {
+		arg2Copy = make([]atc.Version, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.saveVersionsIdentifiedByMutex.Lock()
+	ret, specificReturn := fake.saveVersionsIdentifiedByReturnsOnCall[len(fake.saveVersionsIdentifiedByArgsForCall)]
+	fake.saveVersionsIdentifiedByArgsForCall = append(fake.saveVersionsIdentifiedByArgsForCall, struct {
+		arg1 db.SpanContext
+		arg2 []atc.Version
+		arg3 []string
+	}{arg1, arg2Copy, arg3Copy})
+	stub := fake.SaveVersionsIdentifiedByStub
+	fakeReturns := fake.saveVersionsIdentifiedByReturns
+	fake.recordInvocation("SaveVersionsIdentifiedBy", []interface{}{arg1, arg2Copy, arg3Copy})
+	fake.saveVersionsIdentifiedByMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceConfigScope) SaveVersionsIdentifiedByCallCount() int {
+	fake.saveVersionsIdentifiedByMutex.RLock()
+	defer fake.saveVersionsIdentifiedByMutex.RUnlock()
+	return len(fake.saveVersionsIdentifiedByArgsForCall)
+}
+
+func (fake *FakeResourceConfigScope) SaveVersionsIdentifiedByCalls(stub func(db.SpanContext, []atc.Version, []string) (int, error)) {
+	fake.saveVersionsIdentifiedByMutex.Lock()
+	defer fake.saveVersionsIdentifiedByMutex.Unlock()
+	fake.SaveVersionsIdentifiedByStub = stub
+}
+
+func (fake *FakeResourceConfigScope) SaveVersionsIdentifiedByArgsForCall(i int) (db.SpanContext, []atc.Version, []string) {
+	fake.saveVersionsIdentifiedByMutex.RLock()
+	defer fake.saveVersionsIdentifiedByMutex.RUnlock()
+	argsForCall := fake.saveVersionsIdentifiedByArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeResourceConfigScope) SaveVersionsIdentifiedByReturns(result1 int, result2 error) {
+	fake.saveVersionsIdentifiedByMutex.Lock()
+	defer fake.saveVersionsIdentifiedByMutex.Unlock()
+	fake.SaveVersionsIdentifiedByStub = nil
+	fake.saveVersionsIdentifiedByReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceConfigScope) SaveVersionsIdentifiedByReturnsOnCall(i int, result1 int, result2 error) {
+	fake.saveVersionsIdentifiedByMutex.Lock()
+	defer fake.saveVersionsIdentifiedByMutex.Unlock()
+	fake.SaveVersionsIdentifiedByStub = nil
+	if fake.saveVersionsIdentifiedByReturnsOnCall == nil {
+		fake.saveVersionsIdentifiedByReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.saveVersionsIdentifiedByReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckCanceled() (bool, error) {
+	fake.updateLastCheckCanceledMutex.Lock()
+	ret, specificReturn := fake.updateLastCheckCanceledReturnsOnCall[len(fake.updateLastCheckCanceledArgsForCall)]
+	fake.updateLastCheckCanceledArgsForCall = append(fake.updateLastCheckCanceledArgsForCall, struct {
+	}{})
+	stub := fake.UpdateLastCheckCanceledStub
+	fakeReturns := fake.updateLastCheckCanceledReturns
+	fake.recordInvocation("UpdateLastCheckCanceled", []interface{}{})
+	fake.updateLastCheckCanceledMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckCanceledCallCount() int {
+	fake.updateLastCheckCanceledMutex.RLock()
+	defer fake.updateLastCheckCanceledMutex.RUnlock()
+	return len(fake.updateLastCheckCanceledArgsForCall)
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckCanceledCalls(stub func() (bool, error)) {
+	fake.updateLastCheckCanceledMutex.Lock()
+	defer fake.updateLastCheckCanceledMutex.Unlock()
+	fake.UpdateLastCheckCanceledStub = stub
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckCanceledReturns(result1 bool, result2 error) {
+	fake.updateLastCheckCanceledMutex.Lock()
+	defer fake.updateLastCheckCanceledMutex.Unlock()
+	fake.UpdateLastCheckCanceledStub = nil
+	fake.updateLastCheckCanceledReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckCanceledReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.updateLastCheckCanceledMutex.Lock()
+	defer fake.updateLastCheckCanceledMutex.Unlock()
+	fake.UpdateLastCheckCanceledStub = nil
+	if fake.updateLastCheckCanceledReturnsOnCall == nil {
+		fake.updateLastCheckCanceledReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.updateLastCheckCanceledReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckContainerHandle(arg1 string) (bool, error) {
+	fake.updateLastCheckContainerHandleMutex.Lock()
+	ret, specificReturn := fake.updateLastCheckContainerHandleReturnsOnCall[len(fake.updateLastCheckContainerHandleArgsForCall)]
+	fake.updateLastCheckContainerHandleArgsForCall = append(fake.updateLastCheckContainerHandleArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.UpdateLastCheckContainerHandleStub
+	fakeReturns := fake.updateLastCheckContainerHandleReturns
+	fake.recordInvocation("UpdateLastCheckContainerHandle", []interface{}{arg1})
+	fake.updateLastCheckContainerHandleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckContainerHandleCallCount() int {
+	fake.updateLastCheckContainerHandleMutex.RLock()
+	defer fake.updateLastCheckContainerHandleMutex.RUnlock()
+	return len(fake.updateLastCheckContainerHandleArgsForCall)
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckContainerHandleCalls(stub func(string) (bool, error)) {
+	fake.updateLastCheckContainerHandleMutex.Lock()
+	defer fake.updateLastCheckContainerHandleMutex.Unlock()
+	fake.UpdateLastCheckContainerHandleStub = stub
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckContainerHandleArgsForCall(i int) string {
+	fake.updateLastCheckContainerHandleMutex.RLock()
+	defer fake.updateLastCheckContainerHandleMutex.RUnlock()
+	argsForCall := fake.updateLastCheckContainerHandleArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckContainerHandleReturns(result1 bool, result2 error) {
+	fake.updateLastCheckContainerHandleMutex.Lock()
+	defer fake.updateLastCheckContainerHandleMutex.Unlock()
+	fake.UpdateLastCheckContainerHandleStub = nil
+	fake.updateLastCheckContainerHandleReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckContainerHandleReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.updateLastCheckContainerHandleMutex.Lock()
+	defer fake.updateLastCheckContainerHandleMutex.Unlock()
+	fake.UpdateLastCheckContainerHandleStub = nil
+	if fake.updateLastCheckContainerHandleReturnsOnCall == nil {
+		fake.updateLastCheckContainerHandleReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.updateLastCheckContainerHandleReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakeResourceConfigScope) UpdateLastCheckEndTime(arg1 bool) (bool, error) {
@@ -677,6 +944,70 @@ func (fake *FakeResourceConfigScope) UpdateLastCheckEndTimeReturnsOnCall(i int,
 	}{result1, result2}
 }
 
+func (fake *FakeResourceConfigScope) UpdateLastCheckError(arg1 string) (bool, error) {
+	fake.updateLastCheckErrorMutex.Lock()
+	ret, specificReturn := fake.updateLastCheckErrorReturnsOnCall[len(fake.updateLastCheckErrorArgsForCall)]
+	fake.updateLastCheckErrorArgsForCall = append(fake.updateLastCheckErrorArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.UpdateLastCheckErrorStub
+	fakeReturns := fake.updateLastCheckErrorReturns
+	fake.recordInvocation("UpdateLastCheckError", []interface{}{arg1})
+	fake.updateLastCheckErrorMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckErrorCallCount() int {
+	fake.updateLastCheckErrorMutex.RLock()
+	defer fake.updateLastCheckErrorMutex.RUnlock()
+	return len(fake.updateLastCheckErrorArgsForCall)
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckErrorCalls(stub func(string) (bool, error)) {
+	fake.updateLastCheckErrorMutex.Lock()
+	defer fake.updateLastCheckErrorMutex.Unlock()
+	fake.UpdateLastCheckErrorStub = stub
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckErrorArgsForCall(i int) string {
+	fake.updateLastCheckErrorMutex.RLock()
+	defer fake.updateLastCheckErrorMutex.RUnlock()
+	argsForCall := fake.updateLastCheckErrorArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckErrorReturns(result1 bool, result2 error) {
+	fake.updateLastCheckErrorMutex.Lock()
+	defer fake.updateLastCheckErrorMutex.Unlock()
+	fake.UpdateLastCheckErrorStub = nil
+	fake.updateLastCheckErrorReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastCheckErrorReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.updateLastCheckErrorMutex.Lock()
+	defer fake.updateLastCheckErrorMutex.Unlock()
+	fake.UpdateLastCheckErrorStub = nil
+	if fake.updateLastCheckErrorReturnsOnCall == nil {
+		fake.updateLastCheckErrorReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.updateLastCheckErrorReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResourceConfigScope) UpdateLastCheckStartTime() (bool, error) {
 	fake.updateLastCheckStartTimeMutex.Lock()
 	ret, specificReturn := fake.updateLastCheckStartTimeReturnsOnCall[len(fake.updateLastCheckStartTimeArgsForCall)]
@@ -733,6 +1064,70 @@ func (fake *FakeResourceConfigScope) UpdateLastCheckStartTimeReturnsOnCall(i int
 	}{result1, result2}
 }
 
+func (fake *FakeResourceConfigScope) UpdateLastScheduledCheckEndTime(arg1 bool) (bool, error) {
+	fake.updateLastScheduledCheckEndTimeMutex.Lock()
+	ret, specificReturn := fake.updateLastScheduledCheckEndTimeReturnsOnCall[len(fake.updateLastScheduledCheckEndTimeArgsForCall)]
+	fake.updateLastScheduledCheckEndTimeArgsForCall = append(fake.updateLastScheduledCheckEndTimeArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	stub := fake.UpdateLastScheduledCheckEndTimeStub
+	fakeReturns := fake.updateLastScheduledCheckEndTimeReturns
+	fake.recordInvocation("UpdateLastScheduledCheckEndTime", []interface{}{arg1})
+	fake.updateLastScheduledCheckEndTimeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastScheduledCheckEndTimeCallCount() int {
+	fake.updateLastScheduledCheckEndTimeMutex.RLock()
+	defer fake.updateLastScheduledCheckEndTimeMutex.RUnlock()
+	return len(fake.updateLastScheduledCheckEndTimeArgsForCall)
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastScheduledCheckEndTimeCalls(stub func(bool) (bool, error)) {
+	fake.updateLastScheduledCheckEndTimeMutex.Lock()
+	defer fake.updateLastScheduledCheckEndTimeMutex.Unlock()
+	fake.UpdateLastScheduledCheckEndTimeStub = stub
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastScheduledCheckEndTimeArgsForCall(i int) bool {
+	fake.updateLastScheduledCheckEndTimeMutex.RLock()
+	defer fake.updateLastScheduledCheckEndTimeMutex.RUnlock()
+	argsForCall := fake.updateLastScheduledCheckEndTimeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastScheduledCheckEndTimeReturns(result1 bool, result2 error) {
+	fake.updateLastScheduledCheckEndTimeMutex.Lock()
+	defer fake.updateLastScheduledCheckEndTimeMutex.Unlock()
+	fake.UpdateLastScheduledCheckEndTimeStub = nil
+	fake.updateLastScheduledCheckEndTimeReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceConfigScope) UpdateLastScheduledCheckEndTimeReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.updateLastScheduledCheckEndTimeMutex.Lock()
+	defer fake.updateLastScheduledCheckEndTimeMutex.Unlock()
+	fake.UpdateLastScheduledCheckEndTimeStub = nil
+	if fake.updateLastScheduledCheckEndTimeReturnsOnCall == nil {
+		fake.updateLastScheduledCheckEndTimeReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.updateLastScheduledCheckEndTimeReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResourceConfigScope) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -752,10 +1147,20 @@ func (fake *FakeResourceConfigScope) Invocations() map[string][][]interface{} {
 	defer fake.resourceConfigMutex.RUnlock()
 	fake.saveVersionsMutex.RLock()
 	defer fake.saveVersionsMutex.RUnlock()
+	fake.saveVersionsIdentifiedByMutex.RLock()
+	defer fake.saveVersionsIdentifiedByMutex.RUnlock()
+	fake.updateLastCheckCanceledMutex.RLock()
+	defer fake.updateLastCheckCanceledMutex.RUnlock()
+	fake.updateLastCheckContainerHandleMutex.RLock()
+	defer fake.updateLastCheckContainerHandleMutex.RUnlock()
 	fake.updateLastCheckEndTimeMutex.RLock()
 	defer fake.updateLastCheckEndTimeMutex.RUnlock()
+	fake.updateLastCheckErrorMutex.RLock()
+	defer fake.updateLastCheckErrorMutex.RUnlock()
 	fake.updateLastCheckStartTimeMutex.RLock()
 	defer fake.updateLastCheckStartTimeMutex.RUnlock()
+	fake.updateLastScheduledCheckEndTimeMutex.RLock()
+	defer fake.updateLastScheduledCheckEndTimeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
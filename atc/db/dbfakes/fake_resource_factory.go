@@ -8,6 +8,19 @@ import (
 )
 
 type FakeResourceFactory struct {
+	AllResourceTypeUsagesStub        func(db.ResourceTypeUsageFilter) ([]db.ResourceTypeUsage, error)
+	allResourceTypeUsagesMutex       sync.RWMutex
+	allResourceTypeUsagesArgsForCall []struct {
+		arg1 db.ResourceTypeUsageFilter
+	}
+	allResourceTypeUsagesReturns struct {
+		result1 []db.ResourceTypeUsage
+		result2 error
+	}
+	allResourceTypeUsagesReturnsOnCall map[int]struct {
+		result1 []db.ResourceTypeUsage
+		result2 error
+	}
 	AllResourcesStub        func() ([]db.Resource, error)
 	allResourcesMutex       sync.RWMutex
 	allResourcesArgsForCall []struct {
@@ -35,6 +48,20 @@ type FakeResourceFactory struct {
 		result2 bool
 		result3 error
 	}
+	VisibleResourceTypeUsagesStub        func([]string, db.ResourceTypeUsageFilter) ([]db.ResourceTypeUsage, error)
+	visibleResourceTypeUsagesMutex       sync.RWMutex
+	visibleResourceTypeUsagesArgsForCall []struct {
+		arg1 []string
+		arg2 db.ResourceTypeUsageFilter
+	}
+	visibleResourceTypeUsagesReturns struct {
+		result1 []db.ResourceTypeUsage
+		result2 error
+	}
+	visibleResourceTypeUsagesReturnsOnCall map[int]struct {
+		result1 []db.ResourceTypeUsage
+		result2 error
+	}
 	VisibleResourcesStub        func([]string) ([]db.Resource, error)
 	visibleResourcesMutex       sync.RWMutex
 	visibleResourcesArgsForCall []struct {
@@ -52,6 +79,70 @@ type FakeResourceFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeResourceFactory) AllResourceTypeUsages(arg1 db.ResourceTypeUsageFilter) ([]db.ResourceTypeUsage, error) {
+	fake.allResourceTypeUsagesMutex.Lock()
+	ret, specificReturn := fake.allResourceTypeUsagesReturnsOnCall[len(fake.allResourceTypeUsagesArgsForCall)]
+	fake.allResourceTypeUsagesArgsForCall = append(fake.allResourceTypeUsagesArgsForCall, struct {
+		arg1 db.ResourceTypeUsageFilter
+	}{arg1})
+	stub := fake.AllResourceTypeUsagesStub
+	fakeReturns := fake.allResourceTypeUsagesReturns
+	fake.recordInvocation("AllResourceTypeUsages", []interface{}{arg1})
+	fake.allResourceTypeUsagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceFactory) AllResourceTypeUsagesCallCount() int {
+	fake.allResourceTypeUsagesMutex.RLock()
+	defer fake.allResourceTypeUsagesMutex.RUnlock()
+	return len(fake.allResourceTypeUsagesArgsForCall)
+}
+
+func (fake *FakeResourceFactory) AllResourceTypeUsagesCalls(stub func(db.ResourceTypeUsageFilter) ([]db.ResourceTypeUsage, error)) {
+	fake.allResourceTypeUsagesMutex.Lock()
+	defer fake.allResourceTypeUsagesMutex.Unlock()
+	fake.AllResourceTypeUsagesStub = stub
+}
+
+func (fake *FakeResourceFactory) AllResourceTypeUsagesArgsForCall(i int) db.ResourceTypeUsageFilter {
+	fake.allResourceTypeUsagesMutex.RLock()
+	defer fake.allResourceTypeUsagesMutex.RUnlock()
+	argsForCall := fake.allResourceTypeUsagesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResourceFactory) AllResourceTypeUsagesReturns(result1 []db.ResourceTypeUsage, result2 error) {
+	fake.allResourceTypeUsagesMutex.Lock()
+	defer fake.allResourceTypeUsagesMutex.Unlock()
+	fake.AllResourceTypeUsagesStub = nil
+	fake.allResourceTypeUsagesReturns = struct {
+		result1 []db.ResourceTypeUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceFactory) AllResourceTypeUsagesReturnsOnCall(i int, result1 []db.ResourceTypeUsage, result2 error) {
+	fake.allResourceTypeUsagesMutex.Lock()
+	defer fake.allResourceTypeUsagesMutex.Unlock()
+	fake.AllResourceTypeUsagesStub = nil
+	if fake.allResourceTypeUsagesReturnsOnCall == nil {
+		fake.allResourceTypeUsagesReturnsOnCall = make(map[int]struct {
+			result1 []db.ResourceTypeUsage
+			result2 error
+		})
+	}
+	fake.allResourceTypeUsagesReturnsOnCall[i] = struct {
+		result1 []db.ResourceTypeUsage
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResourceFactory) AllResources() ([]db.Resource, error) {
 	fake.allResourcesMutex.Lock()
 	ret, specificReturn := fake.allResourcesReturnsOnCall[len(fake.allResourcesArgsForCall)]
@@ -175,6 +266,76 @@ func (fake *FakeResourceFactory) ResourceReturnsOnCall(i int, result1 db.Resourc
 	}{result1, result2, result3}
 }
 
+func (fake *FakeResourceFactory) VisibleResourceTypeUsages(arg1 []string, arg2 db.ResourceTypeUsageFilter) ([]db.ResourceTypeUsage, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.visibleResourceTypeUsagesMutex.Lock()
+	ret, specificReturn := fake.visibleResourceTypeUsagesReturnsOnCall[len(fake.visibleResourceTypeUsagesArgsForCall)]
+	fake.visibleResourceTypeUsagesArgsForCall = append(fake.visibleResourceTypeUsagesArgsForCall, struct {
+		arg1 []string
+		arg2 db.ResourceTypeUsageFilter
+	}{arg1Copy, arg2})
+	stub := fake.VisibleResourceTypeUsagesStub
+	fakeReturns := fake.visibleResourceTypeUsagesReturns
+	fake.recordInvocation("VisibleResourceTypeUsages", []interface{}{arg1Copy, arg2})
+	fake.visibleResourceTypeUsagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceFactory) VisibleResourceTypeUsagesCallCount() int {
+	fake.visibleResourceTypeUsagesMutex.RLock()
+	defer fake.visibleResourceTypeUsagesMutex.RUnlock()
+	return len(fake.visibleResourceTypeUsagesArgsForCall)
+}
+
+func (fake *FakeResourceFactory) VisibleResourceTypeUsagesCalls(stub func([]string, db.ResourceTypeUsageFilter) ([]db.ResourceTypeUsage, error)) {
+	fake.visibleResourceTypeUsagesMutex.Lock()
+	defer fake.visibleResourceTypeUsagesMutex.Unlock()
+	fake.VisibleResourceTypeUsagesStub = stub
+}
+
+func (fake *FakeResourceFactory) VisibleResourceTypeUsagesArgsForCall(i int) ([]string, db.ResourceTypeUsageFilter) {
+	fake.visibleResourceTypeUsagesMutex.RLock()
+	defer fake.visibleResourceTypeUsagesMutex.RUnlock()
+	argsForCall := fake.visibleResourceTypeUsagesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResourceFactory) VisibleResourceTypeUsagesReturns(result1 []db.ResourceTypeUsage, result2 error) {
+	fake.visibleResourceTypeUsagesMutex.Lock()
+	defer fake.visibleResourceTypeUsagesMutex.Unlock()
+	fake.VisibleResourceTypeUsagesStub = nil
+	fake.visibleResourceTypeUsagesReturns = struct {
+		result1 []db.ResourceTypeUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceFactory) VisibleResourceTypeUsagesReturnsOnCall(i int, result1 []db.ResourceTypeUsage, result2 error) {
+	fake.visibleResourceTypeUsagesMutex.Lock()
+	defer fake.visibleResourceTypeUsagesMutex.Unlock()
+	fake.VisibleResourceTypeUsagesStub = nil
+	if fake.visibleResourceTypeUsagesReturnsOnCall == nil {
+		fake.visibleResourceTypeUsagesReturnsOnCall = make(map[int]struct {
+			result1 []db.ResourceTypeUsage
+			result2 error
+		})
+	}
+	fake.visibleResourceTypeUsagesReturnsOnCall[i] = struct {
+		result1 []db.ResourceTypeUsage
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResourceFactory) VisibleResources(arg1 []string) ([]db.Resource, error) {
 	var arg1Copy []string
 	if arg1 != nil {
@@ -247,10 +408,14 @@ func (fake *FakeResourceFactory) VisibleResourcesReturnsOnCall(i int, result1 []
 func (fake *FakeResourceFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.allResourceTypeUsagesMutex.RLock()
+	defer fake.allResourceTypeUsagesMutex.RUnlock()
 	fake.allResourcesMutex.RLock()
 	defer fake.allResourcesMutex.RUnlock()
 	fake.resourceMutex.RLock()
 	defer fake.resourceMutex.RUnlock()
+	fake.visibleResourceTypeUsagesMutex.RLock()
+	defer fake.visibleResourceTypeUsagesMutex.RUnlock()
 	fake.visibleResourcesMutex.RLock()
 	defer fake.visibleResourcesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
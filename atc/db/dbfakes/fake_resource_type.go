@@ -35,6 +35,16 @@ type FakeResourceType struct {
 	checkPlanReturnsOnCall map[int]struct {
 		result1 atc.CheckPlan
 	}
+	CheckTTLStub        func() time.Duration
+	checkTTLMutex       sync.RWMutex
+	checkTTLArgsForCall []struct {
+	}
+	checkTTLReturns struct {
+		result1 time.Duration
+	}
+	checkTTLReturnsOnCall map[int]struct {
+		result1 time.Duration
+	}
 	CheckTimeoutStub        func() string
 	checkTimeoutMutex       sync.RWMutex
 	checkTimeoutArgsForCall []struct {
@@ -102,6 +112,16 @@ type FakeResourceType struct {
 	iDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	IdentityFieldsStub        func() []string
+	identityFieldsMutex       sync.RWMutex
+	identityFieldsArgsForCall []struct {
+	}
+	identityFieldsReturns struct {
+		result1 []string
+	}
+	identityFieldsReturnsOnCall map[int]struct {
+		result1 []string
+	}
 	LastCheckEndTimeStub        func() time.Time
 	lastCheckEndTimeMutex       sync.RWMutex
 	lastCheckEndTimeArgsForCall []struct {
@@ -122,6 +142,16 @@ type FakeResourceType struct {
 	lastCheckStartTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	LastScheduledCheckEndTimeStub        func() time.Time
+	lastScheduledCheckEndTimeMutex       sync.RWMutex
+	lastScheduledCheckEndTimeArgsForCall []struct {
+	}
+	lastScheduledCheckEndTimeReturns struct {
+		result1 time.Time
+	}
+	lastScheduledCheckEndTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -132,6 +162,16 @@ type FakeResourceType struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NotRetryableExitCodesStub        func() []int
+	notRetryableExitCodesMutex       sync.RWMutex
+	notRetryableExitCodesArgsForCall []struct {
+	}
+	notRetryableExitCodesReturns struct {
+		result1 []int
+	}
+	notRetryableExitCodesReturnsOnCall map[int]struct {
+		result1 []int
+	}
 	ParamsStub        func() atc.Params
 	paramsMutex       sync.RWMutex
 	paramsArgsForCall []struct {
@@ -228,6 +268,16 @@ type FakeResourceType struct {
 	resourceConfigScopeIDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	ReuseImageForStub        func() string
+	reuseImageForMutex       sync.RWMutex
+	reuseImageForArgsForCall []struct {
+	}
+	reuseImageForReturns struct {
+		result1 string
+	}
+	reuseImageForReturnsOnCall map[int]struct {
+		result1 string
+	}
 	SetResourceConfigScopeStub        func(db.ResourceConfigScope) error
 	setResourceConfigScopeMutex       sync.RWMutex
 	setResourceConfigScopeArgsForCall []struct {
@@ -279,6 +329,26 @@ type FakeResourceType struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	TeamScopedStub        func() bool
+	teamScopedMutex       sync.RWMutex
+	teamScopedArgsForCall []struct {
+	}
+	teamScopedReturns struct {
+		result1 bool
+	}
+	teamScopedReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	TwoPhaseCommitStub        func() bool
+	twoPhaseCommitMutex       sync.RWMutex
+	twoPhaseCommitArgsForCall []struct {
+	}
+	twoPhaseCommitReturns struct {
+		result1 bool
+	}
+	twoPhaseCommitReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	TypeStub        func() string
 	typeMutex       sync.RWMutex
 	typeArgsForCall []struct {
@@ -420,6 +490,59 @@ func (fake *FakeResourceType) CheckPlanReturnsOnCall(i int, result1 atc.CheckPla
 	}{result1}
 }
 
+func (fake *FakeResourceType) CheckTTL() time.Duration {
+	fake.checkTTLMutex.Lock()
+	ret, specificReturn := fake.checkTTLReturnsOnCall[len(fake.checkTTLArgsForCall)]
+	fake.checkTTLArgsForCall = append(fake.checkTTLArgsForCall, struct {
+	}{})
+	stub := fake.CheckTTLStub
+	fakeReturns := fake.checkTTLReturns
+	fake.recordInvocation("CheckTTL", []interface{}{})
+	fake.checkTTLMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceType) CheckTTLCallCount() int {
+	fake.checkTTLMutex.RLock()
+	defer fake.checkTTLMutex.RUnlock()
+	return len(fake.checkTTLArgsForCall)
+}
+
+func (fake *FakeResourceType) CheckTTLCalls(stub func() time.Duration) {
+	fake.checkTTLMutex.Lock()
+	defer fake.checkTTLMutex.Unlock()
+	fake.CheckTTLStub = stub
+}
+
+func (fake *FakeResourceType) CheckTTLReturns(result1 time.Duration) {
+	fake.checkTTLMutex.Lock()
+	defer fake.checkTTLMutex.Unlock()
+	fake.CheckTTLStub = nil
+	fake.checkTTLReturns = struct {
+		result1 time.Duration
+	}{result1}
+}
+
+func (fake *FakeResourceType) CheckTTLReturnsOnCall(i int, result1 time.Duration) {
+	fake.checkTTLMutex.Lock()
+	defer fake.checkTTLMutex.Unlock()
+	fake.CheckTTLStub = nil
+	if fake.checkTTLReturnsOnCall == nil {
+		fake.checkTTLReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+		})
+	}
+	fake.checkTTLReturnsOnCall[i] = struct {
+		result1 time.Duration
+	}{result1}
+}
+
 func (fake *FakeResourceType) CheckTimeout() string {
 	fake.checkTimeoutMutex.Lock()
 	ret, specificReturn := fake.checkTimeoutReturnsOnCall[len(fake.checkTimeoutArgsForCall)]
@@ -754,6 +877,59 @@ func (fake *FakeResourceType) IDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeResourceType) IdentityFields() []string {
+	fake.identityFieldsMutex.Lock()
+	ret, specificReturn := fake.identityFieldsReturnsOnCall[len(fake.identityFieldsArgsForCall)]
+	fake.identityFieldsArgsForCall = append(fake.identityFieldsArgsForCall, struct {
+	}{})
+	stub := fake.IdentityFieldsStub
+	fakeReturns := fake.identityFieldsReturns
+	fake.recordInvocation("IdentityFields", []interface{}{})
+	fake.identityFieldsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceType) IdentityFieldsCallCount() int {
+	fake.identityFieldsMutex.RLock()
+	defer fake.identityFieldsMutex.RUnlock()
+	return len(fake.identityFieldsArgsForCall)
+}
+
+func (fake *FakeResourceType) IdentityFieldsCalls(stub func() []string) {
+	fake.identityFieldsMutex.Lock()
+	defer fake.identityFieldsMutex.Unlock()
+	fake.IdentityFieldsStub = stub
+}
+
+func (fake *FakeResourceType) IdentityFieldsReturns(result1 []string) {
+	fake.identityFieldsMutex.Lock()
+	defer fake.identityFieldsMutex.Unlock()
+	fake.IdentityFieldsStub = nil
+	fake.identityFieldsReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeResourceType) IdentityFieldsReturnsOnCall(i int, result1 []string) {
+	fake.identityFieldsMutex.Lock()
+	defer fake.identityFieldsMutex.Unlock()
+	fake.IdentityFieldsStub = nil
+	if fake.identityFieldsReturnsOnCall == nil {
+		fake.identityFieldsReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.identityFieldsReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *FakeResourceType) LastCheckEndTime() time.Time {
 	fake.lastCheckEndTimeMutex.Lock()
 	ret, specificReturn := fake.lastCheckEndTimeReturnsOnCall[len(fake.lastCheckEndTimeArgsForCall)]
@@ -860,6 +1036,59 @@ func (fake *FakeResourceType) LastCheckStartTimeReturnsOnCall(i int, result1 tim
 	}{result1}
 }
 
+func (fake *FakeResourceType) LastScheduledCheckEndTime() time.Time {
+	fake.lastScheduledCheckEndTimeMutex.Lock()
+	ret, specificReturn := fake.lastScheduledCheckEndTimeReturnsOnCall[len(fake.lastScheduledCheckEndTimeArgsForCall)]
+	fake.lastScheduledCheckEndTimeArgsForCall = append(fake.lastScheduledCheckEndTimeArgsForCall, struct {
+	}{})
+	stub := fake.LastScheduledCheckEndTimeStub
+	fakeReturns := fake.lastScheduledCheckEndTimeReturns
+	fake.recordInvocation("LastScheduledCheckEndTime", []interface{}{})
+	fake.lastScheduledCheckEndTimeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceType) LastScheduledCheckEndTimeCallCount() int {
+	fake.lastScheduledCheckEndTimeMutex.RLock()
+	defer fake.lastScheduledCheckEndTimeMutex.RUnlock()
+	return len(fake.lastScheduledCheckEndTimeArgsForCall)
+}
+
+func (fake *FakeResourceType) LastScheduledCheckEndTimeCalls(stub func() time.Time) {
+	fake.lastScheduledCheckEndTimeMutex.Lock()
+	defer fake.lastScheduledCheckEndTimeMutex.Unlock()
+	fake.LastScheduledCheckEndTimeStub = stub
+}
+
+func (fake *FakeResourceType) LastScheduledCheckEndTimeReturns(result1 time.Time) {
+	fake.lastScheduledCheckEndTimeMutex.Lock()
+	defer fake.lastScheduledCheckEndTimeMutex.Unlock()
+	fake.LastScheduledCheckEndTimeStub = nil
+	fake.lastScheduledCheckEndTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeResourceType) LastScheduledCheckEndTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.lastScheduledCheckEndTimeMutex.Lock()
+	defer fake.lastScheduledCheckEndTimeMutex.Unlock()
+	fake.LastScheduledCheckEndTimeStub = nil
+	if fake.lastScheduledCheckEndTimeReturnsOnCall == nil {
+		fake.lastScheduledCheckEndTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.lastScheduledCheckEndTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeResourceType) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -913,6 +1142,59 @@ func (fake *FakeResourceType) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResourceType) NotRetryableExitCodes() []int {
+	fake.notRetryableExitCodesMutex.Lock()
+	ret, specificReturn := fake.notRetryableExitCodesReturnsOnCall[len(fake.notRetryableExitCodesArgsForCall)]
+	fake.notRetryableExitCodesArgsForCall = append(fake.notRetryableExitCodesArgsForCall, struct {
+	}{})
+	stub := fake.NotRetryableExitCodesStub
+	fakeReturns := fake.notRetryableExitCodesReturns
+	fake.recordInvocation("NotRetryableExitCodes", []interface{}{})
+	fake.notRetryableExitCodesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceType) NotRetryableExitCodesCallCount() int {
+	fake.notRetryableExitCodesMutex.RLock()
+	defer fake.notRetryableExitCodesMutex.RUnlock()
+	return len(fake.notRetryableExitCodesArgsForCall)
+}
+
+func (fake *FakeResourceType) NotRetryableExitCodesCalls(stub func() []int) {
+	fake.notRetryableExitCodesMutex.Lock()
+	defer fake.notRetryableExitCodesMutex.Unlock()
+	fake.NotRetryableExitCodesStub = stub
+}
+
+func (fake *FakeResourceType) NotRetryableExitCodesReturns(result1 []int) {
+	fake.notRetryableExitCodesMutex.Lock()
+	defer fake.notRetryableExitCodesMutex.Unlock()
+	fake.NotRetryableExitCodesStub = nil
+	fake.notRetryableExitCodesReturns = struct {
+		result1 []int
+	}{result1}
+}
+
+func (fake *FakeResourceType) NotRetryableExitCodesReturnsOnCall(i int, result1 []int) {
+	fake.notRetryableExitCodesMutex.Lock()
+	defer fake.notRetryableExitCodesMutex.Unlock()
+	fake.NotRetryableExitCodesStub = nil
+	if fake.notRetryableExitCodesReturnsOnCall == nil {
+		fake.notRetryableExitCodesReturnsOnCall = make(map[int]struct {
+			result1 []int
+		})
+	}
+	fake.notRetryableExitCodesReturnsOnCall[i] = struct {
+		result1 []int
+	}{result1}
+}
+
 func (fake *FakeResourceType) Params() atc.Params {
 	fake.paramsMutex.Lock()
 	ret, specificReturn := fake.paramsReturnsOnCall[len(fake.paramsArgsForCall)]
@@ -1399,6 +1681,59 @@ func (fake *FakeResourceType) ResourceConfigScopeIDReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeResourceType) ReuseImageFor() string {
+	fake.reuseImageForMutex.Lock()
+	ret, specificReturn := fake.reuseImageForReturnsOnCall[len(fake.reuseImageForArgsForCall)]
+	fake.reuseImageForArgsForCall = append(fake.reuseImageForArgsForCall, struct {
+	}{})
+	stub := fake.ReuseImageForStub
+	fakeReturns := fake.reuseImageForReturns
+	fake.recordInvocation("ReuseImageFor", []interface{}{})
+	fake.reuseImageForMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceType) ReuseImageForCallCount() int {
+	fake.reuseImageForMutex.RLock()
+	defer fake.reuseImageForMutex.RUnlock()
+	return len(fake.reuseImageForArgsForCall)
+}
+
+func (fake *FakeResourceType) ReuseImageForCalls(stub func() string) {
+	fake.reuseImageForMutex.Lock()
+	defer fake.reuseImageForMutex.Unlock()
+	fake.ReuseImageForStub = stub
+}
+
+func (fake *FakeResourceType) ReuseImageForReturns(result1 string) {
+	fake.reuseImageForMutex.Lock()
+	defer fake.reuseImageForMutex.Unlock()
+	fake.ReuseImageForStub = nil
+	fake.reuseImageForReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResourceType) ReuseImageForReturnsOnCall(i int, result1 string) {
+	fake.reuseImageForMutex.Lock()
+	defer fake.reuseImageForMutex.Unlock()
+	fake.ReuseImageForStub = nil
+	if fake.reuseImageForReturnsOnCall == nil {
+		fake.reuseImageForReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.reuseImageForReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeResourceType) SetResourceConfigScope(arg1 db.ResourceConfigScope) error {
 	fake.setResourceConfigScopeMutex.Lock()
 	ret, specificReturn := fake.setResourceConfigScopeReturnsOnCall[len(fake.setResourceConfigScopeArgsForCall)]
@@ -1672,6 +2007,112 @@ func (fake *FakeResourceType) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResourceType) TeamScoped() bool {
+	fake.teamScopedMutex.Lock()
+	ret, specificReturn := fake.teamScopedReturnsOnCall[len(fake.teamScopedArgsForCall)]
+	fake.teamScopedArgsForCall = append(fake.teamScopedArgsForCall, struct {
+	}{})
+	stub := fake.TeamScopedStub
+	fakeReturns := fake.teamScopedReturns
+	fake.recordInvocation("TeamScoped", []interface{}{})
+	fake.teamScopedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceType) TeamScopedCallCount() int {
+	fake.teamScopedMutex.RLock()
+	defer fake.teamScopedMutex.RUnlock()
+	return len(fake.teamScopedArgsForCall)
+}
+
+func (fake *FakeResourceType) TeamScopedCalls(stub func() bool) {
+	fake.teamScopedMutex.Lock()
+	defer fake.teamScopedMutex.Unlock()
+	fake.TeamScopedStub = stub
+}
+
+func (fake *FakeResourceType) TeamScopedReturns(result1 bool) {
+	fake.teamScopedMutex.Lock()
+	defer fake.teamScopedMutex.Unlock()
+	fake.TeamScopedStub = nil
+	fake.teamScopedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResourceType) TeamScopedReturnsOnCall(i int, result1 bool) {
+	fake.teamScopedMutex.Lock()
+	defer fake.teamScopedMutex.Unlock()
+	fake.TeamScopedStub = nil
+	if fake.teamScopedReturnsOnCall == nil {
+		fake.teamScopedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.teamScopedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResourceType) TwoPhaseCommit() bool {
+	fake.twoPhaseCommitMutex.Lock()
+	ret, specificReturn := fake.twoPhaseCommitReturnsOnCall[len(fake.twoPhaseCommitArgsForCall)]
+	fake.twoPhaseCommitArgsForCall = append(fake.twoPhaseCommitArgsForCall, struct {
+	}{})
+	stub := fake.TwoPhaseCommitStub
+	fakeReturns := fake.twoPhaseCommitReturns
+	fake.recordInvocation("TwoPhaseCommit", []interface{}{})
+	fake.twoPhaseCommitMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceType) TwoPhaseCommitCallCount() int {
+	fake.twoPhaseCommitMutex.RLock()
+	defer fake.twoPhaseCommitMutex.RUnlock()
+	return len(fake.twoPhaseCommitArgsForCall)
+}
+
+func (fake *FakeResourceType) TwoPhaseCommitCalls(stub func() bool) {
+	fake.twoPhaseCommitMutex.Lock()
+	defer fake.twoPhaseCommitMutex.Unlock()
+	fake.TwoPhaseCommitStub = stub
+}
+
+func (fake *FakeResourceType) TwoPhaseCommitReturns(result1 bool) {
+	fake.twoPhaseCommitMutex.Lock()
+	defer fake.twoPhaseCommitMutex.Unlock()
+	fake.TwoPhaseCommitStub = nil
+	fake.twoPhaseCommitReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResourceType) TwoPhaseCommitReturnsOnCall(i int, result1 bool) {
+	fake.twoPhaseCommitMutex.Lock()
+	defer fake.twoPhaseCommitMutex.Unlock()
+	fake.TwoPhaseCommitStub = nil
+	if fake.twoPhaseCommitReturnsOnCall == nil {
+		fake.twoPhaseCommitReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.twoPhaseCommitReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeResourceType) Type() string {
 	fake.typeMutex.Lock()
 	ret, specificReturn := fake.typeReturnsOnCall[len(fake.typeArgsForCall)]
@@ -1785,6 +2226,8 @@ func (fake *FakeResourceType) Invocations() map[string][][]interface{} {
 	defer fake.checkEveryMutex.RUnlock()
 	fake.checkPlanMutex.RLock()
 	defer fake.checkPlanMutex.RUnlock()
+	fake.checkTTLMutex.RLock()
+	defer fake.checkTTLMutex.RUnlock()
 	fake.checkTimeoutMutex.RLock()
 	defer fake.checkTimeoutMutex.RUnlock()
 	fake.createBuildMutex.RLock()
@@ -1797,12 +2240,18 @@ func (fake *FakeResourceType) Invocations() map[string][][]interface{} {
 	defer fake.hasWebhookMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
+	fake.identityFieldsMutex.RLock()
+	defer fake.identityFieldsMutex.RUnlock()
 	fake.lastCheckEndTimeMutex.RLock()
 	defer fake.lastCheckEndTimeMutex.RUnlock()
 	fake.lastCheckStartTimeMutex.RLock()
 	defer fake.lastCheckStartTimeMutex.RUnlock()
+	fake.lastScheduledCheckEndTimeMutex.RLock()
+	defer fake.lastScheduledCheckEndTimeMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.notRetryableExitCodesMutex.RLock()
+	defer fake.notRetryableExitCodesMutex.RUnlock()
 	fake.paramsMutex.RLock()
 	defer fake.paramsMutex.RUnlock()
 	fake.pipelineMutex.RLock()
@@ -1821,6 +2270,8 @@ func (fake *FakeResourceType) Invocations() map[string][][]interface{} {
 	defer fake.reloadMutex.RUnlock()
 	fake.resourceConfigScopeIDMutex.RLock()
 	defer fake.resourceConfigScopeIDMutex.RUnlock()
+	fake.reuseImageForMutex.RLock()
+	defer fake.reuseImageForMutex.RUnlock()
 	fake.setResourceConfigScopeMutex.RLock()
 	defer fake.setResourceConfigScopeMutex.RUnlock()
 	fake.sourceMutex.RLock()
@@ -1831,6 +2282,10 @@ func (fake *FakeResourceType) Invocations() map[string][][]interface{} {
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.teamScopedMutex.RLock()
+	defer fake.teamScopedMutex.RUnlock()
+	fake.twoPhaseCommitMutex.RLock()
+	defer fake.twoPhaseCommitMutex.RUnlock()
 	fake.typeMutex.RLock()
 	defer fake.typeMutex.RUnlock()
 	fake.versionMutex.RLock()
@@ -171,6 +171,22 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	FindSharedArtifactStub        func(string, string) ([]byte, bool, error)
+	findSharedArtifactMutex       sync.RWMutex
+	findSharedArtifactArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	findSharedArtifactReturns struct {
+		result1 []byte
+		result2 bool
+		result3 error
+	}
+	findSharedArtifactReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 bool
+		result3 error
+	}
 	FindVolumeForWorkerArtifactStub        func(int) (db.CreatedVolume, bool, error)
 	findVolumeForWorkerArtifactMutex       sync.RWMutex
 	findVolumeForWorkerArtifactArgsForCall []struct {
@@ -229,6 +245,18 @@ type FakeTeam struct {
 		result1 []db.Worker
 		result2 error
 	}
+	GrantSharedArtifactStub        func(string, int) error
+	grantSharedArtifactMutex       sync.RWMutex
+	grantSharedArtifactArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	grantSharedArtifactReturns struct {
+		result1 error
+	}
+	grantSharedArtifactReturnsOnCall map[int]struct {
+		result1 error
+	}
 	IDStub        func() int
 	iDMutex       sync.RWMutex
 	iDArgsForCall []struct {
@@ -266,6 +294,16 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	MaxConcurrentBuildsStub        func() int
+	maxConcurrentBuildsMutex       sync.RWMutex
+	maxConcurrentBuildsArgsForCall []struct {
+	}
+	maxConcurrentBuildsReturns struct {
+		result1 int
+	}
+	maxConcurrentBuildsReturnsOnCall map[int]struct {
+		result1 int
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -353,6 +391,19 @@ type FakeTeam struct {
 		result1 []db.Pipeline
 		result2 error
 	}
+	PublishSharedArtifactStub        func(string, int, []byte) error
+	publishSharedArtifactMutex       sync.RWMutex
+	publishSharedArtifactArgsForCall []struct {
+		arg1 string
+		arg2 int
+		arg3 []byte
+	}
+	publishSharedArtifactReturns struct {
+		result1 error
+	}
+	publishSharedArtifactReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RenameStub        func(string) error
 	renameMutex       sync.RWMutex
 	renameArgsForCall []struct {
@@ -378,6 +429,30 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	ResourceTypesStub        func() (atc.ResourceTypes, error)
+	resourceTypesMutex       sync.RWMutex
+	resourceTypesArgsForCall []struct {
+	}
+	resourceTypesReturns struct {
+		result1 atc.ResourceTypes
+		result2 error
+	}
+	resourceTypesReturnsOnCall map[int]struct {
+		result1 atc.ResourceTypes
+		result2 error
+	}
+	RevokeSharedArtifactStub        func(string, int) error
+	revokeSharedArtifactMutex       sync.RWMutex
+	revokeSharedArtifactArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	revokeSharedArtifactReturns struct {
+		result1 error
+	}
+	revokeSharedArtifactReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SavePipelineStub        func(atc.PipelineRef, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)
 	savePipelineMutex       sync.RWMutex
 	savePipelineArgsForCall []struct {
@@ -396,6 +471,17 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	SaveResourceTypesStub        func(atc.ResourceTypes) error
+	saveResourceTypesMutex       sync.RWMutex
+	saveResourceTypesArgsForCall []struct {
+		arg1 atc.ResourceTypes
+	}
+	saveResourceTypesReturns struct {
+		result1 error
+	}
+	saveResourceTypesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveWorkerStub        func(atc.Worker, time.Duration) (db.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -410,6 +496,45 @@ type FakeTeam struct {
 		result1 db.Worker
 		result2 error
 	}
+	SetPipelinesVarStub        func([]int, string, interface{}, bool) (map[int]bool, error)
+	setPipelinesVarMutex       sync.RWMutex
+	setPipelinesVarArgsForCall []struct {
+		arg1 []int
+		arg2 string
+		arg3 interface{}
+		arg4 bool
+	}
+	setPipelinesVarReturns struct {
+		result1 map[int]bool
+		result2 error
+	}
+	setPipelinesVarReturnsOnCall map[int]struct {
+		result1 map[int]bool
+		result2 error
+	}
+	SharedArtifactsStub        func() ([]db.SharedArtifact, error)
+	sharedArtifactsMutex       sync.RWMutex
+	sharedArtifactsArgsForCall []struct {
+	}
+	sharedArtifactsReturns struct {
+		result1 []db.SharedArtifact
+		result2 error
+	}
+	sharedArtifactsReturnsOnCall map[int]struct {
+		result1 []db.SharedArtifact
+		result2 error
+	}
+	UpdateMaxConcurrentBuildsStub        func(int) error
+	updateMaxConcurrentBuildsMutex       sync.RWMutex
+	updateMaxConcurrentBuildsArgsForCall []struct {
+		arg1 int
+	}
+	updateMaxConcurrentBuildsReturns struct {
+		result1 error
+	}
+	updateMaxConcurrentBuildsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateProviderAuthStub        func(atc.TeamAuth) error
 	updateProviderAuthMutex       sync.RWMutex
 	updateProviderAuthArgsForCall []struct {
@@ -1175,6 +1300,74 @@ func (fake *FakeTeam) FindCreatedContainerByHandleReturnsOnCall(i int, result1 d
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) FindSharedArtifact(arg1 string, arg2 string) ([]byte, bool, error) {
+	fake.findSharedArtifactMutex.Lock()
+	ret, specificReturn := fake.findSharedArtifactReturnsOnCall[len(fake.findSharedArtifactArgsForCall)]
+	fake.findSharedArtifactArgsForCall = append(fake.findSharedArtifactArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.FindSharedArtifactStub
+	fakeReturns := fake.findSharedArtifactReturns
+	fake.recordInvocation("FindSharedArtifact", []interface{}{arg1, arg2})
+	fake.findSharedArtifactMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) FindSharedArtifactCallCount() int {
+	fake.findSharedArtifactMutex.RLock()
+	defer fake.findSharedArtifactMutex.RUnlock()
+	return len(fake.findSharedArtifactArgsForCall)
+}
+
+func (fake *FakeTeam) FindSharedArtifactCalls(stub func(string, string) ([]byte, bool, error)) {
+	fake.findSharedArtifactMutex.Lock()
+	defer fake.findSharedArtifactMutex.Unlock()
+	fake.FindSharedArtifactStub = stub
+}
+
+func (fake *FakeTeam) FindSharedArtifactArgsForCall(i int) (string, string) {
+	fake.findSharedArtifactMutex.RLock()
+	defer fake.findSharedArtifactMutex.RUnlock()
+	argsForCall := fake.findSharedArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) FindSharedArtifactReturns(result1 []byte, result2 bool, result3 error) {
+	fake.findSharedArtifactMutex.Lock()
+	defer fake.findSharedArtifactMutex.Unlock()
+	fake.FindSharedArtifactStub = nil
+	fake.findSharedArtifactReturns = struct {
+		result1 []byte
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) FindSharedArtifactReturnsOnCall(i int, result1 []byte, result2 bool, result3 error) {
+	fake.findSharedArtifactMutex.Lock()
+	defer fake.findSharedArtifactMutex.Unlock()
+	fake.FindSharedArtifactStub = nil
+	if fake.findSharedArtifactReturnsOnCall == nil {
+		fake.findSharedArtifactReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findSharedArtifactReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) FindVolumeForWorkerArtifact(arg1 int) (db.CreatedVolume, bool, error) {
 	fake.findVolumeForWorkerArtifactMutex.Lock()
 	ret, specificReturn := fake.findVolumeForWorkerArtifactReturnsOnCall[len(fake.findVolumeForWorkerArtifactArgsForCall)]
@@ -1440,6 +1633,68 @@ func (fake *FakeTeam) FindWorkersForResourceCacheReturnsOnCall(i int, result1 []
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) GrantSharedArtifact(arg1 string, arg2 int) error {
+	fake.grantSharedArtifactMutex.Lock()
+	ret, specificReturn := fake.grantSharedArtifactReturnsOnCall[len(fake.grantSharedArtifactArgsForCall)]
+	fake.grantSharedArtifactArgsForCall = append(fake.grantSharedArtifactArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.GrantSharedArtifactStub
+	fakeReturns := fake.grantSharedArtifactReturns
+	fake.recordInvocation("GrantSharedArtifact", []interface{}{arg1, arg2})
+	fake.grantSharedArtifactMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) GrantSharedArtifactCallCount() int {
+	fake.grantSharedArtifactMutex.RLock()
+	defer fake.grantSharedArtifactMutex.RUnlock()
+	return len(fake.grantSharedArtifactArgsForCall)
+}
+
+func (fake *FakeTeam) GrantSharedArtifactCalls(stub func(string, int) error) {
+	fake.grantSharedArtifactMutex.Lock()
+	defer fake.grantSharedArtifactMutex.Unlock()
+	fake.GrantSharedArtifactStub = stub
+}
+
+func (fake *FakeTeam) GrantSharedArtifactArgsForCall(i int) (string, int) {
+	fake.grantSharedArtifactMutex.RLock()
+	defer fake.grantSharedArtifactMutex.RUnlock()
+	argsForCall := fake.grantSharedArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) GrantSharedArtifactReturns(result1 error) {
+	fake.grantSharedArtifactMutex.Lock()
+	defer fake.grantSharedArtifactMutex.Unlock()
+	fake.GrantSharedArtifactStub = nil
+	fake.grantSharedArtifactReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) GrantSharedArtifactReturnsOnCall(i int, result1 error) {
+	fake.grantSharedArtifactMutex.Lock()
+	defer fake.grantSharedArtifactMutex.Unlock()
+	fake.GrantSharedArtifactStub = nil
+	if fake.grantSharedArtifactReturnsOnCall == nil {
+		fake.grantSharedArtifactReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.grantSharedArtifactReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) ID() int {
 	fake.iDMutex.Lock()
 	ret, specificReturn := fake.iDReturnsOnCall[len(fake.iDArgsForCall)]
@@ -1622,6 +1877,59 @@ func (fake *FakeTeam) IsContainerWithinTeamReturnsOnCall(i int, result1 bool, re
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) MaxConcurrentBuilds() int {
+	fake.maxConcurrentBuildsMutex.Lock()
+	ret, specificReturn := fake.maxConcurrentBuildsReturnsOnCall[len(fake.maxConcurrentBuildsArgsForCall)]
+	fake.maxConcurrentBuildsArgsForCall = append(fake.maxConcurrentBuildsArgsForCall, struct {
+	}{})
+	stub := fake.MaxConcurrentBuildsStub
+	fakeReturns := fake.maxConcurrentBuildsReturns
+	fake.recordInvocation("MaxConcurrentBuilds", []interface{}{})
+	fake.maxConcurrentBuildsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) MaxConcurrentBuildsCallCount() int {
+	fake.maxConcurrentBuildsMutex.RLock()
+	defer fake.maxConcurrentBuildsMutex.RUnlock()
+	return len(fake.maxConcurrentBuildsArgsForCall)
+}
+
+func (fake *FakeTeam) MaxConcurrentBuildsCalls(stub func() int) {
+	fake.maxConcurrentBuildsMutex.Lock()
+	defer fake.maxConcurrentBuildsMutex.Unlock()
+	fake.MaxConcurrentBuildsStub = stub
+}
+
+func (fake *FakeTeam) MaxConcurrentBuildsReturns(result1 int) {
+	fake.maxConcurrentBuildsMutex.Lock()
+	defer fake.maxConcurrentBuildsMutex.Unlock()
+	fake.MaxConcurrentBuildsStub = nil
+	fake.maxConcurrentBuildsReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeTeam) MaxConcurrentBuildsReturnsOnCall(i int, result1 int) {
+	fake.maxConcurrentBuildsMutex.Lock()
+	defer fake.maxConcurrentBuildsMutex.Unlock()
+	fake.MaxConcurrentBuildsStub = nil
+	if fake.maxConcurrentBuildsReturnsOnCall == nil {
+		fake.maxConcurrentBuildsReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.maxConcurrentBuildsReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeTeam) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -2054,6 +2362,74 @@ func (fake *FakeTeam) PublicPipelinesReturnsOnCall(i int, result1 []db.Pipeline,
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) PublishSharedArtifact(arg1 string, arg2 int, arg3 []byte) error {
+	var arg3Copy []byte
+	if arg3 != nil {
+		arg3Copy = make([]byte, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.publishSharedArtifactMutex.Lock()
+	ret, specificReturn := fake.publishSharedArtifactReturnsOnCall[len(fake.publishSharedArtifactArgsForCall)]
+	fake.publishSharedArtifactArgsForCall = append(fake.publishSharedArtifactArgsForCall, struct {
+		arg1 string
+		arg2 int
+		arg3 []byte
+	}{arg1, arg2, arg3Copy})
+	stub := fake.PublishSharedArtifactStub
+	fakeReturns := fake.publishSharedArtifactReturns
+	fake.recordInvocation("PublishSharedArtifact", []interface{}{arg1, arg2, arg3Copy})
+	fake.publishSharedArtifactMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) PublishSharedArtifactCallCount() int {
+	fake.publishSharedArtifactMutex.RLock()
+	defer fake.publishSharedArtifactMutex.RUnlock()
+	return len(fake.publishSharedArtifactArgsForCall)
+}
+
+func (fake *FakeTeam) PublishSharedArtifactCalls(stub func(string, int, []byte) error) {
+	fake.publishSharedArtifactMutex.Lock()
+	defer fake.publishSharedArtifactMutex.Unlock()
+	fake.PublishSharedArtifactStub = stub
+}
+
+func (fake *FakeTeam) PublishSharedArtifactArgsForCall(i int) (string, int, []byte) {
+	fake.publishSharedArtifactMutex.RLock()
+	defer fake.publishSharedArtifactMutex.RUnlock()
+	argsForCall := fake.publishSharedArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) PublishSharedArtifactReturns(result1 error) {
+	fake.publishSharedArtifactMutex.Lock()
+	defer fake.publishSharedArtifactMutex.Unlock()
+	fake.PublishSharedArtifactStub = nil
+	fake.publishSharedArtifactReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) PublishSharedArtifactReturnsOnCall(i int, result1 error) {
+	fake.publishSharedArtifactMutex.Lock()
+	defer fake.publishSharedArtifactMutex.Unlock()
+	fake.PublishSharedArtifactStub = nil
+	if fake.publishSharedArtifactReturnsOnCall == nil {
+		fake.publishSharedArtifactReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.publishSharedArtifactReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) Rename(arg1 string) error {
 	fake.renameMutex.Lock()
 	ret, specificReturn := fake.renameReturnsOnCall[len(fake.renameArgsForCall)]
@@ -2180,6 +2556,124 @@ func (fake *FakeTeam) RenamePipelineReturnsOnCall(i int, result1 bool, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) ResourceTypes() (atc.ResourceTypes, error) {
+	fake.resourceTypesMutex.Lock()
+	ret, specificReturn := fake.resourceTypesReturnsOnCall[len(fake.resourceTypesArgsForCall)]
+	fake.resourceTypesArgsForCall = append(fake.resourceTypesArgsForCall, struct {
+	}{})
+	stub := fake.ResourceTypesStub
+	fakeReturns := fake.resourceTypesReturns
+	fake.recordInvocation("ResourceTypes", []interface{}{})
+	fake.resourceTypesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ResourceTypesCallCount() int {
+	fake.resourceTypesMutex.RLock()
+	defer fake.resourceTypesMutex.RUnlock()
+	return len(fake.resourceTypesArgsForCall)
+}
+
+func (fake *FakeTeam) ResourceTypesCalls(stub func() (atc.ResourceTypes, error)) {
+	fake.resourceTypesMutex.Lock()
+	defer fake.resourceTypesMutex.Unlock()
+	fake.ResourceTypesStub = stub
+}
+
+func (fake *FakeTeam) ResourceTypesReturns(result1 atc.ResourceTypes, result2 error) {
+	fake.resourceTypesMutex.Lock()
+	defer fake.resourceTypesMutex.Unlock()
+	fake.ResourceTypesStub = nil
+	fake.resourceTypesReturns = struct {
+		result1 atc.ResourceTypes
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ResourceTypesReturnsOnCall(i int, result1 atc.ResourceTypes, result2 error) {
+	fake.resourceTypesMutex.Lock()
+	defer fake.resourceTypesMutex.Unlock()
+	fake.ResourceTypesStub = nil
+	if fake.resourceTypesReturnsOnCall == nil {
+		fake.resourceTypesReturnsOnCall = make(map[int]struct {
+			result1 atc.ResourceTypes
+			result2 error
+		})
+	}
+	fake.resourceTypesReturnsOnCall[i] = struct {
+		result1 atc.ResourceTypes
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) RevokeSharedArtifact(arg1 string, arg2 int) error {
+	fake.revokeSharedArtifactMutex.Lock()
+	ret, specificReturn := fake.revokeSharedArtifactReturnsOnCall[len(fake.revokeSharedArtifactArgsForCall)]
+	fake.revokeSharedArtifactArgsForCall = append(fake.revokeSharedArtifactArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.RevokeSharedArtifactStub
+	fakeReturns := fake.revokeSharedArtifactReturns
+	fake.recordInvocation("RevokeSharedArtifact", []interface{}{arg1, arg2})
+	fake.revokeSharedArtifactMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) RevokeSharedArtifactCallCount() int {
+	fake.revokeSharedArtifactMutex.RLock()
+	defer fake.revokeSharedArtifactMutex.RUnlock()
+	return len(fake.revokeSharedArtifactArgsForCall)
+}
+
+func (fake *FakeTeam) RevokeSharedArtifactCalls(stub func(string, int) error) {
+	fake.revokeSharedArtifactMutex.Lock()
+	defer fake.revokeSharedArtifactMutex.Unlock()
+	fake.RevokeSharedArtifactStub = stub
+}
+
+func (fake *FakeTeam) RevokeSharedArtifactArgsForCall(i int) (string, int) {
+	fake.revokeSharedArtifactMutex.RLock()
+	defer fake.revokeSharedArtifactMutex.RUnlock()
+	argsForCall := fake.revokeSharedArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) RevokeSharedArtifactReturns(result1 error) {
+	fake.revokeSharedArtifactMutex.Lock()
+	defer fake.revokeSharedArtifactMutex.Unlock()
+	fake.RevokeSharedArtifactStub = nil
+	fake.revokeSharedArtifactReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) RevokeSharedArtifactReturnsOnCall(i int, result1 error) {
+	fake.revokeSharedArtifactMutex.Lock()
+	defer fake.revokeSharedArtifactMutex.Unlock()
+	fake.RevokeSharedArtifactStub = nil
+	if fake.revokeSharedArtifactReturnsOnCall == nil {
+		fake.revokeSharedArtifactReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeSharedArtifactReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) SavePipeline(arg1 atc.PipelineRef, arg2 atc.Config, arg3 db.ConfigVersion, arg4 bool) (db.Pipeline, bool, error) {
 	fake.savePipelineMutex.Lock()
 	ret, specificReturn := fake.savePipelineReturnsOnCall[len(fake.savePipelineArgsForCall)]
@@ -2250,6 +2744,67 @@ func (fake *FakeTeam) SavePipelineReturnsOnCall(i int, result1 db.Pipeline, resu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) SaveResourceTypes(arg1 atc.ResourceTypes) error {
+	fake.saveResourceTypesMutex.Lock()
+	ret, specificReturn := fake.saveResourceTypesReturnsOnCall[len(fake.saveResourceTypesArgsForCall)]
+	fake.saveResourceTypesArgsForCall = append(fake.saveResourceTypesArgsForCall, struct {
+		arg1 atc.ResourceTypes
+	}{arg1})
+	stub := fake.SaveResourceTypesStub
+	fakeReturns := fake.saveResourceTypesReturns
+	fake.recordInvocation("SaveResourceTypes", []interface{}{arg1})
+	fake.saveResourceTypesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) SaveResourceTypesCallCount() int {
+	fake.saveResourceTypesMutex.RLock()
+	defer fake.saveResourceTypesMutex.RUnlock()
+	return len(fake.saveResourceTypesArgsForCall)
+}
+
+func (fake *FakeTeam) SaveResourceTypesCalls(stub func(atc.ResourceTypes) error) {
+	fake.saveResourceTypesMutex.Lock()
+	defer fake.saveResourceTypesMutex.Unlock()
+	fake.SaveResourceTypesStub = stub
+}
+
+func (fake *FakeTeam) SaveResourceTypesArgsForCall(i int) atc.ResourceTypes {
+	fake.saveResourceTypesMutex.RLock()
+	defer fake.saveResourceTypesMutex.RUnlock()
+	argsForCall := fake.saveResourceTypesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SaveResourceTypesReturns(result1 error) {
+	fake.saveResourceTypesMutex.Lock()
+	defer fake.saveResourceTypesMutex.Unlock()
+	fake.SaveResourceTypesStub = nil
+	fake.saveResourceTypesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) SaveResourceTypesReturnsOnCall(i int, result1 error) {
+	fake.saveResourceTypesMutex.Lock()
+	defer fake.saveResourceTypesMutex.Unlock()
+	fake.SaveResourceTypesStub = nil
+	if fake.saveResourceTypesReturnsOnCall == nil {
+		fake.saveResourceTypesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveResourceTypesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) SaveWorker(arg1 atc.Worker, arg2 time.Duration) (db.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -2315,6 +2870,195 @@ func (fake *FakeTeam) SaveWorkerReturnsOnCall(i int, result1 db.Worker, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SetPipelinesVar(arg1 []int, arg2 string, arg3 interface{}, arg4 bool) (map[int]bool, error) {
+	var arg1Copy []int
+	if arg1 != nil {
+		arg1Copy = make([]int, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.setPipelinesVarMutex.Lock()
+	ret, specificReturn := fake.setPipelinesVarReturnsOnCall[len(fake.setPipelinesVarArgsForCall)]
+	fake.setPipelinesVarArgsForCall = append(fake.setPipelinesVarArgsForCall, struct {
+		arg1 []int
+		arg2 string
+		arg3 interface{}
+		arg4 bool
+	}{arg1Copy, arg2, arg3, arg4})
+	stub := fake.SetPipelinesVarStub
+	fakeReturns := fake.setPipelinesVarReturns
+	fake.recordInvocation("SetPipelinesVar", []interface{}{arg1Copy, arg2, arg3, arg4})
+	fake.setPipelinesVarMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SetPipelinesVarCallCount() int {
+	fake.setPipelinesVarMutex.RLock()
+	defer fake.setPipelinesVarMutex.RUnlock()
+	return len(fake.setPipelinesVarArgsForCall)
+}
+
+func (fake *FakeTeam) SetPipelinesVarCalls(stub func([]int, string, interface{}, bool) (map[int]bool, error)) {
+	fake.setPipelinesVarMutex.Lock()
+	defer fake.setPipelinesVarMutex.Unlock()
+	fake.SetPipelinesVarStub = stub
+}
+
+func (fake *FakeTeam) SetPipelinesVarArgsForCall(i int) ([]int, string, interface{}, bool) {
+	fake.setPipelinesVarMutex.RLock()
+	defer fake.setPipelinesVarMutex.RUnlock()
+	argsForCall := fake.setPipelinesVarArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeTeam) SetPipelinesVarReturns(result1 map[int]bool, result2 error) {
+	fake.setPipelinesVarMutex.Lock()
+	defer fake.setPipelinesVarMutex.Unlock()
+	fake.SetPipelinesVarStub = nil
+	fake.setPipelinesVarReturns = struct {
+		result1 map[int]bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SetPipelinesVarReturnsOnCall(i int, result1 map[int]bool, result2 error) {
+	fake.setPipelinesVarMutex.Lock()
+	defer fake.setPipelinesVarMutex.Unlock()
+	fake.SetPipelinesVarStub = nil
+	if fake.setPipelinesVarReturnsOnCall == nil {
+		fake.setPipelinesVarReturnsOnCall = make(map[int]struct {
+			result1 map[int]bool
+			result2 error
+		})
+	}
+	fake.setPipelinesVarReturnsOnCall[i] = struct {
+		result1 map[int]bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SharedArtifacts() ([]db.SharedArtifact, error) {
+	fake.sharedArtifactsMutex.Lock()
+	ret, specificReturn := fake.sharedArtifactsReturnsOnCall[len(fake.sharedArtifactsArgsForCall)]
+	fake.sharedArtifactsArgsForCall = append(fake.sharedArtifactsArgsForCall, struct {
+	}{})
+	stub := fake.SharedArtifactsStub
+	fakeReturns := fake.sharedArtifactsReturns
+	fake.recordInvocation("SharedArtifacts", []interface{}{})
+	fake.sharedArtifactsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SharedArtifactsCallCount() int {
+	fake.sharedArtifactsMutex.RLock()
+	defer fake.sharedArtifactsMutex.RUnlock()
+	return len(fake.sharedArtifactsArgsForCall)
+}
+
+func (fake *FakeTeam) SharedArtifactsCalls(stub func() ([]db.SharedArtifact, error)) {
+	fake.sharedArtifactsMutex.Lock()
+	defer fake.sharedArtifactsMutex.Unlock()
+	fake.SharedArtifactsStub = stub
+}
+
+func (fake *FakeTeam) SharedArtifactsReturns(result1 []db.SharedArtifact, result2 error) {
+	fake.sharedArtifactsMutex.Lock()
+	defer fake.sharedArtifactsMutex.Unlock()
+	fake.SharedArtifactsStub = nil
+	fake.sharedArtifactsReturns = struct {
+		result1 []db.SharedArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SharedArtifactsReturnsOnCall(i int, result1 []db.SharedArtifact, result2 error) {
+	fake.sharedArtifactsMutex.Lock()
+	defer fake.sharedArtifactsMutex.Unlock()
+	fake.SharedArtifactsStub = nil
+	if fake.sharedArtifactsReturnsOnCall == nil {
+		fake.sharedArtifactsReturnsOnCall = make(map[int]struct {
+			result1 []db.SharedArtifact
+			result2 error
+		})
+	}
+	fake.sharedArtifactsReturnsOnCall[i] = struct {
+		result1 []db.SharedArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UpdateMaxConcurrentBuilds(arg1 int) error {
+	fake.updateMaxConcurrentBuildsMutex.Lock()
+	ret, specificReturn := fake.updateMaxConcurrentBuildsReturnsOnCall[len(fake.updateMaxConcurrentBuildsArgsForCall)]
+	fake.updateMaxConcurrentBuildsArgsForCall = append(fake.updateMaxConcurrentBuildsArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.UpdateMaxConcurrentBuildsStub
+	fakeReturns := fake.updateMaxConcurrentBuildsReturns
+	fake.recordInvocation("UpdateMaxConcurrentBuilds", []interface{}{arg1})
+	fake.updateMaxConcurrentBuildsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateMaxConcurrentBuildsCallCount() int {
+	fake.updateMaxConcurrentBuildsMutex.RLock()
+	defer fake.updateMaxConcurrentBuildsMutex.RUnlock()
+	return len(fake.updateMaxConcurrentBuildsArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateMaxConcurrentBuildsCalls(stub func(int) error) {
+	fake.updateMaxConcurrentBuildsMutex.Lock()
+	defer fake.updateMaxConcurrentBuildsMutex.Unlock()
+	fake.UpdateMaxConcurrentBuildsStub = stub
+}
+
+func (fake *FakeTeam) UpdateMaxConcurrentBuildsArgsForCall(i int) int {
+	fake.updateMaxConcurrentBuildsMutex.RLock()
+	defer fake.updateMaxConcurrentBuildsMutex.RUnlock()
+	argsForCall := fake.updateMaxConcurrentBuildsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateMaxConcurrentBuildsReturns(result1 error) {
+	fake.updateMaxConcurrentBuildsMutex.Lock()
+	defer fake.updateMaxConcurrentBuildsMutex.Unlock()
+	fake.UpdateMaxConcurrentBuildsStub = nil
+	fake.updateMaxConcurrentBuildsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateMaxConcurrentBuildsReturnsOnCall(i int, result1 error) {
+	fake.updateMaxConcurrentBuildsMutex.Lock()
+	defer fake.updateMaxConcurrentBuildsMutex.Unlock()
+	fake.UpdateMaxConcurrentBuildsStub = nil
+	if fake.updateMaxConcurrentBuildsReturnsOnCall == nil {
+		fake.updateMaxConcurrentBuildsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateMaxConcurrentBuildsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) UpdateProviderAuth(arg1 atc.TeamAuth) error {
 	fake.updateProviderAuthMutex.Lock()
 	ret, specificReturn := fake.updateProviderAuthReturnsOnCall[len(fake.updateProviderAuthArgsForCall)]
@@ -2459,6 +3203,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.findContainersByMetadataMutex.RUnlock()
 	fake.findCreatedContainerByHandleMutex.RLock()
 	defer fake.findCreatedContainerByHandleMutex.RUnlock()
+	fake.findSharedArtifactMutex.RLock()
+	defer fake.findSharedArtifactMutex.RUnlock()
 	fake.findVolumeForWorkerArtifactMutex.RLock()
 	defer fake.findVolumeForWorkerArtifactMutex.RUnlock()
 	fake.findWorkerForContainerMutex.RLock()
@@ -2467,12 +3213,16 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.findWorkerForVolumeMutex.RUnlock()
 	fake.findWorkersForResourceCacheMutex.RLock()
 	defer fake.findWorkersForResourceCacheMutex.RUnlock()
+	fake.grantSharedArtifactMutex.RLock()
+	defer fake.grantSharedArtifactMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
 	fake.isCheckContainerMutex.RLock()
 	defer fake.isCheckContainerMutex.RUnlock()
 	fake.isContainerWithinTeamMutex.RLock()
 	defer fake.isContainerWithinTeamMutex.RUnlock()
+	fake.maxConcurrentBuildsMutex.RLock()
+	defer fake.maxConcurrentBuildsMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.orderPipelinesMutex.RLock()
@@ -2487,14 +3237,28 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.privateAndPublicBuildsMutex.RUnlock()
 	fake.publicPipelinesMutex.RLock()
 	defer fake.publicPipelinesMutex.RUnlock()
+	fake.publishSharedArtifactMutex.RLock()
+	defer fake.publishSharedArtifactMutex.RUnlock()
 	fake.renameMutex.RLock()
 	defer fake.renameMutex.RUnlock()
 	fake.renamePipelineMutex.RLock()
 	defer fake.renamePipelineMutex.RUnlock()
+	fake.resourceTypesMutex.RLock()
+	defer fake.resourceTypesMutex.RUnlock()
+	fake.revokeSharedArtifactMutex.RLock()
+	defer fake.revokeSharedArtifactMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
+	fake.saveResourceTypesMutex.RLock()
+	defer fake.saveResourceTypesMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
+	fake.setPipelinesVarMutex.RLock()
+	defer fake.setPipelinesVarMutex.RUnlock()
+	fake.sharedArtifactsMutex.RLock()
+	defer fake.sharedArtifactsMutex.RUnlock()
+	fake.updateMaxConcurrentBuildsMutex.RLock()
+	defer fake.updateMaxConcurrentBuildsMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
 	fake.workersMutex.RLock()
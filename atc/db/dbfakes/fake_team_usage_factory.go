@@ -0,0 +1,256 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeTeamUsageFactory struct {
+	AllTeamUsageStub        func() ([]db.TeamUsage, error)
+	allTeamUsageMutex       sync.RWMutex
+	allTeamUsageArgsForCall []struct {
+	}
+	allTeamUsageReturns struct {
+		result1 []db.TeamUsage
+		result2 error
+	}
+	allTeamUsageReturnsOnCall map[int]struct {
+		result1 []db.TeamUsage
+		result2 error
+	}
+	RefreshUsageStub        func() error
+	refreshUsageMutex       sync.RWMutex
+	refreshUsageArgsForCall []struct {
+	}
+	refreshUsageReturns struct {
+		result1 error
+	}
+	refreshUsageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	TeamUsageStub        func([]string) ([]db.TeamUsage, error)
+	teamUsageMutex       sync.RWMutex
+	teamUsageArgsForCall []struct {
+		arg1 []string
+	}
+	teamUsageReturns struct {
+		result1 []db.TeamUsage
+		result2 error
+	}
+	teamUsageReturnsOnCall map[int]struct {
+		result1 []db.TeamUsage
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTeamUsageFactory) AllTeamUsage() ([]db.TeamUsage, error) {
+	fake.allTeamUsageMutex.Lock()
+	ret, specificReturn := fake.allTeamUsageReturnsOnCall[len(fake.allTeamUsageArgsForCall)]
+	fake.allTeamUsageArgsForCall = append(fake.allTeamUsageArgsForCall, struct {
+	}{})
+	stub := fake.AllTeamUsageStub
+	fakeReturns := fake.allTeamUsageReturns
+	fake.recordInvocation("AllTeamUsage", []interface{}{})
+	fake.allTeamUsageMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeamUsageFactory) AllTeamUsageCallCount() int {
+	fake.allTeamUsageMutex.RLock()
+	defer fake.allTeamUsageMutex.RUnlock()
+	return len(fake.allTeamUsageArgsForCall)
+}
+
+func (fake *FakeTeamUsageFactory) AllTeamUsageCalls(stub func() ([]db.TeamUsage, error)) {
+	fake.allTeamUsageMutex.Lock()
+	defer fake.allTeamUsageMutex.Unlock()
+	fake.AllTeamUsageStub = stub
+}
+
+func (fake *FakeTeamUsageFactory) AllTeamUsageReturns(result1 []db.TeamUsage, result2 error) {
+	fake.allTeamUsageMutex.Lock()
+	defer fake.allTeamUsageMutex.Unlock()
+	fake.AllTeamUsageStub = nil
+	fake.allTeamUsageReturns = struct {
+		result1 []db.TeamUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamUsageFactory) AllTeamUsageReturnsOnCall(i int, result1 []db.TeamUsage, result2 error) {
+	fake.allTeamUsageMutex.Lock()
+	defer fake.allTeamUsageMutex.Unlock()
+	fake.AllTeamUsageStub = nil
+	if fake.allTeamUsageReturnsOnCall == nil {
+		fake.allTeamUsageReturnsOnCall = make(map[int]struct {
+			result1 []db.TeamUsage
+			result2 error
+		})
+	}
+	fake.allTeamUsageReturnsOnCall[i] = struct {
+		result1 []db.TeamUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamUsageFactory) RefreshUsage() error {
+	fake.refreshUsageMutex.Lock()
+	ret, specificReturn := fake.refreshUsageReturnsOnCall[len(fake.refreshUsageArgsForCall)]
+	fake.refreshUsageArgsForCall = append(fake.refreshUsageArgsForCall, struct {
+	}{})
+	stub := fake.RefreshUsageStub
+	fakeReturns := fake.refreshUsageReturns
+	fake.recordInvocation("RefreshUsage", []interface{}{})
+	fake.refreshUsageMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeamUsageFactory) RefreshUsageCallCount() int {
+	fake.refreshUsageMutex.RLock()
+	defer fake.refreshUsageMutex.RUnlock()
+	return len(fake.refreshUsageArgsForCall)
+}
+
+func (fake *FakeTeamUsageFactory) RefreshUsageCalls(stub func() error) {
+	fake.refreshUsageMutex.Lock()
+	defer fake.refreshUsageMutex.Unlock()
+	fake.RefreshUsageStub = stub
+}
+
+func (fake *FakeTeamUsageFactory) RefreshUsageReturns(result1 error) {
+	fake.refreshUsageMutex.Lock()
+	defer fake.refreshUsageMutex.Unlock()
+	fake.RefreshUsageStub = nil
+	fake.refreshUsageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeamUsageFactory) RefreshUsageReturnsOnCall(i int, result1 error) {
+	fake.refreshUsageMutex.Lock()
+	defer fake.refreshUsageMutex.Unlock()
+	fake.RefreshUsageStub = nil
+	if fake.refreshUsageReturnsOnCall == nil {
+		fake.refreshUsageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.refreshUsageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeamUsageFactory) TeamUsage(arg1 []string) ([]db.TeamUsage, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.teamUsageMutex.Lock()
+	ret, specificReturn := fake.teamUsageReturnsOnCall[len(fake.teamUsageArgsForCall)]
+	fake.teamUsageArgsForCall = append(fake.teamUsageArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.TeamUsageStub
+	fakeReturns := fake.teamUsageReturns
+	fake.recordInvocation("TeamUsage", []interface{}{arg1Copy})
+	fake.teamUsageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeamUsageFactory) TeamUsageCallCount() int {
+	fake.teamUsageMutex.RLock()
+	defer fake.teamUsageMutex.RUnlock()
+	return len(fake.teamUsageArgsForCall)
+}
+
+func (fake *FakeTeamUsageFactory) TeamUsageCalls(stub func([]string) ([]db.TeamUsage, error)) {
+	fake.teamUsageMutex.Lock()
+	defer fake.teamUsageMutex.Unlock()
+	fake.TeamUsageStub = stub
+}
+
+func (fake *FakeTeamUsageFactory) TeamUsageArgsForCall(i int) []string {
+	fake.teamUsageMutex.RLock()
+	defer fake.teamUsageMutex.RUnlock()
+	argsForCall := fake.teamUsageArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeamUsageFactory) TeamUsageReturns(result1 []db.TeamUsage, result2 error) {
+	fake.teamUsageMutex.Lock()
+	defer fake.teamUsageMutex.Unlock()
+	fake.TeamUsageStub = nil
+	fake.teamUsageReturns = struct {
+		result1 []db.TeamUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamUsageFactory) TeamUsageReturnsOnCall(i int, result1 []db.TeamUsage, result2 error) {
+	fake.teamUsageMutex.Lock()
+	defer fake.teamUsageMutex.Unlock()
+	fake.TeamUsageStub = nil
+	if fake.teamUsageReturnsOnCall == nil {
+		fake.teamUsageReturnsOnCall = make(map[int]struct {
+			result1 []db.TeamUsage
+			result2 error
+		})
+	}
+	fake.teamUsageReturnsOnCall[i] = struct {
+		result1 []db.TeamUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamUsageFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.allTeamUsageMutex.RLock()
+	defer fake.allTeamUsageMutex.RUnlock()
+	fake.refreshUsageMutex.RLock()
+	defer fake.refreshUsageMutex.RUnlock()
+	fake.teamUsageMutex.RLock()
+	defer fake.teamUsageMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeTeamUsageFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.TeamUsageFactory = new(FakeTeamUsageFactory)
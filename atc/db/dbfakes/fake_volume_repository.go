@@ -9,6 +9,18 @@ import (
 )
 
 type FakeVolumeRepository struct {
+	CountDestroyingVolumesByWorkerStub        func() (map[string]int, error)
+	countDestroyingVolumesByWorkerMutex       sync.RWMutex
+	countDestroyingVolumesByWorkerArgsForCall []struct {
+	}
+	countDestroyingVolumesByWorkerReturns struct {
+		result1 map[string]int
+		result2 error
+	}
+	countDestroyingVolumesByWorkerReturnsOnCall map[int]struct {
+		result1 map[string]int
+		result2 error
+	}
 	CreateBaseResourceTypeVolumeStub        func(*db.UsedWorkerBaseResourceType) (db.CreatingVolume, error)
 	createBaseResourceTypeVolumeMutex       sync.RWMutex
 	createBaseResourceTypeVolumeArgsForCall []struct {
@@ -230,6 +242,20 @@ type FakeVolumeRepository struct {
 		result1 []string
 		result2 error
 	}
+	GetEvictableResourceCacheVolumesStub        func(string, int) ([]db.CreatedVolume, error)
+	getEvictableResourceCacheVolumesMutex       sync.RWMutex
+	getEvictableResourceCacheVolumesArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	getEvictableResourceCacheVolumesReturns struct {
+		result1 []db.CreatedVolume
+		result2 error
+	}
+	getEvictableResourceCacheVolumesReturnsOnCall map[int]struct {
+		result1 []db.CreatedVolume
+		result2 error
+	}
 	GetOrphanedVolumesStub        func() ([]db.CreatedVolume, error)
 	getOrphanedVolumesMutex       sync.RWMutex
 	getOrphanedVolumesArgsForCall []struct {
@@ -282,6 +308,18 @@ type FakeVolumeRepository struct {
 		result1 int
 		result2 error
 	}
+	UpdateVolumeSizesStub        func(string, map[string]uint64) error
+	updateVolumeSizesMutex       sync.RWMutex
+	updateVolumeSizesArgsForCall []struct {
+		arg1 string
+		arg2 map[string]uint64
+	}
+	updateVolumeSizesReturns struct {
+		result1 error
+	}
+	updateVolumeSizesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateVolumesMissingSinceStub        func(string, []string) error
 	updateVolumesMissingSinceMutex       sync.RWMutex
 	updateVolumesMissingSinceArgsForCall []struct {
@@ -298,6 +336,62 @@ type FakeVolumeRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeVolumeRepository) CountDestroyingVolumesByWorker() (map[string]int, error) {
+	fake.countDestroyingVolumesByWorkerMutex.Lock()
+	ret, specificReturn := fake.countDestroyingVolumesByWorkerReturnsOnCall[len(fake.countDestroyingVolumesByWorkerArgsForCall)]
+	fake.countDestroyingVolumesByWorkerArgsForCall = append(fake.countDestroyingVolumesByWorkerArgsForCall, struct {
+	}{})
+	stub := fake.CountDestroyingVolumesByWorkerStub
+	fakeReturns := fake.countDestroyingVolumesByWorkerReturns
+	fake.recordInvocation("CountDestroyingVolumesByWorker", []interface{}{})
+	fake.countDestroyingVolumesByWorkerMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolumeRepository) CountDestroyingVolumesByWorkerCallCount() int {
+	fake.countDestroyingVolumesByWorkerMutex.RLock()
+	defer fake.countDestroyingVolumesByWorkerMutex.RUnlock()
+	return len(fake.countDestroyingVolumesByWorkerArgsForCall)
+}
+
+func (fake *FakeVolumeRepository) CountDestroyingVolumesByWorkerCalls(stub func() (map[string]int, error)) {
+	fake.countDestroyingVolumesByWorkerMutex.Lock()
+	defer fake.countDestroyingVolumesByWorkerMutex.Unlock()
+	fake.CountDestroyingVolumesByWorkerStub = stub
+}
+
+func (fake *FakeVolumeRepository) CountDestroyingVolumesByWorkerReturns(result1 map[string]int, result2 error) {
+	fake.countDestroyingVolumesByWorkerMutex.Lock()
+	defer fake.countDestroyingVolumesByWorkerMutex.Unlock()
+	fake.CountDestroyingVolumesByWorkerStub = nil
+	fake.countDestroyingVolumesByWorkerReturns = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeRepository) CountDestroyingVolumesByWorkerReturnsOnCall(i int, result1 map[string]int, result2 error) {
+	fake.countDestroyingVolumesByWorkerMutex.Lock()
+	defer fake.countDestroyingVolumesByWorkerMutex.Unlock()
+	fake.CountDestroyingVolumesByWorkerStub = nil
+	if fake.countDestroyingVolumesByWorkerReturnsOnCall == nil {
+		fake.countDestroyingVolumesByWorkerReturnsOnCall = make(map[int]struct {
+			result1 map[string]int
+			result2 error
+		})
+	}
+	fake.countDestroyingVolumesByWorkerReturnsOnCall[i] = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeRepository) CreateBaseResourceTypeVolume(arg1 *db.UsedWorkerBaseResourceType) (db.CreatingVolume, error) {
 	fake.createBaseResourceTypeVolumeMutex.Lock()
 	ret, specificReturn := fake.createBaseResourceTypeVolumeReturnsOnCall[len(fake.createBaseResourceTypeVolumeArgsForCall)]
@@ -1288,6 +1382,71 @@ func (fake *FakeVolumeRepository) GetDestroyingVolumesReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
+func (fake *FakeVolumeRepository) GetEvictableResourceCacheVolumes(arg1 string, arg2 int) ([]db.CreatedVolume, error) {
+	fake.getEvictableResourceCacheVolumesMutex.Lock()
+	ret, specificReturn := fake.getEvictableResourceCacheVolumesReturnsOnCall[len(fake.getEvictableResourceCacheVolumesArgsForCall)]
+	fake.getEvictableResourceCacheVolumesArgsForCall = append(fake.getEvictableResourceCacheVolumesArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.GetEvictableResourceCacheVolumesStub
+	fakeReturns := fake.getEvictableResourceCacheVolumesReturns
+	fake.recordInvocation("GetEvictableResourceCacheVolumes", []interface{}{arg1, arg2})
+	fake.getEvictableResourceCacheVolumesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolumeRepository) GetEvictableResourceCacheVolumesCallCount() int {
+	fake.getEvictableResourceCacheVolumesMutex.RLock()
+	defer fake.getEvictableResourceCacheVolumesMutex.RUnlock()
+	return len(fake.getEvictableResourceCacheVolumesArgsForCall)
+}
+
+func (fake *FakeVolumeRepository) GetEvictableResourceCacheVolumesCalls(stub func(string, int) ([]db.CreatedVolume, error)) {
+	fake.getEvictableResourceCacheVolumesMutex.Lock()
+	defer fake.getEvictableResourceCacheVolumesMutex.Unlock()
+	fake.GetEvictableResourceCacheVolumesStub = stub
+}
+
+func (fake *FakeVolumeRepository) GetEvictableResourceCacheVolumesArgsForCall(i int) (string, int) {
+	fake.getEvictableResourceCacheVolumesMutex.RLock()
+	defer fake.getEvictableResourceCacheVolumesMutex.RUnlock()
+	argsForCall := fake.getEvictableResourceCacheVolumesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeVolumeRepository) GetEvictableResourceCacheVolumesReturns(result1 []db.CreatedVolume, result2 error) {
+	fake.getEvictableResourceCacheVolumesMutex.Lock()
+	defer fake.getEvictableResourceCacheVolumesMutex.Unlock()
+	fake.GetEvictableResourceCacheVolumesStub = nil
+	fake.getEvictableResourceCacheVolumesReturns = struct {
+		result1 []db.CreatedVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeRepository) GetEvictableResourceCacheVolumesReturnsOnCall(i int, result1 []db.CreatedVolume, result2 error) {
+	fake.getEvictableResourceCacheVolumesMutex.Lock()
+	defer fake.getEvictableResourceCacheVolumesMutex.Unlock()
+	fake.GetEvictableResourceCacheVolumesStub = nil
+	if fake.getEvictableResourceCacheVolumesReturnsOnCall == nil {
+		fake.getEvictableResourceCacheVolumesReturnsOnCall = make(map[int]struct {
+			result1 []db.CreatedVolume
+			result2 error
+		})
+	}
+	fake.getEvictableResourceCacheVolumesReturnsOnCall[i] = struct {
+		result1 []db.CreatedVolume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeRepository) GetOrphanedVolumes() ([]db.CreatedVolume, error) {
 	fake.getOrphanedVolumesMutex.Lock()
 	ret, specificReturn := fake.getOrphanedVolumesReturnsOnCall[len(fake.getOrphanedVolumesArgsForCall)]
@@ -1542,6 +1701,68 @@ func (fake *FakeVolumeRepository) RemoveMissingVolumesReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
+func (fake *FakeVolumeRepository) UpdateVolumeSizes(arg1 string, arg2 map[string]uint64) error {
+	fake.updateVolumeSizesMutex.Lock()
+	ret, specificReturn := fake.updateVolumeSizesReturnsOnCall[len(fake.updateVolumeSizesArgsForCall)]
+	fake.updateVolumeSizesArgsForCall = append(fake.updateVolumeSizesArgsForCall, struct {
+		arg1 string
+		arg2 map[string]uint64
+	}{arg1, arg2})
+	stub := fake.UpdateVolumeSizesStub
+	fakeReturns := fake.updateVolumeSizesReturns
+	fake.recordInvocation("UpdateVolumeSizes", []interface{}{arg1, arg2})
+	fake.updateVolumeSizesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeVolumeRepository) UpdateVolumeSizesCallCount() int {
+	fake.updateVolumeSizesMutex.RLock()
+	defer fake.updateVolumeSizesMutex.RUnlock()
+	return len(fake.updateVolumeSizesArgsForCall)
+}
+
+func (fake *FakeVolumeRepository) UpdateVolumeSizesCalls(stub func(string, map[string]uint64) error) {
+	fake.updateVolumeSizesMutex.Lock()
+	defer fake.updateVolumeSizesMutex.Unlock()
+	fake.UpdateVolumeSizesStub = stub
+}
+
+func (fake *FakeVolumeRepository) UpdateVolumeSizesArgsForCall(i int) (string, map[string]uint64) {
+	fake.updateVolumeSizesMutex.RLock()
+	defer fake.updateVolumeSizesMutex.RUnlock()
+	argsForCall := fake.updateVolumeSizesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeVolumeRepository) UpdateVolumeSizesReturns(result1 error) {
+	fake.updateVolumeSizesMutex.Lock()
+	defer fake.updateVolumeSizesMutex.Unlock()
+	fake.UpdateVolumeSizesStub = nil
+	fake.updateVolumeSizesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolumeRepository) UpdateVolumeSizesReturnsOnCall(i int, result1 error) {
+	fake.updateVolumeSizesMutex.Lock()
+	defer fake.updateVolumeSizesMutex.Unlock()
+	fake.UpdateVolumeSizesStub = nil
+	if fake.updateVolumeSizesReturnsOnCall == nil {
+		fake.updateVolumeSizesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateVolumeSizesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeVolumeRepository) UpdateVolumesMissingSince(arg1 string, arg2 []string) error {
 	var arg2Copy []string
 	if arg2 != nil {
@@ -1612,6 +1833,8 @@ func (fake *FakeVolumeRepository) UpdateVolumesMissingSinceReturnsOnCall(i int,
 func (fake *FakeVolumeRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.countDestroyingVolumesByWorkerMutex.RLock()
+	defer fake.countDestroyingVolumesByWorkerMutex.RUnlock()
 	fake.createBaseResourceTypeVolumeMutex.RLock()
 	defer fake.createBaseResourceTypeVolumeMutex.RUnlock()
 	fake.createContainerVolumeMutex.RLock()
@@ -1642,6 +1865,8 @@ func (fake *FakeVolumeRepository) Invocations() map[string][][]interface{} {
 	defer fake.findVolumesForContainerMutex.RUnlock()
 	fake.getDestroyingVolumesMutex.RLock()
 	defer fake.getDestroyingVolumesMutex.RUnlock()
+	fake.getEvictableResourceCacheVolumesMutex.RLock()
+	defer fake.getEvictableResourceCacheVolumesMutex.RUnlock()
 	fake.getOrphanedVolumesMutex.RLock()
 	defer fake.getOrphanedVolumesMutex.RUnlock()
 	fake.getTeamVolumesMutex.RLock()
@@ -1650,6 +1875,8 @@ func (fake *FakeVolumeRepository) Invocations() map[string][][]interface{} {
 	defer fake.removeDestroyingVolumesMutex.RUnlock()
 	fake.removeMissingVolumesMutex.RLock()
 	defer fake.removeMissingVolumesMutex.RUnlock()
+	fake.updateVolumeSizesMutex.RLock()
+	defer fake.updateVolumeSizesMutex.RUnlock()
 	fake.updateVolumesMissingSinceMutex.RLock()
 	defer fake.updateVolumesMissingSinceMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
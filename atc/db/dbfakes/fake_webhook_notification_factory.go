@@ -0,0 +1,345 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeWebhookNotificationFactory struct {
+	DeadStub        func(int, string) error
+	deadMutex       sync.RWMutex
+	deadArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	deadReturns struct {
+		result1 error
+	}
+	deadReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeliveredStub        func(int) error
+	deliveredMutex       sync.RWMutex
+	deliveredArgsForCall []struct {
+		arg1 int
+	}
+	deliveredReturns struct {
+		result1 error
+	}
+	deliveredReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DueNotificationsStub        func(int) ([]db.WebhookNotification, error)
+	dueNotificationsMutex       sync.RWMutex
+	dueNotificationsArgsForCall []struct {
+		arg1 int
+	}
+	dueNotificationsReturns struct {
+		result1 []db.WebhookNotification
+		result2 error
+	}
+	dueNotificationsReturnsOnCall map[int]struct {
+		result1 []db.WebhookNotification
+		result2 error
+	}
+	RetryStub        func(int, time.Time, string) error
+	retryMutex       sync.RWMutex
+	retryArgsForCall []struct {
+		arg1 int
+		arg2 time.Time
+		arg3 string
+	}
+	retryReturns struct {
+		result1 error
+	}
+	retryReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWebhookNotificationFactory) Dead(arg1 int, arg2 string) error {
+	fake.deadMutex.Lock()
+	ret, specificReturn := fake.deadReturnsOnCall[len(fake.deadArgsForCall)]
+	fake.deadArgsForCall = append(fake.deadArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeadStub
+	fakeReturns := fake.deadReturns
+	fake.recordInvocation("Dead", []interface{}{arg1, arg2})
+	fake.deadMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWebhookNotificationFactory) DeadCallCount() int {
+	fake.deadMutex.RLock()
+	defer fake.deadMutex.RUnlock()
+	return len(fake.deadArgsForCall)
+}
+
+func (fake *FakeWebhookNotificationFactory) DeadCalls(stub func(int, string) error) {
+	fake.deadMutex.Lock()
+	defer fake.deadMutex.Unlock()
+	fake.DeadStub = stub
+}
+
+func (fake *FakeWebhookNotificationFactory) DeadArgsForCall(i int) (int, string) {
+	fake.deadMutex.RLock()
+	defer fake.deadMutex.RUnlock()
+	argsForCall := fake.deadArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWebhookNotificationFactory) DeadReturns(result1 error) {
+	fake.deadMutex.Lock()
+	defer fake.deadMutex.Unlock()
+	fake.DeadStub = nil
+	fake.deadReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookNotificationFactory) DeadReturnsOnCall(i int, result1 error) {
+	fake.deadMutex.Lock()
+	defer fake.deadMutex.Unlock()
+	fake.DeadStub = nil
+	if fake.deadReturnsOnCall == nil {
+		fake.deadReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deadReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookNotificationFactory) Delivered(arg1 int) error {
+	fake.deliveredMutex.Lock()
+	ret, specificReturn := fake.deliveredReturnsOnCall[len(fake.deliveredArgsForCall)]
+	fake.deliveredArgsForCall = append(fake.deliveredArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.DeliveredStub
+	fakeReturns := fake.deliveredReturns
+	fake.recordInvocation("Delivered", []interface{}{arg1})
+	fake.deliveredMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWebhookNotificationFactory) DeliveredCallCount() int {
+	fake.deliveredMutex.RLock()
+	defer fake.deliveredMutex.RUnlock()
+	return len(fake.deliveredArgsForCall)
+}
+
+func (fake *FakeWebhookNotificationFactory) DeliveredCalls(stub func(int) error) {
+	fake.deliveredMutex.Lock()
+	defer fake.deliveredMutex.Unlock()
+	fake.DeliveredStub = stub
+}
+
+func (fake *FakeWebhookNotificationFactory) DeliveredArgsForCall(i int) int {
+	fake.deliveredMutex.RLock()
+	defer fake.deliveredMutex.RUnlock()
+	argsForCall := fake.deliveredArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWebhookNotificationFactory) DeliveredReturns(result1 error) {
+	fake.deliveredMutex.Lock()
+	defer fake.deliveredMutex.Unlock()
+	fake.DeliveredStub = nil
+	fake.deliveredReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookNotificationFactory) DeliveredReturnsOnCall(i int, result1 error) {
+	fake.deliveredMutex.Lock()
+	defer fake.deliveredMutex.Unlock()
+	fake.DeliveredStub = nil
+	if fake.deliveredReturnsOnCall == nil {
+		fake.deliveredReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deliveredReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookNotificationFactory) DueNotifications(arg1 int) ([]db.WebhookNotification, error) {
+	fake.dueNotificationsMutex.Lock()
+	ret, specificReturn := fake.dueNotificationsReturnsOnCall[len(fake.dueNotificationsArgsForCall)]
+	fake.dueNotificationsArgsForCall = append(fake.dueNotificationsArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.DueNotificationsStub
+	fakeReturns := fake.dueNotificationsReturns
+	fake.recordInvocation("DueNotifications", []interface{}{arg1})
+	fake.dueNotificationsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWebhookNotificationFactory) DueNotificationsCallCount() int {
+	fake.dueNotificationsMutex.RLock()
+	defer fake.dueNotificationsMutex.RUnlock()
+	return len(fake.dueNotificationsArgsForCall)
+}
+
+func (fake *FakeWebhookNotificationFactory) DueNotificationsCalls(stub func(int) ([]db.WebhookNotification, error)) {
+	fake.dueNotificationsMutex.Lock()
+	defer fake.dueNotificationsMutex.Unlock()
+	fake.DueNotificationsStub = stub
+}
+
+func (fake *FakeWebhookNotificationFactory) DueNotificationsArgsForCall(i int) int {
+	fake.dueNotificationsMutex.RLock()
+	defer fake.dueNotificationsMutex.RUnlock()
+	argsForCall := fake.dueNotificationsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWebhookNotificationFactory) DueNotificationsReturns(result1 []db.WebhookNotification, result2 error) {
+	fake.dueNotificationsMutex.Lock()
+	defer fake.dueNotificationsMutex.Unlock()
+	fake.DueNotificationsStub = nil
+	fake.dueNotificationsReturns = struct {
+		result1 []db.WebhookNotification
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookNotificationFactory) DueNotificationsReturnsOnCall(i int, result1 []db.WebhookNotification, result2 error) {
+	fake.dueNotificationsMutex.Lock()
+	defer fake.dueNotificationsMutex.Unlock()
+	fake.DueNotificationsStub = nil
+	if fake.dueNotificationsReturnsOnCall == nil {
+		fake.dueNotificationsReturnsOnCall = make(map[int]struct {
+			result1 []db.WebhookNotification
+			result2 error
+		})
+	}
+	fake.dueNotificationsReturnsOnCall[i] = struct {
+		result1 []db.WebhookNotification
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookNotificationFactory) Retry(arg1 int, arg2 time.Time, arg3 string) error {
+	fake.retryMutex.Lock()
+	ret, specificReturn := fake.retryReturnsOnCall[len(fake.retryArgsForCall)]
+	fake.retryArgsForCall = append(fake.retryArgsForCall, struct {
+		arg1 int
+		arg2 time.Time
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.RetryStub
+	fakeReturns := fake.retryReturns
+	fake.recordInvocation("Retry", []interface{}{arg1, arg2, arg3})
+	fake.retryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWebhookNotificationFactory) RetryCallCount() int {
+	fake.retryMutex.RLock()
+	defer fake.retryMutex.RUnlock()
+	return len(fake.retryArgsForCall)
+}
+
+func (fake *FakeWebhookNotificationFactory) RetryCalls(stub func(int, time.Time, string) error) {
+	fake.retryMutex.Lock()
+	defer fake.retryMutex.Unlock()
+	fake.RetryStub = stub
+}
+
+func (fake *FakeWebhookNotificationFactory) RetryArgsForCall(i int) (int, time.Time, string) {
+	fake.retryMutex.RLock()
+	defer fake.retryMutex.RUnlock()
+	argsForCall := fake.retryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeWebhookNotificationFactory) RetryReturns(result1 error) {
+	fake.retryMutex.Lock()
+	defer fake.retryMutex.Unlock()
+	fake.RetryStub = nil
+	fake.retryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookNotificationFactory) RetryReturnsOnCall(i int, result1 error) {
+	fake.retryMutex.Lock()
+	defer fake.retryMutex.Unlock()
+	fake.RetryStub = nil
+	if fake.retryReturnsOnCall == nil {
+		fake.retryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.retryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookNotificationFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deadMutex.RLock()
+	defer fake.deadMutex.RUnlock()
+	fake.deliveredMutex.RLock()
+	defer fake.deliveredMutex.RUnlock()
+	fake.dueNotificationsMutex.RLock()
+	defer fake.dueNotificationsMutex.RUnlock()
+	fake.retryMutex.RLock()
+	defer fake.retryMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeWebhookNotificationFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.WebhookNotificationFactory = new(FakeWebhookNotificationFactory)
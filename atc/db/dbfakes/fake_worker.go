@@ -98,6 +98,28 @@ type FakeWorker struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DiskPressureStub        func() bool
+	diskPressureMutex       sync.RWMutex
+	diskPressureArgsForCall []struct {
+	}
+	diskPressureReturns struct {
+		result1 bool
+	}
+	diskPressureReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	DiskUsageStub        func() (atc.WorkerDiskUsage, bool)
+	diskUsageMutex       sync.RWMutex
+	diskUsageArgsForCall []struct {
+	}
+	diskUsageReturns struct {
+		result1 atc.WorkerDiskUsage
+		result2 bool
+	}
+	diskUsageReturnsOnCall map[int]struct {
+		result1 atc.WorkerDiskUsage
+		result2 bool
+	}
 	EphemeralStub        func() bool
 	ephemeralMutex       sync.RWMutex
 	ephemeralArgsForCall []struct {
@@ -237,6 +259,17 @@ type FakeWorker struct {
 		result1 bool
 		result2 error
 	}
+	ReportDiskUsageStub        func(atc.WorkerDiskUsage) error
+	reportDiskUsageMutex       sync.RWMutex
+	reportDiskUsageArgsForCall []struct {
+		arg1 atc.WorkerDiskUsage
+	}
+	reportDiskUsageReturns struct {
+		result1 error
+	}
+	reportDiskUsageReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ResourceCertsStub        func() (*db.UsedWorkerResourceCerts, bool, error)
 	resourceCertsMutex       sync.RWMutex
 	resourceCertsArgsForCall []struct {
@@ -271,6 +304,27 @@ type FakeWorker struct {
 	retireReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RuntimeStub        func() string
+	runtimeMutex       sync.RWMutex
+	runtimeArgsForCall []struct {
+	}
+	runtimeReturns struct {
+		result1 string
+	}
+	runtimeReturnsOnCall map[int]struct {
+		result1 string
+	}
+	SetDiskPressureStub        func(bool) error
+	setDiskPressureMutex       sync.RWMutex
+	setDiskPressureArgsForCall []struct {
+		arg1 bool
+	}
+	setDiskPressureReturns struct {
+		result1 error
+	}
+	setDiskPressureReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StartTimeStub        func() time.Time
 	startTimeMutex       sync.RWMutex
 	startTimeArgsForCall []struct {
@@ -777,6 +831,115 @@ func (fake *FakeWorker) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) DiskPressure() bool {
+	fake.diskPressureMutex.Lock()
+	ret, specificReturn := fake.diskPressureReturnsOnCall[len(fake.diskPressureArgsForCall)]
+	fake.diskPressureArgsForCall = append(fake.diskPressureArgsForCall, struct {
+	}{})
+	stub := fake.DiskPressureStub
+	fakeReturns := fake.diskPressureReturns
+	fake.recordInvocation("DiskPressure", []interface{}{})
+	fake.diskPressureMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) DiskPressureCallCount() int {
+	fake.diskPressureMutex.RLock()
+	defer fake.diskPressureMutex.RUnlock()
+	return len(fake.diskPressureArgsForCall)
+}
+
+func (fake *FakeWorker) DiskPressureCalls(stub func() bool) {
+	fake.diskPressureMutex.Lock()
+	defer fake.diskPressureMutex.Unlock()
+	fake.DiskPressureStub = stub
+}
+
+func (fake *FakeWorker) DiskPressureReturns(result1 bool) {
+	fake.diskPressureMutex.Lock()
+	defer fake.diskPressureMutex.Unlock()
+	fake.DiskPressureStub = nil
+	fake.diskPressureReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) DiskPressureReturnsOnCall(i int, result1 bool) {
+	fake.diskPressureMutex.Lock()
+	defer fake.diskPressureMutex.Unlock()
+	fake.DiskPressureStub = nil
+	if fake.diskPressureReturnsOnCall == nil {
+		fake.diskPressureReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.diskPressureReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) DiskUsage() (atc.WorkerDiskUsage, bool) {
+	fake.diskUsageMutex.Lock()
+	ret, specificReturn := fake.diskUsageReturnsOnCall[len(fake.diskUsageArgsForCall)]
+	fake.diskUsageArgsForCall = append(fake.diskUsageArgsForCall, struct {
+	}{})
+	stub := fake.DiskUsageStub
+	fakeReturns := fake.diskUsageReturns
+	fake.recordInvocation("DiskUsage", []interface{}{})
+	fake.diskUsageMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) DiskUsageCallCount() int {
+	fake.diskUsageMutex.RLock()
+	defer fake.diskUsageMutex.RUnlock()
+	return len(fake.diskUsageArgsForCall)
+}
+
+func (fake *FakeWorker) DiskUsageCalls(stub func() (atc.WorkerDiskUsage, bool)) {
+	fake.diskUsageMutex.Lock()
+	defer fake.diskUsageMutex.Unlock()
+	fake.DiskUsageStub = stub
+}
+
+func (fake *FakeWorker) DiskUsageReturns(result1 atc.WorkerDiskUsage, result2 bool) {
+	fake.diskUsageMutex.Lock()
+	defer fake.diskUsageMutex.Unlock()
+	fake.DiskUsageStub = nil
+	fake.diskUsageReturns = struct {
+		result1 atc.WorkerDiskUsage
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) DiskUsageReturnsOnCall(i int, result1 atc.WorkerDiskUsage, result2 bool) {
+	fake.diskUsageMutex.Lock()
+	defer fake.diskUsageMutex.Unlock()
+	fake.DiskUsageStub = nil
+	if fake.diskUsageReturnsOnCall == nil {
+		fake.diskUsageReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerDiskUsage
+			result2 bool
+		})
+	}
+	fake.diskUsageReturnsOnCall[i] = struct {
+		result1 atc.WorkerDiskUsage
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeWorker) Ephemeral() bool {
 	fake.ephemeralMutex.Lock()
 	ret, specificReturn := fake.ephemeralReturnsOnCall[len(fake.ephemeralArgsForCall)]
@@ -1486,6 +1649,67 @@ func (fake *FakeWorker) ReloadReturnsOnCall(i int, result1 bool, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeWorker) ReportDiskUsage(arg1 atc.WorkerDiskUsage) error {
+	fake.reportDiskUsageMutex.Lock()
+	ret, specificReturn := fake.reportDiskUsageReturnsOnCall[len(fake.reportDiskUsageArgsForCall)]
+	fake.reportDiskUsageArgsForCall = append(fake.reportDiskUsageArgsForCall, struct {
+		arg1 atc.WorkerDiskUsage
+	}{arg1})
+	stub := fake.ReportDiskUsageStub
+	fakeReturns := fake.reportDiskUsageReturns
+	fake.recordInvocation("ReportDiskUsage", []interface{}{arg1})
+	fake.reportDiskUsageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) ReportDiskUsageCallCount() int {
+	fake.reportDiskUsageMutex.RLock()
+	defer fake.reportDiskUsageMutex.RUnlock()
+	return len(fake.reportDiskUsageArgsForCall)
+}
+
+func (fake *FakeWorker) ReportDiskUsageCalls(stub func(atc.WorkerDiskUsage) error) {
+	fake.reportDiskUsageMutex.Lock()
+	defer fake.reportDiskUsageMutex.Unlock()
+	fake.ReportDiskUsageStub = stub
+}
+
+func (fake *FakeWorker) ReportDiskUsageArgsForCall(i int) atc.WorkerDiskUsage {
+	fake.reportDiskUsageMutex.RLock()
+	defer fake.reportDiskUsageMutex.RUnlock()
+	argsForCall := fake.reportDiskUsageArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorker) ReportDiskUsageReturns(result1 error) {
+	fake.reportDiskUsageMutex.Lock()
+	defer fake.reportDiskUsageMutex.Unlock()
+	fake.ReportDiskUsageStub = nil
+	fake.reportDiskUsageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) ReportDiskUsageReturnsOnCall(i int, result1 error) {
+	fake.reportDiskUsageMutex.Lock()
+	defer fake.reportDiskUsageMutex.Unlock()
+	fake.ReportDiskUsageStub = nil
+	if fake.reportDiskUsageReturnsOnCall == nil {
+		fake.reportDiskUsageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.reportDiskUsageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeWorker) ResourceCerts() (*db.UsedWorkerResourceCerts, bool, error) {
 	fake.resourceCertsMutex.Lock()
 	ret, specificReturn := fake.resourceCertsReturnsOnCall[len(fake.resourceCertsArgsForCall)]
@@ -1651,6 +1875,120 @@ func (fake *FakeWorker) RetireReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Runtime() string {
+	fake.runtimeMutex.Lock()
+	ret, specificReturn := fake.runtimeReturnsOnCall[len(fake.runtimeArgsForCall)]
+	fake.runtimeArgsForCall = append(fake.runtimeArgsForCall, struct {
+	}{})
+	stub := fake.RuntimeStub
+	fakeReturns := fake.runtimeReturns
+	fake.recordInvocation("Runtime", []interface{}{})
+	fake.runtimeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) RuntimeCallCount() int {
+	fake.runtimeMutex.RLock()
+	defer fake.runtimeMutex.RUnlock()
+	return len(fake.runtimeArgsForCall)
+}
+
+func (fake *FakeWorker) RuntimeCalls(stub func() string) {
+	fake.runtimeMutex.Lock()
+	defer fake.runtimeMutex.Unlock()
+	fake.RuntimeStub = stub
+}
+
+func (fake *FakeWorker) RuntimeReturns(result1 string) {
+	fake.runtimeMutex.Lock()
+	defer fake.runtimeMutex.Unlock()
+	fake.RuntimeStub = nil
+	fake.runtimeReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeWorker) RuntimeReturnsOnCall(i int, result1 string) {
+	fake.runtimeMutex.Lock()
+	defer fake.runtimeMutex.Unlock()
+	fake.RuntimeStub = nil
+	if fake.runtimeReturnsOnCall == nil {
+		fake.runtimeReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.runtimeReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeWorker) SetDiskPressure(arg1 bool) error {
+	fake.setDiskPressureMutex.Lock()
+	ret, specificReturn := fake.setDiskPressureReturnsOnCall[len(fake.setDiskPressureArgsForCall)]
+	fake.setDiskPressureArgsForCall = append(fake.setDiskPressureArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	stub := fake.SetDiskPressureStub
+	fakeReturns := fake.setDiskPressureReturns
+	fake.recordInvocation("SetDiskPressure", []interface{}{arg1})
+	fake.setDiskPressureMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) SetDiskPressureCallCount() int {
+	fake.setDiskPressureMutex.RLock()
+	defer fake.setDiskPressureMutex.RUnlock()
+	return len(fake.setDiskPressureArgsForCall)
+}
+
+func (fake *FakeWorker) SetDiskPressureCalls(stub func(bool) error) {
+	fake.setDiskPressureMutex.Lock()
+	defer fake.setDiskPressureMutex.Unlock()
+	fake.SetDiskPressureStub = stub
+}
+
+func (fake *FakeWorker) SetDiskPressureArgsForCall(i int) bool {
+	fake.setDiskPressureMutex.RLock()
+	defer fake.setDiskPressureMutex.RUnlock()
+	argsForCall := fake.setDiskPressureArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorker) SetDiskPressureReturns(result1 error) {
+	fake.setDiskPressureMutex.Lock()
+	defer fake.setDiskPressureMutex.Unlock()
+	fake.SetDiskPressureStub = nil
+	fake.setDiskPressureReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) SetDiskPressureReturnsOnCall(i int, result1 error) {
+	fake.setDiskPressureMutex.Lock()
+	defer fake.setDiskPressureMutex.Unlock()
+	fake.SetDiskPressureStub = nil
+	if fake.setDiskPressureReturnsOnCall == nil {
+		fake.setDiskPressureReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setDiskPressureReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeWorker) StartTime() time.Time {
 	fake.startTimeMutex.Lock()
 	ret, specificReturn := fake.startTimeReturnsOnCall[len(fake.startTimeArgsForCall)]
@@ -1988,6 +2326,10 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.decreaseActiveTasksMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.diskPressureMutex.RLock()
+	defer fake.diskPressureMutex.RUnlock()
+	fake.diskUsageMutex.RLock()
+	defer fake.diskUsageMutex.RUnlock()
 	fake.ephemeralMutex.RLock()
 	defer fake.ephemeralMutex.RUnlock()
 	fake.expiresAtMutex.RLock()
@@ -2014,12 +2356,18 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.pruneMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.reportDiskUsageMutex.RLock()
+	defer fake.reportDiskUsageMutex.RUnlock()
 	fake.resourceCertsMutex.RLock()
 	defer fake.resourceCertsMutex.RUnlock()
 	fake.resourceTypesMutex.RLock()
 	defer fake.resourceTypesMutex.RUnlock()
 	fake.retireMutex.RLock()
 	defer fake.retireMutex.RUnlock()
+	fake.runtimeMutex.RLock()
+	defer fake.runtimeMutex.RUnlock()
+	fake.setDiskPressureMutex.RLock()
+	defer fake.setDiskPressureMutex.RUnlock()
 	fake.startTimeMutex.RLock()
 	defer fake.startTimeMutex.RUnlock()
 	fake.stateMutex.RLock()
@@ -64,6 +64,18 @@ type FakeWorkerFactory struct {
 		result1 db.Worker
 		result2 error
 	}
+	RunningBuildContainersCountPerWorkerStub        func() (map[string]int, error)
+	runningBuildContainersCountPerWorkerMutex       sync.RWMutex
+	runningBuildContainersCountPerWorkerArgsForCall []struct {
+	}
+	runningBuildContainersCountPerWorkerReturns struct {
+		result1 map[string]int
+		result2 error
+	}
+	runningBuildContainersCountPerWorkerReturnsOnCall map[int]struct {
+		result1 map[string]int
+		result2 error
+	}
 	SaveWorkerStub        func(atc.Worker, time.Duration) (db.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -91,6 +103,18 @@ type FakeWorkerFactory struct {
 		result1 []db.Worker
 		result2 error
 	}
+	VolumesCountPerWorkerStub        func() (map[string]int, error)
+	volumesCountPerWorkerMutex       sync.RWMutex
+	volumesCountPerWorkerArgsForCall []struct {
+	}
+	volumesCountPerWorkerReturns struct {
+		result1 map[string]int
+		result2 error
+	}
+	volumesCountPerWorkerReturnsOnCall map[int]struct {
+		result1 map[string]int
+		result2 error
+	}
 	WorkersStub        func() ([]db.Worker, error)
 	workersMutex       sync.RWMutex
 	workersArgsForCall []struct {
@@ -359,6 +383,62 @@ func (fake *FakeWorkerFactory) HeartbeatWorkerReturnsOnCall(i int, result1 db.Wo
 	}{result1, result2}
 }
 
+func (fake *FakeWorkerFactory) RunningBuildContainersCountPerWorker() (map[string]int, error) {
+	fake.runningBuildContainersCountPerWorkerMutex.Lock()
+	ret, specificReturn := fake.runningBuildContainersCountPerWorkerReturnsOnCall[len(fake.runningBuildContainersCountPerWorkerArgsForCall)]
+	fake.runningBuildContainersCountPerWorkerArgsForCall = append(fake.runningBuildContainersCountPerWorkerArgsForCall, struct {
+	}{})
+	stub := fake.RunningBuildContainersCountPerWorkerStub
+	fakeReturns := fake.runningBuildContainersCountPerWorkerReturns
+	fake.recordInvocation("RunningBuildContainersCountPerWorker", []interface{}{})
+	fake.runningBuildContainersCountPerWorkerMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerFactory) RunningBuildContainersCountPerWorkerCallCount() int {
+	fake.runningBuildContainersCountPerWorkerMutex.RLock()
+	defer fake.runningBuildContainersCountPerWorkerMutex.RUnlock()
+	return len(fake.runningBuildContainersCountPerWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerFactory) RunningBuildContainersCountPerWorkerCalls(stub func() (map[string]int, error)) {
+	fake.runningBuildContainersCountPerWorkerMutex.Lock()
+	defer fake.runningBuildContainersCountPerWorkerMutex.Unlock()
+	fake.RunningBuildContainersCountPerWorkerStub = stub
+}
+
+func (fake *FakeWorkerFactory) RunningBuildContainersCountPerWorkerReturns(result1 map[string]int, result2 error) {
+	fake.runningBuildContainersCountPerWorkerMutex.Lock()
+	defer fake.runningBuildContainersCountPerWorkerMutex.Unlock()
+	fake.RunningBuildContainersCountPerWorkerStub = nil
+	fake.runningBuildContainersCountPerWorkerReturns = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerFactory) RunningBuildContainersCountPerWorkerReturnsOnCall(i int, result1 map[string]int, result2 error) {
+	fake.runningBuildContainersCountPerWorkerMutex.Lock()
+	defer fake.runningBuildContainersCountPerWorkerMutex.Unlock()
+	fake.RunningBuildContainersCountPerWorkerStub = nil
+	if fake.runningBuildContainersCountPerWorkerReturnsOnCall == nil {
+		fake.runningBuildContainersCountPerWorkerReturnsOnCall = make(map[int]struct {
+			result1 map[string]int
+			result2 error
+		})
+	}
+	fake.runningBuildContainersCountPerWorkerReturnsOnCall[i] = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorkerFactory) SaveWorker(arg1 atc.Worker, arg2 time.Duration) (db.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -493,6 +573,62 @@ func (fake *FakeWorkerFactory) VisibleWorkersReturnsOnCall(i int, result1 []db.W
 	}{result1, result2}
 }
 
+func (fake *FakeWorkerFactory) VolumesCountPerWorker() (map[string]int, error) {
+	fake.volumesCountPerWorkerMutex.Lock()
+	ret, specificReturn := fake.volumesCountPerWorkerReturnsOnCall[len(fake.volumesCountPerWorkerArgsForCall)]
+	fake.volumesCountPerWorkerArgsForCall = append(fake.volumesCountPerWorkerArgsForCall, struct {
+	}{})
+	stub := fake.VolumesCountPerWorkerStub
+	fakeReturns := fake.volumesCountPerWorkerReturns
+	fake.recordInvocation("VolumesCountPerWorker", []interface{}{})
+	fake.volumesCountPerWorkerMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerFactory) VolumesCountPerWorkerCallCount() int {
+	fake.volumesCountPerWorkerMutex.RLock()
+	defer fake.volumesCountPerWorkerMutex.RUnlock()
+	return len(fake.volumesCountPerWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerFactory) VolumesCountPerWorkerCalls(stub func() (map[string]int, error)) {
+	fake.volumesCountPerWorkerMutex.Lock()
+	defer fake.volumesCountPerWorkerMutex.Unlock()
+	fake.VolumesCountPerWorkerStub = stub
+}
+
+func (fake *FakeWorkerFactory) VolumesCountPerWorkerReturns(result1 map[string]int, result2 error) {
+	fake.volumesCountPerWorkerMutex.Lock()
+	defer fake.volumesCountPerWorkerMutex.Unlock()
+	fake.VolumesCountPerWorkerStub = nil
+	fake.volumesCountPerWorkerReturns = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerFactory) VolumesCountPerWorkerReturnsOnCall(i int, result1 map[string]int, result2 error) {
+	fake.volumesCountPerWorkerMutex.Lock()
+	defer fake.volumesCountPerWorkerMutex.Unlock()
+	fake.VolumesCountPerWorkerStub = nil
+	if fake.volumesCountPerWorkerReturnsOnCall == nil {
+		fake.volumesCountPerWorkerReturnsOnCall = make(map[int]struct {
+			result1 map[string]int
+			result2 error
+		})
+	}
+	fake.volumesCountPerWorkerReturnsOnCall[i] = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorkerFactory) Workers() ([]db.Worker, error) {
 	fake.workersMutex.Lock()
 	ret, specificReturn := fake.workersReturnsOnCall[len(fake.workersArgsForCall)]
@@ -560,10 +696,14 @@ func (fake *FakeWorkerFactory) Invocations() map[string][][]interface{} {
 	defer fake.getWorkerMutex.RUnlock()
 	fake.heartbeatWorkerMutex.RLock()
 	defer fake.heartbeatWorkerMutex.RUnlock()
+	fake.runningBuildContainersCountPerWorkerMutex.RLock()
+	defer fake.runningBuildContainersCountPerWorkerMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
 	fake.visibleWorkersMutex.RLock()
 	defer fake.visibleWorkersMutex.RUnlock()
+	fake.volumesCountPerWorkerMutex.RLock()
+	defer fake.volumesCountPerWorkerMutex.RUnlock()
 	fake.workersMutex.RLock()
 	defer fake.workersMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -0,0 +1,322 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/encryption"
+	"github.com/concourse/concourse/atc/db/migration"
+)
+
+var ErrEncryptionKeyRotationNotConfigured = errors.New("rotating the encryption key requires both an encryption key and an old encryption key")
+
+type EncryptionKeyRotationProgress struct {
+	Table  string
+	Column string
+
+	Rotated    int
+	Finished   bool
+	Error      string
+	UpdateTime time.Time
+}
+
+// EncryptionKeyRotation re-encrypts the encrypted columns from the old
+// encryption key to the new one while the ATC is running. Its progress is
+// recorded after every batch of rows, so that a rotation interrupted by a
+// restart or an error carries on from where it left off.
+//
+//counterfeiter:generate . EncryptionKeyRotation
+type EncryptionKeyRotation interface {
+	// Start requests every encrypted column to be rotated. It returns false
+	// if a rotation is still under way.
+	Start() (bool, error)
+	Progress() ([]EncryptionKeyRotationProgress, error)
+
+	// Rotate re-encrypts the rows of the columns left to rotate, batch by
+	// batch, until none are left or the context is canceled.
+	Rotate(ctx context.Context, logger lager.Logger) error
+}
+
+type encryptionKeyRotation struct {
+	conn      Conn
+	newKey    *encryption.Key
+	oldKey    *encryption.Key
+	batchSize int
+}
+
+func NewEncryptionKeyRotation(conn Conn, newKey, oldKey *encryption.Key, batchSize int) EncryptionKeyRotation {
+	return &encryptionKeyRotation{
+		conn:      conn,
+		newKey:    newKey,
+		oldKey:    oldKey,
+		batchSize: batchSize,
+	}
+}
+
+func (r *encryptionKeyRotation) Start() (bool, error) {
+	if r.newKey == nil || r.oldKey == nil {
+		return false, ErrEncryptionKeyRotationNotConfigured
+	}
+
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	var inProgress bool
+	err = psql.Select("EXISTS (SELECT 1 FROM encryption_key_rotations WHERE NOT finished)").
+		RunWith(tx).
+		QueryRow().
+		Scan(&inProgress)
+	if err != nil {
+		return false, err
+	}
+
+	if inProgress {
+		return false, nil
+	}
+
+	_, err = psql.Delete("encryption_key_rotations").
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	insert := psql.Insert("encryption_key_rotations").
+		Columns("table_name", "column_name")
+
+	for _, ec := range migration.EncryptedColumns {
+		insert = insert.Values(ec.Table, ec.Column)
+	}
+
+	_, err = insert.RunWith(tx).Exec()
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	err = r.conn.Bus().Notify(atc.ComponentEncryptionKeyRotator)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *encryptionKeyRotation) Progress() ([]EncryptionKeyRotationProgress, error) {
+	rows, err := psql.Select("table_name", "column_name", "rotated", "finished", "last_error", "update_time").
+		From("encryption_key_rotations").
+		OrderBy("table_name ASC", "column_name ASC").
+		RunWith(r.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var progress []EncryptionKeyRotationProgress
+	for rows.Next() {
+		var p EncryptionKeyRotationProgress
+		var lastError sql.NullString
+
+		err = rows.Scan(&p.Table, &p.Column, &p.Rotated, &p.Finished, &lastError, &p.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+
+		p.Error = lastError.String
+
+		progress = append(progress, p)
+	}
+
+	return progress, rows.Err()
+}
+
+func (r *encryptionKeyRotation) Rotate(ctx context.Context, logger lager.Logger) error {
+	if r.newKey == nil || r.oldKey == nil {
+		return nil
+	}
+
+	for _, ec := range migration.EncryptedColumns {
+		var lastKey sql.NullString
+		var finished bool
+		err := psql.Select("last_key", "finished").
+			From("encryption_key_rotations").
+			Where(sq.Eq{
+				"table_name":  ec.Table,
+				"column_name": ec.Column,
+			}).
+			RunWith(r.conn).
+			QueryRow().
+			Scan(&lastKey, &finished)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				continue
+			}
+
+			return err
+		}
+
+		cLog := logger.Session("column", lager.Data{
+			"table":  ec.Table,
+			"column": ec.Column,
+		})
+
+		for !finished {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			lastKey, finished, err = r.rotateBatch(cLog, ec, lastKey)
+			if err != nil {
+				r.saveError(cLog, ec, err)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// rotateBatch re-encrypts the next batch of rows after the given key, which
+// is NULL to start from the beginning of the table. Rows encrypted with the
+// new key already, e.g. because they were written since the rotation
+// started, are left as they are.
+func (r *encryptionKeyRotation) rotateBatch(logger lager.Logger, ec migration.EncryptedColumn, after sql.NullString) (sql.NullString, bool, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return after, false, err
+	}
+
+	defer Rollback(tx)
+
+	query := psql.Select(ec.PrimaryKey, ec.Nonce, ec.Column).
+		From(ec.Table).
+		Where(sq.NotEq{ec.Nonce: nil}).
+		OrderBy(ec.PrimaryKey + " ASC").
+		Limit(uint64(r.batchSize))
+
+	if after.Valid {
+		query = query.Where(sq.Expr(ec.PrimaryKey+" > ?", after.String))
+	}
+
+	rows, err := query.RunWith(tx).Query()
+	if err != nil {
+		return after, false, err
+	}
+
+	type encryptedRow struct {
+		primaryKey string
+		nonce      string
+		value      string
+	}
+
+	var batch []encryptedRow
+	for rows.Next() {
+		var row encryptedRow
+		err = rows.Scan(&row.primaryKey, &row.nonce, &row.value)
+		if err != nil {
+			Close(rows)
+			return after, false, err
+		}
+
+		batch = append(batch, row)
+	}
+
+	Close(rows)
+
+	rotated := 0
+	for _, row := range batch {
+		_, err = r.newKey.Decrypt(row.value, &row.nonce)
+		if err == nil {
+			continue
+		}
+
+		decrypted, err := r.oldKey.Decrypt(row.value, &row.nonce)
+		if err != nil {
+			return after, false, fmt.Errorf("%s %s: %w", ec.Table, row.primaryKey, migration.ErrEncryptedWithUnknownKey)
+		}
+
+		encrypted, newNonce, err := r.newKey.Encrypt(decrypted)
+		if err != nil {
+			return after, false, err
+		}
+
+		// only update the row if it hasn't been written to since it was read,
+		// in which case it is encrypted with the new key already
+		_, err = psql.Update(ec.Table).
+			Set(ec.Column, encrypted).
+			Set(ec.Nonce, newNonce).
+			Where(sq.Expr(ec.PrimaryKey+" = ?", row.primaryKey)).
+			Where(sq.Eq{ec.Nonce: row.nonce}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return after, false, err
+		}
+
+		rotated++
+	}
+
+	finished := len(batch) < r.batchSize
+	if len(batch) > 0 {
+		after = sql.NullString{String: batch[len(batch)-1].primaryKey, Valid: true}
+	}
+
+	_, err = psql.Update("encryption_key_rotations").
+		Set("last_key", after).
+		Set("rotated", sq.Expr("rotated + ?", rotated)).
+		Set("finished", finished).
+		Set("last_error", nil).
+		Set("update_time", sq.Expr("now()")).
+		Where(sq.Eq{
+			"table_name":  ec.Table,
+			"column_name": ec.Column,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return after, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return after, false, err
+	}
+
+	logger.Info("rotated-batch", lager.Data{
+		"rows":     rotated,
+		"finished": finished,
+	})
+
+	return after, finished, nil
+}
+
+func (r *encryptionKeyRotation) saveError(logger lager.Logger, ec migration.EncryptedColumn, rotateErr error) {
+	_, err := psql.Update("encryption_key_rotations").
+		Set("last_error", rotateErr.Error()).
+		Set("update_time", sq.Expr("now()")).
+		Where(sq.Eq{
+			"table_name":  ec.Table,
+			"column_name": ec.Column,
+		}).
+		RunWith(r.conn).
+		Exec()
+	if err != nil {
+		logger.Error("failed-to-save-error", err)
+	}
+}
@@ -0,0 +1,225 @@
+package db_test
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/encryption"
+	"github.com/concourse/concourse/atc/db/migration"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EncryptionKeyRotation", func() {
+	var (
+		oldKey *encryption.Key
+		newKey *encryption.Key
+
+		rotation db.EncryptionKeyRotation
+
+		teamIDs []int
+	)
+
+	newEncryptionKey := func(k string) *encryption.Key {
+		block, err := aes.NewCipher([]byte(k))
+		Expect(err).ToNot(HaveOccurred())
+
+		aesgcm, err := cipher.NewGCM(block)
+		Expect(err).ToNot(HaveOccurred())
+
+		return encryption.NewKey(aesgcm)
+	}
+
+	setLegacyAuth := func(teamID int, key *encryption.Key, value string) {
+		encrypted, nonce, err := key.Encrypt([]byte(value))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = dbConn.Exec(`UPDATE teams SET legacy_auth = $1, nonce = $2 WHERE id = $3`, encrypted, nonce, teamID)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	legacyAuth := func(teamID int, key *encryption.Key) (string, error) {
+		var encrypted, nonce string
+		err := dbConn.QueryRow(`SELECT legacy_auth, nonce FROM teams WHERE id = $1`, teamID).Scan(&encrypted, &nonce)
+		Expect(err).ToNot(HaveOccurred())
+
+		decrypted, err := key.Decrypt(encrypted, &nonce)
+		return string(decrypted), err
+	}
+
+	teamsProgress := func() db.EncryptionKeyRotationProgress {
+		progress, err := rotation.Progress()
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, p := range progress {
+			if p.Table == "teams" && p.Column == "legacy_auth" {
+				return p
+			}
+		}
+
+		Fail("no progress for teams.legacy_auth")
+		return db.EncryptionKeyRotationProgress{}
+	}
+
+	BeforeEach(func() {
+		oldKey = newEncryptionKey("AES256Key-32Characters1234567890")
+		newKey = newEncryptionKey("AES256Key-32Characters0987654321")
+
+		teamIDs = nil
+		for i := 0; i < 5; i++ {
+			team, err := teamFactory.CreateTeam(atc.Team{Name: fmt.Sprintf("rotation-team-%d", i)})
+			Expect(err).ToNot(HaveOccurred())
+
+			setLegacyAuth(team.ID(), oldKey, fmt.Sprintf("auth-%d", i))
+			teamIDs = append(teamIDs, team.ID())
+		}
+
+		rotation = db.NewEncryptionKeyRotation(dbConn, newKey, oldKey, 2)
+	})
+
+	Describe("Start", func() {
+		It("records every encrypted column as left to rotate", func() {
+			started, err := rotation.Start()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			progress, err := rotation.Progress()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(progress).To(HaveLen(len(migration.EncryptedColumns)))
+
+			for _, p := range progress {
+				Expect(p.Finished).To(BeFalse())
+				Expect(p.Rotated).To(BeZero())
+			}
+		})
+
+		Context("when a rotation is under way", func() {
+			BeforeEach(func() {
+				started, err := rotation.Start()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(started).To(BeTrue())
+			})
+
+			It("does not start another", func() {
+				started, err := rotation.Start()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(started).To(BeFalse())
+			})
+		})
+
+		Context("when the previous rotation finished", func() {
+			BeforeEach(func() {
+				_, err := rotation.Start()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = rotation.Rotate(context.Background(), lagertest.NewTestLogger("test"))
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("starts over", func() {
+				started, err := rotation.Start()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				Expect(teamsProgress().Rotated).To(BeZero())
+			})
+		})
+
+		Context("when there is no old key", func() {
+			BeforeEach(func() {
+				rotation = db.NewEncryptionKeyRotation(dbConn, newKey, nil, 2)
+			})
+
+			It("errors", func() {
+				_, err := rotation.Start()
+				Expect(err).To(Equal(db.ErrEncryptionKeyRotationNotConfigured))
+			})
+		})
+	})
+
+	Describe("Rotate", func() {
+		var rotateErr error
+
+		BeforeEach(func() {
+			_, err := rotation.Start()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			rotateErr = rotation.Rotate(context.Background(), lagertest.NewTestLogger("test"))
+		})
+
+		It("re-encrypts the rows with the new key", func() {
+			Expect(rotateErr).ToNot(HaveOccurred())
+
+			for i, id := range teamIDs {
+				value, err := legacyAuth(id, newKey)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(value).To(Equal(fmt.Sprintf("auth-%d", i)))
+			}
+		})
+
+		It("records the progress", func() {
+			p := teamsProgress()
+			Expect(p.Rotated).To(Equal(len(teamIDs)))
+			Expect(p.Finished).To(BeTrue())
+			Expect(p.Error).To(BeEmpty())
+		})
+
+		Context("when some rows were written with the new key already", func() {
+			BeforeEach(func() {
+				setLegacyAuth(teamIDs[0], newKey, "fresh-auth")
+			})
+
+			It("leaves them as they are", func() {
+				value, err := legacyAuth(teamIDs[0], newKey)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(value).To(Equal("fresh-auth"))
+
+				Expect(teamsProgress().Rotated).To(Equal(len(teamIDs) - 1))
+			})
+		})
+
+		Context("when a row is encrypted with neither key", func() {
+			BeforeEach(func() {
+				setLegacyAuth(teamIDs[3], newEncryptionKey("AES256Key-32Characters1111111111"), "unknown")
+			})
+
+			It("errors and records the error", func() {
+				Expect(rotateErr).To(MatchError(migration.ErrEncryptedWithUnknownKey))
+
+				p := teamsProgress()
+				Expect(p.Finished).To(BeFalse())
+				Expect(p.Error).ToNot(BeEmpty())
+			})
+
+			It("keeps the batches rotated before the error", func() {
+				value, err := legacyAuth(teamIDs[0], newKey)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(value).To(Equal("auth-0"))
+			})
+
+			Context("once the row is fixed", func() {
+				It("resumes where it left off", func() {
+					setLegacyAuth(teamIDs[3], oldKey, "auth-3")
+
+					err := rotation.Rotate(context.Background(), lagertest.NewTestLogger("test"))
+					Expect(err).ToNot(HaveOccurred())
+
+					p := teamsProgress()
+					Expect(p.Finished).To(BeTrue())
+					Expect(p.Error).To(BeEmpty())
+
+					value, err := legacyAuth(teamIDs[4], newKey)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(value).To(Equal("auth-4"))
+				})
+			})
+		})
+	})
+})
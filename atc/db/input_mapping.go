@@ -9,9 +9,11 @@ import (
 type ResolutionFailure string
 
 const (
-	LatestVersionNotFound ResolutionFailure = "latest version of resource not found"
-	VersionNotFound       ResolutionFailure = "version of resource not found"
-	NoSatisfiableBuilds   ResolutionFailure = "no satisfiable builds from passed jobs found for set of inputs"
+	LatestVersionNotFound       ResolutionFailure = "latest version of resource not found"
+	LatestStableVersionNotFound ResolutionFailure = "no promoted version of resource found"
+	VersionNotFound             ResolutionFailure = "version of resource not found"
+	NoSatisfiableBuilds         ResolutionFailure = "no satisfiable builds from passed jobs found for set of inputs"
+	NoSatisfiableVersion        ResolutionFailure = "no version of resource satisfies the version constraint"
 )
 
 type PinnedVersionNotFound struct {
@@ -21,13 +21,15 @@ import (
 type InputConfigs []InputConfig
 
 type InputConfig struct {
-	Name            string
-	Trigger         bool
-	Passed          JobSet
-	UseEveryVersion bool
-	PinnedVersion   atc.Version
-	ResourceID      int
-	JobID           int
+	Name                   string
+	Trigger                bool
+	Passed                 JobSet
+	UseEveryVersion        bool
+	UseLatestStableVersion bool
+	PinnedVersion          atc.Version
+	VersionConstraint      string
+	ResourceID             int
+	JobID                  int
 }
 
 func (cfgs InputConfigs) String() string {
@@ -78,8 +80,11 @@ type Job interface {
 	Unpause() error
 
 	ScheduleBuild(Build) (bool, error)
+	TeamMaxConcurrentBuildsReached(build Build) (bool, error)
 	CreateBuild(createdBy string) (Build, error)
+	CreateBuildWithVars(createdBy string, triggerVars TriggerVars) (Build, error)
 	RerunBuild(build Build, createdBy string) (Build, error)
+	RerunBuildWithLatestIfUnavailable(build Build, createdBy string) (Build, error)
 
 	RequestSchedule() error
 	UpdateLastScheduled(time.Time) error
@@ -87,6 +92,7 @@ type Job interface {
 	Builds(page Page) ([]Build, Pagination, error)
 	BuildsWithTime(page Page) ([]Build, Pagination, error)
 	Build(name string) (Build, bool, error)
+	LatestSucceededBuild() (Build, bool, error)
 	FinishedAndNextBuild() (Build, Build, error)
 	UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error
 	EnsurePendingBuildExists(context.Context) error
@@ -102,6 +108,10 @@ type Job interface {
 
 	SetHasNewInputs(bool) error
 	HasNewInputs() bool
+	AwaitingResourceChecks() (bool, error)
+
+	SaveSchedulerDecision(atc.SchedulerDecision) error
+	SchedulerDecision() (atc.SchedulerDecision, bool, error)
 }
 
 var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.public", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.instance_vars", "p.team_id", "t.name", "j.nonce", "j.tags", "j.has_new_inputs", "j.schedule_requested", "j.max_in_flight", "j.disable_manual_trigger").
@@ -166,6 +176,88 @@ func (j *job) SetHasNewInputs(hasNewInputs bool) error {
 	return nil
 }
 
+// AwaitingResourceChecks returns whether any of the check builds that the
+// job's pipeline was unpaused awaiting is still running for one of the job's
+// inputs.
+func (j *job) AwaitingResourceChecks() (bool, error) {
+	var awaiting bool
+	err := j.conn.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM job_inputs ji
+			JOIN resources r ON r.id = ji.resource_id
+			JOIN pipelines p ON p.id = r.pipeline_id
+			JOIN builds b ON b.resource_id = r.id AND b.id = ANY(p.awaited_check_builds)
+			WHERE ji.job_id = $1
+			AND NOT b.completed
+		)`, j.id).Scan(&awaiting)
+	if err != nil {
+		return false, err
+	}
+
+	return awaiting, nil
+}
+
+// SaveSchedulerDecision replaces the decision log of the job's last
+// scheduling.
+func (j *job) SaveSchedulerDecision(decision atc.SchedulerDecision) error {
+	payload, err := json.Marshal(decision)
+	if err != nil {
+		return err
+	}
+
+	result, err := psql.Update("jobs").
+		Set("scheduler_decision", payload).
+		Where(sq.Eq{"id": j.id}).
+		RunWith(j.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return NonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
+
+// SchedulerDecision returns the decision log of the job's last scheduling,
+// or false if the job hasn't been scheduled since it was recorded.
+func (j *job) SchedulerDecision() (atc.SchedulerDecision, bool, error) {
+	var payload []byte
+	err := psql.Select("scheduler_decision").
+		From("jobs").
+		Where(sq.Eq{"id": j.id}).
+		RunWith(j.conn).
+		QueryRow().
+		Scan(&payload)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.SchedulerDecision{}, false, nil
+		}
+
+		return atc.SchedulerDecision{}, false, err
+	}
+
+	if payload == nil {
+		return atc.SchedulerDecision{}, false, nil
+	}
+
+	var decision atc.SchedulerDecision
+	err = json.Unmarshal(payload, &decision)
+	if err != nil {
+		return atc.SchedulerDecision{}, false, err
+	}
+
+	return decision, true, nil
+}
+
 type Jobs []Job
 
 func (jobs Jobs) Configs() (atc.JobConfigs, error) {
@@ -223,13 +315,13 @@ func (j *job) Config() (atc.JobConfig, error) {
 }
 
 func (j *job) AlgorithmInputs() (InputConfigs, error) {
-	rows, err := psql.Select("ji.name", "ji.resource_id", "array_agg(ji.passed_job_id)", "ji.version", "rp.version", "ji.trigger").
+	rows, err := psql.Select("ji.name", "ji.resource_id", "array_agg(ji.passed_job_id)", "ji.version", "rp.version", "ji.trigger", "ji.version_constraint").
 		From("job_inputs ji").
 		LeftJoin("resource_pins rp ON rp.resource_id = ji.resource_id").
 		Where(sq.Eq{
 			"ji.job_id": j.id,
 		}).
-		GroupBy("ji.name, ji.job_id, ji.resource_id, ji.version, rp.version, ji.trigger").
+		GroupBy("ji.name, ji.job_id, ji.resource_id, ji.version, rp.version, ji.trigger, ji.version_constraint").
 		RunWith(j.conn).
 		Query()
 	if err != nil {
@@ -239,21 +331,22 @@ func (j *job) AlgorithmInputs() (InputConfigs, error) {
 	var inputs InputConfigs
 	for rows.Next() {
 		var passedJobs []sql.NullInt64
-		var configVersionString, pinnedVersionString sql.NullString
+		var configVersionString, pinnedVersionString, versionConstraint sql.NullString
 		var inputName string
 		var resourceID int
 		var trigger bool
 
-		err = rows.Scan(&inputName, &resourceID, pq.Array(&passedJobs), &configVersionString, &pinnedVersionString, &trigger)
+		err = rows.Scan(&inputName, &resourceID, pq.Array(&passedJobs), &configVersionString, &pinnedVersionString, &trigger, &versionConstraint)
 		if err != nil {
 			return nil, err
 		}
 
 		inputConfig := InputConfig{
-			Name:       inputName,
-			ResourceID: resourceID,
-			JobID:      j.id,
-			Trigger:    trigger,
+			Name:              inputName,
+			ResourceID:        resourceID,
+			JobID:             j.id,
+			Trigger:           trigger,
+			VersionConstraint: versionConstraint.String,
 		}
 
 		if pinnedVersionString.Valid {
@@ -272,6 +365,7 @@ func (j *job) AlgorithmInputs() (InputConfigs, error) {
 			}
 
 			inputConfig.UseEveryVersion = version.Every
+			inputConfig.UseLatestStableVersion = version.LatestStable
 
 			if version.Pinned != nil {
 				inputConfig.PinnedVersion = version.Pinned
@@ -528,6 +622,30 @@ func (j *job) Build(name string) (Build, bool, error) {
 	return build, true, nil
 }
 
+func (j *job) LatestSucceededBuild() (Build, bool, error) {
+	row := buildsQuery.
+		Where(sq.Eq{
+			"b.job_id": j.id,
+			"b.status": BuildStatusSucceeded,
+		}).
+		OrderBy("b.id DESC").
+		Limit(1).
+		RunWith(j.conn).
+		QueryRow()
+
+	build := newEmptyBuild(j.conn, j.lockFactory)
+
+	err := scanBuild(build, row, j.conn.EncryptionStrategy())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return build, true, nil
+}
+
 func (j *job) ScheduleBuild(build Build) (bool, error) {
 	if build.IsScheduled() {
 		return true, nil
@@ -574,8 +692,16 @@ func (j *job) ScheduleBuild(build Build) (bool, error) {
 		return false, NonOneRowAffectedError{rowsAffected}
 	}
 
-	var scheduled bool
+	var teamLimitReached bool
 	if !reached {
+		teamLimitReached, err = isTeamMaxConcurrentBuildsReached(tx, j.teamID, build, true)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	var scheduled bool
+	if !reached && !teamLimitReached {
 		result, err = psql.Update("builds").
 			Set("scheduled", true).
 			Where(sq.Eq{"id": build.ID()}).
@@ -772,6 +898,10 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 }
 
 func (j *job) CreateBuild(createdBy string) (Build, error) {
+	return j.CreateBuildWithVars(createdBy, TriggerVars{})
+}
+
+func (j *job) CreateBuildWithVars(createdBy string, triggerVars TriggerVars) (Build, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -784,8 +914,7 @@ func (j *job) CreateBuild(createdBy string) (Build, error) {
 		return nil, err
 	}
 
-	build := newEmptyBuild(j.conn, j.lockFactory)
-	err = createBuild(tx, build, map[string]interface{}{
+	buildVals := map[string]interface{}{
 		"name":               buildName,
 		"job_id":             j.id,
 		"pipeline_id":        j.pipelineID,
@@ -793,7 +922,28 @@ func (j *job) CreateBuild(createdBy string) (Build, error) {
 		"status":             BuildStatusPending,
 		"manually_triggered": true,
 		"created_by":         createdBy,
-	})
+	}
+
+	if len(triggerVars.Vars) > 0 {
+		if !triggerVars.AllowOverride {
+			for name := range triggerVars.Vars {
+				if _, found := j.pipelineInstanceVars[name]; found {
+					return nil, TriggerVarConflictError{Name: name}
+				}
+			}
+		}
+
+		encryptedVars, nonce, err := encryptTriggerVars(j.conn.EncryptionStrategy(), triggerVars)
+		if err != nil {
+			return nil, err
+		}
+
+		buildVals["trigger_vars"] = encryptedVars
+		buildVals["trigger_vars_nonce"] = nonce
+	}
+
+	build := newEmptyBuild(j.conn, j.lockFactory)
+	err = createBuild(tx, build, buildVals)
 	if err != nil {
 		return nil, err
 	}
@@ -822,8 +972,19 @@ func (j *job) CreateBuild(createdBy string) (Build, error) {
 }
 
 func (j *job) RerunBuild(buildToRerun Build, createdBy string) (Build, error) {
+	return j.rerunBuild(buildToRerun, createdBy, false)
+}
+
+// RerunBuildWithLatestIfUnavailable reruns the build like RerunBuild, except
+// that any input whose version is no longer available uses the job's latest
+// version of it instead of aborting the rerun.
+func (j *job) RerunBuildWithLatestIfUnavailable(buildToRerun Build, createdBy string) (Build, error) {
+	return j.rerunBuild(buildToRerun, createdBy, true)
+}
+
+func (j *job) rerunBuild(buildToRerun Build, createdBy string, latestIfUnavailable bool) (Build, error) {
 	for {
-		rerunBuild, err := j.tryRerunBuild(buildToRerun, createdBy)
+		rerunBuild, err := j.tryRerunBuild(buildToRerun, createdBy, latestIfUnavailable)
 		if err != nil {
 			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
 				continue
@@ -836,7 +997,7 @@ func (j *job) RerunBuild(buildToRerun Build, createdBy string) (Build, error) {
 	}
 }
 
-func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error) {
+func (j *job) tryRerunBuild(buildToRerun Build, createdBy string, latestIfUnavailable bool) (Build, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -854,8 +1015,7 @@ func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error)
 		return nil, err
 	}
 
-	rerunBuild := newEmptyBuild(j.conn, j.lockFactory)
-	err = createBuild(tx, rerunBuild, map[string]interface{}{
+	buildVals := map[string]interface{}{
 		"name":         rerunBuildName,
 		"job_id":       j.id,
 		"pipeline_id":  j.pipelineID,
@@ -864,7 +1024,25 @@ func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error)
 		"rerun_of":     buildToRerunID,
 		"rerun_number": rerunNumber,
 		"created_by":   createdBy,
-	})
+	}
+
+	if latestIfUnavailable {
+		buildVals["rerun_latest_if_unavailable"] = true
+	}
+
+	// a rerun runs with the same vars it was originally triggered with
+	if triggerVars := buildToRerun.TriggerVars(); len(triggerVars.Vars) > 0 {
+		encryptedVars, nonce, err := encryptTriggerVars(j.conn.EncryptionStrategy(), triggerVars)
+		if err != nil {
+			return nil, err
+		}
+
+		buildVals["trigger_vars"] = encryptedVars
+		buildVals["trigger_vars_nonce"] = nonce
+	}
+
+	rerunBuild := newEmptyBuild(j.conn, j.lockFactory)
+	err = createBuild(tx, rerunBuild, buildVals)
 	if err != nil {
 		return nil, err
 	}
@@ -947,12 +1125,17 @@ func (j *job) isMaxInFlightReached(tx Tx, buildID int) (bool, error) {
 		return false, err
 	}
 
+	maxInFlight, err := j.getMaxInFlightBySerialGroup(tx, serialGroups)
+	if err != nil {
+		return false, err
+	}
+
 	builds, err := j.getRunningBuildsBySerialGroup(tx, serialGroups)
 	if err != nil {
 		return false, err
 	}
 
-	if len(builds) >= j.maxInFlight {
+	if len(builds) >= maxInFlight {
 		return true, nil
 	}
 
@@ -972,6 +1155,113 @@ func (j *job) isMaxInFlightReached(tx Tx, buildID int) (bool, error) {
 	return false, nil
 }
 
+// TeamMaxConcurrentBuildsReached returns whether the build has to wait for
+// the team's running builds and the team's builds queued ahead of it to fill
+// its max concurrent builds.
+func (j *job) TeamMaxConcurrentBuildsReached(build Build) (bool, error) {
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	reached, err := isTeamMaxConcurrentBuildsReached(tx, j.teamID, build, false)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return reached, nil
+}
+
+// isTeamMaxConcurrentBuildsReached returns whether the team's scheduled job
+// builds which have not completed, together with the team's builds queued
+// ahead of the given build, fill the team's limit. Builds are queued in the
+// same order as in QueuePosition, so that the team's builds are scheduled
+// oldest first across its jobs. Builds are only queued ahead once their
+// inputs are determined, i.e. they are reruns or their job has next inputs,
+// so that builds waiting on versions don't hold back the team's other builds.
+//
+// With lock set, a build which the unlocked count doesn't hold back is counted
+// again with the team's row locked for the remainder of the transaction, so
+// that builds of the team's other jobs can't be scheduled concurrently past
+// the limit. Builds which are held back anyway don't take the lock.
+func isTeamMaxConcurrentBuildsReached(tx Tx, teamID int, build Build, lock bool) (bool, error) {
+	reached, err := teamBuildsFillLimit(tx, teamID, build, false)
+	if err != nil {
+		return false, err
+	}
+
+	if reached || !lock {
+		return reached, nil
+	}
+
+	return teamBuildsFillLimit(tx, teamID, build, true)
+}
+
+func teamBuildsFillLimit(tx Tx, teamID int, build Build, lock bool) (bool, error) {
+	query := psql.Select("max_concurrent_builds").
+		From("teams").
+		Where(sq.Eq{"id": teamID})
+	if lock {
+		query = query.Suffix("FOR UPDATE")
+	}
+
+	var limit int
+	err := query.
+		RunWith(tx).
+		QueryRow().
+		Scan(&limit)
+	if err != nil {
+		return false, err
+	}
+
+	if limit == 0 {
+		return false, nil
+	}
+
+	original := build.ID()
+	if build.RerunOf() != 0 {
+		original = build.RerunOf()
+	}
+
+	var running, ahead int
+	err = tx.QueryRow(`
+		SELECT
+			(
+				SELECT COUNT(*)
+				FROM builds
+				WHERE team_id = $1
+				AND job_id IS NOT NULL
+				AND scheduled
+				AND NOT completed
+			),
+			(
+				SELECT COUNT(*)
+				FROM builds b
+				JOIN jobs j ON j.id = b.job_id
+				JOIN pipelines p ON p.id = j.pipeline_id
+				WHERE b.team_id = $1
+				AND b.status = $2
+				AND NOT b.scheduled
+				AND NOT j.paused
+				AND NOT p.paused
+				AND NOT j.max_in_flight_reached
+				AND (b.rerun_of IS NOT NULL OR j.inputs_determined)
+				AND (COALESCE(b.rerun_of, b.id), b.id) < ($3, $4)
+			)`, teamID, BuildStatusPending, original, build.ID()).Scan(&running, &ahead)
+	if err != nil {
+		return false, err
+	}
+
+	return running+ahead >= limit, nil
+}
+
 func (j *job) getSerialGroups(tx Tx) ([]string, error) {
 	rows, err := psql.Select("serial_group").
 		From("jobs_serial_groups").
@@ -1026,6 +1316,33 @@ func (j *job) UpdateLastScheduled(requestedTime time.Time) error {
 	return err
 }
 
+// getMaxInFlightBySerialGroup returns the strictest max_in_flight of the job
+// and the active jobs sharing any of its serial groups.
+func (j *job) getMaxInFlightBySerialGroup(tx Tx, serialGroups []string) (int, error) {
+	var maxInFlight sql.NullInt64
+	err := psql.Select("MIN(j.max_in_flight)").
+		From("jobs j").
+		Join("jobs_serial_groups jsg ON j.id = jsg.job_id").
+		Where(sq.Eq{
+			"jsg.serial_group": serialGroups,
+			"j.pipeline_id":    j.pipelineID,
+			"j.active":         true,
+		}).
+		Where(sq.Gt{"j.max_in_flight": 0}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&maxInFlight)
+	if err != nil {
+		return 0, err
+	}
+
+	if maxInFlight.Valid && int(maxInFlight.Int64) < j.maxInFlight {
+		return int(maxInFlight.Int64), nil
+	}
+
+	return j.maxInFlight, nil
+}
+
 func (j *job) getRunningBuildsBySerialGroup(tx Tx, serialGroups []string) ([]Build, error) {
 	rows, err := buildsQuery.Options(`DISTINCT ON (b.id)`).
 		Join(`jobs_serial_groups jsg ON j.id = jsg.job_id`).
@@ -1278,7 +1595,7 @@ func (j *job) getNewRerunBuildName(tx Tx, buildID int) (string, int, error) {
 }
 
 func (j *job) getNextBuildInputs(tx Tx) ([]BuildInput, error) {
-	rows, err := psql.Select("i.input_name, i.first_occurrence, i.resource_id, v.version, i.resolve_error, v.span_context").
+	rows, err := psql.Select("i.input_name, i.first_occurrence, i.resource_id, v.version, v.metadata, v.created_at, i.resolve_error, v.span_context").
 		From("next_build_inputs i").
 		LeftJoin("resources r ON r.id = i.resource_id").
 		LeftJoin("resource_config_versions v ON v.version_md5 = i.version_md5 AND r.resource_config_scope_id = v.resource_config_scope_id").
@@ -1297,12 +1614,19 @@ func (j *job) getNextBuildInputs(tx Tx) ([]BuildInput, error) {
 			inputName       string
 			firstOcc        sql.NullBool
 			versionBlob     sql.NullString
+			metadataBlob    sql.NullString
+			createdAt       pq.NullTime
 			resID           sql.NullString
 			resolveErr      sql.NullString
 			spanContextJSON sql.NullString
 		)
 
-		err := rows.Scan(&inputName, &firstOcc, &resID, &versionBlob, &resolveErr, &spanContextJSON)
+		err := rows.Scan(&inputName, &firstOcc, &resID, &versionBlob, &metadataBlob, &createdAt, &resolveErr, &spanContextJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		metadata, err := scanResourceVersionMetadata(metadataBlob)
 		if err != nil {
 			return nil, err
 		}
@@ -1342,12 +1666,14 @@ func (j *job) getNextBuildInputs(tx Tx) ([]BuildInput, error) {
 		}
 
 		buildInputs = append(buildInputs, BuildInput{
-			Name:            inputName,
-			ResourceID:      resourceID,
-			Version:         version,
-			FirstOccurrence: firstOccurrence,
-			ResolveError:    resolveError,
-			Context:         spanContext,
+			Name:             inputName,
+			ResourceID:       resourceID,
+			Version:          version,
+			Metadata:         metadata,
+			VersionCreatedAt: createdAt.Time,
+			FirstOccurrence:  firstOccurrence,
+			ResolveError:     resolveError,
+			Context:          spanContext,
 		})
 	}
 
@@ -41,6 +41,7 @@ type SchedulerJob struct {
 	Job
 	Resources     SchedulerResources
 	ResourceTypes atc.VersionedResourceTypes
+	TaskDefaults  *atc.TaskDefaults
 }
 
 type SchedulerResources []SchedulerResource
@@ -50,6 +51,9 @@ type SchedulerResource struct {
 	Type                 string
 	Source               atc.Source
 	ExposeBuildCreatedBy bool
+	CheckOnDemand        bool
+	Proxy                *atc.ProxyConfig
+	TriggerWindow        *atc.TriggerWindowConfig
 }
 
 func (r *SchedulerResource) ApplySourceDefaults(resourceTypes atc.VersionedResourceTypes) {
@@ -74,6 +78,19 @@ func (resources SchedulerResources) Lookup(name string) (*SchedulerResource, boo
 	return nil, false
 }
 
+// CheckedOnDemand returns the names of the resources which are only checked
+// when a build needs them.
+func (resources SchedulerResources) CheckedOnDemand() []string {
+	var names []string
+	for _, resource := range resources {
+		if resource.CheckOnDemand {
+			names = append(names, resource.Name)
+		}
+	}
+
+	return names
+}
+
 func (j *jobFactory) JobsToSchedule() (SchedulerJobs, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
@@ -102,6 +119,7 @@ func (j *jobFactory) JobsToSchedule() (SchedulerJobs, error) {
 
 	var schedulerJobs SchedulerJobs
 	pipelineResourceTypes := make(map[int]ResourceTypes)
+	pipelineTaskDefaults := make(map[int]*atc.TaskDefaults)
 	for _, job := range jobs {
 		rows, err := tx.Query(`WITH inputs AS (
 				SELECT ji.resource_id from job_inputs ji where ji.job_id = $1
@@ -151,6 +169,9 @@ func (j *jobFactory) JobsToSchedule() (SchedulerJobs, error) {
 				Type:                 type_,
 				Source:               config.Source,
 				ExposeBuildCreatedBy: config.ExposeBuildCreatedBy,
+				CheckOnDemand:        config.CheckOnDemand,
+				Proxy:                config.Proxy,
+				TriggerWindow:        config.TriggerWindow,
 			})
 		}
 
@@ -182,10 +203,34 @@ func (j *jobFactory) JobsToSchedule() (SchedulerJobs, error) {
 			pipelineResourceTypes[job.PipelineID()] = resourceTypes
 		}
 
+		taskDefaults, found := pipelineTaskDefaults[job.PipelineID()]
+		if !found {
+			var taskDefaultsBlob sql.NullString
+			err := psql.Select("task_defaults").
+				From("pipelines").
+				Where(sq.Eq{"id": job.PipelineID()}).
+				RunWith(tx).
+				QueryRow().
+				Scan(&taskDefaultsBlob)
+			if err != nil {
+				return nil, err
+			}
+
+			if taskDefaultsBlob.Valid {
+				err = json.Unmarshal([]byte(taskDefaultsBlob.String), &taskDefaults)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			pipelineTaskDefaults[job.PipelineID()] = taskDefaults
+		}
+
 		schedulerJobs = append(schedulerJobs, SchedulerJob{
 			Job:           job,
 			Resources:     schedulerResources,
 			ResourceTypes: resourceTypes.Deserialize(),
+			TaskDefaults:  taskDefaults,
 		})
 	}
 
@@ -588,6 +588,36 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("LatestSucceededBuild", func() {
+		It("finds nothing when no build has succeeded", func() {
+			build, err := job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(build.Finish(db.BuildStatusFailed)).To(Succeed())
+
+			_, found, err := job.LatestSucceededBuild()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("finds the latest succeeded build", func() {
+			succeededBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(succeededBuild.Finish(db.BuildStatusSucceeded)).To(Succeed())
+
+			failedBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(failedBuild.Finish(db.BuildStatusFailed)).To(Succeed())
+
+			build, found, err := job.LatestSucceededBuild()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.ID()).To(Equal(succeededBuild.ID()))
+		})
+	})
+
 	Describe("RerunBuild", func() {
 		var firstBuild db.Build
 		var rerunErr error
@@ -669,6 +699,78 @@ var _ = Describe("Job", func() {
 					Expect(rerunBuild.RerunNumber()).To(Equal(rerun1.RerunNumber() + 1))
 				})
 			})
+
+			Context("when the build was triggered with vars", func() {
+				var triggerVars db.TriggerVars
+
+				BeforeEach(func() {
+					triggerVars = db.TriggerVars{
+						Vars:          map[string]interface{}{"version": "1.2.3"},
+						AllowOverride: true,
+					}
+
+					var err error
+					buildToRerun, err = job.CreateBuildWithVars(defaultBuildCreatedBy, triggerVars)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("reruns it with the same vars", func() {
+					Expect(rerunErr).ToNot(HaveOccurred())
+					Expect(rerunBuild.TriggerVars()).To(Equal(triggerVars))
+				})
+			})
+		})
+	})
+
+	Describe("CreateBuildWithVars", func() {
+		var (
+			triggerVars db.TriggerVars
+			createdErr  error
+			build       db.Build
+		)
+
+		BeforeEach(func() {
+			triggerVars = db.TriggerVars{
+				Vars: map[string]interface{}{"version": "1.2.3"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			build, createdErr = defaultJob.CreateBuildWithVars(defaultBuildCreatedBy, triggerVars)
+		})
+
+		It("stores the vars", func() {
+			Expect(createdErr).ToNot(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.TriggerVars()).To(Equal(triggerVars))
+		})
+
+		Context("when a var is also an instance var of the pipeline", func() {
+			BeforeEach(func() {
+				triggerVars.Vars["branch"] = "feature"
+			})
+
+			It("errors without creating a build", func() {
+				Expect(createdErr).To(Equal(db.TriggerVarConflictError{Name: "branch"}))
+
+				builds, _, err := defaultJob.Builds(db.Page{Limit: 10})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(builds).To(BeEmpty())
+			})
+
+			Context("when overriding is allowed", func() {
+				BeforeEach(func() {
+					triggerVars.AllowOverride = true
+				})
+
+				It("creates the build", func() {
+					Expect(createdErr).ToNot(HaveOccurred())
+					Expect(build.TriggerVars()).To(Equal(triggerVars))
+				})
+			})
 		})
 	})
 
@@ -843,6 +945,53 @@ var _ = Describe("Job", func() {
 			})
 		}
 
+		saveSerialGroupsMaxInFlightPipeline := func(otherMaxInFlight int) {
+			BeforeEach(func() {
+				var err error
+				pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name:                    "some-job",
+							SerialGroups:            []string{"serial-group"},
+							SerialGroupsMaxInFlight: 2,
+						},
+						{
+							Name:                    "other-serial-group-job",
+							SerialGroups:            []string{"serial-group"},
+							SerialGroupsMaxInFlight: otherMaxInFlight,
+						},
+					},
+				}, pipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		}
+
+		startOtherSerialGroupBuilds := func(count int) {
+			BeforeEach(func() {
+				otherSerialJob, found, err := pipeline.Job("other-serial-group-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = otherSerialJob.SaveNextInputMapping(nil, true)
+				Expect(err).NotTo(HaveOccurred())
+
+				for i := 0; i < count; i++ {
+					serialGroupBuild, err := otherSerialJob.CreateBuild(defaultBuildCreatedBy)
+					Expect(err).NotTo(HaveOccurred())
+
+					scheduled, err := otherSerialJob.ScheduleBuild(serialGroupBuild)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(scheduled).To(BeTrue())
+
+					_, err = serialGroupBuild.Start(atc.Plan{})
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				err = job.SaveNextInputMapping(nil, true)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		}
+
 		JustBeforeEach(func() {
 			var found bool
 			var err error
@@ -888,6 +1037,76 @@ var _ = Describe("Job", func() {
 					Expect(scheduleFound).To(BeTrue())
 				})
 
+				Context("when the team has a max concurrent builds", func() {
+					var runningBuild db.Build
+
+					BeforeEach(func() {
+						err := team.UpdateMaxConcurrentBuilds(1)
+						Expect(err).ToNot(HaveOccurred())
+
+						runningBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+						Expect(err).ToNot(HaveOccurred())
+
+						scheduled, err := job.ScheduleBuild(runningBuild)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(scheduled).To(BeTrue())
+					})
+
+					It("does not schedule the build while the limit is reached", func() {
+						Expect(schedulingErr).ToNot(HaveOccurred())
+						Expect(scheduleFound).To(BeFalse())
+						Expect(schedulingBuild.IsScheduled()).To(BeFalse())
+
+						reached, err := job.TeamMaxConcurrentBuildsReached(schedulingBuild)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(reached).To(BeTrue())
+					})
+
+					It("schedules the build once a running build completes", func() {
+						err := runningBuild.Finish(db.BuildStatusSucceeded)
+						Expect(err).ToNot(HaveOccurred())
+
+						scheduled, err := job.ScheduleBuild(schedulingBuild)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(scheduled).To(BeTrue())
+					})
+
+					It("schedules the team's builds oldest first across its jobs", func() {
+						err := job.SaveNextInputMapping(nil, true)
+						Expect(err).ToNot(HaveOccurred())
+
+						err = runningBuild.Finish(db.BuildStatusSucceeded)
+						Expect(err).ToNot(HaveOccurred())
+
+						newerBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+						Expect(err).ToNot(HaveOccurred())
+
+						scheduled, err := job.ScheduleBuild(newerBuild)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(scheduled).To(BeFalse())
+
+						reached, err := job.TeamMaxConcurrentBuildsReached(newerBuild)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(reached).To(BeTrue())
+
+						scheduled, err = job.ScheduleBuild(schedulingBuild)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(scheduled).To(BeTrue())
+					})
+
+					It("doesn't queue builds whose inputs aren't determined ahead", func() {
+						err := runningBuild.Finish(db.BuildStatusSucceeded)
+						Expect(err).ToNot(HaveOccurred())
+
+						newerBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+						Expect(err).ToNot(HaveOccurred())
+
+						reached, err := job.TeamMaxConcurrentBuildsReached(newerBuild)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(reached).To(BeFalse())
+					})
+				})
+
 				Context("when build exists", func() {
 					Context("when the pipeline is paused", func() {
 						BeforeEach(func() {
@@ -1111,6 +1330,47 @@ var _ = Describe("Job", func() {
 			})
 		})
 
+		Context("when the job is in serial groups with serial_groups_max_in_flight", func() {
+			BeforeEach(func() {
+				var err error
+				schedulingBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			Context("when every job in the group allows 2 builds and 1 is running", func() {
+				saveSerialGroupsMaxInFlightPipeline(3)
+				startOtherSerialGroupBuilds(1)
+
+				It("schedules the build", func() {
+					Expect(schedulingErr).ToNot(HaveOccurred())
+					Expect(scheduleFound).To(BeTrue())
+					Expect(reloadFound).To(BeTrue())
+				})
+			})
+
+			Context("when every job in the group allows 2 builds and 2 are running", func() {
+				saveSerialGroupsMaxInFlightPipeline(3)
+				startOtherSerialGroupBuilds(2)
+
+				It("does not schedule the build", func() {
+					Expect(schedulingErr).ToNot(HaveOccurred())
+					Expect(scheduleFound).To(BeFalse())
+					Expect(reloadFound).To(BeTrue())
+				})
+			})
+
+			Context("when another job in the group is limited to one build and 1 is running", func() {
+				saveSerialGroupsMaxInFlightPipeline(0)
+				startOtherSerialGroupBuilds(1)
+
+				It("does not schedule the build", func() {
+					Expect(schedulingErr).ToNot(HaveOccurred())
+					Expect(scheduleFound).To(BeFalse())
+					Expect(reloadFound).To(BeTrue())
+				})
+			})
+		})
+
 		Context("when the scheduling build is not the first one created (with serial groups)", func() {
 			Context("when the scheduling build has inputs determined as false", func() {
 				BeforeEach(func() {
@@ -2146,6 +2406,40 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("SchedulerDecision", func() {
+		It("is not found before the job is scheduled", func() {
+			_, found, err := job.SchedulerDecision()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns the last decision saved", func() {
+			err := job.SaveSchedulerDecision(atc.SchedulerDecision{
+				Time: 1,
+				Reasons: []atc.SchedulerDecisionReason{
+					{Kind: atc.SchedulerNoNewTriggerInputs, Message: "no input has a new version"},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			decision := atc.SchedulerDecision{
+				Time: 2,
+				Reasons: []atc.SchedulerDecisionReason{
+					{Kind: atc.SchedulerNewTriggerInput, Input: "some-input", Message: "a new version of trigger input 'some-input' triggers a build"},
+					{Kind: atc.SchedulerBuildStarted, Build: "1", Message: "the build was started"},
+				},
+			}
+
+			err = job.SaveSchedulerDecision(decision)
+			Expect(err).ToNot(HaveOccurred())
+
+			savedDecision, found, err := job.SchedulerDecision()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedDecision).To(Equal(decision))
+		})
+	})
+
 	Describe("AlgorithmInputs", func() {
 		var scenario *dbtest.Scenario
 		var inputs db.InputConfigs
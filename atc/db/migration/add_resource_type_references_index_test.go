@@ -0,0 +1,68 @@
+package migration_test
+
+import (
+	"database/sql"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Add resource type references index", func() {
+	const preMigrationVersion = 1619180123
+	const postMigrationVersion = 1619180124
+
+	var (
+		db *sql.DB
+	)
+
+	Context("Up", func() {
+		It("records the image repository of existing resource types", func() {
+			db = postgresRunner.OpenDBAtVersion(preMigrationVersion)
+
+			_, err := db.Exec(`
+				INSERT INTO teams(id, name) VALUES
+				(1, 'some-team')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO pipelines(id, team_id, name) VALUES
+				(1, 1, 'some-pipeline')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO resource_types(name, pipeline_id, config, active, type) VALUES
+				('image-type', 1, '{"type": "registry-image", "source": {"repository": "example/some-resource"}}', true, 'registry-image'),
+				('other-type', 1, '{"type": "image-type", "source": {"some": "source"}}', true, 'image-type')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			db.Close()
+
+			db = postgresRunner.OpenDBAtVersion(postMigrationVersion)
+
+			images := map[string]sql.NullString{}
+
+			rows, err := db.Query(`SELECT name, image FROM resource_types`)
+			Expect(err).NotTo(HaveOccurred())
+
+			for rows.Next() {
+				var name string
+				var image sql.NullString
+
+				err := rows.Scan(&name, &image)
+				Expect(err).NotTo(HaveOccurred())
+
+				images[name] = image
+			}
+
+			Expect(images).To(Equal(map[string]sql.NullString{
+				"image-type": {String: "example/some-resource", Valid: true},
+				"other-type": {},
+			}))
+
+			db.Close()
+		})
+	})
+})
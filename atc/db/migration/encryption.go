@@ -8,29 +8,42 @@ import (
 	"github.com/concourse/concourse/atc/db/encryption"
 )
 
-var encryptedColumns = []encryptedColumn{
-	{"teams", "legacy_auth", "id"},
-	{"resources", "config", "id"},
-	{"jobs", "config", "id"},
-	{"resource_types", "config", "id"},
-	{"builds", "private_plan", "id"},
-	{"cert_cache", "cert", "domain"},
-	{"pipelines", "var_sources", "id"},
+// EncryptedColumns are the columns encrypted with the configured encryption
+// key, along with the column of the same table holding their nonce.
+var EncryptedColumns = []EncryptedColumn{
+	{"teams", "legacy_auth", "id", "nonce"},
+	{"resources", "config", "id", "nonce"},
+	{"jobs", "config", "id", "nonce"},
+	{"resource_types", "config", "id", "nonce"},
+	{"builds", "private_plan", "id", "nonce"},
+	{"cert_cache", "cert", "domain", "nonce"},
+	{"pipelines", "var_sources", "id", "nonce"},
+	{"pipeline_vars", "value", "id", "nonce"},
+	{"team_resource_types", "config", "team_id || '/' || name", "nonce"},
+	{"shared_artifacts", "data", "team_id || '/' || name", "nonce"},
+	{"builds", "trigger_vars", "id", "trigger_vars_nonce"},
+	{"pipelines", "webhooks", "id", "webhooks_nonce"},
 }
 
-type encryptedColumn struct {
-	Table      string
-	Column     string
+type EncryptedColumn struct {
+	Table  string
+	Column string
+
+	// PrimaryKey is an expression uniquely identifying a row, which for
+	// tables with a composite primary key combines its columns.
 	PrimaryKey string
+
+	// Nonce is the column holding the nonce the column was encrypted with.
+	Nonce string
 }
 
 func (m migrator) encryptPlaintext(key *encryption.Key) error {
 	logger := m.logger.Session("encrypt")
-	for _, ec := range encryptedColumns {
+	for _, ec := range EncryptedColumns {
 		rows, err := m.db.Query(`
 			SELECT ` + ec.PrimaryKey + `, ` + ec.Column + `
 			FROM ` + ec.Table + `
-			WHERE nonce IS NULL
+			WHERE ` + ec.Nonce + ` IS NULL
 			AND ` + ec.Column + ` IS NOT NULL
 		`)
 		if err != nil {
@@ -71,7 +84,7 @@ func (m migrator) encryptPlaintext(key *encryption.Key) error {
 
 			_, err = m.db.Exec(`
 				UPDATE `+ec.Table+`
-				SET `+ec.Column+` = $1, nonce = $2
+				SET `+ec.Column+` = $1, `+ec.Nonce+` = $2
 				WHERE `+ec.PrimaryKey+` = $3
 			`, encrypted, nonce, primaryKey)
 			if err != nil {
@@ -94,11 +107,11 @@ func (m migrator) encryptPlaintext(key *encryption.Key) error {
 
 func (m migrator) decryptToPlaintext(oldKey *encryption.Key) error {
 	logger := m.logger.Session("decrypt")
-	for _, ec := range encryptedColumns {
+	for _, ec := range EncryptedColumns {
 		rows, err := m.db.Query(`
-			SELECT ` + ec.PrimaryKey + `, nonce, ` + ec.Column + `
+			SELECT ` + ec.PrimaryKey + `, ` + ec.Nonce + `, ` + ec.Column + `
 			FROM ` + ec.Table + `
-			WHERE nonce IS NOT NULL
+			WHERE ` + ec.Nonce + ` IS NOT NULL
 		`)
 		if err != nil {
 			return err
@@ -134,7 +147,7 @@ func (m migrator) decryptToPlaintext(oldKey *encryption.Key) error {
 
 			_, err = m.db.Exec(`
 				UPDATE `+ec.Table+`
-				SET `+ec.Column+` = $1, nonce = NULL
+				SET `+ec.Column+` = $1, `+ec.Nonce+` = NULL
 				WHERE `+ec.PrimaryKey+` = $2
 			`, decrypted, primaryKey)
 			if err != nil {
@@ -159,11 +172,11 @@ var ErrEncryptedWithUnknownKey = errors.New("row encrypted with neither old nor
 
 func (m migrator) encryptWithNewKey(newKey *encryption.Key, oldKey *encryption.Key) error {
 	logger := m.logger.Session("rotate")
-	for _, ec := range encryptedColumns {
+	for _, ec := range EncryptedColumns {
 		rows, err := m.db.Query(`
-			SELECT ` + ec.PrimaryKey + `, nonce, ` + ec.Column + `
+			SELECT ` + ec.PrimaryKey + `, ` + ec.Nonce + `, ` + ec.Column + `
 			FROM ` + ec.Table + `
-			WHERE nonce IS NOT NULL
+			WHERE ` + ec.Nonce + ` IS NOT NULL
 		`)
 		if err != nil {
 			return err
@@ -211,7 +224,7 @@ func (m migrator) encryptWithNewKey(newKey *encryption.Key, oldKey *encryption.K
 
 			_, err = m.db.Exec(`
 				UPDATE `+ec.Table+`
-				SET `+ec.Column+` = $1, nonce = $2
+				SET `+ec.Column+` = $1, `+ec.Nonce+` = $2
 				WHERE `+ec.PrimaryKey+` = $3
 			`, encrypted, newNonce, primaryKey)
 			if err != nil {
@@ -0,0 +1,2 @@
+
+  ALTER TABLE builds DROP COLUMN aborted_by, DROP COLUMN abort_reason;
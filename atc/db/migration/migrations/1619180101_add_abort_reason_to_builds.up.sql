@@ -0,0 +1,2 @@
+
+  ALTER TABLE builds ADD COLUMN aborted_by text, ADD COLUMN abort_reason text;
@@ -0,0 +1,2 @@
+
+  ALTER TABLE job_inputs ADD COLUMN version_constraint text;
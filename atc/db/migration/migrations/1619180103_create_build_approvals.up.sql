@@ -0,0 +1,12 @@
+
+  CREATE TABLE build_approvals (
+      build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+      plan_id text NOT NULL,
+      name text NOT NULL,
+      requested_at timestamp with time zone DEFAULT now() NOT NULL,
+      approved boolean,
+      decided_by text,
+      decided_at timestamp with time zone,
+      comment text,
+      PRIMARY KEY (build_id, plan_id)
+  );
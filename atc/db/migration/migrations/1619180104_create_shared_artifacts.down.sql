@@ -0,0 +1,4 @@
+
+  DROP TABLE shared_artifact_grants;
+
+  DROP TABLE shared_artifacts;
@@ -0,0 +1,18 @@
+
+  CREATE TABLE shared_artifacts (
+      team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+      name text NOT NULL,
+      build_id integer REFERENCES builds (id) ON DELETE SET NULL,
+      data text NOT NULL,
+      nonce text,
+      size bigint NOT NULL,
+      created_at timestamp with time zone DEFAULT now() NOT NULL,
+      PRIMARY KEY (team_id, name)
+  );
+
+  CREATE TABLE shared_artifact_grants (
+      team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+      name text NOT NULL,
+      grantee_team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+      PRIMARY KEY (team_id, name, grantee_team_id)
+  );
@@ -0,0 +1,4 @@
+
+  ALTER TABLE resource_config_scopes
+    DROP COLUMN last_check_success_time,
+    DROP COLUMN check_failures;
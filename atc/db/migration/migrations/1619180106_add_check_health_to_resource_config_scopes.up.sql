@@ -0,0 +1,4 @@
+
+  ALTER TABLE resource_config_scopes
+    ADD COLUMN last_check_success_time timestamp with time zone,
+    ADD COLUMN check_failures integer NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+
+ALTER TABLE builds
+  DROP COLUMN events_archive_key;
@@ -0,0 +1,4 @@
+
+ALTER TABLE builds
+  DROP COLUMN trigger_vars,
+  DROP COLUMN trigger_vars_nonce;
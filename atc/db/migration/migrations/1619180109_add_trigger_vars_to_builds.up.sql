@@ -0,0 +1,4 @@
+
+ALTER TABLE builds
+  ADD COLUMN trigger_vars text,
+  ADD COLUMN trigger_vars_nonce text;
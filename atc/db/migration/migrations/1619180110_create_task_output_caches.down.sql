@@ -0,0 +1,2 @@
+
+  DROP TABLE task_output_caches;
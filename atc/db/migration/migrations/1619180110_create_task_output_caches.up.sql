@@ -0,0 +1,11 @@
+
+  CREATE TABLE task_output_caches (
+      job_id integer NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+      step_name text NOT NULL,
+      fingerprint text NOT NULL,
+      output_name text NOT NULL,
+      worker_artifact_id integer NOT NULL REFERENCES worker_artifacts (id) ON DELETE CASCADE,
+      PRIMARY KEY (job_id, step_name, fingerprint, output_name)
+  );
+
+  CREATE INDEX task_output_caches_worker_artifact_id ON task_output_caches (worker_artifact_id);
@@ -0,0 +1,6 @@
+
+  DROP TABLE webhook_notifications;
+
+  ALTER TABLE pipelines
+    DROP COLUMN webhooks,
+    DROP COLUMN webhooks_nonce;
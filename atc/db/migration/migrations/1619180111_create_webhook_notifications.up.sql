@@ -0,0 +1,18 @@
+
+  ALTER TABLE pipelines
+    ADD COLUMN webhooks text,
+    ADD COLUMN webhooks_nonce text;
+
+  CREATE TABLE webhook_notifications (
+      id serial PRIMARY KEY,
+      build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+      webhook text NOT NULL,
+      payload jsonb NOT NULL,
+      attempts integer NOT NULL DEFAULT 0,
+      next_attempt_at timestamp with time zone NOT NULL DEFAULT now(),
+      last_error text,
+      dead boolean NOT NULL DEFAULT false
+  );
+
+  CREATE INDEX webhook_notifications_build_id ON webhook_notifications (build_id);
+  CREATE INDEX webhook_notifications_next_attempt_at ON webhook_notifications (next_attempt_at) WHERE NOT dead;
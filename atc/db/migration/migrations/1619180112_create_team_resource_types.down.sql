@@ -0,0 +1,4 @@
+
+  ALTER TABLE resource_types DROP COLUMN team_scoped;
+
+  DROP TABLE team_resource_types;
@@ -0,0 +1,10 @@
+
+  CREATE TABLE team_resource_types (
+      team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+      name text NOT NULL,
+      config text NOT NULL,
+      nonce text,
+      PRIMARY KEY (team_id, name)
+  );
+
+  ALTER TABLE resource_types ADD COLUMN team_scoped boolean NOT NULL DEFAULT false;
@@ -0,0 +1,3 @@
+
+ALTER TABLE pipelines
+  DROP COLUMN awaited_check_builds;
@@ -0,0 +1,3 @@
+
+ALTER TABLE pipelines
+  ADD COLUMN awaited_check_builds integer[];
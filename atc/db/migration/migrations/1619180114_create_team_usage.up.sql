@@ -0,0 +1,9 @@
+
+  CREATE TABLE team_usage (
+      team_id integer PRIMARY KEY REFERENCES teams (id) ON DELETE CASCADE,
+      pipelines integer NOT NULL DEFAULT 0,
+      resources integer NOT NULL DEFAULT 0,
+      containers integer NOT NULL DEFAULT 0,
+      volumes integer NOT NULL DEFAULT 0,
+      updated_at timestamp with time zone NOT NULL DEFAULT now()
+  );
@@ -0,0 +1,6 @@
+
+  ALTER TABLE resource_pins DROP COLUMN IF EXISTS version_set_id;
+
+  DROP TABLE IF EXISTS pipeline_version_set_versions;
+
+  DROP TABLE IF EXISTS pipeline_version_sets;
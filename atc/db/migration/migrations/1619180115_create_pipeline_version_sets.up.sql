@@ -0,0 +1,18 @@
+
+  CREATE TABLE pipeline_version_sets (
+      id serial PRIMARY KEY,
+      pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+      name text NOT NULL,
+      created_at timestamp with time zone NOT NULL DEFAULT now(),
+      UNIQUE (pipeline_id, name)
+  );
+
+  CREATE TABLE pipeline_version_set_versions (
+      version_set_id integer NOT NULL REFERENCES pipeline_version_sets (id) ON DELETE CASCADE,
+      resource_id integer NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+      version jsonb NOT NULL,
+      PRIMARY KEY (version_set_id, resource_id)
+  );
+
+  ALTER TABLE resource_pins
+      ADD COLUMN version_set_id integer REFERENCES pipeline_version_sets (id) ON DELETE SET NULL;
@@ -0,0 +1,4 @@
+
+  DROP TABLE IF EXISTS build_protected_resource_config_scopes;
+
+  ALTER TABLE builds DROP COLUMN IF EXISTS protected;
@@ -0,0 +1,10 @@
+
+  ALTER TABLE builds ADD COLUMN protected boolean NOT NULL DEFAULT false;
+
+  CREATE TABLE build_protected_resource_config_scopes (
+      build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+      resource_config_scope_id integer NOT NULL REFERENCES resource_config_scopes (id) ON DELETE CASCADE,
+      PRIMARY KEY (build_id, resource_config_scope_id)
+  );
+
+  CREATE INDEX build_protected_resource_config_scopes_scope_id ON build_protected_resource_config_scopes (resource_config_scope_id);
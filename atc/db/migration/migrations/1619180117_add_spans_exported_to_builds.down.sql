@@ -0,0 +1,3 @@
+
+ALTER TABLE builds
+  DROP COLUMN IF EXISTS spans_exported;
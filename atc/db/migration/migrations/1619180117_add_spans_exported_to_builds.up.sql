@@ -0,0 +1,3 @@
+
+ALTER TABLE builds
+  ADD COLUMN spans_exported boolean NOT NULL DEFAULT false;
@@ -0,0 +1,3 @@
+
+ALTER TABLE jobs
+  DROP COLUMN IF EXISTS scheduler_decision;
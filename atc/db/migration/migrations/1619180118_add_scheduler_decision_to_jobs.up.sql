@@ -0,0 +1,3 @@
+
+ALTER TABLE jobs
+  ADD COLUMN scheduler_decision jsonb;
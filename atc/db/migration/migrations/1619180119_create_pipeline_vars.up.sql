@@ -0,0 +1,9 @@
+
+  CREATE TABLE pipeline_vars (
+      id serial PRIMARY KEY,
+      pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+      name text NOT NULL,
+      value text NOT NULL,
+      nonce text,
+      UNIQUE (pipeline_id, name)
+  );
@@ -0,0 +1,2 @@
+
+  ALTER TABLE pipelines DROP COLUMN IF EXISTS max_build_duration;
@@ -0,0 +1,2 @@
+
+  ALTER TABLE pipelines ADD COLUMN max_build_duration text;
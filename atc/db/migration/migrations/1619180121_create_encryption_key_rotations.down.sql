@@ -0,0 +1,2 @@
+
+  DROP TABLE encryption_key_rotations;
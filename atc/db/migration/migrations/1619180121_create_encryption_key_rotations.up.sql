@@ -0,0 +1,11 @@
+
+  CREATE TABLE encryption_key_rotations (
+      table_name text NOT NULL,
+      column_name text NOT NULL,
+      last_key text,
+      rotated bigint NOT NULL DEFAULT 0,
+      finished boolean NOT NULL DEFAULT false,
+      last_error text,
+      update_time timestamp with time zone DEFAULT now() NOT NULL,
+      PRIMARY KEY (table_name, column_name)
+  );
@@ -0,0 +1,10 @@
+
+  DROP INDEX worker_resource_caches_last_used_idx;
+
+  ALTER TABLE worker_resource_caches
+      DROP COLUMN last_used;
+
+  ALTER TABLE workers
+      DROP COLUMN disk_used_bytes,
+      DROP COLUMN disk_total_bytes,
+      DROP COLUMN disk_pressure;
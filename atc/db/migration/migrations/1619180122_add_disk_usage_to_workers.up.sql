@@ -0,0 +1,10 @@
+
+  ALTER TABLE workers
+      ADD COLUMN disk_used_bytes bigint,
+      ADD COLUMN disk_total_bytes bigint,
+      ADD COLUMN disk_pressure boolean NOT NULL DEFAULT false;
+
+  ALTER TABLE worker_resource_caches
+      ADD COLUMN last_used timestamp with time zone DEFAULT now() NOT NULL;
+
+  CREATE INDEX worker_resource_caches_last_used_idx ON worker_resource_caches (worker_name, last_used);
@@ -0,0 +1,4 @@
+
+  ALTER TABLE resource_config_scopes
+    DROP COLUMN last_scheduled_check_end_time,
+    DROP COLUMN last_scheduled_check_succeeded;
@@ -0,0 +1,4 @@
+
+  ALTER TABLE resource_config_scopes
+    ADD COLUMN last_scheduled_check_end_time timestamp with time zone,
+    ADD COLUMN last_scheduled_check_succeeded boolean NOT NULL DEFAULT false;
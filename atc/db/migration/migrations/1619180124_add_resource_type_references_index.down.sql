@@ -0,0 +1,6 @@
+
+  DROP INDEX resources_type_idx;
+  DROP INDEX resource_types_type_idx;
+  DROP INDEX resource_types_image_idx;
+
+  ALTER TABLE resource_types DROP COLUMN image;
@@ -0,0 +1,80 @@
+package migrations
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+func (m *migrations) Up_1619180124() error {
+	type resourceType struct {
+		id     int
+		config string
+		nonce  sql.NullString
+	}
+
+	tx := m.Tx
+
+	_, err := tx.Exec("ALTER TABLE resource_types ADD COLUMN image text")
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query("SELECT id, config, nonce FROM resource_types")
+	if err != nil {
+		return err
+	}
+
+	resourceTypes := []resourceType{}
+	for rows.Next() {
+
+		resourceType := resourceType{}
+		if err = rows.Scan(&resourceType.id, &resourceType.config, &resourceType.nonce); err != nil {
+			return err
+		}
+
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+
+	for _, resourceType := range resourceTypes {
+
+		var noncense *string
+		if resourceType.nonce.Valid {
+			noncense = &resourceType.nonce.String
+		}
+
+		decrypted, err := m.Strategy.Decrypt(resourceType.config, noncense)
+		if err != nil {
+			return err
+		}
+
+		var payload struct {
+			Source map[string]interface{} `json:"source"`
+		}
+
+		err = json.Unmarshal(decrypted, &payload)
+		if err != nil {
+			return err
+		}
+
+		repository, ok := payload.Source["repository"].(string)
+		if !ok || repository == "" {
+			continue
+		}
+
+		_, err = tx.Exec("UPDATE resource_types SET image = $1 WHERE id = $2", repository, resourceType.id)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX resource_types_image_idx ON resource_types (image);
+		CREATE INDEX resource_types_type_idx ON resource_types (type);
+		CREATE INDEX resources_type_idx ON resources (type);
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
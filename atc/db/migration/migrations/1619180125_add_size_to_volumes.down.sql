@@ -0,0 +1,3 @@
+
+  ALTER TABLE volumes
+      DROP COLUMN size;
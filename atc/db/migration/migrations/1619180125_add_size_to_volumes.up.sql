@@ -0,0 +1,3 @@
+
+  ALTER TABLE volumes
+      ADD COLUMN size bigint;
@@ -0,0 +1,2 @@
+
+  DROP TABLE resource_promoted_versions;
@@ -0,0 +1,8 @@
+
+  CREATE TABLE resource_promoted_versions (
+      resource_id integer NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+      version_md5 text NOT NULL
+  );
+
+  CREATE UNIQUE INDEX resource_promoted_versions_resource_id_version_md5_uniq
+      ON resource_promoted_versions (resource_id, version_md5);
@@ -0,0 +1,3 @@
+
+ALTER TABLE builds
+  DROP COLUMN rerun_latest_if_unavailable;
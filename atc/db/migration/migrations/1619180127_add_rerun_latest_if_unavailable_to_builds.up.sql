@@ -0,0 +1,3 @@
+
+ALTER TABLE builds
+  ADD COLUMN rerun_latest_if_unavailable boolean NOT NULL DEFAULT false;
@@ -0,0 +1,2 @@
+
+  DROP TABLE check_records;
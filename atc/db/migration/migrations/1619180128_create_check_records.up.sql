@@ -0,0 +1,12 @@
+
+  CREATE TABLE check_records (
+      id serial PRIMARY KEY,
+      resource_config_scope_id integer NOT NULL REFERENCES resource_config_scopes (id) ON DELETE CASCADE,
+      status text NOT NULL,
+      start_time timestamp with time zone DEFAULT now() NOT NULL,
+      end_time timestamp with time zone,
+      error text,
+      versions_added integer DEFAULT 0 NOT NULL
+  );
+
+  CREATE INDEX check_records_resource_config_scope_id_idx ON check_records (resource_config_scope_id, id);
@@ -0,0 +1,3 @@
+
+ALTER TABLE workers
+  ADD COLUMN runtime text;
@@ -0,0 +1,3 @@
+
+ALTER TABLE teams
+  DROP COLUMN max_concurrent_builds;
@@ -0,0 +1,3 @@
+
+ALTER TABLE teams
+  ADD COLUMN max_concurrent_builds integer NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+
+  ALTER TABLE resources
+    ADD COLUMN check_paused boolean NOT NULL DEFAULT false;
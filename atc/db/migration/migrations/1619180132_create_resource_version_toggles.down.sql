@@ -0,0 +1,2 @@
+
+  DROP TABLE resource_version_toggles;
@@ -0,0 +1,16 @@
+
+  CREATE TABLE resource_version_toggles (
+      id serial PRIMARY KEY,
+      resource_id integer REFERENCES resources (id) ON DELETE SET NULL,
+      team_name text NOT NULL,
+      pipeline_name text NOT NULL,
+      pipeline_instance_vars jsonb,
+      resource_name text NOT NULL,
+      version jsonb NOT NULL,
+      enabled boolean NOT NULL,
+      actor text NOT NULL,
+      reason text,
+      toggle_time timestamp with time zone DEFAULT now() NOT NULL
+  );
+
+  CREATE INDEX resource_version_toggles_resource_id_idx ON resource_version_toggles (resource_id, id);
@@ -0,0 +1,3 @@
+
+  ALTER TABLE resource_config_versions
+    ADD COLUMN created_at timestamp with time zone NOT NULL DEFAULT now();
@@ -0,0 +1,3 @@
+
+  ALTER TABLE pipeline_vars
+    DROP COLUMN value_hash;
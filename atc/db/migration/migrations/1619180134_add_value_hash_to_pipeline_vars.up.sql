@@ -0,0 +1,3 @@
+
+  ALTER TABLE pipeline_vars
+    ADD COLUMN value_hash text;
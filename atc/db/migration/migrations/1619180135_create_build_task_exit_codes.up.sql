@@ -0,0 +1,8 @@
+
+  CREATE TABLE build_task_exit_codes (
+      build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+      plan_id text NOT NULL,
+      name text NOT NULL,
+      exit_code integer NOT NULL,
+      PRIMARY KEY (build_id, plan_id)
+  );
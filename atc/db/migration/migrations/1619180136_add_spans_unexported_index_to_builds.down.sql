@@ -0,0 +1,2 @@
+
+  DROP INDEX IF EXISTS builds_spans_unexported;
@@ -0,0 +1,3 @@
+
+  CREATE INDEX builds_spans_unexported ON builds (id)
+    WHERE completed AND NOT spans_exported AND resource_id IS NULL AND resource_type_id IS NULL;
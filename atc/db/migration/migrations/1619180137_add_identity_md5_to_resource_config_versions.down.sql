@@ -0,0 +1,8 @@
+
+  ALTER TABLE resource_config_scopes
+    DROP COLUMN IF EXISTS identity_fields;
+
+  DROP INDEX IF EXISTS resource_config_versions_identity;
+
+  ALTER TABLE resource_config_versions
+    DROP COLUMN IF EXISTS identity_md5;
@@ -0,0 +1,9 @@
+
+  ALTER TABLE resource_config_versions
+    ADD COLUMN identity_md5 text;
+
+  CREATE INDEX resource_config_versions_identity ON resource_config_versions (resource_config_scope_id, identity_md5)
+    WHERE identity_md5 IS NOT NULL;
+
+  ALTER TABLE resource_config_scopes
+    ADD COLUMN identity_fields jsonb;
@@ -0,0 +1,5 @@
+
+  DROP TRIGGER IF EXISTS deleted_events_archives_update_trigger ON builds;
+  DROP TRIGGER IF EXISTS deleted_events_archives_delete_trigger ON builds;
+  DROP FUNCTION IF EXISTS on_events_archive_delete();
+  DROP TABLE IF EXISTS deleted_events_archives;
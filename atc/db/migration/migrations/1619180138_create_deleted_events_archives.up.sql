@@ -0,0 +1,24 @@
+
+  CREATE TABLE deleted_events_archives (
+    key text PRIMARY KEY,
+    deleted_at timestamp with time zone DEFAULT now() NOT NULL
+  );
+
+  CREATE OR REPLACE FUNCTION on_events_archive_delete() RETURNS TRIGGER AS $$
+  BEGIN
+    INSERT INTO deleted_events_archives (key) VALUES (OLD.events_archive_key) ON CONFLICT DO NOTHING;
+    RETURN NULL;
+  END;
+  $$ LANGUAGE plpgsql;
+
+  CREATE TRIGGER deleted_events_archives_delete_trigger
+    AFTER DELETE ON builds
+    FOR EACH ROW
+    WHEN (OLD.events_archive_key IS NOT NULL)
+    EXECUTE PROCEDURE on_events_archive_delete();
+
+  CREATE TRIGGER deleted_events_archives_update_trigger
+    AFTER UPDATE OF events_archive_key ON builds
+    FOR EACH ROW
+    WHEN (OLD.events_archive_key IS NOT NULL AND OLD.events_archive_key IS DISTINCT FROM NEW.events_archive_key)
+    EXECUTE PROCEDURE on_events_archive_delete();
@@ -0,0 +1,2 @@
+
+  DROP TABLE build_server_logs;
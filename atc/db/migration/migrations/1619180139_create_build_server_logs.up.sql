@@ -0,0 +1,8 @@
+
+  CREATE TABLE build_server_logs (
+      id bigserial PRIMARY KEY,
+      build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+      log jsonb NOT NULL
+  );
+
+  CREATE INDEX build_server_logs_build_id ON build_server_logs (build_id);
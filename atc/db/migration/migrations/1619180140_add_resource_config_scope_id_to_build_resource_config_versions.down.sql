@@ -0,0 +1,6 @@
+
+  ALTER TABLE build_resource_config_version_inputs
+    DROP COLUMN resource_config_scope_id;
+
+  ALTER TABLE build_resource_config_version_outputs
+    DROP COLUMN resource_config_scope_id;
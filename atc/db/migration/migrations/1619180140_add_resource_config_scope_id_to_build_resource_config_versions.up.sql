@@ -0,0 +1,6 @@
+
+  ALTER TABLE build_resource_config_version_inputs
+    ADD COLUMN resource_config_scope_id integer;
+
+  ALTER TABLE build_resource_config_version_outputs
+    ADD COLUMN resource_config_scope_id integer;
@@ -20,6 +20,7 @@ type Executor interface {
 	Exec(statement string, args ...interface{}) (sql.Result, error)
 }
 
+//counterfeiter:generate . NotificationsBus
 type NotificationsBus interface {
 	Notify(channel string) error
 	Listen(channel string) (chan bool, error)
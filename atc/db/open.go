@@ -63,8 +63,29 @@ type Tx interface {
 }
 
 func Open(logger lager.Logger, driver, dsn string, newKey, oldKey *encryption.Key, name string, lockFactory lock.LockFactory) (Conn, error) {
+	sqlDB, err := open(logger, migration.NewOpenHelper(driver, dsn, lockFactory, newKey, oldKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewConn(name, sqlDB, dsn, oldKey, newKey), nil
+}
+
+// OpenWithOnlineKeyRotation opens the database without re-encrypting the data
+// encrypted with the old key, leaving that to an EncryptionKeyRotation. Until
+// then, data is decrypted with either key.
+func OpenWithOnlineKeyRotation(logger lager.Logger, driver, dsn string, newKey, oldKey *encryption.Key, name string, lockFactory lock.LockFactory) (Conn, error) {
+	sqlDB, err := open(logger, migration.NewOpenHelper(driver, dsn, lockFactory, newKey, nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewConn(name, sqlDB, dsn, oldKey, newKey), nil
+}
+
+func open(logger lager.Logger, helper *migration.OpenHelper) (*sql.DB, error) {
 	for {
-		sqlDB, err := migration.NewOpenHelper(driver, dsn, lockFactory, newKey, oldKey).Open()
+		sqlDB, err := helper.Open()
 		if err != nil {
 			if shouldRetry(err) {
 				logger.Error("failed-to-open-db-retrying", err)
@@ -75,7 +96,7 @@ func Open(logger lager.Logger, driver, dsn string, newKey, oldKey *encryption.Ke
 			return nil, err
 		}
 
-		return NewConn(name, sqlDB, dsn, oldKey, newKey), nil
+		return sqlDB, nil
 	}
 }
 
@@ -83,7 +104,10 @@ func NewConn(name string, sqlDB *sql.DB, dsn string, oldKey, newKey *encryption.
 	listener := pq.NewDialListener(keepAliveDialer{}, dsn, time.Second, time.Minute, nil)
 
 	var strategy encryption.Strategy
-	if newKey != nil {
+	if newKey != nil && oldKey != nil {
+		// anything not yet rotated to the new key is decrypted with the old one
+		strategy = encryption.NewFallbackStrategy(newKey, oldKey)
+	} else if newKey != nil {
 		strategy = newKey
 	} else {
 		strategy = encryption.NewNoEncryption()
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"crypto/sha256"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 
 	"github.com/concourse/concourse/atc"
@@ -45,6 +47,9 @@ type Pipeline interface {
 	Groups() atc.GroupConfigs
 	VarSources() atc.VarSourceConfigs
 	Display() *atc.DisplayConfig
+	TaskDefaults() *atc.TaskDefaults
+	Webhooks() atc.WebhookConfigs
+	MaxBuildDuration() string
 	ConfigVersion() ConfigVersion
 	Config() (atc.Config, error)
 	Public() bool
@@ -67,6 +72,9 @@ type Pipeline interface {
 
 	BuildsWithTime(page Page) ([]Build, Pagination, error)
 
+	PendingChecks() ([]PendingCheck, error)
+	SerialGroups() ([]SerialGroup, error)
+
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
 
 	LoadDebugVersionsDB() (*atc.DebugVersionsDB, error)
@@ -88,32 +96,45 @@ type Pipeline interface {
 
 	Pause() error
 	Unpause() error
+	UnpauseAwaitingChecks(checkBuildIDs []int) error
 
 	Archive() error
 
+	SaveVersionSet(name string) (VersionSet, error)
+	VersionSet(name string) (VersionSet, bool, error)
+	VersionSets() ([]VersionSet, error)
+
 	Destroy() error
 
 	Variables(lager.Logger, creds.Secrets, creds.VarSourcePool) (vars.Variables, error)
 
+	Vars() (vars.StaticVariables, error)
+	VarNames() ([]string, error)
+	SetVar(name string, value interface{}, create bool) (bool, error)
+	DeleteVar(name string) (bool, error)
+
 	SetParentIDs(jobID, buildID int) error
 }
 
 type pipeline struct {
-	id            int
-	name          string
-	teamID        int
-	teamName      string
-	instanceVars  atc.InstanceVars
-	parentJobID   int
-	parentBuildID int
-	groups        atc.GroupConfigs
-	varSources    atc.VarSourceConfigs
-	display       *atc.DisplayConfig
-	configVersion ConfigVersion
-	paused        bool
-	public        bool
-	archived      bool
-	lastUpdated   time.Time
+	id               int
+	name             string
+	teamID           int
+	teamName         string
+	instanceVars     atc.InstanceVars
+	parentJobID      int
+	parentBuildID    int
+	groups           atc.GroupConfigs
+	varSources       atc.VarSourceConfigs
+	display          *atc.DisplayConfig
+	taskDefaults     *atc.TaskDefaults
+	webhooks         atc.WebhookConfigs
+	maxBuildDuration string
+	configVersion    ConfigVersion
+	paused           bool
+	public           bool
+	archived         bool
+	lastUpdated      time.Time
 
 	conn        Conn
 	lockFactory lock.LockFactory
@@ -128,6 +149,10 @@ var pipelinesQuery = psql.Select(`
 		p.groups,
 		p.var_sources,
 		p.display,
+		p.task_defaults,
+		p.webhooks,
+		p.webhooks_nonce,
+		p.max_build_duration,
 		p.nonce,
 		p.version,
 		p.team_id,
@@ -161,6 +186,9 @@ func (p *pipeline) Groups() atc.GroupConfigs       { return p.groups }
 
 func (p *pipeline) VarSources() atc.VarSourceConfigs { return p.varSources }
 func (p *pipeline) Display() *atc.DisplayConfig      { return p.display }
+func (p *pipeline) TaskDefaults() *atc.TaskDefaults  { return p.taskDefaults }
+func (p *pipeline) Webhooks() atc.WebhookConfigs     { return p.webhooks }
+func (p *pipeline) MaxBuildDuration() string         { return p.maxBuildDuration }
 func (p *pipeline) ConfigVersion() ConfigVersion     { return p.configVersion }
 func (p *pipeline) Public() bool                     { return p.public }
 func (p *pipeline) Paused() bool                     { return p.paused }
@@ -275,6 +303,10 @@ func (p *pipeline) Config() (atc.Config, error) {
 		ResourceTypes: resourceTypes.Configs(),
 		Jobs:          jobConfigs,
 		Display:       p.Display(),
+		TaskDefaults:  p.TaskDefaults(),
+		Webhooks:      p.Webhooks(),
+
+		MaxBuildDuration: p.MaxBuildDuration(),
 	}
 
 	return config, nil
@@ -478,6 +510,173 @@ func (p *pipeline) BuildsWithTime(page Page) ([]Build, Pagination, error) {
 		buildsQuery.Where(sq.Eq{"b.pipeline_id": p.id}), minMaxIdQuery, page, p.conn, p.lockFactory)
 }
 
+// PendingCheck is a check build of one of the pipeline's resources or resource
+// types which has yet to finish.
+type PendingCheck struct {
+	BuildID           int
+	ResourceName      string
+	ResourceTypeName  string
+	CreateTime        time.Time
+	ManuallyTriggered bool
+
+	// Running is set once the check has started, as opposed to still waiting
+	// on the rate limit or on another check of the same scope.
+	Running bool
+}
+
+func (p *pipeline) PendingChecks() ([]PendingCheck, error) {
+	rows, err := psql.Select(
+		"b.id",
+		"b.create_time",
+		"b.manually_triggered",
+		"r.name",
+		"rt.name",
+		"COALESCE(rs.last_check_start_time, rts.last_check_start_time) >= b.create_time",
+	).
+		From("builds b").
+		LeftJoin("resources r ON r.id = b.resource_id").
+		LeftJoin("resource_config_scopes rs ON rs.id = r.resource_config_scope_id").
+		LeftJoin("resource_types rt ON rt.id = b.resource_type_id").
+		LeftJoin("resource_config_scopes rts ON rts.resource_config_id = rt.resource_config_id AND rts.resource_id IS NULL").
+		Where(sq.Eq{
+			"b.pipeline_id": p.id,
+			"b.name":        CheckBuildName,
+			"b.completed":   false,
+		}).
+		Where(sq.Or{
+			sq.NotEq{"b.resource_id": nil},
+			sq.NotEq{"b.resource_type_id": nil},
+		}).
+		OrderBy("b.id ASC").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var checks []PendingCheck
+	for rows.Next() {
+		var check PendingCheck
+		var resourceName, resourceTypeName sql.NullString
+		var running sql.NullBool
+
+		err := rows.Scan(&check.BuildID, &check.CreateTime, &check.ManuallyTriggered, &resourceName, &resourceTypeName, &running)
+		if err != nil {
+			return nil, err
+		}
+
+		check.ResourceName = resourceName.String
+		check.ResourceTypeName = resourceTypeName.String
+		check.Running = running.Bool
+
+		checks = append(checks, check)
+	}
+
+	return checks, rows.Err()
+}
+
+type SerialGroup struct {
+	Name string
+	Jobs []string
+
+	// Running are the builds holding the serial group, and Pending are the
+	// builds waiting for it, in the order the scheduler considers them.
+	Running []Build
+	Pending []Build
+}
+
+func (p *pipeline) SerialGroups() ([]SerialGroup, error) {
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := psql.Select("jsg.serial_group", "j.name").
+		From("jobs_serial_groups jsg").
+		Join("jobs j ON j.id = jsg.job_id").
+		Where(sq.Eq{
+			"j.pipeline_id": p.id,
+			"j.active":      true,
+		}).
+		OrderBy("jsg.serial_group ASC", "j.name ASC").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []SerialGroup
+	for rows.Next() {
+		var group, job string
+		err = rows.Scan(&group, &job)
+		if err != nil {
+			Close(rows)
+			return nil, err
+		}
+
+		if len(groups) == 0 || groups[len(groups)-1].Name != group {
+			groups = append(groups, SerialGroup{Name: group})
+		}
+
+		last := &groups[len(groups)-1]
+		last.Jobs = append(last.Jobs, job)
+	}
+
+	Close(rows)
+
+	for i := range groups {
+		err = p.serialGroupBuilds(tx, &groups[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (p *pipeline) serialGroupBuilds(tx Tx, group *SerialGroup) error {
+	rows, err := buildsQuery.
+		Join("jobs_serial_groups jsg ON j.id = jsg.job_id").
+		Where(sq.Eq{
+			"jsg.serial_group": group.Name,
+			"j.pipeline_id":    p.id,
+			"b.completed":      false,
+		}).
+		OrderBy("COALESCE(b.rerun_of, b.id) ASC", "b.id ASC").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		build := newEmptyBuild(p.conn, p.lockFactory)
+		err = scanBuild(build, rows, p.conn.EncryptionStrategy())
+		if err != nil {
+			return err
+		}
+
+		if build.IsScheduled() {
+			group.Running = append(group.Running, build)
+		} else {
+			group.Pending = append(group.Pending, build)
+		}
+	}
+
+	return rows.Err()
+}
+
 func (p *pipeline) Resources() (Resources, error) {
 	return resources(p.id, p.conn, p.lockFactory)
 }
@@ -617,6 +816,16 @@ func (p *pipeline) Pause() error {
 }
 
 func (p *pipeline) Unpause() error {
+	return p.unpause(nil)
+}
+
+// UnpauseAwaitingChecks unpauses the pipeline, holding off the scheduling of
+// its jobs until the given check builds of their inputs have completed.
+func (p *pipeline) UnpauseAwaitingChecks(checkBuildIDs []int) error {
+	return p.unpause(checkBuildIDs)
+}
+
+func (p *pipeline) unpause(awaitedCheckBuildIDs []int) error {
 	tx, err := p.conn.Begin()
 	if err != nil {
 		return err
@@ -624,8 +833,14 @@ func (p *pipeline) Unpause() error {
 
 	defer Rollback(tx)
 
+	var awaitedCheckBuilds interface{}
+	if len(awaitedCheckBuildIDs) != 0 {
+		awaitedCheckBuilds = pq.Array(awaitedCheckBuildIDs)
+	}
+
 	_, err = psql.Update("pipelines").
 		Set("paused", false).
+		Set("awaited_check_builds", awaitedCheckBuilds).
 		Where(sq.Eq{
 			"id": p.id,
 		}).
@@ -964,7 +1179,7 @@ func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 
 	_, err = tx.Exec(`
 		UPDATE builds
-		SET reap_time = now()
+		SET reap_time = now(), events_archive_key = NULL
 		WHERE id IN (`+strings.Join(indexStrings, ",")+`)
 	`, interfaceBuildIDs...)
 	if err != nil {
@@ -1061,15 +1276,22 @@ func (p *pipeline) CreateStartedBuild(plan atc.Plan) (Build, error) {
 }
 
 // Variables creates variables for this pipeline. If this pipeline has its own
-// var_sources, a vars.MultiVars containing all pipeline specific var_sources
-// plug the global variables, otherwise just return the global variables.
+// var_sources or local vars, a vars.MultiVars containing all pipeline specific
+// var_sources plus the global variables and then its local vars, otherwise
+// just return the global variables. Local vars only fill in what the
+// credential manager doesn't have, so they can't shadow its secrets.
 func (p *pipeline) Variables(logger lager.Logger, globalSecrets creds.Secrets, varSourcePool creds.VarSourcePool) (vars.Variables, error) {
 	globalVars := creds.NewVariables(globalSecrets, p.TeamName(), p.Name(), false)
 	namedVarsMap := vars.NamedVariables{}
 
+	localVars, err := p.Vars()
+	if err != nil {
+		return nil, err
+	}
+
 	// It's safe to add NamedVariables to allVars via an array here, because
 	// a map is passed by reference.
-	allVars := vars.NewMultiVars([]vars.Variables{namedVarsMap, globalVars})
+	allVars := vars.NewMultiVars([]vars.Variables{namedVarsMap, globalVars, localVars})
 
 	orderedVarSources, err := p.varSources.OrderByDependency()
 	if err != nil {
@@ -1099,15 +1321,195 @@ func (p *pipeline) Variables(logger lager.Logger, globalSecrets creds.Secrets, v
 		namedVarsMap[cm.Name] = creds.NewVariables(secrets, p.TeamName(), p.Name(), true)
 	}
 
-	// If there is no var_source from the pipeline, then just return the global
-	// vars.
-	if len(namedVarsMap) == 0 {
+	// If there is no var_source nor local var from the pipeline, then just
+	// return the global vars.
+	if len(namedVarsMap) == 0 && len(localVars) == 0 {
 		return globalVars, nil
 	}
 
 	return allVars, nil
 }
 
+// Vars returns the local vars of the pipeline, which are set through SetVar
+// rather than its config.
+func (p *pipeline) Vars() (vars.StaticVariables, error) {
+	rows, err := psql.Select("name", "value", "nonce").
+		From("pipeline_vars").
+		Where(sq.Eq{"pipeline_id": p.id}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	es := p.conn.EncryptionStrategy()
+
+	localVars := vars.StaticVariables{}
+	for rows.Next() {
+		var (
+			name      string
+			encrypted string
+			nonce     sql.NullString
+		)
+
+		err = rows.Scan(&name, &encrypted, &nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		var noncense *string
+		if nonce.Valid {
+			noncense = &nonce.String
+		}
+
+		decrypted, err := es.Decrypt(encrypted, noncense)
+		if err != nil {
+			return nil, err
+		}
+
+		var value interface{}
+		err = json.Unmarshal(decrypted, &value)
+		if err != nil {
+			return nil, err
+		}
+
+		localVars[name] = value
+	}
+
+	return localVars, nil
+}
+
+// VarNames returns the names of the local vars of the pipeline, without
+// decrypting their values.
+func (p *pipeline) VarNames() ([]string, error) {
+	rows, err := psql.Select("name").
+		From("pipeline_vars").
+		Where(sq.Eq{"pipeline_id": p.id}).
+		OrderBy("name").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// SetVar sets a local var of the pipeline. The value is encrypted with the
+// configured encryption strategy. Unless create is true, a var the pipeline
+// doesn't have yet is not set, which is reported by returning false.
+func (p *pipeline) SetVar(name string, value interface{}, create bool) (bool, error) {
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	set, err := setPipelineVar(tx, p.id, name, value, create)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return set, nil
+}
+
+// setPipelineVar sets a local var of the pipeline with the given id. The var
+// is identified by the hash of its value, the way a resource config is
+// identified by the hash of its source, so setting the value the var already
+// has writes nothing.
+func setPipelineVar(tx Tx, pipelineID int, name string, value interface{}, create bool) (bool, error) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+
+	valueHash := fmt.Sprintf("%x", sha256.Sum256(payload))
+
+	var currentHash sql.NullString
+	err = psql.Select("value_hash").
+		From("pipeline_vars").
+		Where(sq.Eq{
+			"pipeline_id": pipelineID,
+			"name":        name,
+		}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&currentHash)
+	if err != nil && err != sql.ErrNoRows {
+		return false, err
+	}
+
+	found := err == nil
+	if !found && !create {
+		return false, nil
+	}
+
+	if found && currentHash.String == valueHash {
+		return true, nil
+	}
+
+	encrypted, nonce, err := tx.EncryptionStrategy().Encrypt(payload)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = psql.Insert("pipeline_vars").
+		Columns("pipeline_id", "name", "value", "nonce", "value_hash").
+		Values(pipelineID, name, encrypted, nonce, valueHash).
+		Suffix("ON CONFLICT (pipeline_id, name) DO UPDATE SET value = EXCLUDED.value, nonce = EXCLUDED.nonce, value_hash = EXCLUDED.value_hash").
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// DeleteVar deletes a local var of the pipeline, returning false if the
+// pipeline doesn't have it.
+func (p *pipeline) DeleteVar(name string) (bool, error) {
+	result, err := psql.Delete("pipeline_vars").
+		Where(sq.Eq{
+			"pipeline_id": p.id,
+			"name":        name,
+		}).
+		RunWith(p.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
 func (p *pipeline) SetParentIDs(jobID, buildID int) error {
 	if jobID <= 0 || buildID <= 0 {
 		return errors.New("job and build id cannot be negative or zero-value")
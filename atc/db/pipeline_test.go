@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"time"
@@ -29,6 +30,8 @@ var _ = Describe("Pipeline", func() {
 		pipelineConfig atc.Config
 	)
 
+	defaultMemoryLimit := atc.MemoryLimit(1024)
+
 	BeforeEach(func() {
 		var err error
 		team, err = teamFactory.CreateTeam(atc.Team{Name: "some-team"})
@@ -54,6 +57,19 @@ var _ = Describe("Pipeline", func() {
 			Display: &atc.DisplayConfig{
 				BackgroundImage: "background.jpg",
 			},
+			TaskDefaults: &atc.TaskDefaults{
+				Limits: &atc.ContainerLimits{
+					Memory: &defaultMemoryLimit,
+				},
+			},
+			Webhooks: atc.WebhookConfigs{
+				{
+					Name:    "some-webhook",
+					URL:     "https://example.com/hook",
+					Headers: map[string]string{"Authorization": "Bearer ((token))"},
+				},
+			},
+			MaxBuildDuration: "2h",
 			Jobs: atc.JobConfigs{
 				{
 					Name: "job-name",
@@ -414,6 +430,86 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("UnpauseAwaitingChecks", func() {
+		var (
+			job   db.Job
+			build db.Build
+		)
+
+		BeforeEach(func() {
+			Expect(pipeline.Pause()).To(Succeed())
+
+			var found bool
+			var err error
+			job, found, err = pipeline.Job("job-name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resource, found, err := pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			var created bool
+			build, created, err = resource.CreateBuild(context.TODO(), true, atc.Plan{
+				ID:    "some-id",
+				Check: &atc.CheckPlan{Name: "some-resource"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			Expect(pipeline.UnpauseAwaitingChecks([]int{build.ID()})).To(Succeed())
+
+			found, err = pipeline.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("unpauses the pipeline", func() {
+			Expect(pipeline.Paused()).To(BeFalse())
+		})
+
+		It("makes jobs with inputs being checked await the checks", func() {
+			awaiting, err := job.AwaitingResourceChecks()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(awaiting).To(BeTrue())
+		})
+
+		It("does not make jobs without such inputs await the checks", func() {
+			otherJob, found, err := pipeline.Job("some-other-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			awaiting, err := otherJob.AwaitingResourceChecks()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(awaiting).To(BeFalse())
+		})
+
+		Context("when the checks complete", func() {
+			BeforeEach(func() {
+				Expect(build.Finish(db.BuildStatusSucceeded)).To(Succeed())
+			})
+
+			It("stops awaiting them", func() {
+				awaiting, err := job.AwaitingResourceChecks()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(awaiting).To(BeFalse())
+			})
+		})
+
+		Context("when the pipeline is unpaused again without waiting", func() {
+			BeforeEach(func() {
+				Expect(pipeline.Pause()).To(Succeed())
+				Expect(pipeline.Unpause()).To(Succeed())
+			})
+
+			It("stops awaiting the checks", func() {
+				awaiting, err := job.AwaitingResourceChecks()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(awaiting).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("Resource Config Versions", func() {
 		resourceName := "some-resource"
 		otherResourceName := "some-other-resource"
@@ -1032,7 +1128,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(found).To(BeFalse())
 
 			By("including disabled versions")
-			err = resource.DisableVersion(savedVR2.ID())
+			err = resource.DisableVersion(savedVR2.ID(), "", "")
 			Expect(err).ToNot(HaveOccurred())
 
 			latestVR, found, err := resource.FindVersion(atc.Version{"version": "2"})
@@ -1880,7 +1976,7 @@ var _ = Describe("Pipeline", func() {
 
 		Context("when a resource is not enabled", func() {
 			BeforeEach(func() {
-				err := scenario.Resource("some-resource").DisableVersion(resourceConfigVersion.ID())
+				err := scenario.Resource("some-resource").DisableVersion(resourceConfigVersion.ID(), "", "")
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -2107,6 +2203,133 @@ var _ = Describe("Pipeline", func() {
 				Expect(v.(string)).To(Equal("pv"))
 			})
 		})
+
+		Context("with local vars", func() {
+			BeforeEach(func() {
+				_, err := pipeline.SetVar("gk", "local-gv", true)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = pipeline.SetVar("lk", "local-lv", true)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not let them shadow the global ones", func() {
+				v, found, err := pvars.Get(vars.Reference{Path: "gk"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(v).To(Equal("gv"))
+			})
+
+			It("gets them when the global ones don't have them", func() {
+				v, found, err := pvars.Get(vars.Reference{Path: "lk"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(v).To(Equal("local-lv"))
+			})
+		})
+	})
+
+	Describe("SetVar", func() {
+		It("does not set a var the pipeline doesn't have", func() {
+			updated, err := pipeline.SetVar("some-var", "some-value", false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(BeFalse())
+
+			localVars, err := pipeline.Vars()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(localVars).To(BeEmpty())
+		})
+
+		Context("when creating the var", func() {
+			It("sets it", func() {
+				updated, err := pipeline.SetVar("some-var", "some-value", true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updated).To(BeTrue())
+
+				localVars, err := pipeline.Vars()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(localVars).To(Equal(vars.StaticVariables{"some-var": "some-value"}))
+			})
+		})
+
+		Context("when the pipeline has the var", func() {
+			BeforeEach(func() {
+				_, err := pipeline.SetVar("some-var", "some-value", true)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("updates it", func() {
+				updated, err := pipeline.SetVar("some-var", map[string]interface{}{"password": "new-value"}, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updated).To(BeTrue())
+
+				localVars, err := pipeline.Vars()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(localVars).To(Equal(vars.StaticVariables{
+					"some-var": map[string]interface{}{"password": "new-value"},
+				}))
+			})
+
+			It("does not set it on the other pipelines", func() {
+				localVars, err := defaultPipeline.Vars()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(localVars).To(BeEmpty())
+			})
+
+			It("writes nothing when the value is unchanged", func() {
+				var before string
+				err := dbConn.QueryRow(`SELECT value FROM pipeline_vars WHERE pipeline_id = $1 AND name = 'some-var'`, pipeline.ID()).Scan(&before)
+				Expect(err).ToNot(HaveOccurred())
+
+				updated, err := pipeline.SetVar("some-var", "some-value", false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updated).To(BeTrue())
+
+				var after string
+				err = dbConn.QueryRow(`SELECT value FROM pipeline_vars WHERE pipeline_id = $1 AND name = 'some-var'`, pipeline.ID()).Scan(&after)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(after).To(Equal(before))
+			})
+		})
+	})
+
+	Describe("VarNames", func() {
+		It("lists the names of the pipeline's vars", func() {
+			_, err := pipeline.SetVar("some-var", "some-value", true)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = pipeline.SetVar("another-var", "another-value", true)
+			Expect(err).ToNot(HaveOccurred())
+
+			names, err := pipeline.VarNames()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(names).To(Equal([]string{"another-var", "some-var"}))
+
+			names, err = defaultPipeline.VarNames()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(names).To(BeEmpty())
+		})
+	})
+
+	Describe("DeleteVar", func() {
+		It("does nothing when the pipeline doesn't have the var", func() {
+			deleted, err := pipeline.DeleteVar("some-var")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+		})
+
+		It("deletes the var", func() {
+			_, err := pipeline.SetVar("some-var", "some-value", true)
+			Expect(err).ToNot(HaveOccurred())
+
+			deleted, err := pipeline.DeleteVar("some-var")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			localVars, err := pipeline.Vars()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(localVars).To(BeEmpty())
+		})
 	})
 
 	Describe("SetParentIDs", func() {
@@ -17,6 +17,8 @@ import (
 )
 
 var ErrPinnedThroughConfig = errors.New("resource is pinned through config")
+var ErrVersionDisabled = errors.New("resource version is disabled")
+var ErrResourceNotChecked = errors.New("resource has not been checked yet")
 
 const CheckBuildName = "check"
 
@@ -33,8 +35,17 @@ type Resource interface {
 	Source() atc.Source
 	CheckEvery() *atc.CheckEvery
 	CheckTimeout() string
+	CheckOnDemand() bool
 	LastCheckStartTime() time.Time
 	LastCheckEndTime() time.Time
+	LastCheckContainerHandle() string
+	LastCheckSucceeded() bool
+	LastCheckSuccessTime() time.Time
+	CheckFailures() int
+	CheckTTL() time.Duration
+	LastScheduledCheckEndTime() time.Time
+	LastScheduledCheckSucceeded() bool
+	CheckPaused() bool
 	Tags() atc.Tags
 	WebhookToken() string
 	Config() atc.ResourceConfig
@@ -48,18 +59,29 @@ type Resource interface {
 
 	HasWebhook() bool
 
+	OnDemandCheckNeeded() (bool, error)
+
 	CurrentPinnedVersion() atc.Version
 
 	BuildSummary() *atc.BuildSummary
 
 	Versions(page Page, versionFilter atc.Version) ([]atc.ResourceVersion, Pagination, bool, error)
+	CheckRecords(page Page) ([]CheckRecord, Pagination, error)
 	FindVersion(filter atc.Version) (ResourceConfigVersion, bool, error) // Only used in tests!!
 	UpdateMetadata(atc.Version, ResourceConfigMetadataFields) (bool, error)
+	BackfillVersions([]atc.Version) (int, error)
+
+	PauseChecking() error
+	UnpauseChecking() error
 
-	EnableVersion(rcvID int) error
-	DisableVersion(rcvID int) error
+	EnableVersion(rcvID int, actor string, reason string) error
+	DisableVersion(rcvID int, actor string, reason string) error
+	VersionToggles() ([]VersionToggle, error)
 
-	PinVersion(rcvID int) (bool, error)
+	PromoteVersion(rcvID int) (bool, error)
+	UnpromoteVersion(rcvID int) (bool, error)
+
+	PinVersion(rcvID int, comment string) (bool, error)
 	UnpinVersion() error
 
 	SetResourceConfigScope(ResourceConfigScope) error
@@ -80,6 +102,13 @@ var (
 		"r.config",
 		"rs.last_check_start_time",
 		"rs.last_check_end_time",
+		"rs.last_check_container_handle",
+		"rs.last_check_succeeded",
+		"rs.last_check_success_time",
+		"rs.check_failures",
+		"rs.last_scheduled_check_end_time",
+		"rs.last_scheduled_check_succeeded",
+		"r.check_paused",
 		"r.pipeline_id",
 		"r.nonce",
 		"r.resource_config_id",
@@ -116,6 +145,13 @@ type resource struct {
 	type_                 string
 	lastCheckStartTime    time.Time
 	lastCheckEndTime      time.Time
+	lastCheckHandle       string
+	lastCheckSucceeded    bool
+	lastCheckSuccessTime  time.Time
+	checkFailures         int
+	lastScheduledCheckEnd time.Time
+	lastScheduledCheckOK  bool
+	checkPaused           bool
 	config                atc.ResourceConfig
 	configPinnedVersion   atc.Version
 	apiPinnedVersion      atc.Version
@@ -166,8 +202,13 @@ func (r *resource) Type() string                     { return r.type_ }
 func (r *resource) Source() atc.Source               { return r.config.Source }
 func (r *resource) CheckEvery() *atc.CheckEvery      { return r.config.CheckEvery }
 func (r *resource) CheckTimeout() string             { return r.config.CheckTimeout }
+func (r *resource) CheckOnDemand() bool              { return r.config.CheckOnDemand }
 func (r *resource) LastCheckStartTime() time.Time    { return r.lastCheckStartTime }
 func (r *resource) LastCheckEndTime() time.Time      { return r.lastCheckEndTime }
+func (r *resource) LastCheckContainerHandle() string { return r.lastCheckHandle }
+func (r *resource) LastCheckSucceeded() bool         { return r.lastCheckSucceeded }
+func (r *resource) LastCheckSuccessTime() time.Time  { return r.lastCheckSuccessTime }
+func (r *resource) CheckFailures() int               { return r.checkFailures }
 func (r *resource) Tags() atc.Tags                   { return r.config.Tags }
 func (r *resource) WebhookToken() string             { return r.config.WebhookToken }
 func (r *resource) Config() atc.ResourceConfig       { return r.config }
@@ -180,6 +221,57 @@ func (r *resource) Icon() string                     { return r.config.Icon }
 
 func (r *resource) HasWebhook() bool { return r.WebhookToken() != "" }
 
+func (r *resource) LastScheduledCheckEndTime() time.Time { return r.lastScheduledCheckEnd }
+func (r *resource) LastScheduledCheckSucceeded() bool    { return r.lastScheduledCheckOK }
+func (r *resource) CheckPaused() bool                    { return r.checkPaused }
+
+// CheckTTL returns the resource's check TTL, or 0 if it has none. The TTL is
+// validated when the pipeline is set, so an invalid one is treated as unset.
+func (r *resource) CheckTTL() time.Duration {
+	if r.config.CheckTTL == "" {
+		return 0
+	}
+
+	ttl, err := time.ParseDuration(r.config.CheckTTL)
+	if err != nil {
+		return 0
+	}
+
+	return ttl
+}
+
+// OnDemandCheckNeeded returns whether a pending build of a job using the
+// resource as an input was created since the resource was last checked, and
+// no check of the resource is already running.
+func (r *resource) OnDemandCheckNeeded() (bool, error) {
+	var needed bool
+	err := r.conn.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM builds b
+			JOIN jobs j ON j.id = b.job_id
+			JOIN job_inputs ji ON ji.job_id = j.id
+			JOIN resources r ON r.id = ji.resource_id
+			LEFT JOIN resource_config_scopes rs ON rs.id = r.resource_config_scope_id
+			LEFT JOIN builds cb ON cb.id = r.build_id
+			WHERE r.id = $1
+			AND b.status = 'pending'
+			AND NOT j.paused
+			AND (rs.last_check_end_time IS NULL OR rs.last_check_end_time < b.create_time)
+			AND (cb.id IS NULL OR cb.completed)
+			AND NOT EXISTS (
+				SELECT
+				FROM resource_pins
+				WHERE resource_id = r.id
+			)
+		)`, r.id).Scan(&needed)
+	if err != nil {
+		return false, err
+	}
+
+	return needed, nil
+}
+
 func (r *resource) Reload() (bool, error) {
 	row := resourcesQuery.Where(sq.Eq{"r.id": r.id}).
 		RunWith(r.conn).
@@ -260,6 +352,11 @@ func (r *resource) CheckPlan(from atc.Version, interval time.Duration, resourceT
 		Source:  sourceDefaults.Merge(r.Source()),
 		Tags:    r.Tags(),
 		Timeout: r.CheckTimeout(),
+		Limits:  r.config.CheckLimits,
+
+		CheckPool: r.config.CheckPool,
+		Proxy:     r.config.Proxy,
+		TTL:       r.config.CheckTTL,
 
 		FromVersion:            from,
 		Interval:               interval.String(),
@@ -373,6 +470,44 @@ func (r *resource) UpdateMetadata(version atc.Version, metadata ResourceConfigMe
 	return true, nil
 }
 
+// BackfillVersions saves versions, oldest first, as history preceding the
+// versions found by checking the resource. The resource must have been
+// checked at least once so that it has a resource config scope.
+func (r *resource) BackfillVersions(versions []atc.Version) (int, error) {
+	if r.resourceConfigScopeID == 0 {
+		return 0, ErrResourceNotChecked
+	}
+
+	return backfillVersions(r.conn, r.resourceConfigScopeID, versions)
+}
+
+// PauseChecking stops the resource from being checked for new versions. The
+// versions already found can still be used by builds. Other resources sharing
+// its resource config scope are still checked, and the versions they find are
+// shared with it.
+func (r *resource) PauseChecking() error {
+	return r.setCheckPaused(true)
+}
+
+func (r *resource) UnpauseChecking() error {
+	return r.setCheckPaused(false)
+}
+
+func (r *resource) setCheckPaused(paused bool) error {
+	_, err := psql.Update("resources").
+		Set("check_paused", paused).
+		Where(sq.Eq{"id": r.id}).
+		RunWith(r.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	r.checkPaused = paused
+
+	return nil
+}
+
 // XXX: Deprecated, only used in tests
 func (r *resource) FindVersion(v atc.Version) (ResourceConfigVersion, bool, error) {
 	if r.resourceConfigScopeID == 0 {
@@ -446,6 +581,12 @@ func (r *resource) Versions(page Page, versionFilter atc.Version) ([]atc.Resourc
 				WHERE v.version_md5 = d.version_md5
 				AND r.resource_config_scope_id = v.resource_config_scope_id
 				AND r.id = d.resource_id
+			),
+			EXISTS (
+				SELECT 1
+				FROM resource_promoted_versions p
+				WHERE v.version_md5 = p.version_md5
+				AND r.id = p.resource_id
 			)
 		FROM resource_config_versions v, resources r
 		WHERE r.id = $1 AND r.resource_config_scope_id = v.resource_config_scope_id
@@ -517,7 +658,7 @@ func (r *resource) Versions(page Page, versionFilter atc.Version) ([]atc.Resourc
 		)
 
 		rv := atc.ResourceVersion{}
-		err := rows.Scan(&rv.ID, &versionBytes, &metadataBytes, &checkOrder, &rv.Enabled)
+		err := rows.Scan(&rv.ID, &versionBytes, &metadataBytes, &checkOrder, &rv.Enabled, &rv.Promoted)
 		if err != nil {
 			return nil, Pagination{}, false, err
 		}
@@ -594,15 +735,30 @@ func (r *resource) Versions(page Page, versionFilter atc.Version) ([]atc.Resourc
 	return rvs, pagination, true, nil
 }
 
-func (r *resource) EnableVersion(rcvID int) error {
-	return r.toggleVersion(rcvID, true)
+// EnableVersion enables the version so that it can be used by builds again,
+// recording who enabled it and why.
+func (r *resource) EnableVersion(rcvID int, actor string, reason string) error {
+	return r.toggleVersion(rcvID, true, actor, reason)
+}
+
+// DisableVersion disables the version so that it is no longer used by builds,
+// recording who disabled it and why.
+func (r *resource) DisableVersion(rcvID int, actor string, reason string) error {
+	return r.toggleVersion(rcvID, false, actor, reason)
+}
+
+// PromoteVersion marks the version as stable for this resource so that it can
+// be selected by its inputs configured with version: latest-stable. Disabled
+// versions cannot be promoted.
+func (r *resource) PromoteVersion(rcvID int) (bool, error) {
+	return r.togglePromotion(rcvID, true)
 }
 
-func (r *resource) DisableVersion(rcvID int) error {
-	return r.toggleVersion(rcvID, false)
+func (r *resource) UnpromoteVersion(rcvID int) (bool, error) {
+	return r.togglePromotion(rcvID, false)
 }
 
-func (r *resource) PinVersion(rcvID int) (bool, error) {
+func (r *resource) PinVersion(rcvID int, comment string) (bool, error) {
 	tx, err := r.conn.Begin()
 	if err != nil {
 		return false, err
@@ -630,8 +786,8 @@ func (r *resource) PinVersion(rcvID int) (bool, error) {
 				( SELECT rcv.version
 				FROM resource_config_versions rcv
 				WHERE rcv.id = $2 ),
-				'', false)
-			ON CONFLICT (resource_id) DO UPDATE SET version=EXCLUDED.version`, r.id, rcvID)
+				$3, false)
+			ON CONFLICT (resource_id) DO UPDATE SET version=EXCLUDED.version, comment_text=EXCLUDED.comment_text, version_set_id=NULL`, r.id, rcvID, comment)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return false, nil
@@ -699,7 +855,7 @@ func (r *resource) UnpinVersion() error {
 	return nil
 }
 
-func (r *resource) toggleVersion(rcvID int, enable bool) error {
+func (r *resource) toggleVersion(rcvID int, enable bool, actor string, reason string) error {
 	tx, err := r.conn.Begin()
 	if err != nil {
 		return err
@@ -735,6 +891,23 @@ func (r *resource) toggleVersion(rcvID int, enable bool) error {
 		return NonOneRowAffectedError{rowsAffected}
 	}
 
+	err = recordVersionToggle(tx, r.id, rcvID, enable, actor, reason)
+	if err != nil {
+		return err
+	}
+
+	if !enable {
+		// a disabled version is no longer considered stable
+		_, err = tx.Exec(`
+			DELETE FROM resource_promoted_versions
+			WHERE resource_id = $1
+			AND version_md5 = (SELECT version_md5 FROM resource_config_versions rcv WHERE rcv.id = $2)
+			`, r.id, rcvID)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = requestScheduleForJobsUsingResource(tx, r.id)
 	if err != nil {
 		return err
@@ -743,6 +916,69 @@ func (r *resource) toggleVersion(rcvID int, enable bool) error {
 	return tx.Commit()
 }
 
+func (r *resource) togglePromotion(rcvID int, promote bool) (bool, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	var versionMD5 string
+	var disabled bool
+	err = tx.QueryRow(`
+		SELECT rcv.version_md5, EXISTS (
+			SELECT 1
+			FROM resource_disabled_versions d
+			WHERE d.resource_id = r.id
+			AND d.version_md5 = rcv.version_md5
+		)
+		FROM resource_config_versions rcv, resources r
+		WHERE r.id = $1
+		AND rcv.id = $2
+		AND rcv.resource_config_scope_id = r.resource_config_scope_id
+		`, r.id, rcvID).Scan(&versionMD5, &disabled)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if promote {
+		if disabled {
+			return false, ErrVersionDisabled
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO resource_promoted_versions (resource_id, version_md5)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+			`, r.id, versionMD5)
+	} else {
+		_, err = tx.Exec(`
+			DELETE FROM resource_promoted_versions
+			WHERE resource_id = $1
+			AND version_md5 = $2
+			`, r.id, versionMD5)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	err = requestScheduleForJobsUsingResource(tx, r.id)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (r *resource) NotifyScan() error {
 	return r.conn.Bus().Notify(fmt.Sprintf("resource_scan_%d", r.id))
 }
@@ -752,8 +988,11 @@ func scanResource(r *resource, row scannable) error {
 		configBlob                                        sql.NullString
 		nonce, rcID, rcScopeID, pinnedVersion, pinComment sql.NullString
 		lastCheckStartTime, lastCheckEndTime              pq.NullTime
-		pinnedThroughConfig                               sql.NullBool
-		pipelineInstanceVars                              sql.NullString
+		lastCheckSuccessTime, lastScheduledCheckEndTime   pq.NullTime
+		pinnedThroughConfig, lastCheckSucceeded           sql.NullBool
+		lastScheduledCheckSucceeded, checkPaused          sql.NullBool
+		checkFailures                                     sql.NullInt64
+		pipelineInstanceVars, lastCheckHandle             sql.NullString
 	)
 
 	var build struct {
@@ -764,13 +1003,20 @@ func scanResource(r *resource, row scannable) error {
 		endTime   pq.NullTime
 	}
 
-	err := row.Scan(&r.id, &r.name, &r.type_, &configBlob, &lastCheckStartTime, &lastCheckEndTime, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.pipelineName, &pipelineInstanceVars, &r.teamID, &r.teamName, &pinnedVersion, &pinComment, &pinnedThroughConfig, &build.id, &build.name, &build.status, &build.startTime, &build.endTime)
+	err := row.Scan(&r.id, &r.name, &r.type_, &configBlob, &lastCheckStartTime, &lastCheckEndTime, &lastCheckHandle, &lastCheckSucceeded, &lastCheckSuccessTime, &checkFailures, &lastScheduledCheckEndTime, &lastScheduledCheckSucceeded, &checkPaused, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.pipelineName, &pipelineInstanceVars, &r.teamID, &r.teamName, &pinnedVersion, &pinComment, &pinnedThroughConfig, &build.id, &build.name, &build.status, &build.startTime, &build.endTime)
 	if err != nil {
 		return err
 	}
 
 	r.lastCheckStartTime = lastCheckStartTime.Time
 	r.lastCheckEndTime = lastCheckEndTime.Time
+	r.lastCheckHandle = lastCheckHandle.String
+	r.lastCheckSucceeded = lastCheckSucceeded.Bool
+	r.lastCheckSuccessTime = lastCheckSuccessTime.Time
+	r.checkFailures = int(checkFailures.Int64)
+	r.lastScheduledCheckEnd = lastScheduledCheckEndTime.Time
+	r.lastScheduledCheckOK = lastScheduledCheckSucceeded.Bool
+	r.checkPaused = checkPaused.Bool
 
 	es := r.conn.EncryptionStrategy()
 
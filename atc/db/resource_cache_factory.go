@@ -28,6 +28,22 @@ type ResourceCacheFactory interface {
 	ResourceCacheMetadata(UsedResourceCache) (ResourceConfigMetadataFields, error)
 
 	FindResourceCacheByID(id int) (UsedResourceCache, bool, error)
+
+	// FindResourceCachesForVersion returns the resource caches of the given
+	// resource config version, one for each set of params it was fetched with.
+	FindResourceCachesForVersion(resourceConfigVersionID int) ([]UsedResourceCache, error)
+
+	// ResourceConfigVersionVisibleToTeam returns whether the resource config
+	// version was found by checking a resource of one of the team's
+	// pipelines, including through a resource config scope it shares with
+	// other teams' resources, so that the team could have fetched it itself.
+	ResourceConfigVersionVisibleToTeam(resourceConfigVersionID int, teamID int) (bool, error)
+
+	// ExpireResourceCache invalidates the resource cache on every worker so
+	// that the next get fetches it again. Its volumes are left for the volume
+	// collector, which removes them once no build is using them. It returns
+	// the number of volumes scheduled for removal.
+	ExpireResourceCache(UsedResourceCache) (int, error)
 }
 
 type resourceCacheFactory struct {
@@ -133,6 +149,121 @@ func (f *resourceCacheFactory) FindResourceCacheByID(id int) (UsedResourceCache,
 	return findResourceCacheByID(tx, id, f.lockFactory, f.conn)
 }
 
+func (f *resourceCacheFactory) FindResourceCachesForVersion(resourceConfigVersionID int) ([]UsedResourceCache, error) {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := psql.Select("rc.id").
+		From("resource_caches rc").
+		Join("resource_config_scopes rcs ON rcs.resource_config_id = rc.resource_config_id").
+		Join("resource_config_versions rcv ON rcv.resource_config_scope_id = rcs.id AND rcv.version_md5 = rc.version_md5").
+		Where(sq.Eq{"rcv.id": resourceConfigVersionID}).
+		OrderBy("rc.id").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	var resourceCacheIDs []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			Close(rows)
+			return nil, err
+		}
+
+		resourceCacheIDs = append(resourceCacheIDs, id)
+	}
+
+	Close(rows)
+
+	var resourceCaches []UsedResourceCache
+	for _, id := range resourceCacheIDs {
+		resourceCache, found, err := findResourceCacheByID(tx, id, f.lockFactory, f.conn)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			resourceCaches = append(resourceCaches, resourceCache)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return resourceCaches, nil
+}
+
+func (f *resourceCacheFactory) ResourceConfigVersionVisibleToTeam(resourceConfigVersionID int, teamID int) (bool, error) {
+	var visible bool
+	err := psql.Select("1").
+		Prefix("SELECT EXISTS (").
+		From("resource_config_versions rcv").
+		Join("resources r ON r.resource_config_scope_id = rcv.resource_config_scope_id").
+		Join("pipelines p ON p.id = r.pipeline_id").
+		Where(sq.Eq{
+			"rcv.id":    resourceConfigVersionID,
+			"p.team_id": teamID,
+			"r.active":  true,
+		}).
+		Suffix(")").
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&visible)
+	if err != nil {
+		return false, err
+	}
+
+	return visible, nil
+}
+
+func (f *resourceCacheFactory) ExpireResourceCache(resourceCache UsedResourceCache) (int, error) {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Rollback(tx)
+
+	var volumes int
+	err = psql.Select("COUNT(*)").
+		From("volumes v").
+		Join("worker_resource_caches wrc ON wrc.id = v.worker_resource_cache_id").
+		Where(sq.Eq{"wrc.resource_cache_id": resourceCache.ID()}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&volumes)
+	if err != nil {
+		return 0, err
+	}
+
+	// volumes.worker_resource_cache_id is set to NULL on delete, which leaves
+	// the cache volumes orphaned for the volume collector to destroy
+	_, err = psql.Delete("worker_resource_caches").
+		Where(sq.Eq{"resource_cache_id": resourceCache.ID()}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return volumes, nil
+}
+
 func findResourceCacheByID(tx Tx, resourceCacheID int, lock lock.LockFactory, conn Conn) (UsedResourceCache, bool, error) {
 	var rcID int
 	var versionBytes string
@@ -12,6 +12,7 @@ import (
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbtest"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -368,6 +369,172 @@ var _ = Describe("ResourceCacheFactory", func() {
 		})
 	})
 
+	Describe("FindResourceCachesForVersion", func() {
+		var (
+			scenario       *dbtest.Scenario
+			resourceCaches []db.UsedResourceCache
+			versionID      int
+		)
+
+		BeforeEach(func() {
+			resourceCaches = nil
+
+			scenario = dbtest.Setup(
+				builder.WithResourceVersions("some-resource",
+					atc.Version{"some": "version"},
+					atc.Version{"some": "other-version"},
+				),
+			)
+
+			resource := scenario.Resource("some-resource")
+
+			resourceConfigVersion, found, err := resource.FindVersion(atc.Version{"some": "version"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			versionID = resourceConfigVersion.ID()
+
+			for _, params := range []atc.Params{{"some": "params"}, {"other": "params"}} {
+				resourceCache, err := resourceCacheFactory.FindOrCreateResourceCache(
+					db.ForBuild(build.ID()),
+					resource.Type(),
+					atc.Version{"some": "version"},
+					resource.Source(),
+					params,
+					atc.VersionedResourceTypes{},
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				resourceCaches = append(resourceCaches, resourceCache)
+			}
+
+			_, err = resourceCacheFactory.FindOrCreateResourceCache(
+				db.ForBuild(build.ID()),
+				resource.Type(),
+				atc.Version{"some": "other-version"},
+				resource.Source(),
+				atc.Params{"some": "params"},
+				atc.VersionedResourceTypes{},
+			)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the resource caches of the version", func() {
+			actualResourceCaches, err := resourceCacheFactory.FindResourceCachesForVersion(versionID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actualResourceCaches).To(HaveLen(2))
+			Expect(actualResourceCaches[0].ID()).To(Equal(resourceCaches[0].ID()))
+			Expect(actualResourceCaches[1].ID()).To(Equal(resourceCaches[1].ID()))
+		})
+
+		It("returns nothing when the version does not exist", func() {
+			actualResourceCaches, err := resourceCacheFactory.FindResourceCachesForVersion(42)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actualResourceCaches).To(BeEmpty())
+		})
+	})
+
+	Describe("ResourceConfigVersionVisibleToTeam", func() {
+		var (
+			scenario  *dbtest.Scenario
+			versionID int
+		)
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithResourceVersions("some-resource", atc.Version{"some": "version"}),
+			)
+
+			resourceConfigVersion, found, err := scenario.Resource("some-resource").FindVersion(atc.Version{"some": "version"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			versionID = resourceConfigVersion.ID()
+		})
+
+		It("is visible to the team of the resource", func() {
+			visible, err := resourceCacheFactory.ResourceConfigVersionVisibleToTeam(versionID, scenario.Team.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(visible).To(BeTrue())
+		})
+
+		It("is not visible to other teams", func() {
+			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+			Expect(err).ToNot(HaveOccurred())
+
+			visible, err := resourceCacheFactory.ResourceConfigVersionVisibleToTeam(versionID, otherTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(visible).To(BeFalse())
+		})
+	})
+
+	Describe("ExpireResourceCache", func() {
+		var (
+			resourceCache db.UsedResourceCache
+			cacheVolume   db.CreatedVolume
+		)
+
+		BeforeEach(func() {
+			resourceCache, err = resourceCacheFactory.FindOrCreateResourceCache(
+				db.ForBuild(build.ID()),
+				"some-base-resource-type",
+				atc.Version{"some": "version"},
+				atc.Source{"some": "source"},
+				atc.Params{"some": "params"},
+				atc.VersionedResourceTypes{},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingVolume, err := volumeRepository.CreateVolume(defaultTeam.ID(), defaultWorker.Name(), db.VolumeTypeResource)
+			Expect(err).ToNot(HaveOccurred())
+
+			cacheVolume, err = creatingVolume.Created()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = cacheVolume.InitializeResourceCache(resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the number of volumes scheduled for removal", func() {
+			volumes, err := resourceCacheFactory.ExpireResourceCache(resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(volumes).To(Equal(1))
+		})
+
+		It("no longer finds the cache volume", func() {
+			_, err := resourceCacheFactory.ExpireResourceCache(resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := volumeRepository.FindResourceCacheVolume(defaultWorker.Name(), resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("orphans the cache volume so that it gets collected", func() {
+			_, err := resourceCacheFactory.ExpireResourceCache(resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+
+			orphanedVolumes, err := volumeRepository.GetOrphanedVolumes()
+			Expect(err).ToNot(HaveOccurred())
+
+			var handles []string
+			for _, volume := range orphanedVolumes {
+				handles = append(handles, volume.Handle())
+			}
+
+			Expect(handles).To(ContainElement(cacheVolume.Handle()))
+		})
+
+		It("is idempotent", func() {
+			_, err := resourceCacheFactory.ExpireResourceCache(resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+
+			volumes, err := resourceCacheFactory.ExpireResourceCache(resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(volumes).To(BeZero())
+		})
+	})
+
 })
 
 type resourceCache struct {
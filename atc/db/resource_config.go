@@ -216,6 +216,71 @@ func (r *ResourceConfigDescriptor) findWithParentID(tx Tx, rc *resourceConfig, p
 	return true, nil
 }
 
+// findID returns the ID of the resource config, along with those of the
+// resource caches and configs its chain is built on, without creating any of
+// them.
+func (r *ResourceConfigDescriptor) findID(tx Tx) (int, bool, error) {
+	var parentID int
+	var parentColumnName string
+	if r.CreatedByResourceCache != nil {
+		parentColumnName = "resource_cache_id"
+
+		cacheConfigID, found, err := r.CreatedByResourceCache.ResourceConfigDescriptor.findID(tx)
+		if err != nil || !found {
+			return 0, false, err
+		}
+
+		err = psql.Select("id").
+			From("resource_caches").
+			Where(sq.Eq{
+				"resource_config_id": cacheConfigID,
+				"params_hash":        paramsHash(r.CreatedByResourceCache.Params),
+			}).
+			Where(sq.Expr("version_md5 = md5(?)", r.CreatedByResourceCache.version())).
+			RunWith(tx).
+			QueryRow().
+			Scan(&parentID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return 0, false, nil
+			}
+
+			return 0, false, err
+		}
+	}
+
+	if r.CreatedByBaseResourceType != nil {
+		parentColumnName = "base_resource_type_id"
+
+		brt, found, err := r.CreatedByBaseResourceType.Find(tx)
+		if err != nil || !found {
+			return 0, false, err
+		}
+
+		parentID = brt.ID
+	}
+
+	var id int
+	err := psql.Select("id").
+		From("resource_configs").
+		Where(sq.Eq{
+			parentColumnName: parentID,
+			"source_hash":    mapHash(r.Source),
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	return id, true, nil
+}
+
 func findOrCreateResourceConfigScope(
 	tx Tx,
 	conn Conn,
@@ -269,12 +334,14 @@ func findOrCreateResourceConfigScope(
 			return nil, err
 		}
 	} else if uniqueResource != nil {
-		// delete outdated scopes for resource
+		// delete outdated scopes for resource, except for the ones holding
+		// versions used by protected builds
 		_, err := psql.Delete("resource_config_scopes").
 			Where(sq.And{
 				sq.Eq{
 					"resource_id": resource.ID(),
 				},
+				sq.Expr("id NOT IN (SELECT resource_config_scope_id FROM build_protected_resource_config_scopes)"),
 			}).
 			RunWith(tx).
 			Exec()
@@ -2,6 +2,7 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
@@ -30,6 +31,14 @@ type ResourceConfigFactory interface {
 
 	FindResourceConfigByID(int) (ResourceConfig, bool, error)
 
+	// FindCheckedVersion returns the latest version checked of the shared
+	// resource config of the type and source, without creating it.
+	FindCheckedVersion(
+		resourceType string,
+		source atc.Source,
+		resourceTypes atc.VersionedResourceTypes,
+	) (atc.Version, bool, error)
+
 	CleanUnreferencedConfigs(time.Duration) error
 }
 
@@ -103,6 +112,66 @@ func (f *resourceConfigFactory) FindOrCreateResourceConfig(
 	return resourceConfig, nil
 }
 
+func (f *resourceConfigFactory) FindCheckedVersion(
+	resourceType string,
+	source atc.Source,
+	resourceTypes atc.VersionedResourceTypes,
+) (atc.Version, bool, error) {
+	resourceConfigDescriptor, err := constructResourceConfigDescriptor(resourceType, source, resourceTypes)
+	if err != nil {
+		return nil, false, err
+	}
+
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+	defer Rollback(tx)
+
+	resourceConfigID, found, err := resourceConfigDescriptor.findID(tx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	var versionBlob string
+	err = psql.Select("v.version").
+		From("resource_config_versions v").
+		Join("resource_config_scopes s ON s.id = v.resource_config_scope_id").
+		Where(sq.Eq{
+			"s.resource_config_id": resourceConfigID,
+			"s.resource_id":        nil,
+		}).
+		OrderBy("v.check_order DESC").
+		Limit(1).
+		RunWith(tx).
+		QueryRow().
+		Scan(&versionBlob)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	var version atc.Version
+	err = json.Unmarshal([]byte(versionBlob), &version)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return version, true, nil
+}
+
 // constructResourceConfig cannot be called for constructing a resource type's
 // resource config while also containing the same resource type in the list of
 // resource types, because that results in a circular dependency.
@@ -166,8 +235,17 @@ func (f *resourceConfigFactory) CleanUnreferencedConfigs(gracePeriod time.Durati
 		return err
 	}
 
+	usedByProtectedBuildsIds, _, err := sq.
+		Select("s.resource_config_id").
+		From("resource_config_scopes s").
+		Join("build_protected_resource_config_scopes p ON p.resource_config_scope_id = s.id").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
 	_, err = psql.Delete("resource_configs").
-		Where("id NOT IN (" + usedByResourceCachesIds + " UNION " + usedByResourceIds + " UNION " + usedByResourceTypesIds + ")").
+		Where("id NOT IN (" + usedByResourceCachesIds + " UNION " + usedByResourceIds + " UNION " + usedByResourceTypesIds + " UNION " + usedByProtectedBuildsIds + ")").
 		Where(sq.Expr(fmt.Sprintf("now() - last_referenced > '%d seconds'::interval", int(gracePeriod.Seconds())))).
 		PlaceholderFormat(sq.Dollar).
 		RunWith(f.conn).Exec()
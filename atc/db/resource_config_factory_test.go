@@ -255,4 +255,49 @@ var _ = Describe("ResourceConfigFactory", func() {
 			})
 		})
 	})
+
+	Describe("FindCheckedVersion", func() {
+		var (
+			version atc.Version
+			found   bool
+			err     error
+		)
+
+		JustBeforeEach(func() {
+			version, found, err = resourceConfigFactory.FindCheckedVersion(
+				"some-base-resource-type",
+				atc.Source{"some": "checked-source"},
+				atc.VersionedResourceTypes{},
+			)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the resource config does not exist", func() {
+			It("does not find a version", func() {
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the resource config has been checked", func() {
+			BeforeEach(func() {
+				resourceConfig, err := resourceConfigFactory.FindOrCreateResourceConfig(
+					"some-base-resource-type",
+					atc.Source{"some": "checked-source"},
+					atc.VersionedResourceTypes{},
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				scope, err := resourceConfig.FindOrCreateScope(nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = scope.SaveVersions(nil, []atc.Version{{"ref": "v1"}, {"ref": "v2"}})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("finds the latest version", func() {
+				Expect(found).To(BeTrue())
+				Expect(version).To(Equal(atc.Version{"ref": "v2"}))
+			})
+		})
+	})
 })
@@ -3,18 +3,38 @@ package db
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
+	"sort"
 	"time"
 
 	"code.cloudfoundry.org/lager"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/lib/pq"
 )
 
 type LastCheck struct {
 	StartTime time.Time
 	EndTime   time.Time
 	Succeeded bool
+
+	// When a check last succeeded, and how many have failed since.
+	SuccessTime         time.Time
+	ConsecutiveFailures int
+
+	// The handle of the container the check ran in, if it got that far.
+	ContainerHandle string
+
+	// Why the check failed, if it did.
+	Error string
+
+	// When a scheduled check, as opposed to one triggered by a webhook, by
+	// hand or by a pending build, last finished and whether it succeeded.
+	// This proves the resource was checked without relying on anything
+	// outside of Concourse.
+	ScheduledEndTime   time.Time
+	ScheduledSucceeded bool
 }
 
 //counterfeiter:generate . ResourceConfigScope
@@ -31,7 +51,8 @@ type ResourceConfigScope interface {
 	Resource() Resource
 	ResourceConfig() ResourceConfig
 
-	SaveVersions(SpanContext, []atc.Version) error
+	SaveVersions(SpanContext, []atc.Version) (int, error)
+	SaveVersionsIdentifiedBy(SpanContext, []atc.Version, []string) (int, error)
 	FindVersion(atc.Version) (ResourceConfigVersion, bool, error)
 	LatestVersion() (ResourceConfigVersion, bool, error)
 
@@ -42,6 +63,10 @@ type ResourceConfigScope interface {
 	LastCheck() (LastCheck, error)
 	UpdateLastCheckStartTime() (bool, error)
 	UpdateLastCheckEndTime(bool) (bool, error)
+	UpdateLastScheduledCheckEndTime(bool) (bool, error)
+	UpdateLastCheckCanceled() (bool, error)
+	UpdateLastCheckContainerHandle(string) (bool, error)
+	UpdateLastCheckError(string) (bool, error)
 }
 
 type resourceConfigScope struct {
@@ -60,20 +85,30 @@ func (r *resourceConfigScope) ResourceConfig() ResourceConfig { return r.resourc
 func (r *resourceConfigScope) LastCheck() (LastCheck, error) {
 	var lastCheckStartTime, lastCheckEndTime time.Time
 	var lastCheckSucceeded bool
-	err := psql.Select("last_check_start_time", "last_check_end_time", "last_check_succeeded").
+	var lastCheckSuccessTime, lastScheduledCheckEndTime pq.NullTime
+	var checkFailures int
+	var lastCheckContainerHandle, lastCheckError sql.NullString
+	var lastScheduledCheckSucceeded bool
+	err := psql.Select("last_check_start_time", "last_check_end_time", "last_check_succeeded", "last_check_success_time", "check_failures", "last_check_container_handle", "last_check_error", "last_scheduled_check_end_time", "last_scheduled_check_succeeded").
 		From("resource_config_scopes").
 		Where(sq.Eq{"id": r.id}).
 		RunWith(r.conn).
 		QueryRow().
-		Scan(&lastCheckStartTime, &lastCheckEndTime, &lastCheckSucceeded)
+		Scan(&lastCheckStartTime, &lastCheckEndTime, &lastCheckSucceeded, &lastCheckSuccessTime, &checkFailures, &lastCheckContainerHandle, &lastCheckError, &lastScheduledCheckEndTime, &lastScheduledCheckSucceeded)
 	if err != nil {
 		return LastCheck{}, err
 	}
 
 	return LastCheck{
-		StartTime: lastCheckStartTime,
-		EndTime:   lastCheckEndTime,
-		Succeeded: lastCheckSucceeded,
+		StartTime:           lastCheckStartTime,
+		EndTime:             lastCheckEndTime,
+		Succeeded:           lastCheckSucceeded,
+		SuccessTime:         lastCheckSuccessTime.Time,
+		ConsecutiveFailures: checkFailures,
+		ContainerHandle:     lastCheckContainerHandle.String,
+		Error:               lastCheckError.String,
+		ScheduledEndTime:    lastScheduledCheckEndTime.Time,
+		ScheduledSucceeded:  lastScheduledCheckSucceeded,
 	}, nil
 }
 
@@ -84,55 +119,208 @@ func (r *resourceConfigScope) LastCheck() (LastCheck, error) {
 // In the case of a check resource from an older version, the versions
 // that already exist in the DB will be re-ordered using
 // incrementCheckOrder to input the correct check order
-func (r *resourceConfigScope) SaveVersions(spanContext SpanContext, versions []atc.Version) error {
-	return saveVersions(r.conn, r.ID(), versions, spanContext)
+//
+// It returns the number of versions which were not saved before.
+func (r *resourceConfigScope) SaveVersions(spanContext SpanContext, versions []atc.Version) (int, error) {
+	return saveVersions(r.conn, r.ID(), versions, nil, spanContext)
 }
 
-func saveVersions(conn Conn, rcsID int, versions []atc.Version, spanContext SpanContext) error {
+// SaveVersionsIdentifiedBy saves the versions the same as SaveVersions, except
+// that a version with the same values for the identity fields as a saved
+// version is taken to be the saved version rather than saved as a new one.
+func (r *resourceConfigScope) SaveVersionsIdentifiedBy(spanContext SpanContext, versions []atc.Version, identityFields []string) (int, error) {
+	return saveVersions(r.conn, r.ID(), versions, identityFields, spanContext)
+}
+
+func saveVersions(conn Conn, rcsID int, checkedVersions []atc.Version, identityFields []string, spanContext SpanContext) (int, error) {
 	tx, err := conn.Begin()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	defer Rollback(tx)
 
-	var containsNewVersion bool
-	for _, version := range versions {
-		newVersion, err := saveResourceVersion(tx, rcsID, version, nil, spanContext)
+	err = lockResourceConfigScope(tx, rcsID)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(identityFields) > 0 {
+		err = hashVersionIdentities(tx, rcsID, identityFields)
+	} else {
+		err = forgetVersionIdentities(tx, rcsID)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var newVersions int
+	versions := make([]atc.Version, len(checkedVersions))
+	for i, version := range checkedVersions {
+		var newVersion bool
+		if len(identityFields) > 0 {
+			version, newVersion, err = saveIdentifiedResourceVersion(tx, rcsID, version, nil, identityFields, spanContext)
+		} else {
+			newVersion, err = saveResourceVersion(tx, rcsID, version, nil, spanContext)
+		}
 		if err != nil {
-			return err
+			return 0, err
 		}
 
-		containsNewVersion = containsNewVersion || newVersion
+		versions[i] = version
+
+		if newVersion {
+			newVersions++
+		}
 	}
 
+	containsNewVersion := newVersions > 0
+
 	if containsNewVersion {
+		err = updateCheckRecord(tx, rcsID, map[string]interface{}{
+			"versions_added": sq.Expr("versions_added + ?", newVersions),
+		})
+		if err != nil {
+			return 0, err
+		}
+
 		// bump the check order of all the versions returned by the check if there
 		// is at least one new version within the set of returned versions
 		for _, version := range versions {
 			versionJSON, err := json.Marshal(version)
 			if err != nil {
-				return err
+				return 0, err
 			}
 
 			err = incrementCheckOrder(tx, rcsID, string(versionJSON))
 			if err != nil {
-				return err
+				return 0, err
 			}
 		}
 
 		err = requestScheduleForJobsUsingResourceConfigScope(tx, rcsID)
 		if err != nil {
-			return err
+			return 0, err
 		}
 	}
 
 	err = tx.Commit()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	if containsNewVersion {
+		err = conn.Bus().Notify(resourceConfigScopeVersionsChannel(rcsID))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return newVersions, nil
+}
+
+func resourceConfigScopeVersionsChannel(rcsID int) string {
+	return fmt.Sprintf("resource_config_scope_versions_%d", rcsID)
+}
+
+// lockResourceConfigScope locks the resource config scope until the end of the
+// transaction, so that versions saved by a check and versions backfilled at
+// the same time are ordered one after the other rather than interleaved.
+func lockResourceConfigScope(tx Tx, rcsID int) error {
+	var id int
+	return psql.Select("id").
+		From("resource_config_scopes").
+		Where(sq.Eq{"id": rcsID}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&id)
+}
+
+// backfillVersions stores versions that predate the version history of the
+// resource config scope. The versions are given oldest first and any that are
+// not already known are ordered before all of the existing versions, which
+// keep their relative order. It is run under the same lock of the scope as
+// saving checked versions. It returns the number of versions that were new.
+func backfillVersions(conn Conn, rcsID int, versions []atc.Version) (int, error) {
+	tx, err := conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Rollback(tx)
+
+	err = lockResourceConfigScope(tx, rcsID)
+	if err != nil {
+		return 0, err
+	}
+
+	err = forgetVersionIdentities(tx, rcsID)
+	if err != nil {
+		return 0, err
+	}
+
+	var newVersionIDs []int
+	for _, version := range versions {
+		versionJSON, err := json.Marshal(version)
+		if err != nil {
+			return 0, err
+		}
+
+		var id int
+		err = tx.QueryRow(`
+			INSERT INTO resource_config_versions (resource_config_scope_id, version, version_md5, metadata)
+			SELECT $1, $2, md5($3), 'null'
+			ON CONFLICT (resource_config_scope_id, version_md5) DO NOTHING
+			RETURNING id
+			`, rcsID, string(versionJSON), string(versionJSON)).Scan(&id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				continue
+			}
+
+			return 0, err
+		}
+
+		newVersionIDs = append(newVersionIDs, id)
+	}
+
+	if len(newVersionIDs) == 0 {
+		return 0, nil
+	}
+
+	_, err = psql.Update("resource_config_versions").
+		Set("check_order", sq.Expr("check_order + ?", len(newVersionIDs))).
+		Where(sq.Eq{"resource_config_scope_id": rcsID}).
+		Where(sq.NotEq{"id": newVersionIDs}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, id := range newVersionIDs {
+		_, err = psql.Update("resource_config_versions").
+			Set("check_order", i+1).
+			Where(sq.Eq{"id": id}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = requestScheduleForJobsUsingResourceConfigScope(tx, rcsID)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(newVersionIDs), nil
 }
 
 func (r *resourceConfigScope) FindVersion(v atc.Version) (ResourceConfigVersion, bool, error) {
@@ -206,7 +394,7 @@ func (r *resourceConfigScope) UpdateLastCheckStartTime() (bool, error) {
 
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE resource_config_scopes
-		SET last_check_start_time = now()
+		SET last_check_start_time = now(), last_check_container_handle = NULL, last_check_error = NULL
 		WHERE id = $1
 	`, r.id)
 	if err != nil {
@@ -217,6 +405,11 @@ func (r *resourceConfigScope) UpdateLastCheckStartTime() (bool, error) {
 		return false, nil
 	}
 
+	err = startCheckRecord(tx, r.id)
+	if err != nil {
+		return false, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return false, err
@@ -235,7 +428,53 @@ func (r *resourceConfigScope) UpdateLastCheckEndTime(succeeded bool) (bool, erro
 
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE resource_config_scopes
-		SET last_check_end_time = now(), last_check_succeeded = $1
+		SET last_check_end_time = now(),
+			last_check_succeeded = $1,
+			last_check_success_time = CASE WHEN $1 THEN now() ELSE last_check_success_time END,
+			check_failures = CASE WHEN $1 THEN 0 ELSE check_failures + 1 END
+		WHERE id = $2
+	`, succeeded, r.id)
+	if err != nil {
+		return false, err
+	}
+
+	if !updated {
+		return false, nil
+	}
+
+	status := atc.StatusFailed
+	if succeeded {
+		status = atc.StatusSucceeded
+	}
+
+	err = finishCheckRecord(tx, r.id, status)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// UpdateLastScheduledCheckEndTime records that a scheduled check finished,
+// separately from the end of the last check of any kind, as the proof that
+// the resource is checked periodically regardless of webhooks.
+func (r *resourceConfigScope) UpdateLastScheduledCheckEndTime(succeeded bool) (bool, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	updated, err := checkIfRowsUpdated(tx, `
+		UPDATE resource_config_scopes
+		SET last_scheduled_check_end_time = now(),
+			last_scheduled_check_succeeded = $1
 		WHERE id = $2
 	`, succeeded, r.id)
 	if err != nil {
@@ -254,6 +493,109 @@ func (r *resourceConfigScope) UpdateLastCheckEndTime(succeeded bool) (bool, erro
 	return true, nil
 }
 
+// UpdateLastCheckCanceled ends a check which was canceled before it could
+// finish. It says nothing about the resource, so the outcome of the previous
+// check and the count of consecutive failures are left as they were.
+func (r *resourceConfigScope) UpdateLastCheckCanceled() (bool, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	updated, err := checkIfRowsUpdated(tx, `
+		UPDATE resource_config_scopes
+		SET last_check_end_time = now()
+		WHERE id = $1
+	`, r.id)
+	if err != nil {
+		return false, err
+	}
+
+	if !updated {
+		return false, nil
+	}
+
+	err = finishCheckRecord(tx, r.id, atc.StatusAborted)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// UpdateLastCheckContainerHandle records the container the current check is
+// running in, so that it can be found (e.g. to hijack it) after the fact.
+func (r *resourceConfigScope) UpdateLastCheckContainerHandle(handle string) (bool, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	updated, err := checkIfRowsUpdated(tx, `
+		UPDATE resource_config_scopes
+		SET last_check_container_handle = $1
+		WHERE id = $2
+	`, handle, r.id)
+	if err != nil {
+		return false, err
+	}
+
+	if !updated {
+		return false, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// UpdateLastCheckError records why the current check failed.
+func (r *resourceConfigScope) UpdateLastCheckError(checkErr string) (bool, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	updated, err := checkIfRowsUpdated(tx, `
+		UPDATE resource_config_scopes
+		SET last_check_error = $1
+		WHERE id = $2
+	`, checkErr, r.id)
+	if err != nil {
+		return false, err
+	}
+
+	if !updated {
+		return false, nil
+	}
+
+	err = updateCheckRecord(tx, r.id, map[string]interface{}{"error": checkErr})
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func saveResourceVersion(tx Tx, rcsID int, version atc.Version, metadata ResourceConfigMetadataFields, spanContext SpanContext) (bool, error) {
 	versionJSON, err := json.Marshal(version)
 	if err != nil {
@@ -285,6 +627,225 @@ func saveResourceVersion(tx Tx, rcsID int, version atc.Version, metadata Resourc
 	return checkOrder == 0, nil
 }
 
+// resourceTypeIdentityFields returns the fields which identify the versions
+// of the given type, if it's a custom type whose versions aren't identified
+// by all of their fields.
+func resourceTypeIdentityFields(resourceTypes atc.VersionedResourceTypes, resourceType string) []string {
+	customType, found := resourceTypes.Lookup(resourceType)
+	if !found {
+		return nil
+	}
+
+	return customType.IdentityFields
+}
+
+// versionIdentityMD5 is the hash of the identity fields, given as $2, of a
+// saved version, or NULL if it doesn't have all of them. The fields are
+// hashed as jsonb text, which is the same however they were marshalled.
+const versionIdentityMD5 = `CASE WHEN version ?& $2 THEN md5((SELECT jsonb_object_agg(key, value) FROM jsonb_each(version) WHERE key = ANY($2))::text) END`
+
+// hashVersionIdentities hashes the identity fields of the scope's versions,
+// so that versions can be looked up by them, unless they were last hashed
+// with the same fields.
+func hashVersionIdentities(tx Tx, rcsID int, identityFields []string) error {
+	fields := append([]string{}, identityFields...)
+	sort.Strings(fields)
+
+	fieldsJSON, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`
+		UPDATE resource_config_scopes
+		SET identity_fields = $2
+		WHERE id = $1
+		AND identity_fields IS DISTINCT FROM $2::jsonb
+	`, rcsID, string(fieldsJSON))
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return nil
+	}
+
+	_, err = tx.Exec(`
+		UPDATE resource_config_versions
+		SET identity_md5 = `+versionIdentityMD5+`
+		WHERE resource_config_scope_id = $1
+	`, rcsID, pq.Array(fields))
+	return err
+}
+
+// forgetVersionIdentities records that versions are being saved without
+// their identity hashes, so that they're all hashed again once versions are
+// saved with identity fields.
+func forgetVersionIdentities(tx Tx, rcsID int) error {
+	_, err := psql.Update("resource_config_scopes").
+		Set("identity_fields", nil).
+		Where(sq.Eq{"id": rcsID}).
+		Where(sq.NotEq{"identity_fields": nil}).
+		RunWith(tx).
+		Exec()
+	return err
+}
+
+// saveIdentifiedResourceVersion saves the version unless it has the same
+// values for the identity fields as a saved version, in which case the saved
+// version is returned and updated in place: its metadata is replaced by the
+// given metadata, if any, with the values of the version's other fields,
+// which are what vary for the same version.
+func saveIdentifiedResourceVersion(tx Tx, rcsID int, version atc.Version, metadata ResourceConfigMetadataFields, identityFields []string, spanContext SpanContext) (atc.Version, bool, error) {
+	id, savedVersion, savedMetadata, found, err := findIdentifiedVersion(tx, rcsID, version, identityFields)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		newVersion, err := saveResourceVersion(tx, rcsID, version, metadata, spanContext)
+		if err != nil {
+			return nil, false, err
+		}
+
+		versionJSON, err := json.Marshal(version)
+		if err != nil {
+			return nil, false, err
+		}
+
+		_, err = tx.Exec(`
+			UPDATE resource_config_versions
+			SET identity_md5 = `+versionIdentityMD5+`
+			WHERE resource_config_scope_id = $1
+			AND version_md5 = md5($3)
+		`, rcsID, pq.Array(identityFields), string(versionJSON))
+		if err != nil {
+			return nil, false, err
+		}
+
+		return version, newVersion, nil
+	}
+
+	if len(metadata) == 0 {
+		metadata = savedMetadata
+	}
+
+	metadataJSON, err := json.Marshal(withVersionFields(metadata, version, identityFields))
+	if err != nil {
+		return nil, false, err
+	}
+
+	_, err = psql.Update("resource_config_versions").
+		Set("metadata", string(metadataJSON)).
+		Where(sq.Eq{"id": id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return savedVersion, false, nil
+}
+
+// findIdentifiedVersion finds the saved version with the same values for the
+// identity fields as the version, by the hash of them. Versions which don't
+// have all of the fields are never found, as they can't be told apart by
+// them.
+func findIdentifiedVersion(tx Tx, rcsID int, version atc.Version, identityFields []string) (int, atc.Version, ResourceConfigMetadataFields, bool, error) {
+	identity := atc.Version{}
+	for _, field := range identityFields {
+		value, found := version[field]
+		if !found {
+			return 0, nil, nil, false, nil
+		}
+
+		identity[field] = value
+	}
+
+	identityJSON, err := json.Marshal(identity)
+	if err != nil {
+		return 0, nil, nil, false, err
+	}
+
+	var (
+		id           int
+		versionJSON  string
+		metadataJSON sql.NullString
+	)
+	err = psql.Select("id", "version", "metadata").
+		From("resource_config_versions").
+		Where(sq.Eq{"resource_config_scope_id": rcsID}).
+		Where(sq.Expr("identity_md5 = md5(?::jsonb::text)", string(identityJSON))).
+		OrderBy("check_order DESC").
+		Limit(1).
+		RunWith(tx).
+		QueryRow().
+		Scan(&id, &versionJSON, &metadataJSON)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil, nil, false, nil
+		}
+
+		return 0, nil, nil, false, err
+	}
+
+	var savedVersion atc.Version
+	err = json.Unmarshal([]byte(versionJSON), &savedVersion)
+	if err != nil {
+		return 0, nil, nil, false, err
+	}
+
+	var savedMetadata ResourceConfigMetadataFields
+	if metadataJSON.Valid {
+		err = json.Unmarshal([]byte(metadataJSON.String), &savedMetadata)
+		if err != nil {
+			return 0, nil, nil, false, err
+		}
+	}
+
+	return id, savedVersion, savedMetadata, true, nil
+}
+
+// withVersionFields returns the metadata with a field for each of the
+// version's fields other than the identity fields, replacing any of the same
+// name.
+func withVersionFields(metadata ResourceConfigMetadataFields, version atc.Version, identityFields []string) ResourceConfigMetadataFields {
+	identity := map[string]bool{}
+	for _, field := range identityFields {
+		identity[field] = true
+	}
+
+	var names []string
+	for name := range version {
+		if !identity[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	merged := append(ResourceConfigMetadataFields{}, metadata...)
+	for _, name := range names {
+		replaced := false
+		for i, field := range merged {
+			if field.Name == name {
+				merged[i].Value = version[name]
+				replaced = true
+			}
+		}
+
+		if !replaced {
+			merged = append(merged, ResourceConfigMetadataField{Name: name, Value: version[name]})
+		}
+	}
+
+	return merged
+}
+
 // increment the check order if the version's check order is less than the
 // current max. This will fix the case of a check from an old version causing
 // the desired order to change; existing versions will be re-ordered since
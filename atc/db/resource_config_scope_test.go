@@ -79,7 +79,7 @@ var _ = Describe("Resource Config Scope", func() {
 
 		// XXX: Can make test more resilient if there is a method that gives all versions by descending check order
 		It("ensures versioned resources have the correct check_order", func() {
-			err := resourceScope.SaveVersions(nil, originalVersionSlice)
+			_, err := resourceScope.SaveVersions(nil, originalVersionSlice)
 			Expect(err).ToNot(HaveOccurred())
 
 			latestVR, found, err := resourceScope.LatestVersion()
@@ -94,7 +94,7 @@ var _ = Describe("Resource Config Scope", func() {
 				{"ref": "v3"},
 			}
 
-			err = resourceScope.SaveVersions(nil, pretendCheckResults)
+			_, err = resourceScope.SaveVersions(nil, pretendCheckResults)
 			Expect(err).ToNot(HaveOccurred())
 
 			latestVR, found, err = resourceScope.LatestVersion()
@@ -105,6 +105,23 @@ var _ = Describe("Resource Config Scope", func() {
 			Expect(latestVR.CheckOrder()).To(Equal(4))
 		})
 
+		It("returns the number of versions which were not saved before", func() {
+			saved, err := resourceScope.SaveVersions(nil, originalVersionSlice)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved).To(Equal(2))
+
+			saved, err = resourceScope.SaveVersions(nil, []atc.Version{
+				{"ref": "v2"},
+				{"ref": "v3"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved).To(Equal(1))
+
+			saved, err = resourceScope.SaveVersions(nil, originalVersionSlice)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved).To(BeZero())
+		})
+
 		Context("when the versions already exists", func() {
 			var newVersionSlice []atc.Version
 
@@ -114,7 +131,7 @@ var _ = Describe("Resource Config Scope", func() {
 					{"ref": "v3"},
 				}
 
-				err := resourceScope.SaveVersions(nil, originalVersionSlice)
+				_, err := resourceScope.SaveVersions(nil, originalVersionSlice)
 				Expect(err).ToNot(HaveOccurred())
 
 				latestVR, found, err := resourceScope.LatestVersion()
@@ -126,7 +143,7 @@ var _ = Describe("Resource Config Scope", func() {
 			})
 
 			It("does not change the check order", func() {
-				err := resourceScope.SaveVersions(nil, newVersionSlice)
+				_, err := resourceScope.SaveVersions(nil, newVersionSlice)
 				Expect(err).ToNot(HaveOccurred())
 
 				latestVR, found, err := resourceScope.LatestVersion()
@@ -139,7 +156,7 @@ var _ = Describe("Resource Config Scope", func() {
 
 			Context("when a new version is added", func() {
 				It("requests schedule on the jobs that use the resource", func() {
-					err := resourceScope.SaveVersions(nil, originalVersionSlice)
+					_, err := resourceScope.SaveVersions(nil, originalVersionSlice)
 					Expect(err).ToNot(HaveOccurred())
 
 					requestedSchedule := scenario.Job("some-job").ScheduleRequestedTime()
@@ -148,14 +165,14 @@ var _ = Describe("Resource Config Scope", func() {
 						{"ref": "v0"},
 						{"ref": "v3"},
 					}
-					err = resourceScope.SaveVersions(nil, newVersions)
+					_, err = resourceScope.SaveVersions(nil, newVersions)
 					Expect(err).ToNot(HaveOccurred())
 
 					Expect(scenario.Job("some-job").ScheduleRequestedTime()).Should(BeTemporally(">", requestedSchedule))
 				})
 
 				It("does not request schedule on the jobs that use the resource but through passed constraints", func() {
-					err := resourceScope.SaveVersions(nil, originalVersionSlice)
+					_, err := resourceScope.SaveVersions(nil, originalVersionSlice)
 					Expect(err).ToNot(HaveOccurred())
 
 					requestedSchedule := scenario.Job("downstream-job").ScheduleRequestedTime()
@@ -164,14 +181,14 @@ var _ = Describe("Resource Config Scope", func() {
 						{"ref": "v0"},
 						{"ref": "v3"},
 					}
-					err = resourceScope.SaveVersions(nil, newVersions)
+					_, err = resourceScope.SaveVersions(nil, newVersions)
 					Expect(err).ToNot(HaveOccurred())
 
 					Expect(scenario.Job("downstream-job").ScheduleRequestedTime()).Should(BeTemporally("==", requestedSchedule))
 				})
 
 				It("does not request schedule on the jobs that do not use the resource", func() {
-					err := resourceScope.SaveVersions(nil, originalVersionSlice)
+					_, err := resourceScope.SaveVersions(nil, originalVersionSlice)
 					Expect(err).ToNot(HaveOccurred())
 
 					requestedSchedule := scenario.Job("some-other-job").ScheduleRequestedTime()
@@ -180,7 +197,7 @@ var _ = Describe("Resource Config Scope", func() {
 						{"ref": "v0"},
 						{"ref": "v3"},
 					}
-					err = resourceScope.SaveVersions(nil, newVersions)
+					_, err = resourceScope.SaveVersions(nil, newVersions)
 					Expect(err).ToNot(HaveOccurred())
 
 					Expect(scenario.Job("some-other-job").ScheduleRequestedTime()).Should(BeTemporally("==", requestedSchedule))
@@ -189,6 +206,105 @@ var _ = Describe("Resource Config Scope", func() {
 		})
 	})
 
+	Describe("SaveVersionsIdentifiedBy", func() {
+		BeforeEach(func() {
+			_, err := resourceScope.SaveVersions(nil, []atc.Version{
+				{"ref": "v1", "fetched_at": "1"},
+				{"ref": "v2", "fetched_at": "1"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("takes versions with the same identity fields to be the saved versions", func() {
+			_, err := resourceScope.SaveVersionsIdentifiedBy(nil, []atc.Version{
+				{"ref": "v1", "fetched_at": "2"},
+				{"ref": "v2", "fetched_at": "2"},
+			}, []string{"ref"})
+			Expect(err).ToNot(HaveOccurred())
+
+			latestVR, found, err := resourceScope.LatestVersion()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(latestVR.Version()).To(Equal(db.Version{"ref": "v2", "fetched_at": "1"}))
+			Expect(latestVR.CheckOrder()).To(Equal(2))
+
+			_, found, err = resourceScope.FindVersion(atc.Version{"ref": "v2", "fetched_at": "2"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("updates the metadata of the saved versions in place with the other fields", func() {
+			_, err := resourceScope.SaveVersionsIdentifiedBy(nil, []atc.Version{
+				{"ref": "v2", "fetched_at": "2"},
+			}, []string{"ref"})
+			Expect(err).ToNot(HaveOccurred())
+
+			savedVR, found, err := resourceScope.FindVersion(atc.Version{"ref": "v2", "fetched_at": "1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedVR.Metadata()).To(Equal(db.ResourceConfigMetadataFields{
+				{Name: "fetched_at", Value: "2"},
+			}))
+		})
+
+		It("takes versions reported more than once in the same check to be the same version", func() {
+			newVersions, err := resourceScope.SaveVersionsIdentifiedBy(nil, []atc.Version{
+				{"ref": "v3", "fetched_at": "2"},
+				{"ref": "v3", "fetched_at": "3"},
+			}, []string{"ref"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(newVersions).To(Equal(1))
+		})
+
+		Context("when versions were saved without identity fields since they were last identified", func() {
+			BeforeEach(func() {
+				_, err := resourceScope.SaveVersionsIdentifiedBy(nil, []atc.Version{
+					{"ref": "v2", "fetched_at": "2"},
+				}, []string{"ref"})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = resourceScope.SaveVersions(nil, []atc.Version{
+					{"ref": "v3", "fetched_at": "1"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("still finds them", func() {
+				newVersions, err := resourceScope.SaveVersionsIdentifiedBy(nil, []atc.Version{
+					{"ref": "v3", "fetched_at": "2"},
+				}, []string{"ref"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newVersions).To(BeZero())
+			})
+		})
+
+		It("saves versions with new identity fields", func() {
+			_, err := resourceScope.SaveVersionsIdentifiedBy(nil, []atc.Version{
+				{"ref": "v2", "fetched_at": "2"},
+				{"ref": "v3", "fetched_at": "2"},
+			}, []string{"ref"})
+			Expect(err).ToNot(HaveOccurred())
+
+			latestVR, found, err := resourceScope.LatestVersion()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(latestVR.Version()).To(Equal(db.Version{"ref": "v3", "fetched_at": "2"}))
+		})
+
+		It("saves versions without all of the identity fields as they are", func() {
+			_, err := resourceScope.SaveVersionsIdentifiedBy(nil, []atc.Version{
+				{"fetched_at": "2"},
+			}, []string{"ref"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := resourceScope.FindVersion(atc.Version{"fetched_at": "2"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+
 	Describe("LatestVersion", func() {
 		Context("when the resource config exists", func() {
 			var latestCV db.ResourceConfigVersion
@@ -199,7 +315,7 @@ var _ = Describe("Resource Config Scope", func() {
 					{"ref": "v3"},
 				}
 
-				err := resourceScope.SaveVersions(nil, originalVersionSlice)
+				_, err := resourceScope.SaveVersions(nil, originalVersionSlice)
 				Expect(err).ToNot(HaveOccurred())
 
 				var found bool
@@ -214,7 +330,7 @@ var _ = Describe("Resource Config Scope", func() {
 			})
 
 			It("disabled versions do not affect fetching the latest version", func() {
-				err := resourceScope.SaveVersions(nil, []atc.Version{{"version": "1"}})
+				_, err := resourceScope.SaveVersions(nil, []atc.Version{{"version": "1"}})
 				Expect(err).ToNot(HaveOccurred())
 
 				savedRCV, found, err := resourceScope.LatestVersion()
@@ -239,7 +355,7 @@ var _ = Describe("Resource Config Scope", func() {
 			})
 
 			It("saving versioned resources updates the latest versioned resource", func() {
-				err := resourceScope.SaveVersions(nil, []atc.Version{{"ref": "4"}, {"ref": "5"}})
+				_, err := resourceScope.SaveVersions(nil, []atc.Version{{"ref": "4"}, {"ref": "5"}})
 				Expect(err).ToNot(HaveOccurred())
 
 				savedVR, found, err := resourceScope.LatestVersion()
@@ -258,7 +374,7 @@ var _ = Describe("Resource Config Scope", func() {
 				{"ref": "v3"},
 			}
 
-			err := resourceScope.SaveVersions(nil, originalVersionSlice)
+			_, err := resourceScope.SaveVersions(nil, originalVersionSlice)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -318,6 +434,243 @@ var _ = Describe("Resource Config Scope", func() {
 
 			Expect(scenario.Resource("some-resource").LastCheckEndTime()).To(BeTemporally(">", lastTime))
 		})
+
+		It("counts consecutive failures until the next success", func() {
+			_, err := resourceScope.UpdateLastCheckEndTime(false)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = resourceScope.UpdateLastCheckEndTime(false)
+			Expect(err).ToNot(HaveOccurred())
+
+			lastCheck, err := resourceScope.LastCheck()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lastCheck.Succeeded).To(BeFalse())
+			Expect(lastCheck.ConsecutiveFailures).To(Equal(2))
+			Expect(scenario.Resource("some-resource").CheckFailures()).To(Equal(2))
+
+			_, err = resourceScope.UpdateLastCheckEndTime(true)
+			Expect(err).ToNot(HaveOccurred())
+
+			lastCheck, err = resourceScope.LastCheck()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lastCheck.ConsecutiveFailures).To(BeZero())
+		})
+
+		It("only records the success time when the check succeeds", func() {
+			_, err := resourceScope.UpdateLastCheckEndTime(true)
+			Expect(err).ToNot(HaveOccurred())
+
+			lastSuccess := scenario.Resource("some-resource").LastCheckSuccessTime()
+			Expect(lastSuccess).ToNot(BeZero())
+
+			_, err = resourceScope.UpdateLastCheckEndTime(false)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(scenario.Resource("some-resource").LastCheckSuccessTime()).To(Equal(lastSuccess))
+		})
+	})
+
+	Describe("UpdateLastScheduledCheckEndTime", func() {
+		It("records the last scheduled check apart from the last check", func() {
+			_, err := resourceScope.UpdateLastCheckEndTime(true)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(scenario.Resource("some-resource").LastScheduledCheckEndTime()).To(BeZero())
+
+			updated, err := resourceScope.UpdateLastScheduledCheckEndTime(false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(BeTrue())
+
+			lastCheck, err := resourceScope.LastCheck()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lastCheck.ScheduledEndTime).ToNot(BeZero())
+			Expect(lastCheck.ScheduledSucceeded).To(BeFalse())
+			Expect(lastCheck.Succeeded).To(BeTrue())
+
+			resource := scenario.Resource("some-resource")
+			Expect(resource.LastScheduledCheckEndTime()).To(Equal(lastCheck.ScheduledEndTime))
+			Expect(resource.LastScheduledCheckSucceeded()).To(BeFalse())
+		})
+	})
+
+	Describe("UpdateLastCheckError", func() {
+		It("records why the last check failed", func() {
+			updated, err := resourceScope.UpdateLastCheckError("container ran out of memory")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(BeTrue())
+
+			lastCheck, err := resourceScope.LastCheck()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lastCheck.Error).To(Equal("container ran out of memory"))
+		})
+
+		Context("when a new check starts", func() {
+			BeforeEach(func() {
+				_, err := resourceScope.UpdateLastCheckError("container ran out of memory")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = resourceScope.UpdateLastCheckStartTime()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("clears the previous check's error", func() {
+				lastCheck, err := resourceScope.LastCheck()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lastCheck.Error).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("UpdateLastCheckContainerHandle", func() {
+		It("records the handle on the last check", func() {
+			updated, err := resourceScope.UpdateLastCheckContainerHandle("some-handle")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(BeTrue())
+
+			lastCheck, err := resourceScope.LastCheck()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lastCheck.ContainerHandle).To(Equal("some-handle"))
+		})
+
+		Context("when a new check starts", func() {
+			BeforeEach(func() {
+				_, err := resourceScope.UpdateLastCheckContainerHandle("some-handle")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = resourceScope.UpdateLastCheckStartTime()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("clears the previous check's handle", func() {
+				lastCheck, err := resourceScope.LastCheck()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lastCheck.ContainerHandle).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("check records", func() {
+		checkRecords := func(page db.Page) ([]db.CheckRecord, db.Pagination) {
+			records, pagination, err := scenario.Resource("some-resource").CheckRecords(page)
+			Expect(err).ToNot(HaveOccurred())
+			return records, pagination
+		}
+
+		It("records each check from its start to its end", func() {
+			_, err := resourceScope.UpdateLastCheckStartTime()
+			Expect(err).ToNot(HaveOccurred())
+
+			records, _ := checkRecords(db.Page{Limit: 10})
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].Status).To(Equal(atc.StatusStarted))
+			Expect(records[0].StartTime).ToNot(BeZero())
+			Expect(records[0].EndTime).To(BeZero())
+
+			_, err = resourceScope.SaveVersions(nil, []atc.Version{{"ref": "new-1"}, {"ref": "new-2"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = resourceScope.UpdateLastCheckEndTime(true)
+			Expect(err).ToNot(HaveOccurred())
+
+			records, _ = checkRecords(db.Page{Limit: 10})
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].Status).To(Equal(atc.StatusSucceeded))
+			Expect(records[0].EndTime).ToNot(BeZero())
+			Expect(records[0].VersionsAdded).To(Equal(2))
+		})
+
+		It("records why a check failed", func() {
+			_, err := resourceScope.UpdateLastCheckStartTime()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = resourceScope.UpdateLastCheckError("exit status 1")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = resourceScope.UpdateLastCheckEndTime(false)
+			Expect(err).ToNot(HaveOccurred())
+
+			records, _ := checkRecords(db.Page{Limit: 10})
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].Status).To(Equal(atc.StatusFailed))
+			Expect(records[0].Error).To(Equal("exit status 1"))
+			Expect(records[0].VersionsAdded).To(BeZero())
+		})
+
+		It("records canceled checks as aborted", func() {
+			_, err := resourceScope.UpdateLastCheckStartTime()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = resourceScope.UpdateLastCheckCanceled()
+			Expect(err).ToNot(HaveOccurred())
+
+			records, _ := checkRecords(db.Page{Limit: 10})
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].Status).To(Equal(atc.StatusAborted))
+			Expect(records[0].EndTime).ToNot(BeZero())
+		})
+
+		It("records a check which never finished as aborted when the next one starts", func() {
+			_, err := resourceScope.UpdateLastCheckStartTime()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = resourceScope.UpdateLastCheckStartTime()
+			Expect(err).ToNot(HaveOccurred())
+
+			records, _ := checkRecords(db.Page{Limit: 10})
+			Expect(records).To(HaveLen(2))
+			Expect(records[0].Status).To(Equal(atc.StatusStarted))
+			Expect(records[1].Status).To(Equal(atc.StatusAborted))
+		})
+
+		Context("with more checks than fit in a page", func() {
+			var ids []int
+
+			BeforeEach(func() {
+				for i := 0; i < 3; i++ {
+					_, err := resourceScope.UpdateLastCheckStartTime()
+					Expect(err).ToNot(HaveOccurred())
+
+					_, err = resourceScope.UpdateLastCheckEndTime(true)
+					Expect(err).ToNot(HaveOccurred())
+				}
+
+				records, _ := checkRecords(db.Page{Limit: 10})
+				Expect(records).To(HaveLen(3))
+
+				ids = nil
+				for _, record := range records {
+					ids = append(ids, record.ID)
+				}
+			})
+
+			It("returns the newest checks first with a link to the older ones", func() {
+				records, pagination := checkRecords(db.Page{Limit: 2})
+				Expect(records).To(HaveLen(2))
+				Expect(records[0].ID).To(Equal(ids[0]))
+				Expect(records[1].ID).To(Equal(ids[1]))
+
+				Expect(pagination.Newer).To(BeNil())
+				Expect(pagination.Older).To(Equal(&db.Page{To: db.NewIntPtr(ids[2]), Limit: 2}))
+			})
+
+			It("returns older checks up to the given id", func() {
+				records, pagination := checkRecords(db.Page{To: db.NewIntPtr(ids[2]), Limit: 2})
+				Expect(records).To(HaveLen(1))
+				Expect(records[0].ID).To(Equal(ids[2]))
+
+				Expect(pagination.Older).To(BeNil())
+				Expect(pagination.Newer).To(Equal(&db.Page{From: db.NewIntPtr(ids[1]), Limit: 2}))
+			})
+
+			It("returns newer checks from the given id", func() {
+				records, pagination := checkRecords(db.Page{From: db.NewIntPtr(ids[1]), Limit: 2})
+				Expect(records).To(HaveLen(2))
+				Expect(records[0].ID).To(Equal(ids[0]))
+				Expect(records[1].ID).To(Equal(ids[1]))
+
+				Expect(pagination.Newer).To(BeNil())
+				Expect(pagination.Older).To(Equal(&db.Page{To: db.NewIntPtr(ids[2]), Limit: 2}))
+			})
+		})
 	})
 
 	Describe("AcquireResourceCheckingLock", func() {
@@ -12,6 +12,9 @@ type ResourceFactory interface {
 	Resource(int) (Resource, bool, error)
 	VisibleResources([]string) ([]Resource, error)
 	AllResources() ([]Resource, error)
+
+	VisibleResourceTypeUsages([]string, ResourceTypeUsageFilter) ([]ResourceTypeUsage, error)
+	AllResourceTypeUsages(ResourceTypeUsageFilter) ([]ResourceTypeUsage, error)
 }
 
 type resourceFactory struct {
@@ -5,6 +5,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("Resource Factory", func() {
@@ -77,4 +78,93 @@ var _ = Describe("Resource Factory", func() {
 			})
 		})
 	})
+
+	Describe("Resource Type Usages", func() {
+		BeforeEach(func() {
+			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+			Expect(err).NotTo(HaveOccurred())
+
+			config := atc.Config{
+				ResourceTypes: atc.ResourceTypes{
+					{
+						Name:   "custom-git",
+						Type:   "registry-image",
+						Source: atc.Source{"repository": "example/custom-git-resource"},
+					},
+					{
+						Name: "custom-git-extended",
+						Type: "custom-git",
+					},
+					{
+						Name: "custom-git-extended-further",
+						Type: "custom-git-extended",
+					},
+				},
+				Resources: atc.ResourceConfigs{
+					{Name: "custom-repo", Type: "custom-git"},
+					{Name: "extended-repo", Type: "custom-git-extended-further"},
+					{Name: "plain-repo", Type: "plain-git"},
+				},
+			}
+
+			publicPipeline, _, err := otherTeam.SavePipeline(atc.PipelineRef{Name: "public-pipeline"}, config, db.ConfigVersion(0), false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(publicPipeline.Expose()).To(Succeed())
+
+			_, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "private-pipeline"}, config, db.ConfigVersion(0), false)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("by type", func() {
+			It("returns the resources and resource types of that type", func() {
+				usages, err := resourceFactory.AllResourceTypeUsages(db.ResourceTypeUsageFilter{Type: "custom-git"})
+				Expect(err).ToNot(HaveOccurred())
+
+				var names []string
+				for _, usage := range usages {
+					Expect(usage.Type).To(Equal("custom-git"))
+					Expect(usage.TeamName).To(Equal("other-team"))
+					names = append(names, usage.PipelineName+"/"+usage.ResourceName+usage.ResourceTypeName)
+				}
+
+				Expect(names).To(Equal([]string{
+					"private-pipeline/custom-repo",
+					"public-pipeline/custom-repo",
+					"private-pipeline/custom-git-extended",
+					"public-pipeline/custom-git-extended",
+				}))
+			})
+		})
+
+		Context("by image", func() {
+			It("returns the resource types defined with the image and everything built on them down the chain", func() {
+				usages, err := resourceFactory.AllResourceTypeUsages(db.ResourceTypeUsageFilter{Image: "example/custom-git-resource"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(usages).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"PipelineName": Equal("private-pipeline"), "ResourceName": Equal("custom-repo")}),
+					MatchFields(IgnoreExtras, Fields{"PipelineName": Equal("public-pipeline"), "ResourceName": Equal("custom-repo")}),
+					MatchFields(IgnoreExtras, Fields{"PipelineName": Equal("private-pipeline"), "ResourceTypeName": Equal("custom-git"), "Image": Equal("example/custom-git-resource")}),
+					MatchFields(IgnoreExtras, Fields{"PipelineName": Equal("public-pipeline"), "ResourceTypeName": Equal("custom-git"), "Image": Equal("example/custom-git-resource")}),
+					MatchFields(IgnoreExtras, Fields{"PipelineName": Equal("private-pipeline"), "ResourceTypeName": Equal("custom-git-extended")}),
+					MatchFields(IgnoreExtras, Fields{"PipelineName": Equal("public-pipeline"), "ResourceTypeName": Equal("custom-git-extended")}),
+					MatchFields(IgnoreExtras, Fields{"PipelineName": Equal("private-pipeline"), "ResourceTypeName": Equal("custom-git-extended-further")}),
+					MatchFields(IgnoreExtras, Fields{"PipelineName": Equal("public-pipeline"), "ResourceTypeName": Equal("custom-git-extended-further")}),
+					MatchFields(IgnoreExtras, Fields{"PipelineName": Equal("private-pipeline"), "ResourceName": Equal("extended-repo")}),
+					MatchFields(IgnoreExtras, Fields{"PipelineName": Equal("public-pipeline"), "ResourceName": Equal("extended-repo")}),
+				))
+			})
+		})
+
+		Context("visible to a team", func() {
+			It("only returns usages in the team's pipelines and public pipelines", func() {
+				usages, err := resourceFactory.VisibleResourceTypeUsages([]string{"default-team"}, db.ResourceTypeUsageFilter{Type: "plain-git"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(usages).To(HaveLen(1))
+				Expect(usages[0].PipelineName).To(Equal("public-pipeline"))
+				Expect(usages[0].ResourceName).To(Equal("plain-repo"))
+			})
+		})
+	})
 })
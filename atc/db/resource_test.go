@@ -2,6 +2,7 @@ package db_test
 
 import (
 	"context"
+	"database/sql"
 	"strconv"
 	"time"
 
@@ -290,7 +291,7 @@ var _ = Describe("Resource", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				disableErr = resource.DisableVersion(123456)
+				disableErr = resource.DisableVersion(123456, "", "")
 			})
 
 			It("returns an error", func() {
@@ -305,7 +306,7 @@ var _ = Describe("Resource", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				enableError = resource.EnableVersion(scenario.ResourceVersion("some-other-resource", atc.Version{"disabled": "version"}).ID())
+				enableError = resource.EnableVersion(scenario.ResourceVersion("some-other-resource", atc.Version{"disabled": "version"}).ID(), "", "")
 			})
 
 			It("returns a non one row affected error", func() {
@@ -314,6 +315,275 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("VersionToggles", func() {
+		var (
+			scenario *dbtest.Scenario
+			resource db.Resource
+			rcvID    int
+		)
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   "some-base-resource-type",
+							Source: atc.Source{"some": "repository"},
+						},
+					},
+				}),
+				builder.WithResourceVersions("some-resource", atc.Version{"some": "version"}),
+			)
+
+			resource = scenario.Resource("some-resource")
+			rcvID = scenario.ResourceVersion("some-resource", atc.Version{"some": "version"}).ID()
+		})
+
+		It("is empty when no version was ever toggled", func() {
+			toggles, err := resource.VersionToggles()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(toggles).To(BeEmpty())
+		})
+
+		Context("when a version is disabled and enabled again", func() {
+			BeforeEach(func() {
+				err := resource.DisableVersion(rcvID, "some-user", "bad build")
+				Expect(err).ToNot(HaveOccurred())
+
+				err = resource.EnableVersion(rcvID, "other-user", "")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("records who toggled it and why, most recent first", func() {
+				toggles, err := resource.VersionToggles()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(toggles).To(HaveLen(2))
+
+				Expect(toggles[0].Version).To(Equal(atc.Version{"some": "version"}))
+				Expect(toggles[0].Enabled).To(BeTrue())
+				Expect(toggles[0].Actor).To(Equal("other-user"))
+				Expect(toggles[0].Reason).To(BeEmpty())
+
+				Expect(toggles[1].Version).To(Equal(atc.Version{"some": "version"}))
+				Expect(toggles[1].Enabled).To(BeFalse())
+				Expect(toggles[1].Actor).To(Equal("some-user"))
+				Expect(toggles[1].Reason).To(Equal("bad build"))
+				Expect(toggles[1].Time).To(BeTemporally("~", time.Now(), time.Minute))
+			})
+		})
+
+		Context("when the resource is deleted after a version was toggled", func() {
+			BeforeEach(func() {
+				err := resource.DisableVersion(rcvID, "some-user", "bad build")
+				Expect(err).ToNot(HaveOccurred())
+
+				err = scenario.Pipeline.Destroy()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("keeps the toggle along with the names of the resource, its pipeline and its team", func() {
+				var resourceID sql.NullInt64
+				var teamName, pipelineName, resourceName, actor string
+				err := dbConn.QueryRow(`
+					SELECT resource_id, team_name, pipeline_name, resource_name, actor
+					FROM resource_version_toggles
+				`).Scan(&resourceID, &teamName, &pipelineName, &resourceName, &actor)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(resourceID.Valid).To(BeFalse())
+				Expect(teamName).To(Equal(scenario.Team.Name()))
+				Expect(pipelineName).To(Equal(scenario.Pipeline.Name()))
+				Expect(resourceName).To(Equal("some-resource"))
+				Expect(actor).To(Equal("some-user"))
+			})
+		})
+
+		Context("when enabling the version fails", func() {
+			BeforeEach(func() {
+				err := resource.EnableVersion(rcvID, "some-user", "")
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("records nothing", func() {
+				toggles, err := resource.VersionToggles()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(toggles).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("PromoteVersion", func() {
+		var (
+			scenario *dbtest.Scenario
+			resource db.Resource
+			rcvID    int
+
+			promoteFound bool
+			promoteErr   error
+		)
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   "some-base-resource-type",
+							Source: atc.Source{"some": "repository"},
+						},
+					},
+				}),
+				builder.WithResourceVersions("some-resource", atc.Version{"some": "version"}),
+			)
+
+			resource = scenario.Resource("some-resource")
+			rcvID = scenario.ResourceVersion("some-resource", atc.Version{"some": "version"}).ID()
+		})
+
+		JustBeforeEach(func() {
+			promoteFound, promoteErr = resource.PromoteVersion(rcvID)
+		})
+
+		promotedFor := func(resource db.Resource) bool {
+			versions, _, found, err := resource.Versions(db.Page{Limit: 1}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(versions).To(HaveLen(1))
+			return versions[0].Promoted
+		}
+
+		promoted := func() bool {
+			return promotedFor(resource)
+		}
+
+		It("promotes the version", func() {
+			Expect(promoteErr).ToNot(HaveOccurred())
+			Expect(promoteFound).To(BeTrue())
+			Expect(promoted()).To(BeTrue())
+		})
+
+		Context("when another resource shares the resource config scope", func() {
+			var otherResource db.Resource
+
+			BeforeEach(func() {
+				scenario.Run(
+					builder.WithPipeline(atc.Config{
+						Resources: atc.ResourceConfigs{
+							{
+								Name:   "some-resource",
+								Type:   "some-base-resource-type",
+								Source: atc.Source{"some": "repository"},
+							},
+							{
+								Name:   "some-other-resource",
+								Type:   "some-base-resource-type",
+								Source: atc.Source{"some": "repository"},
+							},
+						},
+					}),
+					builder.WithResourceVersions("some-other-resource", atc.Version{"some": "version"}),
+				)
+
+				resource = scenario.Resource("some-resource")
+				otherResource = scenario.Resource("some-other-resource")
+				Expect(otherResource.ResourceConfigScopeID()).To(Equal(resource.ResourceConfigScopeID()))
+			})
+
+			It("does not promote the version for the other resource", func() {
+				Expect(promotedFor(otherResource)).To(BeFalse())
+			})
+
+			Context("when the other resource disables the version", func() {
+				JustBeforeEach(func() {
+					err := otherResource.DisableVersion(rcvID, "", "")
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("stays promoted for the resource", func() {
+					Expect(promoted()).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when the version is promoted again", func() {
+			JustBeforeEach(func() {
+				found, err := resource.PromoteVersion(rcvID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("stays promoted", func() {
+				Expect(promoted()).To(BeTrue())
+			})
+		})
+
+		Context("when the version is unpromoted", func() {
+			JustBeforeEach(func() {
+				found, err := resource.UnpromoteVersion(rcvID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("is no longer promoted", func() {
+				Expect(promoted()).To(BeFalse())
+			})
+		})
+
+		Context("when the version is disabled afterwards", func() {
+			JustBeforeEach(func() {
+				err := resource.DisableVersion(rcvID, "", "")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is no longer promoted", func() {
+				Expect(promoted()).To(BeFalse())
+			})
+
+			Context("when it is enabled again", func() {
+				JustBeforeEach(func() {
+					err := resource.EnableVersion(rcvID, "", "")
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("stays unpromoted", func() {
+					Expect(promoted()).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the version is disabled", func() {
+			BeforeEach(func() {
+				scenario.Run(builder.WithDisabledVersion("some-resource", atc.Version{"some": "version"}))
+			})
+
+			It("cannot be promoted", func() {
+				Expect(promoteErr).To(Equal(db.ErrVersionDisabled))
+			})
+		})
+
+		Context("when the version is discovered again", func() {
+			BeforeEach(func() {
+				scenario.Run(builder.WithResourceVersions("some-resource", atc.Version{"some": "version"}))
+			})
+
+			It("stays promoted", func() {
+				Expect(promoted()).To(BeTrue())
+			})
+		})
+
+		Context("when the version does not exist", func() {
+			BeforeEach(func() {
+				rcvID = 123456
+			})
+
+			It("returns not found", func() {
+				Expect(promoteErr).ToNot(HaveOccurred())
+				Expect(promoteFound).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("SetResourceConfigScope", func() {
 		var pipeline db.Pipeline
 		var resource db.Resource
@@ -421,6 +691,200 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("OnDemandCheckNeeded", func() {
+		var scenario *dbtest.Scenario
+		var needed bool
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.GetStep{
+										Name: "some-resource",
+									},
+								},
+							},
+						},
+					},
+					Resources: atc.ResourceConfigs{
+						{
+							Name:          "some-resource",
+							Type:          dbtest.BaseResourceType,
+							Source:        atc.Source{"some": "source"},
+							CheckOnDemand: true,
+						},
+					},
+				}),
+				builder.WithResourceVersions("some-resource", atc.Version{"some": "version"}),
+			)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			needed, err = scenario.Resource("some-resource").OnDemandCheckNeeded()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when there are no pending builds", func() {
+			It("returns false", func() {
+				Expect(needed).To(BeFalse())
+			})
+		})
+
+		Context("when a pending build was created since the resource was last checked", func() {
+			BeforeEach(func() {
+				var build db.Build
+				scenario.Run(builder.WithPendingJobBuild(&build, "some-job"))
+			})
+
+			It("returns true", func() {
+				Expect(needed).To(BeTrue())
+			})
+
+			Context("when the resource has been checked since", func() {
+				BeforeEach(func() {
+					scenario.Run(builder.WithResourceVersions("some-resource"))
+				})
+
+				It("returns false", func() {
+					Expect(needed).To(BeFalse())
+				})
+			})
+
+			Context("when the job is paused", func() {
+				BeforeEach(func() {
+					err := scenario.Job("some-job").Pause()
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("returns false", func() {
+					Expect(needed).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	Describe("BackfillVersions", func() {
+		var scenario *dbtest.Scenario
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   dbtest.BaseResourceType,
+							Source: atc.Source{"some": "source"},
+						},
+					},
+				}),
+				builder.WithResourceVersions("some-resource",
+					atc.Version{"ref": "v1"},
+					atc.Version{"ref": "v2"},
+				),
+			)
+		})
+
+		It("orders the new versions before the existing ones", func() {
+			saved, err := scenario.Resource("some-resource").BackfillVersions([]atc.Version{
+				{"ref": "old1"},
+				{"ref": "old2"},
+				{"ref": "v1"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved).To(Equal(2))
+
+			versions, _, found, err := scenario.Resource("some-resource").Versions(db.Page{Limit: 10}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			var refs []string
+			for _, v := range versions {
+				refs = append(refs, v.Version["ref"])
+			}
+			Expect(refs).To(Equal([]string{"v2", "v1", "old2", "old1"}))
+		})
+
+		It("waits for the scope to be unlocked, as saving checked versions does", func() {
+			tx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+
+			defer db.Rollback(tx)
+
+			resource := scenario.Resource("some-resource")
+
+			_, err = tx.Exec(`SELECT id FROM resource_config_scopes WHERE id = $1 FOR UPDATE`, resource.ResourceConfigScopeID())
+			Expect(err).ToNot(HaveOccurred())
+
+			backfilled := make(chan error, 1)
+			go func() {
+				_, err := resource.BackfillVersions([]atc.Version{{"ref": "old1"}})
+				backfilled <- err
+			}()
+
+			Consistently(backfilled).ShouldNot(Receive())
+
+			Expect(tx.Commit()).To(Succeed())
+
+			Eventually(backfilled).Should(Receive(BeNil()))
+		})
+
+		It("does not pause checking of other resources sharing its scope", func() {
+			scenario.Run(
+				builder.WithPipeline(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   dbtest.BaseResourceType,
+							Source: atc.Source{"some": "source"},
+						},
+						{
+							Name:   "other-resource",
+							Type:   dbtest.BaseResourceType,
+							Source: atc.Source{"some": "source"},
+						},
+					},
+				}),
+				builder.WithResourceVersions("other-resource", atc.Version{"ref": "v1"}),
+			)
+
+			Expect(scenario.Resource("some-resource").ResourceConfigScopeID()).To(Equal(scenario.Resource("other-resource").ResourceConfigScopeID()))
+
+			err := scenario.Resource("some-resource").PauseChecking()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(scenario.Resource("some-resource").CheckPaused()).To(BeTrue())
+			Expect(scenario.Resource("other-resource").CheckPaused()).To(BeFalse())
+		})
+
+		Context("when the resource has never been checked", func() {
+			BeforeEach(func() {
+				scenario = dbtest.Setup(
+					builder.WithPipeline(atc.Config{
+						Resources: atc.ResourceConfigs{
+							{
+								Name:   "some-resource",
+								Type:   dbtest.BaseResourceType,
+								Source: atc.Source{"some": "source"},
+							},
+						},
+					}),
+				)
+			})
+
+			It("pauses checking of the resource", func() {
+				err := scenario.Resource("some-resource").PauseChecking()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(scenario.Resource("some-resource").CheckPaused()).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("CheckPlan", func() {
 		var resource db.Resource
 		var resourceTypes db.ResourceTypes
@@ -454,6 +918,70 @@ var _ = Describe("Resource", func() {
 				Resource: resource.Name(),
 			}))
 		})
+
+		Context("when the resource configures check limits", func() {
+			var memory atc.MemoryLimit
+
+			BeforeEach(func() {
+				memory = atc.MemoryLimit(1024)
+
+				config, err := pipeline.Config()
+				Expect(err).ToNot(HaveOccurred())
+
+				config.Resources[0].CheckLimits = &atc.ContainerLimits{Memory: &memory}
+
+				pipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: pipeline.Name()}, config, pipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				resource, _, err = pipeline.Resource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("sets them on the plan", func() {
+				plan := resource.CheckPlan(nil, time.Minute, resourceTypes, nil)
+				Expect(plan.Limits).To(Equal(&atc.ContainerLimits{Memory: &memory}))
+			})
+		})
+
+		Context("when the resource is in a check pool", func() {
+			BeforeEach(func() {
+				config, err := pipeline.Config()
+				Expect(err).ToNot(HaveOccurred())
+
+				config.Resources[0].CheckPool = "some-pool"
+
+				pipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: pipeline.Name()}, config, pipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				resource, _, err = pipeline.Resource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("sets it on the plan", func() {
+				plan := resource.CheckPlan(nil, time.Minute, resourceTypes, nil)
+				Expect(plan.CheckPool).To(Equal("some-pool"))
+			})
+		})
+
+		Context("when the resource has a proxy", func() {
+			BeforeEach(func() {
+				config, err := pipeline.Config()
+				Expect(err).ToNot(HaveOccurred())
+
+				config.Resources[0].Proxy = &atc.ProxyConfig{HTTP: "http://((proxy-user))@proxy.example.com"}
+
+				pipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: pipeline.Name()}, config, pipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				resource, _, err = pipeline.Resource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("sets it on the plan", func() {
+				plan := resource.CheckPlan(nil, time.Minute, resourceTypes, nil)
+				Expect(plan.Proxy).To(Equal(&atc.ProxyConfig{HTTP: "http://((proxy-user))@proxy.example.com"}))
+			})
+		})
 	})
 
 	Describe("CreateBuild", func() {
@@ -1032,7 +1560,7 @@ var _ = Describe("Resource", func() {
 			)
 
 			BeforeEach(func() {
-				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID())
+				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID(), "")
 				Expect(found).To(BeTrue())
 				Expect(err).ToNot(HaveOccurred())
 
@@ -1041,7 +1569,7 @@ var _ = Describe("Resource", func() {
 			})
 
 			It("returns not found and does not update anything", func() {
-				found, err := scenario.Resource("some-resource").PinVersion(-1)
+				found, err := scenario.Resource("some-resource").PinVersion(-1, "")
 				Expect(found).To(BeFalse())
 				Expect(err).To(HaveOccurred())
 
@@ -1053,7 +1581,7 @@ var _ = Describe("Resource", func() {
 			It("requests schedule on all jobs using the resource", func() {
 				requestedSchedule := scenario.Job("job-using-resource").ScheduleRequestedTime()
 
-				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID())
+				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID(), "")
 				Expect(found).To(BeTrue())
 				Expect(err).ToNot(HaveOccurred())
 
@@ -1063,7 +1591,7 @@ var _ = Describe("Resource", func() {
 			It("does not request schedule on jobs that do not use the resource", func() {
 				requestedSchedule := scenario.Job("not-using-resource").ScheduleRequestedTime()
 
-				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID())
+				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID(), "")
 				Expect(found).To(BeTrue())
 				Expect(err).ToNot(HaveOccurred())
 
@@ -1073,7 +1601,7 @@ var _ = Describe("Resource", func() {
 
 		Context("when we pin a resource to a version", func() {
 			BeforeEach(func() {
-				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID())
+				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID(), "")
 				Expect(found).To(BeTrue())
 				Expect(err).ToNot(HaveOccurred())
 			})
@@ -1087,7 +1615,7 @@ var _ = Describe("Resource", func() {
 
 			Context("when the resource is pinned by another version already", func() {
 				BeforeEach(func() {
-					found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v3"}).ID())
+					found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v3"}).ID(), "")
 					Expect(found).To(BeTrue())
 					Expect(err).ToNot(HaveOccurred())
 				})
@@ -1098,6 +1626,19 @@ var _ = Describe("Resource", func() {
 				})
 			})
 
+			Context("when the pin is given a comment", func() {
+				BeforeEach(func() {
+					found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v3"}).ID(), "investigating an incident")
+					Expect(found).To(BeTrue())
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("stores the comment alongside the pin", func() {
+					Expect(scenario.Resource("some-resource").APIPinnedVersion()).To(Equal(atc.Version{"version": "v3"}))
+					Expect(scenario.Resource("some-resource").PinComment()).To(Equal("investigating an incident"))
+				})
+			})
+
 			Context("when we set the pin comment on a resource", func() {
 				BeforeEach(func() {
 					err := scenario.Resource("some-resource").SetPinComment("foo")
@@ -1168,7 +1709,7 @@ var _ = Describe("Resource", func() {
 			})
 
 			It("should fail to update the pinned version", func() {
-				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID())
+				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID(), "")
 				Expect(found).To(BeFalse())
 				Expect(err).To(Equal(db.ErrPinnedThroughConfig))
 			})
@@ -32,6 +32,10 @@ type ResourceType interface {
 	Name() string
 	Type() string
 	Privileged() bool
+	TwoPhaseCommit() bool
+	IdentityFields() []string
+	NotRetryableExitCodes() []int
+	ReuseImageFor() string
 	Source() atc.Source
 	Defaults() atc.Source
 	Params() atc.Params
@@ -40,9 +44,15 @@ type ResourceType interface {
 	CheckTimeout() string
 	LastCheckStartTime() time.Time
 	LastCheckEndTime() time.Time
+	CheckTTL() time.Duration
+	LastScheduledCheckEndTime() time.Time
 	CurrentPinnedVersion() atc.Version
 	ResourceConfigScopeID() int
 
+	// TeamScoped is true for resource types which the pipeline inherited from
+	// its team rather than defining them itself.
+	TeamScoped() bool
+
 	HasWebhook() bool
 
 	SetResourceConfigScope(ResourceConfigScope) error
@@ -108,6 +118,11 @@ func (resourceTypes ResourceTypes) Deserialize() atc.VersionedResourceTypes {
 				CheckEvery: t.CheckEvery(),
 				Tags:       t.Tags(),
 				Params:     t.Params(),
+
+				TwoPhaseCommit:        t.TwoPhaseCommit(),
+				IdentityFields:        t.IdentityFields(),
+				NotRetryableExitCodes: t.NotRetryableExitCodes(),
+				ReuseImageFor:         t.ReuseImageFor(),
 			},
 			Version: t.Version(),
 		})
@@ -116,10 +131,16 @@ func (resourceTypes ResourceTypes) Deserialize() atc.VersionedResourceTypes {
 	return versionedResourceTypes
 }
 
+// Configs returns the configs of the resource types defined by the pipeline,
+// leaving out the ones inherited from its team.
 func (resourceTypes ResourceTypes) Configs() atc.ResourceTypes {
 	var configs atc.ResourceTypes
 
 	for _, r := range resourceTypes {
+		if r.TeamScoped() {
+			continue
+		}
+
 		configs = append(configs, atc.ResourceType{
 			Name:       r.Name(),
 			Type:       r.Type(),
@@ -129,6 +150,11 @@ func (resourceTypes ResourceTypes) Configs() atc.ResourceTypes {
 			CheckEvery: r.CheckEvery(),
 			Tags:       r.Tags(),
 			Params:     r.Params(),
+
+			TwoPhaseCommit:        r.TwoPhaseCommit(),
+			IdentityFields:        r.IdentityFields(),
+			NotRetryableExitCodes: r.NotRetryableExitCodes(),
+			ReuseImageFor:         r.ReuseImageFor(),
 		})
 	}
 
@@ -141,6 +167,7 @@ var resourceTypesQuery = psql.Select(
 	"r.name",
 	"r.type",
 	"r.config",
+	"r.team_scoped",
 	"rcv.version",
 	"r.nonce",
 	"p.name",
@@ -175,6 +202,11 @@ type resourceType struct {
 	name                  string
 	type_                 string
 	privileged            bool
+	twoPhaseCommit        bool
+	identityFields        []string
+	notRetryableExitCodes []int
+	reuseImageFor         string
+	teamScoped            bool
 	source                atc.Source
 	defaults              atc.Source
 	params                atc.Params
@@ -191,6 +223,10 @@ func (t *resourceType) TeamName() string              { return t.teamName }
 func (t *resourceType) Name() string                  { return t.name }
 func (t *resourceType) Type() string                  { return t.type_ }
 func (t *resourceType) Privileged() bool              { return t.privileged }
+func (t *resourceType) TwoPhaseCommit() bool          { return t.twoPhaseCommit }
+func (t *resourceType) IdentityFields() []string      { return t.identityFields }
+func (t *resourceType) NotRetryableExitCodes() []int  { return t.notRetryableExitCodes }
+func (t *resourceType) ReuseImageFor() string         { return t.reuseImageFor }
 func (t *resourceType) CheckEvery() *atc.CheckEvery   { return t.checkEvery }
 func (t *resourceType) CheckTimeout() string          { return "" }
 func (r *resourceType) LastCheckStartTime() time.Time { return r.lastCheckStartTime }
@@ -200,6 +236,7 @@ func (t *resourceType) Defaults() atc.Source          { return t.defaults }
 func (t *resourceType) Params() atc.Params            { return t.params }
 func (t *resourceType) Tags() atc.Tags                { return t.tags }
 func (t *resourceType) ResourceConfigScopeID() int    { return t.resourceConfigScopeID }
+func (t *resourceType) TeamScoped() bool              { return t.teamScoped }
 
 func (t *resourceType) Version() atc.Version              { return t.version }
 func (t *resourceType) CurrentPinnedVersion() atc.Version { return nil }
@@ -208,6 +245,10 @@ func (t *resourceType) HasWebhook() bool {
 	return false
 }
 
+// Resource types have no check TTL.
+func (t *resourceType) CheckTTL() time.Duration              { return 0 }
+func (t *resourceType) LastScheduledCheckEndTime() time.Time { return time.Time{} }
+
 func newEmptyResourceType(conn Conn, lockFactory lock.LockFactory) *resourceType {
 	return &resourceType{pipelineRef: pipelineRef{conn: conn, lockFactory: lockFactory}}
 }
@@ -335,7 +376,7 @@ func scanResourceType(t *resourceType, row scannable) error {
 		pipelineInstanceVars                 sql.NullString
 	)
 
-	err := row.Scan(&t.id, &t.pipelineID, &t.name, &t.type_, &configJSON, &version, &nonce, &t.pipelineName, &pipelineInstanceVars, &t.teamID, &t.teamName, &rcsID, &lastCheckStartTime, &lastCheckEndTime)
+	err := row.Scan(&t.id, &t.pipelineID, &t.name, &t.type_, &configJSON, &t.teamScoped, &version, &nonce, &t.pipelineName, &pipelineInstanceVars, &t.teamID, &t.teamName, &rcsID, &lastCheckStartTime, &lastCheckEndTime)
 	if err != nil {
 		return err
 	}
@@ -376,6 +417,10 @@ func scanResourceType(t *resourceType, row scannable) error {
 	t.defaults = config.Defaults
 	t.params = config.Params
 	t.privileged = config.Privileged
+	t.twoPhaseCommit = config.TwoPhaseCommit
+	t.identityFields = config.IdentityFields
+	t.notRetryableExitCodes = config.NotRetryableExitCodes
+	t.reuseImageFor = config.ReuseImageFor
 	t.tags = config.Tags
 	t.checkEvery = config.CheckEvery
 
@@ -0,0 +1,172 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// ResourceTypeUsage is a resource or resource type of a pipeline which
+// references a resource type.
+type ResourceTypeUsage struct {
+	TeamName             string
+	PipelineID           int
+	PipelineName         string
+	PipelineInstanceVars atc.InstanceVars
+
+	// Only one of ResourceName and ResourceTypeName is set.
+	ResourceName     string
+	ResourceTypeName string
+
+	Type  string
+	Image string
+}
+
+// ResourceTypeUsageFilter selects the resource type to find the usages of,
+// either by the name it is referenced by as a type or by the image a custom
+// resource type is defined with. When both are given, usages must match both.
+type ResourceTypeUsageFilter struct {
+	Type  string
+	Image string
+}
+
+// resourceTypeImage returns the image repository a custom resource type is
+// defined with, which is kept alongside its encrypted config so that the
+// resource types using an image can be looked up.
+func resourceTypeImage(resourceType atc.ResourceType) sql.NullString {
+	repository, ok := resourceType.Source["repository"].(string)
+	if !ok || repository == "" {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: repository, Valid: true}
+}
+
+// imageTypesExpr matches the rows of the given table alias whose column names
+// a custom resource type of the same pipeline which is defined with the image
+// or is built, however many types down the chain, on one that is.
+func imageTypesExpr(alias string, column string, image string) sq.Sqlizer {
+	return sq.Expr(`(`+alias+`.pipeline_id, `+alias+`.`+column+`) IN (
+		WITH RECURSIVE image_types (pipeline_id, name) AS (
+			SELECT pipeline_id, name
+			FROM resource_types
+			WHERE active
+			AND image = ?
+			UNION
+			SELECT rt.pipeline_id, rt.name
+			FROM resource_types rt
+			JOIN image_types it ON it.pipeline_id = rt.pipeline_id AND it.name = rt.type
+			WHERE rt.active
+		)
+		SELECT pipeline_id, name FROM image_types
+	)`, image)
+}
+
+func (r *resourceFactory) VisibleResourceTypeUsages(teamNames []string, filter ResourceTypeUsageFilter) ([]ResourceTypeUsage, error) {
+	return r.resourceTypeUsages(filter, sq.Or{
+		sq.Eq{"t.name": teamNames},
+		sq.Eq{"p.public": true},
+	})
+}
+
+func (r *resourceFactory) AllResourceTypeUsages(filter ResourceTypeUsageFilter) ([]ResourceTypeUsage, error) {
+	return r.resourceTypeUsages(filter, sq.And{})
+}
+
+func (r *resourceFactory) resourceTypeUsages(filter ResourceTypeUsageFilter, visible sq.Sqlizer) ([]ResourceTypeUsage, error) {
+	resourcesFilter := sq.And{sq.Eq{"r.active": true}, visible}
+	resourceTypesFilter := sq.And{sq.Eq{"rt.active": true}, visible}
+
+	if filter.Type != "" {
+		resourcesFilter = append(resourcesFilter, sq.Eq{"r.type": filter.Type})
+		resourceTypesFilter = append(resourceTypesFilter, sq.Eq{"rt.type": filter.Type})
+	}
+
+	if filter.Image != "" {
+		resourcesFilter = append(resourcesFilter, imageTypesExpr("r", "type", filter.Image))
+
+		// the resource types defined with the image, as well as the ones
+		// built on top of them
+		resourceTypesFilter = append(resourceTypesFilter, imageTypesExpr("rt", "name", filter.Image))
+	}
+
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := psql.Select("t.name", "p.id", "p.name", "p.instance_vars", "r.name", "NULL", "r.type", "NULL").
+		From("resources r").
+		Join("pipelines p ON p.id = r.pipeline_id").
+		Join("teams t ON t.id = p.team_id").
+		Where(resourcesFilter).
+		OrderBy("t.name ASC", "p.name ASC", "p.id ASC", "r.name ASC").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := scanResourceTypeUsages(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err = psql.Select("t.name", "p.id", "p.name", "p.instance_vars", "NULL", "rt.name", "rt.type", "rt.image").
+		From("resource_types rt").
+		Join("pipelines p ON p.id = rt.pipeline_id").
+		Join("teams t ON t.id = p.team_id").
+		Where(resourceTypesFilter).
+		OrderBy("t.name ASC", "p.name ASC", "p.id ASC", "rt.name ASC").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	typeUsages, err := scanResourceTypeUsages(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(usages, typeUsages...), nil
+}
+
+func scanResourceTypeUsages(rows *sql.Rows) ([]ResourceTypeUsage, error) {
+	defer Close(rows)
+
+	var usages []ResourceTypeUsage
+	for rows.Next() {
+		var usage ResourceTypeUsage
+		var instanceVars, resourceName, resourceTypeName, image sql.NullString
+
+		err := rows.Scan(&usage.TeamName, &usage.PipelineID, &usage.PipelineName, &instanceVars, &resourceName, &resourceTypeName, &usage.Type, &image)
+		if err != nil {
+			return nil, err
+		}
+
+		if instanceVars.Valid {
+			err = json.Unmarshal([]byte(instanceVars.String), &usage.PipelineInstanceVars)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		usage.ResourceName = resourceName.String
+		usage.ResourceTypeName = resourceTypeName.String
+		usage.Image = image.String
+
+		usages = append(usages, usage)
+	}
+
+	return usages, rows.Err()
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+// TaskOutputCacheFactory keeps track of the outputs of task steps configured
+// with `cache_outputs: true`, keyed on a fingerprint of everything that went
+// into running them, so that a later build can restore them instead of
+// running the task again.
+//
+// Only the outputs of the latest fingerprint are kept for each job step.
+//
+//counterfeiter:generate . TaskOutputCacheFactory
+type TaskOutputCacheFactory interface {
+	// Find returns the handles of the volumes holding the cached outputs,
+	// keyed by output name. Outputs whose volume is no longer available on a
+	// running worker are left out.
+	Find(jobID int, stepName string, fingerprint string) (map[string]string, error)
+
+	// Save replaces the cached outputs of the job step with the given
+	// artifacts, keyed by output name.
+	Save(jobID int, stepName string, fingerprint string, artifacts map[string]WorkerArtifact) error
+}
+
+type taskOutputCacheFactory struct {
+	conn Conn
+}
+
+func NewTaskOutputCacheFactory(conn Conn) TaskOutputCacheFactory {
+	return &taskOutputCacheFactory{
+		conn: conn,
+	}
+}
+
+func (f *taskOutputCacheFactory) Find(jobID int, stepName string, fingerprint string) (map[string]string, error) {
+	rows, err := psql.Select("c.output_name", "v.handle").
+		From("task_output_caches c").
+		Join("volumes v ON v.worker_artifact_id = c.worker_artifact_id").
+		Join("workers w ON w.name = v.worker_name").
+		Where(sq.Eq{
+			"c.job_id":      jobID,
+			"c.step_name":   stepName,
+			"c.fingerprint": fingerprint,
+			"v.state":       VolumeStateCreated,
+			"w.state":       WorkerStateRunning,
+		}).
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	handles := map[string]string{}
+	for rows.Next() {
+		var outputName, handle string
+		err = rows.Scan(&outputName, &handle)
+		if err != nil {
+			return nil, err
+		}
+
+		handles[outputName] = handle
+	}
+
+	return handles, nil
+}
+
+func (f *taskOutputCacheFactory) Save(jobID int, stepName string, fingerprint string, artifacts map[string]WorkerArtifact) error {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	// the replaced artifacts are left to expire like any other
+	_, err = psql.Delete("task_output_caches").
+		Where(sq.Eq{
+			"job_id":    jobID,
+			"step_name": stepName,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	for outputName, artifact := range artifacts {
+		_, err = psql.Insert("task_output_caches").
+			Columns("job_id", "step_name", "fingerprint", "output_name", "worker_artifact_id").
+			Values(jobID, stepName, fingerprint, outputName, artifact.ID()).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
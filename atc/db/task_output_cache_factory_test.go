@@ -0,0 +1,95 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskOutputCacheFactory", func() {
+	var (
+		taskOutputCacheFactory db.TaskOutputCacheFactory
+
+		outputVolume   db.CreatedVolume
+		outputArtifact db.WorkerArtifact
+	)
+
+	BeforeEach(func() {
+		taskOutputCacheFactory = db.NewTaskOutputCacheFactory(dbConn)
+
+		creatingVolume, err := volumeRepository.CreateVolume(defaultTeam.ID(), defaultWorker.Name(), db.VolumeTypeArtifact)
+		Expect(err).ToNot(HaveOccurred())
+
+		outputVolume, err = creatingVolume.Created()
+		Expect(err).ToNot(HaveOccurred())
+
+		outputArtifact, err = outputVolume.InitializeArtifact("some-output", 0)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("Find", func() {
+		Context("when nothing has been cached", func() {
+			It("finds no outputs", func() {
+				handles, err := taskOutputCacheFactory.Find(defaultJob.ID(), "some-task", "some-fingerprint")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(handles).To(BeEmpty())
+			})
+		})
+
+		Context("when the outputs have been cached", func() {
+			BeforeEach(func() {
+				err := taskOutputCacheFactory.Save(defaultJob.ID(), "some-task", "some-fingerprint", map[string]db.WorkerArtifact{
+					"some-output": outputArtifact,
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("finds the volumes holding the outputs", func() {
+				handles, err := taskOutputCacheFactory.Find(defaultJob.ID(), "some-task", "some-fingerprint")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(handles).To(Equal(map[string]string{
+					"some-output": outputVolume.Handle(),
+				}))
+			})
+
+			It("finds nothing for another fingerprint", func() {
+				handles, err := taskOutputCacheFactory.Find(defaultJob.ID(), "some-task", "some-other-fingerprint")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(handles).To(BeEmpty())
+			})
+
+			Context("when the worker is no longer running", func() {
+				BeforeEach(func() {
+					err := defaultWorker.Land()
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("finds no outputs", func() {
+					handles, err := taskOutputCacheFactory.Find(defaultJob.ID(), "some-task", "some-fingerprint")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(handles).To(BeEmpty())
+				})
+			})
+
+			Context("when the outputs are cached for another fingerprint", func() {
+				BeforeEach(func() {
+					err := taskOutputCacheFactory.Save(defaultJob.ID(), "some-task", "some-other-fingerprint", map[string]db.WorkerArtifact{
+						"some-output": outputArtifact,
+					})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("replaces the previously cached outputs", func() {
+					handles, err := taskOutputCacheFactory.Find(defaultJob.ID(), "some-task", "some-fingerprint")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(handles).To(BeEmpty())
+
+					handles, err = taskOutputCacheFactory.Find(defaultJob.ID(), "some-task", "some-other-fingerprint")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(handles).To(HaveKey("some-output"))
+				})
+			})
+		})
+	})
+})
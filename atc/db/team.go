@@ -2,9 +2,11 @@ package db
 
 import (
 	"database/sql"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -35,6 +37,10 @@ type Team interface {
 
 	Auth() atc.TeamAuth
 
+	// The number of the team's job builds which may be running at once, or 0
+	// for no limit.
+	MaxConcurrentBuilds() int
+
 	Delete() error
 	Rename(string) error
 
@@ -51,6 +57,7 @@ type Team interface {
 	PublicPipelines() ([]Pipeline, error)
 	OrderPipelines([]string) error
 	OrderPipelinesWithinGroup(string, []atc.InstanceVars) error
+	SetPipelinesVar(pipelineIDs []int, name string, value interface{}, create bool) (map[int]bool, error)
 
 	CreateOneOffBuild() (Build, error)
 	CreateStartedBuild(plan atc.Plan) (Build, error)
@@ -76,6 +83,29 @@ type Team interface {
 	FindWorkersForResourceCache(rcId int) ([]Worker, error)
 
 	UpdateProviderAuth(auth atc.TeamAuth) error
+	UpdateMaxConcurrentBuilds(limit int) error
+
+	PublishSharedArtifact(name string, buildID int, data []byte) error
+	FindSharedArtifact(ownerTeamName string, name string) ([]byte, bool, error)
+	SharedArtifacts() ([]SharedArtifact, error)
+	GrantSharedArtifact(name string, granteeTeamID int) error
+	RevokeSharedArtifact(name string, granteeTeamID int) error
+
+	ResourceTypes() (atc.ResourceTypes, error)
+	SaveResourceTypes(atc.ResourceTypes) error
+}
+
+// SharedArtifact is an artifact published by one of a team's builds for
+// builds of other teams to consume, along with the teams granted access to
+// it. An artifact can be granted to teams before it is first published, in
+// which case it has no build and a zero PublishedAt.
+type SharedArtifact struct {
+	Name        string
+	BuildID     int
+	Size        int64
+	PublishedAt time.Time
+
+	GrantedTeams []string
 }
 
 type team struct {
@@ -87,6 +117,8 @@ type team struct {
 	admin bool
 
 	auth atc.TeamAuth
+
+	maxConcurrentBuilds int
 }
 
 func (t *team) ID() int      { return t.id }
@@ -95,6 +127,8 @@ func (t *team) Admin() bool  { return t.admin }
 
 func (t *team) Auth() atc.TeamAuth { return t.auth }
 
+func (t *team) MaxConcurrentBuilds() int { return t.maxConcurrentBuilds }
+
 func (t *team) Delete() error {
 	_, err := psql.Delete("teams").
 		Where(sq.Eq{
@@ -423,21 +457,46 @@ func savePipeline(
 		return 0, false, err
 	}
 
+	taskDefaultsPayload, err := json.Marshal(config.TaskDefaults)
+	if err != nil {
+		return 0, false, err
+	}
+
+	webhooksPayload, err := json.Marshal(config.Webhooks)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// webhooks are encrypted as their headers may carry credentials
+	encryptedWebhooksPayload, webhooksNonce, err := tx.EncryptionStrategy().Encrypt(webhooksPayload)
+	if err != nil {
+		return 0, false, err
+	}
+
+	maxBuildDuration := sql.NullString{
+		String: config.MaxBuildDuration,
+		Valid:  config.MaxBuildDuration != "",
+	}
+
 	var pipelineID int
 	if !existingConfig {
 		values := map[string]interface{}{
-			"name":            pipelineRef.Name,
-			"groups":          groupsPayload,
-			"var_sources":     encryptedVarSourcesPayload,
-			"display":         displayPayload,
-			"nonce":           nonce,
-			"version":         sq.Expr("nextval('config_version_seq')"),
-			"paused":          initiallyPaused,
-			"last_updated":    sq.Expr("now()"),
-			"team_id":         teamID,
-			"parent_job_id":   jobID,
-			"parent_build_id": buildID,
-			"instance_vars":   instanceVars,
+			"name":               pipelineRef.Name,
+			"groups":             groupsPayload,
+			"var_sources":        encryptedVarSourcesPayload,
+			"display":            displayPayload,
+			"task_defaults":      taskDefaultsPayload,
+			"webhooks":           encryptedWebhooksPayload,
+			"webhooks_nonce":     webhooksNonce,
+			"max_build_duration": maxBuildDuration,
+			"nonce":              nonce,
+			"version":            sq.Expr("nextval('config_version_seq')"),
+			"paused":             initiallyPaused,
+			"last_updated":       sq.Expr("now()"),
+			"team_id":            teamID,
+			"parent_job_id":      jobID,
+			"parent_build_id":    buildID,
+			"instance_vars":      instanceVars,
 		}
 		var ordering sql.NullInt64
 		var secondaryOrdering sql.NullInt64
@@ -475,6 +534,10 @@ func savePipeline(
 			Set("groups", groupsPayload).
 			Set("var_sources", encryptedVarSourcesPayload).
 			Set("display", displayPayload).
+			Set("task_defaults", taskDefaultsPayload).
+			Set("webhooks", encryptedWebhooksPayload).
+			Set("webhooks_nonce", webhooksNonce).
+			Set("max_build_duration", maxBuildDuration).
 			Set("nonce", nonce).
 			Set("version", sq.Expr("nextval('config_version_seq')")).
 			Set("last_updated", sq.Expr("now()")).
@@ -547,6 +610,16 @@ func savePipeline(
 		return 0, false, err
 	}
 
+	teamTypes, err := teamResourceTypes(tx, teamID)
+	if err != nil {
+		return 0, false, err
+	}
+
+	err = saveTeamResourceTypes(tx, teamTypes, pipelineID)
+	if err != nil {
+		return 0, false, err
+	}
+
 	err = updateJobsName(tx, config.Jobs, pipelineID)
 	if err != nil {
 		return 0, false, err
@@ -709,6 +782,39 @@ func (t *team) PublicPipelines() ([]Pipeline, error) {
 	return pipelines, nil
 }
 
+// SetPipelinesVar sets a local var of each of the team's pipelines with the
+// given ids, as found by Pipelines, in one transaction, so that either all of them get the new value
+// or none do. It returns whether the var was set on each pipeline, which a
+// pipeline that doesn't have the var yet isn't unless create is true.
+func (t *team) SetPipelinesVar(pipelineIDs []int, name string, value interface{}, create bool) (map[int]bool, error) {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	// the vars are locked in the same order by every caller, so that two
+	// requests setting the same var can't deadlock
+	ids := append([]int{}, pipelineIDs...)
+	sort.Ints(ids)
+
+	set := map[int]bool{}
+	for _, id := range ids {
+		set[id], err = setPipelineVar(tx, id, name, value, create)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return set, nil
+}
+
 func (t *team) OrderPipelines(names []string) error {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -928,7 +1034,7 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 		UPDATE teams
 		SET auth = $1, legacy_auth = NULL, nonce = NULL
 		WHERE id = $2
-		RETURNING id, name, admin, auth, nonce
+		RETURNING id, name, admin, auth, nonce, max_concurrent_builds
 	`
 	err = t.queryTeam(tx, query, jsonEncodedProviderAuth, t.id)
 	if err != nil {
@@ -938,6 +1044,299 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 	return tx.Commit()
 }
 
+func (t *team) UpdateMaxConcurrentBuilds(limit int) error {
+	result, err := psql.Update("teams").
+		Set("max_concurrent_builds", limit).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return NonOneRowAffectedError{rowsAffected}
+	}
+
+	t.maxConcurrentBuilds = limit
+
+	return nil
+}
+
+// PublishSharedArtifact stores the given artifact contents under the name,
+// replacing any previously published contents. The contents are encrypted
+// with the configured encryption strategy.
+func (t *team) PublishSharedArtifact(name string, buildID int, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	encrypted, nonce, err := t.conn.EncryptionStrategy().Encrypt([]byte(encoded))
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("shared_artifacts").
+		Columns("team_id", "name", "build_id", "data", "nonce", "size").
+		Values(t.id, name, buildID, encrypted, nonce, len(data)).
+		Suffix(`ON CONFLICT (team_id, name) DO UPDATE SET
+			build_id = EXCLUDED.build_id,
+			data = EXCLUDED.data,
+			nonce = EXCLUDED.nonce,
+			size = EXCLUDED.size,
+			created_at = now()`).
+		RunWith(t.conn).
+		Exec()
+
+	return err
+}
+
+// FindSharedArtifact returns the contents of an artifact published by the
+// given team, provided it is this team or it has granted this team access.
+// An artifact this team may not access is reported as not found.
+func (t *team) FindSharedArtifact(ownerTeamName string, name string) ([]byte, bool, error) {
+	var (
+		encrypted string
+		nonce     sql.NullString
+	)
+
+	err := psql.Select("a.data", "a.nonce").
+		From("shared_artifacts a").
+		Join("teams t ON t.id = a.team_id").
+		Where(sq.Eq{
+			"t.name": ownerTeamName,
+			"a.name": name,
+		}).
+		Where(sq.Or{
+			sq.Eq{"a.team_id": t.id},
+			sq.Expr(`EXISTS (
+				SELECT 1 FROM shared_artifact_grants g
+				WHERE g.team_id = a.team_id AND g.name = a.name AND g.grantee_team_id = ?
+			)`, t.id),
+		}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&encrypted, &nonce)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decrypted, err := t.conn.EncryptionStrategy().Decrypt(encrypted, noncense)
+	if err != nil {
+		return nil, false, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(string(decrypted))
+	if err != nil {
+		return nil, false, err
+	}
+
+	return data, true, nil
+}
+
+func (t *team) SharedArtifacts() ([]SharedArtifact, error) {
+	rows, err := psql.Select("name", "build_id", "size", "created_at").
+		From("shared_artifacts").
+		Where(sq.Eq{"team_id": t.id}).
+		OrderBy("name").
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	artifacts := []SharedArtifact{}
+	indexes := map[string]int{}
+	for rows.Next() {
+		var (
+			artifact SharedArtifact
+			buildID  sql.NullInt64
+		)
+
+		err = rows.Scan(&artifact.Name, &buildID, &artifact.Size, &artifact.PublishedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		artifact.BuildID = int(buildID.Int64)
+
+		indexes[artifact.Name] = len(artifacts)
+		artifacts = append(artifacts, artifact)
+	}
+
+	grantRows, err := psql.Select("g.name", "t.name").
+		From("shared_artifact_grants g").
+		Join("teams t ON t.id = g.grantee_team_id").
+		Where(sq.Eq{"g.team_id": t.id}).
+		OrderBy("g.name", "t.name").
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(grantRows)
+
+	for grantRows.Next() {
+		var name, granteeTeamName string
+		err = grantRows.Scan(&name, &granteeTeamName)
+		if err != nil {
+			return nil, err
+		}
+
+		i, found := indexes[name]
+		if !found {
+			i = len(artifacts)
+			indexes[name] = i
+			artifacts = append(artifacts, SharedArtifact{Name: name})
+		}
+
+		artifacts[i].GrantedTeams = append(artifacts[i].GrantedTeams, granteeTeamName)
+	}
+
+	return artifacts, nil
+}
+
+// GrantSharedArtifact allows builds of the given team to consume the artifact
+// with the given name, whether or not it has been published yet.
+func (t *team) GrantSharedArtifact(name string, granteeTeamID int) error {
+	_, err := psql.Insert("shared_artifact_grants").
+		Columns("team_id", "name", "grantee_team_id").
+		Values(t.id, name, granteeTeamID).
+		Suffix("ON CONFLICT DO NOTHING").
+		RunWith(t.conn).
+		Exec()
+
+	return err
+}
+
+func (t *team) RevokeSharedArtifact(name string, granteeTeamID int) error {
+	_, err := psql.Delete("shared_artifact_grants").
+		Where(sq.Eq{
+			"team_id":         t.id,
+			"name":            name,
+			"grantee_team_id": granteeTeamID,
+		}).
+		RunWith(t.conn).
+		Exec()
+
+	return err
+}
+
+// ResourceTypes returns the resource types registered for the team, which
+// are available to all of its pipelines.
+func (t *team) ResourceTypes() (atc.ResourceTypes, error) {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	resourceTypes, err := teamResourceTypes(tx, t.id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return resourceTypes, nil
+}
+
+// SaveResourceTypes replaces the resource types registered for the team and
+// updates the resource types of the team's pipelines to match. A pipeline
+// which defines a resource type of the same name keeps its own.
+func (t *team) SaveResourceTypes(resourceTypes atc.ResourceTypes) error {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Delete("team_resource_types").
+		Where(sq.Eq{"team_id": t.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	es := tx.EncryptionStrategy()
+	for _, resourceType := range resourceTypes {
+		configPayload, err := json.Marshal(resourceType)
+		if err != nil {
+			return err
+		}
+
+		encryptedPayload, nonce, err := es.Encrypt(configPayload)
+		if err != nil {
+			return err
+		}
+
+		_, err = psql.Insert("team_resource_types").
+			Columns("team_id", "name", "config", "nonce").
+			Values(t.id, resourceType.Name, encryptedPayload, nonce).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	rows, err := psql.Select("id").
+		From("pipelines").
+		Where(sq.Eq{
+			"team_id":  t.id,
+			"archived": false,
+		}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	var pipelineIDs []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			Close(rows)
+			return err
+		}
+
+		pipelineIDs = append(pipelineIDs, id)
+	}
+
+	Close(rows)
+
+	for _, pipelineID := range pipelineIDs {
+		err = saveTeamResourceTypes(tx, resourceTypes, pipelineID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
 func (t *team) FindCheckContainers(logger lager.Logger, pipelineRef atc.PipelineRef, resourceName string, secretManager creds.Secrets, varSourcePool creds.VarSourcePool) ([]Container, map[int]time.Time, error) {
 	pipeline, found, err := t.Pipeline(pipelineRef)
 	if err != nil {
@@ -1281,7 +1680,7 @@ func saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int) (int, erro
 		_, err = psql.Insert("resource_pins").
 			Columns("resource_id", "version", "comment_text", "config").
 			Values(resourceID, version, "", true).
-			Suffix("ON CONFLICT (resource_id) DO UPDATE SET version = EXCLUDED.version, comment_text = EXCLUDED.comment_text, config = true").
+			Suffix("ON CONFLICT (resource_id) DO UPDATE SET version = EXCLUDED.version, comment_text = EXCLUDED.comment_text, config = true, version_set_id = NULL").
 			RunWith(tx).
 			Exec()
 		if err != nil {
@@ -1305,15 +1704,106 @@ func saveResourceType(tx Tx, resourceType atc.ResourceType, pipelineID int) erro
 	}
 
 	_, err = psql.Insert("resource_types").
-		Columns("name", "pipeline_id", "config", "active", "nonce", "type").
-		Values(resourceType.Name, pipelineID, encryptedPayload, true, nonce, resourceType.Type).
-		Suffix("ON CONFLICT (name, pipeline_id) DO UPDATE SET config = EXCLUDED.config, active = EXCLUDED.active, nonce = EXCLUDED.nonce, type = EXCLUDED.type").
+		Columns("name", "pipeline_id", "config", "active", "nonce", "type", "image").
+		Values(resourceType.Name, pipelineID, encryptedPayload, true, nonce, resourceType.Type, resourceTypeImage(resourceType)).
+		Suffix("ON CONFLICT (name, pipeline_id) DO UPDATE SET config = EXCLUDED.config, active = EXCLUDED.active, nonce = EXCLUDED.nonce, type = EXCLUDED.type, image = EXCLUDED.image, team_scoped = false").
 		RunWith(tx).
 		Exec()
 
 	return err
 }
 
+func teamResourceTypes(tx Tx, teamID int) (atc.ResourceTypes, error) {
+	rows, err := psql.Select("config", "nonce").
+		From("team_resource_types").
+		Where(sq.Eq{"team_id": teamID}).
+		OrderBy("name").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	es := tx.EncryptionStrategy()
+
+	resourceTypes := atc.ResourceTypes{}
+	for rows.Next() {
+		var (
+			configJSON string
+			nonce      sql.NullString
+		)
+
+		err = rows.Scan(&configJSON, &nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		var noncense *string
+		if nonce.Valid {
+			noncense = &nonce.String
+		}
+
+		decryptedConfig, err := es.Decrypt(configJSON, noncense)
+		if err != nil {
+			return nil, err
+		}
+
+		var resourceType atc.ResourceType
+		err = json.Unmarshal(decryptedConfig, &resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+
+	return resourceTypes, rows.Err()
+}
+
+// saveTeamResourceTypes makes the team's resource types available to the
+// pipeline, skipping the ones the pipeline defines itself. Team resource
+// types which have since been removed from the team are deactivated.
+func saveTeamResourceTypes(tx Tx, resourceTypes atc.ResourceTypes, pipelineID int) error {
+	_, err := psql.Update("resource_types").
+		Set("active", false).
+		Where(sq.Eq{
+			"pipeline_id": pipelineID,
+			"team_scoped": true,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	es := tx.EncryptionStrategy()
+	for _, resourceType := range resourceTypes {
+		configPayload, err := json.Marshal(resourceType)
+		if err != nil {
+			return err
+		}
+
+		encryptedPayload, nonce, err := es.Encrypt(configPayload)
+		if err != nil {
+			return err
+		}
+
+		_, err = psql.Insert("resource_types").
+			Columns("name", "pipeline_id", "config", "active", "nonce", "type", "image", "team_scoped").
+			Values(resourceType.Name, pipelineID, encryptedPayload, true, nonce, resourceType.Type, resourceTypeImage(resourceType), true).
+			Suffix("ON CONFLICT (name, pipeline_id) DO UPDATE SET config = EXCLUDED.config, active = EXCLUDED.active, nonce = EXCLUDED.nonce, type = EXCLUDED.type, image = EXCLUDED.image, team_scoped = true WHERE NOT resource_types.active OR resource_types.team_scoped").
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func checkIfRowsUpdated(tx Tx, query string, params ...interface{}) (bool, error) {
 	result, err := tx.Exec(query, params...)
 	if err != nil {
@@ -1356,22 +1846,27 @@ func (t *team) findContainer(whereClause sq.Sqlizer) (CreatingContainer, Created
 
 func scanPipeline(p *pipeline, scan scannable) error {
 	var (
-		groups        sql.NullString
-		varSources    sql.NullString
-		display       sql.NullString
-		nonce         sql.NullString
-		nonceStr      *string
-		lastUpdated   pq.NullTime
-		parentJobID   sql.NullInt64
-		parentBuildID sql.NullInt64
-		instanceVars  sql.NullString
+		groups           sql.NullString
+		varSources       sql.NullString
+		display          sql.NullString
+		taskDefaults     sql.NullString
+		webhooks         sql.NullString
+		webhooksNonce    sql.NullString
+		maxBuildDuration sql.NullString
+		nonce            sql.NullString
+		nonceStr         *string
+		lastUpdated      pq.NullTime
+		parentJobID      sql.NullInt64
+		parentBuildID    sql.NullInt64
+		instanceVars     sql.NullString
 	)
-	err := scan.Scan(&p.id, &p.name, &groups, &varSources, &display, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID, &instanceVars)
+	err := scan.Scan(&p.id, &p.name, &groups, &varSources, &display, &taskDefaults, &webhooks, &webhooksNonce, &maxBuildDuration, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID, &instanceVars)
 	if err != nil {
 		return err
 	}
 
 	p.lastUpdated = lastUpdated.Time
+	p.maxBuildDuration = maxBuildDuration.String
 	p.parentJobID = int(parentJobID.Int64)
 	p.parentBuildID = int(parentBuildID.Int64)
 
@@ -1399,6 +1894,36 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		p.display = displayConfig
 	}
 
+	if taskDefaults.Valid {
+		var taskDefaultsConfig *atc.TaskDefaults
+		err = json.Unmarshal([]byte(taskDefaults.String), &taskDefaultsConfig)
+		if err != nil {
+			return err
+		}
+
+		p.taskDefaults = taskDefaultsConfig
+	}
+
+	if webhooks.Valid {
+		var webhooksNonceStr *string
+		if webhooksNonce.Valid {
+			webhooksNonceStr = &webhooksNonce.String
+		}
+
+		decryptedWebhooks, err := p.conn.EncryptionStrategy().Decrypt(webhooks.String, webhooksNonceStr)
+		if err != nil {
+			return err
+		}
+
+		var pipelineWebhooks atc.WebhookConfigs
+		err = json.Unmarshal(decryptedWebhooks, &pipelineWebhooks)
+		if err != nil {
+			return err
+		}
+
+		p.webhooks = pipelineWebhooks
+	}
+
 	if varSources.Valid {
 		var pipelineVarSources atc.VarSourceConfigs
 		decryptedVarSource, err := p.conn.EncryptionStrategy().Decrypt(varSources.String, nonceStr)
@@ -1478,6 +2003,7 @@ func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
 		&t.admin,
 		&providerAuth,
 		&nonce,
+		&t.maxConcurrentBuilds,
 	)
 	if err != nil {
 		return err
@@ -1638,6 +2164,11 @@ func insertJobPipes(tx Tx, jobConfigs atc.JobConfigs, resourceNameToID map[strin
 }
 
 func insertJobInput(tx Tx, step *atc.GetStep, jobName string, resourceNameToID map[string]int, jobNameToID map[string]int) error {
+	var versionConstraint sql.NullString
+	if step.VersionConstraint != "" {
+		versionConstraint = sql.NullString{Valid: true, String: step.VersionConstraint}
+	}
+
 	if len(step.Passed) != 0 {
 		for _, passedJob := range step.Passed {
 			var version sql.NullString
@@ -1651,8 +2182,8 @@ func insertJobInput(tx Tx, step *atc.GetStep, jobName string, resourceNameToID m
 			}
 
 			_, err := psql.Insert("job_inputs").
-				Columns("name", "job_id", "resource_id", "passed_job_id", "trigger", "version").
-				Values(step.Name, jobNameToID[jobName], resourceNameToID[step.ResourceName()], jobNameToID[passedJob], step.Trigger, version).
+				Columns("name", "job_id", "resource_id", "passed_job_id", "trigger", "version", "version_constraint").
+				Values(step.Name, jobNameToID[jobName], resourceNameToID[step.ResourceName()], jobNameToID[passedJob], step.Trigger, version, versionConstraint).
 				RunWith(tx).
 				Exec()
 			if err != nil {
@@ -1671,8 +2202,8 @@ func insertJobInput(tx Tx, step *atc.GetStep, jobName string, resourceNameToID m
 		}
 
 		_, err := psql.Insert("job_inputs").
-			Columns("name", "job_id", "resource_id", "trigger", "version").
-			Values(step.Name, jobNameToID[jobName], resourceNameToID[step.ResourceName()], step.Trigger, version).
+			Columns("name", "job_id", "resource_id", "trigger", "version", "version_constraint").
+			Values(step.Name, jobNameToID[jobName], resourceNameToID[step.ResourceName()], step.Trigger, version, versionConstraint).
 			RunWith(tx).
 			Exec()
 		if err != nil {
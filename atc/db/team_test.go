@@ -15,6 +15,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbtest"
 	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/vars"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -1002,6 +1003,21 @@ var _ = Describe("Team", func() {
 			}
 		})
 
+		Describe("UpdateMaxConcurrentBuilds", func() {
+			It("saves the limit of the team", func() {
+				Expect(team.MaxConcurrentBuilds()).To(Equal(0))
+
+				err := team.UpdateMaxConcurrentBuilds(5)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(team.MaxConcurrentBuilds()).To(Equal(5))
+
+				foundTeam, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(foundTeam.MaxConcurrentBuilds()).To(Equal(5))
+			})
+		})
+
 		Describe("UpdateProviderAuth", func() {
 			It("saves auth team info to the existing team", func() {
 				err := team.UpdateProviderAuth(authProvider)
@@ -1176,6 +1192,61 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("SetPipelinesVar", func() {
+		var (
+			pipeline1 db.Pipeline
+			pipeline2 db.Pipeline
+		)
+
+		BeforeEach(func() {
+			var err error
+			pipeline1, _, err = team.SavePipeline(atc.PipelineRef{Name: "pipeline1"}, atc.Config{}, 0, false)
+			Expect(err).ToNot(HaveOccurred())
+			pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "pipeline2"}, atc.Config{}, 0, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = pipeline1.SetVar("some-var", "old-value", true)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("only sets the var of the pipelines which have it", func() {
+			set, err := team.SetPipelinesVar([]int{pipeline2.ID(), pipeline1.ID()}, "some-var", "new-value", false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(set).To(Equal(map[int]bool{pipeline1.ID(): true, pipeline2.ID(): false}))
+
+			localVars, err := pipeline1.Vars()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(localVars).To(Equal(vars.StaticVariables{"some-var": "new-value"}))
+
+			localVars, err = pipeline2.Vars()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(localVars).To(BeEmpty())
+		})
+
+		Context("when creating the var", func() {
+			It("sets it on every pipeline", func() {
+				set, err := team.SetPipelinesVar([]int{pipeline1.ID(), pipeline2.ID()}, "some-var", "new-value", true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(set).To(Equal(map[int]bool{pipeline1.ID(): true, pipeline2.ID(): true}))
+
+				localVars, err := pipeline2.Vars()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(localVars).To(Equal(vars.StaticVariables{"some-var": "new-value"}))
+			})
+		})
+
+		Context("when setting the var of one of the pipelines fails", func() {
+			It("sets it on none of them", func() {
+				_, err := team.SetPipelinesVar([]int{pipeline1.ID(), pipeline2.ID() + 1000}, "some-var", "new-value", true)
+				Expect(err).To(HaveOccurred())
+
+				localVars, err := pipeline1.Vars()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(localVars).To(Equal(vars.StaticVariables{"some-var": "old-value"}))
+			})
+		})
+	})
+
 	Describe("OrderPipelines", func() {
 		var (
 			instancePipeline1 db.Pipeline
@@ -3893,4 +3964,242 @@ var _ = Describe("Team", func() {
 			})
 		})
 	})
+
+	Describe("SharedArtifacts", func() {
+		var (
+			ownerTeam   db.Team
+			granteeTeam db.Team
+			build       db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			ownerTeam, err = teamFactory.CreateTeam(atc.Team{Name: "artifact-owner"})
+			Expect(err).ToNot(HaveOccurred())
+
+			granteeTeam, err = teamFactory.CreateTeam(atc.Team{Name: "artifact-grantee"})
+			Expect(err).ToNot(HaveOccurred())
+
+			build, err = ownerTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = ownerTeam.PublishSharedArtifact("some-artifact", build.ID(), []byte("some-tgz"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("can be consumed by the owning team", func() {
+			data, found, err := ownerTeam.FindSharedArtifact("artifact-owner", "some-artifact")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(data).To(Equal([]byte("some-tgz")))
+		})
+
+		It("cannot be consumed by other teams without a grant", func() {
+			_, found, err := granteeTeam.FindSharedArtifact("artifact-owner", "some-artifact")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("lists the artifact", func() {
+			artifacts, err := ownerTeam.SharedArtifacts()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(artifacts).To(HaveLen(1))
+			Expect(artifacts[0].Name).To(Equal("some-artifact"))
+			Expect(artifacts[0].BuildID).To(Equal(build.ID()))
+			Expect(artifacts[0].Size).To(Equal(int64(len("some-tgz"))))
+			Expect(artifacts[0].GrantedTeams).To(BeEmpty())
+		})
+
+		Context("when it is published again", func() {
+			BeforeEach(func() {
+				err := ownerTeam.PublishSharedArtifact("some-artifact", build.ID(), []byte("new-tgz"))
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("replaces the contents", func() {
+				data, _, err := ownerTeam.FindSharedArtifact("artifact-owner", "some-artifact")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(data).To(Equal([]byte("new-tgz")))
+			})
+		})
+
+		Context("when it is granted to another team", func() {
+			BeforeEach(func() {
+				err := ownerTeam.GrantSharedArtifact("some-artifact", granteeTeam.ID())
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("can be consumed by that team", func() {
+				data, found, err := granteeTeam.FindSharedArtifact("artifact-owner", "some-artifact")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(data).To(Equal([]byte("some-tgz")))
+			})
+
+			It("lists the grant", func() {
+				artifacts, err := ownerTeam.SharedArtifacts()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(artifacts[0].GrantedTeams).To(Equal([]string{"artifact-grantee"}))
+			})
+
+			Context("when the grant is revoked", func() {
+				BeforeEach(func() {
+					err := ownerTeam.RevokeSharedArtifact("some-artifact", granteeTeam.ID())
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("can no longer be consumed by that team", func() {
+					_, found, err := granteeTeam.FindSharedArtifact("artifact-owner", "some-artifact")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when an artifact is granted before it is published", func() {
+			BeforeEach(func() {
+				err := ownerTeam.GrantSharedArtifact("future-artifact", granteeTeam.ID())
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("lists the grant without a build", func() {
+				artifacts, err := ownerTeam.SharedArtifacts()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(artifacts).To(HaveLen(2))
+				Expect(artifacts[1].Name).To(Equal("future-artifact"))
+				Expect(artifacts[1].BuildID).To(BeZero())
+				Expect(artifacts[1].GrantedTeams).To(Equal([]string{"artifact-grantee"}))
+			})
+		})
+	})
+
+	Describe("ResourceTypes", func() {
+		var (
+			pipeline       db.Pipeline
+			pipelineConfig atc.Config
+		)
+
+		BeforeEach(func() {
+			pipelineConfig = atc.Config{
+				ResourceTypes: atc.ResourceTypes{
+					{
+						Name:   "pipeline-type",
+						Type:   "registry-image",
+						Source: atc.Source{"repository": "pipeline/image"},
+					},
+				},
+				Jobs: atc.JobConfigs{
+					{Name: "some-job"},
+				},
+			}
+
+			var err error
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "resource-types-pipeline"}, pipelineConfig, db.ConfigVersion(0), false)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = team.SaveResourceTypes(atc.ResourceTypes{
+				{
+					Name:   "team-type",
+					Type:   "registry-image",
+					Source: atc.Source{"repository": "team/image", "password": "((registry-password))"},
+				},
+				{
+					Name:   "pipeline-type",
+					Type:   "registry-image",
+					Source: atc.Source{"repository": "team/other-image"},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the team's resource types", func() {
+			resourceTypes, err := team.ResourceTypes()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resourceTypes).To(HaveLen(2))
+			Expect(resourceTypes[0].Name).To(Equal("pipeline-type"))
+			Expect(resourceTypes[1].Name).To(Equal("team-type"))
+			Expect(resourceTypes[1].Source).To(Equal(atc.Source{"repository": "team/image", "password": "((registry-password))"}))
+		})
+
+		It("does not return the resource types of other teams", func() {
+			resourceTypes, err := otherTeam.ResourceTypes()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resourceTypes).To(BeEmpty())
+		})
+
+		It("makes them available to the team's pipelines", func() {
+			resourceType, found, err := pipeline.ResourceType("team-type")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(resourceType.TeamScoped()).To(BeTrue())
+			Expect(resourceType.Source()).To(Equal(atc.Source{"repository": "team/image", "password": "((registry-password))"}))
+		})
+
+		It("prefers the pipeline's own resource type of the same name", func() {
+			resourceType, found, err := pipeline.ResourceType("pipeline-type")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(resourceType.TeamScoped()).To(BeFalse())
+			Expect(resourceType.Source()).To(Equal(atc.Source{"repository": "pipeline/image"}))
+		})
+
+		It("leaves them out of the pipeline's config", func() {
+			config, err := pipeline.Config()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.ResourceTypes).To(Equal(pipelineConfig.ResourceTypes))
+		})
+
+		Context("when the pipeline stops defining a resource type of the same name", func() {
+			BeforeEach(func() {
+				pipelineConfig.ResourceTypes = nil
+
+				var err error
+				pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "resource-types-pipeline"}, pipelineConfig, pipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("falls back on the team's resource type", func() {
+				resourceType, found, err := pipeline.ResourceType("pipeline-type")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(resourceType.TeamScoped()).To(BeTrue())
+				Expect(resourceType.Source()).To(Equal(atc.Source{"repository": "team/other-image"}))
+			})
+		})
+
+		Context("when a pipeline is created afterwards", func() {
+			It("makes them available to it", func() {
+				otherPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
+					Jobs: atc.JobConfigs{{Name: "some-job"}},
+				}, db.ConfigVersion(0), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				resourceTypes, err := otherPipeline.ResourceTypes()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resourceTypes).To(HaveLen(2))
+			})
+		})
+
+		Context("when a resource type is removed from the team", func() {
+			BeforeEach(func() {
+				err := team.SaveResourceTypes(atc.ResourceTypes{
+					{Name: "pipeline-type", Type: "registry-image"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is no longer available to the team's pipelines", func() {
+				_, found, err := pipeline.ResourceType("team-type")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("keeps the pipeline's own resource types", func() {
+				resourceType, found, err := pipeline.ResourceType("pipeline-type")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(resourceType.TeamScoped()).To(BeFalse())
+			})
+		})
+	})
 })
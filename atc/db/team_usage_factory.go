@@ -0,0 +1,128 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// TeamUsage is how much of the cluster a team was using as of UpdatedAt.
+type TeamUsage struct {
+	TeamName   string
+	Pipelines  int
+	Resources  int
+	Containers int
+	Volumes    int
+	UpdatedAt  time.Time
+}
+
+// TeamUsageFactory maintains the per-team usage counts. Counting spans some
+// of the largest tables, so the counts are computed periodically by
+// RefreshUsage and stored in team_usage rather than computed on every read.
+//
+//counterfeiter:generate . TeamUsageFactory
+type TeamUsageFactory interface {
+	// RefreshUsage recomputes the usage of every team.
+	RefreshUsage() error
+
+	// AllTeamUsage returns the last computed usage of every team, ordered by
+	// team name.
+	AllTeamUsage() ([]TeamUsage, error)
+
+	// TeamUsage is like AllTeamUsage, limited to the given teams.
+	TeamUsage(teamNames []string) ([]TeamUsage, error)
+}
+
+type teamUsageFactory struct {
+	conn Conn
+}
+
+func NewTeamUsageFactory(conn Conn) TeamUsageFactory {
+	return &teamUsageFactory{
+		conn: conn,
+	}
+}
+
+// Only unarchived pipelines and their active resources are counted, along
+// with created containers and volumes. Volumes which don't belong to a team,
+// such as resource caches, are shared and aren't counted against anyone.
+const refreshTeamUsageQuery = `
+	INSERT INTO team_usage (team_id, pipelines, resources, containers, volumes, updated_at)
+	SELECT t.id, COALESCE(p.count, 0), COALESCE(r.count, 0), COALESCE(c.count, 0), COALESCE(v.count, 0), now()
+	FROM teams t
+	LEFT JOIN (
+		SELECT team_id, COUNT(*) AS count
+		FROM pipelines
+		WHERE NOT archived
+		GROUP BY team_id
+	) p ON p.team_id = t.id
+	LEFT JOIN (
+		SELECT p.team_id, COUNT(*) AS count
+		FROM resources r
+		JOIN pipelines p ON p.id = r.pipeline_id
+		WHERE r.active AND NOT p.archived
+		GROUP BY p.team_id
+	) r ON r.team_id = t.id
+	LEFT JOIN (
+		SELECT team_id, COUNT(*) AS count
+		FROM containers
+		WHERE state = 'created'
+		GROUP BY team_id
+	) c ON c.team_id = t.id
+	LEFT JOIN (
+		SELECT team_id, COUNT(*) AS count
+		FROM volumes
+		WHERE state = 'created'
+		GROUP BY team_id
+	) v ON v.team_id = t.id
+	ON CONFLICT (team_id) DO UPDATE SET
+		pipelines = EXCLUDED.pipelines,
+		resources = EXCLUDED.resources,
+		containers = EXCLUDED.containers,
+		volumes = EXCLUDED.volumes,
+		updated_at = EXCLUDED.updated_at
+`
+
+func (f *teamUsageFactory) RefreshUsage() error {
+	_, err := f.conn.Exec(refreshTeamUsageQuery)
+	return err
+}
+
+func (f *teamUsageFactory) AllTeamUsage() ([]TeamUsage, error) {
+	return f.teamUsage(nil)
+}
+
+func (f *teamUsageFactory) TeamUsage(teamNames []string) ([]TeamUsage, error) {
+	return f.teamUsage(sq.Eq{"t.name": teamNames})
+}
+
+func (f *teamUsageFactory) teamUsage(where sq.Sqlizer) ([]TeamUsage, error) {
+	query := psql.Select("t.name", "u.pipelines", "u.resources", "u.containers", "u.volumes", "u.updated_at").
+		From("team_usage u").
+		Join("teams t ON t.id = u.team_id").
+		OrderBy("t.name ASC")
+
+	if where != nil {
+		query = query.Where(where)
+	}
+
+	rows, err := query.RunWith(f.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	usage := []TeamUsage{}
+	for rows.Next() {
+		var u TeamUsage
+		err = rows.Scan(&u.TeamName, &u.Pipelines, &u.Resources, &u.Containers, &u.Volumes, &u.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		usage = append(usage, u)
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,116 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamUsageFactory", func() {
+	var (
+		usageFactory db.TeamUsageFactory
+		otherTeam    db.Team
+	)
+
+	BeforeEach(func() {
+		usageFactory = db.NewTeamUsageFactory(dbConn)
+
+		var err error
+		otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+		Expect(err).ToNot(HaveOccurred())
+
+		build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+
+		creatingContainer, err := defaultWorker.CreateContainer(
+			db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()),
+			db.ContainerMetadata{},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		creatingVolume, err := volumeRepository.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), creatingContainer, "some-path")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = creatingVolume.Created()
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = creatingContainer.Created()
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = defaultWorker.CreateContainer(
+			db.NewBuildStepContainerOwner(build.ID(), "other-plan", defaultTeam.ID()),
+			db.ContainerMetadata{},
+		)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("RefreshUsage", func() {
+		It("reports nothing until the usage has been computed", func() {
+			usage, err := usageFactory.AllTeamUsage()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usage).To(BeEmpty())
+		})
+
+		Context("once the usage has been computed", func() {
+			BeforeEach(func() {
+				Expect(usageFactory.RefreshUsage()).To(Succeed())
+			})
+
+			It("counts the pipelines, active resources, created containers and created volumes of every team", func() {
+				usage, err := usageFactory.AllTeamUsage()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(usage).To(HaveLen(2))
+
+				Expect(usage[0].TeamName).To(Equal("default-team"))
+				Expect(usage[0].Pipelines).To(Equal(1))
+				Expect(usage[0].Resources).To(Equal(1))
+				Expect(usage[0].Containers).To(Equal(1))
+				Expect(usage[0].Volumes).To(Equal(1))
+				Expect(usage[0].UpdatedAt).ToNot(BeZero())
+
+				Expect(usage[1].TeamName).To(Equal("other-team"))
+				Expect(usage[1].Pipelines).To(BeZero())
+				Expect(usage[1].Resources).To(BeZero())
+				Expect(usage[1].Containers).To(BeZero())
+				Expect(usage[1].Volumes).To(BeZero())
+			})
+
+			It("can be limited to some teams", func() {
+				usage, err := usageFactory.TeamUsage([]string{"other-team"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(usage).To(HaveLen(1))
+				Expect(usage[0].TeamName).To(Equal("other-team"))
+
+				usage, err = usageFactory.TeamUsage([]string{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(usage).To(BeEmpty())
+			})
+
+			Context("when the usage changes", func() {
+				BeforeEach(func() {
+					Expect(defaultPipeline.Archive()).To(Succeed())
+
+					_, _, err := otherTeam.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, defaultPipelineConfig, db.ConfigVersion(0), false)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(usageFactory.RefreshUsage()).To(Succeed())
+				})
+
+				It("updates the counts", func() {
+					usage, err := usageFactory.AllTeamUsage()
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(usage[0].TeamName).To(Equal("default-team"))
+					Expect(usage[0].Pipelines).To(BeZero())
+					Expect(usage[0].Resources).To(BeZero())
+
+					Expect(usage[1].TeamName).To(Equal("other-team"))
+					Expect(usage[1].Pipelines).To(Equal(1))
+					Expect(usage[1].Resources).To(Equal(1))
+				})
+			})
+		})
+	})
+})
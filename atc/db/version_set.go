@@ -0,0 +1,415 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var ErrVersionSetExists = errors.New("version set already exists")
+
+// VersionSetMissingVersionsError is returned when applying a version set
+// whose captured version of some resources can no longer be pinned, either
+// because the resource was removed from the pipeline or because the version
+// is no longer known to it (e.g. its source changed).
+type VersionSetMissingVersionsError struct {
+	Resources []string
+}
+
+func (err VersionSetMissingVersionsError) Error() string {
+	return fmt.Sprintf("captured version not found for resource(s): %s", strings.Join(err.Resources, ", "))
+}
+
+// VersionSetPinnedThroughConfigError is returned when applying a version set
+// to resources whose version is pinned in the pipeline config to another
+// version than the captured one, since the config takes precedence over any
+// pin made through the API.
+type VersionSetPinnedThroughConfigError struct {
+	Resources []string
+}
+
+func (err VersionSetPinnedThroughConfigError) Error() string {
+	return fmt.Sprintf("resource(s) pinned through config: %s", strings.Join(err.Resources, ", "))
+}
+
+// A VersionSet is a named snapshot of the versions of a pipeline's
+// resources, which can be pinned and unpinned together.
+//
+//counterfeiter:generate . VersionSet
+type VersionSet interface {
+	ID() int
+	Name() string
+	PipelineID() int
+	CreatedAt() time.Time
+	Applied() bool
+
+	// Versions returns the captured versions, keyed by resource name.
+	Versions() (map[string]atc.Version, error)
+
+	Apply() error
+	Clear() error
+	Destroy() error
+}
+
+var versionSetsQuery = psql.Select(
+	"s.id",
+	"s.name",
+	"s.pipeline_id",
+	"s.created_at",
+	"EXISTS (SELECT 1 FROM resource_pins rp WHERE rp.version_set_id = s.id)",
+).
+	From("pipeline_version_sets s")
+
+type versionSet struct {
+	id         int
+	name       string
+	pipelineID int
+	createdAt  time.Time
+	applied    bool
+
+	conn Conn
+}
+
+func (s *versionSet) ID() int              { return s.id }
+func (s *versionSet) Name() string         { return s.name }
+func (s *versionSet) PipelineID() int      { return s.pipelineID }
+func (s *versionSet) CreatedAt() time.Time { return s.createdAt }
+func (s *versionSet) Applied() bool        { return s.applied }
+
+func (s *versionSet) Versions() (map[string]atc.Version, error) {
+	rows, err := psql.Select("r.name", "sv.version").
+		From("pipeline_version_set_versions sv").
+		Join("resources r ON r.id = sv.resource_id").
+		Where(sq.Eq{"sv.version_set_id": s.id}).
+		RunWith(s.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	versions := map[string]atc.Version{}
+	for rows.Next() {
+		var (
+			name        string
+			versionJSON []byte
+		)
+
+		err = rows.Scan(&name, &versionJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		var version atc.Version
+		err = json.Unmarshal(versionJSON, &version)
+		if err != nil {
+			return nil, err
+		}
+
+		versions[name] = version
+	}
+
+	return versions, nil
+}
+
+// Apply pins every resource of the set to its captured version. Either all
+// of them are pinned or, if any of them can't be, none are. Resources still
+// pinned through config to their captured version are left pinned by the
+// config.
+func (s *versionSet) Apply() error {
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := tx.Query(`
+		SELECT r.id, r.name, sv.version,
+			r.active AND EXISTS (
+				SELECT 1
+				FROM resource_config_versions v
+				WHERE v.resource_config_scope_id = r.resource_config_scope_id
+				AND v.version = sv.version
+			),
+			COALESCE((
+				SELECT rp.version = sv.version
+				FROM resource_pins rp
+				WHERE rp.resource_id = r.id
+				AND rp.config
+			), false),
+			EXISTS (
+				SELECT 1
+				FROM resource_pins rp
+				WHERE rp.resource_id = r.id
+				AND rp.config
+			)
+		FROM pipeline_version_set_versions sv
+		JOIN resources r ON r.id = sv.resource_id
+		WHERE sv.version_set_id = $1
+		ORDER BY r.name
+	`, s.id)
+	if err != nil {
+		return err
+	}
+
+	type capturedVersion struct {
+		resourceID int
+		version    []byte
+	}
+
+	var (
+		captured            []capturedVersion
+		missing             []string
+		pinnedThroughConfig []string
+	)
+
+	for rows.Next() {
+		var (
+			c                                     capturedVersion
+			name                                  string
+			found, configPinMatches, configPinned bool
+		)
+
+		err = rows.Scan(&c.resourceID, &name, &c.version, &found, &configPinMatches, &configPinned)
+		if err != nil {
+			Close(rows)
+			return err
+		}
+
+		if configPinMatches {
+			continue
+		}
+
+		if !found {
+			missing = append(missing, name)
+		} else if configPinned {
+			pinnedThroughConfig = append(pinnedThroughConfig, name)
+		}
+
+		captured = append(captured, c)
+	}
+
+	Close(rows)
+
+	if len(missing) != 0 {
+		return VersionSetMissingVersionsError{Resources: missing}
+	}
+
+	if len(pinnedThroughConfig) != 0 {
+		return VersionSetPinnedThroughConfigError{Resources: pinnedThroughConfig}
+	}
+
+	comment := fmt.Sprintf("pinned by version set '%s'", s.name)
+
+	for _, c := range captured {
+		_, err = psql.Insert("resource_pins").
+			Columns("resource_id", "version", "comment_text", "config", "version_set_id").
+			Values(c.resourceID, c.version, comment, false, s.id).
+			Suffix("ON CONFLICT (resource_id) DO UPDATE SET version = EXCLUDED.version, comment_text = EXCLUDED.comment_text, version_set_id = EXCLUDED.version_set_id").
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+
+		err = requestScheduleForJobsUsingResource(tx, c.resourceID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Clear unpins the resources that are still pinned by the set. Resources that
+// have since been pinned to another version are left alone.
+func (s *versionSet) Clear() error {
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := psql.Delete("resource_pins").
+		Where(sq.Eq{"version_set_id": s.id}).
+		Suffix("RETURNING resource_id").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	var resourceIDs []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			Close(rows)
+			return err
+		}
+
+		resourceIDs = append(resourceIDs, id)
+	}
+
+	Close(rows)
+
+	for _, id := range resourceIDs {
+		err = requestScheduleForJobsUsingResource(tx, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Destroy deletes the set. Resources it has pinned stay pinned, as if they
+// had been pinned individually.
+func (s *versionSet) Destroy() error {
+	_, err := psql.Delete("pipeline_version_sets").
+		Where(sq.Eq{"id": s.id}).
+		RunWith(s.conn).
+		Exec()
+
+	return err
+}
+
+func (p *pipeline) SaveVersionSet(name string) (VersionSet, error) {
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	var id int
+	err = psql.Insert("pipeline_version_sets").
+		Columns("pipeline_id", "name").
+		Values(p.id, name).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return nil, ErrVersionSetExists
+		}
+
+		return nil, err
+	}
+
+	// capture the version each resource is pinned to, or else its latest
+	// enabled version. resources that have no version yet are left out.
+	_, err = tx.Exec(`
+		INSERT INTO pipeline_version_set_versions (version_set_id, resource_id, version)
+		SELECT $1, c.id, c.version
+		FROM (
+			SELECT r.id, COALESCE(rp.version, (
+				SELECT v.version
+				FROM resource_config_versions v
+				WHERE v.resource_config_scope_id = r.resource_config_scope_id
+				AND NOT EXISTS (
+					SELECT 1
+					FROM resource_disabled_versions d
+					WHERE d.resource_id = r.id
+					AND d.version_md5 = v.version_md5
+				)
+				ORDER BY v.check_order DESC
+				LIMIT 1
+			)) AS version
+			FROM resources r
+			LEFT JOIN resource_pins rp ON rp.resource_id = r.id
+			WHERE r.pipeline_id = $2
+			AND r.active
+		) c
+		WHERE c.version IS NOT NULL
+	`, id, p.id)
+	if err != nil {
+		return nil, err
+	}
+
+	set, found, err := p.versionSet(tx, sq.Eq{"s.id": id})
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, sql.ErrNoRows
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return set, nil
+}
+
+func (p *pipeline) VersionSet(name string) (VersionSet, bool, error) {
+	return p.versionSet(p.conn, sq.Eq{
+		"s.pipeline_id": p.id,
+		"s.name":        name,
+	})
+}
+
+func (p *pipeline) VersionSets() ([]VersionSet, error) {
+	rows, err := versionSetsQuery.
+		Where(sq.Eq{"s.pipeline_id": p.id}).
+		OrderBy("s.name").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	sets := []VersionSet{}
+	for rows.Next() {
+		set := &versionSet{conn: p.conn}
+
+		err = scanVersionSet(set, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		sets = append(sets, set)
+	}
+
+	return sets, nil
+}
+
+func (p *pipeline) versionSet(runner sq.Runner, where sq.Eq) (VersionSet, bool, error) {
+	set := &versionSet{conn: p.conn}
+
+	row := versionSetsQuery.
+		Where(where).
+		RunWith(runner).
+		QueryRow()
+
+	err := scanVersionSet(set, row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return set, true, nil
+}
+
+func scanVersionSet(set *versionSet, row scannable) error {
+	return row.Scan(&set.id, &set.name, &set.pipelineID, &set.createdAt, &set.applied)
+}
@@ -0,0 +1,270 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbtest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VersionSet", func() {
+	var scenario *dbtest.Scenario
+
+	BeforeEach(func() {
+		scenario = dbtest.Setup(
+			builder.WithPipeline(atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   "some-base-resource-type",
+						Source: atc.Source{"some": "repository"},
+					},
+					{
+						Name:   "other-resource",
+						Type:   "some-base-resource-type",
+						Source: atc.Source{"other": "repository"},
+					},
+					{
+						Name:   "unchecked-resource",
+						Type:   "some-base-resource-type",
+						Source: atc.Source{"unchecked": "repository"},
+					},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						PlanSequence: []atc.Step{
+							{
+								Config: &atc.GetStep{
+									Name: "some-resource",
+								},
+							},
+						},
+					},
+				},
+			}),
+			builder.WithResourceVersions(
+				"some-resource",
+				atc.Version{"version": "v1"},
+				atc.Version{"version": "v2"},
+			),
+			builder.WithResourceVersions(
+				"other-resource",
+				atc.Version{"version": "o1"},
+				atc.Version{"version": "o2"},
+			),
+			builder.WithPinnedVersion("other-resource", atc.Version{"version": "o1"}),
+		)
+	})
+
+	saveVersionSet := func(name string) db.VersionSet {
+		set, err := scenario.Pipeline.SaveVersionSet(name)
+		Expect(err).ToNot(HaveOccurred())
+		return set
+	}
+
+	Describe("SaveVersionSet", func() {
+		It("captures the pinned or else latest version of each resource", func() {
+			set := saveVersionSet("release")
+			Expect(set.Name()).To(Equal("release"))
+			Expect(set.PipelineID()).To(Equal(scenario.Pipeline.ID()))
+			Expect(set.Applied()).To(BeFalse())
+
+			versions, err := set.Versions()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(Equal(map[string]atc.Version{
+				"some-resource":  {"version": "v2"},
+				"other-resource": {"version": "o1"},
+			}))
+		})
+
+		It("cannot save two sets with the same name", func() {
+			saveVersionSet("release")
+
+			_, err := scenario.Pipeline.SaveVersionSet("release")
+			Expect(err).To(Equal(db.ErrVersionSetExists))
+		})
+
+		It("can be found by name", func() {
+			saveVersionSet("release")
+			saveVersionSet("another")
+
+			set, found, err := scenario.Pipeline.VersionSet("release")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(set.Name()).To(Equal("release"))
+
+			sets, err := scenario.Pipeline.VersionSets()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sets).To(HaveLen(2))
+			Expect(sets[0].Name()).To(Equal("another"))
+			Expect(sets[1].Name()).To(Equal("release"))
+		})
+	})
+
+	Describe("Apply", func() {
+		var set db.VersionSet
+
+		BeforeEach(func() {
+			set = saveVersionSet("release")
+
+			scenario.Run(builder.WithResourceVersions("some-resource", atc.Version{"version": "v3"}))
+
+			err := scenario.Resource("other-resource").UnpinVersion()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("pins every resource to its captured version", func() {
+			requestedSchedule := scenario.Job("some-job").ScheduleRequestedTime()
+
+			Expect(set.Apply()).To(Succeed())
+
+			Expect(scenario.Resource("some-resource").APIPinnedVersion()).To(Equal(atc.Version{"version": "v2"}))
+			Expect(scenario.Resource("some-resource").PinComment()).To(Equal("pinned by version set 'release'"))
+			Expect(scenario.Resource("other-resource").APIPinnedVersion()).To(Equal(atc.Version{"version": "o1"}))
+			Expect(scenario.Resource("unchecked-resource").APIPinnedVersion()).To(BeNil())
+
+			Expect(scenario.Job("some-job").ScheduleRequestedTime()).To(BeTemporally(">", requestedSchedule))
+
+			reloaded, found, err := scenario.Pipeline.VersionSet("release")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reloaded.Applied()).To(BeTrue())
+		})
+
+		Context("when a captured version is no longer available", func() {
+			BeforeEach(func() {
+				scenario.Run(builder.WithPipeline(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   "some-base-resource-type",
+							Source: atc.Source{"some": "other-repository"},
+						},
+						{
+							Name:   "other-resource",
+							Type:   "some-base-resource-type",
+							Source: atc.Source{"other": "repository"},
+						},
+					},
+				}))
+
+				scenario.Run(builder.WithResourceVersions("some-resource", atc.Version{"version": "elsewhere"}))
+			})
+
+			It("reports which resources lack it and pins nothing", func() {
+				err := set.Apply()
+				Expect(err).To(Equal(db.VersionSetMissingVersionsError{
+					Resources: []string{"some-resource"},
+				}))
+
+				Expect(scenario.Resource("other-resource").APIPinnedVersion()).To(BeNil())
+			})
+		})
+
+		Context("when a resource is pinned through config", func() {
+			var configPinnedVersion atc.Version
+
+			JustBeforeEach(func() {
+				scenario.Run(builder.WithPipeline(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   "some-base-resource-type",
+							Source: atc.Source{"some": "repository"},
+						},
+						{
+							Name:    "other-resource",
+							Type:    "some-base-resource-type",
+							Source:  atc.Source{"other": "repository"},
+							Version: configPinnedVersion,
+						},
+					},
+				}))
+			})
+
+			Context("to its captured version", func() {
+				BeforeEach(func() {
+					configPinnedVersion = atc.Version{"version": "o1"}
+				})
+
+				It("leaves it pinned by the config and pins the others", func() {
+					Expect(set.Apply()).To(Succeed())
+
+					Expect(scenario.Resource("some-resource").APIPinnedVersion()).To(Equal(atc.Version{"version": "v2"}))
+					Expect(scenario.Resource("other-resource").APIPinnedVersion()).To(BeNil())
+					Expect(scenario.Resource("other-resource").ConfigPinnedVersion()).To(Equal(atc.Version{"version": "o1"}))
+
+					Expect(set.Clear()).To(Succeed())
+					Expect(scenario.Resource("other-resource").ConfigPinnedVersion()).To(Equal(atc.Version{"version": "o1"}))
+				})
+			})
+
+			Context("to another version", func() {
+				BeforeEach(func() {
+					configPinnedVersion = atc.Version{"version": "o2"}
+				})
+
+				It("reports it and pins nothing", func() {
+					err := set.Apply()
+					Expect(err).To(Equal(db.VersionSetPinnedThroughConfigError{
+						Resources: []string{"other-resource"},
+					}))
+
+					Expect(scenario.Resource("some-resource").APIPinnedVersion()).To(BeNil())
+				})
+			})
+		})
+	})
+
+	Describe("Clear", func() {
+		var set db.VersionSet
+
+		BeforeEach(func() {
+			set = saveVersionSet("release")
+			Expect(set.Apply()).To(Succeed())
+		})
+
+		It("unpins the resources it pinned", func() {
+			Expect(set.Clear()).To(Succeed())
+
+			Expect(scenario.Resource("some-resource").APIPinnedVersion()).To(BeNil())
+			Expect(scenario.Resource("other-resource").APIPinnedVersion()).To(BeNil())
+		})
+
+		Context("when a resource has been pinned again since", func() {
+			BeforeEach(func() {
+				version, found, err := scenario.Resource("some-resource").FindVersion(atc.Version{"version": "v1"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				found, err = scenario.Resource("some-resource").PinVersion(version.ID(), "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("leaves that pin alone", func() {
+				Expect(set.Clear()).To(Succeed())
+
+				Expect(scenario.Resource("some-resource").APIPinnedVersion()).To(Equal(atc.Version{"version": "v1"}))
+				Expect(scenario.Resource("other-resource").APIPinnedVersion()).To(BeNil())
+			})
+		})
+	})
+
+	Describe("Destroy", func() {
+		It("removes the set but keeps its pins", func() {
+			set := saveVersionSet("release")
+			Expect(set.Apply()).To(Succeed())
+
+			Expect(set.Destroy()).To(Succeed())
+
+			_, found, err := scenario.Pipeline.VersionSet("release")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			Expect(scenario.Resource("some-resource").APIPinnedVersion()).To(Equal(atc.Version{"version": "v2"}))
+		})
+	})
+})
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// VersionToggle is a version of a resource being enabled or disabled, as
+// recorded when it happened.
+type VersionToggle struct {
+	ID      int
+	Version atc.Version
+	Enabled bool
+	Actor   string
+	Reason  string
+	Time    time.Time
+}
+
+// VersionToggles returns the versions of the resource enabled or disabled,
+// most recent first.
+func (r *resource) VersionToggles() ([]VersionToggle, error) {
+	rows, err := psql.Select("id", "version", "enabled", "actor", "reason", "toggle_time").
+		From("resource_version_toggles").
+		Where(sq.Eq{"resource_id": r.id}).
+		OrderBy("id DESC").
+		RunWith(r.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var toggles []VersionToggle
+	for rows.Next() {
+		var toggle VersionToggle
+		var version []byte
+		var reason sql.NullString
+
+		err = rows.Scan(&toggle.ID, &version, &toggle.Enabled, &toggle.Actor, &reason, &toggle.Time)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(version, &toggle.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		toggle.Reason = reason.String
+
+		toggles = append(toggles, toggle)
+	}
+
+	return toggles, nil
+}
+
+// recordVersionToggle records the version being enabled or disabled on the
+// resource. The version and the names of the resource, its pipeline and its
+// team are saved along with the record, so that the history outlives the
+// version being garbage collected and the resource being deleted.
+func recordVersionToggle(tx Tx, resourceID int, rcvID int, enabled bool, actor string, reason string) error {
+	_, err := tx.Exec(`
+		INSERT INTO resource_version_toggles (resource_id, team_name, pipeline_name, pipeline_instance_vars, resource_name, version, enabled, actor, reason)
+		SELECT r.id, t.name, p.name, p.instance_vars, r.name, rcv.version, $3, $4, NULLIF($5, '')
+		FROM resource_config_versions rcv, resources r
+		JOIN pipelines p ON p.id = r.pipeline_id
+		JOIN teams t ON t.id = p.team_id
+		WHERE r.id = $1
+		AND rcv.id = $2
+	`, resourceID, rcvID, enabled, actor, reason)
+	return err
+}
@@ -9,6 +9,8 @@ import (
 	"strconv"
 	"time"
 
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/tracing"
@@ -71,6 +73,34 @@ func (versions VersionsDB) VersionIsDisabled(ctx context.Context, resourceID int
 	return exists, nil
 }
 
+// PromotedVersions returns the versions which have been promoted as stable
+// for the resource.
+func (versions VersionsDB) PromotedVersions(ctx context.Context, resourceID int) (map[ResourceVersion]bool, error) {
+	rows, err := psql.Select("version_md5").
+		From("resource_promoted_versions").
+		Where(sq.Eq{"resource_id": resourceID}).
+		RunWith(versions.conn).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	promoted := map[ResourceVersion]bool{}
+	for rows.Next() {
+		var version ResourceVersion
+		err = rows.Scan(&version)
+		if err != nil {
+			return nil, err
+		}
+
+		promoted[version] = true
+	}
+
+	return promoted, rows.Err()
+}
+
 func (versions VersionsDB) LatestVersionOfResource(ctx context.Context, resourceID int) (ResourceVersion, bool, error) {
 	tx, err := versions.conn.Begin()
 	if err != nil {
@@ -96,6 +126,163 @@ func (versions VersionsDB) LatestVersionOfResource(ctx context.Context, resource
 	return version, true, nil
 }
 
+// LatestPromotedVersionOfResource returns the latest version of the resource
+// which has been promoted as stable for it.
+func (versions VersionsDB) LatestPromotedVersionOfResource(ctx context.Context, resourceID int) (ResourceVersion, bool, error) {
+	var scopeID sql.NullInt64
+	err := psql.Select("resource_config_scope_id").
+		From("resources").
+		Where(sq.Eq{"id": resourceID}).
+		RunWith(versions.conn).
+		QueryRowContext(ctx).
+		Scan(&scopeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+
+	if !scopeID.Valid {
+		return "", false, nil
+	}
+
+	var version ResourceVersion
+	err = psql.Select("version_md5").
+		From("resource_config_versions").
+		Where(sq.Eq{"resource_config_scope_id": scopeID}).
+		Where(sq.Expr("version_md5 IN (SELECT version_md5 FROM resource_promoted_versions WHERE resource_id = ?)", resourceID)).
+		Where(sq.Expr("version_md5 NOT IN (SELECT version_md5 FROM resource_disabled_versions WHERE resource_id = ?)", resourceID)).
+		OrderBy("check_order DESC").
+		Limit(1).
+		RunWith(versions.conn).
+		QueryRowContext(ctx).
+		Scan(&version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+
+	return version, true, nil
+}
+
+// LatestVersionOfResourceMatching returns the latest version of the resource
+// which satisfies the constraint. Versions without a field that parses as
+// semver are skipped. The versions are read a page at a time, newest first,
+// until one satisfies the constraint.
+func (versions VersionsDB) LatestVersionOfResourceMatching(ctx context.Context, resourceID int, constraint atc.VersionConstraint) (ResourceVersion, bool, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	var scopeID sql.NullInt64
+	err := psql.Select("resource_config_scope_id").
+		From("resources").
+		Where(sq.Eq{"id": resourceID}).
+		RunWith(versions.conn).
+		QueryRowContext(ctx).
+		Scan(&scopeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+
+	if !scopeID.Valid {
+		return "", false, nil
+	}
+
+	var skipped int
+	defer func() {
+		if skipped > 0 {
+			logger.Info("skipped-versions-without-semver", lager.Data{
+				"resource-id": resourceID,
+				"count":       skipped,
+			})
+		}
+	}()
+
+	var cursor *versionCursor
+	for {
+		builder := psql.Select("id", "check_order", "version", "version_md5").
+			From("resource_config_versions").
+			Where(sq.Eq{"resource_config_scope_id": scopeID}).
+			Where(sq.Expr("version_md5 NOT IN (SELECT version_md5 FROM resource_disabled_versions WHERE resource_id = ?)", resourceID)).
+			OrderBy("check_order DESC", "id DESC").
+			Limit(uint64(versions.limitRows))
+
+		if cursor != nil {
+			builder = builder.Where(sq.Expr("(check_order, id) < (?, ?)", cursor.checkOrder, cursor.id))
+		}
+
+		page, err := versions.versionsPage(ctx, builder)
+		if err != nil {
+			return "", false, err
+		}
+
+		for _, version := range page {
+			allowed, err := constraint.AllowsVersion(version.version)
+			if err != nil {
+				skipped++
+				continue
+			}
+
+			if allowed {
+				return version.md5, true, nil
+			}
+		}
+
+		if len(page) < versions.limitRows {
+			return "", false, nil
+		}
+
+		cursor = &page[len(page)-1].versionCursor
+	}
+}
+
+type versionCursor struct {
+	id         int
+	checkOrder int
+}
+
+type pagedVersion struct {
+	versionCursor
+
+	version atc.Version
+	md5     ResourceVersion
+}
+
+func (versions VersionsDB) versionsPage(ctx context.Context, builder sq.SelectBuilder) ([]pagedVersion, error) {
+	rows, err := builder.
+		RunWith(versions.conn).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	page := []pagedVersion{}
+	for rows.Next() {
+		var version pagedVersion
+		var versionJSON string
+		err = rows.Scan(&version.id, &version.checkOrder, &versionJSON, &version.md5)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(versionJSON), &version.version)
+		if err != nil {
+			return nil, err
+		}
+
+		page = append(page, version)
+	}
+
+	return page, rows.Err()
+}
+
 func (versions VersionsDB) SuccessfulBuilds(ctx context.Context, jobID int) PaginatedBuilds {
 	builder := psql.Select("id", "rerun_of").
 		From("builds").
@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -770,4 +773,199 @@ var _ = Describe("VersionsDB", func() {
 			})
 		})
 	})
+
+	Describe("LatestVersionOfResourceMatching", func() {
+		var (
+			scenario   *dbtest.Scenario
+			constraint atc.VersionConstraint
+
+			resourceVersion db.ResourceVersion
+			found           bool
+
+			testLogger *lagertest.TestLogger
+		)
+
+		BeforeEach(func() {
+			var err error
+			constraint, err = atc.ParseVersionConstraint("2.x")
+			Expect(err).ToNot(HaveOccurred())
+
+			testLogger = lagertest.NewTestLogger("versions")
+			ctx = lagerctx.NewContext(ctx, testLogger)
+		})
+
+		skippedLogs := func() []lager.LogFormat {
+			var logs []lager.LogFormat
+			for _, log := range testLogger.Logs() {
+				if log.Message == "versions.skipped-versions-without-semver" {
+					logs = append(logs, log)
+				}
+			}
+
+			return logs
+		}
+
+		JustBeforeEach(func() {
+			var err error
+			resourceVersion, found, err = vdb.LatestVersionOfResourceMatching(
+				ctx,
+				scenario.Resource("some-resource").ID(),
+				constraint,
+			)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when some versions satisfy the constraint", func() {
+			BeforeEach(func() {
+				scenario = dbtest.Setup(
+					builder.WithResourceVersions("some-resource",
+						atc.Version{"tag": "2.0.0"},
+						atc.Version{"tag": "2.1.0"},
+						atc.Version{"tag": "not-semver"},
+						atc.Version{"tag": "3.0.0"},
+					),
+				)
+			})
+
+			It("returns the latest satisfying version", func() {
+				Expect(found).To(BeTrue())
+				Expect(string(resourceVersion)).To(Equal(convertToMD5(atc.Version{"tag": "2.1.0"})))
+			})
+
+			It("logs the versions it skipped for not being semver", func() {
+				logs := skippedLogs()
+				Expect(logs).To(HaveLen(1))
+				Expect(logs[0].LogLevel).To(Equal(lager.INFO))
+				Expect(logs[0].Data["count"]).To(BeNumerically("==", 1))
+			})
+		})
+
+		Context("when the satisfying version is more than a page of versions back", func() {
+			BeforeEach(func() {
+				scenario = dbtest.Setup(
+					builder.WithResourceVersions("some-resource",
+						atc.Version{"tag": "2.0.0"},
+						atc.Version{"tag": "2.1.0"},
+						atc.Version{"tag": "3.0.0"},
+						atc.Version{"tag": "not-semver"},
+						atc.Version{"tag": "3.1.0"},
+						atc.Version{"tag": "also-not-semver"},
+						atc.Version{"tag": "3.2.0"},
+						atc.Version{"tag": "4.0.0"},
+					),
+				)
+			})
+
+			It("returns the latest satisfying version", func() {
+				Expect(found).To(BeTrue())
+				Expect(string(resourceVersion)).To(Equal(convertToMD5(atc.Version{"tag": "2.1.0"})))
+			})
+
+			It("logs the versions it skipped across the pages once", func() {
+				logs := skippedLogs()
+				Expect(logs).To(HaveLen(1))
+				Expect(logs[0].Data["count"]).To(BeNumerically("==", 2))
+			})
+		})
+
+		Context("when no version satisfies the constraint", func() {
+			BeforeEach(func() {
+				scenario = dbtest.Setup(
+					builder.WithResourceVersions("some-resource",
+						atc.Version{"tag": "1.0.0"},
+						atc.Version{"tag": "not-semver"},
+					),
+				)
+			})
+
+			It("does not find a version", func() {
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when every version is semver", func() {
+			BeforeEach(func() {
+				scenario = dbtest.Setup(
+					builder.WithResourceVersions("some-resource",
+						atc.Version{"tag": "2.0.0"},
+					),
+				)
+			})
+
+			It("logs nothing", func() {
+				Expect(skippedLogs()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("LatestPromotedVersionOfResource", func() {
+		var (
+			scenario *dbtest.Scenario
+
+			resourceVersion db.ResourceVersion
+			found           bool
+		)
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithResourceVersions("some-resource",
+					atc.Version{"v": "1"},
+					atc.Version{"v": "2"},
+					atc.Version{"v": "3"},
+				),
+			)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resourceVersion, found, err = vdb.LatestPromotedVersionOfResource(ctx, scenario.Resource("some-resource").ID())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when no version is promoted", func() {
+			It("does not find a version", func() {
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when some versions are promoted", func() {
+			BeforeEach(func() {
+				for _, v := range []atc.Version{{"v": "1"}, {"v": "2"}} {
+					found, err := scenario.Resource("some-resource").PromoteVersion(scenario.ResourceVersion("some-resource", v).ID())
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+				}
+			})
+
+			It("returns the latest promoted version", func() {
+				Expect(found).To(BeTrue())
+				Expect(string(resourceVersion)).To(Equal(convertToMD5(atc.Version{"v": "2"})))
+			})
+		})
+	})
+
+	Describe("PromotedVersions", func() {
+		var scenario *dbtest.Scenario
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithResourceVersions("some-resource",
+					atc.Version{"v": "1"},
+					atc.Version{"v": "2"},
+				),
+			)
+
+			found, err := scenario.Resource("some-resource").PromoteVersion(scenario.ResourceVersion("some-resource", atc.Version{"v": "2"}).ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("returns the versions promoted for the resource", func() {
+			promoted, err := vdb.PromotedVersions(ctx, scenario.Resource("some-resource").ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(promoted).To(Equal(map[db.ResourceVersion]bool{
+				db.ResourceVersion(convertToMD5(atc.Version{"v": "2"})): true,
+			}))
+		})
+	})
 })
@@ -161,6 +161,8 @@ type CreatedVolume interface {
 	ResourceType() (*VolumeResourceType, error)
 	BaseResourceType() (*UsedWorkerBaseResourceType, error)
 	TaskIdentifier() (int, atc.PipelineRef, string, string, error)
+
+	Size() (uint64, bool, error)
 }
 
 type createdVolume struct {
@@ -211,6 +213,31 @@ func (volume *createdVolume) BaseResourceType() (*UsedWorkerBaseResourceType, er
 	return volume.findWorkerBaseResourceTypeByID(volume.workerBaseResourceTypeID)
 }
 
+// Size returns the size of the volume as last reported by its worker. It is
+// not found if the worker hasn't reported it yet.
+func (volume *createdVolume) Size() (uint64, bool, error) {
+	var size sql.NullInt64
+	err := psql.Select("size").
+		From("volumes").
+		Where(sq.Eq{"id": volume.id}).
+		RunWith(volume.conn).
+		QueryRow().
+		Scan(&size)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	if !size.Valid {
+		return 0, false, nil
+	}
+
+	return uint64(size.Int64), true, nil
+}
+
 func (volume *createdVolume) TaskIdentifier() (int, atc.PipelineRef, string, string, error) {
 	if volume.workerTaskCacheID == 0 {
 		return 0, atc.PipelineRef{}, "", "", nil
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
 	uuid "github.com/nu7hatch/gouuid"
 )
 
@@ -29,10 +30,12 @@ type VolumeRepository interface {
 
 	FindVolumesForContainer(container CreatedContainer) ([]CreatedVolume, error)
 	GetOrphanedVolumes() ([]CreatedVolume, error)
+	GetEvictableResourceCacheVolumes(workerName string, limit int) ([]CreatedVolume, error)
 
 	DestroyFailedVolumes() (count int, err error)
 
 	GetDestroyingVolumes(workerName string) ([]string, error)
+	CountDestroyingVolumesByWorker() (map[string]int, error)
 
 	CreateVolume(int, string, VolumeType) (CreatingVolume, error)
 	FindCreatedVolume(handle string) (CreatedVolume, bool, error)
@@ -43,6 +46,8 @@ type VolumeRepository interface {
 	RemoveMissingVolumes(gracePeriod time.Duration) (removed int, err error)
 
 	DestroyUnknownVolumes(workerName string, handles []string) (int, error)
+
+	UpdateVolumeSizes(workerName string, sizes map[string]uint64) error
 }
 
 const noTeam = 0
@@ -274,12 +279,15 @@ func (repository *volumeRepository) CreateBaseResourceTypeVolume(uwbrt *UsedWork
 }
 
 func (repository *volumeRepository) CreateVolume(teamID int, workerName string, volumeType VolumeType) (CreatingVolume, error) {
+	columns := map[string]interface{}{}
+	if teamID != noTeam {
+		columns["team_id"] = teamID
+	}
+
 	volume, err := repository.createVolume(
 		0,
 		workerName,
-		map[string]interface{}{
-			"team_id": teamID,
-		},
+		columns,
 		volumeType,
 	)
 	if err != nil {
@@ -448,6 +456,19 @@ func (repository *volumeRepository) FindResourceCacheVolume(workerName string, r
 		return nil, false, nil
 	}
 
+	// keep track of when the cache was last used so that the least recently
+	// used caches are the first to be evicted under disk pressure. it's only
+	// tracked to the minute so that the row isn't written on every lookup.
+	_, err = psql.Update("worker_resource_caches").
+		Set("last_used", sq.Expr("date_trunc('minute', now())")).
+		Where(sq.Eq{"id": workerResourceCache.ID}).
+		Where(sq.Expr("last_used < date_trunc('minute', now())")).
+		RunWith(repository.conn).
+		Exec()
+	if err != nil {
+		return nil, false, err
+	}
+
 	return createdVolume, true, nil
 }
 
@@ -521,6 +542,61 @@ func (repository *volumeRepository) GetOrphanedVolumes() ([]CreatedVolume, error
 	return createdVolumes, nil
 }
 
+// GetEvictableResourceCacheVolumes returns the resource cache volumes of a
+// worker which may be destroyed to free up disk space, least recently used
+// first. Volumes with children, such as the copy-on-write volumes of running
+// containers, and the caches used by running builds or by containers are never
+// returned.
+func (repository *volumeRepository) GetEvictableResourceCacheVolumes(workerName string, limit int) ([]CreatedVolume, error) {
+	query, args, err := psql.Select(volumeColumns...).
+		From("volumes v").
+		LeftJoin("workers w ON v.worker_name = w.name").
+		LeftJoin("containers c ON v.container_id = c.id").
+		LeftJoin("volumes pv ON v.parent_id = pv.id").
+		Join("worker_resource_caches wrc ON wrc.id = v.worker_resource_cache_id").
+		Where(sq.Eq{
+			"v.worker_name":  workerName,
+			"v.state":        string(VolumeStateCreated),
+			"v.container_id": nil,
+		}).
+		Where(sq.Expr(`NOT EXISTS (
+			SELECT 1 FROM volumes cv WHERE cv.parent_id = v.id
+		)`)).
+		Where(sq.Expr(`NOT EXISTS (
+			SELECT 1
+			FROM resource_cache_uses rcu
+			LEFT JOIN builds b ON b.id = rcu.build_id
+			WHERE rcu.resource_cache_id = wrc.resource_cache_id
+			AND (rcu.container_id IS NOT NULL OR NOT b.completed)
+		)`)).
+		OrderBy("wrc.last_used ASC", "v.id ASC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repository.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer Close(rows)
+
+	var createdVolumes []CreatedVolume
+	for rows.Next() {
+		_, createdVolume, _, _, err := scanVolume(rows, repository.conn)
+		if err != nil {
+			return nil, err
+		}
+
+		if createdVolume != nil {
+			createdVolumes = append(createdVolumes, createdVolume)
+		}
+	}
+
+	return createdVolumes, nil
+}
+
 func (repository *volumeRepository) DestroyFailedVolumes() (int, error) {
 	queryId, args, err := psql.Select("v.id").
 		From("volumes v").
@@ -579,6 +655,20 @@ func (repository *volumeRepository) GetDestroyingVolumes(workerName string) ([]s
 	return volumes, nil
 }
 
+func (repository *volumeRepository) CountDestroyingVolumesByWorker() (map[string]int, error) {
+	rows, err := psql.Select("w.name", "COUNT(v.id)").
+		From("workers w").
+		LeftJoin(fmt.Sprintf("volumes v ON v.worker_name = w.name AND v.state = '%s'", VolumeStateDestroying)).
+		GroupBy("w.name").
+		RunWith(repository.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanCountsByWorker(rows)
+}
+
 func (repository *volumeRepository) DestroyUnknownVolumes(workerName string, reportedHandles []string) (int, error) {
 	tx, err := repository.conn.Begin()
 	if err != nil {
@@ -626,6 +716,31 @@ func (repository *volumeRepository) DestroyUnknownVolumes(workerName string, rep
 	return len(unknownHandles), nil
 }
 
+// UpdateVolumeSizes records the sizes of the worker's volumes, keyed by
+// handle, as measured by the worker. Sizes of volumes unknown to the database
+// are ignored.
+func (repository *volumeRepository) UpdateVolumeSizes(workerName string, sizes map[string]uint64) error {
+	if len(sizes) == 0 {
+		return nil
+	}
+
+	handles := make([]string, 0, len(sizes))
+	bytes := make([]int64, 0, len(sizes))
+	for handle, size := range sizes {
+		handles = append(handles, handle)
+		bytes = append(bytes, int64(size))
+	}
+
+	_, err := repository.conn.Exec(`
+		UPDATE volumes v
+		SET size = s.size
+		FROM unnest($1::text[], $2::bigint[]) AS s (handle, size)
+		WHERE v.handle = s.handle
+		AND v.worker_name = $3
+	`, pq.Array(handles), pq.Array(bytes), workerName)
+	return err
+}
+
 // 1. open tx
 // 2. lookup worker resource type id
 //   * if not found, fail; worker must have new version or no longer supports type
@@ -731,7 +846,10 @@ var volumeColumns = []string{
 	"v.worker_task_cache_id",
 	"v.worker_resource_certs_id",
 	"v.worker_artifact_id",
-	`case
+	volumeTypeColumn,
+}
+
+const volumeTypeColumn = `case
 	when v.worker_base_resource_type_id is not NULL then 'resource-type'
 	when v.worker_resource_cache_id is not NULL then 'resource'
 	when v.container_id is not NULL then 'container'
@@ -739,8 +857,7 @@ var volumeColumns = []string{
 	when v.worker_resource_certs_id is not NULL then 'resource-certs'
 	when v.worker_artifact_id is not NULL then 'artifact'
 	else 'unknown'
-end`,
-}
+end`
 
 func scanVolume(row sq.RowScanner, conn Conn) (CreatingVolume, CreatedVolume, DestroyingVolume, FailedVolume, error) {
 	var id int
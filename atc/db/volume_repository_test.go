@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"database/sql"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -412,6 +413,31 @@ var _ = Describe("VolumeRepository", func() {
 		})
 	})
 
+	Describe("CountDestroyingVolumesByWorker", func() {
+		BeforeEach(func() {
+			creatingVolume, err := volumeRepository.CreateVolume(defaultTeam.ID(), defaultWorker.Name(), db.VolumeTypeResource)
+			Expect(err).NotTo(HaveOccurred())
+
+			createdVolume, err := creatingVolume.Created()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = createdVolume.Destroying()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = volumeRepository.CreateVolume(defaultTeam.ID(), otherWorker.Name(), db.VolumeTypeResource)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("counts the destroying volumes on every worker", func() {
+			counts, err := volumeRepository.CountDestroyingVolumesByWorker()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(counts).To(Equal(map[string]int{
+				defaultWorker.Name(): 1,
+				otherWorker.Name():   0,
+			}))
+		})
+	})
+
 	Describe("CreateBaseResourceTypeVolume", func() {
 		var usedWorkerBaseResourceType *db.UsedWorkerBaseResourceType
 		BeforeEach(func() {
@@ -446,6 +472,16 @@ var _ = Describe("VolumeRepository", func() {
 			Expect(teamID).To(Equal(defaultTeam.ID()))
 			Expect(workerName).To(Equal(defaultWorker.Name()))
 		})
+
+		It("creates a CreatingVolume without a team", func() {
+			volume, err := volumeRepository.CreateVolume(0, defaultWorker.Name(), db.VolumeTypeResource)
+			Expect(err).NotTo(HaveOccurred())
+			var teamID sql.NullInt64
+			err = psql.Select("team_id").From("volumes").
+				Where(sq.Eq{"handle": volume.Handle()}).RunWith(dbConn).QueryRow().Scan(&teamID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(teamID.Valid).To(BeFalse())
+		})
 	})
 
 	Describe("FindBaseResourceTypeVolume", func() {
@@ -556,6 +592,33 @@ var _ = Describe("VolumeRepository", func() {
 				Expect(createdVolume.Handle()).To(Equal(existingVolume.Handle()))
 				Expect(found).To(BeTrue())
 			})
+
+			It("records when the cache was last used, once a minute", func() {
+				_, err := dbConn.Exec(`UPDATE worker_resource_caches SET last_used = now() - interval '1 hour' WHERE resource_cache_id = $1`, usedResourceCache.ID())
+				Expect(err).NotTo(HaveOccurred())
+
+				lastUsed := func() (time.Time, string) {
+					var lastUsed time.Time
+					var xmin string
+					err := dbConn.QueryRow(`SELECT last_used, xmin FROM worker_resource_caches WHERE resource_cache_id = $1`, usedResourceCache.ID()).Scan(&lastUsed, &xmin)
+					Expect(err).NotTo(HaveOccurred())
+					return lastUsed, xmin
+				}
+
+				_, found, err := volumeRepository.FindResourceCacheVolume(defaultWorker.Name(), usedResourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				used, written := lastUsed()
+				Expect(used).To(BeTemporally("~", time.Now(), time.Minute))
+
+				_, found, err = volumeRepository.FindResourceCacheVolume(defaultWorker.Name(), usedResourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, rewritten := lastUsed()
+				Expect(rewritten).To(Equal(written))
+			})
 		})
 	})
 
@@ -1070,4 +1133,51 @@ var _ = Describe("VolumeRepository", func() {
 			})
 		})
 	})
+
+	Describe("UpdateVolumeSizes", func() {
+		BeforeEach(func() {
+			for handle, worker := range map[string]string{
+				"some-handle":  defaultWorker.Name(),
+				"other-handle": otherWorker.Name(),
+			} {
+				_, err := psql.Insert("volumes").SetMap(map[string]interface{}{
+					"state":       db.VolumeStateCreated,
+					"handle":      handle,
+					"worker_name": worker,
+				}).RunWith(dbConn).Exec()
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		volumeSize := func(handle string) sql.NullInt64 {
+			var size sql.NullInt64
+			err := psql.Select("size").
+				From("volumes").
+				Where(sq.Eq{"handle": handle}).
+				RunWith(dbConn).
+				QueryRow().
+				Scan(&size)
+			Expect(err).ToNot(HaveOccurred())
+			return size
+		}
+
+		It("saves the sizes of the worker's volumes", func() {
+			err := volumeRepository.UpdateVolumeSizes(defaultWorker.Name(), map[string]uint64{
+				"some-handle":    1024,
+				"unknown-handle": 2048,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(volumeSize("some-handle")).To(Equal(sql.NullInt64{Int64: 1024, Valid: true}))
+		})
+
+		It("does not save the sizes of other workers' volumes", func() {
+			err := volumeRepository.UpdateVolumeSizes(defaultWorker.Name(), map[string]uint64{
+				"other-handle": 1024,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(volumeSize("other-handle").Valid).To(BeFalse())
+		})
+	})
 })
@@ -436,6 +436,42 @@ var _ = Describe("Volume", func() {
 		})
 	})
 
+	Describe("createdVolume.Size", func() {
+		var createdVolume db.CreatedVolume
+
+		BeforeEach(func() {
+			creatingVolume, err := volumeRepository.CreateVolume(defaultTeam.ID(), defaultWorker.Name(), db.VolumeTypeArtifact)
+			Expect(err).ToNot(HaveOccurred())
+
+			createdVolume, err = creatingVolume.Created()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the worker has not reported its size", func() {
+			It("is not found", func() {
+				_, found, err := createdVolume.Size()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the worker has reported its size", func() {
+			BeforeEach(func() {
+				err := volumeRepository.UpdateVolumeSizes(defaultWorker.Name(), map[string]uint64{
+					createdVolume.Handle(): 4096,
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the reported size", func() {
+				size, found, err := createdVolume.Size()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(size).To(Equal(uint64(4096)))
+			})
+		})
+	})
+
 	Describe("createdVolume.InitializeTaskCache", func() {
 		Context("when there is a volume that belongs to worker task cache", func() {
 			var (
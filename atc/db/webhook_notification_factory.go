@@ -0,0 +1,107 @@
+package db
+
+import (
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// WebhookNotification is a build notification which has yet to be delivered
+// to one of the pipeline's webhooks.
+type WebhookNotification struct {
+	ID       int
+	BuildID  int
+	Webhook  string
+	Payload  json.RawMessage
+	Attempts int
+}
+
+// WebhookNotificationFactory hands out the notifications queued by builds as
+// they finish. A notification stays queued until it is either delivered or
+// given up on, at which point it is kept around as a dead letter until its
+// build is deleted.
+//
+//counterfeiter:generate . WebhookNotificationFactory
+type WebhookNotificationFactory interface {
+	// DueNotifications returns up to limit notifications whose next attempt
+	// is due, oldest first.
+	DueNotifications(limit int) ([]WebhookNotification, error)
+
+	Delivered(id int) error
+	Retry(id int, nextAttemptAt time.Time, reason string) error
+	Dead(id int, reason string) error
+}
+
+type webhookNotificationFactory struct {
+	conn Conn
+}
+
+func NewWebhookNotificationFactory(conn Conn) WebhookNotificationFactory {
+	return &webhookNotificationFactory{
+		conn: conn,
+	}
+}
+
+func (f *webhookNotificationFactory) DueNotifications(limit int) ([]WebhookNotification, error) {
+	rows, err := psql.Select("id", "build_id", "webhook", "payload", "attempts").
+		From("webhook_notifications").
+		Where(sq.Eq{"dead": false}).
+		Where(sq.Expr("next_attempt_at <= now()")).
+		OrderBy("id ASC").
+		Limit(uint64(limit)).
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var notifications []WebhookNotification
+	for rows.Next() {
+		var notification WebhookNotification
+		var payload string
+
+		err := rows.Scan(&notification.ID, &notification.BuildID, &notification.Webhook, &payload, &notification.Attempts)
+		if err != nil {
+			return nil, err
+		}
+
+		notification.Payload = json.RawMessage(payload)
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, rows.Err()
+}
+
+func (f *webhookNotificationFactory) Delivered(id int) error {
+	_, err := psql.Delete("webhook_notifications").
+		Where(sq.Eq{"id": id}).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+func (f *webhookNotificationFactory) Retry(id int, nextAttemptAt time.Time, reason string) error {
+	_, err := psql.Update("webhook_notifications").
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("next_attempt_at", nextAttemptAt).
+		Set("last_error", reason).
+		Where(sq.Eq{"id": id}).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+func (f *webhookNotificationFactory) Dead(id int, reason string) error {
+	_, err := psql.Update("webhook_notifications").
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("last_error", reason).
+		Set("dead", true).
+		Where(sq.Eq{"id": id}).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
@@ -0,0 +1,129 @@
+package db_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebhookNotificationFactory", func() {
+	var (
+		notificationFactory db.WebhookNotificationFactory
+
+		build   db.Build
+		payload json.RawMessage
+	)
+
+	BeforeEach(func() {
+		notificationFactory = db.NewWebhookNotificationFactory(dbConn)
+
+		var err error
+		build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+
+		payload = json.RawMessage(`{"status":"succeeded"}`)
+
+		err = build.FinishWithWebhookNotifications(db.BuildStatusSucceeded, []string{"some-webhook", "other-webhook"}, payload)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("DueNotifications", func() {
+		It("returns the queued notifications", func() {
+			notifications, err := notificationFactory.DueNotifications(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(notifications).To(HaveLen(2))
+
+			Expect(notifications[0].BuildID).To(Equal(build.ID()))
+			Expect(notifications[0].Webhook).To(Equal("some-webhook"))
+			Expect(notifications[0].Payload).To(MatchJSON(payload))
+			Expect(notifications[0].Attempts).To(BeZero())
+
+			Expect(notifications[1].Webhook).To(Equal("other-webhook"))
+		})
+
+		It("returns no more than the limit", func() {
+			notifications, err := notificationFactory.DueNotifications(1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(notifications).To(HaveLen(1))
+		})
+
+		Context("when a notification has been delivered", func() {
+			BeforeEach(func() {
+				notifications, err := notificationFactory.DueNotifications(1)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = notificationFactory.Delivered(notifications[0].ID)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is no longer returned", func() {
+				notifications, err := notificationFactory.DueNotifications(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(notifications).To(HaveLen(1))
+				Expect(notifications[0].Webhook).To(Equal("other-webhook"))
+			})
+		})
+
+		Context("when a notification is to be retried later", func() {
+			BeforeEach(func() {
+				notifications, err := notificationFactory.DueNotifications(1)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = notificationFactory.Retry(notifications[0].ID, time.Now().Add(time.Hour), "some-error")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is not returned until it is due", func() {
+				notifications, err := notificationFactory.DueNotifications(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(notifications).To(HaveLen(1))
+				Expect(notifications[0].Webhook).To(Equal("other-webhook"))
+			})
+		})
+
+		Context("when a notification is to be retried right away", func() {
+			BeforeEach(func() {
+				notifications, err := notificationFactory.DueNotifications(1)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = notificationFactory.Retry(notifications[0].ID, time.Now().Add(-time.Second), "some-error")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("counts the failed attempt", func() {
+				notifications, err := notificationFactory.DueNotifications(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(notifications).To(HaveLen(2))
+				Expect(notifications[0].Attempts).To(Equal(1))
+			})
+		})
+
+		Context("when a notification is dead", func() {
+			BeforeEach(func() {
+				notifications, err := notificationFactory.DueNotifications(1)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = notificationFactory.Dead(notifications[0].ID, "some-error")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is no longer returned", func() {
+				notifications, err := notificationFactory.DueNotifications(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(notifications).To(HaveLen(1))
+				Expect(notifications[0].Webhook).To(Equal("other-webhook"))
+			})
+
+			It("is kept as a dead letter", func() {
+				var lastError string
+				err := dbConn.QueryRow(`SELECT last_error FROM webhook_notifications WHERE dead`).Scan(&lastError)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lastError).To(Equal("some-error"))
+			})
+		})
+	})
+})
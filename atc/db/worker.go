@@ -62,6 +62,7 @@ type Worker interface {
 	ActiveVolumes() int
 	ResourceTypes() []atc.WorkerResourceType
 	Platform() string
+	Runtime() string
 	Tags() []string
 	TeamID() int
 	TeamName() string
@@ -69,6 +70,12 @@ type Worker interface {
 	ExpiresAt() time.Time
 	Ephemeral() bool
 
+	// DiskUsage returns the disk usage last reported by the worker, if any.
+	DiskUsage() (atc.WorkerDiskUsage, bool)
+	// DiskPressure returns whether caches are being evicted from the worker
+	// to free up disk space.
+	DiskPressure() bool
+
 	Reload() (bool, error)
 
 	Land() error
@@ -76,6 +83,9 @@ type Worker interface {
 	Prune() error
 	Delete() error
 
+	ReportDiskUsage(atc.WorkerDiskUsage) error
+	SetDiskPressure(bool) error
+
 	ActiveTasks() (int, error)
 	IncreaseActiveTasks() (int, error)
 	DecreaseActiveTasks() (int, error)
@@ -100,6 +110,7 @@ type worker struct {
 	activeTasks      int
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
+	runtime          string
 	tags             []string
 	teamID           int
 	teamName         string
@@ -107,6 +118,8 @@ type worker struct {
 	expiresAt        time.Time
 	certsPath        *string
 	ephemeral        bool
+	diskUsage        *atc.WorkerDiskUsage
+	diskPressure     bool
 }
 
 func (worker *worker) Name() string             { return worker.name }
@@ -123,11 +136,22 @@ func (worker *worker) ActiveContainers() int                   { return worker.a
 func (worker *worker) ActiveVolumes() int                      { return worker.activeVolumes }
 func (worker *worker) ResourceTypes() []atc.WorkerResourceType { return worker.resourceTypes }
 func (worker *worker) Platform() string                        { return worker.platform }
+func (worker *worker) Runtime() string                         { return worker.runtime }
 func (worker *worker) Tags() []string                          { return worker.tags }
 func (worker *worker) TeamID() int                             { return worker.teamID }
 func (worker *worker) TeamName() string                        { return worker.teamName }
 func (worker *worker) Ephemeral() bool                         { return worker.ephemeral }
 
+func (worker *worker) DiskPressure() bool { return worker.diskPressure }
+
+func (worker *worker) DiskUsage() (atc.WorkerDiskUsage, bool) {
+	if worker.diskUsage == nil {
+		return atc.WorkerDiskUsage{}, false
+	}
+
+	return *worker.diskUsage, true
+}
+
 func (worker *worker) StartTime() time.Time { return worker.startTime }
 func (worker *worker) ExpiresAt() time.Time { return worker.expiresAt }
 
@@ -262,6 +286,46 @@ func (worker *worker) Delete() error {
 	return err
 }
 
+func (worker *worker) ReportDiskUsage(usage atc.WorkerDiskUsage) error {
+	result, err := psql.Update("workers").
+		Set("disk_used_bytes", usage.UsedBytes).
+		Set("disk_total_bytes", usage.TotalBytes).
+		Where(sq.Eq{"name": worker.name}).
+		RunWith(worker.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return ErrWorkerNotPresent
+	}
+
+	worker.diskUsage = &usage
+
+	return nil
+}
+
+func (worker *worker) SetDiskPressure(pressure bool) error {
+	_, err := psql.Update("workers").
+		Set("disk_pressure", pressure).
+		Where(sq.Eq{"name": worker.name}).
+		RunWith(worker.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	worker.diskPressure = pressure
+
+	return nil
+}
+
 func (worker *worker) ResourceCerts() (*UsedWorkerResourceCerts, bool, error) {
 	if worker.certsPath != nil {
 		wrc := &WorkerResourceCerts{
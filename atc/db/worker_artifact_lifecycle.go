@@ -21,8 +21,36 @@ func NewArtifactLifecycle(conn Conn) *artifactLifecycle {
 
 func (lifecycle *artifactLifecycle) RemoveExpiredArtifacts() error {
 
-	_, err := psql.Delete("worker_artifacts").
-		Where(sq.Expr("created_at < NOW() - interval '12 hours'")).
+	// artifacts holding cached task outputs are kept for as long as they are
+	// cached, see TaskOutputCacheFactory
+	notCached := sq.Expr(`NOT EXISTS (
+		SELECT 1
+		FROM task_output_caches c
+		WHERE c.worker_artifact_id = wa.id
+	)`)
+
+	_, err := psql.Delete("worker_artifacts wa").
+		Where(sq.Expr("wa.created_at < NOW() - interval '12 hours'")).
+		Where(notCached).
+		RunWith(lifecycle.conn).
+		Exec()
+
+	if err != nil {
+		return err
+	}
+
+	// only the outputs of a job's latest successful build are kept around for
+	// downloading, so that every job build does not hold on to its volumes
+	_, err = psql.Delete("worker_artifacts wa").
+		Where(sq.Expr(`EXISTS (
+			SELECT 1
+			FROM builds b
+			JOIN builds newer ON newer.job_id = b.job_id
+			WHERE b.id = wa.build_id
+			AND newer.id > b.id
+			AND newer.status = 'succeeded'
+		)`)).
+		Where(notCached).
 		RunWith(lifecycle.conn).
 		Exec()
 
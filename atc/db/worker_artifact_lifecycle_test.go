@@ -51,5 +51,70 @@ var _ = Describe("WorkerArtifactLifecycle", func() {
 				Expect(count).To(Equal(1))
 			})
 		})
+
+		Context("when a job build has outputs", func() {
+			var build db.Build
+
+			BeforeEach(func() {
+				var err error
+				build, err = defaultJob.CreateBuild("some-user")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbConn.Exec("INSERT INTO worker_artifacts(name, build_id) VALUES('some-output', $1)", build.ID())
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			Context("when a newer build of the job has succeeded", func() {
+				BeforeEach(func() {
+					newerBuild, err := defaultJob.CreateBuild("some-user")
+					Expect(err).ToNot(HaveOccurred())
+
+					err = newerBuild.Finish(db.BuildStatusSucceeded)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("removes the record", func() {
+					var count int
+					err := dbConn.QueryRow("SELECT count(*) from worker_artifacts").Scan(&count)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(count).To(Equal(0))
+				})
+			})
+
+			Context("when a newer build of the job has failed", func() {
+				BeforeEach(func() {
+					newerBuild, err := defaultJob.CreateBuild("some-user")
+					Expect(err).ToNot(HaveOccurred())
+
+					err = newerBuild.Finish(db.BuildStatusFailed)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("does not remove the record", func() {
+					var count int
+					err := dbConn.QueryRow("SELECT count(*) from worker_artifacts").Scan(&count)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(count).To(Equal(1))
+				})
+			})
+		})
+
+		Context("when an expired artifact holds cached task outputs", func() {
+			BeforeEach(func() {
+				var artifactID int
+				err := dbConn.QueryRow("INSERT INTO worker_artifacts(name, created_at) VALUES('some-output', NOW() - '13 hours'::interval) RETURNING id").Scan(&artifactID)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbConn.Exec("INSERT INTO task_output_caches(job_id, step_name, fingerprint, output_name, worker_artifact_id) VALUES($1, 'some-task', 'some-fingerprint', 'some-output', $2)", defaultJob.ID(), artifactID)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not remove the record", func() {
+				var count int
+				err := dbConn.QueryRow("SELECT count(*) from worker_artifacts").Scan(&count)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(Equal(1))
+			})
+		})
 	})
 })
@@ -22,6 +22,8 @@ type WorkerFactory interface {
 
 	FindWorkersForContainerByOwner(ContainerOwner) ([]Worker, error)
 	BuildContainersCountPerWorker() (map[string]int, error)
+	RunningBuildContainersCountPerWorker() (map[string]int, error)
+	VolumesCountPerWorker() (map[string]int, error)
 }
 
 type workerFactory struct {
@@ -48,12 +50,16 @@ var workersQuery = psql.Select(`
 		w.active_volumes,
 		w.resource_types,
 		w.platform,
+		w.runtime,
 		w.tags,
 		t.name,
 		w.team_id,
 		w.start_time,
 		w.expires,
-		w.ephemeral
+		w.ephemeral,
+		w.disk_used_bytes,
+		w.disk_total_bytes,
+		w.disk_pressure
 	`).
 	From("workers w").
 	LeftJoin("teams t ON w.team_id = t.id")
@@ -133,12 +139,15 @@ func scanWorker(worker *worker, row scannable) error {
 		noProxy       sql.NullString
 		resourceTypes []byte
 		platform      sql.NullString
+		runtime       sql.NullString
 		tags          []byte
 		teamName      sql.NullString
 		teamID        sql.NullInt64
 		startTime     pq.NullTime
 		expiresAt     pq.NullTime
 		ephemeral     sql.NullBool
+		diskUsed      sql.NullInt64
+		diskTotal     sql.NullInt64
 	)
 
 	err := row.Scan(
@@ -155,12 +164,16 @@ func scanWorker(worker *worker, row scannable) error {
 		&worker.activeVolumes,
 		&resourceTypes,
 		&platform,
+		&runtime,
 		&tags,
 		&teamName,
 		&teamID,
 		&startTime,
 		&expiresAt,
 		&ephemeral,
+		&diskUsed,
+		&diskTotal,
+		&worker.diskPressure,
 	)
 	if err != nil {
 		return err
@@ -210,10 +223,23 @@ func scanWorker(worker *worker, row scannable) error {
 		worker.platform = platform.String
 	}
 
+	if runtime.Valid {
+		worker.runtime = runtime.String
+	}
+
 	if ephemeral.Valid {
 		worker.ephemeral = ephemeral.Bool
 	}
 
+	if diskUsed.Valid && diskTotal.Valid {
+		worker.diskUsage = &atc.WorkerDiskUsage{
+			UsedBytes:  uint64(diskUsed.Int64),
+			TotalBytes: uint64(diskTotal.Int64),
+		}
+	} else {
+		worker.diskUsage = nil
+	}
+
 	err = json.Unmarshal(resourceTypes, &worker.resourceTypes)
 	if err != nil {
 		return err
@@ -363,6 +389,53 @@ func (f *workerFactory) BuildContainersCountPerWorker() (map[string]int, error)
 	return countByWorker, nil
 }
 
+// RunningBuildContainersCountPerWorker counts the containers on each worker
+// which belong to builds that haven't finished.
+func (f *workerFactory) RunningBuildContainersCountPerWorker() (map[string]int, error) {
+	rows, err := psql.Select("c.worker_name, COUNT(*)").
+		From("containers c").
+		Join("builds b ON b.id = c.build_id").
+		Where(sq.Eq{"b.status": []BuildStatus{BuildStatusPending, BuildStatusStarted}}).
+		GroupBy("c.worker_name").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	countByWorker := make(map[string]int)
+
+	for rows.Next() {
+		var workerName string
+		var containersCount int
+
+		err = rows.Scan(&workerName, &containersCount)
+		if err != nil {
+			return nil, err
+		}
+
+		countByWorker[workerName] = containersCount
+	}
+
+	return countByWorker, nil
+}
+
+func (f *workerFactory) VolumesCountPerWorker() (map[string]int, error) {
+	rows, err := psql.Select("worker_name, COUNT(*)").
+		From("volumes").
+		Where(sq.Eq{"state": VolumeStateCreated}).
+		GroupBy("worker_name").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanCountsByWorker(rows)
+}
+
 func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, conn Conn) (Worker, error) {
 	resourceTypes, err := json.Marshal(atcWorker.ResourceTypes)
 	if err != nil {
@@ -400,6 +473,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		resourceTypes,
 		tags,
 		atcWorker.Platform,
+		atcWorker.Runtime,
 		atcWorker.BaggageclaimURL,
 		atcWorker.CertsPath,
 		atcWorker.HTTPProxyURL,
@@ -431,6 +505,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			"resource_types",
 			"tags",
 			"platform",
+			"runtime",
 			"baggageclaim_url",
 			"certs_path",
 			"http_proxy_url",
@@ -456,6 +531,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				resource_types = ?,
 				tags = ?,
 				platform = ?,
+				runtime = ?,
 				baggageclaim_url = ?,
 				certs_path = ?,
 				http_proxy_url = ?,
@@ -503,6 +579,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		activeVolumes:    atcWorker.ActiveVolumes,
 		resourceTypes:    atcWorker.ResourceTypes,
 		platform:         atcWorker.Platform,
+		runtime:          atcWorker.Runtime,
 		tags:             atcWorker.Tags,
 		teamName:         atcWorker.Team,
 		teamID:           workerTeamID,
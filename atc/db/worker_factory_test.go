@@ -44,6 +44,7 @@ var _ = Describe("WorkerFactory", func() {
 				},
 			},
 			Platform:  "some-platform",
+			Runtime:   "containerd",
 			Tags:      atc.Tags{"some", "tags"},
 			Name:      "some-name",
 			StartTime: 1565367209,
@@ -242,6 +243,7 @@ var _ = Describe("WorkerFactory", func() {
 					},
 				}))
 				Expect(foundWorker.Platform()).To(Equal("some-platform"))
+				Expect(foundWorker.Runtime()).To(Equal("containerd"))
 				Expect(foundWorker.Tags()).To(Equal([]string{"some", "tags"}))
 				Expect(foundWorker.StartTime().Unix()).To(Equal(int64(1565367209)))
 				Expect(foundWorker.State()).To(Equal(db.WorkerStateRunning))
@@ -785,4 +787,76 @@ var _ = Describe("WorkerFactory", func() {
 			Expect(containersCountByWorker[worker.Name()]).To(Equal(1))
 		})
 	})
+
+	Describe("RunningBuildContainersCountPerWorker", func() {
+		var (
+			runningBuild  db.Build
+			finishedBuild db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+
+			runningBuild, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			finishedBuild, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = finishedBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(runningBuild.ID(), "some-plan", defaultTeam.ID()), db.ContainerMetadata{
+				Type:     "task",
+				StepName: "some-task",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = worker.CreateContainer(db.NewBuildStepContainerOwner(finishedBuild.ID(), "some-plan", defaultTeam.ID()), db.ContainerMetadata{
+				Type:     "task",
+				StepName: "other-task",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("only counts the containers of builds which haven't finished", func() {
+			containersCountByWorker, err := workerFactory.RunningBuildContainersCountPerWorker()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(containersCountByWorker).To(HaveLen(1))
+			Expect(containersCountByWorker[defaultWorker.Name()]).To(Equal(1))
+		})
+	})
+
+	Describe("VolumesCountPerWorker", func() {
+		BeforeEach(func() {
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingVolume, err := volumeRepository.CreateVolume(defaultTeam.ID(), defaultWorker.Name(), db.VolumeTypeContainer)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = creatingVolume.Created()
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingVolume, err = volumeRepository.CreateVolume(defaultTeam.ID(), defaultWorker.Name(), db.VolumeTypeContainer)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = creatingVolume.Created()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = volumeRepository.CreateVolume(defaultTeam.ID(), worker.Name(), db.VolumeTypeContainer)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns a map of worker to number of created volumes", func() {
+			volumesCountByWorker, err := workerFactory.VolumesCountPerWorker()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(volumesCountByWorker).To(HaveLen(1))
+			Expect(volumesCountByWorker[defaultWorker.Name()]).To(Equal(2))
+		})
+	})
 })
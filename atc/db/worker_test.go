@@ -98,6 +98,67 @@ var _ = Describe("Worker", func() {
 		})
 	})
 
+	Describe("ReportDiskUsage", func() {
+		BeforeEach(func() {
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("has no disk usage until it is reported", func() {
+			_, reported := worker.DiskUsage()
+			Expect(reported).To(BeFalse())
+		})
+
+		It("saves the worker's disk usage", func() {
+			err := worker.ReportDiskUsage(atc.WorkerDiskUsage{UsedBytes: 80, TotalBytes: 100})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worker.Reload()
+			Expect(err).NotTo(HaveOccurred())
+
+			usage, reported := worker.DiskUsage()
+			Expect(reported).To(BeTrue())
+			Expect(usage).To(Equal(atc.WorkerDiskUsage{UsedBytes: 80, TotalBytes: 100}))
+		})
+
+		Context("when the worker is not present", func() {
+			It("returns an error", func() {
+				err := worker.Delete()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = worker.ReportDiskUsage(atc.WorkerDiskUsage{UsedBytes: 80, TotalBytes: 100})
+				Expect(err).To(Equal(ErrWorkerNotPresent))
+			})
+		})
+	})
+
+	Describe("SetDiskPressure", func() {
+		BeforeEach(func() {
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("marks the worker as being under disk pressure until it is unset", func() {
+			Expect(worker.DiskPressure()).To(BeFalse())
+
+			err := worker.SetDiskPressure(true)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worker.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(worker.DiskPressure()).To(BeTrue())
+
+			err = worker.SetDiskPressure(false)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worker.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(worker.DiskPressure()).To(BeFalse())
+		})
+	})
+
 	Describe("Retire", func() {
 		BeforeEach(func() {
 			var err error
@@ -0,0 +1,13 @@
+package atc
+
+// EncryptionKeyRotationProgress is how far along re-encrypting a column with
+// the new encryption key is.
+type EncryptionKeyRotationProgress struct {
+	Table  string `json:"table"`
+	Column string `json:"column"`
+
+	Rotated    int    `json:"rotated"`
+	Finished   bool   `json:"finished"`
+	Error      string `json:"error,omitempty"`
+	UpdateTime int64  `json:"update_time"`
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
@@ -29,27 +30,35 @@ type buildStepDelegate struct {
 	state           exec.RunState
 	stderr          io.Writer
 	stdout          io.Writer
+	logEvents       *logEventQueue
 	policyChecker   policy.Checker
 	artifactSourcer worker.ArtifactSourcer
+	outputLimiter   *OutputRateLimiter
 }
 
 func NewBuildStepDelegate(
+	ctx context.Context,
 	build db.Build,
 	planID atc.PlanID,
 	state exec.RunState,
 	clock clock.Clock,
 	policyChecker policy.Checker,
 	artifactSourcer worker.ArtifactSourcer,
+	outputLimiter *OutputRateLimiter,
 ) *buildStepDelegate {
+	logEvents := newLogEventQueue(ctx, build)
+
 	return &buildStepDelegate{
-		build:           build,
+		build:           logFlushingBuild{Build: build, logEvents: logEvents},
 		planID:          planID,
 		clock:           clock,
 		state:           state,
 		stdout:          nil,
 		stderr:          nil,
+		logEvents:       logEvents,
 		policyChecker:   policyChecker,
 		artifactSourcer: artifactSourcer,
+		outputLimiter:   outputLimiter,
 	}
 }
 
@@ -86,22 +95,24 @@ func (delegate *buildStepDelegate) Stdout() io.Writer {
 	}
 	if delegate.state.RedactionEnabled() {
 		delegate.stdout = newDBEventWriterWithSecretRedaction(
-			delegate.build,
+			delegate.logEvents,
 			event.Origin{
 				Source: event.OriginSourceStdout,
 				ID:     event.OriginID(delegate.planID),
 			},
 			delegate.clock,
+			delegate.outputLimiter,
 			delegate.buildOutputFilter,
 		)
 	} else {
 		delegate.stdout = newDBEventWriter(
-			delegate.build,
+			delegate.logEvents,
 			event.Origin{
 				Source: event.OriginSourceStdout,
 				ID:     event.OriginID(delegate.planID),
 			},
 			delegate.clock,
+			delegate.outputLimiter,
 		)
 	}
 	return delegate.stdout
@@ -113,22 +124,24 @@ func (delegate *buildStepDelegate) Stderr() io.Writer {
 	}
 	if delegate.state.RedactionEnabled() {
 		delegate.stderr = newDBEventWriterWithSecretRedaction(
-			delegate.build,
+			delegate.logEvents,
 			event.Origin{
 				Source: event.OriginSourceStderr,
 				ID:     event.OriginID(delegate.planID),
 			},
 			delegate.clock,
+			delegate.outputLimiter,
 			delegate.buildOutputFilter,
 		)
 	} else {
 		delegate.stderr = newDBEventWriter(
-			delegate.build,
+			delegate.logEvents,
 			event.Origin{
 				Source: event.OriginSourceStderr,
 				ID:     event.OriginID(delegate.planID),
 			},
 			delegate.clock,
+			delegate.outputLimiter,
 		)
 	}
 	return delegate.stderr
@@ -161,6 +174,13 @@ func (delegate *buildStepDelegate) Starting(logger lager.Logger) {
 		return
 	}
 
+	if delegate.state.TrackStepStarted() && delegate.build.Name() != db.CheckBuildName {
+		metric.BuildStartLatency{
+			Build:   delegate.build,
+			Latency: delegate.clock.Since(delegate.build.CreateTime()),
+		}.Emit(logger)
+	}
+
 	logger.Debug("starting")
 }
 
@@ -213,7 +233,13 @@ func (delegate *buildStepDelegate) SelectedWorker(logger lager.Logger, worker st
 }
 
 func (delegate *buildStepDelegate) Errored(logger lager.Logger, message string) {
-	err := delegate.build.SaveEvent(event.Error{
+	// save the logs leading up to the error first
+	err := delegate.logEvents.Flush()
+	if err != nil {
+		logger.Error("failed-to-save-log-events", err)
+	}
+
+	err = delegate.build.SaveEvent(event.Error{
 		Message: message,
 		Origin: event.Origin{
 			ID: event.OriginID(delegate.planID),
@@ -260,7 +286,10 @@ func (delegate *buildStepDelegate) FetchImage(
 
 				VersionedResourceTypes: types,
 
-				Tags: image.Tags,
+				Interval: image.CheckInterval,
+
+				Tags:    image.Tags,
+				AnyTags: image.AnyTags,
 			},
 		}
 
@@ -302,7 +331,8 @@ func (delegate *buildStepDelegate) FetchImage(
 
 			VersionedResourceTypes: types,
 
-			Tags: image.Tags,
+			Tags:    image.Tags,
+			AnyTags: image.AnyTags,
 		},
 	}
 
@@ -336,6 +366,19 @@ func (delegate *buildStepDelegate) FetchImage(
 		return worker.ImageSpec{}, fmt.Errorf("save image version: %w", err)
 	}
 
+	err = delegate.build.SaveEvent(event.ImageFetched{
+		Time: delegate.clock.Now().Unix(),
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Name:           imageName,
+		Type:           image.Type,
+		FetchedVersion: version,
+	})
+	if err != nil {
+		return worker.ImageSpec{}, fmt.Errorf("save image fetched event: %w", err)
+	}
+
 	art, found := fetchState.ArtifactRepository().ArtifactFor(build.ArtifactName(imageName))
 	if !found {
 		return worker.ImageSpec{}, fmt.Errorf("fetched artifact not found")
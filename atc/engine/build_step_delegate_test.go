@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -45,6 +46,14 @@ var _ = Describe("BuildStepDelegate", func() {
 		delegate exec.BuildStepDelegate
 	)
 
+	savedLogEvents := func() []atc.Event {
+		var events []atc.Event
+		for i := 0; i < fakeBuild.SaveEventsCallCount(); i++ {
+			events = append(events, fakeBuild.SaveEventsArgsForCall(i)...)
+		}
+		return events
+	}
+
 	BeforeEach(func() {
 		logger = lagertest.NewTestLogger("test")
 
@@ -66,7 +75,7 @@ var _ = Describe("BuildStepDelegate", func() {
 
 		fakeArtifactSourcer = new(workerfakes.FakeArtifactSourcer)
 
-		delegate = engine.NewBuildStepDelegate(fakeBuild, planID, runState, fakeClock, fakePolicyChecker, fakeArtifactSourcer)
+		delegate = engine.NewBuildStepDelegate(context.Background(), fakeBuild, planID, runState, fakeClock, fakePolicyChecker, fakeArtifactSourcer, nil)
 	})
 
 	Describe("Initializing", func() {
@@ -81,6 +90,32 @@ var _ = Describe("BuildStepDelegate", func() {
 		})
 	})
 
+	Describe("Starting", func() {
+		JustBeforeEach(func() {
+			delegate.Starting(logger)
+		})
+
+		It("saves an event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			event := fakeBuild.SaveEventArgsForCall(0)
+			Expect(event.EventType()).To(Equal(atc.EventType("start")))
+		})
+
+		It("tracks that a step has started", func() {
+			Expect(runState.TrackStepStartedCallCount()).To(Equal(1))
+		})
+
+		Context("when saving the event fails", func() {
+			BeforeEach(func() {
+				fakeBuild.SaveEventReturns(errors.New("nope"))
+			})
+
+			It("does not track the step as started", func() {
+				Expect(runState.TrackStepStartedCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("Finished", func() {
 		JustBeforeEach(func() {
 			delegate.Finished(logger, true)
@@ -128,6 +163,8 @@ var _ = Describe("BuildStepDelegate", func() {
 				Source: atc.Source{"some": "((source-var))"},
 				Params: atc.Params{"some": "((params-var))"},
 				Tags:   atc.Tags{"some", "tags"},
+
+				AnyTags: atc.Tags{"gpu", "bigmem"},
 			}
 
 			types = atc.VersionedResourceTypes{
@@ -159,6 +196,7 @@ var _ = Describe("BuildStepDelegate", func() {
 					Source:                 atc.Source{"some": "((source-var))"},
 					VersionedResourceTypes: types,
 					Tags:                   atc.Tags{"some", "tags"},
+					AnyTags:                atc.Tags{"gpu", "bigmem"},
 				},
 			}
 
@@ -172,6 +210,7 @@ var _ = Describe("BuildStepDelegate", func() {
 					Params:                 atc.Params{"some": "((params-var))"},
 					VersionedResourceTypes: types,
 					Tags:                   atc.Tags{"some", "tags"},
+					AnyTags:                atc.Tags{"gpu", "bigmem"},
 				},
 			}
 
@@ -233,6 +272,30 @@ var _ = Describe("BuildStepDelegate", func() {
 			Expect(plan).To(Equal(expectedGetPlan))
 		})
 
+		Context("when the image is that of a resource type", func() {
+			BeforeEach(func() {
+				imageResource.CheckInterval = "1m0s"
+			})
+
+			It("reuses a version checked within the type's check interval", func() {
+				_, plan := childState.RunArgsForCall(0)
+				Expect(plan.Check.Interval).To(Equal("1m0s"))
+			})
+		})
+
+		It("records the fetched image version for the step", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(3))
+			Expect(fakeBuild.SaveEventArgsForCall(2)).To(Equal(event.ImageFetched{
+				Time: fakeClock.Now().Unix(),
+				Origin: event.Origin{
+					ID: event.OriginID(planID),
+				},
+				Name:           "image",
+				Type:           "docker",
+				FetchedVersion: atc.Version{"some": "version"},
+			}))
+		})
+
 		It("records the resource cache as an image resource for the build", func() {
 			Expect(fakeBuild.SaveImageResourceVersionCallCount()).To(Equal(1))
 			Expect(fakeBuild.SaveImageResourceVersionArgsForCall(0)).To(Equal(fakeResourceCache))
@@ -371,6 +434,8 @@ var _ = Describe("BuildStepDelegate", func() {
 						Expect(childState.RunCallCount()).To(Equal(0))
 					case event.ImageGet:
 						Expect(childState.RunCallCount()).To(Equal(1))
+					case event.ImageFetched:
+						Expect(childState.RunCallCount()).To(Equal(2))
 					default:
 						Fail("unknown event type")
 					}
@@ -379,7 +444,7 @@ var _ = Describe("BuildStepDelegate", func() {
 			})
 
 			It("sends events before each run", func() {
-				Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(3))
 				e := fakeBuild.SaveEventArgsForCall(0)
 				Expect(e).To(Equal(event.ImageCheck{
 					Time: 675927000,
@@ -415,8 +480,8 @@ var _ = Describe("BuildStepDelegate", func() {
 				Expect(planID).To(Equal(expectedGetPlan.ID))
 			})
 
-			It("only saves an ImageGet event", func() {
-				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			It("only saves ImageGet and ImageFetched events", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
 				e := fakeBuild.SaveEventArgsForCall(0)
 				Expect(e).To(Equal(event.ImageGet{
 					Time: 675927000,
@@ -490,15 +555,16 @@ var _ = Describe("BuildStepDelegate", func() {
 		Describe("writing to the writer", func() {
 			var writtenBytes int
 			var writeErr error
+			var closeErr error
 
 			JustBeforeEach(func() {
 				writtenBytes, writeErr = writer.Write([]byte("hello\nworld"))
-				writer.(io.Closer).Close()
+				closeErr = writer.(io.Closer).Close()
 			})
 
 			Context("when saving the event succeeds", func() {
 				BeforeEach(func() {
-					fakeBuild.SaveEventReturns(nil)
+					fakeBuild.SaveEventsReturns(nil)
 				})
 
 				It("returns the length of the string, and no error", func() {
@@ -507,8 +573,8 @@ var _ = Describe("BuildStepDelegate", func() {
 				})
 
 				It("saves a log event", func() {
-					Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
-					Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+					Expect(savedLogEvents()).To(HaveLen(2))
+					Expect(savedLogEvents()[0]).To(Equal(event.Log{
 						Time:    now.Unix(),
 						Payload: "hello\n",
 						Origin: event.Origin{
@@ -516,7 +582,7 @@ var _ = Describe("BuildStepDelegate", func() {
 							ID:     "some-plan-id",
 						},
 					}))
-					Expect(fakeBuild.SaveEventArgsForCall(1)).To(Equal(event.Log{
+					Expect(savedLogEvents()[1]).To(Equal(event.Log{
 						Time:    now.Unix(),
 						Payload: "world",
 						Origin: event.Origin{
@@ -531,12 +597,17 @@ var _ = Describe("BuildStepDelegate", func() {
 				disaster := errors.New("nope")
 
 				BeforeEach(func() {
-					fakeBuild.SaveEventReturns(disaster)
+					fakeBuild.SaveEventsReturns(disaster)
+				})
+
+				It("returns the error when closing", func() {
+					Expect(writeErr).ToNot(HaveOccurred())
+					Expect(closeErr).To(Equal(disaster))
 				})
 
-				It("returns 0 length, and the error", func() {
-					Expect(writtenBytes).To(Equal(0))
-					Expect(writeErr).To(Equal(disaster))
+				It("returns the error on the following writes", func() {
+					_, err := writer.Write([]byte("more\n"))
+					Expect(err).To(Equal(disaster))
 				})
 			})
 		})
@@ -552,15 +623,16 @@ var _ = Describe("BuildStepDelegate", func() {
 		Describe("writing to the writer", func() {
 			var writtenBytes int
 			var writeErr error
+			var closeErr error
 
 			JustBeforeEach(func() {
 				writtenBytes, writeErr = writer.Write([]byte("hello\n"))
-				writer.(io.Closer).Close()
+				closeErr = writer.(io.Closer).Close()
 			})
 
 			Context("when saving the event succeeds", func() {
 				BeforeEach(func() {
-					fakeBuild.SaveEventReturns(nil)
+					fakeBuild.SaveEventsReturns(nil)
 				})
 
 				It("returns the length of the string, and no error", func() {
@@ -569,8 +641,8 @@ var _ = Describe("BuildStepDelegate", func() {
 				})
 
 				It("saves a log event", func() {
-					Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
-					Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+					Expect(savedLogEvents()).To(HaveLen(1))
+					Expect(savedLogEvents()[0]).To(Equal(event.Log{
 						Time:    now.Unix(),
 						Payload: "hello\n",
 						Origin: event.Origin{
@@ -585,17 +657,188 @@ var _ = Describe("BuildStepDelegate", func() {
 				disaster := errors.New("nope")
 
 				BeforeEach(func() {
-					fakeBuild.SaveEventReturns(disaster)
+					fakeBuild.SaveEventsReturns(disaster)
 				})
 
-				It("returns 0 length, and the error", func() {
-					Expect(writtenBytes).To(Equal(0))
-					Expect(writeErr).To(Equal(disaster))
+				It("returns the error when closing", func() {
+					Expect(writeErr).ToNot(HaveOccurred())
+					Expect(closeErr).To(Equal(disaster))
+				})
+
+				It("returns the error on the following writes", func() {
+					_, err := writer.Write([]byte("more\n"))
+					Expect(err).To(Equal(disaster))
 				})
 			})
 		})
 	})
 
+	Describe("writing logs faster than they are saved", func() {
+		var (
+			writer  io.Writer
+			release chan struct{}
+		)
+
+		BeforeEach(func() {
+			release = make(chan struct{})
+			fakeBuild.SaveEventsStub = func([]atc.Event) error {
+				<-release
+				return nil
+			}
+
+			writer = delegate.Stdout()
+		})
+
+		It("blocks writes once the queue is full and saves every event in batches", func() {
+			written := make(chan int)
+			go func() {
+				defer GinkgoRecover()
+
+				var count int
+				for i := 0; i < 2000; i++ {
+					_, err := writer.Write([]byte("line\n"))
+					Expect(err).ToNot(HaveOccurred())
+					count++
+				}
+
+				written <- count
+			}()
+
+			Consistently(written).ShouldNot(Receive())
+
+			close(release)
+			Eventually(written).Should(Receive(Equal(2000)))
+
+			Expect(writer.(io.Closer).Close()).To(Succeed())
+			Expect(savedLogEvents()).To(HaveLen(2000))
+			Expect(fakeBuild.SaveEventsCallCount()).To(BeNumerically("<", 2000))
+
+			for i := 0; i < fakeBuild.SaveEventsCallCount(); i++ {
+				Expect(len(fakeBuild.SaveEventsArgsForCall(i))).To(BeNumerically("<=", 100))
+			}
+		})
+
+		Context("when the build's context is done", func() {
+			var (
+				cancel       context.CancelFunc
+				flushTimeout time.Duration
+			)
+
+			BeforeEach(func() {
+				var ctx context.Context
+				ctx, cancel = context.WithCancel(context.Background())
+				delegate = engine.NewBuildStepDelegate(ctx, fakeBuild, "some-plan-id", runState, fakeClock, fakePolicyChecker, fakeArtifactSourcer, nil)
+				writer = delegate.Stdout()
+
+				flushTimeout = engine.LogEventFlushTimeout
+				engine.LogEventFlushTimeout = 100 * time.Millisecond
+			})
+
+			AfterEach(func() {
+				engine.LogEventFlushTimeout = flushTimeout
+			})
+
+			It("still saves the events queued before it when closing", func() {
+				for i := 0; i < 10; i++ {
+					_, err := writer.Write([]byte("line\n"))
+					Expect(err).ToNot(HaveOccurred())
+				}
+
+				cancel()
+
+				closed := make(chan error, 1)
+				go func() {
+					closed <- writer.(io.Closer).Close()
+				}()
+
+				Consistently(closed, 50*time.Millisecond).ShouldNot(Receive())
+
+				close(release)
+				Eventually(closed).Should(Receive(BeNil()))
+				Expect(savedLogEvents()).To(HaveLen(10))
+			})
+
+			It("returns the context's error once writes have been blocked for the flush timeout", func() {
+				defer close(release)
+
+				errs := make(chan error, 1)
+				go func() {
+					for {
+						_, err := writer.Write([]byte("line\n"))
+						if err != nil {
+							errs <- err
+							return
+						}
+					}
+				}()
+
+				Consistently(errs).ShouldNot(Receive())
+
+				cancel()
+				Consistently(errs, 50*time.Millisecond).ShouldNot(Receive())
+				Eventually(errs).Should(Receive(Equal(context.Canceled)))
+			})
+		})
+
+		Context("when a batch fails to save", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBuild.SaveEventsStub = func([]atc.Event) error {
+					<-release
+					return disaster
+				}
+			})
+
+			It("saves none of the events queued after it", func() {
+				written := make(chan error)
+				go func() {
+					var err error
+					for i := 0; i < 2000 && err == nil; i++ {
+						_, err = writer.Write([]byte("line\n"))
+					}
+
+					written <- err
+				}()
+
+				Consistently(written).ShouldNot(Receive())
+
+				close(release)
+				Eventually(written).Should(Receive(Equal(disaster)))
+
+				Expect(writer.(io.Closer).Close()).To(Equal(disaster))
+				Expect(fakeBuild.SaveEventsCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("saving a step's event", func() {
+		var saved []string
+
+		BeforeEach(func() {
+			saved = nil
+			fakeBuild.SaveEventsStub = func(events []atc.Event) error {
+				for _, ev := range events {
+					saved = append(saved, string(ev.EventType()))
+				}
+				return nil
+			}
+			fakeBuild.SaveEventStub = func(ev atc.Event) error {
+				saved = append(saved, string(ev.EventType()))
+				return nil
+			}
+		})
+
+		It("saves the log events queued before it first", func() {
+			_, err := delegate.Stdout().Write([]byte("hello\n"))
+			Expect(err).ToNot(HaveOccurred())
+
+			delegate.SelectedWorker(logger, "some-worker")
+
+			Expect(saved).To(Equal([]string{"log", "selected-worker"}))
+		})
+	})
+
 	Describe("Errored", func() {
 		JustBeforeEach(func() {
 			delegate.Errored(logger, "fake error message")
@@ -640,7 +883,7 @@ var _ = Describe("BuildStepDelegate", func() {
 		BeforeEach(func() {
 			credVars := vars.StaticVariables{}
 			runState = exec.NewRunState(noopStepper, credVars, false)
-			delegate = engine.NewBuildStepDelegate(fakeBuild, "some-plan-id", runState, fakeClock, fakePolicyChecker, fakeArtifactSourcer)
+			delegate = engine.NewBuildStepDelegate(context.Background(), fakeBuild, "some-plan-id", runState, fakeClock, fakePolicyChecker, fakeArtifactSourcer, nil)
 		})
 
 		Context("Stdout", func() {
@@ -658,8 +901,8 @@ var _ = Describe("BuildStepDelegate", func() {
 				writeErr = writer.(io.Closer).Close()
 				Expect(writeErr).To(BeNil())
 
-				Expect(fakeBuild.SaveEventCallCount()).To(Equal(3))
-				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+				Expect(savedLogEvents()).To(HaveLen(3))
+				Expect(savedLogEvents()[0]).To(Equal(event.Log{
 					Time:    now.Unix(),
 					Payload: "1\r",
 					Origin: event.Origin{
@@ -667,7 +910,7 @@ var _ = Describe("BuildStepDelegate", func() {
 						ID:     "some-plan-id",
 					},
 				}))
-				Expect(fakeBuild.SaveEventArgsForCall(1)).To(Equal(event.Log{
+				Expect(savedLogEvents()[1]).To(Equal(event.Log{
 					Time:    now.Unix(),
 					Payload: "2\r",
 					Origin: event.Origin{
@@ -675,7 +918,7 @@ var _ = Describe("BuildStepDelegate", func() {
 						ID:     "some-plan-id",
 					},
 				}))
-				Expect(fakeBuild.SaveEventArgsForCall(2)).To(Equal(event.Log{
+				Expect(savedLogEvents()[2]).To(Equal(event.Log{
 					Time:    now.Unix(),
 					Payload: "3\r",
 					Origin: event.Origin{
@@ -701,8 +944,8 @@ var _ = Describe("BuildStepDelegate", func() {
 				writeErr = writer.(io.Closer).Close()
 				Expect(writeErr).To(BeNil())
 
-				Expect(fakeBuild.SaveEventCallCount()).To(Equal(3))
-				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+				Expect(savedLogEvents()).To(HaveLen(3))
+				Expect(savedLogEvents()[0]).To(Equal(event.Log{
 					Time:    now.Unix(),
 					Payload: "1\r",
 					Origin: event.Origin{
@@ -710,7 +953,7 @@ var _ = Describe("BuildStepDelegate", func() {
 						ID:     "some-plan-id",
 					},
 				}))
-				Expect(fakeBuild.SaveEventArgsForCall(1)).To(Equal(event.Log{
+				Expect(savedLogEvents()[1]).To(Equal(event.Log{
 					Time:    now.Unix(),
 					Payload: "2\r",
 					Origin: event.Origin{
@@ -718,7 +961,7 @@ var _ = Describe("BuildStepDelegate", func() {
 						ID:     "some-plan-id",
 					},
 				}))
-				Expect(fakeBuild.SaveEventArgsForCall(2)).To(Equal(event.Log{
+				Expect(savedLogEvents()[2]).To(Equal(event.Log{
 					Time:    now.Unix(),
 					Payload: "3\r",
 					Origin: event.Origin{
@@ -730,6 +973,126 @@ var _ = Describe("BuildStepDelegate", func() {
 		})
 	})
 
+	Describe("limiting the output rate", func() {
+		var limiter *engine.OutputRateLimiter
+
+		payloads := func() []string {
+			var payloads []string
+			for _, ev := range savedLogEvents() {
+				payloads = append(payloads, ev.(event.Log).Payload)
+			}
+			return payloads
+		}
+
+		BeforeEach(func() {
+			limiter = engine.NewOutputRateLimiter(10)
+			delegate = engine.NewBuildStepDelegate(context.Background(), fakeBuild, "some-plan-id", runState, fakeClock, fakePolicyChecker, fakeArtifactSourcer, limiter)
+		})
+
+		line := func(length int, char string) string {
+			return strings.Repeat(char, length-1) + "\n"
+		}
+
+		write := func(writer io.Writer, text string) {
+			n, err := writer.Write([]byte(text))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(n).To(Equal(len(text)))
+		}
+
+		flush := func(writer io.Writer) {
+			Expect(writer.(io.Closer).Close()).To(Succeed())
+		}
+
+		It("saves the output within the limit", func() {
+			writer := delegate.Stdout()
+			write(writer, line(100, "a"))
+			flush(writer)
+
+			Expect(payloads()).To(Equal([]string{line(100, "a")}))
+		})
+
+		It("truncates output longer than a burst", func() {
+			writer := delegate.Stdout()
+			write(writer, line(101, "a"))
+			flush(writer)
+
+			Expect(payloads()).To(Equal([]string{
+				strings.Repeat("a", 100) + "\n[output rate limited to 10 bytes/s: dropping output]\n",
+				"[1 bytes of output dropped]\n",
+			}))
+		})
+
+		It("drops the output over the limit and says how much once the output ends", func() {
+			writer := delegate.Stdout()
+			write(writer, line(100, "a"))
+			write(writer, line(5, "b"))
+			write(writer, line(5, "c"))
+			flush(writer)
+
+			Expect(payloads()).To(Equal([]string{
+				line(100, "a"),
+				"\n[output rate limited to 10 bytes/s: dropping output]\n",
+				"[10 bytes of output dropped]\n",
+			}))
+		})
+
+		It("says so once for both of the step's streams", func() {
+			write(delegate.Stdout(), line(100, "a"))
+			write(delegate.Stdout(), line(5, "b"))
+			write(delegate.Stderr(), line(5, "c"))
+			flush(delegate.Stdout())
+			flush(delegate.Stderr())
+
+			Expect(payloads()).To(Equal([]string{
+				line(100, "a"),
+				"\n[output rate limited to 10 bytes/s: dropping output]\n",
+				"[10 bytes of output dropped]\n",
+			}))
+		})
+
+		It("says how much was dropped once output is allowed again", func() {
+			writer := delegate.Stdout()
+			write(writer, line(100, "a"))
+			write(writer, line(5, "b"))
+			write(writer, line(5, "c"))
+
+			fakeClock.Increment(time.Second)
+			write(writer, line(10, "d"))
+			flush(writer)
+
+			Expect(payloads()).To(Equal([]string{
+				line(100, "a"),
+				"\n[output rate limited to 10 bytes/s: dropping output]\n",
+				"[10 bytes of output dropped]\n" + line(10, "d"),
+			}))
+		})
+
+		It("is shared by the other steps of the build", func() {
+			write(delegate.Stdout(), line(100, "a"))
+			flush(delegate.Stdout())
+
+			otherDelegate := engine.NewBuildStepDelegate(context.Background(), fakeBuild, "other-plan-id", runState, fakeClock, fakePolicyChecker, fakeArtifactSourcer, limiter)
+			write(otherDelegate.Stderr(), line(5, "b"))
+			flush(otherDelegate.Stderr())
+
+			Expect(payloads()).To(Equal([]string{
+				line(100, "a"),
+				"\n[output rate limited to 10 bytes/s: dropping output]\n",
+				"[5 bytes of output dropped]\n",
+			}))
+		})
+
+		It("still saves the step finishing", func() {
+			writer := delegate.Stdout()
+			write(writer, line(200, "a"))
+
+			delegate.Finished(logger, true)
+
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0).EventType()).To(Equal(event.EventTypeFinish))
+		})
+	})
+
 	Describe("Secrets redaction", func() {
 		var (
 			runState     exec.RunState
@@ -740,7 +1103,7 @@ var _ = Describe("BuildStepDelegate", func() {
 
 		BeforeEach(func() {
 			runState = exec.NewRunState(noopStepper, credVars, true)
-			delegate = engine.NewBuildStepDelegate(fakeBuild, "some-plan-id", runState, fakeClock, fakePolicyChecker, fakeArtifactSourcer)
+			delegate = engine.NewBuildStepDelegate(context.Background(), fakeBuild, "some-plan-id", runState, fakeClock, fakePolicyChecker, fakeArtifactSourcer, nil)
 
 			runState.Get(vars.Reference{Path: "source-param"})
 			runState.Get(vars.Reference{Path: "git-key"})
@@ -757,8 +1120,8 @@ var _ = Describe("BuildStepDelegate", func() {
 				It("should be redacted", func() {
 					Expect(writeErr).To(BeNil())
 					Expect(writtenBytes).To(Equal(len("ok super-secret-source ok")))
-					Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
-					Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+					Expect(savedLogEvents()).To(HaveLen(1))
+					Expect(savedLogEvents()[0]).To(Equal(event.Log{
 						Time:    now.Unix(),
 						Payload: "ok ((redacted)) ok",
 						Origin: event.Origin{
@@ -782,8 +1145,8 @@ var _ = Describe("BuildStepDelegate", func() {
 				It("should be redacted", func() {
 					Expect(writeErr).To(BeNil())
 					Expect(writtenBytes).To(Equal(len(logLines)))
-					Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
-					Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+					Expect(savedLogEvents()).To(HaveLen(1))
+					Expect(savedLogEvents()[0]).To(Equal(event.Log{
 						Time:    now.Unix(),
 						Payload: "ok((redacted))ok\nok((redacted))ok\nok((redacted))ok\n",
 						Origin: event.Origin{
@@ -803,8 +1166,8 @@ var _ = Describe("BuildStepDelegate", func() {
 				})
 
 				It("should be redacted", func() {
-					Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
-					Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+					Expect(savedLogEvents()).To(HaveLen(2))
+					Expect(savedLogEvents()[0]).To(Equal(event.Log{
 						Time:    now.Unix(),
 						Payload: "ok((redacted))ok\n",
 						Origin: event.Origin{
@@ -812,7 +1175,7 @@ var _ = Describe("BuildStepDelegate", func() {
 							ID:     "some-plan-id",
 						},
 					}))
-					Expect(fakeBuild.SaveEventArgsForCall(1)).To(Equal(event.Log{
+					Expect(savedLogEvents()[1]).To(Equal(event.Log{
 						Time:    now.Unix(),
 						Payload: "ok((redacted))ok\nok((redacted))ok\n",
 						Origin: event.Origin{
@@ -835,8 +1198,8 @@ var _ = Describe("BuildStepDelegate", func() {
 				It("should be redacted", func() {
 					Expect(writeErr).To(BeNil())
 					Expect(writtenBytes).To(Equal(len("ok super-secret-source ok")))
-					Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
-					Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+					Expect(savedLogEvents()).To(HaveLen(1))
+					Expect(savedLogEvents()[0]).To(Equal(event.Log{
 						Time:    now.Unix(),
 						Payload: "ok ((redacted)) ok",
 						Origin: event.Origin{
@@ -860,8 +1223,8 @@ var _ = Describe("BuildStepDelegate", func() {
 				It("should be redacted", func() {
 					Expect(writeErr).To(BeNil())
 					Expect(writtenBytes).To(Equal(len(logLines)))
-					Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
-					Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+					Expect(savedLogEvents()).To(HaveLen(1))
+					Expect(savedLogEvents()[0]).To(Equal(event.Log{
 						Time:    now.Unix(),
 						Payload: "{\nok((redacted))ok\nok((redacted))ok\nok((redacted))ok\n}\n",
 						Origin: event.Origin{
@@ -881,8 +1244,8 @@ var _ = Describe("BuildStepDelegate", func() {
 				})
 
 				It("should be redacted", func() {
-					Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
-					Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+					Expect(savedLogEvents()).To(HaveLen(2))
+					Expect(savedLogEvents()[0]).To(Equal(event.Log{
 						Time:    now.Unix(),
 						Payload: "ok((redacted))ok\n",
 						Origin: event.Origin{
@@ -890,7 +1253,7 @@ var _ = Describe("BuildStepDelegate", func() {
 							ID:     "some-plan-id",
 						},
 					}))
-					Expect(fakeBuild.SaveEventArgsForCall(1)).To(Equal(event.Log{
+					Expect(savedLogEvents()[1]).To(Equal(event.Log{
 						Time:    now.Unix(),
 						Payload: "ok((redacted))ok\nok((redacted))ok\n",
 						Origin: event.Origin{
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"strconv"
@@ -23,33 +24,44 @@ type CoreStepFactory interface {
 	TaskStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, DelegateFactory) exec.Step
 	CheckStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, DelegateFactory) exec.Step
 	SetPipelineStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
-	LoadVarStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
+	LoadVarStep(atc.Plan, exec.StepMetadata, db.Build, DelegateFactory) exec.Step
+	ApprovalStep(atc.Plan, exec.StepMetadata, db.Build, DelegateFactory) exec.Step
+	PublishArtifactStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
+	ConsumeArtifactStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
+	LoadBuildOutputsStep(atc.Plan, exec.StepMetadata, db.Build, DelegateFactory) exec.Step
+	AssertGreenStep(atc.Plan, exec.StepMetadata, db.Build, DelegateFactory) exec.Step
 	ArtifactInputStep(atc.Plan, db.Build) exec.Step
 	ArtifactOutputStep(atc.Plan, db.Build) exec.Step
 }
 
 //counterfeiter:generate . StepperFactory
 type StepperFactory interface {
-	StepperForBuild(db.Build) (exec.Stepper, error)
+	StepperForBuild(context.Context, db.Build) (exec.Stepper, error)
 }
 
 func NewStepperFactory(
 	coreFactory CoreStepFactory,
 	externalURL string,
 	rateLimiter RateLimiter,
+	poolLimiter CheckPoolRateLimiter,
+	checkLimiter CheckLimiter,
 	policyChecker policy.Checker,
 	artifactSourcer worker.ArtifactSourcer,
 	dbWorkerFactory db.WorkerFactory,
 	lockFactory lock.LockFactory,
+	outputRateLimit int,
 ) StepperFactory {
 	return &stepperFactory{
 		coreFactory:     coreFactory,
 		externalURL:     externalURL,
 		rateLimiter:     rateLimiter,
+		poolLimiter:     poolLimiter,
+		checkLimiter:    checkLimiter,
 		policyChecker:   policyChecker,
 		artifactSourcer: artifactSourcer,
 		dbWorkerFactory: dbWorkerFactory,
 		lockFactory:     lockFactory,
+		outputRateLimit: outputRateLimit,
 	}
 }
 
@@ -57,31 +69,47 @@ type stepperFactory struct {
 	coreFactory     CoreStepFactory
 	externalURL     string
 	rateLimiter     RateLimiter
+	poolLimiter     CheckPoolRateLimiter
+	checkLimiter    CheckLimiter
 	policyChecker   policy.Checker
 	artifactSourcer worker.ArtifactSourcer
 	dbWorkerFactory db.WorkerFactory
 	lockFactory     lock.LockFactory
+	outputRateLimit int
+
+	// ctx and outputLimiter are set for the steps of a build, and shared by
+	// them. The steps' log events stop being saved once ctx is done.
+	ctx           context.Context
+	outputLimiter *OutputRateLimiter
 }
 
-func (factory *stepperFactory) StepperForBuild(build db.Build) (exec.Stepper, error) {
+func (factory *stepperFactory) StepperForBuild(ctx context.Context, build db.Build) (exec.Stepper, error) {
 	if build.Schema() != supportedSchema {
 		return nil, errors.New("schema not supported")
 	}
 
+	buildFactory := *factory
+	buildFactory.ctx = ctx
+	buildFactory.outputLimiter = NewOutputRateLimiter(factory.outputRateLimit)
+
 	return func(plan atc.Plan) exec.Step {
-		return factory.buildStep(build, plan)
+		return buildFactory.buildStep(build, plan)
 	}, nil
 }
 
 func (factory *stepperFactory) buildDelegateFactory(build db.Build, plan atc.Plan) DelegateFactory {
 	return DelegateFactory{
+		ctx:             factory.ctx,
 		build:           build,
 		plan:            plan,
 		rateLimiter:     factory.rateLimiter,
+		poolLimiter:     factory.poolLimiter,
+		checkLimiter:    factory.checkLimiter,
 		policyChecker:   factory.policyChecker,
 		artifactSourcer: factory.artifactSourcer,
 		dbWorkerFactory: factory.dbWorkerFactory,
 		lockFactory:     factory.lockFactory,
+		outputLimiter:   factory.outputLimiter,
 	}
 }
 
@@ -98,6 +126,10 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 		return factory.buildDoStep(build, plan)
 	}
 
+	if plan.Atomic != nil {
+		return factory.buildAtomicStep(build, plan)
+	}
+
 	if plan.Timeout != nil {
 		return factory.buildTimeoutStep(build, plan)
 	}
@@ -138,6 +170,26 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 		return factory.buildLoadVarStep(build, plan)
 	}
 
+	if plan.Approval != nil {
+		return factory.buildApprovalStep(build, plan)
+	}
+
+	if plan.PublishArtifact != nil {
+		return factory.buildPublishArtifactStep(build, plan)
+	}
+
+	if plan.ConsumeArtifact != nil {
+		return factory.buildConsumeArtifactStep(build, plan)
+	}
+
+	if plan.LoadBuildOutputs != nil {
+		return factory.buildLoadBuildOutputsStep(build, plan)
+	}
+
+	if plan.AssertGreen != nil {
+		return factory.buildAssertGreenStep(build, plan)
+	}
+
 	if plan.Check != nil {
 		return factory.buildCheckStep(build, plan)
 	}
@@ -175,7 +227,14 @@ func (factory *stepperFactory) buildParallelStep(build db.Build, plan atc.Plan)
 		steps = append(steps, step)
 	}
 
-	return exec.InParallel(steps, plan.InParallel.Limit, plan.InParallel.FailFast)
+	return exec.InParallel(
+		steps,
+		plan.InParallel.Limit,
+		plan.InParallel.FailFast,
+		plan.InParallel.FailFastGracePeriod,
+		plan.InParallel.OutputPriority,
+		factory.buildDelegateFactory(build, plan),
+	)
 }
 
 func (factory *stepperFactory) buildAcrossStep(build db.Build, plan atc.Plan) exec.Step {
@@ -215,6 +274,52 @@ func (factory *stepperFactory) buildDoStep(build db.Build, plan atc.Plan) exec.S
 	return step
 }
 
+func (factory *stepperFactory) buildAtomicStep(build db.Build, plan atc.Plan) exec.Step {
+	var puts []exec.AtomicPut
+
+	for _, innerPlan := range plan.Atomic.Steps {
+		innerPlan.Attempts = plan.Attempts
+
+		putPlan := innerPlan
+		var getPlan *atc.Plan
+		if innerPlan.OnSuccess != nil {
+			putPlan = innerPlan.OnSuccess.Step
+			getPlan = &innerPlan.OnSuccess.Next
+		}
+
+		putPlan.Attempts = plan.Attempts
+
+		put := exec.AtomicPut{
+			Stage:    factory.buildStep(build, withPutPhase(putPlan, atc.PutPhaseStage)),
+			Commit:   factory.buildStep(build, withPutPhase(putPlan, atc.PutPhaseCommit)),
+			Rollback: factory.buildStep(build, withPutPhase(putPlan, atc.PutPhaseRollback)),
+		}
+
+		if getPlan != nil {
+			getPlan.Attempts = plan.Attempts
+			put.Get = factory.buildStep(build, *getPlan)
+		}
+
+		puts = append(puts, put)
+	}
+
+	return exec.Atomic(puts)
+}
+
+// withPutPhase returns a copy of the put plan which runs the given phase of
+// its two-phase commit.
+func withPutPhase(plan atc.Plan, phase atc.PutPhase) atc.Plan {
+	if plan.Put == nil {
+		return plan
+	}
+
+	put := *plan.Put
+	put.Phase = phase
+	plan.Put = &put
+
+	return plan
+}
+
 func (factory *stepperFactory) buildTimeoutStep(build db.Build, plan atc.Plan) exec.Step {
 	innerPlan := plan.Timeout.Step
 	innerPlan.Attempts = plan.Attempts
@@ -272,13 +377,17 @@ func (factory *stepperFactory) buildEnsureStep(build db.Build, plan atc.Plan) ex
 func (factory *stepperFactory) buildRetryStep(build db.Build, plan atc.Plan) exec.Step {
 	steps := []exec.Step{}
 
-	for index, innerPlan := range *plan.Retry {
+	for index, innerPlan := range plan.Retry.Steps {
 		innerPlan.Attempts = append(plan.Attempts, index+1)
 
 		step := factory.buildStep(build, innerPlan)
 		steps = append(steps, step)
 	}
 
+	if plan.Retry.Backoff != "" || plan.Retry.MaxDuration != "" {
+		return exec.RetryWithBackoff(plan.Retry.Backoff, plan.Retry.MaxDuration, steps...)
+	}
+
 	return exec.Retry(steps...)
 }
 
@@ -399,6 +508,85 @@ func (factory *stepperFactory) buildLoadVarStep(build db.Build, plan atc.Plan) e
 	return factory.coreFactory.LoadVarStep(
 		plan,
 		stepMetadata,
+		build,
+		factory.buildDelegateFactory(build, plan),
+	)
+}
+
+func (factory *stepperFactory) buildApprovalStep(build db.Build, plan atc.Plan) exec.Step {
+
+	stepMetadata := factory.stepMetadata(
+		build,
+		factory.externalURL,
+		false,
+	)
+
+	return factory.coreFactory.ApprovalStep(
+		plan,
+		stepMetadata,
+		build,
+		factory.buildDelegateFactory(build, plan),
+	)
+}
+
+func (factory *stepperFactory) buildPublishArtifactStep(build db.Build, plan atc.Plan) exec.Step {
+
+	stepMetadata := factory.stepMetadata(
+		build,
+		factory.externalURL,
+		false,
+	)
+
+	return factory.coreFactory.PublishArtifactStep(
+		plan,
+		stepMetadata,
+		factory.buildDelegateFactory(build, plan),
+	)
+}
+
+func (factory *stepperFactory) buildConsumeArtifactStep(build db.Build, plan atc.Plan) exec.Step {
+
+	stepMetadata := factory.stepMetadata(
+		build,
+		factory.externalURL,
+		false,
+	)
+
+	return factory.coreFactory.ConsumeArtifactStep(
+		plan,
+		stepMetadata,
+		factory.buildDelegateFactory(build, plan),
+	)
+}
+
+func (factory *stepperFactory) buildLoadBuildOutputsStep(build db.Build, plan atc.Plan) exec.Step {
+
+	stepMetadata := factory.stepMetadata(
+		build,
+		factory.externalURL,
+		false,
+	)
+
+	return factory.coreFactory.LoadBuildOutputsStep(
+		plan,
+		stepMetadata,
+		build,
+		factory.buildDelegateFactory(build, plan),
+	)
+}
+
+func (factory *stepperFactory) buildAssertGreenStep(build db.Build, plan atc.Plan) exec.Step {
+
+	stepMetadata := factory.stepMetadata(
+		build,
+		factory.externalURL,
+		false,
+	)
+
+	return factory.coreFactory.AssertGreenStep(
+		plan,
+		stepMetadata,
+		build,
 		factory.buildDelegateFactory(build, plan),
 	)
 }
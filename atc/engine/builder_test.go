@@ -1,6 +1,8 @@
 package engine_test
 
 import (
+	"context"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/builds"
 	"github.com/concourse/concourse/atc/db"
@@ -24,6 +26,8 @@ var _ = Describe("Builder", func() {
 
 			fakeCoreStepFactory *enginefakes.FakeCoreStepFactory
 			fakeRateLimiter     *enginefakes.FakeRateLimiter
+			fakePoolLimiter     *enginefakes.FakeCheckPoolRateLimiter
+			fakeCheckLimiter    *enginefakes.FakeCheckLimiter
 			fakePolicyChecker   *policyfakes.FakeChecker
 			fakeArtifactSourcer *workerfakes.FakeArtifactSourcer
 			fakeWorkerFactory   *dbfakes.FakeWorkerFactory
@@ -36,6 +40,8 @@ var _ = Describe("Builder", func() {
 		BeforeEach(func() {
 			fakeCoreStepFactory = new(enginefakes.FakeCoreStepFactory)
 			fakeRateLimiter = new(enginefakes.FakeRateLimiter)
+			fakePoolLimiter = new(enginefakes.FakeCheckPoolRateLimiter)
+			fakeCheckLimiter = new(enginefakes.FakeCheckLimiter)
 			fakePolicyChecker = new(policyfakes.FakeChecker)
 			fakeArtifactSourcer = new(workerfakes.FakeArtifactSourcer)
 			fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
@@ -45,10 +51,13 @@ var _ = Describe("Builder", func() {
 				fakeCoreStepFactory,
 				"http://example.com",
 				fakeRateLimiter,
+				fakePoolLimiter,
+				fakeCheckLimiter,
 				fakePolicyChecker,
 				fakeArtifactSourcer,
 				fakeWorkerFactory,
 				fakeLockFactory,
+				0,
 			)
 
 			planFactory = atc.NewPlanFactory(123)
@@ -118,7 +127,7 @@ var _ = Describe("Builder", func() {
 				})
 
 				It("errors", func() {
-					_, err := stepperFactory.StepperForBuild(fakeBuild)
+					_, err := stepperFactory.StepperForBuild(context.Background(), fakeBuild)
 					Expect(err).To(HaveOccurred())
 				})
 			})
@@ -131,12 +140,73 @@ var _ = Describe("Builder", func() {
 				JustBeforeEach(func() {
 					fakeBuild.PrivatePlanReturns(expectedPlan)
 
-					stepper, err := stepperFactory.StepperForBuild(fakeBuild)
+					stepper, err := stepperFactory.StepperForBuild(context.Background(), fakeBuild)
 					Expect(err).ToNot(HaveOccurred())
 
 					stepper(fakeBuild.PrivatePlan())
 				})
 
+				Context("with an atomic step", func() {
+					var (
+						putPlan          atc.Plan
+						dependentGetPlan atc.Plan
+					)
+
+					BeforeEach(func() {
+						putPlan = planFactory.NewPlan(atc.PutPlan{
+							Name:     "some-put",
+							Resource: "some-output-resource",
+							Type:     "put",
+							Source:   atc.Source{"some": "source"},
+						})
+
+						dependentGetPlan = planFactory.NewPlan(atc.GetPlan{
+							Name:        "some-put",
+							Resource:    "some-output-resource",
+							Type:        "put",
+							Source:      atc.Source{"some": "source"},
+							VersionFrom: &putPlan.ID,
+						})
+
+						expectedPlan = planFactory.NewPlan(atc.AtomicPlan{
+							Steps: []atc.Plan{
+								planFactory.NewPlan(atc.OnSuccessPlan{
+									Step: putPlan,
+									Next: dependentGetPlan,
+								}),
+							},
+						})
+					})
+
+					It("constructs a put for each phase", func() {
+						Expect(fakeCoreStepFactory.PutStepCallCount()).To(Equal(3))
+
+						var phases []atc.PutPhase
+						for i := 0; i < 3; i++ {
+							plan, _, _, _ := fakeCoreStepFactory.PutStepArgsForCall(i)
+							Expect(plan.ID).To(Equal(putPlan.ID))
+							phases = append(phases, plan.Put.Phase)
+						}
+
+						Expect(phases).To(Equal([]atc.PutPhase{
+							atc.PutPhaseStage,
+							atc.PutPhaseCommit,
+							atc.PutPhaseRollback,
+						}))
+					})
+
+					It("does not change the put plan", func() {
+						Expect(putPlan.Put.Phase).To(BeEmpty())
+					})
+
+					It("constructs the get of the put's version", func() {
+						Expect(fakeCoreStepFactory.GetStepCallCount()).To(Equal(1))
+
+						plan, _, _, _ := fakeCoreStepFactory.GetStepArgsForCall(0)
+						Expect(plan).To(Equal(dependentGetPlan))
+					})
+				})
+
 				Context("with a putget in an in_parallel", func() {
 					var (
 						putPlan               atc.Plan
@@ -319,8 +389,10 @@ var _ = Describe("Builder", func() {
 						})
 
 						retryPlanTwo = planFactory.NewPlan(atc.RetryPlan{
-							taskPlan,
-							taskPlan,
+							Steps: []atc.Plan{
+								taskPlan,
+								taskPlan,
+							},
 						})
 
 						inParallelPlan = planFactory.NewPlan(atc.InParallelPlan{Steps: []atc.Plan{retryPlanTwo}})
@@ -339,14 +411,16 @@ var _ = Describe("Builder", func() {
 						})
 
 						expectedPlan = planFactory.NewPlan(atc.RetryPlan{
-							getPlan,
-							timeoutPlan,
-							getPlan,
+							Steps: []atc.Plan{
+								getPlan,
+								timeoutPlan,
+								getPlan,
+							},
 						})
 					})
 
 					It("constructs the retry correctly", func() {
-						Expect(*expectedPlan.Retry).To(HaveLen(3))
+						Expect(expectedPlan.Retry.Steps).To(HaveLen(3))
 					})
 
 					It("constructs the first get correctly", func() {
@@ -390,7 +464,7 @@ var _ = Describe("Builder", func() {
 					})
 
 					It("constructs nested retries correctly", func() {
-						Expect(*retryPlanTwo.Retry).To(HaveLen(2))
+						Expect(retryPlanTwo.Retry.Steps).To(HaveLen(2))
 					})
 
 					It("constructs nested steps correctly", func() {
@@ -476,7 +550,9 @@ var _ = Describe("Builder", func() {
 						})
 
 						expectedPlan = planFactory.NewPlan(atc.RetryPlan{
-							ensurePlan,
+							Steps: []atc.Plan{
+								ensurePlan,
+							},
 						})
 					})
 
@@ -583,9 +659,88 @@ var _ = Describe("Builder", func() {
 						})
 
 						It("constructs load_var correctly", func() {
-							plan, stepMetadata, _ := fakeCoreStepFactory.LoadVarStepArgsForCall(0)
+							plan, stepMetadata, stepBuild, _ := fakeCoreStepFactory.LoadVarStepArgsForCall(0)
+							Expect(plan).To(Equal(expectedPlan))
+							Expect(stepMetadata).To(Equal(expectedMetadataWithoutCreatedBy))
+							Expect(stepBuild).To(Equal(fakeBuild))
+						})
+					})
+
+					Context("that contains an approval step", func() {
+						BeforeEach(func() {
+							expectedPlan = planFactory.NewPlan(atc.ApprovalPlan{
+								Name:    "deploy",
+								Timeout: "1h",
+							})
+						})
+
+						It("constructs approval correctly", func() {
+							plan, stepMetadata, stepBuild, _ := fakeCoreStepFactory.ApprovalStepArgsForCall(0)
+							Expect(plan).To(Equal(expectedPlan))
+							Expect(stepMetadata).To(Equal(expectedMetadataWithoutCreatedBy))
+							Expect(stepBuild).To(Equal(fakeBuild))
+						})
+					})
+
+					Context("that contains a publish_artifact step", func() {
+						BeforeEach(func() {
+							expectedPlan = planFactory.NewPlan(atc.PublishArtifactPlan{
+								Name: "some-artifact",
+								From: "some-output",
+							})
+						})
+
+						It("constructs publish_artifact correctly", func() {
+							plan, stepMetadata, _ := fakeCoreStepFactory.PublishArtifactStepArgsForCall(0)
+							Expect(plan).To(Equal(expectedPlan))
+							Expect(stepMetadata).To(Equal(expectedMetadataWithoutCreatedBy))
+						})
+					})
+
+					Context("that contains a consume_artifact step", func() {
+						BeforeEach(func() {
+							expectedPlan = planFactory.NewPlan(atc.ConsumeArtifactPlan{
+								Name: "some-artifact",
+								Team: "other-team",
+							})
+						})
+
+						It("constructs consume_artifact correctly", func() {
+							plan, stepMetadata, _ := fakeCoreStepFactory.ConsumeArtifactStepArgsForCall(0)
+							Expect(plan).To(Equal(expectedPlan))
+							Expect(stepMetadata).To(Equal(expectedMetadataWithoutCreatedBy))
+						})
+					})
+
+					Context("that contains a load_build_outputs step", func() {
+						BeforeEach(func() {
+							expectedPlan = planFactory.NewPlan(atc.LoadBuildOutputsPlan{
+								Name: "some-outputs",
+								Job:  "some-job",
+							})
+						})
+
+						It("constructs load_build_outputs correctly", func() {
+							plan, stepMetadata, stepBuild, _ := fakeCoreStepFactory.LoadBuildOutputsStepArgsForCall(0)
+							Expect(plan).To(Equal(expectedPlan))
+							Expect(stepMetadata).To(Equal(expectedMetadataWithoutCreatedBy))
+							Expect(stepBuild).To(Equal(fakeBuild))
+						})
+					})
+
+					Context("that contains an assert_green step", func() {
+						BeforeEach(func() {
+							expectedPlan = planFactory.NewPlan(atc.AssertGreenPlan{
+								Job:      "some-job",
+								Pipeline: "some-pipeline",
+							})
+						})
+
+						It("constructs assert_green correctly", func() {
+							plan, stepMetadata, stepBuild, _ := fakeCoreStepFactory.AssertGreenStepArgsForCall(0)
 							Expect(plan).To(Equal(expectedPlan))
 							Expect(stepMetadata).To(Equal(expectedMetadataWithoutCreatedBy))
+							Expect(stepBuild).To(Equal(fakeBuild))
 						})
 					})
 
@@ -875,7 +1030,7 @@ var _ = Describe("Builder", func() {
 							},
 						}
 
-						expectedPlan, err = planner.Create(step, nil, nil, nil)
+						expectedPlan, err = planner.Create(step, nil, nil, nil, nil)
 						Expect(err).ToNot(HaveOccurred())
 					})
 
@@ -21,24 +21,42 @@ type RateLimiter interface {
 	Wait(context.Context) error
 }
 
+//counterfeiter:generate . CheckPoolRateLimiter
+type CheckPoolRateLimiter interface {
+	Wait(ctx context.Context, pool string) error
+}
+
+//counterfeiter:generate . CheckLimiter
+type CheckLimiter interface {
+	// Acquire blocks until a check may run, returning a func to call once it
+	// has finished.
+	Acquire(context.Context) (func(), error)
+}
+
 func NewCheckDelegate(
+	ctx context.Context,
 	build db.Build,
 	plan atc.Plan,
 	state exec.RunState,
 	clock clock.Clock,
 	limiter RateLimiter,
+	poolLimiter CheckPoolRateLimiter,
+	checkLimiter CheckLimiter,
 	policyChecker policy.Checker,
 	artifactSourcer worker.ArtifactSourcer,
+	outputLimiter *OutputRateLimiter,
 ) exec.CheckDelegate {
 	return &checkDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, plan.ID, state, clock, policyChecker, artifactSourcer),
+		BuildStepDelegate: NewBuildStepDelegate(ctx, build, plan.ID, state, clock, policyChecker, artifactSourcer, outputLimiter),
 
 		build:       build,
 		plan:        plan.Check,
 		eventOrigin: event.Origin{ID: event.OriginID(plan.ID)},
 		clock:       clock,
 
-		limiter: limiter,
+		limiter:      limiter,
+		poolLimiter:  poolLimiter,
+		checkLimiter: checkLimiter,
 	}
 }
 
@@ -55,7 +73,9 @@ type checkDelegate struct {
 	cachedResource     db.Resource
 	cachedResourceType db.ResourceType
 
-	limiter RateLimiter
+	limiter      RateLimiter
+	poolLimiter  CheckPoolRateLimiter
+	checkLimiter CheckLimiter
 }
 
 func (d *checkDelegate) FindOrCreateScope(config db.ResourceConfig) (db.ResourceConfigScope, error) {
@@ -74,11 +94,12 @@ func (d *checkDelegate) FindOrCreateScope(config db.ResourceConfig) (db.Resource
 
 // WaitToRun decides if a check should really run or just reuse a previous result, and acquires
 // a check lock accordingly. There are three types of checks, each reflects to a different behavior:
-// 1) A Lidar triggered checks should always run once reach to next check time;
+// 1) A Lidar triggered checks should always run once reach to next check time, or once the check TTL
+// has elapsed since the last Lidar triggered check;
 // 2) A manually triggered checks may reuse a previous result if the last check succeeded and began
 // later than the current check build's create time.
 // 3) A step embedded check may reuse a previous step if the last check succeeded and finished later
-// than the current build started.
+// than the current build started, or within the check's interval if it has one.
 func (d *checkDelegate) WaitToRun(ctx context.Context, scope db.ResourceConfigScope) (lock.Lock, bool, error) {
 	logger := lagerctx.FromContext(ctx)
 
@@ -104,16 +125,31 @@ func (d *checkDelegate) WaitToRun(ctx context.Context, scope db.ResourceConfigSc
 	var lock lock.Lock = lock.NoopLock{}
 	if d.plan.IsPeriodic() {
 		for {
+			// bound the check containers this node runs at once, so that a
+			// check storm queues up here rather than overwhelming the
+			// workers. the slot is taken before the checking lock so that a
+			// check queued up on this node doesn't hold the lock, keeping
+			// other ATCs from running it, while it waits for a slot.
+			release, err := d.checkLimiter.Acquire(ctx)
+			if err != nil {
+				return nil, false, fmt.Errorf("concurrent check limit: %w", err)
+			}
+
 			var acquired bool
 			lock, acquired, err = scope.AcquireResourceCheckingLock(logger)
 			if err != nil {
+				release()
 				return nil, false, fmt.Errorf("acquire lock: %w", err)
 			}
 
 			if acquired {
+				lock = limitedCheckLock{Lock: lock, release: release}
 				break
 			}
 
+			// give the slot up while the lock is held elsewhere
+			release()
+
 			d.clock.Sleep(time.Second)
 		}
 	}
@@ -131,6 +167,14 @@ func (d *checkDelegate) WaitToRun(ctx context.Context, scope db.ResourceConfigSc
 		if !lastCheck.Succeeded || lastCheck.EndTime.Before(d.build.StartTime()) {
 			shouldRun = true
 		}
+
+		// e.g. the image of a resource type which opts in to reusing it with
+		// reuse_image_for. The config is keyed on the image's source, so a
+		// change to its repository, tag or credentials is checked for right
+		// away.
+		if interval > 0 && lastCheck.Succeeded && d.clock.Now().Before(lastCheck.EndTime.Add(interval)) {
+			shouldRun = false
+		}
 	} else if d.build.IsManuallyTriggered() {
 		// If a manually triggered check takes a from version, then it should be run.
 		if d.plan.FromVersion != nil {
@@ -142,6 +186,22 @@ func (d *checkDelegate) WaitToRun(ctx context.Context, scope db.ResourceConfigSc
 		}
 	} else {
 		shouldRun = !d.clock.Now().Before(lastCheck.EndTime.Add(interval))
+
+		if d.plan.TTL != "" {
+			ttl, err := time.ParseDuration(d.plan.TTL)
+			if err != nil {
+				if releaseErr := lock.Release(); releaseErr != nil {
+					logger.Error("failed-to-release-lock", releaseErr)
+				}
+				return nil, false, fmt.Errorf("parse ttl: %w", err)
+			}
+
+			// checks triggered by webhooks keep the last check fresh, but
+			// don't count towards the TTL
+			if !d.clock.Now().Before(lastCheck.ScheduledEndTime.Add(ttl)) {
+				shouldRun = true
+			}
+		}
 	}
 
 	// XXX(check-refactor): we could add an else{} case and potentially sleep
@@ -164,9 +224,49 @@ func (d *checkDelegate) WaitToRun(ctx context.Context, scope db.ResourceConfigSc
 		return nil, false, nil
 	}
 
+	// checks which aren't periodic hold no checking lock, so they only take a
+	// concurrent check slot once they're known to run
+	if !d.plan.IsPeriodic() {
+		release, err := d.checkLimiter.Acquire(ctx)
+		if err != nil {
+			return nil, false, fmt.Errorf("concurrent check limit: %w", err)
+		}
+
+		lock = limitedCheckLock{Lock: lock, release: release}
+	}
+
+	// all checks in a pool hit the same backend, so they queue up here rather
+	// than exceed its rate limit
+	if d.plan.CheckPool != "" {
+		err := d.poolLimiter.Wait(ctx, d.plan.CheckPool)
+		if err != nil {
+			if releaseErr := lock.Release(); releaseErr != nil {
+				logger.Error("failed-to-release-lock", releaseErr)
+			}
+			return nil, false, fmt.Errorf("check pool rate limit: %w", err)
+		}
+	}
+
 	return lock, true, nil
 }
 
+func (d *checkDelegate) Scheduled() bool {
+	return d.plan.IsPeriodic() && !d.build.IsManuallyTriggered()
+}
+
+// limitedCheckLock frees the check's concurrent check slot along with its
+// checking lock.
+type limitedCheckLock struct {
+	lock.Lock
+
+	release func()
+}
+
+func (l limitedCheckLock) Release() error {
+	l.release()
+	return l.Lock.Release()
+}
+
 func (d *checkDelegate) PointToCheckedConfig(scope db.ResourceConfigScope) error {
 	resource, found, err := d.resource()
 	if err != nil {
@@ -28,6 +28,9 @@ var _ = Describe("CheckDelegate", func() {
 		fakeBuild           *dbfakes.FakeBuild
 		fakeClock           *fakeclock.FakeClock
 		fakeRateLimiter     *enginefakes.FakeRateLimiter
+		fakePoolLimiter     *enginefakes.FakeCheckPoolRateLimiter
+		fakeCheckLimiter    *enginefakes.FakeCheckLimiter
+		checkSlotsReleased  int
 		fakePolicyChecker   *policyfakes.FakeChecker
 		fakeArtifactSourcer *workerfakes.FakeArtifactSourcer
 
@@ -46,6 +49,10 @@ var _ = Describe("CheckDelegate", func() {
 		fakeBuild = new(dbfakes.FakeBuild)
 		fakeClock = fakeclock.NewFakeClock(now)
 		fakeRateLimiter = new(enginefakes.FakeRateLimiter)
+		fakePoolLimiter = new(enginefakes.FakeCheckPoolRateLimiter)
+		fakeCheckLimiter = new(enginefakes.FakeCheckLimiter)
+		checkSlotsReleased = 0
+		fakeCheckLimiter.AcquireReturns(func() { checkSlotsReleased++ }, nil)
 		fakeArtifactSourcer = new(workerfakes.FakeArtifactSourcer)
 		credVars := vars.StaticVariables{
 			"source-param": "super-secret-source",
@@ -63,7 +70,7 @@ var _ = Describe("CheckDelegate", func() {
 		fakeBuild.NameReturns(db.CheckBuildName)
 		fakeBuild.ResourceIDReturns(88)
 
-		delegate = engine.NewCheckDelegate(fakeBuild, plan, state, fakeClock, fakeRateLimiter, fakePolicyChecker, fakeArtifactSourcer)
+		delegate = engine.NewCheckDelegate(context.Background(), fakeBuild, plan, state, fakeClock, fakeRateLimiter, fakePoolLimiter, fakeCheckLimiter, fakePolicyChecker, fakeArtifactSourcer, nil)
 
 		fakeResourceConfig = new(dbfakes.FakeResourceConfig)
 		fakeResourceConfigScope = new(dbfakes.FakeResourceConfigScope)
@@ -191,8 +198,94 @@ var _ = Describe("CheckDelegate", func() {
 				fakeResourceConfigScope.AcquireResourceCheckingLockReturns(fakeLock, true, nil)
 			})
 
-			It("returns a lock", func() {
-				Expect(runLock).To(Equal(fakeLock))
+			It("returns a lock which releases the checking lock", func() {
+				Expect(runLock.Release()).To(Succeed())
+				Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+			})
+
+			It("acquires a concurrent check slot", func() {
+				Expect(fakeCheckLimiter.AcquireCallCount()).To(Equal(1))
+				Expect(checkSlotsReleased).To(Equal(0))
+			})
+
+			It("frees the check slot when the lock is released", func() {
+				Expect(runLock.Release()).To(Succeed())
+				Expect(checkSlotsReleased).To(Equal(1))
+			})
+
+			Context("when acquiring a check slot fails", func() {
+				BeforeEach(func() {
+					fakeCheckLimiter.AcquireReturns(nil, context.Canceled)
+				})
+
+				It("returns the error", func() {
+					Expect(runErr).To(MatchError(context.Canceled))
+					Expect(run).To(BeFalse())
+				})
+
+				It("does not acquire the lock", func() {
+					Expect(fakeResourceConfigScope.AcquireResourceCheckingLockCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("before acquiring the lock", func() {
+				BeforeEach(func() {
+					fakeResourceConfigScope.AcquireResourceCheckingLockStub = func(lager.Logger) (lock.Lock, bool, error) {
+						Expect(fakeCheckLimiter.AcquireCallCount()).To(Equal(1))
+						return fakeLock, true, nil
+					}
+				})
+
+				It("acquires a check slot, so that the lock isn't held while waiting for one", func() {
+					Expect(fakeResourceConfigScope.AcquireResourceCheckingLockCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the lock is held elsewhere at first", func() {
+				BeforeEach(func() {
+					fakeResourceConfigScope.AcquireResourceCheckingLockStub = func(lager.Logger) (lock.Lock, bool, error) {
+						if fakeResourceConfigScope.AcquireResourceCheckingLockCallCount() > 1 {
+							return fakeLock, true, nil
+						}
+
+						go fakeClock.WaitForWatcherAndIncrement(time.Second)
+
+						return nil, false, nil
+					}
+				})
+
+				It("frees the check slot while waiting for the lock", func() {
+					Expect(run).To(BeTrue())
+					Expect(fakeCheckLimiter.AcquireCallCount()).To(Equal(2))
+					Expect(checkSlotsReleased).To(Equal(1))
+				})
+			})
+
+			Context("when acquiring the lock fails", func() {
+				BeforeEach(func() {
+					fakeResourceConfigScope.AcquireResourceCheckingLockReturns(nil, false, errors.New("nope"))
+				})
+
+				It("frees the check slot", func() {
+					Expect(runErr).To(HaveOccurred())
+					Expect(checkSlotsReleased).To(Equal(1))
+				})
+			})
+
+			Context("when the check does not need to run", func() {
+				BeforeEach(func() {
+					plan.Check.Interval = time.Minute.String()
+					fakeResourceConfigScope.LastCheckReturns(db.LastCheck{
+						EndTime:   now,
+						Succeeded: true,
+					}, nil)
+				})
+
+				It("frees the check slot along with the lock", func() {
+					Expect(run).To(BeFalse())
+					Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+					Expect(checkSlotsReleased).To(Equal(1))
+				})
 			})
 
 			Context("before acquiring the lock", func() {
@@ -208,6 +301,55 @@ var _ = Describe("CheckDelegate", func() {
 				})
 			})
 
+			It("does not wait on a check pool", func() {
+				Expect(fakePoolLimiter.WaitCallCount()).To(Equal(0))
+			})
+
+			Context("when the resource is in a check pool", func() {
+				BeforeEach(func() {
+					plan.Check.CheckPool = "some-pool"
+				})
+
+				It("waits for the pool's rate limit before running", func() {
+					Expect(fakePoolLimiter.WaitCallCount()).To(Equal(1))
+					_, pool := fakePoolLimiter.WaitArgsForCall(0)
+					Expect(pool).To(Equal("some-pool"))
+
+					Expect(run).To(BeTrue())
+				})
+
+				Context("when the check does not need to run", func() {
+					BeforeEach(func() {
+						plan.Check.Interval = time.Minute.String()
+						fakeResourceConfigScope.LastCheckReturns(db.LastCheck{
+							EndTime:   now,
+							Succeeded: true,
+						}, nil)
+					})
+
+					It("does not wait on the pool", func() {
+						Expect(run).To(BeFalse())
+						Expect(fakePoolLimiter.WaitCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when waiting on the pool fails", func() {
+					BeforeEach(func() {
+						fakePoolLimiter.WaitReturns(context.Canceled)
+					})
+
+					It("returns the error", func() {
+						Expect(runErr).To(MatchError(context.Canceled))
+						Expect(run).To(BeFalse())
+					})
+
+					It("releases the lock and frees the check slot", func() {
+						Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+						Expect(checkSlotsReleased).To(Equal(1))
+					})
+				})
+			})
+
 			Context("when the build is manually triggered", func() {
 				BeforeEach(func() {
 					fakeBuild.IsManuallyTriggeredReturns(true)
@@ -320,6 +462,59 @@ var _ = Describe("CheckDelegate", func() {
 					})
 				})
 			})
+
+			Context("with a TTL configured", func() {
+				var ttl time.Duration = time.Hour
+
+				BeforeEach(func() {
+					plan.Check.Interval = time.Minute.String()
+					plan.Check.TTL = ttl.String()
+				})
+
+				Context("when the last check is recent but the TTL has elapsed since the last scheduled check", func() {
+					BeforeEach(func() {
+						fakeResourceConfigScope.LastCheckReturns(db.LastCheck{
+							StartTime:        now.Add(-10 * time.Second),
+							EndTime:          now.Add(-5 * time.Second),
+							Succeeded:        true,
+							ScheduledEndTime: now.Add(-(ttl + 1)),
+						}, nil)
+					})
+
+					It("returns true", func() {
+						Expect(run).To(BeTrue())
+					})
+				})
+
+				Context("when the TTL has not elapsed since the last scheduled check", func() {
+					BeforeEach(func() {
+						fakeResourceConfigScope.LastCheckReturns(db.LastCheck{
+							StartTime:        now.Add(-10 * time.Second),
+							EndTime:          now.Add(-5 * time.Second),
+							Succeeded:        true,
+							ScheduledEndTime: now.Add(-(ttl - 1)),
+						}, nil)
+					})
+
+					It("returns false", func() {
+						Expect(run).To(BeFalse())
+					})
+				})
+
+				Context("when the TTL is invalid", func() {
+					BeforeEach(func() {
+						plan.Check.TTL = "daily"
+					})
+
+					It("returns an error", func() {
+						Expect(runErr).To(HaveOccurred())
+					})
+
+					It("releases the lock", func() {
+						Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+					})
+				})
+			})
 		})
 
 		Context("when not running for a resource", func() {
@@ -335,8 +530,9 @@ var _ = Describe("CheckDelegate", func() {
 				Expect(fakeResourceConfigScope.AcquireResourceCheckingLockCallCount()).To(Equal(0))
 			})
 
-			It("returns a no-op lock", func() {
-				Expect(runLock).To(Equal(lock.NoopLock{}))
+			It("returns a lock which only frees the check slot", func() {
+				Expect(runLock.Release()).To(Succeed())
+				Expect(checkSlotsReleased).To(Equal(1))
 			})
 
 			Context("when last check failed", func() {
@@ -381,6 +577,87 @@ var _ = Describe("CheckDelegate", func() {
 				It("returns false", func() {
 					Expect(run).To(BeFalse())
 				})
+
+				It("does not acquire a check slot", func() {
+					Expect(fakeCheckLimiter.AcquireCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("with an interval configured", func() {
+				BeforeEach(func() {
+					plan.Check.Interval = "1m"
+					fakeBuild.StartTimeReturns(now)
+				})
+
+				Context("when the last check succeeded within the interval", func() {
+					BeforeEach(func() {
+						fakeResourceConfigScope.LastCheckReturns(db.LastCheck{
+							StartTime: now.Add(-40 * time.Second),
+							EndTime:   now.Add(-30 * time.Second),
+							Succeeded: true,
+						}, nil)
+					})
+
+					It("returns false", func() {
+						Expect(run).To(BeFalse())
+					})
+				})
+
+				Context("when the last check succeeded before the interval", func() {
+					BeforeEach(func() {
+						fakeResourceConfigScope.LastCheckReturns(db.LastCheck{
+							StartTime: now.Add(-2 * time.Minute),
+							EndTime:   now.Add(-90 * time.Second),
+							Succeeded: true,
+						}, nil)
+					})
+
+					It("returns true", func() {
+						Expect(run).To(BeTrue())
+					})
+				})
+
+				Context("when the last check failed within the interval", func() {
+					BeforeEach(func() {
+						fakeResourceConfigScope.LastCheckReturns(db.LastCheck{
+							StartTime: now.Add(-40 * time.Second),
+							EndTime:   now.Add(-30 * time.Second),
+							Succeeded: false,
+						}, nil)
+					})
+
+					It("returns true", func() {
+						Expect(run).To(BeTrue())
+					})
+				})
+			})
+		})
+	})
+
+	Describe("Scheduled", func() {
+		Context("when running for a resource", func() {
+			BeforeEach(func() {
+				plan.Check.Resource = "some-resource"
+			})
+
+			It("returns true", func() {
+				Expect(delegate.Scheduled()).To(BeTrue())
+			})
+
+			Context("when the build is manually triggered", func() {
+				BeforeEach(func() {
+					fakeBuild.IsManuallyTriggeredReturns(true)
+				})
+
+				It("returns false", func() {
+					Expect(delegate.Scheduled()).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when not running for a resource", func() {
+			It("returns false", func() {
+				Expect(delegate.Scheduled()).To(BeFalse())
 			})
 		})
 	})
@@ -0,0 +1,48 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/concourse/concourse/atc/metric"
+)
+
+// NewCheckLimiter bounds how many checks this ATC runs at once. Checks over
+// the limit queue up until a running check finishes, and are counted as
+// throttled while they wait. A limit of 0 means checks are not limited.
+func NewCheckLimiter(limit int) CheckLimiter {
+	if limit <= 0 {
+		return unlimitedChecks{}
+	}
+
+	return checkLimiter(make(chan struct{}, limit))
+}
+
+type checkLimiter chan struct{}
+
+func (slots checkLimiter) Acquire(ctx context.Context) (func(), error) {
+	select {
+	case slots <- struct{}{}:
+		return slots.release, nil
+	default:
+	}
+
+	metric.Metrics.ChecksThrottled.Inc()
+	defer metric.Metrics.ChecksThrottled.Dec()
+
+	select {
+	case slots <- struct{}{}:
+		return slots.release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (slots checkLimiter) release() {
+	<-slots
+}
+
+type unlimitedChecks struct{}
+
+func (unlimitedChecks) Acquire(context.Context) (func(), error) {
+	return func() {}, nil
+}
@@ -0,0 +1,68 @@
+package engine_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/metric"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckLimiter", func() {
+	var limiter engine.CheckLimiter
+
+	BeforeEach(func() {
+		limiter = engine.NewCheckLimiter(1)
+	})
+
+	It("queues checks until a running check finishes", func() {
+		release, err := limiter.Acquire(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+
+		acquired := make(chan func())
+		go func() {
+			defer GinkgoRecover()
+
+			release, err := limiter.Acquire(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+
+			acquired <- release
+		}()
+
+		Consistently(acquired, 100*time.Millisecond).ShouldNot(Receive())
+		Eventually(metric.Metrics.ChecksThrottled.Max).Should(Equal(float64(1)))
+
+		release()
+
+		var queuedRelease func()
+		Eventually(acquired).Should(Receive(&queuedRelease))
+		queuedRelease()
+	})
+
+	It("stops waiting when the context is done", func() {
+		release, err := limiter.Acquire(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		defer release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err = limiter.Acquire(ctx)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+
+	Context("when there is no limit", func() {
+		BeforeEach(func() {
+			limiter = engine.NewCheckLimiter(0)
+		})
+
+		It("does not limit checks", func() {
+			for i := 0; i < 10; i++ {
+				_, err := limiter.Acquire(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	})
+})
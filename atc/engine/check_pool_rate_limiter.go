@@ -0,0 +1,30 @@
+package engine
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+)
+
+// NewCheckPoolRateLimiter gives each check pool its own token bucket, filled
+// at the pool's rate in checks per second, which is shared by every resource
+// in the pool. Checks in a pool without a configured rate are not limited.
+func NewCheckPoolRateLimiter(rates map[string]float64) CheckPoolRateLimiter {
+	limiters := map[string]*rate.Limiter{}
+	for pool, checksPerSecond := range rates {
+		limiters[pool] = rate.NewLimiter(rate.Limit(checksPerSecond), 1)
+	}
+
+	return checkPoolRateLimiter(limiters)
+}
+
+type checkPoolRateLimiter map[string]*rate.Limiter
+
+func (limiters checkPoolRateLimiter) Wait(ctx context.Context, pool string) error {
+	limiter, found := limiters[pool]
+	if !found {
+		return nil
+	}
+
+	return limiter.Wait(ctx)
+}
@@ -0,0 +1,35 @@
+package engine_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/concourse/concourse/atc/engine"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckPoolRateLimiter", func() {
+	var limiter engine.CheckPoolRateLimiter
+
+	BeforeEach(func() {
+		limiter = engine.NewCheckPoolRateLimiter(map[string]float64{
+			"some-pool": 0.1,
+		})
+	})
+
+	It("shares the pool's rate between all of its checks", func() {
+		Expect(limiter.Wait(context.Background(), "some-pool")).To(Succeed())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		Expect(limiter.Wait(ctx, "some-pool")).ToNot(Succeed())
+	})
+
+	It("does not limit pools without a configured rate", func() {
+		for i := 0; i < 10; i++ {
+			Expect(limiter.Wait(context.Background(), "other-pool")).To(Succeed())
+		}
+	})
+})
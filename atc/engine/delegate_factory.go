@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"context"
+
 	"code.cloudfoundry.org/clock"
 
 	"github.com/concourse/concourse/atc"
@@ -12,35 +14,39 @@ import (
 )
 
 type DelegateFactory struct {
+	ctx             context.Context
 	build           db.Build
 	plan            atc.Plan
 	rateLimiter     RateLimiter
+	poolLimiter     CheckPoolRateLimiter
+	checkLimiter    CheckLimiter
 	policyChecker   policy.Checker
 	artifactSourcer worker.ArtifactSourcer
+	outputLimiter   *OutputRateLimiter
 	dbWorkerFactory db.WorkerFactory
 	lockFactory     lock.LockFactory
 }
 
 func (delegate DelegateFactory) GetDelegate(state exec.RunState) exec.GetDelegate {
-	return NewGetDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker, delegate.artifactSourcer)
+	return NewGetDelegate(delegate.ctx, delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker, delegate.artifactSourcer, delegate.outputLimiter)
 }
 
 func (delegate DelegateFactory) PutDelegate(state exec.RunState) exec.PutDelegate {
-	return NewPutDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker, delegate.artifactSourcer)
+	return NewPutDelegate(delegate.ctx, delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker, delegate.artifactSourcer, delegate.outputLimiter)
 }
 
 func (delegate DelegateFactory) TaskDelegate(state exec.RunState) exec.TaskDelegate {
-	return NewTaskDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker, delegate.artifactSourcer, delegate.dbWorkerFactory, delegate.lockFactory)
+	return NewTaskDelegate(delegate.ctx, delegate.build, delegate.plan.ID, delegate.plan.Task.Name, state, clock.NewClock(), delegate.policyChecker, delegate.artifactSourcer, delegate.outputLimiter, delegate.dbWorkerFactory, delegate.lockFactory)
 }
 
 func (delegate DelegateFactory) CheckDelegate(state exec.RunState) exec.CheckDelegate {
-	return NewCheckDelegate(delegate.build, delegate.plan, state, clock.NewClock(), delegate.rateLimiter, delegate.policyChecker, delegate.artifactSourcer)
+	return NewCheckDelegate(delegate.ctx, delegate.build, delegate.plan, state, clock.NewClock(), delegate.rateLimiter, delegate.poolLimiter, delegate.checkLimiter, delegate.policyChecker, delegate.artifactSourcer, delegate.outputLimiter)
 }
 
 func (delegate DelegateFactory) BuildStepDelegate(state exec.RunState) exec.BuildStepDelegate {
-	return NewBuildStepDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker, delegate.artifactSourcer)
+	return NewBuildStepDelegate(delegate.ctx, delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker, delegate.artifactSourcer, delegate.outputLimiter)
 }
 
 func (delegate DelegateFactory) SetPipelineStepDelegate(state exec.RunState) exec.SetPipelineStepDelegate {
-	return NewSetPipelineStepDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock())
+	return NewSetPipelineStepDelegate(delegate.ctx, delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.outputLimiter)
 }
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sync"
@@ -14,9 +15,11 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/idtoken"
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/util"
 	"github.com/concourse/concourse/tracing"
+	"github.com/concourse/concourse/vars"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -37,6 +40,7 @@ func NewEngine(
 	stepperFactory StepperFactory,
 	secrets creds.Secrets,
 	varSourcePool creds.VarSourcePool,
+	idTokenIssuer *idtoken.Issuer,
 ) Engine {
 	return &engine{
 		stepperFactory: stepperFactory,
@@ -46,6 +50,7 @@ func NewEngine(
 
 		globalSecrets: secrets,
 		varSourcePool: varSourcePool,
+		idTokenIssuer: idTokenIssuer,
 	}
 }
 
@@ -57,6 +62,7 @@ type engine struct {
 
 	globalSecrets creds.Secrets
 	varSourcePool creds.VarSourcePool
+	idTokenIssuer *idtoken.Issuer
 }
 
 func (engine *engine) Drain(ctx context.Context) {
@@ -78,6 +84,7 @@ func (engine *engine) NewBuild(build db.Build) Runnable {
 		engine.stepperFactory,
 		engine.globalSecrets,
 		engine.varSourcePool,
+		engine.idTokenIssuer,
 		engine.release,
 		engine.trackedStates,
 		engine.waitGroup,
@@ -89,6 +96,7 @@ func NewBuild(
 	builder StepperFactory,
 	globalSecrets creds.Secrets,
 	varSourcePool creds.VarSourcePool,
+	idTokenIssuer *idtoken.Issuer,
 	release chan bool,
 	trackedStates *sync.Map,
 	waitGroup *sync.WaitGroup,
@@ -99,6 +107,7 @@ func NewBuild(
 
 		globalSecrets: globalSecrets,
 		varSourcePool: varSourcePool,
+		idTokenIssuer: idTokenIssuer,
 
 		release:       release,
 		trackedStates: trackedStates,
@@ -112,6 +121,7 @@ type engineBuild struct {
 
 	globalSecrets creds.Secrets
 	varSourcePool creds.VarSourcePool
+	idTokenIssuer *idtoken.Issuer
 
 	release       chan bool
 	trackedStates *sync.Map
@@ -124,6 +134,14 @@ func (b *engineBuild) Run(ctx context.Context) {
 
 	logger := lagerctx.FromContext(ctx).WithData(b.build.LagerData())
 
+	// once draining, leave builds to the other ATCs rather than picking up
+	// the ones they've handed over. this is checked once counted in the wait
+	// group, so that a drain either waits for the build or is seen by it
+	if b.draining() {
+		logger.Debug("not-tracking-while-draining")
+		return
+	}
+
 	lock, acquired, err := b.build.AcquireTrackingLock(logger, time.Minute)
 	if err != nil {
 		logger.Error("failed-to-get-lock", err)
@@ -164,14 +182,14 @@ func (b *engineBuild) Run(ctx context.Context) {
 	ctx, span := tracing.StartSpanFollowing(ctx, b.build, "build", b.build.TracingAttrs())
 	defer span.End()
 
-	stepper, err := b.builder.StepperForBuild(b.build)
+	stepper, err := b.builder.StepperForBuild(ctx, b.build)
 	if err != nil {
 		logger.Error("failed-to-construct-build-stepper", err)
 
 		// Fails the build if BuildStep returned an error because such unrecoverable
 		// errors will cause a build to never start to run.
 		b.buildStepErrored(logger, err.Error())
-		b.finish(logger.Session("finish"), err, false)
+		b.finish(logger.Session("finish"), nil, err, false)
 
 		return
 	}
@@ -188,7 +206,7 @@ func (b *engineBuild) Run(ctx context.Context) {
 		// Fails the build if fetching the pipeline variables fails, as these errors
 		// are unrecoverable - e.g. if pipeline var_sources is wrong
 		b.buildStepErrored(logger, err.Error())
-		b.finish(logger.Session("finish"), err, false)
+		b.finish(logger.Session("finish"), nil, err, false)
 
 		return
 	}
@@ -199,12 +217,50 @@ func (b *engineBuild) Run(ctx context.Context) {
 	noleak := make(chan bool)
 	defer close(noleak)
 
+	// left nil, and so never ready, unless the build can be superseded
+	var superseded <-chan struct{}
+
+	supersededBy, supersededNotifier := b.supersededNotifier(logger)
+	if supersededNotifier != nil {
+		defer supersededNotifier.Close()
+		superseded = supersededNotifier.Notify()
+	}
+
+	// left nil, and so never ready, unless the build's pipeline limits how
+	// long its builds may run for
+	var timedOut <-chan time.Time
+
+	maxBuildDuration := b.maxBuildDuration(logger)
+	if maxBuildDuration > 0 {
+		remaining := maxBuildDuration
+		if !b.build.StartTime().IsZero() {
+			remaining -= time.Since(b.build.StartTime())
+		}
+
+		timer := time.NewTimer(remaining)
+		defer timer.Stop()
+		timedOut = timer.C
+	}
+
 	go func() {
-		select {
-		case <-noleak:
-		case <-notifier.Notify():
-			logger.Info("aborting")
-			cancel()
+		for {
+			select {
+			case <-noleak:
+				return
+			case <-notifier.Notify():
+				logger.Info("aborting")
+				cancel()
+				return
+			case <-superseded:
+				if b.abortIfSuperseded(logger, supersededBy) {
+					cancel()
+					return
+				}
+			case <-timedOut:
+				b.abortTimedOut(logger, maxBuildDuration)
+				cancel()
+				return
+			}
 		}
 	}()
 
@@ -234,7 +290,141 @@ func (b *engineBuild) Run(ctx context.Context) {
 			return
 		}
 
-		b.finish(logger.Session("finish"), runErr, succeeded)
+		// steps erroring while the ATC shuts down are likely to be erroring
+		// because of it, so hand the build over rather than error it
+		if runErr != nil && b.draining() {
+			logger.Info("releasing-errored", lager.Data{"error": runErr.Error()})
+			return
+		}
+
+		b.finish(logger.Session("finish"), state, runErr, succeeded)
+	}
+}
+
+// draining returns whether the engine is draining, in which case builds are
+// released for the other ATCs to take over.
+func (b *engineBuild) draining() bool {
+	select {
+	case <-b.release:
+		return true
+	default:
+		return false
+	}
+}
+
+// supersededNotifier returns the resource whose new versions supersede the
+// build along with a notifier for them, or a nil notifier if the build's job
+// is not superseded by any resource. Reruns deliberately run with older
+// versions and so are never superseded.
+func (b *engineBuild) supersededNotifier(logger lager.Logger) (string, db.Notifier) {
+	if b.build.JobID() == 0 || b.build.RerunOf() != 0 {
+		return "", nil
+	}
+
+	pipeline, found, err := b.build.Pipeline()
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		return "", nil
+	}
+
+	if !found {
+		return "", nil
+	}
+
+	job, found, err := pipeline.Job(b.build.JobName())
+	if err != nil {
+		logger.Error("failed-to-find-job", err)
+		return "", nil
+	}
+
+	if !found {
+		return "", nil
+	}
+
+	config, err := job.Config()
+	if err != nil {
+		logger.Error("failed-to-get-job-config", err)
+		return "", nil
+	}
+
+	if config.SupersededBy == "" {
+		return "", nil
+	}
+
+	notifier, err := b.build.SupersededNotifier(config.SupersededBy)
+	if err != nil {
+		logger.Error("failed-to-listen-for-new-versions", err, lager.Data{"resource": config.SupersededBy})
+		return "", nil
+	}
+
+	return config.SupersededBy, notifier
+}
+
+// abortIfSuperseded aborts the build if the resource has a version newer than
+// the one the build is running with. It returns whether the build was aborted.
+func (b *engineBuild) abortIfSuperseded(logger lager.Logger, resourceName string) bool {
+	version, found, err := b.build.SupersedingVersion(resourceName)
+	if err != nil {
+		logger.Error("failed-to-find-superseding-version", err)
+		return false
+	}
+
+	if !found {
+		return false
+	}
+
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		logger.Error("failed-to-marshal-version", err)
+		return false
+	}
+
+	logger.Info("superseded", lager.Data{"resource": resourceName, "version": version})
+
+	err = b.build.MarkAsAborted("", fmt.Sprintf("superseded by version %s", versionJSON))
+	if err != nil {
+		logger.Error("failed-to-mark-build-as-aborted", err)
+	}
+
+	return true
+}
+
+// maxBuildDuration returns how long the build may run for according to the
+// max_build_duration of its pipeline, or zero if there is no limit. Only job
+// builds are limited.
+func (b *engineBuild) maxBuildDuration(logger lager.Logger) time.Duration {
+	if b.build.JobID() == 0 {
+		return 0
+	}
+
+	pipeline, found, err := b.build.Pipeline()
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		return 0
+	}
+
+	if !found || pipeline.MaxBuildDuration() == "" {
+		return 0
+	}
+
+	duration, err := time.ParseDuration(pipeline.MaxBuildDuration())
+	if err != nil {
+		logger.Error("failed-to-parse-max-build-duration", err)
+		return 0
+	}
+
+	return duration
+}
+
+// abortTimedOut aborts the build for having run longer than its pipeline's
+// max_build_duration. Unlike a user abort it isn't aborted by anyone, and the
+// reason says why it was aborted.
+func (b *engineBuild) abortTimedOut(logger lager.Logger, maxBuildDuration time.Duration) {
+	logger.Info("exceeded-max-build-duration", lager.Data{"max-build-duration": maxBuildDuration.String()})
+
+	err := b.build.MarkAsAborted("", fmt.Sprintf("timed out after exceeding max_build_duration of %s", maxBuildDuration))
+	if err != nil {
+		logger.Error("failed-to-mark-build-as-aborted", err)
 	}
 }
 
@@ -251,31 +441,135 @@ func (b *engineBuild) buildStepErrored(logger lager.Logger, message string) {
 	}
 }
 
-func (b *engineBuild) finish(logger lager.Logger, err error, succeeded bool) {
+// finish saves the status of the build. The state is nil if the build never
+// got to run, in which case no credentials can have made it into its inputs.
+func (b *engineBuild) finish(logger lager.Logger, state exec.RunState, err error, succeeded bool) {
+	var status atc.BuildStatus
 	if errors.Is(err, context.Canceled) {
-		b.saveStatus(logger, atc.StatusAborted)
+		status = atc.StatusAborted
 		logger.Info("aborted")
 
 	} else if err != nil {
-		b.saveStatus(logger, atc.StatusErrored)
+		status = atc.StatusErrored
 		logger.Info("errored", lager.Data{"error": err.Error()})
 
 	} else if succeeded {
-		b.saveStatus(logger, atc.StatusSucceeded)
+		status = atc.StatusSucceeded
 		logger.Info("succeeded")
 
 	} else {
-		b.saveStatus(logger, atc.StatusFailed)
+		status = atc.StatusFailed
 		logger.Info("failed")
 	}
+
+	webhooks, payload := b.notifications(logger, state, status)
+
+	b.saveStatus(logger, status, webhooks, payload)
 }
 
-func (b *engineBuild) saveStatus(logger lager.Logger, status atc.BuildStatus) {
-	if err := b.build.Finish(db.BuildStatus(status)); err != nil {
+// saveStatus finishes the build, queueing its notifications in the same
+// transaction so that they're queued if and only if the build is finished.
+func (b *engineBuild) saveStatus(logger lager.Logger, status atc.BuildStatus, webhooks []string, payload json.RawMessage) {
+	var err error
+	if len(webhooks) == 0 {
+		err = b.build.Finish(db.BuildStatus(status))
+	} else {
+		err = b.build.FinishWithWebhookNotifications(db.BuildStatus(status), webhooks, payload)
+	}
+
+	if err != nil {
 		logger.Error("failed-to-finish-build", err)
 	}
 }
 
+// notifications returns the webhooks of the build's pipeline to notify of the
+// build finishing with the given status, and the payload to send them.
+func (b *engineBuild) notifications(logger lager.Logger, state exec.RunState, status atc.BuildStatus) ([]string, json.RawMessage) {
+	if b.build.JobID() == 0 {
+		return nil, nil
+	}
+
+	pipeline, found, err := b.build.Pipeline()
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		return nil, nil
+	}
+
+	if !found || len(pipeline.Webhooks()) == 0 {
+		return nil, nil
+	}
+
+	previousStatus, _, err := b.build.PreviousStatus()
+	if err != nil {
+		logger.Error("failed-to-get-previous-build-status", err)
+		return nil, nil
+	}
+
+	var webhooks []string
+	for _, webhook := range pipeline.Webhooks() {
+		if webhook.NotifiesOf(status, atc.BuildStatus(previousStatus)) {
+			webhooks = append(webhooks, webhook.Name)
+		}
+	}
+
+	if len(webhooks) == 0 {
+		return nil, nil
+	}
+
+	inputs, _, err := b.build.Resources()
+	if err != nil {
+		logger.Error("failed-to-get-build-resources", err)
+		return nil, nil
+	}
+
+	redact := func(value string) string {
+		if state == nil {
+			return value
+		}
+
+		it := &credVarsIterator{line: value}
+		state.IterateInterpolatedCreds(it)
+		return it.line
+	}
+
+	notification := atc.BuildNotification{
+		BuildID:              b.build.ID(),
+		BuildName:            b.build.Name(),
+		TeamName:             b.build.TeamName(),
+		PipelineName:         b.build.PipelineName(),
+		PipelineInstanceVars: b.build.PipelineInstanceVars(),
+		JobName:              b.build.JobName(),
+		Status:               status,
+		PreviousStatus:       atc.BuildStatus(previousStatus),
+		EndTime:              time.Now().Unix(),
+		Inputs:               []atc.BuildNotificationInput{},
+	}
+
+	if !b.build.StartTime().IsZero() {
+		notification.StartTime = b.build.StartTime().Unix()
+	}
+
+	for _, input := range inputs {
+		version := atc.Version{}
+		for k, v := range input.Version {
+			version[k] = redact(v)
+		}
+
+		notification.Inputs = append(notification.Inputs, atc.BuildNotificationInput{
+			Name:    input.Name,
+			Version: version,
+		})
+	}
+
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		logger.Error("failed-to-marshal-notification", err)
+		return nil, nil
+	}
+
+	return webhooks, payload
+}
+
 func (b *engineBuild) trackStarted(logger lager.Logger) {
 	if b.build.Name() != db.CheckBuildName {
 		metric.BuildStarted{
@@ -305,6 +599,8 @@ func (b *engineBuild) trackFinished(logger lager.Logger) {
 			metric.BuildFinished{
 				Build: b.build,
 			}.Emit(logger)
+
+			b.trackTimeToGreen(logger)
 		} else {
 			metric.CheckBuildFinished{
 				Build: b.build,
@@ -313,6 +609,29 @@ func (b *engineBuild) trackFinished(logger lager.Logger) {
 	}
 }
 
+// trackTimeToGreen emits how long the build's job took to recover if the build
+// succeeded after the job had been failing.
+func (b *engineBuild) trackTimeToGreen(logger lager.Logger) {
+	if b.build.JobID() == 0 || b.build.Status() != db.BuildStatusSucceeded {
+		return
+	}
+
+	streakStart, found, err := b.build.FailingStreakStart()
+	if err != nil {
+		logger.Error("failed-to-find-failing-streak", err)
+		return
+	}
+
+	if !found {
+		return
+	}
+
+	metric.JobTimeToGreen{
+		Build:    b.build,
+		Duration: b.build.EndTime().Sub(streakStart),
+	}.Emit(logger)
+}
+
 func (b *engineBuild) runState(logger lager.Logger, stepper exec.Stepper) (exec.RunState, error) {
 	id := fmt.Sprintf("build:%v", b.build.ID())
 	existingState, ok := b.trackedStates.Load(id)
@@ -323,6 +642,10 @@ func (b *engineBuild) runState(logger lager.Logger, stepper exec.Stepper) (exec.
 	if err != nil {
 		return nil, err
 	}
+
+	if b.idTokenIssuer != nil {
+		credVars = vars.NewMultiVars([]vars.Variables{b.idTokenIssuer.Variables(b.build), credVars})
+	}
 	state, _ := b.trackedStates.LoadOrStore(id, exec.NewRunState(stepper, credVars, atc.EnableRedactSecrets))
 	return state.(exec.RunState), nil
 }
@@ -2,11 +2,15 @@ package engine_test
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sync"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"code.cloudfoundry.org/lager/lagertest"
@@ -20,6 +24,8 @@ import (
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/idtoken"
+	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
 
 	. "github.com/onsi/ginkgo"
@@ -52,7 +58,7 @@ var _ = Describe("Engine", func() {
 		)
 
 		BeforeEach(func() {
-			engine = NewEngine(fakeStepperFactory, fakeGlobalCreds, fakeVarSourcePool)
+			engine = NewEngine(fakeStepperFactory, fakeGlobalCreds, fakeVarSourcePool, nil)
 		})
 
 		JustBeforeEach(func() {
@@ -64,6 +70,61 @@ var _ = Describe("Engine", func() {
 		})
 	})
 
+	Describe("Drain", func() {
+		var engine Engine
+
+		BeforeEach(func() {
+			engine = NewEngine(fakeStepperFactory, fakeGlobalCreds, fakeVarSourcePool, nil)
+		})
+
+		Context("when a build is entering Run as the engine drains", func() {
+			var (
+				ran     chan struct{}
+				drained chan struct{}
+			)
+
+			BeforeEach(func() {
+				ran = make(chan struct{})
+				drained = make(chan struct{})
+
+				entering := make(chan struct{})
+				proceed := make(chan struct{})
+
+				fakeBuild.LagerDataStub = func() lager.Data {
+					close(entering)
+					<-proceed
+					return lager.Data{}
+				}
+
+				go func() {
+					defer close(ran)
+					engine.NewBuild(fakeBuild).Run(lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("build")))
+				}()
+
+				<-entering
+
+				go func() {
+					defer close(drained)
+					engine.Drain(lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("drain")))
+				}()
+
+				Consistently(drained).ShouldNot(BeClosed())
+
+				close(proceed)
+			})
+
+			It("waits for the build to return", func() {
+				Eventually(ran).Should(BeClosed())
+				Eventually(drained).Should(BeClosed())
+			})
+
+			It("does not track the build", func() {
+				Eventually(ran).Should(BeClosed())
+				Expect(fakeBuild.AcquireTrackingLockCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("Build", func() {
 		var (
 			build     Runnable
@@ -82,6 +143,7 @@ var _ = Describe("Engine", func() {
 				fakeStepperFactory,
 				fakeGlobalCreds,
 				fakeVarSourcePool,
+				nil,
 				release,
 				trackedStates,
 				waitGroup,
@@ -103,6 +165,16 @@ var _ = Describe("Engine", func() {
 				build.Run(lagerctx.NewContext(ctx, logger))
 			})
 
+			Context("when the engine is draining", func() {
+				BeforeEach(func() {
+					close(release)
+				})
+
+				It("does not track the build", func() {
+					Expect(fakeBuild.AcquireTrackingLockCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when acquiring the lock succeeds", func() {
 				var fakeLock *lockfakes.FakeLock
 
@@ -190,6 +262,41 @@ var _ = Describe("Engine", func() {
 									Expect(val).To(Equal("bar"))
 								})
 
+								Context("when identity tokens are issued to builds", func() {
+									BeforeEach(func() {
+										key, err := rsa.GenerateKey(rand.Reader, 2048)
+										Expect(err).ToNot(HaveOccurred())
+
+										issuer, err := idtoken.NewIssuer("https://concourse.example.com", key, idtoken.Config{TTL: time.Minute}, clock.NewClock())
+										Expect(err).ToNot(HaveOccurred())
+
+										build = NewBuild(
+											fakeBuild,
+											fakeStepperFactory,
+											fakeGlobalCreds,
+											fakeVarSourcePool,
+											issuer,
+											release,
+											new(sync.Map),
+											waitGroup,
+										)
+									})
+
+									It("runs the step with an identity token var along with the build variables", func() {
+										state := <-invokedState
+
+										token, found, err := state.Get(vars.Reference{Source: "idtoken", Path: "token"})
+										Expect(err).ToNot(HaveOccurred())
+										Expect(found).To(BeTrue())
+										Expect(token).ToNot(BeEmpty())
+
+										val, found, err := state.Get(vars.Reference{Path: "foo"})
+										Expect(err).ToNot(HaveOccurred())
+										Expect(found).To(BeTrue())
+										Expect(val).To(Equal("bar"))
+									})
+								})
+
 								Context("when the build is released", func() {
 									BeforeEach(func() {
 										readyToRelease := make(chan bool)
@@ -212,6 +319,25 @@ var _ = Describe("Engine", func() {
 									})
 								})
 
+								Context("when the build errors while the engine is draining", func() {
+									BeforeEach(func() {
+										fakeStep.RunStub = func(context.Context, exec.RunState) (bool, error) {
+											close(release)
+											return false, errors.New("connection to worker lost")
+										}
+									})
+
+									It("does not error the build", func() {
+										waitGroup.Wait()
+										Expect(fakeBuild.FinishCallCount()).To(Equal(0))
+									})
+
+									It("releases the lock", func() {
+										waitGroup.Wait()
+										Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+									})
+								})
+
 								Context("when the build is aborted", func() {
 									BeforeEach(func() {
 										readyToAbort := make(chan bool)
@@ -245,6 +371,38 @@ var _ = Describe("Engine", func() {
 										Expect(fakeBuild.FinishCallCount()).To(Equal(1))
 										Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusSucceeded))
 									})
+
+									Context("when the build is of a job", func() {
+										BeforeEach(func() {
+											fakeBuild.JobIDReturns(1)
+											fakeBuild.TracingAttrsReturns(tracing.Attrs{})
+											fakeBuild.IsRunningStub = func() bool {
+												return fakeBuild.FinishCallCount() == 0
+											}
+										})
+
+										Context("when it succeeded", func() {
+											BeforeEach(func() {
+												fakeBuild.StatusReturns(db.BuildStatusSucceeded)
+											})
+
+											It("looks for the job's failing streak to track its time to green", func() {
+												waitGroup.Wait()
+												Expect(fakeBuild.FailingStreakStartCallCount()).To(Equal(1))
+											})
+										})
+
+										Context("when it did not succeed", func() {
+											BeforeEach(func() {
+												fakeBuild.StatusReturns(db.BuildStatusFailed)
+											})
+
+											It("does not track the job's time to green", func() {
+												waitGroup.Wait()
+												Expect(fakeBuild.FailingStreakStartCallCount()).To(BeZero())
+											})
+										})
+									})
 								})
 
 								Context("when the build finishes woefully", func() {
@@ -308,6 +466,262 @@ var _ = Describe("Engine", func() {
 										Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusErrored))
 									})
 								})
+
+								Context("when the build's pipeline has webhooks", func() {
+									BeforeEach(func() {
+										atc.EnableRedactSecrets = true
+
+										fakePipeline := new(dbfakes.FakePipeline)
+										fakePipeline.WebhooksReturns(atc.WebhookConfigs{
+											{Name: "some-webhook", URL: "https://example.com/hook"},
+											{Name: "other-webhook", URL: "https://example.com/other-hook"},
+										})
+
+										fakeBuild.JobIDReturns(1)
+										fakeBuild.JobNameReturns("some-job")
+										fakeBuild.NameReturns("42")
+										fakeBuild.PipelineReturns(fakePipeline, true, nil)
+										fakeBuild.ResourcesReturns([]db.BuildInput{
+											{Name: "some-input", Version: atc.Version{"ref": "v1", "token": "bar"}},
+										}, nil, nil)
+
+										fakeStep.RunStub = func(ctx context.Context, state exec.RunState) (bool, error) {
+											_, _, err := state.Get(vars.Reference{Path: "foo"})
+											return false, err
+										}
+									})
+
+									AfterEach(func() {
+										atc.EnableRedactSecrets = false
+									})
+
+									It("finishes the build queueing a notification for each webhook", func() {
+										waitGroup.Wait()
+										Expect(fakeBuild.FinishWithWebhookNotificationsCallCount()).To(Equal(1))
+										Expect(fakeBuild.FinishCallCount()).To(BeZero())
+
+										status, webhooks, payload := fakeBuild.FinishWithWebhookNotificationsArgsForCall(0)
+										Expect(status).To(Equal(db.BuildStatusFailed))
+										Expect(webhooks).To(Equal([]string{"some-webhook", "other-webhook"}))
+
+										var notification atc.BuildNotification
+										Expect(json.Unmarshal(payload, &notification)).To(Succeed())
+										Expect(notification.BuildID).To(Equal(128))
+										Expect(notification.BuildName).To(Equal("42"))
+										Expect(notification.JobName).To(Equal("some-job"))
+										Expect(notification.Status).To(Equal(atc.StatusFailed))
+										Expect(notification.EndTime).ToNot(BeZero())
+										Expect(notification.Inputs).To(Equal([]atc.BuildNotificationInput{
+											{Name: "some-input", Version: atc.Version{"ref": "v1", "token": "((redacted))"}},
+										}))
+									})
+
+									Context("when a webhook only notifies on status changes", func() {
+										BeforeEach(func() {
+											fakePipeline := new(dbfakes.FakePipeline)
+											fakePipeline.WebhooksReturns(atc.WebhookConfigs{
+												{Name: "some-webhook", URL: "https://example.com/hook"},
+												{Name: "other-webhook", URL: "https://example.com/other-hook", Notify: atc.WebhookNotifyOnChange},
+											})
+
+											fakeBuild.PipelineReturns(fakePipeline, true, nil)
+										})
+
+										Context("when the previous build had the same status", func() {
+											BeforeEach(func() {
+												fakeBuild.PreviousStatusReturns(db.BuildStatusFailed, true, nil)
+											})
+
+											It("only queues a notification for the other webhooks", func() {
+												waitGroup.Wait()
+												Expect(fakeBuild.FinishWithWebhookNotificationsCallCount()).To(Equal(1))
+
+												_, webhooks, payload := fakeBuild.FinishWithWebhookNotificationsArgsForCall(0)
+												Expect(webhooks).To(Equal([]string{"some-webhook"}))
+
+												var notification atc.BuildNotification
+												Expect(json.Unmarshal(payload, &notification)).To(Succeed())
+												Expect(notification.PreviousStatus).To(Equal(atc.StatusFailed))
+											})
+										})
+
+										Context("when the previous build had a different status", func() {
+											BeforeEach(func() {
+												fakeBuild.PreviousStatusReturns(db.BuildStatusSucceeded, true, nil)
+											})
+
+											It("queues a notification for every webhook", func() {
+												waitGroup.Wait()
+												_, webhooks, _ := fakeBuild.FinishWithWebhookNotificationsArgsForCall(0)
+												Expect(webhooks).To(Equal([]string{"some-webhook", "other-webhook"}))
+											})
+										})
+
+										Context("when it is the job's first build", func() {
+											BeforeEach(func() {
+												fakeBuild.PreviousStatusReturns("", false, nil)
+											})
+
+											It("queues a notification for every webhook", func() {
+												waitGroup.Wait()
+												_, webhooks, _ := fakeBuild.FinishWithWebhookNotificationsArgsForCall(0)
+												Expect(webhooks).To(Equal([]string{"some-webhook", "other-webhook"}))
+											})
+										})
+									})
+								})
+
+								Context("when the build's job is superseded by a resource", func() {
+									var (
+										superseded         chan struct{}
+										supersededNotifier *dbfakes.FakeNotifier
+									)
+
+									BeforeEach(func() {
+										fakeJob := new(dbfakes.FakeJob)
+										fakeJob.ConfigReturns(atc.JobConfig{Name: "some-job", SupersededBy: "some-resource"}, nil)
+
+										fakePipeline := new(dbfakes.FakePipeline)
+										fakePipeline.JobReturns(fakeJob, true, nil)
+
+										fakeBuild.JobIDReturns(1)
+										fakeBuild.JobNameReturns("some-job")
+										fakeBuild.PipelineReturns(fakePipeline, true, nil)
+
+										superseded = make(chan struct{})
+										supersededNotifier = new(dbfakes.FakeNotifier)
+										supersededNotifier.NotifyReturns(superseded)
+										fakeBuild.SupersededNotifierReturns(supersededNotifier, nil)
+
+										fakeStep.RunStub = func(ctx context.Context, state exec.RunState) (bool, error) {
+											superseded <- struct{}{}
+
+											select {
+											case <-ctx.Done():
+												return false, ctx.Err()
+											case <-time.After(time.Second):
+												return true, nil
+											}
+										}
+									})
+
+									It("watches the resource for new versions", func() {
+										waitGroup.Wait()
+										Expect(fakeBuild.SupersededNotifierCallCount()).To(Equal(1))
+										Expect(fakeBuild.SupersededNotifierArgsForCall(0)).To(Equal("some-resource"))
+										Expect(supersededNotifier.CloseCallCount()).To(Equal(1))
+									})
+
+									Context("when a newer version has been found", func() {
+										BeforeEach(func() {
+											fakeBuild.SupersedingVersionReturns(atc.Version{"ref": "v2"}, true, nil)
+										})
+
+										It("aborts the build with the version as the reason", func() {
+											waitGroup.Wait()
+											Expect(fakeBuild.SupersedingVersionArgsForCall(0)).To(Equal("some-resource"))
+											Expect(fakeBuild.MarkAsAbortedCallCount()).To(Equal(1))
+
+											abortedBy, reason := fakeBuild.MarkAsAbortedArgsForCall(0)
+											Expect(abortedBy).To(BeEmpty())
+											Expect(reason).To(Equal(`superseded by version {"ref":"v2"}`))
+
+											Expect(fakeBuild.FinishCallCount()).To(Equal(1))
+											Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusAborted))
+										})
+									})
+
+									Context("when no newer version has been found", func() {
+										BeforeEach(func() {
+											fakeBuild.SupersedingVersionReturns(nil, false, nil)
+										})
+
+										It("keeps running the build", func() {
+											waitGroup.Wait()
+											Expect(fakeBuild.MarkAsAbortedCallCount()).To(BeZero())
+											Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusSucceeded))
+										})
+									})
+
+									Context("when the build is a rerun", func() {
+										BeforeEach(func() {
+											fakeBuild.RerunOfReturns(41)
+
+											fakeStep.RunStub = func(context.Context, exec.RunState) (bool, error) {
+												return true, nil
+											}
+										})
+
+										It("does not watch the resource", func() {
+											waitGroup.Wait()
+											Expect(fakeBuild.SupersededNotifierCallCount()).To(BeZero())
+										})
+									})
+								})
+
+								Context("when the build's pipeline has a max_build_duration", func() {
+									var fakePipeline *dbfakes.FakePipeline
+
+									BeforeEach(func() {
+										fakePipeline = new(dbfakes.FakePipeline)
+										fakePipeline.MaxBuildDurationReturns("1h")
+
+										fakeBuild.JobIDReturns(1)
+										fakeBuild.JobNameReturns("some-job")
+										fakeBuild.PipelineReturns(fakePipeline, true, nil)
+
+										fakeStep.RunStub = func(ctx context.Context, state exec.RunState) (bool, error) {
+											select {
+											case <-ctx.Done():
+												return false, ctx.Err()
+											case <-time.After(time.Second):
+												return true, nil
+											}
+										}
+									})
+
+									Context("when the build has run for longer", func() {
+										BeforeEach(func() {
+											fakeBuild.StartTimeReturns(time.Now().Add(-2 * time.Hour))
+										})
+
+										It("aborts the build with a timeout as the reason", func() {
+											waitGroup.Wait()
+											Expect(fakeBuild.MarkAsAbortedCallCount()).To(Equal(1))
+
+											abortedBy, reason := fakeBuild.MarkAsAbortedArgsForCall(0)
+											Expect(abortedBy).To(BeEmpty())
+											Expect(reason).To(Equal("timed out after exceeding max_build_duration of 1h0m0s"))
+
+											Expect(fakeBuild.FinishCallCount()).To(Equal(1))
+											Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusAborted))
+										})
+									})
+
+									Context("when the build has not run for longer", func() {
+										BeforeEach(func() {
+											fakeBuild.StartTimeReturns(time.Now())
+										})
+
+										It("keeps running the build", func() {
+											waitGroup.Wait()
+											Expect(fakeBuild.MarkAsAbortedCallCount()).To(BeZero())
+											Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusSucceeded))
+										})
+									})
+								})
+
+								Context("when the build is a one-off", func() {
+									BeforeEach(func() {
+										fakeBuild.JobIDReturns(0)
+									})
+
+									It("does not queue any notifications", func() {
+										waitGroup.Wait()
+										Expect(fakeBuild.PipelineCallCount()).To(BeZero())
+										Expect(fakeBuild.FinishWithWebhookNotificationsCallCount()).To(BeZero())
+									})
+								})
 							})
 
 							Context("when getting the build vars fails", func() {
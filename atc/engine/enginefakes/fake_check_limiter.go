@@ -0,0 +1,117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package enginefakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/concourse/concourse/atc/engine"
+)
+
+type FakeCheckLimiter struct {
+	AcquireStub        func(context.Context) (func(), error)
+	acquireMutex       sync.RWMutex
+	acquireArgsForCall []struct {
+		arg1 context.Context
+	}
+	acquireReturns struct {
+		result1 func()
+		result2 error
+	}
+	acquireReturnsOnCall map[int]struct {
+		result1 func()
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCheckLimiter) Acquire(arg1 context.Context) (func(), error) {
+	fake.acquireMutex.Lock()
+	ret, specificReturn := fake.acquireReturnsOnCall[len(fake.acquireArgsForCall)]
+	fake.acquireArgsForCall = append(fake.acquireArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.AcquireStub
+	fakeReturns := fake.acquireReturns
+	fake.recordInvocation("Acquire", []interface{}{arg1})
+	fake.acquireMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCheckLimiter) AcquireCallCount() int {
+	fake.acquireMutex.RLock()
+	defer fake.acquireMutex.RUnlock()
+	return len(fake.acquireArgsForCall)
+}
+
+func (fake *FakeCheckLimiter) AcquireCalls(stub func(context.Context) (func(), error)) {
+	fake.acquireMutex.Lock()
+	defer fake.acquireMutex.Unlock()
+	fake.AcquireStub = stub
+}
+
+func (fake *FakeCheckLimiter) AcquireArgsForCall(i int) context.Context {
+	fake.acquireMutex.RLock()
+	defer fake.acquireMutex.RUnlock()
+	argsForCall := fake.acquireArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheckLimiter) AcquireReturns(result1 func(), result2 error) {
+	fake.acquireMutex.Lock()
+	defer fake.acquireMutex.Unlock()
+	fake.AcquireStub = nil
+	fake.acquireReturns = struct {
+		result1 func()
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckLimiter) AcquireReturnsOnCall(i int, result1 func(), result2 error) {
+	fake.acquireMutex.Lock()
+	defer fake.acquireMutex.Unlock()
+	fake.AcquireStub = nil
+	if fake.acquireReturnsOnCall == nil {
+		fake.acquireReturnsOnCall = make(map[int]struct {
+			result1 func()
+			result2 error
+		})
+	}
+	fake.acquireReturnsOnCall[i] = struct {
+		result1 func()
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckLimiter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.acquireMutex.RLock()
+	defer fake.acquireMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCheckLimiter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ engine.CheckLimiter = new(FakeCheckLimiter)
@@ -11,6 +11,20 @@ import (
 )
 
 type FakeCoreStepFactory struct {
+	ApprovalStepStub        func(atc.Plan, exec.StepMetadata, db.Build, engine.DelegateFactory) exec.Step
+	approvalStepMutex       sync.RWMutex
+	approvalStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 db.Build
+		arg4 engine.DelegateFactory
+	}
+	approvalStepReturns struct {
+		result1 exec.Step
+	}
+	approvalStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	ArtifactInputStepStub        func(atc.Plan, db.Build) exec.Step
 	artifactInputStepMutex       sync.RWMutex
 	artifactInputStepArgsForCall []struct {
@@ -35,6 +49,20 @@ type FakeCoreStepFactory struct {
 	artifactOutputStepReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
+	AssertGreenStepStub        func(atc.Plan, exec.StepMetadata, db.Build, engine.DelegateFactory) exec.Step
+	assertGreenStepMutex       sync.RWMutex
+	assertGreenStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 db.Build
+		arg4 engine.DelegateFactory
+	}
+	assertGreenStepReturns struct {
+		result1 exec.Step
+	}
+	assertGreenStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	CheckStepStub        func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, engine.DelegateFactory) exec.Step
 	checkStepMutex       sync.RWMutex
 	checkStepArgsForCall []struct {
@@ -49,6 +77,19 @@ type FakeCoreStepFactory struct {
 	checkStepReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
+	ConsumeArtifactStepStub        func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step
+	consumeArtifactStepMutex       sync.RWMutex
+	consumeArtifactStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}
+	consumeArtifactStepReturns struct {
+		result1 exec.Step
+	}
+	consumeArtifactStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	GetStepStub        func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, engine.DelegateFactory) exec.Step
 	getStepMutex       sync.RWMutex
 	getStepArgsForCall []struct {
@@ -63,12 +104,27 @@ type FakeCoreStepFactory struct {
 	getStepReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
-	LoadVarStepStub        func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step
+	LoadBuildOutputsStepStub        func(atc.Plan, exec.StepMetadata, db.Build, engine.DelegateFactory) exec.Step
+	loadBuildOutputsStepMutex       sync.RWMutex
+	loadBuildOutputsStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 db.Build
+		arg4 engine.DelegateFactory
+	}
+	loadBuildOutputsStepReturns struct {
+		result1 exec.Step
+	}
+	loadBuildOutputsStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
+	LoadVarStepStub        func(atc.Plan, exec.StepMetadata, db.Build, engine.DelegateFactory) exec.Step
 	loadVarStepMutex       sync.RWMutex
 	loadVarStepArgsForCall []struct {
 		arg1 atc.Plan
 		arg2 exec.StepMetadata
-		arg3 engine.DelegateFactory
+		arg3 db.Build
+		arg4 engine.DelegateFactory
 	}
 	loadVarStepReturns struct {
 		result1 exec.Step
@@ -76,6 +132,19 @@ type FakeCoreStepFactory struct {
 	loadVarStepReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
+	PublishArtifactStepStub        func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step
+	publishArtifactStepMutex       sync.RWMutex
+	publishArtifactStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}
+	publishArtifactStepReturns struct {
+		result1 exec.Step
+	}
+	publishArtifactStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	PutStepStub        func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, engine.DelegateFactory) exec.Step
 	putStepMutex       sync.RWMutex
 	putStepArgsForCall []struct {
@@ -121,6 +190,70 @@ type FakeCoreStepFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeCoreStepFactory) ApprovalStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.Build, arg4 engine.DelegateFactory) exec.Step {
+	fake.approvalStepMutex.Lock()
+	ret, specificReturn := fake.approvalStepReturnsOnCall[len(fake.approvalStepArgsForCall)]
+	fake.approvalStepArgsForCall = append(fake.approvalStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 db.Build
+		arg4 engine.DelegateFactory
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ApprovalStepStub
+	fakeReturns := fake.approvalStepReturns
+	fake.recordInvocation("ApprovalStep", []interface{}{arg1, arg2, arg3, arg4})
+	fake.approvalStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepCallCount() int {
+	fake.approvalStepMutex.RLock()
+	defer fake.approvalStepMutex.RUnlock()
+	return len(fake.approvalStepArgsForCall)
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepCalls(stub func(atc.Plan, exec.StepMetadata, db.Build, engine.DelegateFactory) exec.Step) {
+	fake.approvalStepMutex.Lock()
+	defer fake.approvalStepMutex.Unlock()
+	fake.ApprovalStepStub = stub
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, db.Build, engine.DelegateFactory) {
+	fake.approvalStepMutex.RLock()
+	defer fake.approvalStepMutex.RUnlock()
+	argsForCall := fake.approvalStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepReturns(result1 exec.Step) {
+	fake.approvalStepMutex.Lock()
+	defer fake.approvalStepMutex.Unlock()
+	fake.ApprovalStepStub = nil
+	fake.approvalStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.approvalStepMutex.Lock()
+	defer fake.approvalStepMutex.Unlock()
+	fake.ApprovalStepStub = nil
+	if fake.approvalStepReturnsOnCall == nil {
+		fake.approvalStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.approvalStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeCoreStepFactory) ArtifactInputStep(arg1 atc.Plan, arg2 db.Build) exec.Step {
 	fake.artifactInputStepMutex.Lock()
 	ret, specificReturn := fake.artifactInputStepReturnsOnCall[len(fake.artifactInputStepArgsForCall)]
@@ -245,6 +378,70 @@ func (fake *FakeCoreStepFactory) ArtifactOutputStepReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeCoreStepFactory) AssertGreenStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.Build, arg4 engine.DelegateFactory) exec.Step {
+	fake.assertGreenStepMutex.Lock()
+	ret, specificReturn := fake.assertGreenStepReturnsOnCall[len(fake.assertGreenStepArgsForCall)]
+	fake.assertGreenStepArgsForCall = append(fake.assertGreenStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 db.Build
+		arg4 engine.DelegateFactory
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AssertGreenStepStub
+	fakeReturns := fake.assertGreenStepReturns
+	fake.recordInvocation("AssertGreenStep", []interface{}{arg1, arg2, arg3, arg4})
+	fake.assertGreenStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCoreStepFactory) AssertGreenStepCallCount() int {
+	fake.assertGreenStepMutex.RLock()
+	defer fake.assertGreenStepMutex.RUnlock()
+	return len(fake.assertGreenStepArgsForCall)
+}
+
+func (fake *FakeCoreStepFactory) AssertGreenStepCalls(stub func(atc.Plan, exec.StepMetadata, db.Build, engine.DelegateFactory) exec.Step) {
+	fake.assertGreenStepMutex.Lock()
+	defer fake.assertGreenStepMutex.Unlock()
+	fake.AssertGreenStepStub = stub
+}
+
+func (fake *FakeCoreStepFactory) AssertGreenStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, db.Build, engine.DelegateFactory) {
+	fake.assertGreenStepMutex.RLock()
+	defer fake.assertGreenStepMutex.RUnlock()
+	argsForCall := fake.assertGreenStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeCoreStepFactory) AssertGreenStepReturns(result1 exec.Step) {
+	fake.assertGreenStepMutex.Lock()
+	defer fake.assertGreenStepMutex.Unlock()
+	fake.AssertGreenStepStub = nil
+	fake.assertGreenStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) AssertGreenStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.assertGreenStepMutex.Lock()
+	defer fake.assertGreenStepMutex.Unlock()
+	fake.AssertGreenStepStub = nil
+	if fake.assertGreenStepReturnsOnCall == nil {
+		fake.assertGreenStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.assertGreenStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeCoreStepFactory) CheckStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.ContainerMetadata, arg4 engine.DelegateFactory) exec.Step {
 	fake.checkStepMutex.Lock()
 	ret, specificReturn := fake.checkStepReturnsOnCall[len(fake.checkStepArgsForCall)]
@@ -309,6 +506,69 @@ func (fake *FakeCoreStepFactory) CheckStepReturnsOnCall(i int, result1 exec.Step
 	}{result1}
 }
 
+func (fake *FakeCoreStepFactory) ConsumeArtifactStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 engine.DelegateFactory) exec.Step {
+	fake.consumeArtifactStepMutex.Lock()
+	ret, specificReturn := fake.consumeArtifactStepReturnsOnCall[len(fake.consumeArtifactStepArgsForCall)]
+	fake.consumeArtifactStepArgsForCall = append(fake.consumeArtifactStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}{arg1, arg2, arg3})
+	stub := fake.ConsumeArtifactStepStub
+	fakeReturns := fake.consumeArtifactStepReturns
+	fake.recordInvocation("ConsumeArtifactStep", []interface{}{arg1, arg2, arg3})
+	fake.consumeArtifactStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCoreStepFactory) ConsumeArtifactStepCallCount() int {
+	fake.consumeArtifactStepMutex.RLock()
+	defer fake.consumeArtifactStepMutex.RUnlock()
+	return len(fake.consumeArtifactStepArgsForCall)
+}
+
+func (fake *FakeCoreStepFactory) ConsumeArtifactStepCalls(stub func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step) {
+	fake.consumeArtifactStepMutex.Lock()
+	defer fake.consumeArtifactStepMutex.Unlock()
+	fake.ConsumeArtifactStepStub = stub
+}
+
+func (fake *FakeCoreStepFactory) ConsumeArtifactStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, engine.DelegateFactory) {
+	fake.consumeArtifactStepMutex.RLock()
+	defer fake.consumeArtifactStepMutex.RUnlock()
+	argsForCall := fake.consumeArtifactStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCoreStepFactory) ConsumeArtifactStepReturns(result1 exec.Step) {
+	fake.consumeArtifactStepMutex.Lock()
+	defer fake.consumeArtifactStepMutex.Unlock()
+	fake.ConsumeArtifactStepStub = nil
+	fake.consumeArtifactStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) ConsumeArtifactStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.consumeArtifactStepMutex.Lock()
+	defer fake.consumeArtifactStepMutex.Unlock()
+	fake.ConsumeArtifactStepStub = nil
+	if fake.consumeArtifactStepReturnsOnCall == nil {
+		fake.consumeArtifactStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.consumeArtifactStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeCoreStepFactory) GetStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.ContainerMetadata, arg4 engine.DelegateFactory) exec.Step {
 	fake.getStepMutex.Lock()
 	ret, specificReturn := fake.getStepReturnsOnCall[len(fake.getStepArgsForCall)]
@@ -373,20 +633,85 @@ func (fake *FakeCoreStepFactory) GetStepReturnsOnCall(i int, result1 exec.Step)
 	}{result1}
 }
 
-func (fake *FakeCoreStepFactory) LoadVarStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 engine.DelegateFactory) exec.Step {
+func (fake *FakeCoreStepFactory) LoadBuildOutputsStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.Build, arg4 engine.DelegateFactory) exec.Step {
+	fake.loadBuildOutputsStepMutex.Lock()
+	ret, specificReturn := fake.loadBuildOutputsStepReturnsOnCall[len(fake.loadBuildOutputsStepArgsForCall)]
+	fake.loadBuildOutputsStepArgsForCall = append(fake.loadBuildOutputsStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 db.Build
+		arg4 engine.DelegateFactory
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.LoadBuildOutputsStepStub
+	fakeReturns := fake.loadBuildOutputsStepReturns
+	fake.recordInvocation("LoadBuildOutputsStep", []interface{}{arg1, arg2, arg3, arg4})
+	fake.loadBuildOutputsStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCoreStepFactory) LoadBuildOutputsStepCallCount() int {
+	fake.loadBuildOutputsStepMutex.RLock()
+	defer fake.loadBuildOutputsStepMutex.RUnlock()
+	return len(fake.loadBuildOutputsStepArgsForCall)
+}
+
+func (fake *FakeCoreStepFactory) LoadBuildOutputsStepCalls(stub func(atc.Plan, exec.StepMetadata, db.Build, engine.DelegateFactory) exec.Step) {
+	fake.loadBuildOutputsStepMutex.Lock()
+	defer fake.loadBuildOutputsStepMutex.Unlock()
+	fake.LoadBuildOutputsStepStub = stub
+}
+
+func (fake *FakeCoreStepFactory) LoadBuildOutputsStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, db.Build, engine.DelegateFactory) {
+	fake.loadBuildOutputsStepMutex.RLock()
+	defer fake.loadBuildOutputsStepMutex.RUnlock()
+	argsForCall := fake.loadBuildOutputsStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeCoreStepFactory) LoadBuildOutputsStepReturns(result1 exec.Step) {
+	fake.loadBuildOutputsStepMutex.Lock()
+	defer fake.loadBuildOutputsStepMutex.Unlock()
+	fake.LoadBuildOutputsStepStub = nil
+	fake.loadBuildOutputsStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) LoadBuildOutputsStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.loadBuildOutputsStepMutex.Lock()
+	defer fake.loadBuildOutputsStepMutex.Unlock()
+	fake.LoadBuildOutputsStepStub = nil
+	if fake.loadBuildOutputsStepReturnsOnCall == nil {
+		fake.loadBuildOutputsStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.loadBuildOutputsStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) LoadVarStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.Build, arg4 engine.DelegateFactory) exec.Step {
 	fake.loadVarStepMutex.Lock()
 	ret, specificReturn := fake.loadVarStepReturnsOnCall[len(fake.loadVarStepArgsForCall)]
 	fake.loadVarStepArgsForCall = append(fake.loadVarStepArgsForCall, struct {
 		arg1 atc.Plan
 		arg2 exec.StepMetadata
-		arg3 engine.DelegateFactory
-	}{arg1, arg2, arg3})
+		arg3 db.Build
+		arg4 engine.DelegateFactory
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.LoadVarStepStub
 	fakeReturns := fake.loadVarStepReturns
-	fake.recordInvocation("LoadVarStep", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("LoadVarStep", []interface{}{arg1, arg2, arg3, arg4})
 	fake.loadVarStepMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1
@@ -400,17 +725,17 @@ func (fake *FakeCoreStepFactory) LoadVarStepCallCount() int {
 	return len(fake.loadVarStepArgsForCall)
 }
 
-func (fake *FakeCoreStepFactory) LoadVarStepCalls(stub func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step) {
+func (fake *FakeCoreStepFactory) LoadVarStepCalls(stub func(atc.Plan, exec.StepMetadata, db.Build, engine.DelegateFactory) exec.Step) {
 	fake.loadVarStepMutex.Lock()
 	defer fake.loadVarStepMutex.Unlock()
 	fake.LoadVarStepStub = stub
 }
 
-func (fake *FakeCoreStepFactory) LoadVarStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, engine.DelegateFactory) {
+func (fake *FakeCoreStepFactory) LoadVarStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, db.Build, engine.DelegateFactory) {
 	fake.loadVarStepMutex.RLock()
 	defer fake.loadVarStepMutex.RUnlock()
 	argsForCall := fake.loadVarStepArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeCoreStepFactory) LoadVarStepReturns(result1 exec.Step) {
@@ -436,6 +761,69 @@ func (fake *FakeCoreStepFactory) LoadVarStepReturnsOnCall(i int, result1 exec.St
 	}{result1}
 }
 
+func (fake *FakeCoreStepFactory) PublishArtifactStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 engine.DelegateFactory) exec.Step {
+	fake.publishArtifactStepMutex.Lock()
+	ret, specificReturn := fake.publishArtifactStepReturnsOnCall[len(fake.publishArtifactStepArgsForCall)]
+	fake.publishArtifactStepArgsForCall = append(fake.publishArtifactStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}{arg1, arg2, arg3})
+	stub := fake.PublishArtifactStepStub
+	fakeReturns := fake.publishArtifactStepReturns
+	fake.recordInvocation("PublishArtifactStep", []interface{}{arg1, arg2, arg3})
+	fake.publishArtifactStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCoreStepFactory) PublishArtifactStepCallCount() int {
+	fake.publishArtifactStepMutex.RLock()
+	defer fake.publishArtifactStepMutex.RUnlock()
+	return len(fake.publishArtifactStepArgsForCall)
+}
+
+func (fake *FakeCoreStepFactory) PublishArtifactStepCalls(stub func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step) {
+	fake.publishArtifactStepMutex.Lock()
+	defer fake.publishArtifactStepMutex.Unlock()
+	fake.PublishArtifactStepStub = stub
+}
+
+func (fake *FakeCoreStepFactory) PublishArtifactStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, engine.DelegateFactory) {
+	fake.publishArtifactStepMutex.RLock()
+	defer fake.publishArtifactStepMutex.RUnlock()
+	argsForCall := fake.publishArtifactStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCoreStepFactory) PublishArtifactStepReturns(result1 exec.Step) {
+	fake.publishArtifactStepMutex.Lock()
+	defer fake.publishArtifactStepMutex.Unlock()
+	fake.PublishArtifactStepStub = nil
+	fake.publishArtifactStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) PublishArtifactStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.publishArtifactStepMutex.Lock()
+	defer fake.publishArtifactStepMutex.Unlock()
+	fake.PublishArtifactStepStub = nil
+	if fake.publishArtifactStepReturnsOnCall == nil {
+		fake.publishArtifactStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.publishArtifactStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeCoreStepFactory) PutStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.ContainerMetadata, arg4 engine.DelegateFactory) exec.Step {
 	fake.putStepMutex.Lock()
 	ret, specificReturn := fake.putStepReturnsOnCall[len(fake.putStepArgsForCall)]
@@ -630,16 +1018,26 @@ func (fake *FakeCoreStepFactory) TaskStepReturnsOnCall(i int, result1 exec.Step)
 func (fake *FakeCoreStepFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.approvalStepMutex.RLock()
+	defer fake.approvalStepMutex.RUnlock()
 	fake.artifactInputStepMutex.RLock()
 	defer fake.artifactInputStepMutex.RUnlock()
 	fake.artifactOutputStepMutex.RLock()
 	defer fake.artifactOutputStepMutex.RUnlock()
+	fake.assertGreenStepMutex.RLock()
+	defer fake.assertGreenStepMutex.RUnlock()
 	fake.checkStepMutex.RLock()
 	defer fake.checkStepMutex.RUnlock()
+	fake.consumeArtifactStepMutex.RLock()
+	defer fake.consumeArtifactStepMutex.RUnlock()
 	fake.getStepMutex.RLock()
 	defer fake.getStepMutex.RUnlock()
+	fake.loadBuildOutputsStepMutex.RLock()
+	defer fake.loadBuildOutputsStepMutex.RUnlock()
 	fake.loadVarStepMutex.RLock()
 	defer fake.loadVarStepMutex.RUnlock()
+	fake.publishArtifactStepMutex.RLock()
+	defer fake.publishArtifactStepMutex.RUnlock()
 	fake.putStepMutex.RLock()
 	defer fake.putStepMutex.RUnlock()
 	fake.setPipelineStepMutex.RLock()
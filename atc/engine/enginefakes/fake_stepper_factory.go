@@ -2,6 +2,7 @@
 package enginefakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/concourse/concourse/atc/db"
@@ -10,10 +11,11 @@ import (
 )
 
 type FakeStepperFactory struct {
-	StepperForBuildStub        func(db.Build) (exec.Stepper, error)
+	StepperForBuildStub        func(context.Context, db.Build) (exec.Stepper, error)
 	stepperForBuildMutex       sync.RWMutex
 	stepperForBuildArgsForCall []struct {
-		arg1 db.Build
+		arg1 context.Context
+		arg2 db.Build
 	}
 	stepperForBuildReturns struct {
 		result1 exec.Stepper
@@ -27,18 +29,19 @@ type FakeStepperFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeStepperFactory) StepperForBuild(arg1 db.Build) (exec.Stepper, error) {
+func (fake *FakeStepperFactory) StepperForBuild(arg1 context.Context, arg2 db.Build) (exec.Stepper, error) {
 	fake.stepperForBuildMutex.Lock()
 	ret, specificReturn := fake.stepperForBuildReturnsOnCall[len(fake.stepperForBuildArgsForCall)]
 	fake.stepperForBuildArgsForCall = append(fake.stepperForBuildArgsForCall, struct {
-		arg1 db.Build
-	}{arg1})
+		arg1 context.Context
+		arg2 db.Build
+	}{arg1, arg2})
 	stub := fake.StepperForBuildStub
 	fakeReturns := fake.stepperForBuildReturns
-	fake.recordInvocation("StepperForBuild", []interface{}{arg1})
+	fake.recordInvocation("StepperForBuild", []interface{}{arg1, arg2})
 	fake.stepperForBuildMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -52,17 +55,17 @@ func (fake *FakeStepperFactory) StepperForBuildCallCount() int {
 	return len(fake.stepperForBuildArgsForCall)
 }
 
-func (fake *FakeStepperFactory) StepperForBuildCalls(stub func(db.Build) (exec.Stepper, error)) {
+func (fake *FakeStepperFactory) StepperForBuildCalls(stub func(context.Context, db.Build) (exec.Stepper, error)) {
 	fake.stepperForBuildMutex.Lock()
 	defer fake.stepperForBuildMutex.Unlock()
 	fake.StepperForBuildStub = stub
 }
 
-func (fake *FakeStepperFactory) StepperForBuildArgsForCall(i int) db.Build {
+func (fake *FakeStepperFactory) StepperForBuildArgsForCall(i int) (context.Context, db.Build) {
 	fake.stepperForBuildMutex.RLock()
 	defer fake.stepperForBuildMutex.RUnlock()
 	argsForCall := fake.stepperForBuildArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeStepperFactory) StepperForBuildReturns(result1 exec.Stepper, result2 error) {
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"io"
 	"time"
 
@@ -16,18 +17,22 @@ import (
 )
 
 func NewGetDelegate(
+	ctx context.Context,
 	build db.Build,
 	planID atc.PlanID,
 	state exec.RunState,
 	clock clock.Clock,
 	policyChecker policy.Checker,
 	artifactSourcer worker.ArtifactSourcer,
+	outputLimiter *OutputRateLimiter,
 ) exec.GetDelegate {
+	stepDelegate := NewBuildStepDelegate(ctx, build, planID, state, clock, policyChecker, artifactSourcer, outputLimiter)
+
 	return &getDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, state, clock, policyChecker, artifactSourcer),
+		BuildStepDelegate: stepDelegate,
 
 		eventOrigin: event.Origin{ID: event.OriginID(planID)},
-		build:       build,
+		build:       stepDelegate.build,
 		clock:       clock,
 	}
 }
@@ -1,6 +1,7 @@
 package engine_test
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -60,7 +61,7 @@ var _ = Describe("GetDelegate", func() {
 		fakePolicyChecker = new(policyfakes.FakeChecker)
 		fakeArtifactSourcer = new(workerfakes.FakeArtifactSourcer)
 
-		delegate = engine.NewGetDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker, fakeArtifactSourcer)
+		delegate = engine.NewGetDelegate(context.Background(), fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker, fakeArtifactSourcer, nil)
 	})
 
 	Describe("Finished", func() {
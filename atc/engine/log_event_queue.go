@@ -0,0 +1,188 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// logEventQueueSize is the number of events a step can have waiting to be
+// saved before writing its logs blocks.
+const logEventQueueSize = 1000
+
+// logEventBatchSize is the maximum number of events saved in a single
+// transaction.
+const logEventBatchSize = 100
+
+// LogEventFlushTimeout is how long the log events of a step are still given to
+// be saved once the build's context is done, so that aborted and timed out
+// builds keep the output leading up to their end.
+var LogEventFlushTimeout = 10 * time.Second
+
+// logEventQueue buffers the log events of a step and saves them in batches,
+// so that log-heavy builds don't cost a transaction per write.
+//
+// The queue is bounded: once it is full, saving an event blocks until there is
+// room, slowing down the step rather than dropping any of its output. Events
+// are drained by a goroutine which only runs while there are events to save.
+//
+// Once ctx is done, events are still queued and flushed until
+// LogEventFlushTimeout has passed, after which ctx's error is returned.
+//
+// Once a batch fails to save, no more events are saved, so that the build's
+// log never has a gap in it which later output carries on after. The events
+// queued after it are discarded and the error is returned to the writers.
+type logEventQueue struct {
+	ctx    context.Context
+	build  db.Build
+	events chan atc.Event
+
+	lock    sync.Mutex
+	drained chan struct{}
+	grace   chan struct{}
+	err     error
+}
+
+func newLogEventQueue(ctx context.Context, build db.Build) *logEventQueue {
+	return &logEventQueue{
+		ctx:    ctx,
+		build:  build,
+		events: make(chan atc.Event, logEventQueueSize),
+	}
+}
+
+// Save queues the event to be saved. It returns the first error encountered
+// saving the events queued before it, if any.
+func (queue *logEventQueue) Save(ev atc.Event) error {
+	err := queue.error()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case queue.events <- ev:
+	case <-queue.ctx.Done():
+		select {
+		case queue.events <- ev:
+		case <-queue.gracePeriod():
+			return queue.ctx.Err()
+		}
+	}
+
+	queue.lock.Lock()
+	if queue.drained == nil {
+		queue.drained = make(chan struct{})
+		go queue.drain(queue.drained)
+	}
+	queue.lock.Unlock()
+
+	return nil
+}
+
+// Flush waits for every queued event to be saved and returns the first error
+// encountered saving them, if any.
+func (queue *logEventQueue) Flush() error {
+	queue.lock.Lock()
+	drained := queue.drained
+	queue.lock.Unlock()
+
+	if drained != nil {
+		select {
+		case <-drained:
+		case <-queue.ctx.Done():
+			select {
+			case <-drained:
+			case <-queue.gracePeriod():
+				return queue.ctx.Err()
+			}
+		}
+	}
+
+	return queue.error()
+}
+
+// gracePeriod returns a channel which is closed LogEventFlushTimeout after it
+// is first called, i.e. after ctx was first seen to be done.
+func (queue *logEventQueue) gracePeriod() <-chan struct{} {
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	if queue.grace == nil {
+		grace := make(chan struct{})
+		time.AfterFunc(LogEventFlushTimeout, func() { close(grace) })
+		queue.grace = grace
+	}
+
+	return queue.grace
+}
+
+func (queue *logEventQueue) drain(drained chan struct{}) {
+	defer close(drained)
+
+	for {
+		batch := queue.nextBatch()
+		if len(batch) == 0 {
+			queue.lock.Lock()
+			if len(queue.events) == 0 {
+				queue.drained = nil
+				queue.lock.Unlock()
+				return
+			}
+			queue.lock.Unlock()
+			continue
+		}
+
+		if queue.error() != nil {
+			// discard the events queued after the batch which failed, so that
+			// writers blocked on a full queue see the error
+			continue
+		}
+
+		err := queue.build.SaveEvents(batch)
+		if err != nil {
+			queue.lock.Lock()
+			queue.err = err
+			queue.lock.Unlock()
+		}
+	}
+}
+
+func (queue *logEventQueue) nextBatch() []atc.Event {
+	var batch []atc.Event
+	for len(batch) < logEventBatchSize {
+		select {
+		case ev := <-queue.events:
+			batch = append(batch, ev)
+		default:
+			return batch
+		}
+	}
+
+	return batch
+}
+
+func (queue *logEventQueue) error() error {
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+	return queue.err
+}
+
+// logFlushingBuild saves the queued log events of a step before saving any
+// other event of it, so that e.g. a step's last lines of output come before
+// its finish event.
+type logFlushingBuild struct {
+	db.Build
+
+	logEvents *logEventQueue
+}
+
+func (build logFlushingBuild) SaveEvent(ev atc.Event) error {
+	// a failure to save the log events is returned to their writers; the
+	// event is saved regardless so that the step's outcome isn't lost
+	_ = build.logEvents.Flush()
+
+	return build.Build.SaveEvent(ev)
+}
@@ -1,28 +1,30 @@
 package engine
 
 import (
+	"fmt"
 	"io"
 	"strings"
 	"unicode/utf8"
 
 	"code.cloudfoundry.org/clock"
-	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 )
 
-func newDBEventWriter(build db.Build, origin event.Origin, clock clock.Clock) io.WriteCloser {
+func newDBEventWriter(events *logEventQueue, origin event.Origin, clock clock.Clock, limiter *OutputRateLimiter) io.WriteCloser {
 	return &dbEventWriter{
-		build:  build,
-		origin: origin,
-		clock:  clock,
+		events:  events,
+		origin:  origin,
+		clock:   clock,
+		limiter: limiter,
 	}
 }
 
 type dbEventWriter struct {
-	build    db.Build
+	events   *logEventQueue
 	origin   event.Origin
 	clock    clock.Clock
+	limiter  *OutputRateLimiter
 	dangling []byte
 }
 
@@ -53,24 +55,70 @@ func (writer *dbEventWriter) writeDangling(data []byte) []byte {
 	return text
 }
 
+// saveLog saves as much of the text as the build's output rate limit allows.
+// The output dropped over the limit is marked in its place, once when the
+// limit is hit and once more with the number of bytes dropped when output is
+// allowed again, or when the writer is closed. The step's other events, such
+// as its exit status, are saved regardless.
 func (writer *dbEventWriter) saveLog(text string) error {
-	return writer.build.SaveEvent(event.Log{
-		Time:    writer.clock.Now().Unix(),
-		Payload: text,
+	now := writer.clock.Now()
+
+	allowed := writer.limiter.Allow(now, text)
+
+	payload := allowed
+	if allowed != "" {
+		payload = droppedMarker(writer.limiter.takeDropped()) + payload
+	}
+
+	if dropped := len(text) - len(allowed); dropped > 0 {
+		if writer.limiter.drop(dropped) {
+			payload += fmt.Sprintf("\n[output rate limited to %d bytes/s: dropping output]\n", writer.limiter.bytesPerSecond)
+		}
+	}
+
+	if payload == "" {
+		return nil
+	}
+
+	return writer.events.Save(event.Log{
+		Time:    now.Unix(),
+		Payload: payload,
 		Origin:  writer.origin,
 	})
 }
 
+// droppedMarker returns the marker saying how many bytes of output were
+// dropped, if any were.
+func droppedMarker(dropped int) string {
+	if dropped == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("[%d bytes of output dropped]\n", dropped)
+}
+
 func (writer *dbEventWriter) Close() error {
-	return nil
+	if marker := droppedMarker(writer.limiter.takeDropped()); marker != "" {
+		err := writer.events.Save(event.Log{
+			Time:    writer.clock.Now().Unix(),
+			Payload: marker,
+			Origin:  writer.origin,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.events.Flush()
 }
 
-func newDBEventWriterWithSecretRedaction(build db.Build, origin event.Origin, clock clock.Clock, filter exec.BuildOutputFilter) io.Writer {
+func newDBEventWriterWithSecretRedaction(events *logEventQueue, origin event.Origin, clock clock.Clock, limiter *OutputRateLimiter, filter exec.BuildOutputFilter) io.Writer {
 	return &dbEventWriterWithSecretRedaction{
 		dbEventWriter: dbEventWriter{
-			build:  build,
-			origin: origin,
-			clock:  clock,
+			events:  events,
+			origin:  origin,
+			clock:   clock,
+			limiter: limiter,
 		},
 		filter: filter,
 	}
@@ -121,6 +169,10 @@ func (writer *dbEventWriterWithSecretRedaction) Write(data []byte) (int, error)
 }
 
 func (writer *dbEventWriterWithSecretRedaction) Close() error {
-	writer.Write(nil)
-	return nil
+	_, err := writer.Write(nil)
+	if err != nil {
+		return err
+	}
+
+	return writer.dbEventWriter.Close()
 }
@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/time/rate"
+)
+
+// outputBurstSeconds is the number of seconds worth of output a build can log
+// at once before its output is limited.
+const outputBurstSeconds = 10
+
+// An OutputRateLimiter limits the rate at which a build logs, in bytes per
+// second. Every step of the build shares the same limiter, which also keeps
+// count of the output dropped so that the build's output is only marked as
+// being dropped once, whichever step or stream it is dropped from.
+type OutputRateLimiter struct {
+	bytesPerSecond int
+	limiter        *rate.Limiter
+
+	droppedLock sync.Mutex
+	// dropped is the number of bytes dropped since the output was last
+	// allowed.
+	dropped int
+}
+
+// NewOutputRateLimiter returns a limiter allowing the given number of bytes of
+// output per second, in bursts of up to ten seconds worth. It returns nil,
+// which does not limit output, if the rate is 0.
+func NewOutputRateLimiter(bytesPerSecond int) *OutputRateLimiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+
+	return &OutputRateLimiter{
+		bytesPerSecond: bytesPerSecond,
+		limiter:        rate.NewLimiter(rate.Limit(bytesPerSecond), bytesPerSecond*outputBurstSeconds),
+	}
+}
+
+// Allow returns as much of the text as can be logged at the given time: none
+// of it if the build is over its limit, and at most a burst's worth of it
+// otherwise.
+func (limiter *OutputRateLimiter) Allow(now time.Time, text string) string {
+	if limiter == nil {
+		return text
+	}
+
+	n := len(text)
+	if burst := limiter.limiter.Burst(); n > burst {
+		n = burst
+		for n > 0 && !utf8.RuneStart(text[n]) {
+			n--
+		}
+	}
+
+	if !limiter.limiter.AllowN(now, n) {
+		return ""
+	}
+
+	return text[:n]
+}
+
+// drop records that n bytes of output were dropped, returning whether output
+// was not already being dropped.
+func (limiter *OutputRateLimiter) drop(n int) bool {
+	limiter.droppedLock.Lock()
+	defer limiter.droppedLock.Unlock()
+
+	started := limiter.dropped == 0
+	limiter.dropped += n
+
+	return started
+}
+
+// takeDropped returns the number of bytes dropped since output was last
+// allowed, and starts counting again.
+func (limiter *OutputRateLimiter) takeDropped() int {
+	if limiter == nil {
+		return 0
+	}
+
+	limiter.droppedLock.Lock()
+	defer limiter.droppedLock.Unlock()
+
+	dropped := limiter.dropped
+	limiter.dropped = 0
+
+	return dropped
+}
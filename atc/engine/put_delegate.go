@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"io"
 	"time"
 
@@ -16,18 +17,22 @@ import (
 )
 
 func NewPutDelegate(
+	ctx context.Context,
 	build db.Build,
 	planID atc.PlanID,
 	state exec.RunState,
 	clock clock.Clock,
 	policyChecker policy.Checker,
 	artifactSourcer worker.ArtifactSourcer,
+	outputLimiter *OutputRateLimiter,
 ) exec.PutDelegate {
+	stepDelegate := NewBuildStepDelegate(ctx, build, planID, state, clock, policyChecker, artifactSourcer, outputLimiter)
+
 	return &putDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, state, clock, policyChecker, artifactSourcer),
+		BuildStepDelegate: stepDelegate,
 
 		eventOrigin: event.Origin{ID: event.OriginID(planID)},
-		build:       build,
+		build:       stepDelegate.build,
 		clock:       clock,
 	}
 }
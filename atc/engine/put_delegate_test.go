@@ -1,6 +1,7 @@
 package engine_test
 
 import (
+	"context"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -56,7 +57,7 @@ var _ = Describe("PutDelegate", func() {
 		fakePolicyChecker = new(policyfakes.FakeChecker)
 		fakeArtifactSourcer = new(workerfakes.FakeArtifactSourcer)
 
-		delegate = engine.NewPutDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker, fakeArtifactSourcer)
+		delegate = engine.NewPutDelegate(context.Background(), fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker, fakeArtifactSourcer, nil)
 	})
 
 	Describe("Finished", func() {
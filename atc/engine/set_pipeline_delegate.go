@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"context"
+
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -10,20 +12,15 @@ import (
 )
 
 func NewSetPipelineStepDelegate(
+	ctx context.Context,
 	build db.Build,
 	planID atc.PlanID,
 	state exec.RunState,
 	clock clock.Clock,
+	outputLimiter *OutputRateLimiter,
 ) *setPipelineStepDelegate {
 	return &setPipelineStepDelegate{
-		buildStepDelegate{
-			build:  build,
-			planID: planID,
-			clock:  clock,
-			state:  state,
-			stdout: nil,
-			stderr: nil,
-		},
+		*NewBuildStepDelegate(ctx, build, planID, state, clock, nil, nil, outputLimiter),
 	}
 }
 
@@ -1,6 +1,7 @@
 package engine_test
 
 import (
+	"context"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -38,7 +39,7 @@ var _ = Describe("SetPipelineStepDelegate", func() {
 		}
 		state = exec.NewRunState(noopStepper, credVars, true)
 
-		delegate = engine.NewSetPipelineStepDelegate(fakeBuild, "some-plan-id", state, fakeClock)
+		delegate = engine.NewSetPipelineStepDelegate(context.Background(), fakeBuild, "some-plan-id", state, fakeClock, nil)
 	})
 
 	Describe("SetPipelineChanged", func() {
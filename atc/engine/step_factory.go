@@ -11,6 +11,7 @@ import (
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
+	"golang.org/x/sync/singleflight"
 )
 
 type coreStepFactory struct {
@@ -22,9 +23,15 @@ type coreStepFactory struct {
 	buildFactory          db.BuildFactory
 	resourceCacheFactory  db.ResourceCacheFactory
 	resourceConfigFactory db.ResourceConfigFactory
+	taskOutputCache       db.TaskOutputCacheFactory
 	defaultLimits         atc.ContainerLimits
 	strategy              worker.ContainerPlacementStrategy
 	defaultCheckTimeout   time.Duration
+	maxVersionsPerCheck   int
+
+	// shared by all check steps so that concurrent checks of the same scope
+	// are coalesced
+	checkFlights *singleflight.Group
 }
 
 func NewCoreStepFactory(
@@ -36,9 +43,11 @@ func NewCoreStepFactory(
 	buildFactory db.BuildFactory,
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	taskOutputCache db.TaskOutputCacheFactory,
 	defaultLimits atc.ContainerLimits,
 	strategy worker.ContainerPlacementStrategy,
 	defaultCheckTimeout time.Duration,
+	maxVersionsPerCheck int,
 ) CoreStepFactory {
 	return &coreStepFactory{
 		pool:                  pool,
@@ -49,9 +58,12 @@ func NewCoreStepFactory(
 		buildFactory:          buildFactory,
 		resourceCacheFactory:  resourceCacheFactory,
 		resourceConfigFactory: resourceConfigFactory,
+		taskOutputCache:       taskOutputCache,
 		defaultLimits:         defaultLimits,
 		strategy:              strategy,
 		defaultCheckTimeout:   defaultCheckTimeout,
+		maxVersionsPerCheck:   maxVersionsPerCheck,
+		checkFlights:          new(singleflight.Group),
 	}
 }
 
@@ -129,6 +141,8 @@ func (factory *coreStepFactory) CheckStep(
 		factory.pool,
 		delegateFactory,
 		factory.defaultCheckTimeout,
+		factory.maxVersionsPerCheck,
+		factory.checkFlights,
 	)
 
 	checkStep = exec.LogError(checkStep, delegateFactory)
@@ -157,6 +171,7 @@ func (factory *coreStepFactory) TaskStep(
 		factory.pool,
 		factory.artifactStreamer,
 		factory.artifactSourcer,
+		factory.taskOutputCache,
 		delegateFactory,
 	)
 
@@ -193,12 +208,14 @@ func (factory *coreStepFactory) SetPipelineStep(
 func (factory *coreStepFactory) LoadVarStep(
 	plan atc.Plan,
 	stepMetadata exec.StepMetadata,
+	build db.Build,
 	delegateFactory DelegateFactory,
 ) exec.Step {
 	loadVarStep := exec.NewLoadVarStep(
 		plan.ID,
 		*plan.LoadVar,
 		stepMetadata,
+		build,
 		delegateFactory,
 		factory.artifactStreamer,
 	)
@@ -210,6 +227,92 @@ func (factory *coreStepFactory) LoadVarStep(
 	return loadVarStep
 }
 
+func (factory *coreStepFactory) ApprovalStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	build db.Build,
+	delegateFactory DelegateFactory,
+) exec.Step {
+	approvalStep := exec.NewApprovalStep(
+		plan.ID,
+		*plan.Approval,
+		stepMetadata,
+		build,
+		delegateFactory,
+	)
+
+	return exec.LogError(approvalStep, delegateFactory)
+}
+
+func (factory *coreStepFactory) PublishArtifactStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	delegateFactory DelegateFactory,
+) exec.Step {
+	publishArtifactStep := exec.NewPublishArtifactStep(
+		plan.ID,
+		*plan.PublishArtifact,
+		stepMetadata,
+		delegateFactory,
+		factory.teamFactory,
+		factory.pool,
+	)
+
+	return exec.LogError(publishArtifactStep, delegateFactory)
+}
+
+func (factory *coreStepFactory) ConsumeArtifactStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	delegateFactory DelegateFactory,
+) exec.Step {
+	consumeArtifactStep := exec.NewConsumeArtifactStep(
+		plan.ID,
+		*plan.ConsumeArtifact,
+		stepMetadata,
+		delegateFactory,
+		factory.teamFactory,
+		factory.pool,
+	)
+
+	return exec.LogError(consumeArtifactStep, delegateFactory)
+}
+
+func (factory *coreStepFactory) LoadBuildOutputsStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	build db.Build,
+	delegateFactory DelegateFactory,
+) exec.Step {
+	loadBuildOutputsStep := exec.NewLoadBuildOutputsStep(
+		plan.ID,
+		*plan.LoadBuildOutputs,
+		stepMetadata,
+		build,
+		delegateFactory,
+	)
+
+	return exec.LogError(loadBuildOutputsStep, delegateFactory)
+}
+
+func (factory *coreStepFactory) AssertGreenStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	build db.Build,
+	delegateFactory DelegateFactory,
+) exec.Step {
+	assertGreenStep := exec.NewAssertGreenStep(
+		plan.ID,
+		*plan.AssertGreen,
+		stepMetadata,
+		build,
+		delegateFactory,
+		factory.teamFactory,
+	)
+
+	return exec.LogError(assertGreenStep, delegateFactory)
+}
+
 func (factory *coreStepFactory) ArtifactInputStep(
 	plan atc.Plan,
 	build db.Build,
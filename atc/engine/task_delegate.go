@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"io"
 
 	"code.cloudfoundry.org/clock"
@@ -15,20 +16,26 @@ import (
 )
 
 func NewTaskDelegate(
+	ctx context.Context,
 	build db.Build,
 	planID atc.PlanID,
+	taskName string,
 	state exec.RunState,
 	clock clock.Clock,
 	policyChecker policy.Checker,
 	artifactSourcer worker.ArtifactSourcer,
+	outputLimiter *OutputRateLimiter,
 	dbWorkerFactory db.WorkerFactory,
 	lockFactory lock.LockFactory,
 ) exec.TaskDelegate {
+	stepDelegate := NewBuildStepDelegate(ctx, build, planID, state, clock, policyChecker, artifactSourcer, outputLimiter)
+
 	return &taskDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, state, clock, policyChecker, artifactSourcer),
+		BuildStepDelegate: stepDelegate,
 
+		taskName:    taskName,
 		eventOrigin: event.Origin{ID: event.OriginID(planID)},
-		build:       build,
+		build:       stepDelegate.build,
 		clock:       clock,
 
 		dbWorkerFactory: dbWorkerFactory,
@@ -40,6 +47,7 @@ type taskDelegate struct {
 	exec.BuildStepDelegate
 
 	config      atc.TaskConfig
+	taskName    string
 	build       db.Build
 	eventOrigin event.Origin
 	clock       clock.Clock
@@ -100,5 +108,13 @@ func (d *taskDelegate) Finished(
 		return
 	}
 
+	// saved on the build too, as the task var source's vars only live as long
+	// as the build runs on this ATC
+	err = d.build.SaveTaskExitCode(atc.PlanID(d.eventOrigin.ID), d.taskName, int(exitStatus))
+	if err != nil {
+		logger.Error("failed-to-save-exit-code", err)
+		return
+	}
+
 	logger.Info("finished", lager.Data{"exit-status": exitStatus})
 }
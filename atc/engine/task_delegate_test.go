@@ -1,7 +1,9 @@
 package engine
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -60,7 +62,7 @@ var _ = Describe("TaskDelegate", func() {
 		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
 		fakeLockFactory = new(lockfakes.FakeLockFactory)
 
-		delegate = NewTaskDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker, fakeArtifactSourcer, fakeWorkerFactory, fakeLockFactory).(*taskDelegate)
+		delegate = NewTaskDelegate(context.Background(), fakeBuild, "some-plan-id", "some-task", state, fakeClock, fakePolicyChecker, fakeArtifactSourcer, nil, fakeWorkerFactory, fakeLockFactory).(*taskDelegate)
 
 		delegate.SetTaskConfig(atc.TaskConfig{
 			Platform: "some-platform",
@@ -140,6 +142,7 @@ var _ = Describe("TaskDelegate", func() {
 		BeforeEach(func() {
 			fakeClient = new(workerfakes.FakeClient)
 			fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
+			exitStatus = 0
 		})
 
 		JustBeforeEach(func() {
@@ -151,6 +154,30 @@ var _ = Describe("TaskDelegate", func() {
 			event := fakeBuild.SaveEventArgsForCall(0)
 			Expect(event.EventType()).To(Equal(atc.EventType("finish-task")))
 		})
+
+		Context("when the task exits", func() {
+			BeforeEach(func() {
+				exitStatus = 42
+			})
+
+			It("saves the exit code on the build", func() {
+				Expect(fakeBuild.SaveTaskExitCodeCallCount()).To(Equal(1))
+				planID, name, exitCode := fakeBuild.SaveTaskExitCodeArgsForCall(0)
+				Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+				Expect(name).To(Equal("some-task"))
+				Expect(exitCode).To(Equal(42))
+			})
+		})
+
+		Context("when saving the finish event fails", func() {
+			BeforeEach(func() {
+				fakeBuild.SaveEventReturns(errors.New("nope"))
+			})
+
+			It("does not save the exit code", func() {
+				Expect(fakeBuild.SaveTaskExitCodeCallCount()).To(BeZero())
+			})
+		})
 	})
 })
 
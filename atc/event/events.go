@@ -240,3 +240,14 @@ type ImageGet struct {
 
 func (ImageGet) EventType() atc.EventType  { return EventTypeImageGet }
 func (ImageGet) Version() atc.EventVersion { return "1.1" }
+
+type ImageFetched struct {
+	Time           int64       `json:"time"`
+	Origin         Origin      `json:"origin"`
+	Name           string      `json:"name"`
+	Type           string      `json:"type"`
+	FetchedVersion atc.Version `json:"version"`
+}
+
+func (ImageFetched) EventType() atc.EventType  { return EventTypeImageFetched }
+func (ImageFetched) Version() atc.EventVersion { return "1.0" }
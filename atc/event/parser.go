@@ -51,6 +51,7 @@ func init() {
 	RegisterEvent(Error{})
 	RegisterEvent(ImageCheck{})
 	RegisterEvent(ImageGet{})
+	RegisterEvent(ImageFetched{})
 
 	// deprecated:
 	RegisterEvent(InitializeV10{})
@@ -61,4 +61,7 @@ const (
 
 	// image get sub-plan
 	EventTypeImageGet atc.EventType = "image-get"
+
+	// image resolved and fetched for a step
+	EventTypeImageFetched atc.EventType = "image-fetched"
 )
@@ -0,0 +1,170 @@
+package eventarchive
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/component"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// batchSize is how many builds are archived, or deleted archives removed, per
+// query.
+const batchSize = 100
+
+type archiver struct {
+	store                 Store
+	buildFactory          db.BuildFactory
+	syslogDrainConfigured bool
+}
+
+// NewArchiver returns a component which moves the events of completed builds
+// from the database to the store, leaving the key they were stored under on
+// the build.
+//
+// If a syslog drainer is configured, builds are only archived once they have
+// been drained.
+//
+// The archived events of builds which have since been deleted or reaped are
+// removed from the store.
+func NewArchiver(store Store, buildFactory db.BuildFactory, syslogDrainConfigured bool) component.Runnable {
+	return &archiver{
+		store:                 store,
+		buildFactory:          buildFactory,
+		syslogDrainConfigured: syslogDrainConfigured,
+	}
+}
+
+func (a *archiver) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("event-archiver")
+
+	err := a.archiveBuilds(ctx, logger)
+	if err != nil {
+		return err
+	}
+
+	return a.deleteArchives(ctx, logger)
+}
+
+func (a *archiver) archiveBuilds(ctx context.Context, logger lager.Logger) error {
+	afterID := 0
+	for {
+		builds, err := a.buildFactory.GetArchivableBuilds(afterID, batchSize)
+		if err != nil {
+			logger.Error("failed-to-get-archivable-builds", err)
+			return err
+		}
+
+		for _, build := range builds {
+			afterID = build.ID()
+
+			if a.syslogDrainConfigured && !build.IsDrained() {
+				continue
+			}
+
+			err := a.archiveBuild(ctx, logger, build)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(builds) < batchSize {
+			return nil
+		}
+	}
+}
+
+func (a *archiver) deleteArchives(ctx context.Context, logger lager.Logger) error {
+	for {
+		keys, err := a.buildFactory.DeletedEventsArchiveKeys(batchSize)
+		if err != nil {
+			logger.Error("failed-to-get-deleted-archives", err)
+			return err
+		}
+
+		for _, key := range keys {
+			err := a.store.Delete(ctx, key)
+			if err != nil {
+				logger.Error("failed-to-delete-archive", err, lager.Data{"key": key})
+				return err
+			}
+		}
+
+		err = a.buildFactory.ForgetDeletedEventsArchiveKeys(keys)
+		if err != nil {
+			logger.Error("failed-to-forget-deleted-archives", err)
+			return err
+		}
+
+		if len(keys) < batchSize {
+			return nil
+		}
+	}
+}
+
+func (a *archiver) archiveBuild(ctx context.Context, logger lager.Logger, build db.Build) error {
+	logger = logger.Session("archive-build", build.LagerData())
+
+	events, err := build.Events(0)
+	if err != nil {
+		logger.Error("failed-to-get-events", err)
+		return err
+	}
+
+	// ignore any errors coming from events.Close()
+	defer db.Close(events)
+
+	reader, writer := io.Pipe()
+
+	// unblocks the writer if the upload gives up early
+	defer reader.Close()
+
+	go func() {
+		writer.CloseWithError(writeEvents(writer, events))
+	}()
+
+	key := Key(build)
+
+	err = a.store.Put(ctx, key, reader)
+	if err != nil {
+		logger.Error("failed-to-store-events", err)
+		return err
+	}
+
+	err = build.ArchiveEvents(key)
+	if err != nil {
+		logger.Error("failed-to-mark-events-archived", err)
+		return err
+	}
+
+	logger.Debug("archived", lager.Data{"key": key})
+
+	return nil
+}
+
+func writeEvents(w io.Writer, events db.EventSource) error {
+	gz := gzip.NewWriter(w)
+	encoder := json.NewEncoder(gz)
+
+	for {
+		ev, err := events.Next()
+		if err != nil {
+			if err == db.ErrEndOfBuildEventStream {
+				break
+			}
+
+			return err
+		}
+
+		err = encoder.Encode(ev)
+		if err != nil {
+			return err
+		}
+	}
+
+	return gz.Close()
+}
@@ -0,0 +1,195 @@
+package eventarchive_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/concourse/concourse/atc/component"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/eventarchive"
+	"github.com/concourse/concourse/atc/eventarchive/eventarchivefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Archiver", func() {
+	var (
+		fakeStore        *eventarchivefakes.FakeStore
+		fakeBuildFactory *dbfakes.FakeBuildFactory
+		fakeBuild        *dbfakes.FakeBuild
+
+		syslogDrainConfigured bool
+		stored                map[string][]byte
+
+		archiver component.Runnable
+		runErr   error
+	)
+
+	BeforeEach(func() {
+		stored = map[string][]byte{}
+
+		fakeStore = new(eventarchivefakes.FakeStore)
+		fakeStore.PutStub = func(_ context.Context, key string, events io.Reader) error {
+			payload, err := ioutil.ReadAll(events)
+			if err != nil {
+				return err
+			}
+
+			stored[key] = payload
+			return nil
+		}
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.EventsReturns(fakeEventSource(logEnvelope(0), logEnvelope(1)), nil)
+
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeBuildFactory.GetArchivableBuildsReturns([]db.Build{fakeBuild}, nil)
+
+		syslogDrainConfigured = false
+	})
+
+	JustBeforeEach(func() {
+		archiver = eventarchive.NewArchiver(fakeStore, fakeBuildFactory, syslogDrainConfigured)
+		runErr = archiver.Run(context.TODO())
+	})
+
+	It("stores the build's events", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+
+		Expect(fakeBuild.EventsArgsForCall(0)).To(BeZero())
+		Expect(stored).To(HaveKeyWithValue("builds/42/events.json.gz", archived(logEnvelope(0), logEnvelope(1))))
+	})
+
+	It("pages through the archivable builds", func() {
+		Expect(fakeBuildFactory.GetArchivableBuildsCallCount()).To(Equal(1))
+
+		afterID, limit := fakeBuildFactory.GetArchivableBuildsArgsForCall(0)
+		Expect(afterID).To(BeZero())
+		Expect(limit).To(BeNumerically(">", 1))
+	})
+
+	Context("when a page of archivable builds is full", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.GetArchivableBuildsStub = func(afterID int, limit int) ([]db.Build, error) {
+				if afterID != 0 {
+					return nil, nil
+				}
+
+				var builds []db.Build
+				for i := 1; i <= limit; i++ {
+					build := new(dbfakes.FakeBuild)
+					build.IDReturns(i)
+					build.EventsReturns(fakeEventSource(logEnvelope(0)), nil)
+					builds = append(builds, build)
+				}
+
+				return builds, nil
+			}
+		})
+
+		It("gets the next page after the last build", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeBuildFactory.GetArchivableBuildsCallCount()).To(Equal(2))
+
+			_, limit := fakeBuildFactory.GetArchivableBuildsArgsForCall(0)
+			afterID, _ := fakeBuildFactory.GetArchivableBuildsArgsForCall(1)
+			Expect(afterID).To(Equal(limit))
+			Expect(stored).To(HaveLen(limit))
+		})
+	})
+
+	It("records the key on the build", func() {
+		Expect(fakeBuild.ArchiveEventsCallCount()).To(Equal(1))
+		Expect(fakeBuild.ArchiveEventsArgsForCall(0)).To(Equal("builds/42/events.json.gz"))
+	})
+
+	Context("when storing the events fails", func() {
+		BeforeEach(func() {
+			fakeStore.PutReturns(errors.New("disaster"))
+			fakeStore.PutStub = nil
+		})
+
+		It("keeps the events in the database", func() {
+			Expect(runErr).To(MatchError("disaster"))
+			Expect(fakeBuild.ArchiveEventsCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when reading the events fails", func() {
+		BeforeEach(func() {
+			source := new(dbfakes.FakeEventSource)
+			source.NextReturns(logEnvelope(0), nil)
+			source.NextReturnsOnCall(1, logEnvelope(0), errors.New("db gone"))
+			fakeBuild.EventsReturns(source, nil)
+		})
+
+		It("does not mark the events as archived", func() {
+			Expect(runErr).To(HaveOccurred())
+			Expect(fakeBuild.ArchiveEventsCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the builds of archived events have been deleted", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.DeletedEventsArchiveKeysReturns([]string{
+				"builds/1/events.json.gz",
+				"builds/2/events.json.gz",
+			}, nil)
+		})
+
+		It("deletes the archived events from the store", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeStore.DeleteCallCount()).To(Equal(2))
+
+			_, key := fakeStore.DeleteArgsForCall(0)
+			Expect(key).To(Equal("builds/1/events.json.gz"))
+
+			_, key = fakeStore.DeleteArgsForCall(1)
+			Expect(key).To(Equal("builds/2/events.json.gz"))
+		})
+
+		It("forgets the deleted keys", func() {
+			Expect(fakeBuildFactory.ForgetDeletedEventsArchiveKeysCallCount()).To(Equal(1))
+			Expect(fakeBuildFactory.ForgetDeletedEventsArchiveKeysArgsForCall(0)).To(Equal([]string{
+				"builds/1/events.json.gz",
+				"builds/2/events.json.gz",
+			}))
+		})
+
+		Context("when deleting the archived events fails", func() {
+			BeforeEach(func() {
+				fakeStore.DeleteReturns(errors.New("disaster"))
+			})
+
+			It("keeps the keys to retry", func() {
+				Expect(runErr).To(MatchError("disaster"))
+				Expect(fakeBuildFactory.ForgetDeletedEventsArchiveKeysCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when a syslog drainer is configured", func() {
+		BeforeEach(func() {
+			syslogDrainConfigured = true
+		})
+
+		It("skips builds which have not been drained", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeStore.PutCallCount()).To(BeZero())
+		})
+
+		Context("when the build has been drained", func() {
+			BeforeEach(func() {
+				fakeBuild.IsDrainedReturns(true)
+			})
+
+			It("archives it", func() {
+				Expect(fakeBuild.ArchiveEventsCallCount()).To(Equal(1))
+			})
+		})
+	})
+})
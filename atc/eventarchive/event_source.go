@@ -0,0 +1,117 @@
+package eventarchive
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// WithArchivedEvents wraps the build so that its events are read from the
+// store once they have been archived. Builds which have not been archived
+// keep reading from the database.
+func WithArchivedEvents(build db.Build, store Store) db.Build {
+	return archivedBuild{
+		Build: build,
+		store: store,
+	}
+}
+
+type archivedBuild struct {
+	db.Build
+
+	store Store
+}
+
+func (build archivedBuild) Events(from uint) (db.EventSource, error) {
+	key := build.EventsArchiveKey()
+	if key == "" {
+		return build.Build.Events(from)
+	}
+
+	return newEventSource(build.store, key, from), nil
+}
+
+// eventSource fetches the archived events on the first call to Next, so that
+// builds which are looked up but never streamed don't hit the store.
+type eventSource struct {
+	store Store
+	key   string
+	from  uint
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	body    io.ReadCloser
+	decoder *json.Decoder
+}
+
+func newEventSource(store Store, key string, from uint) *eventSource {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &eventSource{
+		store: store,
+		key:   key,
+		from:  from,
+
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (source *eventSource) Next() (event.Envelope, error) {
+	if source.ctx.Err() != nil {
+		return event.Envelope{}, db.ErrBuildEventStreamClosed
+	}
+
+	if source.decoder == nil {
+		body, err := source.store.Get(source.ctx, source.key)
+		if err != nil {
+			return event.Envelope{}, err
+		}
+
+		source.body = body
+
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return event.Envelope{}, err
+		}
+
+		source.decoder = json.NewDecoder(gz)
+	}
+
+	for {
+		var ev event.Envelope
+		err := source.decoder.Decode(&ev)
+		if err != nil {
+			if err == io.EOF {
+				return event.Envelope{}, db.ErrEndOfBuildEventStream
+			}
+
+			return event.Envelope{}, err
+		}
+
+		id, err := strconv.Atoi(ev.EventID)
+		if err != nil {
+			return event.Envelope{}, err
+		}
+
+		if uint(id) >= source.from {
+			return ev, nil
+		}
+	}
+}
+
+func (source *eventSource) Close() error {
+	source.cancel()
+
+	if source.body == nil {
+		return nil
+	}
+
+	return source.body.Close()
+}
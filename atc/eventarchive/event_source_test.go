@@ -0,0 +1,161 @@
+package eventarchive_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/eventarchive"
+	"github.com/concourse/concourse/atc/eventarchive/eventarchivefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WithArchivedEvents", func() {
+	var (
+		fakeStore *eventarchivefakes.FakeStore
+		fakeBuild *dbfakes.FakeBuild
+
+		build db.Build
+	)
+
+	BeforeEach(func() {
+		fakeStore = new(eventarchivefakes.FakeStore)
+		fakeStore.GetReturns(ioutil.NopCloser(bytes.NewReader(archived(logEnvelope(0), logEnvelope(1), logEnvelope(2)))), nil)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+	})
+
+	JustBeforeEach(func() {
+		build = eventarchive.WithArchivedEvents(fakeBuild, fakeStore)
+	})
+
+	Context("when the build's events have not been archived", func() {
+		var dbSource *dbfakes.FakeEventSource
+
+		BeforeEach(func() {
+			dbSource = fakeEventSource()
+			fakeBuild.EventsReturns(dbSource, nil)
+		})
+
+		It("reads them from the database", func() {
+			source, err := build.Events(3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(source).To(Equal(dbSource))
+			Expect(fakeBuild.EventsArgsForCall(0)).To(Equal(uint(3)))
+
+			Expect(fakeStore.GetCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the build's events have been archived", func() {
+		BeforeEach(func() {
+			fakeBuild.EventsArchiveKeyReturns("builds/42/events.json.gz")
+		})
+
+		It("does not fetch them until they are read", func() {
+			source, err := build.Events(0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeStore.GetCallCount()).To(BeZero())
+
+			_, err = source.Next()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeStore.GetCallCount()).To(Equal(1))
+			_, key := fakeStore.GetArgsForCall(0)
+			Expect(key).To(Equal("builds/42/events.json.gz"))
+		})
+
+		It("reads them from the store and then ends the stream", func() {
+			source, err := build.Events(0)
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := 0; i < 3; i++ {
+				ev, err := source.Next()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ev).To(Equal(logEnvelope(i)))
+			}
+
+			_, err = source.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+
+			Expect(fakeBuild.EventsCallCount()).To(BeZero())
+		})
+
+		It("skips the events before the one requested", func() {
+			source, err := build.Events(2)
+			Expect(err).ToNot(HaveOccurred())
+
+			ev, err := source.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ev).To(Equal(logEnvelope(2)))
+		})
+
+		It("cannot be read after it is closed", func() {
+			source, err := build.Events(0)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(source.Close()).To(Succeed())
+
+			_, err = source.Next()
+			Expect(err).To(Equal(db.ErrBuildEventStreamClosed))
+		})
+
+		Context("when the store fails", func() {
+			BeforeEach(func() {
+				fakeStore.GetReturns(nil, errors.New("disaster"))
+			})
+
+			It("returns the error", func() {
+				source, err := build.Events(0)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = source.Next()
+				Expect(err).To(MatchError("disaster"))
+			})
+		})
+	})
+
+	It("passes every other method through to the build", func() {
+		Expect(build.ID()).To(Equal(42))
+	})
+
+	It("round-trips events written by the archiver", func() {
+		var payload []byte
+		fakeStore.PutStub = func(_ context.Context, _ string, events io.Reader) error {
+			var err error
+			payload, err = ioutil.ReadAll(events)
+			return err
+		}
+
+		fakeBuild.EventsReturns(fakeEventSource(logEnvelope(0), logEnvelope(1)), nil)
+
+		fakeBuildFactory := new(dbfakes.FakeBuildFactory)
+		fakeBuildFactory.GetArchivableBuildsReturns([]db.Build{fakeBuild}, nil)
+		Expect(eventarchive.NewArchiver(fakeStore, fakeBuildFactory, false).Run(context.TODO())).To(Succeed())
+
+		fakeBuild.EventsArchiveKeyReturns(fakeBuild.ArchiveEventsArgsForCall(0))
+		fakeStore.GetReturns(ioutil.NopCloser(bytes.NewReader(payload)), nil)
+
+		source, err := build.Events(0)
+		Expect(err).ToNot(HaveOccurred())
+
+		var envelopes []event.Envelope
+		for {
+			ev, err := source.Next()
+			if err == db.ErrEndOfBuildEventStream {
+				break
+			}
+			Expect(err).ToNot(HaveOccurred())
+			envelopes = append(envelopes, ev)
+		}
+
+		Expect(envelopes).To(Equal([]event.Envelope{logEnvelope(0), logEnvelope(1)}))
+	})
+})
@@ -0,0 +1,51 @@
+package eventarchive_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"strconv"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestEventArchive(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Event Archive Suite")
+}
+
+func logEnvelope(id int) event.Envelope {
+	payload := json.RawMessage(`{"time":1533744538,"payload":"line ` + strconv.Itoa(id) + `"}`)
+	return event.Envelope{
+		Data:    &payload,
+		Event:   "log",
+		Version: "5.1",
+		EventID: strconv.Itoa(id),
+	}
+}
+
+func fakeEventSource(envelopes ...event.Envelope) *dbfakes.FakeEventSource {
+	source := new(dbfakes.FakeEventSource)
+	for i, ev := range envelopes {
+		source.NextReturnsOnCall(i, ev, nil)
+	}
+	source.NextReturns(event.Envelope{}, db.ErrEndOfBuildEventStream)
+	return source
+}
+
+func archived(envelopes ...event.Envelope) []byte {
+	buf := new(bytes.Buffer)
+	gz := gzip.NewWriter(buf)
+	encoder := json.NewEncoder(gz)
+	for _, ev := range envelopes {
+		Expect(encoder.Encode(ev)).To(Succeed())
+	}
+	Expect(gz.Close()).To(Succeed())
+	return buf.Bytes()
+}
@@ -0,0 +1,99 @@
+package eventarchive
+
+import (
+	"context"
+	"io"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// S3 archives build events to an S3 bucket. Any S3-compatible object store
+// can be used by setting the endpoint, e.g. https://storage.googleapis.com
+// with HMAC keys for GCS.
+type S3 struct {
+	Bucket             string `long:"s3-bucket" description:"Bucket to archive the events of completed builds to."`
+	Prefix             string `long:"s3-prefix" description:"Prefix to prepend to the key of every archived object."`
+	Region             string `long:"s3-region" description:"AWS region of the bucket."`
+	Endpoint           string `long:"s3-endpoint" description:"Endpoint of an S3-compatible object store, if not AWS S3."`
+	AwsAccessKeyID     string `long:"s3-access-key" description:"AWS Access key ID"`
+	AwsSecretAccessKey string `long:"s3-secret-key" description:"AWS Secret Access Key"`
+	AwsSessionToken    string `long:"s3-session-token" description:"AWS Session Token"`
+	ForcePathStyle     bool   `long:"s3-force-path-style" description:"Address the bucket in the path rather than the host name, as most S3-compatible stores require."`
+}
+
+func (s S3) IsConfigured() bool {
+	return s.Bucket != ""
+}
+
+func (s S3) Store() (Store, error) {
+	config := &aws.Config{
+		Region:           aws.String(s.Region),
+		S3ForcePathStyle: aws.Bool(s.ForcePathStyle),
+	}
+
+	if s.Endpoint != "" {
+		config.Endpoint = aws.String(s.Endpoint)
+	}
+
+	if s.AwsAccessKeyID != "" {
+		config.Credentials = credentials.NewStaticCredentials(s.AwsAccessKeyID, s.AwsSecretAccessKey, s.AwsSessionToken)
+	}
+
+	session, err := session.NewSession(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3Store{
+		bucket:   s.Bucket,
+		prefix:   s.Prefix,
+		client:   s3.New(session),
+		uploader: s3manager.NewUploader(session),
+	}, nil
+}
+
+type s3Store struct {
+	bucket string
+	prefix string
+
+	client   *s3.S3
+	uploader *s3manager.Uploader
+}
+
+func (store *s3Store) Put(ctx context.Context, key string, events io.Reader) error {
+	_, err := store.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:          aws.String(store.bucket),
+		Key:             aws.String(path.Join(store.prefix, key)),
+		Body:            events,
+		ContentType:     aws.String("application/x-ndjson"),
+		ContentEncoding: aws.String("gzip"),
+	})
+
+	return err
+}
+
+func (store *s3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := store.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(path.Join(store.prefix, key)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Body, nil
+}
+
+func (store *s3Store) Delete(ctx context.Context, key string) error {
+	_, err := store.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(path.Join(store.prefix, key)),
+	})
+
+	return err
+}
@@ -0,0 +1,43 @@
+package eventarchive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate . Store
+
+// Store holds the events of completed builds outside of the database.
+//
+// Archived events are written as gzipped, newline-delimited JSON envelopes,
+// in the same order they were saved in.
+type Store interface {
+	Put(ctx context.Context, key string, events io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type Config struct {
+	Interval time.Duration `long:"interval" default:"1m" description:"Interval on which the events of completed builds are moved to the archive."`
+
+	S3 S3
+}
+
+func (c Config) IsConfigured() bool {
+	return c.S3.IsConfigured()
+}
+
+func (c Config) Store() (Store, error) {
+	return c.S3.Store()
+}
+
+// Key returns where the events of the given build are archived.
+func Key(build db.Build) string {
+	return fmt.Sprintf("builds/%d/events.json.gz", build.ID())
+}
@@ -0,0 +1,152 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/tracing"
+)
+
+// ApprovalStep pauses the build until the step is approved or rejected
+// through the API, succeeding if it is approved and failing otherwise.
+//
+// The approval is persisted on the build, so a build resumed by another ATC
+// keeps waiting on the same approval, and its timeout is counted from when
+// the approval was first requested.
+type ApprovalStep struct {
+	planID          atc.PlanID
+	plan            atc.ApprovalPlan
+	metadata        StepMetadata
+	build           db.Build
+	delegateFactory BuildStepDelegateFactory
+}
+
+func NewApprovalStep(
+	planID atc.PlanID,
+	plan atc.ApprovalPlan,
+	metadata StepMetadata,
+	build db.Build,
+	delegateFactory BuildStepDelegateFactory,
+) Step {
+	return &ApprovalStep{
+		planID:          planID,
+		plan:            plan,
+		metadata:        metadata,
+		build:           build,
+		delegateFactory: delegateFactory,
+	}
+}
+
+func (step *ApprovalStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.BuildStepDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "approval", tracing.Attrs{
+		"name": step.plan.Name,
+	})
+
+	ok, err := step.run(ctx, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *ApprovalStep) run(ctx context.Context, delegate BuildStepDelegate) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("approval-step", lager.Data{
+		"step-name": step.plan.Name,
+		"job-id":    step.metadata.JobID,
+	})
+
+	delegate.Initializing(logger)
+	stdout := delegate.Stdout()
+
+	var timeout time.Duration
+	if step.plan.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(step.plan.Timeout)
+		if err != nil {
+			return false, fmt.Errorf("parse timeout: %w", err)
+		}
+	}
+
+	// listen before requesting so that a decision made in between is not missed
+	notifier, err := step.build.ApprovalNotifier(step.planID)
+	if err != nil {
+		return false, err
+	}
+
+	defer notifier.Close()
+
+	approval, err := step.build.RequestApproval(step.planID, step.plan.Name)
+	if err != nil {
+		return false, err
+	}
+
+	delegate.Starting(logger)
+
+	if !approval.Decided {
+		fmt.Fprintf(stdout, "waiting for approval of %s\n", step.plan.Name)
+		fmt.Fprintf(stdout, "approve or reject it with:\n\n")
+		fmt.Fprintf(stdout, "  fly approve-build -b %d -s %s [--reject]\n\n", step.metadata.BuildID, step.plan.Name)
+		fmt.Fprintf(stdout, "or by sending a PUT request to:\n\n")
+		fmt.Fprintf(stdout, "  %s/api/v1/builds/%d/approvals/%s\n\n", step.metadata.ExternalURL, step.metadata.BuildID, step.planID)
+	}
+
+	var timedOut <-chan time.Time
+	if timeout != 0 && !approval.Decided {
+		timer := time.NewTimer(time.Until(approval.RequestedAt.Add(timeout)))
+		defer timer.Stop()
+
+		timedOut = timer.C
+	}
+
+	for !approval.Decided {
+		select {
+		case <-notifier.Notify():
+		case <-timedOut:
+			timedOut = nil
+
+			// a decision made concurrently wins; either way it is reloaded below
+			_, err := step.build.DecideApproval(step.planID, false, "", fmt.Sprintf("timed out after %s", timeout))
+			if err != nil {
+				return false, err
+			}
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+
+		approval, err = step.build.RequestApproval(step.planID, step.plan.Name)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	decision := "rejected"
+	if approval.Approved {
+		decision = "approved"
+	}
+
+	decidedBy := approval.DecidedBy
+	if decidedBy == "" {
+		decidedBy = "system"
+	}
+
+	fmt.Fprintf(stdout, "%s by %s", decision, decidedBy)
+	if approval.Comment != "" {
+		fmt.Fprintf(stdout, ": %s", approval.Comment)
+	}
+	fmt.Fprintln(stdout)
+
+	logger.Info("decided", lager.Data{
+		"approved":   approval.Approved,
+		"decided-by": approval.DecidedBy,
+	})
+
+	delegate.Finished(logger, approval.Approved)
+
+	return approval.Approved, nil
+}
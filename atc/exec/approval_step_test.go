@@ -0,0 +1,239 @@
+package exec_test
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe("ApprovalStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+
+		fakeBuild    *dbfakes.FakeBuild
+		fakeNotifier *dbfakes.FakeNotifier
+		notify       chan struct{}
+
+		approvalPlan atc.ApprovalPlan
+		state        *execfakes.FakeRunState
+
+		stepMetadata = exec.StepMetadata{
+			BuildID:     42,
+			ExternalURL: "https://ci.example.com",
+		}
+
+		stdout *gbytes.Buffer
+
+		planID atc.PlanID = "56"
+
+		stepOk  bool
+		stepErr error
+		done    chan struct{}
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, lagertest.NewTestLogger("approval-step-test"))
+
+		state = new(execfakes.FakeRunState)
+
+		stdout = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StartSpanStub = func(ctx context.Context, _ string, _ tracing.Attrs) (context.Context, trace.Span) {
+			return ctx, tracing.NoopSpan
+		}
+
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		notify = make(chan struct{}, 1)
+		fakeNotifier = new(dbfakes.FakeNotifier)
+		fakeNotifier.NotifyReturns(notify)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.ApprovalNotifierReturns(fakeNotifier, nil)
+		fakeBuild.RequestApprovalReturns(db.BuildApproval{
+			PlanID:      planID,
+			Name:        "deploy",
+			RequestedAt: time.Now(),
+		}, nil)
+
+		approvalPlan = atc.ApprovalPlan{Name: "deploy"}
+	})
+
+	JustBeforeEach(func() {
+		step := exec.NewApprovalStep(
+			planID,
+			approvalPlan,
+			stepMetadata,
+			fakeBuild,
+			fakeDelegateFactory,
+		)
+
+		done = make(chan struct{})
+		go func() {
+			defer close(done)
+			stepOk, stepErr = step.Run(ctx, state)
+		}()
+	})
+
+	AfterEach(func() {
+		cancel()
+		Eventually(done).Should(BeClosed())
+	})
+
+	It("requests an approval for the step", func() {
+		Eventually(fakeBuild.RequestApprovalCallCount).Should(Equal(1))
+		requestedPlanID, name := fakeBuild.RequestApprovalArgsForCall(0)
+		Expect(requestedPlanID).To(Equal(planID))
+		Expect(name).To(Equal("deploy"))
+
+		Expect(fakeBuild.ApprovalNotifierArgsForCall(0)).To(Equal(planID))
+	})
+
+	It("prints how to approve it", func() {
+		Eventually(stdout).Should(gbytes.Say("waiting for approval of deploy"))
+		Eventually(stdout).Should(gbytes.Say("fly approve-build -b 42 -s deploy"))
+		Eventually(stdout).Should(gbytes.Say("https://ci.example.com/api/v1/builds/42/approvals/56"))
+	})
+
+	It("waits for a decision", func() {
+		Consistently(done).ShouldNot(BeClosed())
+		Expect(fakeDelegate.FinishedCallCount()).To(Equal(0))
+	})
+
+	Context("when the approval is approved", func() {
+		JustBeforeEach(func() {
+			Eventually(fakeBuild.RequestApprovalCallCount).Should(Equal(1))
+
+			fakeBuild.RequestApprovalReturns(db.BuildApproval{
+				PlanID:    planID,
+				Name:      "deploy",
+				Decided:   true,
+				Approved:  true,
+				DecidedBy: "some-user",
+				Comment:   "ship it",
+			}, nil)
+
+			notify <- struct{}{}
+		})
+
+		It("succeeds", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeTrue())
+		})
+
+		It("prints who approved it", func() {
+			Eventually(stdout).Should(gbytes.Say("approved by some-user: ship it"))
+		})
+
+		It("closes the notifier", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(fakeNotifier.CloseCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the approval was already rejected before the build was resumed", func() {
+		BeforeEach(func() {
+			fakeBuild.RequestApprovalReturns(db.BuildApproval{
+				PlanID:    planID,
+				Name:      "deploy",
+				Decided:   true,
+				Approved:  false,
+				DecidedBy: "some-user",
+			}, nil)
+		})
+
+		It("fails without waiting", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+
+			Expect(stdout).To(gbytes.Say("rejected by some-user"))
+		})
+	})
+
+	Context("when a timeout is configured", func() {
+		BeforeEach(func() {
+			approvalPlan.Timeout = "100ms"
+
+			fakeBuild.DecideApprovalStub = func(atc.PlanID, bool, string, string) (bool, error) {
+				fakeBuild.RequestApprovalReturns(db.BuildApproval{
+					PlanID:  planID,
+					Name:    "deploy",
+					Decided: true,
+					Comment: "timed out after 100ms",
+				}, nil)
+				return true, nil
+			}
+		})
+
+		It("rejects the approval and fails once it expires", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+
+			Expect(fakeBuild.DecideApprovalCallCount()).To(Equal(1))
+			decidedPlanID, approved, decidedBy, comment := fakeBuild.DecideApprovalArgsForCall(0)
+			Expect(decidedPlanID).To(Equal(planID))
+			Expect(approved).To(BeFalse())
+			Expect(decidedBy).To(BeEmpty())
+			Expect(comment).To(Equal("timed out after 100ms"))
+
+			Expect(stdout).To(gbytes.Say("rejected by system: timed out after 100ms"))
+		})
+
+		Context("when the approval was requested long enough ago", func() {
+			BeforeEach(func() {
+				approvalPlan.Timeout = "1h"
+
+				fakeBuild.RequestApprovalReturns(db.BuildApproval{
+					PlanID:      planID,
+					Name:        "deploy",
+					RequestedAt: time.Now().Add(-2 * time.Hour),
+				}, nil)
+			})
+
+			It("counts the timeout from when it was first requested", func() {
+				Eventually(done).Should(BeClosed())
+				Expect(stepOk).To(BeFalse())
+				Expect(fakeBuild.DecideApprovalCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when the build is aborted", func() {
+		JustBeforeEach(func() {
+			Eventually(fakeBuild.RequestApprovalCallCount).Should(Equal(1))
+			cancel()
+		})
+
+		It("returns the context error", func() {
+			Eventually(done).Should(BeClosed())
+			Expect(stepErr).To(Equal(context.Canceled))
+		})
+	})
+})
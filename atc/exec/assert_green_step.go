@@ -0,0 +1,196 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/tracing"
+)
+
+var ErrAssertGreenOutsidePipeline = errors.New("assert_green can only default to the current pipeline in pipeline builds")
+
+type AssertGreenPipelineNotFoundError struct {
+	Team     string
+	Pipeline atc.PipelineRef
+}
+
+func (err AssertGreenPipelineNotFoundError) Error() string {
+	return fmt.Sprintf("pipeline '%s' of team '%s' not found or not public", err.Pipeline, err.Team)
+}
+
+type AssertGreenJobNotFoundError struct {
+	Pipeline atc.PipelineRef
+	Job      string
+}
+
+func (err AssertGreenJobNotFoundError) Error() string {
+	return fmt.Sprintf("job '%s' not found in pipeline '%s'", err.Job, err.Pipeline)
+}
+
+// AssertGreenStep fails unless the latest finished build of a job has
+// succeeded, which gates the rest of the build on the job being green.
+//
+// Jobs of any pipeline of the build's team can be asserted on, but jobs of
+// another team's pipeline only if the pipeline is public, the same as for
+// viewing them through the API.
+type AssertGreenStep struct {
+	planID          atc.PlanID
+	plan            atc.AssertGreenPlan
+	metadata        StepMetadata
+	build           db.Build
+	delegateFactory BuildStepDelegateFactory
+	teamFactory     db.TeamFactory
+}
+
+func NewAssertGreenStep(
+	planID atc.PlanID,
+	plan atc.AssertGreenPlan,
+	metadata StepMetadata,
+	build db.Build,
+	delegateFactory BuildStepDelegateFactory,
+	teamFactory db.TeamFactory,
+) Step {
+	return &AssertGreenStep{
+		planID:          planID,
+		plan:            plan,
+		metadata:        metadata,
+		build:           build,
+		delegateFactory: delegateFactory,
+		teamFactory:     teamFactory,
+	}
+}
+
+func (step *AssertGreenStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.BuildStepDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "assert_green", tracing.Attrs{
+		"job": step.plan.Job,
+	})
+
+	ok, err := step.run(ctx, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *AssertGreenStep) run(ctx context.Context, delegate BuildStepDelegate) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("assert-green-step", lager.Data{
+		"job":    step.plan.Job,
+		"job-id": step.metadata.JobID,
+	})
+
+	delegate.Initializing(logger)
+	stdout := delegate.Stdout()
+
+	pipeline, err := step.findPipeline()
+	if err != nil {
+		return false, err
+	}
+
+	pipelineRef := atc.PipelineRef{
+		Name:         pipeline.Name(),
+		InstanceVars: pipeline.InstanceVars(),
+	}
+
+	job, found, err := pipeline.Job(step.plan.Job)
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, AssertGreenJobNotFoundError{
+			Pipeline: pipelineRef,
+			Job:      step.plan.Job,
+		}
+	}
+
+	delegate.Starting(logger)
+
+	jobName := fmt.Sprintf("%s/%s/%s", pipeline.TeamName(), pipelineRef, job.Name())
+
+	finished, _, err := job.FinishedAndNextBuild()
+	if err != nil {
+		return false, err
+	}
+
+	if finished == nil {
+		fmt.Fprintf(stdout, "job %s has no finished builds\n", jobName)
+		delegate.Finished(logger, false)
+		return false, nil
+	}
+
+	succeeded := finished.Status() == db.BuildStatusSucceeded
+	if succeeded {
+		fmt.Fprintf(stdout, "job %s is green as of build %s\n", jobName, finished.Name())
+	} else {
+		fmt.Fprintf(stdout, "job %s is not green: build %s %s\n", jobName, finished.Name(), finished.Status())
+	}
+
+	delegate.Finished(logger, succeeded)
+
+	return succeeded, nil
+}
+
+func (step *AssertGreenStep) findPipeline() (db.Pipeline, error) {
+	if step.plan.Pipeline == "" {
+		if step.build.PipelineID() == 0 {
+			return nil, ErrAssertGreenOutsidePipeline
+		}
+
+		pipeline, found, err := step.build.Pipeline()
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return nil, ErrAssertGreenOutsidePipeline
+		}
+
+		return pipeline, nil
+	}
+
+	teamName := step.plan.Team
+	if teamName == "" {
+		teamName = step.metadata.TeamName
+	}
+
+	pipelineRef := atc.PipelineRef{
+		Name:         step.plan.Pipeline,
+		InstanceVars: step.plan.InstanceVars,
+	}
+
+	notFound := AssertGreenPipelineNotFoundError{
+		Team:     teamName,
+		Pipeline: pipelineRef,
+	}
+
+	team, found, err := step.teamFactory.FindTeam(teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, notFound
+	}
+
+	pipeline, found, err := team.Pipeline(pipelineRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, notFound
+	}
+
+	// don't reveal whether private pipelines of other teams exist
+	if team.ID() != step.metadata.TeamID && !pipeline.Public() {
+		return nil, notFound
+	}
+
+	return pipeline, nil
+}
@@ -0,0 +1,290 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe("AssertGreenStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+		fakeTeamFactory     *dbfakes.FakeTeamFactory
+
+		fakeBuild         *dbfakes.FakeBuild
+		fakePipeline      *dbfakes.FakePipeline
+		fakeJob           *dbfakes.FakeJob
+		fakeFinishedBuild *dbfakes.FakeBuild
+
+		assertPlan atc.AssertGreenPlan
+		state      *execfakes.FakeRunState
+
+		stdout *gbytes.Buffer
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, lagertest.NewTestLogger("assert-green-step-test"))
+
+		state = new(execfakes.FakeRunState)
+
+		stdout = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StartSpanStub = func(ctx context.Context, _ string, _ tracing.Attrs) (context.Context, trace.Span) {
+			return ctx, tracing.NoopSpan
+		}
+
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		fakeFinishedBuild = new(dbfakes.FakeBuild)
+		fakeFinishedBuild.NameReturns("7")
+		fakeFinishedBuild.StatusReturns(db.BuildStatusSucceeded)
+
+		fakeJob = new(dbfakes.FakeJob)
+		fakeJob.NameReturns("unit")
+		fakeJob.FinishedAndNextBuildReturns(fakeFinishedBuild, nil, nil)
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.NameReturns("some-pipeline")
+		fakePipeline.TeamNameReturns("some-team")
+		fakePipeline.JobReturns(fakeJob, true, nil)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.PipelineIDReturns(1)
+		fakeBuild.PipelineReturns(fakePipeline, true, nil)
+
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+
+		assertPlan = atc.AssertGreenPlan{
+			Job: "unit",
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step := exec.NewAssertGreenStep(
+			"56",
+			assertPlan,
+			exec.StepMetadata{
+				TeamID:   1,
+				TeamName: "some-team",
+			},
+			fakeBuild,
+			fakeDelegateFactory,
+			fakeTeamFactory,
+		)
+
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	It("looks up the job in the build's pipeline", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(fakePipeline.JobArgsForCall(0)).To(Equal("unit"))
+		Expect(fakeTeamFactory.FindTeamCallCount()).To(BeZero())
+	})
+
+	Context("when the job's latest finished build succeeded", func() {
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeTrue())
+		})
+
+		It("prints the build it is green as of", func() {
+			Expect(stdout).To(gbytes.Say("job some-team/some-pipeline/unit is green as of build 7"))
+		})
+	})
+
+	Context("when the job's latest finished build did not succeed", func() {
+		BeforeEach(func() {
+			fakeFinishedBuild.StatusReturns(db.BuildStatusFailed)
+		})
+
+		It("fails", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeFalse())
+		})
+
+		It("prints the status of the build", func() {
+			Expect(stdout).To(gbytes.Say("job some-team/some-pipeline/unit is not green: build 7 failed"))
+		})
+	})
+
+	Context("when the job has no finished builds", func() {
+		BeforeEach(func() {
+			fakeJob.FinishedAndNextBuildReturns(nil, nil, nil)
+		})
+
+		It("fails", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+			Expect(stdout).To(gbytes.Say("job some-team/some-pipeline/unit has no finished builds"))
+		})
+	})
+
+	Context("when the job is not found", func() {
+		BeforeEach(func() {
+			fakePipeline.JobReturns(nil, false, nil)
+		})
+
+		It("errors", func() {
+			Expect(stepErr).To(Equal(exec.AssertGreenJobNotFoundError{
+				Pipeline: atc.PipelineRef{Name: "some-pipeline"},
+				Job:      "unit",
+			}))
+		})
+	})
+
+	Context("when finding the job's builds fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeJob.FinishedAndNextBuildReturns(nil, nil, disaster)
+		})
+
+		It("errors", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when no pipeline is given outside of a pipeline build", func() {
+		BeforeEach(func() {
+			fakeBuild.PipelineIDReturns(0)
+		})
+
+		It("errors", func() {
+			Expect(stepErr).To(Equal(exec.ErrAssertGreenOutsidePipeline))
+		})
+	})
+
+	Context("when a pipeline is given", func() {
+		var (
+			fakeTeam          *dbfakes.FakeTeam
+			fakeOtherPipeline *dbfakes.FakePipeline
+		)
+
+		BeforeEach(func() {
+			assertPlan.Pipeline = "upstream"
+			assertPlan.InstanceVars = atc.InstanceVars{"branch": "main"}
+
+			fakeOtherPipeline = new(dbfakes.FakePipeline)
+			fakeOtherPipeline.NameReturns("upstream")
+			fakeOtherPipeline.InstanceVarsReturns(atc.InstanceVars{"branch": "main"})
+			fakeOtherPipeline.TeamNameReturns("some-team")
+			fakeOtherPipeline.JobReturns(fakeJob, true, nil)
+
+			fakeTeam = new(dbfakes.FakeTeam)
+			fakeTeam.IDReturns(1)
+			fakeTeam.PipelineReturns(fakeOtherPipeline, true, nil)
+
+			fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+		})
+
+		It("looks up the job in the pipeline of the build's team", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+
+			Expect(fakeTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+			Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{
+				Name:         "upstream",
+				InstanceVars: atc.InstanceVars{"branch": "main"},
+			}))
+			Expect(fakeOtherPipeline.JobArgsForCall(0)).To(Equal("unit"))
+			Expect(stdout).To(gbytes.Say("job some-team/upstream/branch:main/unit is green"))
+		})
+
+		Context("when the pipeline is not found", func() {
+			BeforeEach(func() {
+				fakeTeam.PipelineReturns(nil, false, nil)
+			})
+
+			It("errors", func() {
+				Expect(stepErr).To(BeAssignableToTypeOf(exec.AssertGreenPipelineNotFoundError{}))
+			})
+		})
+
+		Context("when the pipeline belongs to another team", func() {
+			BeforeEach(func() {
+				assertPlan.Team = "other-team"
+				fakeTeam.IDReturns(2)
+				fakeOtherPipeline.TeamNameReturns("other-team")
+			})
+
+			It("looks up the pipeline in that team", func() {
+				Expect(fakeTeamFactory.FindTeamArgsForCall(0)).To(Equal("other-team"))
+			})
+
+			Context("when the pipeline is public", func() {
+				BeforeEach(func() {
+					fakeOtherPipeline.PublicReturns(true)
+				})
+
+				It("succeeds", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stepOk).To(BeTrue())
+				})
+			})
+
+			Context("when the pipeline is not public", func() {
+				BeforeEach(func() {
+					fakeOtherPipeline.PublicReturns(false)
+				})
+
+				It("errors as if the pipeline did not exist", func() {
+					Expect(stepErr).To(Equal(exec.AssertGreenPipelineNotFoundError{
+						Team: "other-team",
+						Pipeline: atc.PipelineRef{
+							Name:         "upstream",
+							InstanceVars: atc.InstanceVars{"branch": "main"},
+						},
+					}))
+				})
+
+				It("does not look at the job", func() {
+					Expect(fakeOtherPipeline.JobCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the team is not found", func() {
+			BeforeEach(func() {
+				fakeTeamFactory.FindTeamReturns(nil, false, nil)
+			})
+
+			It("errors", func() {
+				Expect(stepErr).To(BeAssignableToTypeOf(exec.AssertGreenPipelineNotFoundError{}))
+			})
+		})
+	})
+})
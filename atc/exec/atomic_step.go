@@ -0,0 +1,83 @@
+package exec
+
+import (
+	"context"
+)
+
+// AtomicPut is a put run by an AtomicStep, as the steps running each phase
+// of its two-phase commit and the get of the version it created, if any.
+type AtomicPut struct {
+	Stage    Step
+	Commit   Step
+	Rollback Step
+
+	Get Step
+}
+
+// AtomicStep publishes puts all or nothing.
+type AtomicStep struct {
+	puts []AtomicPut
+}
+
+// Atomic constructs an AtomicStep.
+func Atomic(puts []AtomicPut) AtomicStep {
+	return AtomicStep{
+		puts: puts,
+	}
+}
+
+// Run stages every put in order, then commits every one of them. Once they
+// are all committed, the gets of the versions they created are run.
+//
+// If a put fails to be staged, the ones staged before it are rolled back. If
+// a put fails to be committed, it and the ones which haven't been committed
+// yet are rolled back; the ones already committed stay published. Rolling
+// back still happens when the step is aborted.
+func (step AtomicStep) Run(ctx context.Context, state RunState) (bool, error) {
+	for i, put := range step.puts {
+		ok, err := put.Stage.Run(ctx, state)
+		if err != nil || !ok {
+			step.rollBack(ctx, state, step.puts[:i])
+			return false, err
+		}
+	}
+
+	for i, put := range step.puts {
+		ok, err := put.Commit.Run(ctx, state)
+		if err != nil || !ok {
+			step.rollBack(ctx, state, step.puts[i:])
+			return false, err
+		}
+	}
+
+	for _, put := range step.puts {
+		if put.Get == nil {
+			continue
+		}
+
+		ok, err := put.Get.Run(ctx, state)
+		if err != nil {
+			return false, err
+		}
+
+		if !ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (step AtomicStep) rollBack(ctx context.Context, state RunState, puts []AtomicPut) {
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = cleanupContext(ctx)
+		defer cancel()
+	}
+
+	for _, put := range puts {
+		// a failure to roll back is logged by the step itself; the rest are
+		// still rolled back
+		put.Rollback.Run(ctx, state)
+	}
+}
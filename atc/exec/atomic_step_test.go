@@ -0,0 +1,227 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Atomic Step", func() {
+	type fakePut struct {
+		stage    *execfakes.FakeStep
+		commit   *execfakes.FakeStep
+		rollback *execfakes.FakeStep
+		get      *execfakes.FakeStep
+	}
+
+	var (
+		ctx    context.Context
+		cancel func()
+
+		state *execfakes.FakeRunState
+
+		puts []fakePut
+
+		// the phases in the order they were run, e.g. "stage 0"
+		ran []string
+
+		atomicStep exec.Step
+
+		stepOk  bool
+		stepErr error
+	)
+
+	newFakeStep := func(name string) *execfakes.FakeStep {
+		step := new(execfakes.FakeStep)
+		step.RunStub = func(context.Context, exec.RunState) (bool, error) {
+			ran = append(ran, name)
+			return true, nil
+		}
+
+		return step
+	}
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		state = new(execfakes.FakeRunState)
+
+		ran = nil
+		puts = nil
+		for _, i := range []string{"0", "1", "2"} {
+			puts = append(puts, fakePut{
+				stage:    newFakeStep("stage " + i),
+				commit:   newFakeStep("commit " + i),
+				rollback: newFakeStep("rollback " + i),
+				get:      newFakeStep("get " + i),
+			})
+		}
+	})
+
+	JustBeforeEach(func() {
+		var atomicPuts []exec.AtomicPut
+		for _, put := range puts {
+			atomicPut := exec.AtomicPut{
+				Stage:    put.stage,
+				Commit:   put.commit,
+				Rollback: put.rollback,
+			}
+
+			if put.get != nil {
+				atomicPut.Get = put.get
+			}
+
+			atomicPuts = append(atomicPuts, atomicPut)
+		}
+
+		atomicStep = exec.Atomic(atomicPuts)
+
+		stepOk, stepErr = atomicStep.Run(ctx, state)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	Context("when every put is staged and committed", func() {
+		It("stages every put before committing any, then gets their versions", func() {
+			Expect(ran).To(Equal([]string{
+				"stage 0", "stage 1", "stage 2",
+				"commit 0", "commit 1", "commit 2",
+				"get 0", "get 1", "get 2",
+			}))
+		})
+
+		It("runs the steps with the run state", func() {
+			_, runState := puts[0].stage.RunArgsForCall(0)
+			Expect(runState).To(Equal(state))
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+	})
+
+	Context("when a put fails to be staged", func() {
+		BeforeEach(func() {
+			puts[1].stage.RunStub = func(context.Context, exec.RunState) (bool, error) {
+				ran = append(ran, "stage 1")
+				return false, nil
+			}
+		})
+
+		It("rolls back the puts staged before it and commits nothing", func() {
+			Expect(ran).To(Equal([]string{
+				"stage 0", "stage 1",
+				"rollback 0",
+			}))
+		})
+
+		It("fails", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+		})
+	})
+
+	Context("when staging a put errors", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			puts[2].stage.RunReturns(false, disaster)
+		})
+
+		It("rolls back the puts staged before it", func() {
+			Expect(ran).To(Equal([]string{
+				"stage 0", "stage 1",
+				"rollback 0", "rollback 1",
+			}))
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when a put fails to be committed", func() {
+		BeforeEach(func() {
+			puts[1].commit.RunStub = func(context.Context, exec.RunState) (bool, error) {
+				ran = append(ran, "commit 1")
+				return false, nil
+			}
+		})
+
+		It("rolls back it and the puts not committed yet", func() {
+			Expect(ran).To(Equal([]string{
+				"stage 0", "stage 1", "stage 2",
+				"commit 0", "commit 1",
+				"rollback 1", "rollback 2",
+			}))
+		})
+
+		It("fails", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+		})
+	})
+
+	Context("when rolling back a put fails", func() {
+		BeforeEach(func() {
+			puts[2].stage.RunReturns(false, nil)
+			puts[0].rollback.RunReturns(false, errors.New("nope"))
+		})
+
+		It("still rolls back the rest", func() {
+			Expect(puts[1].rollback.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when aborted while staging", func() {
+		BeforeEach(func() {
+			puts[1].stage.RunStub = func(context.Context, exec.RunState) (bool, error) {
+				cancel()
+				return false, context.Canceled
+			}
+		})
+
+		It("rolls back the staged puts with a context which isn't cancelled", func() {
+			Expect(puts[0].rollback.RunCallCount()).To(Equal(1))
+
+			rollbackCtx, _ := puts[0].rollback.RunArgsForCall(0)
+			Expect(rollbackCtx.Err()).ToNot(HaveOccurred())
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(context.Canceled))
+		})
+	})
+
+	Context("when a put has no get", func() {
+		BeforeEach(func() {
+			puts[1].get = nil
+		})
+
+		It("gets the versions of the others", func() {
+			Expect(ran).To(Equal([]string{
+				"stage 0", "stage 1", "stage 2",
+				"commit 0", "commit 1", "commit 2",
+				"get 0", "get 2",
+			}))
+		})
+	})
+
+	Context("when a get fails", func() {
+		BeforeEach(func() {
+			puts[0].get.RunReturns(false, nil)
+		})
+
+		It("fails without rolling back", func() {
+			Expect(stepOk).To(BeFalse())
+			Expect(puts[0].rollback.RunCallCount()).To(BeZero())
+		})
+	})
+})
@@ -24,7 +24,8 @@ type Repository struct {
 	repo  map[ArtifactName]runtime.Artifact
 	repoL sync.RWMutex
 
-	parent *Repository
+	parent       *Repository
+	writeThrough bool
 }
 
 // NewArtifactRepository constructs a new repository.
@@ -47,6 +48,10 @@ func (repo *Repository) RegisterArtifact(name ArtifactName, artifact Registerabl
 	repo.repoL.Lock()
 	repo.repo[name] = artifact
 	repo.repoL.Unlock()
+
+	if repo.writeThrough {
+		repo.parent.RegisterArtifact(name, artifact)
+	}
 }
 
 // SourceFor looks up a Source for the given ArtifactName. Consumers of
@@ -88,6 +93,29 @@ func (repo *Repository) NewLocalScope() *Repository {
 	return child
 }
 
+// NewTrackingScope constructs a child repository which registers artifacts
+// with its parent as they are registered with it, while keeping track of
+// which artifacts were registered through it.
+func (repo *Repository) NewTrackingScope() *Repository {
+	child := repo.NewLocalScope()
+	child.writeThrough = true
+	return child
+}
+
+// RegisteredArtifacts returns the artifacts registered directly with this
+// repository, excluding any from its parent.
+func (repo *Repository) RegisteredArtifacts() map[ArtifactName]runtime.Artifact {
+	result := make(map[ArtifactName]runtime.Artifact)
+
+	repo.repoL.RLock()
+	for name, artifact := range repo.repo {
+		result[name] = artifact
+	}
+	repo.repoL.RUnlock()
+
+	return result
+}
+
 func (repo *Repository) Parent() *Repository {
 	return repo.parent
 }
@@ -106,6 +106,47 @@ var _ = Describe("ArtifactRepository", func() {
 			})
 		})
 
+		Describe("NewTrackingScope", func() {
+			var child *Repository
+
+			BeforeEach(func() {
+				child = repo.NewTrackingScope()
+			})
+
+			It("maintains a reference to the parent", func() {
+				Expect(child.Parent()).To(Equal(repo))
+			})
+
+			Context("when an artifact is registered", func() {
+				var secondArtifact *runtimefakes.FakeArtifact
+
+				BeforeEach(func() {
+					secondArtifact = new(runtimefakes.FakeArtifact)
+					secondArtifact.IDReturns("some-second")
+
+					child.RegisterArtifact("second-artifact", secondArtifact)
+				})
+
+				It("is present in both the child and the parent", func() {
+					Expect(child.AsMap()).To(Equal(map[ArtifactName]runtime.Artifact{
+						"first-artifact":  firstArtifact,
+						"second-artifact": secondArtifact,
+					}))
+
+					Expect(repo.AsMap()).To(Equal(map[ArtifactName]runtime.Artifact{
+						"first-artifact":  firstArtifact,
+						"second-artifact": secondArtifact,
+					}))
+				})
+
+				It("is the only artifact registered with the child", func() {
+					Expect(child.RegisteredArtifacts()).To(Equal(map[ArtifactName]runtime.Artifact{
+						"second-artifact": secondArtifact,
+					}))
+				})
+			})
+		})
+
 		Context("when a second artifact is registered", func() {
 			var secondArtifact *runtimefakes.FakeArtifact
 
@@ -45,3 +45,23 @@ type SetPipelineStepDelegate interface {
 	BuildStepDelegate
 	SetPipelineChanged(lager.Logger, bool)
 }
+
+// resourceTypeImage returns the image a step runs a custom resource type in.
+func resourceTypeImage(resourceType atc.VersionedResourceType, stepTags atc.Tags, stepAnyTags atc.Tags) atc.ImageResource {
+	image := atc.ImageResource{
+		Name:    resourceType.Name,
+		Type:    resourceType.Type,
+		Source:  resourceType.Source,
+		Params:  resourceType.Params,
+		Version: resourceType.Version,
+		Tags:    resourceType.Tags,
+
+		CheckInterval: resourceType.ReuseImageFor,
+	}
+	if len(image.Tags) == 0 {
+		image.Tags = stepTags
+		image.AnyTags = stepAnyTags
+	}
+
+	return image
+}
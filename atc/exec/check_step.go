@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -19,6 +20,26 @@ import (
 	"github.com/concourse/concourse/tracing"
 )
 
+// Properties the check container is labelled with, so that it can be traced
+// back to what it was checking. A check container is shared by all scopes of
+// the same resource config, so these reflect the check which created it; the
+// container a scope was last checked in is recorded on the scope itself.
+const (
+	CheckScopeIDPropertyName      = "concourse:resource-config-scope-id"
+	CheckResourcePropertyName     = "concourse:resource-name"
+	CheckResourceTypePropertyName = "concourse:resource-type-name"
+	CheckTeamPropertyName         = "concourse:team-name"
+)
+
+// checkVersionsChunkSize is the number of versions emitted by a check which
+// are saved at once, so that a check emitting a huge number of versions
+// doesn't have to be held in memory all at once.
+const checkVersionsChunkSize = 500
+
+// errCheckVersionsLimitReached stops a check which has emitted as many
+// versions as a single check is allowed to save.
+var errCheckVersionsLimitReached = errors.New("check versions limit reached")
+
 type CheckStep struct {
 	planID                atc.PlanID
 	plan                  atc.CheckPlan
@@ -30,6 +51,8 @@ type CheckStep struct {
 	delegateFactory       CheckDelegateFactory
 	workerPool            worker.Pool
 	defaultCheckTimeout   time.Duration
+	maxVersionsPerCheck   int
+	checkFlights          CheckFlightGroup
 }
 
 //counterfeiter:generate . CheckDelegateFactory
@@ -44,6 +67,18 @@ type CheckDelegate interface {
 	FindOrCreateScope(db.ResourceConfig) (db.ResourceConfigScope, error)
 	WaitToRun(context.Context, db.ResourceConfigScope) (lock.Lock, bool, error)
 	PointToCheckedConfig(db.ResourceConfigScope) error
+
+	// Scheduled returns whether the check was scheduled periodically, rather
+	// than triggered by a webhook, by hand or by a pending build.
+	Scheduled() bool
+}
+
+//counterfeiter:generate . CheckFlightGroup
+
+// CheckFlightGroup coalesces concurrent calls of the same key, as a
+// singleflight.Group does.
+type CheckFlightGroup interface {
+	Do(key string, fn func() (interface{}, error)) (interface{}, error, bool)
 }
 
 func NewCheckStep(
@@ -57,6 +92,8 @@ func NewCheckStep(
 	pool worker.Pool,
 	delegateFactory CheckDelegateFactory,
 	defaultCheckTimeout time.Duration,
+	maxVersionsPerCheck int,
+	checkFlights CheckFlightGroup,
 ) Step {
 	return &CheckStep{
 		planID:                planID,
@@ -69,6 +106,8 @@ func NewCheckStep(
 		strategy:              strategy,
 		delegateFactory:       delegateFactory,
 		defaultCheckTimeout:   defaultCheckTimeout,
+		maxVersionsPerCheck:   maxVersionsPerCheck,
+		checkFlights:          checkFlights,
 	}
 }
 
@@ -121,6 +160,11 @@ func (step *CheckStep) run(ctx context.Context, state RunState, delegate CheckDe
 		return false, fmt.Errorf("resource types creds evaluation: %w", err)
 	}
 
+	proxy, err := creds.NewProxy(state, step.plan.Proxy).Evaluate()
+	if err != nil {
+		return false, fmt.Errorf("proxy creds evaluation: %w", err)
+	}
+
 	resourceConfig, err := step.resourceConfigFactory.FindOrCreateResourceConfig(step.plan.Type, source, resourceTypes)
 	if err != nil {
 		return false, fmt.Errorf("create resource config: %w", err)
@@ -136,111 +180,363 @@ func (step *CheckStep) run(ctx context.Context, state RunState, delegate CheckDe
 		return false, fmt.Errorf("create resource config scope: %w", err)
 	}
 
-	lock, run, err := delegate.WaitToRun(ctx, scope)
+	var result checkScopeResult
+	if step.checkFlights != nil && step.plan.FromVersion == nil {
+		result, err = step.checkScopeOnce(ctx, logger, delegate, scope, timeout, resourceConfig, source, resourceTypes, proxy)
+	} else {
+		result, err = step.checkScope(ctx, logger, delegate, scope, timeout, resourceConfig, source, resourceTypes, proxy)
+	}
 	if err != nil {
-		return false, fmt.Errorf("wait: %w", err)
+		return false, err
 	}
 
-	if run {
+	if result.lock != nil {
 		defer func() {
-			err := lock.Release()
+			err := result.lock.Release()
 			if err != nil {
 				logger.Error("failed-to-release-lock", err)
 			}
 		}()
+	}
 
-		fromVersion := step.plan.FromVersion
-		if fromVersion == nil {
-			latestVersion, found, err := scope.LatestVersion()
-			if err != nil {
-				return false, fmt.Errorf("get latest version: %w", err)
-			}
+	if result.latestVersion != nil {
+		state.StoreResult(step.planID, result.latestVersion)
+	}
 
-			if found {
-				fromVersion = atc.Version(latestVersion.Version())
-			}
+	err = delegate.PointToCheckedConfig(scope)
+	if err != nil {
+		return false, fmt.Errorf("update resource config scope: %w", err)
+	}
+
+	if result.checkErr != nil {
+		if errors.Is(result.checkErr, context.DeadlineExceeded) {
+			delegate.Errored(logger, TimeoutLogMessage)
+			return false, nil
 		}
 
-		metric.Metrics.ChecksStarted.Inc()
+		if errors.As(result.checkErr, &runtime.ErrResourceScriptOOMKilled{}) {
+			delegate.Errored(logger, OOMKilledLogMessage)
+			return false, nil
+		}
 
-		_, err = scope.UpdateLastCheckStartTime()
-		if err != nil {
-			return false, fmt.Errorf("update check end time: %w", err)
+		if errors.As(result.checkErr, &runtime.ErrResourceScriptFailed{}) {
+			delegate.Finished(logger, false)
+			return false, nil
 		}
 
-		result, runErr := step.runCheck(ctx, logger, delegate, timeout, resourceConfig, source, resourceTypes, fromVersion)
-		if runErr != nil {
-			metric.Metrics.ChecksFinishedWithError.Inc()
+		return false, fmt.Errorf("run check: %w", result.checkErr)
+	}
 
-			if _, err := scope.UpdateLastCheckEndTime(false); err != nil {
-				return false, fmt.Errorf("update check end time: %w", err)
-			}
+	delegate.Finished(logger, true)
 
-			if err := delegate.PointToCheckedConfig(scope); err != nil {
-				return false, fmt.Errorf("update resource config scope: %w", err)
-			}
+	return true, nil
+}
 
-			if errors.Is(runErr, context.DeadlineExceeded) {
-				delegate.Errored(logger, TimeoutLogMessage)
-				return false, nil
-			}
+type checkScopeResult struct {
+	// The latest version of the scope, if any.
+	latestVersion atc.Version
 
-			if errors.As(runErr, &runtime.ErrResourceScriptFailed{}) {
-				delegate.Finished(logger, false)
-				return false, nil
-			}
+	// The error returned by running the check, if it failed.
+	checkErr error
+
+	// The lock held while running the check, if it ran. It must be released
+	// by the caller once done with the scope.
+	lock lock.Lock
 
-			return false, fmt.Errorf("run check: %w", runErr)
+	// Whether the check was run, rather than skipped because the scope had
+	// been checked recently enough.
+	ran bool
+}
+
+// checkScopeOnce coalesces concurrent checks of the same scope in this
+// process, so that only one of them runs the check and the rest share its
+// result. A check which wasn't scheduled, e.g. one triggered by hand, only
+// shares the result of a check which ran, as it must not be answered with
+// the versions found by an earlier check.
+func (step *CheckStep) checkScopeOnce(
+	ctx context.Context,
+	logger lager.Logger,
+	delegate CheckDelegate,
+	scope db.ResourceConfigScope,
+	timeout time.Duration,
+	resourceConfig db.ResourceConfig,
+	source atc.Source,
+	resourceTypes atc.VersionedResourceTypes,
+	proxy atc.ProxyConfig,
+) (checkScopeResult, error) {
+	var ran bool
+	val, err, shared := step.checkFlights.Do(strconv.Itoa(scope.ID()), func() (interface{}, error) {
+		ran = true
+		return step.checkScope(ctx, logger, delegate, scope, timeout, resourceConfig, source, resourceTypes, proxy)
+	})
+
+	var result checkScopeResult
+	if err == nil {
+		result = val.(checkScopeResult)
+	}
+
+	if !ran {
+		// the lock belongs to the step that ran the check
+		result.lock = nil
+	}
+
+	if shared {
+		logger.Debug("shared-check-result", lager.Data{"scope": scope.ID()})
+
+		// the check we joined was aborted along with its build; that says
+		// nothing about this one, so run it ourselves
+		aborted := errors.Is(err, context.Canceled) || errors.Is(result.checkErr, context.Canceled)
+		if aborted && ctx.Err() == nil {
+			return step.checkScope(ctx, logger, delegate, scope, timeout, resourceConfig, source, resourceTypes, proxy)
 		}
 
-		metric.Metrics.ChecksFinishedWithSuccess.Inc()
+		// the check we joined only looked up the latest version, which is
+		// all a scheduled check wants but not one that has to be run
+		if !ran && err == nil && !result.ran && !delegate.Scheduled() {
+			return step.checkScope(ctx, logger, delegate, scope, timeout, resourceConfig, source, resourceTypes, proxy)
+		}
+	}
+
+	return result, err
+}
+
+func (step *CheckStep) checkScope(
+	ctx context.Context,
+	logger lager.Logger,
+	delegate CheckDelegate,
+	scope db.ResourceConfigScope,
+	timeout time.Duration,
+	resourceConfig db.ResourceConfig,
+	source atc.Source,
+	resourceTypes atc.VersionedResourceTypes,
+	proxy atc.ProxyConfig,
+) (checkScopeResult, error) {
+	lock, run, err := delegate.WaitToRun(ctx, scope)
+	if err != nil {
+		return checkScopeResult{}, fmt.Errorf("wait: %w", err)
+	}
 
-		err = scope.SaveVersions(db.NewSpanContext(ctx), result.Versions)
+	if !run {
+		latestVersion, found, err := scope.LatestVersion()
 		if err != nil {
-			return false, fmt.Errorf("save versions: %w", err)
+			return checkScopeResult{}, fmt.Errorf("get latest version: %w", err)
 		}
 
-		if len(result.Versions) > 0 {
-			state.StoreResult(step.planID, result.Versions[len(result.Versions)-1])
+		if !found {
+			return checkScopeResult{}, nil
 		}
 
-		_, err = scope.UpdateLastCheckEndTime(true)
-		if err != nil {
-			return false, fmt.Errorf("update check end time: %w", err)
+		return checkScopeResult{latestVersion: atc.Version(latestVersion.Version())}, nil
+	}
+
+	result, err := step.checkAndSave(ctx, logger, delegate, scope, timeout, resourceConfig, source, resourceTypes, proxy)
+	if err != nil {
+		if releaseErr := lock.Release(); releaseErr != nil {
+			logger.Error("failed-to-release-lock", releaseErr)
 		}
-	} else {
+
+		return checkScopeResult{}, err
+	}
+
+	result.lock = lock
+	result.ran = true
+
+	return result, nil
+}
+
+func (step *CheckStep) checkAndSave(
+	ctx context.Context,
+	logger lager.Logger,
+	delegate CheckDelegate,
+	scope db.ResourceConfigScope,
+	timeout time.Duration,
+	resourceConfig db.ResourceConfig,
+	source atc.Source,
+	resourceTypes atc.VersionedResourceTypes,
+	proxy atc.ProxyConfig,
+) (checkScopeResult, error) {
+	fromVersion := step.plan.FromVersion
+	if fromVersion == nil {
 		latestVersion, found, err := scope.LatestVersion()
 		if err != nil {
-			return false, fmt.Errorf("get latest version: %w", err)
+			return checkScopeResult{}, fmt.Errorf("get latest version: %w", err)
 		}
 
 		if found {
-			state.StoreResult(step.planID, atc.Version(latestVersion.Version()))
+			fromVersion = atc.Version(latestVersion.Version())
 		}
 	}
 
-	err = delegate.PointToCheckedConfig(scope)
+	metric.Metrics.ChecksStarted.Inc()
+
+	_, err := scope.UpdateLastCheckStartTime()
 	if err != nil {
-		return false, fmt.Errorf("update resource config scope: %w", err)
+		return checkScopeResult{}, fmt.Errorf("update check end time: %w", err)
 	}
 
-	delegate.Finished(logger, true)
+	// the versions are saved in chunks as the check emits them, so a check
+	// which fails part way through may still have saved some of them
+	var (
+		pending       []atc.Version
+		saved         int
+		discovered    int
+		saveErr       error
+		latestVersion atc.Version
+	)
 
-	return true, nil
+	identityFields := step.identityFields()
+
+	saveVersions := func() error {
+		var (
+			newVersions int
+			err         error
+		)
+		if len(identityFields) > 0 {
+			newVersions, err = scope.SaveVersionsIdentifiedBy(db.NewSpanContext(ctx), pending, identityFields)
+		} else {
+			newVersions, err = scope.SaveVersions(db.NewSpanContext(ctx), pending)
+		}
+		if err != nil {
+			saveErr = err
+			return err
+		}
+
+		saved += len(pending)
+		discovered += newVersions
+		pending = nil
+
+		return nil
+	}
+
+	handleVersion := func(version atc.Version) error {
+		if step.maxVersionsPerCheck > 0 && saved+len(pending) >= step.maxVersionsPerCheck {
+			return errCheckVersionsLimitReached
+		}
+
+		pending = append(pending, version)
+		latestVersion = version
+
+		if len(pending) < checkVersionsChunkSize {
+			return nil
+		}
+
+		return saveVersions()
+	}
+
+	result, runErr := step.runCheck(ctx, logger, delegate, scope, timeout, resourceConfig, source, resourceTypes, proxy, fromVersion, handleVersion)
+
+	if saveErr != nil {
+		return checkScopeResult{}, fmt.Errorf("save versions: %w", saveErr)
+	}
+
+	if errors.Is(runErr, errCheckVersionsLimitReached) {
+		// the versions are emitted oldest first, so the next check picks up
+		// from the last one saved
+		logger.Info("check-versions-limit-reached", lager.Data{"limit": step.maxVersionsPerCheck})
+		fmt.Fprintf(delegate.Stderr(), "\x1b[1;33mWARNING: check stopped after saving %d versions; the next check picks up from there\x1b[0m\n", step.maxVersionsPerCheck)
+
+		runErr = nil
+	}
+
+	if result.ContainerHandle != "" {
+		_, err := scope.UpdateLastCheckContainerHandle(result.ContainerHandle)
+		if err != nil {
+			return checkScopeResult{}, fmt.Errorf("update check container handle: %w", err)
+		}
+	}
+
+	if runErr != nil && errors.Is(runErr, context.Canceled) {
+		// a canceled check says nothing about the resource, so it is not
+		// counted as a failure
+		if _, err := scope.UpdateLastCheckCanceled(); err != nil {
+			return checkScopeResult{}, fmt.Errorf("update check end time: %w", err)
+		}
+
+		return checkScopeResult{checkErr: runErr}, nil
+	}
+
+	if runErr != nil {
+		metric.Metrics.ChecksFinishedWithError.Inc()
+		step.emitVersionsDiscovered(logger, discovered)
+
+		if _, err := scope.UpdateLastCheckError(runErr.Error()); err != nil {
+			return checkScopeResult{}, fmt.Errorf("update check error: %w", err)
+		}
+
+		if err := step.updateLastCheckEndTime(delegate, scope, false); err != nil {
+			return checkScopeResult{}, err
+		}
+
+		return checkScopeResult{checkErr: runErr}, nil
+	}
+
+	metric.Metrics.ChecksFinishedWithSuccess.Inc()
+
+	if len(pending) > 0 || saved == 0 {
+		err = saveVersions()
+		if err != nil {
+			return checkScopeResult{}, fmt.Errorf("save versions: %w", err)
+		}
+	}
+
+	step.emitVersionsDiscovered(logger, discovered)
+
+	err = step.updateLastCheckEndTime(delegate, scope, true)
+	if err != nil {
+		return checkScopeResult{}, err
+	}
+
+	return checkScopeResult{latestVersion: latestVersion}, nil
+}
+
+// emitVersionsDiscovered reports how many new versions a check of a pipeline
+// resource saved, including none, so that resources which stop producing
+// versions stand out as much as those flooding them.
+func (step *CheckStep) emitVersionsDiscovered(logger lager.Logger, discovered int) {
+	if step.plan.Resource == "" {
+		return
+	}
+
+	metric.ResourceVersionsDiscovered{
+		TeamName:     step.metadata.TeamName,
+		PipelineName: step.metadata.PipelineName,
+		ResourceName: step.plan.Resource,
+		Count:        discovered,
+	}.Emit(logger, metric.Metrics)
+}
+
+// updateLastCheckEndTime records the end of the check, and separately that
+// of the last scheduled check if it was one, which the check TTL counts from.
+func (step *CheckStep) updateLastCheckEndTime(delegate CheckDelegate, scope db.ResourceConfigScope, succeeded bool) error {
+	if _, err := scope.UpdateLastCheckEndTime(succeeded); err != nil {
+		return fmt.Errorf("update check end time: %w", err)
+	}
+
+	if delegate.Scheduled() {
+		if _, err := scope.UpdateLastScheduledCheckEndTime(succeeded); err != nil {
+			return fmt.Errorf("update scheduled check end time: %w", err)
+		}
+	}
+
+	return nil
 }
 
 func (step *CheckStep) runCheck(
 	ctx context.Context,
 	logger lager.Logger,
 	delegate CheckDelegate,
+	scope db.ResourceConfigScope,
 	timeout time.Duration,
 	resourceConfig db.ResourceConfig,
 	source atc.Source,
 	resourceTypes atc.VersionedResourceTypes,
+	proxy atc.ProxyConfig,
 	fromVersion atc.Version,
+	handleVersion resource.VersionHandler,
 ) (worker.CheckResult, error) {
 	workerSpec := worker.WorkerSpec{
 		Tags:         step.plan.Tags,
+		AnyTags:      step.plan.AnyTags,
 		TeamID:       step.metadata.TeamID,
 		ResourceType: step.plan.VersionedResourceTypes.Base(step.plan.Type),
 	}
@@ -248,17 +544,7 @@ func (step *CheckStep) runCheck(
 	var imageSpec worker.ImageSpec
 	resourceType, found := step.plan.VersionedResourceTypes.Lookup(step.plan.Type)
 	if found {
-		image := atc.ImageResource{
-			Name:    resourceType.Name,
-			Type:    resourceType.Type,
-			Source:  resourceType.Source,
-			Params:  resourceType.Params,
-			Version: resourceType.Version,
-			Tags:    resourceType.Tags,
-		}
-		if len(image.Tags) == 0 {
-			image.Tags = step.plan.Tags
-		}
+		image := resourceTypeImage(resourceType, step.plan.Tags, step.plan.AnyTags)
 
 		types := step.plan.VersionedResourceTypes.Without(step.plan.Type)
 
@@ -279,7 +565,16 @@ func (step *CheckStep) runCheck(
 		BindMounts: []worker.BindMountSource{
 			&worker.CertsVolumeMount{Logger: logger},
 		},
-		Env: step.metadata.Env(),
+		Env: append(step.metadata.Env(), proxy.Env()...),
+
+		Properties: step.containerProperties(scope),
+	}
+
+	if step.plan.Limits != nil {
+		containerSpec.Limits = worker.ContainerLimits{
+			CPU:    (*uint64)(step.plan.Limits.CPU),
+			Memory: (*uint64)(step.plan.Limits.Memory),
+		}
 	}
 	tracing.Inject(ctx, &containerSpec)
 
@@ -333,9 +628,27 @@ func (step *CheckStep) runCheck(
 		processSpec,
 		delegate,
 		checkable,
+		handleVersion,
 	)
 }
 
+func (step *CheckStep) containerProperties(scope db.ResourceConfigScope) map[string]string {
+	properties := map[string]string{
+		CheckScopeIDPropertyName: strconv.Itoa(scope.ID()),
+		CheckTeamPropertyName:    step.metadata.TeamName,
+	}
+
+	if step.plan.Resource != "" {
+		properties[CheckResourcePropertyName] = step.plan.Resource
+	}
+
+	if step.plan.ResourceType != "" {
+		properties[CheckResourceTypePropertyName] = step.plan.ResourceType
+	}
+
+	return properties
+}
+
 func (step *CheckStep) containerOwner(resourceConfig db.ResourceConfig) db.ContainerOwner {
 	if step.plan.Resource == "" {
 		return db.NewBuildStepContainerOwner(
@@ -359,3 +672,15 @@ func (step *CheckStep) containerOwner(resourceConfig db.ResourceConfig) db.Conta
 		expires,
 	)
 }
+
+// identityFields returns the fields which identify the versions of the
+// resource type being checked, if its versions aren't identified by all of
+// their fields.
+func (step *CheckStep) identityFields() []string {
+	resourceType, found := step.plan.VersionedResourceTypes.Lookup(step.plan.Type)
+	if !found {
+		return nil
+	}
+
+	return resourceType.IdentityFields
+}
@@ -0,0 +1,65 @@
+package exec
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type cleanupDeadlineKey struct{}
+
+// cleanupDeadline is the point in time after which the cleanup of steps
+// aborted by a fail-fast in_parallel step is cut short. It is only set once
+// the in_parallel step aborts its steps, by the first step to fail.
+type cleanupDeadline struct {
+	parent *cleanupDeadline
+
+	lock sync.Mutex
+	at   time.Time
+}
+
+func withCleanupDeadline(ctx context.Context) (context.Context, *cleanupDeadline) {
+	parent, _ := ctx.Value(cleanupDeadlineKey{}).(*cleanupDeadline)
+
+	deadline := &cleanupDeadline{parent: parent}
+
+	return context.WithValue(ctx, cleanupDeadlineKey{}, deadline), deadline
+}
+
+func (d *cleanupDeadline) set(at time.Time) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.at.IsZero() {
+		d.at = at
+	}
+}
+
+func (d *cleanupDeadline) get() (time.Time, bool) {
+	for ; d != nil; d = d.parent {
+		d.lock.Lock()
+		at := d.at
+		d.lock.Unlock()
+
+		if !at.IsZero() {
+			return at, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// cleanupContext returns the context to run a hook with once ctx has been
+// cancelled, so that the hook isn't immediately cancelled too. If ctx was
+// cancelled by a fail-fast in_parallel step with a grace period, the hook is
+// cancelled once the grace period is over.
+func cleanupContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, _ := ctx.Value(cleanupDeadlineKey{}).(*cleanupDeadline)
+
+	at, found := deadline.get()
+	if !found {
+		return context.Background(), func() {}
+	}
+
+	return context.WithDeadline(context.Background(), at)
+}
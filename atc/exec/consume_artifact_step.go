@@ -0,0 +1,136 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/tracing"
+)
+
+type SharedArtifactNotFoundError struct {
+	Team string
+	Name string
+}
+
+func (err SharedArtifactNotFoundError) Error() string {
+	return fmt.Sprintf("shared artifact '%s' of team '%s' not found or not granted to this team", err.Name, err.Team)
+}
+
+// ConsumeArtifactStep fetches an artifact published by a PublishArtifactStep,
+// either of the same team or of a team which has granted it access, and
+// registers it as an artifact of the build.
+type ConsumeArtifactStep struct {
+	planID          atc.PlanID
+	plan            atc.ConsumeArtifactPlan
+	metadata        StepMetadata
+	delegateFactory BuildStepDelegateFactory
+	teamFactory     db.TeamFactory
+	workerPool      worker.Pool
+}
+
+func NewConsumeArtifactStep(
+	planID atc.PlanID,
+	plan atc.ConsumeArtifactPlan,
+	metadata StepMetadata,
+	delegateFactory BuildStepDelegateFactory,
+	teamFactory db.TeamFactory,
+	workerPool worker.Pool,
+) Step {
+	return &ConsumeArtifactStep{
+		planID:          planID,
+		plan:            plan,
+		metadata:        metadata,
+		delegateFactory: delegateFactory,
+		teamFactory:     teamFactory,
+		workerPool:      workerPool,
+	}
+}
+
+func (step *ConsumeArtifactStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.BuildStepDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "consume_artifact", tracing.Attrs{
+		"name": step.plan.Name,
+	})
+
+	ok, err := step.run(ctx, state, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *ConsumeArtifactStep) run(ctx context.Context, state RunState, delegate BuildStepDelegate) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("consume-artifact-step", lager.Data{
+		"step-name": step.plan.Name,
+		"job-id":    step.metadata.JobID,
+	})
+
+	delegate.Initializing(logger)
+	stdout := delegate.Stdout()
+
+	ownerTeamName := step.plan.Team
+	if ownerTeamName == "" {
+		ownerTeamName = step.metadata.TeamName
+	}
+
+	team := step.teamFactory.GetByID(step.metadata.TeamID)
+
+	data, found, err := team.FindSharedArtifact(ownerTeamName, step.plan.Name)
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, SharedArtifactNotFoundError{
+			Team: ownerTeamName,
+			Name: step.plan.Name,
+		}
+	}
+
+	delegate.Starting(logger)
+
+	volume, err := step.workerPool.CreateVolume(
+		logger,
+		worker.VolumeSpec{
+			Strategy: baggageclaim.EmptyStrategy{},
+		},
+		worker.WorkerSpec{
+			TeamID: step.metadata.TeamID,
+		},
+		db.VolumeTypeArtifact,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	// tie the volume to the build so that it is not garbage collected as an
+	// orphaned artifact volume while the build is using it
+	_, err = volume.InitializeArtifact(step.plan.Name, step.metadata.BuildID)
+	if err != nil {
+		return false, err
+	}
+
+	err = volume.StreamIn(ctx, "/", baggageclaim.GzipEncoding, bytes.NewReader(data))
+	if err != nil {
+		return false, err
+	}
+
+	state.ArtifactRepository().RegisterArtifact(build.ArtifactName(step.plan.Name), &runtime.TaskArtifact{
+		VolumeHandle: volume.Handle(),
+	})
+
+	fmt.Fprintf(stdout, "fetched %s/%s (%d bytes)\n", ownerTeamName, step.plan.Name, len(data))
+
+	delegate.Finished(logger, true)
+
+	return true, nil
+}
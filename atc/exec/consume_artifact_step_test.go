@@ -0,0 +1,183 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/concourse/concourse/tracing"
+	"github.com/concourse/concourse/vars"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe("ConsumeArtifactStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+		fakeWorkerPool  *workerfakes.FakePool
+		fakeVolume      *workerfakes.FakeVolume
+
+		consumePlan atc.ConsumeArtifactPlan
+		state       exec.RunState
+
+		stepMetadata = exec.StepMetadata{
+			TeamID:   123,
+			TeamName: "some-team",
+			BuildID:  42,
+		}
+
+		stdout *gbytes.Buffer
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, lagertest.NewTestLogger("consume-artifact-step-test"))
+
+		state = exec.NewRunState(noopStepper, vars.StaticVariables{}, false)
+
+		stdout = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StartSpanStub = func(ctx context.Context, _ string, _ tracing.Attrs) (context.Context, trace.Span) {
+			return ctx, tracing.NoopSpan
+		}
+
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.FindSharedArtifactReturns([]byte("some-tgz"), true, nil)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.GetByIDReturns(fakeTeam)
+
+		fakeVolume = new(workerfakes.FakeVolume)
+		fakeVolume.HandleReturns("some-handle")
+
+		fakeWorkerPool = new(workerfakes.FakePool)
+		fakeWorkerPool.CreateVolumeReturns(fakeVolume, nil)
+
+		consumePlan = atc.ConsumeArtifactPlan{
+			Name: "some-artifact",
+			Team: "other-team",
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step := exec.NewConsumeArtifactStep(
+			"56",
+			consumePlan,
+			stepMetadata,
+			fakeDelegateFactory,
+			fakeTeamFactory,
+			fakeWorkerPool,
+		)
+
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	It("looks up the artifact as the build's team", func() {
+		Expect(fakeTeamFactory.GetByIDArgsForCall(0)).To(Equal(123))
+
+		ownerTeam, name := fakeTeam.FindSharedArtifactArgsForCall(0)
+		Expect(ownerTeam).To(Equal("other-team"))
+		Expect(name).To(Equal("some-artifact"))
+	})
+
+	It("streams it into a new artifact volume for the build", func() {
+		_, _, workerSpec, volumeType := fakeWorkerPool.CreateVolumeArgsForCall(0)
+		Expect(workerSpec).To(Equal(worker.WorkerSpec{TeamID: 123}))
+		Expect(volumeType).To(Equal(db.VolumeTypeArtifact))
+
+		name, buildID := fakeVolume.InitializeArtifactArgsForCall(0)
+		Expect(name).To(Equal("some-artifact"))
+		Expect(buildID).To(Equal(42))
+
+		_, path, _, reader := fakeVolume.StreamInArgsForCall(0)
+		Expect(path).To(Equal("/"))
+		Expect(ioutil.ReadAll(reader)).To(Equal([]byte("some-tgz")))
+	})
+
+	It("registers the artifact", func() {
+		art, found := state.ArtifactRepository().ArtifactFor(build.ArtifactName("some-artifact"))
+		Expect(found).To(BeTrue())
+		Expect(art.ID()).To(Equal("some-handle"))
+	})
+
+	It("succeeds", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(stepOk).To(BeTrue())
+
+		_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+		Expect(succeeded).To(BeTrue())
+
+		Expect(stdout).To(gbytes.Say("fetched other-team/some-artifact"))
+	})
+
+	Context("when no team is given", func() {
+		BeforeEach(func() {
+			consumePlan.Team = ""
+		})
+
+		It("looks up the build's own team's artifact", func() {
+			ownerTeam, _ := fakeTeam.FindSharedArtifactArgsForCall(0)
+			Expect(ownerTeam).To(Equal("some-team"))
+		})
+	})
+
+	Context("when the artifact is not found or not granted", func() {
+		BeforeEach(func() {
+			fakeTeam.FindSharedArtifactReturns(nil, false, nil)
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(Equal(exec.SharedArtifactNotFoundError{
+				Team: "other-team",
+				Name: "some-artifact",
+			}))
+			Expect(fakeWorkerPool.CreateVolumeCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when streaming in fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeVolume.StreamInReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+
+			_, found := state.ArtifactRepository().ArtifactFor(build.ArtifactName("some-artifact"))
+			Expect(found).To(BeFalse())
+		})
+	})
+})
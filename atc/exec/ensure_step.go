@@ -38,7 +38,9 @@ func (o EnsureStep) Run(ctx context.Context, state RunState) (bool, error) {
 	hookCtx := ctx
 	if ctx.Err() != nil {
 		// prevent hook from being immediately canceled
-		hookCtx = context.Background()
+		var cancel context.CancelFunc
+		hookCtx, cancel = cleanupContext(ctx)
+		defer cancel()
 	}
 
 	hookOk, hookErr := o.hook.Run(hookCtx, state)
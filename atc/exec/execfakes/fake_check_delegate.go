@@ -74,6 +74,16 @@ type FakeCheckDelegate struct {
 	pointToCheckedConfigReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ScheduledStub        func() bool
+	scheduledMutex       sync.RWMutex
+	scheduledArgsForCall []struct {
+	}
+	scheduledReturns struct {
+		result1 bool
+	}
+	scheduledReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
@@ -435,6 +445,59 @@ func (fake *FakeCheckDelegate) PointToCheckedConfigReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeCheckDelegate) Scheduled() bool {
+	fake.scheduledMutex.Lock()
+	ret, specificReturn := fake.scheduledReturnsOnCall[len(fake.scheduledArgsForCall)]
+	fake.scheduledArgsForCall = append(fake.scheduledArgsForCall, struct {
+	}{})
+	stub := fake.ScheduledStub
+	fakeReturns := fake.scheduledReturns
+	fake.recordInvocation("Scheduled", []interface{}{})
+	fake.scheduledMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckDelegate) ScheduledCallCount() int {
+	fake.scheduledMutex.RLock()
+	defer fake.scheduledMutex.RUnlock()
+	return len(fake.scheduledArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) ScheduledCalls(stub func() bool) {
+	fake.scheduledMutex.Lock()
+	defer fake.scheduledMutex.Unlock()
+	fake.ScheduledStub = stub
+}
+
+func (fake *FakeCheckDelegate) ScheduledReturns(result1 bool) {
+	fake.scheduledMutex.Lock()
+	defer fake.scheduledMutex.Unlock()
+	fake.ScheduledStub = nil
+	fake.scheduledReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) ScheduledReturnsOnCall(i int, result1 bool) {
+	fake.scheduledMutex.Lock()
+	defer fake.scheduledMutex.Unlock()
+	fake.ScheduledStub = nil
+	if fake.scheduledReturnsOnCall == nil {
+		fake.scheduledReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.scheduledReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeCheckDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -787,6 +850,8 @@ func (fake *FakeCheckDelegate) Invocations() map[string][][]interface{} {
 	defer fake.initializingMutex.RUnlock()
 	fake.pointToCheckedConfigMutex.RLock()
 	defer fake.pointToCheckedConfigMutex.RUnlock()
+	fake.scheduledMutex.RLock()
+	defer fake.scheduledMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
 	fake.startSpanMutex.RLock()
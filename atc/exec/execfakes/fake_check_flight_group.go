@@ -0,0 +1,123 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package execfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/exec"
+)
+
+type FakeCheckFlightGroup struct {
+	DoStub        func(string, func() (interface{}, error)) (interface{}, error, bool)
+	doMutex       sync.RWMutex
+	doArgsForCall []struct {
+		arg1 string
+		arg2 func() (interface{}, error)
+	}
+	doReturns struct {
+		result1 interface{}
+		result2 error
+		result3 bool
+	}
+	doReturnsOnCall map[int]struct {
+		result1 interface{}
+		result2 error
+		result3 bool
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCheckFlightGroup) Do(arg1 string, arg2 func() (interface{}, error)) (interface{}, error, bool) {
+	fake.doMutex.Lock()
+	ret, specificReturn := fake.doReturnsOnCall[len(fake.doArgsForCall)]
+	fake.doArgsForCall = append(fake.doArgsForCall, struct {
+		arg1 string
+		arg2 func() (interface{}, error)
+	}{arg1, arg2})
+	stub := fake.DoStub
+	fakeReturns := fake.doReturns
+	fake.recordInvocation("Do", []interface{}{arg1, arg2})
+	fake.doMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeCheckFlightGroup) DoCallCount() int {
+	fake.doMutex.RLock()
+	defer fake.doMutex.RUnlock()
+	return len(fake.doArgsForCall)
+}
+
+func (fake *FakeCheckFlightGroup) DoCalls(stub func(string, func() (interface{}, error)) (interface{}, error, bool)) {
+	fake.doMutex.Lock()
+	defer fake.doMutex.Unlock()
+	fake.DoStub = stub
+}
+
+func (fake *FakeCheckFlightGroup) DoArgsForCall(i int) (string, func() (interface{}, error)) {
+	fake.doMutex.RLock()
+	defer fake.doMutex.RUnlock()
+	argsForCall := fake.doArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCheckFlightGroup) DoReturns(result1 interface{}, result2 error, result3 bool) {
+	fake.doMutex.Lock()
+	defer fake.doMutex.Unlock()
+	fake.DoStub = nil
+	fake.doReturns = struct {
+		result1 interface{}
+		result2 error
+		result3 bool
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCheckFlightGroup) DoReturnsOnCall(i int, result1 interface{}, result2 error, result3 bool) {
+	fake.doMutex.Lock()
+	defer fake.doMutex.Unlock()
+	fake.DoStub = nil
+	if fake.doReturnsOnCall == nil {
+		fake.doReturnsOnCall = make(map[int]struct {
+			result1 interface{}
+			result2 error
+			result3 bool
+		})
+	}
+	fake.doReturnsOnCall[i] = struct {
+		result1 interface{}
+		result2 error
+		result3 bool
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCheckFlightGroup) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.doMutex.RLock()
+	defer fake.doMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCheckFlightGroup) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.CheckFlightGroup = new(FakeCheckFlightGroup)
@@ -123,6 +123,16 @@ type FakeRunState struct {
 		arg1 atc.PlanID
 		arg2 interface{}
 	}
+	TrackStepStartedStub        func() bool
+	trackStepStartedMutex       sync.RWMutex
+	trackStepStartedArgsForCall []struct {
+	}
+	trackStepStartedReturns struct {
+		result1 bool
+	}
+	trackStepStartedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -688,6 +698,59 @@ func (fake *FakeRunState) StoreResultArgsForCall(i int) (atc.PlanID, interface{}
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeRunState) TrackStepStarted() bool {
+	fake.trackStepStartedMutex.Lock()
+	ret, specificReturn := fake.trackStepStartedReturnsOnCall[len(fake.trackStepStartedArgsForCall)]
+	fake.trackStepStartedArgsForCall = append(fake.trackStepStartedArgsForCall, struct {
+	}{})
+	stub := fake.TrackStepStartedStub
+	fakeReturns := fake.trackStepStartedReturns
+	fake.recordInvocation("TrackStepStarted", []interface{}{})
+	fake.trackStepStartedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRunState) TrackStepStartedCallCount() int {
+	fake.trackStepStartedMutex.RLock()
+	defer fake.trackStepStartedMutex.RUnlock()
+	return len(fake.trackStepStartedArgsForCall)
+}
+
+func (fake *FakeRunState) TrackStepStartedCalls(stub func() bool) {
+	fake.trackStepStartedMutex.Lock()
+	defer fake.trackStepStartedMutex.Unlock()
+	fake.TrackStepStartedStub = stub
+}
+
+func (fake *FakeRunState) TrackStepStartedReturns(result1 bool) {
+	fake.trackStepStartedMutex.Lock()
+	defer fake.trackStepStartedMutex.Unlock()
+	fake.TrackStepStartedStub = nil
+	fake.trackStepStartedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeRunState) TrackStepStartedReturnsOnCall(i int, result1 bool) {
+	fake.trackStepStartedMutex.Lock()
+	defer fake.trackStepStartedMutex.Unlock()
+	fake.TrackStepStartedStub = nil
+	if fake.trackStepStartedReturnsOnCall == nil {
+		fake.trackStepStartedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.trackStepStartedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -713,6 +776,8 @@ func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	defer fake.runMutex.RUnlock()
 	fake.storeResultMutex.RLock()
 	defer fake.storeResultMutex.RUnlock()
+	fake.trackStepStartedMutex.RLock()
+	defer fake.trackStepStartedMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
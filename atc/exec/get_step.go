@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
@@ -36,6 +37,16 @@ func (e ErrResourceNotFound) Error() string {
 	return fmt.Sprintf("resource '%s' not found", e.ResourceName)
 }
 
+// MissingMetadataKeysError is returned when the metadata of a fetched version
+// lacks any of the keys the get step was asked to set as vars.
+type MissingMetadataKeysError struct {
+	Keys []string
+}
+
+func (err MissingMetadataKeysError) Error() string {
+	return fmt.Sprintf("version metadata has no key(s): %s", strings.Join(err.Keys, ", "))
+}
+
 //counterfeiter:generate . GetDelegateFactory
 type GetDelegateFactory interface {
 	GetDelegate(state RunState) GetDelegate
@@ -63,6 +74,10 @@ type GetDelegate interface {
 
 // GetStep will fetch a version of a resource on a worker that supports the
 // resource type.
+// UnpackCacheParam is the param under which the unpack format of a get step
+// is recorded on its resource cache.
+const UnpackCacheParam = "concourse:unpack"
+
 type GetStep struct {
 	planID               atc.PlanID
 	plan                 atc.GetPlan
@@ -130,8 +145,20 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 		return false, err
 	}
 
+	proxy, err := creds.NewProxy(state, step.plan.Proxy).Evaluate()
+	if err != nil {
+		return false, err
+	}
+
+	hosts, err := creds.NewHosts(state, step.plan.Hosts).Evaluate()
+	if err != nil {
+		return false, err
+	}
+
 	workerSpec := worker.WorkerSpec{
 		Tags:         step.plan.Tags,
+		AnyTags:      step.plan.AnyTags,
+		Runtime:      step.plan.Runtime,
 		TeamID:       step.metadata.TeamID,
 		ResourceType: step.plan.VersionedResourceTypes.Base(step.plan.Type),
 	}
@@ -139,17 +166,7 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 	var imageSpec worker.ImageSpec
 	resourceType, found := step.plan.VersionedResourceTypes.Lookup(step.plan.Type)
 	if found {
-		image := atc.ImageResource{
-			Name:    resourceType.Name,
-			Type:    resourceType.Type,
-			Source:  resourceType.Source,
-			Params:  resourceType.Params,
-			Version: resourceType.Version,
-			Tags:    resourceType.Tags,
-		}
-		if len(image.Tags) == 0 {
-			image.Tags = step.plan.Tags
-		}
+		image := resourceTypeImage(resourceType, step.plan.Tags, step.plan.AnyTags)
 
 		types := step.plan.VersionedResourceTypes.Without(step.plan.Type)
 
@@ -177,16 +194,21 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 		TeamID:    step.metadata.TeamID,
 		Type:      step.containerMetadata.Type,
 
-		Env: step.metadata.Env(),
+		Env:   append(step.metadata.Env(), proxy.Env()...),
+		Hosts: hosts,
 	}
 	tracing.Inject(ctx, &containerSpec)
 
+	if workerSpec.Runtime == "" {
+		workerSpec.Runtime = containerSpec.RequiredRuntime()
+	}
+
 	resourceCache, err := step.resourceCacheFactory.FindOrCreateResourceCache(
 		db.ForBuild(step.metadata.BuildID),
 		step.plan.Type,
 		version,
 		source,
-		params,
+		step.cacheParams(params),
 		resourceTypes,
 	)
 	if err != nil {
@@ -207,6 +229,12 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 			fmt.Fprintln(delegate.Stderr(), "")
 
 			delegate.Starting(logger)
+
+				err = step.addMetadataVars(state, getResult.VersionResult)
+			if err != nil {
+				return false, err
+			}
+
 			state.StoreResult(step.planID, resourceCache)
 
 			state.ArtifactRepository().RegisterArtifact(
@@ -235,6 +263,7 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 		Args:         []string{resource.ResourcesDir("get")},
 		StdoutWriter: delegate.Stdout(),
 		StderrWriter: delegate.Stderr(),
+		Unpack:       step.plan.Unpack,
 	}
 
 	resourceToGet := step.resourceFactory.NewResource(
@@ -296,6 +325,11 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 
 	var succeeded bool
 	if getResult.ExitStatus == 0 {
+		err = step.addMetadataVars(state, getResult.VersionResult)
+		if err != nil {
+			return false, err
+		}
+
 		state.StoreResult(step.planID, resourceCache)
 
 		state.ArtifactRepository().RegisterArtifact(
@@ -308,6 +342,8 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 		}
 
 		succeeded = true
+	} else if exitStatusNotRetryable(step.plan.VersionedResourceTypes, step.plan.Type, getResult.ExitStatus) {
+		SkipRemainingAttempts(ctx)
 	}
 
 	delegate.Finished(
@@ -319,6 +355,60 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 	return succeeded, nil
 }
 
+// cacheParams returns the params which identify the step's resource cache.
+// Archives are unpacked in the cache, so the format is among them to keep the
+// cache apart from those of gets of the same version which don't unpack.
+func (step *GetStep) cacheParams(params atc.Params) atc.Params {
+	if step.plan.Unpack == "" {
+		return params
+	}
+
+	cacheParams := atc.Params{}
+	for key, value := range params {
+		cacheParams[key] = value
+	}
+
+	cacheParams[UnpackCacheParam] = step.plan.Unpack
+
+	return cacheParams
+}
+
+// addMetadataVars sets the requested keys of the fetched version's metadata
+// as fields of a local var named after the step. The metadata is only known
+// once the version has been fetched, so a key the resource type didn't report
+// fails the step.
+func (step *GetStep) addMetadataVars(state RunState, result runtime.VersionResult) error {
+	if len(step.plan.MetadataVars) == 0 {
+		return nil
+	}
+
+	metadata := map[string]string{}
+	for _, field := range result.Metadata {
+		metadata[field.Name] = field.Value
+	}
+
+	values := map[string]interface{}{}
+
+	var missing []string
+	for _, key := range step.plan.MetadataVars {
+		value, found := metadata[key]
+		if !found {
+			missing = append(missing, key)
+			continue
+		}
+
+		values[key] = value
+	}
+
+	if len(missing) != 0 {
+		return MissingMetadataKeysError{Keys: missing}
+	}
+
+	state.AddLocalVar(step.plan.Name, values, false)
+
+	return nil
+}
+
 func (step *GetStep) getFromLocalCache(
 	logger lager.Logger,
 	teamId int,
@@ -338,7 +428,10 @@ func (step *GetStep) getFromLocalCache(
 			Version:  resourceCache.Version(),
 			Metadata: metadata.ToATCMetadata(),
 		},
-		GetArtifact: runtime.GetArtifact{volume.Handle()},
+		GetArtifact: runtime.GetArtifact{
+			VolumeHandle:    volume.Handle(),
+			ResourceCacheID: resourceCache.ID(),
+		},
 	}, true, nil
 }
 
@@ -3,30 +3,47 @@ package exec
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"sort"
 	"sync/atomic"
+	"time"
 
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/util"
 	"github.com/hashicorp/go-multierror"
 )
 
 // InParallelStep is a step of steps to run in parallel.
 type InParallelStep struct {
-	steps       []Step
-	maxInFlight atc.MaxInFlightConfig
-	failFast    bool
+	steps          []Step
+	maxInFlight    atc.MaxInFlightConfig
+	failFast       bool
+	gracePeriod    string
+	outputPriority []int
+
+	delegateFactory BuildStepDelegateFactory
 }
 
-// InParallel constructs an InParallelStep.
-func InParallel(steps []Step, limit int, failFast bool) InParallelStep {
+// InParallel constructs an InParallelStep. The grace period only applies when
+// failing fast, and the output priority holds indexes into steps, highest
+// priority first.
+func InParallel(steps []Step, limit int, failFast bool, gracePeriod string, outputPriority []int, delegateFactory BuildStepDelegateFactory) InParallelStep {
 	maxInFlight := atc.MaxInFlightConfig{Limit: limit}
 	if limit < 1 {
 		maxInFlight.All = true
 	}
 	return InParallelStep{
-		steps:       steps,
-		maxInFlight: maxInFlight,
-		failFast:    failFast,
+		steps:          steps,
+		maxInFlight:    maxInFlight,
+		failFast:       failFast,
+		gracePeriod:    gracePeriod,
+		outputPriority: outputPriority,
+
+		delegateFactory: delegateFactory,
 	}
 }
 
@@ -36,29 +53,129 @@ func InParallel(steps []Step, limit int, failFast bool) InParallelStep {
 //
 // Fail fast can be used to abort running steps if any steps exit with an error. When set
 // to false, parallel wil wait for all the steps to exit even if a step fails or errors.
+// The aborted steps still run their cleanup, such as ensure and on_abort hooks, which
+// is cut short once the optional grace period is over.
 //
 // Cancelling a parallel step means that any outstanding steps will not be scheduled to run.
 // After all steps finish, their errors (if any) will be collected and returned as a
 // single error.
+//
+// When more than one step produces an artifact with the same name, the artifact
+// from the step with the highest output priority is registered once all steps
+// have finished. Without an output priority, whichever step registered it last wins,
+// and a warning naming the artifact is written to the build's stderr.
 func (step InParallelStep) Run(ctx context.Context, state RunState) (bool, error) {
+	var gracePeriod time.Duration
+	if step.failFast && step.gracePeriod != "" {
+		var err error
+		gracePeriod, err = time.ParseDuration(step.gracePeriod)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	scopes := make([]*build.Repository, len(step.steps))
+	for i := range scopes {
+		scopes[i] = state.ArtifactRepository().NewTrackingScope()
+	}
+
+	defer step.resolveOutputs(lagerctx.FromContext(ctx), state, scopes)
+
 	return parallelExecutor{
 		stepName: "in_parallel",
 
 		maxInFlight: &step.maxInFlight,
 		failFast:    step.failFast,
+		gracePeriod: gracePeriod,
 		count:       len(step.steps),
 
 		runFunc: func(ctx context.Context, i int) (bool, error) {
-			return step.steps[i].Run(ctx, state)
+			return step.steps[i].Run(ctx, artifactScopedState{state, scopes[i]})
 		},
 	}.run(ctx)
 }
 
+func (step InParallelStep) resolveOutputs(logger lager.Logger, state RunState, scopes []*build.Repository) {
+	repo := state.ArtifactRepository()
+
+	var stderr io.Writer
+
+	producers := map[build.ArtifactName][]int{}
+	for i, scope := range scopes {
+		for name := range scope.RegisteredArtifacts() {
+			producers[name] = append(producers[name], i)
+		}
+	}
+
+	rank := map[int]int{}
+	for r, i := range step.outputPriority {
+		rank[i] = r
+	}
+
+	names := []build.ArtifactName{}
+	for name := range producers {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+
+	for _, name := range names {
+		steps := producers[name]
+		if len(steps) < 2 {
+			continue
+		}
+
+		prioritized := []int{}
+		for _, i := range steps {
+			if _, found := rank[i]; found {
+				prioritized = append(prioritized, i)
+			}
+		}
+
+		if len(prioritized) == 0 {
+			logger.Info("nondeterministic-output", lager.Data{
+				"artifact": name,
+				"steps":    steps,
+			})
+
+			if stderr == nil {
+				stderr = step.delegateFactory.BuildStepDelegate(state).Stderr()
+			}
+
+			fmt.Fprintf(stderr, "\x1b[1;33mWARNING: more than one step produced the artifact '%s'; whichever finished last is used. set `output_priority` to choose one\x1b[0m\n", name)
+
+			continue
+		}
+
+		sort.Slice(prioritized, func(a, b int) bool {
+			return rank[prioritized[a]] < rank[prioritized[b]]
+		})
+
+		artifact, _ := scopes[prioritized[0]].ArtifactFor(name)
+		repo.RegisterArtifact(name, artifact)
+	}
+}
+
+// artifactScopedState overrides the artifact repository of a RunState,
+// leaving everything else as-is.
+type artifactScopedState struct {
+	RunState
+
+	artifacts *build.Repository
+}
+
+func (state artifactScopedState) ArtifactRepository() *build.Repository {
+	return state.artifacts
+}
+
 type parallelExecutor struct {
 	stepName string
 
 	maxInFlight *atc.MaxInFlightConfig
 	failFast    bool
+	gracePeriod time.Duration
 	count       int
 
 	runFunc func(ctx context.Context, i int) (bool, error)
@@ -74,6 +191,19 @@ func (p parallelExecutor) run(ctx context.Context) (bool, error) {
 	runCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	var deadline *cleanupDeadline
+	if p.failFast && p.gracePeriod > 0 {
+		runCtx, deadline = withCleanupDeadline(runCtx)
+	}
+
+	failFast := func() {
+		if deadline != nil {
+			deadline.set(time.Now().Add(p.gracePeriod))
+		}
+
+		cancel()
+	}
+
 	var numFailures uint32 = 0
 	for i := 0; i < p.count; i++ {
 		i := i
@@ -96,7 +226,7 @@ func (p parallelExecutor) run(ctx context.Context) (bool, error) {
 			if !succeeded {
 				atomic.AddUint32(&numFailures, 1)
 				if p.failFast {
-					cancel()
+					failFast()
 				}
 			}
 			errs <- err
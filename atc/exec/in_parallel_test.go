@@ -10,8 +10,10 @@ import (
 	. "github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/runtime/runtimefakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 var _ = Describe("Parallel", func() {
@@ -26,6 +28,10 @@ var _ = Describe("Parallel", func() {
 		repo  *build.Repository
 		state *execfakes.FakeRunState
 
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		stderr              *gbytes.Buffer
+
 		step    Step
 		stepOk  bool
 		stepErr error
@@ -38,7 +44,15 @@ var _ = Describe("Parallel", func() {
 		fakeStepB = new(execfakes.FakeStep)
 		fakeSteps = []Step{fakeStepA, fakeStepB}
 
-		step = InParallel(fakeSteps, len(fakeSteps), false)
+		stderr = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate.StderrReturns(stderr)
+
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		step = InParallel(fakeSteps, len(fakeSteps), false, "", nil, fakeDelegateFactory)
 
 		repo = build.NewRepository()
 		state = new(execfakes.FakeRunState)
@@ -94,7 +108,7 @@ var _ = Describe("Parallel", func() {
 
 		Context("when parallel limit is 1", func() {
 			BeforeEach(func() {
-				step = InParallel(fakeSteps, 1, false)
+				step = InParallel(fakeSteps, 1, false, "", nil, fakeDelegateFactory)
 				ch := make(chan struct{}, 1)
 
 				fakeStepA.RunStub = func(context.Context, RunState) (bool, error) {
@@ -153,7 +167,7 @@ var _ = Describe("Parallel", func() {
 
 		Context("when there are steps pending execution", func() {
 			BeforeEach(func() {
-				step = InParallel(fakeSteps, 1, false)
+				step = InParallel(fakeSteps, 1, false, "", nil, fakeDelegateFactory)
 
 				fakeStepA.RunStub = func(context.Context, RunState) (bool, error) {
 					cancel()
@@ -190,7 +204,7 @@ var _ = Describe("Parallel", func() {
 
 			Context("and fail fast is false", func() {
 				BeforeEach(func() {
-					step = InParallel(fakeSteps, 1, false)
+					step = InParallel(fakeSteps, 1, false, "", nil, fakeDelegateFactory)
 				})
 				It("lets all steps finish before exiting", func() {
 					Expect(fakeStepA.RunCallCount()).To(Equal(1))
@@ -204,7 +218,7 @@ var _ = Describe("Parallel", func() {
 
 			Context("and fail fast is true", func() {
 				BeforeEach(func() {
-					step = InParallel(fakeSteps, 1, true)
+					step = InParallel(fakeSteps, 1, true, "", nil, fakeDelegateFactory)
 				})
 				It("it cancels remaining steps", func() {
 					Expect(fakeStepA.RunCallCount()).To(Equal(1))
@@ -215,6 +229,81 @@ var _ = Describe("Parallel", func() {
 					Expect(stepErr.Error()).NotTo(ContainSubstring("nope B"))
 				})
 			})
+
+			Context("and fail fast is true while other steps are running", func() {
+				var (
+					fakeHook *execfakes.FakeStep
+
+					hookDeadline  time.Time
+					hookBounded   bool
+					hookCancelled error
+				)
+
+				failFastWithin := func(gracePeriod string) Step {
+					return InParallel([]Step{fakeStepA, Ensure(fakeStepB, fakeHook)}, 2, true, gracePeriod, nil, fakeDelegateFactory)
+				}
+
+				BeforeEach(func() {
+					started := make(chan struct{})
+
+					fakeStepA.RunStub = func(context.Context, RunState) (bool, error) {
+						<-started
+						return false, disasterA
+					}
+
+					fakeStepB.RunStub = func(ctx context.Context, _ RunState) (bool, error) {
+						close(started)
+						<-ctx.Done()
+						return false, ctx.Err()
+					}
+
+					fakeHook = new(execfakes.FakeStep)
+					fakeHook.RunStub = func(ctx context.Context, _ RunState) (bool, error) {
+						hookDeadline, hookBounded = ctx.Deadline()
+						if hookBounded {
+							<-ctx.Done()
+							hookCancelled = ctx.Err()
+						}
+						return true, nil
+					}
+
+					step = failFastWithin("")
+				})
+
+				It("aborts them, ignoring their error", func() {
+					Expect(stepErr).To(MatchError(ContainSubstring("nope A")))
+					Expect(stepErr).ToNot(MatchError(ContainSubstring("context canceled")))
+				})
+
+				It("lets them clean up without a deadline", func() {
+					Expect(fakeHook.RunCallCount()).To(Equal(1))
+					Expect(hookBounded).To(BeFalse())
+				})
+
+				Context("with a grace period", func() {
+					BeforeEach(func() {
+						step = failFastWithin("100ms")
+					})
+
+					It("cuts their cleanup short once it's over", func() {
+						Expect(fakeHook.RunCallCount()).To(Equal(1))
+						Expect(hookBounded).To(BeTrue())
+						Expect(hookDeadline).To(BeTemporally("~", time.Now(), time.Second))
+						Expect(hookCancelled).To(Equal(context.DeadlineExceeded))
+					})
+				})
+
+				Context("with an invalid grace period", func() {
+					BeforeEach(func() {
+						step = failFastWithin("bogus")
+					})
+
+					It("errors without running any step", func() {
+						Expect(stepErr).To(HaveOccurred())
+						Expect(fakeHook.RunCallCount()).To(BeZero())
+					})
+				})
+			})
 		})
 
 		Context("with context canceled error", func() {
@@ -267,6 +356,67 @@ var _ = Describe("Parallel", func() {
 		})
 	})
 
+	Describe("outputs", func() {
+		var artifactA, artifactB *runtimefakes.FakeArtifact
+
+		BeforeEach(func() {
+			artifactA = new(runtimefakes.FakeArtifact)
+			artifactA.IDReturns("artifact-a")
+			artifactB = new(runtimefakes.FakeArtifact)
+			artifactB.IDReturns("artifact-b")
+
+			fakeStepA.RunStub = func(_ context.Context, state RunState) (bool, error) {
+				state.ArtifactRepository().RegisterArtifact("some-output", artifactA)
+				state.ArtifactRepository().RegisterArtifact("output-a", artifactA)
+				return true, nil
+			}
+
+			fakeStepB.RunStub = func(_ context.Context, state RunState) (bool, error) {
+				state.ArtifactRepository().RegisterArtifact("some-output", artifactB)
+				return true, nil
+			}
+		})
+
+		Context("when the steps produce outputs with the same name", func() {
+			BeforeEach(func() {
+				step = InParallel(fakeSteps, 1, false, "", nil, fakeDelegateFactory)
+			})
+
+			It("registers the output of the step which finished last", func() {
+				artifact, found := repo.ArtifactFor("some-output")
+				Expect(found).To(BeTrue())
+				Expect(artifact).To(Equal(artifactB))
+			})
+
+			It("registers outputs which do not collide", func() {
+				artifact, found := repo.ArtifactFor("output-a")
+				Expect(found).To(BeTrue())
+				Expect(artifact).To(Equal(artifactA))
+			})
+
+			It("warns about the colliding output on stderr", func() {
+				Expect(fakeDelegateFactory.BuildStepDelegateCallCount()).To(Equal(1))
+				Expect(stderr).To(gbytes.Say("WARNING: more than one step produced the artifact 'some-output'"))
+			})
+		})
+
+		Context("when an output priority is configured", func() {
+			BeforeEach(func() {
+				step = InParallel(fakeSteps, 1, false, "", []int{0, 1}, fakeDelegateFactory)
+			})
+
+			It("registers the output of the step with the highest priority", func() {
+				artifact, found := repo.ArtifactFor("some-output")
+				Expect(found).To(BeTrue())
+				Expect(artifact).To(Equal(artifactA))
+			})
+
+			It("doesn't warn", func() {
+				Expect(fakeDelegateFactory.BuildStepDelegateCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Context("when there are no steps", func() {
 		BeforeEach(func() {
 			step = InParallelStep{}
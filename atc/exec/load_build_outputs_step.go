@@ -0,0 +1,227 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/tracing"
+)
+
+var ErrLoadBuildOutputsOutsidePipeline = errors.New("load_build_outputs can only be used in pipeline builds")
+
+type BuildOutputsJobNotFoundError struct {
+	Job string
+}
+
+func (err BuildOutputsJobNotFoundError) Error() string {
+	return fmt.Sprintf("job '%s' not found in the pipeline", err.Job)
+}
+
+type BuildOutputsBuildNotFoundError struct {
+	Job   string
+	Build string
+}
+
+func (err BuildOutputsBuildNotFoundError) Error() string {
+	if err.Build == "" {
+		return fmt.Sprintf("job '%s' has no succeeded build", err.Job)
+	}
+
+	return fmt.Sprintf("build '%s' of job '%s' not found", err.Build, err.Job)
+}
+
+type BuildOutputNotFoundError struct {
+	Job    string
+	Build  string
+	Output string
+}
+
+func (err BuildOutputNotFoundError) Error() string {
+	return fmt.Sprintf("build %s/%s has no output '%s'", err.Job, err.Build, err.Output)
+}
+
+// BuildOutputVersionMissingError is returned when the version of an output
+// has since been garbage collected, so it can no longer be loaded.
+type BuildOutputVersionMissingError struct {
+	Job    string
+	Build  string
+	Output string
+}
+
+func (err BuildOutputVersionMissingError) Error() string {
+	return fmt.Sprintf("the version of output '%s' of build %s/%s no longer exists", err.Output, err.Job, err.Build)
+}
+
+// LoadBuildOutputsStep loads the versions and metadata of the outputs of
+// another build of the pipeline and sets them as a build-local var, keyed by
+// output name.
+//
+// Only builds of jobs in the same pipeline can be referenced, which also
+// keeps them within the team.
+type LoadBuildOutputsStep struct {
+	planID          atc.PlanID
+	plan            atc.LoadBuildOutputsPlan
+	metadata        StepMetadata
+	build           db.Build
+	delegateFactory BuildStepDelegateFactory
+}
+
+func NewLoadBuildOutputsStep(
+	planID atc.PlanID,
+	plan atc.LoadBuildOutputsPlan,
+	metadata StepMetadata,
+	build db.Build,
+	delegateFactory BuildStepDelegateFactory,
+) Step {
+	return &LoadBuildOutputsStep{
+		planID:          planID,
+		plan:            plan,
+		metadata:        metadata,
+		build:           build,
+		delegateFactory: delegateFactory,
+	}
+}
+
+func (step *LoadBuildOutputsStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.BuildStepDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "load_build_outputs", tracing.Attrs{
+		"name": step.plan.Name,
+	})
+
+	ok, err := step.run(ctx, state, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *LoadBuildOutputsStep) run(ctx context.Context, state RunState, delegate BuildStepDelegate) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("load-build-outputs-step", lager.Data{
+		"step-name": step.plan.Name,
+		"job-id":    step.metadata.JobID,
+	})
+
+	delegate.Initializing(logger)
+	stdout := delegate.Stdout()
+
+	referencedBuild, err := step.findBuild()
+	if err != nil {
+		return false, err
+	}
+
+	delegate.Starting(logger)
+
+	_, outputs, err := referencedBuild.Resources()
+	if err != nil {
+		return false, err
+	}
+
+	values := map[string]interface{}{}
+	missing := map[string]bool{}
+	for _, output := range outputs {
+		if output.VersionMissing {
+			missing[output.Name] = true
+		}
+
+		version := map[string]interface{}{}
+		for k, v := range output.Version {
+			version[k] = v
+		}
+
+		metadata := map[string]interface{}{}
+		for _, field := range output.Metadata {
+			metadata[field.Name] = field.Value
+		}
+
+		values[output.Name] = map[string]interface{}{
+			"version":  version,
+			"metadata": metadata,
+		}
+	}
+
+	if len(step.plan.Outputs) != 0 {
+		selected := map[string]interface{}{}
+		for _, name := range step.plan.Outputs {
+			value, found := values[name]
+			if !found {
+				return false, BuildOutputNotFoundError{
+					Job:    step.plan.Job,
+					Build:  referencedBuild.Name(),
+					Output: name,
+				}
+			}
+
+			selected[name] = value
+		}
+
+		values = selected
+	}
+
+	for _, output := range outputs {
+		_, loaded := values[output.Name]
+		if loaded && missing[output.Name] {
+			return false, BuildOutputVersionMissingError{
+				Job:    step.plan.Job,
+				Build:  referencedBuild.Name(),
+				Output: output.Name,
+			}
+		}
+	}
+
+	fmt.Fprintf(stdout, "loaded %d output(s) of build %s/%s.\n", len(values), step.plan.Job, referencedBuild.Name())
+
+	state.AddLocalVar(step.plan.Name, values, !step.plan.Reveal)
+	fmt.Fprintf(stdout, "added var %s to build.\n", step.plan.Name)
+
+	delegate.Finished(logger, true)
+
+	return true, nil
+}
+
+func (step *LoadBuildOutputsStep) findBuild() (db.Build, error) {
+	if step.build.PipelineID() == 0 {
+		return nil, ErrLoadBuildOutputsOutsidePipeline
+	}
+
+	pipeline, found, err := step.build.Pipeline()
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, ErrLoadBuildOutputsOutsidePipeline
+	}
+
+	job, found, err := pipeline.Job(step.plan.Job)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, BuildOutputsJobNotFoundError{Job: step.plan.Job}
+	}
+
+	var referencedBuild db.Build
+	if step.plan.Build == "" {
+		referencedBuild, found, err = job.LatestSucceededBuild()
+	} else {
+		referencedBuild, found, err = job.Build(step.plan.Build)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, BuildOutputsBuildNotFoundError{
+			Job:   step.plan.Job,
+			Build: step.plan.Build,
+		}
+	}
+
+	return referencedBuild, nil
+}
@@ -0,0 +1,270 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe("LoadBuildOutputsStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+
+		fakeBuild           *dbfakes.FakeBuild
+		fakePipeline        *dbfakes.FakePipeline
+		fakeJob             *dbfakes.FakeJob
+		fakeReferencedBuild *dbfakes.FakeBuild
+
+		loadPlan atc.LoadBuildOutputsPlan
+		state    *execfakes.FakeRunState
+
+		stdout *gbytes.Buffer
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, lagertest.NewTestLogger("load-build-outputs-step-test"))
+
+		state = new(execfakes.FakeRunState)
+
+		stdout = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StartSpanStub = func(ctx context.Context, _ string, _ tracing.Attrs) (context.Context, trace.Span) {
+			return ctx, tracing.NoopSpan
+		}
+
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		fakeReferencedBuild = new(dbfakes.FakeBuild)
+		fakeReferencedBuild.NameReturns("7")
+		fakeReferencedBuild.ResourcesReturns(nil, []db.BuildOutput{
+			{
+				Name:    "some-image",
+				Version: atc.Version{"digest": "sha256:abc"},
+				Metadata: db.ResourceConfigMetadataFields{
+					{Name: "tag", Value: "1.2.3"},
+				},
+			},
+			{
+				Name:    "other-image",
+				Version: atc.Version{"digest": "sha256:def"},
+			},
+		}, nil)
+
+		fakeJob = new(dbfakes.FakeJob)
+		fakeJob.LatestSucceededBuildReturns(fakeReferencedBuild, true, nil)
+		fakeJob.BuildReturns(fakeReferencedBuild, true, nil)
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.JobReturns(fakeJob, true, nil)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.PipelineIDReturns(1)
+		fakeBuild.PipelineReturns(fakePipeline, true, nil)
+
+		loadPlan = atc.LoadBuildOutputsPlan{
+			Name: "some-outputs",
+			Job:  "build-images",
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step := exec.NewLoadBuildOutputsStep(
+			"56",
+			loadPlan,
+			exec.StepMetadata{},
+			fakeBuild,
+			fakeDelegateFactory,
+		)
+
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	It("looks up the job in the build's pipeline", func() {
+		Expect(fakePipeline.JobArgsForCall(0)).To(Equal("build-images"))
+	})
+
+	It("loads the outputs of the job's latest succeeded build as a redacted var", func() {
+		Expect(fakeJob.LatestSucceededBuildCallCount()).To(Equal(1))
+		Expect(fakeJob.BuildCallCount()).To(BeZero())
+
+		Expect(state.AddLocalVarCallCount()).To(Equal(1))
+		name, value, redact := state.AddLocalVarArgsForCall(0)
+		Expect(name).To(Equal("some-outputs"))
+		Expect(value).To(Equal(map[string]interface{}{
+			"some-image": map[string]interface{}{
+				"version":  map[string]interface{}{"digest": "sha256:abc"},
+				"metadata": map[string]interface{}{"tag": "1.2.3"},
+			},
+			"other-image": map[string]interface{}{
+				"version":  map[string]interface{}{"digest": "sha256:def"},
+				"metadata": map[string]interface{}{},
+			},
+		}))
+		Expect(redact).To(BeTrue())
+	})
+
+	It("succeeds", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(stepOk).To(BeTrue())
+
+		_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+		Expect(succeeded).To(BeTrue())
+
+		Expect(stdout).To(gbytes.Say("loaded 2 output\\(s\\) of build build-images/7"))
+	})
+
+	Context("when a build is given", func() {
+		BeforeEach(func() {
+			loadPlan.Build = "7"
+			loadPlan.Reveal = true
+		})
+
+		It("loads the outputs of that build", func() {
+			Expect(fakeJob.BuildArgsForCall(0)).To(Equal("7"))
+
+			_, _, redact := state.AddLocalVarArgsForCall(0)
+			Expect(redact).To(BeFalse())
+		})
+
+		Context("when the build is not found", func() {
+			BeforeEach(func() {
+				fakeJob.BuildReturns(nil, false, nil)
+			})
+
+			It("returns an error", func() {
+				Expect(stepErr).To(Equal(exec.BuildOutputsBuildNotFoundError{
+					Job:   "build-images",
+					Build: "7",
+				}))
+				Expect(stepErr).To(MatchError("build '7' of job 'build-images' not found"))
+			})
+		})
+	})
+
+	Context("when outputs are given", func() {
+		BeforeEach(func() {
+			loadPlan.Outputs = []string{"some-image"}
+		})
+
+		It("only loads those outputs", func() {
+			_, value, _ := state.AddLocalVarArgsForCall(0)
+			Expect(value).To(HaveLen(1))
+			Expect(value).To(HaveKey("some-image"))
+		})
+
+		Context("when the build has no such output", func() {
+			BeforeEach(func() {
+				loadPlan.Outputs = []string{"bogus-image"}
+			})
+
+			It("returns an error", func() {
+				Expect(stepErr).To(MatchError("build build-images/7 has no output 'bogus-image'"))
+				Expect(state.AddLocalVarCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the version of another output is missing", func() {
+			BeforeEach(func() {
+				fakeReferencedBuild.ResourcesReturns(nil, []db.BuildOutput{
+					{Name: "some-image", Version: atc.Version{"digest": "sha256:abc"}},
+					{Name: "other-image", VersionMissing: true},
+				}, nil)
+			})
+
+			It("loads the given outputs", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(state.AddLocalVarCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when the version of an output is missing", func() {
+		BeforeEach(func() {
+			fakeReferencedBuild.ResourcesReturns(nil, []db.BuildOutput{
+				{Name: "some-image", Version: atc.Version{"digest": "sha256:abc"}},
+				{Name: "other-image", VersionMissing: true},
+			}, nil)
+		})
+
+		It("returns an error naming the output", func() {
+			Expect(stepErr).To(Equal(exec.BuildOutputVersionMissingError{
+				Job:    "build-images",
+				Build:  "7",
+				Output: "other-image",
+			}))
+			Expect(stepErr).To(MatchError("the version of output 'other-image' of build build-images/7 no longer exists"))
+			Expect(state.AddLocalVarCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the job has no succeeded build", func() {
+		BeforeEach(func() {
+			fakeJob.LatestSucceededBuildReturns(nil, false, nil)
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(MatchError("job 'build-images' has no succeeded build"))
+		})
+	})
+
+	Context("when the job is not in the pipeline", func() {
+		BeforeEach(func() {
+			fakePipeline.JobReturns(nil, false, nil)
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(Equal(exec.BuildOutputsJobNotFoundError{Job: "build-images"}))
+		})
+	})
+
+	Context("when the build is a one-off", func() {
+		BeforeEach(func() {
+			fakeBuild.PipelineIDReturns(0)
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(Equal(exec.ErrLoadBuildOutputsOutsidePipeline))
+			Expect(fakeBuild.PipelineCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when getting the outputs fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeReferencedBuild.ResourcesReturns(nil, nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+	})
+})
@@ -3,6 +3,7 @@ package exec
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec/artifact"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/worker"
@@ -25,6 +27,7 @@ type LoadVarStep struct {
 	planID           atc.PlanID
 	plan             atc.LoadVarPlan
 	metadata         StepMetadata
+	build            db.Build
 	delegateFactory  BuildStepDelegateFactory
 	artifactStreamer worker.ArtifactStreamer
 }
@@ -33,6 +36,7 @@ func NewLoadVarStep(
 	planID atc.PlanID,
 	plan atc.LoadVarPlan,
 	metadata StepMetadata,
+	build db.Build,
 	delegateFactory BuildStepDelegateFactory,
 	artifactStreamer worker.ArtifactStreamer,
 ) Step {
@@ -40,11 +44,14 @@ func NewLoadVarStep(
 		planID:           planID,
 		plan:             plan,
 		metadata:         metadata,
+		build:            build,
 		delegateFactory:  delegateFactory,
 		artifactStreamer: artifactStreamer,
 	}
 }
 
+var ErrSetPipelineVarOutsidePipeline = errors.New("set_pipeline_var can only be used in pipeline builds")
+
 type UnspecifiedLoadVarStepFileError struct {
 	File string
 }
@@ -103,11 +110,38 @@ func (step *LoadVarStep) run(ctx context.Context, state RunState, delegate Build
 	state.AddLocalVar(step.plan.Name, value, !step.plan.Reveal)
 	fmt.Fprintf(stdout, "added var %s to build.\n", step.plan.Name)
 
+	if step.plan.SetPipelineVar {
+		err = step.setPipelineVar(value)
+		if err != nil {
+			return false, err
+		}
+
+		fmt.Fprintf(stdout, "set var %s of pipeline %s.\n", step.plan.Name, step.metadata.PipelineName)
+	}
+
 	delegate.Finished(logger, true)
 
 	return true, nil
 }
 
+func (step *LoadVarStep) setPipelineVar(value interface{}) error {
+	if step.build.PipelineID() == 0 {
+		return ErrSetPipelineVarOutsidePipeline
+	}
+
+	pipeline, found, err := step.build.Pipeline()
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return ErrSetPipelineVarOutsidePipeline
+	}
+
+	_, err = pipeline.SetVar(step.plan.Name, value, true)
+	return err
+}
+
 func (step *LoadVarStep) fetchVars(
 	ctx context.Context,
 	logger lager.Logger,
@@ -11,6 +11,7 @@ import (
 	"github.com/onsi/gomega/gbytes"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/artifact"
 	"github.com/concourse/concourse/atc/exec/build"
@@ -45,6 +46,9 @@ var _ = Describe("LoadVarStep", func() {
 
 		fakeArtifactStreamer *workerfakes.FakeArtifactStreamer
 
+		fakeBuild    *dbfakes.FakeBuild
+		fakePipeline *dbfakes.FakePipeline
+
 		spanCtx context.Context
 
 		loadVarPlan        *atc.LoadVarPlan
@@ -96,6 +100,11 @@ var _ = Describe("LoadVarStep", func() {
 		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
 
 		fakeArtifactStreamer = new(workerfakes.FakeArtifactStreamer)
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.PipelineIDReturns(4567)
+		fakeBuild.PipelineReturns(fakePipeline, true, nil)
 	})
 
 	expectLocalVarAdded := func(expectKey string, expectValue interface{}, expectRedact bool) {
@@ -120,6 +129,7 @@ var _ = Describe("LoadVarStep", func() {
 			plan.ID,
 			*plan.LoadVar,
 			stepMetadata,
+			fakeBuild,
 			fakeDelegateFactory,
 			fakeArtifactStreamer,
 		)
@@ -431,4 +441,59 @@ var _ = Describe("LoadVarStep", func() {
 			})
 		})
 	})
+
+	Context("set_pipeline_var", func() {
+		BeforeEach(func() {
+			fakeArtifactStreamer.StreamFileFromArtifactReturns(&fakeReadCloser{str: plainString}, nil)
+		})
+
+		Context("when it is not specified", func() {
+			BeforeEach(func() {
+				loadVarPlan = &atc.LoadVarPlan{
+					Name: "some-var",
+					File: "some-resource/a.diff",
+				}
+			})
+
+			It("does not set the var on the pipeline", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(fakePipeline.SetVarCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when it is true", func() {
+			BeforeEach(func() {
+				loadVarPlan = &atc.LoadVarPlan{
+					Name:           "some-var",
+					File:           "some-resource/a.diff",
+					SetPipelineVar: true,
+				}
+			})
+
+			It("sets the var on the pipeline, creating it if need be", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeTrue())
+
+				Expect(fakePipeline.SetVarCallCount()).To(Equal(1))
+				name, value, create := fakePipeline.SetVarArgsForCall(0)
+				Expect(name).To(Equal("some-var"))
+				Expect(value).To(Equal(strings.TrimSpace(plainString)))
+				Expect(create).To(BeTrue())
+			})
+
+			It("still adds the var to the build", func() {
+				expectLocalVarAdded("some-var", strings.TrimSpace(plainString), true)
+			})
+
+			Context("when the build is not a pipeline build", func() {
+				BeforeEach(func() {
+					fakeBuild.PipelineIDReturns(0)
+				})
+
+				It("errors", func() {
+					Expect(stepErr).To(Equal(exec.ErrSetPipelineVarOutsidePipeline))
+				})
+			})
+		})
+	})
 })
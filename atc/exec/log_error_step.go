@@ -10,6 +10,7 @@ import (
 
 const AbortedLogMessage = "interrupted"
 const TimeoutLogMessage = "timeout exceeded"
+const OOMKilledLogMessage = "out of memory"
 
 type LogErrorStep struct {
 	Step
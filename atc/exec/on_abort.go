@@ -34,7 +34,10 @@ func (o OnAbortStep) Run(ctx context.Context, state RunState) (bool, error) {
 
 	if errors.Is(stepRunErr, context.Canceled) {
 		// run only on abort, not timeout
-		o.hook.Run(context.Background(), state)
+		hookCtx, cancel := cleanupContext(ctx)
+		defer cancel()
+
+		o.hook.Run(hookCtx, state)
 	}
 
 	return stepRunOk, stepRunErr
@@ -0,0 +1,129 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/tracing"
+)
+
+// MaxSharedArtifactSize is the largest compressed artifact that can be
+// published. Shared artifacts are stored in the database, so they are meant
+// for build outputs such as binaries and reports rather than whole
+// repositories.
+const MaxSharedArtifactSize = 64 * 1024 * 1024
+
+type SharedArtifactTooLargeError struct {
+	Name string
+}
+
+func (err SharedArtifactTooLargeError) Error() string {
+	return fmt.Sprintf("artifact '%s' is larger than the maximum of %d bytes (compressed) that can be published", err.Name, MaxSharedArtifactSize)
+}
+
+// PublishArtifactStep stores an artifact of the build under a name in the
+// team's shared artifacts, for builds of teams it has been granted to to
+// consume with a ConsumeArtifactStep.
+type PublishArtifactStep struct {
+	planID          atc.PlanID
+	plan            atc.PublishArtifactPlan
+	metadata        StepMetadata
+	delegateFactory BuildStepDelegateFactory
+	teamFactory     db.TeamFactory
+	workerPool      worker.Pool
+}
+
+func NewPublishArtifactStep(
+	planID atc.PlanID,
+	plan atc.PublishArtifactPlan,
+	metadata StepMetadata,
+	delegateFactory BuildStepDelegateFactory,
+	teamFactory db.TeamFactory,
+	workerPool worker.Pool,
+) Step {
+	return &PublishArtifactStep{
+		planID:          planID,
+		plan:            plan,
+		metadata:        metadata,
+		delegateFactory: delegateFactory,
+		teamFactory:     teamFactory,
+		workerPool:      workerPool,
+	}
+}
+
+func (step *PublishArtifactStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.BuildStepDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "publish_artifact", tracing.Attrs{
+		"name": step.plan.Name,
+	})
+
+	ok, err := step.run(ctx, state, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *PublishArtifactStep) run(ctx context.Context, state RunState, delegate BuildStepDelegate) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("publish-artifact-step", lager.Data{
+		"step-name": step.plan.Name,
+		"job-id":    step.metadata.JobID,
+	})
+
+	delegate.Initializing(logger)
+	stdout := delegate.Stdout()
+
+	art, found := state.ArtifactRepository().ArtifactFor(build.ArtifactName(step.plan.From))
+	if !found {
+		return false, ArtifactNotFoundError{step.plan.From}
+	}
+
+	volume, found, err := step.workerPool.FindVolume(logger, step.metadata.TeamID, art.ID())
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, ArtifactVolumeNotFoundError{step.plan.From}
+	}
+
+	delegate.Starting(logger)
+
+	stream, err := volume.StreamOut(ctx, "/", baggageclaim.GzipEncoding)
+	if err != nil {
+		return false, err
+	}
+
+	defer stream.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(stream, MaxSharedArtifactSize+1))
+	if err != nil {
+		return false, err
+	}
+
+	if len(data) > MaxSharedArtifactSize {
+		return false, SharedArtifactTooLargeError{step.plan.From}
+	}
+
+	team := step.teamFactory.GetByID(step.metadata.TeamID)
+
+	err = team.PublishSharedArtifact(step.plan.Name, step.metadata.BuildID, data)
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Fprintf(stdout, "published %s as %s/%s (%d bytes)\n", step.plan.From, step.metadata.TeamName, step.plan.Name, len(data))
+
+	delegate.Finished(logger, true)
+
+	return true, nil
+}
@@ -0,0 +1,169 @@
+package exec_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/concourse/concourse/tracing"
+	"github.com/concourse/concourse/vars"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe("PublishArtifactStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+		fakeWorkerPool  *workerfakes.FakePool
+		fakeVolume      *workerfakes.FakeVolume
+
+		publishPlan atc.PublishArtifactPlan
+		state       exec.RunState
+
+		stepMetadata = exec.StepMetadata{
+			TeamID:   123,
+			TeamName: "some-team",
+			BuildID:  42,
+		}
+
+		stdout *gbytes.Buffer
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, lagertest.NewTestLogger("publish-artifact-step-test"))
+
+		state = exec.NewRunState(noopStepper, vars.StaticVariables{}, false)
+		state.ArtifactRepository().RegisterArtifact(build.ArtifactName("some-output"), &runtime.TaskArtifact{
+			VolumeHandle: "some-handle",
+		})
+
+		stdout = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StartSpanStub = func(ctx context.Context, _ string, _ tracing.Attrs) (context.Context, trace.Span) {
+			return ctx, tracing.NoopSpan
+		}
+
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.GetByIDReturns(fakeTeam)
+
+		fakeVolume = new(workerfakes.FakeVolume)
+		fakeVolume.StreamOutReturns(ioutil.NopCloser(bytes.NewBufferString("some-tgz")), nil)
+
+		fakeWorkerPool = new(workerfakes.FakePool)
+		fakeWorkerPool.FindVolumeReturns(fakeVolume, true, nil)
+
+		publishPlan = atc.PublishArtifactPlan{
+			Name: "some-artifact",
+			From: "some-output",
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step := exec.NewPublishArtifactStep(
+			"56",
+			publishPlan,
+			stepMetadata,
+			fakeDelegateFactory,
+			fakeTeamFactory,
+			fakeWorkerPool,
+		)
+
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	It("streams out the artifact's volume", func() {
+		_, teamID, handle := fakeWorkerPool.FindVolumeArgsForCall(0)
+		Expect(teamID).To(Equal(123))
+		Expect(handle).To(Equal("some-handle"))
+
+		Expect(fakeVolume.StreamOutCallCount()).To(Equal(1))
+	})
+
+	It("publishes it as a shared artifact of the team", func() {
+		Expect(fakeTeamFactory.GetByIDArgsForCall(0)).To(Equal(123))
+
+		Expect(fakeTeam.PublishSharedArtifactCallCount()).To(Equal(1))
+		name, buildID, data := fakeTeam.PublishSharedArtifactArgsForCall(0)
+		Expect(name).To(Equal("some-artifact"))
+		Expect(buildID).To(Equal(42))
+		Expect(data).To(Equal([]byte("some-tgz")))
+	})
+
+	It("succeeds", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(stepOk).To(BeTrue())
+
+		_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+		Expect(succeeded).To(BeTrue())
+
+		Expect(stdout).To(gbytes.Say("published some-output as some-team/some-artifact"))
+	})
+
+	Context("when the artifact does not exist", func() {
+		BeforeEach(func() {
+			publishPlan.From = "bogus"
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(Equal(exec.ArtifactNotFoundError{ArtifactName: "bogus"}))
+			Expect(fakeTeam.PublishSharedArtifactCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the artifact's volume no longer exists", func() {
+		BeforeEach(func() {
+			fakeWorkerPool.FindVolumeReturns(nil, false, nil)
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(Equal(exec.ArtifactVolumeNotFoundError{ArtifactName: "some-output"}))
+		})
+	})
+
+	Context("when publishing fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeTeam.PublishSharedArtifactReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(fakeDelegate.FinishedCallCount()).To(BeZero())
+		})
+	})
+})
@@ -3,6 +3,7 @@ package exec
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 
 	"code.cloudfoundry.org/lager"
@@ -91,6 +92,11 @@ func NewPutStep(
 //
 // The resource's put script is then invoked. If the context is canceled, the
 // script will be interrupted.
+//
+// As part of an atomic step the put is run in a phase of a two-phase commit,
+// which the resource is told of through the two_phase_commit param. A put to a
+// type which doesn't support two-phase commit skips staging and rolling back,
+// and is run as a regular put when committing.
 func (step *PutStep) Run(ctx context.Context, state RunState) (bool, error) {
 	delegate := step.delegateFactory.PutDelegate(state)
 	ctx, span := delegate.StartSpan(ctx, "put", tracing.Attrs{
@@ -111,6 +117,19 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 		"job-id":    step.metadata.JobID,
 	})
 
+	phase := step.plan.Phase
+	if phase != "" && !step.supportsTwoPhaseCommit() {
+		switch phase {
+		case atc.PutPhaseStage:
+			fmt.Fprintf(delegate.Stderr(), "\x1b[1;33mWARNING: resource type '%s' does not support two-phase commit; the put will run once the other puts are staged and cannot be rolled back\x1b[0m\n", step.plan.Type)
+			return true, nil
+		case atc.PutPhaseRollback:
+			return true, nil
+		}
+
+		phase = ""
+	}
+
 	delegate.Initializing(logger)
 
 	source, err := creds.NewSource(state, step.plan.Source).Evaluate()
@@ -123,6 +142,22 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 		return false, err
 	}
 
+	proxy, err := creds.NewProxy(state, step.plan.Proxy).Evaluate()
+	if err != nil {
+		return false, err
+	}
+
+	hosts, err := creds.NewHosts(state, step.plan.Hosts).Evaluate()
+	if err != nil {
+		return false, err
+	}
+
+	ownerPlanID := step.planID
+	if phase != "" {
+		params = step.withPhaseParam(state, params, phase)
+		ownerPlanID = atc.PlanID(fmt.Sprintf("%s/%s", step.planID, phase))
+	}
+
 	resourceTypes, err := creds.NewVersionedResourceTypes(state, step.plan.VersionedResourceTypes).Evaluate()
 	if err != nil {
 		return false, err
@@ -155,6 +190,8 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 
 	workerSpec := worker.WorkerSpec{
 		Tags:         step.plan.Tags,
+		AnyTags:      step.plan.AnyTags,
+		Runtime:      step.plan.Runtime,
 		TeamID:       step.metadata.TeamID,
 		ResourceType: step.plan.VersionedResourceTypes.Base(step.plan.Type),
 	}
@@ -162,17 +199,7 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 	var imageSpec worker.ImageSpec
 	resourceType, found := step.plan.VersionedResourceTypes.Lookup(step.plan.Type)
 	if found {
-		image := atc.ImageResource{
-			Name:    resourceType.Name,
-			Type:    resourceType.Type,
-			Source:  resourceType.Source,
-			Params:  resourceType.Params,
-			Version: resourceType.Version,
-			Tags:    resourceType.Tags,
-		}
-		if len(image.Tags) == 0 {
-			image.Tags = step.plan.Tags
-		}
+		image := resourceTypeImage(resourceType, step.plan.Tags, step.plan.AnyTags)
 
 		types := step.plan.VersionedResourceTypes.Without(step.plan.Type)
 
@@ -190,14 +217,21 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 		TeamID:    step.metadata.TeamID,
 		Type:      step.containerMetadata.Type,
 
-		Dir: step.containerMetadata.WorkingDirectory,
-		Env: step.metadata.Env(),
+		Dir:   step.containerMetadata.WorkingDirectory,
+		Env:   append(step.metadata.Env(), proxy.Env()...),
+		Hosts: hosts,
 
 		Inputs: containerInputs,
 	}
 	tracing.Inject(ctx, &containerSpec)
 
-	owner := db.NewBuildStepContainerOwner(step.metadata.BuildID, step.planID, step.metadata.TeamID)
+	if workerSpec.Runtime == "" {
+		workerSpec.Runtime = containerSpec.RequiredRuntime()
+	}
+
+	// each phase runs in a container of its own, as the result of a put is
+	// cached in its container
+	owner := db.NewBuildStepContainerOwner(step.metadata.BuildID, ownerPlanID, step.metadata.TeamID)
 
 	containerSpec.BindMounts = []worker.BindMountSource{
 		&worker.CertsVolumeMount{Logger: logger},
@@ -261,6 +295,10 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 	}
 
 	if result.ExitStatus != 0 {
+		if exitStatusNotRetryable(step.plan.VersionedResourceTypes, step.plan.Type, result.ExitStatus) {
+			SkipRemainingAttempts(ctx)
+		}
+
 		delegate.Finished(logger, ExitStatus(result.ExitStatus), runtime.VersionResult{})
 		return false, nil
 	}
@@ -268,13 +306,46 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 	versionResult := result.VersionResult
 	// step.plan.Resource maps to an actual resource that may have been used outside of a pipeline context.
 	// Hence, if it was used outside the pipeline context, we don't want to save the output.
-	if step.plan.Resource != "" {
+	// Staged and rolled back versions aren't published, so they aren't saved
+	// either.
+	if step.plan.Resource != "" && (phase == "" || phase == atc.PutPhaseCommit) {
 		delegate.SaveOutput(logger, step.plan, source, resourceTypes, versionResult)
 	}
 
-	state.StoreResult(step.planID, versionResult)
+	if phase != atc.PutPhaseRollback {
+		state.StoreResult(step.planID, versionResult)
+	}
 
 	delegate.Finished(logger, 0, versionResult)
 
 	return true, nil
 }
+
+func (step *PutStep) supportsTwoPhaseCommit() bool {
+	resourceType, found := step.plan.VersionedResourceTypes.Lookup(step.plan.Type)
+	return found && resourceType.TwoPhaseCommit
+}
+
+// withPhaseParam returns the params with the two_phase_commit param added.
+// Committing and rolling back pass the version that was staged.
+func (step *PutStep) withPhaseParam(state RunState, params atc.Params, phase atc.PutPhase) atc.Params {
+	twoPhaseCommit := map[string]interface{}{
+		"phase": phase,
+	}
+
+	if phase != atc.PutPhaseStage {
+		var staged runtime.VersionResult
+		if state.Result(step.planID, &staged) {
+			twoPhaseCommit["staged_version"] = staged.Version
+		}
+	}
+
+	withPhase := atc.Params{}
+	for k, v := range params {
+		withPhase[k] = v
+	}
+
+	withPhase[atc.TwoPhaseCommitParam] = twoPhaseCommit
+
+	return withPhase
+}
@@ -2,6 +2,12 @@ package exec
 
 import (
 	"context"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
 )
 
 // RetryStep is a step that will run the steps in order until one of them
@@ -9,6 +15,9 @@ import (
 type RetryStep struct {
 	Attempts    []Step
 	LastAttempt Step
+
+	backoff     string
+	maxDuration string
 }
 
 func Retry(attempts ...Step) Step {
@@ -17,16 +26,65 @@ func Retry(attempts ...Step) Step {
 	}
 }
 
-// Run iterates through each step, stopping once a step succeeds. If all steps
-// fail, the RetryStep will fail.
+// RetryWithBackoff constructs a RetryStep which waits between attempts. The
+// first wait is the given backoff and it doubles after every attempt. Once
+// maxDuration has elapsed since the first attempt started, no further attempts
+// are made. Either duration may be empty to disable it.
+func RetryWithBackoff(backoff string, maxDuration string, attempts ...Step) Step {
+	return &RetryStep{
+		Attempts:    attempts,
+		backoff:     backoff,
+		maxDuration: maxDuration,
+	}
+}
+
+// Run iterates through each step, stopping once a step succeeds or fails in a
+// way retrying can't fix. If all steps fail, the RetryStep will fail.
 func (step *RetryStep) Run(ctx context.Context, state RunState) (bool, error) {
+	var backoff, maxDuration time.Duration
+	var err error
+
+	if step.backoff != "" {
+		backoff, err = time.ParseDuration(step.backoff)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if step.maxDuration != "" {
+		maxDuration, err = time.ParseDuration(step.maxDuration)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	var attemptOk bool
 	var attemptErr error
 
-	for _, attempt := range step.Attempts {
+	start := time.Now()
+
+	for i, attempt := range step.Attempts {
+		if i > 0 {
+			if maxDuration > 0 && time.Since(start)+backoff >= maxDuration {
+				break
+			}
+
+			if backoff > 0 {
+				select {
+				case <-time.After(backoff):
+				case <-ctx.Done():
+					return false, ctx.Err()
+				}
+
+				backoff *= 2
+			}
+		}
+
 		step.LastAttempt = attempt
 
-		attemptOk, attemptErr = attempt.Run(ctx, state)
+		attemptCtx, outcome := withAttemptOutcome(ctx)
+
+		attemptOk, attemptErr = attempt.Run(attemptCtx, state)
 		if ctx.Err() != nil {
 			return false, ctx.Err()
 		}
@@ -38,7 +96,60 @@ func (step *RetryStep) Run(ctx context.Context, state RunState) (bool, error) {
 		if attemptOk {
 			break
 		}
+
+		if outcome.notRetryable() {
+			lagerctx.FromContext(ctx).Info("skipping-remaining-attempts", lager.Data{
+				"attempt": i + 1,
+			})
+
+			// an enclosing retry can't fix it either
+			SkipRemainingAttempts(ctx)
+			break
+		}
 	}
 
 	return attemptOk, attemptErr
 }
+
+type attemptOutcomeKey struct{}
+
+// attemptOutcome is how the steps run by an attempt tell the RetryStep that
+// they failed in a way which retrying can't fix.
+type attemptOutcome struct {
+	skip int32
+}
+
+func withAttemptOutcome(ctx context.Context) (context.Context, *attemptOutcome) {
+	outcome := &attemptOutcome{}
+	return context.WithValue(ctx, attemptOutcomeKey{}, outcome), outcome
+}
+
+func (outcome *attemptOutcome) notRetryable() bool {
+	return atomic.LoadInt32(&outcome.skip) == 1
+}
+
+// SkipRemainingAttempts marks the failure of the step being run as one which
+// retrying can't fix, so that the attempt it's run by, if any, is the last.
+func SkipRemainingAttempts(ctx context.Context) {
+	outcome, ok := ctx.Value(attemptOutcomeKey{}).(*attemptOutcome)
+	if ok {
+		atomic.StoreInt32(&outcome.skip, 1)
+	}
+}
+
+// exitStatusNotRetryable returns whether the custom resource type declares
+// the exit status its script failed with as one which retrying can't fix.
+func exitStatusNotRetryable(resourceTypes atc.VersionedResourceTypes, resourceType string, exitStatus int) bool {
+	t, found := resourceTypes.Lookup(resourceType)
+	if !found {
+		return false
+	}
+
+	for _, code := range t.NotRetryableExitCodes {
+		if code == exitStatus {
+			return true
+		}
+	}
+
+	return false
+}
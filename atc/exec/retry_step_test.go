@@ -3,6 +3,7 @@ package exec_test
 import (
 	"context"
 	"errors"
+	"time"
 
 	. "github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
@@ -212,5 +213,126 @@ var _ = Describe("Retry Step", func() {
 				Expect(stepOk).To(BeFalse())
 			})
 		})
+
+		Context("when attempt 1 fails in a way retrying can't fix", func() {
+			BeforeEach(func() {
+				attempt1.RunStub = func(c context.Context, r RunState) (bool, error) {
+					SkipRemainingAttempts(c)
+					return false, nil
+				}
+			})
+
+			It("skips the remaining attempts", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+
+				Expect(attempt1.RunCallCount()).To(Equal(1))
+				Expect(attempt2.RunCallCount()).To(Equal(0))
+				Expect(attempt3.RunCallCount()).To(Equal(0))
+			})
+
+			It("fails", func() {
+				Expect(stepOk).To(BeFalse())
+			})
+
+			It("skips the remaining attempts of an enclosing retry step too", func() {
+				outer := Retry(step, attempt2)
+
+				ok, err := outer.Run(ctx, state)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ok).To(BeFalse())
+
+				Expect(attempt1.RunCallCount()).To(Equal(2))
+				Expect(attempt2.RunCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when attempt 1 fails in a way retrying can't fix, but also errors", func() {
+			BeforeEach(func() {
+				attempt1.RunStub = func(c context.Context, r RunState) (bool, error) {
+					SkipRemainingAttempts(c)
+					return false, errors.New("nope")
+				}
+				attempt2.RunReturns(true, nil)
+			})
+
+			It("retries the error", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeTrue())
+				Expect(attempt2.RunCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("with a backoff", func() {
+			var startTimes []time.Time
+
+			BeforeEach(func() {
+				startTimes = nil
+				record := func(context.Context, RunState) (bool, error) {
+					startTimes = append(startTimes, time.Now())
+					return false, nil
+				}
+
+				attempt1.RunStub = record
+				attempt2.RunStub = record
+				attempt3.RunStub = record
+
+				step = RetryWithBackoff("50ms", "", attempt1, attempt2, attempt3)
+			})
+
+			It("waits between attempts, doubling each time", func() {
+				Expect(startTimes).To(HaveLen(3))
+				Expect(startTimes[1].Sub(startTimes[0])).To(BeNumerically(">=", 50*time.Millisecond))
+				Expect(startTimes[2].Sub(startTimes[1])).To(BeNumerically(">=", 100*time.Millisecond))
+			})
+
+			It("fails", func() {
+				Expect(stepOk).To(BeFalse())
+			})
+
+			Context("when interrupted while waiting", func() {
+				BeforeEach(func() {
+					attempt1.RunStub = func(context.Context, RunState) (bool, error) {
+						cancel()
+						return false, nil
+					}
+				})
+
+				It("returns the context error without running another attempt", func() {
+					Expect(stepErr).To(Equal(context.Canceled))
+					Expect(attempt2.RunCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the max duration would be exceeded", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					attempt1.RunReturns(false, errors.New("first"))
+					attempt2.RunStub = func(context.Context, RunState) (bool, error) {
+						return false, disaster
+					}
+
+					step = RetryWithBackoff("50ms", "120ms", attempt1, attempt2, attempt3)
+				})
+
+				It("stops retrying and returns the last attempt's error", func() {
+					Expect(attempt1.RunCallCount()).To(Equal(1))
+					Expect(attempt2.RunCallCount()).To(Equal(1))
+					Expect(attempt3.RunCallCount()).To(Equal(0))
+					Expect(stepErr).To(Equal(disaster))
+				})
+			})
+
+			Context("when the backoff is invalid", func() {
+				BeforeEach(func() {
+					step = RetryWithBackoff("nope", "", attempt1, attempt2, attempt3)
+				})
+
+				It("errors without running any attempt", func() {
+					Expect(stepErr).To(HaveOccurred())
+					Expect(attempt1.RunCallCount()).To(Equal(0))
+				})
+			})
+		})
 	})
 })
@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"sync"
+	"sync/atomic"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/exec/build"
@@ -18,6 +19,8 @@ type runState struct {
 	artifacts *build.Repository
 	results   *sync.Map
 
+	stepStarted *int32
+
 	parent RunState
 }
 
@@ -35,6 +38,8 @@ func NewRunState(
 
 		artifacts: build.NewRepository(),
 		results:   &sync.Map{},
+
+		stepStarted: new(int32),
 	}
 }
 
@@ -92,6 +97,10 @@ func (state *runState) RedactionEnabled() bool {
 	return state.vars.RedactionEnabled()
 }
 
+func (state *runState) TrackStepStarted() bool {
+	return atomic.CompareAndSwapInt32(state.stepStarted, 0, 1)
+}
+
 func (state *runState) Run(ctx context.Context, plan atc.Plan) (bool, error) {
 	return state.stepper(plan).Run(ctx, state)
 }
@@ -288,6 +288,18 @@ var _ = Describe("RunState", func() {
 		})
 	})
 
+	Describe("TrackStepStarted", func() {
+		It("returns true only the first time", func() {
+			Expect(state.TrackStepStarted()).To(BeTrue())
+			Expect(state.TrackStepStarted()).To(BeFalse())
+		})
+
+		It("is shared with local scopes", func() {
+			Expect(state.NewLocalScope().TrackStepStarted()).To(BeTrue())
+			Expect(state.TrackStepStarted()).To(BeFalse())
+		})
+	})
+
 	Describe("NewLocalScope", func() {
 		It("maintains a reference to the parent", func() {
 			Expect(state.NewLocalScope().Parent()).To(Equal(state))
@@ -187,6 +187,15 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		team = targetTeam
 	}
 
+	teamResourceTypes, err := team.ResourceTypes()
+	if err != nil {
+		return false, err
+	}
+
+	for _, warning := range configvalidate.ShadowedTeamResourceTypes(atcConfig, teamResourceTypes) {
+		fmt.Fprintf(stderr, "WARNING: %s\n", warning.Message)
+	}
+
 	pipelineRef := atc.PipelineRef{
 		Name:         step.plan.Name,
 		InstanceVars: step.plan.InstanceVars,
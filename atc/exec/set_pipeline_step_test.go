@@ -321,6 +321,36 @@ jobs:
 				It("should stdout have message", func() {
 					Expect(stdout).To(gbytes.Say("done"))
 				})
+
+				Context("when the pipeline overrides one of the team's resource types", func() {
+					BeforeEach(func() {
+						fakeArtifactStreamer.StreamFileFromArtifactReturns(&fakeReadCloser{str: pipelineContent + `
+resource_types:
+- name: some-type
+  type: registry-image
+`}, nil)
+
+						fakeTeam.ResourceTypesReturns(atc.ResourceTypes{
+							{Name: "some-type", Type: "registry-image"},
+						}, nil)
+					})
+
+					It("warns about it and saves the pipeline", func() {
+						Expect(stderr).To(gbytes.Say("WARNING: resource_types.some-type overrides the team's resource type of the same name"))
+						Expect(fakeBuild.SavePipelineCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when getting the team's resource types fails", func() {
+					BeforeEach(func() {
+						fakeTeam.ResourceTypesReturns(nil, errors.New("nope"))
+					})
+
+					It("should return error", func() {
+						Expect(stepErr).To(MatchError("nope"))
+						Expect(fakeBuild.SavePipelineCallCount()).To(BeZero())
+					})
+				})
 			})
 
 			Context("when specified pipeline exists already", func() {
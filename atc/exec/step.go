@@ -52,6 +52,10 @@ type RunState interface {
 	Run(context.Context, atc.Plan) (bool, error)
 
 	Parent() RunState
+
+	// TrackStepStarted records that a step has started running. It returns
+	// true only for the first step of the build.
+	TrackStepStarted() bool
 }
 
 // ExitStatus is the resulting exit code from the process that the step ran.
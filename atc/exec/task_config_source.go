@@ -156,6 +156,10 @@ func (configSource *OverrideContainerLimitsSource) FetchConfig(ctx context.Conte
 		taskConfig.Limits.Memory = configSource.Limits.Memory
 	}
 
+	if configSource.Limits.OutputSize != nil {
+		taskConfig.Limits.OutputSize = configSource.Limits.OutputSize
+	}
+
 	return taskConfig, nil
 }
 
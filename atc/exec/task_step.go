@@ -2,6 +2,9 @@ package exec
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -13,6 +16,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/runtime"
@@ -22,6 +26,11 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+// TaskInitPath is where workers using the containerd runtime mount their init
+// binary in containers. When given a command, the init binary runs it as its
+// child, forwarding signals to it and reaping any processes orphaned under it.
+const TaskInitPath = "/tmp/gdn-init"
+
 // MissingInputsError is returned when any of the task's required inputs are
 // missing.
 type MissingInputsError struct {
@@ -88,6 +97,7 @@ type TaskStep struct {
 	workerPool        worker.Pool
 	artifactSourcer   worker.ArtifactSourcer
 	artifactStreamer  worker.ArtifactStreamer
+	outputCache       db.TaskOutputCacheFactory
 	delegateFactory   TaskDelegateFactory
 }
 
@@ -101,6 +111,7 @@ func NewTaskStep(
 	workerPool worker.Pool,
 	artifactStreamer worker.ArtifactStreamer,
 	artifactSourcer worker.ArtifactSourcer,
+	outputCache db.TaskOutputCacheFactory,
 	delegateFactory TaskDelegateFactory,
 ) Step {
 	return &TaskStep{
@@ -113,6 +124,7 @@ func NewTaskStep(
 		workerPool:        workerPool,
 		artifactStreamer:  artifactStreamer,
 		artifactSourcer:   artifactSourcer,
+		outputCache:       outputCache,
 		delegateFactory:   delegateFactory,
 	}
 }
@@ -132,6 +144,10 @@ func NewTaskStep(
 // are registered with the artifact.Repository. If no outputs are specified, the
 // task's entire working directory is registered as an StreamableArtifactSource under the
 // name of the task.
+//
+// If the step is configured to cache its outputs, the outputs of a previous
+// run with the same config, image and inputs are registered instead of running
+// the task, and the outputs of a successful run are cached for later builds.
 func (step *TaskStep) Run(ctx context.Context, state RunState) (bool, error) {
 	delegate := step.delegateFactory.TaskDelegate(state)
 	ctx, span := delegate.StartSpan(ctx, "task", tracing.Attrs{
@@ -151,6 +167,10 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		"job-id":    step.metadata.JobID,
 	})
 
+	if step.plan.WorkerName != "" && !atc.EnableStepWorkerName {
+		return false, errors.New("support for `worker_name` is disabled")
+	}
+
 	var taskConfigSource TaskConfigSource
 	var taskVars []vars.Variables
 
@@ -215,12 +235,26 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 	if config.Limits == nil {
 		config.Limits = &atc.ContainerLimits{}
 	}
+	if step.plan.DefaultLimits != nil {
+		if config.Limits.CPU == nil {
+			config.Limits.CPU = step.plan.DefaultLimits.CPU
+		}
+		if config.Limits.Memory == nil {
+			config.Limits.Memory = step.plan.DefaultLimits.Memory
+		}
+		if config.Limits.OutputSize == nil {
+			config.Limits.OutputSize = step.plan.DefaultLimits.OutputSize
+		}
+	}
 	if config.Limits.CPU == nil {
 		config.Limits.CPU = step.defaultLimits.CPU
 	}
 	if config.Limits.Memory == nil {
 		config.Limits.Memory = step.defaultLimits.Memory
 	}
+	if config.Limits.OutputSize == nil {
+		config.Limits.OutputSize = step.defaultLimits.OutputSize
+	}
 
 	delegate.Initializing(logger)
 
@@ -235,6 +269,35 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 	}
 	tracing.Inject(ctx, &containerSpec)
 
+	// Do not cache outputs for one-off builds, as their outputs are not
+	// initialized as artifacts. A task without outputs is always run, as
+	// there is nothing to restore in its place.
+	var fingerprint string
+	if step.plan.CacheOutputs && step.metadata.JobID != 0 && len(config.Outputs) > 0 {
+		fingerprint, err = step.outputCacheFingerprint(state, config)
+		if err != nil {
+			return false, err
+		}
+
+		restored, err := step.restoreCachedOutputs(logger, repository, config, fingerprint)
+		if err != nil {
+			return false, err
+		}
+
+		if restored {
+			fmt.Fprintln(delegate.Stderr(), "\x1b[1;36mINFO: restored outputs from cache\x1b[0m")
+
+			delegate.Starting(logger)
+			delegate.Finished(logger, 0, step.strategy, nil)
+
+			state.AddLocalVar(step.plan.Name, map[string]interface{}{
+				"exit_code": 0,
+			}, false)
+
+			return true, nil
+		}
+	}
+
 	processSpec := runtime.ProcessSpec{
 		Path:         config.Run.Path,
 		Args:         config.Run.Args,
@@ -243,14 +306,24 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		StderrWriter: delegate.Stderr(),
 	}
 
+	if step.plan.Init {
+		processSpec.Path = TaskInitPath
+		processSpec.Args = append([]string{config.Run.Path}, config.Run.Args...)
+	}
+
 	owner := db.NewBuildStepContainerOwner(step.metadata.BuildID, step.planID, step.metadata.TeamID)
 
+	strategy := step.strategy
+	if step.plan.WorkerName != "" {
+		strategy = worker.NewPinnedPlacementStrategy()
+	}
+
 	chosenWorker, _, err := step.workerPool.SelectWorker(
 		lagerctx.NewContext(ctx, logger),
 		owner,
 		containerSpec,
-		step.workerSpec(config),
-		step.strategy,
+		step.workerSpec(config, containerSpec),
+		strategy,
 		delegate,
 	)
 	if err != nil {
@@ -264,7 +337,7 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 			lagerctx.NewContext(ctx, logger),
 			containerSpec,
 			chosenWorker,
-			step.strategy,
+			strategy,
 		)
 	}()
 
@@ -292,10 +365,17 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 	step.registerOutputs(logger, repository, config, result.VolumeMounts, step.containerMetadata)
 
 	// Do not initialize caches for one-off builds
+	var outputArtifacts map[string]db.WorkerArtifact
 	if step.metadata.JobID != 0 {
 		if err := step.registerCaches(logger, repository, config, result.VolumeMounts, step.containerMetadata); err != nil {
 			return false, err
 		}
+
+		var err error
+		outputArtifacts, err = step.initializeOutputArtifacts(logger, config, result.VolumeMounts, step.containerMetadata)
+		if err != nil {
+			return false, err
+		}
 	}
 
 	if runErr != nil {
@@ -304,10 +384,29 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 			return false, nil
 		}
 
+		var sizeErr worker.OutputSizeLimitExceededError
+		if errors.As(runErr, &sizeErr) {
+			delegate.Errored(logger, sizeErr.Error())
+			return false, nil
+		}
+
 		return false, runErr
 	}
 
-	delegate.Finished(logger, ExitStatus(result.ExitStatus), step.strategy, chosenWorker)
+	delegate.Finished(logger, ExitStatus(result.ExitStatus), strategy, chosenWorker)
+
+	if fingerprint != "" && result.ExitStatus == 0 {
+		err := step.outputCache.Save(step.metadata.JobID, step.plan.Name, fingerprint, outputArtifacts)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// expose the exit status to the rest of the build, e.g. so that hooks can
+	// tell ((.:some-task.exit_code)) 1 apart from 137
+	state.AddLocalVar(step.plan.Name, map[string]interface{}{
+		"exit_code": result.ExitStatus,
+	}, false)
 
 	return result.ExitStatus == 0, nil
 }
@@ -335,6 +434,7 @@ func (step *TaskStep) imageSpec(ctx context.Context, logger lager.Logger, state
 		image := *config.ImageResource
 		if len(image.Tags) == 0 {
 			image.Tags = step.plan.Tags
+			image.AnyTags = step.plan.AnyTags
 		}
 
 		return delegate.FetchImage(
@@ -406,11 +506,56 @@ func (step *TaskStep) containerInputs(logger lager.Logger, repository *build.Rep
 	return containerInputs, nil
 }
 
+// colocatedInputs picks out the container inputs of the artifacts the plan
+// marked as only consumed by this task.
+func (step *TaskStep) colocatedInputs(config atc.TaskConfig, metadata db.ContainerMetadata, inputs []worker.InputSource) []worker.InputSource {
+	if len(step.plan.ColocatedInputs) == 0 {
+		return nil
+	}
+
+	colocated := map[string]bool{}
+	for _, name := range step.plan.ColocatedInputs {
+		colocated[name] = true
+	}
+
+	paths := map[string]bool{}
+	for _, input := range config.Inputs {
+		inputName := input.Name
+		if sourceName, ok := step.plan.InputMapping[inputName]; ok {
+			inputName = sourceName
+		}
+
+		if colocated[inputName] {
+			ti := taskInput{
+				config:        input,
+				artifactsRoot: metadata.WorkingDirectory,
+			}
+
+			paths[ti.Path()] = true
+		}
+	}
+
+	var colocatedInputs []worker.InputSource
+	for _, input := range inputs {
+		if paths[input.DestinationPath()] {
+			colocatedInputs = append(colocatedInputs, input)
+		}
+	}
+
+	return colocatedInputs
+}
+
 func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSpec worker.ImageSpec, config atc.TaskConfig, metadata db.ContainerMetadata) (worker.ContainerSpec, error) {
+	hosts, err := creds.NewHosts(state, config.Hosts.Merge(step.plan.Hosts)).Evaluate()
+	if err != nil {
+		return worker.ContainerSpec{}, err
+	}
+
 	var limits worker.ContainerLimits
 	if config.Limits != nil {
 		limits.CPU = (*uint64)(config.Limits.CPU)
 		limits.Memory = (*uint64)(config.Limits.Memory)
+		limits.OutputSize = (*uint64)(config.Limits.OutputSize)
 	}
 
 	containerSpec := worker.ContainerSpec{
@@ -422,16 +567,19 @@ func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSp
 		Env:    config.Params.Env(),
 		Limits: limits,
 		User:   config.Run.User,
+		Hosts:  hosts,
+		Init:   step.plan.Init,
 
 		Outputs: worker.OutputPaths{},
 	}
 
-	var err error
 	containerSpec.Inputs, err = step.containerInputs(logger, state.ArtifactRepository(), config, metadata)
 	if err != nil {
 		return worker.ContainerSpec{}, err
 	}
 
+	containerSpec.ColocatedInputs = step.colocatedInputs(config, metadata, containerSpec.Inputs)
+
 	for _, output := range config.Outputs {
 		path := artifactsPath(output, metadata.WorkingDirectory)
 		containerSpec.Outputs[output.Name] = path
@@ -440,11 +588,20 @@ func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSp
 	return containerSpec, nil
 }
 
-func (step *TaskStep) workerSpec(config atc.TaskConfig) worker.WorkerSpec {
+func (step *TaskStep) workerSpec(config atc.TaskConfig, containerSpec worker.ContainerSpec) worker.WorkerSpec {
+	workerRuntime := step.plan.Runtime
+	if workerRuntime == "" {
+		workerRuntime = containerSpec.RequiredRuntime()
+	}
+
 	return worker.WorkerSpec{
 		Platform: config.Platform,
 		Tags:     step.plan.Tags,
+		AnyTags:  step.plan.AnyTags,
+		Runtime:  workerRuntime,
 		TeamID:   step.metadata.TeamID,
+
+		WorkerName: step.plan.WorkerName,
 	}
 }
 
@@ -470,6 +627,45 @@ func (step *TaskStep) registerOutputs(logger lager.Logger, repository *build.Rep
 	}
 }
 
+// initializeOutputArtifacts records each output volume as an artifact of the
+// build so that it can be downloaded after the build has finished, e.g. with
+// `fly download-artifact`.
+//
+// The artifacts are returned keyed by the name of the output in the task's
+// config.
+func (step *TaskStep) initializeOutputArtifacts(logger lager.Logger, config atc.TaskConfig, volumeMounts []worker.VolumeMount, metadata db.ContainerMetadata) (map[string]db.WorkerArtifact, error) {
+	artifacts := map[string]db.WorkerArtifact{}
+
+	for _, output := range config.Outputs {
+		outputName := output.Name
+		if destinationName, ok := step.plan.OutputMapping[output.Name]; ok {
+			outputName = destinationName
+		}
+
+		outputPath := artifactsPath(output, metadata.WorkingDirectory)
+
+		for _, mount := range volumeMounts {
+			if filepath.Clean(mount.MountPath) == filepath.Clean(outputPath) {
+				artifact, err := mount.Volume.InitializeArtifact(outputName, step.metadata.BuildID)
+				if err != nil {
+					return nil, err
+				}
+
+				artifacts[output.Name] = artifact
+
+				logger.Debug("initialized-output-artifact", lager.Data{
+					"output":      outputName,
+					"artifact_id": artifact.ID(),
+				})
+
+				break
+			}
+		}
+	}
+
+	return artifacts, nil
+}
+
 func (step *TaskStep) registerCaches(logger lager.Logger, repository *build.Repository, config atc.TaskConfig, volumeMounts []worker.VolumeMount, metadata db.ContainerMetadata) error {
 	for _, cacheConfig := range config.Caches {
 		for _, volumeMount := range volumeMounts {
@@ -497,6 +693,103 @@ func (step *TaskStep) registerCaches(logger lager.Logger, repository *build.Repo
 	return nil
 }
 
+// outputCacheFingerprint identifies everything that goes into running the
+// task: its config, its image and the contents of its inputs.
+func (step *TaskStep) outputCacheFingerprint(state RunState, config atc.TaskConfig) (string, error) {
+	repository := state.ArtifactRepository()
+
+	var image string
+	if step.plan.ImageArtifactName != "" {
+		art, found := repository.ArtifactFor(build.ArtifactName(step.plan.ImageArtifactName))
+		if !found {
+			return "", MissingTaskImageSourceError{step.plan.ImageArtifactName}
+		}
+
+		image = artifactFingerprint(art)
+	} else if config.ImageResource != nil {
+		// stored by the image get run by the delegate's FetchImage; the cache
+		// pins the image's digest, even when its version was just checked
+		var cache db.UsedResourceCache
+		if !state.Result(step.planID+"/image-get", &cache) {
+			return "", errors.New("fetched image not found")
+		}
+
+		image = fmt.Sprintf("resource-cache:%d", cache.ID())
+	}
+
+	inputs := map[string]string{}
+	for _, input := range config.Inputs {
+		inputName := input.Name
+		if sourceName, ok := step.plan.InputMapping[inputName]; ok {
+			inputName = sourceName
+		}
+
+		art, found := repository.ArtifactFor(build.ArtifactName(inputName))
+		if !found {
+			continue
+		}
+
+		inputs[input.Name] = artifactFingerprint(art)
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"config":     config,
+		"privileged": step.plan.Privileged,
+		"image":      image,
+		"inputs":     inputs,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(payload)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// artifactFingerprint identifies the contents of an artifact. Fetched
+// resources are identified by their resource cache, so that the same version
+// fetched on different workers matches. Anything else is identified by its
+// volume, which is only reused when it was itself restored from a cache.
+func artifactFingerprint(art runtime.Artifact) string {
+	if getArt, ok := art.(runtime.GetArtifact); ok && getArt.ResourceCacheID != 0 {
+		return fmt.Sprintf("resource-cache:%d", getArt.ResourceCacheID)
+	}
+
+	return fmt.Sprintf("volume:%s", art.ID())
+}
+
+// restoreCachedOutputs registers the cached outputs for the fingerprint, if
+// every one of the task's outputs is still available.
+func (step *TaskStep) restoreCachedOutputs(logger lager.Logger, repository *build.Repository, config atc.TaskConfig, fingerprint string) (bool, error) {
+	handles, err := step.outputCache.Find(step.metadata.JobID, step.plan.Name, fingerprint)
+	if err != nil {
+		return false, err
+	}
+
+	for _, output := range config.Outputs {
+		if _, found := handles[output.Name]; !found {
+			logger.Debug("output-cache-miss", lager.Data{"fingerprint": fingerprint})
+			return false, nil
+		}
+	}
+
+	logger.Debug("output-cache-hit", lager.Data{"fingerprint": fingerprint})
+
+	for _, output := range config.Outputs {
+		outputName := output.Name
+		if destinationName, ok := step.plan.OutputMapping[output.Name]; ok {
+			outputName = destinationName
+		}
+
+		repository.RegisterArtifact(build.ArtifactName(outputName), &runtime.TaskArtifact{
+			VolumeHandle: handles[output.Name],
+		})
+	}
+
+	return true, nil
+}
+
 type taskInput struct {
 	config        atc.TaskInputConfig
 	artifact      runtime.Artifact
@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
@@ -45,6 +46,8 @@ var _ = Describe("TaskStep", func() {
 
 		fakeDelegateFactory *execfakes.FakeTaskDelegateFactory
 
+		fakeOutputCache *dbfakes.FakeTaskOutputCacheFactory
+
 		taskPlan *atc.TaskPlan
 
 		repo       *build.Repository
@@ -99,6 +102,8 @@ var _ = Describe("TaskStep", func() {
 		fakeDelegateFactory = new(execfakes.FakeTaskDelegateFactory)
 		fakeDelegateFactory.TaskDelegateReturns(fakeDelegate)
 
+		fakeOutputCache = new(dbfakes.FakeTaskOutputCacheFactory)
+
 		repo = build.NewRepository()
 		state = new(execfakes.FakeRunState)
 		state.ArtifactRepositoryReturns(repo)
@@ -145,6 +150,7 @@ var _ = Describe("TaskStep", func() {
 			fakePool,
 			fakeArtifactStreamer,
 			fakeArtifactSourcer,
+			fakeOutputCache,
 			fakeDelegateFactory,
 		)
 
@@ -204,6 +210,18 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when a worker name is configured but pinning is disabled", func() {
+			BeforeEach(func() {
+				taskPlan.WorkerName = "some-worker"
+				shouldRunTaskStep = false
+			})
+
+			It("returns an error without selecting a worker", func() {
+				Expect(stepErr).To(MatchError("support for `worker_name` is disabled"))
+				Expect(fakePool.SelectWorkerCallCount()).To(BeZero())
+			})
+		})
+
 		Describe("worker selection", func() {
 			var ctx context.Context
 			var workerSpec worker.WorkerSpec
@@ -234,6 +252,64 @@ var _ = Describe("TaskStep", func() {
 				})
 			})
 
+			It("doesn't require a runtime", func() {
+				Expect(workerSpec.Runtime).To(BeEmpty())
+			})
+
+			Context("when running under an init process", func() {
+				BeforeEach(func() {
+					taskPlan.Init = true
+				})
+
+				It("requires a worker running the containerd runtime", func() {
+					Expect(workerSpec.Runtime).To(Equal(atc.WorkerRuntimeContainerd))
+				})
+
+				Context("when a runtime is configured", func() {
+					BeforeEach(func() {
+						taskPlan.Runtime = atc.WorkerRuntimeGuardian
+					})
+
+					It("requires a worker running it", func() {
+						Expect(workerSpec.Runtime).To(Equal(atc.WorkerRuntimeGuardian))
+					})
+				})
+			})
+
+			Context("when hosts are configured", func() {
+				BeforeEach(func() {
+					taskPlan.Hosts = atc.HostsConfig{"some-host": "10.0.0.1"}
+				})
+
+				It("requires a worker running the containerd runtime", func() {
+					Expect(workerSpec.Runtime).To(Equal(atc.WorkerRuntimeContainerd))
+				})
+			})
+
+			Context("when a worker name is configured", func() {
+				BeforeEach(func() {
+					atc.EnableStepWorkerName = true
+					taskPlan.WorkerName = "some-worker"
+				})
+
+				AfterEach(func() {
+					atc.EnableStepWorkerName = false
+				})
+
+				It("creates a worker spec with the worker name", func() {
+					Expect(workerSpec.WorkerName).To(Equal("some-worker"))
+				})
+
+				It("bypasses the configured placement strategy", func() {
+					_, _, _, _, strategy, _ := fakePool.SelectWorkerArgsForCall(0)
+					Expect(strategy).To(Equal(worker.NewPinnedPlacementStrategy()))
+
+					Expect(fakePool.ReleaseWorkerCallCount()).To(Equal(1))
+					_, _, _, strategy = fakePool.ReleaseWorkerArgsForCall(0)
+					Expect(strategy).To(Equal(worker.NewPinnedPlacementStrategy()))
+				})
+			})
+
 			Context("when selecting a worker fails", func() {
 				BeforeEach(func() {
 					fakePool.SelectWorkerReturns(nil, 0, errors.New("nope"))
@@ -258,6 +334,21 @@ var _ = Describe("TaskStep", func() {
 			Expect(processSpec.Args).To(Equal([]string{"some", "args"}))
 		})
 
+		Context("when running under an init process", func() {
+			BeforeEach(func() {
+				taskPlan.Init = true
+			})
+
+			It("runs the command through the worker's init binary", func() {
+				Expect(processSpec.Path).To(Equal(exec.TaskInitPath))
+				Expect(processSpec.Args).To(Equal([]string{"ls", "some", "args"}))
+			})
+
+			It("requires a worker which provides it", func() {
+				Expect(containerSpec.Init).To(BeTrue())
+			})
+		})
+
 		It("sets the config on the TaskDelegate", func() {
 			Expect(fakeDelegate.SetTaskConfigCallCount()).To(Equal(1))
 			actualTaskConfig := fakeDelegate.SetTaskConfigArgsForCall(0)
@@ -307,6 +398,136 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when default limits are set", func() {
+			BeforeEach(func() {
+				cpu := atc.CPULimit(512)
+				memory := atc.MemoryLimit(512)
+				taskPlan.DefaultLimits = &atc.ContainerLimits{
+					CPU:    &cpu,
+					Memory: &memory,
+				}
+			})
+
+			It("does not override the limits from the config", func() {
+				Expect(atc.CPULimit(*containerSpec.Limits.CPU)).To(Equal(atc.CPULimit(1024)))
+				Expect(atc.MemoryLimit(*containerSpec.Limits.Memory)).To(Equal(atc.MemoryLimit(1024)))
+			})
+
+			Context("when the config does not set limits", func() {
+				BeforeEach(func() {
+					taskPlan.Config.Limits = nil
+				})
+
+				It("uses the default limits", func() {
+					Expect(atc.CPULimit(*containerSpec.Limits.CPU)).To(Equal(atc.CPULimit(512)))
+					Expect(atc.MemoryLimit(*containerSpec.Limits.Memory)).To(Equal(atc.MemoryLimit(512)))
+				})
+
+				Context("when toplevel limits are set", func() {
+					BeforeEach(func() {
+						cpu := atc.CPULimit(2048)
+						taskPlan.Limits = &atc.ContainerLimits{CPU: &cpu}
+					})
+
+					It("only defaults the limits which are not set", func() {
+						Expect(atc.CPULimit(*containerSpec.Limits.CPU)).To(Equal(atc.CPULimit(2048)))
+						Expect(atc.MemoryLimit(*containerSpec.Limits.Memory)).To(Equal(atc.MemoryLimit(512)))
+					})
+				})
+			})
+		})
+
+		Context("when an output size limit is set", func() {
+			BeforeEach(func() {
+				size := atc.SizeLimit(4096)
+				taskPlan.Limits = &atc.ContainerLimits{OutputSize: &size}
+			})
+
+			It("adds it to the container limits", func() {
+				Expect(atc.SizeLimit(*containerSpec.Limits.OutputSize)).To(Equal(atc.SizeLimit(4096)))
+				Expect(atc.CPULimit(*containerSpec.Limits.CPU)).To(Equal(atc.CPULimit(1024)))
+			})
+
+			Context("when a default output size limit is set", func() {
+				BeforeEach(func() {
+					size := atc.SizeLimit(2048)
+					taskPlan.DefaultLimits = &atc.ContainerLimits{OutputSize: &size}
+				})
+
+				It("does not override it", func() {
+					Expect(atc.SizeLimit(*containerSpec.Limits.OutputSize)).To(Equal(atc.SizeLimit(4096)))
+				})
+			})
+
+			Context("when an output grows past it", func() {
+				BeforeEach(func() {
+					fakeClient.RunTaskStepReturns(
+						worker.TaskResult{ExitStatus: 137},
+						worker.OutputSizeLimitExceededError{
+							Output: "some-output",
+							Size:   8192,
+							Limit:  4096,
+						},
+					)
+				})
+
+				It("fails without error", func() {
+					Expect(stepOk).To(BeFalse())
+					Expect(stepErr).To(BeNil())
+				})
+
+				It("emits an Errored event naming the output", func() {
+					Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
+					_, message := fakeDelegate.ErroredArgsForCall(0)
+					Expect(message).To(Equal("output 'some-output' exceeded its size limit of 4096 bytes (8192 bytes)"))
+				})
+			})
+		})
+
+		Context("when a default output size limit is set", func() {
+			BeforeEach(func() {
+				size := atc.SizeLimit(2048)
+				taskPlan.DefaultLimits = &atc.ContainerLimits{OutputSize: &size}
+			})
+
+			It("uses it", func() {
+				Expect(atc.SizeLimit(*containerSpec.Limits.OutputSize)).To(Equal(atc.SizeLimit(2048)))
+			})
+		})
+
+		Context("when the config has hosts", func() {
+			BeforeEach(func() {
+				taskPlan.Config.Hosts = atc.HostsConfig{
+					"some-host":  "10.0.0.1",
+					"other-host": "10.0.0.2",
+				}
+			})
+
+			It("adds them to the container spec", func() {
+				Expect(containerSpec.Hosts).To(Equal(atc.HostsConfig{
+					"some-host":  "10.0.0.1",
+					"other-host": "10.0.0.2",
+				}))
+			})
+
+			Context("when the step has hosts too", func() {
+				BeforeEach(func() {
+					taskPlan.Hosts = atc.HostsConfig{
+						"some-host": "10.0.0.3",
+						"step-host": "10.0.0.4",
+					}
+				})
+
+				It("overrides the config's hosts with them", func() {
+					Expect(containerSpec.Hosts).To(Equal(atc.HostsConfig{
+						"some-host":  "10.0.0.3",
+						"other-host": "10.0.0.2",
+						"step-host":  "10.0.0.4",
+					}))
+				})
+			})
+		})
+
 		Context("when a timeout is configured", func() {
 			BeforeEach(func() {
 				taskPlan.Timeout = "1h"
@@ -468,6 +689,29 @@ var _ = Describe("TaskStep", func() {
 					Expect(inputMap["some-artifact-root/remapped-input"]).To(Equal(remappedInputArtifact))
 					Expect(stepErr).ToNot(HaveOccurred())
 				})
+
+				Context("when the plan marks it as colocated", func() {
+					var inputSource *workerfakes.FakeInputSource
+					var otherInputSource *workerfakes.FakeInputSource
+
+					BeforeEach(func() {
+						taskPlan.ColocatedInputs = []string{"remapped-input-src"}
+
+						inputSource = new(workerfakes.FakeInputSource)
+						inputSource.DestinationPathReturns("some-artifact-root/remapped-input")
+
+						otherInputSource = new(workerfakes.FakeInputSource)
+						otherInputSource.DestinationPathReturns("some-artifact-root/some-cache")
+
+						fakeArtifactSourcer.SourceInputsAndCachesReturns([]worker.InputSource{inputSource, otherInputSource}, nil)
+					})
+
+					It("colocates the container with it", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(containerSpec.Inputs).To(ConsistOf(inputSource, otherInputSource))
+						Expect(containerSpec.ColocatedInputs).To(ConsistOf(inputSource))
+					})
+				})
 			})
 
 			Context("when any of the inputs are missing", func() {
@@ -891,6 +1135,18 @@ var _ = Describe("TaskStep", func() {
 				})
 			})
 
+			Context("when any tags are specified on the task plan", func() {
+				BeforeEach(func() {
+					taskPlan.AnyTags = atc.Tags{"gpu", "bigmem"}
+				})
+
+				It("fetches the image with the same any tags", func() {
+					Expect(fakeDelegate.FetchImageCallCount()).To(Equal(1))
+					_, imageResource, _, _ := fakeDelegate.FetchImageArgsForCall(0)
+					Expect(imageResource.AnyTags).To(Equal(atc.Tags{"gpu", "bigmem"}))
+				})
+			})
+
 			Context("when tags are specified on the image resource", func() {
 				BeforeEach(func() {
 					taskPlan.Config.ImageResource.Tags = atc.Tags{"image", "tags"}
@@ -913,6 +1169,19 @@ var _ = Describe("TaskStep", func() {
 						Expect(imageResource.Tags).To(Equal(atc.Tags{"image", "tags"}))
 					})
 				})
+
+				Context("when any tags are specified on the task plan", func() {
+					BeforeEach(func() {
+						taskPlan.AnyTags = atc.Tags{"gpu", "bigmem"}
+					})
+
+					It("fetches the image using only the image tags", func() {
+						Expect(fakeDelegate.FetchImageCallCount()).To(Equal(1))
+						_, imageResource, _, _ := fakeDelegate.FetchImageArgsForCall(0)
+						Expect(imageResource.Tags).To(Equal(atc.Tags{"image", "tags"}))
+						Expect(imageResource.AnyTags).To(BeEmpty())
+					})
+				})
 			})
 
 			Context("when privileged", func() {
@@ -1081,6 +1350,14 @@ var _ = Describe("TaskStep", func() {
 					Expect(status).To(Equal(exec.ExitStatus(taskStepStatus)))
 				})
 
+				It("exposes the exit code as a local var named after the task", func() {
+					Expect(state.AddLocalVarCallCount()).To(Equal(1))
+					name, val, redact := state.AddLocalVarArgsForCall(0)
+					Expect(name).To(Equal("some-task"))
+					Expect(val).To(Equal(map[string]interface{}{"exit_code": 5}))
+					Expect(redact).To(BeFalse())
+				})
+
 				It("returns successfully", func() {
 					Expect(stepErr).ToNot(HaveOccurred())
 				})
@@ -1102,6 +1379,10 @@ var _ = Describe("TaskStep", func() {
 			It("is not successful", func() {
 				Expect(stepOk).To(BeFalse())
 			})
+
+			It("does not expose an exit code", func() {
+				Expect(state.AddLocalVarCallCount()).To(BeZero())
+			})
 		})
 
 		Context("when the task step is interrupted", func() {
@@ -1227,9 +1508,11 @@ var _ = Describe("TaskStep", func() {
 		Context("when output is remapped", func() {
 			var (
 				fakeMountPath string = "some-artifact-root/generic-remapped-output/"
+				fakeVolume    *workerfakes.FakeVolume
 			)
 
 			BeforeEach(func() {
+				stepMetadata.JobID = 12345
 				taskPlan.OutputMapping = map[string]string{"generic-remapped-output": "specific-remapped-output"}
 				taskPlan.Config = &atc.TaskConfig{
 					Platform: "some-platform",
@@ -1241,8 +1524,9 @@ var _ = Describe("TaskStep", func() {
 					},
 				}
 
-				fakeVolume := new(workerfakes.FakeVolume)
+				fakeVolume = new(workerfakes.FakeVolume)
 				fakeVolume.HandleReturns("some-handle")
+				fakeVolume.InitializeArtifactReturns(new(dbfakes.FakeWorkerArtifact), nil)
 
 				taskResult := worker.TaskResult{
 					ExitStatus: 0,
@@ -1267,6 +1551,214 @@ var _ = Describe("TaskStep", func() {
 				artifactMap := repo.AsMap()
 				Expect(artifactMap).To(ConsistOf(artifact))
 			})
+
+			It("initializes the output volume as a build artifact with the specific name", func() {
+				Expect(fakeVolume.InitializeArtifactCallCount()).To(Equal(1))
+				name, buildID := fakeVolume.InitializeArtifactArgsForCall(0)
+				Expect(name).To(Equal("specific-remapped-output"))
+				Expect(buildID).To(Equal(stepMetadata.BuildID))
+			})
+
+			Context("when the build is a one-off build", func() {
+				BeforeEach(func() {
+					stepMetadata.JobID = 0
+				})
+
+				It("does not initialize the output volume as a build artifact", func() {
+					Expect(fakeVolume.InitializeArtifactCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the task caches its outputs", func() {
+			var (
+				fakeVolume   *workerfakes.FakeVolume
+				fakeArtifact *dbfakes.FakeWorkerArtifact
+			)
+
+			BeforeEach(func() {
+				stepMetadata.JobID = 12345
+				taskPlan.CacheOutputs = true
+				taskPlan.Config = &atc.TaskConfig{
+					Platform: "some-platform",
+					Run: atc.TaskRunConfig{
+						Path: "make",
+					},
+					Inputs: []atc.TaskInputConfig{
+						{Name: "some-input"},
+					},
+					Outputs: []atc.TaskOutputConfig{
+						{Name: "some-output"},
+					},
+				}
+
+				repo.RegisterArtifact("some-input", runtime.GetArtifact{VolumeHandle: "some-input-handle", ResourceCacheID: 1})
+
+				fakeArtifact = new(dbfakes.FakeWorkerArtifact)
+				fakeVolume = new(workerfakes.FakeVolume)
+				fakeVolume.HandleReturns("some-output-handle")
+				fakeVolume.InitializeArtifactReturns(fakeArtifact, nil)
+
+				fakeClient.RunTaskStepReturns(worker.TaskResult{
+					ExitStatus: 0,
+					VolumeMounts: []worker.VolumeMount{
+						{
+							Volume:    fakeVolume,
+							MountPath: "some-artifact-root/some-output/",
+						},
+					},
+				}, nil)
+			})
+
+			Context("when the outputs are not cached", func() {
+				It("runs the task", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stepOk).To(BeTrue())
+					Expect(fakeClient.RunTaskStepCallCount()).To(Equal(1))
+				})
+
+				It("caches the outputs under the fingerprint it looked up", func() {
+					Expect(fakeOutputCache.FindCallCount()).To(Equal(1))
+					jobID, stepName, fingerprint := fakeOutputCache.FindArgsForCall(0)
+					Expect(jobID).To(Equal(12345))
+					Expect(stepName).To(Equal("some-task"))
+					Expect(fingerprint).ToNot(BeEmpty())
+
+					Expect(fakeOutputCache.SaveCallCount()).To(Equal(1))
+					savedJobID, savedStepName, savedFingerprint, artifacts := fakeOutputCache.SaveArgsForCall(0)
+					Expect(savedJobID).To(Equal(12345))
+					Expect(savedStepName).To(Equal("some-task"))
+					Expect(savedFingerprint).To(Equal(fingerprint))
+					Expect(artifacts).To(Equal(map[string]db.WorkerArtifact{
+						"some-output": fakeArtifact,
+					}))
+				})
+
+				Context("when the task fails", func() {
+					BeforeEach(func() {
+						fakeClient.RunTaskStepReturns(worker.TaskResult{ExitStatus: 1}, nil)
+					})
+
+					It("does not cache the outputs", func() {
+						Expect(stepOk).To(BeFalse())
+						Expect(fakeOutputCache.SaveCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the build is a one-off build", func() {
+					BeforeEach(func() {
+						stepMetadata.JobID = 0
+					})
+
+					It("does not use the cache", func() {
+						Expect(fakeOutputCache.FindCallCount()).To(BeZero())
+						Expect(fakeOutputCache.SaveCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the outputs are cached", func() {
+				BeforeEach(func() {
+					fakeOutputCache.FindReturns(map[string]string{"some-output": "cached-handle"}, nil)
+					shouldRunTaskStep = false
+				})
+
+				It("succeeds without running the task", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stepOk).To(BeTrue())
+					Expect(fakeClient.RunTaskStepCallCount()).To(BeZero())
+
+					Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+					_, status, _, _ := fakeDelegate.FinishedArgsForCall(0)
+					Expect(status).To(Equal(exec.ExitStatus(0)))
+				})
+
+				It("registers the cached outputs", func() {
+					artifact, found := repo.ArtifactFor("some-output")
+					Expect(found).To(BeTrue())
+					Expect(artifact.ID()).To(Equal("cached-handle"))
+				})
+
+				Context("when only some of the outputs are cached", func() {
+					BeforeEach(func() {
+						taskPlan.Config.Outputs = append(taskPlan.Config.Outputs, atc.TaskOutputConfig{Name: "other-output"})
+						shouldRunTaskStep = true
+					})
+
+					It("runs the task", func() {
+						Expect(fakeClient.RunTaskStepCallCount()).To(Equal(1))
+					})
+				})
+			})
+
+			Context("when a different version of an input is used", func() {
+				It("looks up a different fingerprint", func() {
+					_, _, fingerprint := fakeOutputCache.FindArgsForCall(0)
+
+					repo.RegisterArtifact("some-input", runtime.GetArtifact{VolumeHandle: "some-input-handle", ResourceCacheID: 2})
+
+					_, err := taskStep.Run(ctx, state)
+					Expect(err).ToNot(HaveOccurred())
+
+					_, _, otherFingerprint := fakeOutputCache.FindArgsForCall(1)
+					Expect(otherFingerprint).ToNot(Equal(fingerprint))
+				})
+			})
+
+			Context("when the same version of an input is fetched on another worker", func() {
+				It("looks up the same fingerprint", func() {
+					_, _, fingerprint := fakeOutputCache.FindArgsForCall(0)
+
+					repo.RegisterArtifact("some-input", runtime.GetArtifact{VolumeHandle: "other-input-handle", ResourceCacheID: 1})
+
+					_, err := taskStep.Run(ctx, state)
+					Expect(err).ToNot(HaveOccurred())
+
+					_, _, otherFingerprint := fakeOutputCache.FindArgsForCall(1)
+					Expect(otherFingerprint).To(Equal(fingerprint))
+				})
+			})
+
+			Context("when the task uses an image resource", func() {
+				BeforeEach(func() {
+					taskPlan.Config.ImageResource = &atc.ImageResource{
+						Type:   "registry-image",
+						Source: atc.Source{"repository": "some-image"},
+					}
+
+					fakeDelegate.FetchImageReturns(worker.ImageSpec{}, nil)
+				})
+
+				Context("when the fetched image's cache is not stored", func() {
+					BeforeEach(func() {
+						shouldRunTaskStep = false
+					})
+
+					It("errors", func() {
+						Expect(stepErr).To(HaveOccurred())
+					})
+				})
+
+				Context("when the fetched image's cache is stored", func() {
+					BeforeEach(func() {
+						state.ResultStub = func(id atc.PlanID, to interface{}) bool {
+							if id != planID+"/image-get" {
+								return false
+							}
+
+							cache := new(dbfakes.FakeUsedResourceCache)
+							cache.IDReturns(7)
+							*(to.(*db.UsedResourceCache)) = cache
+							return true
+						}
+					})
+
+					It("includes it in the fingerprint", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(fakeOutputCache.FindCallCount()).To(Equal(1))
+					})
+				})
+			})
 		})
 	})
 })
@@ -140,6 +140,12 @@ func (br *buildLogCollector) reapLogsOfJob(pipeline db.Pipeline,
 			continue
 		}
 
+		// Protected builds are exempt from retention, and don't count towards it.
+		if build.IsProtected() {
+			firstLoggedBuildID = build.ID()
+			continue
+		}
+
 		if logRetention.Days > 0 {
 			if !build.EndTime().IsZero() && build.EndTime().AddDate(0, 0, logRetention.Days).Before(time.Now()) {
 				logger.Debug("should-reap-due-to-days", build.LagerData())
@@ -281,6 +281,49 @@ var _ = Describe("BuildLogCollector", func() {
 				})
 			})
 
+			Context("when some of the builds are protected", func() {
+				BeforeEach(func() {
+					fakeJob.ConfigReturns(atc.JobConfig{
+						BuildLogsToRetain: 2,
+					}, nil)
+					fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
+						if *page.From == 5 {
+							return []db.Build{
+								sb(10),
+								protectedBuild(9),
+								sb(8),
+								protectedBuild(7),
+								sb(6),
+								sb(5),
+							}, db.Pagination{}, nil
+						}
+						Fail(fmt.Sprintf("Builds called with unexpected argument: page=%#v", page))
+						return nil, db.Pagination{}, nil
+					}
+
+					fakePipeline.DeleteBuildEventsByBuildIDsReturns(nil)
+
+					fakeJob.UpdateFirstLoggedBuildIDReturns(nil)
+				})
+
+				JustBeforeEach(func() {
+					err := buildLogCollector.Run(context.TODO())
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("reaps only unprotected builds, not counting protected ones as retained", func() {
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+					actualBuildIDs := fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)
+					Expect(actualBuildIDs).To(ConsistOf(6, 5))
+				})
+
+				It("updates FirstLoggedBuildID to the earliest protected build", func() {
+					Expect(fakeJob.UpdateFirstLoggedBuildIDCallCount()).To(Equal(1))
+					actualNewFirstLoggedBuildID := fakeJob.UpdateFirstLoggedBuildIDArgsForCall(0)
+					Expect(actualNewFirstLoggedBuildID).To(Equal(7))
+				})
+			})
+
 			Context("when no builds need to be reaped", func() {
 				BeforeEach(func() {
 					fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
@@ -792,6 +835,13 @@ func runningBuild(id int) db.Build {
 	return build
 }
 
+func protectedBuild(id int) db.Build {
+	build := new(dbfakes.FakeBuild)
+	build.IDReturns(id)
+	build.IsProtectedReturns(true)
+	return build
+}
+
 func reapedBuild(id int) db.Build {
 	build := new(dbfakes.FakeBuild)
 	build.IDReturns(id)
@@ -8,24 +8,35 @@ import (
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/worker"
 	"github.com/hashicorp/go-multierror"
 )
 
 type containerCollector struct {
 	containerRepository         db.ContainerRepository
+	workerFactory               db.WorkerFactory
+	workerDestroyer             worker.Destroyer
 	missingContainerGracePeriod time.Duration
 	hijackContainerGracePeriod  time.Duration
+	destroyPool                 destroyPool
 }
 
 func NewContainerCollector(
 	containerRepository db.ContainerRepository,
+	workerFactory db.WorkerFactory,
+	workerDestroyer worker.Destroyer,
 	missingContainerGracePeriod time.Duration,
 	hijackContainerGracePeriod time.Duration,
+	destroyMaxInFlight uint16,
+	destroyMaxInFlightPerWorker uint16,
 ) *containerCollector {
 	return &containerCollector{
 		containerRepository:         containerRepository,
+		workerFactory:               workerFactory,
+		workerDestroyer:             workerDestroyer,
 		missingContainerGracePeriod: missingContainerGracePeriod,
 		hijackContainerGracePeriod:  hijackContainerGracePeriod,
+		destroyPool:                 newDestroyPool(destroyMaxInFlight, destroyMaxInFlightPerWorker),
 	}
 }
 
@@ -62,9 +73,31 @@ func (c *containerCollector) Run(ctx context.Context) error {
 		logger.Error("failed-to-clean-up-missing-containers", err)
 	}
 
+	err = c.emitPendingDestruction(logger.Session("pending-destruction"))
+	if err != nil {
+		errs = multierror.Append(errs, err)
+		logger.Error("failed-to-count-containers-pending-destruction", err)
+	}
+
 	return errs
 }
 
+func (c *containerCollector) emitPendingDestruction(logger lager.Logger) error {
+	counts, err := c.containerRepository.CountDestroyingContainersByWorker()
+	if err != nil {
+		return err
+	}
+
+	for workerName, count := range counts {
+		metric.WorkerContainersPendingDestruction{
+			WorkerName: workerName,
+			Containers: count,
+		}.Emit(logger)
+	}
+
+	return nil
+}
+
 func (c *containerCollector) markFailedContainersAsDestroying(logger lager.Logger) error {
 
 	numFailedContainers, err := c.containerRepository.DestroyFailedContainers()
@@ -114,16 +147,67 @@ func (c *containerCollector) cleanupOrphanedContainers(logger lager.Logger) erro
 		Containers: len(destroyingContainers),
 	}.Emit(logger)
 
+	workers, err := runningWorkers(c.workerFactory)
+	if err != nil {
+		logger.Error("failed-to-get-running-workers", err)
+	}
+
+	items := []destroyItem{}
 	for _, createdContainer := range createdContainers {
+		createdContainer := createdContainer
 
 		if time.Since(createdContainer.LastHijack()) > c.hijackContainerGracePeriod {
-			_, err := createdContainer.Destroying()
-			if err != nil {
-				logger.Error("failed-to-transition", err, lager.Data{"container": createdContainer.Handle()})
-				continue
-			}
+			items = append(items, destroyItem{
+				handle:     createdContainer.Handle(),
+				workerName: createdContainer.WorkerName(),
+				destroy: func() error {
+					destroyingContainer, err := createdContainer.Destroying()
+					if err != nil {
+						return err
+					}
+
+					return c.destroyOnWorker(logger, workers, createdContainer.WorkerName(), destroyingContainer)
+				},
+			})
 		}
 	}
 
+	marked := c.destroyPool.Run(items, func(item destroyItem, err error) {
+		logger.Error("failed-to-destroy", err, lager.Data{"container": item.handle, "worker": item.workerName})
+	})
+
+	for workerName, count := range marked {
+		metric.WorkerContainersMarkedForDestruction{
+			WorkerName: workerName,
+			Containers: count,
+		}.Emit(logger)
+	}
+
+	return nil
+}
+
+// destroyOnWorker destroys the container on its worker, if the worker is
+// running, and then removes it. Otherwise, or if destroying it fails, it's
+// left destroying for the worker to sweep.
+func (c *containerCollector) destroyOnWorker(logger lager.Logger, workers map[string]db.Worker, workerName string, container db.DestroyingContainer) error {
+	dbWorker, running := workers[workerName]
+	if !running {
+		return nil
+	}
+
+	err := c.workerDestroyer.DestroyContainer(logger, dbWorker, container.Handle())
+	if err != nil {
+		return err
+	}
+
+	destroyed, err := container.Destroy()
+	if err != nil {
+		return err
+	}
+
+	if destroyed {
+		metric.Metrics.ContainersDeleted.Inc()
+	}
+
 	return nil
 }
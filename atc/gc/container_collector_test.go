@@ -3,11 +3,15 @@ package gc_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/gc"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
 
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/onsi/ginkgo"
@@ -17,6 +21,8 @@ import (
 var _ = Describe("ContainerCollector", func() {
 	var (
 		fakeContainerRepository *dbfakes.FakeContainerRepository
+		fakeWorkerFactory       *dbfakes.FakeWorkerFactory
+		fakeWorkerDestroyer     *workerfakes.FakeDestroyer
 		creatingContainer       *dbfakes.FakeCreatingContainer
 		createdContainer        *dbfakes.FakeCreatedContainer
 		destroyingContainer     *dbfakes.FakeDestroyingContainer
@@ -29,6 +35,8 @@ var _ = Describe("ContainerCollector", func() {
 
 	BeforeEach(func() {
 		fakeContainerRepository = new(dbfakes.FakeContainerRepository)
+		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		fakeWorkerDestroyer = new(workerfakes.FakeDestroyer)
 		creatingContainer = new(dbfakes.FakeCreatingContainer)
 		createdContainer = new(dbfakes.FakeCreatedContainer)
 		destroyingContainer = new(dbfakes.FakeDestroyingContainer)
@@ -40,8 +48,12 @@ var _ = Describe("ContainerCollector", func() {
 
 		collector = gc.NewContainerCollector(
 			fakeContainerRepository,
+			fakeWorkerFactory,
+			fakeWorkerDestroyer,
 			missingContainerGracePeriod,
 			hijackContainerGracePeriod,
+			4,
+			2,
 		)
 	})
 
@@ -83,6 +95,22 @@ var _ = Describe("ContainerCollector", func() {
 			})
 		})
 
+		Describe("Containers pending destruction", func() {
+			It("counts the destroying containers on each worker", func() {
+				Expect(fakeContainerRepository.CountDestroyingContainersByWorkerCallCount()).To(Equal(1))
+			})
+
+			Context("when counting the destroying containers fails", func() {
+				BeforeEach(func() {
+					fakeContainerRepository.CountDestroyingContainersByWorkerReturns(nil, errors.New("some error"))
+				})
+
+				It("returns the error", func() {
+					Expect(err).To(MatchError(ContainSubstring("some error")))
+				})
+			})
+		})
+
 		Describe("Orphaned Containers", func() {
 
 			var (
@@ -169,6 +197,141 @@ var _ = Describe("ContainerCollector", func() {
 				Expect(destroyingContainer.DestroyCallCount()).To(Equal(0))
 			})
 
+			It("leaves the containers of workers which aren't running for them to sweep", func() {
+				Expect(fakeWorkerDestroyer.DestroyContainerCallCount()).To(Equal(0))
+			})
+
+			Context("when the container's worker is running", func() {
+				var fakeWorker *dbfakes.FakeWorker
+
+				BeforeEach(func() {
+					fakeWorker = new(dbfakes.FakeWorker)
+					fakeWorker.NameReturns("foo")
+					fakeWorker.StateReturns(db.WorkerStateRunning)
+
+					stalledWorker := new(dbfakes.FakeWorker)
+					stalledWorker.NameReturns("bar")
+					stalledWorker.StateReturns(db.WorkerStateStalled)
+
+					fakeWorkerFactory.WorkersReturns([]db.Worker{fakeWorker, stalledWorker}, nil)
+				})
+
+				It("destroys the container on the worker and then removes it", func() {
+					Expect(fakeWorkerDestroyer.DestroyContainerCallCount()).To(Equal(1))
+					_, worker, handle := fakeWorkerDestroyer.DestroyContainerArgsForCall(0)
+					Expect(worker).To(Equal(fakeWorker))
+					Expect(handle).To(Equal("some-handle-2"))
+
+					Expect(destroyingContainerFromCreated.DestroyCallCount()).To(Equal(1))
+				})
+
+				Context("when destroying the container on the worker fails", func() {
+					BeforeEach(func() {
+						fakeWorkerDestroyer.DestroyContainerReturns(errors.New("worker unreachable"))
+					})
+
+					It("leaves it destroying for the worker to sweep", func() {
+						Expect(err).ToNot(HaveOccurred())
+						Expect(destroyingContainerFromCreated.DestroyCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			Context("when the workers can't be found", func() {
+				BeforeEach(func() {
+					fakeWorkerFactory.WorkersReturns(nil, errors.New("some error"))
+				})
+
+				It("still marks the containers as destroying", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(createdContainer.DestroyingCallCount()).To(Equal(1))
+					Expect(fakeWorkerDestroyer.DestroyContainerCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when there are many created containers across workers", func() {
+				var (
+					createdContainers []*dbfakes.FakeCreatedContainer
+
+					lock        sync.Mutex
+					inFlight    map[string]int
+					maxInFlight map[string]int
+				)
+
+				BeforeEach(func() {
+					createdContainers = nil
+					inFlight = map[string]int{}
+					maxInFlight = map[string]int{}
+					containers := []db.CreatedContainer{}
+
+					workers := []db.Worker{}
+					for i := 0; i < 3; i++ {
+						worker := new(dbfakes.FakeWorker)
+						worker.NameReturns(fmt.Sprintf("worker-%d", i))
+						worker.StateReturns(db.WorkerStateRunning)
+						workers = append(workers, worker)
+					}
+
+					fakeWorkerFactory.WorkersReturns(workers, nil)
+
+					for i := 0; i < 10; i++ {
+						container := new(dbfakes.FakeCreatedContainer)
+						container.HandleReturns(fmt.Sprintf("some-handle-%d", i))
+						container.WorkerNameReturns(fmt.Sprintf("worker-%d", i%3))
+
+						destroying := new(dbfakes.FakeDestroyingContainer)
+						destroying.HandleReturns(fmt.Sprintf("some-handle-%d", i))
+						destroying.WorkerNameReturns(fmt.Sprintf("worker-%d", i%3))
+
+						if i == 0 {
+							container.DestroyingReturns(nil, errors.New("disaster"))
+						} else {
+							container.DestroyingReturns(destroying, nil)
+						}
+
+						createdContainers = append(createdContainers, container)
+						containers = append(containers, container)
+					}
+
+					fakeWorkerDestroyer.DestroyContainerStub = func(_ lager.Logger, worker db.Worker, _ string) error {
+						workerName := worker.Name()
+
+						lock.Lock()
+						inFlight[workerName]++
+						if inFlight[workerName] > maxInFlight[workerName] {
+							maxInFlight[workerName] = inFlight[workerName]
+						}
+						lock.Unlock()
+
+						time.Sleep(10 * time.Millisecond)
+
+						lock.Lock()
+						inFlight[workerName]--
+						lock.Unlock()
+
+						return nil
+					}
+
+					fakeContainerRepository.FindOrphanedContainersReturns(nil, containers, nil, nil)
+				})
+
+				It("still destroys the other containers when one fails", func() {
+					Expect(err).ToNot(HaveOccurred())
+
+					for _, container := range createdContainers {
+						Expect(container.DestroyingCallCount()).To(Equal(1))
+					}
+
+					Expect(fakeWorkerDestroyer.DestroyContainerCallCount()).To(Equal(9))
+				})
+
+				It("destroys at most the configured number of containers per worker at the same time", func() {
+					for _, max := range maxInFlight {
+						Expect(max).To(BeNumerically("<=", 2))
+					}
+				})
+			})
+
 			Context("when finding containers for deletion fails", func() {
 				BeforeEach(func() {
 					fakeContainerRepository.FindOrphanedContainersReturns(nil, nil, nil, errors.New("some error"))
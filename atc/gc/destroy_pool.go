@@ -0,0 +1,118 @@
+package gc
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+// destroyPool runs the destroy phase of a collector concurrently. At most
+// maxInFlight items are destroyed at the same time overall, and at most
+// maxInFlightPerWorker of them for any single worker, so that a big batch
+// for one worker doesn't overwhelm its baggageclaim or garden server.
+type destroyPool struct {
+	maxInFlight          int
+	maxInFlightPerWorker int
+}
+
+func newDestroyPool(maxInFlight, maxInFlightPerWorker uint16) destroyPool {
+	pool := destroyPool{
+		maxInFlight:          int(maxInFlight),
+		maxInFlightPerWorker: int(maxInFlightPerWorker),
+	}
+
+	if pool.maxInFlight < 1 {
+		pool.maxInFlight = 1
+	}
+
+	if pool.maxInFlightPerWorker < 1 || pool.maxInFlightPerWorker > pool.maxInFlight {
+		pool.maxInFlightPerWorker = pool.maxInFlight
+	}
+
+	return pool
+}
+
+// destroyItem is a single item of a batch, destroyed on the named worker.
+type destroyItem struct {
+	handle     string
+	workerName string
+	destroy    func() error
+}
+
+// Run destroys every item and returns the number of items successfully
+// destroyed per worker. An item failing to be destroyed is passed to
+// onError, which may be called concurrently, and doesn't stop the rest of
+// the batch.
+func (pool destroyPool) Run(items []destroyItem, onError func(destroyItem, error)) map[string]int {
+	queues := map[string][]destroyItem{}
+	for _, item := range items {
+		queues[item.workerName] = append(queues[item.workerName], item)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		lock      sync.Mutex
+		destroyed = map[string]int{}
+	)
+
+	inFlight := make(chan struct{}, pool.maxInFlight)
+
+	for workerName, queue := range queues {
+		workerItems := make(chan destroyItem, len(queue))
+		for _, item := range queue {
+			workerItems <- item
+		}
+		close(workerItems)
+
+		workers := pool.maxInFlightPerWorker
+		if workers > len(queue) {
+			workers = len(queue)
+		}
+
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+
+			go func(workerName string) {
+				defer wg.Done()
+
+				for item := range workerItems {
+					inFlight <- struct{}{}
+					err := item.destroy()
+					<-inFlight
+
+					if err != nil {
+						onError(item, err)
+						continue
+					}
+
+					lock.Lock()
+					destroyed[workerName]++
+					lock.Unlock()
+				}
+			}(workerName)
+		}
+	}
+
+	wg.Wait()
+
+	return destroyed
+}
+
+// runningWorkers returns the running workers by name. Containers and volumes
+// are destroyed on them right away, while those of other workers are left
+// destroying for the workers to sweep once they're back.
+func runningWorkers(workerFactory db.WorkerFactory) (map[string]db.Worker, error) {
+	workers, err := workerFactory.Workers()
+	if err != nil {
+		return nil, err
+	}
+
+	running := map[string]db.Worker{}
+	for _, worker := range workers {
+		if worker.State() == db.WorkerStateRunning {
+			running[worker.Name()] = worker
+		}
+	}
+
+	return running, nil
+}
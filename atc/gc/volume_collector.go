@@ -8,21 +8,55 @@ import (
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/worker"
 	multierror "github.com/hashicorp/go-multierror"
 )
 
+// DiskPressure configures the eviction of resource caches from workers whose
+// disks are filling up. Once a worker's disk usage crosses the high water mark,
+// its least recently used resource caches are evicted on every run until its
+// usage drops back down to the low water mark.
+type DiskPressure struct {
+	// HighWaterMark is the percentage of disk usage from which caches are
+	// evicted. Zero disables eviction.
+	HighWaterMark float64
+	LowWaterMark  float64
+
+	// EvictionBatchSize is the maximum number of caches evicted from a worker
+	// on each run, since a worker only reports the disk space freed up once
+	// it has destroyed them.
+	EvictionBatchSize int
+}
+
+func (dp DiskPressure) enabled() bool {
+	return dp.HighWaterMark > 0
+}
+
 type volumeCollector struct {
 	volumeRepository         db.VolumeRepository
+	workerFactory            db.WorkerFactory
+	workerDestroyer          worker.Destroyer
 	missingVolumeGracePeriod time.Duration
+	diskPressure             DiskPressure
+	destroyPool              destroyPool
 }
 
 func NewVolumeCollector(
 	volumeRepository db.VolumeRepository,
+	workerFactory db.WorkerFactory,
+	workerDestroyer worker.Destroyer,
 	missingVolumeGracePeriod time.Duration,
+	diskPressure DiskPressure,
+	destroyMaxInFlight uint16,
+	destroyMaxInFlightPerWorker uint16,
 ) *volumeCollector {
 	return &volumeCollector{
 		volumeRepository:         volumeRepository,
+		workerFactory:            workerFactory,
+		workerDestroyer:          workerDestroyer,
 		missingVolumeGracePeriod: missingVolumeGracePeriod,
+		diskPressure:             diskPressure,
+		destroyPool:              newDestroyPool(destroyMaxInFlight, destroyMaxInFlightPerWorker),
 	}
 }
 
@@ -47,21 +81,57 @@ func (vc *volumeCollector) Run(ctx context.Context) error {
 		logger.Error("failed-to-clean-up-failed-volumes", err)
 	}
 
-	err = vc.markOrphanedVolumesAsDestroying(logger.Session("mark-volumes"))
+	workers, err := runningWorkers(vc.workerFactory)
+	if err != nil {
+		errs = multierror.Append(errs, err)
+		logger.Error("failed-to-get-running-workers", err)
+	}
+
+	err = vc.markOrphanedVolumesAsDestroying(logger.Session("mark-volumes"), workers)
 	if err != nil {
 		errs = multierror.Append(errs, err)
 		logger.Error("failed-to-transition-created-volumes-to-destroying", err)
 	}
 
+	if vc.diskPressure.enabled() {
+		err = vc.evictUnderDiskPressure(logger.Session("disk-pressure"), workers)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			logger.Error("failed-to-evict-volumes-under-disk-pressure", err)
+		}
+	}
+
 	_, err = vc.volumeRepository.RemoveMissingVolumes(vc.missingVolumeGracePeriod)
 	if err != nil {
 		errs = multierror.Append(errs, err)
 		logger.Error("failed-to-clean-up-missing-volumes", err)
 	}
 
+	err = vc.emitPendingDestruction(logger.Session("pending-destruction"))
+	if err != nil {
+		errs = multierror.Append(errs, err)
+		logger.Error("failed-to-count-volumes-pending-destruction", err)
+	}
+
 	return errs
 }
 
+func (vc *volumeCollector) emitPendingDestruction(logger lager.Logger) error {
+	counts, err := vc.volumeRepository.CountDestroyingVolumesByWorker()
+	if err != nil {
+		return err
+	}
+
+	for workerName, count := range counts {
+		metric.WorkerVolumesPendingDestruction{
+			WorkerName: workerName,
+			Volumes:    count,
+		}.Emit(logger)
+	}
+
+	return nil
+}
+
 func (vc *volumeCollector) cleanupFailedVolumes(logger lager.Logger) error {
 	failedVolumesLen, err := vc.volumeRepository.DestroyFailedVolumes()
 	if err != nil {
@@ -82,7 +152,7 @@ func (vc *volumeCollector) cleanupFailedVolumes(logger lager.Logger) error {
 	return nil
 }
 
-func (vc *volumeCollector) markOrphanedVolumesAsDestroying(logger lager.Logger) error {
+func (vc *volumeCollector) markOrphanedVolumesAsDestroying(logger lager.Logger, workers map[string]db.Worker) error {
 	orphanedVolumesHandles, err := vc.volumeRepository.GetOrphanedVolumes()
 	if err != nil {
 		logger.Error("failed-to-get-orphaned-volumes", err)
@@ -99,19 +169,161 @@ func (vc *volumeCollector) markOrphanedVolumesAsDestroying(logger lager.Logger)
 		Volumes: len(orphanedVolumesHandles),
 	}.Emit(logger)
 
-	for _, orphanedVolume := range orphanedVolumesHandles {
-		// queue
-		vLog := logger.Session("mark-created-as-destroying", lager.Data{
-			"volume": orphanedVolume.Handle(),
-			"worker": orphanedVolume.WorkerName(),
+	items := make([]destroyItem, len(orphanedVolumesHandles))
+	for i, orphanedVolume := range orphanedVolumesHandles {
+		orphanedVolume := orphanedVolume
+
+		items[i] = destroyItem{
+			handle:     orphanedVolume.Handle(),
+			workerName: orphanedVolume.WorkerName(),
+			destroy: func() error {
+				destroyingVolume, err := orphanedVolume.Destroying()
+				if err != nil {
+					return err
+				}
+
+				return vc.destroyOnWorker(logger, workers, orphanedVolume.WorkerName(), destroyingVolume)
+			},
+		}
+	}
+
+	marked := vc.destroyPool.Run(items, func(item destroyItem, err error) {
+		logger.Session("destroy-orphaned-volume", lager.Data{
+			"volume": item.handle,
+			"worker": item.workerName,
+		}).Error("failed-to-destroy", err)
+	})
+
+	for workerName, count := range marked {
+		metric.WorkerVolumesMarkedForDestruction{
+			WorkerName: workerName,
+			Volumes:    count,
+		}.Emit(logger)
+	}
+
+	return nil
+}
+
+// evictUnderDiskPressure destroys the least recently used resource cache
+// volumes of workers under disk pressure. Volumes in use by running builds are
+// never evicted.
+func (vc *volumeCollector) evictUnderDiskPressure(logger lager.Logger, workers map[string]db.Worker) error {
+	reportingWorkers, err := vc.workerFactory.Workers()
+	if err != nil {
+		logger.Error("failed-to-get-workers", err)
+		return err
+	}
+
+	var errs error
+	var items []destroyItem
+
+	for _, worker := range reportingWorkers {
+		usage, reported := worker.DiskUsage()
+		if !reported {
+			continue
+		}
+
+		wLog := logger.WithData(lager.Data{
+			"worker":  worker.Name(),
+			"percent": usage.Percent(),
 		})
 
-		_, err = orphanedVolume.Destroying()
+		pressure := worker.DiskPressure()
+		switch {
+		case usage.Percent() >= vc.diskPressure.HighWaterMark:
+			pressure = true
+		case usage.Percent() <= vc.diskPressure.LowWaterMark:
+			pressure = false
+		}
+
+		if pressure != worker.DiskPressure() {
+			wLog.Info("disk-pressure-changed", lager.Data{"pressure": pressure})
+
+			err := worker.SetDiskPressure(pressure)
+			if err != nil {
+				errs = multierror.Append(errs, err)
+				wLog.Error("failed-to-set-disk-pressure", err)
+				continue
+			}
+		}
+
+		if !pressure {
+			continue
+		}
+
+		volumes, err := vc.volumeRepository.GetEvictableResourceCacheVolumes(worker.Name(), vc.diskPressure.EvictionBatchSize)
 		if err != nil {
-			vLog.Error("failed-to-transition", err)
+			errs = multierror.Append(errs, err)
+			wLog.Error("failed-to-get-evictable-volumes", err)
+			continue
+		}
+
+		if len(volumes) == 0 {
+			wLog.Info("no-volumes-to-evict")
 			continue
 		}
 
+		for _, volume := range volumes {
+			volume := volume
+
+			items = append(items, destroyItem{
+				handle:     volume.Handle(),
+				workerName: volume.WorkerName(),
+				destroy: func() error {
+					destroyingVolume, err := volume.Destroying()
+					if err != nil {
+						return err
+					}
+
+					return vc.destroyOnWorker(logger, workers, volume.WorkerName(), destroyingVolume)
+				},
+			})
+		}
+	}
+
+	evicted := vc.destroyPool.Run(items, func(item destroyItem, err error) {
+		logger.Session("evict-volume", lager.Data{
+			"volume": item.handle,
+			"worker": item.workerName,
+		}).Error("failed-to-destroy", err)
+	})
+
+	for workerName, count := range evicted {
+		logger.Info("evicted-volumes", lager.Data{
+			"worker":  workerName,
+			"volumes": count,
+		})
+
+		metric.WorkerVolumesEvictedUnderDiskPressure{
+			WorkerName: workerName,
+			Volumes:    count,
+		}.Emit(logger)
+	}
+
+	return errs
+}
+
+// destroyOnWorker destroys the volume on its worker, if the worker is
+// running, and then removes it. Otherwise, or if destroying it fails, it's
+// left destroying for the worker to sweep.
+func (vc *volumeCollector) destroyOnWorker(logger lager.Logger, workers map[string]db.Worker, workerName string, volume db.DestroyingVolume) error {
+	dbWorker, running := workers[workerName]
+	if !running {
+		return nil
+	}
+
+	err := vc.workerDestroyer.DestroyVolume(logger, dbWorker, volume.Handle())
+	if err != nil {
+		return err
+	}
+
+	destroyed, err := volume.Destroy()
+	if err != nil {
+		return err
+	}
+
+	if destroyed {
+		metric.Metrics.VolumesDeleted.Inc()
 	}
 
 	return nil
@@ -2,12 +2,14 @@ package gc_test
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/gc"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -18,13 +20,14 @@ var _ = Describe("VolumeCollector", func() {
 		volumeCollector          GcCollector
 		missingVolumeGracePeriod time.Duration
 
-		volumeRepository   db.VolumeRepository
-		workerFactory      db.WorkerFactory
-		creatingContainer1 db.CreatingContainer
-		creatingContainer2 db.CreatingContainer
-		team               db.Team
-		worker             db.Worker
-		build              db.Build
+		volumeRepository    db.VolumeRepository
+		workerFactory       db.WorkerFactory
+		fakeWorkerDestroyer *workerfakes.FakeDestroyer
+		creatingContainer1  db.CreatingContainer
+		creatingContainer2  db.CreatingContainer
+		team                db.Team
+		worker              db.Worker
+		build               db.Build
 	)
 
 	BeforeEach(func() {
@@ -32,12 +35,18 @@ var _ = Describe("VolumeCollector", func() {
 
 		volumeRepository = db.NewVolumeRepository(dbConn)
 		workerFactory = db.NewWorkerFactory(dbConn)
+		fakeWorkerDestroyer = new(workerfakes.FakeDestroyer)
 
 		missingVolumeGracePeriod = 1 * time.Minute
 
 		volumeCollector = gc.NewVolumeCollector(
 			volumeRepository,
+			workerFactory,
+			fakeWorkerDestroyer,
 			missingVolumeGracePeriod,
+			gc.DiskPressure{},
+			4,
+			2,
 		)
 	})
 
@@ -72,7 +81,12 @@ var _ = Describe("VolumeCollector", func() {
 
 				volumeCollector = gc.NewVolumeCollector(
 					fakeVolumeRepository,
+					workerFactory,
+					fakeWorkerDestroyer,
 					missingVolumeGracePeriod,
+					gc.DiskPressure{},
+					4,
+					2,
 				)
 
 				err = volumeCollector.Run(context.TODO())
@@ -145,15 +159,169 @@ var _ = Describe("VolumeCollector", func() {
 				Expect(destroyed).To(BeTrue())
 			})
 
-			It("marks orphaned volumes as 'destroying'", func() {
+			It("destroys orphaned volumes on their worker and removes them", func() {
 				err = volumeCollector.Run(context.TODO())
 				Expect(err).NotTo(HaveOccurred())
 
+				Expect(fakeWorkerDestroyer.DestroyVolumeCallCount()).To(Equal(1))
+				_, destroyedOn, handle := fakeWorkerDestroyer.DestroyVolumeArgsForCall(0)
+				Expect(destroyedOn.Name()).To(Equal(worker.Name()))
+				Expect([]string{handle}).To(Equal(expectedOrphanedVolumeHandles))
+
 				destroyingVolumes, err := volumeRepository.GetDestroyingVolumes(worker.Name())
 				Expect(err).NotTo(HaveOccurred())
-				Expect(destroyingVolumes).To(HaveLen(1))
+				Expect(destroyingVolumes).To(BeEmpty())
+
+				_, found, err := volumeRepository.FindCreatedVolume(handle)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			Context("when destroying them on their worker fails", func() {
+				BeforeEach(func() {
+					fakeWorkerDestroyer.DestroyVolumeReturns(errors.New("worker unreachable"))
+				})
+
+				It("marks orphaned volumes as 'destroying' for the worker to sweep", func() {
+					err = volumeCollector.Run(context.TODO())
+					Expect(err).NotTo(HaveOccurred())
+
+					destroyingVolumes, err := volumeRepository.GetDestroyingVolumes(worker.Name())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(destroyingVolumes).To(HaveLen(1))
 
-				Expect(destroyingVolumes).To(Equal(expectedOrphanedVolumeHandles))
+					Expect(destroyingVolumes).To(Equal(expectedOrphanedVolumeHandles))
+				})
+			})
+		})
+
+		Context("when disk pressure eviction is configured", func() {
+			var (
+				fakeVolumeRepository *dbfakes.FakeVolumeRepository
+				fakeWorkerFactory    *dbfakes.FakeWorkerFactory
+				fakeWorker           *dbfakes.FakeWorker
+				fakeVolume           *dbfakes.FakeCreatedVolume
+			)
+
+			BeforeEach(func() {
+				fakeVolumeRepository = new(dbfakes.FakeVolumeRepository)
+				fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+
+				fakeWorker = new(dbfakes.FakeWorker)
+				fakeWorker.NameReturns("some-worker")
+				fakeWorkerFactory.WorkersReturns([]db.Worker{fakeWorker}, nil)
+
+				fakeVolume = new(dbfakes.FakeCreatedVolume)
+				fakeVolume.HandleReturns("some-cache-volume")
+				fakeVolume.WorkerNameReturns("some-worker")
+				fakeVolumeRepository.GetEvictableResourceCacheVolumesReturns([]db.CreatedVolume{fakeVolume}, nil)
+
+				volumeCollector = gc.NewVolumeCollector(
+					fakeVolumeRepository,
+					fakeWorkerFactory,
+					fakeWorkerDestroyer,
+					missingVolumeGracePeriod,
+					gc.DiskPressure{
+						HighWaterMark:     90,
+						LowWaterMark:      70,
+						EvictionBatchSize: 5,
+					},
+					4,
+					2,
+				)
+			})
+
+			JustBeforeEach(func() {
+				err := volumeCollector.Run(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when the worker's disk usage crosses the high water mark", func() {
+				BeforeEach(func() {
+					fakeWorker.DiskUsageReturns(atc.WorkerDiskUsage{UsedBytes: 95, TotalBytes: 100}, true)
+				})
+
+				It("puts the worker under disk pressure", func() {
+					Expect(fakeWorker.SetDiskPressureCallCount()).To(Equal(1))
+					Expect(fakeWorker.SetDiskPressureArgsForCall(0)).To(BeTrue())
+				})
+
+				It("evicts a batch of its least recently used resource caches", func() {
+					Expect(fakeVolumeRepository.GetEvictableResourceCacheVolumesCallCount()).To(Equal(1))
+					workerName, limit := fakeVolumeRepository.GetEvictableResourceCacheVolumesArgsForCall(0)
+					Expect(workerName).To(Equal("some-worker"))
+					Expect(limit).To(Equal(5))
+
+					Expect(fakeVolume.DestroyingCallCount()).To(Equal(1))
+				})
+
+				Context("when the worker is running", func() {
+					var fakeDestroyingVolume *dbfakes.FakeDestroyingVolume
+
+					BeforeEach(func() {
+						fakeWorker.StateReturns(db.WorkerStateRunning)
+
+						fakeDestroyingVolume = new(dbfakes.FakeDestroyingVolume)
+						fakeDestroyingVolume.HandleReturns("some-cache-volume")
+						fakeDestroyingVolume.WorkerNameReturns("some-worker")
+						fakeVolume.DestroyingReturns(fakeDestroyingVolume, nil)
+					})
+
+					It("destroys the evicted caches on the worker and removes them", func() {
+						Expect(fakeWorkerDestroyer.DestroyVolumeCallCount()).To(Equal(1))
+						_, destroyedOn, handle := fakeWorkerDestroyer.DestroyVolumeArgsForCall(0)
+						Expect(destroyedOn).To(Equal(fakeWorker))
+						Expect(handle).To(Equal("some-cache-volume"))
+
+						Expect(fakeDestroyingVolume.DestroyCallCount()).To(Equal(1))
+					})
+				})
+			})
+
+			Context("when the worker is under disk pressure", func() {
+				BeforeEach(func() {
+					fakeWorker.DiskPressureReturns(true)
+				})
+
+				Context("and its disk usage is above the low water mark", func() {
+					BeforeEach(func() {
+						fakeWorker.DiskUsageReturns(atc.WorkerDiskUsage{UsedBytes: 80, TotalBytes: 100}, true)
+					})
+
+					It("keeps evicting resource caches", func() {
+						Expect(fakeWorker.SetDiskPressureCallCount()).To(BeZero())
+						Expect(fakeVolume.DestroyingCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("and its disk usage drops to the low water mark", func() {
+					BeforeEach(func() {
+						fakeWorker.DiskUsageReturns(atc.WorkerDiskUsage{UsedBytes: 70, TotalBytes: 100}, true)
+					})
+
+					It("lifts the disk pressure and stops evicting", func() {
+						Expect(fakeWorker.SetDiskPressureCallCount()).To(Equal(1))
+						Expect(fakeWorker.SetDiskPressureArgsForCall(0)).To(BeFalse())
+						Expect(fakeVolume.DestroyingCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the worker's disk usage is between the water marks", func() {
+				BeforeEach(func() {
+					fakeWorker.DiskUsageReturns(atc.WorkerDiskUsage{UsedBytes: 80, TotalBytes: 100}, true)
+				})
+
+				It("does not evict anything", func() {
+					Expect(fakeWorker.SetDiskPressureCallCount()).To(BeZero())
+					Expect(fakeVolumeRepository.GetEvictableResourceCacheVolumesCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the worker has not reported its disk usage", func() {
+				It("does not evict anything", func() {
+					Expect(fakeVolumeRepository.GetEvictableResourceCacheVolumesCallCount()).To(BeZero())
+				})
 			})
 		})
 	})
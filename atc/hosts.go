@@ -0,0 +1,77 @@
+package atc
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// HostsConfig maps hostnames to the IP addresses they resolve to in a step's
+// container, as entries added to its /etc/hosts.
+type HostsConfig map[string]string
+
+var hostnameRegexp = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+
+// Validate returns an error for every entry which doesn't map a valid
+// hostname to a valid IP address. Hostnames and addresses with ((vars)) are
+// only validated once their vars are resolved.
+func (hosts HostsConfig) Validate() []string {
+	var errors []string
+	for _, hostname := range hosts.hostnames() {
+		if !strings.Contains(hostname, "((") && (len(hostname) > 253 || !hostnameRegexp.MatchString(hostname)) {
+			errors = append(errors, fmt.Sprintf("invalid hostname '%s'", hostname))
+		}
+
+		if !strings.Contains(hosts[hostname], "((") && net.ParseIP(hosts[hostname]) == nil {
+			errors = append(errors, fmt.Sprintf("invalid IP address '%s' for hostname '%s'", hosts[hostname], hostname))
+		}
+	}
+
+	return errors
+}
+
+// Merge returns the entries of both, with the entries of other taking
+// precedence.
+func (hosts HostsConfig) Merge(other HostsConfig) HostsConfig {
+	if len(hosts) == 0 {
+		return other
+	}
+
+	if len(other) == 0 {
+		return hosts
+	}
+
+	merged := HostsConfig{}
+	for hostname, ip := range hosts {
+		merged[hostname] = ip
+	}
+
+	for hostname, ip := range other {
+		merged[hostname] = ip
+	}
+
+	return merged
+}
+
+// Entries returns the /etc/hosts lines of the entries, ordered by hostname.
+func (hosts HostsConfig) Entries() []string {
+	var entries []string
+	for _, hostname := range hosts.hostnames() {
+		entries = append(entries, hosts[hostname]+" "+hostname)
+	}
+
+	return entries
+}
+
+func (hosts HostsConfig) hostnames() []string {
+	hostnames := make([]string, 0, len(hosts))
+	for hostname := range hosts {
+		hostnames = append(hostnames, hostname)
+	}
+
+	sort.Strings(hostnames)
+
+	return hostnames
+}
@@ -0,0 +1,54 @@
+package idtoken
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"gopkg.in/square/go-jose.v2"
+)
+
+const (
+	DiscoveryPath = "/.well-known/openid-configuration"
+	KeySetPath    = "/.well-known/jwks.json"
+)
+
+type discovery struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	ResponseTypesSupported           []string `json:"response_types_supported"`
+	SubjectTypesSupported            []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+	ClaimsSupported                  []string `json:"claims_supported"`
+}
+
+// Handler serves the OIDC discovery document and key set cloud providers
+// fetch to verify the identity tokens, relative to the issuer URL.
+func (issuer *Issuer) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(DiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, discovery{
+			Issuer:                           issuer.url,
+			JWKSURI:                          issuer.url + KeySetPath,
+			ResponseTypesSupported:           []string{"id_token"},
+			SubjectTypesSupported:            []string{"public"},
+			IDTokenSigningAlgValuesSupported: []string{string(jose.RS256)},
+			ClaimsSupported: []string{
+				"iss", "sub", "aud", "exp", "iat", "nbf",
+				"team", "pipeline", "pipeline_instance_vars", "job", "build_id", "build_name",
+			},
+		})
+	})
+
+	mux.HandleFunc(KeySetPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, issuer.KeySet())
+	})
+
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(v)
+}
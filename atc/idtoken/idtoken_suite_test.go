@@ -0,0 +1,13 @@
+package idtoken_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestIDToken(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ID Token Suite")
+}
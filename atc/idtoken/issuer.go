@@ -0,0 +1,163 @@
+package idtoken
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// Config configures the identity tokens issued to builds.
+type Config struct {
+	Enable   bool          `long:"enable" description:"Issue builds short-lived OIDC identity tokens through the 'idtoken' var source, e.g. ((idtoken:token)). They are signed with the session signing key and their issuer is the external URL, which serves the OIDC discovery document cloud providers verify them with."`
+	Audience []string      `long:"audience" description:"Audience of the identity tokens, e.g. sts.amazonaws.com. Can be specified multiple times. Defaults to the external URL."`
+	TTL      time.Duration `long:"ttl" default:"15m" description:"How long identity tokens are valid for."`
+}
+
+// Claims are the claims of the identity token issued to a build. The subject
+// names what the build belongs to, with each name preceded by its kind:
+//
+//	team:<team>:pipeline:<pipeline>:job:<job>
+//	team:<team>:pipeline:<pipeline>:check
+//	team:<team>:one-off
+//
+// so that a cloud provider can be configured to trust the builds of a given
+// job, or of every job of a pipeline, without one subject being mistaken for
+// another.
+type Claims struct {
+	jwt.Claims
+
+	Team         string           `json:"team"`
+	Pipeline     string           `json:"pipeline,omitempty"`
+	InstanceVars atc.InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	Job          string           `json:"job,omitempty"`
+	BuildID      int              `json:"build_id"`
+	BuildName    string           `json:"build_name"`
+}
+
+// An Issuer mints identity tokens for builds.
+type Issuer struct {
+	url      string
+	audience []string
+	ttl      time.Duration
+	clock    clock.Clock
+
+	key    jose.JSONWebKey
+	signer jose.Signer
+}
+
+func NewIssuer(url string, key *rsa.PrivateKey, config Config, clock clock.Clock) (*Issuer, error) {
+	publicKey := jose.JSONWebKey{
+		Key:       &key.PublicKey,
+		Algorithm: string(jose.RS256),
+		Use:       "sig",
+	}
+
+	thumbprint, err := publicKey.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("compute key id: %w", err)
+	}
+
+	publicKey.KeyID = base64.RawURLEncoding.EncodeToString(thumbprint)
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", publicKey.KeyID),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create signer: %w", err)
+	}
+
+	audience := config.Audience
+	if len(audience) == 0 {
+		audience = []string{url}
+	}
+
+	return &Issuer{
+		url:      url,
+		audience: audience,
+		ttl:      config.TTL,
+		clock:    clock,
+
+		key:    publicKey,
+		signer: signer,
+	}, nil
+}
+
+// subjectSeparator separates the kinds and names in the subject of a token.
+const subjectSeparator = ":"
+
+// Issue returns a signed identity token for the build. It refuses to when the
+// build's team, pipeline or job name contains the subject separator, as the
+// subject would then be ambiguous.
+func (issuer *Issuer) Issue(build db.Build) (string, error) {
+	subject, err := buildSubject(build)
+	if err != nil {
+		return "", err
+	}
+
+	now := issuer.clock.Now()
+
+	claims := Claims{
+		Claims: jwt.Claims{
+			Issuer:    issuer.url,
+			Subject:   subject,
+			Audience:  issuer.audience,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Expiry:    jwt.NewNumericDate(now.Add(issuer.ttl)),
+		},
+
+		Team:         build.TeamName(),
+		Pipeline:     build.PipelineName(),
+		InstanceVars: build.PipelineInstanceVars(),
+		Job:          build.JobName(),
+		BuildID:      build.ID(),
+		BuildName:    build.Name(),
+	}
+
+	return jwt.Signed(issuer.signer).Claims(claims).CompactSerialize()
+}
+
+// buildSubject returns the subject of the identity tokens issued to the build.
+func buildSubject(build db.Build) (string, error) {
+	names := []struct {
+		kind string
+		name string
+	}{
+		{"team", build.TeamName()},
+		{"pipeline", build.PipelineName()},
+		{"job", build.JobName()},
+	}
+
+	for _, n := range names {
+		if strings.Contains(n.name, subjectSeparator) {
+			return "", fmt.Errorf("cannot issue identity token: %s name '%s' contains '%s'", n.kind, n.name, subjectSeparator)
+		}
+	}
+
+	subject := []string{"team", build.TeamName()}
+	switch {
+	case build.JobName() != "":
+		subject = append(subject, "pipeline", build.PipelineName(), "job", build.JobName())
+	case build.PipelineName() != "":
+		subject = append(subject, "pipeline", build.PipelineName(), "check")
+	default:
+		subject = append(subject, "one-off")
+	}
+
+	return strings.Join(subject, subjectSeparator), nil
+}
+
+// KeySet returns the public key the tokens can be verified with.
+func (issuer *Issuer) KeySet() jose.JSONWebKeySet {
+	return jose.JSONWebKeySet{Keys: []jose.JSONWebKey{issuer.key}}
+}
@@ -0,0 +1,277 @@
+package idtoken_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/idtoken"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+var _ = Describe("Issuer", func() {
+	var (
+		key       *rsa.PrivateKey
+		fakeClock *fakeclock.FakeClock
+		config    idtoken.Config
+		fakeBuild *dbfakes.FakeBuild
+
+		issuer *idtoken.Issuer
+	)
+
+	BeforeEach(func() {
+		var err error
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ToNot(HaveOccurred())
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(1600000000, 0))
+
+		config = idtoken.Config{TTL: 15 * time.Minute}
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.NameReturns("7")
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.PipelineInstanceVarsReturns(atc.InstanceVars{"branch": "main"})
+		fakeBuild.JobNameReturns("some-job")
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		issuer, err = idtoken.NewIssuer("https://concourse.example.com", key, config, fakeClock)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	parse := func(token string) idtoken.Claims {
+		parsed, err := jwt.ParseSigned(token)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(parsed.Headers).To(HaveLen(1))
+		Expect(parsed.Headers[0].KeyID).To(Equal(issuer.KeySet().Keys[0].KeyID))
+
+		var claims idtoken.Claims
+		Expect(parsed.Claims(&key.PublicKey, &claims)).To(Succeed())
+
+		return claims
+	}
+
+	Describe("Issue", func() {
+		It("signs the build's claims", func() {
+			token, err := issuer.Issue(fakeBuild)
+			Expect(err).ToNot(HaveOccurred())
+
+			claims := parse(token)
+			Expect(claims.Issuer).To(Equal("https://concourse.example.com"))
+			Expect(claims.Subject).To(Equal("team:some-team:pipeline:some-pipeline:job:some-job"))
+			Expect(claims.Audience).To(Equal(jwt.Audience{"https://concourse.example.com"}))
+			Expect(claims.IssuedAt.Time()).To(Equal(fakeClock.Now()))
+			Expect(claims.NotBefore.Time()).To(Equal(fakeClock.Now()))
+			Expect(claims.Expiry.Time()).To(Equal(fakeClock.Now().Add(15 * time.Minute)))
+
+			Expect(claims.Team).To(Equal("some-team"))
+			Expect(claims.Pipeline).To(Equal("some-pipeline"))
+			Expect(claims.InstanceVars).To(Equal(atc.InstanceVars{"branch": "main"}))
+			Expect(claims.Job).To(Equal("some-job"))
+			Expect(claims.BuildID).To(Equal(42))
+			Expect(claims.BuildName).To(Equal("7"))
+		})
+
+		It("can be validated against the expected claims", func() {
+			token, err := issuer.Issue(fakeBuild)
+			Expect(err).ToNot(HaveOccurred())
+
+			claims := parse(token)
+
+			Expect(claims.Validate(jwt.Expected{
+				Issuer:   "https://concourse.example.com",
+				Subject:  "team:some-team:pipeline:some-pipeline:job:some-job",
+				Audience: jwt.Audience{"https://concourse.example.com"},
+				Time:     fakeClock.Now().Add(time.Minute),
+			})).To(Succeed())
+
+			Expect(claims.Validate(jwt.Expected{
+				Time: fakeClock.Now().Add(time.Hour),
+			})).To(Equal(jwt.ErrExpired))
+		})
+
+		Context("with configured audiences", func() {
+			BeforeEach(func() {
+				config.Audience = []string{"sts.amazonaws.com", "other"}
+			})
+
+			It("issues the tokens to them", func() {
+				token, err := issuer.Issue(fakeBuild)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(parse(token).Audience).To(Equal(jwt.Audience{"sts.amazonaws.com", "other"}))
+			})
+		})
+
+		Context("for a one-off build", func() {
+			BeforeEach(func() {
+				fakeBuild.PipelineNameReturns("")
+				fakeBuild.PipelineInstanceVarsReturns(nil)
+				fakeBuild.JobNameReturns("")
+			})
+
+			It("marks the subject as one-off", func() {
+				token, err := issuer.Issue(fakeBuild)
+				Expect(err).ToNot(HaveOccurred())
+
+				claims := parse(token)
+				Expect(claims.Subject).To(Equal("team:some-team:one-off"))
+				Expect(claims.Pipeline).To(BeEmpty())
+				Expect(claims.Job).To(BeEmpty())
+			})
+		})
+
+		Context("for a check build", func() {
+			BeforeEach(func() {
+				fakeBuild.JobNameReturns("")
+			})
+
+			It("marks the subject as a check", func() {
+				token, err := issuer.Issue(fakeBuild)
+				Expect(err).ToNot(HaveOccurred())
+
+				claims := parse(token)
+				Expect(claims.Subject).To(Equal("team:some-team:pipeline:some-pipeline:check"))
+				Expect(claims.Job).To(BeEmpty())
+			})
+		})
+
+		Context("when the names contain slashes", func() {
+			BeforeEach(func() {
+				fakeBuild.PipelineNameReturns("prod/deploy")
+				fakeBuild.JobNameReturns("")
+			})
+
+			It("does not share a subject with a job of another pipeline", func() {
+				token, err := issuer.Issue(fakeBuild)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(parse(token).Subject).To(Equal("team:some-team:pipeline:prod/deploy:check"))
+
+				fakeBuild.PipelineNameReturns("prod")
+				fakeBuild.JobNameReturns("deploy")
+
+				token, err = issuer.Issue(fakeBuild)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(parse(token).Subject).To(Equal("team:some-team:pipeline:prod:job:deploy"))
+			})
+		})
+
+		for _, kind := range []string{"team", "pipeline", "job"} {
+			kind := kind
+
+			Context("when the "+kind+" name contains the separator", func() {
+				BeforeEach(func() {
+					switch kind {
+					case "team":
+						fakeBuild.TeamNameReturns("some:team")
+					case "pipeline":
+						fakeBuild.PipelineNameReturns("some:team")
+					case "job":
+						fakeBuild.JobNameReturns("some:team")
+					}
+				})
+
+				It("refuses to issue a token", func() {
+					_, err := issuer.Issue(fakeBuild)
+					Expect(err).To(MatchError("cannot issue identity token: " + kind + " name 'some:team' contains ':'"))
+				})
+			})
+		}
+	})
+
+	Describe("Variables", func() {
+		var buildVars vars.Variables
+
+		JustBeforeEach(func() {
+			buildVars = issuer.Variables(fakeBuild)
+		})
+
+		It("issues a token as ((idtoken:token))", func() {
+			token, found, err := buildVars.Get(vars.Reference{Source: "idtoken", Path: "token"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(parse(token.(string)).Subject).To(Equal("team:some-team:pipeline:some-pipeline:job:some-job"))
+		})
+
+		It("has no other var", func() {
+			_, found, err := buildVars.Get(vars.Reference{Path: "token"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			_, found, err = buildVars.Get(vars.Reference{Source: "idtoken", Path: "other"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			_, found, err = buildVars.Get(vars.Reference{Source: "idtoken", Path: "token", Fields: []string{"field"}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("Handler", func() {
+		var server *httptest.Server
+
+		JustBeforeEach(func() {
+			server = httptest.NewServer(issuer.Handler())
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		get := func(path string, v interface{}) {
+			response, err := http.Get(server.URL + path)
+			Expect(err).ToNot(HaveOccurred())
+			defer response.Body.Close()
+
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			Expect(json.NewDecoder(response.Body).Decode(v)).To(Succeed())
+		}
+
+		It("serves the discovery document", func() {
+			var discovery map[string]interface{}
+			get("/.well-known/openid-configuration", &discovery)
+
+			Expect(discovery["issuer"]).To(Equal("https://concourse.example.com"))
+			Expect(discovery["jwks_uri"]).To(Equal("https://concourse.example.com/.well-known/jwks.json"))
+			Expect(discovery["id_token_signing_alg_values_supported"]).To(ConsistOf("RS256"))
+		})
+
+		It("serves the key set the tokens are verified with", func() {
+			var keySet jose.JSONWebKeySet
+			get("/.well-known/jwks.json", &keySet)
+
+			token, err := issuer.Issue(fakeBuild)
+			Expect(err).ToNot(HaveOccurred())
+
+			parsed, err := jwt.ParseSigned(token)
+			Expect(err).ToNot(HaveOccurred())
+
+			keys := keySet.Key(parsed.Headers[0].KeyID)
+			Expect(keys).To(HaveLen(1))
+			Expect(keys[0].IsPublic()).To(BeTrue())
+
+			var claims idtoken.Claims
+			Expect(parsed.Claims(keys[0].Key, &claims)).To(Succeed())
+			Expect(claims.BuildID).To(Equal(42))
+		})
+	})
+})
@@ -0,0 +1,42 @@
+package idtoken
+
+import (
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/vars"
+)
+
+// SourceName is the name of the var source builds get their identity token
+// from. Its token var shadows the one of a pipeline var source of the same
+// name.
+const SourceName = "idtoken"
+
+// Variables returns the vars a build gets its identity token from, as
+// ((idtoken:token)). A new token is issued every time the var is looked up.
+func (issuer *Issuer) Variables(build db.Build) vars.Variables {
+	return buildVariables{
+		issuer: issuer,
+		build:  build,
+	}
+}
+
+type buildVariables struct {
+	issuer *Issuer
+	build  db.Build
+}
+
+func (v buildVariables) Get(ref vars.Reference) (interface{}, bool, error) {
+	if ref.Source != SourceName || ref.Path != "token" || len(ref.Fields) != 0 {
+		return nil, false, nil
+	}
+
+	token, err := v.issuer.Issue(v.build)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return token, true, nil
+}
+
+func (v buildVariables) List() ([]vars.Reference, error) {
+	return nil, nil
+}
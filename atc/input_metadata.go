@@ -0,0 +1,43 @@
+package atc
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/concourse/concourse/vars"
+)
+
+var localVarRegexp = regexp.MustCompile(`\(\(\.:([^()]*)\)\)`)
+
+// InputMetadataVar is a local var in a task step's params which refers to a
+// field of the metadata of the version one of the job's inputs fetched, e.g.
+// ((.:some-repo.commit)). The input's get step binds the field as a local var
+// named after the step, the same way as it binds its metadata_vars.
+type InputMetadataVar struct {
+	Input string
+	Field string
+}
+
+func (v InputMetadataVar) String() string {
+	return fmt.Sprintf("((.:%s.%s))", v.Input, v.Field)
+}
+
+// ParseInputMetadataVars returns the local vars with a field in the value, in
+// the order they appear. Only those named after a get step refer to the
+// metadata of an input; the rest are e.g. set by a load_var.
+func ParseInputMetadataVars(value string) []InputMetadataVar {
+	var metadataVars []InputMetadataVar
+	for _, match := range localVarRegexp.FindAllStringSubmatch(value, -1) {
+		ref, err := vars.ParseReference(match[1])
+		if err != nil || len(ref.Fields) == 0 {
+			continue
+		}
+
+		metadataVars = append(metadataVars, InputMetadataVar{
+			Input: ref.Path,
+			Field: ref.Fields[0],
+		})
+	}
+
+	return metadataVars
+}
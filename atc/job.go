@@ -27,11 +27,12 @@ type Job struct {
 }
 
 type JobInput struct {
-	Name     string         `json:"name"`
-	Resource string         `json:"resource"`
-	Trigger  bool           `json:"trigger"`
-	Passed   []string       `json:"passed,omitempty"`
-	Version  *VersionConfig `json:"version,omitempty"`
+	Name              string         `json:"name"`
+	Resource          string         `json:"resource"`
+	Trigger           bool           `json:"trigger"`
+	Passed            []string       `json:"passed,omitempty"`
+	Version           *VersionConfig `json:"version,omitempty"`
+	VersionConstraint string         `json:"version_constraint,omitempty"`
 }
 
 type JobInputParams struct {
@@ -40,6 +41,13 @@ type JobInputParams struct {
 	Tags   Tags   `json:"tags,omitempty"`
 }
 
+// TriggerJobBuildRequest is the optional body of a request to manually
+// trigger a job build.
+type TriggerJobBuildRequest struct {
+	Vars             map[string]interface{} `json:"vars,omitempty"`
+	AllowVarOverride bool                   `json:"allow_var_override,omitempty"`
+}
+
 type JobOutput struct {
 	Name     string `json:"name"`
 	Resource string `json:"resource"`
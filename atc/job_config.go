@@ -11,6 +11,17 @@ type JobConfig struct {
 	SerialGroups         []string `json:"serial_groups,omitempty"`
 	RawMaxInFlight       int      `json:"max_in_flight,omitempty"`
 	BuildLogsToRetain    int      `json:"build_logs_to_retain,omitempty"`
+	TriggerDebounce      string   `json:"trigger_debounce,omitempty"`
+
+	// SerialGroupsMaxInFlight opts a job in serial groups into running more
+	// than one build at once. The builds of all the jobs sharing a serial
+	// group with the job count towards it, and the strictest limit of those
+	// jobs wins.
+	SerialGroupsMaxInFlight int `json:"serial_groups_max_in_flight,omitempty"`
+
+	// SupersededBy names an input resource of the job. Running builds are
+	// aborted as soon as a newer version of it is found.
+	SupersededBy string `json:"superseded_by,omitempty"`
 
 	BuildLogRetention *BuildLogRetention `json:"build_log_retention,omitempty"`
 
@@ -76,8 +87,19 @@ func (config JobConfig) StepConfig() StepConfig {
 	return step
 }
 
+// MaxInFlight returns the maximum number of builds of the job which may run
+// at once. Serial jobs are limited to one build, as are jobs in serial groups
+// unless they set serial_groups_max_in_flight.
 func (config JobConfig) MaxInFlight() int {
-	if config.Serial || len(config.SerialGroups) > 0 {
+	if config.Serial {
+		return 1
+	}
+
+	if len(config.SerialGroups) > 0 {
+		if config.SerialGroupsMaxInFlight > 0 {
+			return config.SerialGroupsMaxInFlight
+		}
+
 		return 1
 	}
 
@@ -95,14 +117,15 @@ func (config JobConfig) Inputs() []JobInputParams {
 		OnGet: func(step *GetStep) error {
 			inputs = append(inputs, JobInputParams{
 				JobInput: JobInput{
-					Name:     step.Name,
-					Resource: step.ResourceName(),
-					Passed:   step.Passed,
-					Version:  step.Version,
-					Trigger:  step.Trigger,
+					Name:              step.Name,
+					Resource:          step.ResourceName(),
+					Passed:            step.Passed,
+					Version:           step.Version,
+					VersionConstraint: step.VersionConstraint,
+					Trigger:           step.Trigger,
 				},
 				Params: step.Params,
-				Tags:   step.Tags,
+				Tags:   step.Tags.AllTags(),
 			})
 
 			return nil
@@ -52,6 +52,19 @@ var _ = Describe("JobConfig", func() {
 			Expect(jobConfig.MaxInFlight()).To(Equal(1))
 		})
 
+		It("returns SerialGroupsMaxInFlight if SerialGroups has items in it and it is set", func() {
+			jobConfig := atc.JobConfig{
+				SerialGroups:            []string{"one"},
+				RawMaxInFlight:          2,
+				SerialGroupsMaxInFlight: 3,
+			}
+
+			Expect(jobConfig.MaxInFlight()).To(Equal(3))
+
+			jobConfig.Serial = true
+			Expect(jobConfig.MaxInFlight()).To(Equal(1))
+		})
+
 		It("returns 0 if MaxInFlight is not set, Serial is false, and SerialGroups is empty", func() {
 			jobConfig := atc.JobConfig{
 				Serial:       false,
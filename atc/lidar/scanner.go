@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"sync"
 
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/metric"
@@ -64,7 +65,33 @@ func (s *scanner) scanResources(ctx context.Context, resources []db.Resource, re
 			}()
 			defer waitGroup.Done()
 
-			s.check(ctx, resource, resourceTypes)
+			// checking can be paused without pausing the resource, which
+			// keeps using the versions found so far
+			if resource.CheckPaused() {
+				logger.Debug("check-paused", lager.Data{"resource": resource.Name()})
+				return
+			}
+
+			// a resource whose check TTL has elapsed is due a scheduled
+			// check however it's otherwise checked
+			if resource.CheckOnDemand() && !db.CheckTTLExpired(resource) {
+				needed, err := resource.OnDemandCheckNeeded()
+				if err != nil {
+					logger.Error("failed-to-determine-if-on-demand-check-needed", err)
+					return
+				}
+
+				if !needed {
+					return
+				}
+
+				// the interval does not apply to on-demand checks, which are
+				// only needed when a pending build is waiting on them
+				s.check(ctx, resource, resourceTypes, true)
+				return
+			}
+
+			s.check(ctx, resource, resourceTypes, false)
 		}(resource, resourceTypes)
 	}
 	waitGroup.Wait()
@@ -83,13 +110,13 @@ func (s *scanner) scanResourceTypes(ctx context.Context, resourceTypes db.Resour
 				}
 			}()
 			defer waitGroup.Done()
-			s.check(ctx, resourceType, resourceTypes)
+			s.check(ctx, resourceType, resourceTypes, false)
 		}(resourceType, resourceTypes)
 	}
 	waitGroup.Wait()
 }
 
-func (s *scanner) check(ctx context.Context, checkable db.Checkable, resourceTypes db.ResourceTypes) {
+func (s *scanner) check(ctx context.Context, checkable db.Checkable, resourceTypes db.ResourceTypes, skipInterval bool) {
 	logger := lagerctx.FromContext(ctx)
 
 	spanCtx, span := tracing.StartSpan(ctx, "scanner.check", tracing.Attrs{
@@ -103,11 +130,11 @@ func (s *scanner) check(ctx context.Context, checkable db.Checkable, resourceTyp
 
 	version := checkable.CurrentPinnedVersion()
 
-	if checkable.CheckEvery() != nil && checkable.CheckEvery().Never {
+	if checkable.CheckEvery() != nil && checkable.CheckEvery().Never && !db.CheckTTLExpired(checkable) {
 		return
 	}
 
-	_, created, err := s.checkFactory.TryCreateCheck(lagerctx.NewContext(spanCtx, logger), checkable, resourceTypes, version, false)
+	_, created, err := s.checkFactory.TryCreateCheck(lagerctx.NewContext(spanCtx, logger), checkable, resourceTypes, version, skipInterval)
 	if err != nil {
 		logger.Error("failed-to-create-check", err)
 		return
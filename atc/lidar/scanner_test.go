@@ -69,6 +69,16 @@ var _ = Describe("Scanner", func() {
 				})
 			})
 
+			Context("when checking of the resource is paused", func() {
+				BeforeEach(func() {
+					fakeResource.CheckPausedReturns(true)
+				})
+
+				It("does not check the resource", func() {
+					Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("when CheckEvery is never", func() {
 				BeforeEach(func() {
 					fakeResource.CheckEveryReturns(&atc.CheckEvery{Never: true})
@@ -84,6 +94,82 @@ var _ = Describe("Scanner", func() {
 				It("does not check the resource but still checks the parent", func() {
 					Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
 				})
+
+				Context("when the check TTL has elapsed since the last scheduled check", func() {
+					BeforeEach(func() {
+						fakeResource.CheckTTLReturns(time.Hour)
+						fakeResource.LastScheduledCheckEndTimeReturns(time.Now().Add(-2 * time.Hour))
+					})
+
+					It("checks the resource as well as the parent", func() {
+						Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(2))
+					})
+				})
+
+				Context("when the check TTL has not elapsed", func() {
+					BeforeEach(func() {
+						fakeResource.CheckTTLReturns(time.Hour)
+						fakeResource.LastScheduledCheckEndTimeReturns(time.Now().Add(-time.Minute))
+					})
+
+					It("only checks the parent", func() {
+						Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+					})
+				})
+			})
+
+			Context("when the resource is checked on demand", func() {
+				BeforeEach(func() {
+					fakeCheckFactory.ResourceTypesReturns([]db.ResourceType{}, nil)
+					fakeResource.CheckOnDemandReturns(true)
+				})
+
+				Context("when no pending build needs a check", func() {
+					BeforeEach(func() {
+						fakeResource.OnDemandCheckNeededReturns(false, nil)
+					})
+
+					It("does not check the resource", func() {
+						Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(0))
+					})
+
+					Context("when the check TTL has elapsed since the last scheduled check", func() {
+						BeforeEach(func() {
+							fakeResource.CheckTTLReturns(time.Hour)
+							fakeResource.LastScheduledCheckEndTimeReturns(time.Now().Add(-2 * time.Hour))
+						})
+
+						It("creates a check without skipping the interval", func() {
+							Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+							_, _, _, _, skipInterval := fakeCheckFactory.TryCreateCheckArgsForCall(0)
+							Expect(skipInterval).To(BeFalse())
+						})
+					})
+				})
+
+				Context("when a pending build needs a check", func() {
+					BeforeEach(func() {
+						fakeResource.OnDemandCheckNeededReturns(true, nil)
+					})
+
+					It("creates a check regardless of the interval", func() {
+						Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+						_, checkable, _, _, skipInterval := fakeCheckFactory.TryCreateCheckArgsForCall(0)
+						Expect(checkable).To(Equal(fakeResource))
+						Expect(skipInterval).To(BeTrue())
+					})
+				})
+
+				Context("when determining whether a check is needed fails", func() {
+					BeforeEach(func() {
+						fakeResource.OnDemandCheckNeededReturns(false, errors.New("nope"))
+					})
+
+					It("does not check the resource", func() {
+						Expect(err).ToNot(HaveOccurred())
+						Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(0))
+					})
+				})
 			})
 
 			Context("when fetching resources types succeeds", func() {
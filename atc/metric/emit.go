@@ -19,6 +19,11 @@ type Event struct {
 	Attributes map[string]string
 	Host       string
 	Time       time.Time
+
+	// TraceID is the ID of the trace the measured operation is part of, if
+	// it's being traced. Emitters which support it link the measurement to the
+	// trace, e.g. through an exemplar.
+	TraceID string
 }
 
 //counterfeiter:generate . Emitter
@@ -78,6 +83,10 @@ type Monitor struct {
 
 	ChecksEnqueued Counter
 
+	// ChecksThrottled is how many checks are waiting for a slot because the
+	// node is already running as many checks as it's allowed to.
+	ChecksThrottled Gauge
+
 	ConcurrentRequests         map[string]*Gauge
 	ConcurrentRequestsLimitHit map[string]*Counter
 
@@ -85,6 +94,11 @@ type Monitor struct {
 
 	GetStepCacheHits       Counter
 	StreamedResourceCaches Counter
+
+	// The pipelines, as TEAM/PIPELINE, whose checks emit how many versions
+	// they discovered. The metric is tagged by resource, so it is opt-in per
+	// pipeline to keep its cardinality down.
+	VersionsDiscoveredPipelines map[string]bool
 }
 
 var Metrics = NewMonitor()
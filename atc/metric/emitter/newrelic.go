@@ -94,6 +94,7 @@ func (emitter *NewRelicEmitter) Emit(logger lager.Logger, event metric.Event) {
 		"checks finished",
 		"checks started",
 		"checks enqueued",
+		"checks throttled",
 		"checks queue size",
 		"worker containers",
 		"worker volumes",
@@ -104,6 +105,11 @@ func (emitter *NewRelicEmitter) Emit(logger lager.Logger, event metric.Event) {
 		"database connections",
 		"worker unknown containers",
 		"worker unknown volumes",
+		"worker containers pending destruction",
+		"worker volumes pending destruction",
+		"worker containers marked for destruction",
+		"worker volumes marked for destruction",
+		"worker volumes evicted under disk pressure",
 		"volumes streamed",
 		"get step cache hits",
 		"streamed resource caches":
@@ -36,6 +36,7 @@ type PrometheusEmitter struct {
 	stepsWaitingDuration *prometheus.HistogramVec
 
 	buildDurationsVec *prometheus.HistogramVec
+	buildStartLatency *prometheus.HistogramVec
 	buildsAborted     prometheus.Counter
 	buildsErrored     prometheus.Counter
 	buildsFailed      prometheus.Counter
@@ -43,6 +44,8 @@ type PrometheusEmitter struct {
 	buildsFinishedVec *prometheus.CounterVec
 	buildsSucceeded   prometheus.Counter
 
+	jobTimeToGreen *prometheus.HistogramVec
+
 	checkBuildsAborted   prometheus.Counter
 	checkBuildsErrored   prometheus.Counter
 	checkBuildsFailed    prometheus.Counter
@@ -58,17 +61,23 @@ type PrometheusEmitter struct {
 
 	locksHeld *prometheus.GaugeVec
 
+	secretFetchDuration *prometheus.HistogramVec
+	secretFetchFailures *prometheus.CounterVec
+
 	// TODO: deprecate
 	checksFinished *prometheus.CounterVec
 	checksStarted  prometheus.Counter
 
-	checksEnqueued prometheus.Counter
+	checksEnqueued  prometheus.Counter
+	checksThrottled prometheus.Gauge
 
 	volumesStreamed prometheus.Counter
 
 	getStepCacheHits       prometheus.Counter
 	streamedResourceCaches prometheus.Counter
 
+	resourceVersionsDiscovered *prometheus.CounterVec
+
 	workerContainers        *prometheus.GaugeVec
 	workerUnknownContainers *prometheus.GaugeVec
 	workerVolumes           *prometheus.GaugeVec
@@ -76,6 +85,14 @@ type PrometheusEmitter struct {
 	workerTasks             *prometheus.GaugeVec
 	workersRegistered       *prometheus.GaugeVec
 
+	workerContainersPendingDestruction *prometheus.GaugeVec
+	workerVolumesPendingDestruction    *prometheus.GaugeVec
+
+	workerContainersMarkedForDestruction *prometheus.CounterVec
+	workerVolumesMarkedForDestruction    *prometheus.CounterVec
+
+	workerVolumesEvictedUnderDiskPressure *prometheus.CounterVec
+
 	workerContainersLabels map[string]map[string]prometheus.Labels
 	workerVolumesLabels    map[string]map[string]prometheus.Labels
 	workerTasksLabels      map[string]map[string]prometheus.Labels
@@ -285,6 +302,30 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(buildDurationsVec)
 
+	buildStartLatency := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "builds",
+			Name:      "start_latency_seconds",
+			Help:      "Time in seconds from a build being created until its first step started",
+			Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600},
+		},
+		[]string{"team", "pipeline", "job"},
+	)
+	prometheus.MustRegister(buildStartLatency)
+
+	jobTimeToGreen := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "jobs",
+			Name:      "time_to_green_seconds",
+			Help:      "Time in seconds from the first build of a job's failing streak finishing until the next succeeded build finished",
+			Buckets:   []float64{60, 300, 900, 1800, 3600, 7200, 14400, 28800, 86400, 259200, 604800},
+		},
+		[]string{"team", "pipeline", "job"},
+	)
+	prometheus.MustRegister(jobTimeToGreen)
+
 	checkBuildsFinished := prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "concourse",
 		Subsystem: "builds",
@@ -370,6 +411,61 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(workerUnknownVolumes)
 
+	workerContainersPendingDestruction := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "gc",
+			Name:      "worker_containers_pending_destruction",
+			Help:      "Number of containers waiting to be destroyed on worker",
+		},
+		[]string{"worker"},
+	)
+	prometheus.MustRegister(workerContainersPendingDestruction)
+
+	workerVolumesPendingDestruction := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "gc",
+			Name:      "worker_volumes_pending_destruction",
+			Help:      "Number of volumes waiting to be destroyed on worker",
+		},
+		[]string{"worker"},
+	)
+	prometheus.MustRegister(workerVolumesPendingDestruction)
+
+	workerContainersMarkedForDestruction := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "gc",
+			Name:      "worker_containers_marked_for_destruction_total",
+			Help:      "Number of containers on worker marked for destruction by the container collector",
+		},
+		[]string{"worker"},
+	)
+	prometheus.MustRegister(workerContainersMarkedForDestruction)
+
+	workerVolumesMarkedForDestruction := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "gc",
+			Name:      "worker_volumes_marked_for_destruction_total",
+			Help:      "Number of volumes on worker marked for destruction by the volume collector",
+		},
+		[]string{"worker"},
+	)
+	prometheus.MustRegister(workerVolumesMarkedForDestruction)
+
+	workerVolumesEvictedUnderDiskPressure := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "gc",
+			Name:      "worker_volumes_evicted_under_disk_pressure_total",
+			Help:      "Number of resource cache volumes on worker marked for destruction to free up its disk",
+		},
+		[]string{"worker"},
+	)
+	prometheus.MustRegister(workerVolumesEvictedUnderDiskPressure)
+
 	workerTasks := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "concourse",
@@ -404,6 +500,29 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(httpRequestsDuration)
 
+	secretFetchDuration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "credential_manager",
+			Name:      "secret_fetch_duration_seconds",
+			Help:      "Time in seconds taken to fetch a secret from the credential manager",
+			Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+		},
+		[]string{"manager"},
+	)
+	prometheus.MustRegister(secretFetchDuration)
+
+	secretFetchFailures := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "credential_manager",
+			Name:      "secret_fetch_failures_total",
+			Help:      "Total number of failed secret fetches from the credential manager",
+		},
+		[]string{"manager"},
+	)
+	prometheus.MustRegister(secretFetchFailures)
+
 	dbQueriesTotal := prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "concourse",
 		Subsystem: "db",
@@ -454,6 +573,16 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(checksEnqueued)
 
+	checksThrottled := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "lidar",
+			Name:      "checks_throttled",
+			Help:      "Number of checks waiting to run because the ATC is running its maximum number of concurrent checks",
+		},
+	)
+	prometheus.MustRegister(checksThrottled)
+
 	volumesStreamed := prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace: "concourse",
@@ -484,12 +613,32 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(streamedResourceCaches)
 
+	resourceVersionsDiscovered := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "resources",
+			Name:      "versions_discovered_total",
+			Help:      "Total number of new versions saved by checks of a resource",
+		},
+		[]string{"team", "pipeline", "resource"},
+	)
+	prometheus.MustRegister(resourceVersionsDiscovered)
+
 	listener, err := net.Listen("tcp", config.bind())
 	if err != nil {
 		return nil, err
 	}
 
-	go http.Serve(listener, promhttp.Handler())
+	handler := promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
+			// exemplars linking measurements to traces are only exposed in
+			// the OpenMetrics format
+			EnableOpenMetrics: true,
+		}),
+	)
+
+	go http.Serve(listener, handler)
 
 	emitter := &PrometheusEmitter{
 		jobsScheduled:  jobsScheduled,
@@ -508,6 +657,7 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		stepsWaitingDuration: stepsWaitingDuration,
 
 		buildDurationsVec: buildDurationsVec,
+		buildStartLatency: buildStartLatency,
 		buildsAborted:     buildsAborted,
 		buildsErrored:     buildsErrored,
 		buildsFailed:      buildsFailed,
@@ -515,6 +665,8 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		buildsFinishedVec: buildsFinishedVec,
 		buildsSucceeded:   buildsSucceeded,
 
+		jobTimeToGreen: jobTimeToGreen,
+
 		checkBuildsAborted:   checkBuildsAborted,
 		checkBuildsErrored:   checkBuildsErrored,
 		checkBuildsFailed:    checkBuildsFailed,
@@ -530,11 +682,15 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 
 		locksHeld: locksHeld,
 
+		secretFetchDuration: secretFetchDuration,
+		secretFetchFailures: secretFetchFailures,
+
 		// TODO: deprecate
 		checksFinished: checksFinished,
 		checksStarted:  checksStarted,
 
-		checksEnqueued: checksEnqueued,
+		checksEnqueued:  checksEnqueued,
+		checksThrottled: checksThrottled,
 
 		workerContainers:        workerContainers,
 		workersRegistered:       workersRegistered,
@@ -547,10 +703,20 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		workerUnknownContainers: workerUnknownContainers,
 		workerUnknownVolumes:    workerUnknownVolumes,
 
+		workerContainersPendingDestruction: workerContainersPendingDestruction,
+		workerVolumesPendingDestruction:    workerVolumesPendingDestruction,
+
+		workerContainersMarkedForDestruction: workerContainersMarkedForDestruction,
+		workerVolumesMarkedForDestruction:    workerVolumesMarkedForDestruction,
+
+		workerVolumesEvictedUnderDiskPressure: workerVolumesEvictedUnderDiskPressure,
+
 		volumesStreamed: volumesStreamed,
 
 		getStepCacheHits:       getStepCacheHits,
 		streamedResourceCaches: streamedResourceCaches,
+
+		resourceVersionsDiscovered: resourceVersionsDiscovered,
 	}
 	go emitter.periodicMetricGC()
 
@@ -597,17 +763,31 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 				event.Attributes["workerTags"],
 			).Set(event.Value)
 	case "steps waiting duration":
-		emitter.stepsWaitingDuration.
+		observe(emitter.stepsWaitingDuration.
 			WithLabelValues(
 				event.Attributes["platform"],
 				event.Attributes["teamId"],
 				event.Attributes["type"],
 				event.Attributes["workerTags"],
-			).Observe(event.Value)
+			), event.Value, event)
 	case "build finished":
 		emitter.buildFinishedMetrics(logger, event)
 	case "check build finished":
 		emitter.checkBuildFinishedMetrics(logger, event)
+	case "build start latency":
+		observe(emitter.buildStartLatency.
+			WithLabelValues(
+				event.Attributes["team_name"],
+				event.Attributes["pipeline"],
+				event.Attributes["job"],
+			), event.Value/1000, event)
+	case "job time to green":
+		emitter.jobTimeToGreen.
+			WithLabelValues(
+				event.Attributes["team_name"],
+				event.Attributes["pipeline"],
+				event.Attributes["job"],
+			).Observe(event.Value / 1000)
 	case "worker containers":
 		emitter.workerContainersMetric(logger, event)
 	case "worker volumes":
@@ -618,6 +798,21 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.workerUnknownVolumesMetric(logger, event)
 	case "worker tasks":
 		emitter.workerTasksMetric(logger, event)
+	case "worker containers pending destruction":
+		emitter.workerContainersPendingDestruction.
+			WithLabelValues(event.Attributes["worker"]).Set(event.Value)
+	case "worker volumes pending destruction":
+		emitter.workerVolumesPendingDestruction.
+			WithLabelValues(event.Attributes["worker"]).Set(event.Value)
+	case "worker containers marked for destruction":
+		emitter.workerContainersMarkedForDestruction.
+			WithLabelValues(event.Attributes["worker"]).Add(event.Value)
+	case "worker volumes marked for destruction":
+		emitter.workerVolumesMarkedForDestruction.
+			WithLabelValues(event.Attributes["worker"]).Add(event.Value)
+	case "worker volumes evicted under disk pressure":
+		emitter.workerVolumesEvictedUnderDiskPressure.
+			WithLabelValues(event.Attributes["worker"]).Add(event.Value)
 	case "worker state":
 		emitter.workersRegisteredMetric(logger, event)
 	case "http response time":
@@ -626,6 +821,12 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.databaseMetrics(logger, event)
 	case "database connections":
 		emitter.databaseMetrics(logger, event)
+	case "secret fetch duration":
+		emitter.secretFetchDuration.
+			WithLabelValues(event.Attributes["manager"]).Observe(event.Value / 1000)
+	case "secret fetch failed":
+		emitter.secretFetchFailures.
+			WithLabelValues(event.Attributes["manager"]).Add(event.Value)
 	// TODO: deprecate
 	case "checks finished":
 		emitter.checksFinished.WithLabelValues(event.Attributes["status"]).Add(event.Value)
@@ -634,12 +835,21 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.checksStarted.Add(event.Value)
 	case "checks enqueued":
 		emitter.checksEnqueued.Add(event.Value)
+	case "checks throttled":
+		emitter.checksThrottled.Set(event.Value)
 	case "volumes streamed":
 		emitter.volumesStreamed.Add(event.Value)
 	case "get step cache hits":
 		emitter.getStepCacheHits.Add(event.Value)
 	case "streamed resource caches":
 		emitter.streamedResourceCaches.Add(event.Value)
+	case "resource versions discovered":
+		emitter.resourceVersionsDiscovered.
+			WithLabelValues(
+				event.Attributes["team_name"],
+				event.Attributes["pipeline"],
+				event.Attributes["resource"],
+			).Add(event.Value)
 	default:
 		// unless we have a specific metric, we do nothing
 	}
@@ -718,7 +928,21 @@ func (emitter *PrometheusEmitter) buildFinishedMetrics(logger lager.Logger, even
 
 	// seconds are the standard prometheus base unit for time
 	duration := event.Value / 1000
-	emitter.buildDurationsVec.WithLabelValues(team, pipeline, job).Observe(duration)
+	observe(emitter.buildDurationsVec.WithLabelValues(team, pipeline, job), duration, event)
+}
+
+// observe records the value, with the ID of the trace of the event as an
+// exemplar if it has one. Exemplars are only exposed to scrapers which
+// negotiate the OpenMetrics format.
+func observe(observer prometheus.Observer, value float64, event metric.Event) {
+	if event.TraceID != "" {
+		if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok {
+			exemplarObserver.ObserveWithExemplar(value, prometheus.Labels{"trace_id": event.TraceID})
+			return
+		}
+	}
+
+	observer.Observe(value)
 }
 
 func (emitter *PrometheusEmitter) checkBuildFinishedMetrics(logger lager.Logger, event metric.Event) {
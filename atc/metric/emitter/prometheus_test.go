@@ -188,7 +188,11 @@ var _ = Describe("PrometheusEmitter", func() {
 	})
 
 	JustBeforeEach(func() {
-		prometheusEmitter, err = prometheusConfig.NewEmitter()
+		// the emitter registers its metrics globally, so it can only be
+		// constructed once
+		if prometheusEmitter == nil {
+			prometheusEmitter, err = prometheusConfig.NewEmitter()
+		}
 	})
 
 	It("emits step waiting metric", func() {
@@ -211,4 +215,61 @@ var _ = Describe("PrometheusEmitter", func() {
 		Expect(string(body)).To(ContainSubstring("concourse_steps_waiting{platform=\"darwin\",teamId=\"42\",type=\"get\",workerTags=\"tester\"} 4"))
 		Expect(err).To(BeNil())
 	})
+
+	Describe("exemplars", func() {
+		scrape := func(accept string) string {
+			req, err := http.NewRequest("GET", fmt.Sprintf("http://%s:%s/metrics", prometheusConfig.BindIP, prometheusConfig.BindPort), nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			req.Header.Set("Accept", accept)
+
+			res, err := http.DefaultClient.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			defer res.Body.Close()
+
+			body, err := ioutil.ReadAll(res.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+			return string(body)
+		}
+
+		emitStepsWaitingDuration := func(teamID string, traceID string) {
+			prometheusEmitter.Emit(logger, metric.Event{
+				Name:  "steps waiting duration",
+				Value: 2,
+				Attributes: map[string]string{
+					"platform":   "linux",
+					"teamId":     teamID,
+					"type":       "task",
+					"workerTags": "",
+				},
+				TraceID: traceID,
+			})
+		}
+
+		It("links durations to the trace they were measured in", func() {
+			emitStepsWaitingDuration("1", "01020300000000000000000000000000")
+
+			Expect(scrape("application/openmetrics-text; version=0.0.1")).To(MatchRegexp(
+				`concourse_steps_wait_duration_bucket\{platform="linux",teamId="1",type="task",workerTags="",le="[^"]+"\} 1 # \{trace_id="01020300000000000000000000000000"\} 2`,
+			))
+		})
+
+		It("does not expose exemplars in the text format", func() {
+			emitStepsWaitingDuration("2", "01020300000000000000000000000000")
+
+			body := scrape("text/plain")
+			Expect(body).To(ContainSubstring(`concourse_steps_wait_duration_count{platform="linux",teamId="2",type="task",workerTags=""} 1`))
+			Expect(body).ToNot(ContainSubstring("trace_id"))
+		})
+
+		It("does not link durations measured without tracing", func() {
+			emitStepsWaitingDuration("3", "")
+
+			Expect(scrape("application/openmetrics-text; version=0.0.1")).To(MatchRegexp(
+				`concourse_steps_wait_duration_bucket\{platform="linux",teamId="3",type="task",workerTags="",le="[^"]+"\} 1\n`,
+			))
+		})
+	})
 })
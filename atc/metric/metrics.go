@@ -9,6 +9,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/tracing"
 )
 
 type StepsWaitingLabels struct {
@@ -21,6 +22,9 @@ type StepsWaitingLabels struct {
 type StepsWaitingDuration struct {
 	Labels   StepsWaitingLabels
 	Duration time.Duration
+
+	// The ID of the trace of the step, if it's being traced.
+	TraceID string
 }
 
 func (event StepsWaitingDuration) Emit(logger lager.Logger) {
@@ -35,6 +39,7 @@ func (event StepsWaitingDuration) Emit(logger lager.Logger) {
 				"type":       event.Labels.Type,
 				"workerTags": event.Labels.WorkerTags,
 			},
+			TraceID: event.TraceID,
 		},
 	)
 }
@@ -411,6 +416,98 @@ func (event FailedVolumesToBeGarbageCollected) Emit(logger lager.Logger) {
 	)
 }
 
+type WorkerContainersPendingDestruction struct {
+	WorkerName string
+	Containers int
+}
+
+func (event WorkerContainersPendingDestruction) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("gc-worker-containers-pending-destruction"),
+		Event{
+			Name:  "worker containers pending destruction",
+			Value: float64(event.Containers),
+			Attributes: map[string]string{
+				"worker": event.WorkerName,
+			},
+		},
+	)
+}
+
+type WorkerVolumesPendingDestruction struct {
+	WorkerName string
+	Volumes    int
+}
+
+func (event WorkerVolumesPendingDestruction) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("gc-worker-volumes-pending-destruction"),
+		Event{
+			Name:  "worker volumes pending destruction",
+			Value: float64(event.Volumes),
+			Attributes: map[string]string{
+				"worker": event.WorkerName,
+			},
+		},
+	)
+}
+
+type WorkerContainersMarkedForDestruction struct {
+	WorkerName string
+	Containers int
+}
+
+func (event WorkerContainersMarkedForDestruction) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("gc-worker-containers-marked-for-destruction"),
+		Event{
+			Name:  "worker containers marked for destruction",
+			Value: float64(event.Containers),
+			Attributes: map[string]string{
+				"worker": event.WorkerName,
+			},
+		},
+	)
+}
+
+type WorkerVolumesMarkedForDestruction struct {
+	WorkerName string
+	Volumes    int
+}
+
+func (event WorkerVolumesMarkedForDestruction) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("gc-worker-volumes-marked-for-destruction"),
+		Event{
+			Name:  "worker volumes marked for destruction",
+			Value: float64(event.Volumes),
+			Attributes: map[string]string{
+				"worker": event.WorkerName,
+			},
+		},
+	)
+}
+
+// WorkerVolumesEvictedUnderDiskPressure is the number of resource cache
+// volumes marked for destruction to free up a worker's disk.
+type WorkerVolumesEvictedUnderDiskPressure struct {
+	WorkerName string
+	Volumes    int
+}
+
+func (event WorkerVolumesEvictedUnderDiskPressure) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("gc-worker-volumes-evicted-under-disk-pressure"),
+		Event{
+			Name:  "worker volumes evicted under disk pressure",
+			Value: float64(event.Volumes),
+			Attributes: map[string]string{
+				"worker": event.WorkerName,
+			},
+		},
+	)
+}
+
 type GarbageCollectionContainerCollectorJobDropped struct {
 	WorkerName string
 }
@@ -457,6 +554,110 @@ func (event BuildFinished) Emit(logger lager.Logger) {
 			Name:       "build finished",
 			Value:      ms(event.Build.EndTime().Sub(event.Build.StartTime())),
 			Attributes: attrs,
+			TraceID:    tracing.TraceIDFollowing(event.Build),
+		},
+	)
+}
+
+type BuildStartLatency struct {
+	Build db.Build
+
+	// The time from the build being created until its first step started.
+	Latency time.Duration
+}
+
+func (event BuildStartLatency) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("build-start-latency"),
+		Event{
+			Name:       "build start latency",
+			Value:      ms(event.Latency),
+			Attributes: event.Build.TracingAttrs(),
+			TraceID:    tracing.TraceIDFollowing(event.Build),
+		},
+	)
+}
+
+// JobTimeToGreen is emitted when a build of a job which was failing succeeds.
+type JobTimeToGreen struct {
+	Build db.Build
+
+	// The time from the first build of the job's failing streak finishing
+	// until the build finished.
+	Duration time.Duration
+}
+
+func (event JobTimeToGreen) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("job-time-to-green"),
+		Event{
+			Name:       "job time to green",
+			Value:      ms(event.Duration),
+			Attributes: event.Build.TracingAttrs(),
+		},
+	)
+}
+
+type SecretFetchDuration struct {
+	Manager  string
+	Duration time.Duration
+}
+
+func (event SecretFetchDuration) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("secret-fetch-duration"),
+		Event{
+			Name:  "secret fetch duration",
+			Value: ms(event.Duration),
+			Attributes: map[string]string{
+				"manager": event.Manager,
+			},
+		},
+	)
+}
+
+type SecretFetchFailed struct {
+	Manager string
+}
+
+func (event SecretFetchFailed) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("secret-fetch-failed"),
+		Event{
+			Name:  "secret fetch failed",
+			Value: 1,
+			Attributes: map[string]string{
+				"manager": event.Manager,
+			},
+		},
+	)
+}
+
+// ResourceVersionsDiscovered is emitted after every check of a resource with
+// the number of versions it saved which weren't saved before, if the
+// resource's pipeline is one of the monitor's VersionsDiscoveredPipelines.
+type ResourceVersionsDiscovered struct {
+	TeamName     string
+	PipelineName string
+	ResourceName string
+	Count        int
+}
+
+func (event ResourceVersionsDiscovered) Emit(logger lager.Logger, m *Monitor) {
+	if !m.VersionsDiscoveredPipelines[event.TeamName+"/"+event.PipelineName] {
+		return
+	}
+
+	m.emit(
+		logger.Session("resource-versions-discovered"),
+		Event{
+			Name:  "resource versions discovered",
+			Value: float64(event.Count),
+			Attributes: map[string]string{
+				"team_name": event.TeamName,
+				"pipeline":  event.PipelineName,
+				"resource":  event.ResourceName,
+			},
 		},
 	)
 }
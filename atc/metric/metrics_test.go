@@ -49,6 +49,74 @@ var _ = Describe("Metrics", func() {
 			Expect(event.Value).To(Equal(float64(1)))
 		})
 	})
+
+	Describe("resource versions discovered metric", func() {
+		var (
+			emitter *metricfakes.FakeEmitter
+			monitor *metric.Monitor
+			event   metric.ResourceVersionsDiscovered
+		)
+
+		BeforeEach(func() {
+			emitter = new(metricfakes.FakeEmitter)
+			monitor = metric.NewMonitor()
+
+			emitterFactory := new(metricfakes.FakeEmitterFactory)
+			emitterFactory.IsConfiguredReturns(true)
+			emitterFactory.NewEmitterReturns(emitter, nil)
+
+			monitor.RegisterEmitter(emitterFactory)
+			monitor.Initialize(testLogger, "test", map[string]string{}, 1000)
+
+			event = metric.ResourceVersionsDiscovered{
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				ResourceName: "some-resource",
+				Count:        3,
+			}
+		})
+
+		Context("when the pipeline is allowed", func() {
+			BeforeEach(func() {
+				monitor.VersionsDiscoveredPipelines = map[string]bool{"some-team/some-pipeline": true}
+			})
+
+			It("emits the count tagged by pipeline and resource", func() {
+				event.Emit(testLogger, monitor)
+
+				Eventually(emitter.EmitCallCount).Should(Equal(1))
+
+				_, emitted := emitter.EmitArgsForCall(0)
+				Expect(emitted.Name).To(Equal("resource versions discovered"))
+				Expect(emitted.Value).To(Equal(float64(3)))
+				Expect(emitted.Attributes).To(Equal(map[string]string{
+					"team_name": "some-team",
+					"pipeline":  "some-pipeline",
+					"resource":  "some-resource",
+				}))
+			})
+		})
+
+		Context("when only a pipeline of the same name in another team is allowed", func() {
+			BeforeEach(func() {
+				monitor.VersionsDiscoveredPipelines = map[string]bool{"other-team/some-pipeline": true}
+			})
+
+			It("does not emit", func() {
+				event.Emit(testLogger, monitor)
+
+				Consistently(emitter.EmitCallCount).Should(BeZero())
+			})
+		})
+
+		Context("when no pipelines are allowed", func() {
+			It("does not emit", func() {
+				event.Emit(testLogger, monitor)
+
+				Consistently(emitter.EmitCallCount).Should(BeZero())
+			})
+		})
+	})
 })
 
 type smartFakeEmitter struct {
@@ -253,6 +253,14 @@ func tick(logger lager.Logger, m *Monitor) {
 		},
 	)
 
+	m.emit(
+		logger.Session("checks-throttled"),
+		Event{
+			Name:  "checks throttled",
+			Value: m.ChecksThrottled.Max(),
+		},
+	)
+
 	var memStats runtime.MemStats
 	runtime.ReadMemStats(&memStats)
 
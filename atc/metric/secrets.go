@@ -0,0 +1,64 @@
+package metric
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+// MeterSecrets wraps the secrets created by the given factory so that the
+// latency and failures of every fetch from the credential manager are
+// emitted, tagged with the manager's name.
+func MeterSecrets(logger lager.Logger, manager string, factory creds.SecretsFactory) creds.SecretsFactory {
+	return &meteredSecretsFactory{
+		SecretsFactory: factory,
+
+		logger:  logger,
+		manager: manager,
+	}
+}
+
+type meteredSecretsFactory struct {
+	creds.SecretsFactory
+
+	logger  lager.Logger
+	manager string
+}
+
+func (f *meteredSecretsFactory) NewSecrets() creds.Secrets {
+	return &meteredSecrets{
+		Secrets: f.SecretsFactory.NewSecrets(),
+
+		logger:  f.logger,
+		manager: f.manager,
+	}
+}
+
+type meteredSecrets struct {
+	creds.Secrets
+
+	logger  lager.Logger
+	manager string
+}
+
+// Get fetches the secret from the underlying credential manager. The secret
+// path is deliberately left out of the emitted metrics.
+func (s *meteredSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	start := time.Now()
+
+	result, expiration, exists, err := s.Secrets.Get(secretPath)
+
+	SecretFetchDuration{
+		Manager:  s.manager,
+		Duration: time.Since(start),
+	}.Emit(s.logger)
+
+	if err != nil {
+		SecretFetchFailed{
+			Manager: s.manager,
+		}.Emit(s.logger)
+	}
+
+	return result, expiration, exists, err
+}
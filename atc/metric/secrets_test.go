@@ -0,0 +1,86 @@
+package metric_test
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/metric/metricfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Metering Secrets", func() {
+	var (
+		emitter         *metricfakes.FakeEmitter
+		originalMonitor *metric.Monitor
+
+		underlyingSecrets *credsfakes.FakeSecrets
+		secrets           creds.Secrets
+	)
+
+	BeforeEach(func() {
+		emitter = new(metricfakes.FakeEmitter)
+
+		emitterFactory := new(metricfakes.FakeEmitterFactory)
+		emitterFactory.IsConfiguredReturns(true)
+		emitterFactory.NewEmitterReturns(emitter, nil)
+
+		originalMonitor = metric.Metrics
+		metric.Metrics = metric.NewMonitor()
+		metric.Metrics.RegisterEmitter(emitterFactory)
+		metric.Metrics.Initialize(testLogger, "test", map[string]string{}, 1000)
+
+		underlyingSecrets = new(credsfakes.FakeSecrets)
+		underlyingSecrets.GetReturns("some-value", nil, true, nil)
+
+		secretsFactory := new(credsfakes.FakeSecretsFactory)
+		secretsFactory.NewSecretsReturns(underlyingSecrets)
+
+		secrets = metric.MeterSecrets(testLogger, "vault", secretsFactory).NewSecrets()
+	})
+
+	AfterEach(func() {
+		metric.Metrics = originalMonitor
+	})
+
+	It("returns the secret from the underlying secrets", func() {
+		value, _, found, err := secrets.Get("/concourse/main/some-secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("some-value"))
+
+		Expect(underlyingSecrets.GetArgsForCall(0)).To(Equal("/concourse/main/some-secret"))
+	})
+
+	It("emits the fetch duration tagged with the manager only", func() {
+		_, _, _, err := secrets.Get("/concourse/main/some-secret")
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(emitter.EmitCallCount).Should(Equal(1))
+
+		_, event := emitter.EmitArgsForCall(0)
+		Expect(event.Name).To(Equal("secret fetch duration"))
+		Expect(event.Attributes).To(Equal(map[string]string{"manager": "vault"}))
+	})
+
+	Context("when fetching the secret fails", func() {
+		BeforeEach(func() {
+			underlyingSecrets.GetReturns(nil, nil, false, errors.New("disaster"))
+		})
+
+		It("returns the error and emits a failure", func() {
+			_, _, _, err := secrets.Get("/concourse/main/some-secret")
+			Expect(err).To(MatchError("disaster"))
+
+			Eventually(emitter.EmitCallCount).Should(Equal(2))
+
+			_, event := emitter.EmitArgsForCall(1)
+			Expect(event.Name).To(Equal("secret fetch failed"))
+			Expect(event.Value).To(Equal(float64(1)))
+			Expect(event.Attributes).To(Equal(map[string]string{"manager": "vault"}))
+		})
+	})
+})
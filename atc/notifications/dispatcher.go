@@ -0,0 +1,209 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/component"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+)
+
+const batchSize = 100
+
+// The first retry happens after initialBackoff, doubling with every failed
+// attempt up to maxBackoff.
+const (
+	initialBackoff = 10 * time.Second
+	maxBackoff     = time.Hour
+)
+
+var errWebhookRemoved = errors.New("webhook is no longer configured")
+
+type dispatcher struct {
+	notificationFactory db.WebhookNotificationFactory
+	buildFactory        db.BuildFactory
+	secrets             creds.Secrets
+	varSourcePool       creds.VarSourcePool
+	client              *http.Client
+	clock               clock.Clock
+	maxAttempts         int
+}
+
+// NewDispatcher returns a component which POSTs the notifications queued by
+// finished builds to their pipeline's webhooks.
+//
+// The webhook's URL and headers are looked up and interpolated when sending,
+// so that credentials are never stored along with the notification. Failed
+// deliveries are retried with an exponential backoff until maxAttempts is
+// reached, at which point the notification is marked as dead and logged.
+func NewDispatcher(
+	notificationFactory db.WebhookNotificationFactory,
+	buildFactory db.BuildFactory,
+	secrets creds.Secrets,
+	varSourcePool creds.VarSourcePool,
+	client *http.Client,
+	clock clock.Clock,
+	maxAttempts int,
+) component.Runnable {
+	return &dispatcher{
+		notificationFactory: notificationFactory,
+		buildFactory:        buildFactory,
+		secrets:             secrets,
+		varSourcePool:       varSourcePool,
+		client:              client,
+		clock:               clock,
+		maxAttempts:         maxAttempts,
+	}
+}
+
+func (d *dispatcher) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("webhook-notifier")
+
+	notifications, err := d.notificationFactory.DueNotifications(batchSize)
+	if err != nil {
+		logger.Error("failed-to-get-due-notifications", err)
+		return err
+	}
+
+	for _, notification := range notifications {
+		err := d.dispatch(ctx, logger, notification)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *dispatcher) dispatch(ctx context.Context, logger lager.Logger, notification db.WebhookNotification) error {
+	logger = logger.Session("dispatch", lager.Data{
+		"notification": notification.ID,
+		"build":        notification.BuildID,
+		"webhook":      notification.Webhook,
+	})
+
+	sendErr := d.send(ctx, logger, notification)
+	if sendErr == nil {
+		err := d.notificationFactory.Delivered(notification.ID)
+		if err != nil {
+			logger.Error("failed-to-mark-delivered", err)
+			return err
+		}
+
+		logger.Debug("delivered")
+
+		return nil
+	}
+
+	attempts := notification.Attempts + 1
+	if attempts >= d.maxAttempts || errors.Is(sendErr, errWebhookRemoved) {
+		// dead letters are kept in the database until the build is deleted
+		logger.Error("giving-up", sendErr, lager.Data{"attempts": attempts})
+
+		err := d.notificationFactory.Dead(notification.ID, sendErr.Error())
+		if err != nil {
+			logger.Error("failed-to-mark-dead", err)
+			return err
+		}
+
+		return nil
+	}
+
+	backoff := initialBackoff << (attempts - 1)
+	if backoff > maxBackoff || backoff <= 0 {
+		backoff = maxBackoff
+	}
+
+	logger.Info("retrying", lager.Data{
+		"error":    sendErr.Error(),
+		"attempts": attempts,
+		"backoff":  backoff.String(),
+	})
+
+	err := d.notificationFactory.Retry(notification.ID, d.clock.Now().Add(backoff), sendErr.Error())
+	if err != nil {
+		logger.Error("failed-to-mark-retry", err)
+		return err
+	}
+
+	return nil
+}
+
+func (d *dispatcher) send(ctx context.Context, logger lager.Logger, notification db.WebhookNotification) error {
+	build, found, err := d.buildFactory.Build(notification.BuildID)
+	if err != nil {
+		return fmt.Errorf("find build: %w", err)
+	}
+
+	if !found {
+		return errWebhookRemoved
+	}
+
+	pipeline, found, err := build.Pipeline()
+	if err != nil {
+		return fmt.Errorf("find pipeline: %w", err)
+	}
+
+	if !found {
+		return errWebhookRemoved
+	}
+
+	webhook, found := pipeline.Webhooks().Lookup(notification.Webhook)
+	if !found {
+		return errWebhookRemoved
+	}
+
+	variables, err := pipeline.Variables(logger, d.secrets, d.varSourcePool)
+	if err != nil {
+		return fmt.Errorf("create var sources: %w", err)
+	}
+
+	webhookURL, err := creds.NewString(variables, webhook.URL).Evaluate()
+	if err != nil {
+		return fmt.Errorf("evaluate url: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(notification.Payload))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for name, value := range webhook.Headers {
+		headerValue, err := creds.NewString(variables, value).Evaluate()
+		if err != nil {
+			return fmt.Errorf("evaluate header %s: %w", name, err)
+		}
+
+		req.Header.Set(name, headerValue)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		// the url may contain credentials, so leave it out of the error
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+
+		return fmt.Errorf("send notification: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	return nil
+}
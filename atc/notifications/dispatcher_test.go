@@ -0,0 +1,200 @@
+package notifications_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/component"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/notifications"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Dispatcher", func() {
+	var (
+		server *ghttp.Server
+
+		fakeNotificationFactory *dbfakes.FakeWebhookNotificationFactory
+		fakeBuildFactory        *dbfakes.FakeBuildFactory
+		fakeBuild               *dbfakes.FakeBuild
+		fakePipeline            *dbfakes.FakePipeline
+		fakeClock               *fakeclock.FakeClock
+
+		notification db.WebhookNotification
+
+		dispatcher component.Runnable
+		runErr     error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		notification = db.WebhookNotification{
+			ID:       1,
+			BuildID:  42,
+			Webhook:  "some-webhook",
+			Payload:  json.RawMessage(`{"build_id":42}`),
+			Attempts: 0,
+		}
+
+		fakeNotificationFactory = new(dbfakes.FakeWebhookNotificationFactory)
+		fakeNotificationFactory.DueNotificationsReturns([]db.WebhookNotification{notification}, nil)
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.WebhooksReturns(atc.WebhookConfigs{
+			{
+				Name:    "some-webhook",
+				URL:     server.URL() + "/((path))",
+				Headers: map[string]string{"Authorization": "Bearer ((token))"},
+			},
+		})
+		fakePipeline.VariablesReturns(vars.StaticVariables{
+			"path":  "hook",
+			"token": "some-token",
+		}, nil)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.PipelineReturns(fakePipeline, true, nil)
+
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeBuildFactory.BuildReturns(fakeBuild, true, nil)
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(0, 0))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		dispatcher = notifications.NewDispatcher(
+			fakeNotificationFactory,
+			fakeBuildFactory,
+			new(credsfakes.FakeSecrets),
+			new(credsfakes.FakeVarSourcePool),
+			http.DefaultClient,
+			fakeClock,
+			3,
+		)
+
+		runErr = dispatcher.Run(lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test")))
+	})
+
+	Context("when the webhook accepts the notification", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/hook"),
+					ghttp.VerifyHeaderKV("Authorization", "Bearer some-token"),
+					ghttp.VerifyHeaderKV("Content-Type", "application/json"),
+					ghttp.VerifyJSON(`{"build_id":42}`),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+		})
+
+		It("marks the notification as delivered", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+
+			Expect(fakeNotificationFactory.DeliveredCallCount()).To(Equal(1))
+			Expect(fakeNotificationFactory.DeliveredArgsForCall(0)).To(Equal(1))
+			Expect(fakeNotificationFactory.RetryCallCount()).To(BeZero())
+		})
+
+		It("looks up the webhook of the notification's build", func() {
+			Expect(fakeBuildFactory.BuildArgsForCall(0)).To(Equal(42))
+		})
+	})
+
+	Context("when the webhook fails", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, nil))
+		})
+
+		It("backs off before retrying", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeNotificationFactory.DeliveredCallCount()).To(BeZero())
+
+			Expect(fakeNotificationFactory.RetryCallCount()).To(Equal(1))
+			id, nextAttemptAt, reason := fakeNotificationFactory.RetryArgsForCall(0)
+			Expect(id).To(Equal(1))
+			Expect(nextAttemptAt).To(Equal(fakeClock.Now().Add(10 * time.Second)))
+			Expect(reason).To(ContainSubstring("500 Internal Server Error"))
+		})
+
+		Context("when it has already been retried", func() {
+			BeforeEach(func() {
+				notification.Attempts = 1
+				fakeNotificationFactory.DueNotificationsReturns([]db.WebhookNotification{notification}, nil)
+			})
+
+			It("doubles the backoff", func() {
+				_, nextAttemptAt, _ := fakeNotificationFactory.RetryArgsForCall(0)
+				Expect(nextAttemptAt).To(Equal(fakeClock.Now().Add(20 * time.Second)))
+			})
+		})
+
+		Context("when it has run out of attempts", func() {
+			BeforeEach(func() {
+				notification.Attempts = 2
+				fakeNotificationFactory.DueNotificationsReturns([]db.WebhookNotification{notification}, nil)
+			})
+
+			It("marks the notification as dead", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(fakeNotificationFactory.RetryCallCount()).To(BeZero())
+
+				Expect(fakeNotificationFactory.DeadCallCount()).To(Equal(1))
+				id, reason := fakeNotificationFactory.DeadArgsForCall(0)
+				Expect(id).To(Equal(1))
+				Expect(reason).To(ContainSubstring("500 Internal Server Error"))
+			})
+		})
+	})
+
+	Context("when the webhook cannot be reached", func() {
+		BeforeEach(func() {
+			server.Close()
+		})
+
+		It("does not include the interpolated url in the error", func() {
+			_, _, reason := fakeNotificationFactory.RetryArgsForCall(0)
+			Expect(reason).ToNot(ContainSubstring("/hook"))
+		})
+	})
+
+	Context("when the webhook has been removed from the pipeline", func() {
+		BeforeEach(func() {
+			fakePipeline.WebhooksReturns(nil)
+		})
+
+		It("marks the notification as dead without retrying", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeNotificationFactory.RetryCallCount()).To(BeZero())
+			Expect(fakeNotificationFactory.DeadCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when getting the due notifications fails", func() {
+		BeforeEach(func() {
+			fakeNotificationFactory.DueNotificationsReturns(nil, errors.New("disaster"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("disaster"))
+		})
+	})
+})
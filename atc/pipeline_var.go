@@ -0,0 +1,26 @@
+package atc
+
+// SetPipelineVarRequest sets a local var of a team's pipelines.
+type SetPipelineVarRequest struct {
+	Value interface{} `json:"value"`
+
+	// Create sets the var on pipelines which don't have it yet, which are
+	// skipped otherwise.
+	Create bool `json:"create,omitempty"`
+
+	// The names of the pipelines to set the var of. Every pipeline of the team
+	// if empty.
+	Pipelines []string `json:"pipelines,omitempty"`
+}
+
+// PipelineVars are the names of a pipeline's local vars. Their values are
+// credentials, so they aren't listed.
+type PipelineVars struct {
+	Pipeline PipelineRef `json:"pipeline"`
+	Vars     []string    `json:"vars"`
+}
+
+type PipelineVarUpdate struct {
+	Pipeline PipelineRef `json:"pipeline"`
+	Updated  bool        `json:"updated"`
+}
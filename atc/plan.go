@@ -1,5 +1,10 @@
 package atc
 
+import (
+	"bytes"
+	"encoding/json"
+)
+
 type Plan struct {
 	ID       PlanID `json:"id"`
 	Attempts []int  `json:"attempts,omitempty"`
@@ -10,10 +15,18 @@ type Plan struct {
 	Task        *TaskPlan        `json:"task,omitempty"`
 	SetPipeline *SetPipelinePlan `json:"set_pipeline,omitempty"`
 	LoadVar     *LoadVarPlan     `json:"load_var,omitempty"`
+	Approval    *ApprovalPlan    `json:"approval,omitempty"`
+
+	PublishArtifact *PublishArtifactPlan `json:"publish_artifact,omitempty"`
+	ConsumeArtifact *ConsumeArtifactPlan `json:"consume_artifact,omitempty"`
+
+	LoadBuildOutputs *LoadBuildOutputsPlan `json:"load_build_outputs,omitempty"`
+	AssertGreen      *AssertGreenPlan      `json:"assert_green,omitempty"`
 
 	Do         *DoPlan         `json:"do,omitempty"`
 	InParallel *InParallelPlan `json:"in_parallel,omitempty"`
 	Across     *AcrossPlan     `json:"across,omitempty"`
+	Atomic     *AtomicPlan     `json:"atomic,omitempty"`
 
 	OnSuccess *OnSuccessPlan `json:"on_success,omitempty"`
 	OnFailure *OnFailurePlan `json:"on_failure,omitempty"`
@@ -57,6 +70,13 @@ func (plan *Plan) Each(f func(*Plan)) {
 		}
 	}
 
+	if plan.Atomic != nil {
+		for i, p := range plan.Atomic.Steps {
+			p.Each(f)
+			plan.Atomic.Steps[i] = p
+		}
+	}
+
 	if plan.OnSuccess != nil {
 		plan.OnSuccess.Step.Each(f)
 		plan.OnSuccess.Next.Each(f)
@@ -91,9 +111,9 @@ func (plan *Plan) Each(f func(*Plan)) {
 	}
 
 	if plan.Retry != nil {
-		for i, p := range *plan.Retry {
+		for i, p := range plan.Retry.Steps {
 			p.Each(f)
-			(*plan.Retry)[i] = p
+			plan.Retry.Steps[i] = p
 		}
 	}
 }
@@ -151,6 +171,11 @@ type InParallelPlan struct {
 	Steps    []Plan `json:"steps"`
 	Limit    int    `json:"limit,omitempty"`
 	FailFast bool   `json:"fail_fast,omitempty"`
+
+	FailFastGracePeriod string `json:"fail_fast_grace_period,omitempty"`
+
+	// OutputPriority holds indexes into Steps, highest priority first.
+	OutputPriority []int `json:"output_priority,omitempty"`
 }
 
 type AcrossPlan struct {
@@ -172,6 +197,13 @@ type VarScopedPlan struct {
 
 type DoPlan []Plan
 
+// AtomicPlan publishes its puts all or nothing. Each of its steps is a put,
+// optionally followed by the get of the version it created through an
+// OnSuccessPlan.
+type AtomicPlan struct {
+	Steps []Plan `json:"steps"`
+}
+
 type GetPlan struct {
 	// The name of the step.
 	Name string `json:"name,omitempty"`
@@ -194,9 +226,29 @@ type GetPlan struct {
 	// Worker tags to influence placement of the container.
 	Tags Tags `json:"tags,omitempty"`
 
+	// Worker tags of which the worker must have at least one.
+	AnyTags Tags `json:"any_tags,omitempty"`
+
+	// The container runtime the worker must be running, e.g. containerd.
+	Runtime string `json:"runtime,omitempty"`
+
 	// A timeout to enforce on the resource `get` process. Note that fetching the
 	// resource's image does not count towards the timeout.
 	Timeout string `json:"timeout,omitempty"`
+
+	// Keys of the fetched version's metadata to set as fields of a local var
+	// named after the step.
+	MetadataVars []string `json:"metadata_vars,omitempty"`
+
+	// The format of the archives to extract in place among the fetched files,
+	// e.g. tar.gz. The files are left as fetched if empty.
+	Unpack string `json:"unpack,omitempty"`
+
+	// The proxies for the container to egress through, if not the worker's.
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+
+	// Extra entries to add to the container's /etc/hosts.
+	Hosts HostsConfig `json:"hosts,omitempty"`
 }
 
 type PutPlan struct {
@@ -220,14 +272,43 @@ type PutPlan struct {
 	// Worker tags to influence placement of the container.
 	Tags Tags `json:"tags,omitempty"`
 
+	// Worker tags of which the worker must have at least one.
+	AnyTags Tags `json:"any_tags,omitempty"`
+
+	// The container runtime the worker must be running, e.g. containerd.
+	Runtime string `json:"runtime,omitempty"`
+
 	// A timeout to enforce on the resource `put` process. Note that fetching the
 	// resource's image does not count towards the timeout.
 	Timeout string `json:"timeout,omitempty"`
 
 	// If or not expose BUILD_CREATED_BY to build metadata
 	ExposeBuildCreatedBy bool `json:"expose_build_created_by,omitempty"`
+
+	// The proxies for the container to egress through, if not the worker's.
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+
+	// Extra entries to add to the container's /etc/hosts.
+	Hosts HostsConfig `json:"hosts,omitempty"`
+
+	// The phase of a two-phase commit to run the put as, as part of an atomic
+	// step. Empty for a regular put.
+	Phase PutPhase `json:"phase,omitempty"`
 }
 
+type PutPhase string
+
+const (
+	PutPhaseStage    PutPhase = "stage"
+	PutPhaseCommit   PutPhase = "commit"
+	PutPhaseRollback PutPhase = "rollback"
+)
+
+// TwoPhaseCommitParam is the param through which a put of a resource type
+// supporting two-phase commit is told which phase to run, along with the
+// version it staged when committing or rolling back.
+const TwoPhaseCommitParam = "two_phase_commit"
+
 type CheckPlan struct {
 	// The name of the step.
 	Name string `json:"name"`
@@ -254,8 +335,24 @@ type CheckPlan struct {
 	// the resource's image does not count towards the timeout.
 	Timeout string `json:"timeout,omitempty"`
 
+	// Limits to set on the check container.
+	Limits *ContainerLimits `json:"container_limits,omitempty"`
+
+	// The check pool whose rate limit the check is subject to.
+	CheckPool string `json:"check_pool,omitempty"`
+
+	// The longest the config may go without a scheduled check. Once it has
+	// elapsed, a scheduled check runs even if the interval hasn't.
+	TTL string `json:"ttl,omitempty"`
+
 	// Worker tags to influence placement of the container.
 	Tags Tags `json:"tags,omitempty"`
+
+	// Worker tags of which the worker must have at least one.
+	AnyTags Tags `json:"any_tags,omitempty"`
+
+	// The proxies for the container to egress through, if not the worker's.
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
 }
 
 func (plan CheckPlan) IsPeriodic() bool {
@@ -274,6 +371,16 @@ type TaskPlan struct {
 	// Worker tags to influence placement of the container.
 	Tags Tags `json:"tags,omitempty"`
 
+	// Worker tags of which the worker must have at least one.
+	AnyTags Tags `json:"any_tags,omitempty"`
+
+	// The container runtime the worker must be running, e.g. containerd.
+	Runtime string `json:"runtime,omitempty"`
+
+	// The name of a worker to run the task on, bypassing the container
+	// placement strategy. Only intended for debugging.
+	WorkerName string `json:"worker_name,omitempty"`
+
 	// The task config to execute - either fetched from a path at runtime, or
 	// provided statically.
 	ConfigPath string      `json:"config_path,omitempty"`
@@ -282,6 +389,10 @@ type TaskPlan struct {
 	// Limits to set on the Task Container
 	Limits *ContainerLimits `json:"container_limits,omitempty"`
 
+	// Limits to fall back on for any limit not set by the step or the task
+	// config, e.g. from the pipeline's task defaults.
+	DefaultLimits *ContainerLimits `json:"default_limits,omitempty"`
+
 	// An artifact in the build plan to use as the task's image. Overrides any
 	// image set in the task's config.
 	ImageArtifactName string `json:"image,omitempty"`
@@ -297,10 +408,27 @@ type TaskPlan struct {
 	InputMapping  map[string]string `json:"input_mapping,omitempty"`
 	OutputMapping map[string]string `json:"output_mapping,omitempty"`
 
+	// Artifacts fetched by a get of which this task is the only consumer. The
+	// task is placed on the worker they were fetched on when it's compatible,
+	// so that they're mounted copy-on-write instead of streamed.
+	ColocatedInputs []string `json:"colocated_inputs,omitempty"`
+
 	// A timeout to enforce on the task's process. Note that etching the task's
 	// image does not count towards the timeout.
 	Timeout string `json:"timeout,omitempty"`
 
+	// Restore the outputs of a previous run of the task with the same config,
+	// image and inputs instead of running it again.
+	CacheOutputs bool `json:"cache_outputs,omitempty"`
+
+	// Run the task's command under a minimal init process which forwards
+	// signals to it and reaps any processes orphaned under it.
+	Init bool `json:"init,omitempty"`
+
+	// Extra entries to add to the container's /etc/hosts. These take
+	// precedence over the entries of the task's config.
+	Hosts HostsConfig `json:"hosts,omitempty"`
+
 	// Resource types to have available for use when fetching the task's image.
 	//
 	// XXX(check-refactor): Eliminating this would be great - if we can replace
@@ -326,9 +454,86 @@ type LoadVarPlan struct {
 	File   string `json:"file"`
 	Format string `json:"format,omitempty"`
 	Reveal bool   `json:"reveal,omitempty"`
+
+	// Whether to also set the var as a var of the build's pipeline.
+	SetPipelineVar bool `json:"set_pipeline_var,omitempty"`
+}
+
+type ApprovalPlan struct {
+	Name    string `json:"name"`
+	Timeout string `json:"timeout,omitempty"`
+}
+
+type PublishArtifactPlan struct {
+	Name string `json:"name"`
+	From string `json:"from"`
+}
+
+type ConsumeArtifactPlan struct {
+	Name string `json:"name"`
+	Team string `json:"team,omitempty"`
+}
+
+type LoadBuildOutputsPlan struct {
+	Name    string   `json:"name"`
+	Job     string   `json:"job"`
+	Build   string   `json:"build,omitempty"`
+	Outputs []string `json:"outputs,omitempty"`
+	Reveal  bool     `json:"reveal,omitempty"`
+}
+
+type AssertGreenPlan struct {
+	Job          string       `json:"job"`
+	Pipeline     string       `json:"pipeline,omitempty"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+	Team         string       `json:"team,omitempty"`
 }
 
-type RetryPlan []Plan
+type RetryPlan struct {
+	Steps []Plan `json:"steps"`
+
+	// The delay before the second attempt, doubling for each attempt after.
+	Backoff string `json:"backoff,omitempty"`
+
+	// The total time after which no further attempts are started.
+	MaxDuration string `json:"max_duration,omitempty"`
+}
+
+// MarshalJSON encodes a plain list of attempts as an array so that plans
+// without a backoff keep the representation used by older builds.
+func (plan RetryPlan) MarshalJSON() ([]byte, error) {
+	if plan.Backoff == "" && plan.MaxDuration == "" {
+		steps := plan.Steps
+		if steps == nil {
+			steps = []Plan{}
+		}
+
+		return json.Marshal(steps)
+	}
+
+	// Used to avoid infinite recursion when marshalling.
+	type target RetryPlan
+
+	return json.Marshal(target(plan))
+}
+
+func (plan *RetryPlan) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte{'['}) {
+		return json.Unmarshal(data, &plan.Steps)
+	}
+
+	// Used to avoid infinite recursion when unmarshalling.
+	type target RetryPlan
+
+	var t target
+	err := json.Unmarshal(data, &t)
+	if err != nil {
+		return err
+	}
+
+	*plan = RetryPlan(t)
+	return nil
+}
 
 type DependentGetPlan struct {
 	Type     string `json:"type"`
@@ -31,6 +31,8 @@ func (factory PlanFactory) NewPlan(step PlanConfig) Plan {
 		plan.Across = &t
 	case DoPlan:
 		plan.Do = &t
+	case AtomicPlan:
+		plan.Atomic = &t
 	case GetPlan:
 		plan.Get = &t
 	case PutPlan:
@@ -41,6 +43,16 @@ func (factory PlanFactory) NewPlan(step PlanConfig) Plan {
 		plan.SetPipeline = &t
 	case LoadVarPlan:
 		plan.LoadVar = &t
+	case ApprovalPlan:
+		plan.Approval = &t
+	case PublishArtifactPlan:
+		plan.PublishArtifact = &t
+	case ConsumeArtifactPlan:
+		plan.ConsumeArtifact = &t
+	case LoadBuildOutputsPlan:
+		plan.LoadBuildOutputs = &t
+	case AssertGreenPlan:
+		plan.AssertGreen = &t
 	case CheckPlan:
 		plan.Check = &t
 	case OnAbortPlan:
@@ -0,0 +1,46 @@
+package provenance
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/golang/groupcache/lru"
+)
+
+// Cache holds the provenance of the builds it most recently generated. The
+// provenance of a finished build never changes, so its event log only has to
+// be read once rather than on every request.
+type Cache struct {
+	cache *lru.Cache
+	mu    sync.Mutex // lru.Cache is not safe for concurrent access
+}
+
+// NewCache returns a cache of the provenance of up to maxBuilds builds.
+func NewCache(maxBuilds int) *Cache {
+	return &Cache{
+		cache: lru.New(maxBuilds),
+	}
+}
+
+// Generate returns the cached provenance of the finished build, generating it
+// if it isn't cached.
+func (c *Cache) Generate(build db.Build, externalURL string) (Statement, error) {
+	c.mu.Lock()
+	cached, found := c.cache.Get(build.ID())
+	c.mu.Unlock()
+
+	if found {
+		return cached.(Statement), nil
+	}
+
+	statement, err := Generate(build, externalURL)
+	if err != nil {
+		return Statement{}, err
+	}
+
+	c.mu.Lock()
+	c.cache.Add(build.ID(), statement)
+	c.mu.Unlock()
+
+	return statement, nil
+}
@@ -0,0 +1,63 @@
+package provenance_test
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/provenance"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cache", func() {
+	var (
+		cache     *provenance.Cache
+		fakeBuild *dbfakes.FakeBuild
+	)
+
+	BeforeEach(func() {
+		cache = provenance.NewCache(1)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+
+		fakeEvents := new(dbfakes.FakeEventSource)
+		fakeEvents.NextReturns(event.Envelope{}, db.ErrEndOfBuildEventStream)
+		fakeBuild.EventsReturns(fakeEvents, nil)
+	})
+
+	It("reads the events of a build once", func() {
+		first, err := cache.Generate(fakeBuild, "https://example.com")
+		Expect(err).ToNot(HaveOccurred())
+
+		second, err := cache.Generate(fakeBuild, "https://example.com")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(second).To(Equal(first))
+		Expect(fakeBuild.EventsCallCount()).To(Equal(1))
+	})
+
+	It("generates the provenance of other builds", func() {
+		_, err := cache.Generate(fakeBuild, "https://example.com")
+		Expect(err).ToNot(HaveOccurred())
+
+		otherBuild := new(dbfakes.FakeBuild)
+		otherBuild.IDReturns(43)
+		otherBuild.EventsReturns(nil, errors.New("nope"))
+
+		_, err = cache.Generate(otherBuild, "https://example.com")
+		Expect(err).To(MatchError(ContainSubstring("nope")))
+	})
+
+	It("does not cache failures", func() {
+		fakeBuild.ResourcesReturnsOnCall(0, nil, nil, errors.New("nope"))
+
+		_, err := cache.Generate(fakeBuild, "https://example.com")
+		Expect(err).To(HaveOccurred())
+
+		_, err = cache.Generate(fakeBuild, "https://example.com")
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
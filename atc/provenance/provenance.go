@@ -0,0 +1,341 @@
+package provenance
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// VersionDigestAlgorithm is the non-standard digest algorithm a version
+// without a digest of its artifact is identified by: the SHA-256 digest of the
+// version's JSON encoding.
+const VersionDigestAlgorithm = "concourseVersionSha256"
+
+var digestRegexp = regexp.MustCompile(`^(sha256|sha384|sha512):([0-9a-f]+)$`)
+
+// digestLengths are the lengths of the hex-encoded digests of each algorithm.
+var digestLengths = map[string]int{
+	"sha256": 64,
+	"sha384": 96,
+	"sha512": 128,
+}
+
+// Generate returns the provenance of a finished build, as recorded by its
+// inputs and outputs, its plan and its events.
+func Generate(build db.Build, externalURL string) (Statement, error) {
+	inputs, outputs, err := build.Resources()
+	if err != nil {
+		return Statement{}, fmt.Errorf("get resources: %w", err)
+	}
+
+	steps, images, err := buildEvents(build)
+	if err != nil {
+		return Statement{}, fmt.Errorf("read events: %w", err)
+	}
+
+	planSteps, err := ranSteps(build.PublicPlan(), steps)
+	if err != nil {
+		return Statement{}, fmt.Errorf("read plan: %w", err)
+	}
+
+	subjects := []Subject{}
+	for _, output := range outputs {
+		subjects = append(subjects, Subject{
+			Name:   output.Name,
+			Digest: versionDigest(output.Version),
+		})
+	}
+
+	materials, err := inputMaterials(build, externalURL, inputs)
+	if err != nil {
+		return Statement{}, err
+	}
+
+	for _, image := range images {
+		materials = appendMaterial(materials, Material{
+			URI:    image.Type + ":" + image.Name,
+			Digest: versionDigest(image.FetchedVersion),
+		})
+	}
+
+	var configSource ConfigSource
+	if build.PipelineID() != 0 {
+		configSource = ConfigSource{
+			URI:        pipelineURL(build, externalURL, ""),
+			EntryPoint: build.JobName(),
+		}
+	}
+
+	return Statement{
+		Type:          StatementType,
+		Subject:       subjects,
+		PredicateType: PredicateType,
+		Predicate: Predicate{
+			Builder:   Builder{ID: externalURL},
+			BuildType: BuildType,
+			Invocation: Invocation{
+				ConfigSource: configSource,
+				Environment: Environment{
+					Team:         build.TeamName(),
+					Pipeline:     build.PipelineName(),
+					InstanceVars: build.PipelineInstanceVars(),
+					Job:          build.JobName(),
+					BuildID:      build.ID(),
+					BuildName:    build.Name(),
+				},
+			},
+			BuildConfig: BuildConfig{
+				Steps: planSteps,
+			},
+			Metadata: Metadata{
+				BuildInvocationID: fmt.Sprintf("%s/builds/%d", externalURL, build.ID()),
+				BuildStartedOn:    timestamp(build.StartTime()),
+				BuildFinishedOn:   timestamp(build.EndTime()),
+				Completeness: Completeness{
+					Materials: identified(materials),
+				},
+			},
+			Materials: materials,
+		},
+	}, nil
+}
+
+// buildEvents returns the order in which the build's steps first emitted an
+// event, along with the images fetched to run them.
+func buildEvents(build db.Build) (map[atc.PlanID]int, []event.ImageFetched, error) {
+	events, err := build.Events(0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer db.Close(events)
+
+	steps := map[atc.PlanID]int{}
+	var images []event.ImageFetched
+	for {
+		ev, err := events.Next()
+		if err == db.ErrEndOfBuildEventStream {
+			break
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if ev.Data == nil {
+			continue
+		}
+
+		var withOrigin struct {
+			Origin event.Origin `json:"origin"`
+		}
+
+		err = json.Unmarshal(*ev.Data, &withOrigin)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		id := atc.PlanID(withOrigin.Origin.ID)
+		if _, seen := steps[id]; id != "" && !seen {
+			steps[id] = len(steps)
+		}
+
+		if ev.Event == event.EventTypeImageFetched {
+			var image event.ImageFetched
+			err = json.Unmarshal(*ev.Data, &image)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			images = append(images, image)
+		}
+	}
+
+	return steps, images, nil
+}
+
+// ranSteps returns the steps of the public plan which emitted events, in the
+// order they did so.
+func ranSteps(plan *json.RawMessage, ran map[atc.PlanID]int) ([]Step, error) {
+	steps := []Step{}
+	if plan == nil {
+		return steps, nil
+	}
+
+	var node interface{}
+	err := json.Unmarshal(*plan, &node)
+	if err != nil {
+		return nil, err
+	}
+
+	walkPlan(node, func(step Step) {
+		if _, found := ran[step.ID]; found {
+			steps = append(steps, step)
+		}
+	})
+
+	sort.Slice(steps, func(i, j int) bool {
+		return ran[steps[i].ID] < ran[steps[j].ID]
+	})
+
+	return steps, nil
+}
+
+func walkPlan(node interface{}, visit func(Step)) {
+	switch n := node.(type) {
+	case []interface{}:
+		for _, child := range n {
+			walkPlan(child, visit)
+		}
+
+	case map[string]interface{}:
+		id, isPlan := n["id"].(string)
+		for key, value := range n {
+			if key == "id" {
+				continue
+			}
+
+			if isPlan {
+				step := Step{ID: atc.PlanID(id), Type: key}
+				if config, ok := value.(map[string]interface{}); ok {
+					step.Name, _ = config["name"].(string)
+				}
+
+				visit(step)
+			}
+
+			walkPlan(value, visit)
+		}
+	}
+}
+
+func inputMaterials(build db.Build, externalURL string, inputs []db.BuildInput) ([]Material, error) {
+	materials := []Material{}
+	if len(inputs) == 0 {
+		return materials, nil
+	}
+
+	pipeline, found, err := build.Pipeline()
+	if err != nil {
+		return nil, fmt.Errorf("get pipeline: %w", err)
+	}
+
+	for _, input := range inputs {
+		uri := input.Name
+		if found {
+			resource, found, err := pipeline.ResourceByID(input.ResourceID)
+			if err != nil {
+				return nil, fmt.Errorf("get resource of input %s: %w", input.Name, err)
+			}
+
+			if found {
+				uri = pipelineURL(build, externalURL, "/resources/"+url.PathEscape(resource.Name()))
+			}
+		}
+
+		materials = appendMaterial(materials, Material{
+			URI:    uri,
+			Digest: versionDigest(input.Version),
+		})
+	}
+
+	return materials, nil
+}
+
+func appendMaterial(materials []Material, material Material) []Material {
+	for _, m := range materials {
+		if m.URI == material.URI && equalDigests(m.Digest, material.Digest) {
+			return materials
+		}
+	}
+
+	return append(materials, material)
+}
+
+// versionDigest returns the digests found in the fields of a version, such as
+// the digest of an image, as "<algorithm>:<hex>". If several fields hold a
+// digest of the same algorithm, the field first by name wins. Versions
+// without any are identified by the non-standard VersionDigestAlgorithm
+// instead, as nothing tells what any other value is a digest of.
+func versionDigest(version atc.Version) DigestSet {
+	fields := make([]string, 0, len(version))
+	for field := range version {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	digests := DigestSet{}
+	for _, field := range fields {
+		match := digestRegexp.FindStringSubmatch(version[field])
+		if match == nil || len(match[2]) != digestLengths[match[1]] {
+			continue
+		}
+
+		if _, found := digests[match[1]]; !found {
+			digests[match[1]] = match[2]
+		}
+	}
+
+	if len(digests) == 0 {
+		payload, _ := json.Marshal(version)
+		sum := sha256.Sum256(payload)
+		digests[VersionDigestAlgorithm] = hex.EncodeToString(sum[:])
+	}
+
+	return digests
+}
+
+// identified returns whether every material has a standard digest, so that
+// the materials fully identify every version and image the build used.
+func identified(materials []Material) bool {
+	for _, material := range materials {
+		if _, found := material.Digest[VersionDigestAlgorithm]; found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func equalDigests(a, b DigestSet) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for algorithm, digest := range a {
+		if b[algorithm] != digest {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pipelineURL returns the web URL of the path under the build's pipeline.
+func pipelineURL(build db.Build, externalURL string, path string) string {
+	pipelineURL := fmt.Sprintf("%s/teams/%s/pipelines/%s%s", externalURL, url.PathEscape(build.TeamName()), url.PathEscape(build.PipelineName()), path)
+	if query := build.PipelineRef().QueryParams(); len(query) > 0 {
+		pipelineURL += "?" + query.Encode()
+	}
+
+	return pipelineURL
+}
+
+func timestamp(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	t = t.UTC()
+	return &t
+}
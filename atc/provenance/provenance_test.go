@@ -0,0 +1,282 @@
+package provenance_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/provenance"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	imageDigest  = strings.Repeat("ab", 32)
+	outputDigest = strings.Repeat("cd", 32)
+)
+
+var _ = Describe("Generate", func() {
+	var (
+		fakeBuild    *dbfakes.FakeBuild
+		fakePipeline *dbfakes.FakePipeline
+		fakeEvents   *dbfakes.FakeEventSource
+
+		statement provenance.Statement
+		err       error
+	)
+
+	BeforeEach(func() {
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.NameReturns("7")
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.PipelineIDReturns(1)
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.PipelineRefReturns(atc.PipelineRef{Name: "some-pipeline"})
+		fakeBuild.JobNameReturns("some-job")
+		fakeBuild.StartTimeReturns(time.Unix(100, 0))
+		fakeBuild.EndTimeReturns(time.Unix(200, 0))
+
+		plan := json.RawMessage(`{
+			"id": "1",
+			"do": [
+				{"id": "2", "get": {"name": "repo"}},
+				{"id": "3", "task": {"name": "build"}},
+				{"id": "4", "put": {"name": "image"}}
+			]
+		}`)
+		fakeBuild.PublicPlanReturns(&plan)
+
+		fakeResource := new(dbfakes.FakeResource)
+		fakeResource.NameReturns("repo")
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.ResourceByIDReturns(fakeResource, true, nil)
+		fakeBuild.PipelineReturns(fakePipeline, true, nil)
+
+		fakeBuild.ResourcesReturns(
+			[]db.BuildInput{
+				{
+					Name:       "repo",
+					ResourceID: 11,
+					Version:    atc.Version{"ref": "0123456789abcdef0123456789abcdef01234567"},
+				},
+			},
+			[]db.BuildOutput{
+				{
+					Name:    "image",
+					Version: atc.Version{"digest": "sha256:" + outputDigest},
+				},
+			},
+			nil,
+		)
+
+		fakeEvents = new(dbfakes.FakeEventSource)
+		for i, ev := range []atc.Event{
+			event.InitializeGet{Origin: event.Origin{ID: "2"}},
+			event.ImageFetched{
+				Origin:         event.Origin{ID: "3"},
+				Name:           "alpine",
+				Type:           "registry-image",
+				FetchedVersion: atc.Version{"digest": "sha256:" + imageDigest},
+			},
+			event.FinishTask{Origin: event.Origin{ID: "3"}},
+		} {
+			payload, err := json.Marshal(ev)
+			Expect(err).ToNot(HaveOccurred())
+
+			data := json.RawMessage(payload)
+			fakeEvents.NextReturnsOnCall(i, event.Envelope{
+				Event:   ev.EventType(),
+				Version: ev.Version(),
+				Data:    &data,
+			}, nil)
+		}
+		fakeEvents.NextReturns(event.Envelope{}, db.ErrEndOfBuildEventStream)
+		fakeBuild.EventsReturns(fakeEvents, nil)
+	})
+
+	JustBeforeEach(func() {
+		statement, err = provenance.Generate(fakeBuild, "https://example.com")
+	})
+
+	It("describes the outputs as its subjects", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(statement.Type).To(Equal(provenance.StatementType))
+		Expect(statement.PredicateType).To(Equal(provenance.PredicateType))
+		Expect(statement.Subject).To(Equal([]provenance.Subject{
+			{Name: "image", Digest: provenance.DigestSet{"sha256": outputDigest}},
+		}))
+	})
+
+	It("lists the inputs and images as materials", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakePipeline.ResourceByIDArgsForCall(0)).To(Equal(11))
+		Expect(statement.Predicate.Materials).To(Equal([]provenance.Material{
+			{
+				URI:    "https://example.com/teams/some-team/pipelines/some-pipeline/resources/repo",
+				Digest: provenance.DigestSet{provenance.VersionDigestAlgorithm: sha256Hex(`{"ref":"0123456789abcdef0123456789abcdef01234567"}`)},
+			},
+			{
+				URI:    "registry-image:alpine",
+				Digest: provenance.DigestSet{"sha256": imageDigest},
+			},
+		}))
+	})
+
+	It("lists the steps which ran in the order they ran", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(statement.Predicate.BuildConfig.Steps).To(Equal([]provenance.Step{
+			{ID: "2", Type: "get", Name: "repo"},
+			{ID: "3", Type: "task", Name: "build"},
+		}))
+	})
+
+	It("describes the invocation", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(statement.Predicate.Builder.ID).To(Equal("https://example.com"))
+		Expect(statement.Predicate.Invocation.ConfigSource).To(Equal(provenance.ConfigSource{
+			URI:        "https://example.com/teams/some-team/pipelines/some-pipeline",
+			EntryPoint: "some-job",
+		}))
+		Expect(statement.Predicate.Invocation.Environment).To(Equal(provenance.Environment{
+			Team:      "some-team",
+			Pipeline:  "some-pipeline",
+			Job:       "some-job",
+			BuildID:   42,
+			BuildName: "7",
+		}))
+
+		started := time.Unix(100, 0).UTC()
+		finished := time.Unix(200, 0).UTC()
+		Expect(statement.Predicate.Metadata).To(Equal(provenance.Metadata{
+			BuildInvocationID: "https://example.com/builds/42",
+			BuildStartedOn:    &started,
+			BuildFinishedOn:   &finished,
+		}))
+	})
+
+	It("does not claim the materials are complete when a version has no digest", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(statement.Predicate.Metadata.Completeness.Materials).To(BeFalse())
+	})
+
+	Context("when a version has no digest", func() {
+		BeforeEach(func() {
+			fakeBuild.ResourcesReturns(nil, []db.BuildOutput{
+				{Name: "some-output", Version: atc.Version{"version": "1.2.3"}},
+			}, nil)
+		})
+
+		It("uses the digest of the version under a non-standard algorithm", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(statement.Subject).To(Equal([]provenance.Subject{
+				{
+					Name:   "some-output",
+					Digest: provenance.DigestSet{provenance.VersionDigestAlgorithm: sha256Hex(`{"version":"1.2.3"}`)},
+				},
+			}))
+		})
+	})
+
+	Context("when a version has a malformed digest", func() {
+		BeforeEach(func() {
+			fakeBuild.ResourcesReturns(nil, []db.BuildOutput{
+				{Name: "some-output", Version: atc.Version{"digest": "sha256:abcdef"}},
+			}, nil)
+		})
+
+		It("is not taken as a digest", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(statement.Subject).To(Equal([]provenance.Subject{
+				{
+					Name:   "some-output",
+					Digest: provenance.DigestSet{provenance.VersionDigestAlgorithm: sha256Hex(`{"digest":"sha256:abcdef"}`)},
+				},
+			}))
+		})
+	})
+
+	Context("when several fields of a version hold digests", func() {
+		BeforeEach(func() {
+			fakeBuild.ResourcesReturns(nil, []db.BuildOutput{
+				{
+					Name: "some-output",
+					Version: atc.Version{
+						"b-digest": "sha256:" + imageDigest,
+						"a-digest": "sha256:" + outputDigest,
+						"c-digest": "sha512:" + strings.Repeat("ef", 64),
+					},
+				},
+			}, nil)
+		})
+
+		It("takes the digest of each algorithm from the field first by name", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(statement.Subject).To(Equal([]provenance.Subject{
+				{
+					Name: "some-output",
+					Digest: provenance.DigestSet{
+						"sha256": outputDigest,
+						"sha512": strings.Repeat("ef", 64),
+					},
+				},
+			}))
+		})
+	})
+
+	Context("when every input and image has a digest", func() {
+		BeforeEach(func() {
+			fakeBuild.ResourcesReturns([]db.BuildInput{
+				{
+					Name:       "repo",
+					ResourceID: 11,
+					Version:    atc.Version{"digest": "sha256:" + outputDigest},
+				},
+			}, nil, nil)
+		})
+
+		It("claims the materials are complete", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(statement.Predicate.Metadata.Completeness.Materials).To(BeTrue())
+		})
+	})
+
+	Context("when the build is a one-off", func() {
+		BeforeEach(func() {
+			fakeBuild.PipelineIDReturns(0)
+			fakeBuild.ResourcesReturns(nil, nil, nil)
+		})
+
+		It("has no config source", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(statement.Predicate.Invocation.ConfigSource).To(BeZero())
+			Expect(statement.Predicate.Materials).To(ConsistOf(provenance.Material{
+				URI:    "registry-image:alpine",
+				Digest: provenance.DigestSet{"sha256": imageDigest},
+			}))
+		})
+	})
+
+	Context("when reading the events fails", func() {
+		BeforeEach(func() {
+			fakeBuild.EventsReturns(nil, errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(err).To(MatchError(ContainSubstring("nope")))
+		})
+	})
+})
+
+func sha256Hex(payload string) string {
+	sum := sha256.Sum256([]byte(payload))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,79 @@
+package provenance
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"gopkg.in/square/go-jose.v2"
+)
+
+// PayloadType is the type of the payload of the envelope the provenance of a
+// build is signed in.
+const PayloadType = "application/vnd.in-toto+json"
+
+// Envelope is a DSSE envelope holding a signed payload.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+type Signature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// A Signer signs payloads with an RSA key, using RSASSA-PKCS1-v1_5 with
+// SHA-256. The key id of its signatures is the JWK thumbprint of the key's
+// public key, which is also the key id of the key in the ATC's OIDC key set.
+type Signer struct {
+	keyID string
+	key   *rsa.PrivateKey
+}
+
+func NewSigner(key *rsa.PrivateKey) (*Signer, error) {
+	thumbprint, err := (&jose.JSONWebKey{Key: &key.PublicKey}).Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("compute key id: %w", err)
+	}
+
+	return &Signer{
+		keyID: base64.RawURLEncoding.EncodeToString(thumbprint),
+		key:   key,
+	}, nil
+}
+
+// Sign returns an envelope holding the payload, signed over its DSSE
+// pre-authentication encoding.
+func (signer *Signer) Sign(payloadType string, payload []byte) (Envelope, error) {
+	digest := sha256.Sum256(preAuthEncoding(payloadType, payload))
+
+	sig, err := rsa.SignPKCS1v15(rand.Reader, signer.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	return Envelope{
+		PayloadType: payloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures: []Signature{
+			{
+				KeyID: signer.keyID,
+				Sig:   base64.StdEncoding.EncodeToString(sig),
+			},
+		},
+	}, nil
+}
+
+// PublicKey returns the key the signatures can be verified with.
+func (signer *Signer) PublicKey() *rsa.PublicKey {
+	return &signer.key.PublicKey
+}
+
+func preAuthEncoding(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
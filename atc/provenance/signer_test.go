@@ -0,0 +1,70 @@
+package provenance_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/concourse/concourse/atc/provenance"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/square/go-jose.v2"
+)
+
+var _ = Describe("Signer", func() {
+	var (
+		key    *rsa.PrivateKey
+		signer *provenance.Signer
+	)
+
+	BeforeEach(func() {
+		var err error
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ToNot(HaveOccurred())
+
+		signer, err = provenance.NewSigner(key)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("Sign", func() {
+		var (
+			envelope provenance.Envelope
+			payload  []byte
+		)
+
+		BeforeEach(func() {
+			payload = []byte(`{"some":"statement"}`)
+
+			var err error
+			envelope, err = signer.Sign(provenance.PayloadType, payload)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("wraps the payload", func() {
+			Expect(envelope.PayloadType).To(Equal(provenance.PayloadType))
+			Expect(envelope.Payload).To(Equal(base64.StdEncoding.EncodeToString(payload)))
+		})
+
+		It("identifies the key by its JWK thumbprint", func() {
+			thumbprint, err := (&jose.JSONWebKey{Key: &key.PublicKey}).Thumbprint(crypto.SHA256)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(envelope.Signatures).To(HaveLen(1))
+			Expect(envelope.Signatures[0].KeyID).To(Equal(base64.RawURLEncoding.EncodeToString(thumbprint)))
+		})
+
+		It("signs the pre-authentication encoding of the payload", func() {
+			sig, err := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+			Expect(err).ToNot(HaveOccurred())
+
+			pae := fmt.Sprintf("DSSEv1 %d %s %d %s", len(provenance.PayloadType), provenance.PayloadType, len(payload), payload)
+			digest := sha256.Sum256([]byte(pae))
+
+			err = rsa.VerifyPKCS1v15(signer.PublicKey(), crypto.SHA256, digest[:], sig)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
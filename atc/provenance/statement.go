@@ -0,0 +1,103 @@
+package provenance
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+)
+
+const (
+	// StatementType is the type of in-toto statement the provenance of a build
+	// is described by.
+	StatementType = "https://in-toto.io/Statement/v0.1"
+
+	// PredicateType is the type of the statement's predicate: SLSA
+	// provenance.
+	PredicateType = "https://slsa.dev/provenance/v0.2"
+
+	// BuildType identifies the format of the predicate's build config and
+	// invocation, for verifiers to interpret them.
+	BuildType = "https://concourse-ci.org/build/v1"
+)
+
+// Statement is an in-toto statement of the provenance of a build. Its
+// subjects are the versions the build put.
+type Statement struct {
+	Type          string    `json:"_type"`
+	Subject       []Subject `json:"subject"`
+	PredicateType string    `json:"predicateType"`
+	Predicate     Predicate `json:"predicate"`
+}
+
+type Subject struct {
+	Name   string    `json:"name"`
+	Digest DigestSet `json:"digest"`
+}
+
+// DigestSet maps digest algorithms to the hex-encoded digests of an artifact.
+type DigestSet map[string]string
+
+// Predicate is the SLSA provenance of a build.
+type Predicate struct {
+	Builder     Builder     `json:"builder"`
+	BuildType   string      `json:"buildType"`
+	Invocation  Invocation  `json:"invocation"`
+	BuildConfig BuildConfig `json:"buildConfig"`
+	Metadata    Metadata    `json:"metadata"`
+	Materials   []Material  `json:"materials"`
+}
+
+type Builder struct {
+	ID string `json:"id"`
+}
+
+type Invocation struct {
+	ConfigSource ConfigSource `json:"configSource"`
+	Environment  Environment  `json:"environment"`
+}
+
+// ConfigSource points to the pipeline and job the build was configured by.
+type ConfigSource struct {
+	URI        string `json:"uri,omitempty"`
+	EntryPoint string `json:"entryPoint,omitempty"`
+}
+
+type Environment struct {
+	Team         string           `json:"team"`
+	Pipeline     string           `json:"pipeline,omitempty"`
+	InstanceVars atc.InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	Job          string           `json:"job,omitempty"`
+	BuildID      int              `json:"build_id"`
+	BuildName    string           `json:"build_name"`
+}
+
+// BuildConfig lists the steps of the build's plan which ran.
+type BuildConfig struct {
+	Steps []Step `json:"steps"`
+}
+
+type Step struct {
+	ID   atc.PlanID `json:"id"`
+	Type string     `json:"type"`
+	Name string     `json:"name,omitempty"`
+}
+
+type Metadata struct {
+	BuildInvocationID string       `json:"buildInvocationId"`
+	BuildStartedOn    *time.Time   `json:"buildStartedOn,omitempty"`
+	BuildFinishedOn   *time.Time   `json:"buildFinishedOn,omitempty"`
+	Completeness      Completeness `json:"completeness"`
+	Reproducible      bool         `json:"reproducible"`
+}
+
+type Completeness struct {
+	Parameters  bool `json:"parameters"`
+	Environment bool `json:"environment"`
+	Materials   bool `json:"materials"`
+}
+
+// Material is a version the build got, or an image one of its steps ran in.
+type Material struct {
+	URI    string    `json:"uri"`
+	Digest DigestSet `json:"digest"`
+}
@@ -6,26 +6,32 @@ func (plan Plan) Public() *json.RawMessage {
 	var public struct {
 		ID PlanID `json:"id"`
 
-		InParallel     *json.RawMessage `json:"in_parallel,omitempty"`
-		Across         *json.RawMessage `json:"across,omitempty"`
-		Do             *json.RawMessage `json:"do,omitempty"`
-		Get            *json.RawMessage `json:"get,omitempty"`
-		Put            *json.RawMessage `json:"put,omitempty"`
-		Check          *json.RawMessage `json:"check,omitempty"`
-		Task           *json.RawMessage `json:"task,omitempty"`
-		SetPipeline    *json.RawMessage `json:"set_pipeline,omitempty"`
-		LoadVar        *json.RawMessage `json:"load_var,omitempty"`
-		OnAbort        *json.RawMessage `json:"on_abort,omitempty"`
-		OnError        *json.RawMessage `json:"on_error,omitempty"`
-		Ensure         *json.RawMessage `json:"ensure,omitempty"`
-		OnSuccess      *json.RawMessage `json:"on_success,omitempty"`
-		OnFailure      *json.RawMessage `json:"on_failure,omitempty"`
-		Try            *json.RawMessage `json:"try,omitempty"`
-		DependentGet   *json.RawMessage `json:"dependent_get,omitempty"`
-		Timeout        *json.RawMessage `json:"timeout,omitempty"`
-		Retry          *json.RawMessage `json:"retry,omitempty"`
-		ArtifactInput  *json.RawMessage `json:"artifact_input,omitempty"`
-		ArtifactOutput *json.RawMessage `json:"artifact_output,omitempty"`
+		InParallel       *json.RawMessage `json:"in_parallel,omitempty"`
+		Across           *json.RawMessage `json:"across,omitempty"`
+		Do               *json.RawMessage `json:"do,omitempty"`
+		Atomic           *json.RawMessage `json:"atomic,omitempty"`
+		Get              *json.RawMessage `json:"get,omitempty"`
+		Put              *json.RawMessage `json:"put,omitempty"`
+		Check            *json.RawMessage `json:"check,omitempty"`
+		Task             *json.RawMessage `json:"task,omitempty"`
+		SetPipeline      *json.RawMessage `json:"set_pipeline,omitempty"`
+		LoadVar          *json.RawMessage `json:"load_var,omitempty"`
+		Approval         *json.RawMessage `json:"approval,omitempty"`
+		PublishArtifact  *json.RawMessage `json:"publish_artifact,omitempty"`
+		ConsumeArtifact  *json.RawMessage `json:"consume_artifact,omitempty"`
+		LoadBuildOutputs *json.RawMessage `json:"load_build_outputs,omitempty"`
+		AssertGreen      *json.RawMessage `json:"assert_green,omitempty"`
+		OnAbort          *json.RawMessage `json:"on_abort,omitempty"`
+		OnError          *json.RawMessage `json:"on_error,omitempty"`
+		Ensure           *json.RawMessage `json:"ensure,omitempty"`
+		OnSuccess        *json.RawMessage `json:"on_success,omitempty"`
+		OnFailure        *json.RawMessage `json:"on_failure,omitempty"`
+		Try              *json.RawMessage `json:"try,omitempty"`
+		DependentGet     *json.RawMessage `json:"dependent_get,omitempty"`
+		Timeout          *json.RawMessage `json:"timeout,omitempty"`
+		Retry            *json.RawMessage `json:"retry,omitempty"`
+		ArtifactInput    *json.RawMessage `json:"artifact_input,omitempty"`
+		ArtifactOutput   *json.RawMessage `json:"artifact_output,omitempty"`
 	}
 
 	public.ID = plan.ID
@@ -42,6 +48,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Do = plan.Do.Public()
 	}
 
+	if plan.Atomic != nil {
+		public.Atomic = plan.Atomic.Public()
+	}
+
 	if plan.Get != nil {
 		public.Get = plan.Get.Public()
 	}
@@ -66,6 +76,26 @@ func (plan Plan) Public() *json.RawMessage {
 		public.LoadVar = plan.LoadVar.Public()
 	}
 
+	if plan.Approval != nil {
+		public.Approval = plan.Approval.Public()
+	}
+
+	if plan.PublishArtifact != nil {
+		public.PublishArtifact = plan.PublishArtifact.Public()
+	}
+
+	if plan.ConsumeArtifact != nil {
+		public.ConsumeArtifact = plan.ConsumeArtifact.Public()
+	}
+
+	if plan.LoadBuildOutputs != nil {
+		public.LoadBuildOutputs = plan.LoadBuildOutputs.Public()
+	}
+
+	if plan.AssertGreen != nil {
+		public.AssertGreen = plan.AssertGreen.Public()
+	}
+
 	if plan.OnAbort != nil {
 		public.OnAbort = plan.OnAbort.Public()
 	}
@@ -166,6 +196,20 @@ func (plan DoPlan) Public() *json.RawMessage {
 	return enc(public)
 }
 
+func (plan AtomicPlan) Public() *json.RawMessage {
+	steps := make([]*json.RawMessage, len(plan.Steps))
+
+	for i := 0; i < len(plan.Steps); i++ {
+		steps[i] = plan.Steps[i].Public()
+	}
+
+	return enc(struct {
+		Steps []*json.RawMessage `json:"steps"`
+	}{
+		Steps: steps,
+	})
+}
+
 func (plan EnsurePlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step *json.RawMessage `json:"step"`
@@ -294,6 +338,60 @@ func (plan LoadVarPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan ApprovalPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name    string `json:"name"`
+		Timeout string `json:"timeout,omitempty"`
+	}{
+		Name:    plan.Name,
+		Timeout: plan.Timeout,
+	})
+}
+
+func (plan PublishArtifactPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+	}{
+		Name: plan.Name,
+	})
+}
+
+func (plan ConsumeArtifactPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+		Team string `json:"team,omitempty"`
+	}{
+		Name: plan.Name,
+		Team: plan.Team,
+	})
+}
+
+func (plan LoadBuildOutputsPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name  string `json:"name"`
+		Job   string `json:"job"`
+		Build string `json:"build,omitempty"`
+	}{
+		Name:  plan.Name,
+		Job:   plan.Job,
+		Build: plan.Build,
+	})
+}
+
+func (plan AssertGreenPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Job          string       `json:"job"`
+		Pipeline     string       `json:"pipeline,omitempty"`
+		InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+		Team         string       `json:"team,omitempty"`
+	}{
+		Job:          plan.Job,
+		Pipeline:     plan.Pipeline,
+		InstanceVars: plan.InstanceVars,
+		Team:         plan.Team,
+	})
+}
+
 func (plan TimeoutPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step     *json.RawMessage `json:"step"`
@@ -313,10 +411,10 @@ func (plan TryPlan) Public() *json.RawMessage {
 }
 
 func (plan RetryPlan) Public() *json.RawMessage {
-	public := make([]*json.RawMessage, len(plan))
+	public := make([]*json.RawMessage, len(plan.Steps))
 
-	for i := 0; i < len(plan); i++ {
-		public[i] = plan[i].Public()
+	for i := 0; i < len(plan.Steps); i++ {
+		public[i] = plan.Steps[i].Public()
 	}
 
 	return enc(public)
@@ -235,33 +235,35 @@ var _ = Describe("Plan", func() {
 						{
 							ID: "24",
 							Retry: &atc.RetryPlan{
-								atc.Plan{
-									ID: "25",
-									Task: &atc.TaskPlan{
-										Name:       "name",
-										ConfigPath: "some/config/path.yml",
-										Config: &atc.TaskConfig{
-											Params: atc.TaskEnv{"some": "secret"},
+								Steps: []atc.Plan{
+									atc.Plan{
+										ID: "25",
+										Task: &atc.TaskPlan{
+											Name:       "name",
+											ConfigPath: "some/config/path.yml",
+											Config: &atc.TaskConfig{
+												Params: atc.TaskEnv{"some": "secret"},
+											},
 										},
 									},
-								},
-								atc.Plan{
-									ID: "26",
-									Task: &atc.TaskPlan{
-										Name:       "name",
-										ConfigPath: "some/config/path.yml",
-										Config: &atc.TaskConfig{
-											Params: atc.TaskEnv{"some": "secret"},
+									atc.Plan{
+										ID: "26",
+										Task: &atc.TaskPlan{
+											Name:       "name",
+											ConfigPath: "some/config/path.yml",
+											Config: &atc.TaskConfig{
+												Params: atc.TaskEnv{"some": "secret"},
+											},
 										},
 									},
-								},
-								atc.Plan{
-									ID: "27",
-									Task: &atc.TaskPlan{
-										Name:       "name",
-										ConfigPath: "some/config/path.yml",
-										Config: &atc.TaskConfig{
-											Params: atc.TaskEnv{"some": "secret"},
+									atc.Plan{
+										ID: "27",
+										Task: &atc.TaskPlan{
+											Name:       "name",
+											ConfigPath: "some/config/path.yml",
+											Config: &atc.TaskConfig{
+												Params: atc.TaskEnv{"some": "secret"},
+											},
 										},
 									},
 								},
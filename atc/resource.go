@@ -8,11 +8,14 @@ type Resource struct {
 	TeamName             string       `json:"team_name"`
 	Type                 string       `json:"type"`
 	LastChecked          int64        `json:"last_checked,omitempty"`
+	LastCheckContainer   string       `json:"last_check_container,omitempty"`
 	Icon                 string       `json:"icon,omitempty"`
 
 	PinnedVersion  Version `json:"pinned_version,omitempty"`
 	PinnedInConfig bool    `json:"pinned_in_config,omitempty"`
 	PinComment     string  `json:"pin_comment,omitempty"`
 
+	CheckPaused bool `json:"check_paused,omitempty"`
+
 	Build *BuildSummary `json:"build,omitempty"`
 }
@@ -36,7 +36,7 @@ func (rf resourceFactory) NewResource(source atc.Source, params atc.Params, vers
 type Resource interface {
 	Get(context.Context, runtime.ProcessSpec, runtime.Runner) (runtime.VersionResult, error)
 	Put(context.Context, runtime.ProcessSpec, runtime.Runner) (runtime.VersionResult, error)
-	Check(context.Context, runtime.ProcessSpec, runtime.Runner) ([]atc.Version, error)
+	Check(context.Context, runtime.ProcessSpec, runtime.Runner, VersionHandler) error
 	Signature() ([]byte, error)
 }
 
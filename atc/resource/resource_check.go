@@ -1,32 +1,167 @@
 package resource
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"unicode"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/runtime"
 )
 
+// A VersionHandler is given each version emitted by a check, in order, as
+// soon as it has been read. Returning an error stops the check, which then
+// returns the error.
+type VersionHandler func(atc.Version) error
+
+// Check runs the check script and hands the versions it emits to
+// handleVersion as they are read, rather than once the script has exited.
+//
+// The script emits either a JSON array of versions or, to let it flush
+// versions as it finds them, a stream of JSON version objects.
 func (resource *resource) Check(
 	ctx context.Context,
 	spec runtime.ProcessSpec,
-	runnable runtime.Runner) ([]atc.Version, error) {
-	var versions []atc.Version
-
+	runnable runtime.Runner,
+	handleVersion VersionHandler,
+) error {
 	input, err := resource.Signature()
 	if err != nil {
-		return versions, err
+		return err
 	}
 
-	err = runnable.RunScript(
-		ctx,
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stdout, output := io.Pipe()
+
+	decoded := make(chan error, 1)
+	go func() {
+		err := decodeVersions(stdout, handleVersion)
+		if err != nil {
+			// stop the script, but keep draining its output so that it doesn't
+			// block writing to it
+			cancel()
+			_, _ = io.Copy(ioutil.Discard, stdout)
+		}
+
+		decoded <- err
+	}()
+
+	runErr := runnable.RunScript(
+		runCtx,
 		spec.Path,
 		spec.Args,
 		input,
-		&versions,
+		output,
 		spec.StderrWriter,
 		false,
 	)
 
-	return versions, err
+	_ = output.Close()
+
+	decodeErr := <-decoded
+
+	// the script being cancelled because its output couldn't be handled is
+	// reported as the reason it was cancelled
+	if runErr != nil && (ctx.Err() != nil || !errors.Is(runErr, context.Canceled)) {
+		return runErr
+	}
+
+	if decodeErr != nil {
+		return decodeErr
+	}
+
+	return runErr
+}
+
+func decodeVersions(r io.Reader, handleVersion VersionHandler) error {
+	buf := bufio.NewReader(r)
+
+	array, err := startsWithArray(buf)
+	if err != nil {
+		return parseError(err)
+	}
+
+	decoder := json.NewDecoder(buf)
+
+	if !array {
+		for {
+			var version atc.Version
+			err := decoder.Decode(&version)
+			if err == io.EOF {
+				return nil
+			}
+
+			if err != nil {
+				return parseError(err)
+			}
+
+			err = handleVersion(version)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// consume the opening bracket
+	_, err = decoder.Token()
+	if err != nil {
+		return parseError(err)
+	}
+
+	for decoder.More() {
+		var version atc.Version
+		err := decoder.Decode(&version)
+		if err != nil {
+			return parseError(err)
+		}
+
+		err = handleVersion(version)
+		if err != nil {
+			return err
+		}
+	}
+
+	// consume the closing bracket
+	_, err = decoder.Token()
+	if err != nil {
+		return parseError(err)
+	}
+
+	return nil
+}
+
+// startsWithArray reports whether the first non-space character read from buf
+// opens a JSON array, without consuming it.
+func startsWithArray(buf *bufio.Reader) (bool, error) {
+	for {
+		r, _, err := buf.ReadRune()
+		if err == io.EOF {
+			return false, io.ErrUnexpectedEOF
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		if unicode.IsSpace(r) {
+			continue
+		}
+
+		return r == '[', buf.UnreadRune()
+	}
+}
+
+func parseError(err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return fmt.Errorf("%s\n\nwhen parsing resource response", err)
 }
@@ -24,6 +24,7 @@ var _ = Describe("Resource Check", func() {
 		fakeStdout, fakeStderr io.Writer
 
 		checkVersions []atc.Version
+		handleErr     error
 
 		source  atc.Source
 		params  atc.Params
@@ -36,6 +37,8 @@ var _ = Describe("Resource Check", func() {
 
 	BeforeEach(func() {
 		ctx = context.Background()
+		fakeRunnable = runtimefakes.FakeRunner{}
+		handleErr = nil
 
 		source = atc.Source{"some": "source"}
 		version = atc.Version{"some": "version"}
@@ -54,33 +57,110 @@ var _ = Describe("Resource Check", func() {
 	})
 
 	JustBeforeEach(func() {
-		checkVersions, checkErr = resource.Check(ctx, someProcessSpec, &fakeRunnable)
+		checkVersions = nil
+		checkErr = resource.Check(ctx, someProcessSpec, &fakeRunnable, func(version atc.Version) error {
+			if handleErr != nil {
+				return handleErr
+			}
+
+			checkVersions = append(checkVersions, version)
+			return nil
+		})
 	})
 
+	emits := func(response string) func(context.Context, string, []string, []byte, interface{}, io.Writer, bool) error {
+		return func(_ context.Context, _ string, _ []string, _ []byte, output interface{}, _ io.Writer, _ bool) error {
+			_, err := io.WriteString(output.(io.Writer), response)
+			return err
+		}
+	}
+
 	Context("when Runnable -> RunScript succeeds", func() {
 		BeforeEach(func() {
-			fakeRunnable.RunScriptReturns(nil)
+			fakeRunnable.RunScriptStub = emits(`[{"ref":"v1"},{"ref":"v2"}]`)
 		})
 
 		It("Invokes Runnable -> RunScript with the correct arguments", func() {
-			actualCtx, actualSpecPath, actualArgs,
-				actualInput, actualVersionResultRef, actualSpecStdErrWriter,
+			_, actualSpecPath, actualArgs,
+				actualInput, actualOutput, actualSpecStdErrWriter,
 				actualRecoverableBool := fakeRunnable.RunScriptArgsForCall(0)
 
 			signature, err := resource.Signature()
 			Expect(err).ToNot(HaveOccurred())
 
-			Expect(actualCtx).To(Equal(ctx))
 			Expect(actualSpecPath).To(Equal(someProcessSpec.Path))
 			Expect(actualArgs).To(Equal(someProcessSpec.Args))
 			Expect(actualInput).To(Equal(signature))
-			Expect(actualVersionResultRef).To(Equal(&checkVersions))
+			Expect(actualOutput).To(BeAssignableToTypeOf(&io.PipeWriter{}))
 			Expect(actualSpecStdErrWriter).To(Equal(fakeStderr))
 			Expect(actualRecoverableBool).To(BeFalse())
 		})
 
-		It("doesnt return an error", func() {
+		It("hands over each version, in order", func() {
+			Expect(checkErr).To(BeNil())
+			Expect(checkVersions).To(Equal([]atc.Version{
+				{"ref": "v1"},
+				{"ref": "v2"},
+			}))
+		})
+
+		Context("when handling a version fails", func() {
+			BeforeEach(func() {
+				handleErr = errors.New("nope")
+			})
+
+			It("stops the script and returns the error", func() {
+				Expect(checkErr).To(Equal(handleErr))
+
+				runCtx, _, _, _, _, _, _ := fakeRunnable.RunScriptArgsForCall(0)
+				Expect(runCtx.Err()).To(Equal(context.Canceled))
+			})
+		})
+	})
+
+	Context("when the script streams version objects", func() {
+		BeforeEach(func() {
+			fakeRunnable.RunScriptStub = emits("{\"ref\":\"v1\"}\n{\"ref\":\"v2\"}\n")
+		})
+
+		It("hands over each version, in order", func() {
+			Expect(checkErr).To(BeNil())
+			Expect(checkVersions).To(Equal([]atc.Version{
+				{"ref": "v1"},
+				{"ref": "v2"},
+			}))
+		})
+	})
+
+	Context("when the script emits an empty array", func() {
+		BeforeEach(func() {
+			fakeRunnable.RunScriptStub = emits(" [ ]")
+		})
+
+		It("succeeds without any version", func() {
 			Expect(checkErr).To(BeNil())
+			Expect(checkVersions).To(BeEmpty())
+		})
+	})
+
+	Context("when the script emits nothing", func() {
+		BeforeEach(func() {
+			fakeRunnable.RunScriptReturns(nil)
+		})
+
+		It("returns an error", func() {
+			Expect(checkErr).To(MatchError(ContainSubstring("when parsing resource response")))
+		})
+	})
+
+	Context("when the script emits a malformed response", func() {
+		BeforeEach(func() {
+			fakeRunnable.RunScriptStub = emits(`[{"ref":"v1"},"bogus"]`)
+		})
+
+		It("returns an error, having handed over the versions before it", func() {
+			Expect(checkErr).To(MatchError(ContainSubstring("when parsing resource response")))
+			Expect(checkVersions).To(Equal([]atc.Version{{"ref": "v1"}}))
 		})
 	})
 
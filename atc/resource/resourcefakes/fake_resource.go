@@ -5,26 +5,24 @@ import (
 	"context"
 	"sync"
 
-	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/runtime"
 )
 
 type FakeResource struct {
-	CheckStub        func(context.Context, runtime.ProcessSpec, runtime.Runner) ([]atc.Version, error)
+	CheckStub        func(context.Context, runtime.ProcessSpec, runtime.Runner, resource.VersionHandler) error
 	checkMutex       sync.RWMutex
 	checkArgsForCall []struct {
 		arg1 context.Context
 		arg2 runtime.ProcessSpec
 		arg3 runtime.Runner
+		arg4 resource.VersionHandler
 	}
 	checkReturns struct {
-		result1 []atc.Version
-		result2 error
+		result1 error
 	}
 	checkReturnsOnCall map[int]struct {
-		result1 []atc.Version
-		result2 error
+		result1 error
 	}
 	GetStub        func(context.Context, runtime.ProcessSpec, runtime.Runner) (runtime.VersionResult, error)
 	getMutex       sync.RWMutex
@@ -72,25 +70,26 @@ type FakeResource struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeResource) Check(arg1 context.Context, arg2 runtime.ProcessSpec, arg3 runtime.Runner) ([]atc.Version, error) {
+func (fake *FakeResource) Check(arg1 context.Context, arg2 runtime.ProcessSpec, arg3 runtime.Runner, arg4 resource.VersionHandler) error {
 	fake.checkMutex.Lock()
 	ret, specificReturn := fake.checkReturnsOnCall[len(fake.checkArgsForCall)]
 	fake.checkArgsForCall = append(fake.checkArgsForCall, struct {
 		arg1 context.Context
 		arg2 runtime.ProcessSpec
 		arg3 runtime.Runner
-	}{arg1, arg2, arg3})
+		arg4 resource.VersionHandler
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.CheckStub
 	fakeReturns := fake.checkReturns
-	fake.recordInvocation("Check", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("Check", []interface{}{arg1, arg2, arg3, arg4})
 	fake.checkMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fakeReturns.result1, fakeReturns.result2
+	return fakeReturns.result1
 }
 
 func (fake *FakeResource) CheckCallCount() int {
@@ -99,43 +98,40 @@ func (fake *FakeResource) CheckCallCount() int {
 	return len(fake.checkArgsForCall)
 }
 
-func (fake *FakeResource) CheckCalls(stub func(context.Context, runtime.ProcessSpec, runtime.Runner) ([]atc.Version, error)) {
+func (fake *FakeResource) CheckCalls(stub func(context.Context, runtime.ProcessSpec, runtime.Runner, resource.VersionHandler) error) {
 	fake.checkMutex.Lock()
 	defer fake.checkMutex.Unlock()
 	fake.CheckStub = stub
 }
 
-func (fake *FakeResource) CheckArgsForCall(i int) (context.Context, runtime.ProcessSpec, runtime.Runner) {
+func (fake *FakeResource) CheckArgsForCall(i int) (context.Context, runtime.ProcessSpec, runtime.Runner, resource.VersionHandler) {
 	fake.checkMutex.RLock()
 	defer fake.checkMutex.RUnlock()
 	argsForCall := fake.checkArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
-func (fake *FakeResource) CheckReturns(result1 []atc.Version, result2 error) {
+func (fake *FakeResource) CheckReturns(result1 error) {
 	fake.checkMutex.Lock()
 	defer fake.checkMutex.Unlock()
 	fake.CheckStub = nil
 	fake.checkReturns = struct {
-		result1 []atc.Version
-		result2 error
-	}{result1, result2}
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeResource) CheckReturnsOnCall(i int, result1 []atc.Version, result2 error) {
+func (fake *FakeResource) CheckReturnsOnCall(i int, result1 error) {
 	fake.checkMutex.Lock()
 	defer fake.checkMutex.Unlock()
 	fake.CheckStub = nil
 	if fake.checkReturnsOnCall == nil {
 		fake.checkReturnsOnCall = make(map[int]struct {
-			result1 []atc.Version
-			result2 error
+			result1 error
 		})
 	}
 	fake.checkReturnsOnCall[i] = struct {
-		result1 []atc.Version
-		result2 error
-	}{result1, result2}
+		result1 error
+	}{result1}
 }
 
 func (fake *FakeResource) Get(arg1 context.Context, arg2 runtime.ProcessSpec, arg3 runtime.Runner) (runtime.VersionResult, error) {
@@ -0,0 +1,58 @@
+package atc
+
+// Reasons a resource has no next check scheduled.
+const (
+	CheckUnscheduledNever    = "never"
+	CheckUnscheduledOnDemand = "on_demand"
+	CheckUnscheduledPaused   = "paused"
+)
+
+type ResourceCheckStatus struct {
+	Name string `json:"name"`
+
+	LastChecked         int64 `json:"last_checked,omitempty"`
+	Succeeded           bool  `json:"succeeded"`
+	LastSucceeded       int64 `json:"last_succeeded,omitempty"`
+	ConsecutiveFailures int   `json:"consecutive_failures"`
+
+	// When the resource is next due to be checked, unless Unscheduled says why
+	// it is not checked periodically.
+	NextCheck   int64  `json:"next_check,omitempty"`
+	Unscheduled string `json:"unscheduled,omitempty"`
+
+	// The last check run on schedule rather than triggered by a webhook, fly
+	// or on demand, which is what the check TTL counts from.
+	CheckTTL                    string `json:"check_ttl,omitempty"`
+	LastScheduledCheck          int64  `json:"last_scheduled_check,omitempty"`
+	LastScheduledCheckSucceeded bool   `json:"last_scheduled_check_succeeded,omitempty"`
+}
+
+// States of a pending check.
+const (
+	PendingCheckQueued  = "queued"
+	PendingCheckRunning = "running"
+)
+
+// PendingCheck is a check of a resource or resource type which has yet to
+// finish, either because it is running or because it is queued behind the
+// rate limit or another check of the same scope.
+type PendingCheck struct {
+	BuildID          int    `json:"build_id"`
+	ResourceName     string `json:"resource_name,omitempty"`
+	ResourceTypeName string `json:"resource_type_name,omitempty"`
+	Status           string `json:"status"`
+
+	CreateTime        int64 `json:"create_time"`
+	ManuallyTriggered bool  `json:"manually_triggered,omitempty"`
+}
+
+// CheckRecord is a past or running check of the version history of a
+// resource, i.e. of its resource config scope.
+type CheckRecord struct {
+	ID            int         `json:"id"`
+	Status        BuildStatus `json:"status"`
+	StartTime     int64       `json:"start_time"`
+	EndTime       int64       `json:"end_time,omitempty"`
+	Error         string      `json:"error,omitempty"`
+	VersionsAdded int         `json:"versions_added"`
+}
@@ -3,3 +3,7 @@ package atc
 type SetPinCommentRequestBody struct {
 	PinComment string `json:"pin_comment"`
 }
+
+type PinVersionRequestBody struct {
+	PinComment string `json:"pin_comment,omitempty"`
+}
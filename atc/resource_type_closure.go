@@ -0,0 +1,31 @@
+package atc
+
+// ResourceTypeClosure is every resource type a pipeline's resources are built
+// on, along with the cycles found among its custom types.
+type ResourceTypeClosure struct {
+	Types  []ResourceTypeDependency `json:"types"`
+	Cycles [][]string               `json:"cycles,omitempty"`
+}
+
+// ResourceTypeDependency is a custom or base resource type in a pipeline's
+// type chains.
+type ResourceTypeDependency struct {
+	Name string `json:"name"`
+	Base bool   `json:"base,omitempty"`
+
+	// Type and Source are the type a custom type is built on and the source
+	// its image is fetched from.
+	Type   string `json:"type,omitempty"`
+	Source Source `json:"source,omitempty"`
+
+	// Version is the version of a custom type's image, if the pipeline or
+	// any other has already checked the type with the same source.
+	Version Version `json:"version,omitempty"`
+
+	// VersionUnavailable is why a custom type has no version.
+	VersionUnavailable string `json:"version_unavailable,omitempty"`
+
+	// WorkerVersions are the versions of a base type which the team's workers
+	// provide.
+	WorkerVersions []string `json:"worker_versions,omitempty"`
+}
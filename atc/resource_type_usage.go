@@ -0,0 +1,17 @@
+package atc
+
+// ResourceTypeUsage is a resource or resource type of a pipeline referencing
+// a resource type, either as its type or, for custom resource types, through
+// the image the type is defined with.
+type ResourceTypeUsage struct {
+	TeamName             string       `json:"team_name"`
+	PipelineID           int          `json:"pipeline_id"`
+	PipelineName         string       `json:"pipeline_name"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+
+	ResourceName     string `json:"resource_name,omitempty"`
+	ResourceTypeName string `json:"resource_type_name,omitempty"`
+
+	Type  string `json:"type"`
+	Image string `json:"image,omitempty"`
+}
@@ -0,0 +1,14 @@
+package atc
+
+// BackfillVersionsResponse is returned after saving historical versions of a
+// resource with the BackfillResourceVersions endpoint.
+type BackfillVersionsResponse struct {
+	NewVersions int `json:"new_versions"`
+}
+
+// ExpireResourceCachesResponse is returned after expiring the caches of a
+// resource version with the ExpireResourceVersionCaches endpoint.
+type ExpireResourceCachesResponse struct {
+	ResourceCaches int `json:"resource_caches"`
+	Volumes        int `json:"volumes"`
+}
@@ -0,0 +1,12 @@
+package atc
+
+// ResourceVersionToggle is a version of a resource having been enabled or
+// disabled, along with who did so and why.
+type ResourceVersionToggle struct {
+	ID      int     `json:"id"`
+	Version Version `json:"version"`
+	Enabled bool    `json:"enabled"`
+	Actor   string  `json:"actor"`
+	Reason  string  `json:"reason,omitempty"`
+	Time    int64   `json:"time"`
+}
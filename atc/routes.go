@@ -3,8 +3,11 @@ package atc
 import "github.com/tedsuo/rata"
 
 const (
-	SaveConfig = "SaveConfig"
-	GetConfig  = "GetConfig"
+	SaveConfig  = "SaveConfig"
+	GetConfig   = "GetConfig"
+	PatchConfig = "PatchConfig"
+
+	GetResourceTypeClosure = "GetResourceTypeClosure"
 
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
@@ -13,7 +16,14 @@ const (
 	BuildEvents         = "BuildEvents"
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
+	ProtectBuild        = "ProtectBuild"
+	UnprotectBuild      = "UnprotectBuild"
 	GetBuildPreparation = "GetBuildPreparation"
+	GetBuildServerLogs  = "GetBuildServerLogs"
+	ListBuildApprovals  = "ListBuildApprovals"
+	DecideBuildApproval = "DecideBuildApproval"
+	ListBuildVolumes    = "ListBuildVolumes"
+	GetBuildProvenance  = "GetBuildProvenance"
 
 	GetJob         = "GetJob"
 	CreateJobBuild = "CreateJobBuild"
@@ -30,23 +40,38 @@ const (
 	JobBadge       = "JobBadge"
 	MainJobBadge   = "MainJobBadge"
 
+	GetJobSchedulerDecision = "GetJobSchedulerDecision"
+
 	ClearTaskCache = "ClearTaskCache"
 
-	ListAllResources     = "ListAllResources"
-	ListResources        = "ListResources"
-	ListResourceTypes    = "ListResourceTypes"
-	GetResource          = "GetResource"
-	CheckResource        = "CheckResource"
-	CheckResourceWebHook = "CheckResourceWebHook"
-	CheckResourceType    = "CheckResourceType"
+	ListAllResources         = "ListAllResources"
+	ListResources            = "ListResources"
+	ListResourceChecks       = "ListResourceChecks"
+	ListResourceCheckRecords = "ListResourceCheckRecords"
+	ListPendingChecks        = "ListPendingChecks"
+	CancelCheck              = "CancelCheck"
+	ListResourceTypes        = "ListResourceTypes"
+	GetResource              = "GetResource"
+	CheckResource            = "CheckResource"
+	CheckResourceWebHook     = "CheckResourceWebHook"
+	CheckResourceType        = "CheckResourceType"
+
+	ListResourceTypeUsages = "ListResourceTypeUsages"
 
 	ListResourceVersions          = "ListResourceVersions"
 	GetResourceVersion            = "GetResourceVersion"
 	EnableResourceVersion         = "EnableResourceVersion"
 	DisableResourceVersion        = "DisableResourceVersion"
+	PromoteResourceVersion        = "PromoteResourceVersion"
+	UnpromoteResourceVersion      = "UnpromoteResourceVersion"
 	PinResourceVersion            = "PinResourceVersion"
+	BackfillResourceVersions      = "BackfillResourceVersions"
+	ExpireResourceVersionCaches   = "ExpireResourceVersionCaches"
+	ListResourceVersionToggles    = "ListResourceVersionToggles"
 	UnpinResource                 = "UnpinResource"
 	SetPinCommentOnResource       = "SetPinCommentOnResource"
+	PauseResourceChecking         = "PauseResourceChecking"
+	UnpauseResourceChecking       = "UnpauseResourceChecking"
 	ListBuildsWithVersionAsInput  = "ListBuildsWithVersionAsInput"
 	ListBuildsWithVersionAsOutput = "ListBuildsWithVersionAsOutput"
 	GetResourceCausality          = "GetResourceCausality"
@@ -69,14 +94,28 @@ const (
 	CreatePipelineBuild       = "CreatePipelineBuild"
 	PipelineBadge             = "PipelineBadge"
 
+	ListSerialGroups = "ListSerialGroups"
+
+	ListVersionSets   = "ListVersionSets"
+	SaveVersionSet    = "SaveVersionSet"
+	ApplyVersionSet   = "ApplyVersionSet"
+	ClearVersionSet   = "ClearVersionSet"
+	DestroyVersionSet = "DestroyVersionSet"
+
 	RegisterWorker  = "RegisterWorker"
 	LandWorker      = "LandWorker"
 	RetireWorker    = "RetireWorker"
 	PruneWorker     = "PruneWorker"
 	HeartbeatWorker = "HeartbeatWorker"
+	WarmWorker      = "WarmWorker"
 	ListWorkers     = "ListWorkers"
+	ListWorkerUsage = "ListWorkerUsage"
 	DeleteWorker    = "DeleteWorker"
 
+	PruneStalledWorkers = "PruneStalledWorkers"
+
+	ReportWorkerDiskUsage = "ReportWorkerDiskUsage"
+
 	SetLogLevel = "SetLogLevel"
 	GetLogLevel = "GetLogLevel"
 
@@ -94,12 +133,26 @@ const (
 	ListDestroyingVolumes = "ListDestroyingVolumes"
 	ReportWorkerVolumes   = "ReportWorkerVolumes"
 
+	ReportWorkerVolumeSizes = "ReportWorkerVolumeSizes"
+
 	ListTeams      = "ListTeams"
 	GetTeam        = "GetTeam"
 	SetTeam        = "SetTeam"
 	RenameTeam     = "RenameTeam"
 	DestroyTeam    = "DestroyTeam"
 	ListTeamBuilds = "ListTeamBuilds"
+	ListTeamUsage  = "ListTeamUsage"
+
+	ListSharedArtifacts  = "ListSharedArtifacts"
+	GrantSharedArtifact  = "GrantSharedArtifact"
+	RevokeSharedArtifact = "RevokeSharedArtifact"
+
+	ListPipelineVars  = "ListPipelineVars"
+	SetPipelineVar    = "SetPipelineVar"
+	DeletePipelineVar = "DeletePipelineVar"
+
+	GetTeamResourceTypes = "GetTeamResourceTypes"
+	SetTeamResourceTypes = "SetTeamResourceTypes"
 
 	CreateArtifact     = "CreateArtifact"
 	GetArtifact        = "GetArtifact"
@@ -111,6 +164,9 @@ const (
 	SetWall   = "SetWall"
 	GetWall   = "GetWall"
 	ClearWall = "ClearWall"
+
+	RotateEncryptionKey      = "RotateEncryptionKey"
+	GetEncryptionKeyRotation = "GetEncryptionKeyRotation"
 )
 
 const (
@@ -121,6 +177,8 @@ const (
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PATCH", Name: PatchConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resource-type-closure", Method: "POST", Name: GetResourceTypeClosure},
 
 	{Path: "/api/v1/teams/:team_name/builds", Method: "POST", Name: CreateBuild},
 
@@ -129,9 +187,17 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/plan", Method: "GET", Name: GetBuildPlan},
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
+	{Path: "/api/v1/builds/:build_id/provenance", Method: "GET", Name: GetBuildProvenance},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
+	{Path: "/api/v1/builds/:build_id/protect", Method: "PUT", Name: ProtectBuild},
+	{Path: "/api/v1/builds/:build_id/unprotect", Method: "PUT", Name: UnprotectBuild},
+	{Path: "/api/v1/checks/:build_id/cancel", Method: "PUT", Name: CancelCheck},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
 	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
+	{Path: "/api/v1/builds/:build_id/server-logs", Method: "GET", Name: GetBuildServerLogs},
+	{Path: "/api/v1/builds/:build_id/approvals", Method: "GET", Name: ListBuildApprovals},
+	{Path: "/api/v1/builds/:build_id/approvals/:plan_id", Method: "PUT", Name: DecideBuildApproval},
+	{Path: "/api/v1/builds/:build_id/volumes", Method: "GET", Name: ListBuildVolumes},
 
 	{Path: "/api/v1/jobs", Method: "GET", Name: ListAllJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
@@ -144,6 +210,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/schedule", Method: "PUT", Name: ScheduleJob},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/scheduler-decision", Method: "GET", Name: GetJobSchedulerDecision},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: JobBadge},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: MainJobBadge},
 
@@ -166,21 +233,40 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "POST", Name: CreatePipelineBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/badge", Method: "GET", Name: PipelineBadge},
 
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/serial-groups", Method: "GET", Name: ListSerialGroups},
+
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/version-sets", Method: "GET", Name: ListVersionSets},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/version-sets/:version_set_name", Method: "PUT", Name: SaveVersionSet},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/version-sets/:version_set_name", Method: "DELETE", Name: DestroyVersionSet},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/version-sets/:version_set_name/apply", Method: "PUT", Name: ApplyVersionSet},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/version-sets/:version_set_name/clear", Method: "PUT", Name: ClearVersionSet},
+
 	{Path: "/api/v1/resources", Method: "GET", Name: ListAllResources},
+	{Path: "/api/v1/resource-type-usages", Method: "GET", Name: ListResourceTypeUsages},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources", Method: "GET", Name: ListResources},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resource-checks", Method: "GET", Name: ListResourceChecks},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/pending-checks", Method: "GET", Name: ListPendingChecks},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types", Method: "GET", Name: ListResourceTypes},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name", Method: "GET", Name: GetResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/checks", Method: "GET", Name: ListResourceCheckRecords},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebHook},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types/:resource_type_name/check", Method: "POST", Name: CheckResourceType},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "POST", Name: BackfillResourceVersions},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id", Method: "GET", Name: GetResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/version-toggles", Method: "GET", Name: ListResourceVersionToggles},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/promote", Method: "PUT", Name: PromoteResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/unpromote", Method: "PUT", Name: UnpromoteResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/pin", Method: "PUT", Name: PinResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/caches", Method: "DELETE", Name: ExpireResourceVersionCaches},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", Method: "PUT", Name: UnpinResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pin_comment", Method: "PUT", Name: SetPinCommentOnResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pause_checking", Method: "PUT", Name: PauseResourceChecking},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpause_checking", Method: "PUT", Name: UnpauseResourceChecking},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/input_to", Method: "GET", Name: ListBuildsWithVersionAsInput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/output_of", Method: "GET", Name: ListBuildsWithVersionAsOutput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/causality", Method: "GET", Name: GetResourceCausality},
@@ -188,11 +274,15 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/cc.xml", Method: "GET", Name: GetCC},
 
 	{Path: "/api/v1/workers", Method: "GET", Name: ListWorkers},
+	{Path: "/api/v1/workers/usage", Method: "GET", Name: ListWorkerUsage},
 	{Path: "/api/v1/workers", Method: "POST", Name: RegisterWorker},
+	{Path: "/api/v1/workers/prune-stalled", Method: "PUT", Name: PruneStalledWorkers},
 	{Path: "/api/v1/workers/:worker_name/land", Method: "PUT", Name: LandWorker},
 	{Path: "/api/v1/workers/:worker_name/retire", Method: "PUT", Name: RetireWorker},
 	{Path: "/api/v1/workers/:worker_name/prune", Method: "PUT", Name: PruneWorker},
 	{Path: "/api/v1/workers/:worker_name/heartbeat", Method: "PUT", Name: HeartbeatWorker},
+	{Path: "/api/v1/workers/:worker_name/warm", Method: "PUT", Name: WarmWorker},
+	{Path: "/api/v1/workers/:worker_name/disk-usage", Method: "PUT", Name: ReportWorkerDiskUsage},
 	{Path: "/api/v1/workers/:worker_name", Method: "DELETE", Name: DeleteWorker},
 
 	{Path: "/api/v1/log-level", Method: "GET", Name: GetLogLevel},
@@ -214,6 +304,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/volumes", Method: "GET", Name: ListVolumes},
 	{Path: "/api/v1/volumes/destroying", Method: "GET", Name: ListDestroyingVolumes},
 	{Path: "/api/v1/volumes/report", Method: "PUT", Name: ReportWorkerVolumes},
+	{Path: "/api/v1/volumes/sizes", Method: "PUT", Name: ReportWorkerVolumeSizes},
 
 	{Path: "/api/v1/teams", Method: "GET", Name: ListTeams},
 	{Path: "/api/v1/teams/:team_name", Method: "GET", Name: GetTeam},
@@ -221,6 +312,18 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/rename", Method: "PUT", Name: RenameTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
 	{Path: "/api/v1/teams/:team_name/builds", Method: "GET", Name: ListTeamBuilds},
+	{Path: "/api/v1/usage/teams", Method: "GET", Name: ListTeamUsage},
+
+	{Path: "/api/v1/teams/:team_name/shared-artifacts", Method: "GET", Name: ListSharedArtifacts},
+	{Path: "/api/v1/teams/:team_name/shared-artifacts/:artifact_name/grants/:grantee_team_name", Method: "PUT", Name: GrantSharedArtifact},
+	{Path: "/api/v1/teams/:team_name/shared-artifacts/:artifact_name/grants/:grantee_team_name", Method: "DELETE", Name: RevokeSharedArtifact},
+
+	{Path: "/api/v1/teams/:team_name/pipeline-vars", Method: "GET", Name: ListPipelineVars},
+	{Path: "/api/v1/teams/:team_name/pipeline-vars/:var_name", Method: "PUT", Name: SetPipelineVar},
+	{Path: "/api/v1/teams/:team_name/pipeline-vars/:var_name", Method: "DELETE", Name: DeletePipelineVar},
+
+	{Path: "/api/v1/teams/:team_name/resource-types", Method: "GET", Name: GetTeamResourceTypes},
+	{Path: "/api/v1/teams/:team_name/resource-types", Method: "PUT", Name: SetTeamResourceTypes},
 
 	{Path: "/api/v1/teams/:team_name/artifacts", Method: "POST", Name: CreateArtifact},
 	{Path: "/api/v1/teams/:team_name/artifacts/:artifact_id", Method: "GET", Name: GetArtifact},
@@ -228,4 +331,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/wall", Method: "GET", Name: GetWall},
 	{Path: "/api/v1/wall", Method: "PUT", Name: SetWall},
 	{Path: "/api/v1/wall", Method: "DELETE", Name: ClearWall},
+
+	{Path: "/api/v1/encryption-key/rotation", Method: "PUT", Name: RotateEncryptionKey},
+	{Path: "/api/v1/encryption-key/rotation", Method: "GET", Name: GetEncryptionKeyRotation},
 })
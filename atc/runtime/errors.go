@@ -36,3 +36,18 @@ func (err ErrResourceScriptFailed) Error() string {
 
 	return msg
 }
+
+// ErrResourceScriptOOMKilled is returned instead of ErrResourceScriptFailed
+// when the script was killed because its container ran out of memory.
+type ErrResourceScriptOOMKilled struct {
+	Path string
+	Args []string
+}
+
+func (err ErrResourceScriptOOMKilled) Error() string {
+	return fmt.Sprintf(
+		"resource script '%s %v' was killed: container ran out of memory",
+		err.Path,
+		err.Args,
+	)
+}
@@ -12,8 +12,9 @@ import (
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 const (
-	ResourceResultPropertyName = "concourse:resource-result"
-	ResourceProcessID          = "resource"
+	ResourceResultPropertyName   = "concourse:resource-result"
+	ResourceUnpackedPropertyName = "concourse:resource-unpacked"
+	ResourceProcessID            = "resource"
 )
 
 //counterfeiter:generate . StartingEventDelegate
@@ -55,6 +56,10 @@ func (art CacheArtifact) ID() string {
 // TODO (Krishna/Sameer): get rid of these - can GetArtifact and TaskArtifact be merged ?
 type GetArtifact struct {
 	VolumeHandle string
+
+	// The resource cache the volume was fetched into, which identifies its
+	// contents regardless of the worker it is on.
+	ResourceCacheID int
 }
 
 func (art GetArtifact) ID() string {
@@ -70,6 +75,11 @@ func (art TaskArtifact) ID() string {
 }
 
 // TODO (runtime/#4910): consider a different name as this is close to "Runnable" in atc/engine/engine
+//
+// The script's stdout is parsed as JSON into output, unless the script isn't
+// recoverable and output is an io.Writer, in which case stdout is written to
+// it as it's produced.
+//
 //counterfeiter:generate . Runner
 type Runner interface {
 	RunScript(
@@ -90,4 +100,8 @@ type ProcessSpec struct {
 	User         string
 	StdoutWriter io.Writer
 	StderrWriter io.Writer
+
+	// Unpack is the format of the archives to extract from the fetched bits
+	// once a get has run, if any.
+	Unpack string
 }
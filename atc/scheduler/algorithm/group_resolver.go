@@ -38,6 +38,11 @@ type groupResolver struct {
 	doomedCandidates []*versionCandidate
 
 	lastUsedPassedBuilds map[int]db.BuildCursor
+
+	// promotedVersions holds the versions promoted for each resource with
+	// inputs configured with version: latest-stable, looked up once per
+	// resolve rather than for each output tried.
+	promotedVersions map[int]map[db.ResourceVersion]bool
 }
 
 func NewGroupResolver(vdb db.VersionsDB, inputConfigs db.InputConfigs) Resolver {
@@ -48,6 +53,7 @@ func NewGroupResolver(vdb db.VersionsDB, inputConfigs db.InputConfigs) Resolver
 		orderedJobs:      make([][]int, len(inputConfigs)),
 		candidates:       make([]*versionCandidate, len(inputConfigs)),
 		doomedCandidates: make([]*versionCandidate, len(inputConfigs)),
+		promotedVersions: map[int]map[db.ResourceVersion]bool{},
 	}
 }
 
@@ -452,6 +458,22 @@ func (r *groupResolver) outputIsRelatedAndMatches(ctx context.Context, span trac
 		return false, false, nil
 	}
 
+	if inputConfig.UseLatestStableVersion {
+		promoted, err := r.promoted(ctx, output.ResourceID)
+		if err != nil {
+			return false, false, err
+		}
+
+		if !promoted[output.Version] {
+			// only promoted versions can be used with version: latest-stable
+			span.AddEvent("version not promoted", trace.WithAttributes(
+				attribute.Int("resourceID", output.ResourceID),
+				attribute.String("version", string(output.Version)),
+			))
+			return false, false, nil
+		}
+	}
+
 	if inputConfig.PinnedVersion != nil && r.pins[candidateIdx] != output.Version {
 		// input is both pinned and assigned a 'passed' constraint, but the pinned
 		// version doesn't match the job's output version
@@ -468,6 +490,22 @@ func (r *groupResolver) outputIsRelatedAndMatches(ctx context.Context, span trac
 	return true, false, nil
 }
 
+func (r *groupResolver) promoted(ctx context.Context, resourceID int) (map[db.ResourceVersion]bool, error) {
+	promoted, found := r.promotedVersions[resourceID]
+	if found {
+		return promoted, nil
+	}
+
+	promoted, err := r.vdb.PromotedVersions(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.promotedVersions[resourceID] = promoted
+
+	return promoted, nil
+}
+
 func (r *groupResolver) vouchForCandidate(oldCandidate *versionCandidate, version db.ResourceVersion, passedJobID int, passedBuildID int, hasNext bool) *versionCandidate {
 	// create a new candidate with the new version
 	newCandidate := newCandidateVersion(version)
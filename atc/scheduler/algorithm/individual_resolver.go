@@ -3,6 +3,7 @@ package algorithm
 import (
 	"context"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/tracing"
 	"go.opentelemetry.io/otel/attribute"
@@ -26,8 +27,9 @@ func (r *individualResolver) InputConfigs() db.InputConfigs {
 	return db.InputConfigs{r.inputConfig}
 }
 
-// Handles two different configurations of a resource without passed
-// constraints: every and latest
+// Handles four different configurations of a resource without passed
+// constraints: every, latest, latest promoted, and latest satisfying a version
+// constraint
 func (r *individualResolver) Resolve(ctx context.Context) (map[string]*versionCandidate, db.ResolutionFailure, error) {
 	ctx, span := tracing.StartSpan(ctx, "individualResolver.Resolve", tracing.Attrs{
 		"input": r.inputConfig.Name,
@@ -54,6 +56,47 @@ func (r *individualResolver) Resolve(ctx context.Context) (map[string]*versionCa
 		span.AddEvent("found via every", trace.WithAttributes(
 			attribute.String("version", string(version)),
 		))
+	} else if r.inputConfig.UseLatestStableVersion {
+		var found bool
+		var err error
+		version, found, err = r.vdb.LatestPromotedVersionOfResource(ctx, r.inputConfig.ResourceID)
+		if err != nil {
+			tracing.End(span, err)
+			return nil, "", err
+		}
+
+		if !found {
+			span.AddEvent("latest stable version not found")
+			span.SetStatus(codes.Error, "latest stable version not found")
+			return nil, db.LatestStableVersionNotFound, nil
+		}
+
+		span.AddEvent("found via latest stable", trace.WithAttributes(
+			attribute.String("version", string(version)),
+		))
+	} else if r.inputConfig.VersionConstraint != "" {
+		constraint, err := atc.ParseVersionConstraint(r.inputConfig.VersionConstraint)
+		if err != nil {
+			tracing.End(span, err)
+			return nil, "", err
+		}
+
+		var found bool
+		version, found, err = r.vdb.LatestVersionOfResourceMatching(ctx, r.inputConfig.ResourceID, constraint)
+		if err != nil {
+			tracing.End(span, err)
+			return nil, "", err
+		}
+
+		if !found {
+			span.AddEvent("no version satisfies constraint")
+			span.SetStatus(codes.Error, "no version satisfies constraint")
+			return nil, db.NoSatisfiableVersion, nil
+		}
+
+		span.AddEvent("found via version constraint", trace.WithAttributes(
+			attribute.String("version", string(version)),
+		))
 	} else {
 		// there are no passed constraints, so just take the latest version
 		var err error
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
@@ -53,10 +54,36 @@ func (m *manualTriggerBuild) BuildInputs(ctx context.Context) ([]db.BuildInput,
 
 type schedulerBuild struct {
 	db.Build
+
+	job               db.Job
+	onDemandResources []string
 }
 
 func (s *schedulerBuild) IsReadyToDetermineInputs(logger lager.Logger) (bool, error) {
-	return true, nil
+	config, err := s.job.Config()
+	if err != nil {
+		return false, fmt.Errorf("config: %w", err)
+	}
+
+	if config.TriggerDebounce != "" {
+		debounce, err := time.ParseDuration(config.TriggerDebounce)
+		if err != nil {
+			return false, fmt.Errorf("parse trigger debounce: %w", err)
+		}
+
+		// triggers arriving within the window are coalesced into this pending
+		// build, which adopts the newest inputs once the window has passed
+		if remaining := debounce - time.Since(s.CreateTime()); remaining > 0 {
+			logger.Debug("waiting-for-trigger-debounce", lager.Data{"remaining": remaining.String()})
+			return false, nil
+		}
+	}
+
+	if len(s.onDemandResources) == 0 {
+		return true, nil
+	}
+
+	return s.OnDemandResourcesChecked(s.onDemandResources)
 }
 
 func (s *schedulerBuild) BuildInputs(ctx context.Context) ([]db.BuildInput, bool, error) {
@@ -16,12 +16,13 @@ type BuildStarter interface {
 		logger lager.Logger,
 		job db.SchedulerJob,
 		inputs db.InputConfigs,
+		decisions *Decisions,
 	) (bool, error)
 }
 
 //counterfeiter:generate . BuildPlanner
 type BuildPlanner interface {
-	Create(atc.StepConfig, db.SchedulerResources, atc.VersionedResourceTypes, []db.BuildInput) (atc.Plan, error)
+	Create(atc.StepConfig, db.SchedulerResources, atc.VersionedResourceTypes, *atc.TaskDefaults, []db.BuildInput) (atc.Plan, error)
 }
 
 type Build interface {
@@ -50,6 +51,7 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 	logger lager.Logger,
 	job db.SchedulerJob,
 	jobInputs db.InputConfigs,
+	decisions *Decisions,
 ) (bool, error) {
 	nextPendingBuilds, err := job.GetPendingBuilds()
 	if err != nil {
@@ -60,7 +62,7 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 
 	var needsRetry bool
 	for _, nextSchedulableBuild := range buildsToSchedule {
-		results, err := s.tryStartNextPendingBuild(logger, nextSchedulableBuild, job)
+		results, err := s.tryStartNextPendingBuild(logger, nextSchedulableBuild, job, decisions)
 		if err != nil {
 			return false, err
 		}
@@ -95,7 +97,7 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 	return needsRetry, nil
 }
 
-func (s *buildStarter) constructBuilds(job db.Job, jobInputs db.InputConfigs, builds []db.Build) []Build {
+func (s *buildStarter) constructBuilds(job db.SchedulerJob, jobInputs db.InputConfigs, builds []db.Build) []Build {
 	var buildsToSchedule []Build
 
 	for _, nextPendingBuild := range builds {
@@ -112,7 +114,9 @@ func (s *buildStarter) constructBuilds(job db.Job, jobInputs db.InputConfigs, bu
 			})
 		} else {
 			buildsToSchedule = append(buildsToSchedule, &schedulerBuild{
-				Build: nextPendingBuild,
+				Build:             nextPendingBuild,
+				job:               job,
+				onDemandResources: job.Resources.CheckedOnDemand(),
 			})
 		}
 	}
@@ -131,14 +135,24 @@ func (s *buildStarter) tryStartNextPendingBuild(
 	logger lager.Logger,
 	nextPendingBuild Build,
 	job db.SchedulerJob,
+	decisions *Decisions,
 ) (startResults, error) {
 	logger = logger.Session("try-start-next-pending-build", lager.Data{
 		"build-id":   nextPendingBuild.ID(),
 		"build-name": nextPendingBuild.Name(),
 	})
 
+	decide := func(kind atc.SchedulerDecisionKind, message string) {
+		decisions.Record(atc.SchedulerDecisionReason{
+			Kind:    kind,
+			Build:   nextPendingBuild.Name(),
+			Message: message,
+		})
+	}
+
 	if nextPendingBuild.IsAborted() {
 		logger.Debug("cancel-aborted-pending-build")
+		decide(atc.SchedulerBuildAborted, "the build was aborted before it started")
 
 		err := nextPendingBuild.Finish(db.BuildStatusAborted)
 		if err != nil {
@@ -157,6 +171,22 @@ func (s *buildStarter) tryStartNextPendingBuild(
 
 	if !scheduled {
 		logger.Debug("build-not-scheduled")
+
+		if job.Paused() {
+			decide(atc.SchedulerBuildNotScheduled, "the job is paused")
+		} else {
+			teamLimitReached, err := job.TeamMaxConcurrentBuildsReached(nextPendingBuild)
+			if err != nil {
+				return startResults{}, fmt.Errorf("team max concurrent builds reached: %w", err)
+			}
+
+			if teamLimitReached {
+				decide(atc.SchedulerBuildNotScheduled, "the max concurrent builds of the team is reached by its running builds and the builds queued ahead")
+			} else {
+				decide(atc.SchedulerBuildNotScheduled, "the max in flight of the job or of its serial groups is reached")
+			}
+		}
+
 		return startResults{
 			scheduled: scheduled,
 		}, nil
@@ -168,6 +198,7 @@ func (s *buildStarter) tryStartNextPendingBuild(
 	}
 
 	if !readyToDetermineInputs {
+		decide(atc.SchedulerBuildNotReady, "waiting for the build's resources to be checked or for its trigger debounce to pass")
 		return startResults{
 			scheduled:              scheduled,
 			readyToDetermineInputs: readyToDetermineInputs,
@@ -182,6 +213,12 @@ func (s *buildStarter) tryStartNextPendingBuild(
 	if !inputsDetermined {
 		logger.Debug("build-inputs-not-found")
 
+		if nextPendingBuild.RerunOf() != 0 {
+			decide(atc.SchedulerBuildInputsNotDetermined, "the versions of the inputs of the rerun build are not available")
+		} else {
+			decide(atc.SchedulerBuildInputsNotDetermined, "not every input has a version satisfying its constraints")
+		}
+
 		// don't retry when build inputs are not found because this is due to the
 		// inputs being unsatisfiable
 		return startResults{
@@ -196,9 +233,10 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return startResults{}, fmt.Errorf("config: %w", err)
 	}
 
-	plan, err := s.planner.Create(config.StepConfig(), job.Resources, job.ResourceTypes, buildInputs)
+	plan, err := s.planner.Create(config.StepConfig(), job.Resources, job.ResourceTypes, job.TaskDefaults, buildInputs)
 	if err != nil {
 		logger.Error("failed-to-create-build-plan", err)
+		decide(atc.SchedulerBuildErrored, fmt.Sprintf("failed to create the build plan: %s", err))
 
 		// Don't use ErrorBuild because it logs a build event, and this build hasn't started
 		if err = nextPendingBuild.Finish(db.BuildStatusErrored); err != nil {
@@ -218,6 +256,8 @@ func (s *buildStarter) tryStartNextPendingBuild(
 	}
 
 	if !started {
+		decide(atc.SchedulerBuildNotStarted, "the build was aborted while it was being started")
+
 		if err = nextPendingBuild.Finish(db.BuildStatusAborted); err != nil {
 			logger.Error("failed-to-mark-build-as-finished", err)
 			return startResults{}, fmt.Errorf("finish build: %w", err)
@@ -228,6 +268,8 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		}, nil
 	}
 
+	decide(atc.SchedulerBuildStarted, "the build was started")
+
 	// To avoid breaking existing dashboards, don't count check builds into BuildsStarted.
 	if nextPendingBuild.Name() == db.CheckBuildName {
 		metric.Metrics.CheckBuildsStarted.Inc()
@@ -3,6 +3,7 @@ package scheduler_test
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
@@ -46,8 +47,12 @@ var _ = Describe("BuildStarter", func() {
 		var job *dbfakes.FakeJob
 		var resources db.SchedulerResources
 		var versionedResourceTypes atc.VersionedResourceTypes
+		var taskDefaults *atc.TaskDefaults
+		var decisions *scheduler.Decisions
 
 		BeforeEach(func() {
+			decisions = &scheduler.Decisions{}
+
 			versionedResourceTypes = atc.VersionedResourceTypes{
 				{
 					ResourceType: atc.ResourceType{Name: "some-resource-type"},
@@ -60,6 +65,10 @@ var _ = Describe("BuildStarter", func() {
 					Name: "some-resource",
 				},
 			}
+
+			taskDefaults = &atc.TaskDefaults{
+				Limits: &atc.ContainerLimits{Memory: new(atc.MemoryLimit)},
+			}
 		})
 
 		Context("when pending builds are successfully fetched", func() {
@@ -119,8 +128,10 @@ var _ = Describe("BuildStarter", func() {
 							Job:           job,
 							Resources:     resources,
 							ResourceTypes: versionedResourceTypes,
+							TaskDefaults:  taskDefaults,
 						},
 						jobInputs,
+						decisions,
 					)
 				})
 
@@ -134,6 +145,13 @@ var _ = Describe("BuildStarter", func() {
 						Expect(abortedBuild.FinishCallCount()).To(Equal(1))
 					})
 
+					It("records that the build was aborted", func() {
+						Expect(decisions.Reasons()).To(ConsistOf(atc.SchedulerDecisionReason{
+							Kind:    atc.SchedulerBuildAborted,
+							Message: "the build was aborted before it started",
+						}))
+					})
+
 					It("returns without error", func() {
 						Expect(tryStartErr).NotTo(HaveOccurred())
 						Expect(needsReschedule).To(BeFalse())
@@ -185,6 +203,7 @@ var _ = Describe("BuildStarter", func() {
 							Resources: resources,
 						},
 						jobInputs,
+						decisions,
 					)
 				})
 
@@ -204,6 +223,61 @@ var _ = Describe("BuildStarter", func() {
 						Expect(tryStartErr).ToNot(HaveOccurred())
 						Expect(needsReschedule).To(BeTrue())
 					})
+
+					It("records that max in flight is reached", func() {
+						Expect(decisions.Reasons()).To(ConsistOf(atc.SchedulerDecisionReason{
+							Kind:    atc.SchedulerBuildNotScheduled,
+							Build:   "some-build",
+							Message: "the max in flight of the job or of its serial groups is reached",
+						}))
+					})
+
+					Context("when the job is paused", func() {
+						BeforeEach(func() {
+							job.PausedReturns(true)
+						})
+
+						It("records that the job is paused", func() {
+							Expect(decisions.Reasons()).To(ConsistOf(atc.SchedulerDecisionReason{
+								Kind:    atc.SchedulerBuildNotScheduled,
+								Build:   "some-build",
+								Message: "the job is paused",
+							}))
+						})
+					})
+
+					Context("when the max concurrent builds of the team is reached", func() {
+						BeforeEach(func() {
+							job.TeamMaxConcurrentBuildsReachedReturns(true, nil)
+						})
+
+						It("needs to be rescheduled", func() {
+							Expect(needsReschedule).To(BeTrue())
+						})
+
+						It("records that the team's limit is reached", func() {
+							Expect(decisions.Reasons()).To(ConsistOf(atc.SchedulerDecisionReason{
+								Kind:    atc.SchedulerBuildNotScheduled,
+								Build:   "some-build",
+								Message: "the max concurrent builds of the team is reached by its running builds and the builds queued ahead",
+							}))
+						})
+
+						It("checks the limit for the build", func() {
+							Expect(job.TeamMaxConcurrentBuildsReachedCallCount()).To(Equal(1))
+							Expect(job.TeamMaxConcurrentBuildsReachedArgsForCall(0).Name()).To(Equal(createdBuild.Name()))
+						})
+					})
+
+					Context("when checking the max concurrent builds of the team fails", func() {
+						BeforeEach(func() {
+							job.TeamMaxConcurrentBuildsReachedReturns(false, disaster)
+						})
+
+						It("returns the error", func() {
+							Expect(tryStartErr).To(MatchError(ContainSubstring(disaster.Error())))
+						})
+					})
 				})
 
 				Context("when scheduling the build fails", func() {
@@ -398,6 +472,14 @@ var _ = Describe("BuildStarter", func() {
 									Expect(tryStartErr).ToNot(HaveOccurred())
 									Expect(needsReschedule).To(BeFalse())
 								})
+
+								It("records that the inputs of the build are not determined", func() {
+									Expect(decisions.Reasons()).To(ConsistOf(atc.SchedulerDecisionReason{
+										Kind:    atc.SchedulerBuildInputsNotDetermined,
+										Build:   "some-build",
+										Message: "not every input has a version satisfying its constraints",
+									}))
+								})
 							})
 						})
 					})
@@ -450,8 +532,10 @@ var _ = Describe("BuildStarter", func() {
 									Version: atc.Version{"some": "version"},
 								},
 							},
+							TaskDefaults: taskDefaults,
 						},
 						jobInputs,
+						decisions,
 					)
 				})
 
@@ -538,6 +622,114 @@ var _ = Describe("BuildStarter", func() {
 						})
 					})
 
+					Context("when a normal scheduler build has inputs checked on demand", func() {
+						BeforeEach(func() {
+							resources = db.SchedulerResources{
+								{Name: "some-resource"},
+								{Name: "some-on-demand-resource", CheckOnDemand: true},
+							}
+
+							pendingBuild1 = new(dbfakes.FakeBuild)
+							pendingBuild1.IDReturns(99)
+							pendingBuild1.AdoptInputsAndPipesReturns([]db.BuildInput{{Name: "some-input"}}, true, nil)
+							job.GetPendingBuildsReturns([]db.Build{pendingBuild1}, nil)
+						})
+
+						It("checks whether the on demand resources have been checked", func() {
+							Expect(pendingBuild1.OnDemandResourcesCheckedCallCount()).To(Equal(1))
+							Expect(pendingBuild1.OnDemandResourcesCheckedArgsForCall(0)).To(Equal([]string{"some-on-demand-resource"}))
+						})
+
+						Context("when they have not been checked since the build was created", func() {
+							BeforeEach(func() {
+								pendingBuild1.OnDemandResourcesCheckedReturns(false, nil)
+							})
+
+							It("does not start the build and retries to schedule", func() {
+								Expect(pendingBuild1.AdoptInputsAndPipesCallCount()).To(BeZero())
+								Expect(pendingBuild1.StartCallCount()).To(BeZero())
+								Expect(tryStartErr).ToNot(HaveOccurred())
+								Expect(needsReschedule).To(BeTrue())
+							})
+						})
+
+						Context("when they have been checked since the build was created", func() {
+							BeforeEach(func() {
+								pendingBuild1.OnDemandResourcesCheckedReturns(true, nil)
+							})
+
+							It("starts the build", func() {
+								Expect(pendingBuild1.AdoptInputsAndPipesCallCount()).To(Equal(1))
+								Expect(pendingBuild1.StartCallCount()).To(Equal(1))
+								Expect(tryStartErr).ToNot(HaveOccurred())
+								Expect(needsReschedule).To(BeFalse())
+							})
+						})
+
+						Context("when checking whether they have been checked fails", func() {
+							BeforeEach(func() {
+								pendingBuild1.OnDemandResourcesCheckedReturns(false, disaster)
+							})
+
+							It("returns the error", func() {
+								Expect(tryStartErr).To(Equal(fmt.Errorf("ready to determine inputs: %w", disaster)))
+								Expect(needsReschedule).To(BeFalse())
+							})
+						})
+					})
+
+					Context("when the job has a trigger debounce", func() {
+						BeforeEach(func() {
+							debouncedConfig := jobConfig
+							debouncedConfig.TriggerDebounce = "30s"
+							job.ConfigReturns(debouncedConfig, nil)
+
+							pendingBuild1 = new(dbfakes.FakeBuild)
+							pendingBuild1.IDReturns(99)
+							pendingBuild1.AdoptInputsAndPipesReturns([]db.BuildInput{{Name: "some-input"}}, true, nil)
+							job.GetPendingBuildsReturns([]db.Build{pendingBuild1}, nil)
+						})
+
+						Context("when the build was created within the debounce window", func() {
+							BeforeEach(func() {
+								pendingBuild1.CreateTimeReturns(time.Now().Add(-10 * time.Second))
+							})
+
+							It("does not start the build and retries to schedule", func() {
+								Expect(pendingBuild1.AdoptInputsAndPipesCallCount()).To(BeZero())
+								Expect(pendingBuild1.StartCallCount()).To(BeZero())
+								Expect(tryStartErr).ToNot(HaveOccurred())
+								Expect(needsReschedule).To(BeTrue())
+							})
+						})
+
+						Context("when the debounce window has passed", func() {
+							BeforeEach(func() {
+								pendingBuild1.CreateTimeReturns(time.Now().Add(-time.Minute))
+							})
+
+							It("starts the build with the newest inputs", func() {
+								Expect(pendingBuild1.AdoptInputsAndPipesCallCount()).To(Equal(1))
+								Expect(pendingBuild1.StartCallCount()).To(Equal(1))
+								Expect(tryStartErr).ToNot(HaveOccurred())
+								Expect(needsReschedule).To(BeFalse())
+							})
+						})
+
+						Context("when the build is manually triggered", func() {
+							BeforeEach(func() {
+								pendingBuild1.CreateTimeReturns(time.Now())
+								pendingBuild1.IsManuallyTriggeredReturns(true)
+								pendingBuild1.ResourcesCheckedReturns(true, nil)
+							})
+
+							It("starts the build without waiting", func() {
+								Expect(pendingBuild1.StartCallCount()).To(Equal(1))
+								Expect(needsReschedule).To(BeFalse())
+							})
+						})
+					})
+
 					Context("when there are several pending builds consisting of both retrigger and normal scheduler builds", func() {
 						BeforeEach(func() {
 							pendingBuild1 = new(dbfakes.FakeBuild)
@@ -661,22 +853,25 @@ var _ = Describe("BuildStarter", func() {
 									It("creates build plans for all builds", func() {
 										Expect(fakePlanner.CreateCallCount()).To(Equal(3))
 
-										actualPlanConfig, actualResourceConfigs, actualResourceTypes, actualBuildInputs := fakePlanner.CreateArgsForCall(0)
+										actualPlanConfig, actualResourceConfigs, actualResourceTypes, actualTaskDefaults, actualBuildInputs := fakePlanner.CreateArgsForCall(0)
 										Expect(actualPlanConfig).To(Equal(&atc.DoStep{Steps: jobConfig.PlanSequence}))
 										Expect(actualResourceConfigs).To(Equal(db.SchedulerResources{{Name: "some-resource"}}))
 										Expect(actualResourceTypes).To(Equal(versionedResourceTypes))
+										Expect(actualTaskDefaults).To(Equal(taskDefaults))
 										Expect(actualBuildInputs).To(Equal([]db.BuildInput{{Name: "some-input"}}))
 
-										actualPlanConfig, actualResourceConfigs, actualResourceTypes, actualBuildInputs = fakePlanner.CreateArgsForCall(1)
+										actualPlanConfig, actualResourceConfigs, actualResourceTypes, actualTaskDefaults, actualBuildInputs = fakePlanner.CreateArgsForCall(1)
 										Expect(actualPlanConfig).To(Equal(&atc.DoStep{Steps: jobConfig.PlanSequence}))
 										Expect(actualResourceConfigs).To(Equal(db.SchedulerResources{{Name: "some-resource"}}))
 										Expect(actualResourceTypes).To(Equal(versionedResourceTypes))
+										Expect(actualTaskDefaults).To(Equal(taskDefaults))
 										Expect(actualBuildInputs).To(Equal([]db.BuildInput{{Name: "some-input"}}))
 
-										actualPlanConfig, actualResourceConfigs, actualResourceTypes, actualBuildInputs = fakePlanner.CreateArgsForCall(2)
+										actualPlanConfig, actualResourceConfigs, actualResourceTypes, actualTaskDefaults, actualBuildInputs = fakePlanner.CreateArgsForCall(2)
 										Expect(actualPlanConfig).To(Equal(&atc.DoStep{Steps: jobConfig.PlanSequence}))
 										Expect(actualResourceConfigs).To(Equal(db.SchedulerResources{{Name: "some-resource"}}))
 										Expect(actualResourceTypes).To(Equal(versionedResourceTypes))
+										Expect(actualTaskDefaults).To(Equal(taskDefaults))
 										Expect(actualBuildInputs).To(Equal([]db.BuildInput{{Name: "some-input"}}))
 									})
 
@@ -765,6 +960,13 @@ var _ = Describe("BuildStarter", func() {
 
 										itScheduledAllBuilds()
 
+										It("records that the builds were started", func() {
+											Expect(decisions.Reasons()).To(HaveLen(3))
+											for _, reason := range decisions.Reasons() {
+												Expect(reason.Kind).To(Equal(atc.SchedulerBuildStarted))
+											}
+										})
+
 										It("starts the build with the right plan", func() {
 											Expect(pendingBuild1.StartCallCount()).To(Equal(1))
 											Expect(pendingBuild1.StartArgsForCall(0)).To(Equal(plannedPlan))
@@ -0,0 +1,21 @@
+package scheduler
+
+import "github.com/concourse/concourse/atc"
+
+// Decisions collects the reasons for what the scheduler decided while
+// scheduling a job, which make up the job's decision log.
+type Decisions struct {
+	reasons []atc.SchedulerDecisionReason
+}
+
+func (d *Decisions) Record(reason atc.SchedulerDecisionReason) {
+	d.reasons = append(d.reasons, reason)
+}
+
+func (d *Decisions) Reasons() []atc.SchedulerDecisionReason {
+	if d.reasons == nil {
+		return []atc.SchedulerDecisionReason{}
+	}
+
+	return d.reasons
+}
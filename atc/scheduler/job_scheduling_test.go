@@ -334,7 +334,8 @@ func (example Example) Run() {
 			},
 		},
 	},
-		jobInputs)
+		jobInputs,
+		&scheduler.Decisions{})
 	if err != nil {
 		Expect(example.Result.Errored).To(BeTrue())
 	} else {
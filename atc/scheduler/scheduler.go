@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/tracing"
 )
@@ -26,17 +29,62 @@ type Scheduler struct {
 	BuildStarter BuildStarter
 }
 
+// Schedule resolves the inputs of the job and starts its pending builds. What
+// it decided and why is saved as the job's decision log.
 func (s *Scheduler) Schedule(
 	ctx context.Context,
 	logger lager.Logger,
 	job db.SchedulerJob,
 ) (bool, error) {
+	decisions := &Decisions{}
+
+	needsRetry, err := s.schedule(ctx, logger, job, decisions)
+	if err != nil {
+		decisions.Record(atc.SchedulerDecisionReason{
+			Kind:    atc.SchedulerErrored,
+			Message: err.Error(),
+		})
+	}
+
+	saveErr := job.SaveSchedulerDecision(atc.SchedulerDecision{
+		Time:    time.Now().Unix(),
+		Reasons: decisions.Reasons(),
+	})
+	if saveErr != nil {
+		logger.Error("failed-to-save-scheduler-decision", saveErr)
+	}
+
+	return needsRetry, err
+}
+
+func (s *Scheduler) schedule(
+	ctx context.Context,
+	logger lager.Logger,
+	job db.SchedulerJob,
+	decisions *Decisions,
+) (bool, error) {
+	awaitingChecks, err := job.AwaitingResourceChecks()
+	if err != nil {
+		return false, fmt.Errorf("awaiting resource checks: %w", err)
+	}
+
+	if awaitingChecks {
+		// the pipeline was unpaused with checks of its resources enqueued;
+		// retry once they have finished so that builds use the new versions
+		logger.Debug("awaiting-resource-checks")
+		decisions.Record(atc.SchedulerDecisionReason{
+			Kind:    atc.SchedulerAwaitingResourceChecks,
+			Message: "waiting for the checks of the resources enqueued when the pipeline was unpaused",
+		})
+		return true, nil
+	}
+
 	jobInputs, err := job.AlgorithmInputs()
 	if err != nil {
 		return false, fmt.Errorf("inputs: %w", err)
 	}
 
-	inputMapping, resolved, runAgain, err := s.Algorithm.Compute(ctx, job, jobInputs)
+	inputMapping, resolved, runAgain, err := s.Algorithm.Compute(lagerctx.NewContext(ctx, logger), job, jobInputs)
 	if err != nil {
 		return false, fmt.Errorf("compute inputs: %w", err)
 	}
@@ -53,12 +101,23 @@ func (s *Scheduler) Schedule(
 		return false, fmt.Errorf("save next input mapping: %w", err)
 	}
 
-	err = s.ensurePendingBuildExists(ctx, logger, job, jobInputs)
+	for _, input := range jobInputs {
+		result, found := inputMapping[input.Name]
+		if found && result.ResolveError != "" {
+			decisions.Record(atc.SchedulerDecisionReason{
+				Kind:    atc.SchedulerInputUnresolved,
+				Input:   input.Name,
+				Message: string(result.ResolveError),
+			})
+		}
+	}
+
+	err = s.ensurePendingBuildExists(ctx, logger, job, jobInputs, decisions)
 	if err != nil {
 		return false, err
 	}
 
-	return s.BuildStarter.TryStartPendingBuildsForJob(logger, job, jobInputs)
+	return s.BuildStarter.TryStartPendingBuildsForJob(logger, job, jobInputs, decisions)
 }
 
 func (s *Scheduler) ensurePendingBuildExists(
@@ -66,6 +125,7 @@ func (s *Scheduler) ensurePendingBuildExists(
 	logger lager.Logger,
 	job db.SchedulerJob,
 	jobInputs db.InputConfigs,
+	decisions *Decisions,
 ) error {
 	buildInputs, satisfiableInputs, err := job.GetFullNextBuildInputs()
 	if err != nil {
@@ -74,6 +134,10 @@ func (s *Scheduler) ensurePendingBuildExists(
 
 	if !satisfiableInputs {
 		logger.Debug("next-build-inputs-not-determined")
+		decisions.Record(atc.SchedulerDecisionReason{
+			Kind:    atc.SchedulerInputsNotDetermined,
+			Message: "not every input has a version satisfying its constraints, so no build is triggered",
+		})
 		return nil
 	}
 
@@ -82,7 +146,12 @@ func (s *Scheduler) ensurePendingBuildExists(
 		inputMapping[input.Name] = input
 	}
 
-	var hasNewInputs bool
+	windows, err := triggerWindows(job)
+	if err != nil {
+		return fmt.Errorf("trigger windows: %w", err)
+	}
+
+	var hasNewInputs, triggered, outsideWindow bool
 	for _, inputConfig := range jobInputs {
 		inputSource, ok := inputMapping[inputConfig.Name]
 
@@ -90,6 +159,22 @@ func (s *Scheduler) ensurePendingBuildExists(
 		if ok && inputSource.FirstOccurrence {
 			hasNewInputs = true
 			if inputConfig.Trigger {
+				inWindow, reason, err := inTriggerWindow(windows[inputConfig.Name], inputSource)
+				if err != nil {
+					return fmt.Errorf("trigger window: %w", err)
+				}
+
+				if !inWindow {
+					outsideWindow = true
+					decisions.Record(atc.SchedulerDecisionReason{
+						Kind:    atc.SchedulerOutsideTriggerWindow,
+						Input:   inputConfig.Name,
+						Message: reason,
+					})
+
+					continue
+				}
+
 				version, _ := json.Marshal(inputSource.Version)
 				spanCtx, _ := tracing.StartSpanLinkedToFollowing(
 					ctx,
@@ -103,16 +188,37 @@ func (s *Scheduler) ensurePendingBuildExists(
 						"version":  string(version),
 					},
 				)
-				err := job.EnsurePendingBuildExists(spanCtx)
+				err = job.EnsurePendingBuildExists(spanCtx)
 				if err != nil {
 					return fmt.Errorf("ensure pending build exists: %w", err)
 				}
 
+				triggered = true
+				decisions.Record(atc.SchedulerDecisionReason{
+					Kind:    atc.SchedulerNewTriggerInput,
+					Input:   inputConfig.Name,
+					Message: fmt.Sprintf("a new version of trigger input '%s' triggers a build", inputConfig.Name),
+				})
+
 				break
 			}
 		}
 	}
 
+	if !triggered {
+		message := "no input has a new version"
+		if outsideWindow {
+			message = "the new versions of trigger inputs are outside of their resources' trigger windows"
+		} else if hasNewInputs {
+			message = "only inputs without trigger: true have new versions"
+		}
+
+		decisions.Record(atc.SchedulerDecisionReason{
+			Kind:    atc.SchedulerNoNewTriggerInputs,
+			Message: message,
+		})
+	}
+
 	if hasNewInputs != job.HasNewInputs() {
 		if err := job.SetHasNewInputs(hasNewInputs); err != nil {
 			return fmt.Errorf("set has new inputs: %w", err)
@@ -121,3 +227,80 @@ func (s *Scheduler) ensurePendingBuildExists(
 
 	return nil
 }
+
+// triggerWindows returns the trigger windows of the resources of the job's
+// inputs, by input name.
+func triggerWindows(job db.SchedulerJob) (map[string]*atc.TriggerWindowConfig, error) {
+	var hasWindow bool
+	for _, resource := range job.Resources {
+		if resource.TriggerWindow != nil {
+			hasWindow = true
+			break
+		}
+	}
+
+	if !hasWindow {
+		return nil, nil
+	}
+
+	config, err := job.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	windows := map[string]*atc.TriggerWindowConfig{}
+	for _, jobInput := range config.Inputs() {
+		resource, found := job.Resources.Lookup(jobInput.Resource)
+		if found && resource.TriggerWindow != nil {
+			windows[jobInput.Name] = resource.TriggerWindow
+		}
+	}
+
+	return windows, nil
+}
+
+// inTriggerWindow returns whether the new version of a trigger input may
+// trigger a build, as its resource has no trigger window or the version was
+// created within it. Otherwise, it also returns the reason why not.
+//
+// The version is timed by the window's metadata field if it has it, and
+// otherwise by when it was first saved, as versions saved by a check have no
+// metadata until a get fetches them.
+func inTriggerWindow(window *atc.TriggerWindowConfig, input db.BuildInput) (bool, string, error) {
+	if window == nil {
+		return true, "", nil
+	}
+
+	createdAt := input.VersionCreatedAt
+
+	if window.Field != "" {
+		for _, field := range input.Metadata {
+			if field.Name != window.Field {
+				continue
+			}
+
+			t, err := window.ParseTimestamp(field.Value)
+			if err != nil {
+				return false, fmt.Sprintf("the new version of trigger input '%s' is not triggering, as its %s can't be told: %s", input.Name, window.Field, err), nil
+			}
+
+			createdAt = t
+			break
+		}
+	}
+
+	if createdAt.IsZero() {
+		return false, fmt.Sprintf("the new version of trigger input '%s' is not triggering, as when it was created is unknown", input.Name), nil
+	}
+
+	included, err := window.Includes(createdAt)
+	if err != nil {
+		return false, "", err
+	}
+
+	if !included {
+		return false, fmt.Sprintf("the new version of trigger input '%s' was created outside of the trigger window of its resource", input.Name), nil
+	}
+
+	return true, "", nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -45,29 +47,37 @@ var _ = Describe("Scheduler", func() {
 		var (
 			fakePipeline *dbfakes.FakePipeline
 			fakeJob      *dbfakes.FakeJob
+			resources    db.SchedulerResources
+			needsRetry   bool
 			scheduleErr  error
 		)
 
+		savedReasons := func() []atc.SchedulerDecisionReason {
+			Expect(fakeJob.SaveSchedulerDecisionCallCount()).To(Equal(1))
+			return fakeJob.SaveSchedulerDecisionArgsForCall(0).Reasons
+		}
+
 		BeforeEach(func() {
 			fakeJob = new(dbfakes.FakeJob)
 			fakePipeline = new(dbfakes.FakePipeline)
 			fakePipeline.NameReturns("fake-pipeline")
+			resources = db.SchedulerResources{
+				{
+					Name: "some-resource",
+				},
+			}
 			ctx = context.Background()
 		})
 
 		JustBeforeEach(func() {
 			var waiter interface{ Wait() }
 
-			_, scheduleErr = scheduler.Schedule(
+			needsRetry, scheduleErr = scheduler.Schedule(
 				ctx,
 				lagertest.NewTestLogger("test"),
 				db.SchedulerJob{
-					Job: fakeJob,
-					Resources: db.SchedulerResources{
-						{
-							Name: "some-resource",
-						},
-					},
+					Job:       fakeJob,
+					Resources: resources,
 				},
 			)
 			if waiter != nil {
@@ -75,6 +85,55 @@ var _ = Describe("Scheduler", func() {
 			}
 		})
 
+		Context("when the job is awaiting resource checks", func() {
+			BeforeEach(func() {
+				fakeJob.AwaitingResourceChecksReturns(true, nil)
+			})
+
+			It("retries later without computing inputs", func() {
+				Expect(scheduleErr).ToNot(HaveOccurred())
+				Expect(needsRetry).To(BeTrue())
+				Expect(fakeAlgorithm.ComputeCallCount()).To(BeZero())
+				Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(BeZero())
+			})
+
+			It("saves that it is awaiting the checks as the decision", func() {
+				Expect(savedReasons()).To(ConsistOf(atc.SchedulerDecisionReason{
+					Kind:    atc.SchedulerAwaitingResourceChecks,
+					Message: "waiting for the checks of the resources enqueued when the pipeline was unpaused",
+				}))
+			})
+		})
+
+		Context("when checking whether the job is awaiting resource checks fails", func() {
+			BeforeEach(func() {
+				fakeJob.AwaitingResourceChecksReturns(false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(scheduleErr).To(MatchError(ContainSubstring(disaster.Error())))
+				Expect(fakeAlgorithm.ComputeCallCount()).To(BeZero())
+			})
+
+			It("saves the error as the decision", func() {
+				Expect(savedReasons()).To(ConsistOf(atc.SchedulerDecisionReason{
+					Kind:    atc.SchedulerErrored,
+					Message: scheduleErr.Error(),
+				}))
+			})
+		})
+
+		Context("when saving the decision fails", func() {
+			BeforeEach(func() {
+				fakeJob.SaveSchedulerDecisionReturns(disaster)
+			})
+
+			It("still schedules the job", func() {
+				Expect(scheduleErr).ToNot(HaveOccurred())
+				Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("when the job has no inputs", func() {
 			BeforeEach(func() {
 				fakeJob.NameReturns("some-job-1")
@@ -186,7 +245,7 @@ var _ = Describe("Scheduler", func() {
 
 							It("started all pending builds", func() {
 								Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
-								_, actualJob, actualInputs := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
+								_, actualJob, actualInputs, _ := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
 								Expect(actualJob.Name()).To(Equal(fakeJob.Name()))
 								Expect(len(actualJob.Resources)).To(Equal(1))
 								Expect(actualJob.Resources[0].Name).To(Equal("some-resource"))
@@ -231,7 +290,7 @@ var _ = Describe("Scheduler", func() {
 
 			It("started the builds with the correct arguments", func() {
 				Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
-				_, actualJob, actualInputs := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
+				_, actualJob, actualInputs, _ := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
 				Expect(actualJob.Name()).To(Equal(fakeJob.Name()))
 				Expect(len(actualJob.Resources)).To(Equal(1))
 				Expect(actualJob.Resources[0].Name).To(Equal("some-resource"))
@@ -258,6 +317,53 @@ var _ = Describe("Scheduler", func() {
 				It("didn't mark the job as having new inputs", func() {
 					Expect(fakeJob.SetHasNewInputsCallCount()).To(BeZero())
 				})
+
+				It("saves that the inputs are not determined as the decision", func() {
+					Expect(savedReasons()).To(ContainElement(atc.SchedulerDecisionReason{
+						Kind:    atc.SchedulerInputsNotDetermined,
+						Message: "not every input has a version satisfying its constraints, so no build is triggered",
+					}))
+				})
+			})
+
+			Context("when an input can't be resolved", func() {
+				BeforeEach(func() {
+					fakeAlgorithm.ComputeReturns(db.InputMapping{
+						"a": db.InputResult{
+							ResolveError: db.NoSatisfiableBuilds,
+						},
+					}, false, false, nil)
+				})
+
+				It("saves why as the decision", func() {
+					Expect(savedReasons()).To(ContainElement(atc.SchedulerDecisionReason{
+						Kind:    atc.SchedulerInputUnresolved,
+						Input:   "a",
+						Message: string(db.NoSatisfiableBuilds),
+					}))
+				})
+			})
+
+			Context("when the build starter records decisions", func() {
+				BeforeEach(func() {
+					fakeBuildStarter.TryStartPendingBuildsForJobStub = func(_ lager.Logger, _ db.SchedulerJob, _ db.InputConfigs, decisions *Decisions) (bool, error) {
+						decisions.Record(atc.SchedulerDecisionReason{
+							Kind:    atc.SchedulerBuildStarted,
+							Build:   "1",
+							Message: "the build was started",
+						})
+
+						return false, nil
+					}
+				})
+
+				It("saves them as part of the decision", func() {
+					Expect(savedReasons()).To(ContainElement(atc.SchedulerDecisionReason{
+						Kind:    atc.SchedulerBuildStarted,
+						Build:   "1",
+						Message: "the build was started",
+					}))
+				})
 			})
 
 			Context("when no first occurrence input has trigger: true", func() {
@@ -287,6 +393,13 @@ var _ = Describe("Scheduler", func() {
 					Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
 				})
 
+				It("saves that only inputs without trigger: true have new versions as the decision", func() {
+					Expect(savedReasons()).To(ContainElement(atc.SchedulerDecisionReason{
+						Kind:    atc.SchedulerNoNewTriggerInputs,
+						Message: "only inputs without trigger: true have new versions",
+					}))
+				})
+
 				Context("when the job does not have new inputs since before", func() {
 					BeforeEach(func() {
 						fakeJob.HasNewInputsReturns(false)
@@ -366,6 +479,186 @@ var _ = Describe("Scheduler", func() {
 						Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
 						Expect(scheduleErr).NotTo(HaveOccurred())
 					})
+
+					It("saves the input which triggered the build as the decision", func() {
+						Expect(savedReasons()).To(ConsistOf(atc.SchedulerDecisionReason{
+							Kind:    atc.SchedulerNewTriggerInput,
+							Input:   "a",
+							Message: "a new version of trigger input 'a' triggers a build",
+						}))
+					})
+				})
+
+				Context("when the resource of the input has a trigger window", func() {
+					BeforeEach(func() {
+						resources = db.SchedulerResources{
+							{
+								Name: "some-resource",
+								TriggerWindow: &atc.TriggerWindowConfig{
+									Field: "committed_at",
+									Start: "09:00",
+									Stop:  "17:00",
+								},
+							},
+						}
+
+						fakeJob.ConfigReturns(atc.JobConfig{
+							Name: "some-job",
+							PlanSequence: []atc.Step{
+								{Config: &atc.GetStep{Name: "a", Resource: "some-resource", Trigger: true}},
+								{Config: &atc.GetStep{Name: "b", Resource: "other-resource"}},
+							},
+						}, nil)
+					})
+
+					Context("when the version was created within the window", func() {
+						BeforeEach(func() {
+							fakeJob.GetFullNextBuildInputsReturns([]db.BuildInput{
+								{
+									Name:            "a",
+									Version:         atc.Version{"ref": "v1"},
+									Metadata:        db.ResourceConfigMetadataFields{{Name: "committed_at", Value: "2021-04-20T10:30:00Z"}},
+									FirstOccurrence: true,
+								},
+							}, true, nil)
+						})
+
+						It("creates a pending build", func() {
+							Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when the version was created outside of the window", func() {
+						BeforeEach(func() {
+							fakeJob.GetFullNextBuildInputsReturns([]db.BuildInput{
+								{
+									Name:            "a",
+									Version:         atc.Version{"ref": "v1"},
+									Metadata:        db.ResourceConfigMetadataFields{{Name: "committed_at", Value: "2021-04-20T22:30:00Z"}},
+									FirstOccurrence: true,
+								},
+							}, true, nil)
+						})
+
+						It("doesn't create a pending build", func() {
+							Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
+						})
+
+						It("still marks the job as having new inputs", func() {
+							Expect(fakeJob.SetHasNewInputsCallCount()).To(Equal(1))
+							Expect(fakeJob.SetHasNewInputsArgsForCall(0)).To(BeTrue())
+						})
+
+						It("saves that the version is outside of the window as the decision", func() {
+							Expect(savedReasons()).To(ConsistOf(
+								atc.SchedulerDecisionReason{
+									Kind:    atc.SchedulerOutsideTriggerWindow,
+									Input:   "a",
+									Message: "the new version of trigger input 'a' was created outside of the trigger window of its resource",
+								},
+								atc.SchedulerDecisionReason{
+									Kind:    atc.SchedulerNoNewTriggerInputs,
+									Message: "the new versions of trigger inputs are outside of their resources' trigger windows",
+								},
+							))
+						})
+					})
+
+					Context("when the version has no metadata, as it was saved by a check", func() {
+						var createdAt time.Time
+
+						BeforeEach(func() {
+							createdAt = time.Date(2021, 4, 20, 10, 30, 0, 0, time.UTC)
+						})
+
+						Context("when it was first saved within the window", func() {
+							BeforeEach(func() {
+								fakeJob.GetFullNextBuildInputsReturns([]db.BuildInput{
+									{
+										Name:             "a",
+										Version:          atc.Version{"ref": "v1"},
+										VersionCreatedAt: createdAt,
+										FirstOccurrence:  true,
+									},
+								}, true, nil)
+							})
+
+							It("creates a pending build", func() {
+								Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(Equal(1))
+							})
+						})
+
+						Context("when it was first saved outside of the window", func() {
+							BeforeEach(func() {
+								fakeJob.GetFullNextBuildInputsReturns([]db.BuildInput{
+									{
+										Name:             "a",
+										Version:          atc.Version{"ref": "v1"},
+										VersionCreatedAt: createdAt.Add(12 * time.Hour),
+										FirstOccurrence:  true,
+									},
+								}, true, nil)
+							})
+
+							It("doesn't create a pending build", func() {
+								Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
+								Expect(savedReasons()).To(ContainElement(atc.SchedulerDecisionReason{
+									Kind:    atc.SchedulerOutsideTriggerWindow,
+									Input:   "a",
+									Message: "the new version of trigger input 'a' was created outside of the trigger window of its resource",
+								}))
+							})
+						})
+					})
+
+					Context("when the metadata timestamp can't be parsed", func() {
+						BeforeEach(func() {
+							fakeJob.GetFullNextBuildInputsReturns([]db.BuildInput{
+								{
+									Name:             "a",
+									Version:          atc.Version{"ref": "v1"},
+									Metadata:         db.ResourceConfigMetadataFields{{Name: "committed_at", Value: "yesterday"}},
+									VersionCreatedAt: time.Date(2021, 4, 20, 10, 30, 0, 0, time.UTC),
+									FirstOccurrence:  true,
+								},
+							}, true, nil)
+						})
+
+						It("doesn't create a pending build", func() {
+							Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when several inputs have new versions", func() {
+						BeforeEach(func() {
+							fakeJob.ConfigReturns(atc.JobConfig{
+								Name: "some-job",
+								PlanSequence: []atc.Step{
+									{Config: &atc.GetStep{Name: "a", Resource: "some-resource", Trigger: true}},
+									{Config: &atc.GetStep{Name: "b", Resource: "some-resource", Trigger: true}},
+								},
+							}, nil)
+
+							fakeJob.GetFullNextBuildInputsReturns([]db.BuildInput{
+								{
+									Name:             "a",
+									Version:          atc.Version{"ref": "v1"},
+									VersionCreatedAt: time.Date(2021, 4, 20, 22, 30, 0, 0, time.UTC),
+									FirstOccurrence:  true,
+								},
+								{
+									Name:             "b",
+									Version:          atc.Version{"ref": "v1"},
+									VersionCreatedAt: time.Date(2021, 4, 20, 23, 30, 0, 0, time.UTC),
+									FirstOccurrence:  true,
+								},
+							}, true, nil)
+						})
+
+						It("reads the job's config once", func() {
+							Expect(fakeJob.ConfigCallCount()).To(Equal(1))
+						})
+					})
 				})
 			})
 
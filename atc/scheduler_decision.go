@@ -0,0 +1,39 @@
+package atc
+
+// SchedulerDecision is the decision log of the last time the scheduler
+// scheduled a job: the reasons it did or didn't start builds of it.
+type SchedulerDecision struct {
+	Time    int64                     `json:"time"`
+	Reasons []SchedulerDecisionReason `json:"reasons"`
+}
+
+type SchedulerDecisionReason struct {
+	Kind SchedulerDecisionKind `json:"kind"`
+
+	// The input or build the reason is about, if any.
+	Input string `json:"input,omitempty"`
+	Build string `json:"build,omitempty"`
+
+	Message string `json:"message"`
+}
+
+type SchedulerDecisionKind string
+
+const (
+	SchedulerAwaitingResourceChecks SchedulerDecisionKind = "awaiting-resource-checks"
+	SchedulerInputUnresolved        SchedulerDecisionKind = "input-unresolved"
+	SchedulerInputsNotDetermined    SchedulerDecisionKind = "inputs-not-determined"
+	SchedulerNewTriggerInput        SchedulerDecisionKind = "new-trigger-input"
+	SchedulerNoNewTriggerInputs     SchedulerDecisionKind = "no-new-trigger-inputs"
+	SchedulerOutsideTriggerWindow   SchedulerDecisionKind = "outside-trigger-window"
+
+	SchedulerBuildAborted             SchedulerDecisionKind = "build-aborted"
+	SchedulerBuildNotScheduled        SchedulerDecisionKind = "build-not-scheduled"
+	SchedulerBuildNotReady            SchedulerDecisionKind = "build-not-ready"
+	SchedulerBuildInputsNotDetermined SchedulerDecisionKind = "build-inputs-not-determined"
+	SchedulerBuildErrored             SchedulerDecisionKind = "build-errored"
+	SchedulerBuildStarted             SchedulerDecisionKind = "build-started"
+	SchedulerBuildNotStarted          SchedulerDecisionKind = "build-not-started"
+
+	SchedulerErrored SchedulerDecisionKind = "errored"
+)
@@ -0,0 +1,11 @@
+package atc
+
+// SerialGroup is a serial group of a pipeline along with the builds holding
+// it and the builds queued up waiting for it, in the order they'll run.
+type SerialGroup struct {
+	Name string   `json:"name"`
+	Jobs []string `json:"jobs"`
+
+	RunningBuilds []Build `json:"running_builds"`
+	PendingBuilds []Build `json:"pending_builds"`
+}
@@ -0,0 +1,9 @@
+package atc
+
+type SharedArtifact struct {
+	Name         string   `json:"name"`
+	BuildID      int      `json:"build_id,omitempty"`
+	Size         int64    `json:"size,omitempty"`
+	PublishedAt  int64    `json:"published_at,omitempty"`
+	GrantedTeams []string `json:"granted_teams,omitempty"`
+}
@@ -22,6 +22,21 @@ type StepRecursor struct {
 
 	// OnLoadVar will be invoked for any *LoadVarStep present in the StepConfig.
 	OnLoadVar func(*LoadVarStep) error
+
+	// OnApproval will be invoked for any *ApprovalStep present in the StepConfig.
+	OnApproval func(*ApprovalStep) error
+
+	// OnPublishArtifact will be invoked for any *PublishArtifactStep present in the StepConfig.
+	OnPublishArtifact func(*PublishArtifactStep) error
+
+	// OnConsumeArtifact will be invoked for any *ConsumeArtifactStep present in the StepConfig.
+	OnConsumeArtifact func(*ConsumeArtifactStep) error
+
+	// OnLoadBuildOutputs will be invoked for any *LoadBuildOutputsStep present in the StepConfig.
+	OnLoadBuildOutputs func(*LoadBuildOutputsStep) error
+
+	// OnAssertGreen will be invoked for any *AssertGreenStep present in the StepConfig.
+	OnAssertGreen func(*AssertGreenStep) error
 }
 
 // VisitTask calls the OnTask hook if configured.
@@ -69,6 +84,51 @@ func (recursor StepRecursor) VisitLoadVar(step *LoadVarStep) error {
 	return nil
 }
 
+// VisitApproval calls the OnApproval hook if configured.
+func (recursor StepRecursor) VisitApproval(step *ApprovalStep) error {
+	if recursor.OnApproval != nil {
+		return recursor.OnApproval(step)
+	}
+
+	return nil
+}
+
+// VisitPublishArtifact calls the OnPublishArtifact hook if configured.
+func (recursor StepRecursor) VisitPublishArtifact(step *PublishArtifactStep) error {
+	if recursor.OnPublishArtifact != nil {
+		return recursor.OnPublishArtifact(step)
+	}
+
+	return nil
+}
+
+// VisitConsumeArtifact calls the OnConsumeArtifact hook if configured.
+func (recursor StepRecursor) VisitConsumeArtifact(step *ConsumeArtifactStep) error {
+	if recursor.OnConsumeArtifact != nil {
+		return recursor.OnConsumeArtifact(step)
+	}
+
+	return nil
+}
+
+// VisitLoadBuildOutputs calls the OnLoadBuildOutputs hook if configured.
+func (recursor StepRecursor) VisitLoadBuildOutputs(step *LoadBuildOutputsStep) error {
+	if recursor.OnLoadBuildOutputs != nil {
+		return recursor.OnLoadBuildOutputs(step)
+	}
+
+	return nil
+}
+
+// VisitAssertGreen calls the OnAssertGreen hook if configured.
+func (recursor StepRecursor) VisitAssertGreen(step *AssertGreenStep) error {
+	if recursor.OnAssertGreen != nil {
+		return recursor.OnAssertGreen(step)
+	}
+
+	return nil
+}
+
 // VisitTry recurses through to the wrapped step.
 func (recursor StepRecursor) VisitTry(step *TryStep) error {
 	return step.Step.Config.Visit(recursor)
@@ -86,6 +146,18 @@ func (recursor StepRecursor) VisitDo(step *DoStep) error {
 	return nil
 }
 
+// VisitAtomic recurses through to the wrapped steps.
+func (recursor StepRecursor) VisitAtomic(step *AtomicStep) error {
+	for _, sub := range step.Steps {
+		err := sub.Config.Visit(recursor)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // VisitInParallel recurses through to the wrapped steps.
 func (recursor StepRecursor) VisitInParallel(step *InParallelStep) error {
 	for _, sub := range step.Config.Steps {
@@ -2,6 +2,7 @@ package atc
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 )
@@ -80,6 +81,10 @@ func (validator *StepValidator) VisitTask(plan *TaskStep) error {
 		validator.recordError("must specify one of `file:` or `config:`, not both")
 	}
 
+	if plan.WorkerName != "" && !EnableStepWorkerName {
+		validator.recordError("`worker_name:` must be explicitly opted-in to using the `--enable-step-worker-name` flag")
+	}
+
 	if plan.Config != nil && (plan.Config.RootfsURI != "" || plan.Config.ImageResource != nil) && plan.ImageArtifactName != "" {
 		validator.recordWarning(ConfigWarning{
 			Type:    "pipeline",
@@ -103,6 +108,17 @@ func (validator *StepValidator) VisitTask(plan *TaskStep) error {
 		validator.popContext()
 	}
 
+	if validator.localVarIsDeclared(plan.Name) {
+		validator.recordWarning(ConfigWarning{
+			Type:    "var_shadowed",
+			Message: validator.annotate(fmt.Sprintf("sets its exit code as a local var which shadows local var '%s'", plan.Name)),
+		})
+	}
+
+	validator.validateInputMetadataVars(plan.Params)
+	validator.validateHosts(plan.Hosts)
+	validator.validateRuntime(plan.Runtime)
+
 	return nil
 }
 
@@ -131,6 +147,25 @@ func (validator *StepValidator) VisitGet(step *GetStep) error {
 		validator.recordError("unknown resource '%s'", resourceName)
 	}
 
+	if step.VersionConstraint != "" {
+		validator.pushContext(".version_constraint")
+
+		_, err := ParseVersionConstraint(step.VersionConstraint)
+		if err != nil {
+			validator.recordError(err.Error())
+		}
+
+		if step.Version != nil && (step.Version.Every || step.Version.LatestStable || step.Version.Pinned != nil) {
+			validator.recordError("cannot be used with a version other than latest")
+		}
+
+		if len(step.Passed) != 0 {
+			validator.recordError("cannot be used with passed constraints")
+		}
+
+		validator.popContext()
+	}
+
 	validator.pushContext(".passed")
 
 	for _, job := range step.Passed {
@@ -164,6 +199,29 @@ func (validator *StepValidator) VisitGet(step *GetStep) error {
 
 	validator.popContext()
 
+	if len(step.MetadataVars) != 0 {
+		validator.pushContext(".metadata_vars")
+
+		seenKeys := map[string]bool{}
+		for _, key := range step.MetadataVars {
+			if key == "" {
+				validator.recordError("empty metadata key")
+			} else if seenKeys[key] {
+				validator.recordError("repeated metadata key '%s'", key)
+			}
+
+			seenKeys[key] = true
+		}
+
+		validator.popContext()
+
+		validator.declareLocalVar(step.Name)
+	}
+
+	validator.validateHosts(step.Hosts)
+	validator.validateRuntime(step.Runtime)
+	validator.validateUnpack(step.Unpack)
+
 	return nil
 }
 
@@ -186,6 +244,9 @@ func (validator *StepValidator) VisitPut(step *PutStep) error {
 		validator.recordError("unknown resource '%s'", resourceName)
 	}
 
+	validator.validateHosts(step.Hosts)
+	validator.validateRuntime(step.Runtime)
+
 	return nil
 }
 
@@ -229,6 +290,103 @@ func (validator *StepValidator) VisitLoadVar(step *LoadVarStep) error {
 	return nil
 }
 
+func (validator *StepValidator) VisitApproval(step *ApprovalStep) error {
+	validator.pushContext(".approval(%s)", step.Name)
+	defer validator.popContext()
+
+	warning, err := ValidateIdentifier(step.Name, validator.context...)
+	if err != nil {
+		validator.recordError(err.Error())
+	}
+	if warning != nil {
+		validator.recordWarning(*warning)
+	}
+
+	if step.Timeout != "" {
+		_, err := time.ParseDuration(step.Timeout)
+		if err != nil {
+			validator.recordError("invalid timeout '%s'", step.Timeout)
+		}
+	}
+
+	return nil
+}
+
+func (validator *StepValidator) VisitPublishArtifact(step *PublishArtifactStep) error {
+	validator.pushContext(".publish_artifact(%s)", step.Name)
+	defer validator.popContext()
+
+	warning, err := ValidateIdentifier(step.Name, validator.context...)
+	if err != nil {
+		validator.recordError(err.Error())
+	}
+	if warning != nil {
+		validator.recordWarning(*warning)
+	}
+
+	return nil
+}
+
+func (validator *StepValidator) VisitConsumeArtifact(step *ConsumeArtifactStep) error {
+	validator.pushContext(".consume_artifact(%s)", step.Name)
+	defer validator.popContext()
+
+	warning, err := ValidateIdentifier(step.Name, validator.context...)
+	if err != nil {
+		validator.recordError(err.Error())
+	}
+	if warning != nil {
+		validator.recordWarning(*warning)
+	}
+
+	return nil
+}
+
+func (validator *StepValidator) VisitLoadBuildOutputs(step *LoadBuildOutputsStep) error {
+	validator.pushContext(".load_build_outputs(%s)", step.Name)
+	defer validator.popContext()
+
+	warning, err := ValidateIdentifier(step.Name, validator.context...)
+	if err != nil {
+		validator.recordError(err.Error())
+	}
+	if warning != nil {
+		validator.recordWarning(*warning)
+	}
+
+	validator.declareLocalVar(step.Name)
+
+	if step.Job == "" {
+		validator.recordError("no job specified")
+	} else if _, found := validator.config.Jobs.Lookup(step.Job); !found {
+		validator.recordError("unknown job '%s'", step.Job)
+	}
+
+	return nil
+}
+
+func (validator *StepValidator) VisitAssertGreen(step *AssertGreenStep) error {
+	validator.pushContext(".assert_green(%s)", step.Job)
+	defer validator.popContext()
+
+	if step.Job == "" {
+		validator.recordError("no job specified")
+		return nil
+	}
+
+	if step.Pipeline == "" {
+		if step.Team != "" {
+			validator.recordError("pipeline must be specified along with team")
+		} else if len(step.InstanceVars) != 0 {
+			validator.recordError("pipeline must be specified along with instance_vars")
+		} else if _, found := validator.config.Jobs.Lookup(step.Job); !found {
+			validator.recordError("unknown job '%s'", step.Job)
+		}
+	}
+
+	return nil
+}
+
 func (validator *StepValidator) VisitTry(step *TryStep) error {
 	validator.pushContext(".try")
 	defer validator.popContext()
@@ -254,6 +412,28 @@ func (validator *StepValidator) VisitDo(step *DoStep) error {
 	return nil
 }
 
+func (validator *StepValidator) VisitAtomic(step *AtomicStep) error {
+	validator.pushContext(".atomic")
+	defer validator.popContext()
+
+	for i, sub := range step.Steps {
+		validator.pushContext(fmt.Sprintf("[%d]", i))
+
+		if _, ok := sub.Config.(*PutStep); !ok {
+			validator.recordError("only put steps can be published atomically")
+		}
+
+		err := validator.Validate(sub)
+		if err != nil {
+			return err
+		}
+
+		validator.popContext()
+	}
+
+	return nil
+}
+
 func (validator *StepValidator) VisitInParallel(step *InParallelStep) error {
 	validator.pushContext(".in_parallel")
 	defer validator.popContext()
@@ -269,6 +449,33 @@ func (validator *StepValidator) VisitInParallel(step *InParallelStep) error {
 		validator.popContext()
 	}
 
+	if step.Config.FailFastGracePeriod != "" {
+		validator.pushContext(".fail_fast_grace_period")
+
+		if !step.Config.FailFast {
+			validator.recordError("only applies when fail_fast is set")
+		} else if _, err := time.ParseDuration(step.Config.FailFastGracePeriod); err != nil {
+			validator.recordError("invalid duration '%s'", step.Config.FailFastGracePeriod)
+		}
+
+		validator.popContext()
+	}
+
+	seen := map[string]bool{}
+	for i, name := range step.Config.OutputPriority {
+		validator.pushContext(".output_priority[%d]", i)
+
+		if seen[name] {
+			validator.recordError("step '%s' is listed more than once", name)
+		} else if _, found := step.Config.stepIndex(name); !found {
+			validator.recordError("unknown step '%s'", name)
+		}
+
+		seen[name] = true
+
+		validator.popContext()
+	}
+
 	return nil
 }
 
@@ -333,6 +540,20 @@ func (validator *StepValidator) VisitRetry(step *RetryStep) error {
 		validator.recordError("must be greater than 0")
 	}
 
+	if step.Backoff != "" {
+		_, err = time.ParseDuration(step.Backoff)
+		if err != nil {
+			validator.recordError("invalid backoff '%s'", step.Backoff)
+		}
+	}
+
+	if step.MaxDuration != "" {
+		_, err = time.ParseDuration(step.MaxDuration)
+		if err != nil {
+			validator.recordError("invalid max_duration '%s'", step.MaxDuration)
+		}
+	}
+
 	return nil
 }
 
@@ -396,6 +617,77 @@ func (validator *StepValidator) VisitEnsure(step *EnsureStep) error {
 	return validator.Validate(step.Hook)
 }
 
+func (validator *StepValidator) validateHosts(hosts HostsConfig) {
+	validator.pushContext(".hosts")
+	defer validator.popContext()
+
+	for _, msg := range hosts.Validate() {
+		validator.recordError("%s", msg)
+	}
+}
+
+func (validator *StepValidator) validateRuntime(runtime string) {
+	if runtime == "" {
+		return
+	}
+
+	for _, known := range WorkerRuntimes {
+		if runtime == known {
+			return
+		}
+	}
+
+	validator.pushContext(".runtime")
+	defer validator.popContext()
+
+	validator.recordError("unknown runtime '%s' (must be one of: %s)", runtime, strings.Join(WorkerRuntimes, ", "))
+}
+
+// validateInputMetadataVars checks that the local vars in the params of a task
+// which are named after a resource, rather than a local var, refer to the
+// metadata of a get step visited before it.
+func (validator *StepValidator) validateInputMetadataVars(params TaskEnv) {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		validator.pushContext(".params.%s", name)
+
+		for _, metadataVar := range ParseInputMetadataVars(params[name]) {
+			if validator.localVarIsDeclared(metadataVar.Input) || validator.seenGetName[metadataVar.Input] {
+				continue
+			}
+
+			if _, found := validator.config.Resources.Lookup(metadataVar.Input); found {
+				validator.recordError("var '%s' refers to the metadata of '%s', which is not a get step before the task", metadataVar, metadataVar.Input)
+			}
+		}
+
+		validator.popContext()
+	}
+}
+
+func (validator *StepValidator) validateUnpack(format string) {
+	if format == "" {
+		return
+	}
+
+	for _, known := range UnpackFormats {
+		if format == known {
+			return
+		}
+	}
+
+	validator.pushContext(".unpack")
+	defer validator.popContext()
+
+	validator.recordError("unsupported unpack format '%s' (must be one of: %s)", format, strings.Join(UnpackFormats, ", "))
+}
+
 func (validator *StepValidator) recordWarning(warning ConfigWarning) {
 	validator.Warnings = append(validator.Warnings, warning)
 }
@@ -193,8 +193,14 @@ type StepVisitor interface {
 	VisitPut(*PutStep) error
 	VisitSetPipeline(*SetPipelineStep) error
 	VisitLoadVar(*LoadVarStep) error
+	VisitApproval(*ApprovalStep) error
+	VisitPublishArtifact(*PublishArtifactStep) error
+	VisitConsumeArtifact(*ConsumeArtifactStep) error
+	VisitLoadBuildOutputs(*LoadBuildOutputsStep) error
+	VisitAssertGreen(*AssertGreenStep) error
 	VisitTry(*TryStep) error
 	VisitDo(*DoStep) error
+	VisitAtomic(*AtomicStep) error
 	VisitInParallel(*InParallelStep) error
 	VisitAcross(*AcrossStep) error
 	VisitTimeout(*TimeoutStep) error
@@ -261,6 +267,10 @@ var StepPrecedence = []StepDetector{
 		Key: "get",
 		New: func() StepConfig { return &GetStep{} },
 	},
+	{
+		Key: "approval",
+		New: func() StepConfig { return &ApprovalStep{} },
+	},
 	{
 		Key: "timeout",
 		New: func() StepConfig { return &TimeoutStep{} },
@@ -273,6 +283,22 @@ var StepPrecedence = []StepDetector{
 		Key: "load_var",
 		New: func() StepConfig { return &LoadVarStep{} },
 	},
+	{
+		Key: "load_build_outputs",
+		New: func() StepConfig { return &LoadBuildOutputsStep{} },
+	},
+	{
+		Key: "assert_green",
+		New: func() StepConfig { return &AssertGreenStep{} },
+	},
+	{
+		Key: "publish_artifact",
+		New: func() StepConfig { return &PublishArtifactStep{} },
+	},
+	{
+		Key: "consume_artifact",
+		New: func() StepConfig { return &ConsumeArtifactStep{} },
+	},
 	{
 		Key: "try",
 		New: func() StepConfig { return &TryStep{} },
@@ -281,6 +307,10 @@ var StepPrecedence = []StepDetector{
 		Key: "do",
 		New: func() StepConfig { return &DoStep{} },
 	},
+	{
+		Key: "atomic",
+		New: func() StepConfig { return &AtomicStep{} },
+	},
 	{
 		Key: "in_parallel",
 		New: func() StepConfig { return &InParallelStep{} },
@@ -288,14 +318,19 @@ var StepPrecedence = []StepDetector{
 }
 
 type GetStep struct {
-	Name     string         `json:"get"`
-	Resource string         `json:"resource,omitempty"`
-	Version  *VersionConfig `json:"version,omitempty"`
-	Params   Params         `json:"params,omitempty"`
-	Passed   []string       `json:"passed,omitempty"`
-	Trigger  bool           `json:"trigger,omitempty"`
-	Tags     Tags           `json:"tags,omitempty"`
-	Timeout  string         `json:"timeout,omitempty"`
+	Name              string         `json:"get"`
+	Resource          string         `json:"resource,omitempty"`
+	Version           *VersionConfig `json:"version,omitempty"`
+	VersionConstraint string         `json:"version_constraint,omitempty"`
+	Params            Params         `json:"params,omitempty"`
+	Passed            []string       `json:"passed,omitempty"`
+	Trigger           bool           `json:"trigger,omitempty"`
+	Tags              *TagsConfig    `json:"tags,omitempty"`
+	Timeout           string         `json:"timeout,omitempty"`
+	MetadataVars      []string       `json:"metadata_vars,omitempty"`
+	Hosts             HostsConfig    `json:"hosts,omitempty"`
+	Runtime           string         `json:"runtime,omitempty"`
+	Unpack            string         `json:"unpack,omitempty"`
 }
 
 func (step *GetStep) ResourceName() string {
@@ -306,6 +341,14 @@ func (step *GetStep) ResourceName() string {
 	return step.Name
 }
 
+// The archive formats a get step can unpack.
+const (
+	UnpackFormatTarGz  = "tar.gz"
+	UnpackFormatTarZst = "tar.zst"
+)
+
+var UnpackFormats = []string{UnpackFormatTarGz, UnpackFormatTarZst}
+
 func (step *GetStep) Visit(v StepVisitor) error {
 	return v.VisitGet(step)
 }
@@ -315,9 +358,11 @@ type PutStep struct {
 	Resource  string        `json:"resource,omitempty"`
 	Params    Params        `json:"params,omitempty"`
 	Inputs    *InputsConfig `json:"inputs,omitempty"`
-	Tags      Tags          `json:"tags,omitempty"`
+	Tags      *TagsConfig   `json:"tags,omitempty"`
 	GetParams Params        `json:"get_params,omitempty"`
 	Timeout   string        `json:"timeout,omitempty"`
+	Hosts     HostsConfig   `json:"hosts,omitempty"`
+	Runtime   string        `json:"runtime,omitempty"`
 }
 
 func (step *PutStep) ResourceName() string {
@@ -340,11 +385,16 @@ type TaskStep struct {
 	Config            *TaskConfig       `json:"config,omitempty"`
 	Params            TaskEnv           `json:"params,omitempty"`
 	Vars              Params            `json:"vars,omitempty"`
-	Tags              Tags              `json:"tags,omitempty"`
+	Tags              *TagsConfig       `json:"tags,omitempty"`
 	InputMapping      map[string]string `json:"input_mapping,omitempty"`
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
 	Timeout           string            `json:"timeout,omitempty"`
+	WorkerName        string            `json:"worker_name,omitempty"`
+	CacheOutputs      bool              `json:"cache_outputs,omitempty"`
+	Init              bool              `json:"init,omitempty"`
+	Hosts             HostsConfig       `json:"hosts,omitempty"`
+	Runtime           string            `json:"runtime,omitempty"`
 }
 
 func (step *TaskStep) Visit(v StepVisitor) error {
@@ -369,12 +419,105 @@ type LoadVarStep struct {
 	File   string `json:"file,omitempty"`
 	Format string `json:"format,omitempty"`
 	Reveal bool   `json:"reveal,omitempty"`
+
+	// SetPipelineVar also sets the var as a var of the pipeline, so that the
+	// pipeline's resources can use a value produced by a build in their source,
+	// e.g. the name of a bucket the build provisioned. Resources resolve it on
+	// every check, after the credential manager, whose secrets it can't shadow.
+	// Like any credential it is redacted from build output. A new value makes
+	// a new config of the resource, which has a version history of its own.
+	SetPipelineVar bool `json:"set_pipeline_var,omitempty"`
 }
 
 func (step *LoadVarStep) Visit(v StepVisitor) error {
 	return v.VisitLoadVar(step)
 }
 
+type ApprovalStep struct {
+	Name string `json:"approval"`
+
+	// Timeout is how long to wait for a decision before the step fails. It is
+	// parsed by the step rather than wrapping it in a TimeoutStep so that the
+	// step fails instead of erroring.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+func (step *ApprovalStep) Visit(v StepVisitor) error {
+	return v.VisitApproval(step)
+}
+
+type PublishArtifactStep struct {
+	Name string `json:"publish_artifact"`
+
+	// From is the name of the artifact within the build to publish, if
+	// different from Name.
+	From string `json:"from,omitempty"`
+}
+
+func (step *PublishArtifactStep) ArtifactName() string {
+	if step.From != "" {
+		return step.From
+	}
+
+	return step.Name
+}
+
+func (step *PublishArtifactStep) Visit(v StepVisitor) error {
+	return v.VisitPublishArtifact(step)
+}
+
+type ConsumeArtifactStep struct {
+	Name string `json:"consume_artifact"`
+
+	// Team is the name of the team that published the artifact, if different
+	// from the team running the build.
+	Team string `json:"team,omitempty"`
+}
+
+func (step *ConsumeArtifactStep) Visit(v StepVisitor) error {
+	return v.VisitConsumeArtifact(step)
+}
+
+type LoadBuildOutputsStep struct {
+	Name string `json:"load_build_outputs"`
+
+	// Job is the job of the pipeline whose build's outputs are loaded.
+	Job string `json:"job"`
+
+	// Build is the name of the job's build to load the outputs of. The job's
+	// latest succeeded build is used if unset.
+	Build string `json:"build,omitempty"`
+
+	// Outputs limits the outputs which are loaded. Every output of the build
+	// is loaded if unset.
+	Outputs []string `json:"outputs,omitempty"`
+
+	Reveal bool `json:"reveal,omitempty"`
+}
+
+func (step *LoadBuildOutputsStep) Visit(v StepVisitor) error {
+	return v.VisitLoadBuildOutputs(step)
+}
+
+type AssertGreenStep struct {
+	// Job is the job whose latest finished build must have succeeded.
+	Job string `json:"assert_green"`
+
+	// Pipeline is the pipeline of the job, defaulting to the pipeline of the
+	// build running the step.
+	Pipeline     string       `json:"pipeline,omitempty"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+
+	// Team is the team of the pipeline, if different from the team running
+	// the build. Only jobs of public pipelines can be asserted on across
+	// teams.
+	Team string `json:"team,omitempty"`
+}
+
+func (step *AssertGreenStep) Visit(v StepVisitor) error {
+	return v.VisitAssertGreen(step)
+}
+
 type TryStep struct {
 	Step Step `json:"try"`
 }
@@ -391,6 +534,18 @@ func (step *DoStep) Visit(v StepVisitor) error {
 	return v.VisitDo(step)
 }
 
+// AtomicStep runs put steps so that they publish all or nothing: every put is
+// staged before any of them is committed, and the staged ones are rolled back
+// if one fails. Only resource types with two_phase_commit support staging;
+// puts to other types are only run when committing.
+type AtomicStep struct {
+	Steps []Step `json:"atomic"`
+}
+
+func (step *AtomicStep) Visit(v StepVisitor) error {
+	return v.VisitAtomic(step)
+}
+
 type InParallelStep struct {
 	Config InParallelConfig `json:"in_parallel"`
 }
@@ -403,6 +558,91 @@ type InParallelConfig struct {
 	Steps    []Step `json:"steps,omitempty"`
 	Limit    int    `json:"limit,omitempty"`
 	FailFast bool   `json:"fail_fast,omitempty"`
+
+	// FailFastGracePeriod bounds how long the steps aborted by FailFast get to
+	// run their cleanup (e.g. ensure and on_abort hooks) before it is cut short.
+	FailFastGracePeriod string `json:"fail_fast_grace_period,omitempty"`
+
+	// OutputPriority names the steps whose outputs take precedence when more
+	// than one step produces an artifact with the same name, highest priority
+	// first.
+	OutputPriority []string `json:"output_priority,omitempty"`
+}
+
+// PrioritizedSteps returns the indexes of the steps named by OutputPriority,
+// highest priority first. Names which do not match any step are skipped.
+func (c InParallelConfig) PrioritizedSteps() []int {
+	var indexes []int
+	for _, name := range c.OutputPriority {
+		i, found := c.stepIndex(name)
+		if found {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
+
+// stepIndex returns the index of the first step which contains a step with
+// the given name.
+func (c InParallelConfig) stepIndex(name string) (int, bool) {
+	for i, sub := range c.Steps {
+		found := false
+		matchName := func(stepName string) {
+			if stepName == name {
+				found = true
+			}
+		}
+
+		_ = sub.Config.Visit(StepRecursor{
+			OnTask: func(step *TaskStep) error {
+				matchName(step.Name)
+				return nil
+			},
+			OnGet: func(step *GetStep) error {
+				matchName(step.Name)
+				return nil
+			},
+			OnPut: func(step *PutStep) error {
+				matchName(step.Name)
+				return nil
+			},
+			OnSetPipeline: func(step *SetPipelineStep) error {
+				matchName(step.Name)
+				return nil
+			},
+			OnLoadVar: func(step *LoadVarStep) error {
+				matchName(step.Name)
+				return nil
+			},
+			OnApproval: func(step *ApprovalStep) error {
+				matchName(step.Name)
+				return nil
+			},
+			OnPublishArtifact: func(step *PublishArtifactStep) error {
+				matchName(step.Name)
+				return nil
+			},
+			OnConsumeArtifact: func(step *ConsumeArtifactStep) error {
+				matchName(step.Name)
+				return nil
+			},
+			OnLoadBuildOutputs: func(step *LoadBuildOutputsStep) error {
+				matchName(step.Name)
+				return nil
+			},
+			OnAssertGreen: func(step *AssertGreenStep) error {
+				matchName(step.Job)
+				return nil
+			},
+		})
+
+		if found {
+			return i, true
+		}
+	}
+
+	return 0, false
 }
 
 func (c *InParallelConfig) UnmarshalJSON(payload []byte) error {
@@ -426,7 +666,7 @@ func (c *InParallelConfig) UnmarshalJSON(payload []byte) error {
 			return fmt.Errorf("failed to unmarshal parallel config: %s", err)
 		}
 
-		c.Steps, c.Limit, c.FailFast = t.Steps, t.Limit, t.FailFast
+		c.Steps, c.Limit, c.FailFast, c.FailFastGracePeriod, c.OutputPriority = t.Steps, t.Limit, t.FailFast, t.FailFastGracePeriod, t.OutputPriority
 	default:
 		return fmt.Errorf("wrong type for parallel config: %v", actual)
 	}
@@ -478,6 +718,62 @@ func (step *AcrossStep) Unwrap() StepConfig {
 type RetryStep struct {
 	Step     StepConfig `json:"-"`
 	Attempts int        `json:"attempts"`
+
+	// Backoff is the delay before the second attempt. The delay doubles for
+	// every subsequent attempt.
+	Backoff string `json:"-"`
+
+	// MaxDuration bounds the total time spent across all attempts. No new
+	// attempt is started once it has elapsed.
+	MaxDuration string `json:"-"`
+}
+
+// RetryConfig is the object form of the `attempts:` modifier.
+type RetryConfig struct {
+	Count       int    `json:"count"`
+	Backoff     string `json:"backoff,omitempty"`
+	MaxDuration string `json:"max_duration,omitempty"`
+}
+
+func (step *RetryStep) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Attempts json.RawMessage `json:"attempts"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(raw.Attempts), []byte{'{'}) {
+		var config RetryConfig
+		err := unmarshalStrict(raw.Attempts, &config)
+		if err != nil {
+			return err
+		}
+
+		step.Attempts = config.Count
+		step.Backoff = config.Backoff
+		step.MaxDuration = config.MaxDuration
+		return nil
+	}
+
+	return json.Unmarshal(raw.Attempts, &step.Attempts)
+}
+
+func (step RetryStep) MarshalJSON() ([]byte, error) {
+	if step.Backoff == "" && step.MaxDuration == "" {
+		return json.Marshal(struct {
+			Attempts int `json:"attempts"`
+		}{step.Attempts})
+	}
+
+	return json.Marshal(struct {
+		Attempts RetryConfig `json:"attempts"`
+	}{RetryConfig{
+		Count:       step.Attempts,
+		Backoff:     step.Backoff,
+		MaxDuration: step.MaxDuration,
+	}})
 }
 
 func (step *RetryStep) Wrap(sub StepConfig) {
@@ -645,15 +941,74 @@ func (c *MaxInFlightConfig) EffectiveLimit(numSteps int) int {
 	return c.Limit
 }
 
+// A TagsConfig represents the worker tags a step is placed by. In its list
+// form a worker must have every tag. In its object form, `{any: [...]}`, a
+// worker must have at least one of the tags.
+type TagsConfig struct {
+	All Tags
+	Any Tags
+}
+
+func (c *TagsConfig) UnmarshalJSON(tags []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(tags), []byte{'{'}) {
+		var data struct {
+			Any Tags `json:"any"`
+		}
+
+		err := unmarshalStrict(tags, &data)
+		if err != nil {
+			return err
+		}
+
+		if len(data.Any) == 0 {
+			return errors.New("`any` must list at least one tag")
+		}
+
+		c.Any = data.Any
+		return nil
+	}
+
+	return json.Unmarshal(tags, &c.All)
+}
+
+func (c TagsConfig) MarshalJSON() ([]byte, error) {
+	if c.Any != nil {
+		return json.Marshal(map[string]Tags{"any": c.Any})
+	}
+
+	return json.Marshal(c.All)
+}
+
+// AllTags returns the tags which a worker must all have.
+func (c *TagsConfig) AllTags() Tags {
+	if c == nil {
+		return nil
+	}
+
+	return c.All
+}
+
+// AnyTags returns the tags of which a worker must have at least one.
+func (c *TagsConfig) AnyTags() Tags {
+	if c == nil {
+		return nil
+	}
+
+	return c.Any
+}
+
 // A VersionConfig represents the choice to include every version of a
-// resource, the latest version of a resource, or a pinned (specific) one.
+// resource, the latest version of a resource, the latest promoted version of a
+// resource, or a pinned (specific) one.
 type VersionConfig struct {
-	Every  bool
-	Latest bool
-	Pinned Version
+	Every        bool
+	Latest       bool
+	LatestStable bool
+	Pinned       Version
 }
 
 const VersionLatest = "latest"
+const VersionLatestStable = "latest-stable"
 const VersionEvery = "every"
 
 func (c *VersionConfig) UnmarshalJSON(version []byte) error {
@@ -668,6 +1023,7 @@ func (c *VersionConfig) UnmarshalJSON(version []byte) error {
 	case string:
 		c.Every = actual == VersionEvery
 		c.Latest = actual == VersionLatest
+		c.LatestStable = actual == VersionLatestStable
 	case map[string]interface{}:
 		version := Version{}
 
@@ -693,6 +1049,10 @@ func (c *VersionConfig) MarshalJSON() ([]byte, error) {
 		return json.Marshal(VersionLatest)
 	}
 
+	if c.LatestStable {
+		return json.Marshal(VersionLatestStable)
+	}
+
 	if c.Every {
 		return json.Marshal(VersionEvery)
 	}
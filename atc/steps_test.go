@@ -41,10 +41,21 @@ var factoryTests = []StepTest{
 			Resource: "some-resource",
 			Params:   atc.Params{"some": "params"},
 			Version:  &atc.VersionConfig{Pinned: atc.Version{"some": "version"}},
-			Tags:     []string{"tag-1", "tag-2"},
+			Tags:     &atc.TagsConfig{All: atc.Tags{"tag-1", "tag-2"}},
 			Timeout:  "1h",
 		},
 	},
+	{
+		Title: "get step with metadata vars",
+		ConfigYAML: `
+			get: some-name
+			metadata_vars: [message, author]
+		`,
+		StepConfig: &atc.GetStep{
+			Name:         "some-name",
+			MetadataVars: []string{"message", "author"},
+		},
+	},
 	{
 		Title: "put step",
 
@@ -61,7 +72,7 @@ var factoryTests = []StepTest{
 			Name:      "some-name",
 			Resource:  "some-resource",
 			Params:    atc.Params{"some": "params"},
-			Tags:      []string{"tag-1", "tag-2"},
+			Tags:      &atc.TagsConfig{All: atc.Tags{"tag-1", "tag-2"}},
 			Inputs:    &atc.InputsConfig{All: true},
 			GetParams: atc.Params{"some": "get-params"},
 			Timeout:   "1h",
@@ -96,13 +107,43 @@ var factoryTests = []StepTest{
 			ConfigPath:        "some-task-file",
 			Vars:              atc.Params{"some": "vars"},
 			Params:            atc.TaskEnv{"SOME": "PARAMS"},
-			Tags:              []string{"tag-1", "tag-2"},
+			Tags:              &atc.TagsConfig{All: atc.Tags{"tag-1", "tag-2"}},
 			InputMapping:      map[string]string{"generic": "specific"},
 			OutputMapping:     map[string]string{"specific": "generic"},
 			ImageArtifactName: "some-image",
 			Timeout:           "1h",
 		},
 	},
+	{
+		Title: "task step with output caching",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-task-file
+			cache_outputs: true
+		`,
+
+		StepConfig: &atc.TaskStep{
+			Name:         "some-task",
+			ConfigPath:   "some-task-file",
+			CacheOutputs: true,
+		},
+	},
+	{
+		Title: "task step with an init process",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-task-file
+			init: true
+		`,
+
+		StepConfig: &atc.TaskStep{
+			Name:       "some-task",
+			ConfigPath: "some-task-file",
+			Init:       true,
+		},
+	},
 	{
 		Title: "task step with container limits",
 
@@ -134,7 +175,7 @@ var factoryTests = []StepTest{
 			Vars:              atc.Params{"some": "vars"},
 			Params:            atc.TaskEnv{"SOME": "PARAMS"},
 			Limits:            &atc.ContainerLimits{CPU: newCPULimit(10), Memory: newMemoryLimit(1024)},
-			Tags:              []string{"tag-1", "tag-2"},
+			Tags:              &atc.TagsConfig{All: atc.Tags{"tag-1", "tag-2"}},
 			InputMapping:      map[string]string{"generic": "specific"},
 			OutputMapping:     map[string]string{"specific": "generic"},
 			ImageArtifactName: "some-image",
@@ -201,6 +242,81 @@ var factoryTests = []StepTest{
 			Reveal: true,
 		},
 	},
+	{
+		Title: "approval step",
+
+		ConfigYAML: `
+			approval: deploy
+			timeout: 1h
+		`,
+
+		StepConfig: &atc.ApprovalStep{
+			Name:    "deploy",
+			Timeout: "1h",
+		},
+	},
+	{
+		Title: "publish_artifact step",
+
+		ConfigYAML: `
+			publish_artifact: some-artifact
+			from: some-output
+		`,
+
+		StepConfig: &atc.PublishArtifactStep{
+			Name: "some-artifact",
+			From: "some-output",
+		},
+	},
+	{
+		Title: "consume_artifact step",
+
+		ConfigYAML: `
+			consume_artifact: some-artifact
+			team: other-team
+		`,
+
+		StepConfig: &atc.ConsumeArtifactStep{
+			Name: "some-artifact",
+			Team: "other-team",
+		},
+	},
+	{
+		Title: "load_build_outputs step",
+
+		ConfigYAML: `
+			load_build_outputs: some-outputs
+			job: some-job
+			build: "42"
+			outputs: [some-resource]
+			reveal: true
+		`,
+
+		StepConfig: &atc.LoadBuildOutputsStep{
+			Name:    "some-outputs",
+			Job:     "some-job",
+			Build:   "42",
+			Outputs: []string{"some-resource"},
+			Reveal:  true,
+		},
+	},
+	{
+		Title: "assert_green step",
+
+		ConfigYAML: `
+			assert_green: unit
+			pipeline: upstream
+			instance_vars: {branch: main}
+			team: other-team
+		`,
+
+		StepConfig: &atc.AssertGreenStep{
+			Job:          "unit",
+			Pipeline:     "upstream",
+			InstanceVars: atc.InstanceVars{"branch": "main"},
+			Team:         "other-team",
+		},
+	},
 	{
 		Title: "try step",
 
@@ -247,6 +363,30 @@ var factoryTests = []StepTest{
 			},
 		},
 	},
+	{
+		Title: "atomic step",
+
+		ConfigYAML: `
+			atomic:
+			- put: some-resource
+			- put: some-other-resource
+		`,
+
+		StepConfig: &atc.AtomicStep{
+			Steps: []atc.Step{
+				{
+					Config: &atc.PutStep{
+						Name: "some-resource",
+					},
+				},
+				{
+					Config: &atc.PutStep{
+						Name: "some-other-resource",
+					},
+				},
+			},
+		},
+	},
 	{
 		Title: "in_parallel step with simple list",
 
@@ -289,6 +429,7 @@ var factoryTests = []StepTest{
 			    file: some-other-file
 			  limit: 3
 			  fail_fast: true
+			  fail_fast_grace_period: 1m
 		`,
 
 		StepConfig: &atc.InParallelStep{
@@ -307,8 +448,42 @@ var factoryTests = []StepTest{
 						},
 					},
 				},
-				Limit:    3,
-				FailFast: true,
+				Limit:               3,
+				FailFast:            true,
+				FailFastGracePeriod: "1m",
+			},
+		},
+	},
+	{
+		Title: "in_parallel step with output priority",
+
+		ConfigYAML: `
+			in_parallel:
+			  steps:
+			  - task: some-task
+			    file: some-task-file
+			  - task: some-other-task
+			    file: some-other-task-file
+			  output_priority: [some-other-task, some-task]
+		`,
+
+		StepConfig: &atc.InParallelStep{
+			Config: atc.InParallelConfig{
+				Steps: []atc.Step{
+					{
+						Config: &atc.TaskStep{
+							Name:       "some-task",
+							ConfigPath: "some-task-file",
+						},
+					},
+					{
+						Config: &atc.TaskStep{
+							Name:       "some-other-task",
+							ConfigPath: "some-other-task-file",
+						},
+					},
+				},
+				OutputPriority: []string{"some-other-task", "some-task"},
 			},
 		},
 	},
@@ -399,6 +574,49 @@ var factoryTests = []StepTest{
 			Duration: "1h",
 		},
 	},
+	{
+		Title: "task step with any tags",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-file
+			tags:
+			  any: [gpu, bigmem]
+		`,
+
+		StepConfig: &atc.TaskStep{
+			Name:       "some-task",
+			ConfigPath: "some-file",
+			Tags:       &atc.TagsConfig{Any: atc.Tags{"gpu", "bigmem"}},
+		},
+	},
+	{
+		Title: "task step with empty any tags",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-file
+			tags:
+			  any: []
+		`,
+
+		Err: "`any` must list at least one tag",
+	},
+	{
+		Title: "task step with worker name",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-file
+			worker_name: some-worker
+		`,
+
+		StepConfig: &atc.TaskStep{
+			Name:       "some-task",
+			ConfigPath: "some-file",
+			WorkerName: "some-worker",
+		},
+	},
 	{
 		Title: "attempts modifier",
 
@@ -416,6 +634,28 @@ var factoryTests = []StepTest{
 			Attempts: 3,
 		},
 	},
+	{
+		Title: "attempts modifier with backoff",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			attempts:
+			  count: 5
+			  backoff: 10s
+			  max_duration: 5m
+		`,
+
+		StepConfig: &atc.RetryStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Attempts:    5,
+			Backoff:     "10s",
+			MaxDuration: "5m",
+		},
+	},
 	{
 		Title: "precedence of all hooks and modifiers",
 
@@ -36,6 +36,10 @@ type TaskConfig struct {
 
 	// Path to cached directory that will be shared between builds for the same task.
 	Caches []TaskCacheConfig `json:"caches,omitempty"`
+
+	// Extra entries to add to the container's /etc/hosts, mapping hostnames
+	// to IP addresses.
+	Hosts HostsConfig `json:"hosts,omitempty"`
 }
 
 type ImageResource struct {
@@ -45,6 +49,16 @@ type ImageResource struct {
 	Version Version `json:"version,omitempty"`
 	Params  Params  `json:"params,omitempty"`
 	Tags    Tags    `json:"tags,omitempty"`
+
+	// AnyTags are the worker tags of which the workers checking and fetching
+	// the image must have at least one. They are set from the tags of the
+	// step the image is for.
+	AnyTags Tags `json:"-"`
+
+	// CheckInterval is set when the image is that of a custom resource type
+	// which opts in to reusing its image. A version of the image checked
+	// within the interval is reused rather than checked for again.
+	CheckInterval string `json:"-"`
 }
 
 func (ir *ImageResource) ApplySourceDefaults(resourceTypes VersionedResourceTypes) {
@@ -99,6 +113,7 @@ func (config TaskConfig) Validate() error {
 
 	errors = append(errors, config.validateInputContainsNames()...)
 	errors = append(errors, config.validateOutputContainsNames()...)
+	errors = append(errors, config.Hosts.Validate()...)
 
 	if len(errors) > 0 {
 		return TaskValidationError{
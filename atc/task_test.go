@@ -295,6 +295,71 @@ run: {path: a/file}
 
 			})
 
+			Context("when an output size limit is specified", func() {
+				It("parses the limit with size units", func() {
+					data := []byte(`
+platform: beos
+container_limits: { output_size: 2TB }
+
+run: {path: a/file}
+`)
+					task, err := NewTaskConfig(data)
+					Expect(err).ToNot(HaveOccurred())
+					size := SizeLimit(2 * 1024 * 1024 * 1024 * 1024)
+					Expect(task.Limits).To(Equal(&ContainerLimits{
+						OutputSize: &size,
+					}))
+				})
+
+				It("parses the limit without size units", func() {
+					data := []byte(`
+platform: beos
+container_limits: { output_size: 1048576 }
+
+run: {path: a/file}
+`)
+					task, err := NewTaskConfig(data)
+					Expect(err).ToNot(HaveOccurred())
+					size := SizeLimit(1048576)
+					Expect(task.Limits).To(Equal(&ContainerLimits{
+						OutputSize: &size,
+					}))
+				})
+
+				It("errors when the limit is invalid", func() {
+					data := []byte(`
+platform: beos
+container_limits: { output_size: lots }
+
+run: {path: a/file}
+`)
+					_, err := NewTaskConfig(data)
+					Expect(err).To(MatchError(ContainSubstring("could not parse output size limit")))
+				})
+
+				It("errors when the limit overflows", func() {
+					data := []byte(`
+platform: beos
+container_limits: { output_size: 16777216TB }
+
+run: {path: a/file}
+`)
+					_, err := NewTaskConfig(data)
+					Expect(err).To(MatchError(ContainSubstring("output size limit is out of range")))
+				})
+
+				It("errors when the limit in bytes overflows", func() {
+					data := []byte(`
+platform: beos
+container_limits: { output_size: 18446744073709551616 }
+
+run: {path: a/file}
+`)
+					_, err := NewTaskConfig(data)
+					Expect(err).To(MatchError(ContainSubstring("output size limit is out of range")))
+				})
+			})
+
 			Context("when invalid cpu limit value is provided", func() {
 				It("throws an error and does not continue", func() {
 					data := []byte(`
@@ -395,6 +460,49 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has hosts", func() {
+			BeforeEach(func() {
+				validConfig.Hosts = HostsConfig{
+					"some-host":             "10.0.0.1",
+					"some-host.example.com": "fd00::1",
+				}
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when a hostname is invalid", func() {
+				BeforeEach(func() {
+					invalidConfig.Hosts = HostsConfig{"-some-host": "10.0.0.1"}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("invalid hostname '-some-host'")))
+				})
+			})
+
+			Context("when an IP address is invalid", func() {
+				BeforeEach(func() {
+					invalidConfig.Hosts = HostsConfig{"some-host": "10.0.0.256"}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("invalid IP address '10.0.0.256' for hostname 'some-host'")))
+				})
+			})
+
+			Context("when an entry has vars", func() {
+				BeforeEach(func() {
+					validConfig.Hosts = HostsConfig{"((some-host))": "((some-ip))"}
+				})
+
+				It("is valid, as it is validated once its vars are resolved", func() {
+					Expect(validConfig.Validate()).ToNot(HaveOccurred())
+				})
+			})
+		})
+
 	})
 
 })
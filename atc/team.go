@@ -7,18 +7,38 @@ import (
 var (
 	ErrAuthConfigEmpty   = errors.New("auth config for the team must not be empty")
 	ErrAuthConfigInvalid = errors.New("auth config for the team does not have users and groups configured")
+
+	ErrMaxConcurrentBuildsInvalid = errors.New("max concurrent builds of the team must not be negative")
 )
 
 type Team struct {
 	ID   int      `json:"id,omitempty"`
 	Name string   `json:"name,omitempty"`
 	Auth TeamAuth `json:"auth,omitempty"`
+
+	// The number of the team's job builds which may be running at once, with
+	// 0 meaning no limit. Left as is when setting a team if omitted.
+	MaxConcurrentBuilds *int `json:"max_concurrent_builds,omitempty"`
 }
 
 func (team Team) Validate() error {
+	if team.MaxConcurrentBuilds != nil && *team.MaxConcurrentBuilds < 0 {
+		return ErrMaxConcurrentBuildsInvalid
+	}
+
 	return team.Auth.Validate()
 }
 
+// TeamUsage is a team's share of the cluster, as last computed at UpdatedAt.
+type TeamUsage struct {
+	Team       string `json:"team"`
+	Pipelines  int    `json:"pipelines"`
+	Resources  int    `json:"resources"`
+	Containers int    `json:"containers"`
+	Volumes    int    `json:"volumes"`
+	UpdatedAt  int64  `json:"updated_at"`
+}
+
 type TeamAuth map[string]map[string][]string
 
 func (auth TeamAuth) Validate() error {
@@ -0,0 +1,151 @@
+package atc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const triggerWindowTimeLayout = "15:04"
+
+// TriggerWindowConfig restricts the versions of a resource which trigger
+// builds to those created within a daily time window. Versions created outside
+// of the window are still saved and can be used by builds triggered otherwise.
+type TriggerWindowConfig struct {
+	// Field is the metadata field holding the time the version was created.
+	// Versions without it, such as those saved by a check, which has no
+	// metadata, are instead timed by when they were first saved. Defaults to
+	// always timing versions by when they were first saved.
+	Field string `json:"field,omitempty"`
+
+	// Layout is the Go time layout of the field. Defaults to RFC 3339.
+	Layout string `json:"layout,omitempty"`
+
+	// Start and Stop are the times of day the window opens and closes at, as
+	// HH:MM. The window spans midnight if it closes before it opens.
+	Start string `json:"start"`
+	Stop  string `json:"stop"`
+
+	// Location is the time zone of the window. Defaults to UTC.
+	Location string `json:"location,omitempty"`
+
+	// Days are the days of the week the window opens on. Defaults to every
+	// day.
+	Days []string `json:"days,omitempty"`
+}
+
+// Validate returns an error for every invalid setting of the window.
+func (window TriggerWindowConfig) Validate() []string {
+	var errors []string
+	if window.Layout != "" {
+		if window.Field == "" {
+			errors = append(errors, "layout given without a metadata field")
+		}
+
+		if !validTimeLayout(window.Layout) {
+			errors = append(errors, fmt.Sprintf("invalid layout '%s' (must give the date and time of day)", window.Layout))
+		}
+	}
+
+	if _, err := time.Parse(triggerWindowTimeLayout, window.Start); err != nil {
+		errors = append(errors, fmt.Sprintf("invalid start '%s' (must be HH:MM)", window.Start))
+	}
+
+	if _, err := time.Parse(triggerWindowTimeLayout, window.Stop); err != nil {
+		errors = append(errors, fmt.Sprintf("invalid stop '%s' (must be HH:MM)", window.Stop))
+	}
+
+	if _, err := time.LoadLocation(window.Location); err != nil {
+		errors = append(errors, fmt.Sprintf("invalid location '%s'", window.Location))
+	}
+
+	for _, day := range window.Days {
+		if _, found := parseWeekday(day); !found {
+			errors = append(errors, fmt.Sprintf("invalid day '%s'", day))
+		}
+	}
+
+	return errors
+}
+
+// ParseTimestamp parses the value of the window's metadata field.
+func (window TriggerWindowConfig) ParseTimestamp(value string) (time.Time, error) {
+	layout := window.Layout
+	if layout == "" {
+		layout = time.RFC3339
+	}
+
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse %s: %w", window.Field, err)
+	}
+
+	return t, nil
+}
+
+// Includes returns whether the time falls within the window.
+func (window TriggerWindowConfig) Includes(t time.Time) (bool, error) {
+	location, err := time.LoadLocation(window.Location)
+	if err != nil {
+		return false, err
+	}
+
+	start, err := time.Parse(triggerWindowTimeLayout, window.Start)
+	if err != nil {
+		return false, err
+	}
+
+	stop, err := time.Parse(triggerWindowTimeLayout, window.Stop)
+	if err != nil {
+		return false, err
+	}
+
+	t = t.In(location)
+
+	if len(window.Days) > 0 && !window.opensOn(t.Weekday()) {
+		return false, nil
+	}
+
+	minute := t.Hour()*60 + t.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	stopMinute := stop.Hour()*60 + stop.Minute()
+
+	if startMinute <= stopMinute {
+		return minute >= startMinute && minute < stopMinute, nil
+	}
+
+	return minute >= startMinute || minute < stopMinute, nil
+}
+
+func (window TriggerWindowConfig) opensOn(weekday time.Weekday) bool {
+	for _, day := range window.Days {
+		if d, found := parseWeekday(day); found && d == weekday {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseWeekday(day string) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(day, weekday.String()) {
+			return weekday, true
+		}
+	}
+
+	return 0, false
+}
+
+// validTimeLayout returns whether times formatted with the layout parse back
+// to the same minute, which they don't if it lacks the date or time of day.
+func validTimeLayout(layout string) bool {
+	reference := time.Date(2021, time.November, 23, 13, 45, 0, 0, time.UTC)
+
+	t, err := time.Parse(layout, reference.Format(layout))
+	if err != nil {
+		return false
+	}
+
+	return t.Equal(reference)
+}
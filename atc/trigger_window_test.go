@@ -0,0 +1,54 @@
+package atc_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TriggerWindowConfig", func() {
+	DescribeTable("Includes",
+		func(window atc.TriggerWindowConfig, timestamp string, included bool) {
+			window.Field = "created_at"
+
+			t, err := window.ParseTimestamp(timestamp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(window.Includes(t)).To(Equal(included))
+		},
+		Entry("within the window", atc.TriggerWindowConfig{Start: "09:00", Stop: "17:00"}, "2021-04-20T09:00:00Z", true),
+		Entry("before the window", atc.TriggerWindowConfig{Start: "09:00", Stop: "17:00"}, "2021-04-20T08:59:59Z", false),
+		Entry("when the window closes", atc.TriggerWindowConfig{Start: "09:00", Stop: "17:00"}, "2021-04-20T17:00:00Z", false),
+		Entry("in another time zone", atc.TriggerWindowConfig{Start: "09:00", Stop: "17:00"}, "2021-04-20T10:00:00-08:00", false),
+		Entry("in the window's location", atc.TriggerWindowConfig{Start: "09:00", Stop: "17:00", Location: "America/Vancouver"}, "2021-04-20T17:00:00Z", true),
+		Entry("after midnight in a window spanning it", atc.TriggerWindowConfig{Start: "22:00", Stop: "02:00"}, "2021-04-20T01:00:00Z", true),
+		Entry("outside of a window spanning midnight", atc.TriggerWindowConfig{Start: "22:00", Stop: "02:00"}, "2021-04-20T12:00:00Z", false),
+		Entry("on one of the window's days", atc.TriggerWindowConfig{Start: "09:00", Stop: "17:00", Days: []string{"Monday", "tuesday"}}, "2021-04-20T12:00:00Z", true),
+		Entry("on another day", atc.TriggerWindowConfig{Start: "09:00", Stop: "17:00", Days: []string{"Saturday"}}, "2021-04-20T12:00:00Z", false),
+		Entry("with a custom layout", atc.TriggerWindowConfig{Start: "09:00", Stop: "17:00", Layout: "2006-01-02 15:04:05 -0700"}, "2021-04-20 12:00:00 +0000", true),
+	)
+
+	It("errors when the timestamp doesn't match the layout", func() {
+		window := atc.TriggerWindowConfig{Field: "created_at", Start: "09:00", Stop: "17:00"}
+
+		_, err := window.ParseTimestamp("yesterday")
+		Expect(err).To(MatchError(ContainSubstring("parse created_at")))
+	})
+
+	DescribeTable("Validate",
+		func(window atc.TriggerWindowConfig, errs []string) {
+			window.Start = "09:00"
+			window.Stop = "17:00"
+			Expect(window.Validate()).To(Equal(errs))
+		},
+		Entry("without a field", atc.TriggerWindowConfig{}, nil),
+		Entry("with a field", atc.TriggerWindowConfig{Field: "created_at"}, nil),
+		Entry("with a layout", atc.TriggerWindowConfig{Field: "created_at", Layout: "2006-01-02 15:04:05 -0700"}, nil),
+		Entry("with a layout without a date", atc.TriggerWindowConfig{Field: "created_at", Layout: "15:04"}, []string{"invalid layout '15:04' (must give the date and time of day)"}),
+		Entry("with a layout without a time of day", atc.TriggerWindowConfig{Field: "created_at", Layout: "2006-01-02"}, []string{"invalid layout '2006-01-02' (must give the date and time of day)"}),
+		Entry("with a layout without a field", atc.TriggerWindowConfig{Layout: time.RFC1123Z}, []string{"layout given without a metadata field"}),
+	)
+})
@@ -0,0 +1,388 @@
+package atc
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Semver is a semantic version, as described by https://semver.org. Build
+// metadata is ignored as it does not affect precedence.
+type Semver struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease []string
+}
+
+// ParseSemver parses a version of the form MAJOR.MINOR.PATCH, optionally
+// prefixed with 'v' and followed by a pre-release and build metadata.
+func ParseSemver(str string) (Semver, error) {
+	var version Semver
+
+	parts, prerelease, err := splitSemver(str)
+	if err != nil {
+		return Semver{}, err
+	}
+
+	if len(parts) != 3 {
+		return Semver{}, fmt.Errorf("invalid semver '%s': expected MAJOR.MINOR.PATCH", str)
+	}
+
+	components := []*uint64{&version.Major, &version.Minor, &version.Patch}
+	for i, part := range parts {
+		*components[i], err = parseSemverComponent(str, part)
+		if err != nil {
+			return Semver{}, err
+		}
+	}
+
+	version.Prerelease = prerelease
+
+	return version, nil
+}
+
+func (v Semver) String() string {
+	str := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		str += "-" + strings.Join(v.Prerelease, ".")
+	}
+
+	return str
+}
+
+// Compare returns -1, 0, or 1 depending on whether the version has a lower,
+// equal, or higher precedence than the other.
+func (v Semver) Compare(other Semver) int {
+	for _, cmp := range [][2]uint64{
+		{v.Major, other.Major},
+		{v.Minor, other.Minor},
+		{v.Patch, other.Patch},
+	} {
+		if cmp[0] < cmp[1] {
+			return -1
+		}
+
+		if cmp[0] > cmp[1] {
+			return 1
+		}
+	}
+
+	// a pre-release version has a lower precedence than the release
+	switch {
+	case len(v.Prerelease) == 0 && len(other.Prerelease) == 0:
+		return 0
+	case len(v.Prerelease) == 0:
+		return 1
+	case len(other.Prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.Prerelease) && i < len(other.Prerelease); i++ {
+		cmp := comparePrereleaseIdentifiers(v.Prerelease[i], other.Prerelease[i])
+		if cmp != 0 {
+			return cmp
+		}
+	}
+
+	switch {
+	case len(v.Prerelease) < len(other.Prerelease):
+		return -1
+	case len(v.Prerelease) > len(other.Prerelease):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func comparePrereleaseIdentifiers(a, b string) int {
+	aNum, aErr := strconv.ParseUint(a, 10, 64)
+	bNum, bErr := strconv.ParseUint(b, 10, 64)
+
+	switch {
+	case aErr == nil && bErr == nil:
+		switch {
+		case aNum < bNum:
+			return -1
+		case aNum > bNum:
+			return 1
+		default:
+			return 0
+		}
+	case aErr == nil:
+		// numeric identifiers have a lower precedence than alphanumeric ones
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// VersionConstraint is a range of semantic versions. Comparisons separated by
+// commas or whitespace must all be satisfied, and '||' separates
+// alternatives, e.g.:
+//
+//	>= 2.0.0, < 3.0.0, != 2.5.0 || 4.1.x
+//
+// Supported operators are =, !=, >, >=, <, and <=. Omitting the operator is
+// the same as '='. Patch and minor components may be wildcards ('x' or '*')
+// or omitted, so '2.x' matches any 2.MINOR.PATCH version.
+//
+// Pre-release versions are only matched by constraints which mention a
+// pre-release themselves.
+type VersionConstraint struct {
+	alternatives []versionComparisons
+}
+
+type versionComparisons struct {
+	comparisons       []versionComparison
+	allowsPrereleases bool
+}
+
+type versionComparison struct {
+	operator string
+	version  Semver
+}
+
+var operatorSpacing = regexp.MustCompile(`(>=|<=|!=|==|=|>|<)\s+`)
+
+// ParseVersionConstraint parses a constraint expression.
+func ParseVersionConstraint(expr string) (VersionConstraint, error) {
+	var constraint VersionConstraint
+
+	for _, alternative := range strings.Split(expr, "||") {
+		alternative = operatorSpacing.ReplaceAllString(alternative, "$1")
+
+		terms := strings.FieldsFunc(alternative, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+
+		if len(terms) == 0 {
+			return VersionConstraint{}, fmt.Errorf("invalid version constraint '%s': empty range", expr)
+		}
+
+		var comparisons versionComparisons
+		for _, term := range terms {
+			expanded, prerelease, err := parseVersionComparison(term)
+			if err != nil {
+				return VersionConstraint{}, fmt.Errorf("invalid version constraint '%s': %w", expr, err)
+			}
+
+			if prerelease {
+				comparisons.allowsPrereleases = true
+			}
+
+			comparisons.comparisons = append(comparisons.comparisons, expanded...)
+		}
+
+		constraint.alternatives = append(constraint.alternatives, comparisons)
+	}
+
+	return constraint, nil
+}
+
+// parseVersionComparison expands a single comparison into the comparisons
+// against full versions that it implies, and returns whether it mentions a
+// pre-release.
+func parseVersionComparison(term string) ([]versionComparison, bool, error) {
+	var operator string
+	for _, op := range []string{">=", "<=", "!=", "==", "=", ">", "<"} {
+		if strings.HasPrefix(term, op) {
+			operator = op
+			break
+		}
+	}
+
+	str := strings.TrimPrefix(term, operator)
+	if operator == "" || operator == "==" {
+		operator = "="
+	}
+
+	parts, prerelease, err := splitSemver(str)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(parts) > 3 {
+		return nil, false, fmt.Errorf("invalid semver '%s': too many components", str)
+	}
+
+	var components []uint64
+	for _, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+
+		component, err := parseSemverComponent(str, part)
+		if err != nil {
+			return nil, false, err
+		}
+
+		components = append(components, component)
+	}
+
+	if len(components) == 3 {
+		return []versionComparison{{
+			operator: operator,
+			version: Semver{
+				Major:      components[0],
+				Minor:      components[1],
+				Patch:      components[2],
+				Prerelease: prerelease,
+			},
+		}}, len(prerelease) > 0, nil
+	}
+
+	if len(prerelease) > 0 {
+		return nil, false, fmt.Errorf("invalid semver '%s': pre-release requires MAJOR.MINOR.PATCH", str)
+	}
+
+	if len(components) == 0 {
+		switch operator {
+		case "=", ">=", "<=":
+			// matches any version
+			return []versionComparison{{operator: ">=", version: Semver{}}}, false, nil
+		default:
+			return nil, false, fmt.Errorf("wildcard '%s' cannot be used with '%s'", str, operator)
+		}
+	}
+
+	// a partial version covers the range [lower, upper)
+	lower := Semver{Major: components[0]}
+	upper := Semver{Major: components[0] + 1}
+	if len(components) == 2 {
+		lower.Minor = components[1]
+		upper = Semver{Major: components[0], Minor: components[1] + 1}
+	}
+
+	// the lowest pre-release of the upper bound, so that pre-releases of it
+	// are excluded from the range too
+	upper.Prerelease = []string{"0"}
+
+	switch operator {
+	case "=":
+		return []versionComparison{{">=", lower}, {"<", upper}}, false, nil
+	case ">=":
+		return []versionComparison{{">=", lower}}, false, nil
+	case ">":
+		return []versionComparison{{">=", upper}}, false, nil
+	case "<":
+		return []versionComparison{{"<", lower}}, false, nil
+	case "<=":
+		return []versionComparison{{"<", upper}}, false, nil
+	default:
+		return nil, false, fmt.Errorf("partial version '%s' cannot be used with '%s'", str, operator)
+	}
+}
+
+func splitSemver(str string) ([]string, []string, error) {
+	str = strings.TrimPrefix(str, "v")
+
+	if i := strings.Index(str, "+"); i != -1 {
+		str = str[:i]
+	}
+
+	var prerelease []string
+	if i := strings.Index(str, "-"); i != -1 {
+		prerelease = strings.Split(str[i+1:], ".")
+		str = str[:i]
+
+		for _, identifier := range prerelease {
+			if identifier == "" {
+				return nil, nil, fmt.Errorf("invalid semver '%s': empty pre-release identifier", str)
+			}
+		}
+	}
+
+	if str == "" {
+		return nil, nil, errors.New("invalid semver: empty version")
+	}
+
+	return strings.Split(str, "."), prerelease, nil
+}
+
+func parseSemverComponent(str, part string) (uint64, error) {
+	component, err := strconv.ParseUint(part, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid semver '%s': '%s' is not a number", str, part)
+	}
+
+	return component, nil
+}
+
+// Allows returns whether the version satisfies the constraint.
+func (c VersionConstraint) Allows(version Semver) bool {
+	for _, alternative := range c.alternatives {
+		if alternative.allows(version) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (comparisons versionComparisons) allows(version Semver) bool {
+	if len(version.Prerelease) > 0 && !comparisons.allowsPrereleases {
+		return false
+	}
+
+	for _, comparison := range comparisons.comparisons {
+		if !comparison.allows(version) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (comparison versionComparison) allows(version Semver) bool {
+	cmp := version.Compare(comparison.version)
+
+	switch comparison.operator {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return false
+	}
+}
+
+// ErrVersionNotSemver is returned when none of the fields of a resource
+// version parses as a semantic version.
+var ErrVersionNotSemver = errors.New("version has no semver field")
+
+// AllowsVersion returns whether the resource version satisfies the
+// constraint. The first field of the version, in order of name, which parses
+// as a semantic version is the one compared.
+func (c VersionConstraint) AllowsVersion(version Version) (bool, error) {
+	fields := make([]string, 0, len(version))
+	for field := range version {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		semver, err := ParseSemver(version[field])
+		if err != nil {
+			continue
+		}
+
+		return c.Allows(semver), nil
+	}
+
+	return false, ErrVersionNotSemver
+}
@@ -0,0 +1,98 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VersionConstraint", func() {
+	DescribeTable("Allows",
+		func(expr string, version string, allowed bool) {
+			constraint, err := atc.ParseVersionConstraint(expr)
+			Expect(err).ToNot(HaveOccurred())
+
+			semver, err := atc.ParseSemver(version)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(constraint.Allows(semver)).To(Equal(allowed))
+		},
+		Entry("exact match", "1.2.3", "1.2.3", true),
+		Entry("exact mismatch", "1.2.3", "1.2.4", false),
+		Entry("with a v prefix", "=v1.2.3", "v1.2.3", true),
+		Entry("greater than", "> 1.2.3", "1.2.4", true),
+		Entry("not greater than", ">1.2.3", "1.2.3", false),
+		Entry("less than or equal", "<= 1.2.3", "1.2.3", true),
+		Entry("not equal", "!= 1.2.3", "1.2.3", false),
+		Entry("within a range", ">= 2.0.0, < 3.0.0", "2.9.9", true),
+		Entry("outside of a range", ">= 2.0.0 < 3.0.0", "3.0.0", false),
+		Entry("within a wildcard", "2.x", "2.5.1", true),
+		Entry("outside of a wildcard", "2.x", "3.0.0", false),
+		Entry("within a partial version", "2.5", "2.5.9", true),
+		Entry("outside of a partial version", "2.5", "2.6.0", false),
+		Entry("above a wildcard", "> 2.x", "3.0.0", true),
+		Entry("not above a wildcard", "> 2.x", "2.9.0", false),
+		Entry("at most a wildcard", "<= 2.x", "2.9.0", true),
+		Entry("excluded from a wildcard", "2.x, != 2.5.0", "2.5.0", false),
+		Entry("any version", "*", "0.0.1", true),
+		Entry("matching an alternative", "1.x || 3.x", "3.1.0", true),
+		Entry("matching no alternative", "1.x || 3.x", "2.1.0", false),
+		Entry("ignoring build metadata", "1.2.3", "1.2.3+build.1", true),
+		Entry("excluding pre-releases by default", "2.x", "2.5.0-rc.1", false),
+		Entry("excluding pre-releases of the upper bound", "< 3.0.0-0 || 2.x", "3.0.0-rc.1", false),
+		Entry("including pre-releases when mentioned", ">= 2.5.0-rc.1", "2.5.0-rc.2", true),
+		Entry("ordering pre-releases before releases", ">= 2.5.0-rc.1, < 2.5.0", "2.5.0-rc.2", true),
+		Entry("ordering numeric pre-release identifiers", "> 1.0.0-rc.2", "1.0.0-rc.10", true),
+		Entry("ordering alphanumeric pre-release identifiers", "> 1.0.0-alpha", "1.0.0-beta", true),
+		Entry("ordering numeric identifiers before alphanumeric ones", "> 1.0.0-1", "1.0.0-alpha", true),
+		Entry("ordering shorter pre-releases first", "> 1.0.0-alpha", "1.0.0-alpha.1", true),
+	)
+
+	DescribeTable("invalid constraints",
+		func(expr string) {
+			_, err := atc.ParseVersionConstraint(expr)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("empty alternative", "1.x ||"),
+		Entry("not a version", "latest"),
+		Entry("too many components", "1.2.3.4"),
+		Entry("negated wildcard", "!= 2.x"),
+		Entry("pre-release of a partial version", "2.5-rc.1"),
+	)
+
+	Describe("AllowsVersion", func() {
+		var constraint atc.VersionConstraint
+
+		BeforeEach(func() {
+			var err error
+			constraint, err = atc.ParseVersionConstraint("2.x")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("compares the field which parses as semver", func() {
+			allowed, err := constraint.AllowsVersion(atc.Version{
+				"digest": "sha256:abcdef",
+				"tag":    "2.1.0",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allowed).To(BeTrue())
+		})
+
+		It("compares the first such field by name", func() {
+			allowed, err := constraint.AllowsVersion(atc.Version{
+				"a": "3.0.0",
+				"b": "2.1.0",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allowed).To(BeFalse())
+		})
+
+		It("errors when no field parses as semver", func() {
+			_, err := constraint.AllowsVersion(atc.Version{"ref": "abcdef"})
+			Expect(err).To(Equal(atc.ErrVersionNotSemver))
+		})
+	})
+})
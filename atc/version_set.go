@@ -0,0 +1,10 @@
+package atc
+
+// VersionSet is a named snapshot of the versions of a pipeline's resources,
+// keyed by resource name, that can be pinned and unpinned together.
+type VersionSet struct {
+	Name      string             `json:"name"`
+	CreatedAt int64              `json:"created_at"`
+	Applied   bool               `json:"applied"`
+	Versions  map[string]Version `json:"versions"`
+}
@@ -1,5 +1,10 @@
 package atc
 
+import (
+	"sort"
+	"strings"
+)
+
 type VersionedResourceType struct {
 	ResourceType
 
@@ -43,3 +48,98 @@ func (types VersionedResourceTypes) Base(name string) string {
 
 	return base
 }
+
+// Closure returns every resource type the named types are built on: the
+// custom types along each of their chains, in the order they are first
+// reached, and the base types the chains end at. A chain which comes back to
+// a custom type it has already passed through is reported as a cycle instead
+// of ending at a base type. A type of the same name as its own type overrides
+// the base type, and is not a cycle.
+func (types VersionedResourceTypes) Closure(names ...string) ResourceTypeClosure {
+	closure := ResourceTypeClosure{
+		Types: []ResourceTypeDependency{},
+	}
+
+	type typeKey struct {
+		name string
+		base bool
+	}
+
+	added := map[typeKey]bool{}
+	add := func(dependency ResourceTypeDependency) {
+		key := typeKey{dependency.Name, dependency.Base}
+		if !added[key] {
+			added[key] = true
+			closure.Types = append(closure.Types, dependency)
+		}
+	}
+
+	cycles := map[string]bool{}
+	for _, name := range names {
+		chain, base, cycle := types.chain(name)
+
+		for _, resourceType := range chain {
+			add(ResourceTypeDependency{
+				Name:    resourceType.Name,
+				Type:    resourceType.Type,
+				Source:  resourceType.Source,
+				Version: resourceType.Version,
+			})
+		}
+
+		if cycle != nil {
+			members := append([]string{}, cycle[1:]...)
+			sort.Strings(members)
+
+			key := strings.Join(members, "\x00")
+			if !cycles[key] {
+				cycles[key] = true
+				closure.Cycles = append(closure.Cycles, cycle)
+			}
+
+			continue
+		}
+
+		add(ResourceTypeDependency{
+			Name: base,
+			Base: true,
+		})
+	}
+
+	return closure
+}
+
+// chain returns the custom types the named type is built on, in order, along
+// with either the base type at the end of the chain or, if the chain comes
+// back to one of them, the names of the types going around the cycle.
+func (types VersionedResourceTypes) chain(name string) (VersionedResourceTypes, string, []string) {
+	var chain VersionedResourceTypes
+
+	base := name
+	for {
+		resourceType, found := types.Lookup(base)
+		if !found {
+			return chain, base, nil
+		}
+
+		chain = append(chain, resourceType)
+
+		types = types.Without(base)
+		base = resourceType.Type
+
+		if base == resourceType.Name {
+			continue
+		}
+
+		for i, t := range chain {
+			if t.Name == base {
+				var cycle []string
+				for _, t := range chain[i:] {
+					cycle = append(cycle, t.Name)
+				}
+
+				return chain, "", append(cycle, base)
+			}
+		}
+	}
+}
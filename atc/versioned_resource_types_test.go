@@ -63,4 +63,73 @@ var _ = Describe("VersionedResourceTypes", func() {
 			})
 		})
 	})
+
+	Describe("Closure", func() {
+		It("returns the custom types along each chain and the base types they end at", func() {
+			Expect(types.Closure("nested-type", "overridden-base-type", "bogus")).To(Equal(atc.ResourceTypeClosure{
+				Types: []atc.ResourceTypeDependency{
+					{
+						Name:    "nested-type",
+						Type:    "some-type",
+						Source:  atc.Source{"nested": "source"},
+						Version: atc.Version{"nested": "version"},
+					},
+					{
+						Name:    "some-type",
+						Type:    "some-base-type",
+						Source:  atc.Source{"some": "source"},
+						Version: atc.Version{"some": "version"},
+					},
+					{Name: "some-base-type", Base: true},
+					{
+						Name:    "overridden-base-type",
+						Type:    "overridden-base-type",
+						Source:  atc.Source{"overriding": "source"},
+						Version: atc.Version{"overriding": "version"},
+					},
+					{Name: "overridden-base-type", Base: true},
+					{Name: "bogus", Base: true},
+				},
+			}))
+		})
+
+		It("returns the types shared by chains once", func() {
+			closure := types.Closure("some-type", "nested-type")
+			Expect(closure.Types).To(HaveLen(3))
+			Expect(closure.Types[0].Name).To(Equal("some-type"))
+			Expect(closure.Types[1].Name).To(Equal("some-base-type"))
+			Expect(closure.Types[2].Name).To(Equal("nested-type"))
+		})
+
+		Context("when the custom types form a cycle", func() {
+			BeforeEach(func() {
+				types = append(types,
+					atc.VersionedResourceType{
+						ResourceType: atc.ResourceType{Name: "cycle-a", Type: "cycle-b"},
+					},
+					atc.VersionedResourceType{
+						ResourceType: atc.ResourceType{Name: "cycle-b", Type: "cycle-c"},
+					},
+					atc.VersionedResourceType{
+						ResourceType: atc.ResourceType{Name: "cycle-c", Type: "cycle-b"},
+					},
+				)
+			})
+
+			It("reports the cycle once instead of a base type", func() {
+				closure := types.Closure("cycle-a", "cycle-c")
+				Expect(closure.Cycles).To(Equal([][]string{
+					{"cycle-b", "cycle-c", "cycle-b"},
+				}))
+
+				var names []string
+				for _, t := range closure.Types {
+					Expect(t.Base).To(BeFalse())
+					names = append(names, t.Name)
+				}
+
+				Expect(names).To(Equal([]string{"cycle-a", "cycle-b", "cycle-c"}))
+			})
+		})
+	})
 })
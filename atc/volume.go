@@ -26,3 +26,30 @@ type Volume struct {
 	JobName              string                  `json:"job_name"`
 	StepName             string                  `json:"step_name"`
 }
+
+// BuildVolumes are the containers created on workers for a build and the
+// volumes created for it, along with the volumes those were copied on write
+// from.
+type BuildVolumes struct {
+	Containers []Container   `json:"containers"`
+	Volumes    []BuildVolume `json:"volumes"`
+}
+
+type BuildVolume struct {
+	ID              string `json:"id"`
+	WorkerName      string `json:"worker_name"`
+	State           string `json:"state"`
+	Type            string `json:"type"`
+	ContainerHandle string `json:"container_handle,omitempty"`
+	ParentHandle    string `json:"parent_handle,omitempty"`
+	Path            string `json:"path,omitempty"`
+
+	// SizeBytes is the size of the volume as last reported by its worker,
+	// which for a copy-on-write volume includes what it shares with its
+	// parent.
+	SizeBytes *uint64 `json:"size_bytes,omitempty"`
+
+	// Inherited volumes weren't created for the build, but volumes of the
+	// build were copied on write from them.
+	Inherited bool `json:"inherited,omitempty"`
+}
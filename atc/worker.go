@@ -23,6 +23,7 @@ type Worker struct {
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
 	Platform  string   `json:"platform"`
+	Runtime   string   `json:"runtime,omitempty"`
 	Tags      []string `json:"tags"`
 	Team      string   `json:"team"`
 	Name      string   `json:"name"`
@@ -32,6 +33,15 @@ type Worker struct {
 	State     string   `json:"state"`
 }
 
+// The container runtimes a worker can be running.
+const (
+	WorkerRuntimeGuardian   = "guardian"
+	WorkerRuntimeContainerd = "containerd"
+	WorkerRuntimeHoudini    = "houdini"
+)
+
+var WorkerRuntimes = []string{WorkerRuntimeGuardian, WorkerRuntimeContainerd, WorkerRuntimeHoudini}
+
 var ErrInvalidWorkerVersion = errors.New("invalid worker version, only numeric characters are allowed")
 var ErrMissingWorkerGardenAddress = errors.New("missing garden address")
 var ErrNoWorkers = errors.New("no workers available for checking")
@@ -59,3 +69,64 @@ type WorkerResourceType struct {
 type PruneWorkerResponseBody struct {
 	Stderr string `json:"stderr"`
 }
+
+// PruneStalledWorkersResponse lists the stalled workers which were pruned, and
+// those which were left alone because containers of running builds are still
+// on them.
+type PruneStalledWorkersResponse struct {
+	Pruned []string `json:"pruned"`
+	Busy   []string `json:"busy,omitempty"`
+}
+
+type WarmWorkerRequest struct {
+	ResourceConfigVersionIDs []int `json:"resource_config_version_ids"`
+}
+
+const (
+	WarmStatusStreamed    = "streamed"
+	WarmStatusAlreadyWarm = "already-warm"
+	WarmStatusFailed      = "failed"
+)
+
+// WorkerUsage is the number of containers and volumes on a worker, both as
+// reported by the worker on its last heartbeat and as tracked by the ATC, and
+// the disk usage last reported by the worker, if it reports it.
+type WorkerUsage struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+	Team  string `json:"team,omitempty"`
+
+	ActiveContainers int `json:"active_containers"`
+	ActiveVolumes    int `json:"active_volumes"`
+	ActiveTasks      int `json:"active_tasks"`
+
+	BuildContainers int `json:"build_containers"`
+	Volumes         int `json:"volumes"`
+
+	DiskUsage *WorkerDiskUsage `json:"disk_usage,omitempty"`
+}
+
+// WarmedResourceCache is the outcome of warming a worker with one resource
+// cache of a resource config version.
+type WarmedResourceCache struct {
+	ResourceConfigVersionID int    `json:"resource_config_version_id"`
+	ResourceCacheID         int    `json:"resource_cache_id,omitempty"`
+	Status                  string `json:"status"`
+	Error                   string `json:"error,omitempty"`
+}
+
+// WorkerDiskUsage is how much of the disk holding a worker's volumes is in
+// use, as measured by the worker.
+type WorkerDiskUsage struct {
+	UsedBytes  uint64 `json:"used_bytes"`
+	TotalBytes uint64 `json:"total_bytes"`
+}
+
+// Percent returns the percentage of the disk in use.
+func (u WorkerDiskUsage) Percent() float64 {
+	if u.TotalBytes == 0 {
+		return 0
+	}
+
+	return float64(u.UsedBytes) * 100 / float64(u.TotalBytes)
+}
@@ -5,12 +5,13 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"path"
+	"sort"
 	"strconv"
+	"time"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
-	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/runtime"
@@ -19,6 +20,27 @@ import (
 const taskProcessID = "task"
 const taskExitStatusPropertyName = "concourse:exit-status"
 
+// sizeLimitedPropertyName marks the volumes of outputs with a size limit, for
+// their worker to report their sizes more often.
+const sizeLimitedPropertyName = "concourse:size-limited"
+
+// OutputSizeCheckInterval is how often the outputs of a task step with an
+// output size limit are checked. The sizes checked are the ones last reported
+// by the worker, so they are only as fresh as its volume size reports.
+var OutputSizeCheckInterval = 30 * time.Second
+
+// OutputSizeLimitExceededError is returned by RunTaskStep when one of the
+// outputs grew past the output size limit, once the task has been stopped.
+type OutputSizeLimitExceededError struct {
+	Output string
+	Size   uint64
+	Limit  uint64
+}
+
+func (err OutputSizeLimitExceededError) Error() string {
+	return fmt.Sprintf("output '%s' exceeded its size limit of %d bytes (%d bytes)", err.Output, err.Limit, err.Size)
+}
+
 //counterfeiter:generate . Client
 type Client interface {
 	Name() string
@@ -33,6 +55,7 @@ type Client interface {
 		runtime.ProcessSpec,
 		runtime.StartingEventDelegate,
 		resource.Resource,
+		resource.VersionHandler,
 	) (CheckResult, error)
 
 	RunTaskStep(
@@ -82,7 +105,9 @@ type TaskResult struct {
 }
 
 type CheckResult struct {
-	Versions []atc.Version
+	// The handle of the container the check ran in, set even if the check
+	// itself failed.
+	ContainerHandle string
 }
 
 type PutResult struct {
@@ -117,6 +142,7 @@ func (client *client) RunCheckStep(
 	processSpec runtime.ProcessSpec,
 	eventDelegate runtime.StartingEventDelegate,
 	checkable resource.Resource,
+	handleVersion resource.VersionHandler,
 ) (CheckResult, error) {
 	logger := lagerctx.FromContext(ctx)
 
@@ -133,12 +159,14 @@ func (client *client) RunCheckStep(
 
 	eventDelegate.Starting(logger)
 
-	versions, err := checkable.Check(ctx, processSpec, container)
+	err = checkable.Check(ctx, processSpec, container, handleVersion)
 	if err != nil {
-		return CheckResult{}, fmt.Errorf("check: %w", err)
+		return CheckResult{ContainerHandle: container.Handle()}, fmt.Errorf("check: %w", err)
 	}
 
-	return CheckResult{Versions: versions}, nil
+	return CheckResult{
+		ContainerHandle: container.Handle(),
+	}, nil
 }
 
 func (client *client) RunTaskStep(
@@ -227,6 +255,14 @@ func (client *client) RunTaskStep(
 		exitStatusChan <- status
 	}()
 
+	outputSizeExceeded := make(chan OutputSizeLimitExceededError, 1)
+	if containerSpec.Limits.OutputSize != nil && *containerSpec.Limits.OutputSize > 0 && len(containerSpec.Outputs) > 0 {
+		monitorCtx, stopMonitoring := context.WithCancel(ctx)
+		defer stopMonitoring()
+
+		go monitorOutputSizes(monitorCtx, logger, container, containerSpec.Outputs, *containerSpec.Limits.OutputSize, outputSizeExceeded)
+	}
+
 	select {
 	case <-ctx.Done():
 		err = container.Stop(false)
@@ -240,6 +276,21 @@ func (client *client) RunTaskStep(
 			VolumeMounts: container.VolumeMounts(),
 		}, ctx.Err()
 
+	case exceeded := <-outputSizeExceeded:
+		logger.Info("output-size-limit-exceeded", lager.Data{"output": exceeded.Output, "size": exceeded.Size, "limit": exceeded.Limit})
+
+		// kill the task right away; it's still writing to its outputs
+		err = container.Stop(true)
+		if err != nil {
+			logger.Error("stopping-container", err)
+		}
+
+		status := <-exitStatusChan
+		return TaskResult{
+			ExitStatus:   status.processStatus,
+			VolumeMounts: container.VolumeMounts(),
+		}, exceeded
+
 	case status := <-exitStatusChan:
 		if status.processErr != nil {
 			return TaskResult{
@@ -260,6 +311,79 @@ func (client *client) RunTaskStep(
 	}
 }
 
+// monitorOutputSizes checks the sizes of the output volumes of the container
+// every OutputSizeCheckInterval, until one of them is larger than the limit
+// or the context is done.
+//
+// The volumes are marked as size-limited, so that their worker measures and
+// reports their sizes more often than those of its other volumes. The sizes
+// checked are the ones last reported, so a breach is noticed at most one of
+// the worker's --limited-volume-size-report-interval late.
+func monitorOutputSizes(
+	ctx context.Context,
+	logger lager.Logger,
+	container Container,
+	outputs OutputPaths,
+	limit uint64,
+	exceeded chan<- OutputSizeLimitExceededError,
+) {
+	names := make([]string, 0, len(outputs))
+	for name := range outputs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	volumes := map[string]Volume{}
+	for _, mount := range container.VolumeMounts() {
+		for _, name := range names {
+			if path.Clean(mount.MountPath) != path.Clean(outputs[name]) {
+				continue
+			}
+
+			err := mount.Volume.SetProperty(sizeLimitedPropertyName, "true")
+			if err != nil {
+				logger.Error("failed-to-mark-output-as-size-limited", err, lager.Data{"output": name})
+			}
+
+			volumes[name] = mount.Volume
+		}
+	}
+
+	ticker := time.NewTicker(OutputSizeCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, name := range names {
+			volume, found := volumes[name]
+			if !found {
+				continue
+			}
+
+			size, found, err := volume.Size()
+			if err != nil {
+				logger.Error("failed-to-get-output-size", err, lager.Data{"output": name})
+				continue
+			}
+
+			if found && size > limit {
+				exceeded <- OutputSizeLimitExceededError{
+					Output: name,
+					Size:   size,
+					Limit:  limit,
+				}
+				return
+			}
+		}
+	}
+}
+
 func (client *client) RunGetStep(
 	ctx context.Context,
 	owner db.ContainerOwner,
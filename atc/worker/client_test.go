@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"time"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden/gardenfakes"
@@ -13,6 +14,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/resource/resourcefakes"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimefakes"
@@ -48,9 +50,11 @@ var _ = Describe("Client", func() {
 			fakeResource      *resourcefakes.FakeResource
 			fakeEventDelegate *runtimefakes.FakeStartingEventDelegate
 			fakeProcessSpec   runtime.ProcessSpec
+			handledVersions   []atc.Version
 		)
 
 		BeforeEach(func() {
+			handledVersions = nil
 			fakeResource = new(resourcefakes.FakeResource)
 			fakeEventDelegate = new(runtimefakes.FakeStartingEventDelegate)
 			stdout := new(gbytes.Buffer)
@@ -81,6 +85,10 @@ var _ = Describe("Client", func() {
 				fakeProcessSpec,
 				fakeEventDelegate,
 				fakeResource,
+				func(version atc.Version) error {
+					handledVersions = append(handledVersions, version)
+					return nil
+				},
 			)
 		})
 
@@ -100,17 +108,18 @@ var _ = Describe("Client", func() {
 
 			BeforeEach(func() {
 				fakeContainer = new(workerfakes.FakeContainer)
+				fakeContainer.HandleReturns("some-handle")
 				fakeWorker.FindOrCreateContainerReturns(fakeContainer, nil)
 			})
 
 			It("uses the right executable path in the proc spec", func() {
-				_, processSpec, _ := fakeResource.CheckArgsForCall(0)
+				_, processSpec, _, _ := fakeResource.CheckArgsForCall(0)
 
 				Expect(processSpec).To(Equal(fakeProcessSpec))
 			})
 
 			It("uses the container as the runner", func() {
-				_, _, container := fakeResource.CheckArgsForCall(0)
+				_, _, container, _ := fakeResource.CheckArgsForCall(0)
 
 				Expect(container).To(Equal(fakeContainer))
 			})
@@ -129,26 +138,34 @@ var _ = Describe("Client", func() {
 
 			Context("succeeding", func() {
 				BeforeEach(func() {
-					fakeResource.CheckReturns([]atc.Version{
-						{"version": "1"},
-					}, nil)
+					fakeResource.CheckStub = func(_ context.Context, _ runtime.ProcessSpec, _ runtime.Runner, handleVersion resource.VersionHandler) error {
+						return handleVersion(atc.Version{"version": "1"})
+					}
+				})
+
+				It("hands over the versions", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(handledVersions).To(Equal([]atc.Version{{"version": "1"}}))
 				})
 
-				It("returns the versions", func() {
-					Expect(result.Versions).To(HaveLen(1))
-					Expect(result.Versions[0]).To(Equal(atc.Version{"version": "1"}))
+				It("returns the container handle", func() {
+					Expect(result.ContainerHandle).To(Equal("some-handle"))
 				})
 			})
 
 			Context("check erroring", func() {
 				BeforeEach(func() {
 					expectedErr = errors.New("check-err")
-					fakeResource.CheckReturns(nil, expectedErr)
+					fakeResource.CheckReturns(expectedErr)
 				})
 
 				It("errors", func() {
 					Expect(errors.Is(err, expectedErr)).To(BeTrue())
 				})
+
+				It("still returns the container handle", func() {
+					Expect(result.ContainerHandle).To(Equal("some-handle"))
+				})
 			})
 		})
 	})
@@ -647,6 +664,121 @@ var _ = Describe("Client", func() {
 					})
 				})
 
+				Context("when the outputs have a size limit", func() {
+					var checkInterval time.Duration
+
+					BeforeEach(func() {
+						checkInterval = worker.OutputSizeCheckInterval
+						worker.OutputSizeCheckInterval = 10 * time.Millisecond
+
+						limit := uint64(1024)
+						fakeContainerSpec.Limits.OutputSize = &limit
+						fakeContainerSpec.Outputs = worker.OutputPaths{
+							"some-output": "some-artifact-root/some-other-output/",
+						}
+					})
+
+					AfterEach(func() {
+						worker.OutputSizeCheckInterval = checkInterval
+					})
+
+					Context("when an output grows past the limit", func() {
+						var stopped chan struct{}
+
+						BeforeEach(func() {
+							stopped = make(chan struct{})
+
+							fakeVolume2.SizeReturns(2048, true, nil)
+
+							fakeProcess.WaitStub = func() (int, error) {
+								<-stopped
+								return 128 + 9, nil
+							}
+
+							fakeContainer.StopStub = func(bool) error {
+								close(stopped)
+								return nil
+							}
+						})
+
+						It("kills the container", func() {
+							Expect(fakeContainer.StopCallCount()).To(Equal(1))
+							Expect(fakeContainer.StopArgsForCall(0)).To(BeTrue())
+						})
+
+						It("returns an error naming the output", func() {
+							Expect(err).To(Equal(worker.OutputSizeLimitExceededError{
+								Output: "some-output",
+								Size:   2048,
+								Limit:  1024,
+							}))
+							Expect(err).To(MatchError("output 'some-output' exceeded its size limit of 1024 bytes (2048 bytes)"))
+							Expect(status).To(Equal(128 + 9))
+						})
+
+						It("returns all the volume mounts", func() {
+							Expect(volumeMounts).To(HaveLen(3))
+						})
+					})
+
+					Context("when the outputs stay within the limit", func() {
+						BeforeEach(func() {
+							fakeVolume2.SizeReturns(1024, true, nil)
+
+							fakeProcess.WaitStub = func() (int, error) {
+								for fakeVolume2.SizeCallCount() < 2 {
+									time.Sleep(time.Millisecond)
+								}
+
+								return 0, nil
+							}
+						})
+
+						It("runs the task to completion", func() {
+							Expect(err).ToNot(HaveOccurred())
+							Expect(status).To(BeZero())
+							Expect(fakeContainer.StopCallCount()).To(BeZero())
+						})
+
+						It("marks the output volumes for their worker to report their sizes more often", func() {
+							Expect(fakeVolume2.SetPropertyCallCount()).To(Equal(1))
+
+							key, value := fakeVolume2.SetPropertyArgsForCall(0)
+							Expect(key).To(Equal("concourse:size-limited"))
+							Expect(value).To(Equal("true"))
+
+							Expect(fakeVolume1.SetPropertyCallCount()).To(BeZero())
+							Expect(fakeVolume3.SetPropertyCallCount()).To(BeZero())
+						})
+
+						It("only checks the sizes of the outputs, without streaming them", func() {
+							Expect(fakeVolume1.SizeCallCount()).To(BeZero())
+							Expect(fakeVolume3.SizeCallCount()).To(BeZero())
+
+							Expect(fakeVolume2.StreamOutCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the size of an output hasn't been reported", func() {
+						BeforeEach(func() {
+							fakeVolume2.SizeReturns(0, false, nil)
+
+							fakeProcess.WaitStub = func() (int, error) {
+								for fakeVolume2.SizeCallCount() < 2 {
+									time.Sleep(time.Millisecond)
+								}
+
+								return 0, nil
+							}
+						})
+
+						It("runs the task to completion", func() {
+							Expect(err).ToNot(HaveOccurred())
+							Expect(fakeContainer.StopCallCount()).To(BeZero())
+						})
+					})
+				})
+
 				Context("when the process exits successfully", func() {
 					It("returns a successful result", func() {
 						Expect(status).To(BeZero())
@@ -175,6 +175,13 @@ func (container *gardenWorkerContainer) RunScript(
 		Stdout: stdout,
 	}
 
+	streamedOutput, streamed := output.(io.Writer)
+	if streamed && !recoverable {
+		processIO.Stdout = streamedOutput
+	} else {
+		streamed = false
+	}
+
 	if logDest != nil {
 		processIO.Stderr = logDest
 	} else {
@@ -224,6 +231,13 @@ func (container *gardenWorkerContainer) RunScript(
 		}
 
 		if processStatus != 0 {
+			if container.oomKilled() {
+				return runtime.ErrResourceScriptOOMKilled{
+					Path: path,
+					Args: args,
+				}
+			}
+
 			return runtime.ErrResourceScriptFailed{
 				Path:       path,
 				Args:       args,
@@ -240,6 +254,10 @@ func (container *gardenWorkerContainer) RunScript(
 			}
 		}
 
+		if streamed {
+			return nil
+		}
+
 		err := json.Unmarshal(stdout.Bytes(), output)
 		if err != nil {
 			return fmt.Errorf("%s\n\nwhen parsing resource response:\n\n%s", err, stdout.String())
@@ -252,3 +270,20 @@ func (container *gardenWorkerContainer) RunScript(
 		return ctx.Err()
 	}
 }
+
+// oomKilled reports whether Garden saw the container run out of memory.
+// Backends which don't report events are assumed not to have.
+func (container *gardenWorkerContainer) oomKilled() bool {
+	info, err := container.Info()
+	if err != nil {
+		return false
+	}
+
+	for _, event := range info.Events {
+		if event == "oom" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"go.opentelemetry.io/otel/propagation"
 )
@@ -13,7 +14,16 @@ type WorkerSpec struct {
 	Platform     string
 	ResourceType string
 	Tags         []string
+	AnyTags      []string
 	TeamID       int
+
+	// The container runtime the worker must be running, e.g. containerd. Any
+	// runtime is allowed when empty.
+	Runtime string
+
+	// The name of the only worker that may be selected. Tags are not taken
+	// into account when this is set.
+	WorkerName string
 }
 
 type ContainerSpec struct {
@@ -30,6 +40,11 @@ type ContainerSpec struct {
 	// streamed.
 	Inputs []InputSource
 
+	// Inputs of which this container is the only consumer, e.g. a get feeding
+	// a single task. The container is placed on a compatible worker which
+	// already has them, if there is one, so that they aren't streamed.
+	ColocatedInputs []InputSource
+
 	// Outputs for which volumes should be created and mounted into the container.
 	Outputs OutputPaths
 
@@ -41,6 +56,17 @@ type ContainerSpec struct {
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Extra entries to add to the container's /etc/hosts.
+	Hosts atc.HostsConfig
+
+	// Whether processes are run under the init binary which only the
+	// containerd runtime mounts in containers.
+	Init bool
+
+	// Additional properties to label the container with when creating it in
+	// garden, e.g. to identify what it is running.
+	Properties map[string]string
 }
 
 // ContainerSpec must implement propagation.TextMapCarrier so that it can be
@@ -84,6 +110,17 @@ func (cs *ContainerSpec) Keys() []string {
 	return keys
 }
 
+// RequiredRuntime returns the runtime a worker must be running for the
+// container to be created on it, or "" if any runtime will do. Only the
+// containerd runtime supports hosts and init.
+func (cs *ContainerSpec) RequiredRuntime() string {
+	if len(cs.Hosts) != 0 || cs.Init {
+		return atc.WorkerRuntimeContainerd
+	}
+
+	return ""
+}
+
 //counterfeiter:generate . InputSource
 type InputSource interface {
 	Source() ArtifactSource
@@ -108,6 +145,10 @@ type ImageSpec struct {
 type ContainerLimits struct {
 	CPU    *uint64
 	Memory *uint64
+
+	// OutputSize is the largest each of the container's outputs may grow to.
+	// It is not a garden limit; it is enforced while running a task step.
+	OutputSize *uint64
 }
 
 type inputSource struct {
@@ -143,6 +184,10 @@ func (cl ContainerLimits) ToGardenLimits() garden.Limits {
 func (spec WorkerSpec) Description() string {
 	var attrs []string
 
+	if spec.WorkerName != "" {
+		attrs = append(attrs, fmt.Sprintf("worker '%s'", spec.WorkerName))
+	}
+
 	if spec.ResourceType != "" {
 		attrs = append(attrs, fmt.Sprintf("resource type '%s'", spec.ResourceType))
 	}
@@ -151,9 +196,17 @@ func (spec WorkerSpec) Description() string {
 		attrs = append(attrs, fmt.Sprintf("platform '%s'", spec.Platform))
 	}
 
+	if spec.Runtime != "" {
+		attrs = append(attrs, fmt.Sprintf("runtime '%s'", spec.Runtime))
+	}
+
 	for _, tag := range spec.Tags {
 		attrs = append(attrs, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	if len(spec.AnyTags) > 0 {
+		attrs = append(attrs, fmt.Sprintf("any tag of '%s'", strings.Join(spec.AnyTags, "', '")))
+	}
+
 	return strings.Join(attrs, ", ")
 }
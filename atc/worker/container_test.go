@@ -59,6 +59,7 @@ var _ = Describe("RunScript", func() {
 		runScriptArgs           []string
 		runScriptInput          []byte
 		runScriptOutput         map[string]string
+		runScriptStreamedOutput *gbytes.Buffer
 		runScriptLogDestination io.Writer
 		runScriptRecoverable    bool
 	)
@@ -127,6 +128,7 @@ var _ = Describe("RunScript", func() {
 				"version": {"some":"version"}
 			}`)
 		runScriptOutput = make(map[string]string)
+		runScriptStreamedOutput = nil
 		runScriptLogDestination = stderrBuf
 		runScriptRecoverable = true
 
@@ -164,12 +166,17 @@ var _ = Describe("RunScript", func() {
 		})
 
 		JustBeforeEach(func() {
+			var output interface{} = &runScriptOutput
+			if runScriptStreamedOutput != nil {
+				output = runScriptStreamedOutput
+			}
+
 			runScriptErr = workerContainer.RunScript(
 				runScriptCtx,
 				runScriptBinPath,
 				runScriptArgs,
 				runScriptInput,
-				&runScriptOutput,
+				output,
 				runScriptLogDestination,
 				runScriptRecoverable,
 			)
@@ -283,6 +290,20 @@ var _ = Describe("RunScript", func() {
 					Expect(runScriptErr).To(HaveOccurred())
 					Expect(runScriptErr.Error()).To(ContainSubstring("exit status 9"))
 				})
+
+				Context("because the container ran out of memory", func() {
+					BeforeEach(func() {
+						scriptExitStatus = 137
+						fakeGClientContainer.InfoReturns(garden.ContainerInfo{Events: []string{"oom"}}, nil)
+					})
+
+					It("returns a distinct error", func() {
+						Expect(runScriptErr).To(Equal(runtime.ErrResourceScriptOOMKilled{
+							Path: runScriptBinPath,
+							Args: runScriptArgs,
+						}))
+					})
+				})
 			})
 
 		})
@@ -392,6 +413,23 @@ var _ = Describe("RunScript", func() {
 					Expect(runScriptErr.Error()).Should(ContainSubstring(fakeGardenContainerScriptStdout))
 				})
 			})
+
+			Context("when the script isn't recoverable and the output is a writer", func() {
+				BeforeEach(func() {
+					runScriptRecoverable = false
+					runScriptStreamedOutput = gbytes.NewBuffer()
+					fakeGardenContainerScriptStdout = "ß"
+				})
+
+				It("writes stdout to it as-is", func() {
+					Expect(runScriptErr).ToNot(HaveOccurred())
+					Expect(runScriptStreamedOutput.Contents()).To(Equal([]byte("ß")))
+				})
+
+				It("doesn't save it as a property on the container", func() {
+					Expect(fakeGClientContainer.SetPropertyCallCount()).To(BeZero())
+				})
+			})
 		})
 	})
 
@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	bclient "github.com/concourse/baggageclaim/client"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker/gclient"
+	"github.com/concourse/concourse/atc/worker/transport"
+	"github.com/concourse/retryhttp"
+)
+
+// Destroyer destroys containers and volumes on the worker they live on, so
+// that the garbage collector doesn't have to wait for the worker to sweep
+// them.
+//
+//counterfeiter:generate . Destroyer
+type Destroyer interface {
+	DestroyContainer(logger lager.Logger, worker db.Worker, handle string) error
+	DestroyVolume(logger lager.Logger, worker db.Worker, handle string) error
+}
+
+type destroyer struct {
+	dbWorkerFactory                   db.WorkerFactory
+	retryBackOffFactory               retryhttp.BackOffFactory
+	gardenRequestTimeout              time.Duration
+	baggageclaimResponseHeaderTimeout time.Duration
+}
+
+func NewDestroyer(
+	dbWorkerFactory db.WorkerFactory,
+	retryBackOffFactory retryhttp.BackOffFactory,
+	gardenRequestTimeout time.Duration,
+	baggageclaimResponseHeaderTimeout time.Duration,
+) Destroyer {
+	return &destroyer{
+		dbWorkerFactory:                   dbWorkerFactory,
+		retryBackOffFactory:               retryBackOffFactory,
+		gardenRequestTimeout:              gardenRequestTimeout,
+		baggageclaimResponseHeaderTimeout: baggageclaimResponseHeaderTimeout,
+	}
+}
+
+// DestroyContainer destroys the container in the worker's garden server. A
+// container which is already gone counts as destroyed.
+func (d *destroyer) DestroyContainer(logger lager.Logger, worker db.Worker, handle string) error {
+	gClient := gclient.NewGardenClientFactory(
+		d.dbWorkerFactory,
+		logger.Session("garden-connection"),
+		worker.Name(),
+		worker.GardenAddr(),
+		d.retryBackOffFactory,
+		d.gardenRequestTimeout,
+	).NewClient()
+
+	err := gClient.Destroy(handle)
+	if _, ok := err.(garden.ContainerNotFoundError); ok {
+		return nil
+	}
+
+	return err
+}
+
+// DestroyVolume destroys the volume in the worker's baggageclaim server.
+func (d *destroyer) DestroyVolume(logger lager.Logger, worker db.Worker, handle string) error {
+	bClient := bclient.New("", transport.NewBaggageclaimRoundTripper(
+		worker.Name(),
+		worker.BaggageclaimURL(),
+		d.dbWorkerFactory,
+		&http.Transport{
+			DisableKeepAlives:     true,
+			ResponseHeaderTimeout: d.baggageclaimResponseHeaderTimeout,
+		},
+	))
+
+	return bClient.DestroyVolume(logger, handle)
+}
@@ -0,0 +1,162 @@
+package worker_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/garden/client"
+	"code.cloudfoundry.org/garden/client/connection"
+	gfakes "code.cloudfoundry.org/garden/gardenfakes"
+	"code.cloudfoundry.org/garden/server"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/retryhttp/retryhttpfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Destroyer", func() {
+	var (
+		logger *lagertest.TestLogger
+
+		fakeGardenBackend  *gfakes.FakeBackend
+		gardenAddr         string
+		gardenServer       *server.GardenServer
+		baggageclaimServer *ghttp.Server
+		baggageclaimURL    string
+
+		fakeWorker *dbfakes.FakeWorker
+
+		destroyer Destroyer
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		baggageclaimServer = ghttp.NewServer()
+		baggageclaimURL = baggageclaimServer.URL()
+
+		gardenAddr = fmt.Sprintf("0.0.0.0:%d", 9888+GinkgoParallelNode())
+		fakeGardenBackend = new(gfakes.FakeBackend)
+		gardenServer = server.New("tcp", gardenAddr, 0, fakeGardenBackend, logger)
+
+		go func() {
+			defer GinkgoRecover()
+			err := gardenServer.ListenAndServe()
+			Expect(err).NotTo(HaveOccurred())
+		}()
+
+		apiClient := client.New(connection.New("tcp", gardenAddr))
+		Eventually(apiClient.Ping).Should(Succeed())
+
+		err := gardenServer.SetupBomberman()
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeWorker = new(dbfakes.FakeWorker)
+		fakeWorker.NameReturns("some-worker")
+		fakeWorker.GardenAddrReturns(&gardenAddr)
+		fakeWorker.BaggageclaimURLReturns(&baggageclaimURL)
+
+		fakeBackOffFactory := new(retryhttpfakes.FakeBackOffFactory)
+		fakeBackOff := new(retryhttpfakes.FakeBackOff)
+		fakeBackOffFactory.NewBackOffReturns(fakeBackOff)
+
+		destroyer = NewDestroyer(
+			new(dbfakes.FakeWorkerFactory),
+			fakeBackOffFactory,
+			time.Minute,
+			time.Minute,
+		)
+	})
+
+	AfterEach(func() {
+		gardenServer.Stop()
+
+		Eventually(func() error {
+			conn, err := net.Dial("tcp", gardenAddr)
+			if err == nil {
+				conn.Close()
+			}
+
+			return err
+		}).Should(HaveOccurred())
+
+		baggageclaimServer.Close()
+	})
+
+	Describe("DestroyContainer", func() {
+		var destroyErr error
+
+		JustBeforeEach(func() {
+			destroyErr = destroyer.DestroyContainer(logger, fakeWorker, "some-handle")
+		})
+
+		It("destroys the container in the worker's garden server", func() {
+			Expect(destroyErr).ToNot(HaveOccurred())
+			Expect(fakeGardenBackend.DestroyCallCount()).To(Equal(1))
+			Expect(fakeGardenBackend.DestroyArgsForCall(0)).To(Equal("some-handle"))
+		})
+
+		Context("when the container is already gone", func() {
+			BeforeEach(func() {
+				fakeGardenBackend.DestroyReturns(garden.ContainerNotFoundError{Handle: "some-handle"})
+			})
+
+			It("succeeds", func() {
+				Expect(destroyErr).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when destroying the container fails", func() {
+			BeforeEach(func() {
+				fakeGardenBackend.DestroyReturns(errors.New("disaster"))
+			})
+
+			It("returns the error", func() {
+				Expect(destroyErr).To(MatchError(ContainSubstring("disaster")))
+			})
+		})
+	})
+
+	Describe("DestroyVolume", func() {
+		var destroyErr error
+
+		JustBeforeEach(func() {
+			destroyErr = destroyer.DestroyVolume(logger, fakeWorker, "some-handle")
+		})
+
+		Context("when the volume is destroyed", func() {
+			BeforeEach(func() {
+				baggageclaimServer.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/volumes/some-handle"),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				))
+			})
+
+			It("destroys it in the worker's baggageclaim server", func() {
+				Expect(destroyErr).ToNot(HaveOccurred())
+				Expect(baggageclaimServer.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("when destroying the volume fails", func() {
+			BeforeEach(func() {
+				baggageclaimServer.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/volumes/some-handle"),
+					ghttp.RespondWith(http.StatusInternalServerError, nil),
+				))
+			})
+
+			It("returns an error", func() {
+				Expect(destroyErr).To(HaveOccurred())
+			})
+		})
+	})
+})
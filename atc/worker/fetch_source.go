@@ -119,7 +119,10 @@ func (s *fetchSource) Find() (GetResult, Volume, bool, error) {
 				Version:  s.cache.Version(),
 				Metadata: atcMetaData,
 			},
-			GetArtifact: runtime.GetArtifact{VolumeHandle: volume.Handle()},
+			GetArtifact: runtime.GetArtifact{
+				VolumeHandle:    volume.Handle(),
+				ResourceCacheID: s.cache.ID(),
+			},
 		},
 		volume, true, nil
 }
@@ -168,6 +171,14 @@ func (s *fetchSource) Create(ctx context.Context) (GetResult, Volume, error) {
 		return GetResult{}, nil, err
 	}
 
+	if s.processSpec.Unpack != "" {
+		err = unpackArchives(ctx, container, s.processSpec.Args[0], s.processSpec.Unpack, s.processSpec.StderrWriter)
+		if err != nil {
+			sLog.Error("failed-to-unpack-archives", err)
+			return GetResult{}, nil, err
+		}
+	}
+
 	volume = volumeWithFetchedBits(s.processSpec.Args[0], container)
 
 	err = volume.SetPrivileged(false)
@@ -192,7 +203,8 @@ func (s *fetchSource) Create(ctx context.Context) (GetResult, Volume, error) {
 		ExitStatus:    0,
 		VersionResult: vr,
 		GetArtifact: runtime.GetArtifact{
-			VolumeHandle: volume.Handle(),
+			VolumeHandle:    volume.Handle(),
+			ResourceCacheID: s.cache.ID(),
 		},
 	}, volume, nil
 }
@@ -33,13 +33,15 @@ var _ = Describe("FetchSource", func() {
 		fakeResource             *resourcefakes.FakeResource
 		metadata                 db.ContainerMetadata
 		owner                    db.ContainerOwner
+		getProcessSpec           runtime.ProcessSpec
+		logger                   *lagertest.TestLogger
 
 		ctx    context.Context
 		cancel func()
 	)
 
 	BeforeEach(func() {
-		logger := lagertest.NewTestLogger("test")
+		logger = lagertest.NewTestLogger("test")
 		fakeContainer = new(workerfakes.FakeContainer)
 
 		ctx, cancel = context.WithCancel(context.Background())
@@ -87,12 +89,15 @@ var _ = Describe("FetchSource", func() {
 			{Name: "some", Value: "metadata"},
 		}, nil)
 
-		getProcessSpec := runtime.ProcessSpec{
+		getProcessSpec = runtime.ProcessSpec{
 			Path: "/opt/resource/in",
 			Args: []string{resource.ResourcesDir("get")},
 		}
 
 		fetchSourceFactory = worker.NewFetchSourceFactory(fakeResourceCacheFactory)
+	})
+
+	JustBeforeEach(func() {
 		fetchSource = fetchSourceFactory.NewFetchSource(
 			logger,
 			fakeWorker,
@@ -130,7 +135,7 @@ var _ = Describe("FetchSource", func() {
 				expectedGetResult = worker.GetResult{
 					ExitStatus:    0,
 					VersionResult: runtime.VersionResult{Metadata: expectedMetadata},
-					GetArtifact:   runtime.GetArtifact{VolumeHandle: fakeVolume.Handle()},
+					GetArtifact:   runtime.GetArtifact{VolumeHandle: fakeVolume.Handle(), ResourceCacheID: 42},
 				}
 			})
 
@@ -181,7 +186,7 @@ var _ = Describe("FetchSource", func() {
 				expectedGetResult = worker.GetResult{
 					ExitStatus:    0,
 					VersionResult: runtime.VersionResult{Metadata: expectedMetadata},
-					GetArtifact:   runtime.GetArtifact{VolumeHandle: fakeVolume.Handle()},
+					GetArtifact:   runtime.GetArtifact{VolumeHandle: fakeVolume.Handle(), ResourceCacheID: 42},
 				}
 			})
 
@@ -259,6 +264,73 @@ var _ = Describe("FetchSource", func() {
 				})
 			})
 
+			Context("when the archives are to be unpacked", func() {
+				var unpackProcess *gardenfakes.FakeProcess
+
+				BeforeEach(func() {
+					getProcessSpec.Unpack = "tar.gz"
+
+					unpackProcess = new(gardenfakes.FakeProcess)
+					fakeContainer.RunReturns(unpackProcess, nil)
+				})
+
+				It("unpacks them in the fetched directory of the get container", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeContainer.RunCallCount()).To(Equal(1))
+					_, spec, _ := fakeContainer.RunArgsForCall(0)
+					Expect(spec.Path).To(Equal("sh"))
+					Expect(spec.Args[0]).To(Equal("-c"))
+					Expect(spec.Args[1]).To(ContainSubstring("tar -xzf"))
+					Expect(spec.Args[2:]).To(Equal([]string{"sh", resource.ResourcesDir("get")}))
+				})
+
+				It("records that they were unpacked before the cache is initialized", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeContainer.SetPropertyCallCount()).To(Equal(1))
+					name, value := fakeContainer.SetPropertyArgsForCall(0)
+					Expect(name).To(Equal(runtime.ResourceUnpackedPropertyName))
+					Expect(value).To(Equal("tar.gz"))
+					Expect(fakeVolume.InitializeResourceCacheCallCount()).To(Equal(1))
+				})
+
+				Context("when they were already unpacked in the container", func() {
+					BeforeEach(func() {
+						fakeContainer.PropertiesReturns(garden.Properties{
+							runtime.ResourceUnpackedPropertyName: "tar.gz",
+						}, nil)
+					})
+
+					It("does not unpack them again", func() {
+						Expect(err).NotTo(HaveOccurred())
+						Expect(fakeContainer.RunCallCount()).To(BeZero())
+						Expect(fakeVolume.InitializeResourceCacheCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when there are no archives to unpack", func() {
+					BeforeEach(func() {
+						unpackProcess.WaitReturns(3, nil)
+					})
+
+					It("returns an error and does not initialize the cache", func() {
+						Expect(err).To(Equal(worker.NoArchivesToUnpackError{Format: "tar.gz"}))
+						Expect(err).To(MatchError("no tar.gz archives found to unpack"))
+						Expect(fakeVolume.InitializeResourceCacheCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when unpacking fails", func() {
+					BeforeEach(func() {
+						unpackProcess.WaitReturns(127, nil)
+					})
+
+					It("returns an error and does not initialize the cache", func() {
+						Expect(err).To(Equal(worker.UnpackFailedError{Format: "tar.gz", ExitStatus: 127}))
+						Expect(fakeVolume.InitializeResourceCacheCallCount()).To(BeZero())
+					})
+				})
+			})
+
 			It("returns a successful GetResult and volume with fetched bits", func() {
 				Expect(getResult.ExitStatus).To(BeZero())
 				Expect(getResult.GetArtifact.VolumeHandle).To(Equal(fakeVolume.Handle()))
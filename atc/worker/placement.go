@@ -400,3 +400,27 @@ func (strategy *LimitActiveVolumesStrategy) Approve(logger lager.Logger, worker
 
 func (strategy *LimitActiveVolumesStrategy) Release(logger lager.Logger, worker Worker, spec ContainerSpec) {
 }
+
+// Strategy which approves any worker without ordering or limiting them. Used
+// for steps pinned to a worker by name, where the worker spec has already
+// narrowed the candidates down to that worker.
+type PinnedPlacementStrategy struct {
+	NamedPlacementStrategy
+}
+
+func NewPinnedPlacementStrategy() ContainerPlacementStrategy {
+	return &PinnedPlacementStrategy{
+		NamedPlacementStrategy: NamedPlacementStrategy{"pinned"},
+	}
+}
+
+func (strategy *PinnedPlacementStrategy) Order(logger lager.Logger, workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	return workers, nil
+}
+
+func (strategy *PinnedPlacementStrategy) Approve(logger lager.Logger, worker Worker, spec ContainerSpec) error {
+	return nil
+}
+
+func (strategy *PinnedPlacementStrategy) Release(logger lager.Logger, worker Worker, spec ContainerSpec) {
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/tracing"
 	"github.com/hashicorp/go-multierror"
 )
 
@@ -26,6 +28,14 @@ func (err NoCompatibleWorkersError) Error() string {
 	return fmt.Sprintf("no workers satisfying: %s", err.Spec.Description())
 }
 
+type NamedWorkerUnavailableError struct {
+	Spec WorkerSpec
+}
+
+func (err NamedWorkerUnavailableError) Error() string {
+	return fmt.Sprintf("worker '%s' is not running or does not satisfy: %s", err.Spec.WorkerName, err.Spec.Description())
+}
+
 //counterfeiter:generate . Pool
 type Pool interface {
 	FindContainer(lager.Logger, int, string) (Container, bool, error)
@@ -139,6 +149,55 @@ func (pool *pool) findWorkerWithContainer(
 	return nil, nil
 }
 
+// findWorkerWithColocatedInputs looks for a compatible worker which already
+// has the inputs of which the container is the only consumer, preferring the
+// one with the most of them. Such a worker still has to be approved by the
+// placement strategy.
+func (pool *pool) findWorkerWithColocatedInputs(
+	logger lager.Logger,
+	compatible []Worker,
+	containerSpec ContainerSpec,
+	strategy ContainerPlacementStrategy,
+) (Worker, error) {
+	if len(containerSpec.ColocatedInputs) == 0 {
+		return nil, nil
+	}
+
+	var candidates []Worker
+	counts := make(map[Worker]int, len(compatible))
+
+	for _, worker := range compatible {
+		for _, inputSource := range containerSpec.ColocatedInputs {
+			_, found, err := inputSource.Source().ExistsOn(logger, worker)
+			if err != nil {
+				return nil, err
+			}
+
+			if found {
+				counts[worker]++
+			}
+		}
+
+		if counts[worker] > 0 {
+			candidates = append(candidates, worker)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return counts[candidates[i]] > counts[candidates[j]]
+	})
+
+	for _, candidate := range candidates {
+		err := strategy.Approve(logger, candidate, containerSpec)
+		if err == nil {
+			logger.Debug("found-worker-with-colocated-inputs", lager.Data{"worker": candidate.Name()})
+			return candidate, nil
+		}
+	}
+
+	return nil, nil
+}
+
 func (pool *pool) findWorkerFromStrategy(
 	logger lager.Logger,
 	compatible []Worker,
@@ -196,6 +255,18 @@ func (pool *pool) findWorker(
 		return nil, err
 	}
 
+	if worker == nil {
+		worker, err = pool.findWorkerWithColocatedInputs(
+			logger,
+			compatibleWorkers,
+			containerSpec,
+			strategy,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if worker == nil {
 		worker, err = pool.findWorkerFromStrategy(
 			logger,
@@ -299,6 +370,12 @@ func (pool *pool) SelectWorker(
 			break
 		}
 
+		if workerSpec.WorkerName != "" {
+			// waiting for a specific worker to come back could take forever,
+			// so fail instead
+			return nil, 0, NamedWorkerUnavailableError{Spec: workerSpec}
+		}
+
 		if pollingTicker == nil {
 			pollingTicker = time.NewTicker(WorkerPollingInterval)
 			defer pollingTicker.Stop()
@@ -331,6 +408,7 @@ func (pool *pool) SelectWorker(
 	metric.StepsWaitingDuration{
 		Labels:   labels,
 		Duration: elapsed,
+		TraceID:  tracing.TraceID(ctx),
 	}.Emit(logger)
 
 	return worker, elapsed, nil
@@ -527,6 +527,75 @@ var _ = Describe("Pool", func() {
 							Expect(selectedWorker.Name()).To(Equal(workers[1].Name()))
 						})
 					})
+
+					Context("when the container has colocated inputs", func() {
+						var fakeInputSource *workerfakes.FakeInputSource
+						var fakeArtifactSource *workerfakes.FakeArtifactSource
+
+						BeforeEach(func() {
+							fakeArtifactSource = new(workerfakes.FakeArtifactSource)
+							fakeInputSource = new(workerfakes.FakeInputSource)
+							fakeInputSource.SourceReturns(fakeArtifactSource)
+
+							containerSpec.Inputs = []InputSource{fakeInputSource}
+							containerSpec.ColocatedInputs = []InputSource{fakeInputSource}
+						})
+
+						Context("when a compatible worker has them", func() {
+							BeforeEach(func() {
+								fakeArtifactSource.ExistsOnCalls(func(_ lager.Logger, worker Worker) (Volume, bool, error) {
+									return nil, worker.Name() == workers[2].Name(), nil
+								})
+							})
+
+							It("chooses that worker without ordering the workers", func() {
+								Expect(fakeStrategy.OrderCallCount()).To(Equal(0))
+								Expect(fakeStrategy.ApproveCallCount()).To(Equal(1))
+
+								Expect(selectErr).NotTo(HaveOccurred())
+								Expect(selectedWorker.Name()).To(Equal(workers[2].Name()))
+							})
+
+							Context("when the strategy does not approve it", func() {
+								BeforeEach(func() {
+									fakeStrategy.ApproveReturnsOnCall(0, errors.New("too-busy"))
+								})
+
+								It("falls back to the strategy", func() {
+									Expect(fakeStrategy.OrderCallCount()).To(Equal(1))
+
+									Expect(selectErr).NotTo(HaveOccurred())
+									Expect(selectedWorker.Name()).To(Equal(workers[0].Name()))
+								})
+							})
+						})
+
+						Context("when no compatible worker has them", func() {
+							BeforeEach(func() {
+								fakeArtifactSource.ExistsOnReturns(nil, false, nil)
+							})
+
+							It("falls back to the strategy", func() {
+								Expect(fakeStrategy.OrderCallCount()).To(Equal(1))
+
+								Expect(selectErr).NotTo(HaveOccurred())
+								Expect(selectedWorker.Name()).To(Equal(workers[0].Name()))
+							})
+						})
+
+						Context("when looking for them fails", func() {
+							var disaster error
+
+							BeforeEach(func() {
+								disaster = errors.New("nope")
+								fakeArtifactSource.ExistsOnReturns(nil, false, disaster)
+							})
+
+							It("returns the error", func() {
+								Expect(selectErr).To(Equal(disaster))
+							})
+						})
+					})
 				})
 			})
 		})
@@ -576,6 +645,18 @@ var _ = Describe("Pool", func() {
 					Expect(fakeProvider.RunningWorkersCallCount()).To(Equal(2))
 					Expect(workerFakes[0].SatisfiesCallCount()).To(Equal(2))
 				})
+
+				Context("when a worker name is specified", func() {
+					BeforeEach(func() {
+						workerSpec.WorkerName = "some-worker"
+					})
+
+					It("returns an error without waiting", func() {
+						Expect(selectErr).To(Equal(NamedWorkerUnavailableError{Spec: workerSpec}))
+						Expect(fakeProvider.RunningWorkersCallCount()).To(Equal(1))
+						Expect(fakeCallbacks.WaitingForWorkerCallCount()).To(BeZero())
+					})
+				})
 			})
 		})
 	})
@@ -0,0 +1,182 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/baggageclaim"
+
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+var ErrNoVolumeForResourceCache = errors.New("no worker has a volume for the resource cache")
+
+type ErrWorkerNotRunning struct {
+	WorkerName string
+}
+
+func (e ErrWorkerNotRunning) Error() string {
+	return fmt.Sprintf("worker '%s' is not running", e.WorkerName)
+}
+
+//counterfeiter:generate . ResourceCacheWarmer
+
+// ResourceCacheWarmer copies resource caches onto a worker ahead of time, so
+// that the first builds placed on it do not have to fetch or stream them.
+type ResourceCacheWarmer interface {
+	// Warm streams the resource cache onto the named worker from a worker
+	// which already has it. It returns false if the worker already had the
+	// resource cache.
+	Warm(ctx context.Context, workerName string, resourceCache db.UsedResourceCache) (bool, error)
+}
+
+type resourceCacheWarmer struct {
+	provider            WorkerProvider
+	compression         compression.Compression
+	enableP2PStreaming  bool
+	p2pStreamingTimeout time.Duration
+}
+
+func NewResourceCacheWarmer(
+	provider WorkerProvider,
+	compression compression.Compression,
+	enableP2PStreaming bool,
+	p2pStreamingTimeout time.Duration,
+) ResourceCacheWarmer {
+	return resourceCacheWarmer{
+		provider:            provider,
+		compression:         compression,
+		enableP2PStreaming:  enableP2PStreaming,
+		p2pStreamingTimeout: p2pStreamingTimeout,
+	}
+}
+
+func (warmer resourceCacheWarmer) Warm(ctx context.Context, workerName string, resourceCache db.UsedResourceCache) (bool, error) {
+	logger := lagerctx.FromContext(ctx).Session("warm-resource-cache", lager.Data{
+		"worker":            workerName,
+		"resource-cache-id": resourceCache.ID(),
+	})
+
+	workers, err := warmer.provider.RunningWorkers(logger)
+	if err != nil {
+		return false, err
+	}
+
+	var destinationWorker Worker
+	for _, worker := range workers {
+		if worker.Name() == workerName {
+			destinationWorker = worker
+			break
+		}
+	}
+
+	if destinationWorker == nil {
+		return false, ErrWorkerNotRunning{WorkerName: workerName}
+	}
+
+	_, found, err := destinationWorker.FindVolumeForResourceCache(logger, resourceCache)
+	if err != nil {
+		return false, err
+	}
+
+	if found {
+		logger.Debug("already-warm")
+		return false, nil
+	}
+
+	sourceVolume, found, err := warmer.findSourceVolume(logger, destinationWorker, resourceCache)
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, ErrNoVolumeForResourceCache
+	}
+
+	// resource cache volumes do not belong to a team
+	destinationVolume, err := destinationWorker.CreateVolume(
+		logger,
+		VolumeSpec{
+			Strategy: baggageclaim.EmptyStrategy{},
+		},
+		0,
+		db.VolumeTypeResource,
+	)
+	if err != nil {
+		logger.Error("failed-to-create-volume", err)
+		return false, err
+	}
+
+	logger.Info("streaming", lager.Data{"source-worker": sourceVolume.WorkerName()})
+
+	source := &artifactSource{
+		volume:              sourceVolume,
+		compression:         warmer.compression,
+		enabledP2pStreaming: warmer.enableP2PStreaming,
+		p2pStreamingTimeout: warmer.p2pStreamingTimeout,
+	}
+
+	if warmer.enableP2PStreaming {
+		err = source.p2pStreamTo(ctx, destinationVolume)
+	} else {
+		err = source.streamTo(ctx, destinationVolume)
+	}
+	if err != nil {
+		logger.Error("failed-to-stream", err)
+		return false, err
+	}
+
+	metric.Metrics.VolumesStreamed.Inc()
+
+	err = destinationVolume.InitializeStreamedResourceCache(resourceCache, sourceVolume.WorkerName())
+	if err != nil {
+		logger.Error("failed-to-initialize-resource-cache", err)
+		return false, err
+	}
+
+	metric.Metrics.StreamedResourceCaches.Inc()
+
+	logger.Info("warmed")
+
+	return true, nil
+}
+
+// findSourceVolume returns a volume for the resource cache on another worker
+// which the destination worker could have fetched it from itself: a global
+// worker, or a worker of the same team. Caches on other teams' workers are
+// never copied, as they may hold what their team fetched with its own
+// credentials.
+func (warmer resourceCacheWarmer) findSourceVolume(logger lager.Logger, destinationWorker Worker, resourceCache db.UsedResourceCache) (Volume, bool, error) {
+	workers, err := warmer.provider.FindWorkersForResourceCache(logger, destinationWorker.TeamID(), resourceCache.ID())
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, worker := range workers {
+		if worker.Name() == destinationWorker.Name() {
+			continue
+		}
+
+		if worker.IsOwnedByTeam() && worker.TeamID() != destinationWorker.TeamID() {
+			continue
+		}
+
+		volume, found, err := worker.FindVolumeForResourceCache(logger, resourceCache)
+		if err != nil {
+			logger.Error("failed-to-find-volume-on-worker", err, lager.Data{"source-worker": worker.Name()})
+			continue
+		}
+
+		if found {
+			return volume, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
@@ -0,0 +1,210 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc/compression/compressionfakes"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceCacheWarmer", func() {
+	var (
+		fakeProvider          *workerfakes.FakeWorkerProvider
+		fakeCompression       *compressionfakes.FakeCompression
+		fakeResourceCache     *dbfakes.FakeUsedResourceCache
+		fakeDestinationWorker *workerfakes.FakeWorker
+		fakeSourceWorker      *workerfakes.FakeWorker
+		fakeSourceVolume      *workerfakes.FakeVolume
+		fakeDestinationVolume *workerfakes.FakeVolume
+
+		warmer worker.ResourceCacheWarmer
+
+		streamed bool
+		warmErr  error
+	)
+
+	BeforeEach(func() {
+		fakeProvider = new(workerfakes.FakeWorkerProvider)
+		fakeCompression = new(compressionfakes.FakeCompression)
+		fakeCompression.EncodingReturns(baggageclaim.GzipEncoding)
+
+		fakeResourceCache = new(dbfakes.FakeUsedResourceCache)
+		fakeResourceCache.IDReturns(42)
+
+		fakeDestinationWorker = new(workerfakes.FakeWorker)
+		fakeDestinationWorker.NameReturns("destination-worker")
+
+		fakeSourceWorker = new(workerfakes.FakeWorker)
+		fakeSourceWorker.NameReturns("source-worker")
+
+		fakeSourceVolume = new(workerfakes.FakeVolume)
+		fakeSourceVolume.WorkerNameReturns("source-worker")
+		fakeSourceVolume.StreamOutReturns(ioutil.NopCloser(strings.NewReader("some-tar")), nil)
+		fakeSourceWorker.FindVolumeForResourceCacheReturns(fakeSourceVolume, true, nil)
+
+		fakeDestinationVolume = new(workerfakes.FakeVolume)
+		fakeDestinationWorker.CreateVolumeReturns(fakeDestinationVolume, nil)
+
+		fakeProvider.RunningWorkersReturns([]worker.Worker{fakeSourceWorker, fakeDestinationWorker}, nil)
+		fakeProvider.FindWorkersForResourceCacheReturns([]worker.Worker{fakeDestinationWorker, fakeSourceWorker}, nil)
+
+		warmer = worker.NewResourceCacheWarmer(fakeProvider, fakeCompression, false, 0)
+	})
+
+	JustBeforeEach(func() {
+		ctx := lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+		streamed, warmErr = warmer.Warm(ctx, "destination-worker", fakeResourceCache)
+	})
+
+	It("streams the resource cache from a worker which has it", func() {
+		Expect(warmErr).ToNot(HaveOccurred())
+		Expect(streamed).To(BeTrue())
+
+		_, _, rcID := fakeProvider.FindWorkersForResourceCacheArgsForCall(0)
+		Expect(rcID).To(Equal(42))
+
+		Expect(fakeDestinationWorker.CreateVolumeCallCount()).To(Equal(1))
+		_, spec, teamID, volumeType := fakeDestinationWorker.CreateVolumeArgsForCall(0)
+		Expect(spec.Strategy).To(Equal(baggageclaim.EmptyStrategy{}))
+		Expect(teamID).To(Equal(0))
+		Expect(volumeType).To(Equal(db.VolumeTypeResource))
+
+		Expect(fakeDestinationVolume.StreamInCallCount()).To(Equal(1))
+		_, path, encoding, stream := fakeDestinationVolume.StreamInArgsForCall(0)
+		Expect(path).To(Equal("."))
+		Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
+		Expect(ioutil.ReadAll(stream)).To(Equal([]byte("some-tar")))
+	})
+
+	It("initializes the streamed volume as the resource cache", func() {
+		Expect(fakeDestinationVolume.InitializeStreamedResourceCacheCallCount()).To(Equal(1))
+		resourceCache, sourceWorkerName := fakeDestinationVolume.InitializeStreamedResourceCacheArgsForCall(0)
+		Expect(resourceCache).To(Equal(fakeResourceCache))
+		Expect(sourceWorkerName).To(Equal("source-worker"))
+	})
+
+	Context("when the worker already has the resource cache", func() {
+		BeforeEach(func() {
+			fakeDestinationWorker.FindVolumeForResourceCacheReturns(new(workerfakes.FakeVolume), true, nil)
+		})
+
+		It("does not stream it", func() {
+			Expect(warmErr).ToNot(HaveOccurred())
+			Expect(streamed).To(BeFalse())
+			Expect(fakeDestinationWorker.CreateVolumeCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the worker is not running", func() {
+		BeforeEach(func() {
+			fakeProvider.RunningWorkersReturns([]worker.Worker{fakeSourceWorker}, nil)
+		})
+
+		It("errors", func() {
+			Expect(warmErr).To(Equal(worker.ErrWorkerNotRunning{WorkerName: "destination-worker"}))
+		})
+	})
+
+	Context("when no other worker has a volume for the resource cache", func() {
+		BeforeEach(func() {
+			fakeSourceWorker.FindVolumeForResourceCacheReturns(nil, false, nil)
+		})
+
+		It("errors", func() {
+			Expect(warmErr).To(Equal(worker.ErrNoVolumeForResourceCache))
+			Expect(fakeDestinationWorker.CreateVolumeCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the worker belongs to a team", func() {
+		BeforeEach(func() {
+			fakeDestinationWorker.IsOwnedByTeamReturns(true)
+			fakeDestinationWorker.TeamIDReturns(7)
+		})
+
+		It("looks for the resource cache on the team's workers", func() {
+			_, teamID, _ := fakeProvider.FindWorkersForResourceCacheArgsForCall(0)
+			Expect(teamID).To(Equal(7))
+		})
+
+		It("streams the resource cache from a global worker", func() {
+			Expect(warmErr).ToNot(HaveOccurred())
+			Expect(fakeSourceVolume.StreamOutCallCount()).To(Equal(1))
+		})
+
+		Context("when the worker with the resource cache belongs to another team", func() {
+			BeforeEach(func() {
+				fakeSourceWorker.IsOwnedByTeamReturns(true)
+				fakeSourceWorker.TeamIDReturns(8)
+			})
+
+			It("does not stream from it", func() {
+				Expect(warmErr).To(Equal(worker.ErrNoVolumeForResourceCache))
+				Expect(fakeSourceWorker.FindVolumeForResourceCacheCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the worker with the resource cache belongs to the same team", func() {
+			BeforeEach(func() {
+				fakeSourceWorker.IsOwnedByTeamReturns(true)
+				fakeSourceWorker.TeamIDReturns(7)
+			})
+
+			It("streams from it", func() {
+				Expect(warmErr).ToNot(HaveOccurred())
+				Expect(fakeSourceVolume.StreamOutCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when the worker is global and the worker with the resource cache belongs to a team", func() {
+		BeforeEach(func() {
+			fakeSourceWorker.IsOwnedByTeamReturns(true)
+			fakeSourceWorker.TeamIDReturns(8)
+		})
+
+		It("does not stream from it", func() {
+			Expect(warmErr).To(Equal(worker.ErrNoVolumeForResourceCache))
+		})
+	})
+
+	Context("when streaming fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDestinationVolume.StreamInReturns(disaster)
+		})
+
+		It("errors without initializing the resource cache", func() {
+			Expect(warmErr).To(Equal(disaster))
+			Expect(fakeDestinationVolume.InitializeStreamedResourceCacheCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when p2p streaming is enabled", func() {
+		BeforeEach(func() {
+			fakeDestinationVolume.GetStreamInP2pUrlReturns("http://destination-worker/stream-in", nil)
+			warmer = worker.NewResourceCacheWarmer(fakeProvider, fakeCompression, true, 0)
+		})
+
+		It("streams directly between the workers", func() {
+			Expect(warmErr).ToNot(HaveOccurred())
+			Expect(fakeSourceVolume.StreamP2pOutCallCount()).To(Equal(1))
+			_, _, url, _ := fakeSourceVolume.StreamP2pOutArgsForCall(0)
+			Expect(url).To(Equal("http://destination-worker/stream-in"))
+			Expect(fakeDestinationVolume.StreamInCallCount()).To(BeZero())
+		})
+	})
+})
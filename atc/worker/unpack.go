@@ -0,0 +1,128 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/runtime"
+)
+
+// unpackNoArchivesStatus is what the unpack script exits with when there are
+// no archives of the format to unpack.
+const unpackNoArchivesStatus = 3
+
+// unpackScripts extract every archive of a format at the root of the directory
+// given as the script's first argument in place of the archive, leaving the
+// other files as they were fetched. They're run in the get container, so the
+// resource type's image must provide the tools they use.
+var unpackScripts = map[string]string{
+	atc.UnpackFormatTarGz: `
+		set -e
+		cd "$1"
+		found=
+		for archive in *.tar.gz *.tgz; do
+			[ -f "$archive" ] || continue
+			found=1
+			tar -xzf "$archive"
+			rm "$archive"
+		done
+		[ -n "$found" ] || exit ` + fmt.Sprint(unpackNoArchivesStatus) + `
+	`,
+	atc.UnpackFormatTarZst: `
+		set -e
+		cd "$1"
+		found=
+		for archive in *.tar.zst; do
+			[ -f "$archive" ] || continue
+			found=1
+			zstd -dcq "$archive" > "$archive.tar"
+			tar -xf "$archive.tar"
+			rm "$archive" "$archive.tar"
+		done
+		[ -n "$found" ] || exit ` + fmt.Sprint(unpackNoArchivesStatus) + `
+	`,
+}
+
+// NoArchivesToUnpackError is returned when a get step was asked to unpack
+// archives but the resource fetched none of the given format.
+type NoArchivesToUnpackError struct {
+	Format string
+}
+
+func (err NoArchivesToUnpackError) Error() string {
+	return fmt.Sprintf("no %s archives found to unpack", err.Format)
+}
+
+// UnpackFailedError is returned when the archives fetched by a get step could
+// not be unpacked, e.g. because they are corrupt or the resource type's image
+// lacks the tools to unpack them.
+type UnpackFailedError struct {
+	Format     string
+	ExitStatus int
+}
+
+func (err UnpackFailedError) Error() string {
+	return fmt.Sprintf("unpacking %s archives exited with status %d", err.Format, err.ExitStatus)
+}
+
+// unpackArchives extracts the archives of the format which the resource
+// fetched into dir, in the container it was fetched in. Once done it is
+// recorded on the container, so that a get which is recovered after its
+// archives were unpacked doesn't look for them again.
+func unpackArchives(ctx context.Context, container Container, dir string, format string, logDest io.Writer) error {
+	script, found := unpackScripts[format]
+	if !found {
+		return fmt.Errorf("unsupported unpack format '%s'", format)
+	}
+
+	properties, err := container.Properties()
+	if err == nil && properties[runtime.ResourceUnpackedPropertyName] == format {
+		return nil
+	}
+
+	process, err := container.Run(ctx, garden.ProcessSpec{
+		Path: "sh",
+		Args: []string{"-c", script, "sh", dir},
+	}, garden.ProcessIO{
+		Stdout: logDest,
+		Stderr: logDest,
+	})
+	if err != nil {
+		return err
+	}
+
+	processExited := make(chan struct{})
+
+	var processStatus int
+	var processErr error
+
+	go func() {
+		processStatus, processErr = process.Wait()
+		close(processExited)
+	}()
+
+	select {
+	case <-processExited:
+	case <-ctx.Done():
+		_ = container.Stop(false)
+		<-processExited
+		return ctx.Err()
+	}
+
+	if processErr != nil {
+		return processErr
+	}
+
+	switch processStatus {
+	case 0:
+	case unpackNoArchivesStatus:
+		return NoArchivesToUnpackError{Format: format}
+	default:
+		return UnpackFailedError{Format: format, ExitStatus: processStatus}
+	}
+
+	return container.SetProperty(runtime.ResourceUnpackedPropertyName, format)
+}
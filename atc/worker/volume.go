@@ -38,6 +38,7 @@ type Volume interface {
 	CreateChildForContainer(db.CreatingContainer, string) (db.CreatingVolume, error)
 
 	WorkerName() string
+	Size() (uint64, bool, error)
 	Destroy() error
 }
 
@@ -122,6 +123,10 @@ func (v *volume) WorkerName() string {
 	return v.dbVolume.WorkerName()
 }
 
+func (v *volume) Size() (uint64, bool, error) {
+	return v.dbVolume.Size()
+}
+
 func (v *volume) Destroy() error {
 	return v.bcVolume.Destroy()
 }
@@ -32,6 +32,37 @@ import (
 
 const userPropertyName = "user"
 
+// hostsPropertyName is the property through which the entries to add to a
+// container's /etc/hosts are passed to the worker. Only the containerd
+// runtime honors it, so containers with entries can't be created on workers
+// running another runtime.
+const hostsPropertyName = "concourse:hosts"
+
+// UnsupportedRuntimeError is returned when a container needs a feature which
+// only the containerd runtime provides, but the worker it is to be created on
+// runs another runtime.
+type UnsupportedRuntimeError struct {
+	Feature    string
+	WorkerName string
+	Runtime    string
+}
+
+func (err UnsupportedRuntimeError) Error() string {
+	runtime := "an unknown runtime"
+	if err.Runtime != "" {
+		runtime = fmt.Sprintf("the %s runtime", err.Runtime)
+	}
+
+	return fmt.Sprintf(
+		"%s requires a worker running the %s runtime, but worker '%s' runs %s; set `runtime: %s` on the step to only use such workers",
+		err.Feature,
+		atc.WorkerRuntimeContainerd,
+		err.WorkerName,
+		runtime,
+		atc.WorkerRuntimeContainerd,
+	)
+}
+
 var ErrResourceConfigCheckSessionExpired = errors.New("no db container was found for owner")
 
 //counterfeiter:generate . Worker
@@ -44,6 +75,7 @@ type Worker interface {
 	Tags() atc.Tags
 	Uptime() time.Duration
 	IsOwnedByTeam() bool
+	TeamID() int
 	Ephemeral() bool
 	IsVersionCompatible(lager.Logger, version.Version) bool
 	Satisfies(lager.Logger, WorkerSpec) bool
@@ -493,6 +525,9 @@ func (worker *gardenWorker) createVolumes(
 		cleanedInputPath := filepath.Clean(inputSource.DestinationPath())
 		inputDestinationPaths[cleanedInputPath] = true
 
+		// an input that already lives on this worker (e.g. a get whose only
+		// consumer is this container) is mounted as a COW child without any
+		// streaming.
 		if found {
 			localInputs = append(localInputs, mountableLocalInput{
 				desiredCOWParent: inputSourceVolume,
@@ -812,7 +847,17 @@ func (worker *gardenWorker) Satisfies(logger lager.Logger, spec WorkerSpec) bool
 		}
 	}
 
-	if !worker.tagsMatch(spec.Tags) {
+	if spec.Runtime != "" {
+		if spec.Runtime != worker.dbWorker.Runtime() {
+			return false
+		}
+	}
+
+	if spec.WorkerName != "" {
+		return spec.WorkerName == worker.Name()
+	}
+
+	if !worker.tagsMatch(spec.Tags, spec.AnyTags) {
 		return false
 	}
 
@@ -824,6 +869,10 @@ func (worker *gardenWorker) Description() string {
 		fmt.Sprintf("platform '%s'", worker.dbWorker.Platform()),
 	}
 
+	if worker.dbWorker.Runtime() != "" {
+		messages = append(messages, fmt.Sprintf("runtime '%s'", worker.dbWorker.Runtime()))
+	}
+
 	for _, tag := range worker.dbWorker.Tags() {
 		messages = append(messages, fmt.Sprintf("tag '%s'", tag))
 	}
@@ -835,13 +884,21 @@ func (worker *gardenWorker) IsOwnedByTeam() bool {
 	return worker.dbWorker.TeamID() != 0
 }
 
+func (worker *gardenWorker) TeamID() int {
+	return worker.dbWorker.TeamID()
+}
+
 func (worker *gardenWorker) Uptime() time.Duration {
 	return time.Since(worker.dbWorker.StartTime())
 }
 
-func (worker *gardenWorker) tagsMatch(tags []string) bool {
+func (worker *gardenWorker) tagsMatch(tags []string, anyTags []string) bool {
 	workerTags := worker.dbWorker.Tags()
-	if len(tags) == 0 {
+	if len(anyTags) > 0 && !worker.anyTagMatches(anyTags) {
+		return false
+	}
+
+	if len(tags) == 0 && len(anyTags) == 0 {
 		// If worker only has an empty tag due to user specifying "" instead of []
 		if len(workerTags) == 1 && workerTags[0] == "" {
 			return true
@@ -865,6 +922,18 @@ insert_coin:
 	return true
 }
 
+func (worker *gardenWorker) anyTagMatches(tags []string) bool {
+	for _, stag := range tags {
+		for _, wtag := range worker.dbWorker.Tags() {
+			if stag == wtag {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func (worker *gardenWorker) ActiveTasks() (int, error) {
 	return worker.dbWorker.ActiveTasks()
 }
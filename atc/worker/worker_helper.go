@@ -3,9 +3,11 @@ package worker
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/worker/gclient"
 )
@@ -27,23 +29,49 @@ func (w workerHelper) createGardenContainer(
 
 	gardenProperties := garden.Properties{}
 
+	for name, value := range containerSpec.Properties {
+		gardenProperties[name] = value
+	}
+
 	if containerSpec.User != "" {
 		gardenProperties[userPropertyName] = containerSpec.User
 	} else {
 		gardenProperties[userPropertyName] = fetchedImage.Metadata.User
 	}
 
+	if len(containerSpec.Hosts) != 0 {
+		if w.dbWorker.Runtime() != atc.WorkerRuntimeContainerd {
+			return nil, UnsupportedRuntimeError{
+				Feature:    "hosts",
+				WorkerName: w.dbWorker.Name(),
+				Runtime:    w.dbWorker.Runtime(),
+			}
+		}
+
+		gardenProperties[hostsPropertyName] = strings.Join(containerSpec.Hosts.Entries(), "\n")
+	}
+
+	if containerSpec.Init && w.dbWorker.Runtime() != atc.WorkerRuntimeContainerd {
+		return nil, UnsupportedRuntimeError{
+			Feature:    "init",
+			WorkerName: w.dbWorker.Name(),
+			Runtime:    w.dbWorker.Runtime(),
+		}
+	}
+
 	env := append(fetchedImage.Metadata.Env, containerSpec.Env...)
 
-	if w.dbWorker.HTTPProxyURL() != "" {
+	// the proxies of the container, e.g. of a resource's, take precedence over
+	// the worker's
+	if w.dbWorker.HTTPProxyURL() != "" && !hasEnv(containerSpec.Env, "http_proxy") {
 		env = append(env, fmt.Sprintf("http_proxy=%s", w.dbWorker.HTTPProxyURL()))
 	}
 
-	if w.dbWorker.HTTPSProxyURL() != "" {
+	if w.dbWorker.HTTPSProxyURL() != "" && !hasEnv(containerSpec.Env, "https_proxy") {
 		env = append(env, fmt.Sprintf("https_proxy=%s", w.dbWorker.HTTPSProxyURL()))
 	}
 
-	if w.dbWorker.NoProxy() != "" {
+	if w.dbWorker.NoProxy() != "" && !hasEnv(containerSpec.Env, "no_proxy") {
 		env = append(env, fmt.Sprintf("no_proxy=%s", w.dbWorker.NoProxy()))
 	}
 
@@ -59,6 +87,16 @@ func (w workerHelper) createGardenContainer(
 		})
 }
 
+func hasEnv(env []string, name string) bool {
+	for _, e := range env {
+		if strings.HasPrefix(e, name+"=") {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (w workerHelper) constructGardenWorkerContainer(
 	logger lager.Logger,
 	createdContainer db.CreatedContainer,
@@ -32,6 +32,7 @@ var _ = Describe("Worker", func() {
 		activeContainers         int
 		resourceTypes            []atc.WorkerResourceType
 		platform                 string
+		workerRuntime            string
 		tags                     atc.Tags
 		teamID                   int
 		ephemeral                bool
@@ -91,6 +92,7 @@ var _ = Describe("Worker", func() {
 			},
 		}
 		platform = "some-platform"
+		workerRuntime = "containerd"
 		tags = atc.Tags{"some", "tags"}
 		teamID = 17
 		ephemeral = true
@@ -232,6 +234,7 @@ var _ = Describe("Worker", func() {
 		fakeDBWorker.ActiveContainersReturns(activeContainers)
 		fakeDBWorker.ResourceTypesReturns(resourceTypes)
 		fakeDBWorker.PlatformReturns(platform)
+		fakeDBWorker.RuntimeReturns(workerRuntime)
 		fakeDBWorker.TagsReturns(tags)
 		fakeDBWorker.EphemeralReturns(ephemeral)
 		fakeDBWorker.TeamIDReturns(teamID)
@@ -660,6 +663,59 @@ var _ = Describe("Worker", func() {
 					Expect(satisfies).To(BeFalse())
 				})
 			})
+
+			Context("when only one of the requested any-tags is present", func() {
+				BeforeEach(func() {
+					spec.Tags = nil
+					spec.AnyTags = []string{"bogus", "tags"}
+				})
+
+				It("returns true", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+
+				Context("when a requested tag is not present", func() {
+					BeforeEach(func() {
+						spec.Tags = []string{"bogus"}
+					})
+
+					It("returns false", func() {
+						Expect(satisfies).To(BeFalse())
+					})
+				})
+			})
+
+			Context("when none of the requested any-tags are present", func() {
+				BeforeEach(func() {
+					spec.Tags = nil
+					spec.AnyTags = []string{"bogus", "nope"}
+				})
+
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+
+			Context("when the worker name is specified", func() {
+				BeforeEach(func() {
+					spec.Tags = nil
+					spec.WorkerName = workerName
+				})
+
+				It("returns true regardless of tags", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+
+				Context("when it is the name of another worker", func() {
+					BeforeEach(func() {
+						spec.WorkerName = "some-other-worker"
+					})
+
+					It("returns false", func() {
+						Expect(satisfies).To(BeFalse())
+					})
+				})
+			})
 		})
 
 		Context("when the platform is incompatible", func() {
@@ -672,6 +728,36 @@ var _ = Describe("Worker", func() {
 			})
 		})
 
+		Context("when the runtime is the worker's", func() {
+			BeforeEach(func() {
+				spec.Runtime = "containerd"
+			})
+
+			It("returns true", func() {
+				Expect(satisfies).To(BeTrue())
+			})
+		})
+
+		Context("when the runtime is not the worker's", func() {
+			BeforeEach(func() {
+				spec.Runtime = "guardian"
+			})
+
+			It("returns false", func() {
+				Expect(satisfies).To(BeFalse())
+			})
+
+			Context("when the worker does not advertise its runtime", func() {
+				BeforeEach(func() {
+					workerRuntime = ""
+				})
+
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+		})
+
 		Context("when the resource type is supported by the worker", func() {
 			BeforeEach(func() {
 				spec.ResourceType = "some-base-type"
@@ -861,6 +947,80 @@ var _ = Describe("Worker", func() {
 					Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
 				})
 
+				Context("when the container spec has properties", func() {
+					BeforeEach(func() {
+						containerSpec.Properties = map[string]string{
+							"concourse:some-label": "some-value",
+						}
+					})
+
+					It("labels the container with them", func() {
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Properties).To(Equal(garden.Properties{
+							"user":                 "some-user",
+							"concourse:some-label": "some-value",
+						}))
+					})
+				})
+
+				Context("when the container spec has hosts", func() {
+					BeforeEach(func() {
+						containerSpec.Hosts = atc.HostsConfig{
+							"some-host":  "10.0.0.1",
+							"other-host": "10.0.0.2",
+						}
+					})
+
+					It("passes their entries to the container as a property", func() {
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Properties).To(Equal(garden.Properties{
+							"user":            "some-user",
+							"concourse:hosts": "10.0.0.2 other-host\n10.0.0.1 some-host",
+						}))
+					})
+
+					Context("when the worker runs another runtime", func() {
+						BeforeEach(func() {
+							workerRuntime = "guardian"
+						})
+
+						It("fails without creating the container", func() {
+							Expect(findOrCreateErr).To(MatchError(ContainSubstring(UnsupportedRuntimeError{
+								Feature:    "hosts",
+								WorkerName: "some-worker",
+								Runtime:    "guardian",
+							}.Error())))
+							Expect(fakeGardenClient.CreateCallCount()).To(BeZero())
+						})
+					})
+				})
+
+				Context("when the container spec runs processes under init", func() {
+					BeforeEach(func() {
+						containerSpec.Init = true
+					})
+
+					It("creates the container", func() {
+						Expect(findOrCreateErr).ToNot(HaveOccurred())
+						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+					})
+
+					Context("when the worker runs another runtime", func() {
+						BeforeEach(func() {
+							workerRuntime = "guardian"
+						})
+
+						It("fails without creating the container", func() {
+							Expect(findOrCreateErr).To(MatchError(ContainSubstring(UnsupportedRuntimeError{
+								Feature:    "init",
+								WorkerName: "some-worker",
+								Runtime:    "guardian",
+							}.Error())))
+							Expect(fakeGardenClient.CreateCallCount()).To(BeZero())
+						})
+					})
+				})
+
 				It("marks container as created", func() {
 					Expect(fakeCreatingContainer.CreatedCallCount()).To(Equal(1))
 				})
@@ -928,6 +1088,25 @@ var _ = Describe("Worker", func() {
 					}))
 				})
 
+				Context("when the container spec sets its own proxy", func() {
+					BeforeEach(func() {
+						containerSpec.Env = append(containerSpec.Env, "http_proxy=http://resource-proxy.com")
+					})
+
+					It("uses it in place of the worker's", func() {
+						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Env).To(Equal([]string{
+							"IMAGE=ENV",
+							"SOME=ENV",
+							"http_proxy=http://resource-proxy.com",
+							"https_proxy=https://proxy.com",
+							"no_proxy=http://noproxy.com",
+						}))
+					})
+				})
+
 				Context("when the input and output destination paths overlap", func() {
 					var (
 						fakeRemoteInputUnderInput    *workerfakes.FakeInputSource
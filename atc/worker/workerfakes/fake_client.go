@@ -22,7 +22,7 @@ type FakeClient struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
-	RunCheckStepStub        func(context.Context, db.ContainerOwner, worker.ContainerSpec, db.ContainerMetadata, runtime.ProcessSpec, runtime.StartingEventDelegate, resource.Resource) (worker.CheckResult, error)
+	RunCheckStepStub        func(context.Context, db.ContainerOwner, worker.ContainerSpec, db.ContainerMetadata, runtime.ProcessSpec, runtime.StartingEventDelegate, resource.Resource, resource.VersionHandler) (worker.CheckResult, error)
 	runCheckStepMutex       sync.RWMutex
 	runCheckStepArgsForCall []struct {
 		arg1 context.Context
@@ -32,6 +32,7 @@ type FakeClient struct {
 		arg5 runtime.ProcessSpec
 		arg6 runtime.StartingEventDelegate
 		arg7 resource.Resource
+		arg8 resource.VersionHandler
 	}
 	runCheckStepReturns struct {
 		result1 worker.CheckResult
@@ -165,7 +166,7 @@ func (fake *FakeClient) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
-func (fake *FakeClient) RunCheckStep(arg1 context.Context, arg2 db.ContainerOwner, arg3 worker.ContainerSpec, arg4 db.ContainerMetadata, arg5 runtime.ProcessSpec, arg6 runtime.StartingEventDelegate, arg7 resource.Resource) (worker.CheckResult, error) {
+func (fake *FakeClient) RunCheckStep(arg1 context.Context, arg2 db.ContainerOwner, arg3 worker.ContainerSpec, arg4 db.ContainerMetadata, arg5 runtime.ProcessSpec, arg6 runtime.StartingEventDelegate, arg7 resource.Resource, arg8 resource.VersionHandler) (worker.CheckResult, error) {
 	fake.runCheckStepMutex.Lock()
 	ret, specificReturn := fake.runCheckStepReturnsOnCall[len(fake.runCheckStepArgsForCall)]
 	fake.runCheckStepArgsForCall = append(fake.runCheckStepArgsForCall, struct {
@@ -176,13 +177,14 @@ func (fake *FakeClient) RunCheckStep(arg1 context.Context, arg2 db.ContainerOwne
 		arg5 runtime.ProcessSpec
 		arg6 runtime.StartingEventDelegate
 		arg7 resource.Resource
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+		arg8 resource.VersionHandler
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
 	stub := fake.RunCheckStepStub
 	fakeReturns := fake.runCheckStepReturns
-	fake.recordInvocation("RunCheckStep", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.recordInvocation("RunCheckStep", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
 	fake.runCheckStepMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -196,17 +198,17 @@ func (fake *FakeClient) RunCheckStepCallCount() int {
 	return len(fake.runCheckStepArgsForCall)
 }
 
-func (fake *FakeClient) RunCheckStepCalls(stub func(context.Context, db.ContainerOwner, worker.ContainerSpec, db.ContainerMetadata, runtime.ProcessSpec, runtime.StartingEventDelegate, resource.Resource) (worker.CheckResult, error)) {
+func (fake *FakeClient) RunCheckStepCalls(stub func(context.Context, db.ContainerOwner, worker.ContainerSpec, db.ContainerMetadata, runtime.ProcessSpec, runtime.StartingEventDelegate, resource.Resource, resource.VersionHandler) (worker.CheckResult, error)) {
 	fake.runCheckStepMutex.Lock()
 	defer fake.runCheckStepMutex.Unlock()
 	fake.RunCheckStepStub = stub
 }
 
-func (fake *FakeClient) RunCheckStepArgsForCall(i int) (context.Context, db.ContainerOwner, worker.ContainerSpec, db.ContainerMetadata, runtime.ProcessSpec, runtime.StartingEventDelegate, resource.Resource) {
+func (fake *FakeClient) RunCheckStepArgsForCall(i int) (context.Context, db.ContainerOwner, worker.ContainerSpec, db.ContainerMetadata, runtime.ProcessSpec, runtime.StartingEventDelegate, resource.Resource, resource.VersionHandler) {
 	fake.runCheckStepMutex.RLock()
 	defer fake.runCheckStepMutex.RUnlock()
 	argsForCall := fake.runCheckStepArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7, argsForCall.arg8
 }
 
 func (fake *FakeClient) RunCheckStepReturns(result1 worker.CheckResult, result2 error) {
@@ -0,0 +1,195 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package workerfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+type FakeDestroyer struct {
+	DestroyContainerStub        func(lager.Logger, db.Worker, string) error
+	destroyContainerMutex       sync.RWMutex
+	destroyContainerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 db.Worker
+		arg3 string
+	}
+	destroyContainerReturns struct {
+		result1 error
+	}
+	destroyContainerReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DestroyVolumeStub        func(lager.Logger, db.Worker, string) error
+	destroyVolumeMutex       sync.RWMutex
+	destroyVolumeArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 db.Worker
+		arg3 string
+	}
+	destroyVolumeReturns struct {
+		result1 error
+	}
+	destroyVolumeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDestroyer) DestroyContainer(arg1 lager.Logger, arg2 db.Worker, arg3 string) error {
+	fake.destroyContainerMutex.Lock()
+	ret, specificReturn := fake.destroyContainerReturnsOnCall[len(fake.destroyContainerArgsForCall)]
+	fake.destroyContainerArgsForCall = append(fake.destroyContainerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 db.Worker
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DestroyContainerStub
+	fakeReturns := fake.destroyContainerReturns
+	fake.recordInvocation("DestroyContainer", []interface{}{arg1, arg2, arg3})
+	fake.destroyContainerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDestroyer) DestroyContainerCallCount() int {
+	fake.destroyContainerMutex.RLock()
+	defer fake.destroyContainerMutex.RUnlock()
+	return len(fake.destroyContainerArgsForCall)
+}
+
+func (fake *FakeDestroyer) DestroyContainerCalls(stub func(lager.Logger, db.Worker, string) error) {
+	fake.destroyContainerMutex.Lock()
+	defer fake.destroyContainerMutex.Unlock()
+	fake.DestroyContainerStub = stub
+}
+
+func (fake *FakeDestroyer) DestroyContainerArgsForCall(i int) (lager.Logger, db.Worker, string) {
+	fake.destroyContainerMutex.RLock()
+	defer fake.destroyContainerMutex.RUnlock()
+	argsForCall := fake.destroyContainerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDestroyer) DestroyContainerReturns(result1 error) {
+	fake.destroyContainerMutex.Lock()
+	defer fake.destroyContainerMutex.Unlock()
+	fake.DestroyContainerStub = nil
+	fake.destroyContainerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDestroyer) DestroyContainerReturnsOnCall(i int, result1 error) {
+	fake.destroyContainerMutex.Lock()
+	defer fake.destroyContainerMutex.Unlock()
+	fake.DestroyContainerStub = nil
+	if fake.destroyContainerReturnsOnCall == nil {
+		fake.destroyContainerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.destroyContainerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDestroyer) DestroyVolume(arg1 lager.Logger, arg2 db.Worker, arg3 string) error {
+	fake.destroyVolumeMutex.Lock()
+	ret, specificReturn := fake.destroyVolumeReturnsOnCall[len(fake.destroyVolumeArgsForCall)]
+	fake.destroyVolumeArgsForCall = append(fake.destroyVolumeArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 db.Worker
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DestroyVolumeStub
+	fakeReturns := fake.destroyVolumeReturns
+	fake.recordInvocation("DestroyVolume", []interface{}{arg1, arg2, arg3})
+	fake.destroyVolumeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDestroyer) DestroyVolumeCallCount() int {
+	fake.destroyVolumeMutex.RLock()
+	defer fake.destroyVolumeMutex.RUnlock()
+	return len(fake.destroyVolumeArgsForCall)
+}
+
+func (fake *FakeDestroyer) DestroyVolumeCalls(stub func(lager.Logger, db.Worker, string) error) {
+	fake.destroyVolumeMutex.Lock()
+	defer fake.destroyVolumeMutex.Unlock()
+	fake.DestroyVolumeStub = stub
+}
+
+func (fake *FakeDestroyer) DestroyVolumeArgsForCall(i int) (lager.Logger, db.Worker, string) {
+	fake.destroyVolumeMutex.RLock()
+	defer fake.destroyVolumeMutex.RUnlock()
+	argsForCall := fake.destroyVolumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDestroyer) DestroyVolumeReturns(result1 error) {
+	fake.destroyVolumeMutex.Lock()
+	defer fake.destroyVolumeMutex.Unlock()
+	fake.DestroyVolumeStub = nil
+	fake.destroyVolumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDestroyer) DestroyVolumeReturnsOnCall(i int, result1 error) {
+	fake.destroyVolumeMutex.Lock()
+	defer fake.destroyVolumeMutex.Unlock()
+	fake.DestroyVolumeStub = nil
+	if fake.destroyVolumeReturnsOnCall == nil {
+		fake.destroyVolumeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.destroyVolumeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDestroyer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.destroyContainerMutex.RLock()
+	defer fake.destroyContainerMutex.RUnlock()
+	fake.destroyVolumeMutex.RLock()
+	defer fake.destroyVolumeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeDestroyer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ worker.Destroyer = new(FakeDestroyer)
@@ -0,0 +1,122 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package workerfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+type FakeResourceCacheWarmer struct {
+	WarmStub        func(context.Context, string, db.UsedResourceCache) (bool, error)
+	warmMutex       sync.RWMutex
+	warmArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.UsedResourceCache
+	}
+	warmReturns struct {
+		result1 bool
+		result2 error
+	}
+	warmReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeResourceCacheWarmer) Warm(arg1 context.Context, arg2 string, arg3 db.UsedResourceCache) (bool, error) {
+	fake.warmMutex.Lock()
+	ret, specificReturn := fake.warmReturnsOnCall[len(fake.warmArgsForCall)]
+	fake.warmArgsForCall = append(fake.warmArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.UsedResourceCache
+	}{arg1, arg2, arg3})
+	stub := fake.WarmStub
+	fakeReturns := fake.warmReturns
+	fake.recordInvocation("Warm", []interface{}{arg1, arg2, arg3})
+	fake.warmMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceCacheWarmer) WarmCallCount() int {
+	fake.warmMutex.RLock()
+	defer fake.warmMutex.RUnlock()
+	return len(fake.warmArgsForCall)
+}
+
+func (fake *FakeResourceCacheWarmer) WarmCalls(stub func(context.Context, string, db.UsedResourceCache) (bool, error)) {
+	fake.warmMutex.Lock()
+	defer fake.warmMutex.Unlock()
+	fake.WarmStub = stub
+}
+
+func (fake *FakeResourceCacheWarmer) WarmArgsForCall(i int) (context.Context, string, db.UsedResourceCache) {
+	fake.warmMutex.RLock()
+	defer fake.warmMutex.RUnlock()
+	argsForCall := fake.warmArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeResourceCacheWarmer) WarmReturns(result1 bool, result2 error) {
+	fake.warmMutex.Lock()
+	defer fake.warmMutex.Unlock()
+	fake.WarmStub = nil
+	fake.warmReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceCacheWarmer) WarmReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.warmMutex.Lock()
+	defer fake.warmMutex.Unlock()
+	fake.WarmStub = nil
+	if fake.warmReturnsOnCall == nil {
+		fake.warmReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.warmReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceCacheWarmer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.warmMutex.RLock()
+	defer fake.warmMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeResourceCacheWarmer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ worker.ResourceCacheWarmer = new(FakeResourceCacheWarmer)
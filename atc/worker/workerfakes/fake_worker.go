@@ -332,6 +332,16 @@ type FakeWorker struct {
 	tagsReturnsOnCall map[int]struct {
 		result1 atc.Tags
 	}
+	TeamIDStub        func() int
+	teamIDMutex       sync.RWMutex
+	teamIDArgsForCall []struct {
+	}
+	teamIDReturns struct {
+		result1 int
+	}
+	teamIDReturnsOnCall map[int]struct {
+		result1 int
+	}
 	UptimeStub        func() time.Duration
 	uptimeMutex       sync.RWMutex
 	uptimeArgsForCall []struct {
@@ -1789,6 +1799,59 @@ func (fake *FakeWorker) TagsReturnsOnCall(i int, result1 atc.Tags) {
 	}{result1}
 }
 
+func (fake *FakeWorker) TeamID() int {
+	fake.teamIDMutex.Lock()
+	ret, specificReturn := fake.teamIDReturnsOnCall[len(fake.teamIDArgsForCall)]
+	fake.teamIDArgsForCall = append(fake.teamIDArgsForCall, struct {
+	}{})
+	stub := fake.TeamIDStub
+	fakeReturns := fake.teamIDReturns
+	fake.recordInvocation("TeamID", []interface{}{})
+	fake.teamIDMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) TeamIDCallCount() int {
+	fake.teamIDMutex.RLock()
+	defer fake.teamIDMutex.RUnlock()
+	return len(fake.teamIDArgsForCall)
+}
+
+func (fake *FakeWorker) TeamIDCalls(stub func() int) {
+	fake.teamIDMutex.Lock()
+	defer fake.teamIDMutex.Unlock()
+	fake.TeamIDStub = stub
+}
+
+func (fake *FakeWorker) TeamIDReturns(result1 int) {
+	fake.teamIDMutex.Lock()
+	defer fake.teamIDMutex.Unlock()
+	fake.TeamIDStub = nil
+	fake.teamIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) TeamIDReturnsOnCall(i int, result1 int) {
+	fake.teamIDMutex.Lock()
+	defer fake.teamIDMutex.Unlock()
+	fake.TeamIDStub = nil
+	if fake.teamIDReturnsOnCall == nil {
+		fake.teamIDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.teamIDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeWorker) Uptime() time.Duration {
 	fake.uptimeMutex.Lock()
 	ret, specificReturn := fake.uptimeReturnsOnCall[len(fake.uptimeArgsForCall)]
@@ -1893,6 +1956,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.satisfiesMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
+	fake.teamIDMutex.RLock()
+	defer fake.teamIDMutex.RUnlock()
 	fake.uptimeMutex.RLock()
 	defer fake.uptimeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
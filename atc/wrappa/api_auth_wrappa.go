@@ -47,21 +47,32 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetBuildPreparation,
 			atc.BuildEvents,
 			atc.GetBuildPlan,
-			atc.ListBuildArtifacts:
+			atc.GetBuildProvenance,
+			atc.ListBuildArtifacts,
+			atc.ListBuildVolumes,
+			atc.ListBuildApprovals:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 			// resource belongs to authorized team
-		case atc.AbortBuild:
+		case atc.AbortBuild,
+			atc.ProtectBuild,
+			atc.UnprotectBuild,
+			atc.CancelCheck,
+			atc.GetBuildServerLogs,
+			atc.DecideBuildApproval:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// requester is system, admin team, or worker owning team
 		case atc.PruneWorker,
 			atc.LandWorker,
 			atc.RetireWorker,
+			atc.WarmWorker,
+			atc.ReportWorkerDiskUsage,
 			atc.ListDestroyingVolumes,
 			atc.ListDestroyingContainers,
 			atc.ReportWorkerContainers,
-			atc.ReportWorkerVolumes:
+			atc.ReportWorkerVolumes,
+			atc.ReportWorkerVolumeSizes:
 			newHandler = wrappa.checkWorkerTeamAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// pipeline is public or authorized
@@ -79,16 +90,24 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetResourceCausality,
 			atc.GetResourceVersion,
 			atc.ListResources,
+			atc.ListResourceChecks,
+			atc.ListResourceCheckRecords,
+			atc.ListPendingChecks,
+			atc.ListSerialGroups,
 			atc.ListResourceTypes,
-			atc.ListResourceVersions:
+			atc.ListResourceVersions,
+			atc.ListResourceVersionToggles:
 			newHandler = wrappa.checkPipelineAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// authenticated
 		case atc.ListWorkers,
+			atc.ListWorkerUsage,
+			atc.PruneStalledWorkers,
 			atc.RegisterWorker,
 			atc.HeartbeatWorker,
 			atc.DeleteWorker,
 			atc.ListTeamBuilds,
+			atc.ListTeamUsage,
 			atc.GetUser:
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
@@ -101,6 +120,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListPipelines,
 			atc.ListAllJobs,
 			atc.ListAllResources,
+			atc.ListResourceTypeUsages,
 			atc.ListBuilds,
 			atc.MainJobBadge,
 			atc.GetWall:
@@ -113,7 +133,11 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.SetLogLevel,
 			atc.GetInfoCreds,
 			atc.SetWall,
-			atc.ClearWall:
+			atc.ClearWall,
+			atc.BackfillResourceVersions,
+			atc.ExpireResourceVersionCaches,
+			atc.RotateEncryptionKey,
+			atc.GetEncryptionKeyRotation:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
 		// authorized (requested team matches resource team and has required role, or is admin)
@@ -133,13 +157,24 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.DeletePipeline,
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
+			atc.PromoteResourceVersion,
+			atc.UnpromoteResourceVersion,
 			atc.PinResourceVersion,
 			atc.UnpinResource,
+			atc.ListVersionSets,
+			atc.SaveVersionSet,
+			atc.ApplyVersionSet,
+			atc.ClearVersionSet,
+			atc.DestroyVersionSet,
 			atc.SetPinCommentOnResource,
+			atc.PauseResourceChecking,
+			atc.UnpauseResourceChecking,
 			atc.GetConfig,
+			atc.GetResourceTypeClosure,
 			atc.GetCC,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
+			atc.GetJobSchedulerDecision,
 			atc.OrderPipelines,
 			atc.OrderPipelinesWithinGroup,
 			atc.PauseJob,
@@ -150,8 +185,17 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ExposePipeline,
 			atc.HidePipeline,
 			atc.SaveConfig,
+			atc.PatchConfig,
 			atc.ArchivePipeline,
 			atc.ClearTaskCache,
+			atc.ListSharedArtifacts,
+			atc.GrantSharedArtifact,
+			atc.RevokeSharedArtifact,
+			atc.ListPipelineVars,
+			atc.SetPipelineVar,
+			atc.DeletePipelineVar,
+			atc.GetTeamResourceTypes,
+			atc.SetTeamResourceTypes,
 			atc.CreateArtifact,
 			atc.ScheduleJob,
 			atc.GetArtifact:
@@ -32,9 +32,19 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.CheckResourceType,
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
+			atc.PromoteResourceVersion,
+			atc.UnpromoteResourceVersion,
 			atc.PinResourceVersion,
+			atc.BackfillResourceVersions,
+			atc.ExpireResourceVersionCaches,
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
+			atc.PauseResourceChecking,
+			atc.UnpauseResourceChecking,
+			atc.SaveVersionSet,
+			atc.ApplyVersionSet,
+			atc.ClearVersionSet,
+			atc.PatchConfig,
 			atc.RerunJobBuild:
 
 			newHandler = rw.handlerFactory.RejectArchived(handler)
@@ -42,23 +52,38 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			// leave the handler as-is
 		case
 			atc.GetConfig,
+			atc.GetResourceTypeClosure,
 			atc.GetBuild,
 			atc.BuildResources,
 			atc.BuildEvents,
 			atc.ListBuildArtifacts,
+			atc.ListBuildVolumes,
 			atc.GetBuildPreparation,
 			atc.GetBuildPlan,
+			atc.GetBuildProvenance,
 			atc.AbortBuild,
+			atc.ProtectBuild,
+			atc.UnprotectBuild,
+			atc.CancelCheck,
+			atc.GetBuildServerLogs,
+			atc.ListBuildApprovals,
+			atc.DecideBuildApproval,
 			atc.PruneWorker,
 			atc.LandWorker,
+			atc.WarmWorker,
+			atc.ReportWorkerDiskUsage,
 			atc.ReportWorkerContainers,
 			atc.ReportWorkerVolumes,
+			atc.ReportWorkerVolumeSizes,
 			atc.RetireWorker,
 			atc.ListDestroyingContainers,
 			atc.ListDestroyingVolumes,
 			atc.GetPipeline,
 			atc.GetJobBuild,
 			atc.PipelineBadge,
+			atc.ListSerialGroups,
+			atc.ListVersionSets,
+			atc.DestroyVersionSet,
 			atc.JobBadge,
 			atc.ListJobs,
 			atc.GetJob,
@@ -68,8 +93,12 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListBuildsWithVersionAsInput,
 			atc.ListBuildsWithVersionAsOutput,
 			atc.ListResources,
+			atc.ListResourceChecks,
+			atc.ListResourceCheckRecords,
+			atc.ListPendingChecks,
 			atc.ListResourceTypes,
 			atc.ListResourceVersions,
+			atc.ListResourceVersionToggles,
 			atc.GetResourceCausality,
 			atc.GetResourceVersion,
 			atc.CreateBuild,
@@ -79,6 +108,8 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListVolumes,
 			atc.ListTeamBuilds,
 			atc.ListWorkers,
+			atc.ListWorkerUsage,
+			atc.PruneStalledWorkers,
 			atc.RegisterWorker,
 			atc.HeartbeatWorker,
 			atc.DeleteWorker,
@@ -95,7 +126,9 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListPipelines,
 			atc.ListAllJobs,
 			atc.ListAllResources,
+			atc.ListResourceTypeUsages,
 			atc.ListTeams,
+			atc.ListTeamUsage,
 			atc.MainJobBadge,
 			atc.GetWall,
 			atc.GetLogLevel,
@@ -104,10 +137,13 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListActiveUsersSince,
 			atc.SetWall,
 			atc.ClearWall,
+			atc.RotateEncryptionKey,
+			atc.GetEncryptionKeyRotation,
 			atc.DeletePipeline,
 			atc.GetCC,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
+			atc.GetJobSchedulerDecision,
 			atc.OrderPipelines,
 			atc.OrderPipelinesWithinGroup,
 			atc.PauseJob,
@@ -119,6 +155,14 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.HidePipeline,
 			atc.CreatePipelineBuild,
 			atc.ClearTaskCache,
+			atc.ListSharedArtifacts,
+			atc.GrantSharedArtifact,
+			atc.RevokeSharedArtifact,
+			atc.ListPipelineVars,
+			atc.SetPipelineVar,
+			atc.DeletePipelineVar,
+			atc.GetTeamResourceTypes,
+			atc.SetTeamResourceTypes,
 			atc.CreateArtifact,
 			atc.GetArtifact:
 
@@ -39,9 +39,19 @@ var _ = Describe("RejectArchivedWrappa", func() {
 			atc.CheckResourceType,
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
+			atc.PromoteResourceVersion,
+			atc.UnpromoteResourceVersion,
 			atc.PinResourceVersion,
+			atc.BackfillResourceVersions,
+			atc.ExpireResourceVersionCaches,
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
+			atc.PauseResourceChecking,
+			atc.UnpauseResourceChecking,
+			atc.SaveVersionSet,
+			atc.ApplyVersionSet,
+			atc.ClearVersionSet,
+			atc.PatchConfig,
 			atc.RerunJobBuild,
 		}
 
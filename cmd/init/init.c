@@ -3,18 +3,107 @@
  * parents usually receive when their children `exit`) to include SA_NOCLDWAIT
  * (so that we don't need to `wait` for children to have them not becoming
  * zombies when they `exit`).
+ *
+ * When given a command (e.g. `init /bin/my-task arg`), it instead runs the
+ * command as its child, forwarding signals to it and reaping any process
+ * orphaned under it, and exits with the command's exit status once the
+ * command exits. Tasks opt into this to have an init process of their own.
  */
 
+#include <errno.h>
 #include <stdio.h>
 #include <unistd.h>
 #include <signal.h>
 #include <stddef.h>
+#include <sys/prctl.h>
+#include <sys/wait.h>
+
+static pid_t child = 0;
+
+static void
+forward(int sig)
+{
+	if (child > 0) {
+		kill(child, sig);
+	}
+}
+
+static int
+run(char **argv)
+{
+	int forwarded[] = {SIGHUP, SIGINT, SIGQUIT, SIGTERM, SIGUSR1, SIGUSR2, SIGWINCH};
+	struct sigaction act = {0};
+	size_t i;
+	int status;
+	pid_t pid;
+
+	// become the parent of any process orphaned by the command, instead of
+	// the container's init, so that we get to reap it.
+	//
+	if (!~prctl(PR_SET_CHILD_SUBREAPER, 1)) {
+		perror("prctl PR_SET_CHILD_SUBREAPER");
+		return 1;
+	}
+
+	// install the handlers before forking so that no signal is missed. the
+	// command gets the default dispositions back once it `exec`s.
+	//
+	act.sa_handler = forward;
+	sigemptyset(&act.sa_mask);
+	for (i = 0; i < sizeof(forwarded) / sizeof(forwarded[0]); i++) {
+		if (!~sigaction(forwarded[i], &act, NULL)) {
+			perror("sigaction");
+			return 1;
+		}
+	}
+
+	child = fork();
+	if (!~child) {
+		perror("fork");
+		return 1;
+	}
+
+	if (child == 0) {
+		execvp(argv[0], argv);
+		status = errno == ENOENT ? 127 : 126;
+		perror(argv[0]);
+		_exit(status);
+	}
+
+	// reap whatever exits until the command itself does.
+	//
+	for (;;) {
+		pid = waitpid(-1, &status, 0);
+		if (!~pid) {
+			if (errno == EINTR) {
+				continue;
+			}
+
+			perror("waitpid");
+			return 1;
+		}
+
+		if (pid != child) {
+			continue;
+		}
+
+		if (WIFSIGNALED(status)) {
+			return 128 + WTERMSIG(status);
+		}
+
+		return WEXITSTATUS(status);
+	}
+}
 
 int
-main(void)
+main(int argc, char **argv)
 {
 	struct sigaction act;
 
+	if (argc > 1) {
+		return run(argv + 1);
+	}
+
 	// retrieve the action that is currently associated with SIGCHLD
 	//
 	if (!~sigaction(SIGCHLD, NULL, &act)) {
@@ -29,7 +118,7 @@ main(void)
 	act.sa_flags |= SA_NOCLDWAIT;
 
 	// set the action with our new flag set
-	// 
+	//
 	if (!~sigaction(SIGCHLD, &act, NULL)) {
 		perror("sigaction SIGCHLD SA_NOCLDWAIT");
 		return 1;
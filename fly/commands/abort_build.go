@@ -10,8 +10,9 @@ import (
 )
 
 type AbortBuildCommand struct {
-	Job   flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB"   description:"Name of a job to cancel"`
-	Build string              `short:"b" long:"build" required:"true" description:"If job is specified: build number to cancel. If job not specified: build id"`
+	Job    flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB"   description:"Name of a job to cancel"`
+	Build  string              `short:"b" long:"build" required:"true" description:"If job is specified: build number to cancel. If job not specified: build id"`
+	Reason string              `short:"r" long:"reason" description:"Why the build is being aborted, shown alongside its status"`
 }
 
 func (command *AbortBuildCommand) Execute([]string) error {
@@ -40,7 +41,7 @@ func (command *AbortBuildCommand) Execute([]string) error {
 		return fmt.Errorf("build does not exist")
 	}
 
-	if err := target.Client().AbortBuild(strconv.Itoa(build.ID)); err != nil {
+	if err := target.Client().AbortBuild(strconv.Itoa(build.ID), command.Reason); err != nil {
 		return err
 	}
 
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type ApproveBuildCommand struct {
+	Job     flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Name of the job the build belongs to"`
+	Build   string              `short:"b" long:"build" required:"true" description:"If job is specified: build number. If job not specified: build id"`
+	Step    string              `short:"s" long:"step" value-name:"NAME" description:"Name of the approval step, required if the build is waiting on more than one"`
+	Reject  bool                `long:"reject" description:"Reject the step instead of approving it, failing the build"`
+	Comment string              `short:"m" long:"comment" description:"Comment to record alongside the decision"`
+}
+
+func (command *ApproveBuildCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var build atc.Build
+	var exists bool
+	if command.Job.PipelineRef.Name == "" && command.Job.JobName == "" {
+		build, exists, err = target.Client().Build(command.Build)
+	} else {
+		build, exists, err = target.Team().JobBuild(command.Job.PipelineRef, command.Job.JobName, command.Build)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("build does not exist")
+	}
+
+	buildID := strconv.Itoa(build.ID)
+
+	approvals, err := target.Client().ListBuildApprovals(buildID)
+	if err != nil {
+		return err
+	}
+
+	var pending []atc.BuildApproval
+	for _, approval := range approvals {
+		if approval.Status != atc.ApprovalPending {
+			continue
+		}
+
+		if command.Step != "" && approval.Name != command.Step {
+			continue
+		}
+
+		pending = append(pending, approval)
+	}
+
+	switch len(pending) {
+	case 0:
+		if command.Step != "" {
+			return fmt.Errorf("build is not waiting on approval step '%s'", command.Step)
+		}
+		return errors.New("build is not waiting on any approval steps")
+	case 1:
+	default:
+		var names []string
+		for _, approval := range pending {
+			names = append(names, approval.Name)
+		}
+		return fmt.Errorf("build is waiting on more than one approval step (%s); specify one with --step", strings.Join(names, ", "))
+	}
+
+	err = target.Client().DecideBuildApproval(buildID, pending[0].PlanID, atc.BuildApprovalDecision{
+		Approved: !command.Reject,
+		Comment:  command.Comment,
+	})
+	if err == concourse.ErrApprovalNotPending {
+		return fmt.Errorf("approval step '%s' has already been decided", pending[0].Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if command.Reject {
+		fmt.Printf("rejected %s\n", pending[0].Name)
+	} else {
+		fmt.Printf("approved %s\n", pending[0].Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"sigs.k8s.io/yaml"
+)
+
+type BackfillResourceVersionsCommand struct {
+	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of the resource"`
+	Versions atc.PathFlag             `short:"f" long:"versions-file" required:"true" description:"YAML or JSON file listing the versions to import, oldest first"`
+	Team     string                   `long:"team" description:"Name of the team to which the resource belongs, if different from the target default"`
+}
+
+func (command *BackfillResourceVersionsCommand) Execute([]string) error {
+	versionsFile, err := ioutil.ReadFile(string(command.Versions))
+	if err != nil {
+		return err
+	}
+
+	var versions []atc.Version
+	err = yaml.Unmarshal(versionsFile, &versions)
+	if err != nil {
+		return fmt.Errorf("failed to parse versions file: %s", err)
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team := target.Team()
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	}
+
+	newVersions, found, err := team.BackfillResourceVersions(command.Resource.PipelineRef, command.Resource.ResourceName, versions)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("resource '%s' not found", command.Resource.ResourceName)
+	}
+
+	fmt.Printf("backfilled %d new version(s) of %s\n", newVersions, command.Resource.String())
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type BuildLogsCommand struct {
+	Build int  `short:"b" long:"build" required:"true" value-name:"ID" description:"ID of the build whose server logs to print"`
+	Json  bool `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *BuildLogsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	logs, found, err := target.Client().BuildServerLogs(command.Build)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("build not found")
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(logs)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "timestamp", Color: color.New(color.Bold)},
+			{Contents: "level", Color: color.New(color.Bold)},
+			{Contents: "message", Color: color.New(color.Bold)},
+			{Contents: "data", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, log := range logs {
+		levelCell := ui.TableCell{Contents: string(log.LogLevel)}
+		if log.LogLevel == atc.LogLevelError || log.LogLevel == atc.LogLevelFatal {
+			levelCell.Color = ui.FailedColor
+		}
+
+		data, err := json.Marshal(log.Data)
+		if err != nil {
+			return err
+		}
+
+		table.Data = append(table.Data, []ui.TableCell{
+			{Contents: formatLogTimestamp(log.Timestamp)},
+			levelCell,
+			{Contents: log.Message},
+			{Contents: string(data)},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+// formatLogTimestamp converts the seconds since the epoch logged by the ATC
+// to local time, leaving it as-is if it is in any other format.
+func formatLogTimestamp(timestamp string) string {
+	seconds, err := strconv.ParseFloat(timestamp, 64)
+	if err != nil {
+		return timestamp
+	}
+
+	return time.Unix(0, int64(seconds*float64(time.Second))).Local().Format(time.RFC3339)
+}
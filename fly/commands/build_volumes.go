@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type BuildVolumesCommand struct {
+	Build int  `short:"b" long:"build" required:"true" value-name:"ID" description:"ID of the build whose containers and volumes to list"`
+	Json  bool `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *BuildVolumesCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	buildVolumes, found, err := target.Client().BuildVolumes(command.Build)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("build not found")
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(buildVolumes)
+	}
+
+	containersTable := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "handle", Color: color.New(color.Bold)},
+			{Contents: "worker", Color: color.New(color.Bold)},
+			{Contents: "type", Color: color.New(color.Bold)},
+			{Contents: "step", Color: color.New(color.Bold)},
+			{Contents: "state", Color: color.New(color.Bold)},
+		},
+	}
+
+	steps := map[string]string{}
+	for _, container := range buildVolumes.Containers {
+		steps[container.ID] = container.StepName
+
+		containersTable.Data = append(containersTable.Data, ui.TableRow{
+			{Contents: container.ID},
+			{Contents: container.WorkerName},
+			{Contents: container.Type},
+			optionalCell(container.StepName),
+			{Contents: container.State},
+		})
+	}
+
+	volumesTable := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "handle", Color: color.New(color.Bold)},
+			{Contents: "worker", Color: color.New(color.Bold)},
+			{Contents: "type", Color: color.New(color.Bold)},
+			{Contents: "container", Color: color.New(color.Bold)},
+			{Contents: "step", Color: color.New(color.Bold)},
+			{Contents: "parent", Color: color.New(color.Bold)},
+			{Contents: "size", Color: color.New(color.Bold)},
+			{Contents: "inherited", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, volume := range buildVolumes.Volumes {
+		sizeCell := optionalCell("")
+		if volume.SizeBytes != nil {
+			sizeCell = ui.TableCell{Contents: formatBytes(*volume.SizeBytes)}
+		}
+
+		inheritedCell := ui.TableCell{Contents: "no"}
+		if volume.Inherited {
+			inheritedCell = ui.TableCell{Contents: "yes"}
+		}
+
+		volumesTable.Data = append(volumesTable.Data, ui.TableRow{
+			{Contents: volume.ID},
+			{Contents: volume.WorkerName},
+			{Contents: volume.Type},
+			optionalCell(volume.ContainerHandle),
+			optionalCell(steps[volume.ContainerHandle]),
+			optionalCell(volume.ParentHandle),
+			sizeCell,
+			inheritedCell,
+		})
+	}
+
+	err = containersTable.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+
+	return volumesTable.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+var byteUnits = []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// formatBytes formats a number of bytes using the largest binary unit it
+// amounts to at least one of.
+func formatBytes(bytes uint64) string {
+	if bytes < 1024 {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	size := float64(bytes) / 1024
+	unit := 0
+	for size >= 1024 && unit < len(byteUnits)-1 {
+		size /= 1024
+		unit++
+	}
+
+	return fmt.Sprintf("%.1f %s", size, byteUnits[unit])
+}
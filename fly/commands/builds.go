@@ -223,15 +223,27 @@ func (command *BuildsCommand) displayBuilds(builds []atc.Build) error {
 		names = append(names, b.Name)
 
 		nameCell.Contents = strings.Join(names, "/")
+		if b.Protected {
+			nameCell.Contents += " (protected)"
+		}
 
 		createdBy := "system"
 		if b.CreatedBy != nil {
 			createdBy = *b.CreatedBy
 		}
+
+		statusCell := ui.BuildStatusCell(b.Status)
+		if b.AbortedBy != nil {
+			statusCell.Contents += " by " + *b.AbortedBy
+		}
+		if b.AbortReason != "" {
+			statusCell.Contents += ": " + b.AbortReason
+		}
+
 		table.Data = append(table.Data, []ui.TableCell{
 			{Contents: strconv.Itoa(b.ID)},
 			nameCell,
-			ui.BuildStatusCell(b.Status),
+			statusCell,
 			startTimeCell,
 			endTimeCell,
 			durationCell,
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type CancelCheckCommand struct {
+	ID int `long:"id" required:"true" description:"ID of the check to cancel, as shown by fly checks"`
+}
+
+func (command *CancelCheckCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = target.Client().CancelCheck(strconv.Itoa(command.ID))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("check successfully canceled")
+	return nil
+}
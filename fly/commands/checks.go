@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type ChecksCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Show the pending checks of this pipeline"`
+	Json     bool                     `long:"json" description:"Print command result as JSON"`
+	Team     string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *ChecksCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	checks, err := team.ListPendingChecks(command.Pipeline.Ref())
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(checks)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "id", Color: color.New(color.Bold)},
+			{Contents: "resource", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+			{Contents: "created", Color: color.New(color.Bold)},
+			{Contents: "manual", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, check := range checks {
+		resourceCell := ui.TableCell{Contents: check.ResourceName}
+		if check.ResourceTypeName != "" {
+			resourceCell.Contents = check.ResourceTypeName + " (type)"
+		}
+
+		statusCell := ui.TableCell{Contents: check.Status, Color: ui.PendingColor}
+		if check.Status == atc.PendingCheckRunning {
+			statusCell.Color = ui.StartedColor
+		}
+
+		manualCell := ui.TableCell{Contents: "no", Color: ui.OffColor}
+		if check.ManuallyTriggered {
+			manualCell = ui.TableCell{Contents: "yes"}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: strconv.Itoa(check.BuildID)},
+			resourceCell,
+			statusCell,
+			checkTimeCell(check.CreateTime),
+			manualCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+)
+
+type ClearVersionSetCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Pipeline whose resources to unpin"`
+	Name     string                   `short:"n" long:"name"     required:"true" description:"Name of the version set"`
+	Team     string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *ClearVersionSetCommand) Execute([]string) error {
+	_, err := command.Pipeline.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := versionSetTeam(command.Team)
+	if err != nil {
+		return err
+	}
+
+	pipelineRef := command.Pipeline.Ref()
+	found, err := team.ClearVersionSet(pipelineRef, command.Name)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("version set '%s' of pipeline '%s' not found\n", command.Name, pipelineRef.String())
+	}
+
+	fmt.Printf("unpinned the resources pinned by version set '%s' of pipeline '%s'\n", command.Name, pipelineRef.String())
+
+	return nil
+}
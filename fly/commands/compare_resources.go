@@ -0,0 +1,233 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type CompareResourcesCommand struct {
+	A     flaghelpers.ResourceFlag `long:"a" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of the resource to compare against"`
+	B     flaghelpers.ResourceFlag `long:"b" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of the resource to compare"`
+	Count int                      `short:"c" long:"count" default:"50" description:"Number of most recent versions of each resource to compare"`
+	Json  bool                     `long:"json" description:"Print command result as JSON"`
+}
+
+type resourceComparison struct {
+	// Status is one of the resourceComparison* constants below
+	Status string `json:"status"`
+	// Distance is how many versions b is behind or ahead of a
+	Distance int `json:"distance,omitempty"`
+
+	Versions []versionDiff `json:"versions"`
+}
+
+type versionDiff struct {
+	Version atc.Version `json:"version"`
+	Change  string      `json:"change"`
+
+	AMetadata []atc.MetadataField `json:"a_metadata,omitempty"`
+	BMetadata []atc.MetadataField `json:"b_metadata,omitempty"`
+}
+
+const (
+	resourceComparisonSame     = "same"
+	resourceComparisonBehind   = "behind"
+	resourceComparisonAhead    = "ahead"
+	resourceComparisonDiverged = "diverged"
+
+	versionOnlyInA         = "only in a"
+	versionOnlyInB         = "only in b"
+	versionMetadataChanged = "metadata changed"
+)
+
+func (command *CompareResourcesCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	a, err := command.versions(target, command.A)
+	if err != nil {
+		return err
+	}
+
+	b, err := command.versions(target, command.B)
+	if err != nil {
+		return err
+	}
+
+	comparison := compareVersions(a, b)
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(comparison)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "version", Color: color.New(color.Bold)},
+			{Contents: "change", Color: color.New(color.Bold)},
+			{Contents: "a metadata", Color: color.New(color.Bold)},
+			{Contents: "b metadata", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, diff := range comparison.Versions {
+		changeCell := ui.TableCell{Contents: diff.Change}
+		switch diff.Change {
+		case versionOnlyInA:
+			changeCell.Color = ui.FailedColor
+		case versionOnlyInB:
+			changeCell.Color = ui.SucceededColor
+		case versionMetadataChanged:
+			changeCell.Color = ui.StartedColor
+		}
+
+		table.Data = append(table.Data, []ui.TableCell{
+			{Contents: formatVersion(diff.Version)},
+			changeCell,
+			{Contents: formatMetadata(diff.AMetadata)},
+			{Contents: formatMetadata(diff.BMetadata)},
+		})
+	}
+
+	err = table.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+
+	switch comparison.Status {
+	case resourceComparisonSame:
+		fmt.Printf("%s is on the same version as %s\n", command.B, command.A)
+	case resourceComparisonBehind:
+		fmt.Printf("%s is %d version(s) behind %s\n", command.B, comparison.Distance, command.A)
+	case resourceComparisonAhead:
+		fmt.Printf("%s is %d version(s) ahead of %s\n", command.B, comparison.Distance, command.A)
+	default:
+		fmt.Printf("the latest versions of %s and %s are not among each other's last %d versions\n", command.A, command.B, command.Count)
+	}
+
+	return nil
+}
+
+func (command *CompareResourcesCommand) versions(target rc.Target, resource flaghelpers.ResourceFlag) ([]atc.ResourceVersion, error) {
+	versions, _, found, err := target.Team().ResourceVersions(
+		resource.PipelineRef,
+		resource.ResourceName,
+		concourse.Page{Limit: command.Count},
+		atc.Version{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, fmt.Errorf("resource '%s' does not exist", resource)
+	}
+
+	if len(versions) > command.Count {
+		versions = versions[:command.Count]
+	}
+
+	return versions, nil
+}
+
+// compareVersions compares two lists of versions, newest first. The versions
+// that are in only one of them, or whose metadata differs, are returned in
+// the order they appear in a, followed by the ones only in b.
+func compareVersions(a, b []atc.ResourceVersion) resourceComparison {
+	comparison := resourceComparison{
+		Status:   resourceComparisonDiverged,
+		Versions: []versionDiff{},
+	}
+
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		comparison.Status = resourceComparisonSame
+	case len(a) != 0 && len(b) != 0:
+		if i := indexOfVersion(a, b[0].Version); i == 0 {
+			comparison.Status = resourceComparisonSame
+		} else if i > 0 {
+			comparison.Status = resourceComparisonBehind
+			comparison.Distance = i
+		} else if i := indexOfVersion(b, a[0].Version); i > 0 {
+			comparison.Status = resourceComparisonAhead
+			comparison.Distance = i
+		}
+	}
+
+	for _, version := range a {
+		i := indexOfVersion(b, version.Version)
+		if i == -1 {
+			comparison.Versions = append(comparison.Versions, versionDiff{
+				Version:   version.Version,
+				Change:    versionOnlyInA,
+				AMetadata: version.Metadata,
+			})
+			continue
+		}
+
+		if !metadataEqual(version.Metadata, b[i].Metadata) {
+			comparison.Versions = append(comparison.Versions, versionDiff{
+				Version:   version.Version,
+				Change:    versionMetadataChanged,
+				AMetadata: version.Metadata,
+				BMetadata: b[i].Metadata,
+			})
+		}
+	}
+
+	for _, version := range b {
+		if indexOfVersion(a, version.Version) == -1 {
+			comparison.Versions = append(comparison.Versions, versionDiff{
+				Version:   version.Version,
+				Change:    versionOnlyInB,
+				BMetadata: version.Metadata,
+			})
+		}
+	}
+
+	return comparison
+}
+
+func indexOfVersion(versions []atc.ResourceVersion, version atc.Version) int {
+	for i, v := range versions {
+		if versionsEqual(v.Version, version) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func metadataEqual(a, b []atc.MetadataField) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,242 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type DiffBuildsCommand struct {
+	Job    flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Name of the job the builds belong to"`
+	First  string              `long:"first" required:"true" description:"If job is specified: first build number. If job not specified: first build id"`
+	Second string              `long:"second" required:"true" description:"If job is specified: second build number. If job not specified: second build id"`
+	Json   bool                `long:"json" description:"Print command result as JSON"`
+}
+
+type inputDiff struct {
+	Name   string                `json:"name"`
+	Change string                `json:"change"`
+	First  *atc.PublicBuildInput `json:"first,omitempty"`
+	Second *atc.PublicBuildInput `json:"second,omitempty"`
+}
+
+const (
+	inputAdded   = "added"
+	inputRemoved = "removed"
+	inputChanged = "changed"
+	inputUnknown = "unknown"
+)
+
+func (command *DiffBuildsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	first, err := command.buildInputs(target, command.First)
+	if err != nil {
+		return err
+	}
+
+	second, err := command.buildInputs(target, command.Second)
+	if err != nil {
+		return err
+	}
+
+	diffs := diffInputs(first, second)
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(diffs)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "change", Color: color.New(color.Bold)},
+			{Contents: "first", Color: color.New(color.Bold)},
+			{Contents: "second", Color: color.New(color.Bold)},
+			{Contents: "metadata", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, diff := range diffs {
+		changeCell := ui.TableCell{Contents: diff.Change}
+		switch diff.Change {
+		case inputAdded:
+			changeCell.Color = ui.SucceededColor
+		case inputRemoved:
+			changeCell.Color = ui.FailedColor
+		case inputChanged:
+			changeCell.Color = ui.StartedColor
+		}
+
+		// show the metadata of the version the build moved to, or of the
+		// version it lost
+		metadataInput := diff.Second
+		if metadataInput == nil {
+			metadataInput = diff.First
+		}
+
+		table.Data = append(table.Data, []ui.TableCell{
+			{Contents: diff.Name},
+			changeCell,
+			inputVersionCell(diff.First),
+			inputVersionCell(diff.Second),
+			{Contents: formatMetadata(metadataInput.Metadata)},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func (command *DiffBuildsCommand) buildInputs(target rc.Target, buildRef string) ([]atc.PublicBuildInput, error) {
+	var build atc.Build
+	var exists bool
+	var err error
+	if command.Job.PipelineRef.Name == "" && command.Job.JobName == "" {
+		build, exists, err = target.Client().Build(buildRef)
+	} else {
+		build, exists, err = target.Team().JobBuild(command.Job.PipelineRef, command.Job.JobName, buildRef)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("build '%s' does not exist", buildRef)
+	}
+
+	resources, found, err := target.Client().BuildResources(build.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, fmt.Errorf("build '%s' does not exist", buildRef)
+	}
+
+	return resources.Inputs, nil
+}
+
+// diffInputs returns the inputs which differ between the two builds, sorted
+// by name.
+func diffInputs(first, second []atc.PublicBuildInput) []inputDiff {
+	firstByName := map[string]atc.PublicBuildInput{}
+	for _, input := range first {
+		firstByName[input.Name] = input
+	}
+
+	secondByName := map[string]atc.PublicBuildInput{}
+	for _, input := range second {
+		secondByName[input.Name] = input
+	}
+
+	diffs := []inputDiff{}
+	for name, firstInput := range firstByName {
+		firstInput := firstInput
+
+		secondInput, found := secondByName[name]
+		if !found {
+			diffs = append(diffs, inputDiff{Name: name, Change: inputRemoved, First: &firstInput})
+			continue
+		}
+
+		var change string
+		switch {
+		case firstInput.VersionMissing && secondInput.VersionMissing:
+			// both versions were garbage collected, so only their md5s tell
+			// them apart, if they are known
+			if firstInput.VersionMD5 == "" || secondInput.VersionMD5 == "" {
+				change = inputUnknown
+			} else if firstInput.VersionMD5 != secondInput.VersionMD5 {
+				change = inputChanged
+			} else {
+				continue
+			}
+		case firstInput.VersionMissing != secondInput.VersionMissing,
+			!versionsEqual(firstInput.Version, secondInput.Version):
+			change = inputChanged
+		default:
+			continue
+		}
+
+		diffs = append(diffs, inputDiff{Name: name, Change: change, First: &firstInput, Second: &secondInput})
+	}
+
+	for name, secondInput := range secondByName {
+		secondInput := secondInput
+
+		if _, found := firstByName[name]; !found {
+			diffs = append(diffs, inputDiff{Name: name, Change: inputAdded, Second: &secondInput})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Name < diffs[j].Name
+	})
+
+	return diffs
+}
+
+func versionsEqual(a, b atc.Version) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k, v := range a {
+		if bv, found := b[k]; !found || bv != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+func inputVersionCell(input *atc.PublicBuildInput) ui.TableCell {
+	if input == nil {
+		return ui.TableCell{Contents: "none", Color: color.New(color.Faint)}
+	}
+
+	if input.VersionMissing {
+		return ui.TableCell{Contents: "garbage collected", Color: color.New(color.Faint)}
+	}
+
+	return ui.TableCell{Contents: formatVersion(input.Version)}
+}
+
+func formatVersion(version atc.Version) string {
+	fields := []string{}
+	for k, v := range version {
+		fields = append(fields, k+":"+v)
+	}
+
+	sort.Strings(fields)
+
+	return strings.Join(fields, ",")
+}
+
+func formatMetadata(metadata []atc.MetadataField) string {
+	fields := []string{}
+	for _, field := range metadata {
+		fields = append(fields, field.Name+":"+field.Value)
+	}
+
+	return strings.Join(fields, ",")
+}
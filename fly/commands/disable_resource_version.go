@@ -13,6 +13,7 @@ import (
 type DisableResourceVersionCommand struct {
 	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of the resource"`
 	Version  *atc.Version             `short:"v" long:"version" required:"true" value-name:"KEY:VALUE" description:"Version of the resource to disable. The given key value pair(s) has to be an exact match but not all fields are needed. In the case of multiple resource versions matched, it will disable the latest one."`
+	Reason   string                   `long:"reason" description:"Why the version is disabled, recorded in the history of the resource"`
 }
 
 func (command *DisableResourceVersionCommand) Execute([]string) error {
@@ -37,7 +38,7 @@ func (command *DisableResourceVersionCommand) Execute([]string) error {
 		disabled := !latestResourceVer.Enabled
 
 		if !disabled {
-			disabled, err = team.DisableResourceVersion(command.Resource.PipelineRef, command.Resource.ResourceName, latestResourceVer.ID)
+			disabled, err = team.DisableResourceVersion(command.Resource.PipelineRef, command.Resource.ResourceName, latestResourceVer.ID, command.Reason)
 			if err != nil {
 				return err
 			}
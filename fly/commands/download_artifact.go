@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui/progress"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/concourse/go-archive/tgzfs"
+	"github.com/vbauerster/mpb/v4"
+)
+
+type DownloadArtifactCommand struct {
+	Job           flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of the job whose build produced the output"`
+	Build         string              `short:"b" long:"build" description:"Build number within the job"`
+	LatestSuccess bool                `long:"latest-success" description:"Download from the job's latest successful build"`
+	Output        string              `short:"o" long:"output" required:"true" value-name:"NAME" description:"Name of the task output to download"`
+	Path          string              `short:"d" long:"path" value-name:"DIR" description:"Directory to download the output into (default: ./NAME)"`
+	Team          string              `long:"team" description:"Name of the team to which the job belongs, if different from the target default"`
+}
+
+func (command *DownloadArtifactCommand) Execute([]string) error {
+	if command.LatestSuccess == (command.Build != "") {
+		return errors.New("exactly one of --build or --latest-success must be specified")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	var build atc.Build
+	if command.LatestSuccess {
+		build, err = GetLatestSuccessfulJobBuild(team, command.Job.PipelineRef, command.Job.JobName)
+	} else {
+		build, err = GetBuild(target.Client(), team, command.Job.JobName, command.Build, command.Job.PipelineRef)
+	}
+	if err != nil {
+		return err
+	}
+
+	artifacts, err := target.Client().ListBuildArtifacts(strconv.Itoa(build.ID))
+	if err != nil {
+		return err
+	}
+
+	var artifact *atc.WorkerArtifact
+	for _, a := range artifacts {
+		if a.Name == command.Output {
+			artifact = &a
+			break
+		}
+	}
+
+	gcErr := fmt.Errorf("output '%s' of %s/%s #%s is no longer available; its volume has been garbage collected", command.Output, command.Job.PipelineRef.String(), command.Job.JobName, build.Name)
+
+	if artifact == nil {
+		return gcErr
+	}
+
+	path := command.Path
+	if path == "" {
+		path = command.Output
+	}
+
+	out, err := team.GetArtifact(artifact.ID)
+	if err == concourse.ErrArtifactNotFound {
+		return gcErr
+	}
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	prog := progress.New()
+
+	prog.Go("downloading "+command.Output, func(bar *mpb.Bar) error {
+		return tgzfs.Extract(bar.ProxyReader(out), path)
+	})
+
+	err = prog.Wait()
+	if err != nil {
+		displayhelpers.FailWithErrorf("downloading failed", err)
+		return err
+	}
+
+	fmt.Printf("downloaded output '%s' of %s/%s #%s to %s\n", command.Output, command.Job.PipelineRef.String(), command.Job.JobName, build.Name, path)
+
+	return nil
+}
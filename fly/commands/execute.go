@@ -210,7 +210,7 @@ func abortOnSignal(
 
 	fmt.Fprintf(ui.Stderr, "\naborting...\n")
 
-	err := client.AbortBuild(strconv.Itoa(build.ID))
+	err := client.AbortBuild(strconv.Itoa(build.ID), "fly execute was interrupted")
 	if err != nil {
 		fmt.Fprintln(ui.Stderr, "failed to abort:", err)
 		os.Exit(2)
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type ExpireResourceCacheCommand struct {
+	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of the resource"`
+	Version  *atc.Version             `short:"v" long:"version" required:"true" value-name:"KEY:VALUE" description:"Version of the resource whose caches to expire. The given key value pair(s) has to be an exact match but not all fields are needed. In the case of multiple resource versions matched, it will expire the latest one."`
+	Team     string                   `long:"team" description:"Name of the team to which the resource belongs, if different from the target default"`
+}
+
+func (command *ExpireResourceCacheCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team := target.Team()
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	}
+
+	resourceVersion, err := GetLatestResourceVersion(team, command.Resource, *command.Version)
+	if err != nil {
+		return err
+	}
+
+	expired, found, err := team.ExpireResourceVersionCaches(command.Resource.PipelineRef, command.Resource.ResourceName, resourceVersion.ID)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("resource '%s' not found", command.Resource.ResourceName)
+	}
+
+	versionBytes, err := json.Marshal(resourceVersion.Version)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("expired %d cache(s) of %s with version %s, %d volume(s) scheduled for removal\n", expired.ResourceCaches, command.Resource.String(), string(versionBytes), expired.Volumes)
+
+	return nil
+}
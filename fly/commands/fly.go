@@ -32,10 +32,16 @@ type FlyCommand struct {
 	RenameTeam  RenameTeamCommand  `command:"rename-team"   alias:"rt" description:"Rename a team"`
 	DestroyTeam DestroyTeamCommand `command:"destroy-team"  alias:"dt" description:"Destroy a team and delete all of its data"`
 
+	TeamResourceTypes    TeamResourceTypesCommand    `command:"team-resource-types"     alias:"trts"  description:"List the resource types registered for a team"`
+	SetTeamResourceTypes SetTeamResourceTypesCommand `command:"set-team-resource-types" alias:"strts" description:"Register resource types for all of a team's pipelines to use"`
+
+	ResourceTypeUsages ResourceTypeUsagesCommand `command:"resource-type-usages" alias:"rtus" description:"List the pipelines' resources and resource types referencing a resource type"`
+
 	Checklist ChecklistCommand `command:"checklist" alias:"cl" description:"Print a Checkfile of the given pipeline"`
 
-	Execute ExecuteCommand `command:"execute" alias:"e" description:"Execute a one-off build using local bits"`
-	Watch   WatchCommand   `command:"watch"   alias:"w" description:"Stream a build's output"`
+	Execute      ExecuteCommand      `command:"execute"       alias:"e"  description:"Execute a one-off build using local bits"`
+	ValidateTask ValidateTaskCommand `command:"validate-task" alias:"vt" description:"Validate a task config without running it"`
+	Watch        WatchCommand        `command:"watch"         alias:"w"  description:"Stream a build's output"`
 
 	Containers ContainersCommand `command:"containers" alias:"cs" description:"Print the active containers"`
 	Hijack     HijackCommand     `command:"hijack"     alias:"intercept" alias:"i" description:"Execute a command in a container"`
@@ -49,6 +55,7 @@ type FlyCommand struct {
 	DestroyPipeline           DestroyPipelineCommand         `command:"destroy-pipeline"          alias:"dp"   description:"Destroy a pipeline"`
 	GetPipeline               GetPipelineCommand             `command:"get-pipeline"              alias:"gp"   description:"Get a pipeline's current configuration"`
 	SetPipeline               SetPipelineCommand             `command:"set-pipeline"              alias:"sp"   description:"Create or update a pipeline's configuration"`
+	PatchPipeline             PatchPipelineCommand           `command:"patch-pipeline"            alias:"pap"  description:"Apply a JSON Patch to a pipeline's configuration"`
 	PausePipeline             PausePipelineCommand           `command:"pause-pipeline"            alias:"pp"   description:"Pause a pipeline"`
 	ArchivePipeline           ArchivePipelineCommand         `command:"archive-pipeline"          alias:"ap"   description:"Archive a pipeline"`
 	UnpausePipeline           UnpausePipelineCommand         `command:"unpause-pipeline"          alias:"up"   description:"Un-pause a pipeline"`
@@ -57,16 +64,40 @@ type FlyCommand struct {
 	RenamePipeline            RenamePipelineCommand          `command:"rename-pipeline"           alias:"rp"   description:"Rename a pipeline"`
 	ValidatePipeline          ValidatePipelineCommand        `command:"validate-pipeline"         alias:"vp"   description:"Validate a pipeline config"`
 	FormatPipeline            FormatPipelineCommand          `command:"format-pipeline"           alias:"fp"   description:"Format a pipeline config"`
+	PipelineGraph             PipelineGraphCommand           `command:"pipeline-graph"            alias:"pg"   description:"Graph a pipeline's job dependencies as Graphviz DOT"`
+	ResourceTypeClosure       ResourceTypeClosureCommand     `command:"resource-type-closure"     alias:"rtc"  description:"List every resource type a pipeline config is built on"`
+	SerialGroups              SerialGroupsCommand            `command:"serial-groups"             alias:"sgs"  description:"Show the builds holding and waiting for each serial group of a pipeline"`
 	OrderPipelines            OrderPipelinesCommand          `command:"order-pipelines"           alias:"op"   description:"Orders pipelines"`
 	OrderPipelinesWithinGroup OrderInstancedPipelinesCommand `command:"order-instanced-pipelines" alias:"oip"  description:"Orders instanced pipelines within an instance group"`
-
-	Resources              ResourcesCommand              `command:"resources"                  alias:"rs"   description:"List the resources in the pipeline"`
-	ResourceVersions       ResourceVersionsCommand       `command:"resource-versions"          alias:"rvs"  description:"List the versions of a resource"`
-	CheckResource          CheckResourceCommand          `command:"check-resource"             alias:"cr"   description:"Check a resource"`
-	PinResource            PinResourceCommand            `command:"pin-resource"               alias:"pr"   description:"Pin a version to a resource"`
-	UnpinResource          UnpinResourceCommand          `command:"unpin-resource"             alias:"ur"   description:"Unpin a resource"`
-	EnableResourceVersion  EnableResourceVersionCommand  `command:"enable-resource-version"    alias:"erv"  description:"Enable a version of a resource"`
-	DisableResourceVersion DisableResourceVersionCommand `command:"disable-resource-version"   alias:"drv"  description:"Disable a version of a resource"`
+	PipelineVars              PipelineVarsCommand            `command:"pipeline-vars"             alias:"pvs"  description:"List the pipeline-local vars of a team's pipelines"`
+	SetVar                    SetVarCommand                  `command:"set-var"                   alias:"sv"   description:"Set a pipeline-local var across a team's pipelines"`
+	UnsetVar                  UnsetVarCommand                `command:"unset-var"                 alias:"uv"   description:"Delete a pipeline-local var across a team's pipelines"`
+
+	Resources               ResourcesCommand               `command:"resources"                  alias:"rs"   description:"List the resources in the pipeline"`
+	ResourceVersions        ResourceVersionsCommand        `command:"resource-versions"          alias:"rvs"  description:"List the versions of a resource"`
+	ResourceChecks          ResourceChecksCommand          `command:"resource-checks"            alias:"rcs"  description:"Show when the resources in the pipeline were last checked and are next due, or the check history of a resource"`
+	CheckResource           CheckResourceCommand           `command:"check-resource"             alias:"cr"   description:"Check a resource"`
+	Checks                  ChecksCommand                  `command:"checks"                     alias:"cks"  description:"List the queued and running checks of the pipeline"`
+	CancelCheck             CancelCheckCommand             `command:"cancel-check"               alias:"cc"   description:"Cancel a queued or running check"`
+	PinResource             PinResourceCommand             `command:"pin-resource"               alias:"pr"   description:"Pin a version to a resource"`
+	UnpinResource           UnpinResourceCommand           `command:"unpin-resource"             alias:"ur"   description:"Unpin a resource"`
+	PauseResourceChecking   PauseResourceCheckingCommand   `command:"pause-resource-checking"    alias:"prc"  description:"Pause checking of a resource for new versions"`
+	UnpauseResourceChecking UnpauseResourceCheckingCommand `command:"unpause-resource-checking"  alias:"uprc" description:"Unpause checking of a resource for new versions"`
+	EnableResourceVersion   EnableResourceVersionCommand   `command:"enable-resource-version"    alias:"erv"  description:"Enable a version of a resource"`
+	DisableResourceVersion  DisableResourceVersionCommand  `command:"disable-resource-version"   alias:"drv"  description:"Disable a version of a resource"`
+	ResourceVersionHistory  ResourceVersionHistoryCommand  `command:"resource-version-history"   alias:"rvh"  description:"Show who enabled and disabled versions of a resource, and why"`
+
+	PromoteResourceVersion   PromoteResourceVersionCommand   `command:"promote-resource-version"   alias:"prv"  description:"Promote a version of a resource as stable"`
+	UnpromoteResourceVersion UnpromoteResourceVersionCommand `command:"unpromote-resource-version" alias:"uprv" description:"Unpromote a stable version of a resource"`
+
+	VersionSets       VersionSetsCommand       `command:"version-sets"        alias:"vss" description:"List the version sets of a pipeline"`
+	SaveVersionSet    SaveVersionSetCommand    `command:"save-version-set"    alias:"svs" description:"Capture the current versions of a pipeline's resources as a version set"`
+	ApplyVersionSet   ApplyVersionSetCommand   `command:"apply-version-set"   alias:"avs" description:"Pin a pipeline's resources to the versions of a version set"`
+	ClearVersionSet   ClearVersionSetCommand   `command:"clear-version-set"   alias:"cvs" description:"Unpin the resources pinned by a version set"`
+	DestroyVersionSet DestroyVersionSetCommand `command:"destroy-version-set" alias:"dvs" description:"Destroy a version set"`
+
+	BackfillResourceVersions BackfillResourceVersionsCommand `command:"backfill-resource-versions" alias:"brv" description:"Import historical versions of a resource, oldest first (admin only)"`
+	ExpireResourceCache      ExpireResourceCacheCommand      `command:"expire-resource-cache"      alias:"erc" description:"Expire the caches of a resource version on all workers (admin only)"`
 
 	CheckResourceType CheckResourceTypeCommand `command:"check-resource-type" alias:"crt"  description:"Check a resource-type"`
 
@@ -76,6 +107,23 @@ type FlyCommand struct {
 	AbortBuild AbortBuildCommand `command:"abort-build" alias:"ab" description:"Abort a build"`
 	RerunBuild RerunBuildCommand `command:"rerun-build" alias:"rb" description:"Rerun a build"`
 
+	ProtectBuild   ProtectBuildCommand   `command:"protect-build"   alias:"pb"  description:"Exempt a build from build log retention and version garbage collection"`
+	UnprotectBuild UnprotectBuildCommand `command:"unprotect-build" alias:"upb" description:"Subject a protected build to garbage collection again"`
+
+	ApproveBuild ApproveBuildCommand `command:"approve-build" alias:"apb" description:"Approve or reject an approval step of a running build"`
+
+	DiffBuilds DiffBuildsCommand `command:"diff-builds" alias:"db" description:"Show the inputs that differ between two builds"`
+	BuildLogs  BuildLogsCommand  `command:"build-logs"  alias:"bl" description:"Print the ATC server logs pertaining to a build"`
+
+	BuildVolumes BuildVolumesCommand `command:"build-volumes" alias:"bvs" description:"List the containers and volumes created for a build"`
+
+	CompareResources CompareResourcesCommand `command:"compare-resources" alias:"cmpr" description:"Show the versions that differ between two resources"`
+
+	DownloadArtifact DownloadArtifactCommand `command:"download-artifact" alias:"da" description:"Download a task output of a job's build"`
+
+	SharedArtifacts     SharedArtifactsCommand     `command:"shared-artifacts"      alias:"sas" description:"List the artifacts shared by a team and who may consume them"`
+	GrantSharedArtifact GrantSharedArtifactCommand `command:"grant-shared-artifact" alias:"gsa" description:"Allow another team's builds to consume a shared artifact"`
+
 	TriggerJob TriggerJobCommand `command:"trigger-job" alias:"tj" description:"Start a job in a pipeline"`
 
 	Volumes VolumesCommand `command:"volumes" alias:"vs" description:"List the active volumes"`
@@ -83,6 +131,9 @@ type FlyCommand struct {
 	Workers     WorkersCommand     `command:"workers" alias:"ws" description:"List the registered workers"`
 	LandWorker  LandWorkerCommand  `command:"land-worker" alias:"lw" description:"Land a worker"`
 	PruneWorker PruneWorkerCommand `command:"prune-worker" alias:"pw" description:"Prune a stalled, landing, landed, or retiring worker"`
+	WarmWorker  WarmWorkerCommand  `command:"warm-worker" alias:"ww" description:"Stream resource caches onto a worker ahead of its first builds"`
+
+	RotateEncryptionKey RotateEncryptionKeyCommand `command:"rotate-encryption-key" alias:"rek" description:"Re-encrypt the database with the new encryption key (admin only)"`
 
 	Curl CurlCommand `command:"curl" alias:"c" description:"curl the api"`
 
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type GrantSharedArtifactCommand struct {
+	Artifact string `short:"a" long:"artifact" required:"true" value-name:"NAME" description:"Name of the shared artifact"`
+	To       string `long:"to" required:"true" value-name:"TEAM" description:"Name of the team to grant access to"`
+	Revoke   bool   `long:"revoke" description:"Revoke the team's access instead of granting it"`
+	Team     string `long:"team" description:"Name of the team which publishes the artifact, if different from the target default"`
+}
+
+func (command *GrantSharedArtifactCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	var found bool
+	if command.Revoke {
+		found, err = team.RevokeSharedArtifact(command.Artifact, command.To)
+	} else {
+		found, err = team.GrantSharedArtifact(command.Artifact, command.To)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("team '%s' not found", command.To)
+	}
+
+	if command.Revoke {
+		fmt.Printf("revoked access to %s/%s from team %s\n", team.Name(), command.Artifact, command.To)
+	} else {
+		fmt.Printf("granted access to %s/%s to team %s\n", team.Name(), command.Artifact, command.To)
+	}
+
+	return nil
+}
@@ -72,6 +72,31 @@ func GetBuild(client concourse.Client, team concourse.Team, jobName string, buil
 	}
 }
 
+func GetLatestSuccessfulJobBuild(team concourse.Team, pipelineRef atc.PipelineRef, jobName string) (atc.Build, error) {
+	page := &concourse.Page{Limit: 100}
+
+	for page != nil {
+		builds, pagination, found, err := team.JobBuilds(pipelineRef, jobName, *page)
+		if err != nil {
+			return atc.Build{}, fmt.Errorf("failed to get job builds %s", err)
+		}
+
+		if !found {
+			return atc.Build{}, errors.New("job not found")
+		}
+
+		for _, build := range builds {
+			if build.Status == atc.StatusSucceeded {
+				return build, nil
+			}
+		}
+
+		page = pagination.Next
+	}
+
+	return atc.Build{}, errors.New("job has no successful builds")
+}
+
 func GetLatestResourceVersion(team concourse.Team, resource flaghelpers.ResourceFlag, version atc.Version) (atc.ResourceVersion, error) {
 	versions, _, found, err := team.ResourceVersions(resource.PipelineRef, resource.ResourceName, concourse.Page{}, version)
 
@@ -0,0 +1,121 @@
+package validatetaskhelpers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/templatehelpers"
+	"github.com/concourse/concourse/vars"
+)
+
+var unknownFieldRegex = regexp.MustCompile(`unknown field "([^"]+)"`)
+
+func Validate(configPath atc.PathFlag, taskTemplate templatehelpers.YamlTemplateWithParams) error {
+	rawConfig, err := ioutil.ReadFile(string(configPath))
+	if err != nil {
+		return fmt.Errorf("could not read file: %s", err.Error())
+	}
+
+	evaluatedTemplate, err := taskTemplate.Evaluate(false, true)
+	if err != nil {
+		return err
+	}
+
+	lines := bytes.Split(rawConfig, []byte("\n"))
+
+	var errorMessages []string
+
+	// vars left over after evaluation were not provided by any of the flags
+	for _, name := range vars.NewTemplate(evaluatedTemplate).ExtraVarNames() {
+		errorMessages = append(errorMessages, withLineContext(
+			fmt.Sprintf("undefined var '%s'", name),
+			lines,
+			regexp.MustCompile(regexp.QuoteMeta("(("+name+"))")),
+			1,
+		))
+	}
+
+	config, err := atc.NewTaskConfig(evaluatedTemplate)
+	if err != nil {
+		var validationErr atc.TaskValidationError
+		if errors.As(err, &validationErr) {
+			for _, message := range validationErr.Errors {
+				errorMessages = append(errorMessages, strings.TrimSpace(message))
+			}
+		} else if match := unknownFieldRegex.FindStringSubmatch(err.Error()); match != nil {
+			errorMessages = append(errorMessages, withLineContext(
+				fmt.Sprintf("unknown field '%s'", match[1]),
+				lines,
+				regexp.MustCompile(`^\s*(- )?`+regexp.QuoteMeta(match[1])+`:`),
+				1,
+			))
+		} else {
+			errorMessages = append(errorMessages, err.Error())
+		}
+	} else {
+		var inputNames []string
+		for _, input := range config.Inputs {
+			inputNames = append(inputNames, input.Name)
+		}
+
+		var outputNames []string
+		for _, output := range config.Outputs {
+			outputNames = append(outputNames, output.Name)
+		}
+
+		errorMessages = append(errorMessages, duplicateNames("input", inputNames, lines)...)
+		errorMessages = append(errorMessages, duplicateNames("output", outputNames, lines)...)
+	}
+
+	if len(errorMessages) > 0 {
+		displayhelpers.ShowErrors("Error validating task config", errorMessages)
+		return errors.New("configuration invalid")
+	}
+
+	fmt.Println("looks good")
+
+	return nil
+}
+
+func duplicateNames(kind string, names []string, lines [][]byte) []string {
+	var messages []string
+
+	seen := map[string]int{}
+	for _, name := range names {
+		seen[name]++
+		if seen[name] == 2 {
+			messages = append(messages, withLineContext(
+				fmt.Sprintf("%s '%s' is declared more than once", kind, name),
+				lines,
+				regexp.MustCompile(`^\s*(- )?name:\s*["']?`+regexp.QuoteMeta(name)+`["']?\s*$`),
+				2,
+			))
+		}
+	}
+
+	return messages
+}
+
+// withLineContext appends the n-th line of the original config matching the
+// given pattern to the message, so that it can still be found in the file
+// even though the config has since been evaluated.
+func withLineContext(message string, lines [][]byte, pattern *regexp.Regexp, n int) string {
+	for i, line := range lines {
+		if !pattern.Match(line) {
+			continue
+		}
+
+		n--
+		if n == 0 {
+			return fmt.Sprintf("%s\n      %d | %s", message, i+1, line)
+		}
+	}
+
+	return message
+}
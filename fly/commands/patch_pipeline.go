@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"sigs.k8s.io/yaml"
+)
+
+type PatchPipelineCommand struct {
+	Pipeline      flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Pipeline to patch"`
+	Patch         atc.PathFlag             `short:"f" long:"patch"    required:"true" description:"YAML or JSON file containing the JSON Patch (RFC 6902) operations to apply"`
+	ConfigVersion string                   `long:"config-version" description:"Only apply the patch if the pipeline config is still at this version. Defaults to the current version"`
+	Team          string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *PatchPipelineCommand) Execute([]string) error {
+	patchFile, err := ioutil.ReadFile(string(command.Patch))
+	if err != nil {
+		return err
+	}
+
+	patch, err := yaml.YAMLToJSON(patchFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse patch file: %s", err)
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team := target.Team()
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	}
+
+	pipelineRef := command.Pipeline.Ref()
+
+	configVersion := command.ConfigVersion
+	if configVersion == "" {
+		var found bool
+		_, configVersion, found, err = team.PipelineConfig(pipelineRef)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return fmt.Errorf("pipeline '%s' not found", pipelineRef.String())
+		}
+	}
+
+	newVersion, warnings, found, err := team.PatchPipelineConfig(pipelineRef, configVersion, patch)
+	if err != nil {
+		if err == concourse.ErrConfigVersionConflict {
+			return fmt.Errorf("pipeline '%s' has been updated since config version %s, please retry", pipelineRef.String(), configVersion)
+		}
+
+		var invalidErr concourse.InvalidConfigError
+		if errors.As(err, &invalidErr) {
+			return errors.New(strings.Join(invalidErr.Errors, "\n"))
+		}
+
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("pipeline '%s' not found", pipelineRef.String())
+	}
+
+	if len(warnings) > 0 {
+		displayhelpers.ShowWarnings(warnings)
+	}
+
+	fmt.Printf("pipeline '%s' patched, config version is now %s\n", pipelineRef.String(), newVersion)
+
+	return nil
+}
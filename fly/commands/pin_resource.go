@@ -15,7 +15,7 @@ import (
 type PinResourceCommand struct {
 	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of the resource"`
 	Version  *atc.Version             `short:"v" long:"version" description:"Version of the resource to pin. The given key value pair(s) has to be an exact match but not all fields are needed. In the case of multiple resource versions matched, it will pin the latest one."`
-	Comment  string                   `short:"c" long:"comment" description:"Message to be saved to the pinned resource. Resource has to be pinned otherwise --version should be specified to pin the resource first."`
+	Comment  string                   `short:"c" long:"comment" description:"Reason for the pin, saved alongside it. Resource has to be pinned otherwise --version should be specified to pin the resource with the comment."`
 }
 
 func (command *PinResourceCommand) Execute([]string) error {
@@ -56,7 +56,7 @@ func (command *PinResourceCommand) Execute([]string) error {
 			return err
 		}
 
-		pinned, err := team.PinResourceVersion(pipelineRef, command.Resource.ResourceName, latestResourceVersion.ID)
+		pinned, err := team.PinResourceVersion(pipelineRef, command.Resource.ResourceName, latestResourceVersion.ID, command.Comment)
 
 		if err != nil {
 			return err
@@ -69,9 +69,15 @@ func (command *PinResourceCommand) Execute([]string) error {
 			}
 
 			fmt.Printf("pinned '%s/%s' with version %s\n", pipelineRef.String(), command.Resource.ResourceName, string(versionBytes))
+
+			if command.Comment != "" {
+				fmt.Printf("pin comment '%s' is saved\n", command.Comment)
+			}
 		} else {
 			displayhelpers.Failf("could not pin '%s/%s', make sure the resource exists\n", pipelineRef.String(), command.Resource.ResourceName)
 		}
+
+		return nil
 	}
 
 	if command.Comment != "" {
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type PipelineGraphCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Pipeline to graph"`
+	Output   string                   `short:"o" long:"output"   value-name:"PATH" description:"File to write the graph to, instead of stdout"`
+	Team     string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *PipelineGraphCommand) Validate() error {
+	_, err := command.Pipeline.Validate()
+	return err
+}
+
+func (command *PipelineGraphCommand) Execute(args []string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	pipelineRef := command.Pipeline.Ref()
+	config, _, found, err := team.PipelineConfig(pipelineRef)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("pipeline not found")
+	}
+
+	var out io.Writer = os.Stdout
+	if command.Output != "" {
+		file, err := os.Create(command.Output)
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+
+		out = file
+	}
+
+	return writeJobGraph(out, pipelineRef.String(), config)
+}
+
+// writeJobGraph writes the jobs of the pipeline as a Graphviz digraph, with
+// an edge from a job to each job taking one of its resources as a passed
+// constraint. Edges of inputs which don't trigger the job are dashed.
+func writeJobGraph(w io.Writer, pipelineName string, config atc.Config) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "digraph %s {\n", dotID(pipelineName))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	for _, job := range config.Jobs {
+		fmt.Fprintf(&b, "  %s;\n", dotID(job.Name))
+	}
+
+	type edge struct {
+		from, to, resource string
+	}
+
+	seen := map[edge]bool{}
+	for _, job := range config.Jobs {
+		for _, input := range job.Inputs() {
+			for _, passed := range input.Passed {
+				e := edge{from: passed, to: job.Name, resource: input.Resource}
+				if seen[e] {
+					continue
+				}
+
+				seen[e] = true
+
+				attrs := "label=" + dotID(input.Resource)
+				if !input.Trigger {
+					attrs += ", style=dashed"
+				}
+
+				fmt.Fprintf(&b, "  %s -> %s [%s];\n", dotID(e.from), dotID(e.to), attrs)
+			}
+		}
+	}
+
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func dotID(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"os"
+	"strings"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type PipelineVarsCommand struct {
+	Team string `long:"team" description:"Name of the team whose pipelines to list the vars of, if different from the target default"`
+	Json bool   `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *PipelineVarsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	pipelineVars, err := team.ListPipelineVars()
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(pipelineVars)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "pipeline", Color: color.New(color.Bold)},
+			{Contents: "vars", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, p := range pipelineVars {
+		varsCell := ui.TableCell{Contents: strings.Join(p.Vars, ",")}
+		if len(p.Vars) == 0 {
+			varsCell = ui.TableCell{Contents: "none", Color: color.New(color.Faint)}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: p.Pipeline.String()},
+			varsCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type PromoteResourceVersionCommand struct {
+	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of the resource"`
+	Version  *atc.Version             `short:"v" long:"version" required:"true" value-name:"KEY:VALUE" description:"Version of the resource to promote. The given key value pair(s) has to be an exact match but not all fields are needed. In the case of multiple resource versions matched, it will promote the latest one."`
+}
+
+func (command *PromoteResourceVersionCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team := target.Team()
+
+	if command.Version != nil {
+		latestResourceVer, err := GetLatestResourceVersion(team, command.Resource, *command.Version)
+		if err != nil {
+			return err
+		}
+
+		if !latestResourceVer.Enabled {
+			displayhelpers.Failf("could not promote '%s/%s', the resource version is disabled\n", command.Resource.PipelineRef.String(), command.Resource.ResourceName)
+		}
+
+		promoted := latestResourceVer.Promoted
+
+		if !promoted {
+			promoted, err = team.PromoteResourceVersion(command.Resource.PipelineRef, command.Resource.ResourceName, latestResourceVer.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		if promoted {
+			promoteVersionBytes, err := json.Marshal(latestResourceVer.Version)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("promoted '%s/%s' with version %s\n", command.Resource.PipelineRef.String(), command.Resource.ResourceName, string(promoteVersionBytes))
+		} else {
+			displayhelpers.Failf("could not promote '%s/%s', make sure the resource version exists\n", command.Resource.PipelineRef.String(), command.Resource.ResourceName)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type ProtectBuildCommand struct {
+	Job   flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB"   description:"Name of a job to protect a build of"`
+	Build string              `short:"b" long:"build" required:"true" description:"If job is specified: build number to protect. If job not specified: build id"`
+}
+
+func (command *ProtectBuildCommand) Execute([]string) error {
+	return setBuildProtected(command.Job, command.Build, true)
+}
+
+type UnprotectBuildCommand struct {
+	Job   flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB"   description:"Name of a job to unprotect a build of"`
+	Build string              `short:"b" long:"build" required:"true" description:"If job is specified: build number to unprotect. If job not specified: build id"`
+}
+
+func (command *UnprotectBuildCommand) Execute([]string) error {
+	return setBuildProtected(command.Job, command.Build, false)
+}
+
+func setBuildProtected(job flaghelpers.JobFlag, buildRef string, protected bool) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var build atc.Build
+	var exists bool
+	if job.PipelineRef.Name == "" && job.JobName == "" {
+		build, exists, err = target.Client().Build(buildRef)
+	} else {
+		build, exists, err = target.Team().JobBuild(job.PipelineRef, job.JobName, buildRef)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("build does not exist")
+	}
+
+	if protected {
+		err = target.Client().ProtectBuild(strconv.Itoa(build.ID))
+	} else {
+		err = target.Client().UnprotectBuild(strconv.Itoa(build.ID))
+	}
+	if err != nil {
+		return err
+	}
+
+	if protected {
+		fmt.Println("build successfully protected")
+	} else {
+		fmt.Println("build successfully unprotected")
+	}
+
+	return nil
+}
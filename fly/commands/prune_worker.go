@@ -12,6 +12,7 @@ import (
 type PruneWorkerCommand struct {
 	Worker     flaghelpers.WorkerFlag `short:"w"  long:"worker" description:"Worker to prune"`
 	AllStalled bool                   `short:"a" long:"all-stalled" description:"Prune all stalled workers"`
+	Force      bool                   `long:"force" description:"With --all-stalled, also prune stalled workers which still have containers of running builds"`
 }
 
 func (command *PruneWorkerCommand) Execute(args []string) error {
@@ -19,13 +20,6 @@ func (command *PruneWorkerCommand) Execute(args []string) error {
 		displayhelpers.Failf("Either a worker name or --all-stalled are required")
 	}
 
-	workerName := command.Worker.Name()
-	var workersNames []string
-
-	if command.Worker != "" {
-		workersNames = append(workersNames, workerName)
-	}
-
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
 		return err
@@ -36,28 +30,35 @@ func (command *PruneWorkerCommand) Execute(args []string) error {
 		return err
 	}
 
-	if command.AllStalled {
-		workers, err := target.Client().ListWorkers()
+	if command.Worker != "" {
+		workerName := command.Worker.Name()
+
+		err = target.Client().PruneWorker(workerName)
 		if err != nil {
 			return err
 		}
-		for _, worker := range workers {
-			if worker.State == "stalled" {
-				workersNames = append(workersNames, worker.Name)
-			}
-		}
-		if workersNames == nil {
-			fmt.Printf(ui.WarningColor("WARNING: No stalled workers found.\n"))
-		}
+
+		fmt.Printf("pruned '%s'\n", workerName)
 	}
 
-	for _, workerName := range workersNames {
-		err = target.Client().PruneWorker(workerName)
+	if command.AllStalled {
+		response, err := target.Client().PruneStalledWorkers(command.Force)
 		if err != nil {
 			return err
 		}
 
-		fmt.Printf("pruned '%s'\n", workerName)
+		for _, workerName := range response.Pruned {
+			fmt.Printf("pruned '%s'\n", workerName)
+		}
+
+		for _, workerName := range response.Busy {
+			fmt.Print(ui.WarningColor("WARNING: not pruning '%s' as it still has containers of running builds; use --force to prune it anyway\n", workerName))
+		}
+
+		if len(response.Pruned) == 0 && len(response.Busy) == 0 {
+			fmt.Printf(ui.WarningColor("WARNING: No stalled workers found.\n"))
+		}
 	}
+
 	return nil
 }
@@ -1,27 +1,28 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
 	"github.com/concourse/concourse/fly/eventstream"
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
 )
 
 type RerunBuildCommand struct {
-	Job   flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of the job that you want to rerun a build for"`
-	Build string              `short:"b" long:"build" required:"true" description:"The number of the build to rerun"`
-	Watch bool                `short:"w" long:"watch" description:"Start watching the rerun build output"`
+	Job                 flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Name of the job that you want to rerun a build for"`
+	Build               string              `short:"b" long:"build" required:"true" description:"The number of the build to rerun, or its ID if no job is given"`
+	LatestIfUnavailable bool                `long:"latest-if-unavailable" description:"Use the latest version of any input whose version used by the build is no longer available"`
+	Watch               bool                `short:"w" long:"watch" description:"Start watching the rerun build output"`
 }
 
 func (command *RerunBuildCommand) Execute(args []string) error {
-	jobName, buildName := command.Job.JobName, command.Build
-	pipelineRef := command.Job.PipelineRef
-
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
 		return err
@@ -32,10 +33,46 @@ func (command *RerunBuildCommand) Execute(args []string) error {
 		return err
 	}
 
-	build, err := target.Team().RerunJobBuild(pipelineRef, jobName, buildName)
+	team := target.Team()
+	jobName, buildName := command.Job.JobName, command.Build
+	pipelineRef := command.Job.PipelineRef
+
+	if jobName == "" {
+		buildToRerun, found, err := target.Client().Build(command.Build)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return errors.New("build not found")
+		}
+
+		if buildToRerun.OneOff() {
+			return errors.New("only builds of jobs can be rerun")
+		}
+
+		team = target.Client().Team(buildToRerun.TeamName)
+		jobName, buildName = buildToRerun.JobName, buildToRerun.Name
+		pipelineRef = atc.PipelineRef{
+			Name:         buildToRerun.PipelineName,
+			InstanceVars: buildToRerun.PipelineInstanceVars,
+		}
+	}
+
+	var build atc.Build
+	if command.LatestIfUnavailable {
+		build, err = team.RerunJobBuildWithLatestIfUnavailable(pipelineRef, jobName, buildName)
+	} else {
+		build, err = team.RerunJobBuild(pipelineRef, jobName, buildName)
+	}
 	if err != nil {
+		if _, ok := err.(concourse.GenericError); ok {
+			return fmt.Errorf("%w\nrerun with --latest-if-unavailable to use their latest versions instead", err)
+		}
+
 		return err
 	}
+
 	fmt.Printf("started %s/%s #%s\n", pipelineRef.String(), jobName, build.Name)
 
 	if command.Watch {
@@ -0,0 +1,177 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type ResourceChecksCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" description:"Show the checks of resources in this pipeline"`
+	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" value-name:"PIPELINE/RESOURCE" description:"Show the check history of this resource"`
+	Count    int                      `short:"c" long:"count" default:"50" description:"Number of checks of the resource to show"`
+	Json     bool                     `long:"json" description:"Print command result as JSON"`
+	Team     string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *ResourceChecksCommand) Execute([]string) error {
+	if command.Pipeline.Name == "" && command.Resource.ResourceName == "" {
+		return errors.New("either --pipeline or --resource must be specified")
+	}
+
+	if command.Pipeline.Name != "" && command.Resource.ResourceName != "" {
+		return errors.New("cannot specify both --pipeline and --resource")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	if command.Resource.ResourceName != "" {
+		return command.showHistory(team)
+	}
+
+	statuses, err := team.ListResourceChecks(command.Pipeline.Ref())
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(statuses)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "last check", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+			{Contents: "last success", Color: color.New(color.Bold)},
+			{Contents: "failures", Color: color.New(color.Bold)},
+			{Contents: "next check", Color: color.New(color.Bold)},
+		},
+	}
+
+	now := time.Now()
+
+	for _, status := range statuses {
+		statusCell := ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		if status.LastChecked != 0 {
+			if status.Succeeded {
+				statusCell = ui.BuildStatusCell(atc.StatusSucceeded)
+			} else {
+				statusCell = ui.BuildStatusCell(atc.StatusFailed)
+			}
+		}
+
+		failuresCell := ui.TableCell{Contents: strconv.Itoa(status.ConsecutiveFailures)}
+		if status.ConsecutiveFailures > 0 {
+			failuresCell.Color = ui.FailedColor
+		}
+
+		var nextCheckCell ui.TableCell
+		switch {
+		case status.Unscheduled != "":
+			nextCheckCell = ui.TableCell{Contents: status.Unscheduled, Color: ui.OffColor}
+		case !time.Unix(status.NextCheck, 0).After(now):
+			nextCheckCell.Contents = "due"
+		default:
+			nextCheckCell = checkTimeCell(status.NextCheck)
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: status.Name},
+			checkTimeCell(status.LastChecked),
+			statusCell,
+			checkTimeCell(status.LastSucceeded),
+			failuresCell,
+			nextCheckCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func (command *ResourceChecksCommand) showHistory(team concourse.Team) error {
+	records, _, found, err := team.ListResourceCheckRecords(command.Resource.PipelineRef, command.Resource.ResourceName, concourse.Page{Limit: command.Count})
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("resource not found")
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(records)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "id", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+			{Contents: "start", Color: color.New(color.Bold)},
+			{Contents: "end", Color: color.New(color.Bold)},
+			{Contents: "new versions", Color: color.New(color.Bold)},
+			{Contents: "error", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, record := range records {
+		errorCell := ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		if record.Error != "" {
+			errorCell = ui.TableCell{Contents: record.Error, Color: ui.FailedColor}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: strconv.Itoa(record.ID)},
+			ui.BuildStatusCell(record.Status),
+			checkTimeCell(record.StartTime),
+			checkTimeCell(record.EndTime),
+			{Contents: strconv.Itoa(record.VersionsAdded)},
+			errorCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func checkTimeCell(unix int64) ui.TableCell {
+	if unix == 0 {
+		return ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+	}
+
+	return ui.TableCell{Contents: time.Unix(unix, 0).Local().Format(timeDateLayout)}
+}
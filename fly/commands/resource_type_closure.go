@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/commands/internal/templatehelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type ResourceTypeClosureCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Pipeline the config is for"`
+	Config   atc.PathFlag             `short:"c" long:"config"   required:"true" description:"Pipeline configuration file, \"-\" stands for stdin"`
+
+	Var     []flaghelpers.VariablePairFlag     `short:"v"  long:"var"       unquote:"false"  value-name:"[NAME=STRING]"  description:"Specify a string value to set for a variable in the pipeline"`
+	YAMLVar []flaghelpers.YAMLVariablePairFlag `short:"y"  long:"yaml-var"  unquote:"false"  value-name:"[NAME=YAML]"    description:"Specify a YAML value to set for a variable in the pipeline"`
+
+	VarsFrom []atc.PathFlag `short:"l"  long:"load-vars-from"  description:"Variable flag that can be used for filling in template values in configuration from a YAML file"`
+
+	Json bool `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *ResourceTypeClosureCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	yamlTemplate := templatehelpers.NewYamlTemplateWithParams(command.Config, command.VarsFrom, command.Var, command.YAMLVar, command.Pipeline.InstanceVars)
+	config, err := yamlTemplate.Evaluate(false, false)
+	if err != nil {
+		return err
+	}
+
+	closure, err := target.Team().ResourceTypeClosure(command.Pipeline.Ref(), config)
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(closure)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = renderResourceTypeClosure(closure)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(closure.Cycles) > 0 {
+		return errors.New("custom resource types form a cycle")
+	}
+
+	return nil
+}
+
+func renderResourceTypeClosure(closure atc.ResourceTypeClosure) error {
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "type", Color: color.New(color.Bold)},
+			{Contents: "version", Color: color.New(color.Bold)},
+		},
+	}
+
+	var unavailable []string
+	for _, dependency := range closure.Types {
+		typeCell := ui.TableCell{Contents: dependency.Type}
+		if dependency.Base {
+			typeCell = ui.TableCell{Contents: "base", Color: ui.OffColor}
+		}
+
+		versionCell := ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		switch {
+		case dependency.Base && len(dependency.WorkerVersions) == 0:
+			versionCell = ui.TableCell{Contents: "no workers", Color: ui.FailedColor}
+		case dependency.Base:
+			versionCell = ui.TableCell{Contents: strings.Join(dependency.WorkerVersions, ",")}
+		case dependency.Version != nil:
+			fields := []string{}
+			for k, v := range dependency.Version {
+				fields = append(fields, k+":"+v)
+			}
+			sort.Strings(fields)
+
+			versionCell = ui.TableCell{Contents: strings.Join(fields, ",")}
+		case dependency.VersionUnavailable != "":
+			versionCell = ui.TableCell{Contents: "unknown", Color: ui.OffColor}
+			unavailable = append(unavailable, dependency.Name+": "+dependency.VersionUnavailable)
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: dependency.Name},
+			typeCell,
+			versionCell,
+		})
+	}
+
+	err := table.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	if len(unavailable) > 0 {
+		displayhelpers.ShowErrors("the versions of some custom types are unknown", unavailable)
+	}
+
+	if len(closure.Cycles) > 0 {
+		var cycles []string
+		for _, cycle := range closure.Cycles {
+			cycles = append(cycles, strings.Join(cycle, " -> "))
+		}
+
+		displayhelpers.ShowErrors("custom resource types form cycles", cycles)
+	}
+
+	return nil
+}
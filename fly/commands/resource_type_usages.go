@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"errors"
+	"os"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type ResourceTypeUsagesCommand struct {
+	Type  string `long:"type" description:"Find the resources and resource types of this type"`
+	Image string `long:"image" description:"Find the resource types defined with this image repository, and the resources and resource types using them"`
+	Json  bool   `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *ResourceTypeUsagesCommand) Execute([]string) error {
+	if command.Type == "" && command.Image == "" {
+		return errors.New("either --type or --image must be given")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	usages, err := target.Client().ListResourceTypeUsages(command.Type, command.Image)
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(usages)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "team", Color: color.New(color.Bold)},
+			{Contents: "pipeline", Color: color.New(color.Bold)},
+			{Contents: "resource", Color: color.New(color.Bold)},
+			{Contents: "resource type", Color: color.New(color.Bold)},
+			{Contents: "type", Color: color.New(color.Bold)},
+			{Contents: "image", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, usage := range usages {
+		pipelineRef := atc.PipelineRef{
+			Name:         usage.PipelineName,
+			InstanceVars: usage.PipelineInstanceVars,
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: usage.TeamName},
+			{Contents: pipelineRef.String()},
+			optionalCell(usage.ResourceName),
+			optionalCell(usage.ResourceTypeName),
+			{Contents: usage.Type},
+			optionalCell(usage.Image),
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func optionalCell(contents string) ui.TableCell {
+	if contents == "" {
+		return ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+	}
+
+	return ui.TableCell{Contents: contents}
+}
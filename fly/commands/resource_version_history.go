@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type ResourceVersionHistoryCommand struct {
+	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of the resource"`
+	Json     bool                     `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *ResourceVersionHistoryCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	toggles, found, err := target.Team().ResourceVersionToggles(command.Resource.PipelineRef, command.Resource.ResourceName)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("resource not found")
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(toggles)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "time", Color: color.New(color.Bold)},
+			{Contents: "action", Color: color.New(color.Bold)},
+			{Contents: "version", Color: color.New(color.Bold)},
+			{Contents: "by", Color: color.New(color.Bold)},
+			{Contents: "reason", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, toggle := range toggles {
+		actionCell := ui.TableCell{Contents: "disabled", Color: ui.FailedColor}
+		if toggle.Enabled {
+			actionCell = ui.TableCell{Contents: "enabled", Color: ui.OnColor}
+		}
+
+		fields := []string{}
+		for k, v := range toggle.Version {
+			fields = append(fields, k+":"+v)
+		}
+		sort.Strings(fields)
+
+		reasonCell := ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		if toggle.Reason != "" {
+			reasonCell = ui.TableCell{Contents: toggle.Reason}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			checkTimeCell(toggle.Time),
+			actionCell,
+			{Contents: strings.Join(fields, ",")},
+			{Contents: toggle.Actor},
+			reasonCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
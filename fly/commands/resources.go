@@ -53,12 +53,18 @@ func (command *ResourcesCommand) Execute([]string) error {
 		var pinnedColumn ui.TableCell
 		if resource.PinnedVersion != nil {
 			pinnedColumn.Contents = ui.PresentVersion(resource.PinnedVersion)
+			if resource.PinComment != "" {
+				pinnedColumn.Contents += " (" + resource.PinComment + ")"
+			}
 		} else {
 			pinnedColumn.Contents = "n/a"
 		}
 
 		var statusColumn ui.TableCell
-		if resource.Build != nil {
+		if resource.CheckPaused {
+			statusColumn.Contents = "paused"
+			statusColumn.Color = ui.PausedColor
+		} else if resource.Build != nil {
 			statusColumn = ui.BuildStatusCell(resource.Build.Status)
 		} else {
 			statusColumn.Contents = "n/a"
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type RotateEncryptionKeyCommand struct {
+	Status bool `long:"status" description:"Only show the progress of the current rotation rather than starting one"`
+	Json   bool `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *RotateEncryptionKeyCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var progress []atc.EncryptionKeyRotationProgress
+	if command.Status {
+		progress, err = target.Client().EncryptionKeyRotation()
+	} else {
+		progress, err = target.Client().RotateEncryptionKey()
+	}
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(progress)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if !command.Status {
+		fmt.Fprintln(ui.Stderr, "started rotating the encryption key; run with --status to follow its progress")
+	}
+
+	headers := ui.TableRow{
+		{Contents: "table", Color: color.New(color.Bold)},
+		{Contents: "column", Color: color.New(color.Bold)},
+		{Contents: "rotated", Color: color.New(color.Bold)},
+		{Contents: "status", Color: color.New(color.Bold)},
+		{Contents: "updated", Color: color.New(color.Bold)},
+	}
+
+	table := ui.Table{Headers: headers}
+
+	for _, p := range progress {
+		var status ui.TableCell
+		switch {
+		case p.Error != "":
+			status.Contents = "errored: " + p.Error
+			status.Color = ui.ErroredColor
+		case p.Finished:
+			status.Contents = "finished"
+			status.Color = ui.SucceededColor
+		default:
+			status.Contents = "rotating"
+			status.Color = ui.StartedColor
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: p.Table},
+			{Contents: p.Column},
+			{Contents: strconv.Itoa(p.Rotated)},
+			status,
+			{Contents: time.Unix(p.UpdateTime, 0).Format(time.RFC3339)},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/ui"
+)
+
+type SaveVersionSetCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Pipeline whose resource versions to capture"`
+	Name     string                   `short:"n" long:"name"     required:"true" description:"Name of the version set"`
+	Team     string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *SaveVersionSetCommand) Execute([]string) error {
+	_, err := command.Pipeline.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := versionSetTeam(command.Team)
+	if err != nil {
+		return err
+	}
+
+	pipelineRef := command.Pipeline.Ref()
+	set, found, err := team.SaveVersionSet(pipelineRef, command.Name)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("pipeline '%s' not found\n", pipelineRef.String())
+	}
+
+	resources := []string{}
+	for resource := range set.Versions {
+		resources = append(resources, resource)
+	}
+
+	sort.Strings(resources)
+
+	fmt.Printf("saved version set '%s' of pipeline '%s':\n", set.Name, pipelineRef.String())
+	for _, resource := range resources {
+		fmt.Printf("  %s: %s\n", resource, ui.PresentVersion(set.Versions[resource]))
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"os"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type SerialGroupsCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Show the serial groups of this pipeline"`
+	Json     bool                     `long:"json" description:"Print command result as JSON"`
+	Team     string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *SerialGroupsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	groups, err := team.ListSerialGroups(command.Pipeline.Ref())
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(groups)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "jobs", Color: color.New(color.Bold)},
+			{Contents: "running", Color: color.New(color.Bold)},
+			{Contents: "pending", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, group := range groups {
+		runningCell := serialGroupBuildsCell(group.RunningBuilds)
+		if len(group.RunningBuilds) > 0 {
+			runningCell.Color = ui.StartedColor
+		}
+
+		pendingCell := serialGroupBuildsCell(group.PendingBuilds)
+		if len(group.PendingBuilds) > 0 {
+			pendingCell.Color = ui.PendingColor
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: group.Name},
+			{Contents: strings.Join(group.Jobs, ", ")},
+			runningCell,
+			pendingCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func serialGroupBuildsCell(builds []atc.Build) ui.TableCell {
+	if len(builds) == 0 {
+		return ui.TableCell{Contents: "none", Color: ui.OffColor}
+	}
+
+	var names []string
+	for _, build := range builds {
+		names = append(names, build.JobName+"/"+build.Name)
+	}
+
+	return ui.TableCell{Contents: strings.Join(names, ", ")}
+}
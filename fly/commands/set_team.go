@@ -26,9 +26,10 @@ func WireTeamConnectors(command *flags.Command) {
 }
 
 type SetTeamCommand struct {
-	Team            flaghelpers.TeamFlag `short:"n" long:"team-name" required:"true" description:"The team to create or modify"`
-	SkipInteractive bool                 `long:"non-interactive" description:"Force apply configuration"`
-	AuthFlags       skycmd.AuthTeamFlags `group:"Authentication"`
+	Team                flaghelpers.TeamFlag `short:"n" long:"team-name" required:"true" description:"The team to create or modify"`
+	SkipInteractive     bool                 `long:"non-interactive" description:"Force apply configuration"`
+	MaxConcurrentBuilds *int                 `long:"max-concurrent-builds" value-name:"LIMIT" description:"Number of the team's job builds which may be running at once, 0 for no limit. Left as is if not specified. Only admins may set it."`
+	AuthFlags           skycmd.AuthTeamFlags `group:"Authentication"`
 }
 
 func (command *SetTeamCommand) Validate() ([]concourse.ConfigWarning, error) {
@@ -103,6 +104,15 @@ func (command *SetTeamCommand) Execute([]string) error {
 		}
 	}
 
+	if command.MaxConcurrentBuilds != nil {
+		fmt.Println()
+		if *command.MaxConcurrentBuilds == 0 {
+			fmt.Printf("max concurrent builds: %s\n", ui.OffColor.Sprint("unlimited"))
+		} else {
+			fmt.Printf("max concurrent builds: %d\n", *command.MaxConcurrentBuilds)
+		}
+	}
+
 	if len(warnings) > 0 {
 		displayhelpers.ShowWarnings(warnings)
 	}
@@ -120,7 +130,10 @@ func (command *SetTeamCommand) Execute([]string) error {
 		displayhelpers.Failf("bailing out")
 	}
 
-	team := atc.Team{Auth: authRoles}
+	team := atc.Team{
+		Auth:                authRoles,
+		MaxConcurrentBuilds: command.MaxConcurrentBuilds,
+	}
 
 	_, created, updated, warnings, err := target.Client().Team(teamName).CreateOrUpdate(team)
 	if err != nil {
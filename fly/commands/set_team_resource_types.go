@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"sigs.k8s.io/yaml"
+)
+
+type SetTeamResourceTypesCommand struct {
+	Config atc.PathFlag `short:"c" long:"config" required:"true" description:"YAML or JSON file listing the resource types, in the same format as a pipeline's resource_types"`
+	Team   string       `long:"team" description:"Name of the team to register the resource types for, if different from the target default"`
+}
+
+func (command *SetTeamResourceTypesCommand) Execute([]string) error {
+	configFile, err := ioutil.ReadFile(string(command.Config))
+	if err != nil {
+		return err
+	}
+
+	var resourceTypes atc.ResourceTypes
+	err = yaml.Unmarshal(configFile, &resourceTypes)
+	if err != nil {
+		return fmt.Errorf("failed to parse resource types file: %s", err)
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	warnings, err := team.SetTeamResourceTypes(resourceTypes)
+	if err != nil {
+		var invalidErr concourse.InvalidConfigError
+		if errors.As(err, &invalidErr) {
+			return errors.New(strings.Join(invalidErr.Errors, "\n"))
+		}
+
+		return err
+	}
+
+	if len(warnings) > 0 {
+		displayhelpers.ShowWarnings(warnings)
+	}
+
+	fmt.Printf("saved %d resource type(s) for team '%s'\n", len(resourceTypes), team.Name())
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type SetVarCommand struct {
+	Team         string   `long:"team" description:"Name of the team whose pipelines to set the var of, if different from the target default"`
+	Pipelines    []string `short:"p" long:"pipeline" value-name:"NAME" description:"Name of a pipeline to set the var of, including all of its instances. Can be specified multiple times."`
+	AllPipelines bool     `long:"all-pipelines" description:"Set the var of every pipeline of the team"`
+	Create       bool     `long:"create" description:"Set the var on pipelines which don't have it yet, which are skipped otherwise"`
+	Json         bool     `long:"json" description:"Print command result as JSON"`
+
+	Args struct {
+		Var string `positional-arg-name:"NAME=VALUE" required:"true" description:"The var to set"`
+	} `positional-args:"yes"`
+}
+
+func (command *SetVarCommand) Execute([]string) error {
+	pair := strings.SplitN(command.Args.Var, "=", 2)
+	if len(pair) != 2 || pair[0] == "" {
+		return fmt.Errorf("invalid var '%s' (must be name=value)", command.Args.Var)
+	}
+
+	name, value := pair[0], pair[1]
+
+	if command.AllPipelines == (len(command.Pipelines) > 0) {
+		return errors.New("either --pipeline or --all-pipelines must be given")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	updates, err := team.SetPipelineVar(name, atc.SetPipelineVarRequest{
+		Value:     value,
+		Create:    command.Create,
+		Pipelines: command.Pipelines,
+	})
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(updates)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "pipeline", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, update := range updates {
+		row := ui.TableRow{
+			{Contents: update.Pipeline.String()},
+		}
+
+		if update.Updated {
+			row = append(row, ui.TableCell{Contents: "updated", Color: color.New(color.FgGreen)})
+		} else {
+			row = append(row, ui.TableCell{Contents: "skipped (no such var)", Color: color.New(color.Faint)})
+		}
+
+		table.Data = append(table.Data, row)
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
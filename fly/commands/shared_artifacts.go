@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type SharedArtifactsCommand struct {
+	Team string `long:"team" description:"Name of the team whose shared artifacts to list, if different from the target default"`
+	Json bool   `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *SharedArtifactsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	artifacts, err := team.ListSharedArtifacts()
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(artifacts)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "build", Color: color.New(color.Bold)},
+			{Contents: "size", Color: color.New(color.Bold)},
+			{Contents: "published", Color: color.New(color.Bold)},
+			{Contents: "granted to", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, a := range artifacts {
+		row := ui.TableRow{
+			{Contents: a.Name},
+		}
+
+		if a.PublishedAt == 0 {
+			row = append(row,
+				ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)},
+				ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)},
+				ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)},
+			)
+		} else {
+			row = append(row,
+				ui.TableCell{Contents: strconv.Itoa(a.BuildID)},
+				ui.TableCell{Contents: strconv.FormatInt(a.Size, 10)},
+				ui.TableCell{Contents: time.Unix(a.PublishedAt, 0).Local().Format(timeDateLayout)},
+			)
+		}
+
+		if len(a.GrantedTeams) == 0 {
+			row = append(row, ui.TableCell{Contents: "none", Color: color.New(color.Faint)})
+		} else {
+			row = append(row, ui.TableCell{Contents: strings.Join(a.GrantedTeams, ",")})
+		}
+
+		table.Data = append(table.Data, row)
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"os"
+	"strings"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type TeamResourceTypesCommand struct {
+	Team string `long:"team" description:"Name of the team whose resource types to list, if different from the target default"`
+	Json bool   `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *TeamResourceTypesCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	resourceTypes, err := team.TeamResourceTypes()
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(resourceTypes)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "type", Color: color.New(color.Bold)},
+			{Contents: "tags", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, resourceType := range resourceTypes {
+		tagsCell := ui.TableCell{Contents: "none", Color: ui.OffColor}
+		if len(resourceType.Tags) > 0 {
+			tagsCell = ui.TableCell{Contents: strings.Join(resourceType.Tags, ", ")}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: resourceType.Name},
+			{Contents: resourceType.Type},
+			tagsCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
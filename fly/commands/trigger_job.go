@@ -12,12 +12,17 @@ import (
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/fly/ui"
 	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/concourse/concourse/vars"
 )
 
 type TriggerJobCommand struct {
 	Job   flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to trigger"`
 	Watch bool                `short:"w" long:"watch" description:"Start watching the build output"`
 	Team  string              `long:"team" description:"Name of the team to which the job belongs, if different from the target default"`
+
+	Var              []flaghelpers.VariablePairFlag     `short:"v"  long:"var"       unquote:"false"  value-name:"[NAME=STRING]"  description:"Specify a string value to set for a variable in the build"`
+	YAMLVar          []flaghelpers.YAMLVariablePairFlag `short:"y"  long:"yaml-var"  unquote:"false"  value-name:"[NAME=YAML]"    description:"Specify a YAML value to set for a variable in the build"`
+	AllowVarOverride bool                               `long:"allow-var-override"  description:"Allow the given vars to override vars defined by the pipeline, e.g. its instance vars or credentials"`
 }
 
 func (command *TriggerJobCommand) Execute(args []string) error {
@@ -47,7 +52,22 @@ func (command *TriggerJobCommand) Execute(args []string) error {
 		team = target.Team()
 	}
 
-	build, err = team.CreateJobBuild(pipelineRef, jobName)
+	var varPairs vars.KVPairs
+	for _, f := range command.Var {
+		varPairs = append(varPairs, vars.KVPair(f))
+	}
+	for _, f := range command.YAMLVar {
+		varPairs = append(varPairs, vars.KVPair(f))
+	}
+
+	if len(varPairs) == 0 {
+		build, err = team.CreateJobBuild(pipelineRef, jobName)
+	} else {
+		build, err = team.CreateJobBuildWithVars(pipelineRef, jobName, atc.TriggerJobBuildRequest{
+			Vars:             varPairs.Expand(),
+			AllowVarOverride: command.AllowVarOverride,
+		})
+	}
 	if err != nil {
 		return err
 	} else {
@@ -14,6 +14,9 @@ type UnpausePipelineCommand struct {
 	Pipeline *flaghelpers.PipelineFlag `short:"p" long:"pipeline" description:"Pipeline to unpause"`
 	All      bool                      `short:"a" long:"all"      description:"Unpause all pipelines"`
 	Team     string                    `long:"team"              description:"Name of the team to which the pipeline belongs, if different from the target default"`
+
+	CheckResources bool `long:"check-resources" description:"Check all of the pipeline's resources as it is unpaused"`
+	WaitForChecks  bool `long:"wait-for-checks" description:"Check all of the pipeline's resources and hold off scheduling jobs until the checks have finished"`
 }
 
 func (command *UnpausePipelineCommand) Validate() error {
@@ -73,7 +76,12 @@ func (command *UnpausePipelineCommand) Execute(args []string) error {
 	}
 
 	for _, pipelineRef := range pipelineRefs {
-		found, err := team.UnpausePipeline(pipelineRef)
+		var found bool
+		if command.CheckResources || command.WaitForChecks {
+			found, err = team.UnpausePipelineWithChecks(pipelineRef, command.WaitForChecks)
+		} else {
+			found, err = team.UnpausePipeline(pipelineRef)
+		}
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type UnpauseResourceCheckingCommand struct {
+	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of the resource"`
+}
+
+func (command *UnpauseResourceCheckingCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	found, err := target.Team().UnpauseResourceChecking(command.Resource.PipelineRef, command.Resource.ResourceName)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		fmt.Printf("unpaused checking of '%s/%s'\n", command.Resource.PipelineRef.String(), command.Resource.ResourceName)
+	} else {
+		displayhelpers.Failf("could not find resource '%s/%s'\n", command.Resource.PipelineRef.String(), command.Resource.ResourceName)
+	}
+
+	return nil
+}
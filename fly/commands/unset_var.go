@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"errors"
+	"os"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type UnsetVarCommand struct {
+	Team         string   `long:"team" description:"Name of the team whose pipelines to delete the var of, if different from the target default"`
+	Pipelines    []string `short:"p" long:"pipeline" value-name:"NAME" description:"Name of a pipeline to delete the var of, including all of its instances. Can be specified multiple times."`
+	AllPipelines bool     `long:"all-pipelines" description:"Delete the var of every pipeline of the team"`
+	Json         bool     `long:"json" description:"Print command result as JSON"`
+
+	Args struct {
+		Var string `positional-arg-name:"NAME" required:"true" description:"The var to delete"`
+	} `positional-args:"yes"`
+}
+
+func (command *UnsetVarCommand) Execute([]string) error {
+	if command.AllPipelines == (len(command.Pipelines) > 0) {
+		return errors.New("either --pipeline or --all-pipelines must be given")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	updates, err := team.DeletePipelineVar(command.Args.Var, command.Pipelines)
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(updates)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "pipeline", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, update := range updates {
+		row := ui.TableRow{
+			{Contents: update.Pipeline.String()},
+		}
+
+		if update.Updated {
+			row = append(row, ui.TableCell{Contents: "deleted", Color: color.New(color.FgGreen)})
+		} else {
+			row = append(row, ui.TableCell{Contents: "skipped (no such var)", Color: color.New(color.Faint)})
+		}
+
+		table.Data = append(table.Data, row)
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
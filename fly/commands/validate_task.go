@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/commands/internal/templatehelpers"
+	"github.com/concourse/concourse/fly/commands/internal/validatetaskhelpers"
+)
+
+type ValidateTaskCommand struct {
+	TaskConfig atc.PathFlag `short:"c" long:"config" required:"true" description:"The task config to validate"`
+
+	Var     []flaghelpers.VariablePairFlag     `short:"v"  long:"var"       unquote:"false"  value-name:"[NAME=STRING]"  description:"Specify a string value to set for a variable in the task config"`
+	YAMLVar []flaghelpers.YAMLVariablePairFlag `short:"y"  long:"yaml-var"  unquote:"false"  value-name:"[NAME=YAML]"    description:"Specify a YAML value to set for a variable in the task config"`
+
+	VarsFrom []atc.PathFlag `short:"l"  long:"load-vars-from"  description:"Variable flag that can be used for filling in template values in configuration from a YAML file"`
+}
+
+func (command *ValidateTaskCommand) Execute(args []string) error {
+	taskTemplate := templatehelpers.NewYamlTemplateWithParams(command.TaskConfig, command.VarsFrom, command.Var, command.YAMLVar, nil)
+	return validatetaskhelpers.Validate(command.TaskConfig, taskTemplate)
+}
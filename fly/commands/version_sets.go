@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type VersionSetsCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Get version sets of this pipeline"`
+	Team     string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+	Json     bool                     `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *VersionSetsCommand) Execute([]string) error {
+	_, err := command.Pipeline.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := versionSetTeam(command.Team)
+	if err != nil {
+		return err
+	}
+
+	pipelineRef := command.Pipeline.Ref()
+	sets, found, err := team.ListVersionSets(pipelineRef)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("pipeline '%s' not found\n", pipelineRef.String())
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(sets)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "resources", Color: color.New(color.Bold)},
+			{Contents: "created", Color: color.New(color.Bold)},
+			{Contents: "applied", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, set := range sets {
+		applied := ui.TableCell{Contents: "no"}
+		if set.Applied {
+			applied = ui.TableCell{Contents: "yes", Color: color.New(color.FgMagenta)}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: set.Name},
+			{Contents: strconv.Itoa(len(set.Versions))},
+			{Contents: time.Unix(set.CreatedAt, 0).Local().Format(timeDateLayout)},
+			applied,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func versionSetTeam(teamName string) (concourse.Team, error) {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return nil, err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if teamName != "" {
+		return target.FindTeam(teamName)
+	}
+
+	return target.Team(), nil
+}
@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type WarmWorkerCommand struct {
+	Worker     flaghelpers.WorkerFlag `short:"w" long:"worker" required:"true" description:"Worker to warm"`
+	VersionIDs []int                  `short:"v" long:"version-id" required:"true" value-name:"ID" description:"ID of a resource version whose caches should be streamed onto the worker (can be specified multiple times)"`
+	Json       bool                   `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *WarmWorkerCommand) Execute(args []string) error {
+	workerName := command.Worker.Name()
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	results, err := target.Client().WarmWorker(workerName, command.VersionIDs)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == atc.WarmStatusFailed {
+			failed++
+		}
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(results)
+		if err != nil {
+			return err
+		}
+	} else {
+		table := ui.Table{
+			Headers: ui.TableRow{
+				{Contents: "version id", Color: color.New(color.Bold)},
+				{Contents: "cache id", Color: color.New(color.Bold)},
+				{Contents: "status", Color: color.New(color.Bold)},
+				{Contents: "error", Color: color.New(color.Bold)},
+			},
+		}
+
+		for _, result := range results {
+			cacheCell := ui.TableCell{Contents: "none", Color: color.New(color.Faint)}
+			if result.ResourceCacheID != 0 {
+				cacheCell = ui.TableCell{Contents: strconv.Itoa(result.ResourceCacheID)}
+			}
+
+			statusCell := ui.TableCell{Contents: result.Status}
+			switch result.Status {
+			case atc.WarmStatusStreamed:
+				statusCell.Color = ui.SucceededColor
+			case atc.WarmStatusFailed:
+				statusCell.Color = ui.FailedColor
+			}
+
+			table.Data = append(table.Data, []ui.TableCell{
+				{Contents: strconv.Itoa(result.ResourceConfigVersionID)},
+				cacheCell,
+				statusCell,
+				{Contents: result.Error},
+			})
+		}
+
+		err = table.Render(os.Stdout, Fly.PrintTableHeaders)
+		if err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		displayhelpers.Failf("failed to warm %d resource cache(s) on '%s'", failed, workerName)
+	}
+
+	return nil
+}
@@ -59,6 +59,33 @@ var _ = Describe("AbortBuild", func() {
 			})
 		})
 
+		Context("and a reason is specified", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuild),
+					),
+
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedAbortURL, "reason=taking+too+long"),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("sends the reason along with the abort", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "abort-build", "-b", "23", "--reason", "taking too long")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say("build successfully aborted"))
+			})
+		})
+
 		Context("and the build id does not exist", func() {
 			BeforeEach(func() {
 				expectedURL := "/api/v1/builds/42"
@@ -0,0 +1,126 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("ApproveBuild", func() {
+	var (
+		flyCmd    *exec.Cmd
+		approvals []atc.BuildApproval
+	)
+
+	BeforeEach(func() {
+		approvals = []atc.BuildApproval{
+			{PlanID: "plan-1", Name: "staging", Status: atc.ApprovalApproved},
+			{PlanID: "plan-2", Name: "deploy", Status: atc.ApprovalPending},
+		}
+	})
+
+	JustBeforeEach(func() {
+		atcServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 23, Name: "42"}),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/builds/23/approvals"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, approvals),
+			),
+		)
+	})
+
+	Context("when approving the pending step", func() {
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23", "-m", "ship it")
+
+			atcServer.RouteToHandler("PUT", "/api/v1/builds/23/approvals/plan-2", ghttp.CombineHandlers(
+				ghttp.VerifyJSONRepresenting(atc.BuildApprovalDecision{Approved: true, Comment: "ship it"}),
+				ghttp.RespondWith(http.StatusNoContent, ""),
+			))
+		})
+
+		It("approves it", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say("approved deploy"))
+		})
+	})
+
+	Context("when rejecting the step", func() {
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23", "-s", "deploy", "--reject")
+
+			atcServer.RouteToHandler("PUT", "/api/v1/builds/23/approvals/plan-2", ghttp.CombineHandlers(
+				ghttp.VerifyJSONRepresenting(atc.BuildApprovalDecision{Approved: false}),
+				ghttp.RespondWith(http.StatusNoContent, ""),
+			))
+		})
+
+		It("rejects it", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say("rejected deploy"))
+		})
+	})
+
+	Context("when the named step is not pending", func() {
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23", "-s", "staging")
+		})
+
+		It("errors", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("build is not waiting on approval step 'staging'"))
+		})
+	})
+
+	Context("when more than one step is pending", func() {
+		BeforeEach(func() {
+			approvals[0].Status = atc.ApprovalPending
+
+			flyCmd = exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23")
+		})
+
+		It("asks for the step to be specified", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say(`build is waiting on more than one approval step \(staging, deploy\); specify one with --step`))
+		})
+	})
+
+	Context("when the step was decided in the meantime", func() {
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23")
+
+			atcServer.RouteToHandler("PUT", "/api/v1/builds/23/approvals/plan-2", ghttp.RespondWith(http.StatusConflict, ""))
+		})
+
+		It("errors", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("approval step 'deploy' has already been decided"))
+		})
+	})
+})
@@ -0,0 +1,78 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("backfill-resource-versions", func() {
+		var (
+			tmpdir       string
+			versionsPath string
+			status       int
+		)
+
+		BeforeEach(func() {
+			var err error
+			tmpdir, err = ioutil.TempDir("", "fly-backfill")
+			Expect(err).NotTo(HaveOccurred())
+
+			versionsPath = filepath.Join(tmpdir, "versions.yml")
+			err = ioutil.WriteFile(versionsPath, []byte("- ref: old1\n- ref: old2\n"), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			status = http.StatusOK
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmpdir)
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/teams/main/pipelines/some-pipeline/resources/some-resource/versions"),
+					ghttp.VerifyJSONRepresenting([]atc.Version{{"ref": "old1"}, {"ref": "old2"}}),
+					ghttp.RespondWithJSONEncoded(status, atc.BackfillVersionsResponse{NewVersions: 2}),
+				),
+			)
+		})
+
+		It("sends the versions in the file and reports how many were new", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "backfill-resource-versions", "-r", "some-pipeline/some-resource", "-f", versionsPath)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say("backfilled 2 new version\\(s\\) of some-pipeline/some-resource"))
+		})
+
+		Context("when the resource does not exist", func() {
+			BeforeEach(func() {
+				status = http.StatusNotFound
+			})
+
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "backfill-resource-versions", "-r", "some-pipeline/some-resource", "-f", versionsPath)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say("resource 'some-resource' not found"))
+			})
+		})
+	})
+})
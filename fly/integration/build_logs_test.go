@@ -0,0 +1,118 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("build-logs", func() {
+		var (
+			flyCmd *exec.Cmd
+			logs   []atc.ServerLog
+			status int
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "build-logs", "-b", "42")
+
+			status = http.StatusOK
+			logs = []atc.ServerLog{
+				{
+					Timestamp: "1600000000.500000000",
+					Source:    "atc",
+					Message:   "atc.tracker.track.run",
+					LogLevel:  atc.LogLevelInfo,
+					Data:      map[string]interface{}{"build_id": 42},
+				},
+				{
+					Timestamp: "1600000001.000000000",
+					Source:    "atc",
+					Message:   "atc.tracker.track.failed-to-run",
+					LogLevel:  atc.LogLevelError,
+					Data:      map[string]interface{}{"build_id": 42, "error": "nope"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/42/server-logs"),
+					ghttp.RespondWithJSONEncoded(status, logs),
+				),
+			)
+		})
+
+		It("prints the server logs for the build", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "timestamp", Color: color.New(color.Bold)},
+					{Contents: "level", Color: color.New(color.Bold)},
+					{Contents: "message", Color: color.New(color.Bold)},
+					{Contents: "data", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{
+						{Contents: time.Unix(1600000000, 500000000).Local().Format(time.RFC3339)},
+						{Contents: "info"},
+						{Contents: "atc.tracker.track.run"},
+						{Contents: `{"build_id":42}`},
+					},
+					{
+						{Contents: time.Unix(1600000001, 0).Local().Format(time.RFC3339)},
+						{Contents: "error", Color: ui.FailedColor},
+						{Contents: "atc.tracker.track.failed-to-run"},
+						{Contents: `{"build_id":42,"error":"nope"}`},
+					},
+				},
+			}))
+		})
+
+		Context("when --json is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--json")
+			})
+
+			It("prints the server logs as json", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out.Contents()).To(MatchJSON(`[
+					{"timestamp": "1600000000.500000000", "source": "atc", "message": "atc.tracker.track.run", "log_level": "info", "data": {"build_id": 42}},
+					{"timestamp": "1600000001.000000000", "source": "atc", "message": "atc.tracker.track.failed-to-run", "log_level": "error", "data": {"build_id": 42, "error": "nope"}}
+				]`))
+			})
+		})
+
+		Context("when the build does not exist", func() {
+			BeforeEach(func() {
+				status = http.StatusNotFound
+				logs = nil
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say("build not found"))
+			})
+		})
+	})
+})
@@ -0,0 +1,176 @@
+package integration_test
+
+import (
+	"bytes"
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("build-volumes", func() {
+		var (
+			flyCmd       *exec.Cmd
+			buildVolumes atc.BuildVolumes
+			status       int
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "build-volumes", "-b", "42")
+
+			size := uint64(3 * 1024 * 1024)
+			status = http.StatusOK
+			buildVolumes = atc.BuildVolumes{
+				Containers: []atc.Container{
+					{
+						ID:         "some-container",
+						WorkerName: "some-worker",
+						Type:       "task",
+						StepName:   "some-task",
+						State:      "created",
+						BuildID:    42,
+					},
+				},
+				Volumes: []atc.BuildVolume{
+					{
+						ID:         "some-cache",
+						WorkerName: "some-worker",
+						State:      "created",
+						Type:       "resource",
+						Inherited:  true,
+					},
+					{
+						ID:              "some-input",
+						WorkerName:      "some-worker",
+						State:           "created",
+						Type:            "container",
+						ContainerHandle: "some-container",
+						ParentHandle:    "some-cache",
+						SizeBytes:       &size,
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/42/volumes"),
+					ghttp.RespondWithJSONEncoded(status, buildVolumes),
+				),
+			)
+		})
+
+		It("prints the containers and volumes of the build", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			expected := new(bytes.Buffer)
+
+			err = ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "handle", Color: color.New(color.Bold)},
+					{Contents: "worker", Color: color.New(color.Bold)},
+					{Contents: "type", Color: color.New(color.Bold)},
+					{Contents: "step", Color: color.New(color.Bold)},
+					{Contents: "state", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{
+						{Contents: "some-container"},
+						{Contents: "some-worker"},
+						{Contents: "task"},
+						{Contents: "some-task"},
+						{Contents: "created"},
+					},
+				},
+			}.Render(expected, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected.WriteString("\n")
+
+			err = ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "handle", Color: color.New(color.Bold)},
+					{Contents: "worker", Color: color.New(color.Bold)},
+					{Contents: "type", Color: color.New(color.Bold)},
+					{Contents: "container", Color: color.New(color.Bold)},
+					{Contents: "step", Color: color.New(color.Bold)},
+					{Contents: "parent", Color: color.New(color.Bold)},
+					{Contents: "size", Color: color.New(color.Bold)},
+					{Contents: "inherited", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{
+						{Contents: "some-cache"},
+						{Contents: "some-worker"},
+						{Contents: "resource"},
+						{Contents: "n/a", Color: ui.OffColor},
+						{Contents: "n/a", Color: ui.OffColor},
+						{Contents: "n/a", Color: ui.OffColor},
+						{Contents: "n/a", Color: ui.OffColor},
+						{Contents: "yes"},
+					},
+					{
+						{Contents: "some-input"},
+						{Contents: "some-worker"},
+						{Contents: "container"},
+						{Contents: "some-container"},
+						{Contents: "some-task"},
+						{Contents: "some-cache"},
+						{Contents: "3.0 MiB"},
+						{Contents: "no"},
+					},
+				},
+			}.Render(expected, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(string(sess.Out.Contents())).To(Equal(expected.String()))
+		})
+
+		Context("when --json is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--json")
+			})
+
+			It("prints the containers and volumes as json", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out.Contents()).To(MatchJSON(`{
+					"containers": [
+						{"id": "some-container", "worker_name": "some-worker", "type": "task", "step_name": "some-task", "state": "created", "build_id": 42}
+					],
+					"volumes": [
+						{"id": "some-cache", "worker_name": "some-worker", "state": "created", "type": "resource", "inherited": true},
+						{"id": "some-input", "worker_name": "some-worker", "state": "created", "type": "container", "container_handle": "some-container", "parent_handle": "some-cache", "size_bytes": 3145728}
+					]
+				}`))
+			})
+		})
+
+		Context("when the build does not exist", func() {
+			BeforeEach(func() {
+				status = http.StatusNotFound
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say("build not found"))
+			})
+		})
+	})
+})
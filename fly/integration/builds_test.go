@@ -320,6 +320,79 @@ var _ = Describe("Fly CLI", func() {
 					Eventually(session).Should(gexec.Exit(1))
 				})
 			})
+
+			Context("when a build was aborted with a reason", func() {
+				BeforeEach(func() {
+					abortedBy := "some-user"
+
+					returnedBuilds = []atc.Build{
+						{
+							ID:          1002,
+							Name:        "one-off",
+							Status:      "aborted",
+							StartTime:   zeroTime.Unix(),
+							EndTime:     abortedBuildEndTime.Unix(),
+							TeamName:    "team1",
+							AbortedBy:   &abortedBy,
+							AbortReason: "taking too long",
+						},
+					}
+				})
+
+				It("shows who aborted the build and why", func() {
+					Eventually(session).Should(gexec.Exit(0))
+					Expect(session.Out).To(PrintTable(ui.Table{
+						Headers: expectedHeaders,
+						Data: []ui.TableRow{
+							{
+								{Contents: "1002"},
+								{Contents: "one-off"},
+								{Contents: "aborted by some-user: taking too long"},
+								{Contents: "n/a"},
+								{Contents: abortedBuildEndTime.Local().Format(timeDateLayout)},
+								{Contents: "n/a"},
+								{Contents: "team1"},
+								{Contents: "system"},
+							},
+						},
+					}))
+				})
+			})
+
+			Context("when a build is protected", func() {
+				BeforeEach(func() {
+					returnedBuilds = []atc.Build{
+						{
+							ID:        1002,
+							Name:      "one-off",
+							Status:    "succeeded",
+							StartTime: zeroTime.Unix(),
+							EndTime:   abortedBuildEndTime.Unix(),
+							TeamName:  "team1",
+							Protected: true,
+						},
+					}
+				})
+
+				It("marks it as protected", func() {
+					Eventually(session).Should(gexec.Exit(0))
+					Expect(session.Out).To(PrintTable(ui.Table{
+						Headers: expectedHeaders,
+						Data: []ui.TableRow{
+							{
+								{Contents: "1002"},
+								{Contents: "one-off (protected)"},
+								{Contents: "succeeded"},
+								{Contents: "n/a"},
+								{Contents: abortedBuildEndTime.Local().Format(timeDateLayout)},
+								{Contents: "n/a"},
+								{Contents: "team1"},
+								{Contents: "system"},
+							},
+						},
+					}))
+				})
+			})
 		})
 
 		Context("when validating parameters", func() {
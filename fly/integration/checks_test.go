@@ -0,0 +1,138 @@
+package integration_test
+
+import (
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("checks", func() {
+		var (
+			flyCmd *exec.Cmd
+
+			createTime = time.Now().Add(-time.Minute).Unix()
+		)
+
+		format := func(unix int64) string {
+			return time.Unix(unix, 0).Local().Format("2006-01-02@15:04:05-0700")
+		}
+
+		Context("when pipeline name is not specified", func() {
+			It("fails and says pipeline name is required", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "checks")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+
+				Expect(sess.Err).To(gbytes.Say("error: the required flag `" + osFlag("p", "pipeline") + "' was not specified"))
+			})
+		})
+
+		Context("when pending checks are returned from the API", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "checks", "--pipeline", "pipeline/branch:master")
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/pending-checks", "vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(200, []atc.PendingCheck{
+							{
+								BuildID:      1,
+								ResourceName: "some-resource",
+								Status:       atc.PendingCheckRunning,
+								CreateTime:   createTime,
+							},
+							{
+								BuildID:           2,
+								ResourceTypeName:  "some-type",
+								Status:            atc.PendingCheckQueued,
+								CreateTime:        createTime,
+								ManuallyTriggered: true,
+							},
+						}),
+					),
+				)
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints response in json as stdout", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchJSON(`[
+						{
+							"build_id": 1,
+							"resource_name": "some-resource",
+							"status": "running",
+							"create_time": ` + strconv.FormatInt(createTime, 10) + `
+						},
+						{
+							"build_id": 2,
+							"resource_type_name": "some-type",
+							"status": "queued",
+							"create_time": ` + strconv.FormatInt(createTime, 10) + `,
+							"manually_triggered": true
+						}
+					]`))
+				})
+			})
+
+			It("shows the queued and running checks", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "id", Color: color.New(color.Bold)},
+						{Contents: "resource", Color: color.New(color.Bold)},
+						{Contents: "status", Color: color.New(color.Bold)},
+						{Contents: "created", Color: color.New(color.Bold)},
+						{Contents: "manual", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "1"}, {Contents: "some-resource"}, {Contents: "running", Color: color.New(color.FgYellow)}, {Contents: format(createTime)}, {Contents: "no", Color: color.New(color.Faint)}},
+						{{Contents: "2"}, {Contents: "some-type (type)"}, {Contents: "queued", Color: color.New(color.FgWhite)}, {Contents: format(createTime)}, {Contents: "yes"}},
+					},
+				}))
+			})
+		})
+
+		Context("when the api returns an internal server error", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "checks", "-p", "pipeline")
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/pending-checks"),
+						ghttp.RespondWith(500, ""),
+					),
+				)
+			})
+
+			It("writes an error message to stderr", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Eventually(sess.Err).Should(gbytes.Say("Unexpected Response"))
+			})
+		})
+	})
+})
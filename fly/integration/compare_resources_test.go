@@ -0,0 +1,159 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("compare-resources", func() {
+		var (
+			flyCmd *exec.Cmd
+
+			stagingVersions []atc.ResourceVersion
+			prodVersions    []atc.ResourceVersion
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "compare-resources", "--a", "staging/dep", "--b", "prod/dep")
+
+			stagingVersions = []atc.ResourceVersion{
+				{ID: 3, Version: atc.Version{"ref": "c"}, Metadata: []atc.MetadataField{{Name: "tag", Value: "v3"}}},
+				{ID: 2, Version: atc.Version{"ref": "b"}, Metadata: []atc.MetadataField{{Name: "tag", Value: "v2"}}},
+				{ID: 1, Version: atc.Version{"ref": "a"}, Metadata: []atc.MetadataField{{Name: "tag", Value: "v1"}}},
+			}
+
+			prodVersions = []atc.ResourceVersion{
+				{ID: 12, Version: atc.Version{"ref": "b"}, Metadata: []atc.MetadataField{{Name: "tag", Value: "v2-prod"}}},
+				{ID: 11, Version: atc.Version{"ref": "a"}, Metadata: []atc.MetadataField{{Name: "tag", Value: "v1"}}},
+			}
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/staging/resources/dep/versions", "limit=50"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, stagingVersions),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/prod/resources/dep/versions", "limit=50"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, prodVersions),
+				),
+			)
+		})
+
+		It("prints the versions that differ and how far behind b is", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "version", Color: color.New(color.Bold)},
+					{Contents: "change", Color: color.New(color.Bold)},
+					{Contents: "a metadata", Color: color.New(color.Bold)},
+					{Contents: "b metadata", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{{Contents: "ref:c"}, {Contents: "only in a"}, {Contents: "tag:v3"}, {Contents: ""}},
+					{{Contents: "ref:b"}, {Contents: "metadata changed"}, {Contents: "tag:v2"}, {Contents: "tag:v2-prod"}},
+				},
+			}))
+
+			Expect(sess.Out).To(gbytes.Say("prod/dep is 1 version\\(s\\) behind staging/dep"))
+		})
+
+		Context("when b is ahead of a", func() {
+			BeforeEach(func() {
+				stagingVersions, prodVersions = prodVersions, stagingVersions
+			})
+
+			It("says so", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say("prod/dep is 1 version\\(s\\) ahead of staging/dep"))
+			})
+		})
+
+		Context("when both are on the same version", func() {
+			BeforeEach(func() {
+				prodVersions = stagingVersions
+			})
+
+			It("says so", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say("prod/dep is on the same version as staging/dep"))
+			})
+		})
+
+		Context("when the latest versions are unrelated", func() {
+			BeforeEach(func() {
+				prodVersions = []atc.ResourceVersion{
+					{ID: 21, Version: atc.Version{"ref": "z"}},
+				}
+			})
+
+			It("says they have diverged", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say("the latest versions of staging/dep and prod/dep are not among each other's last 50 versions"))
+			})
+		})
+
+		Context("when --json is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--json")
+			})
+
+			It("prints the comparison as JSON", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				var comparison map[string]interface{}
+				Expect(json.Unmarshal(sess.Out.Contents(), &comparison)).To(Succeed())
+				Expect(comparison["status"]).To(Equal("behind"))
+				Expect(comparison["distance"]).To(BeEquivalentTo(1))
+				Expect(comparison["versions"]).To(HaveLen(2))
+			})
+		})
+	})
+
+	Describe("compare-resources with a missing resource", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/staging/resources/dep/versions"),
+					ghttp.RespondWith(http.StatusNotFound, ""),
+				),
+			)
+		})
+
+		It("errors", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "compare-resources", "--a", "staging/dep", "--b", "prod/dep")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("resource 'staging/dep' does not exist"))
+		})
+	})
+})
@@ -0,0 +1,182 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("diff-builds", func() {
+		var flyCmd *exec.Cmd
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "diff-builds", "--first", "1", "--second", "2")
+		})
+
+		Context("when both builds exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/1"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 1}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/1/resources"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.BuildInputsOutputs{
+							Inputs: []atc.PublicBuildInput{
+								{Name: "same", Version: atc.Version{"ref": "a"}},
+								{Name: "moved", Version: atc.Version{"ref": "old"}},
+								{Name: "gone", Version: atc.Version{"ref": "x"}},
+								{Name: "collected", VersionMissing: true, VersionMD5: "some-md5"},
+								{Name: "recollected", VersionMissing: true, VersionMD5: "some-md5"},
+							},
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/2"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 2}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/2/resources"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.BuildInputsOutputs{
+							Inputs: []atc.PublicBuildInput{
+								{Name: "same", Version: atc.Version{"ref": "a"}},
+								{
+									Name:     "moved",
+									Version:  atc.Version{"ref": "new"},
+									Metadata: []atc.MetadataField{{Name: "author", Value: "someone"}},
+								},
+								{Name: "new", Version: atc.Version{"ref": "y"}},
+								{Name: "collected", VersionMissing: true, VersionMD5: "some-md5"},
+								{Name: "recollected", VersionMissing: true, VersionMD5: "other-md5"},
+							},
+						}),
+					),
+				)
+			})
+
+			It("prints the inputs that differ", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "name", Color: color.New(color.Bold)},
+						{Contents: "change", Color: color.New(color.Bold)},
+						{Contents: "first", Color: color.New(color.Bold)},
+						{Contents: "second", Color: color.New(color.Bold)},
+						{Contents: "metadata", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "gone"}, {Contents: "removed"}, {Contents: "ref:x"}, {Contents: "none"}, {Contents: ""}},
+						{{Contents: "moved"}, {Contents: "changed"}, {Contents: "ref:old"}, {Contents: "ref:new"}, {Contents: "author:someone"}},
+						{{Contents: "new"}, {Contents: "added"}, {Contents: "none"}, {Contents: "ref:y"}, {Contents: ""}},
+						{{Contents: "recollected"}, {Contents: "changed"}, {Contents: "garbage collected"}, {Contents: "garbage collected"}, {Contents: ""}},
+					},
+				}))
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints the differences as json", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(sess).Should(gexec.Exit(0))
+
+					Expect(sess.Out.Contents()).To(MatchJSON(`[
+						{
+							"name": "gone",
+							"change": "removed",
+							"first": {"name": "gone", "version": {"ref": "x"}, "pipeline_id": 0, "first_occurrence": false}
+						},
+						{
+							"name": "moved",
+							"change": "changed",
+							"first": {"name": "moved", "version": {"ref": "old"}, "pipeline_id": 0, "first_occurrence": false},
+							"second": {"name": "moved", "version": {"ref": "new"}, "metadata": [{"name": "author", "value": "someone"}], "pipeline_id": 0, "first_occurrence": false}
+						},
+						{
+							"name": "new",
+							"change": "added",
+							"second": {"name": "new", "version": {"ref": "y"}, "pipeline_id": 0, "first_occurrence": false}
+						},
+						{
+							"name": "recollected",
+							"change": "changed",
+							"first": {"name": "recollected", "version": null, "pipeline_id": 0, "first_occurrence": false, "version_missing": true, "version_md5": "some-md5"},
+							"second": {"name": "recollected", "version": null, "pipeline_id": 0, "first_occurrence": false, "version_missing": true, "version_md5": "other-md5"}
+						}
+					]`))
+				})
+			})
+		})
+
+		Context("when a build does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/1"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("error: build '1' does not exist"))
+			})
+		})
+
+		Context("when the job is specified", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "diff-builds", "-j", "some-pipeline/some-job", "--first", "3", "--second", "4")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job/builds/3"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 13}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/13/resources"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.BuildInputsOutputs{}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job/builds/4"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 14}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/14/resources"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.BuildInputsOutputs{}),
+					),
+				)
+			})
+
+			It("looks up the builds by name within the job", func() {
+				Expect(func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(5))
+			})
+		})
+	})
+})
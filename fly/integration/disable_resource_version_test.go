@@ -36,10 +36,12 @@ var _ = Describe("Fly CLI", func() {
 				Enabled: true,
 			}
 			expectedQueryParams []string
+			expectedPutQuery    string
 		)
 
 		BeforeEach(func() {
 			expectedQueryParams = []string{}
+			expectedPutQuery = "vars.branch=%22master%22"
 		})
 
 		Context("make sure the command exists", func() {
@@ -86,7 +88,7 @@ var _ = Describe("Fly CLI", func() {
 							ghttp.RespondWithJSONEncoded(expectedGetStatus, []atc.ResourceVersion{expectedVersion}),
 						),
 						ghttp.CombineHandlers(
-							ghttp.VerifyRequest("PUT", disablePath, "vars.branch=%22master%22"),
+							ghttp.VerifyRequest("PUT", disablePath, expectedPutQuery),
 							ghttp.RespondWith(expectedPutStatus, nil),
 						),
 					)
@@ -115,6 +117,22 @@ var _ = Describe("Fly CLI", func() {
 							return len(atcServer.ReceivedRequests())
 						}).By(3))
 					})
+
+					Context("when a reason is given", func() {
+						BeforeEach(func() {
+							expectedPutQuery = "reason=bad+build&vars.branch=%22master%22"
+						})
+
+						It("disables the resource version with the reason", func() {
+							flyCmd := exec.Command(flyPath, "-t", targetName, "disable-resource-version", "-r", pipelineResource, "-v", disableVersion, "--reason", "bad build")
+
+							sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+							Expect(err).NotTo(HaveOccurred())
+
+							<-sess.Exited
+							Expect(sess.ExitCode()).To(Equal(0))
+						})
+					})
 				})
 
 				Context("when the resource does not exist", func() {
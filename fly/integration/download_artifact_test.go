@@ -0,0 +1,123 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("download-artifact", func() {
+		var (
+			flyCmd    *exec.Cmd
+			outputDir string
+			artifacts []atc.WorkerArtifact
+		)
+
+		BeforeEach(func() {
+			var err error
+			outputDir, err = ioutil.TempDir("", "fly-download-artifact")
+			Expect(err).NotTo(HaveOccurred())
+
+			artifacts = []atc.WorkerArtifact{
+				{ID: 125, Name: "some-output"},
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(outputDir)
+		})
+
+		Context("when --latest-success is given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "download-artifact", "-j", "some-pipeline/some-job", "-o", "some-output", "-d", outputDir, "--latest-success")
+			})
+
+			JustBeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job/builds", "limit=100"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.Build{
+							{ID: 3, Name: "3", Status: atc.StatusStarted},
+							{ID: 2, Name: "2", Status: atc.StatusFailed},
+							{ID: 1, Name: "1", Status: atc.StatusSucceeded},
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/1/artifacts"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, artifacts),
+					),
+				)
+			})
+
+			Context("when the output volume still exists", func() {
+				BeforeEach(func() {
+					atcServer.RouteToHandler("GET", "/api/v1/teams/main/artifacts/125", tarHandler)
+				})
+
+				It("downloads the output of the latest successful build", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(sess).Should(gexec.Exit(0))
+
+					Expect(sess.Out).To(gbytes.Say("downloaded output 'some-output' of some-pipeline/some-job #1"))
+
+					contents, err := ioutil.ReadFile(filepath.Join(outputDir, "some-file"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(contents)).To(Equal("tar-contents"))
+				})
+			})
+
+			Context("when the output volume has been garbage collected", func() {
+				BeforeEach(func() {
+					atcServer.RouteToHandler("GET", "/api/v1/teams/main/artifacts/125", ghttp.RespondWith(http.StatusNotFound, ""))
+				})
+
+				It("errors clearly", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(sess).Should(gexec.Exit(1))
+
+					Expect(sess.Err).To(gbytes.Say("output 'some-output' of some-pipeline/some-job #1 is no longer available; its volume has been garbage collected"))
+				})
+			})
+
+			Context("when the build has no such artifact", func() {
+				BeforeEach(func() {
+					artifacts = []atc.WorkerArtifact{}
+				})
+
+				It("errors clearly", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(sess).Should(gexec.Exit(1))
+
+					Expect(sess.Err).To(gbytes.Say("output 'some-output' of some-pipeline/some-job #1 is no longer available"))
+				})
+			})
+		})
+
+		Context("when neither --build nor --latest-success is given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "download-artifact", "-j", "some-pipeline/some-job", "-o", "some-output")
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say("exactly one of --build or --latest-success must be specified"))
+			})
+		})
+	})
+})
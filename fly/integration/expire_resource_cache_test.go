@@ -0,0 +1,82 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("expire-resource-cache", func() {
+		var (
+			versions []atc.ResourceVersion
+			status   int
+		)
+
+		BeforeEach(func() {
+			versions = []atc.ResourceVersion{{ID: 42, Version: atc.Version{"ref": "bad"}}}
+			status = http.StatusOK
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/resources/some-resource/versions", "filter=ref:bad"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, versions),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/teams/main/pipelines/some-pipeline/resources/some-resource/versions/42/caches"),
+					ghttp.RespondWithJSONEncoded(status, atc.ExpireResourceCachesResponse{ResourceCaches: 2, Volumes: 3}),
+				),
+			)
+		})
+
+		It("expires the caches of the version and reports how many volumes will be removed", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "expire-resource-cache", "-r", "some-pipeline/some-resource", "-v", "ref:bad")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say(`expired 2 cache\(s\) of some-pipeline/some-resource with version {"ref":"bad"}, 3 volume\(s\) scheduled for removal`))
+		})
+
+		Context("when the version does not exist", func() {
+			BeforeEach(func() {
+				versions = []atc.ResourceVersion{}
+			})
+
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "expire-resource-cache", "-r", "some-pipeline/some-resource", "-v", "ref:bad")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say(`could not find version matching {"ref":"bad"}`))
+			})
+		})
+
+		Context("when the resource does not exist", func() {
+			BeforeEach(func() {
+				status = http.StatusNotFound
+			})
+
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "expire-resource-cache", "-r", "some-pipeline/some-resource", "-v", "ref:bad")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say("resource 'some-resource' not found"))
+			})
+		})
+	})
+})
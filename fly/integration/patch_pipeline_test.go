@@ -0,0 +1,129 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("patch-pipeline", func() {
+		var (
+			tmpdir    string
+			patchPath string
+
+			patchStatus int
+			patchBody   string
+		)
+
+		BeforeEach(func() {
+			var err error
+			tmpdir, err = ioutil.TempDir("", "fly-patch")
+			Expect(err).NotTo(HaveOccurred())
+
+			patchPath = filepath.Join(tmpdir, "patch.yml")
+			err = ioutil.WriteFile(patchPath, []byte("- op: replace\n  path: /resources/0/source/tag\n  value: 1.2.3\n"), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			patchStatus = http.StatusOK
+			patchBody = `{"warnings":[]}`
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmpdir)
+		})
+
+		patchHandler := func(version string) http.HandlerFunc {
+			return ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PATCH", "/api/v1/teams/main/pipelines/some-pipeline/config"),
+				ghttp.VerifyHeaderKV(atc.ConfigVersionHeader, version),
+				ghttp.VerifyContentType(atc.ConfigPatchContentType),
+				ghttp.VerifyBody([]byte(`[{"op":"replace","path":"/resources/0/source/tag","value":"1.2.3"}]`)),
+				func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set(atc.ConfigVersionHeader, "43")
+					w.WriteHeader(patchStatus)
+					w.Write([]byte(patchBody))
+				},
+			)
+		}
+
+		Context("when no config version is given", func() {
+			JustBeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ConfigResponse{}, http.Header{atc.ConfigVersionHeader: {"42"}}),
+					),
+					patchHandler("42"),
+				)
+			})
+
+			It("patches the current version of the config", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "patch-pipeline", "-p", "some-pipeline", "-f", patchPath)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say("pipeline 'some-pipeline' patched, config version is now 43"))
+			})
+
+			Context("when the patched config is invalid", func() {
+				BeforeEach(func() {
+					patchStatus = http.StatusBadRequest
+					patchBody = `{"errors":["resources.some-resource has no type"]}`
+				})
+
+				It("prints the errors", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "patch-pipeline", "-p", "some-pipeline", "-f", patchPath)
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(sess).Should(gexec.Exit(1))
+
+					Expect(sess.Err).To(gbytes.Say("resources.some-resource has no type"))
+				})
+			})
+		})
+
+		Context("when a config version is given", func() {
+			JustBeforeEach(func() {
+				atcServer.AppendHandlers(patchHandler("41"))
+			})
+
+			It("patches that version of the config", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "patch-pipeline", "-p", "some-pipeline", "-f", patchPath, "--config-version", "41")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+
+			Context("when the pipeline has been updated since", func() {
+				BeforeEach(func() {
+					patchStatus = http.StatusConflict
+					patchBody = `{"errors":["pipeline config has been updated since the given version"]}`
+				})
+
+				It("errors", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "patch-pipeline", "-p", "some-pipeline", "-f", patchPath, "--config-version", "41")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(sess).Should(gexec.Exit(1))
+
+					Expect(sess.Err).To(gbytes.Say("pipeline 'some-pipeline' has been updated since config version 41, please retry"))
+				})
+			})
+		})
+	})
+})
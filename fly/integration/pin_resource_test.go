@@ -165,13 +165,9 @@ var _ = Describe("Fly CLI", func() {
 					),
 					ghttp.CombineHandlers(
 						ghttp.VerifyRequest("PUT", pinVersionPath, "vars.branch=%22master%22"),
+						ghttp.VerifyJSONRepresenting(atc.PinVersionRequestBody{PinComment: "some pin message"}),
 						ghttp.RespondWith(pinVersionStatus, nil),
 					),
-					ghttp.CombineHandlers(
-						ghttp.VerifyRequest("PUT", commentPath),
-						ghttp.VerifyJSONRepresenting(atc.SetPinCommentRequestBody{PinComment: "some pin message"}),
-						ghttp.RespondWith(saveCommentStatus, nil),
-					),
 				)
 
 				var err error
@@ -180,14 +176,14 @@ var _ = Describe("Fly CLI", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 
-			Context("when the resource and versions exist, pinning succeeds and saving the comment succeeds", func() {
+			Context("when the resource and versions exist and pinning succeeds", func() {
 				BeforeEach(func() {
 					listVersionsStatus = http.StatusOK
 					pinVersionStatus = http.StatusOK
-					saveCommentStatus = http.StatusOK
 				})
 
-				It("saves the pin comment", func() {
+				It("pins the version with the comment", func() {
+					Eventually(sess.Out).Should(gbytes.Say(fmt.Sprintf("pinned '%s' with version {\"some\":\"value\"}\n", pipelineResource)))
 					Eventually(sess.Out).Should(gbytes.Say(fmt.Sprintf("pin comment 'some pin message' is saved\n")))
 					<-sess.Exited
 					Expect(sess.ExitCode()).To(Equal(0))
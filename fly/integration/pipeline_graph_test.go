@@ -0,0 +1,157 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("pipeline-graph", func() {
+		var config atc.Config
+
+		BeforeEach(func() {
+			config = atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "unit",
+						PlanSequence: []atc.Step{
+							{Config: &atc.GetStep{Name: "repo", Trigger: true}},
+						},
+					},
+					{
+						Name: "build",
+						PlanSequence: []atc.Step{
+							{Config: &atc.GetStep{Name: "repo", Trigger: true, Passed: []string{"unit"}}},
+							{
+								Config: &atc.InParallelStep{
+									Config: atc.InParallelConfig{
+										Steps: []atc.Step{
+											{Config: &atc.GetStep{Name: "src", Resource: "repo", Passed: []string{"unit"}}},
+										},
+									},
+								},
+							},
+						},
+					},
+					{
+						Name: "ship \"it\"",
+						PlanSequence: []atc.Step{
+							{Config: &atc.GetStep{Name: "repo", Passed: []string{"unit", "build"}}},
+						},
+					},
+				},
+			}
+		})
+
+		Context("when a pipeline name is not specified", func() {
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "pipeline-graph")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+			})
+		})
+
+		Context("when the pipeline exists", func() {
+			expectedGraph := `digraph "some-pipeline/branch:master" {
+  rankdir=LR;
+  node [shape=box];
+  "unit";
+  "build";
+  "ship \"it\"";
+  "unit" -> "build" [label="repo"];
+  "unit" -> "ship \"it\"" [label="repo", style=dashed];
+  "build" -> "ship \"it\"" [label="repo", style=dashed];
+}
+`
+
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config", "vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(200, atc.ConfigResponse{Config: config}, http.Header{atc.ConfigVersionHeader: {"42"}}),
+					),
+				)
+			})
+
+			It("prints the job graph as DOT to stdout", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "pipeline-graph", "-p", "some-pipeline/branch:master")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(0))
+
+				Expect(string(sess.Out.Contents())).To(Equal(expectedGraph))
+			})
+
+			Context("when an output file is given", func() {
+				var dir string
+
+				BeforeEach(func() {
+					var err error
+					dir, err = ioutil.TempDir("", "fly-pipeline-graph")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				AfterEach(func() {
+					Expect(os.RemoveAll(dir)).To(Succeed())
+				})
+
+				It("writes the graph to the file", func() {
+					out := filepath.Join(dir, "out.dot")
+
+					flyCmd := exec.Command(flyPath, "-t", targetName, "pipeline-graph", "-p", "some-pipeline/branch:master", "-o", out)
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(0))
+					Expect(sess.Out.Contents()).To(BeEmpty())
+
+					contents, err := ioutil.ReadFile(out)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(contents)).To(Equal(expectedGraph))
+				})
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config"),
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "pipeline-graph", "-p", "some-pipeline")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+				Expect(sess.Err).To(gbytes.Say("pipeline not found"))
+			})
+		})
+	})
+})
@@ -0,0 +1,56 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("pipeline-vars", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipeline-vars"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.PipelineVars{
+						{Pipeline: atc.PipelineRef{Name: "some-pipeline"}, Vars: []string{"registry-password", "some-var"}},
+						{Pipeline: atc.PipelineRef{Name: "other-pipeline", InstanceVars: atc.InstanceVars{"branch": "main"}}, Vars: []string{}},
+					}),
+				),
+			)
+		})
+
+		It("lists the names of each pipeline's vars", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "pipeline-vars")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "pipeline", Color: color.New(color.Bold)},
+					{Contents: "vars", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{
+						{Contents: "some-pipeline"},
+						{Contents: "registry-password,some-var"},
+					},
+					{
+						{Contents: "other-pipeline/branch:main"},
+						{Contents: "none", Color: color.New(color.Faint)},
+					},
+				},
+			}))
+		})
+	})
+})
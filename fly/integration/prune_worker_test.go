@@ -0,0 +1,110 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("prune-worker", func() {
+		Context("when a worker name is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/prune"),
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+				)
+			})
+
+			It("prunes the worker", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "prune-worker", "-w", "some-worker")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say(`pruned 'some-worker'`))
+			})
+		})
+
+		Context("when --all-stalled is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/prune-stalled", ""),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PruneStalledWorkersResponse{
+							Pruned: []string{"stalled-worker"},
+							Busy:   []string{"busy-worker"},
+						}),
+					),
+				)
+			})
+
+			It("reports the pruned workers and those left alone", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "prune-worker", "--all-stalled")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say(`pruned 'stalled-worker'`))
+				Expect(sess.Out).To(gbytes.Say(`not pruning 'busy-worker' as it still has containers of running builds; use --force to prune it anyway`))
+			})
+		})
+
+		Context("when --all-stalled and --force are given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/prune-stalled", "force=true"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PruneStalledWorkersResponse{
+							Pruned: []string{"stalled-worker", "busy-worker"},
+						}),
+					),
+				)
+			})
+
+			It("prunes the busy workers too", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "prune-worker", "--all-stalled", "--force")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say(`pruned 'stalled-worker'`))
+				Expect(sess.Out).To(gbytes.Say(`pruned 'busy-worker'`))
+			})
+		})
+
+		Context("when there are no stalled workers", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/prune-stalled"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PruneStalledWorkersResponse{
+							Pruned: []string{},
+						}),
+					),
+				)
+			})
+
+			It("warns", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "prune-worker", "--all-stalled")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say(`No stalled workers found`))
+			})
+		})
+	})
+})
@@ -0,0 +1,187 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/tedsuo/rata"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("rerun-build", func() {
+		var (
+			mainPath  string
+			otherPath string
+		)
+
+		BeforeEach(func() {
+			var err error
+			mainPath, err = atc.Routes.CreatePathForRoute(atc.RerunJobBuild, rata.Params{"pipeline_name": "awesome-pipeline", "job_name": "awesome-job", "build_name": "42", "team_name": "main"})
+			Expect(err).NotTo(HaveOccurred())
+
+			otherPath, err = atc.Routes.CreatePathForRoute(atc.RerunJobBuild, rata.Params{"pipeline_name": "awesome-pipeline", "job_name": "awesome-job", "build_name": "42", "team_name": "other-team"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the job and build name are specified", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", mainPath, "vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 58, Name: "42.1"}),
+					),
+				)
+			})
+
+			It("reruns the build", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "rerun-build", "-j", "awesome-pipeline/branch:master/awesome-job", "-b", "42")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gbytes.Say(`started awesome-pipeline/branch:master/awesome-job #42.1`))
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(0))
+			})
+		})
+
+		Context("when only the build ID is specified", func() {
+			Context("when the build belongs to a job", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/api/v1/builds/57"),
+							ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{
+								ID:           57,
+								Name:         "42",
+								TeamName:     "other-team",
+								PipelineName: "awesome-pipeline",
+								JobName:      "awesome-job",
+							}),
+						),
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("POST", otherPath),
+							ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 58, Name: "42.1"}),
+						),
+					)
+				})
+
+				It("reruns the build of its job", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "rerun-build", "-b", "57")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gbytes.Say(`started awesome-pipeline/awesome-job #42.1`))
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(0))
+				})
+			})
+
+			Context("when the build is a one-off", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/api/v1/builds/57"),
+							ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{
+								ID:       57,
+								Name:     "57",
+								TeamName: "main",
+							}),
+						),
+					)
+				})
+
+				It("errors", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "rerun-build", "-b", "57")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess.Err).Should(gbytes.Say(`only builds of jobs can be rerun`))
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(1))
+				})
+			})
+
+			Context("when the build does not exist", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/api/v1/builds/57"),
+							ghttp.RespondWith(http.StatusNotFound, nil),
+						),
+					)
+				})
+
+				It("errors", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "rerun-build", "-b", "57")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess.Err).Should(gbytes.Say(`build not found`))
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("when versions of some inputs of the build are no longer available", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", mainPath),
+						ghttp.RespondWith(http.StatusConflict, "versions of inputs no longer available: some-input"),
+					),
+				)
+			})
+
+			It("reports them", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "rerun-build", "-j", "awesome-pipeline/awesome-job", "-b", "42")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess.Err).Should(gbytes.Say(`versions of inputs no longer available: some-input`))
+				Eventually(sess.Err).Should(gbytes.Say(`rerun with --latest-if-unavailable to use their latest versions instead`))
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+			})
+		})
+
+		Context("when --latest-if-unavailable is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", mainPath, "latest_if_unavailable=true"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 58, Name: "42.1"}),
+					),
+				)
+			})
+
+			It("asks to use the latest versions of unavailable inputs", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "rerun-build", "-j", "awesome-pipeline/awesome-job", "-b", "42", "--latest-if-unavailable")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gbytes.Say(`started awesome-pipeline/awesome-job #42.1`))
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(0))
+			})
+		})
+	})
+})
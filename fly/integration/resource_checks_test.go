@@ -0,0 +1,265 @@
+package integration_test
+
+import (
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("resource-checks", func() {
+		var (
+			flyCmd *exec.Cmd
+
+			lastChecked   = time.Now().Add(-time.Minute).Unix()
+			lastSucceeded = time.Now().Add(-time.Hour).Unix()
+			nextCheck     = time.Now().Add(time.Hour).Unix()
+		)
+
+		format := func(unix int64) string {
+			return time.Unix(unix, 0).Local().Format("2006-01-02@15:04:05-0700")
+		}
+
+		Context("when neither a pipeline nor a resource is specified", func() {
+			It("fails and says one of them is required", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "resource-checks")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+
+				Expect(sess.Err).To(gbytes.Say("error: either --pipeline or --resource must be specified"))
+			})
+		})
+
+		Context("when both a pipeline and a resource are specified", func() {
+			It("fails", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "resource-checks", "-p", "pipeline", "-r", "pipeline/some-resource")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+
+				Expect(sess.Err).To(gbytes.Say("error: cannot specify both --pipeline and --resource"))
+			})
+		})
+
+		Context("when a resource is specified", func() {
+			var status int
+
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "resource-checks", "-r", "pipeline/branch:master/some-resource", "-c", "2")
+				status = 200
+			})
+
+			JustBeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/resources/some-resource/checks", "limit=2&vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(status, []atc.CheckRecord{
+							{
+								ID:        2,
+								Status:    atc.StatusStarted,
+								StartTime: lastChecked,
+							},
+							{
+								ID:        1,
+								Status:    atc.StatusFailed,
+								StartTime: lastSucceeded,
+								EndTime:   lastSucceeded,
+								Error:     "some-error",
+							},
+						}),
+					),
+				)
+			})
+
+			It("shows the check history of the resource", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "id", Color: color.New(color.Bold)},
+						{Contents: "status", Color: color.New(color.Bold)},
+						{Contents: "start", Color: color.New(color.Bold)},
+						{Contents: "end", Color: color.New(color.Bold)},
+						{Contents: "new versions", Color: color.New(color.Bold)},
+						{Contents: "error", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "2"}, {Contents: "started", Color: color.New(color.FgYellow)}, {Contents: format(lastChecked)}, {Contents: "n/a", Color: color.New(color.Faint)}, {Contents: "0"}, {Contents: "n/a", Color: color.New(color.Faint)}},
+						{{Contents: "1"}, {Contents: "failed", Color: color.New(color.FgRed)}, {Contents: format(lastSucceeded)}, {Contents: format(lastSucceeded)}, {Contents: "0"}, {Contents: "some-error", Color: color.New(color.FgRed)}},
+					},
+				}))
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints the check history as json", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchJSON(`[
+						{
+							"id": 2,
+							"status": "started",
+							"start_time": ` + strconv.FormatInt(lastChecked, 10) + `,
+							"versions_added": 0
+						},
+						{
+							"id": 1,
+							"status": "failed",
+							"start_time": ` + strconv.FormatInt(lastSucceeded, 10) + `,
+							"end_time": ` + strconv.FormatInt(lastSucceeded, 10) + `,
+							"error": "some-error",
+							"versions_added": 0
+						}
+					]`))
+				})
+			})
+
+			Context("when the resource does not exist", func() {
+				BeforeEach(func() {
+					status = 404
+				})
+
+				It("errors", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(1))
+					Expect(sess.Err).To(gbytes.Say("resource not found"))
+				})
+			})
+		})
+
+		Context("when check statuses are returned from the API", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "resource-checks", "--pipeline", "pipeline/branch:master")
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/resource-checks", "vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(200, []atc.ResourceCheckStatus{
+							{
+								Name:          "healthy",
+								LastChecked:   lastChecked,
+								Succeeded:     true,
+								LastSucceeded: lastChecked,
+								NextCheck:     nextCheck,
+							},
+							{
+								Name:                "failing",
+								LastChecked:         lastChecked,
+								LastSucceeded:       lastSucceeded,
+								ConsecutiveFailures: 3,
+								NextCheck:           lastChecked,
+							},
+							{
+								Name:        "unchecked",
+								Unscheduled: atc.CheckUnscheduledNever,
+							},
+						}),
+					),
+				)
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints response in json as stdout", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchJSON(`[
+						{
+							"name": "healthy",
+							"last_checked": ` + strconv.FormatInt(lastChecked, 10) + `,
+							"succeeded": true,
+							"last_succeeded": ` + strconv.FormatInt(lastChecked, 10) + `,
+							"consecutive_failures": 0,
+							"next_check": ` + strconv.FormatInt(nextCheck, 10) + `
+						},
+						{
+							"name": "failing",
+							"last_checked": ` + strconv.FormatInt(lastChecked, 10) + `,
+							"succeeded": false,
+							"last_succeeded": ` + strconv.FormatInt(lastSucceeded, 10) + `,
+							"consecutive_failures": 3,
+							"next_check": ` + strconv.FormatInt(lastChecked, 10) + `
+						},
+						{
+							"name": "unchecked",
+							"succeeded": false,
+							"consecutive_failures": 0,
+							"unscheduled": "never"
+						}
+					]`))
+				})
+			})
+
+			It("shows when each resource was checked and is next due", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "name", Color: color.New(color.Bold)},
+						{Contents: "last check", Color: color.New(color.Bold)},
+						{Contents: "status", Color: color.New(color.Bold)},
+						{Contents: "last success", Color: color.New(color.Bold)},
+						{Contents: "failures", Color: color.New(color.Bold)},
+						{Contents: "next check", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "healthy"}, {Contents: format(lastChecked)}, {Contents: "succeeded", Color: color.New(color.FgGreen)}, {Contents: format(lastChecked)}, {Contents: "0"}, {Contents: format(nextCheck)}},
+						{{Contents: "failing"}, {Contents: format(lastChecked)}, {Contents: "failed", Color: color.New(color.FgRed)}, {Contents: format(lastSucceeded)}, {Contents: "3", Color: color.New(color.FgRed)}, {Contents: "due"}},
+						{{Contents: "unchecked"}, {Contents: "n/a", Color: color.New(color.Faint)}, {Contents: "n/a", Color: color.New(color.Faint)}, {Contents: "n/a", Color: color.New(color.Faint)}, {Contents: "0"}, {Contents: "never", Color: color.New(color.Faint)}},
+					},
+				}))
+			})
+		})
+
+		Context("when the api returns an internal server error", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "resource-checks", "-p", "pipeline")
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/resource-checks"),
+						ghttp.RespondWith(500, ""),
+					),
+				)
+			})
+
+			It("writes an error message to stderr", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Eventually(sess.Err).Should(gbytes.Say("Unexpected Response"))
+			})
+		})
+	})
+})
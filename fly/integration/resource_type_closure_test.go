@@ -0,0 +1,136 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("resource-type-closure", func() {
+		var (
+			tmpdir     string
+			configFile string
+
+			closure atc.ResourceTypeClosure
+		)
+
+		BeforeEach(func() {
+			var err error
+			tmpdir, err = ioutil.TempDir("", "fly-test")
+			Expect(err).NotTo(HaveOccurred())
+
+			configFile = filepath.Join(tmpdir, "pipeline.yml")
+			err = ioutil.WriteFile(configFile, []byte(`resources: [{name: some-resource, type: some-type}]`), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			closure = atc.ResourceTypeClosure{
+				Types: []atc.ResourceTypeDependency{
+					{
+						Name:    "some-type",
+						Type:    "registry-image",
+						Version: atc.Version{"digest": "sha256:some-digest"},
+					},
+					{Name: "registry-image", Base: true, WorkerVersions: []string{"1.0.0", "1.1.0"}},
+					{Name: "other-type", Type: "missing-type"},
+					{Name: "missing-type", Base: true},
+				},
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmpdir)
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/teams/main/pipelines/some-pipeline/resource-type-closure"),
+					ghttp.VerifyContentType("application/x-yaml"),
+					ghttp.RespondWithJSONEncoded(200, closure),
+				),
+			)
+		})
+
+		It("lists the types the config is built on", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "resource-type-closure", "-p", "some-pipeline", "-c", configFile)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "name", Color: color.New(color.Bold)},
+					{Contents: "type", Color: color.New(color.Bold)},
+					{Contents: "version", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{{Contents: "some-type"}, {Contents: "registry-image"}, {Contents: "digest:sha256:some-digest"}},
+					{{Contents: "registry-image"}, {Contents: "base", Color: color.New(color.Faint)}, {Contents: "1.0.0,1.1.0"}},
+					{{Contents: "other-type"}, {Contents: "missing-type"}, {Contents: "n/a", Color: color.New(color.Faint)}},
+					{{Contents: "missing-type"}, {Contents: "base", Color: color.New(color.Faint)}, {Contents: "no workers", Color: color.New(color.FgRed)}},
+				},
+			}))
+		})
+
+		Context("when the version of a custom type is unavailable", func() {
+			BeforeEach(func() {
+				closure.Types[2].VersionUnavailable = "no check of the type with this source has found a version"
+			})
+
+			It("says why", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "resource-type-closure", "-p", "some-pipeline", "-c", configFile)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "name", Color: color.New(color.Bold)},
+						{Contents: "type", Color: color.New(color.Bold)},
+						{Contents: "version", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "some-type"}, {Contents: "registry-image"}, {Contents: "digest:sha256:some-digest"}},
+						{{Contents: "registry-image"}, {Contents: "base", Color: color.New(color.Faint)}, {Contents: "1.0.0,1.1.0"}},
+						{{Contents: "other-type"}, {Contents: "missing-type"}, {Contents: "unknown", Color: color.New(color.Faint)}},
+						{{Contents: "missing-type"}, {Contents: "base", Color: color.New(color.Faint)}, {Contents: "no workers", Color: color.New(color.FgRed)}},
+					},
+				}))
+
+				Expect(sess.Err).To(gbytes.Say(`the versions of some custom types are unknown:`))
+				Expect(sess.Err).To(gbytes.Say(`  - other-type: no check of the type with this source has found a version`))
+			})
+		})
+
+		Context("when the custom types form a cycle", func() {
+			BeforeEach(func() {
+				closure.Cycles = [][]string{{"cycle-a", "cycle-b", "cycle-a"}}
+			})
+
+			It("reports the cycle and fails", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "resource-type-closure", "-p", "some-pipeline", "-c", configFile)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say(`custom resource types form cycles:`))
+				Expect(sess.Err).To(gbytes.Say(`  - cycle-a -> cycle-b -> cycle-a`))
+				Expect(sess.Err).To(gbytes.Say(`error: custom resource types form a cycle`))
+			})
+		})
+	})
+})
@@ -0,0 +1,128 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("resource-type-usages", func() {
+		var (
+			flyCmd *exec.Cmd
+
+			usages []atc.ResourceTypeUsage
+		)
+
+		Context("when neither a type nor an image is given", func() {
+			It("fails and says one is required", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "resource-type-usages")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+
+				Expect(sess.Err).To(gbytes.Say("either --type or --image must be given"))
+			})
+		})
+
+		Context("when usages are returned from the API", func() {
+			BeforeEach(func() {
+				usages = []atc.ResourceTypeUsage{
+					{
+						TeamName:     "main",
+						PipelineID:   1,
+						PipelineName: "pipeline",
+						ResourceName: "some-repo",
+						Type:         "custom-git",
+					},
+					{
+						TeamName:             "other-team",
+						PipelineID:           2,
+						PipelineName:         "other-pipeline",
+						PipelineInstanceVars: atc.InstanceVars{"branch": "master"},
+						ResourceTypeName:     "custom-git",
+						Type:                 "registry-image",
+						Image:                "example/custom-git-resource",
+					},
+				}
+
+				flyCmd = exec.Command(flyPath, "-t", targetName, "resource-type-usages", "--image", "example/custom-git-resource")
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/resource-type-usages", "image=example%2Fcustom-git-resource"),
+						ghttp.RespondWithJSONEncoded(200, usages),
+					),
+				)
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints response in json as stdout", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+
+					var printed []atc.ResourceTypeUsage
+					Expect(json.Unmarshal(sess.Out.Contents(), &printed)).To(Succeed())
+					Expect(printed).To(Equal(usages))
+				})
+			})
+
+			It("shows each resource and resource type referencing the type", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "team", Color: color.New(color.Bold)},
+						{Contents: "pipeline", Color: color.New(color.Bold)},
+						{Contents: "resource", Color: color.New(color.Bold)},
+						{Contents: "resource type", Color: color.New(color.Bold)},
+						{Contents: "type", Color: color.New(color.Bold)},
+						{Contents: "image", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "main"}, {Contents: "pipeline"}, {Contents: "some-repo"}, {Contents: "n/a", Color: color.New(color.Faint)}, {Contents: "custom-git"}, {Contents: "n/a", Color: color.New(color.Faint)}},
+						{{Contents: "other-team"}, {Contents: "other-pipeline/branch:master"}, {Contents: "n/a", Color: color.New(color.Faint)}, {Contents: "custom-git"}, {Contents: "registry-image"}, {Contents: "example/custom-git-resource"}},
+					},
+				}))
+			})
+		})
+
+		Context("when the api returns an internal server error", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "resource-type-usages", "--type", "git")
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/resource-type-usages", "type=git"),
+						ghttp.RespondWith(500, ""),
+					),
+				)
+			})
+
+			It("writes an error message to stderr", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Eventually(sess.Err).Should(gbytes.Say("Unexpected Response"))
+			})
+		})
+	})
+})
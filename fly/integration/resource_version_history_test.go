@@ -0,0 +1,126 @@
+package integration_test
+
+import (
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("resource-version-history", func() {
+		var (
+			flyCmd *exec.Cmd
+			status int
+
+			disabledAt = time.Now().Add(-time.Hour).Unix()
+			enabledAt  = time.Now().Add(-time.Minute).Unix()
+		)
+
+		format := func(unix int64) string {
+			return time.Unix(unix, 0).Local().Format("2006-01-02@15:04:05-0700")
+		}
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "resource-version-history", "-r", "pipeline/branch:master/some-resource")
+			status = 200
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/resources/some-resource/version-toggles", "vars.branch=%22master%22"),
+					ghttp.RespondWithJSONEncoded(status, []atc.ResourceVersionToggle{
+						{
+							ID:      2,
+							Version: atc.Version{"ref": "abc", "branch": "main"},
+							Enabled: true,
+							Actor:   "other-user",
+							Time:    enabledAt,
+						},
+						{
+							ID:      1,
+							Version: atc.Version{"ref": "abc", "branch": "main"},
+							Enabled: false,
+							Actor:   "some-user",
+							Reason:  "bad build",
+							Time:    disabledAt,
+						},
+					}),
+				),
+			)
+		})
+
+		It("shows who enabled and disabled versions of the resource", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "time", Color: color.New(color.Bold)},
+					{Contents: "action", Color: color.New(color.Bold)},
+					{Contents: "version", Color: color.New(color.Bold)},
+					{Contents: "by", Color: color.New(color.Bold)},
+					{Contents: "reason", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{{Contents: format(enabledAt)}, {Contents: "enabled", Color: color.New(color.FgCyan)}, {Contents: "branch:main,ref:abc"}, {Contents: "other-user"}, {Contents: "n/a", Color: color.New(color.Faint)}},
+					{{Contents: format(disabledAt)}, {Contents: "disabled", Color: color.New(color.FgRed)}, {Contents: "branch:main,ref:abc"}, {Contents: "some-user"}, {Contents: "bad build"}},
+				},
+			}))
+		})
+
+		Context("when --json is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--json")
+			})
+
+			It("prints the history as json", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out.Contents()).To(MatchJSON(`[
+					{
+						"id": 2,
+						"version": {"ref": "abc", "branch": "main"},
+						"enabled": true,
+						"actor": "other-user",
+						"time": ` + strconv.FormatInt(enabledAt, 10) + `
+					},
+					{
+						"id": 1,
+						"version": {"ref": "abc", "branch": "main"},
+						"enabled": false,
+						"actor": "some-user",
+						"reason": "bad build",
+						"time": ` + strconv.FormatInt(disabledAt, 10) + `
+					}
+				]`))
+			})
+		})
+
+		Context("when the resource does not exist", func() {
+			BeforeEach(func() {
+				status = 404
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("resource not found"))
+			})
+		})
+	})
+})
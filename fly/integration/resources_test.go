@@ -211,6 +211,74 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
+		Context("when a pinned resource has a pin comment", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "resources", "-p", "pipeline")
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/resources"),
+						ghttp.RespondWithJSONEncoded(200, []atc.Resource{
+							{
+								Name:          "resource-1",
+								PipelineID:    1,
+								PipelineName:  "pipeline",
+								TeamName:      teamName,
+								Type:          "custom",
+								PinnedVersion: atc.Version{"some": "version"},
+								PinComment:    "investigating an incident",
+							},
+						}),
+					),
+				)
+			})
+
+			It("shows the comment alongside the pinned version", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Data: []ui.TableRow{
+						{{Contents: "resource-1"}, {Contents: "custom"}, {Contents: "some:version (investigating an incident)"}, {Contents: "n/a", Color: color.New(color.Faint)}},
+					},
+				}))
+			})
+		})
+
+		Context("when checking of a resource is paused", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "resources", "-p", "pipeline")
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/resources"),
+						ghttp.RespondWithJSONEncoded(200, []atc.Resource{
+							{
+								Name:         "resource-1",
+								PipelineID:   1,
+								PipelineName: "pipeline",
+								TeamName:     teamName,
+								Type:         "custom",
+								CheckPaused:  true,
+								Build:        &atc.BuildSummary{Status: atc.StatusSucceeded},
+							},
+						}),
+					),
+				)
+			})
+
+			It("shows the check status as paused", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Data: []ui.TableRow{
+						{{Contents: "resource-1"}, {Contents: "custom"}, {Contents: "n/a"}, {Contents: "paused", Color: color.New(color.FgCyan)}},
+					},
+				}))
+			})
+		})
+
 		Context("when the api returns an internal server error", func() {
 			BeforeEach(func() {
 				flyCmd = exec.Command(flyPath, "-t", targetName, "resources", "-p", "pipeline")
@@ -0,0 +1,132 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("rotate-encryption-key", func() {
+		var (
+			flyCmd *exec.Cmd
+
+			progress []atc.EncryptionKeyRotationProgress
+		)
+
+		BeforeEach(func() {
+			progress = []atc.EncryptionKeyRotationProgress{
+				{Table: "pipelines", Column: "config", Rotated: 12, Finished: true, UpdateTime: 1000},
+				{Table: "resources", Column: "config", Rotated: 3, UpdateTime: 2000},
+				{Table: "teams", Column: "legacy_auth", Error: "something went wrong", UpdateTime: 3000},
+			}
+		})
+
+		expectedTable := func() ui.Table {
+			return ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "table", Color: color.New(color.Bold)},
+					{Contents: "column", Color: color.New(color.Bold)},
+					{Contents: "rotated", Color: color.New(color.Bold)},
+					{Contents: "status", Color: color.New(color.Bold)},
+					{Contents: "updated", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{{Contents: "pipelines"}, {Contents: "config"}, {Contents: "12"}, {Contents: "finished", Color: color.New(color.FgGreen)}, {Contents: time.Unix(1000, 0).Format(time.RFC3339)}},
+					{{Contents: "resources"}, {Contents: "config"}, {Contents: "3"}, {Contents: "rotating", Color: color.New(color.FgYellow)}, {Contents: time.Unix(2000, 0).Format(time.RFC3339)}},
+					{{Contents: "teams"}, {Contents: "legacy_auth"}, {Contents: "0"}, {Contents: "errored: something went wrong", Color: color.New(color.FgRed, color.Bold)}, {Contents: time.Unix(3000, 0).Format(time.RFC3339)}},
+				},
+			}
+		}
+
+		Context("when starting a rotation", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "rotate-encryption-key")
+			})
+
+			Context("when the rotation starts", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/api/v1/encryption-key/rotation"),
+							ghttp.RespondWithJSONEncoded(202, progress),
+						),
+					)
+				})
+
+				It("says it started and shows the progress", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(sess).Should(gexec.Exit(0))
+
+					Expect(sess.Err).To(gbytes.Say("started rotating the encryption key"))
+					Expect(sess.Out).To(PrintTable(expectedTable()))
+				})
+
+				Context("when --json is given", func() {
+					BeforeEach(func() {
+						flyCmd.Args = append(flyCmd.Args, "--json")
+					})
+
+					It("prints response in json as stdout", func() {
+						sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+						Eventually(sess).Should(gexec.Exit(0))
+
+						var printed []atc.EncryptionKeyRotationProgress
+						Expect(json.Unmarshal(sess.Out.Contents(), &printed)).To(Succeed())
+						Expect(printed).To(Equal(progress))
+					})
+				})
+			})
+
+			Context("when a rotation is already under way", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/api/v1/encryption-key/rotation"),
+							ghttp.RespondWith(409, ""),
+						),
+					)
+				})
+
+				It("errors", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(sess).Should(gexec.Exit(1))
+
+					Expect(sess.Err).To(gbytes.Say("a rotation of the encryption key is already under way"))
+				})
+			})
+		})
+
+		Context("when --status is given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "rotate-encryption-key", "--status")
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/encryption-key/rotation"),
+						ghttp.RespondWithJSONEncoded(200, progress),
+					),
+				)
+			})
+
+			It("shows the progress without starting a rotation", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(expectedTable()))
+			})
+		})
+	})
+})
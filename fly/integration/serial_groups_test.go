@@ -0,0 +1,127 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("serial-groups", func() {
+		var (
+			flyCmd *exec.Cmd
+
+			groups []atc.SerialGroup
+		)
+
+		Context("when pipeline name is not specified", func() {
+			It("fails and says pipeline name is required", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "serial-groups")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+
+				Expect(sess.Err).To(gbytes.Say("error: the required flag `" + osFlag("p", "pipeline") + "' was not specified"))
+			})
+		})
+
+		Context("when serial groups are returned from the API", func() {
+			BeforeEach(func() {
+				groups = []atc.SerialGroup{
+					{
+						Name: "deployments",
+						Jobs: []string{"deploy", "smoke"},
+						RunningBuilds: []atc.Build{
+							{ID: 1, Name: "4", JobName: "deploy", Status: atc.StatusStarted},
+						},
+						PendingBuilds: []atc.Build{
+							{ID: 2, Name: "7", JobName: "smoke", Status: atc.StatusPending},
+							{ID: 3, Name: "5", JobName: "deploy", Status: atc.StatusPending},
+						},
+					},
+					{
+						Name:          "idle",
+						Jobs:          []string{"other"},
+						RunningBuilds: []atc.Build{},
+						PendingBuilds: []atc.Build{},
+					},
+				}
+
+				flyCmd = exec.Command(flyPath, "-t", targetName, "serial-groups", "--pipeline", "pipeline/branch:master")
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/serial-groups", "vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(200, groups),
+					),
+				)
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints response in json as stdout", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+
+					var printed []atc.SerialGroup
+					Expect(json.Unmarshal(sess.Out.Contents(), &printed)).To(Succeed())
+					Expect(printed).To(Equal(groups))
+				})
+			})
+
+			It("shows the builds holding and waiting for each group", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "name", Color: color.New(color.Bold)},
+						{Contents: "jobs", Color: color.New(color.Bold)},
+						{Contents: "running", Color: color.New(color.Bold)},
+						{Contents: "pending", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "deployments"}, {Contents: "deploy, smoke"}, {Contents: "deploy/4", Color: color.New(color.FgYellow)}, {Contents: "smoke/7, deploy/5", Color: color.New(color.FgWhite)}},
+						{{Contents: "idle"}, {Contents: "other"}, {Contents: "none", Color: color.New(color.Faint)}, {Contents: "none", Color: color.New(color.Faint)}},
+					},
+				}))
+			})
+		})
+
+		Context("when the api returns an internal server error", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "serial-groups", "-p", "pipeline")
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/serial-groups"),
+						ghttp.RespondWith(500, ""),
+					),
+				)
+			})
+
+			It("writes an error message to stderr", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Eventually(sess.Err).Should(gbytes.Say("Unexpected Response"))
+			})
+		})
+	})
+})
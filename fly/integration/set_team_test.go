@@ -717,6 +717,48 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
+		Describe("setting the max concurrent builds", func() {
+			BeforeEach(func() {
+				cmdParams = []string{"--local-user", "brock-obama", "--max-concurrent-builds", "5"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/venture"),
+						ghttp.VerifyJSON(`{
+							"auth": {
+								"owner":{
+									"users": ["local:brock-obama"],
+									"groups": []
+								}
+							},
+							"max_concurrent_builds": 5
+						}`),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Team{
+							Name: "venture",
+							ID:   8,
+						}),
+					),
+				)
+			})
+
+			It("shows and sends the limit", func() {
+				stdin, err := flyCmd.StdinPipe()
+				Expect(err).NotTo(HaveOccurred())
+
+				sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say("max concurrent builds: 5"))
+
+				Eventually(sess).Should(gbytes.Say(`apply team configuration\? \[yN\]: `))
+				yes(stdin)
+
+				Eventually(sess.Out).Should(gbytes.Say("team updated"))
+
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
 		Describe("handling server response", func() {
 			BeforeEach(func() {
 				cmdParams = []string{"--local-user", "brock-obama"}
@@ -0,0 +1,156 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("shared-artifacts", func() {
+		var flyCmd *exec.Cmd
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "shared-artifacts")
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/shared-artifacts"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.SharedArtifact{
+						{
+							Name:         "some-artifact",
+							BuildID:      42,
+							Size:         1024,
+							PublishedAt:  100,
+							GrantedTeams: []string{"team-a", "team-b"},
+						},
+						{
+							Name:         "future-artifact",
+							GrantedTeams: []string{"team-a"},
+						},
+						{
+							Name:        "private-artifact",
+							BuildID:     43,
+							Size:        2048,
+							PublishedAt: 200,
+						},
+					}),
+				),
+			)
+		})
+
+		It("lists the shared artifacts and the teams they are granted to", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "name", Color: color.New(color.Bold)},
+					{Contents: "build", Color: color.New(color.Bold)},
+					{Contents: "size", Color: color.New(color.Bold)},
+					{Contents: "published", Color: color.New(color.Bold)},
+					{Contents: "granted to", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{
+						{Contents: "some-artifact"},
+						{Contents: "42"},
+						{Contents: "1024"},
+						{Contents: time.Unix(100, 0).Local().Format("2006-01-02@15:04:05-0700")},
+						{Contents: "team-a,team-b"},
+					},
+					{
+						{Contents: "future-artifact"},
+						{Contents: "n/a", Color: color.New(color.Faint)},
+						{Contents: "n/a", Color: color.New(color.Faint)},
+						{Contents: "n/a", Color: color.New(color.Faint)},
+						{Contents: "team-a"},
+					},
+					{
+						{Contents: "private-artifact"},
+						{Contents: "43"},
+						{Contents: "2048"},
+						{Contents: time.Unix(200, 0).Local().Format("2006-01-02@15:04:05-0700")},
+						{Contents: "none", Color: color.New(color.Faint)},
+					},
+				},
+			}))
+		})
+	})
+
+	Describe("grant-shared-artifact", func() {
+		var (
+			flyCmd *exec.Cmd
+			method string
+			status int
+		)
+
+		BeforeEach(func() {
+			method = "PUT"
+			status = http.StatusNoContent
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(method, "/api/v1/teams/main/shared-artifacts/some-artifact/grants/other-team"),
+					ghttp.RespondWith(status, ""),
+				),
+			)
+		})
+
+		Context("when granting", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "grant-shared-artifact", "-a", "some-artifact", "--to", "other-team")
+			})
+
+			It("grants the team access", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("granted access to main/some-artifact to team other-team"))
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					status = http.StatusNotFound
+				})
+
+				It("errors", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(1))
+					Expect(sess.Err).To(gbytes.Say("team 'other-team' not found"))
+				})
+			})
+		})
+
+		Context("when revoking", func() {
+			BeforeEach(func() {
+				method = "DELETE"
+				flyCmd = exec.Command(flyPath, "-t", targetName, "grant-shared-artifact", "-a", "some-artifact", "--to", "other-team", "--revoke")
+			})
+
+			It("revokes the team's access", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("revoked access to main/some-artifact from team other-team"))
+			})
+		})
+	})
+})
@@ -0,0 +1,168 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("team-resource-types", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/resource-types"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ResourceTypes{
+						{
+							Name:   "some-type",
+							Type:   "registry-image",
+							Source: atc.Source{"repository": "some/image"},
+							Tags:   atc.Tags{"private"},
+						},
+						{
+							Name: "other-type",
+							Type: "registry-image",
+						},
+					}),
+				),
+			)
+		})
+
+		It("lists the team's resource types", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "team-resource-types")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "name", Color: color.New(color.Bold)},
+					{Contents: "type", Color: color.New(color.Bold)},
+					{Contents: "tags", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{{Contents: "some-type"}, {Contents: "registry-image"}, {Contents: "private"}},
+					{{Contents: "other-type"}, {Contents: "registry-image"}, {Contents: "none", Color: color.New(color.Faint)}},
+				},
+			}))
+		})
+
+		Context("when --json is given", func() {
+			It("prints the resource types as JSON", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "team-resource-types", "--json")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out.Contents()).To(MatchJSON(`[
+					{
+						"name": "some-type",
+						"type": "registry-image",
+						"source": {"repository": "some/image"},
+						"tags": ["private"]
+					},
+					{
+						"name": "other-type",
+						"type": "registry-image",
+						"source": null
+					}
+				]`))
+			})
+		})
+	})
+
+	Describe("set-team-resource-types", func() {
+		var (
+			tmpdir     string
+			configPath string
+			status     int
+			response   atc.SaveConfigResponse
+		)
+
+		BeforeEach(func() {
+			var err error
+			tmpdir, err = ioutil.TempDir("", "fly-team-resource-types")
+			Expect(err).NotTo(HaveOccurred())
+
+			configPath = filepath.Join(tmpdir, "resource-types.yml")
+			err = ioutil.WriteFile(configPath, []byte(`
+- name: some-type
+  type: registry-image
+  source:
+    repository: some/image
+    username: ((registry.username))
+    password: ((registry.password))
+`), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			status = http.StatusOK
+			response = atc.SaveConfigResponse{}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmpdir)
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/teams/main/resource-types"),
+					ghttp.VerifyJSONRepresenting(atc.ResourceTypes{
+						{
+							Name: "some-type",
+							Type: "registry-image",
+							Source: atc.Source{
+								"repository": "some/image",
+								"username":   "((registry.username))",
+								"password":   "((registry.password))",
+							},
+						},
+					}),
+					ghttp.RespondWithJSONEncoded(status, response),
+				),
+			)
+		})
+
+		It("sends the resource types in the file", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "set-team-resource-types", "-c", configPath)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say("saved 1 resource type\\(s\\) for team 'main'"))
+		})
+
+		Context("when the resource types are invalid", func() {
+			BeforeEach(func() {
+				status = http.StatusBadRequest
+				response = atc.SaveConfigResponse{
+					Errors: []string{"invalid resource types:\n\tresource_types.some-type has no type"},
+				}
+			})
+
+			It("prints the errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "set-team-resource-types", "-c", configPath)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say("resource_types.some-type has no type"))
+			})
+		})
+	})
+})
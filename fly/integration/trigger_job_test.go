@@ -97,6 +97,40 @@ var _ = Describe("Fly CLI", func() {
 					})
 				})
 
+				Context("when vars are provided", func() {
+					BeforeEach(func() {
+						atcServer.AppendHandlers(
+							ghttp.CombineHandlers(
+								ghttp.VerifyRequest("POST", mainPath),
+								ghttp.VerifyJSONRepresenting(atc.TriggerJobBuildRequest{
+									Vars: map[string]interface{}{
+										"version": "1.2.3",
+										"flags":   map[string]interface{}{"debug": true},
+									},
+									AllowVarOverride: true,
+								}),
+								ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 57, Name: "42"}),
+							),
+						)
+					})
+
+					It("starts the build with the vars", func() {
+						flyCmd := exec.Command(flyPath, "-t", targetName, "trigger-job", "-j", "awesome-pipeline/awesome-job",
+							"-v", "version=1.2.3",
+							"-y", "flags.debug=true",
+							"--allow-var-override",
+						)
+
+						sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+
+						Eventually(sess).Should(gbytes.Say(`started awesome-pipeline/awesome-job #42`))
+
+						<-sess.Exited
+						Expect(sess.ExitCode()).To(Equal(0))
+					})
+				})
+
 				Context("when -w option is provided", func() {
 					var streaming chan struct{}
 					var events chan atc.Event
@@ -94,6 +94,51 @@ var _ = Describe("Fly CLI", func() {
 
 				})
 
+				Context("when --check-resources is given", func() {
+					BeforeEach(func() {
+						atcServer.AppendHandlers(
+							ghttp.CombineHandlers(
+								ghttp.VerifyRequest("PUT", mainPath, "check_resources=true"),
+								ghttp.RespondWith(http.StatusOK, nil),
+							),
+						)
+					})
+
+					It("asks for the pipeline's resources to be checked", func() {
+						flyCmd := exec.Command(flyPath, "-t", targetName, "unpause-pipeline", "-p", "awesome-pipeline", "--check-resources")
+
+						sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+
+						Eventually(sess).Should(gbytes.Say(`unpaused 'awesome-pipeline'`))
+
+						<-sess.Exited
+						Expect(sess.ExitCode()).To(Equal(0))
+					})
+				})
+
+				Context("when --wait-for-checks is given", func() {
+					BeforeEach(func() {
+						atcServer.AppendHandlers(
+							ghttp.CombineHandlers(
+								ghttp.VerifyRequest("PUT", mainPath, "wait_for_checks=true"),
+								ghttp.RespondWith(http.StatusOK, nil),
+							),
+						)
+					})
+
+					It("asks for the pipeline's checks to be awaited", func() {
+						flyCmd := exec.Command(flyPath, "-t", targetName, "unpause-pipeline", "-p", "awesome-pipeline", "--wait-for-checks")
+
+						sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+
+						Eventually(sess).Should(gbytes.Say(`unpaused 'awesome-pipeline'`))
+
+						<-sess.Exited
+						Expect(sess.ExitCode()).To(Equal(0))
+					})
+				})
 			})
 
 			Context("when the pipeline doesn't exist", func() {
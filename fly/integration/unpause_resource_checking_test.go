@@ -0,0 +1,97 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/tedsuo/rata"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("unpause-resource-checking", func() {
+		var (
+			expectedStatus   int
+			responseBody     string
+			path             string
+			pipelineRef      = atc.PipelineRef{Name: "pipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+			pipelineResource = fmt.Sprintf("%s/resource", pipelineRef.String())
+		)
+
+		BeforeEach(func() {
+			var err error
+			path, err = atc.Routes.CreatePathForRoute(atc.UnpauseResourceChecking, rata.Params{
+				"pipeline_name": "pipeline",
+				"team_name":     "main",
+				"resource_name": "resource",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			responseBody = ""
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", path, "vars.branch=%22master%22"),
+					ghttp.RespondWith(expectedStatus, responseBody),
+				),
+			)
+		})
+
+		Context("when the resource exists", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusOK
+			})
+
+			It("unpauses checking of the resource", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "unpause-resource-checking", "-r", pipelineResource)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say(fmt.Sprintf("unpaused checking of '%s'", pipelineResource)))
+			})
+		})
+
+		Context("when the resource does not exist", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusNotFound
+			})
+
+			It("fails", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "unpause-resource-checking", "-r", pipelineResource)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say(fmt.Sprintf("could not find resource '%s'", pipelineResource)))
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusInternalServerError
+				responseBody = "welp"
+			})
+
+			It("fails with the error", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "unpause-resource-checking", "-r", pipelineResource)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say("welp"))
+			})
+		})
+	})
+})
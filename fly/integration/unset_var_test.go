@@ -0,0 +1,98 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("unset-var", func() {
+		var (
+			flyCmd        *exec.Cmd
+			expectedQuery string
+		)
+
+		BeforeEach(func() {
+			expectedQuery = ""
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/teams/main/pipeline-vars/registry-password", expectedQuery),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.PipelineVarUpdate{
+						{Pipeline: atc.PipelineRef{Name: "some-pipeline"}, Updated: true},
+						{Pipeline: atc.PipelineRef{Name: "other-pipeline", InstanceVars: atc.InstanceVars{"branch": "main"}}, Updated: false},
+					}),
+				),
+			)
+		})
+
+		Context("when deleting the var of every pipeline", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "unset-var", "--all-pipelines", "registry-password")
+			})
+
+			It("reports which pipelines had it", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "pipeline", Color: color.New(color.Bold)},
+						{Contents: "status", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "some-pipeline"},
+							{Contents: "deleted", Color: color.New(color.FgGreen)},
+						},
+						{
+							{Contents: "other-pipeline/branch:main"},
+							{Contents: "skipped (no such var)", Color: color.New(color.Faint)},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when deleting the var of named pipelines", func() {
+			BeforeEach(func() {
+				expectedQuery = "pipeline=some-pipeline&pipeline=other-pipeline"
+
+				flyCmd = exec.Command(flyPath, "-t", targetName, "unset-var", "-p", "some-pipeline", "-p", "other-pipeline", "registry-password")
+			})
+
+			It("sends them with the request", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("some-pipeline"))
+			})
+		})
+	})
+
+	Describe("unset-var without pipelines", func() {
+		It("errors", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "unset-var", "registry-password")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("either --pipeline or --all-pipelines must be given"))
+		})
+	})
+})
@@ -0,0 +1,142 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("validate-task", func() {
+		var tmpdir string
+		var taskConfigPath string
+		var varsPath string
+
+		BeforeEach(func() {
+			var err error
+			tmpdir, err = ioutil.TempDir("", "fly-validate-task")
+			Expect(err).NotTo(HaveOccurred())
+
+			taskConfigPath = filepath.Join(tmpdir, "task.yml")
+			varsPath = filepath.Join(tmpdir, "vars.yml")
+
+			err = ioutil.WriteFile(varsPath, []byte(`repository: ubuntu`), 0644)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmpdir)
+		})
+
+		writeTaskConfig := func(config string) {
+			err := ioutil.WriteFile(taskConfigPath, []byte(config), 0644)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		validate := func(args ...string) *gexec.Session {
+			flyCmd := exec.Command(flyPath, append([]string{"validate-task", "-c", taskConfigPath}, args...)...)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			<-sess.Exited
+			return sess
+		}
+
+		Context("when the task config is valid", func() {
+			BeforeEach(func() {
+				writeTaskConfig(`---
+platform: linux
+image_resource:
+  type: registry-image
+  source: {repository: ((repository))}
+inputs:
+- name: some-input
+outputs:
+- name: some-output
+run:
+  path: echo
+`)
+			})
+
+			It("says it looks good", func() {
+				sess := validate("-l", varsPath)
+				Expect(sess.Out).To(gbytes.Say("looks good"))
+				Expect(sess.ExitCode()).To(Equal(0))
+			})
+
+			Context("when a var is not provided", func() {
+				It("reports it with the line it is used on", func() {
+					sess := validate()
+					Expect(sess.Err).To(gbytes.Say("undefined var 'repository'"))
+					Expect(sess.Err).To(gbytes.Say(`5 \|   source: {repository: \(\(repository\)\)}`))
+					Expect(sess.Err).To(gbytes.Say("configuration invalid"))
+					Expect(sess.ExitCode()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("when the task config has an unknown field", func() {
+			BeforeEach(func() {
+				writeTaskConfig(`---
+platform: linux
+inptus:
+- name: some-input
+run:
+  path: echo
+`)
+			})
+
+			It("reports it with the line it is on", func() {
+				sess := validate()
+				Expect(sess.Err).To(gbytes.Say("unknown field 'inptus'"))
+				Expect(sess.Err).To(gbytes.Say(`3 \| inptus:`))
+				Expect(sess.ExitCode()).To(Equal(1))
+			})
+		})
+
+		Context("when the task config is missing required fields", func() {
+			BeforeEach(func() {
+				writeTaskConfig(`---
+inputs:
+- path: some-path
+`)
+			})
+
+			It("reports each of them", func() {
+				sess := validate()
+				Expect(sess.Err).To(gbytes.Say("missing 'platform'"))
+				Expect(sess.Err).To(gbytes.Say("missing path to executable to run"))
+				Expect(sess.Err).To(gbytes.Say("input in position 0 is missing a name"))
+				Expect(sess.ExitCode()).To(Equal(1))
+			})
+		})
+
+		Context("when an input is declared more than once", func() {
+			BeforeEach(func() {
+				writeTaskConfig(`---
+platform: linux
+inputs:
+- name: some-input
+- name: some-input
+run:
+  path: echo
+`)
+			})
+
+			It("reports the duplicate declaration", func() {
+				sess := validate()
+				Expect(sess.Err).To(gbytes.Say("input 'some-input' is declared more than once"))
+				Expect(sess.Err).To(gbytes.Say(`5 \| - name: some-input`))
+				Expect(sess.ExitCode()).To(Equal(1))
+			})
+		})
+	})
+})
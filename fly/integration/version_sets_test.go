@@ -0,0 +1,205 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("version-sets", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/version-sets"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.VersionSet{
+						{
+							Name:      "release-1.1",
+							CreatedAt: 100,
+							Versions: map[string]atc.Version{
+								"some-resource": {"ref": "abc"},
+							},
+						},
+						{
+							Name:      "release-1.2",
+							CreatedAt: 200,
+							Applied:   true,
+							Versions: map[string]atc.Version{
+								"some-resource":  {"ref": "def"},
+								"other-resource": {"ref": "ghi"},
+							},
+						},
+					}),
+				),
+			)
+		})
+
+		It("lists the version sets of the pipeline", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "version-sets", "-p", "some-pipeline")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "name", Color: color.New(color.Bold)},
+					{Contents: "resources", Color: color.New(color.Bold)},
+					{Contents: "created", Color: color.New(color.Bold)},
+					{Contents: "applied", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{
+						{Contents: "release-1.1"},
+						{Contents: "1"},
+						{Contents: time.Unix(100, 0).Local().Format("2006-01-02@15:04:05-0700")},
+						{Contents: "no"},
+					},
+					{
+						{Contents: "release-1.2"},
+						{Contents: "2"},
+						{Contents: time.Unix(200, 0).Local().Format("2006-01-02@15:04:05-0700")},
+						{Contents: "yes", Color: color.New(color.FgMagenta)},
+					},
+				},
+			}))
+		})
+	})
+
+	Describe("save-version-set", func() {
+		It("prints the captured versions", func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/version-sets/release-1.2"),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.VersionSet{
+						Name: "release-1.2",
+						Versions: map[string]atc.Version{
+							"some-resource":  {"ref": "def"},
+							"other-resource": {"ref": "ghi"},
+						},
+					}),
+				),
+			)
+
+			flyCmd := exec.Command(flyPath, "-t", targetName, "save-version-set", "-p", "some-pipeline", "-n", "release-1.2")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say(`saved version set 'release-1\.2' of pipeline 'some-pipeline':`))
+			Expect(sess.Out).To(gbytes.Say(`other-resource: ref:ghi`))
+			Expect(sess.Out).To(gbytes.Say(`some-resource: ref:def`))
+		})
+	})
+
+	Describe("apply-version-set", func() {
+		var status int
+		var body string
+
+		BeforeEach(func() {
+			status = http.StatusOK
+			body = ""
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/version-sets/release-1.2/apply"),
+					ghttp.RespondWithPtr(&status, &body),
+				),
+			)
+		})
+
+		It("applies the version set", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "apply-version-set", "-p", "some-pipeline", "-n", "release-1.2")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say(`applied version set 'release-1\.2' to pipeline 'some-pipeline'`))
+		})
+
+		Context("when some resources lack their captured version", func() {
+			BeforeEach(func() {
+				status = http.StatusConflict
+				body = "captured version not found for resource(s): some-resource"
+			})
+
+			It("reports which and fails", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "apply-version-set", "-p", "some-pipeline", "-n", "release-1.2")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say(`captured version not found for resource\(s\): some-resource`))
+			})
+		})
+
+		Context("when the version set does not exist", func() {
+			BeforeEach(func() {
+				status = http.StatusNotFound
+			})
+
+			It("fails", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "apply-version-set", "-p", "some-pipeline", "-n", "release-1.2")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say(`version set 'release-1\.2' of pipeline 'some-pipeline' not found`))
+			})
+		})
+	})
+
+	Describe("clear-version-set", func() {
+		It("clears the version set", func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/version-sets/release-1.2/clear"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+
+			flyCmd := exec.Command(flyPath, "-t", targetName, "clear-version-set", "-p", "some-pipeline", "-n", "release-1.2")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say(`unpinned the resources pinned by version set 'release-1\.2' of pipeline 'some-pipeline'`))
+		})
+	})
+
+	Describe("destroy-version-set", func() {
+		It("destroys the version set", func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/teams/main/pipelines/some-pipeline/version-sets/release-1.2"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+
+			flyCmd := exec.Command(flyPath, "-t", targetName, "destroy-version-set", "-p", "some-pipeline", "-n", "release-1.2")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say(`destroyed version set 'release-1\.2' of pipeline 'some-pipeline'`))
+		})
+	})
+})
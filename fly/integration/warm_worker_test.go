@@ -0,0 +1,104 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("warm-worker", func() {
+		var (
+			flyCmd  *exec.Cmd
+			results []atc.WarmedResourceCache
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "warm-worker", "-w", "some-worker", "-v", "1", "-v", "2")
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/warm"),
+					ghttp.VerifyJSONRepresenting(atc.WarmWorkerRequest{ResourceConfigVersionIDs: []int{1, 2}}),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, results),
+				),
+			)
+		})
+
+		Context("when every resource cache is warmed", func() {
+			BeforeEach(func() {
+				results = []atc.WarmedResourceCache{
+					{ResourceConfigVersionID: 1, ResourceCacheID: 11, Status: atc.WarmStatusStreamed},
+					{ResourceConfigVersionID: 2, ResourceCacheID: 12, Status: atc.WarmStatusAlreadyWarm},
+				}
+			})
+
+			It("prints the outcome of each resource cache", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "version id", Color: color.New(color.Bold)},
+						{Contents: "cache id", Color: color.New(color.Bold)},
+						{Contents: "status", Color: color.New(color.Bold)},
+						{Contents: "error", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "1"}, {Contents: "11"}, {Contents: "streamed"}, {Contents: ""}},
+						{{Contents: "2"}, {Contents: "12"}, {Contents: "already-warm"}, {Contents: ""}},
+					},
+				}))
+			})
+		})
+
+		Context("when a resource cache fails to warm", func() {
+			BeforeEach(func() {
+				results = []atc.WarmedResourceCache{
+					{ResourceConfigVersionID: 1, ResourceCacheID: 11, Status: atc.WarmStatusStreamed},
+					{ResourceConfigVersionID: 2, Status: atc.WarmStatusFailed, Error: "no resource caches found for version"},
+				}
+			})
+
+			It("exits with an error", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Out).To(gbytes.Say("no resource caches found for version"))
+				Expect(sess.Err).To(gbytes.Say("failed to warm 1 resource cache\\(s\\) on 'some-worker'"))
+			})
+		})
+
+		Context("when --json is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--json")
+
+				results = []atc.WarmedResourceCache{
+					{ResourceConfigVersionID: 1, ResourceCacheID: 11, Status: atc.WarmStatusStreamed},
+				}
+			})
+
+			It("prints the outcome as json", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out.Contents()).To(MatchJSON(`[
+					{"resource_config_version_id": 1, "resource_cache_id": 11, "status": "streamed"}
+				]`))
+			})
+		})
+	})
+})
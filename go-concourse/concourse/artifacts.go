@@ -1,6 +1,7 @@
 package concourse
 
 import (
+	"errors"
 	"io"
 	"net/http"
 	"net/url"
@@ -31,6 +32,10 @@ func (team *team) CreateArtifact(src io.Reader, platform string, tags []string)
 	return artifact, err
 }
 
+// ErrArtifactNotFound is returned by GetArtifact when the artifact, or the
+// volume holding its contents, no longer exists.
+var ErrArtifactNotFound = errors.New("artifact not found")
+
 func (team *team) GetArtifact(artifactID int) (io.ReadCloser, error) {
 	params := rata.Params{
 		"team_name":   team.Name(),
@@ -44,9 +49,12 @@ func (team *team) GetArtifact(artifactID int) (io.ReadCloser, error) {
 		ReturnResponseBody: true,
 	}, &response)
 
-	if err != nil {
+	switch err.(type) {
+	case nil:
+		return response.Result.(io.ReadCloser), nil
+	case internal.ResourceNotFoundError:
+		return nil, ErrArtifactNotFound
+	default:
 		return nil, err
 	}
-
-	return response.Result.(io.ReadCloser), nil
 }
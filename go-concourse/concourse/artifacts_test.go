@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
@@ -69,6 +70,22 @@ var _ = Describe("ArtifactRepository", func() {
 			})
 		})
 
+		Context("when the artifact does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/artifacts/17"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns ErrArtifactNotFound", func() {
+				_, err := team.GetArtifact(17)
+				Expect(err).To(Equal(concourse.ErrArtifactNotFound))
+			})
+		})
+
 		Context("when the artifact exsits", func() {
 			BeforeEach(func() {
 				atcServer.AppendHandlers(
@@ -0,0 +1,60 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+var ErrApprovalNotPending = errors.New("build is not waiting for this approval")
+
+func (client *client) ListBuildApprovals(buildID string) ([]atc.BuildApproval, error) {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	var approvals []atc.BuildApproval
+
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListBuildApprovals,
+		Params:      params,
+	}, &internal.Response{
+		Result: &approvals,
+	})
+
+	return approvals, err
+}
+
+func (client *client) DecideBuildApproval(buildID string, planID atc.PlanID, decision atc.BuildApprovalDecision) error {
+	params := rata.Params{
+		"build_id": buildID,
+		"plan_id":  string(planID),
+	}
+
+	buffer := &bytes.Buffer{}
+	err := json.NewEncoder(buffer).Encode(decision)
+	if err != nil {
+		return fmt.Errorf("Unable to marshal decision: %s", err)
+	}
+
+	err = client.connection.Send(internal.Request{
+		RequestName: atc.DecideBuildApproval,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+		Params: params,
+		Body:   buffer,
+	}, nil)
+
+	if ure, ok := err.(internal.UnexpectedResponseError); ok && ure.StatusCode == http.StatusConflict {
+		return ErrApprovalNotPending
+	}
+
+	return err
+}
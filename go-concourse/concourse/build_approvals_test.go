@@ -0,0 +1,70 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Approvals", func() {
+	Describe("ListBuildApprovals", func() {
+		var expectedApprovals []atc.BuildApproval
+
+		BeforeEach(func() {
+			expectedApprovals = []atc.BuildApproval{
+				{PlanID: "some-plan", Name: "deploy", Status: atc.ApprovalPending, RequestedAt: 100},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/123/approvals"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedApprovals),
+				),
+			)
+		})
+
+		It("returns the approvals of the build", func() {
+			approvals, err := client.ListBuildApprovals("123")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(approvals).To(Equal(expectedApprovals))
+		})
+	})
+
+	Describe("DecideBuildApproval", func() {
+		var status int
+
+		BeforeEach(func() {
+			status = http.StatusNoContent
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/123/approvals/some-plan"),
+					ghttp.VerifyJSONRepresenting(atc.BuildApprovalDecision{Approved: true, Comment: "ship it"}),
+					ghttp.RespondWith(status, ""),
+				),
+			)
+		})
+
+		It("sends the decision to ATC", func() {
+			err := client.DecideBuildApproval("123", "some-plan", atc.BuildApprovalDecision{Approved: true, Comment: "ship it"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the approval is not pending", func() {
+			BeforeEach(func() {
+				status = http.StatusConflict
+			})
+
+			It("returns ErrApprovalNotPending", func() {
+				err := client.DecideBuildApproval("123", "some-plan", atc.BuildApprovalDecision{Approved: true, Comment: "ship it"})
+				Expect(err).To(Equal(concourse.ErrApprovalNotPending))
+			})
+		})
+	})
+})
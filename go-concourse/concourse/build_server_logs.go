@@ -0,0 +1,32 @@
+package concourse
+
+import (
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (client *client) BuildServerLogs(buildID int) ([]atc.ServerLog, bool, error) {
+	params := rata.Params{
+		"build_id": strconv.Itoa(buildID),
+	}
+
+	var logs []atc.ServerLog
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.GetBuildServerLogs,
+		Params:      params,
+	}, &internal.Response{
+		Result: &logs,
+	})
+
+	switch err.(type) {
+	case nil:
+		return logs, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
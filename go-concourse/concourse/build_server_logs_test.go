@@ -0,0 +1,61 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Server Logs", func() {
+	Describe("BuildServerLogs", func() {
+		expectedURL := "/api/v1/builds/1234/server-logs"
+
+		Context("when the build exists", func() {
+			expectedLogs := []atc.ServerLog{
+				{
+					Timestamp: "1234.5678",
+					Source:    "atc",
+					Message:   "atc.some-message",
+					LogLevel:  atc.LogLevelInfo,
+					Data:      map[string]interface{}{"build_id": float64(1234)},
+				},
+			}
+
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedLogs),
+					),
+				)
+			})
+
+			It("returns the server logs for the build", func() {
+				logs, found, err := client.BuildServerLogs(1234)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(logs).To(Equal(expectedLogs))
+			})
+		})
+
+		Context("when the build does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := client.BuildServerLogs(1234)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
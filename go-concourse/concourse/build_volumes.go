@@ -0,0 +1,32 @@
+package concourse
+
+import (
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (client *client) BuildVolumes(buildID int) (atc.BuildVolumes, bool, error) {
+	params := rata.Params{
+		"build_id": strconv.Itoa(buildID),
+	}
+
+	var volumes atc.BuildVolumes
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListBuildVolumes,
+		Params:      params,
+	}, &internal.Response{
+		Result: &volumes,
+	})
+
+	switch err.(type) {
+	case nil:
+		return volumes, true, nil
+	case internal.ResourceNotFoundError:
+		return atc.BuildVolumes{}, false, nil
+	default:
+		return atc.BuildVolumes{}, false, err
+	}
+}
@@ -0,0 +1,69 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Volumes", func() {
+	Describe("BuildVolumes", func() {
+		expectedURL := "/api/v1/builds/1234/volumes"
+
+		Context("when the build exists", func() {
+			size := uint64(1024)
+			expectedVolumes := atc.BuildVolumes{
+				Containers: []atc.Container{
+					{ID: "some-container", WorkerName: "some-worker", BuildID: 1234},
+				},
+				Volumes: []atc.BuildVolume{
+					{
+						ID:              "some-volume",
+						WorkerName:      "some-worker",
+						State:           "created",
+						Type:            "container",
+						ContainerHandle: "some-container",
+						ParentHandle:    "some-parent",
+						SizeBytes:       &size,
+					},
+				},
+			}
+
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedVolumes),
+					),
+				)
+			})
+
+			It("returns the containers and volumes of the build", func() {
+				volumes, found, err := client.BuildVolumes(1234)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(volumes).To(Equal(expectedVolumes))
+			})
+		})
+
+		Context("when the build does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := client.BuildVolumes(1234)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
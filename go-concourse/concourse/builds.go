@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse/internal"
@@ -54,7 +55,42 @@ func (team *team) CreateJobBuild(pipelineRef atc.PipelineRef, jobName string) (a
 	return build, err
 }
 
+func (team *team) CreateJobBuildWithVars(pipelineRef atc.PipelineRef, jobName string, trigger atc.TriggerJobBuildRequest) (atc.Build, error) {
+	params := rata.Params{
+		"job_name":      jobName,
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	var build atc.Build
+
+	jsonBytes, err := json.Marshal(trigger)
+	if err != nil {
+		return build, err
+	}
+
+	err = team.connection.Send(internal.Request{
+		RequestName: atc.CreateJobBuild,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+		Body:        bytes.NewBuffer(jsonBytes),
+		Header:      http.Header{"Content-Type": []string{"application/json"}},
+	}, &internal.Response{
+		Result: &build,
+	})
+
+	return build, err
+}
+
 func (team *team) RerunJobBuild(pipelineRef atc.PipelineRef, jobName string, buildName string) (atc.Build, error) {
+	return team.rerunJobBuild(pipelineRef, jobName, buildName, false)
+}
+
+func (team *team) RerunJobBuildWithLatestIfUnavailable(pipelineRef atc.PipelineRef, jobName string, buildName string) (atc.Build, error) {
+	return team.rerunJobBuild(pipelineRef, jobName, buildName, true)
+}
+
+func (team *team) rerunJobBuild(pipelineRef atc.PipelineRef, jobName string, buildName string, latestIfUnavailable bool) (atc.Build, error) {
 	params := rata.Params{
 		"build_name":    buildName,
 		"job_name":      jobName,
@@ -62,15 +98,29 @@ func (team *team) RerunJobBuild(pipelineRef atc.PipelineRef, jobName string, bui
 		"team_name":     team.Name(),
 	}
 
+	query := pipelineRef.QueryParams()
+	if latestIfUnavailable {
+		if query == nil {
+			query = url.Values{}
+		}
+
+		query.Set("latest_if_unavailable", "true")
+	}
+
 	var build atc.Build
 	err := team.connection.Send(internal.Request{
 		RequestName: atc.RerunJobBuild,
 		Params:      params,
-		Query:       pipelineRef.QueryParams(),
+		Query:       query,
 	}, &internal.Response{
 		Result: &build,
 	})
 
+	// the versions of some of the build's inputs are no longer available
+	if ure, ok := err.(internal.UnexpectedResponseError); ok && ure.StatusCode == http.StatusConflict {
+		return build, GenericError{Message: ure.Body}
+	}
+
 	return build, err
 }
 
@@ -149,14 +199,34 @@ func (client *client) Builds(page Page) ([]atc.Build, Pagination, error) {
 	}
 }
 
-func (client *client) AbortBuild(buildID string) error {
+func (client *client) AbortBuild(buildID string, reason string) error {
 	params := rata.Params{
 		"build_id": buildID,
 	}
 
+	query := url.Values{}
+	if reason != "" {
+		query.Set("reason", reason)
+	}
+
 	return client.connection.Send(internal.Request{
 		RequestName: atc.AbortBuild,
 		Params:      params,
+		Query:       query,
+	}, nil)
+}
+
+func (client *client) ProtectBuild(buildID string) error {
+	return client.connection.Send(internal.Request{
+		RequestName: atc.ProtectBuild,
+		Params:      rata.Params{"build_id": buildID},
+	}, nil)
+}
+
+func (client *client) UnprotectBuild(buildID string) error {
+	return client.connection.Send(internal.Request{
+		RequestName: atc.UnprotectBuild,
+		Params:      rata.Params{"build_id": buildID},
 	}, nil)
 }
 
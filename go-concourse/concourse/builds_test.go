@@ -98,6 +98,44 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("CreateJobBuildWithVars", func() {
+		var expectedBuild atc.Build
+
+		BeforeEach(func() {
+			expectedBuild = atc.Build{
+				ID:      123,
+				Name:    "mybuild",
+				Status:  "succeeded",
+				JobName: "myjob",
+				APIURL:  "api/v1/builds/123",
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/builds", "vars.branch=%22master%22"),
+					ghttp.VerifyJSONRepresenting(atc.TriggerJobBuildRequest{
+						Vars:             map[string]interface{}{"foo": "bar"},
+						AllowVarOverride: true,
+					}),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, expectedBuild),
+				),
+			)
+		})
+
+		It("sends the vars along with the trigger", func() {
+			build, err := team.CreateJobBuildWithVars(
+				atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}},
+				"myjob",
+				atc.TriggerJobBuildRequest{
+					Vars:             map[string]interface{}{"foo": "bar"},
+					AllowVarOverride: true,
+				},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build).To(Equal(expectedBuild))
+		})
+	})
+
 	Describe("RerunJobBuild", func() {
 		var (
 			pipelineRef   atc.PipelineRef
@@ -137,6 +175,80 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("RerunJobBuildWithLatestIfUnavailable", func() {
+		var (
+			pipelineRef atc.PipelineRef
+			expectedURL string
+		)
+
+		BeforeEach(func() {
+			pipelineRef = atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+			expectedURL = "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/builds/mybuild"
+		})
+
+		Context("when the build is rerun", func() {
+			var expectedBuild atc.Build
+
+			BeforeEach(func() {
+				expectedBuild = atc.Build{
+					ID:      123,
+					Name:    "mybuild.1",
+					Status:  "pending",
+					JobName: "myjob",
+					APIURL:  "api/v1/builds/123",
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", expectedURL, "latest_if_unavailable=true&vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuild),
+					),
+				)
+			})
+
+			It("asks to fall back to the latest versions of unavailable inputs", func() {
+				build, err := team.RerunJobBuildWithLatestIfUnavailable(pipelineRef, "myjob", "mybuild")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(build).To(Equal(expectedBuild))
+			})
+		})
+
+		Context("when the pipeline has no instance vars", func() {
+			BeforeEach(func() {
+				pipelineRef = atc.PipelineRef{Name: "mypipeline"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", expectedURL, "latest_if_unavailable=true"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 123}),
+					),
+				)
+			})
+
+			It("still asks to fall back to the latest versions", func() {
+				build, err := team.RerunJobBuildWithLatestIfUnavailable(pipelineRef, "myjob", "mybuild")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(build.ID).To(Equal(123))
+			})
+		})
+	})
+
+	Describe("RerunJobBuild when inputs are unavailable", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/builds/mybuild"),
+					ghttp.RespondWith(http.StatusConflict, "versions of inputs no longer available: some-input"),
+				),
+			)
+		})
+
+		It("returns an error naming them", func() {
+			_, err := team.RerunJobBuild(atc.PipelineRef{Name: "mypipeline"}, "myjob", "mybuild")
+			Expect(err).To(MatchError("versions of inputs no longer available: some-input"))
+		})
+	})
+
 	Describe("JobBuild", func() {
 		var (
 			expectedBuild atc.Build
@@ -460,7 +572,7 @@ var _ = Describe("ATC Handler Builds", func() {
 
 		It("sends an abort request to ATC", func() {
 			Expect(func() {
-				err := client.AbortBuild("123")
+				err := client.AbortBuild("123", "")
 				Expect(err).NotTo(HaveOccurred())
 			}).To(Change(func() int {
 				return len(atcServer.ReceivedRequests())
@@ -468,6 +580,56 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("AbortBuild with a reason", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/123/abort", "reason=taking+too+long"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("sends the reason to ATC", func() {
+			err := client.AbortBuild("123", "taking too long")
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("ProtectBuild", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/123/protect"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("sends a protect request to ATC", func() {
+			err := client.ProtectBuild("123")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("UnprotectBuild", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/123/unprotect"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("sends an unprotect request to ATC", func() {
+			err := client.UnprotectBuild("123")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
 	Describe("team.Builds", func() {
 		expectedURL := "/api/v1/teams/some-team/builds"
 
@@ -20,21 +20,33 @@ type Client interface {
 	BuildEvents(buildID string) (Events, error)
 	BuildResources(buildID int) (atc.BuildInputsOutputs, bool, error)
 	ListBuildArtifacts(buildID string) ([]atc.WorkerArtifact, error)
-	AbortBuild(buildID string) error
+	AbortBuild(buildID string, reason string) error
+	ProtectBuild(buildID string) error
+	UnprotectBuild(buildID string) error
+	CancelCheck(buildID string) error
+	ListBuildApprovals(buildID string) ([]atc.BuildApproval, error)
+	DecideBuildApproval(buildID string, planID atc.PlanID, decision atc.BuildApprovalDecision) error
 	BuildPlan(buildID int) (atc.PublicBuildPlan, bool, error)
+	BuildServerLogs(buildID int) ([]atc.ServerLog, bool, error)
+	BuildVolumes(buildID int) (atc.BuildVolumes, bool, error)
 	SaveWorker(atc.Worker, *time.Duration) (*atc.Worker, error)
 	ListWorkers() ([]atc.Worker, error)
 	PruneWorker(workerName string) error
+	PruneStalledWorkers(force bool) (atc.PruneStalledWorkersResponse, error)
 	LandWorker(workerName string) error
+	WarmWorker(workerName string, resourceConfigVersionIDs []int) ([]atc.WarmedResourceCache, error)
 	GetInfo() (atc.Info, error)
 	GetCLIReader(arch, platform string) (io.ReadCloser, http.Header, error)
 	ListPipelines() ([]atc.Pipeline, error)
 	ListAllJobs() ([]atc.Job, error)
 	ListTeams() ([]atc.Team, error)
+	ListResourceTypeUsages(typeName string, image string) ([]atc.ResourceTypeUsage, error)
 	FindTeam(teamName string) (Team, error)
 	Team(teamName string) Team
 	UserInfo() (atc.UserInfo, error)
 	ListActiveUsersSince(since time.Time) ([]atc.User, error)
+	RotateEncryptionKey() ([]atc.EncryptionKeyRotationProgress, error)
+	EncryptionKeyRotation() ([]atc.EncryptionKeyRotationProgress, error)
 }
 
 type client struct {
@@ -12,10 +12,11 @@ import (
 )
 
 type FakeClient struct {
-	AbortBuildStub        func(string) error
+	AbortBuildStub        func(string, string) error
 	abortBuildMutex       sync.RWMutex
 	abortBuildArgsForCall []struct {
 		arg1 string
+		arg2 string
 	}
 	abortBuildReturns struct {
 		result1 error
@@ -81,6 +82,36 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	BuildServerLogsStub        func(int) ([]atc.ServerLog, bool, error)
+	buildServerLogsMutex       sync.RWMutex
+	buildServerLogsArgsForCall []struct {
+		arg1 int
+	}
+	buildServerLogsReturns struct {
+		result1 []atc.ServerLog
+		result2 bool
+		result3 error
+	}
+	buildServerLogsReturnsOnCall map[int]struct {
+		result1 []atc.ServerLog
+		result2 bool
+		result3 error
+	}
+	BuildVolumesStub        func(int) (atc.BuildVolumes, bool, error)
+	buildVolumesMutex       sync.RWMutex
+	buildVolumesArgsForCall []struct {
+		arg1 int
+	}
+	buildVolumesReturns struct {
+		result1 atc.BuildVolumes
+		result2 bool
+		result3 error
+	}
+	buildVolumesReturnsOnCall map[int]struct {
+		result1 atc.BuildVolumes
+		result2 bool
+		result3 error
+	}
 	BuildsStub        func(concourse.Page) ([]atc.Build, concourse.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -96,6 +127,42 @@ type FakeClient struct {
 		result2 concourse.Pagination
 		result3 error
 	}
+	CancelCheckStub        func(string) error
+	cancelCheckMutex       sync.RWMutex
+	cancelCheckArgsForCall []struct {
+		arg1 string
+	}
+	cancelCheckReturns struct {
+		result1 error
+	}
+	cancelCheckReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DecideBuildApprovalStub        func(string, atc.PlanID, atc.BuildApprovalDecision) error
+	decideBuildApprovalMutex       sync.RWMutex
+	decideBuildApprovalArgsForCall []struct {
+		arg1 string
+		arg2 atc.PlanID
+		arg3 atc.BuildApprovalDecision
+	}
+	decideBuildApprovalReturns struct {
+		result1 error
+	}
+	decideBuildApprovalReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EncryptionKeyRotationStub        func() ([]atc.EncryptionKeyRotationProgress, error)
+	encryptionKeyRotationMutex       sync.RWMutex
+	encryptionKeyRotationArgsForCall []struct {
+	}
+	encryptionKeyRotationReturns struct {
+		result1 []atc.EncryptionKeyRotationProgress
+		result2 error
+	}
+	encryptionKeyRotationReturnsOnCall map[int]struct {
+		result1 []atc.EncryptionKeyRotationProgress
+		result2 error
+	}
 	FindTeamStub        func(string) (concourse.Team, error)
 	findTeamMutex       sync.RWMutex
 	findTeamArgsForCall []struct {
@@ -183,6 +250,19 @@ type FakeClient struct {
 		result1 []atc.Job
 		result2 error
 	}
+	ListBuildApprovalsStub        func(string) ([]atc.BuildApproval, error)
+	listBuildApprovalsMutex       sync.RWMutex
+	listBuildApprovalsArgsForCall []struct {
+		arg1 string
+	}
+	listBuildApprovalsReturns struct {
+		result1 []atc.BuildApproval
+		result2 error
+	}
+	listBuildApprovalsReturnsOnCall map[int]struct {
+		result1 []atc.BuildApproval
+		result2 error
+	}
 	ListBuildArtifactsStub        func(string) ([]atc.WorkerArtifact, error)
 	listBuildArtifactsMutex       sync.RWMutex
 	listBuildArtifactsArgsForCall []struct {
@@ -208,6 +288,20 @@ type FakeClient struct {
 		result1 []atc.Pipeline
 		result2 error
 	}
+	ListResourceTypeUsagesStub        func(string, string) ([]atc.ResourceTypeUsage, error)
+	listResourceTypeUsagesMutex       sync.RWMutex
+	listResourceTypeUsagesArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	listResourceTypeUsagesReturns struct {
+		result1 []atc.ResourceTypeUsage
+		result2 error
+	}
+	listResourceTypeUsagesReturnsOnCall map[int]struct {
+		result1 []atc.ResourceTypeUsage
+		result2 error
+	}
 	ListTeamsStub        func() ([]atc.Team, error)
 	listTeamsMutex       sync.RWMutex
 	listTeamsArgsForCall []struct {
@@ -232,6 +326,30 @@ type FakeClient struct {
 		result1 []atc.Worker
 		result2 error
 	}
+	ProtectBuildStub        func(string) error
+	protectBuildMutex       sync.RWMutex
+	protectBuildArgsForCall []struct {
+		arg1 string
+	}
+	protectBuildReturns struct {
+		result1 error
+	}
+	protectBuildReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PruneStalledWorkersStub        func(bool) (atc.PruneStalledWorkersResponse, error)
+	pruneStalledWorkersMutex       sync.RWMutex
+	pruneStalledWorkersArgsForCall []struct {
+		arg1 bool
+	}
+	pruneStalledWorkersReturns struct {
+		result1 atc.PruneStalledWorkersResponse
+		result2 error
+	}
+	pruneStalledWorkersReturnsOnCall map[int]struct {
+		result1 atc.PruneStalledWorkersResponse
+		result2 error
+	}
 	PruneWorkerStub        func(string) error
 	pruneWorkerMutex       sync.RWMutex
 	pruneWorkerArgsForCall []struct {
@@ -243,6 +361,18 @@ type FakeClient struct {
 	pruneWorkerReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RotateEncryptionKeyStub        func() ([]atc.EncryptionKeyRotationProgress, error)
+	rotateEncryptionKeyMutex       sync.RWMutex
+	rotateEncryptionKeyArgsForCall []struct {
+	}
+	rotateEncryptionKeyReturns struct {
+		result1 []atc.EncryptionKeyRotationProgress
+		result2 error
+	}
+	rotateEncryptionKeyReturnsOnCall map[int]struct {
+		result1 []atc.EncryptionKeyRotationProgress
+		result2 error
+	}
 	SaveWorkerStub        func(atc.Worker, *time.Duration) (*atc.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -278,6 +408,17 @@ type FakeClient struct {
 	uRLReturnsOnCall map[int]struct {
 		result1 string
 	}
+	UnprotectBuildStub        func(string) error
+	unprotectBuildMutex       sync.RWMutex
+	unprotectBuildArgsForCall []struct {
+		arg1 string
+	}
+	unprotectBuildReturns struct {
+		result1 error
+	}
+	unprotectBuildReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UserInfoStub        func() (atc.UserInfo, error)
 	userInfoMutex       sync.RWMutex
 	userInfoArgsForCall []struct {
@@ -290,22 +431,37 @@ type FakeClient struct {
 		result1 atc.UserInfo
 		result2 error
 	}
+	WarmWorkerStub        func(string, []int) ([]atc.WarmedResourceCache, error)
+	warmWorkerMutex       sync.RWMutex
+	warmWorkerArgsForCall []struct {
+		arg1 string
+		arg2 []int
+	}
+	warmWorkerReturns struct {
+		result1 []atc.WarmedResourceCache
+		result2 error
+	}
+	warmWorkerReturnsOnCall map[int]struct {
+		result1 []atc.WarmedResourceCache
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeClient) AbortBuild(arg1 string) error {
+func (fake *FakeClient) AbortBuild(arg1 string, arg2 string) error {
 	fake.abortBuildMutex.Lock()
 	ret, specificReturn := fake.abortBuildReturnsOnCall[len(fake.abortBuildArgsForCall)]
 	fake.abortBuildArgsForCall = append(fake.abortBuildArgsForCall, struct {
 		arg1 string
-	}{arg1})
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.AbortBuildStub
 	fakeReturns := fake.abortBuildReturns
-	fake.recordInvocation("AbortBuild", []interface{}{arg1})
+	fake.recordInvocation("AbortBuild", []interface{}{arg1, arg2})
 	fake.abortBuildMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -319,17 +475,17 @@ func (fake *FakeClient) AbortBuildCallCount() int {
 	return len(fake.abortBuildArgsForCall)
 }
 
-func (fake *FakeClient) AbortBuildCalls(stub func(string) error) {
+func (fake *FakeClient) AbortBuildCalls(stub func(string, string) error) {
 	fake.abortBuildMutex.Lock()
 	defer fake.abortBuildMutex.Unlock()
 	fake.AbortBuildStub = stub
 }
 
-func (fake *FakeClient) AbortBuildArgsForCall(i int) string {
+func (fake *FakeClient) AbortBuildArgsForCall(i int) (string, string) {
 	fake.abortBuildMutex.RLock()
 	defer fake.abortBuildMutex.RUnlock()
 	argsForCall := fake.abortBuildArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeClient) AbortBuildReturns(result1 error) {
@@ -620,6 +776,140 @@ func (fake *FakeClient) BuildResourcesReturnsOnCall(i int, result1 atc.BuildInpu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) BuildServerLogs(arg1 int) ([]atc.ServerLog, bool, error) {
+	fake.buildServerLogsMutex.Lock()
+	ret, specificReturn := fake.buildServerLogsReturnsOnCall[len(fake.buildServerLogsArgsForCall)]
+	fake.buildServerLogsArgsForCall = append(fake.buildServerLogsArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.BuildServerLogsStub
+	fakeReturns := fake.buildServerLogsReturns
+	fake.recordInvocation("BuildServerLogs", []interface{}{arg1})
+	fake.buildServerLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) BuildServerLogsCallCount() int {
+	fake.buildServerLogsMutex.RLock()
+	defer fake.buildServerLogsMutex.RUnlock()
+	return len(fake.buildServerLogsArgsForCall)
+}
+
+func (fake *FakeClient) BuildServerLogsCalls(stub func(int) ([]atc.ServerLog, bool, error)) {
+	fake.buildServerLogsMutex.Lock()
+	defer fake.buildServerLogsMutex.Unlock()
+	fake.BuildServerLogsStub = stub
+}
+
+func (fake *FakeClient) BuildServerLogsArgsForCall(i int) int {
+	fake.buildServerLogsMutex.RLock()
+	defer fake.buildServerLogsMutex.RUnlock()
+	argsForCall := fake.buildServerLogsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) BuildServerLogsReturns(result1 []atc.ServerLog, result2 bool, result3 error) {
+	fake.buildServerLogsMutex.Lock()
+	defer fake.buildServerLogsMutex.Unlock()
+	fake.BuildServerLogsStub = nil
+	fake.buildServerLogsReturns = struct {
+		result1 []atc.ServerLog
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildServerLogsReturnsOnCall(i int, result1 []atc.ServerLog, result2 bool, result3 error) {
+	fake.buildServerLogsMutex.Lock()
+	defer fake.buildServerLogsMutex.Unlock()
+	fake.BuildServerLogsStub = nil
+	if fake.buildServerLogsReturnsOnCall == nil {
+		fake.buildServerLogsReturnsOnCall = make(map[int]struct {
+			result1 []atc.ServerLog
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildServerLogsReturnsOnCall[i] = struct {
+		result1 []atc.ServerLog
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildVolumes(arg1 int) (atc.BuildVolumes, bool, error) {
+	fake.buildVolumesMutex.Lock()
+	ret, specificReturn := fake.buildVolumesReturnsOnCall[len(fake.buildVolumesArgsForCall)]
+	fake.buildVolumesArgsForCall = append(fake.buildVolumesArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.BuildVolumesStub
+	fakeReturns := fake.buildVolumesReturns
+	fake.recordInvocation("BuildVolumes", []interface{}{arg1})
+	fake.buildVolumesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) BuildVolumesCallCount() int {
+	fake.buildVolumesMutex.RLock()
+	defer fake.buildVolumesMutex.RUnlock()
+	return len(fake.buildVolumesArgsForCall)
+}
+
+func (fake *FakeClient) BuildVolumesCalls(stub func(int) (atc.BuildVolumes, bool, error)) {
+	fake.buildVolumesMutex.Lock()
+	defer fake.buildVolumesMutex.Unlock()
+	fake.BuildVolumesStub = stub
+}
+
+func (fake *FakeClient) BuildVolumesArgsForCall(i int) int {
+	fake.buildVolumesMutex.RLock()
+	defer fake.buildVolumesMutex.RUnlock()
+	argsForCall := fake.buildVolumesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) BuildVolumesReturns(result1 atc.BuildVolumes, result2 bool, result3 error) {
+	fake.buildVolumesMutex.Lock()
+	defer fake.buildVolumesMutex.Unlock()
+	fake.BuildVolumesStub = nil
+	fake.buildVolumesReturns = struct {
+		result1 atc.BuildVolumes
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildVolumesReturnsOnCall(i int, result1 atc.BuildVolumes, result2 bool, result3 error) {
+	fake.buildVolumesMutex.Lock()
+	defer fake.buildVolumesMutex.Unlock()
+	fake.BuildVolumesStub = nil
+	if fake.buildVolumesReturnsOnCall == nil {
+		fake.buildVolumesReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildVolumes
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildVolumesReturnsOnCall[i] = struct {
+		result1 atc.BuildVolumes
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) Builds(arg1 concourse.Page) ([]atc.Build, concourse.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -687,6 +977,186 @@ func (fake *FakeClient) BuildsReturnsOnCall(i int, result1 []atc.Build, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) CancelCheck(arg1 string) error {
+	fake.cancelCheckMutex.Lock()
+	ret, specificReturn := fake.cancelCheckReturnsOnCall[len(fake.cancelCheckArgsForCall)]
+	fake.cancelCheckArgsForCall = append(fake.cancelCheckArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.CancelCheckStub
+	fakeReturns := fake.cancelCheckReturns
+	fake.recordInvocation("CancelCheck", []interface{}{arg1})
+	fake.cancelCheckMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) CancelCheckCallCount() int {
+	fake.cancelCheckMutex.RLock()
+	defer fake.cancelCheckMutex.RUnlock()
+	return len(fake.cancelCheckArgsForCall)
+}
+
+func (fake *FakeClient) CancelCheckCalls(stub func(string) error) {
+	fake.cancelCheckMutex.Lock()
+	defer fake.cancelCheckMutex.Unlock()
+	fake.CancelCheckStub = stub
+}
+
+func (fake *FakeClient) CancelCheckArgsForCall(i int) string {
+	fake.cancelCheckMutex.RLock()
+	defer fake.cancelCheckMutex.RUnlock()
+	argsForCall := fake.cancelCheckArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) CancelCheckReturns(result1 error) {
+	fake.cancelCheckMutex.Lock()
+	defer fake.cancelCheckMutex.Unlock()
+	fake.CancelCheckStub = nil
+	fake.cancelCheckReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CancelCheckReturnsOnCall(i int, result1 error) {
+	fake.cancelCheckMutex.Lock()
+	defer fake.cancelCheckMutex.Unlock()
+	fake.CancelCheckStub = nil
+	if fake.cancelCheckReturnsOnCall == nil {
+		fake.cancelCheckReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.cancelCheckReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) DecideBuildApproval(arg1 string, arg2 atc.PlanID, arg3 atc.BuildApprovalDecision) error {
+	fake.decideBuildApprovalMutex.Lock()
+	ret, specificReturn := fake.decideBuildApprovalReturnsOnCall[len(fake.decideBuildApprovalArgsForCall)]
+	fake.decideBuildApprovalArgsForCall = append(fake.decideBuildApprovalArgsForCall, struct {
+		arg1 string
+		arg2 atc.PlanID
+		arg3 atc.BuildApprovalDecision
+	}{arg1, arg2, arg3})
+	stub := fake.DecideBuildApprovalStub
+	fakeReturns := fake.decideBuildApprovalReturns
+	fake.recordInvocation("DecideBuildApproval", []interface{}{arg1, arg2, arg3})
+	fake.decideBuildApprovalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) DecideBuildApprovalCallCount() int {
+	fake.decideBuildApprovalMutex.RLock()
+	defer fake.decideBuildApprovalMutex.RUnlock()
+	return len(fake.decideBuildApprovalArgsForCall)
+}
+
+func (fake *FakeClient) DecideBuildApprovalCalls(stub func(string, atc.PlanID, atc.BuildApprovalDecision) error) {
+	fake.decideBuildApprovalMutex.Lock()
+	defer fake.decideBuildApprovalMutex.Unlock()
+	fake.DecideBuildApprovalStub = stub
+}
+
+func (fake *FakeClient) DecideBuildApprovalArgsForCall(i int) (string, atc.PlanID, atc.BuildApprovalDecision) {
+	fake.decideBuildApprovalMutex.RLock()
+	defer fake.decideBuildApprovalMutex.RUnlock()
+	argsForCall := fake.decideBuildApprovalArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) DecideBuildApprovalReturns(result1 error) {
+	fake.decideBuildApprovalMutex.Lock()
+	defer fake.decideBuildApprovalMutex.Unlock()
+	fake.DecideBuildApprovalStub = nil
+	fake.decideBuildApprovalReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) DecideBuildApprovalReturnsOnCall(i int, result1 error) {
+	fake.decideBuildApprovalMutex.Lock()
+	defer fake.decideBuildApprovalMutex.Unlock()
+	fake.DecideBuildApprovalStub = nil
+	if fake.decideBuildApprovalReturnsOnCall == nil {
+		fake.decideBuildApprovalReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.decideBuildApprovalReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) EncryptionKeyRotation() ([]atc.EncryptionKeyRotationProgress, error) {
+	fake.encryptionKeyRotationMutex.Lock()
+	ret, specificReturn := fake.encryptionKeyRotationReturnsOnCall[len(fake.encryptionKeyRotationArgsForCall)]
+	fake.encryptionKeyRotationArgsForCall = append(fake.encryptionKeyRotationArgsForCall, struct {
+	}{})
+	stub := fake.EncryptionKeyRotationStub
+	fakeReturns := fake.encryptionKeyRotationReturns
+	fake.recordInvocation("EncryptionKeyRotation", []interface{}{})
+	fake.encryptionKeyRotationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) EncryptionKeyRotationCallCount() int {
+	fake.encryptionKeyRotationMutex.RLock()
+	defer fake.encryptionKeyRotationMutex.RUnlock()
+	return len(fake.encryptionKeyRotationArgsForCall)
+}
+
+func (fake *FakeClient) EncryptionKeyRotationCalls(stub func() ([]atc.EncryptionKeyRotationProgress, error)) {
+	fake.encryptionKeyRotationMutex.Lock()
+	defer fake.encryptionKeyRotationMutex.Unlock()
+	fake.EncryptionKeyRotationStub = stub
+}
+
+func (fake *FakeClient) EncryptionKeyRotationReturns(result1 []atc.EncryptionKeyRotationProgress, result2 error) {
+	fake.encryptionKeyRotationMutex.Lock()
+	defer fake.encryptionKeyRotationMutex.Unlock()
+	fake.EncryptionKeyRotationStub = nil
+	fake.encryptionKeyRotationReturns = struct {
+		result1 []atc.EncryptionKeyRotationProgress
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) EncryptionKeyRotationReturnsOnCall(i int, result1 []atc.EncryptionKeyRotationProgress, result2 error) {
+	fake.encryptionKeyRotationMutex.Lock()
+	defer fake.encryptionKeyRotationMutex.Unlock()
+	fake.EncryptionKeyRotationStub = nil
+	if fake.encryptionKeyRotationReturnsOnCall == nil {
+		fake.encryptionKeyRotationReturnsOnCall = make(map[int]struct {
+			result1 []atc.EncryptionKeyRotationProgress
+			result2 error
+		})
+	}
+	fake.encryptionKeyRotationReturnsOnCall[i] = struct {
+		result1 []atc.EncryptionKeyRotationProgress
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) FindTeam(arg1 string) (concourse.Team, error) {
 	fake.findTeamMutex.Lock()
 	ret, specificReturn := fake.findTeamReturnsOnCall[len(fake.findTeamArgsForCall)]
@@ -1109,6 +1579,70 @@ func (fake *FakeClient) ListAllJobsReturnsOnCall(i int, result1 []atc.Job, resul
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ListBuildApprovals(arg1 string) ([]atc.BuildApproval, error) {
+	fake.listBuildApprovalsMutex.Lock()
+	ret, specificReturn := fake.listBuildApprovalsReturnsOnCall[len(fake.listBuildApprovalsArgsForCall)]
+	fake.listBuildApprovalsArgsForCall = append(fake.listBuildApprovalsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ListBuildApprovalsStub
+	fakeReturns := fake.listBuildApprovalsReturns
+	fake.recordInvocation("ListBuildApprovals", []interface{}{arg1})
+	fake.listBuildApprovalsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListBuildApprovalsCallCount() int {
+	fake.listBuildApprovalsMutex.RLock()
+	defer fake.listBuildApprovalsMutex.RUnlock()
+	return len(fake.listBuildApprovalsArgsForCall)
+}
+
+func (fake *FakeClient) ListBuildApprovalsCalls(stub func(string) ([]atc.BuildApproval, error)) {
+	fake.listBuildApprovalsMutex.Lock()
+	defer fake.listBuildApprovalsMutex.Unlock()
+	fake.ListBuildApprovalsStub = stub
+}
+
+func (fake *FakeClient) ListBuildApprovalsArgsForCall(i int) string {
+	fake.listBuildApprovalsMutex.RLock()
+	defer fake.listBuildApprovalsMutex.RUnlock()
+	argsForCall := fake.listBuildApprovalsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ListBuildApprovalsReturns(result1 []atc.BuildApproval, result2 error) {
+	fake.listBuildApprovalsMutex.Lock()
+	defer fake.listBuildApprovalsMutex.Unlock()
+	fake.ListBuildApprovalsStub = nil
+	fake.listBuildApprovalsReturns = struct {
+		result1 []atc.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListBuildApprovalsReturnsOnCall(i int, result1 []atc.BuildApproval, result2 error) {
+	fake.listBuildApprovalsMutex.Lock()
+	defer fake.listBuildApprovalsMutex.Unlock()
+	fake.ListBuildApprovalsStub = nil
+	if fake.listBuildApprovalsReturnsOnCall == nil {
+		fake.listBuildApprovalsReturnsOnCall = make(map[int]struct {
+			result1 []atc.BuildApproval
+			result2 error
+		})
+	}
+	fake.listBuildApprovalsReturnsOnCall[i] = struct {
+		result1 []atc.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListBuildArtifacts(arg1 string) ([]atc.WorkerArtifact, error) {
 	fake.listBuildArtifactsMutex.Lock()
 	ret, specificReturn := fake.listBuildArtifactsReturnsOnCall[len(fake.listBuildArtifactsArgsForCall)]
@@ -1229,6 +1763,71 @@ func (fake *FakeClient) ListPipelinesReturnsOnCall(i int, result1 []atc.Pipeline
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ListResourceTypeUsages(arg1 string, arg2 string) ([]atc.ResourceTypeUsage, error) {
+	fake.listResourceTypeUsagesMutex.Lock()
+	ret, specificReturn := fake.listResourceTypeUsagesReturnsOnCall[len(fake.listResourceTypeUsagesArgsForCall)]
+	fake.listResourceTypeUsagesArgsForCall = append(fake.listResourceTypeUsagesArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ListResourceTypeUsagesStub
+	fakeReturns := fake.listResourceTypeUsagesReturns
+	fake.recordInvocation("ListResourceTypeUsages", []interface{}{arg1, arg2})
+	fake.listResourceTypeUsagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListResourceTypeUsagesCallCount() int {
+	fake.listResourceTypeUsagesMutex.RLock()
+	defer fake.listResourceTypeUsagesMutex.RUnlock()
+	return len(fake.listResourceTypeUsagesArgsForCall)
+}
+
+func (fake *FakeClient) ListResourceTypeUsagesCalls(stub func(string, string) ([]atc.ResourceTypeUsage, error)) {
+	fake.listResourceTypeUsagesMutex.Lock()
+	defer fake.listResourceTypeUsagesMutex.Unlock()
+	fake.ListResourceTypeUsagesStub = stub
+}
+
+func (fake *FakeClient) ListResourceTypeUsagesArgsForCall(i int) (string, string) {
+	fake.listResourceTypeUsagesMutex.RLock()
+	defer fake.listResourceTypeUsagesMutex.RUnlock()
+	argsForCall := fake.listResourceTypeUsagesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) ListResourceTypeUsagesReturns(result1 []atc.ResourceTypeUsage, result2 error) {
+	fake.listResourceTypeUsagesMutex.Lock()
+	defer fake.listResourceTypeUsagesMutex.Unlock()
+	fake.ListResourceTypeUsagesStub = nil
+	fake.listResourceTypeUsagesReturns = struct {
+		result1 []atc.ResourceTypeUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListResourceTypeUsagesReturnsOnCall(i int, result1 []atc.ResourceTypeUsage, result2 error) {
+	fake.listResourceTypeUsagesMutex.Lock()
+	defer fake.listResourceTypeUsagesMutex.Unlock()
+	fake.ListResourceTypeUsagesStub = nil
+	if fake.listResourceTypeUsagesReturnsOnCall == nil {
+		fake.listResourceTypeUsagesReturnsOnCall = make(map[int]struct {
+			result1 []atc.ResourceTypeUsage
+			result2 error
+		})
+	}
+	fake.listResourceTypeUsagesReturnsOnCall[i] = struct {
+		result1 []atc.ResourceTypeUsage
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListTeams() ([]atc.Team, error) {
 	fake.listTeamsMutex.Lock()
 	ret, specificReturn := fake.listTeamsReturnsOnCall[len(fake.listTeamsArgsForCall)]
@@ -1341,6 +1940,131 @@ func (fake *FakeClient) ListWorkersReturnsOnCall(i int, result1 []atc.Worker, re
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ProtectBuild(arg1 string) error {
+	fake.protectBuildMutex.Lock()
+	ret, specificReturn := fake.protectBuildReturnsOnCall[len(fake.protectBuildArgsForCall)]
+	fake.protectBuildArgsForCall = append(fake.protectBuildArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ProtectBuildStub
+	fakeReturns := fake.protectBuildReturns
+	fake.recordInvocation("ProtectBuild", []interface{}{arg1})
+	fake.protectBuildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) ProtectBuildCallCount() int {
+	fake.protectBuildMutex.RLock()
+	defer fake.protectBuildMutex.RUnlock()
+	return len(fake.protectBuildArgsForCall)
+}
+
+func (fake *FakeClient) ProtectBuildCalls(stub func(string) error) {
+	fake.protectBuildMutex.Lock()
+	defer fake.protectBuildMutex.Unlock()
+	fake.ProtectBuildStub = stub
+}
+
+func (fake *FakeClient) ProtectBuildArgsForCall(i int) string {
+	fake.protectBuildMutex.RLock()
+	defer fake.protectBuildMutex.RUnlock()
+	argsForCall := fake.protectBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ProtectBuildReturns(result1 error) {
+	fake.protectBuildMutex.Lock()
+	defer fake.protectBuildMutex.Unlock()
+	fake.ProtectBuildStub = nil
+	fake.protectBuildReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) ProtectBuildReturnsOnCall(i int, result1 error) {
+	fake.protectBuildMutex.Lock()
+	defer fake.protectBuildMutex.Unlock()
+	fake.ProtectBuildStub = nil
+	if fake.protectBuildReturnsOnCall == nil {
+		fake.protectBuildReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.protectBuildReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) PruneStalledWorkers(arg1 bool) (atc.PruneStalledWorkersResponse, error) {
+	fake.pruneStalledWorkersMutex.Lock()
+	ret, specificReturn := fake.pruneStalledWorkersReturnsOnCall[len(fake.pruneStalledWorkersArgsForCall)]
+	fake.pruneStalledWorkersArgsForCall = append(fake.pruneStalledWorkersArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	stub := fake.PruneStalledWorkersStub
+	fakeReturns := fake.pruneStalledWorkersReturns
+	fake.recordInvocation("PruneStalledWorkers", []interface{}{arg1})
+	fake.pruneStalledWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) PruneStalledWorkersCallCount() int {
+	fake.pruneStalledWorkersMutex.RLock()
+	defer fake.pruneStalledWorkersMutex.RUnlock()
+	return len(fake.pruneStalledWorkersArgsForCall)
+}
+
+func (fake *FakeClient) PruneStalledWorkersCalls(stub func(bool) (atc.PruneStalledWorkersResponse, error)) {
+	fake.pruneStalledWorkersMutex.Lock()
+	defer fake.pruneStalledWorkersMutex.Unlock()
+	fake.PruneStalledWorkersStub = stub
+}
+
+func (fake *FakeClient) PruneStalledWorkersArgsForCall(i int) bool {
+	fake.pruneStalledWorkersMutex.RLock()
+	defer fake.pruneStalledWorkersMutex.RUnlock()
+	argsForCall := fake.pruneStalledWorkersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) PruneStalledWorkersReturns(result1 atc.PruneStalledWorkersResponse, result2 error) {
+	fake.pruneStalledWorkersMutex.Lock()
+	defer fake.pruneStalledWorkersMutex.Unlock()
+	fake.PruneStalledWorkersStub = nil
+	fake.pruneStalledWorkersReturns = struct {
+		result1 atc.PruneStalledWorkersResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) PruneStalledWorkersReturnsOnCall(i int, result1 atc.PruneStalledWorkersResponse, result2 error) {
+	fake.pruneStalledWorkersMutex.Lock()
+	defer fake.pruneStalledWorkersMutex.Unlock()
+	fake.PruneStalledWorkersStub = nil
+	if fake.pruneStalledWorkersReturnsOnCall == nil {
+		fake.pruneStalledWorkersReturnsOnCall = make(map[int]struct {
+			result1 atc.PruneStalledWorkersResponse
+			result2 error
+		})
+	}
+	fake.pruneStalledWorkersReturnsOnCall[i] = struct {
+		result1 atc.PruneStalledWorkersResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) PruneWorker(arg1 string) error {
 	fake.pruneWorkerMutex.Lock()
 	ret, specificReturn := fake.pruneWorkerReturnsOnCall[len(fake.pruneWorkerArgsForCall)]
@@ -1402,6 +2126,62 @@ func (fake *FakeClient) PruneWorkerReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) RotateEncryptionKey() ([]atc.EncryptionKeyRotationProgress, error) {
+	fake.rotateEncryptionKeyMutex.Lock()
+	ret, specificReturn := fake.rotateEncryptionKeyReturnsOnCall[len(fake.rotateEncryptionKeyArgsForCall)]
+	fake.rotateEncryptionKeyArgsForCall = append(fake.rotateEncryptionKeyArgsForCall, struct {
+	}{})
+	stub := fake.RotateEncryptionKeyStub
+	fakeReturns := fake.rotateEncryptionKeyReturns
+	fake.recordInvocation("RotateEncryptionKey", []interface{}{})
+	fake.rotateEncryptionKeyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) RotateEncryptionKeyCallCount() int {
+	fake.rotateEncryptionKeyMutex.RLock()
+	defer fake.rotateEncryptionKeyMutex.RUnlock()
+	return len(fake.rotateEncryptionKeyArgsForCall)
+}
+
+func (fake *FakeClient) RotateEncryptionKeyCalls(stub func() ([]atc.EncryptionKeyRotationProgress, error)) {
+	fake.rotateEncryptionKeyMutex.Lock()
+	defer fake.rotateEncryptionKeyMutex.Unlock()
+	fake.RotateEncryptionKeyStub = stub
+}
+
+func (fake *FakeClient) RotateEncryptionKeyReturns(result1 []atc.EncryptionKeyRotationProgress, result2 error) {
+	fake.rotateEncryptionKeyMutex.Lock()
+	defer fake.rotateEncryptionKeyMutex.Unlock()
+	fake.RotateEncryptionKeyStub = nil
+	fake.rotateEncryptionKeyReturns = struct {
+		result1 []atc.EncryptionKeyRotationProgress
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RotateEncryptionKeyReturnsOnCall(i int, result1 []atc.EncryptionKeyRotationProgress, result2 error) {
+	fake.rotateEncryptionKeyMutex.Lock()
+	defer fake.rotateEncryptionKeyMutex.Unlock()
+	fake.RotateEncryptionKeyStub = nil
+	if fake.rotateEncryptionKeyReturnsOnCall == nil {
+		fake.rotateEncryptionKeyReturnsOnCall = make(map[int]struct {
+			result1 []atc.EncryptionKeyRotationProgress
+			result2 error
+		})
+	}
+	fake.rotateEncryptionKeyReturnsOnCall[i] = struct {
+		result1 []atc.EncryptionKeyRotationProgress
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) SaveWorker(arg1 atc.Worker, arg2 *time.Duration) (*atc.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -1581,6 +2361,67 @@ func (fake *FakeClient) URLReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeClient) UnprotectBuild(arg1 string) error {
+	fake.unprotectBuildMutex.Lock()
+	ret, specificReturn := fake.unprotectBuildReturnsOnCall[len(fake.unprotectBuildArgsForCall)]
+	fake.unprotectBuildArgsForCall = append(fake.unprotectBuildArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.UnprotectBuildStub
+	fakeReturns := fake.unprotectBuildReturns
+	fake.recordInvocation("UnprotectBuild", []interface{}{arg1})
+	fake.unprotectBuildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) UnprotectBuildCallCount() int {
+	fake.unprotectBuildMutex.RLock()
+	defer fake.unprotectBuildMutex.RUnlock()
+	return len(fake.unprotectBuildArgsForCall)
+}
+
+func (fake *FakeClient) UnprotectBuildCalls(stub func(string) error) {
+	fake.unprotectBuildMutex.Lock()
+	defer fake.unprotectBuildMutex.Unlock()
+	fake.UnprotectBuildStub = stub
+}
+
+func (fake *FakeClient) UnprotectBuildArgsForCall(i int) string {
+	fake.unprotectBuildMutex.RLock()
+	defer fake.unprotectBuildMutex.RUnlock()
+	argsForCall := fake.unprotectBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) UnprotectBuildReturns(result1 error) {
+	fake.unprotectBuildMutex.Lock()
+	defer fake.unprotectBuildMutex.Unlock()
+	fake.UnprotectBuildStub = nil
+	fake.unprotectBuildReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UnprotectBuildReturnsOnCall(i int, result1 error) {
+	fake.unprotectBuildMutex.Lock()
+	defer fake.unprotectBuildMutex.Unlock()
+	fake.UnprotectBuildStub = nil
+	if fake.unprotectBuildReturnsOnCall == nil {
+		fake.unprotectBuildReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unprotectBuildReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) UserInfo() (atc.UserInfo, error) {
 	fake.userInfoMutex.Lock()
 	ret, specificReturn := fake.userInfoReturnsOnCall[len(fake.userInfoArgsForCall)]
@@ -1637,6 +2478,76 @@ func (fake *FakeClient) UserInfoReturnsOnCall(i int, result1 atc.UserInfo, resul
 	}{result1, result2}
 }
 
+func (fake *FakeClient) WarmWorker(arg1 string, arg2 []int) ([]atc.WarmedResourceCache, error) {
+	var arg2Copy []int
+	if arg2 != nil {
+		arg2Copy = make([]int, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.warmWorkerMutex.Lock()
+	ret, specificReturn := fake.warmWorkerReturnsOnCall[len(fake.warmWorkerArgsForCall)]
+	fake.warmWorkerArgsForCall = append(fake.warmWorkerArgsForCall, struct {
+		arg1 string
+		arg2 []int
+	}{arg1, arg2Copy})
+	stub := fake.WarmWorkerStub
+	fakeReturns := fake.warmWorkerReturns
+	fake.recordInvocation("WarmWorker", []interface{}{arg1, arg2Copy})
+	fake.warmWorkerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) WarmWorkerCallCount() int {
+	fake.warmWorkerMutex.RLock()
+	defer fake.warmWorkerMutex.RUnlock()
+	return len(fake.warmWorkerArgsForCall)
+}
+
+func (fake *FakeClient) WarmWorkerCalls(stub func(string, []int) ([]atc.WarmedResourceCache, error)) {
+	fake.warmWorkerMutex.Lock()
+	defer fake.warmWorkerMutex.Unlock()
+	fake.WarmWorkerStub = stub
+}
+
+func (fake *FakeClient) WarmWorkerArgsForCall(i int) (string, []int) {
+	fake.warmWorkerMutex.RLock()
+	defer fake.warmWorkerMutex.RUnlock()
+	argsForCall := fake.warmWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) WarmWorkerReturns(result1 []atc.WarmedResourceCache, result2 error) {
+	fake.warmWorkerMutex.Lock()
+	defer fake.warmWorkerMutex.Unlock()
+	fake.WarmWorkerStub = nil
+	fake.warmWorkerReturns = struct {
+		result1 []atc.WarmedResourceCache
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) WarmWorkerReturnsOnCall(i int, result1 []atc.WarmedResourceCache, result2 error) {
+	fake.warmWorkerMutex.Lock()
+	defer fake.warmWorkerMutex.Unlock()
+	fake.WarmWorkerStub = nil
+	if fake.warmWorkerReturnsOnCall == nil {
+		fake.warmWorkerReturnsOnCall = make(map[int]struct {
+			result1 []atc.WarmedResourceCache
+			result2 error
+		})
+	}
+	fake.warmWorkerReturnsOnCall[i] = struct {
+		result1 []atc.WarmedResourceCache
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1650,8 +2561,18 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.buildPlanMutex.RUnlock()
 	fake.buildResourcesMutex.RLock()
 	defer fake.buildResourcesMutex.RUnlock()
+	fake.buildServerLogsMutex.RLock()
+	defer fake.buildServerLogsMutex.RUnlock()
+	fake.buildVolumesMutex.RLock()
+	defer fake.buildVolumesMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
+	fake.cancelCheckMutex.RLock()
+	defer fake.cancelCheckMutex.RUnlock()
+	fake.decideBuildApprovalMutex.RLock()
+	defer fake.decideBuildApprovalMutex.RUnlock()
+	fake.encryptionKeyRotationMutex.RLock()
+	defer fake.encryptionKeyRotationMutex.RUnlock()
 	fake.findTeamMutex.RLock()
 	defer fake.findTeamMutex.RUnlock()
 	fake.getCLIReaderMutex.RLock()
@@ -1666,24 +2587,38 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.listActiveUsersSinceMutex.RUnlock()
 	fake.listAllJobsMutex.RLock()
 	defer fake.listAllJobsMutex.RUnlock()
+	fake.listBuildApprovalsMutex.RLock()
+	defer fake.listBuildApprovalsMutex.RUnlock()
 	fake.listBuildArtifactsMutex.RLock()
 	defer fake.listBuildArtifactsMutex.RUnlock()
 	fake.listPipelinesMutex.RLock()
 	defer fake.listPipelinesMutex.RUnlock()
+	fake.listResourceTypeUsagesMutex.RLock()
+	defer fake.listResourceTypeUsagesMutex.RUnlock()
 	fake.listTeamsMutex.RLock()
 	defer fake.listTeamsMutex.RUnlock()
 	fake.listWorkersMutex.RLock()
 	defer fake.listWorkersMutex.RUnlock()
+	fake.protectBuildMutex.RLock()
+	defer fake.protectBuildMutex.RUnlock()
+	fake.pruneStalledWorkersMutex.RLock()
+	defer fake.pruneStalledWorkersMutex.RUnlock()
 	fake.pruneWorkerMutex.RLock()
 	defer fake.pruneWorkerMutex.RUnlock()
+	fake.rotateEncryptionKeyMutex.RLock()
+	defer fake.rotateEncryptionKeyMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
 	fake.teamMutex.RLock()
 	defer fake.teamMutex.RUnlock()
 	fake.uRLMutex.RLock()
 	defer fake.uRLMutex.RUnlock()
+	fake.unprotectBuildMutex.RLock()
+	defer fake.unprotectBuildMutex.RUnlock()
 	fake.userInfoMutex.RLock()
 	defer fake.userInfoMutex.RUnlock()
+	fake.warmWorkerMutex.RLock()
+	defer fake.warmWorkerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -20,6 +20,20 @@ type FakeTeam struct {
 	aTCTeamReturnsOnCall map[int]struct {
 		result1 atc.Team
 	}
+	ApplyVersionSetStub        func(atc.PipelineRef, string) (bool, error)
+	applyVersionSetMutex       sync.RWMutex
+	applyVersionSetArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}
+	applyVersionSetReturns struct {
+		result1 bool
+		result2 error
+	}
+	applyVersionSetReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	ArchivePipelineStub        func(atc.PipelineRef) (bool, error)
 	archivePipelineMutex       sync.RWMutex
 	archivePipelineArgsForCall []struct {
@@ -43,6 +57,23 @@ type FakeTeam struct {
 	authReturnsOnCall map[int]struct {
 		result1 atc.TeamAuth
 	}
+	BackfillResourceVersionsStub        func(atc.PipelineRef, string, []atc.Version) (int, bool, error)
+	backfillResourceVersionsMutex       sync.RWMutex
+	backfillResourceVersionsArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 []atc.Version
+	}
+	backfillResourceVersionsReturns struct {
+		result1 int
+		result2 bool
+		result3 error
+	}
+	backfillResourceVersionsReturnsOnCall map[int]struct {
+		result1 int
+		result2 bool
+		result3 error
+	}
 	BuildInputsForJobStub        func(atc.PipelineRef, string) ([]atc.BuildInput, bool, error)
 	buildInputsForJobMutex       sync.RWMutex
 	buildInputsForJobArgsForCall []struct {
@@ -158,6 +189,20 @@ type FakeTeam struct {
 		result1 int64
 		result2 error
 	}
+	ClearVersionSetStub        func(atc.PipelineRef, string) (bool, error)
+	clearVersionSetMutex       sync.RWMutex
+	clearVersionSetArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}
+	clearVersionSetReturns struct {
+		result1 bool
+		result2 error
+	}
+	clearVersionSetReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	CreateArtifactStub        func(io.Reader, string, []string) (atc.WorkerArtifact, error)
 	createArtifactMutex       sync.RWMutex
 	createArtifactArgsForCall []struct {
@@ -200,6 +245,21 @@ type FakeTeam struct {
 		result1 atc.Build
 		result2 error
 	}
+	CreateJobBuildWithVarsStub        func(atc.PipelineRef, string, atc.TriggerJobBuildRequest) (atc.Build, error)
+	createJobBuildWithVarsMutex       sync.RWMutex
+	createJobBuildWithVarsArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 atc.TriggerJobBuildRequest
+	}
+	createJobBuildWithVarsReturns struct {
+		result1 atc.Build
+		result2 error
+	}
+	createJobBuildWithVarsReturnsOnCall map[int]struct {
+		result1 atc.Build
+		result2 error
+	}
 	CreateOrUpdateStub        func(atc.Team) (atc.Team, bool, bool, []concourse.ConfigWarning, error)
 	createOrUpdateMutex       sync.RWMutex
 	createOrUpdateArgsForCall []struct {
@@ -266,6 +326,20 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	DeletePipelineVarStub        func(string, []string) ([]atc.PipelineVarUpdate, error)
+	deletePipelineVarMutex       sync.RWMutex
+	deletePipelineVarArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	deletePipelineVarReturns struct {
+		result1 []atc.PipelineVarUpdate
+		result2 error
+	}
+	deletePipelineVarReturnsOnCall map[int]struct {
+		result1 []atc.PipelineVarUpdate
+		result2 error
+	}
 	DestroyTeamStub        func(string) error
 	destroyTeamMutex       sync.RWMutex
 	destroyTeamArgsForCall []struct {
@@ -277,12 +351,27 @@ type FakeTeam struct {
 	destroyTeamReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DisableResourceVersionStub        func(atc.PipelineRef, string, int) (bool, error)
+	DestroyVersionSetStub        func(atc.PipelineRef, string) (bool, error)
+	destroyVersionSetMutex       sync.RWMutex
+	destroyVersionSetArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}
+	destroyVersionSetReturns struct {
+		result1 bool
+		result2 error
+	}
+	destroyVersionSetReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	DisableResourceVersionStub        func(atc.PipelineRef, string, int, string) (bool, error)
 	disableResourceVersionMutex       sync.RWMutex
 	disableResourceVersionArgsForCall []struct {
 		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
+		arg4 string
 	}
 	disableResourceVersionReturns struct {
 		result1 bool
@@ -292,12 +381,13 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	EnableResourceVersionStub        func(atc.PipelineRef, string, int) (bool, error)
+	EnableResourceVersionStub        func(atc.PipelineRef, string, int, string) (bool, error)
 	enableResourceVersionMutex       sync.RWMutex
 	enableResourceVersionArgsForCall []struct {
 		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
+		arg4 string
 	}
 	enableResourceVersionReturns struct {
 		result1 bool
@@ -307,6 +397,23 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	ExpireResourceVersionCachesStub        func(atc.PipelineRef, string, int) (atc.ExpireResourceCachesResponse, bool, error)
+	expireResourceVersionCachesMutex       sync.RWMutex
+	expireResourceVersionCachesArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 int
+	}
+	expireResourceVersionCachesReturns struct {
+		result1 atc.ExpireResourceCachesResponse
+		result2 bool
+		result3 error
+	}
+	expireResourceVersionCachesReturnsOnCall map[int]struct {
+		result1 atc.ExpireResourceCachesResponse
+		result2 bool
+		result3 error
+	}
 	ExposePipelineStub        func(atc.PipelineRef) (bool, error)
 	exposePipelineMutex       sync.RWMutex
 	exposePipelineArgsForCall []struct {
@@ -346,6 +453,20 @@ type FakeTeam struct {
 		result1 atc.Container
 		result2 error
 	}
+	GrantSharedArtifactStub        func(string, string) (bool, error)
+	grantSharedArtifactMutex       sync.RWMutex
+	grantSharedArtifactArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	grantSharedArtifactReturns struct {
+		result1 bool
+		result2 error
+	}
+	grantSharedArtifactReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	HidePipelineStub        func(atc.PipelineRef) (bool, error)
 	hidePipelineMutex       sync.RWMutex
 	hidePipelineArgsForCall []struct {
@@ -421,6 +542,22 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	JobSchedulerDecisionStub        func(atc.PipelineRef, string) (atc.SchedulerDecision, bool, error)
+	jobSchedulerDecisionMutex       sync.RWMutex
+	jobSchedulerDecisionArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}
+	jobSchedulerDecisionReturns struct {
+		result1 atc.SchedulerDecision
+		result2 bool
+		result3 error
+	}
+	jobSchedulerDecisionReturnsOnCall map[int]struct {
+		result1 atc.SchedulerDecision
+		result2 bool
+		result3 error
+	}
 	ListContainersStub        func(map[string]string) ([]atc.Container, error)
 	listContainersMutex       sync.RWMutex
 	listContainersArgsForCall []struct {
@@ -447,6 +584,31 @@ type FakeTeam struct {
 		result1 []atc.Job
 		result2 error
 	}
+	ListPendingChecksStub        func(atc.PipelineRef) ([]atc.PendingCheck, error)
+	listPendingChecksMutex       sync.RWMutex
+	listPendingChecksArgsForCall []struct {
+		arg1 atc.PipelineRef
+	}
+	listPendingChecksReturns struct {
+		result1 []atc.PendingCheck
+		result2 error
+	}
+	listPendingChecksReturnsOnCall map[int]struct {
+		result1 []atc.PendingCheck
+		result2 error
+	}
+	ListPipelineVarsStub        func() ([]atc.PipelineVars, error)
+	listPipelineVarsMutex       sync.RWMutex
+	listPipelineVarsArgsForCall []struct {
+	}
+	listPipelineVarsReturns struct {
+		result1 []atc.PipelineVars
+		result2 error
+	}
+	listPipelineVarsReturnsOnCall map[int]struct {
+		result1 []atc.PipelineVars
+		result2 error
+	}
 	ListPipelinesStub        func() ([]atc.Pipeline, error)
 	listPipelinesMutex       sync.RWMutex
 	listPipelinesArgsForCall []struct {
@@ -459,6 +621,38 @@ type FakeTeam struct {
 		result1 []atc.Pipeline
 		result2 error
 	}
+	ListResourceCheckRecordsStub        func(atc.PipelineRef, string, concourse.Page) ([]atc.CheckRecord, concourse.Pagination, bool, error)
+	listResourceCheckRecordsMutex       sync.RWMutex
+	listResourceCheckRecordsArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 concourse.Page
+	}
+	listResourceCheckRecordsReturns struct {
+		result1 []atc.CheckRecord
+		result2 concourse.Pagination
+		result3 bool
+		result4 error
+	}
+	listResourceCheckRecordsReturnsOnCall map[int]struct {
+		result1 []atc.CheckRecord
+		result2 concourse.Pagination
+		result3 bool
+		result4 error
+	}
+	ListResourceChecksStub        func(atc.PipelineRef) ([]atc.ResourceCheckStatus, error)
+	listResourceChecksMutex       sync.RWMutex
+	listResourceChecksArgsForCall []struct {
+		arg1 atc.PipelineRef
+	}
+	listResourceChecksReturns struct {
+		result1 []atc.ResourceCheckStatus
+		result2 error
+	}
+	listResourceChecksReturnsOnCall map[int]struct {
+		result1 []atc.ResourceCheckStatus
+		result2 error
+	}
 	ListResourcesStub        func(atc.PipelineRef) ([]atc.Resource, error)
 	listResourcesMutex       sync.RWMutex
 	listResourcesArgsForCall []struct {
@@ -472,6 +666,46 @@ type FakeTeam struct {
 		result1 []atc.Resource
 		result2 error
 	}
+	ListSerialGroupsStub        func(atc.PipelineRef) ([]atc.SerialGroup, error)
+	listSerialGroupsMutex       sync.RWMutex
+	listSerialGroupsArgsForCall []struct {
+		arg1 atc.PipelineRef
+	}
+	listSerialGroupsReturns struct {
+		result1 []atc.SerialGroup
+		result2 error
+	}
+	listSerialGroupsReturnsOnCall map[int]struct {
+		result1 []atc.SerialGroup
+		result2 error
+	}
+	ListSharedArtifactsStub        func() ([]atc.SharedArtifact, error)
+	listSharedArtifactsMutex       sync.RWMutex
+	listSharedArtifactsArgsForCall []struct {
+	}
+	listSharedArtifactsReturns struct {
+		result1 []atc.SharedArtifact
+		result2 error
+	}
+	listSharedArtifactsReturnsOnCall map[int]struct {
+		result1 []atc.SharedArtifact
+		result2 error
+	}
+	ListVersionSetsStub        func(atc.PipelineRef) ([]atc.VersionSet, bool, error)
+	listVersionSetsMutex       sync.RWMutex
+	listVersionSetsArgsForCall []struct {
+		arg1 atc.PipelineRef
+	}
+	listVersionSetsReturns struct {
+		result1 []atc.VersionSet
+		result2 bool
+		result3 error
+	}
+	listVersionSetsReturnsOnCall map[int]struct {
+		result1 []atc.VersionSet
+		result2 bool
+		result3 error
+	}
 	ListVolumesStub        func() ([]atc.Volume, error)
 	listVolumesMutex       sync.RWMutex
 	listVolumesArgsForCall []struct {
@@ -517,6 +751,25 @@ type FakeTeam struct {
 	orderingPipelinesWithinGroupReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PatchPipelineConfigStub        func(atc.PipelineRef, string, []byte) (string, []concourse.ConfigWarning, bool, error)
+	patchPipelineConfigMutex       sync.RWMutex
+	patchPipelineConfigArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 []byte
+	}
+	patchPipelineConfigReturns struct {
+		result1 string
+		result2 []concourse.ConfigWarning
+		result3 bool
+		result4 error
+	}
+	patchPipelineConfigReturnsOnCall map[int]struct {
+		result1 string
+		result2 []concourse.ConfigWarning
+		result3 bool
+		result4 error
+	}
 	PauseJobStub        func(atc.PipelineRef, string) (bool, error)
 	pauseJobMutex       sync.RWMutex
 	pauseJobArgsForCall []struct {
@@ -544,12 +797,27 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	PinResourceVersionStub        func(atc.PipelineRef, string, int) (bool, error)
+	PauseResourceCheckingStub        func(atc.PipelineRef, string) (bool, error)
+	pauseResourceCheckingMutex       sync.RWMutex
+	pauseResourceCheckingArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}
+	pauseResourceCheckingReturns struct {
+		result1 bool
+		result2 error
+	}
+	pauseResourceCheckingReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	PinResourceVersionStub        func(atc.PipelineRef, string, int, string) (bool, error)
 	pinResourceVersionMutex       sync.RWMutex
 	pinResourceVersionArgsForCall []struct {
 		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
+		arg4 string
 	}
 	pinResourceVersionReturns struct {
 		result1 bool
@@ -609,6 +877,21 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	PromoteResourceVersionStub        func(atc.PipelineRef, string, int) (bool, error)
+	promoteResourceVersionMutex       sync.RWMutex
+	promoteResourceVersionArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 int
+	}
+	promoteResourceVersionReturns struct {
+		result1 bool
+		result2 error
+	}
+	promoteResourceVersionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	RenamePipelineStub        func(string, string) (bool, []concourse.ConfigWarning, error)
 	renamePipelineMutex       sync.RWMutex
 	renamePipelineArgsForCall []struct {
@@ -656,6 +939,21 @@ type FakeTeam struct {
 		result1 atc.Build
 		result2 error
 	}
+	RerunJobBuildWithLatestIfUnavailableStub        func(atc.PipelineRef, string, string) (atc.Build, error)
+	rerunJobBuildWithLatestIfUnavailableMutex       sync.RWMutex
+	rerunJobBuildWithLatestIfUnavailableArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 string
+	}
+	rerunJobBuildWithLatestIfUnavailableReturns struct {
+		result1 atc.Build
+		result2 error
+	}
+	rerunJobBuildWithLatestIfUnavailableReturnsOnCall map[int]struct {
+		result1 atc.Build
+		result2 error
+	}
 	ResourceStub        func(atc.PipelineRef, string) (atc.Resource, bool, error)
 	resourceMutex       sync.RWMutex
 	resourceArgsForCall []struct {
@@ -672,6 +970,36 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	ResourceTypeClosureStub        func(atc.PipelineRef, []byte) (atc.ResourceTypeClosure, error)
+	resourceTypeClosureMutex       sync.RWMutex
+	resourceTypeClosureArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 []byte
+	}
+	resourceTypeClosureReturns struct {
+		result1 atc.ResourceTypeClosure
+		result2 error
+	}
+	resourceTypeClosureReturnsOnCall map[int]struct {
+		result1 atc.ResourceTypeClosure
+		result2 error
+	}
+	ResourceVersionTogglesStub        func(atc.PipelineRef, string) ([]atc.ResourceVersionToggle, bool, error)
+	resourceVersionTogglesMutex       sync.RWMutex
+	resourceVersionTogglesArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}
+	resourceVersionTogglesReturns struct {
+		result1 []atc.ResourceVersionToggle
+		result2 bool
+		result3 error
+	}
+	resourceVersionTogglesReturnsOnCall map[int]struct {
+		result1 []atc.ResourceVersionToggle
+		result2 bool
+		result3 error
+	}
 	ResourceVersionsStub        func(atc.PipelineRef, string, concourse.Page, atc.Version) ([]atc.ResourceVersion, concourse.Pagination, bool, error)
 	resourceVersionsMutex       sync.RWMutex
 	resourceVersionsArgsForCall []struct {
@@ -692,6 +1020,36 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	RevokeSharedArtifactStub        func(string, string) (bool, error)
+	revokeSharedArtifactMutex       sync.RWMutex
+	revokeSharedArtifactArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	revokeSharedArtifactReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeSharedArtifactReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	SaveVersionSetStub        func(atc.PipelineRef, string) (atc.VersionSet, bool, error)
+	saveVersionSetMutex       sync.RWMutex
+	saveVersionSetArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}
+	saveVersionSetReturns struct {
+		result1 atc.VersionSet
+		result2 bool
+		result3 error
+	}
+	saveVersionSetReturnsOnCall map[int]struct {
+		result1 atc.VersionSet
+		result2 bool
+		result3 error
+	}
 	ScheduleJobStub        func(atc.PipelineRef, string) (bool, error)
 	scheduleJobMutex       sync.RWMutex
 	scheduleJobArgsForCall []struct {
@@ -721,6 +1079,45 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	SetPipelineVarStub        func(string, atc.SetPipelineVarRequest) ([]atc.PipelineVarUpdate, error)
+	setPipelineVarMutex       sync.RWMutex
+	setPipelineVarArgsForCall []struct {
+		arg1 string
+		arg2 atc.SetPipelineVarRequest
+	}
+	setPipelineVarReturns struct {
+		result1 []atc.PipelineVarUpdate
+		result2 error
+	}
+	setPipelineVarReturnsOnCall map[int]struct {
+		result1 []atc.PipelineVarUpdate
+		result2 error
+	}
+	SetTeamResourceTypesStub        func(atc.ResourceTypes) ([]concourse.ConfigWarning, error)
+	setTeamResourceTypesMutex       sync.RWMutex
+	setTeamResourceTypesArgsForCall []struct {
+		arg1 atc.ResourceTypes
+	}
+	setTeamResourceTypesReturns struct {
+		result1 []concourse.ConfigWarning
+		result2 error
+	}
+	setTeamResourceTypesReturnsOnCall map[int]struct {
+		result1 []concourse.ConfigWarning
+		result2 error
+	}
+	TeamResourceTypesStub        func() (atc.ResourceTypes, error)
+	teamResourceTypesMutex       sync.RWMutex
+	teamResourceTypesArgsForCall []struct {
+	}
+	teamResourceTypesReturns struct {
+		result1 atc.ResourceTypes
+		result2 error
+	}
+	teamResourceTypesReturnsOnCall map[int]struct {
+		result1 atc.ResourceTypes
+		result2 error
+	}
 	UnpauseJobStub        func(atc.PipelineRef, string) (bool, error)
 	unpauseJobMutex       sync.RWMutex
 	unpauseJobArgsForCall []struct {
@@ -748,6 +1145,34 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	UnpausePipelineWithChecksStub        func(atc.PipelineRef, bool) (bool, error)
+	unpausePipelineWithChecksMutex       sync.RWMutex
+	unpausePipelineWithChecksArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 bool
+	}
+	unpausePipelineWithChecksReturns struct {
+		result1 bool
+		result2 error
+	}
+	unpausePipelineWithChecksReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	UnpauseResourceCheckingStub        func(atc.PipelineRef, string) (bool, error)
+	unpauseResourceCheckingMutex       sync.RWMutex
+	unpauseResourceCheckingArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}
+	unpauseResourceCheckingReturns struct {
+		result1 bool
+		result2 error
+	}
+	unpauseResourceCheckingReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	UnpinResourceStub        func(atc.PipelineRef, string) (bool, error)
 	unpinResourceMutex       sync.RWMutex
 	unpinResourceArgsForCall []struct {
@@ -762,6 +1187,21 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	UnpromoteResourceVersionStub        func(atc.PipelineRef, string, int) (bool, error)
+	unpromoteResourceVersionMutex       sync.RWMutex
+	unpromoteResourceVersionArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 int
+	}
+	unpromoteResourceVersionReturns struct {
+		result1 bool
+		result2 error
+	}
+	unpromoteResourceVersionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	VersionedResourceTypesStub        func(atc.PipelineRef) (atc.VersionedResourceTypes, bool, error)
 	versionedResourceTypesMutex       sync.RWMutex
 	versionedResourceTypesArgsForCall []struct {
@@ -834,12 +1274,77 @@ func (fake *FakeTeam) ATCTeamReturnsOnCall(i int, result1 atc.Team) {
 	}{result1}
 }
 
-func (fake *FakeTeam) ArchivePipeline(arg1 atc.PipelineRef) (bool, error) {
-	fake.archivePipelineMutex.Lock()
-	ret, specificReturn := fake.archivePipelineReturnsOnCall[len(fake.archivePipelineArgsForCall)]
-	fake.archivePipelineArgsForCall = append(fake.archivePipelineArgsForCall, struct {
+func (fake *FakeTeam) ApplyVersionSet(arg1 atc.PipelineRef, arg2 string) (bool, error) {
+	fake.applyVersionSetMutex.Lock()
+	ret, specificReturn := fake.applyVersionSetReturnsOnCall[len(fake.applyVersionSetArgsForCall)]
+	fake.applyVersionSetArgsForCall = append(fake.applyVersionSetArgsForCall, struct {
 		arg1 atc.PipelineRef
-	}{arg1})
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ApplyVersionSetStub
+	fakeReturns := fake.applyVersionSetReturns
+	fake.recordInvocation("ApplyVersionSet", []interface{}{arg1, arg2})
+	fake.applyVersionSetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ApplyVersionSetCallCount() int {
+	fake.applyVersionSetMutex.RLock()
+	defer fake.applyVersionSetMutex.RUnlock()
+	return len(fake.applyVersionSetArgsForCall)
+}
+
+func (fake *FakeTeam) ApplyVersionSetCalls(stub func(atc.PipelineRef, string) (bool, error)) {
+	fake.applyVersionSetMutex.Lock()
+	defer fake.applyVersionSetMutex.Unlock()
+	fake.ApplyVersionSetStub = stub
+}
+
+func (fake *FakeTeam) ApplyVersionSetArgsForCall(i int) (atc.PipelineRef, string) {
+	fake.applyVersionSetMutex.RLock()
+	defer fake.applyVersionSetMutex.RUnlock()
+	argsForCall := fake.applyVersionSetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) ApplyVersionSetReturns(result1 bool, result2 error) {
+	fake.applyVersionSetMutex.Lock()
+	defer fake.applyVersionSetMutex.Unlock()
+	fake.ApplyVersionSetStub = nil
+	fake.applyVersionSetReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ApplyVersionSetReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.applyVersionSetMutex.Lock()
+	defer fake.applyVersionSetMutex.Unlock()
+	fake.ApplyVersionSetStub = nil
+	if fake.applyVersionSetReturnsOnCall == nil {
+		fake.applyVersionSetReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.applyVersionSetReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ArchivePipeline(arg1 atc.PipelineRef) (bool, error) {
+	fake.archivePipelineMutex.Lock()
+	ret, specificReturn := fake.archivePipelineReturnsOnCall[len(fake.archivePipelineArgsForCall)]
+	fake.archivePipelineArgsForCall = append(fake.archivePipelineArgsForCall, struct {
+		arg1 atc.PipelineRef
+	}{arg1})
 	stub := fake.ArchivePipelineStub
 	fakeReturns := fake.archivePipelineReturns
 	fake.recordInvocation("ArchivePipeline", []interface{}{arg1})
@@ -951,6 +1456,80 @@ func (fake *FakeTeam) AuthReturnsOnCall(i int, result1 atc.TeamAuth) {
 	}{result1}
 }
 
+func (fake *FakeTeam) BackfillResourceVersions(arg1 atc.PipelineRef, arg2 string, arg3 []atc.Version) (int, bool, error) {
+	var arg3Copy []atc.Version
+	if arg3 != nil {
+		arg3Copy = make([]atc.Version, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.backfillResourceVersionsMutex.Lock()
+	ret, specificReturn := fake.backfillResourceVersionsReturnsOnCall[len(fake.backfillResourceVersionsArgsForCall)]
+	fake.backfillResourceVersionsArgsForCall = append(fake.backfillResourceVersionsArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 []atc.Version
+	}{arg1, arg2, arg3Copy})
+	stub := fake.BackfillResourceVersionsStub
+	fakeReturns := fake.backfillResourceVersionsReturns
+	fake.recordInvocation("BackfillResourceVersions", []interface{}{arg1, arg2, arg3Copy})
+	fake.backfillResourceVersionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) BackfillResourceVersionsCallCount() int {
+	fake.backfillResourceVersionsMutex.RLock()
+	defer fake.backfillResourceVersionsMutex.RUnlock()
+	return len(fake.backfillResourceVersionsArgsForCall)
+}
+
+func (fake *FakeTeam) BackfillResourceVersionsCalls(stub func(atc.PipelineRef, string, []atc.Version) (int, bool, error)) {
+	fake.backfillResourceVersionsMutex.Lock()
+	defer fake.backfillResourceVersionsMutex.Unlock()
+	fake.BackfillResourceVersionsStub = stub
+}
+
+func (fake *FakeTeam) BackfillResourceVersionsArgsForCall(i int) (atc.PipelineRef, string, []atc.Version) {
+	fake.backfillResourceVersionsMutex.RLock()
+	defer fake.backfillResourceVersionsMutex.RUnlock()
+	argsForCall := fake.backfillResourceVersionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) BackfillResourceVersionsReturns(result1 int, result2 bool, result3 error) {
+	fake.backfillResourceVersionsMutex.Lock()
+	defer fake.backfillResourceVersionsMutex.Unlock()
+	fake.BackfillResourceVersionsStub = nil
+	fake.backfillResourceVersionsReturns = struct {
+		result1 int
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) BackfillResourceVersionsReturnsOnCall(i int, result1 int, result2 bool, result3 error) {
+	fake.backfillResourceVersionsMutex.Lock()
+	defer fake.backfillResourceVersionsMutex.Unlock()
+	fake.BackfillResourceVersionsStub = nil
+	if fake.backfillResourceVersionsReturnsOnCall == nil {
+		fake.backfillResourceVersionsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 bool
+			result3 error
+		})
+	}
+	fake.backfillResourceVersionsReturnsOnCall[i] = struct {
+		result1 int
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) BuildInputsForJob(arg1 atc.PipelineRef, arg2 string) ([]atc.BuildInput, bool, error) {
 	fake.buildInputsForJobMutex.Lock()
 	ret, specificReturn := fake.buildInputsForJobReturnsOnCall[len(fake.buildInputsForJobArgsForCall)]
@@ -1429,6 +2008,71 @@ func (fake *FakeTeam) ClearTaskCacheReturnsOnCall(i int, result1 int64, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) ClearVersionSet(arg1 atc.PipelineRef, arg2 string) (bool, error) {
+	fake.clearVersionSetMutex.Lock()
+	ret, specificReturn := fake.clearVersionSetReturnsOnCall[len(fake.clearVersionSetArgsForCall)]
+	fake.clearVersionSetArgsForCall = append(fake.clearVersionSetArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ClearVersionSetStub
+	fakeReturns := fake.clearVersionSetReturns
+	fake.recordInvocation("ClearVersionSet", []interface{}{arg1, arg2})
+	fake.clearVersionSetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ClearVersionSetCallCount() int {
+	fake.clearVersionSetMutex.RLock()
+	defer fake.clearVersionSetMutex.RUnlock()
+	return len(fake.clearVersionSetArgsForCall)
+}
+
+func (fake *FakeTeam) ClearVersionSetCalls(stub func(atc.PipelineRef, string) (bool, error)) {
+	fake.clearVersionSetMutex.Lock()
+	defer fake.clearVersionSetMutex.Unlock()
+	fake.ClearVersionSetStub = stub
+}
+
+func (fake *FakeTeam) ClearVersionSetArgsForCall(i int) (atc.PipelineRef, string) {
+	fake.clearVersionSetMutex.RLock()
+	defer fake.clearVersionSetMutex.RUnlock()
+	argsForCall := fake.clearVersionSetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) ClearVersionSetReturns(result1 bool, result2 error) {
+	fake.clearVersionSetMutex.Lock()
+	defer fake.clearVersionSetMutex.Unlock()
+	fake.ClearVersionSetStub = nil
+	fake.clearVersionSetReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ClearVersionSetReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.clearVersionSetMutex.Lock()
+	defer fake.clearVersionSetMutex.Unlock()
+	fake.ClearVersionSetStub = nil
+	if fake.clearVersionSetReturnsOnCall == nil {
+		fake.clearVersionSetReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.clearVersionSetReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) CreateArtifact(arg1 io.Reader, arg2 string, arg3 []string) (atc.WorkerArtifact, error) {
 	var arg3Copy []string
 	if arg3 != nil {
@@ -1629,6 +2273,72 @@ func (fake *FakeTeam) CreateJobBuildReturnsOnCall(i int, result1 atc.Build, resu
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CreateJobBuildWithVars(arg1 atc.PipelineRef, arg2 string, arg3 atc.TriggerJobBuildRequest) (atc.Build, error) {
+	fake.createJobBuildWithVarsMutex.Lock()
+	ret, specificReturn := fake.createJobBuildWithVarsReturnsOnCall[len(fake.createJobBuildWithVarsArgsForCall)]
+	fake.createJobBuildWithVarsArgsForCall = append(fake.createJobBuildWithVarsArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 atc.TriggerJobBuildRequest
+	}{arg1, arg2, arg3})
+	stub := fake.CreateJobBuildWithVarsStub
+	fakeReturns := fake.createJobBuildWithVarsReturns
+	fake.recordInvocation("CreateJobBuildWithVars", []interface{}{arg1, arg2, arg3})
+	fake.createJobBuildWithVarsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateJobBuildWithVarsCallCount() int {
+	fake.createJobBuildWithVarsMutex.RLock()
+	defer fake.createJobBuildWithVarsMutex.RUnlock()
+	return len(fake.createJobBuildWithVarsArgsForCall)
+}
+
+func (fake *FakeTeam) CreateJobBuildWithVarsCalls(stub func(atc.PipelineRef, string, atc.TriggerJobBuildRequest) (atc.Build, error)) {
+	fake.createJobBuildWithVarsMutex.Lock()
+	defer fake.createJobBuildWithVarsMutex.Unlock()
+	fake.CreateJobBuildWithVarsStub = stub
+}
+
+func (fake *FakeTeam) CreateJobBuildWithVarsArgsForCall(i int) (atc.PipelineRef, string, atc.TriggerJobBuildRequest) {
+	fake.createJobBuildWithVarsMutex.RLock()
+	defer fake.createJobBuildWithVarsMutex.RUnlock()
+	argsForCall := fake.createJobBuildWithVarsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) CreateJobBuildWithVarsReturns(result1 atc.Build, result2 error) {
+	fake.createJobBuildWithVarsMutex.Lock()
+	defer fake.createJobBuildWithVarsMutex.Unlock()
+	fake.CreateJobBuildWithVarsStub = nil
+	fake.createJobBuildWithVarsReturns = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateJobBuildWithVarsReturnsOnCall(i int, result1 atc.Build, result2 error) {
+	fake.createJobBuildWithVarsMutex.Lock()
+	defer fake.createJobBuildWithVarsMutex.Unlock()
+	fake.CreateJobBuildWithVarsStub = nil
+	if fake.createJobBuildWithVarsReturnsOnCall == nil {
+		fake.createJobBuildWithVarsReturnsOnCall = make(map[int]struct {
+			result1 atc.Build
+			result2 error
+		})
+	}
+	fake.createJobBuildWithVarsReturnsOnCall[i] = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) CreateOrUpdate(arg1 atc.Team) (atc.Team, bool, bool, []concourse.ConfigWarning, error) {
 	fake.createOrUpdateMutex.Lock()
 	ret, specificReturn := fake.createOrUpdateReturnsOnCall[len(fake.createOrUpdateArgsForCall)]
@@ -1909,6 +2619,76 @@ func (fake *FakeTeam) DeletePipelineReturnsOnCall(i int, result1 bool, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) DeletePipelineVar(arg1 string, arg2 []string) ([]atc.PipelineVarUpdate, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.deletePipelineVarMutex.Lock()
+	ret, specificReturn := fake.deletePipelineVarReturnsOnCall[len(fake.deletePipelineVarArgsForCall)]
+	fake.deletePipelineVarArgsForCall = append(fake.deletePipelineVarArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.DeletePipelineVarStub
+	fakeReturns := fake.deletePipelineVarReturns
+	fake.recordInvocation("DeletePipelineVar", []interface{}{arg1, arg2Copy})
+	fake.deletePipelineVarMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) DeletePipelineVarCallCount() int {
+	fake.deletePipelineVarMutex.RLock()
+	defer fake.deletePipelineVarMutex.RUnlock()
+	return len(fake.deletePipelineVarArgsForCall)
+}
+
+func (fake *FakeTeam) DeletePipelineVarCalls(stub func(string, []string) ([]atc.PipelineVarUpdate, error)) {
+	fake.deletePipelineVarMutex.Lock()
+	defer fake.deletePipelineVarMutex.Unlock()
+	fake.DeletePipelineVarStub = stub
+}
+
+func (fake *FakeTeam) DeletePipelineVarArgsForCall(i int) (string, []string) {
+	fake.deletePipelineVarMutex.RLock()
+	defer fake.deletePipelineVarMutex.RUnlock()
+	argsForCall := fake.deletePipelineVarArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) DeletePipelineVarReturns(result1 []atc.PipelineVarUpdate, result2 error) {
+	fake.deletePipelineVarMutex.Lock()
+	defer fake.deletePipelineVarMutex.Unlock()
+	fake.DeletePipelineVarStub = nil
+	fake.deletePipelineVarReturns = struct {
+		result1 []atc.PipelineVarUpdate
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DeletePipelineVarReturnsOnCall(i int, result1 []atc.PipelineVarUpdate, result2 error) {
+	fake.deletePipelineVarMutex.Lock()
+	defer fake.deletePipelineVarMutex.Unlock()
+	fake.DeletePipelineVarStub = nil
+	if fake.deletePipelineVarReturnsOnCall == nil {
+		fake.deletePipelineVarReturnsOnCall = make(map[int]struct {
+			result1 []atc.PipelineVarUpdate
+			result2 error
+		})
+	}
+	fake.deletePipelineVarReturnsOnCall[i] = struct {
+		result1 []atc.PipelineVarUpdate
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) DestroyTeam(arg1 string) error {
 	fake.destroyTeamMutex.Lock()
 	ret, specificReturn := fake.destroyTeamReturnsOnCall[len(fake.destroyTeamArgsForCall)]
@@ -1970,20 +2750,86 @@ func (fake *FakeTeam) DestroyTeamReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeTeam) DisableResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int) (bool, error) {
+func (fake *FakeTeam) DestroyVersionSet(arg1 atc.PipelineRef, arg2 string) (bool, error) {
+	fake.destroyVersionSetMutex.Lock()
+	ret, specificReturn := fake.destroyVersionSetReturnsOnCall[len(fake.destroyVersionSetArgsForCall)]
+	fake.destroyVersionSetArgsForCall = append(fake.destroyVersionSetArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DestroyVersionSetStub
+	fakeReturns := fake.destroyVersionSetReturns
+	fake.recordInvocation("DestroyVersionSet", []interface{}{arg1, arg2})
+	fake.destroyVersionSetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) DestroyVersionSetCallCount() int {
+	fake.destroyVersionSetMutex.RLock()
+	defer fake.destroyVersionSetMutex.RUnlock()
+	return len(fake.destroyVersionSetArgsForCall)
+}
+
+func (fake *FakeTeam) DestroyVersionSetCalls(stub func(atc.PipelineRef, string) (bool, error)) {
+	fake.destroyVersionSetMutex.Lock()
+	defer fake.destroyVersionSetMutex.Unlock()
+	fake.DestroyVersionSetStub = stub
+}
+
+func (fake *FakeTeam) DestroyVersionSetArgsForCall(i int) (atc.PipelineRef, string) {
+	fake.destroyVersionSetMutex.RLock()
+	defer fake.destroyVersionSetMutex.RUnlock()
+	argsForCall := fake.destroyVersionSetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) DestroyVersionSetReturns(result1 bool, result2 error) {
+	fake.destroyVersionSetMutex.Lock()
+	defer fake.destroyVersionSetMutex.Unlock()
+	fake.DestroyVersionSetStub = nil
+	fake.destroyVersionSetReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DestroyVersionSetReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.destroyVersionSetMutex.Lock()
+	defer fake.destroyVersionSetMutex.Unlock()
+	fake.DestroyVersionSetStub = nil
+	if fake.destroyVersionSetReturnsOnCall == nil {
+		fake.destroyVersionSetReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.destroyVersionSetReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DisableResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int, arg4 string) (bool, error) {
 	fake.disableResourceVersionMutex.Lock()
 	ret, specificReturn := fake.disableResourceVersionReturnsOnCall[len(fake.disableResourceVersionArgsForCall)]
 	fake.disableResourceVersionArgsForCall = append(fake.disableResourceVersionArgsForCall, struct {
 		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
-	}{arg1, arg2, arg3})
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.DisableResourceVersionStub
 	fakeReturns := fake.disableResourceVersionReturns
-	fake.recordInvocation("DisableResourceVersion", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("DisableResourceVersion", []interface{}{arg1, arg2, arg3, arg4})
 	fake.disableResourceVersionMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1997,17 +2843,17 @@ func (fake *FakeTeam) DisableResourceVersionCallCount() int {
 	return len(fake.disableResourceVersionArgsForCall)
 }
 
-func (fake *FakeTeam) DisableResourceVersionCalls(stub func(atc.PipelineRef, string, int) (bool, error)) {
+func (fake *FakeTeam) DisableResourceVersionCalls(stub func(atc.PipelineRef, string, int, string) (bool, error)) {
 	fake.disableResourceVersionMutex.Lock()
 	defer fake.disableResourceVersionMutex.Unlock()
 	fake.DisableResourceVersionStub = stub
 }
 
-func (fake *FakeTeam) DisableResourceVersionArgsForCall(i int) (atc.PipelineRef, string, int) {
+func (fake *FakeTeam) DisableResourceVersionArgsForCall(i int) (atc.PipelineRef, string, int, string) {
 	fake.disableResourceVersionMutex.RLock()
 	defer fake.disableResourceVersionMutex.RUnlock()
 	argsForCall := fake.disableResourceVersionArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeTeam) DisableResourceVersionReturns(result1 bool, result2 error) {
@@ -2036,20 +2882,21 @@ func (fake *FakeTeam) DisableResourceVersionReturnsOnCall(i int, result1 bool, r
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) EnableResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int) (bool, error) {
+func (fake *FakeTeam) EnableResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int, arg4 string) (bool, error) {
 	fake.enableResourceVersionMutex.Lock()
 	ret, specificReturn := fake.enableResourceVersionReturnsOnCall[len(fake.enableResourceVersionArgsForCall)]
 	fake.enableResourceVersionArgsForCall = append(fake.enableResourceVersionArgsForCall, struct {
 		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
-	}{arg1, arg2, arg3})
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.EnableResourceVersionStub
 	fakeReturns := fake.enableResourceVersionReturns
-	fake.recordInvocation("EnableResourceVersion", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("EnableResourceVersion", []interface{}{arg1, arg2, arg3, arg4})
 	fake.enableResourceVersionMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -2063,17 +2910,17 @@ func (fake *FakeTeam) EnableResourceVersionCallCount() int {
 	return len(fake.enableResourceVersionArgsForCall)
 }
 
-func (fake *FakeTeam) EnableResourceVersionCalls(stub func(atc.PipelineRef, string, int) (bool, error)) {
+func (fake *FakeTeam) EnableResourceVersionCalls(stub func(atc.PipelineRef, string, int, string) (bool, error)) {
 	fake.enableResourceVersionMutex.Lock()
 	defer fake.enableResourceVersionMutex.Unlock()
 	fake.EnableResourceVersionStub = stub
 }
 
-func (fake *FakeTeam) EnableResourceVersionArgsForCall(i int) (atc.PipelineRef, string, int) {
+func (fake *FakeTeam) EnableResourceVersionArgsForCall(i int) (atc.PipelineRef, string, int, string) {
 	fake.enableResourceVersionMutex.RLock()
 	defer fake.enableResourceVersionMutex.RUnlock()
 	argsForCall := fake.enableResourceVersionArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeTeam) EnableResourceVersionReturns(result1 bool, result2 error) {
@@ -2102,6 +2949,75 @@ func (fake *FakeTeam) EnableResourceVersionReturnsOnCall(i int, result1 bool, re
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) ExpireResourceVersionCaches(arg1 atc.PipelineRef, arg2 string, arg3 int) (atc.ExpireResourceCachesResponse, bool, error) {
+	fake.expireResourceVersionCachesMutex.Lock()
+	ret, specificReturn := fake.expireResourceVersionCachesReturnsOnCall[len(fake.expireResourceVersionCachesArgsForCall)]
+	fake.expireResourceVersionCachesArgsForCall = append(fake.expireResourceVersionCachesArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.ExpireResourceVersionCachesStub
+	fakeReturns := fake.expireResourceVersionCachesReturns
+	fake.recordInvocation("ExpireResourceVersionCaches", []interface{}{arg1, arg2, arg3})
+	fake.expireResourceVersionCachesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) ExpireResourceVersionCachesCallCount() int {
+	fake.expireResourceVersionCachesMutex.RLock()
+	defer fake.expireResourceVersionCachesMutex.RUnlock()
+	return len(fake.expireResourceVersionCachesArgsForCall)
+}
+
+func (fake *FakeTeam) ExpireResourceVersionCachesCalls(stub func(atc.PipelineRef, string, int) (atc.ExpireResourceCachesResponse, bool, error)) {
+	fake.expireResourceVersionCachesMutex.Lock()
+	defer fake.expireResourceVersionCachesMutex.Unlock()
+	fake.ExpireResourceVersionCachesStub = stub
+}
+
+func (fake *FakeTeam) ExpireResourceVersionCachesArgsForCall(i int) (atc.PipelineRef, string, int) {
+	fake.expireResourceVersionCachesMutex.RLock()
+	defer fake.expireResourceVersionCachesMutex.RUnlock()
+	argsForCall := fake.expireResourceVersionCachesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) ExpireResourceVersionCachesReturns(result1 atc.ExpireResourceCachesResponse, result2 bool, result3 error) {
+	fake.expireResourceVersionCachesMutex.Lock()
+	defer fake.expireResourceVersionCachesMutex.Unlock()
+	fake.ExpireResourceVersionCachesStub = nil
+	fake.expireResourceVersionCachesReturns = struct {
+		result1 atc.ExpireResourceCachesResponse
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) ExpireResourceVersionCachesReturnsOnCall(i int, result1 atc.ExpireResourceCachesResponse, result2 bool, result3 error) {
+	fake.expireResourceVersionCachesMutex.Lock()
+	defer fake.expireResourceVersionCachesMutex.Unlock()
+	fake.ExpireResourceVersionCachesStub = nil
+	if fake.expireResourceVersionCachesReturnsOnCall == nil {
+		fake.expireResourceVersionCachesReturnsOnCall = make(map[int]struct {
+			result1 atc.ExpireResourceCachesResponse
+			result2 bool
+			result3 error
+		})
+	}
+	fake.expireResourceVersionCachesReturnsOnCall[i] = struct {
+		result1 atc.ExpireResourceCachesResponse
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) ExposePipeline(arg1 atc.PipelineRef) (bool, error) {
 	fake.exposePipelineMutex.Lock()
 	ret, specificReturn := fake.exposePipelineReturnsOnCall[len(fake.exposePipelineArgsForCall)]
@@ -2294,6 +3210,71 @@ func (fake *FakeTeam) GetContainerReturnsOnCall(i int, result1 atc.Container, re
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) GrantSharedArtifact(arg1 string, arg2 string) (bool, error) {
+	fake.grantSharedArtifactMutex.Lock()
+	ret, specificReturn := fake.grantSharedArtifactReturnsOnCall[len(fake.grantSharedArtifactArgsForCall)]
+	fake.grantSharedArtifactArgsForCall = append(fake.grantSharedArtifactArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GrantSharedArtifactStub
+	fakeReturns := fake.grantSharedArtifactReturns
+	fake.recordInvocation("GrantSharedArtifact", []interface{}{arg1, arg2})
+	fake.grantSharedArtifactMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) GrantSharedArtifactCallCount() int {
+	fake.grantSharedArtifactMutex.RLock()
+	defer fake.grantSharedArtifactMutex.RUnlock()
+	return len(fake.grantSharedArtifactArgsForCall)
+}
+
+func (fake *FakeTeam) GrantSharedArtifactCalls(stub func(string, string) (bool, error)) {
+	fake.grantSharedArtifactMutex.Lock()
+	defer fake.grantSharedArtifactMutex.Unlock()
+	fake.GrantSharedArtifactStub = stub
+}
+
+func (fake *FakeTeam) GrantSharedArtifactArgsForCall(i int) (string, string) {
+	fake.grantSharedArtifactMutex.RLock()
+	defer fake.grantSharedArtifactMutex.RUnlock()
+	argsForCall := fake.grantSharedArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) GrantSharedArtifactReturns(result1 bool, result2 error) {
+	fake.grantSharedArtifactMutex.Lock()
+	defer fake.grantSharedArtifactMutex.Unlock()
+	fake.GrantSharedArtifactStub = nil
+	fake.grantSharedArtifactReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) GrantSharedArtifactReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.grantSharedArtifactMutex.Lock()
+	defer fake.grantSharedArtifactMutex.Unlock()
+	fake.GrantSharedArtifactStub = nil
+	if fake.grantSharedArtifactReturnsOnCall == nil {
+		fake.grantSharedArtifactReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.grantSharedArtifactReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) HidePipeline(arg1 atc.PipelineRef) (bool, error) {
 	fake.hidePipelineMutex.Lock()
 	ret, specificReturn := fake.hidePipelineReturnsOnCall[len(fake.hidePipelineArgsForCall)]
@@ -2620,6 +3601,74 @@ func (fake *FakeTeam) JobBuildsReturnsOnCall(i int, result1 []atc.Build, result2
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) JobSchedulerDecision(arg1 atc.PipelineRef, arg2 string) (atc.SchedulerDecision, bool, error) {
+	fake.jobSchedulerDecisionMutex.Lock()
+	ret, specificReturn := fake.jobSchedulerDecisionReturnsOnCall[len(fake.jobSchedulerDecisionArgsForCall)]
+	fake.jobSchedulerDecisionArgsForCall = append(fake.jobSchedulerDecisionArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.JobSchedulerDecisionStub
+	fakeReturns := fake.jobSchedulerDecisionReturns
+	fake.recordInvocation("JobSchedulerDecision", []interface{}{arg1, arg2})
+	fake.jobSchedulerDecisionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) JobSchedulerDecisionCallCount() int {
+	fake.jobSchedulerDecisionMutex.RLock()
+	defer fake.jobSchedulerDecisionMutex.RUnlock()
+	return len(fake.jobSchedulerDecisionArgsForCall)
+}
+
+func (fake *FakeTeam) JobSchedulerDecisionCalls(stub func(atc.PipelineRef, string) (atc.SchedulerDecision, bool, error)) {
+	fake.jobSchedulerDecisionMutex.Lock()
+	defer fake.jobSchedulerDecisionMutex.Unlock()
+	fake.JobSchedulerDecisionStub = stub
+}
+
+func (fake *FakeTeam) JobSchedulerDecisionArgsForCall(i int) (atc.PipelineRef, string) {
+	fake.jobSchedulerDecisionMutex.RLock()
+	defer fake.jobSchedulerDecisionMutex.RUnlock()
+	argsForCall := fake.jobSchedulerDecisionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) JobSchedulerDecisionReturns(result1 atc.SchedulerDecision, result2 bool, result3 error) {
+	fake.jobSchedulerDecisionMutex.Lock()
+	defer fake.jobSchedulerDecisionMutex.Unlock()
+	fake.JobSchedulerDecisionStub = nil
+	fake.jobSchedulerDecisionReturns = struct {
+		result1 atc.SchedulerDecision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) JobSchedulerDecisionReturnsOnCall(i int, result1 atc.SchedulerDecision, result2 bool, result3 error) {
+	fake.jobSchedulerDecisionMutex.Lock()
+	defer fake.jobSchedulerDecisionMutex.Unlock()
+	fake.JobSchedulerDecisionStub = nil
+	if fake.jobSchedulerDecisionReturnsOnCall == nil {
+		fake.jobSchedulerDecisionReturnsOnCall = make(map[int]struct {
+			result1 atc.SchedulerDecision
+			result2 bool
+			result3 error
+		})
+	}
+	fake.jobSchedulerDecisionReturnsOnCall[i] = struct {
+		result1 atc.SchedulerDecision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) ListContainers(arg1 map[string]string) ([]atc.Container, error) {
 	fake.listContainersMutex.Lock()
 	ret, specificReturn := fake.listContainersReturnsOnCall[len(fake.listContainersArgsForCall)]
@@ -2748,6 +3797,126 @@ func (fake *FakeTeam) ListJobsReturnsOnCall(i int, result1 []atc.Job, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) ListPendingChecks(arg1 atc.PipelineRef) ([]atc.PendingCheck, error) {
+	fake.listPendingChecksMutex.Lock()
+	ret, specificReturn := fake.listPendingChecksReturnsOnCall[len(fake.listPendingChecksArgsForCall)]
+	fake.listPendingChecksArgsForCall = append(fake.listPendingChecksArgsForCall, struct {
+		arg1 atc.PipelineRef
+	}{arg1})
+	stub := fake.ListPendingChecksStub
+	fakeReturns := fake.listPendingChecksReturns
+	fake.recordInvocation("ListPendingChecks", []interface{}{arg1})
+	fake.listPendingChecksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ListPendingChecksCallCount() int {
+	fake.listPendingChecksMutex.RLock()
+	defer fake.listPendingChecksMutex.RUnlock()
+	return len(fake.listPendingChecksArgsForCall)
+}
+
+func (fake *FakeTeam) ListPendingChecksCalls(stub func(atc.PipelineRef) ([]atc.PendingCheck, error)) {
+	fake.listPendingChecksMutex.Lock()
+	defer fake.listPendingChecksMutex.Unlock()
+	fake.ListPendingChecksStub = stub
+}
+
+func (fake *FakeTeam) ListPendingChecksArgsForCall(i int) atc.PipelineRef {
+	fake.listPendingChecksMutex.RLock()
+	defer fake.listPendingChecksMutex.RUnlock()
+	argsForCall := fake.listPendingChecksArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) ListPendingChecksReturns(result1 []atc.PendingCheck, result2 error) {
+	fake.listPendingChecksMutex.Lock()
+	defer fake.listPendingChecksMutex.Unlock()
+	fake.ListPendingChecksStub = nil
+	fake.listPendingChecksReturns = struct {
+		result1 []atc.PendingCheck
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ListPendingChecksReturnsOnCall(i int, result1 []atc.PendingCheck, result2 error) {
+	fake.listPendingChecksMutex.Lock()
+	defer fake.listPendingChecksMutex.Unlock()
+	fake.ListPendingChecksStub = nil
+	if fake.listPendingChecksReturnsOnCall == nil {
+		fake.listPendingChecksReturnsOnCall = make(map[int]struct {
+			result1 []atc.PendingCheck
+			result2 error
+		})
+	}
+	fake.listPendingChecksReturnsOnCall[i] = struct {
+		result1 []atc.PendingCheck
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ListPipelineVars() ([]atc.PipelineVars, error) {
+	fake.listPipelineVarsMutex.Lock()
+	ret, specificReturn := fake.listPipelineVarsReturnsOnCall[len(fake.listPipelineVarsArgsForCall)]
+	fake.listPipelineVarsArgsForCall = append(fake.listPipelineVarsArgsForCall, struct {
+	}{})
+	stub := fake.ListPipelineVarsStub
+	fakeReturns := fake.listPipelineVarsReturns
+	fake.recordInvocation("ListPipelineVars", []interface{}{})
+	fake.listPipelineVarsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ListPipelineVarsCallCount() int {
+	fake.listPipelineVarsMutex.RLock()
+	defer fake.listPipelineVarsMutex.RUnlock()
+	return len(fake.listPipelineVarsArgsForCall)
+}
+
+func (fake *FakeTeam) ListPipelineVarsCalls(stub func() ([]atc.PipelineVars, error)) {
+	fake.listPipelineVarsMutex.Lock()
+	defer fake.listPipelineVarsMutex.Unlock()
+	fake.ListPipelineVarsStub = stub
+}
+
+func (fake *FakeTeam) ListPipelineVarsReturns(result1 []atc.PipelineVars, result2 error) {
+	fake.listPipelineVarsMutex.Lock()
+	defer fake.listPipelineVarsMutex.Unlock()
+	fake.ListPipelineVarsStub = nil
+	fake.listPipelineVarsReturns = struct {
+		result1 []atc.PipelineVars
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ListPipelineVarsReturnsOnCall(i int, result1 []atc.PipelineVars, result2 error) {
+	fake.listPipelineVarsMutex.Lock()
+	defer fake.listPipelineVarsMutex.Unlock()
+	fake.ListPipelineVarsStub = nil
+	if fake.listPipelineVarsReturnsOnCall == nil {
+		fake.listPipelineVarsReturnsOnCall = make(map[int]struct {
+			result1 []atc.PipelineVars
+			result2 error
+		})
+	}
+	fake.listPipelineVarsReturnsOnCall[i] = struct {
+		result1 []atc.PipelineVars
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) ListPipelines() ([]atc.Pipeline, error) {
 	fake.listPipelinesMutex.Lock()
 	ret, specificReturn := fake.listPipelinesReturnsOnCall[len(fake.listPipelinesArgsForCall)]
@@ -2804,6 +3973,142 @@ func (fake *FakeTeam) ListPipelinesReturnsOnCall(i int, result1 []atc.Pipeline,
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) ListResourceCheckRecords(arg1 atc.PipelineRef, arg2 string, arg3 concourse.Page) ([]atc.CheckRecord, concourse.Pagination, bool, error) {
+	fake.listResourceCheckRecordsMutex.Lock()
+	ret, specificReturn := fake.listResourceCheckRecordsReturnsOnCall[len(fake.listResourceCheckRecordsArgsForCall)]
+	fake.listResourceCheckRecordsArgsForCall = append(fake.listResourceCheckRecordsArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 concourse.Page
+	}{arg1, arg2, arg3})
+	stub := fake.ListResourceCheckRecordsStub
+	fakeReturns := fake.listResourceCheckRecordsReturns
+	fake.recordInvocation("ListResourceCheckRecords", []interface{}{arg1, arg2, arg3})
+	fake.listResourceCheckRecordsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3, ret.result4
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3, fakeReturns.result4
+}
+
+func (fake *FakeTeam) ListResourceCheckRecordsCallCount() int {
+	fake.listResourceCheckRecordsMutex.RLock()
+	defer fake.listResourceCheckRecordsMutex.RUnlock()
+	return len(fake.listResourceCheckRecordsArgsForCall)
+}
+
+func (fake *FakeTeam) ListResourceCheckRecordsCalls(stub func(atc.PipelineRef, string, concourse.Page) ([]atc.CheckRecord, concourse.Pagination, bool, error)) {
+	fake.listResourceCheckRecordsMutex.Lock()
+	defer fake.listResourceCheckRecordsMutex.Unlock()
+	fake.ListResourceCheckRecordsStub = stub
+}
+
+func (fake *FakeTeam) ListResourceCheckRecordsArgsForCall(i int) (atc.PipelineRef, string, concourse.Page) {
+	fake.listResourceCheckRecordsMutex.RLock()
+	defer fake.listResourceCheckRecordsMutex.RUnlock()
+	argsForCall := fake.listResourceCheckRecordsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) ListResourceCheckRecordsReturns(result1 []atc.CheckRecord, result2 concourse.Pagination, result3 bool, result4 error) {
+	fake.listResourceCheckRecordsMutex.Lock()
+	defer fake.listResourceCheckRecordsMutex.Unlock()
+	fake.ListResourceCheckRecordsStub = nil
+	fake.listResourceCheckRecordsReturns = struct {
+		result1 []atc.CheckRecord
+		result2 concourse.Pagination
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeTeam) ListResourceCheckRecordsReturnsOnCall(i int, result1 []atc.CheckRecord, result2 concourse.Pagination, result3 bool, result4 error) {
+	fake.listResourceCheckRecordsMutex.Lock()
+	defer fake.listResourceCheckRecordsMutex.Unlock()
+	fake.ListResourceCheckRecordsStub = nil
+	if fake.listResourceCheckRecordsReturnsOnCall == nil {
+		fake.listResourceCheckRecordsReturnsOnCall = make(map[int]struct {
+			result1 []atc.CheckRecord
+			result2 concourse.Pagination
+			result3 bool
+			result4 error
+		})
+	}
+	fake.listResourceCheckRecordsReturnsOnCall[i] = struct {
+		result1 []atc.CheckRecord
+		result2 concourse.Pagination
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeTeam) ListResourceChecks(arg1 atc.PipelineRef) ([]atc.ResourceCheckStatus, error) {
+	fake.listResourceChecksMutex.Lock()
+	ret, specificReturn := fake.listResourceChecksReturnsOnCall[len(fake.listResourceChecksArgsForCall)]
+	fake.listResourceChecksArgsForCall = append(fake.listResourceChecksArgsForCall, struct {
+		arg1 atc.PipelineRef
+	}{arg1})
+	stub := fake.ListResourceChecksStub
+	fakeReturns := fake.listResourceChecksReturns
+	fake.recordInvocation("ListResourceChecks", []interface{}{arg1})
+	fake.listResourceChecksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ListResourceChecksCallCount() int {
+	fake.listResourceChecksMutex.RLock()
+	defer fake.listResourceChecksMutex.RUnlock()
+	return len(fake.listResourceChecksArgsForCall)
+}
+
+func (fake *FakeTeam) ListResourceChecksCalls(stub func(atc.PipelineRef) ([]atc.ResourceCheckStatus, error)) {
+	fake.listResourceChecksMutex.Lock()
+	defer fake.listResourceChecksMutex.Unlock()
+	fake.ListResourceChecksStub = stub
+}
+
+func (fake *FakeTeam) ListResourceChecksArgsForCall(i int) atc.PipelineRef {
+	fake.listResourceChecksMutex.RLock()
+	defer fake.listResourceChecksMutex.RUnlock()
+	argsForCall := fake.listResourceChecksArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) ListResourceChecksReturns(result1 []atc.ResourceCheckStatus, result2 error) {
+	fake.listResourceChecksMutex.Lock()
+	defer fake.listResourceChecksMutex.Unlock()
+	fake.ListResourceChecksStub = nil
+	fake.listResourceChecksReturns = struct {
+		result1 []atc.ResourceCheckStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ListResourceChecksReturnsOnCall(i int, result1 []atc.ResourceCheckStatus, result2 error) {
+	fake.listResourceChecksMutex.Lock()
+	defer fake.listResourceChecksMutex.Unlock()
+	fake.ListResourceChecksStub = nil
+	if fake.listResourceChecksReturnsOnCall == nil {
+		fake.listResourceChecksReturnsOnCall = make(map[int]struct {
+			result1 []atc.ResourceCheckStatus
+			result2 error
+		})
+	}
+	fake.listResourceChecksReturnsOnCall[i] = struct {
+		result1 []atc.ResourceCheckStatus
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) ListResources(arg1 atc.PipelineRef) ([]atc.Resource, error) {
 	fake.listResourcesMutex.Lock()
 	ret, specificReturn := fake.listResourcesReturnsOnCall[len(fake.listResourcesArgsForCall)]
@@ -2868,17 +4173,18 @@ func (fake *FakeTeam) ListResourcesReturnsOnCall(i int, result1 []atc.Resource,
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) ListVolumes() ([]atc.Volume, error) {
-	fake.listVolumesMutex.Lock()
-	ret, specificReturn := fake.listVolumesReturnsOnCall[len(fake.listVolumesArgsForCall)]
-	fake.listVolumesArgsForCall = append(fake.listVolumesArgsForCall, struct {
-	}{})
-	stub := fake.ListVolumesStub
-	fakeReturns := fake.listVolumesReturns
-	fake.recordInvocation("ListVolumes", []interface{}{})
-	fake.listVolumesMutex.Unlock()
+func (fake *FakeTeam) ListSerialGroups(arg1 atc.PipelineRef) ([]atc.SerialGroup, error) {
+	fake.listSerialGroupsMutex.Lock()
+	ret, specificReturn := fake.listSerialGroupsReturnsOnCall[len(fake.listSerialGroupsArgsForCall)]
+	fake.listSerialGroupsArgsForCall = append(fake.listSerialGroupsArgsForCall, struct {
+		arg1 atc.PipelineRef
+	}{arg1})
+	stub := fake.ListSerialGroupsStub
+	fakeReturns := fake.listSerialGroupsReturns
+	fake.recordInvocation("ListSerialGroups", []interface{}{arg1})
+	fake.listSerialGroupsMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -2886,85 +4192,271 @@ func (fake *FakeTeam) ListVolumes() ([]atc.Volume, error) {
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeTeam) ListVolumesCallCount() int {
-	fake.listVolumesMutex.RLock()
-	defer fake.listVolumesMutex.RUnlock()
-	return len(fake.listVolumesArgsForCall)
+func (fake *FakeTeam) ListSerialGroupsCallCount() int {
+	fake.listSerialGroupsMutex.RLock()
+	defer fake.listSerialGroupsMutex.RUnlock()
+	return len(fake.listSerialGroupsArgsForCall)
 }
 
-func (fake *FakeTeam) ListVolumesCalls(stub func() ([]atc.Volume, error)) {
-	fake.listVolumesMutex.Lock()
-	defer fake.listVolumesMutex.Unlock()
-	fake.ListVolumesStub = stub
+func (fake *FakeTeam) ListSerialGroupsCalls(stub func(atc.PipelineRef) ([]atc.SerialGroup, error)) {
+	fake.listSerialGroupsMutex.Lock()
+	defer fake.listSerialGroupsMutex.Unlock()
+	fake.ListSerialGroupsStub = stub
 }
 
-func (fake *FakeTeam) ListVolumesReturns(result1 []atc.Volume, result2 error) {
-	fake.listVolumesMutex.Lock()
-	defer fake.listVolumesMutex.Unlock()
-	fake.ListVolumesStub = nil
-	fake.listVolumesReturns = struct {
-		result1 []atc.Volume
+func (fake *FakeTeam) ListSerialGroupsArgsForCall(i int) atc.PipelineRef {
+	fake.listSerialGroupsMutex.RLock()
+	defer fake.listSerialGroupsMutex.RUnlock()
+	argsForCall := fake.listSerialGroupsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) ListSerialGroupsReturns(result1 []atc.SerialGroup, result2 error) {
+	fake.listSerialGroupsMutex.Lock()
+	defer fake.listSerialGroupsMutex.Unlock()
+	fake.ListSerialGroupsStub = nil
+	fake.listSerialGroupsReturns = struct {
+		result1 []atc.SerialGroup
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) ListVolumesReturnsOnCall(i int, result1 []atc.Volume, result2 error) {
-	fake.listVolumesMutex.Lock()
-	defer fake.listVolumesMutex.Unlock()
-	fake.ListVolumesStub = nil
-	if fake.listVolumesReturnsOnCall == nil {
-		fake.listVolumesReturnsOnCall = make(map[int]struct {
-			result1 []atc.Volume
+func (fake *FakeTeam) ListSerialGroupsReturnsOnCall(i int, result1 []atc.SerialGroup, result2 error) {
+	fake.listSerialGroupsMutex.Lock()
+	defer fake.listSerialGroupsMutex.Unlock()
+	fake.ListSerialGroupsStub = nil
+	if fake.listSerialGroupsReturnsOnCall == nil {
+		fake.listSerialGroupsReturnsOnCall = make(map[int]struct {
+			result1 []atc.SerialGroup
 			result2 error
 		})
 	}
-	fake.listVolumesReturnsOnCall[i] = struct {
-		result1 []atc.Volume
+	fake.listSerialGroupsReturnsOnCall[i] = struct {
+		result1 []atc.SerialGroup
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) Name() string {
-	fake.nameMutex.Lock()
-	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
-	fake.nameArgsForCall = append(fake.nameArgsForCall, struct {
+func (fake *FakeTeam) ListSharedArtifacts() ([]atc.SharedArtifact, error) {
+	fake.listSharedArtifactsMutex.Lock()
+	ret, specificReturn := fake.listSharedArtifactsReturnsOnCall[len(fake.listSharedArtifactsArgsForCall)]
+	fake.listSharedArtifactsArgsForCall = append(fake.listSharedArtifactsArgsForCall, struct {
 	}{})
-	stub := fake.NameStub
-	fakeReturns := fake.nameReturns
-	fake.recordInvocation("Name", []interface{}{})
-	fake.nameMutex.Unlock()
+	stub := fake.ListSharedArtifactsStub
+	fakeReturns := fake.listSharedArtifactsReturns
+	fake.recordInvocation("ListSharedArtifacts", []interface{}{})
+	fake.listSharedArtifactsMutex.Unlock()
 	if stub != nil {
 		return stub()
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeTeam) NameCallCount() int {
-	fake.nameMutex.RLock()
-	defer fake.nameMutex.RUnlock()
-	return len(fake.nameArgsForCall)
+func (fake *FakeTeam) ListSharedArtifactsCallCount() int {
+	fake.listSharedArtifactsMutex.RLock()
+	defer fake.listSharedArtifactsMutex.RUnlock()
+	return len(fake.listSharedArtifactsArgsForCall)
 }
 
-func (fake *FakeTeam) NameCalls(stub func() string) {
-	fake.nameMutex.Lock()
-	defer fake.nameMutex.Unlock()
-	fake.NameStub = stub
+func (fake *FakeTeam) ListSharedArtifactsCalls(stub func() ([]atc.SharedArtifact, error)) {
+	fake.listSharedArtifactsMutex.Lock()
+	defer fake.listSharedArtifactsMutex.Unlock()
+	fake.ListSharedArtifactsStub = stub
 }
 
-func (fake *FakeTeam) NameReturns(result1 string) {
-	fake.nameMutex.Lock()
-	defer fake.nameMutex.Unlock()
-	fake.NameStub = nil
-	fake.nameReturns = struct {
-		result1 string
-	}{result1}
+func (fake *FakeTeam) ListSharedArtifactsReturns(result1 []atc.SharedArtifact, result2 error) {
+	fake.listSharedArtifactsMutex.Lock()
+	defer fake.listSharedArtifactsMutex.Unlock()
+	fake.ListSharedArtifactsStub = nil
+	fake.listSharedArtifactsReturns = struct {
+		result1 []atc.SharedArtifact
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeTeam) NameReturnsOnCall(i int, result1 string) {
-	fake.nameMutex.Lock()
+func (fake *FakeTeam) ListSharedArtifactsReturnsOnCall(i int, result1 []atc.SharedArtifact, result2 error) {
+	fake.listSharedArtifactsMutex.Lock()
+	defer fake.listSharedArtifactsMutex.Unlock()
+	fake.ListSharedArtifactsStub = nil
+	if fake.listSharedArtifactsReturnsOnCall == nil {
+		fake.listSharedArtifactsReturnsOnCall = make(map[int]struct {
+			result1 []atc.SharedArtifact
+			result2 error
+		})
+	}
+	fake.listSharedArtifactsReturnsOnCall[i] = struct {
+		result1 []atc.SharedArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ListVersionSets(arg1 atc.PipelineRef) ([]atc.VersionSet, bool, error) {
+	fake.listVersionSetsMutex.Lock()
+	ret, specificReturn := fake.listVersionSetsReturnsOnCall[len(fake.listVersionSetsArgsForCall)]
+	fake.listVersionSetsArgsForCall = append(fake.listVersionSetsArgsForCall, struct {
+		arg1 atc.PipelineRef
+	}{arg1})
+	stub := fake.ListVersionSetsStub
+	fakeReturns := fake.listVersionSetsReturns
+	fake.recordInvocation("ListVersionSets", []interface{}{arg1})
+	fake.listVersionSetsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) ListVersionSetsCallCount() int {
+	fake.listVersionSetsMutex.RLock()
+	defer fake.listVersionSetsMutex.RUnlock()
+	return len(fake.listVersionSetsArgsForCall)
+}
+
+func (fake *FakeTeam) ListVersionSetsCalls(stub func(atc.PipelineRef) ([]atc.VersionSet, bool, error)) {
+	fake.listVersionSetsMutex.Lock()
+	defer fake.listVersionSetsMutex.Unlock()
+	fake.ListVersionSetsStub = stub
+}
+
+func (fake *FakeTeam) ListVersionSetsArgsForCall(i int) atc.PipelineRef {
+	fake.listVersionSetsMutex.RLock()
+	defer fake.listVersionSetsMutex.RUnlock()
+	argsForCall := fake.listVersionSetsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) ListVersionSetsReturns(result1 []atc.VersionSet, result2 bool, result3 error) {
+	fake.listVersionSetsMutex.Lock()
+	defer fake.listVersionSetsMutex.Unlock()
+	fake.ListVersionSetsStub = nil
+	fake.listVersionSetsReturns = struct {
+		result1 []atc.VersionSet
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) ListVersionSetsReturnsOnCall(i int, result1 []atc.VersionSet, result2 bool, result3 error) {
+	fake.listVersionSetsMutex.Lock()
+	defer fake.listVersionSetsMutex.Unlock()
+	fake.ListVersionSetsStub = nil
+	if fake.listVersionSetsReturnsOnCall == nil {
+		fake.listVersionSetsReturnsOnCall = make(map[int]struct {
+			result1 []atc.VersionSet
+			result2 bool
+			result3 error
+		})
+	}
+	fake.listVersionSetsReturnsOnCall[i] = struct {
+		result1 []atc.VersionSet
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) ListVolumes() ([]atc.Volume, error) {
+	fake.listVolumesMutex.Lock()
+	ret, specificReturn := fake.listVolumesReturnsOnCall[len(fake.listVolumesArgsForCall)]
+	fake.listVolumesArgsForCall = append(fake.listVolumesArgsForCall, struct {
+	}{})
+	stub := fake.ListVolumesStub
+	fakeReturns := fake.listVolumesReturns
+	fake.recordInvocation("ListVolumes", []interface{}{})
+	fake.listVolumesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ListVolumesCallCount() int {
+	fake.listVolumesMutex.RLock()
+	defer fake.listVolumesMutex.RUnlock()
+	return len(fake.listVolumesArgsForCall)
+}
+
+func (fake *FakeTeam) ListVolumesCalls(stub func() ([]atc.Volume, error)) {
+	fake.listVolumesMutex.Lock()
+	defer fake.listVolumesMutex.Unlock()
+	fake.ListVolumesStub = stub
+}
+
+func (fake *FakeTeam) ListVolumesReturns(result1 []atc.Volume, result2 error) {
+	fake.listVolumesMutex.Lock()
+	defer fake.listVolumesMutex.Unlock()
+	fake.ListVolumesStub = nil
+	fake.listVolumesReturns = struct {
+		result1 []atc.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ListVolumesReturnsOnCall(i int, result1 []atc.Volume, result2 error) {
+	fake.listVolumesMutex.Lock()
+	defer fake.listVolumesMutex.Unlock()
+	fake.ListVolumesStub = nil
+	if fake.listVolumesReturnsOnCall == nil {
+		fake.listVolumesReturnsOnCall = make(map[int]struct {
+			result1 []atc.Volume
+			result2 error
+		})
+	}
+	fake.listVolumesReturnsOnCall[i] = struct {
+		result1 []atc.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) Name() string {
+	fake.nameMutex.Lock()
+	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
+	fake.nameArgsForCall = append(fake.nameArgsForCall, struct {
+	}{})
+	stub := fake.NameStub
+	fakeReturns := fake.nameReturns
+	fake.recordInvocation("Name", []interface{}{})
+	fake.nameMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) NameCallCount() int {
+	fake.nameMutex.RLock()
+	defer fake.nameMutex.RUnlock()
+	return len(fake.nameArgsForCall)
+}
+
+func (fake *FakeTeam) NameCalls(stub func() string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = stub
+}
+
+func (fake *FakeTeam) NameReturns(result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	fake.nameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeTeam) NameReturnsOnCall(i int, result1 string) {
+	fake.nameMutex.Lock()
 	defer fake.nameMutex.Unlock()
 	fake.NameStub = nil
 	if fake.nameReturnsOnCall == nil {
@@ -3110,6 +4602,83 @@ func (fake *FakeTeam) OrderingPipelinesWithinGroupReturnsOnCall(i int, result1 e
 	}{result1}
 }
 
+func (fake *FakeTeam) PatchPipelineConfig(arg1 atc.PipelineRef, arg2 string, arg3 []byte) (string, []concourse.ConfigWarning, bool, error) {
+	var arg3Copy []byte
+	if arg3 != nil {
+		arg3Copy = make([]byte, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.patchPipelineConfigMutex.Lock()
+	ret, specificReturn := fake.patchPipelineConfigReturnsOnCall[len(fake.patchPipelineConfigArgsForCall)]
+	fake.patchPipelineConfigArgsForCall = append(fake.patchPipelineConfigArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 []byte
+	}{arg1, arg2, arg3Copy})
+	stub := fake.PatchPipelineConfigStub
+	fakeReturns := fake.patchPipelineConfigReturns
+	fake.recordInvocation("PatchPipelineConfig", []interface{}{arg1, arg2, arg3Copy})
+	fake.patchPipelineConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3, ret.result4
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3, fakeReturns.result4
+}
+
+func (fake *FakeTeam) PatchPipelineConfigCallCount() int {
+	fake.patchPipelineConfigMutex.RLock()
+	defer fake.patchPipelineConfigMutex.RUnlock()
+	return len(fake.patchPipelineConfigArgsForCall)
+}
+
+func (fake *FakeTeam) PatchPipelineConfigCalls(stub func(atc.PipelineRef, string, []byte) (string, []concourse.ConfigWarning, bool, error)) {
+	fake.patchPipelineConfigMutex.Lock()
+	defer fake.patchPipelineConfigMutex.Unlock()
+	fake.PatchPipelineConfigStub = stub
+}
+
+func (fake *FakeTeam) PatchPipelineConfigArgsForCall(i int) (atc.PipelineRef, string, []byte) {
+	fake.patchPipelineConfigMutex.RLock()
+	defer fake.patchPipelineConfigMutex.RUnlock()
+	argsForCall := fake.patchPipelineConfigArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) PatchPipelineConfigReturns(result1 string, result2 []concourse.ConfigWarning, result3 bool, result4 error) {
+	fake.patchPipelineConfigMutex.Lock()
+	defer fake.patchPipelineConfigMutex.Unlock()
+	fake.PatchPipelineConfigStub = nil
+	fake.patchPipelineConfigReturns = struct {
+		result1 string
+		result2 []concourse.ConfigWarning
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeTeam) PatchPipelineConfigReturnsOnCall(i int, result1 string, result2 []concourse.ConfigWarning, result3 bool, result4 error) {
+	fake.patchPipelineConfigMutex.Lock()
+	defer fake.patchPipelineConfigMutex.Unlock()
+	fake.PatchPipelineConfigStub = nil
+	if fake.patchPipelineConfigReturnsOnCall == nil {
+		fake.patchPipelineConfigReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 []concourse.ConfigWarning
+			result3 bool
+			result4 error
+		})
+	}
+	fake.patchPipelineConfigReturnsOnCall[i] = struct {
+		result1 string
+		result2 []concourse.ConfigWarning
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
 func (fake *FakeTeam) PauseJob(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.pauseJobMutex.Lock()
 	ret, specificReturn := fake.pauseJobReturnsOnCall[len(fake.pauseJobArgsForCall)]
@@ -3239,20 +4808,86 @@ func (fake *FakeTeam) PausePipelineReturnsOnCall(i int, result1 bool, result2 er
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) PinResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int) (bool, error) {
+func (fake *FakeTeam) PauseResourceChecking(arg1 atc.PipelineRef, arg2 string) (bool, error) {
+	fake.pauseResourceCheckingMutex.Lock()
+	ret, specificReturn := fake.pauseResourceCheckingReturnsOnCall[len(fake.pauseResourceCheckingArgsForCall)]
+	fake.pauseResourceCheckingArgsForCall = append(fake.pauseResourceCheckingArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.PauseResourceCheckingStub
+	fakeReturns := fake.pauseResourceCheckingReturns
+	fake.recordInvocation("PauseResourceChecking", []interface{}{arg1, arg2})
+	fake.pauseResourceCheckingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) PauseResourceCheckingCallCount() int {
+	fake.pauseResourceCheckingMutex.RLock()
+	defer fake.pauseResourceCheckingMutex.RUnlock()
+	return len(fake.pauseResourceCheckingArgsForCall)
+}
+
+func (fake *FakeTeam) PauseResourceCheckingCalls(stub func(atc.PipelineRef, string) (bool, error)) {
+	fake.pauseResourceCheckingMutex.Lock()
+	defer fake.pauseResourceCheckingMutex.Unlock()
+	fake.PauseResourceCheckingStub = stub
+}
+
+func (fake *FakeTeam) PauseResourceCheckingArgsForCall(i int) (atc.PipelineRef, string) {
+	fake.pauseResourceCheckingMutex.RLock()
+	defer fake.pauseResourceCheckingMutex.RUnlock()
+	argsForCall := fake.pauseResourceCheckingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) PauseResourceCheckingReturns(result1 bool, result2 error) {
+	fake.pauseResourceCheckingMutex.Lock()
+	defer fake.pauseResourceCheckingMutex.Unlock()
+	fake.PauseResourceCheckingStub = nil
+	fake.pauseResourceCheckingReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PauseResourceCheckingReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.pauseResourceCheckingMutex.Lock()
+	defer fake.pauseResourceCheckingMutex.Unlock()
+	fake.PauseResourceCheckingStub = nil
+	if fake.pauseResourceCheckingReturnsOnCall == nil {
+		fake.pauseResourceCheckingReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.pauseResourceCheckingReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PinResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int, arg4 string) (bool, error) {
 	fake.pinResourceVersionMutex.Lock()
 	ret, specificReturn := fake.pinResourceVersionReturnsOnCall[len(fake.pinResourceVersionArgsForCall)]
 	fake.pinResourceVersionArgsForCall = append(fake.pinResourceVersionArgsForCall, struct {
 		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
-	}{arg1, arg2, arg3})
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.PinResourceVersionStub
 	fakeReturns := fake.pinResourceVersionReturns
-	fake.recordInvocation("PinResourceVersion", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("PinResourceVersion", []interface{}{arg1, arg2, arg3, arg4})
 	fake.pinResourceVersionMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -3266,17 +4901,17 @@ func (fake *FakeTeam) PinResourceVersionCallCount() int {
 	return len(fake.pinResourceVersionArgsForCall)
 }
 
-func (fake *FakeTeam) PinResourceVersionCalls(stub func(atc.PipelineRef, string, int) (bool, error)) {
+func (fake *FakeTeam) PinResourceVersionCalls(stub func(atc.PipelineRef, string, int, string) (bool, error)) {
 	fake.pinResourceVersionMutex.Lock()
 	defer fake.pinResourceVersionMutex.Unlock()
 	fake.PinResourceVersionStub = stub
 }
 
-func (fake *FakeTeam) PinResourceVersionArgsForCall(i int) (atc.PipelineRef, string, int) {
+func (fake *FakeTeam) PinResourceVersionArgsForCall(i int) (atc.PipelineRef, string, int, string) {
 	fake.pinResourceVersionMutex.RLock()
 	defer fake.pinResourceVersionMutex.RUnlock()
 	argsForCall := fake.pinResourceVersionArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeTeam) PinResourceVersionReturns(result1 bool, result2 error) {
@@ -3513,6 +5148,72 @@ func (fake *FakeTeam) PipelineConfigReturnsOnCall(i int, result1 atc.Config, res
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) PromoteResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int) (bool, error) {
+	fake.promoteResourceVersionMutex.Lock()
+	ret, specificReturn := fake.promoteResourceVersionReturnsOnCall[len(fake.promoteResourceVersionArgsForCall)]
+	fake.promoteResourceVersionArgsForCall = append(fake.promoteResourceVersionArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.PromoteResourceVersionStub
+	fakeReturns := fake.promoteResourceVersionReturns
+	fake.recordInvocation("PromoteResourceVersion", []interface{}{arg1, arg2, arg3})
+	fake.promoteResourceVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) PromoteResourceVersionCallCount() int {
+	fake.promoteResourceVersionMutex.RLock()
+	defer fake.promoteResourceVersionMutex.RUnlock()
+	return len(fake.promoteResourceVersionArgsForCall)
+}
+
+func (fake *FakeTeam) PromoteResourceVersionCalls(stub func(atc.PipelineRef, string, int) (bool, error)) {
+	fake.promoteResourceVersionMutex.Lock()
+	defer fake.promoteResourceVersionMutex.Unlock()
+	fake.PromoteResourceVersionStub = stub
+}
+
+func (fake *FakeTeam) PromoteResourceVersionArgsForCall(i int) (atc.PipelineRef, string, int) {
+	fake.promoteResourceVersionMutex.RLock()
+	defer fake.promoteResourceVersionMutex.RUnlock()
+	argsForCall := fake.promoteResourceVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) PromoteResourceVersionReturns(result1 bool, result2 error) {
+	fake.promoteResourceVersionMutex.Lock()
+	defer fake.promoteResourceVersionMutex.Unlock()
+	fake.PromoteResourceVersionStub = nil
+	fake.promoteResourceVersionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PromoteResourceVersionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.promoteResourceVersionMutex.Lock()
+	defer fake.promoteResourceVersionMutex.Unlock()
+	fake.PromoteResourceVersionStub = nil
+	if fake.promoteResourceVersionReturnsOnCall == nil {
+		fake.promoteResourceVersionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.promoteResourceVersionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) RenamePipeline(arg1 string, arg2 string) (bool, []concourse.ConfigWarning, error) {
 	fake.renamePipelineMutex.Lock()
 	ret, specificReturn := fake.renamePipelineReturnsOnCall[len(fake.renamePipelineArgsForCall)]
@@ -3715,7 +5416,73 @@ func (fake *FakeTeam) RerunJobBuildReturnsOnCall(i int, result1 atc.Build, resul
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) Resource(arg1 atc.PipelineRef, arg2 string) (atc.Resource, bool, error) {
+func (fake *FakeTeam) RerunJobBuildWithLatestIfUnavailable(arg1 atc.PipelineRef, arg2 string, arg3 string) (atc.Build, error) {
+	fake.rerunJobBuildWithLatestIfUnavailableMutex.Lock()
+	ret, specificReturn := fake.rerunJobBuildWithLatestIfUnavailableReturnsOnCall[len(fake.rerunJobBuildWithLatestIfUnavailableArgsForCall)]
+	fake.rerunJobBuildWithLatestIfUnavailableArgsForCall = append(fake.rerunJobBuildWithLatestIfUnavailableArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.RerunJobBuildWithLatestIfUnavailableStub
+	fakeReturns := fake.rerunJobBuildWithLatestIfUnavailableReturns
+	fake.recordInvocation("RerunJobBuildWithLatestIfUnavailable", []interface{}{arg1, arg2, arg3})
+	fake.rerunJobBuildWithLatestIfUnavailableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) RerunJobBuildWithLatestIfUnavailableCallCount() int {
+	fake.rerunJobBuildWithLatestIfUnavailableMutex.RLock()
+	defer fake.rerunJobBuildWithLatestIfUnavailableMutex.RUnlock()
+	return len(fake.rerunJobBuildWithLatestIfUnavailableArgsForCall)
+}
+
+func (fake *FakeTeam) RerunJobBuildWithLatestIfUnavailableCalls(stub func(atc.PipelineRef, string, string) (atc.Build, error)) {
+	fake.rerunJobBuildWithLatestIfUnavailableMutex.Lock()
+	defer fake.rerunJobBuildWithLatestIfUnavailableMutex.Unlock()
+	fake.RerunJobBuildWithLatestIfUnavailableStub = stub
+}
+
+func (fake *FakeTeam) RerunJobBuildWithLatestIfUnavailableArgsForCall(i int) (atc.PipelineRef, string, string) {
+	fake.rerunJobBuildWithLatestIfUnavailableMutex.RLock()
+	defer fake.rerunJobBuildWithLatestIfUnavailableMutex.RUnlock()
+	argsForCall := fake.rerunJobBuildWithLatestIfUnavailableArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) RerunJobBuildWithLatestIfUnavailableReturns(result1 atc.Build, result2 error) {
+	fake.rerunJobBuildWithLatestIfUnavailableMutex.Lock()
+	defer fake.rerunJobBuildWithLatestIfUnavailableMutex.Unlock()
+	fake.RerunJobBuildWithLatestIfUnavailableStub = nil
+	fake.rerunJobBuildWithLatestIfUnavailableReturns = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) RerunJobBuildWithLatestIfUnavailableReturnsOnCall(i int, result1 atc.Build, result2 error) {
+	fake.rerunJobBuildWithLatestIfUnavailableMutex.Lock()
+	defer fake.rerunJobBuildWithLatestIfUnavailableMutex.Unlock()
+	fake.RerunJobBuildWithLatestIfUnavailableStub = nil
+	if fake.rerunJobBuildWithLatestIfUnavailableReturnsOnCall == nil {
+		fake.rerunJobBuildWithLatestIfUnavailableReturnsOnCall = make(map[int]struct {
+			result1 atc.Build
+			result2 error
+		})
+	}
+	fake.rerunJobBuildWithLatestIfUnavailableReturnsOnCall[i] = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) Resource(arg1 atc.PipelineRef, arg2 string) (atc.Resource, bool, error) {
 	fake.resourceMutex.Lock()
 	ret, specificReturn := fake.resourceReturnsOnCall[len(fake.resourceArgsForCall)]
 	fake.resourceArgsForCall = append(fake.resourceArgsForCall, struct {
@@ -3783,6 +5550,144 @@ func (fake *FakeTeam) ResourceReturnsOnCall(i int, result1 atc.Resource, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) ResourceTypeClosure(arg1 atc.PipelineRef, arg2 []byte) (atc.ResourceTypeClosure, error) {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.resourceTypeClosureMutex.Lock()
+	ret, specificReturn := fake.resourceTypeClosureReturnsOnCall[len(fake.resourceTypeClosureArgsForCall)]
+	fake.resourceTypeClosureArgsForCall = append(fake.resourceTypeClosureArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 []byte
+	}{arg1, arg2Copy})
+	stub := fake.ResourceTypeClosureStub
+	fakeReturns := fake.resourceTypeClosureReturns
+	fake.recordInvocation("ResourceTypeClosure", []interface{}{arg1, arg2Copy})
+	fake.resourceTypeClosureMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ResourceTypeClosureCallCount() int {
+	fake.resourceTypeClosureMutex.RLock()
+	defer fake.resourceTypeClosureMutex.RUnlock()
+	return len(fake.resourceTypeClosureArgsForCall)
+}
+
+func (fake *FakeTeam) ResourceTypeClosureCalls(stub func(atc.PipelineRef, []byte) (atc.ResourceTypeClosure, error)) {
+	fake.resourceTypeClosureMutex.Lock()
+	defer fake.resourceTypeClosureMutex.Unlock()
+	fake.ResourceTypeClosureStub = stub
+}
+
+func (fake *FakeTeam) ResourceTypeClosureArgsForCall(i int) (atc.PipelineRef, []byte) {
+	fake.resourceTypeClosureMutex.RLock()
+	defer fake.resourceTypeClosureMutex.RUnlock()
+	argsForCall := fake.resourceTypeClosureArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) ResourceTypeClosureReturns(result1 atc.ResourceTypeClosure, result2 error) {
+	fake.resourceTypeClosureMutex.Lock()
+	defer fake.resourceTypeClosureMutex.Unlock()
+	fake.ResourceTypeClosureStub = nil
+	fake.resourceTypeClosureReturns = struct {
+		result1 atc.ResourceTypeClosure
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ResourceTypeClosureReturnsOnCall(i int, result1 atc.ResourceTypeClosure, result2 error) {
+	fake.resourceTypeClosureMutex.Lock()
+	defer fake.resourceTypeClosureMutex.Unlock()
+	fake.ResourceTypeClosureStub = nil
+	if fake.resourceTypeClosureReturnsOnCall == nil {
+		fake.resourceTypeClosureReturnsOnCall = make(map[int]struct {
+			result1 atc.ResourceTypeClosure
+			result2 error
+		})
+	}
+	fake.resourceTypeClosureReturnsOnCall[i] = struct {
+		result1 atc.ResourceTypeClosure
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ResourceVersionToggles(arg1 atc.PipelineRef, arg2 string) ([]atc.ResourceVersionToggle, bool, error) {
+	fake.resourceVersionTogglesMutex.Lock()
+	ret, specificReturn := fake.resourceVersionTogglesReturnsOnCall[len(fake.resourceVersionTogglesArgsForCall)]
+	fake.resourceVersionTogglesArgsForCall = append(fake.resourceVersionTogglesArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ResourceVersionTogglesStub
+	fakeReturns := fake.resourceVersionTogglesReturns
+	fake.recordInvocation("ResourceVersionToggles", []interface{}{arg1, arg2})
+	fake.resourceVersionTogglesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) ResourceVersionTogglesCallCount() int {
+	fake.resourceVersionTogglesMutex.RLock()
+	defer fake.resourceVersionTogglesMutex.RUnlock()
+	return len(fake.resourceVersionTogglesArgsForCall)
+}
+
+func (fake *FakeTeam) ResourceVersionTogglesCalls(stub func(atc.PipelineRef, string) ([]atc.ResourceVersionToggle, bool, error)) {
+	fake.resourceVersionTogglesMutex.Lock()
+	defer fake.resourceVersionTogglesMutex.Unlock()
+	fake.ResourceVersionTogglesStub = stub
+}
+
+func (fake *FakeTeam) ResourceVersionTogglesArgsForCall(i int) (atc.PipelineRef, string) {
+	fake.resourceVersionTogglesMutex.RLock()
+	defer fake.resourceVersionTogglesMutex.RUnlock()
+	argsForCall := fake.resourceVersionTogglesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) ResourceVersionTogglesReturns(result1 []atc.ResourceVersionToggle, result2 bool, result3 error) {
+	fake.resourceVersionTogglesMutex.Lock()
+	defer fake.resourceVersionTogglesMutex.Unlock()
+	fake.ResourceVersionTogglesStub = nil
+	fake.resourceVersionTogglesReturns = struct {
+		result1 []atc.ResourceVersionToggle
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) ResourceVersionTogglesReturnsOnCall(i int, result1 []atc.ResourceVersionToggle, result2 bool, result3 error) {
+	fake.resourceVersionTogglesMutex.Lock()
+	defer fake.resourceVersionTogglesMutex.Unlock()
+	fake.ResourceVersionTogglesStub = nil
+	if fake.resourceVersionTogglesReturnsOnCall == nil {
+		fake.resourceVersionTogglesReturnsOnCall = make(map[int]struct {
+			result1 []atc.ResourceVersionToggle
+			result2 bool
+			result3 error
+		})
+	}
+	fake.resourceVersionTogglesReturnsOnCall[i] = struct {
+		result1 []atc.ResourceVersionToggle
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) ResourceVersions(arg1 atc.PipelineRef, arg2 string, arg3 concourse.Page, arg4 atc.Version) ([]atc.ResourceVersion, concourse.Pagination, bool, error) {
 	fake.resourceVersionsMutex.Lock()
 	ret, specificReturn := fake.resourceVersionsReturnsOnCall[len(fake.resourceVersionsArgsForCall)]
@@ -3856,6 +5761,139 @@ func (fake *FakeTeam) ResourceVersionsReturnsOnCall(i int, result1 []atc.Resourc
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) RevokeSharedArtifact(arg1 string, arg2 string) (bool, error) {
+	fake.revokeSharedArtifactMutex.Lock()
+	ret, specificReturn := fake.revokeSharedArtifactReturnsOnCall[len(fake.revokeSharedArtifactArgsForCall)]
+	fake.revokeSharedArtifactArgsForCall = append(fake.revokeSharedArtifactArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RevokeSharedArtifactStub
+	fakeReturns := fake.revokeSharedArtifactReturns
+	fake.recordInvocation("RevokeSharedArtifact", []interface{}{arg1, arg2})
+	fake.revokeSharedArtifactMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) RevokeSharedArtifactCallCount() int {
+	fake.revokeSharedArtifactMutex.RLock()
+	defer fake.revokeSharedArtifactMutex.RUnlock()
+	return len(fake.revokeSharedArtifactArgsForCall)
+}
+
+func (fake *FakeTeam) RevokeSharedArtifactCalls(stub func(string, string) (bool, error)) {
+	fake.revokeSharedArtifactMutex.Lock()
+	defer fake.revokeSharedArtifactMutex.Unlock()
+	fake.RevokeSharedArtifactStub = stub
+}
+
+func (fake *FakeTeam) RevokeSharedArtifactArgsForCall(i int) (string, string) {
+	fake.revokeSharedArtifactMutex.RLock()
+	defer fake.revokeSharedArtifactMutex.RUnlock()
+	argsForCall := fake.revokeSharedArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) RevokeSharedArtifactReturns(result1 bool, result2 error) {
+	fake.revokeSharedArtifactMutex.Lock()
+	defer fake.revokeSharedArtifactMutex.Unlock()
+	fake.RevokeSharedArtifactStub = nil
+	fake.revokeSharedArtifactReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) RevokeSharedArtifactReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeSharedArtifactMutex.Lock()
+	defer fake.revokeSharedArtifactMutex.Unlock()
+	fake.RevokeSharedArtifactStub = nil
+	if fake.revokeSharedArtifactReturnsOnCall == nil {
+		fake.revokeSharedArtifactReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeSharedArtifactReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SaveVersionSet(arg1 atc.PipelineRef, arg2 string) (atc.VersionSet, bool, error) {
+	fake.saveVersionSetMutex.Lock()
+	ret, specificReturn := fake.saveVersionSetReturnsOnCall[len(fake.saveVersionSetArgsForCall)]
+	fake.saveVersionSetArgsForCall = append(fake.saveVersionSetArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SaveVersionSetStub
+	fakeReturns := fake.saveVersionSetReturns
+	fake.recordInvocation("SaveVersionSet", []interface{}{arg1, arg2})
+	fake.saveVersionSetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) SaveVersionSetCallCount() int {
+	fake.saveVersionSetMutex.RLock()
+	defer fake.saveVersionSetMutex.RUnlock()
+	return len(fake.saveVersionSetArgsForCall)
+}
+
+func (fake *FakeTeam) SaveVersionSetCalls(stub func(atc.PipelineRef, string) (atc.VersionSet, bool, error)) {
+	fake.saveVersionSetMutex.Lock()
+	defer fake.saveVersionSetMutex.Unlock()
+	fake.SaveVersionSetStub = stub
+}
+
+func (fake *FakeTeam) SaveVersionSetArgsForCall(i int) (atc.PipelineRef, string) {
+	fake.saveVersionSetMutex.RLock()
+	defer fake.saveVersionSetMutex.RUnlock()
+	argsForCall := fake.saveVersionSetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) SaveVersionSetReturns(result1 atc.VersionSet, result2 bool, result3 error) {
+	fake.saveVersionSetMutex.Lock()
+	defer fake.saveVersionSetMutex.Unlock()
+	fake.SaveVersionSetStub = nil
+	fake.saveVersionSetReturns = struct {
+		result1 atc.VersionSet
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SaveVersionSetReturnsOnCall(i int, result1 atc.VersionSet, result2 bool, result3 error) {
+	fake.saveVersionSetMutex.Lock()
+	defer fake.saveVersionSetMutex.Unlock()
+	fake.SaveVersionSetStub = nil
+	if fake.saveVersionSetReturnsOnCall == nil {
+		fake.saveVersionSetReturnsOnCall = make(map[int]struct {
+			result1 atc.VersionSet
+			result2 bool
+			result3 error
+		})
+	}
+	fake.saveVersionSetReturnsOnCall[i] = struct {
+		result1 atc.VersionSet
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) ScheduleJob(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.scheduleJobMutex.Lock()
 	ret, specificReturn := fake.scheduleJobReturnsOnCall[len(fake.scheduleJobArgsForCall)]
@@ -3987,6 +6025,191 @@ func (fake *FakeTeam) SetPinCommentReturnsOnCall(i int, result1 bool, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SetPipelineVar(arg1 string, arg2 atc.SetPipelineVarRequest) ([]atc.PipelineVarUpdate, error) {
+	fake.setPipelineVarMutex.Lock()
+	ret, specificReturn := fake.setPipelineVarReturnsOnCall[len(fake.setPipelineVarArgsForCall)]
+	fake.setPipelineVarArgsForCall = append(fake.setPipelineVarArgsForCall, struct {
+		arg1 string
+		arg2 atc.SetPipelineVarRequest
+	}{arg1, arg2})
+	stub := fake.SetPipelineVarStub
+	fakeReturns := fake.setPipelineVarReturns
+	fake.recordInvocation("SetPipelineVar", []interface{}{arg1, arg2})
+	fake.setPipelineVarMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SetPipelineVarCallCount() int {
+	fake.setPipelineVarMutex.RLock()
+	defer fake.setPipelineVarMutex.RUnlock()
+	return len(fake.setPipelineVarArgsForCall)
+}
+
+func (fake *FakeTeam) SetPipelineVarCalls(stub func(string, atc.SetPipelineVarRequest) ([]atc.PipelineVarUpdate, error)) {
+	fake.setPipelineVarMutex.Lock()
+	defer fake.setPipelineVarMutex.Unlock()
+	fake.SetPipelineVarStub = stub
+}
+
+func (fake *FakeTeam) SetPipelineVarArgsForCall(i int) (string, atc.SetPipelineVarRequest) {
+	fake.setPipelineVarMutex.RLock()
+	defer fake.setPipelineVarMutex.RUnlock()
+	argsForCall := fake.setPipelineVarArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) SetPipelineVarReturns(result1 []atc.PipelineVarUpdate, result2 error) {
+	fake.setPipelineVarMutex.Lock()
+	defer fake.setPipelineVarMutex.Unlock()
+	fake.SetPipelineVarStub = nil
+	fake.setPipelineVarReturns = struct {
+		result1 []atc.PipelineVarUpdate
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SetPipelineVarReturnsOnCall(i int, result1 []atc.PipelineVarUpdate, result2 error) {
+	fake.setPipelineVarMutex.Lock()
+	defer fake.setPipelineVarMutex.Unlock()
+	fake.SetPipelineVarStub = nil
+	if fake.setPipelineVarReturnsOnCall == nil {
+		fake.setPipelineVarReturnsOnCall = make(map[int]struct {
+			result1 []atc.PipelineVarUpdate
+			result2 error
+		})
+	}
+	fake.setPipelineVarReturnsOnCall[i] = struct {
+		result1 []atc.PipelineVarUpdate
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SetTeamResourceTypes(arg1 atc.ResourceTypes) ([]concourse.ConfigWarning, error) {
+	fake.setTeamResourceTypesMutex.Lock()
+	ret, specificReturn := fake.setTeamResourceTypesReturnsOnCall[len(fake.setTeamResourceTypesArgsForCall)]
+	fake.setTeamResourceTypesArgsForCall = append(fake.setTeamResourceTypesArgsForCall, struct {
+		arg1 atc.ResourceTypes
+	}{arg1})
+	stub := fake.SetTeamResourceTypesStub
+	fakeReturns := fake.setTeamResourceTypesReturns
+	fake.recordInvocation("SetTeamResourceTypes", []interface{}{arg1})
+	fake.setTeamResourceTypesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SetTeamResourceTypesCallCount() int {
+	fake.setTeamResourceTypesMutex.RLock()
+	defer fake.setTeamResourceTypesMutex.RUnlock()
+	return len(fake.setTeamResourceTypesArgsForCall)
+}
+
+func (fake *FakeTeam) SetTeamResourceTypesCalls(stub func(atc.ResourceTypes) ([]concourse.ConfigWarning, error)) {
+	fake.setTeamResourceTypesMutex.Lock()
+	defer fake.setTeamResourceTypesMutex.Unlock()
+	fake.SetTeamResourceTypesStub = stub
+}
+
+func (fake *FakeTeam) SetTeamResourceTypesArgsForCall(i int) atc.ResourceTypes {
+	fake.setTeamResourceTypesMutex.RLock()
+	defer fake.setTeamResourceTypesMutex.RUnlock()
+	argsForCall := fake.setTeamResourceTypesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SetTeamResourceTypesReturns(result1 []concourse.ConfigWarning, result2 error) {
+	fake.setTeamResourceTypesMutex.Lock()
+	defer fake.setTeamResourceTypesMutex.Unlock()
+	fake.SetTeamResourceTypesStub = nil
+	fake.setTeamResourceTypesReturns = struct {
+		result1 []concourse.ConfigWarning
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SetTeamResourceTypesReturnsOnCall(i int, result1 []concourse.ConfigWarning, result2 error) {
+	fake.setTeamResourceTypesMutex.Lock()
+	defer fake.setTeamResourceTypesMutex.Unlock()
+	fake.SetTeamResourceTypesStub = nil
+	if fake.setTeamResourceTypesReturnsOnCall == nil {
+		fake.setTeamResourceTypesReturnsOnCall = make(map[int]struct {
+			result1 []concourse.ConfigWarning
+			result2 error
+		})
+	}
+	fake.setTeamResourceTypesReturnsOnCall[i] = struct {
+		result1 []concourse.ConfigWarning
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) TeamResourceTypes() (atc.ResourceTypes, error) {
+	fake.teamResourceTypesMutex.Lock()
+	ret, specificReturn := fake.teamResourceTypesReturnsOnCall[len(fake.teamResourceTypesArgsForCall)]
+	fake.teamResourceTypesArgsForCall = append(fake.teamResourceTypesArgsForCall, struct {
+	}{})
+	stub := fake.TeamResourceTypesStub
+	fakeReturns := fake.teamResourceTypesReturns
+	fake.recordInvocation("TeamResourceTypes", []interface{}{})
+	fake.teamResourceTypesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) TeamResourceTypesCallCount() int {
+	fake.teamResourceTypesMutex.RLock()
+	defer fake.teamResourceTypesMutex.RUnlock()
+	return len(fake.teamResourceTypesArgsForCall)
+}
+
+func (fake *FakeTeam) TeamResourceTypesCalls(stub func() (atc.ResourceTypes, error)) {
+	fake.teamResourceTypesMutex.Lock()
+	defer fake.teamResourceTypesMutex.Unlock()
+	fake.TeamResourceTypesStub = stub
+}
+
+func (fake *FakeTeam) TeamResourceTypesReturns(result1 atc.ResourceTypes, result2 error) {
+	fake.teamResourceTypesMutex.Lock()
+	defer fake.teamResourceTypesMutex.Unlock()
+	fake.TeamResourceTypesStub = nil
+	fake.teamResourceTypesReturns = struct {
+		result1 atc.ResourceTypes
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) TeamResourceTypesReturnsOnCall(i int, result1 atc.ResourceTypes, result2 error) {
+	fake.teamResourceTypesMutex.Lock()
+	defer fake.teamResourceTypesMutex.Unlock()
+	fake.TeamResourceTypesStub = nil
+	if fake.teamResourceTypesReturnsOnCall == nil {
+		fake.teamResourceTypesReturnsOnCall = make(map[int]struct {
+			result1 atc.ResourceTypes
+			result2 error
+		})
+	}
+	fake.teamResourceTypesReturnsOnCall[i] = struct {
+		result1 atc.ResourceTypes
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) UnpauseJob(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.unpauseJobMutex.Lock()
 	ret, specificReturn := fake.unpauseJobReturnsOnCall[len(fake.unpauseJobArgsForCall)]
@@ -4116,6 +6339,136 @@ func (fake *FakeTeam) UnpausePipelineReturnsOnCall(i int, result1 bool, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) UnpausePipelineWithChecks(arg1 atc.PipelineRef, arg2 bool) (bool, error) {
+	fake.unpausePipelineWithChecksMutex.Lock()
+	ret, specificReturn := fake.unpausePipelineWithChecksReturnsOnCall[len(fake.unpausePipelineWithChecksArgsForCall)]
+	fake.unpausePipelineWithChecksArgsForCall = append(fake.unpausePipelineWithChecksArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.UnpausePipelineWithChecksStub
+	fakeReturns := fake.unpausePipelineWithChecksReturns
+	fake.recordInvocation("UnpausePipelineWithChecks", []interface{}{arg1, arg2})
+	fake.unpausePipelineWithChecksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) UnpausePipelineWithChecksCallCount() int {
+	fake.unpausePipelineWithChecksMutex.RLock()
+	defer fake.unpausePipelineWithChecksMutex.RUnlock()
+	return len(fake.unpausePipelineWithChecksArgsForCall)
+}
+
+func (fake *FakeTeam) UnpausePipelineWithChecksCalls(stub func(atc.PipelineRef, bool) (bool, error)) {
+	fake.unpausePipelineWithChecksMutex.Lock()
+	defer fake.unpausePipelineWithChecksMutex.Unlock()
+	fake.UnpausePipelineWithChecksStub = stub
+}
+
+func (fake *FakeTeam) UnpausePipelineWithChecksArgsForCall(i int) (atc.PipelineRef, bool) {
+	fake.unpausePipelineWithChecksMutex.RLock()
+	defer fake.unpausePipelineWithChecksMutex.RUnlock()
+	argsForCall := fake.unpausePipelineWithChecksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) UnpausePipelineWithChecksReturns(result1 bool, result2 error) {
+	fake.unpausePipelineWithChecksMutex.Lock()
+	defer fake.unpausePipelineWithChecksMutex.Unlock()
+	fake.UnpausePipelineWithChecksStub = nil
+	fake.unpausePipelineWithChecksReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UnpausePipelineWithChecksReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.unpausePipelineWithChecksMutex.Lock()
+	defer fake.unpausePipelineWithChecksMutex.Unlock()
+	fake.UnpausePipelineWithChecksStub = nil
+	if fake.unpausePipelineWithChecksReturnsOnCall == nil {
+		fake.unpausePipelineWithChecksReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.unpausePipelineWithChecksReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UnpauseResourceChecking(arg1 atc.PipelineRef, arg2 string) (bool, error) {
+	fake.unpauseResourceCheckingMutex.Lock()
+	ret, specificReturn := fake.unpauseResourceCheckingReturnsOnCall[len(fake.unpauseResourceCheckingArgsForCall)]
+	fake.unpauseResourceCheckingArgsForCall = append(fake.unpauseResourceCheckingArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.UnpauseResourceCheckingStub
+	fakeReturns := fake.unpauseResourceCheckingReturns
+	fake.recordInvocation("UnpauseResourceChecking", []interface{}{arg1, arg2})
+	fake.unpauseResourceCheckingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) UnpauseResourceCheckingCallCount() int {
+	fake.unpauseResourceCheckingMutex.RLock()
+	defer fake.unpauseResourceCheckingMutex.RUnlock()
+	return len(fake.unpauseResourceCheckingArgsForCall)
+}
+
+func (fake *FakeTeam) UnpauseResourceCheckingCalls(stub func(atc.PipelineRef, string) (bool, error)) {
+	fake.unpauseResourceCheckingMutex.Lock()
+	defer fake.unpauseResourceCheckingMutex.Unlock()
+	fake.UnpauseResourceCheckingStub = stub
+}
+
+func (fake *FakeTeam) UnpauseResourceCheckingArgsForCall(i int) (atc.PipelineRef, string) {
+	fake.unpauseResourceCheckingMutex.RLock()
+	defer fake.unpauseResourceCheckingMutex.RUnlock()
+	argsForCall := fake.unpauseResourceCheckingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) UnpauseResourceCheckingReturns(result1 bool, result2 error) {
+	fake.unpauseResourceCheckingMutex.Lock()
+	defer fake.unpauseResourceCheckingMutex.Unlock()
+	fake.UnpauseResourceCheckingStub = nil
+	fake.unpauseResourceCheckingReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UnpauseResourceCheckingReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.unpauseResourceCheckingMutex.Lock()
+	defer fake.unpauseResourceCheckingMutex.Unlock()
+	fake.UnpauseResourceCheckingStub = nil
+	if fake.unpauseResourceCheckingReturnsOnCall == nil {
+		fake.unpauseResourceCheckingReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.unpauseResourceCheckingReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) UnpinResource(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.unpinResourceMutex.Lock()
 	ret, specificReturn := fake.unpinResourceReturnsOnCall[len(fake.unpinResourceArgsForCall)]
@@ -4181,6 +6534,72 @@ func (fake *FakeTeam) UnpinResourceReturnsOnCall(i int, result1 bool, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) UnpromoteResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int) (bool, error) {
+	fake.unpromoteResourceVersionMutex.Lock()
+	ret, specificReturn := fake.unpromoteResourceVersionReturnsOnCall[len(fake.unpromoteResourceVersionArgsForCall)]
+	fake.unpromoteResourceVersionArgsForCall = append(fake.unpromoteResourceVersionArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.UnpromoteResourceVersionStub
+	fakeReturns := fake.unpromoteResourceVersionReturns
+	fake.recordInvocation("UnpromoteResourceVersion", []interface{}{arg1, arg2, arg3})
+	fake.unpromoteResourceVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) UnpromoteResourceVersionCallCount() int {
+	fake.unpromoteResourceVersionMutex.RLock()
+	defer fake.unpromoteResourceVersionMutex.RUnlock()
+	return len(fake.unpromoteResourceVersionArgsForCall)
+}
+
+func (fake *FakeTeam) UnpromoteResourceVersionCalls(stub func(atc.PipelineRef, string, int) (bool, error)) {
+	fake.unpromoteResourceVersionMutex.Lock()
+	defer fake.unpromoteResourceVersionMutex.Unlock()
+	fake.UnpromoteResourceVersionStub = stub
+}
+
+func (fake *FakeTeam) UnpromoteResourceVersionArgsForCall(i int) (atc.PipelineRef, string, int) {
+	fake.unpromoteResourceVersionMutex.RLock()
+	defer fake.unpromoteResourceVersionMutex.RUnlock()
+	argsForCall := fake.unpromoteResourceVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) UnpromoteResourceVersionReturns(result1 bool, result2 error) {
+	fake.unpromoteResourceVersionMutex.Lock()
+	defer fake.unpromoteResourceVersionMutex.Unlock()
+	fake.UnpromoteResourceVersionStub = nil
+	fake.unpromoteResourceVersionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UnpromoteResourceVersionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.unpromoteResourceVersionMutex.Lock()
+	defer fake.unpromoteResourceVersionMutex.Unlock()
+	fake.UnpromoteResourceVersionStub = nil
+	if fake.unpromoteResourceVersionReturnsOnCall == nil {
+		fake.unpromoteResourceVersionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.unpromoteResourceVersionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) VersionedResourceTypes(arg1 atc.PipelineRef) (atc.VersionedResourceTypes, bool, error) {
 	fake.versionedResourceTypesMutex.Lock()
 	ret, specificReturn := fake.versionedResourceTypesReturnsOnCall[len(fake.versionedResourceTypesArgsForCall)]
@@ -4253,10 +6672,14 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.aTCTeamMutex.RLock()
 	defer fake.aTCTeamMutex.RUnlock()
+	fake.applyVersionSetMutex.RLock()
+	defer fake.applyVersionSetMutex.RUnlock()
 	fake.archivePipelineMutex.RLock()
 	defer fake.archivePipelineMutex.RUnlock()
 	fake.authMutex.RLock()
 	defer fake.authMutex.RUnlock()
+	fake.backfillResourceVersionsMutex.RLock()
+	defer fake.backfillResourceVersionsMutex.RUnlock()
 	fake.buildInputsForJobMutex.RLock()
 	defer fake.buildInputsForJobMutex.RUnlock()
 	fake.buildsMutex.RLock()
@@ -4271,12 +6694,16 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.checkResourceTypeMutex.RUnlock()
 	fake.clearTaskCacheMutex.RLock()
 	defer fake.clearTaskCacheMutex.RUnlock()
+	fake.clearVersionSetMutex.RLock()
+	defer fake.clearVersionSetMutex.RUnlock()
 	fake.createArtifactMutex.RLock()
 	defer fake.createArtifactMutex.RUnlock()
 	fake.createBuildMutex.RLock()
 	defer fake.createBuildMutex.RUnlock()
 	fake.createJobBuildMutex.RLock()
 	defer fake.createJobBuildMutex.RUnlock()
+	fake.createJobBuildWithVarsMutex.RLock()
+	defer fake.createJobBuildWithVarsMutex.RUnlock()
 	fake.createOrUpdateMutex.RLock()
 	defer fake.createOrUpdateMutex.RUnlock()
 	fake.createOrUpdatePipelineConfigMutex.RLock()
@@ -4285,18 +6712,26 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createPipelineBuildMutex.RUnlock()
 	fake.deletePipelineMutex.RLock()
 	defer fake.deletePipelineMutex.RUnlock()
+	fake.deletePipelineVarMutex.RLock()
+	defer fake.deletePipelineVarMutex.RUnlock()
 	fake.destroyTeamMutex.RLock()
 	defer fake.destroyTeamMutex.RUnlock()
+	fake.destroyVersionSetMutex.RLock()
+	defer fake.destroyVersionSetMutex.RUnlock()
 	fake.disableResourceVersionMutex.RLock()
 	defer fake.disableResourceVersionMutex.RUnlock()
 	fake.enableResourceVersionMutex.RLock()
 	defer fake.enableResourceVersionMutex.RUnlock()
+	fake.expireResourceVersionCachesMutex.RLock()
+	defer fake.expireResourceVersionCachesMutex.RUnlock()
 	fake.exposePipelineMutex.RLock()
 	defer fake.exposePipelineMutex.RUnlock()
 	fake.getArtifactMutex.RLock()
 	defer fake.getArtifactMutex.RUnlock()
 	fake.getContainerMutex.RLock()
 	defer fake.getContainerMutex.RUnlock()
+	fake.grantSharedArtifactMutex.RLock()
+	defer fake.grantSharedArtifactMutex.RUnlock()
 	fake.hidePipelineMutex.RLock()
 	defer fake.hidePipelineMutex.RUnlock()
 	fake.iDMutex.RLock()
@@ -4307,14 +6742,30 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.jobBuildMutex.RUnlock()
 	fake.jobBuildsMutex.RLock()
 	defer fake.jobBuildsMutex.RUnlock()
+	fake.jobSchedulerDecisionMutex.RLock()
+	defer fake.jobSchedulerDecisionMutex.RUnlock()
 	fake.listContainersMutex.RLock()
 	defer fake.listContainersMutex.RUnlock()
 	fake.listJobsMutex.RLock()
 	defer fake.listJobsMutex.RUnlock()
+	fake.listPendingChecksMutex.RLock()
+	defer fake.listPendingChecksMutex.RUnlock()
+	fake.listPipelineVarsMutex.RLock()
+	defer fake.listPipelineVarsMutex.RUnlock()
 	fake.listPipelinesMutex.RLock()
 	defer fake.listPipelinesMutex.RUnlock()
+	fake.listResourceCheckRecordsMutex.RLock()
+	defer fake.listResourceCheckRecordsMutex.RUnlock()
+	fake.listResourceChecksMutex.RLock()
+	defer fake.listResourceChecksMutex.RUnlock()
 	fake.listResourcesMutex.RLock()
 	defer fake.listResourcesMutex.RUnlock()
+	fake.listSerialGroupsMutex.RLock()
+	defer fake.listSerialGroupsMutex.RUnlock()
+	fake.listSharedArtifactsMutex.RLock()
+	defer fake.listSharedArtifactsMutex.RUnlock()
+	fake.listVersionSetsMutex.RLock()
+	defer fake.listVersionSetsMutex.RUnlock()
 	fake.listVolumesMutex.RLock()
 	defer fake.listVolumesMutex.RUnlock()
 	fake.nameMutex.RLock()
@@ -4323,10 +6774,14 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.orderingPipelinesMutex.RUnlock()
 	fake.orderingPipelinesWithinGroupMutex.RLock()
 	defer fake.orderingPipelinesWithinGroupMutex.RUnlock()
+	fake.patchPipelineConfigMutex.RLock()
+	defer fake.patchPipelineConfigMutex.RUnlock()
 	fake.pauseJobMutex.RLock()
 	defer fake.pauseJobMutex.RUnlock()
 	fake.pausePipelineMutex.RLock()
 	defer fake.pausePipelineMutex.RUnlock()
+	fake.pauseResourceCheckingMutex.RLock()
+	defer fake.pauseResourceCheckingMutex.RUnlock()
 	fake.pinResourceVersionMutex.RLock()
 	defer fake.pinResourceVersionMutex.RUnlock()
 	fake.pipelineMutex.RLock()
@@ -4335,26 +6790,50 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.pipelineBuildsMutex.RUnlock()
 	fake.pipelineConfigMutex.RLock()
 	defer fake.pipelineConfigMutex.RUnlock()
+	fake.promoteResourceVersionMutex.RLock()
+	defer fake.promoteResourceVersionMutex.RUnlock()
 	fake.renamePipelineMutex.RLock()
 	defer fake.renamePipelineMutex.RUnlock()
 	fake.renameTeamMutex.RLock()
 	defer fake.renameTeamMutex.RUnlock()
 	fake.rerunJobBuildMutex.RLock()
 	defer fake.rerunJobBuildMutex.RUnlock()
+	fake.rerunJobBuildWithLatestIfUnavailableMutex.RLock()
+	defer fake.rerunJobBuildWithLatestIfUnavailableMutex.RUnlock()
 	fake.resourceMutex.RLock()
 	defer fake.resourceMutex.RUnlock()
+	fake.resourceTypeClosureMutex.RLock()
+	defer fake.resourceTypeClosureMutex.RUnlock()
+	fake.resourceVersionTogglesMutex.RLock()
+	defer fake.resourceVersionTogglesMutex.RUnlock()
 	fake.resourceVersionsMutex.RLock()
 	defer fake.resourceVersionsMutex.RUnlock()
+	fake.revokeSharedArtifactMutex.RLock()
+	defer fake.revokeSharedArtifactMutex.RUnlock()
+	fake.saveVersionSetMutex.RLock()
+	defer fake.saveVersionSetMutex.RUnlock()
 	fake.scheduleJobMutex.RLock()
 	defer fake.scheduleJobMutex.RUnlock()
 	fake.setPinCommentMutex.RLock()
 	defer fake.setPinCommentMutex.RUnlock()
+	fake.setPipelineVarMutex.RLock()
+	defer fake.setPipelineVarMutex.RUnlock()
+	fake.setTeamResourceTypesMutex.RLock()
+	defer fake.setTeamResourceTypesMutex.RUnlock()
+	fake.teamResourceTypesMutex.RLock()
+	defer fake.teamResourceTypesMutex.RUnlock()
 	fake.unpauseJobMutex.RLock()
 	defer fake.unpauseJobMutex.RUnlock()
 	fake.unpausePipelineMutex.RLock()
 	defer fake.unpausePipelineMutex.RUnlock()
+	fake.unpausePipelineWithChecksMutex.RLock()
+	defer fake.unpausePipelineWithChecksMutex.RUnlock()
+	fake.unpauseResourceCheckingMutex.RLock()
+	defer fake.unpauseResourceCheckingMutex.RUnlock()
 	fake.unpinResourceMutex.RLock()
 	defer fake.unpinResourceMutex.RUnlock()
+	fake.unpromoteResourceVersionMutex.RLock()
+	defer fake.unpromoteResourceVersionMutex.RUnlock()
 	fake.versionedResourceTypesMutex.RLock()
 	defer fake.versionedResourceTypesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -3,6 +3,7 @@ package concourse
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -112,6 +113,121 @@ func (team *team) CreateOrUpdatePipelineConfig(pipelineRef atc.PipelineRef, conf
 	}
 }
 
+// ErrConfigVersionConflict is returned when patching a pipeline config that
+// has been updated since the version the patch was made against.
+var ErrConfigVersionConflict = errors.New("pipeline config has been updated since the given version")
+
+// PatchPipelineConfig applies a JSON Patch to the config of an existing
+// pipeline and returns the new config version.
+func (team *team) PatchPipelineConfig(pipelineRef atc.PipelineRef, configVersion string, patch []byte) (string, []ConfigWarning, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	response, err := team.httpAgent.Send(internal.Request{
+		ReturnResponseBody: true,
+		RequestName:        atc.PatchConfig,
+		Params:             params,
+		Query:              pipelineRef.QueryParams(),
+		Body:               bytes.NewBuffer(patch),
+		Header: http.Header{
+			"Content-Type":          {atc.ConfigPatchContentType},
+			atc.ConfigVersionHeader: {configVersion},
+		},
+	})
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	defer response.Body.Close()
+	body, _ := ioutil.ReadAll(response.Body)
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		configResponse := setConfigResponse{}
+		err = json.Unmarshal(body, &configResponse)
+		if err != nil {
+			return "", nil, false, err
+		}
+		return response.Header.Get(atc.ConfigVersionHeader), configResponse.Warnings, true, nil
+	case http.StatusNotFound:
+		return "", nil, false, nil
+	case http.StatusBadRequest:
+		var validationErr atc.SaveConfigResponse
+		err = json.Unmarshal(body, &validationErr)
+		if err != nil {
+			return "", nil, false, err
+		}
+		return "", nil, false, InvalidConfigError{Errors: validationErr.Errors}
+	case http.StatusConflict:
+		return "", nil, false, ErrConfigVersionConflict
+	case http.StatusForbidden:
+		return "", nil, false, internal.ForbiddenError{
+			Reason: string(body),
+		}
+	default:
+		return "", nil, false, internal.UnexpectedResponseError{
+			StatusCode: response.StatusCode,
+			Status:     response.Status,
+			Body:       string(body),
+		}
+	}
+}
+
+// ResourceTypeClosure returns every resource type the given pipeline config
+// is built on, without setting the pipeline.
+func (team *team) ResourceTypeClosure(pipelineRef atc.PipelineRef, passedConfig []byte) (atc.ResourceTypeClosure, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	response, err := team.httpAgent.Send(internal.Request{
+		ReturnResponseBody: true,
+		RequestName:        atc.GetResourceTypeClosure,
+		Params:             params,
+		Query:              pipelineRef.QueryParams(),
+		Body:               bytes.NewBuffer(passedConfig),
+		Header: http.Header{
+			"Content-Type": {"application/x-yaml"},
+		},
+	})
+	if err != nil {
+		return atc.ResourceTypeClosure{}, err
+	}
+
+	defer response.Body.Close()
+	body, _ := ioutil.ReadAll(response.Body)
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		var closure atc.ResourceTypeClosure
+		err = json.Unmarshal(body, &closure)
+		if err != nil {
+			return atc.ResourceTypeClosure{}, err
+		}
+		return closure, nil
+	case http.StatusBadRequest:
+		var validationErr atc.SaveConfigResponse
+		err = json.Unmarshal(body, &validationErr)
+		if err != nil {
+			return atc.ResourceTypeClosure{}, err
+		}
+		return atc.ResourceTypeClosure{}, InvalidConfigError{Errors: validationErr.Errors}
+	case http.StatusForbidden:
+		return atc.ResourceTypeClosure{}, internal.ForbiddenError{
+			Reason: string(body),
+		}
+	default:
+		return atc.ResourceTypeClosure{}, internal.UnexpectedResponseError{
+			StatusCode: response.StatusCode,
+			Status:     response.Status,
+			Body:       string(body),
+		}
+	}
+}
+
 func merge(base, extra url.Values) url.Values {
 	if extra != nil {
 		for key, values := range extra {
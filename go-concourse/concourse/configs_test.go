@@ -395,4 +395,147 @@ var _ = Describe("ATC Handler Configs", func() {
 			})
 		})
 	})
+
+	Describe("PatchPipelineConfig", func() {
+		var (
+			patch []byte
+
+			returnStatus int
+			returnBody   []byte
+		)
+
+		BeforeEach(func() {
+			patch = []byte(`[{"op":"replace","path":"/resources/0/source/tag","value":"1.2.3"}]`)
+
+			returnStatus = http.StatusOK
+			returnBody = []byte(`{"warnings":[{"type": "some-type", "message": "some-warning"}]}`)
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/api/v1/teams/some-team/pipelines/mypipeline/config"),
+					ghttp.VerifyHeaderKV(atc.ConfigVersionHeader, "42"),
+					ghttp.VerifyContentType(atc.ConfigPatchContentType),
+					ghttp.VerifyBody(patch),
+					func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Set(atc.ConfigVersionHeader, "43")
+						w.WriteHeader(returnStatus)
+						w.Write(returnBody)
+					},
+				),
+			)
+		})
+
+		It("returns the new config version and the warnings", func() {
+			version, warnings, found, err := team.PatchPipelineConfig(pipelineRef, "42", patch)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(version).To(Equal("43"))
+			Expect(warnings).To(Equal([]concourse.ConfigWarning{
+				{Type: "some-type", Message: "some-warning"},
+			}))
+		})
+
+		Context("when the pipeline has been updated since", func() {
+			BeforeEach(func() {
+				returnStatus = http.StatusConflict
+			})
+
+			It("returns ErrConfigVersionConflict", func() {
+				_, _, _, err := team.PatchPipelineConfig(pipelineRef, "42", patch)
+				Expect(err).To(Equal(concourse.ErrConfigVersionConflict))
+			})
+		})
+
+		Context("when the patched config is invalid", func() {
+			BeforeEach(func() {
+				returnStatus = http.StatusBadRequest
+				returnBody = []byte(`{"errors":["some-error"]}`)
+			})
+
+			It("returns an InvalidConfigError", func() {
+				_, _, _, err := team.PatchPipelineConfig(pipelineRef, "42", patch)
+				Expect(err).To(Equal(concourse.InvalidConfigError{Errors: []string{"some-error"}}))
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				returnStatus = http.StatusNotFound
+				returnBody = nil
+			})
+
+			It("returns false", func() {
+				_, _, found, err := team.PatchPipelineConfig(pipelineRef, "42", patch)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("ResourceTypeClosure", func() {
+		var (
+			config []byte
+
+			returnStatus int
+			returnBody   []byte
+		)
+
+		BeforeEach(func() {
+			config = []byte("resources: [{name: some-resource, type: some-type}]")
+
+			returnStatus = http.StatusOK
+			returnBody = []byte(`{
+				"types": [{"name": "some-type", "base": true, "worker_versions": ["1.0.0"]}],
+				"cycles": [["cycle-a", "cycle-b", "cycle-a"]]
+			}`)
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/teams/some-team/pipelines/mypipeline/resource-type-closure"),
+					ghttp.VerifyContentType("application/x-yaml"),
+					ghttp.VerifyBody(config),
+					ghttp.RespondWith(returnStatus, returnBody),
+				),
+			)
+		})
+
+		It("returns the closure", func() {
+			closure, err := team.ResourceTypeClosure(pipelineRef, config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(closure).To(Equal(atc.ResourceTypeClosure{
+				Types: []atc.ResourceTypeDependency{
+					{Name: "some-type", Base: true, WorkerVersions: []string{"1.0.0"}},
+				},
+				Cycles: [][]string{{"cycle-a", "cycle-b", "cycle-a"}},
+			}))
+		})
+
+		Context("when the config is malformed", func() {
+			BeforeEach(func() {
+				returnStatus = http.StatusBadRequest
+				returnBody = []byte(`{"errors":["some-error"]}`)
+			})
+
+			It("returns an InvalidConfigError", func() {
+				_, err := team.ResourceTypeClosure(pipelineRef, config)
+				Expect(err).To(Equal(concourse.InvalidConfigError{Errors: []string{"some-error"}}))
+			})
+		})
+
+		Context("when the team does not exist", func() {
+			BeforeEach(func() {
+				returnStatus = http.StatusNotFound
+				returnBody = nil
+			})
+
+			It("returns an error", func() {
+				_, err := team.ResourceTypeClosure(pipelineRef, config)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
 })
@@ -0,0 +1,51 @@
+package concourse
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+)
+
+var ErrEncryptionKeyRotationInProgress = errors.New("a rotation of the encryption key is already under way")
+
+func (client *client) RotateEncryptionKey() ([]atc.EncryptionKeyRotationProgress, error) {
+	var progress []atc.EncryptionKeyRotationProgress
+
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.RotateEncryptionKey,
+	}, &internal.Response{
+		Result: &progress,
+	})
+
+	if ure, ok := err.(internal.UnexpectedResponseError); ok {
+		switch ure.StatusCode {
+		case http.StatusConflict:
+			return nil, ErrEncryptionKeyRotationInProgress
+		case http.StatusBadRequest:
+			return nil, errors.New(ure.Body)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return progress, nil
+}
+
+func (client *client) EncryptionKeyRotation() ([]atc.EncryptionKeyRotationProgress, error) {
+	var progress []atc.EncryptionKeyRotationProgress
+
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.GetEncryptionKeyRotation,
+	}, &internal.Response{
+		Result: &progress,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return progress, nil
+}
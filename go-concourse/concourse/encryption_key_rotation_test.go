@@ -0,0 +1,87 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Encryption Key Rotation", func() {
+	expectedProgress := []atc.EncryptionKeyRotationProgress{
+		{Table: "pipelines", Column: "config", Rotated: 12, Finished: true, UpdateTime: 1000},
+		{Table: "resources", Column: "config", Rotated: 3, UpdateTime: 2000},
+	}
+
+	Describe("RotateEncryptionKey", func() {
+		var (
+			status   int
+			response interface{}
+		)
+
+		BeforeEach(func() {
+			status = http.StatusAccepted
+			response = expectedProgress
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/encryption-key/rotation"),
+					ghttp.RespondWithJSONEncoded(status, response),
+				),
+			)
+		})
+
+		It("starts the rotation and returns its progress", func() {
+			progress, err := client.RotateEncryptionKey()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(progress).To(Equal(expectedProgress))
+		})
+
+		Context("when a rotation is already under way", func() {
+			BeforeEach(func() {
+				status = http.StatusConflict
+			})
+
+			It("returns ErrEncryptionKeyRotationInProgress", func() {
+				_, err := client.RotateEncryptionKey()
+				Expect(err).To(Equal(concourse.ErrEncryptionKeyRotationInProgress))
+			})
+		})
+
+		Context("when the rotation is not configured", func() {
+			JustBeforeEach(func() {
+				atcServer.SetHandler(0, ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/encryption-key/rotation"),
+					ghttp.RespondWith(http.StatusBadRequest, "both keys are required"),
+				))
+			})
+
+			It("returns the reason", func() {
+				_, err := client.RotateEncryptionKey()
+				Expect(err).To(MatchError("both keys are required"))
+			})
+		})
+	})
+
+	Describe("EncryptionKeyRotation", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/encryption-key/rotation"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedProgress),
+				),
+			)
+		})
+
+		It("returns the progress", func() {
+			progress, err := client.EncryptionKeyRotation()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(progress).To(Equal(expectedProgress))
+		})
+	})
+})
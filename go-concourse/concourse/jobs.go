@@ -63,6 +63,31 @@ func (team *team) Job(pipelineRef atc.PipelineRef, jobName string) (atc.Job, boo
 	}
 }
 
+func (team *team) JobSchedulerDecision(pipelineRef atc.PipelineRef, jobName string) (atc.SchedulerDecision, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"job_name":      jobName,
+		"team_name":     team.Name(),
+	}
+
+	var decision atc.SchedulerDecision
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.GetJobSchedulerDecision,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, &internal.Response{
+		Result: &decision,
+	})
+	switch err.(type) {
+	case nil:
+		return decision, true, nil
+	case internal.ResourceNotFoundError:
+		return decision, false, nil
+	default:
+		return decision, false, err
+	}
+}
+
 func (team *team) JobBuilds(pipelineRef atc.PipelineRef, jobName string, page Page) ([]atc.Build, Pagination, bool, error) {
 	params := rata.Params{
 		"pipeline_name": pipelineRef.Name,
@@ -162,6 +162,61 @@ var _ = Describe("ATC Handler Jobs", func() {
 		})
 	})
 
+	Describe("JobSchedulerDecision", func() {
+		var (
+			expectedDecision atc.SchedulerDecision
+			expectedURL      = "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/scheduler-decision"
+			queryParams      = "vars.branch=%22master%22"
+			pipelineRef      = atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+		)
+
+		Context("when the job has a decision", func() {
+			BeforeEach(func() {
+				expectedDecision = atc.SchedulerDecision{
+					Time: 42,
+					Reasons: []atc.SchedulerDecisionReason{
+						{
+							Kind:    atc.SchedulerInputUnresolved,
+							Input:   "some-input",
+							Message: "no satisfiable builds from passed jobs found for set of inputs",
+						},
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, queryParams),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedDecision),
+					),
+				)
+			})
+
+			It("returns the decision", func() {
+				decision, found, err := team.JobSchedulerDecision(pipelineRef, "myjob")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decision).To(Equal(expectedDecision))
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the job or its decision does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, queryParams),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := team.JobSchedulerDecision(pipelineRef, "myjob")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("JobBuilds", func() {
 		var (
 			expectedBuilds []atc.Build
@@ -0,0 +1,53 @@
+package concourse
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+var ErrNotACheck = errors.New("build is not a check")
+var ErrCheckFinished = errors.New("check has already finished")
+
+func (team *team) ListPendingChecks(pipelineRef atc.PipelineRef) ([]atc.PendingCheck, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	var checks []atc.PendingCheck
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListPendingChecks,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, &internal.Response{
+		Result: &checks,
+	})
+
+	return checks, err
+}
+
+func (client *client) CancelCheck(buildID string) error {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.CancelCheck,
+		Params:      params,
+	}, nil)
+
+	if ure, ok := err.(internal.UnexpectedResponseError); ok {
+		switch ure.StatusCode {
+		case http.StatusBadRequest:
+			return ErrNotACheck
+		case http.StatusConflict:
+			return ErrCheckFinished
+		}
+	}
+
+	return err
+}
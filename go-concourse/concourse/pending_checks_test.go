@@ -0,0 +1,81 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Pending Checks", func() {
+	Describe("ListPendingChecks", func() {
+		var expectedChecks []atc.PendingCheck
+
+		BeforeEach(func() {
+			expectedChecks = []atc.PendingCheck{
+				{BuildID: 1, ResourceName: "some-resource", Status: atc.PendingCheckRunning, CreateTime: 100},
+				{BuildID: 2, ResourceTypeName: "some-type", Status: atc.PendingCheckQueued, CreateTime: 101},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/pipelines/some-pipeline/pending-checks", "vars.branch=%22master%22"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedChecks),
+				),
+			)
+		})
+
+		It("returns the pending checks of the pipeline", func() {
+			checks, err := team.ListPendingChecks(atc.PipelineRef{
+				Name:         "some-pipeline",
+				InstanceVars: atc.InstanceVars{"branch": "master"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks).To(Equal(expectedChecks))
+		})
+	})
+
+	Describe("CancelCheck", func() {
+		var status int
+
+		BeforeEach(func() {
+			status = http.StatusNoContent
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/checks/123/cancel"),
+					ghttp.RespondWith(status, ""),
+				),
+			)
+		})
+
+		It("cancels the check", func() {
+			Expect(client.CancelCheck("123")).To(Succeed())
+		})
+
+		Context("when the build is not a check", func() {
+			BeforeEach(func() {
+				status = http.StatusBadRequest
+			})
+
+			It("returns ErrNotACheck", func() {
+				Expect(client.CancelCheck("123")).To(Equal(concourse.ErrNotACheck))
+			})
+		})
+
+		Context("when the check has already finished", func() {
+			BeforeEach(func() {
+				status = http.StatusConflict
+			})
+
+			It("returns ErrCheckFinished", func() {
+				Expect(client.CancelCheck("123")).To(Equal(concourse.ErrCheckFinished))
+			})
+		})
+	})
+})
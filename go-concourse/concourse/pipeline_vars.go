@@ -0,0 +1,68 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) ListPipelineVars() ([]atc.PipelineVars, error) {
+	var pipelineVars []atc.PipelineVars
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListPipelineVars,
+		Params: rata.Params{
+			"team_name": team.Name(),
+		},
+	}, &internal.Response{
+		Result: &pipelineVars,
+	})
+
+	return pipelineVars, err
+}
+
+func (team *team) SetPipelineVar(varName string, request atc.SetPipelineVarRequest) ([]atc.PipelineVarUpdate, error) {
+	buffer := &bytes.Buffer{}
+	err := json.NewEncoder(buffer).Encode(request)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to marshal request: %s", err)
+	}
+
+	var updates []atc.PipelineVarUpdate
+	err = team.connection.Send(internal.Request{
+		RequestName: atc.SetPipelineVar,
+		Body:        buffer,
+		Params: rata.Params{
+			"team_name": team.Name(),
+			"var_name":  varName,
+		},
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, &internal.Response{
+		Result: &updates,
+	})
+
+	return updates, err
+}
+
+func (team *team) DeletePipelineVar(varName string, pipelines []string) ([]atc.PipelineVarUpdate, error) {
+	var updates []atc.PipelineVarUpdate
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.DeletePipelineVar,
+		Params: rata.Params{
+			"team_name": team.Name(),
+			"var_name":  varName,
+		},
+		Query: url.Values{"pipeline": pipelines},
+	}, &internal.Response{
+		Result: &updates,
+	})
+
+	return updates, err
+}
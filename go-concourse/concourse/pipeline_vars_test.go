@@ -0,0 +1,90 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Pipeline Vars", func() {
+	Describe("SetPipelineVar", func() {
+		var expectedUpdates []atc.PipelineVarUpdate
+
+		BeforeEach(func() {
+			expectedUpdates = []atc.PipelineVarUpdate{
+				{Pipeline: atc.PipelineRef{Name: "some-pipeline"}, Updated: true},
+				{Pipeline: atc.PipelineRef{Name: "other-pipeline"}, Updated: false},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/teams/some-team/pipeline-vars/some-var"),
+					ghttp.VerifyJSONRepresenting(atc.SetPipelineVarRequest{
+						Value:  "some-value",
+						Create: true,
+					}),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedUpdates),
+				),
+			)
+		})
+
+		It("returns which pipelines were updated", func() {
+			updates, err := team.SetPipelineVar("some-var", atc.SetPipelineVarRequest{
+				Value:  "some-value",
+				Create: true,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updates).To(Equal(expectedUpdates))
+		})
+	})
+
+	Describe("ListPipelineVars", func() {
+		var expectedVars []atc.PipelineVars
+
+		BeforeEach(func() {
+			expectedVars = []atc.PipelineVars{
+				{Pipeline: atc.PipelineRef{Name: "some-pipeline"}, Vars: []string{"some-var"}},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/pipeline-vars"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedVars),
+				),
+			)
+		})
+
+		It("returns the names of each pipeline's vars", func() {
+			pipelineVars, err := team.ListPipelineVars()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipelineVars).To(Equal(expectedVars))
+		})
+	})
+
+	Describe("DeletePipelineVar", func() {
+		var expectedUpdates []atc.PipelineVarUpdate
+
+		BeforeEach(func() {
+			expectedUpdates = []atc.PipelineVarUpdate{
+				{Pipeline: atc.PipelineRef{Name: "some-pipeline"}, Updated: true},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/pipeline-vars/some-var", "pipeline=some-pipeline&pipeline=other-pipeline"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedUpdates),
+				),
+			)
+		})
+
+		It("returns which pipelines had the var", func() {
+			updates, err := team.DeletePipelineVar("some-var", []string{"some-pipeline", "other-pipeline"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updates).To(Equal(expectedUpdates))
+		})
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse/internal"
@@ -193,6 +194,21 @@ func (team *team) UnpausePipeline(pipelineRef atc.PipelineRef) (bool, error) {
 	return team.managePipeline(pipelineRef, atc.UnpausePipeline)
 }
 
+func (team *team) UnpausePipelineWithChecks(pipelineRef atc.PipelineRef, waitForChecks bool) (bool, error) {
+	query := pipelineRef.QueryParams()
+	if query == nil {
+		query = url.Values{}
+	}
+
+	if waitForChecks {
+		query.Set("wait_for_checks", "true")
+	} else {
+		query.Set("check_resources", "true")
+	}
+
+	return team.managePipelineWithQuery(pipelineRef, atc.UnpausePipeline, query)
+}
+
 func (team *team) ExposePipeline(pipelineRef atc.PipelineRef) (bool, error) {
 	return team.managePipeline(pipelineRef, atc.ExposePipeline)
 }
@@ -202,6 +218,10 @@ func (team *team) HidePipeline(pipelineRef atc.PipelineRef) (bool, error) {
 }
 
 func (team *team) managePipeline(pipelineRef atc.PipelineRef, endpoint string) (bool, error) {
+	return team.managePipelineWithQuery(pipelineRef, endpoint, pipelineRef.QueryParams())
+}
+
+func (team *team) managePipelineWithQuery(pipelineRef atc.PipelineRef, endpoint string, query url.Values) (bool, error) {
 	params := rata.Params{
 		"pipeline_name": pipelineRef.Name,
 		"team_name":     team.Name(),
@@ -209,7 +229,7 @@ func (team *team) managePipeline(pipelineRef atc.PipelineRef, endpoint string) (
 	err := team.connection.Send(internal.Request{
 		RequestName: endpoint,
 		Params:      params,
-		Query:       pipelineRef.QueryParams(),
+		Query:       query,
 	}, nil)
 
 	switch err.(type) {
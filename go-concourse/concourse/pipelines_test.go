@@ -134,6 +134,70 @@ var _ = Describe("ATC Handler Pipelines", func() {
 		})
 	})
 
+	Describe("UnpausePipelineWithChecks", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/unpause"
+		pipelineRef := atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+
+		var waitForChecks bool
+
+		BeforeEach(func() {
+			waitForChecks = false
+		})
+
+		Context("when not waiting for checks", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL, "check_resources=true&vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("asks for the resources to be checked", func() {
+				found, err := team.UnpausePipelineWithChecks(pipelineRef, waitForChecks)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when waiting for checks", func() {
+			BeforeEach(func() {
+				waitForChecks = true
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL, "vars.branch=%22master%22&wait_for_checks=true"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("asks for the checks to be awaited", func() {
+				found, err := team.UnpausePipelineWithChecks(pipelineRef, waitForChecks)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the pipeline doesn't exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				found, err := team.UnpausePipelineWithChecks(pipelineRef, waitForChecks)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("ExposePipeline", func() {
 
 		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/expose"
@@ -1,6 +1,8 @@
 package concourse
 
 import (
+	"net/http"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse/internal"
 	"github.com/tedsuo/rata"
@@ -48,3 +50,84 @@ func (team *team) ListResources(pipelineRef atc.PipelineRef) ([]atc.Resource, er
 
 	return resources, err
 }
+
+func (team *team) ListResourceChecks(pipelineRef atc.PipelineRef) ([]atc.ResourceCheckStatus, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	var statuses []atc.ResourceCheckStatus
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListResourceChecks,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, &internal.Response{
+		Result: &statuses,
+	})
+
+	return statuses, err
+}
+
+func (team *team) ListResourceCheckRecords(pipelineRef atc.PipelineRef, resourceName string, page Page) ([]atc.CheckRecord, Pagination, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"resource_name": resourceName,
+		"team_name":     team.Name(),
+	}
+
+	var records []atc.CheckRecord
+	headers := http.Header{}
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListResourceCheckRecords,
+		Params:      params,
+		Query:       merge(page.QueryParams(), pipelineRef.QueryParams()),
+	}, &internal.Response{
+		Result:  &records,
+		Headers: &headers,
+	})
+	switch err.(type) {
+	case nil:
+		pagination, err := paginationFromHeaders(headers)
+		if err != nil {
+			return records, Pagination{}, false, err
+		}
+
+		return records, pagination, true, nil
+	case internal.ResourceNotFoundError:
+		return records, Pagination{}, false, nil
+	default:
+		return records, Pagination{}, false, err
+	}
+}
+
+func (team *team) PauseResourceChecking(pipelineRef atc.PipelineRef, resourceName string) (bool, error) {
+	return team.manageResource(pipelineRef, resourceName, atc.PauseResourceChecking)
+}
+
+func (team *team) UnpauseResourceChecking(pipelineRef atc.PipelineRef, resourceName string) (bool, error) {
+	return team.manageResource(pipelineRef, resourceName, atc.UnpauseResourceChecking)
+}
+
+func (team *team) manageResource(pipelineRef atc.PipelineRef, resourceName string, endpoint string) (bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"resource_name": resourceName,
+		"team_name":     team.Name(),
+	}
+
+	err := team.connection.Send(internal.Request{
+		RequestName: endpoint,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
@@ -46,6 +47,122 @@ var _ = Describe("ATC Handler Resource", func() {
 		})
 	})
 
+	Describe("team.ListResourceChecks", func() {
+		var (
+			expectedStatuses []atc.ResourceCheckStatus
+
+			expectedURL   = "/api/v1/teams/some-team/pipelines/some-pipeline/resource-checks"
+			expectedQuery = "vars.branch=%22master%22"
+			pipelineRef   = atc.PipelineRef{Name: "some-pipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+		)
+
+		BeforeEach(func() {
+			expectedStatuses = []atc.ResourceCheckStatus{
+				{
+					Name:        "resource-1",
+					LastChecked: 1513364881,
+					Succeeded:   true,
+					NextCheck:   1513364941,
+				},
+				{
+					Name:        "resource-2",
+					Unscheduled: atc.CheckUnscheduledNever,
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL, expectedQuery),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedStatuses),
+				),
+			)
+		})
+
+		It("returns the check status of the pipeline's resources", func() {
+			statuses, err := team.ListResourceChecks(pipelineRef)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statuses).To(Equal(expectedStatuses))
+		})
+	})
+
+	Describe("team.ListResourceCheckRecords", func() {
+		var (
+			expectedRecords []atc.CheckRecord
+
+			expectedURL = "/api/v1/teams/some-team/pipelines/some-pipeline/resources/some-resource/checks"
+			pipelineRef = atc.PipelineRef{Name: "some-pipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+
+			records    []atc.CheckRecord
+			pagination concourse.Pagination
+			found      bool
+			clientErr  error
+		)
+
+		BeforeEach(func() {
+			expectedRecords = []atc.CheckRecord{
+				{
+					ID:        2,
+					Status:    atc.StatusStarted,
+					StartTime: 1513364900,
+				},
+				{
+					ID:            1,
+					Status:        atc.StatusSucceeded,
+					StartTime:     1513364881,
+					EndTime:       1513364890,
+					VersionsAdded: 2,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			records, pagination, found, clientErr = team.ListResourceCheckRecords(pipelineRef, "some-resource", concourse.Page{To: 10, Limit: 2})
+		})
+
+		Context("when the resource exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "limit=2&to=10&vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedRecords, http.Header{
+							"Link": []string{
+								`<http://some-url.com/api/v1/teams/some-team/pipelines/some-pipeline/resources/some-resource/checks?from=3&limit=2>; rel="previous"`,
+								`<http://some-url.com/api/v1/teams/some-team/pipelines/some-pipeline/resources/some-resource/checks?to=0&limit=2>; rel="next"`,
+							},
+						}),
+					),
+				)
+			})
+
+			It("returns the check records of the resource", func() {
+				Expect(clientErr).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(records).To(Equal(expectedRecords))
+			})
+
+			It("returns the pagination data from the header", func() {
+				Expect(pagination.Previous).To(Equal(&concourse.Page{From: 3, Limit: 2}))
+				Expect(pagination.Next).To(Equal(&concourse.Page{To: 0, Limit: 2}))
+			})
+		})
+
+		Context("when the resource does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				Expect(clientErr).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("Resource", func() {
 		var (
 			expectedResource atc.Resource
@@ -117,4 +234,110 @@ var _ = Describe("ATC Handler Resource", func() {
 			})
 		})
 	})
+
+	Describe("team.PauseResourceChecking", func() {
+		var (
+			expectedStatus int
+			expectedURL    = "/api/v1/teams/some-team/pipelines/mypipeline/resources/myresource/pause_checking"
+			expectedQuery  = "vars.branch=%22master%22"
+			pipelineRef    = atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+		)
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", expectedURL, expectedQuery),
+					ghttp.RespondWith(expectedStatus, nil),
+				),
+			)
+		})
+
+		Context("when the resource exists", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusOK
+			})
+
+			It("returns true", func() {
+				found, err := team.PauseResourceChecking(pipelineRef, "myresource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the resource does not exist", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusNotFound
+			})
+
+			It("returns false", func() {
+				found, err := team.PauseResourceChecking(pipelineRef, "myresource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the resource has not been checked yet", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusConflict
+			})
+
+			It("returns an error", func() {
+				_, err := team.PauseResourceChecking(pipelineRef, "myresource")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("team.UnpauseResourceChecking", func() {
+		var (
+			expectedStatus int
+			expectedURL    = "/api/v1/teams/some-team/pipelines/mypipeline/resources/myresource/unpause_checking"
+			expectedQuery  = "vars.branch=%22master%22"
+			pipelineRef    = atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+		)
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", expectedURL, expectedQuery),
+					ghttp.RespondWith(expectedStatus, nil),
+				),
+			)
+		})
+
+		Context("when the resource exists", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusOK
+			})
+
+			It("returns true", func() {
+				found, err := team.UnpauseResourceChecking(pipelineRef, "myresource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the resource does not exist", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusNotFound
+			})
+
+			It("returns false", func() {
+				found, err := team.UnpauseResourceChecking(pipelineRef, "myresource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the resource has not been checked yet", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusConflict
+			})
+
+			It("returns an error", func() {
+				_, err := team.UnpauseResourceChecking(pipelineRef, "myresource")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
 })
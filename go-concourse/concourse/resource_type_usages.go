@@ -0,0 +1,32 @@
+package concourse
+
+import (
+	"net/url"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+)
+
+func (client *client) ListResourceTypeUsages(typeName string, image string) ([]atc.ResourceTypeUsage, error) {
+	queryParams := url.Values{}
+	if typeName != "" {
+		queryParams.Add("type", typeName)
+	}
+
+	if image != "" {
+		queryParams.Add("image", image)
+	}
+
+	var usages []atc.ResourceTypeUsage
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListResourceTypeUsages,
+		Query:       queryParams,
+	}, &internal.Response{
+		Result: &usages,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return usages, nil
+}
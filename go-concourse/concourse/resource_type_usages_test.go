@@ -0,0 +1,68 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ListResourceTypeUsages", func() {
+	var expectedUsages []atc.ResourceTypeUsage
+
+	BeforeEach(func() {
+		expectedUsages = []atc.ResourceTypeUsage{
+			{
+				TeamName:     "some-team",
+				PipelineID:   1,
+				PipelineName: "some-pipeline",
+				ResourceName: "some-repo",
+				Type:         "custom-git",
+			},
+			{
+				TeamName:         "some-team",
+				PipelineID:       1,
+				PipelineName:     "some-pipeline",
+				ResourceTypeName: "custom-git",
+				Type:             "registry-image",
+				Image:            "example/custom-git-resource",
+			},
+		}
+	})
+
+	Context("when given a type", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/resource-type-usages", "type=custom-git"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedUsages),
+				),
+			)
+		})
+
+		It("returns the usages of the type", func() {
+			usages, err := client.ListResourceTypeUsages("custom-git", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usages).To(Equal(expectedUsages))
+		})
+	})
+
+	Context("when given an image", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/resource-type-usages", "image=example%2Fcustom-git-resource"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedUsages),
+				),
+			)
+		})
+
+		It("returns the usages of the image", func() {
+			usages, err := client.ListResourceTypeUsages("", "example/custom-git-resource")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usages).To(Equal(expectedUsages))
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/concourse/concourse/atc"
@@ -50,16 +51,145 @@ func (team *team) ResourceVersions(pipelineRef atc.PipelineRef, resourceName str
 	}
 }
 
-func (team *team) DisableResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error) {
-	return team.sendResourceVersion(pipelineRef, resourceName, resourceVersionID, atc.DisableResourceVersion)
+func (team *team) DisableResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int, reason string) (bool, error) {
+	return team.sendResourceVersion(pipelineRef, resourceName, resourceVersionID, atc.DisableResourceVersion, reasonQuery(reason))
 }
 
-func (team *team) EnableResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error) {
-	return team.sendResourceVersion(pipelineRef, resourceName, resourceVersionID, atc.EnableResourceVersion)
+func (team *team) EnableResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int, reason string) (bool, error) {
+	return team.sendResourceVersion(pipelineRef, resourceName, resourceVersionID, atc.EnableResourceVersion, reasonQuery(reason))
 }
 
-func (team *team) PinResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error) {
-	return team.sendResourceVersion(pipelineRef, resourceName, resourceVersionID, atc.PinResourceVersion)
+func (team *team) PromoteResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error) {
+	return team.sendResourceVersion(pipelineRef, resourceName, resourceVersionID, atc.PromoteResourceVersion, url.Values{})
+}
+
+func (team *team) UnpromoteResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error) {
+	return team.sendResourceVersion(pipelineRef, resourceName, resourceVersionID, atc.UnpromoteResourceVersion, url.Values{})
+}
+
+func (team *team) ResourceVersionToggles(pipelineRef atc.PipelineRef, resourceName string) ([]atc.ResourceVersionToggle, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"resource_name": resourceName,
+		"team_name":     team.Name(),
+	}
+
+	var toggles []atc.ResourceVersionToggle
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListResourceVersionToggles,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, &internal.Response{
+		Result: &toggles,
+	})
+
+	switch err.(type) {
+	case nil:
+		return toggles, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
+
+func (team *team) PinResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int, comment string) (bool, error) {
+	params := rata.Params{
+		"pipeline_name":              pipelineRef.Name,
+		"resource_name":              resourceName,
+		"resource_config_version_id": strconv.Itoa(resourceVersionID),
+		"team_name":                  team.Name(),
+	}
+
+	buffer := &bytes.Buffer{}
+	err := json.NewEncoder(buffer).Encode(atc.PinVersionRequestBody{
+		PinComment: comment,
+	})
+	if err != nil {
+		return false, fmt.Errorf("Unable to marshal comment: %s", err)
+	}
+
+	err = team.connection.Send(internal.Request{
+		RequestName: atc.PinResourceVersion,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+		Params: params,
+		Query:  pipelineRef.QueryParams(),
+		Body:   buffer,
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (team *team) BackfillResourceVersions(pipelineRef atc.PipelineRef, resourceName string, versions []atc.Version) (int, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"resource_name": resourceName,
+		"team_name":     team.Name(),
+	}
+
+	buffer := &bytes.Buffer{}
+	err := json.NewEncoder(buffer).Encode(versions)
+	if err != nil {
+		return 0, false, fmt.Errorf("Unable to marshal versions: %s", err)
+	}
+
+	var response atc.BackfillVersionsResponse
+	err = team.connection.Send(internal.Request{
+		RequestName: atc.BackfillResourceVersions,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+		Params: params,
+		Query:  pipelineRef.QueryParams(),
+		Body:   buffer,
+	}, &internal.Response{
+		Result: &response,
+	})
+
+	switch err.(type) {
+	case nil:
+		return response.NewVersions, true, nil
+	case internal.ResourceNotFoundError:
+		return 0, false, nil
+	default:
+		return 0, false, err
+	}
+}
+
+func (team *team) ExpireResourceVersionCaches(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (atc.ExpireResourceCachesResponse, bool, error) {
+	params := rata.Params{
+		"pipeline_name":              pipelineRef.Name,
+		"resource_name":              resourceName,
+		"resource_config_version_id": strconv.Itoa(resourceVersionID),
+		"team_name":                  team.Name(),
+	}
+
+	var response atc.ExpireResourceCachesResponse
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ExpireResourceVersionCaches,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, &internal.Response{
+		Result: &response,
+	})
+
+	switch err.(type) {
+	case nil:
+		return response, true, nil
+	case internal.ResourceNotFoundError:
+		return atc.ExpireResourceCachesResponse{}, false, nil
+	default:
+		return atc.ExpireResourceCachesResponse{}, false, err
+	}
 }
 
 func (team *team) UnpinResource(pipelineRef atc.PipelineRef, resourceName string) (bool, error) {
@@ -123,7 +253,7 @@ func (team *team) SetPinComment(pipelineRef atc.PipelineRef, resourceName string
 
 }
 
-func (team *team) sendResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int, resourceVersionReq string) (bool, error) {
+func (team *team) sendResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int, resourceVersionReq string, query url.Values) (bool, error) {
 	params := rata.Params{
 		"pipeline_name":              pipelineRef.Name,
 		"resource_name":              resourceName,
@@ -134,7 +264,7 @@ func (team *team) sendResourceVersion(pipelineRef atc.PipelineRef, resourceName
 	err := team.connection.Send(internal.Request{
 		RequestName: resourceVersionReq,
 		Params:      params,
-		Query:       pipelineRef.QueryParams(),
+		Query:       merge(query, pipelineRef.QueryParams()),
 	}, nil)
 
 	switch err.(type) {
@@ -146,3 +276,12 @@ func (team *team) sendResourceVersion(pipelineRef atc.PipelineRef, resourceName
 		return false, err
 	}
 }
+
+func reasonQuery(reason string) url.Values {
+	query := url.Values{}
+	if reason != "" {
+		query.Set("reason", reason)
+	}
+
+	return query
+}
@@ -218,7 +218,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 			resourceName      = "myresource"
 			resourceVersionID = 42
 			expectedURL       = fmt.Sprintf("/api/v1/teams/some-team/pipelines/%s/resources/%s/versions/%s/disable", pipelineName, resourceName, strconv.Itoa(resourceVersionID))
-			expectedQuery     = "vars.branch=%22master%22"
+			expectedQuery     = "reason=bad+build&vars.branch=%22master%22"
 			pipelineRef       = atc.PipelineRef{Name: pipelineName, InstanceVars: atc.InstanceVars{"branch": "master"}}
 		)
 
@@ -238,7 +238,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the disable resource and returns no error", func() {
 				Expect(func() {
-					disabled, err := team.DisableResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					disabled, err := team.DisableResourceVersion(pipelineRef, resourceName, resourceVersionID, "bad build")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(disabled).To(BeTrue())
 				}).To(Change(func() int {
@@ -254,7 +254,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the disable resource and returns an error", func() {
 				Expect(func() {
-					disabled, err := team.DisableResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					disabled, err := team.DisableResourceVersion(pipelineRef, resourceName, resourceVersionID, "bad build")
 					Expect(err).To(HaveOccurred())
 					Expect(disabled).To(BeFalse())
 				}).To(Change(func() int {
@@ -270,7 +270,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the disable resource and returns an error", func() {
 				Expect(func() {
-					disabled, err := team.DisableResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					disabled, err := team.DisableResourceVersion(pipelineRef, resourceName, resourceVersionID, "bad build")
 					Expect(err).ToNot(HaveOccurred())
 					Expect(disabled).To(BeFalse())
 				}).To(Change(func() int {
@@ -280,6 +280,144 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 		})
 	})
 
+	Describe("PromoteResourceVersion", func() {
+		var (
+			expectedStatus    int
+			pipelineName      = "banana"
+			resourceName      = "myresource"
+			resourceVersionID = 42
+			expectedURL       = fmt.Sprintf("/api/v1/teams/some-team/pipelines/%s/resources/%s/versions/%s/promote", pipelineName, resourceName, strconv.Itoa(resourceVersionID))
+			expectedQuery     = "vars.branch=%22master%22"
+			pipelineRef       = atc.PipelineRef{Name: pipelineName, InstanceVars: atc.InstanceVars{"branch": "master"}}
+		)
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", expectedURL, expectedQuery),
+					ghttp.RespondWith(expectedStatus, nil),
+				),
+			)
+		})
+
+		Context("when the resource exists and there are no issues", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusOK
+			})
+
+			It("calls the promote resource version and returns no error", func() {
+				Expect(func() {
+					promoted, err := team.PromoteResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(promoted).To(BeTrue())
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(1))
+			})
+		})
+
+		Context("when the promote resource version call fails", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusInternalServerError
+			})
+
+			It("calls the promote resource version and returns an error", func() {
+				Expect(func() {
+					promoted, err := team.PromoteResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					Expect(err).To(HaveOccurred())
+					Expect(promoted).To(BeFalse())
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(1))
+			})
+		})
+
+		Context("when the resource does not exist", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusNotFound
+			})
+
+			It("calls the promote resource version and returns an error", func() {
+				Expect(func() {
+					promoted, err := team.PromoteResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(promoted).To(BeFalse())
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(1))
+			})
+		})
+	})
+
+	Describe("UnpromoteResourceVersion", func() {
+		var (
+			expectedStatus    int
+			pipelineName      = "banana"
+			resourceName      = "myresource"
+			resourceVersionID = 42
+			expectedURL       = fmt.Sprintf("/api/v1/teams/some-team/pipelines/%s/resources/%s/versions/%s/unpromote", pipelineName, resourceName, strconv.Itoa(resourceVersionID))
+			expectedQuery     = "vars.branch=%22master%22"
+			pipelineRef       = atc.PipelineRef{Name: pipelineName, InstanceVars: atc.InstanceVars{"branch": "master"}}
+		)
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", expectedURL, expectedQuery),
+					ghttp.RespondWith(expectedStatus, nil),
+				),
+			)
+		})
+
+		Context("when the resource exists and there are no issues", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusOK
+			})
+
+			It("calls the unpromote resource version and returns no error", func() {
+				Expect(func() {
+					unpromoted, err := team.UnpromoteResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(unpromoted).To(BeTrue())
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(1))
+			})
+		})
+
+		Context("when the unpromote resource version call fails", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusInternalServerError
+			})
+
+			It("calls the unpromote resource version and returns an error", func() {
+				Expect(func() {
+					unpromoted, err := team.UnpromoteResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					Expect(err).To(HaveOccurred())
+					Expect(unpromoted).To(BeFalse())
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(1))
+			})
+		})
+
+		Context("when the resource does not exist", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusNotFound
+			})
+
+			It("calls the unpromote resource version and returns an error", func() {
+				Expect(func() {
+					unpromoted, err := team.UnpromoteResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(unpromoted).To(BeFalse())
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(1))
+			})
+		})
+	})
+
 	Describe("EnableResourceVersion", func() {
 		var (
 			expectedStatus    int
@@ -287,7 +425,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 			resourceName      = "myresource"
 			resourceVersionID = 42
 			expectedURL       = fmt.Sprintf("/api/v1/teams/some-team/pipelines/%s/resources/%s/versions/%s/enable", pipelineName, resourceName, strconv.Itoa(resourceVersionID))
-			expectedQuery     = "vars.branch=%22master%22"
+			expectedQuery     = "reason=bad+build&vars.branch=%22master%22"
 			pipelineRef       = atc.PipelineRef{Name: pipelineName, InstanceVars: atc.InstanceVars{"branch": "master"}}
 		)
 
@@ -307,7 +445,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the enable resource and returns no error", func() {
 				Expect(func() {
-					enabled, err := team.EnableResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					enabled, err := team.EnableResourceVersion(pipelineRef, resourceName, resourceVersionID, "bad build")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(enabled).To(BeTrue())
 				}).To(Change(func() int {
@@ -323,7 +461,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the enable resource and returns an error", func() {
 				Expect(func() {
-					enabled, err := team.EnableResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					enabled, err := team.EnableResourceVersion(pipelineRef, resourceName, resourceVersionID, "bad build")
 					Expect(err).To(HaveOccurred())
 					Expect(enabled).To(BeFalse())
 				}).To(Change(func() int {
@@ -339,7 +477,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the enable resource and returns an error", func() {
 				Expect(func() {
-					enabled, err := team.EnableResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					enabled, err := team.EnableResourceVersion(pipelineRef, resourceName, resourceVersionID, "bad build")
 					Expect(err).ToNot(HaveOccurred())
 					Expect(enabled).To(BeFalse())
 				}).To(Change(func() int {
@@ -349,10 +487,195 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 		})
 	})
 
+	Describe("ResourceVersionToggles", func() {
+		var (
+			expectedStatus int
+			expectedURL    = "/api/v1/teams/some-team/pipelines/banana/resources/myresource/version-toggles"
+			expectedQuery  = "vars.branch=%22master%22"
+			pipelineRef    = atc.PipelineRef{Name: "banana", InstanceVars: atc.InstanceVars{"branch": "master"}}
+
+			expectedToggles = []atc.ResourceVersionToggle{
+				{
+					ID:      2,
+					Version: atc.Version{"some": "version"},
+					Enabled: true,
+					Actor:   "other-user",
+					Time:    1513364900,
+				},
+				{
+					ID:      1,
+					Version: atc.Version{"some": "version"},
+					Enabled: false,
+					Actor:   "some-user",
+					Reason:  "bad build",
+					Time:    1513364800,
+				},
+			}
+		)
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL, expectedQuery),
+					ghttp.RespondWithJSONEncoded(expectedStatus, expectedToggles),
+				),
+			)
+		})
+
+		Context("when the resource exists", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusOK
+			})
+
+			It("returns the toggles", func() {
+				toggles, found, err := team.ResourceVersionToggles(pipelineRef, "myresource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(toggles).To(Equal(expectedToggles))
+			})
+		})
+
+		Context("when the resource does not exist", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusNotFound
+			})
+
+			It("returns not found", func() {
+				_, found, err := team.ResourceVersionToggles(pipelineRef, "myresource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the call fails", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusInternalServerError
+			})
+
+			It("returns an error", func() {
+				_, _, err := team.ResourceVersionToggles(pipelineRef, "myresource")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("BackfillResourceVersions", func() {
+		var (
+			expectedStatus int
+			expectedBody   interface{}
+			pipelineRef    = atc.PipelineRef{Name: "banana", InstanceVars: atc.InstanceVars{"branch": "master"}}
+			versions       = []atc.Version{{"ref": "old1"}, {"ref": "old2"}}
+		)
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/teams/some-team/pipelines/banana/resources/myresource/versions", "vars.branch=%22master%22"),
+					ghttp.VerifyJSONRepresenting(versions),
+					ghttp.RespondWithJSONEncoded(expectedStatus, expectedBody),
+				),
+			)
+		})
+
+		Context("when the versions are backfilled", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusOK
+				expectedBody = atc.BackfillVersionsResponse{NewVersions: 2}
+			})
+
+			It("returns the number of new versions", func() {
+				newVersions, found, err := team.BackfillResourceVersions(pipelineRef, "myresource", versions)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(newVersions).To(Equal(2))
+			})
+		})
+
+		Context("when the resource does not exist", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusNotFound
+				expectedBody = nil
+			})
+
+			It("returns false", func() {
+				_, found, err := team.BackfillResourceVersions(pipelineRef, "myresource", versions)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the call fails", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusConflict
+				expectedBody = nil
+			})
+
+			It("returns an error", func() {
+				_, _, err := team.BackfillResourceVersions(pipelineRef, "myresource", versions)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("ExpireResourceVersionCaches", func() {
+		var (
+			expectedStatus int
+			expectedBody   interface{}
+			pipelineRef    = atc.PipelineRef{Name: "banana", InstanceVars: atc.InstanceVars{"branch": "master"}}
+		)
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/pipelines/banana/resources/myresource/versions/42/caches", "vars.branch=%22master%22"),
+					ghttp.RespondWithJSONEncoded(expectedStatus, expectedBody),
+				),
+			)
+		})
+
+		Context("when the caches are expired", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusOK
+				expectedBody = atc.ExpireResourceCachesResponse{ResourceCaches: 2, Volumes: 3}
+			})
+
+			It("returns the number of expired caches and volumes", func() {
+				expired, found, err := team.ExpireResourceVersionCaches(pipelineRef, "myresource", 42)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(expired).To(Equal(atc.ExpireResourceCachesResponse{ResourceCaches: 2, Volumes: 3}))
+			})
+		})
+
+		Context("when the resource does not exist", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusNotFound
+				expectedBody = nil
+			})
+
+			It("returns false", func() {
+				_, found, err := team.ExpireResourceVersionCaches(pipelineRef, "myresource", 42)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the call fails", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusInternalServerError
+				expectedBody = nil
+			})
+
+			It("returns an error", func() {
+				_, _, err := team.ExpireResourceVersionCaches(pipelineRef, "myresource", 42)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("PinResourceVersion", func() {
 		var (
 			expectedStatus    int
-			expectedBody      []byte
 			pipelineName      = "banana"
 			resourceName      = "myresource"
 			resourceVersionID = 42
@@ -365,7 +688,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 			atcServer.AppendHandlers(
 				ghttp.CombineHandlers(
 					ghttp.VerifyRequest("PUT", expectedURL, expectedQuery),
-					ghttp.VerifyBody(expectedBody),
+					ghttp.VerifyJSONRepresenting(atc.PinVersionRequestBody{PinComment: "some comment"}),
 					ghttp.RespondWith(expectedStatus, nil),
 				),
 			)
@@ -378,7 +701,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the pin resource and returns no error", func() {
 				Expect(func() {
-					pinned, err := team.PinResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					pinned, err := team.PinResourceVersion(pipelineRef, resourceName, resourceVersionID, "some comment")
 					Expect(err).ToNot(HaveOccurred())
 					Expect(pinned).To(BeTrue())
 				}).To(Change(func() int {
@@ -394,7 +717,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the pin resource and returns an error", func() {
 				Expect(func() {
-					pinned, err := team.PinResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					pinned, err := team.PinResourceVersion(pipelineRef, resourceName, resourceVersionID, "some comment")
 					Expect(err).ToNot(HaveOccurred())
 					Expect(pinned).To(BeFalse())
 				}).To(Change(func() int {
@@ -410,7 +733,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the pin resource and returns an error", func() {
 				Expect(func() {
-					pinned, err := team.PinResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					pinned, err := team.PinResourceVersion(pipelineRef, resourceName, resourceVersionID, "some comment")
 					Expect(err).To(HaveOccurred())
 					Expect(pinned).To(BeFalse())
 				}).To(Change(func() int {
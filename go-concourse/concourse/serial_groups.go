@@ -0,0 +1,25 @@
+package concourse
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) ListSerialGroups(pipelineRef atc.PipelineRef) ([]atc.SerialGroup, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	var groups []atc.SerialGroup
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListSerialGroups,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, &internal.Response{
+		Result: &groups,
+	})
+
+	return groups, err
+}
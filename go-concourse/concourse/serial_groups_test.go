@@ -0,0 +1,43 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Serial Groups", func() {
+	Describe("ListSerialGroups", func() {
+		var expectedGroups []atc.SerialGroup
+
+		BeforeEach(func() {
+			expectedGroups = []atc.SerialGroup{
+				{
+					Name:          "deployments",
+					Jobs:          []string{"deploy", "smoke"},
+					RunningBuilds: []atc.Build{{ID: 1, Name: "4", JobName: "deploy", Status: atc.StatusStarted}},
+					PendingBuilds: []atc.Build{{ID: 2, Name: "7", JobName: "smoke", Status: atc.StatusPending}},
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/pipelines/some-pipeline/serial-groups", "vars.branch=%22master%22"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedGroups),
+				),
+			)
+		})
+
+		It("returns the serial groups of the pipeline", func() {
+			groups, err := team.ListSerialGroups(atc.PipelineRef{
+				Name:         "some-pipeline",
+				InstanceVars: atc.InstanceVars{"branch": "master"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(groups).To(Equal(expectedGroups))
+		})
+	})
+})
@@ -0,0 +1,53 @@
+package concourse
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) ListSharedArtifacts() ([]atc.SharedArtifact, error) {
+	var artifacts []atc.SharedArtifact
+
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListSharedArtifacts,
+		Params:      params,
+	}, &internal.Response{
+		Result: &artifacts,
+	})
+
+	return artifacts, err
+}
+
+func (team *team) GrantSharedArtifact(artifactName string, granteeTeamName string) (bool, error) {
+	return team.sendSharedArtifactGrant(atc.GrantSharedArtifact, artifactName, granteeTeamName)
+}
+
+func (team *team) RevokeSharedArtifact(artifactName string, granteeTeamName string) (bool, error) {
+	return team.sendSharedArtifactGrant(atc.RevokeSharedArtifact, artifactName, granteeTeamName)
+}
+
+func (team *team) sendSharedArtifactGrant(requestName string, artifactName string, granteeTeamName string) (bool, error) {
+	params := rata.Params{
+		"team_name":         team.Name(),
+		"artifact_name":     artifactName,
+		"grantee_team_name": granteeTeamName,
+	}
+
+	err := team.connection.Send(internal.Request{
+		RequestName: requestName,
+		Params:      params,
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
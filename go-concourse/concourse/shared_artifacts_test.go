@@ -0,0 +1,97 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Shared Artifacts", func() {
+	Describe("ListSharedArtifacts", func() {
+		var expectedArtifacts []atc.SharedArtifact
+
+		BeforeEach(func() {
+			expectedArtifacts = []atc.SharedArtifact{
+				{
+					Name:         "some-artifact",
+					BuildID:      42,
+					Size:         1024,
+					PublishedAt:  100,
+					GrantedTeams: []string{"other-team"},
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/shared-artifacts"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedArtifacts),
+				),
+			)
+		})
+
+		It("returns the team's shared artifacts", func() {
+			artifacts, err := team.ListSharedArtifacts()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(artifacts).To(Equal(expectedArtifacts))
+		})
+	})
+
+	Describe("GrantSharedArtifact", func() {
+		expectedURL := "/api/v1/teams/some-team/shared-artifacts/some-artifact/grants/other-team"
+
+		Context("when the grant succeeds", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("returns true", func() {
+				found, err := team.GrantSharedArtifact("some-artifact", "other-team")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the grantee team does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				found, err := team.GrantSharedArtifact("some-artifact", "other-team")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("RevokeSharedArtifact", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/shared-artifacts/some-artifact/grants/other-team"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("revokes the grant", func() {
+			found, err := team.RevokeSharedArtifact("some-artifact", "other-team")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+})
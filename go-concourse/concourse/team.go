@@ -24,22 +24,36 @@ type Team interface {
 	PausePipeline(pipelineRef atc.PipelineRef) (bool, error)
 	ArchivePipeline(pipelineRef atc.PipelineRef) (bool, error)
 	UnpausePipeline(pipelineRef atc.PipelineRef) (bool, error)
+	UnpausePipelineWithChecks(pipelineRef atc.PipelineRef, waitForChecks bool) (bool, error)
 	ExposePipeline(pipelineRef atc.PipelineRef) (bool, error)
 	HidePipeline(pipelineRef atc.PipelineRef) (bool, error)
 	RenamePipeline(oldName, newName string) (bool, []ConfigWarning, error)
 	ListPipelines() ([]atc.Pipeline, error)
 	PipelineConfig(pipelineRef atc.PipelineRef) (atc.Config, string, bool, error)
 	CreateOrUpdatePipelineConfig(pipelineRef atc.PipelineRef, configVersion string, passedConfig []byte, checkCredentials bool) (bool, bool, []ConfigWarning, error)
+	PatchPipelineConfig(pipelineRef atc.PipelineRef, configVersion string, patch []byte) (string, []ConfigWarning, bool, error)
+	ResourceTypeClosure(pipelineRef atc.PipelineRef, passedConfig []byte) (atc.ResourceTypeClosure, error)
+
+	ListVersionSets(pipelineRef atc.PipelineRef) ([]atc.VersionSet, bool, error)
+	SaveVersionSet(pipelineRef atc.PipelineRef, name string) (atc.VersionSet, bool, error)
+	ApplyVersionSet(pipelineRef atc.PipelineRef, name string) (bool, error)
+	ClearVersionSet(pipelineRef atc.PipelineRef, name string) (bool, error)
+	DestroyVersionSet(pipelineRef atc.PipelineRef, name string) (bool, error)
+
+	ListSerialGroups(pipelineRef atc.PipelineRef) ([]atc.SerialGroup, error)
 
 	CreatePipelineBuild(pipelineRef atc.PipelineRef, plan atc.Plan) (atc.Build, error)
 
 	BuildInputsForJob(pipelineRef atc.PipelineRef, jobName string) ([]atc.BuildInput, bool, error)
 
 	Job(pipelineRef atc.PipelineRef, jobName string) (atc.Job, bool, error)
+	JobSchedulerDecision(pipelineRef atc.PipelineRef, jobName string) (atc.SchedulerDecision, bool, error)
 	JobBuild(pipelineRef atc.PipelineRef, jobName, buildName string) (atc.Build, bool, error)
 	JobBuilds(pipelineRef atc.PipelineRef, jobName string, page Page) ([]atc.Build, Pagination, bool, error)
 	CreateJobBuild(pipelineRef atc.PipelineRef, jobName string) (atc.Build, error)
+	CreateJobBuildWithVars(pipelineRef atc.PipelineRef, jobName string, trigger atc.TriggerJobBuildRequest) (atc.Build, error)
 	RerunJobBuild(pipelineRef atc.PipelineRef, jobName string, buildName string) (atc.Build, error)
+	RerunJobBuildWithLatestIfUnavailable(pipelineRef atc.PipelineRef, jobName string, buildName string) (atc.Build, error)
 	ListJobs(pipelineRef atc.PipelineRef) ([]atc.Job, error)
 	ScheduleJob(pipelineRef atc.PipelineRef, jobName string) (bool, error)
 
@@ -50,16 +64,26 @@ type Team interface {
 
 	Resource(pipelineRef atc.PipelineRef, resourceName string) (atc.Resource, bool, error)
 	ListResources(pipelineRef atc.PipelineRef) ([]atc.Resource, error)
+	ListResourceChecks(pipelineRef atc.PipelineRef) ([]atc.ResourceCheckStatus, error)
+	ListResourceCheckRecords(pipelineRef atc.PipelineRef, resourceName string, page Page) ([]atc.CheckRecord, Pagination, bool, error)
+	ListPendingChecks(pipelineRef atc.PipelineRef) ([]atc.PendingCheck, error)
 	VersionedResourceTypes(pipelineRef atc.PipelineRef) (atc.VersionedResourceTypes, bool, error)
 	ResourceVersions(pipelineRef atc.PipelineRef, resourceName string, page Page, filter atc.Version) ([]atc.ResourceVersion, Pagination, bool, error)
 	CheckResource(pipelineRef atc.PipelineRef, resourceName string, version atc.Version) (atc.Build, bool, error)
 	CheckResourceType(pipelineRef atc.PipelineRef, resourceTypeName string, version atc.Version) (atc.Build, bool, error)
-	DisableResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error)
-	EnableResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error)
+	DisableResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int, reason string) (bool, error)
+	EnableResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int, reason string) (bool, error)
+	ResourceVersionToggles(pipelineRef atc.PipelineRef, resourceName string) ([]atc.ResourceVersionToggle, bool, error)
+	PromoteResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error)
+	UnpromoteResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error)
 
-	PinResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error)
+	PinResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int, comment string) (bool, error)
 	UnpinResource(pipelineRef atc.PipelineRef, resourceName string) (bool, error)
+	BackfillResourceVersions(pipelineRef atc.PipelineRef, resourceName string, versions []atc.Version) (int, bool, error)
+	ExpireResourceVersionCaches(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (atc.ExpireResourceCachesResponse, bool, error)
 	SetPinComment(pipelineRef atc.PipelineRef, resourceName string, comment string) (bool, error)
+	PauseResourceChecking(pipelineRef atc.PipelineRef, resourceName string) (bool, error)
+	UnpauseResourceChecking(pipelineRef atc.PipelineRef, resourceName string) (bool, error)
 
 	BuildsWithVersionAsInput(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) ([]atc.Build, bool, error)
 	BuildsWithVersionAsOutput(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) ([]atc.Build, bool, error)
@@ -74,6 +98,17 @@ type Team interface {
 
 	CreateArtifact(io.Reader, string, []string) (atc.WorkerArtifact, error)
 	GetArtifact(int) (io.ReadCloser, error)
+
+	ListSharedArtifacts() ([]atc.SharedArtifact, error)
+	GrantSharedArtifact(artifactName string, granteeTeamName string) (bool, error)
+	RevokeSharedArtifact(artifactName string, granteeTeamName string) (bool, error)
+
+	TeamResourceTypes() (atc.ResourceTypes, error)
+	SetTeamResourceTypes(atc.ResourceTypes) ([]ConfigWarning, error)
+
+	ListPipelineVars() ([]atc.PipelineVars, error)
+	SetPipelineVar(varName string, request atc.SetPipelineVarRequest) ([]atc.PipelineVarUpdate, error)
+	DeletePipelineVar(varName string, pipelines []string) ([]atc.PipelineVarUpdate, error)
 }
 
 type team struct {
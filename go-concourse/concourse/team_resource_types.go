@@ -0,0 +1,81 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) TeamResourceTypes() (atc.ResourceTypes, error) {
+	var resourceTypes atc.ResourceTypes
+
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.GetTeamResourceTypes,
+		Params:      params,
+	}, &internal.Response{
+		Result: &resourceTypes,
+	})
+
+	return resourceTypes, err
+}
+
+func (team *team) SetTeamResourceTypes(resourceTypes atc.ResourceTypes) ([]ConfigWarning, error) {
+	payload, err := json.Marshal(resourceTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+	response, err := team.httpAgent.Send(internal.Request{
+		ReturnResponseBody: true,
+		RequestName:        atc.SetTeamResourceTypes,
+		Params:             params,
+		Body:               bytes.NewBuffer(payload),
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+	body, _ := ioutil.ReadAll(response.Body)
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		var saveResponse setConfigResponse
+		err = json.Unmarshal(body, &saveResponse)
+		if err != nil {
+			return nil, err
+		}
+		return saveResponse.Warnings, nil
+	case http.StatusBadRequest:
+		var validationErr atc.SaveConfigResponse
+		err = json.Unmarshal(body, &validationErr)
+		if err != nil {
+			return nil, err
+		}
+		return nil, InvalidConfigError{Errors: validationErr.Errors}
+	case http.StatusForbidden:
+		return nil, internal.ForbiddenError{
+			Reason: string(body),
+		}
+	default:
+		return nil, internal.UnexpectedResponseError{
+			StatusCode: response.StatusCode,
+			Status:     response.Status,
+			Body:       string(body),
+		}
+	}
+}
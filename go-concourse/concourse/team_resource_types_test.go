@@ -0,0 +1,89 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Team Resource Types", func() {
+	var resourceTypes atc.ResourceTypes
+
+	BeforeEach(func() {
+		resourceTypes = atc.ResourceTypes{
+			{
+				Name:   "some-type",
+				Type:   "registry-image",
+				Source: atc.Source{"repository": "some/image"},
+			},
+		}
+	})
+
+	Describe("TeamResourceTypes", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/resource-types"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, resourceTypes),
+				),
+			)
+		})
+
+		It("returns the team's resource types", func() {
+			types, err := team.TeamResourceTypes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(types).To(Equal(resourceTypes))
+		})
+	})
+
+	Describe("SetTeamResourceTypes", func() {
+		expectedURL := "/api/v1/teams/some-team/resource-types"
+
+		Context("when the resource types are saved", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.VerifyJSONRepresenting(resourceTypes),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.SaveConfigResponse{
+							Warnings: []atc.ConfigWarning{
+								{Type: "invalid_identifier", Message: "some-warning"},
+							},
+						}),
+					),
+				)
+			})
+
+			It("returns the warnings", func() {
+				warnings, err := team.SetTeamResourceTypes(resourceTypes)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(Equal([]concourse.ConfigWarning{
+					{Type: "invalid_identifier", Message: "some-warning"},
+				}))
+			})
+		})
+
+		Context("when the resource types are invalid", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusBadRequest, atc.SaveConfigResponse{
+							Errors: []string{"some-error"},
+						}),
+					),
+				)
+			})
+
+			It("returns the validation errors", func() {
+				_, err := team.SetTeamResourceTypes(resourceTypes)
+				Expect(err).To(Equal(concourse.InvalidConfigError{Errors: []string{"some-error"}}))
+			})
+		})
+	})
+})
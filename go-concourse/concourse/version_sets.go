@@ -0,0 +1,101 @@
+package concourse
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) ListVersionSets(pipelineRef atc.PipelineRef) ([]atc.VersionSet, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	var sets []atc.VersionSet
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListVersionSets,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, &internal.Response{
+		Result: &sets,
+	})
+
+	switch err.(type) {
+	case nil:
+		return sets, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
+
+func (team *team) SaveVersionSet(pipelineRef atc.PipelineRef, name string) (atc.VersionSet, bool, error) {
+	var set atc.VersionSet
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.SaveVersionSet,
+		Params:      team.versionSetParams(pipelineRef, name),
+		Query:       pipelineRef.QueryParams(),
+	}, &internal.Response{
+		Result: &set,
+	})
+
+	switch err.(type) {
+	case nil:
+		return set, true, nil
+	case internal.ResourceNotFoundError:
+		return atc.VersionSet{}, false, nil
+	default:
+		return atc.VersionSet{}, false, versionSetError(err)
+	}
+}
+
+func (team *team) ApplyVersionSet(pipelineRef atc.PipelineRef, name string) (bool, error) {
+	return team.manageVersionSet(pipelineRef, name, atc.ApplyVersionSet)
+}
+
+func (team *team) ClearVersionSet(pipelineRef atc.PipelineRef, name string) (bool, error) {
+	return team.manageVersionSet(pipelineRef, name, atc.ClearVersionSet)
+}
+
+func (team *team) DestroyVersionSet(pipelineRef atc.PipelineRef, name string) (bool, error) {
+	return team.manageVersionSet(pipelineRef, name, atc.DestroyVersionSet)
+}
+
+func (team *team) manageVersionSet(pipelineRef atc.PipelineRef, name string, endpoint string) (bool, error) {
+	err := team.connection.Send(internal.Request{
+		RequestName: endpoint,
+		Params:      team.versionSetParams(pipelineRef, name),
+		Query:       pipelineRef.QueryParams(),
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, versionSetError(err)
+	}
+}
+
+func (team *team) versionSetParams(pipelineRef atc.PipelineRef, name string) rata.Params {
+	return rata.Params{
+		"pipeline_name":    pipelineRef.Name,
+		"team_name":        team.Name(),
+		"version_set_name": name,
+	}
+}
+
+// versionSetError surfaces why a version set could not be saved or applied,
+// e.g. which resources no longer have the captured version.
+func versionSetError(err error) error {
+	if ure, ok := err.(internal.UnexpectedResponseError); ok && ure.StatusCode == http.StatusConflict {
+		return GenericError{Message: ure.Body}
+	}
+
+	return err
+}
@@ -0,0 +1,173 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Version Sets", func() {
+	pipelineRef := atc.PipelineRef{Name: "some-pipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+
+	Describe("ListVersionSets", func() {
+		var expectedSets []atc.VersionSet
+
+		BeforeEach(func() {
+			expectedSets = []atc.VersionSet{
+				{
+					Name:      "release-1.2",
+					CreatedAt: 42,
+					Versions:  map[string]atc.Version{"some-resource": {"ref": "abc"}},
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/pipelines/some-pipeline/version-sets", "vars.branch=%22master%22"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedSets),
+				),
+			)
+		})
+
+		It("returns the pipeline's version sets", func() {
+			sets, found, err := team.ListVersionSets(pipelineRef)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(sets).To(Equal(expectedSets))
+		})
+	})
+
+	Describe("SaveVersionSet", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/some-pipeline/version-sets/release-1.2"
+
+		Context("when the version set is saved", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL, "vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.VersionSet{
+							Name:     "release-1.2",
+							Versions: map[string]atc.Version{"some-resource": {"ref": "abc"}},
+						}),
+					),
+				)
+			})
+
+			It("returns the captured versions", func() {
+				set, found, err := team.SaveVersionSet(pipelineRef, "release-1.2")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(set.Versions).To(Equal(map[string]atc.Version{"some-resource": {"ref": "abc"}}))
+			})
+		})
+
+		Context("when the version set already exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.RespondWith(http.StatusConflict, "version set already exists"),
+					),
+				)
+			})
+
+			It("returns the reason", func() {
+				_, _, err := team.SaveVersionSet(pipelineRef, "release-1.2")
+				Expect(err).To(Equal(concourse.GenericError{Message: "version set already exists"}))
+			})
+		})
+	})
+
+	Describe("ApplyVersionSet", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/some-pipeline/version-sets/release-1.2/apply"
+
+		Context("when the version set is applied", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL, "vars.branch=%22master%22"),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("returns true", func() {
+				found, err := team.ApplyVersionSet(pipelineRef, "release-1.2")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when some captured versions are missing", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.RespondWith(http.StatusConflict, "captured version not found for resource(s): some-resource"),
+					),
+				)
+			})
+
+			It("returns the reason", func() {
+				_, err := team.ApplyVersionSet(pipelineRef, "release-1.2")
+				Expect(err).To(MatchError("captured version not found for resource(s): some-resource"))
+			})
+		})
+
+		Context("when the version set does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				found, err := team.ApplyVersionSet(pipelineRef, "release-1.2")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("ClearVersionSet", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/teams/some-team/pipelines/some-pipeline/version-sets/release-1.2/clear"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+		})
+
+		It("returns true", func() {
+			found, err := team.ClearVersionSet(pipelineRef, "release-1.2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+
+	Describe("DestroyVersionSet", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/pipelines/some-pipeline/version-sets/release-1.2"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("returns true", func() {
+			found, err := team.DestroyVersionSet(pipelineRef, "release-1.2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+})
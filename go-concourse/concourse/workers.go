@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/concourse/concourse/atc"
@@ -80,6 +81,23 @@ func (client *client) PruneWorker(workerName string) error {
 	return err
 }
 
+func (client *client) PruneStalledWorkers(force bool) (atc.PruneStalledWorkersResponse, error) {
+	query := url.Values{}
+	if force {
+		query.Set("force", "true")
+	}
+
+	var response atc.PruneStalledWorkersResponse
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.PruneStalledWorkers,
+		Query:       query,
+	}, &internal.Response{
+		Result: &response,
+	})
+
+	return response, err
+}
+
 func (client *client) LandWorker(workerName string) error {
 	params := rata.Params{"worker_name": workerName}
 	err := client.connection.Send(internal.Request{
@@ -92,3 +110,27 @@ func (client *client) LandWorker(workerName string) error {
 
 	return err
 }
+
+func (client *client) WarmWorker(workerName string, resourceConfigVersionIDs []int) ([]atc.WarmedResourceCache, error) {
+	buffer := &bytes.Buffer{}
+	err := json.NewEncoder(buffer).Encode(atc.WarmWorkerRequest{
+		ResourceConfigVersionIDs: resourceConfigVersionIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to marshal warm worker request: %s", err)
+	}
+
+	var results []atc.WarmedResourceCache
+	err = client.connection.Send(internal.Request{
+		RequestName: atc.WarmWorker,
+		Params:      rata.Params{"worker_name": workerName},
+		Body:        buffer,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, &internal.Response{
+		Result: &results,
+	})
+
+	return results, err
+}
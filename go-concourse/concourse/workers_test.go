@@ -127,6 +127,74 @@ var _ = Describe("ATC Handler Workers", func() {
 		})
 	})
 
+	Describe("PruneStalledWorkers", func() {
+		var force bool
+
+		BeforeEach(func() {
+			force = false
+		})
+
+		Context("when succeeds", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/prune-stalled", ""),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PruneStalledWorkersResponse{
+							Pruned: []string{"some-worker"},
+							Busy:   []string{"busy-worker"},
+						}),
+					),
+				)
+			})
+
+			It("returns the pruned and busy workers", func() {
+				response, err := client.PruneStalledWorkers(force)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response).To(Equal(atc.PruneStalledWorkersResponse{
+					Pruned: []string{"some-worker"},
+					Busy:   []string{"busy-worker"},
+				}))
+			})
+		})
+
+		Context("when forced", func() {
+			BeforeEach(func() {
+				force = true
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/prune-stalled", "force=true"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PruneStalledWorkersResponse{
+							Pruned: []string{"some-worker", "busy-worker"},
+						}),
+					),
+				)
+			})
+
+			It("asks to prune busy workers too", func() {
+				response, err := client.PruneStalledWorkers(force)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Pruned).To(ConsistOf("some-worker", "busy-worker"))
+			})
+		})
+
+		Context("failing to prune workers", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/prune-stalled"),
+						ghttp.RespondWith(http.StatusInternalServerError, nil),
+					),
+				)
+			})
+
+			It("returns the error", func() {
+				_, err := client.PruneStalledWorkers(force)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("LandWorker", func() {
 		Context("when succeeds", func() {
 			BeforeEach(func() {
@@ -160,4 +228,29 @@ var _ = Describe("ATC Handler Workers", func() {
 			})
 		})
 	})
+
+	Describe("WarmWorker", func() {
+		var expectedResults []atc.WarmedResourceCache
+
+		BeforeEach(func() {
+			expectedResults = []atc.WarmedResourceCache{
+				{ResourceConfigVersionID: 1, ResourceCacheID: 11, Status: atc.WarmStatusStreamed},
+				{ResourceConfigVersionID: 2, Status: atc.WarmStatusFailed, Error: "no resource caches found for version"},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/warm"),
+					ghttp.VerifyJSONRepresenting(atc.WarmWorkerRequest{ResourceConfigVersionIDs: []int{1, 2}}),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedResults),
+				),
+			)
+		})
+
+		It("returns the outcome of warming each resource cache", func() {
+			results, err := client.WarmWorker("some-worker", []int{1, 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(Equal(expectedResults))
+		})
+	})
 })
@@ -2,6 +2,7 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -130,10 +131,48 @@ func StartSpan(
 	return startSpan(ctx, component, attrs)
 }
 
+// StartSpanAt is like StartSpan, but for a span which started at the given
+// time rather than now, e.g. one reconstructed after the fact.
+func StartSpanAt(
+	ctx context.Context,
+	component string,
+	attrs Attrs,
+	start time.Time,
+) (context.Context, trace.Span) {
+	return startSpan(ctx, component, attrs, trace.WithTimestamp(start))
+}
+
 func FromContext(ctx context.Context) trace.Span {
 	return trace.SpanFromContext(ctx)
 }
 
+// TraceID returns the ID of the trace the span in the context is part of, or
+// an empty string when tracing is not configured or the trace is not sampled,
+// in which case there's no trace to link to.
+func TraceID(ctx context.Context) string {
+	if !Configured {
+		return ""
+	}
+
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() || !spanContext.IsSampled() {
+		return ""
+	}
+
+	return spanContext.TraceID().String()
+}
+
+// TraceIDFollowing is like TraceID, but for the trace carried by the span
+// context of e.g. a build.
+func TraceIDFollowing(following WithSpanContext) string {
+	supplier := following.SpanContext()
+	if supplier == nil {
+		return ""
+	}
+
+	return TraceID(propagation.TraceContext{}.Extract(context.Background(), supplier))
+}
+
 func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
 	propagation.TraceContext{}.Inject(ctx, carrier)
 }
@@ -199,6 +238,15 @@ func startSpan(
 }
 
 func End(span trace.Span, err error) {
+	end(span, err)
+}
+
+// EndAt is like End, but for a span which finished at the given time.
+func EndAt(span trace.Span, err error, finish time.Time) {
+	end(span, err, trace.WithTimestamp(finish))
+}
+
+func end(span trace.Span, err error, opts ...trace.SpanOption) {
 	if !Configured {
 		return
 	}
@@ -210,7 +258,7 @@ func End(span trace.Span, err error) {
 		)
 	}
 
-	span.End()
+	span.End(opts...)
 }
 
 // ConfigureTraceProvider configures the sdk to use a given trace provider.
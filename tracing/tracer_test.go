@@ -2,10 +2,14 @@ package tracing_test
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/concourse/concourse/tracing"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/oteltest"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 
 	. "github.com/onsi/ginkgo"
@@ -59,6 +63,95 @@ var _ = Describe("Tracer", func() {
 		})
 	})
 
+	Describe("StartSpanAt and EndAt", func() {
+		var (
+			start = time.Date(2021, 4, 23, 12, 0, 0, 0, time.UTC)
+			end   = start.Add(time.Minute)
+		)
+
+		It("records the span with the given times", func() {
+			_, span := tracing.StartSpanAt(context.Background(), "a", nil, start)
+			tracing.EndAt(span, nil, end)
+
+			spans := spanRecorder.Completed()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].StartTime()).To(Equal(start))
+
+			endTime, ended := spans[0].EndTime()
+			Expect(ended).To(BeTrue())
+			Expect(endTime).To(Equal(end))
+			Expect(spans[0].StatusCode()).To(Equal(codes.Unset))
+		})
+
+		It("records the error", func() {
+			_, span := tracing.StartSpanAt(context.Background(), "a", nil, start)
+			tracing.EndAt(span, errors.New("nope"), end)
+
+			spans := spanRecorder.Completed()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].StatusCode()).To(Equal(codes.Error))
+			Expect(spans[0].Attributes()).To(HaveKeyWithValue(attribute.Key("error-message"), attribute.StringValue("nope")))
+		})
+	})
+
+	Describe("TraceID", func() {
+		var (
+			ctx         context.Context
+			traceFlags  trace.TraceFlags
+			traceID     = trace.TraceID{0x01, 0x02, 0x03}
+			spanContext trace.SpanContext
+		)
+
+		BeforeEach(func() {
+			traceFlags = trace.FlagsSampled
+		})
+
+		JustBeforeEach(func() {
+			spanContext = trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     trace.SpanID{0x01},
+				TraceFlags: traceFlags,
+			})
+
+			ctx = trace.ContextWithSpanContext(context.Background(), spanContext)
+		})
+
+		It("returns the ID of the trace of the span in the context", func() {
+			Expect(tracing.TraceID(ctx)).To(Equal(traceID.String()))
+		})
+
+		It("returns the ID of the trace carried by a span context", func() {
+			spanCarrier := carrier{}
+			tracing.Inject(ctx, spanCarrier)
+
+			Expect(tracing.TraceIDFollowing(spanCarrier)).To(Equal(traceID.String()))
+		})
+
+		It("returns nothing when there is no span in the context", func() {
+			Expect(tracing.TraceID(context.Background())).To(BeEmpty())
+		})
+
+		Context("when the trace is not sampled", func() {
+			BeforeEach(func() {
+				traceFlags = 0
+			})
+
+			It("returns nothing", func() {
+				Expect(tracing.TraceID(ctx)).To(BeEmpty())
+			})
+		})
+
+		Context("when tracing is not configured", func() {
+			BeforeEach(func() {
+				tracing.Configured = false
+			})
+
+			It("returns nothing", func() {
+				Expect(tracing.TraceID(ctx)).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("Prepare", func() {
 		BeforeEach(func() {
 			tracing.Configured = false
@@ -92,3 +185,19 @@ var _ = Describe("Tracer", func() {
 		})
 	})
 })
+
+// carrier holds a span context, like a build does.
+type carrier map[string]string
+
+func (c carrier) Get(key string) string { return c[key] }
+func (c carrier) Set(key, value string) { c[key] = value }
+
+func (c carrier) Keys() []string {
+	keys := []string{}
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (c carrier) SpanContext() propagation.TextMapCarrier { return c }
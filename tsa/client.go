@@ -323,6 +323,47 @@ func (client *Client) ReportVolumes(ctx context.Context, handles []string) error
 	return client.run(ctx, sshClient, strings.Join(command, " "), os.Stdout)
 }
 
+// ReportDiskUsage invokes the 'report-disk-usage' command, sending how much of
+// the worker's disk is in use to Concourse.
+func (client *Client) ReportDiskUsage(ctx context.Context, usage atc.WorkerDiskUsage) error {
+	logger := lagerctx.FromContext(ctx)
+
+	sshClient, _, err := client.dial(ctx, 0)
+	if err != nil {
+		logger.Error("failed-to-dial", err)
+		return err
+	}
+
+	defer sshClient.Close()
+
+	command := fmt.Sprintf("%s %d %d", ReportDiskUsage, usage.UsedBytes, usage.TotalBytes)
+
+	return client.run(ctx, sshClient, command, os.Stdout)
+}
+
+// ReportVolumeSizes invokes the 'report-volume-sizes' command, sending the
+// sizes of the worker's volumes, keyed by handle, to Concourse.
+func (client *Client) ReportVolumeSizes(ctx context.Context, sizes map[string]uint64) error {
+	logger := lagerctx.FromContext(ctx)
+
+	sshClient, _, err := client.dial(ctx, 0)
+	if err != nil {
+		logger.Error("failed-to-dial", err)
+		return err
+	}
+
+	defer sshClient.Close()
+
+	command := []string{ReportVolumeSizes}
+	for handle, size := range sizes {
+		command = append(command, fmt.Sprintf("%s=%d", handle, size))
+	}
+
+	sort.Strings(command[1:])
+
+	return client.run(ctx, sshClient, strings.Join(command, " "), os.Stdout)
+}
+
 func (client *Client) dial(ctx context.Context, idleTimeout time.Duration) (*ssh.Client, *net.TCPConn, error) {
 	logger := lagerctx.WithSession(ctx, "dial")
 
@@ -9,5 +9,7 @@ const (
 
 	ReportContainers      = "report-containers"
 	ReportVolumes         = "report-volumes"
+	ReportDiskUsage       = "report-disk-usage"
+	ReportVolumeSizes     = "report-volume-sizes"
 	ResourceActionMissing = "resource-type-missing"
 )
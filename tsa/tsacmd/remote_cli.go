@@ -327,6 +327,52 @@ func (req reportVolumesRequest) Handle(ctx context.Context, state ConnState, cha
 	}).WorkerStatus(ctx, worker, tsa.ReportVolumes)
 }
 
+type reportDiskUsageRequest struct {
+	server *server
+	usage  atc.WorkerDiskUsage
+}
+
+func (req reportDiskUsageRequest) Handle(ctx context.Context, state ConnState, channel ssh.Channel) error {
+	var worker atc.Worker
+	err := json.NewDecoder(channel).Decode(&worker)
+	if err != nil {
+		return err
+	}
+
+	if err := checkTeam(state, worker); err != nil {
+		return err
+	}
+
+	return (&tsa.WorkerStatus{
+		ATCEndpoint: req.server.atcEndpointPicker.Pick(),
+		HTTPClient:  req.server.httpClient,
+		DiskUsage:   req.usage,
+	}).WorkerStatus(ctx, worker, tsa.ReportDiskUsage)
+}
+
+type reportVolumeSizesRequest struct {
+	server *server
+	sizes  map[string]uint64
+}
+
+func (req reportVolumeSizesRequest) Handle(ctx context.Context, state ConnState, channel ssh.Channel) error {
+	var worker atc.Worker
+	err := json.NewDecoder(channel).Decode(&worker)
+	if err != nil {
+		return err
+	}
+
+	if err := checkTeam(state, worker); err != nil {
+		return err
+	}
+
+	return (&tsa.WorkerStatus{
+		ATCEndpoint: req.server.atcEndpointPicker.Pick(),
+		HTTPClient:  req.server.httpClient,
+		VolumeSizes: req.sizes,
+	}).WorkerStatus(ctx, worker, tsa.ReportVolumeSizes)
+}
+
 func gardenURL(addr string) string {
 	return fmt.Sprintf("http://%s", addr)
 }
@@ -8,12 +8,14 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/tsa"
 	"golang.org/x/crypto/ssh"
 )
@@ -538,9 +540,67 @@ func (server *server) parseRequest(cli string) (request, string, error) {
 			server:        server,
 			volumeHandles: args,
 		}
+	case tsa.ReportDiskUsage:
+		if len(args) != 2 {
+			return nil, "", fmt.Errorf("usage: %s <used bytes> <total bytes>", command)
+		}
+
+		used, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid used bytes: %w", err)
+		}
+
+		total, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid total bytes: %w", err)
+		}
+
+		req = reportDiskUsageRequest{
+			server: server,
+			usage: atc.WorkerDiskUsage{
+				UsedBytes:  used,
+				TotalBytes: total,
+			},
+		}
+	case tsa.ReportVolumeSizes:
+		sizes := make(map[string]uint64, len(args))
+		for _, arg := range args {
+			handle, size, ok := splitVolumeSize(arg)
+			if !ok {
+				return nil, "", fmt.Errorf("usage: %s [<handle>=<bytes>...]", command)
+			}
+
+			if _, found := sizes[handle]; found {
+				return nil, "", fmt.Errorf("size of volume %s given more than once", handle)
+			}
+
+			// sizes are saved as bigints, so they mustn't overflow an int64
+			bytes, err := strconv.ParseUint(size, 10, 63)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid size of volume %s: %w", handle, err)
+			}
+
+			sizes[handle] = bytes
+		}
+
+		req = reportVolumeSizesRequest{
+			server: server,
+			sizes:  sizes,
+		}
 	default:
 		return nil, "", fmt.Errorf("unknown command: %s", command)
 	}
 
 	return req, command, nil
 }
+
+// splitVolumeSize splits a "<handle>=<bytes>" argument of the
+// report-volume-sizes command.
+func splitVolumeSize(arg string) (string, string, bool) {
+	i := strings.Index(arg, "=")
+	if i <= 0 {
+		return "", "", false
+	}
+
+	return arg[:i], arg[i+1:], true
+}
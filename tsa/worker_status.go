@@ -23,6 +23,8 @@ type WorkerStatus struct {
 	HTTPClient       *http.Client
 	ContainerHandles []string
 	VolumeHandles    []string
+	DiskUsage        atc.WorkerDiskUsage
+	VolumeSizes      map[string]uint64
 }
 
 func (l *WorkerStatus) WorkerStatus(ctx context.Context, worker atc.Worker, resourceAction string) error {
@@ -31,6 +33,11 @@ func (l *WorkerStatus) WorkerStatus(ctx context.Context, worker atc.Worker, reso
 	logger.Debug("start")
 	defer logger.Debug("end")
 
+	if worker.Name == "" {
+		logger.Info("empty-worker-name-in-req")
+		return fmt.Errorf("empty-worker-name")
+	}
+
 	var (
 		handlesBytes []byte
 		err          error
@@ -60,6 +67,34 @@ func (l *WorkerStatus) WorkerStatus(ctx context.Context, worker atc.Worker, reso
 
 		request, err = l.ATCEndpoint.CreateRequest(atc.ReportWorkerVolumes, nil, bytes.NewBuffer(handlesBytes))
 
+		if err != nil {
+			logger.Error("failed-to-construct-request", err)
+			return err
+		}
+	case ReportDiskUsage:
+		usageBytes, err := json.Marshal(l.DiskUsage)
+		if err != nil {
+			logger.Error("failed-to-encode-request-body", err)
+			return err
+		}
+
+		request, err = l.ATCEndpoint.CreateRequest(atc.ReportWorkerDiskUsage, rata.Params{
+			"worker_name": worker.Name,
+		}, bytes.NewBuffer(usageBytes))
+
+		if err != nil {
+			logger.Error("failed-to-construct-request", err)
+			return err
+		}
+	case ReportVolumeSizes:
+		sizesBytes, err := json.Marshal(l.VolumeSizes)
+		if err != nil {
+			logger.Error("failed-to-encode-request-body", err)
+			return err
+		}
+
+		request, err = l.ATCEndpoint.CreateRequest(atc.ReportWorkerVolumeSizes, nil, bytes.NewBuffer(sizesBytes))
+
 		if err != nil {
 			logger.Error("failed-to-construct-request", err)
 			return err
@@ -68,10 +103,6 @@ func (l *WorkerStatus) WorkerStatus(ctx context.Context, worker atc.Worker, reso
 		return errors.New(ResourceActionMissing)
 	}
 
-	if worker.Name == "" {
-		logger.Info("empty-worker-name-in-req")
-		return fmt.Errorf("empty-worker-name")
-	}
 	request.Header.Add("Content-Type", "application/json")
 
 	request.URL.RawQuery = url.Values{
@@ -259,4 +259,86 @@ var _ = Describe("Worker Status Test", func() {
 			})
 		})
 	})
+
+	Context("Disk usage", func() {
+		BeforeEach(func() {
+			workerStatus.DiskUsage = atc.WorkerDiskUsage{UsedBytes: 80, TotalBytes: 100}
+
+			fakeATC.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/disk-usage"),
+				ghttp.VerifyHeaderKV("Authorization", "Bearer yo"),
+				ghttp.VerifyJSON(`{"used_bytes":80,"total_bytes":100}`),
+				ghttp.RespondWith(204, nil, nil),
+			))
+		})
+
+		It("reports the worker's disk usage to the ATC", func() {
+			err := workerStatus.WorkerStatus(ctx, worker, tsa.ReportDiskUsage)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeATC.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		Context("when the worker name is empty", func() {
+			BeforeEach(func() {
+				worker.Name = ""
+			})
+
+			It("errors", func() {
+				err := workerStatus.WorkerStatus(ctx, worker, tsa.ReportDiskUsage)
+				Expect(err).To(MatchError(ContainSubstring("empty-worker-name")))
+				Expect(fakeATC.ReceivedRequests()).To(HaveLen(0))
+			})
+		})
+
+		Context("when the ATC responds with non 204", func() {
+			BeforeEach(func() {
+				fakeATC.Reset()
+				fakeATC.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/disk-usage"),
+					ghttp.RespondWith(404, nil, nil),
+				))
+			})
+
+			It("errors", func() {
+				err := workerStatus.WorkerStatus(ctx, worker, tsa.ReportDiskUsage)
+				Expect(err).To(MatchError(ContainSubstring("bad-response (404)")))
+			})
+		})
+	})
+
+	Context("Volume sizes", func() {
+		BeforeEach(func() {
+			workerStatus.VolumeSizes = map[string]uint64{"handle1": 1024}
+
+			fakeATC.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/api/v1/volumes/sizes", "worker_name=some-worker"),
+				ghttp.VerifyHeaderKV("Authorization", "Bearer yo"),
+				ghttp.VerifyJSON(`{"handle1":1024}`),
+				ghttp.RespondWith(204, nil, nil),
+			))
+		})
+
+		It("reports the sizes of the worker's volumes to the ATC", func() {
+			err := workerStatus.WorkerStatus(ctx, worker, tsa.ReportVolumeSizes)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeATC.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		Context("when the ATC responds with non 204", func() {
+			BeforeEach(func() {
+				fakeATC.Reset()
+				fakeATC.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/volumes/sizes"),
+					ghttp.RespondWith(500, nil, nil),
+				))
+			})
+
+			It("errors", func() {
+				err := workerStatus.WorkerStatus(ctx, worker, tsa.ReportVolumeSizes)
+				Expect(err).To(MatchError(ContainSubstring("bad-response (500)")))
+			})
+		})
+	})
 })
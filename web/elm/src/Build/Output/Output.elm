@@ -265,6 +265,10 @@ handleEvent event ( model, effects ) =
             , effects
             )
 
+        ImageFetched _ _ ->
+            -- the version is already shown on the image-get sub-step
+            ( model, effects )
+
         End ->
             ( { model | state = StepsComplete, eventStreamUrlPath = Nothing }
             , effects
@@ -50,6 +50,11 @@ type StepTree
     | Put StepID
     | SetPipeline StepID
     | LoadVar StepID
+    | Approval StepID
+    | PublishArtifact StepID
+    | ConsumeArtifact StepID
+    | LoadBuildOutputs StepID
+    | AssertGreen StepID
     | ArtifactInput StepID
     | ArtifactOutput StepID
     | InParallel (Array StepTree)
@@ -206,6 +211,7 @@ type BuildEvent
     | Error Origin String Time.Posix
     | ImageCheck Origin Concourse.BuildPlan
     | ImageGet Origin Concourse.BuildPlan
+    | ImageFetched Origin Concourse.Version
     | End
     | Opened
     | NetworkError
@@ -273,6 +279,21 @@ activeStepIds model tree =
         LoadVar stepId ->
             [ stepId ]
 
+        Approval stepId ->
+            [ stepId ]
+
+        PublishArtifact stepId ->
+            [ stepId ]
+
+        ConsumeArtifact stepId ->
+            [ stepId ]
+
+        LoadBuildOutputs stepId ->
+            [ stepId ]
+
+        AssertGreen stepId ->
+            [ stepId ]
+
         InParallel trees ->
             List.concatMap (activeStepIds model) (Array.toList trees)
 
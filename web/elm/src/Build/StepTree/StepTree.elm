@@ -98,6 +98,21 @@ init buildId hl resources plan =
         Concourse.BuildStepLoadVar _ ->
             step |> initBottom buildId hl resources plan LoadVar
 
+        Concourse.BuildStepApproval _ ->
+            step |> initBottom buildId hl resources plan Approval
+
+        Concourse.BuildStepPublishArtifact _ ->
+            step |> initBottom buildId hl resources plan PublishArtifact
+
+        Concourse.BuildStepConsumeArtifact _ ->
+            step |> initBottom buildId hl resources plan ConsumeArtifact
+
+        Concourse.BuildStepLoadBuildOutputs _ ->
+            step |> initBottom buildId hl resources plan LoadBuildOutputs
+
+        Concourse.BuildStepAssertGreen _ ->
+            step |> initBottom buildId hl resources plan AssertGreen
+
         Concourse.BuildStepInParallel plans ->
             initMultiStep buildId hl resources plan.id InParallel plans Nothing
 
@@ -513,6 +528,21 @@ viewTree session model tree depth =
         LoadVar stepId ->
             viewStep model session depth stepId
 
+        Approval stepId ->
+            viewStep model session depth stepId
+
+        PublishArtifact stepId ->
+            viewStep model session depth stepId
+
+        ConsumeArtifact stepId ->
+            viewStep model session depth stepId
+
+        LoadBuildOutputs stepId ->
+            viewStep model session depth stepId
+
+        AssertGreen stepId ->
+            viewStep model session depth stepId
+
         Try subTree ->
             viewTree session model subTree depth
 
@@ -1161,6 +1191,21 @@ viewStepHeader step =
         Concourse.BuildStepLoadVar name ->
             simpleHeader "load_var:" Nothing name
 
+        Concourse.BuildStepApproval name ->
+            simpleHeader "approval:" Nothing name
+
+        Concourse.BuildStepPublishArtifact name ->
+            simpleHeader "publish_artifact:" Nothing name
+
+        Concourse.BuildStepConsumeArtifact name ->
+            simpleHeader "consume_artifact:" Nothing name
+
+        Concourse.BuildStepLoadBuildOutputs name ->
+            simpleHeader "load_build_outputs:" Nothing name
+
+        Concourse.BuildStepAssertGreen name ->
+            simpleHeader "assert_green:" Nothing name
+
         Concourse.BuildStepCheck name ->
             simpleHeader "check:" Nothing name
 
@@ -1222,6 +1267,21 @@ stepName header =
         Concourse.BuildStepLoadVar name ->
             Just name
 
+        Concourse.BuildStepApproval name ->
+            Just name
+
+        Concourse.BuildStepPublishArtifact name ->
+            Just name
+
+        Concourse.BuildStepConsumeArtifact name ->
+            Just name
+
+        Concourse.BuildStepLoadBuildOutputs name ->
+            Just name
+
+        Concourse.BuildStepAssertGreen name ->
+            Just name
+
         Concourse.BuildStepArtifactInput name ->
             Just name
 
@@ -375,6 +375,21 @@ mapBuildPlan fn plan =
                 BuildStepLoadVar _ ->
                     []
 
+                BuildStepApproval _ ->
+                    []
+
+                BuildStepPublishArtifact _ ->
+                    []
+
+                BuildStepConsumeArtifact _ ->
+                    []
+
+                BuildStepLoadBuildOutputs _ ->
+                    []
+
+                BuildStepAssertGreen _ ->
+                    []
+
                 BuildStepArtifactInput _ ->
                     []
 
@@ -438,6 +453,11 @@ type BuildStep
     = BuildStepTask StepName
     | BuildStepSetPipeline StepName InstanceVars
     | BuildStepLoadVar StepName
+    | BuildStepApproval StepName
+    | BuildStepPublishArtifact StepName
+    | BuildStepConsumeArtifact StepName
+    | BuildStepLoadBuildOutputs StepName
+    | BuildStepAssertGreen StepName
     | BuildStepArtifactInput StepName
     | BuildStepCheck StepName
     | BuildStepGet StepName (Maybe ResourceName) (Maybe Version)
@@ -629,6 +649,8 @@ decodeBuildPlan =
                     lazy (\_ -> decodeBuildStepInParallel)
                 , Json.Decode.field "do" <|
                     lazy (\_ -> decodeBuildStepDo)
+                , Json.Decode.field "atomic" <|
+                    lazy (\_ -> decodeBuildStepAtomic)
                 , Json.Decode.field "on_success" <|
                     lazy (\_ -> decodeBuildStepOnSuccess)
                 , Json.Decode.field "on_failure" <|
@@ -649,6 +671,16 @@ decodeBuildPlan =
                     lazy (\_ -> decodeBuildSetPipeline)
                 , Json.Decode.field "load_var" <|
                     lazy (\_ -> decodeBuildStepLoadVar)
+                , Json.Decode.field "approval" <|
+                    lazy (\_ -> decodeBuildStepApproval)
+                , Json.Decode.field "publish_artifact" <|
+                    lazy (\_ -> decodeBuildStepPublishArtifact)
+                , Json.Decode.field "consume_artifact" <|
+                    lazy (\_ -> decodeBuildStepConsumeArtifact)
+                , Json.Decode.field "load_build_outputs" <|
+                    lazy (\_ -> decodeBuildStepLoadBuildOutputs)
+                , Json.Decode.field "assert_green" <|
+                    lazy (\_ -> decodeBuildStepAssertGreen)
                 , Json.Decode.field "across" <|
                     lazy (\_ -> decodeBuildStepAcross)
                 ]
@@ -706,6 +738,12 @@ decodeBuildStepDo =
         |> andMap (Json.Decode.array (lazy (\_ -> decodeBuildPlan)))
 
 
+decodeBuildStepAtomic : Json.Decode.Decoder BuildStep
+decodeBuildStepAtomic =
+    Json.Decode.succeed BuildStepDo
+        |> andMap (Json.Decode.field "steps" <| Json.Decode.array (lazy (\_ -> decodeBuildPlan)))
+
+
 decodeBuildStepOnSuccess : Json.Decode.Decoder BuildStep
 decodeBuildStepOnSuccess =
     Json.Decode.map BuildStepOnSuccess
@@ -782,6 +820,36 @@ decodeBuildStepLoadVar =
         |> andMap (Json.Decode.field "name" Json.Decode.string)
 
 
+decodeBuildStepApproval : Json.Decode.Decoder BuildStep
+decodeBuildStepApproval =
+    Json.Decode.succeed BuildStepApproval
+        |> andMap (Json.Decode.field "name" Json.Decode.string)
+
+
+decodeBuildStepPublishArtifact : Json.Decode.Decoder BuildStep
+decodeBuildStepPublishArtifact =
+    Json.Decode.succeed BuildStepPublishArtifact
+        |> andMap (Json.Decode.field "name" Json.Decode.string)
+
+
+decodeBuildStepConsumeArtifact : Json.Decode.Decoder BuildStep
+decodeBuildStepConsumeArtifact =
+    Json.Decode.succeed BuildStepConsumeArtifact
+        |> andMap (Json.Decode.field "name" Json.Decode.string)
+
+
+decodeBuildStepLoadBuildOutputs : Json.Decode.Decoder BuildStep
+decodeBuildStepLoadBuildOutputs =
+    Json.Decode.succeed BuildStepLoadBuildOutputs
+        |> andMap (Json.Decode.field "name" Json.Decode.string)
+
+
+decodeBuildStepAssertGreen : Json.Decode.Decoder BuildStep
+decodeBuildStepAssertGreen =
+    Json.Decode.succeed BuildStepAssertGreen
+        |> andMap (Json.Decode.field "job" Json.Decode.string)
+
+
 decodeBuildStepAcross : Json.Decode.Decoder BuildStep
 decodeBuildStepAcross =
     Json.Decode.map BuildStepAcross
@@ -216,6 +216,13 @@ decodeBuildEvent =
                                 (Json.Decode.field "plan" Concourse.decodeBuildPlan)
                             )
 
+                    "image-fetched" ->
+                        Json.Decode.field "data"
+                            (Json.Decode.map2 ImageFetched
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "version" Concourse.decodeVersion)
+                            )
+
                     unknown ->
                         Json.Decode.fail ("unknown event type: " ++ unknown)
             )
@@ -29,6 +29,11 @@ all =
         [ initTask
         , initSetPipeline
         , initLoadVar
+        , initApproval
+        , initPublishArtifact
+        , initConsumeArtifact
+        , initLoadBuildOutputs
+        , initAssertGreen
         , initCheck
         , initGet
         , initPut
@@ -159,6 +164,126 @@ initLoadVar =
         ]
 
 
+initApproval : Test
+initApproval =
+    let
+        step =
+            BuildStepApproval "some-name"
+
+        { tree, steps } =
+            StepTree.init Nothing
+                Routes.HighlightNothing
+                emptyResources
+                { id = "some-id"
+                , step = step
+                }
+    in
+    describe "init with Approval"
+        [ test "the tree" <|
+            \_ ->
+                Expect.equal (Models.Approval "some-id") tree
+        , test "the step" <|
+            \_ ->
+                assertSteps [ someStep "some-id" step Models.StepStatePending ] steps
+        ]
+
+
+initPublishArtifact : Test
+initPublishArtifact =
+    let
+        step =
+            BuildStepPublishArtifact "some-name"
+
+        { tree, steps } =
+            StepTree.init Nothing
+                Routes.HighlightNothing
+                emptyResources
+                { id = "some-id"
+                , step = step
+                }
+    in
+    describe "init with PublishArtifact"
+        [ test "the tree" <|
+            \_ ->
+                Expect.equal (Models.PublishArtifact "some-id") tree
+        , test "the step" <|
+            \_ ->
+                assertSteps [ someStep "some-id" step Models.StepStatePending ] steps
+        ]
+
+
+initConsumeArtifact : Test
+initConsumeArtifact =
+    let
+        step =
+            BuildStepConsumeArtifact "some-name"
+
+        { tree, steps } =
+            StepTree.init Nothing
+                Routes.HighlightNothing
+                emptyResources
+                { id = "some-id"
+                , step = step
+                }
+    in
+    describe "init with ConsumeArtifact"
+        [ test "the tree" <|
+            \_ ->
+                Expect.equal (Models.ConsumeArtifact "some-id") tree
+        , test "the step" <|
+            \_ ->
+                assertSteps [ someStep "some-id" step Models.StepStatePending ] steps
+        ]
+
+
+initLoadBuildOutputs : Test
+initLoadBuildOutputs =
+    let
+        step =
+            BuildStepLoadBuildOutputs "some-name"
+
+        { tree, steps } =
+            StepTree.init Nothing
+                Routes.HighlightNothing
+                emptyResources
+                { id = "some-id"
+                , step = step
+                }
+    in
+    describe "init with LoadBuildOutputs"
+        [ test "the tree" <|
+            \_ ->
+                Expect.equal (Models.LoadBuildOutputs "some-id") tree
+        , test "the step" <|
+            \_ ->
+                assertSteps [ someStep "some-id" step Models.StepStatePending ] steps
+        ]
+
+
+initAssertGreen : Test
+initAssertGreen =
+    let
+        step =
+            BuildStepAssertGreen "some-job"
+
+        { tree, steps } =
+            StepTree.init Nothing
+                Routes.HighlightNothing
+                emptyResources
+                { id = "some-id"
+                , step = step
+                }
+    in
+    describe "init with AssertGreen"
+        [ test "the tree" <|
+            \_ ->
+                Expect.equal (Models.AssertGreen "some-id") tree
+        , test "the step" <|
+            \_ ->
+                assertSteps [ someStep "some-id" step Models.StepStatePending ] steps
+        ]
+
+
 initCheck : Test
 initCheck =
     let
@@ -0,0 +1,28 @@
+// +build !windows
+
+package worker
+
+import (
+	"syscall"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// DirDiskUsage measures how much of the filesystem holding dir is in use.
+func DirDiskUsage(dir string) (atc.WorkerDiskUsage, error) {
+	var stat syscall.Statfs_t
+	err := syscall.Statfs(dir, &stat)
+	if err != nil {
+		return atc.WorkerDiskUsage{}, err
+	}
+
+	blockSize := uint64(stat.Bsize)
+	total := stat.Blocks * blockSize
+
+	return atc.WorkerDiskUsage{
+		// blocks reserved for root aren't available to volumes either, so
+		// count everything that isn't free as used
+		UsedBytes:  total - stat.Bfree*blockSize,
+		TotalBytes: total,
+	}, nil
+}
@@ -0,0 +1,13 @@
+package worker
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// DirDiskUsage is not supported on Windows, where workers don't report their
+// disk usage.
+func DirDiskUsage(dir string) (atc.WorkerDiskUsage, error) {
+	return atc.WorkerDiskUsage{}, errors.New("measuring disk usage is not supported on windows")
+}
@@ -8,6 +8,7 @@ package runtime
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/garden"
@@ -230,7 +231,12 @@ func (b *GardenBackend) createContainer(ctx context.Context, gdnSpec garden.Cont
 		return nil, fmt.Errorf("garden spec to oci spec: %w", err)
 	}
 
-	netMounts, err := b.network.SetupMounts(gdnSpec.Handle)
+	var hosts []string
+	if entries := gdnSpec.Properties[HostsKey]; entries != "" {
+		hosts = strings.Split(entries, "\n")
+	}
+
+	netMounts, err := b.network.SetupMounts(gdnSpec.Handle, hosts)
 	if err != nil {
 		return nil, fmt.Errorf("network setup mounts: %w", err)
 	}
@@ -146,6 +146,43 @@ func (s *BackendSuite) TestCreateContainerSetsHandle() {
 	s.Equal("handle", cont.Handle())
 }
 
+func (s *BackendSuite) TestCreateContainerSetsUpHosts() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	_, err := s.backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		Properties: garden.Properties{
+			runtime.HostsKey: "10.0.0.1 some-host\n10.0.0.2 other-host",
+		},
+	})
+	s.NoError(err)
+
+	s.Equal(1, s.network.SetupMountsCallCount())
+	handle, hosts := s.network.SetupMountsArgsForCall(0)
+	s.Equal("handle", handle)
+	s.Equal([]string{"10.0.0.1 some-host", "10.0.0.2 other-host"}, hosts)
+}
+
+func (s *BackendSuite) TestCreateContainerWithoutHosts() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	_, err := s.backend.Create(minimumValidGdnSpec)
+	s.NoError(err)
+
+	s.Equal(1, s.network.SetupMountsCallCount())
+	_, hosts := s.network.SetupMountsArgsForCall(0)
+	s.Empty(hosts)
+}
+
 func (s *BackendSuite) TestCreateMaxContainersReached() {
 	backend, err := runtime.NewGardenBackend(s.client,
 		runtime.WithKiller(s.killer),
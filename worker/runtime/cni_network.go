@@ -225,14 +225,14 @@ func NewCNINetwork(opts ...CNINetworkOpt) (*cniNetwork, error) {
 	return n, nil
 }
 
-func (n cniNetwork) SetupMounts(handle string) ([]specs.Mount, error) {
+func (n cniNetwork) SetupMounts(handle string, hosts []string) ([]specs.Mount, error) {
 	if handle == "" {
 		return nil, ErrInvalidInput("empty handle")
 	}
 
 	etcHosts, err := n.store.Create(
 		filepath.Join(handle, "/hosts"),
-		[]byte("127.0.0.1 localhost"),
+		[]byte(strings.Join(append([]string{"127.0.0.1 localhost"}, hosts...), "\n")),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("creating /etc/hosts: %w", err)
@@ -53,14 +53,14 @@ func (s *CNINetworkSuite) TestNewCNINetworkWithInvalidConfigDoesntFail() {
 }
 
 func (s *CNINetworkSuite) TestSetupMountsEmptyHandle() {
-	_, err := s.network.SetupMounts("")
+	_, err := s.network.SetupMounts("", nil)
 	s.EqualError(err, "empty handle")
 }
 
 func (s *CNINetworkSuite) TestSetupMountsFailToCreateHosts() {
 	s.store.CreateReturnsOnCall(0, "", errors.New("create-hosts-err"))
 
-	_, err := s.network.SetupMounts("handle")
+	_, err := s.network.SetupMounts("handle", nil)
 	s.EqualError(errors.Unwrap(err), "create-hosts-err")
 
 	s.Equal(1, s.store.CreateCallCount())
@@ -69,10 +69,18 @@ func (s *CNINetworkSuite) TestSetupMountsFailToCreateHosts() {
 	s.Equal("handle/hosts", fname)
 }
 
+func (s *CNINetworkSuite) TestSetupMountsWritesHosts() {
+	_, err := s.network.SetupMounts("handle", []string{"10.0.0.1 some-host", "10.0.0.2 other-host"})
+	s.NoError(err)
+
+	_, contents := s.store.CreateArgsForCall(0)
+	s.Equal("127.0.0.1 localhost\n10.0.0.1 some-host\n10.0.0.2 other-host", string(contents))
+}
+
 func (s *CNINetworkSuite) TestSetupMountsFailToCreateResolvConf() {
 	s.store.CreateReturnsOnCall(1, "", errors.New("create-resolvconf-err"))
 
-	_, err := s.network.SetupMounts("handle")
+	_, err := s.network.SetupMounts("handle", nil)
 	s.EqualError(errors.Unwrap(err), "create-resolvconf-err")
 
 	s.Equal(2, s.store.CreateCallCount())
@@ -85,7 +93,7 @@ func (s *CNINetworkSuite) TestSetupMountsReturnsMountpoints() {
 	s.store.CreateReturnsOnCall(0, "/tmp/handle/etc/hosts", nil)
 	s.store.CreateReturnsOnCall(1, "/tmp/handle/etc/resolv.conf", nil)
 
-	mounts, err := s.network.SetupMounts("some-handle")
+	mounts, err := s.network.SetupMounts("some-handle", nil)
 	s.NoError(err)
 
 	s.Len(mounts, 2)
@@ -113,7 +121,7 @@ func (s *CNINetworkSuite) TestSetupMountsCallsStoreWithNameServers() {
 	)
 	s.NoError(err)
 
-	_, err = network.SetupMounts("some-handle")
+	_, err = network.SetupMounts("some-handle", nil)
 	s.NoError(err)
 
 	_, resolvConfContents := s.store.CreateArgsForCall(1)
@@ -127,7 +135,7 @@ func (s *CNINetworkSuite) TestSetupMountsCallsStoreWithoutNameServers() {
 	)
 	s.NoError(err)
 
-	_, err = network.SetupMounts("some-handle")
+	_, err = network.SetupMounts("some-handle", nil)
 	s.NoError(err)
 
 	actualResolvContents, err := runtime.ParseHostResolveConf("/etc/resolv.conf")
@@ -19,6 +19,10 @@ const (
 	Path          = "PATH=/usr/local/bin:/usr/bin:/bin"
 
 	GraceTimeKey = "garden.grace-time"
+
+	// HostsKey is the property holding the newline-separated entries to add
+	// to the container's /etc/hosts.
+	HostsKey = "concourse:hosts"
 )
 
 type UserNotFoundError struct {
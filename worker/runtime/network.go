@@ -10,9 +10,9 @@ import (
 //counterfeiter:generate . Network
 type Network interface {
 	// SetupMounts prepares mounts that might be necessary for proper
-	// networking functionality.
+	// networking functionality, adding the given entries to /etc/hosts.
 	//
-	SetupMounts(handle string) (mounts []specs.Mount, err error)
+	SetupMounts(handle string, hosts []string) (mounts []specs.Mount, err error)
 
 	// SetupRestrictedNetworks sets up networking rules to prevent
 	// container access to specified network ranges
@@ -35,10 +35,11 @@ type FakeNetwork struct {
 	removeReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SetupMountsStub        func(string) ([]specs.Mount, error)
+	SetupMountsStub        func(string, []string) ([]specs.Mount, error)
 	setupMountsMutex       sync.RWMutex
 	setupMountsArgsForCall []struct {
 		arg1 string
+		arg2 []string
 	}
 	setupMountsReturns struct {
 		result1 []specs.Mount
@@ -186,18 +187,24 @@ func (fake *FakeNetwork) RemoveReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeNetwork) SetupMounts(arg1 string) ([]specs.Mount, error) {
+func (fake *FakeNetwork) SetupMounts(arg1 string, arg2 []string) ([]specs.Mount, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
 	fake.setupMountsMutex.Lock()
 	ret, specificReturn := fake.setupMountsReturnsOnCall[len(fake.setupMountsArgsForCall)]
 	fake.setupMountsArgsForCall = append(fake.setupMountsArgsForCall, struct {
 		arg1 string
-	}{arg1})
+		arg2 []string
+	}{arg1, arg2Copy})
 	stub := fake.SetupMountsStub
 	fakeReturns := fake.setupMountsReturns
-	fake.recordInvocation("SetupMounts", []interface{}{arg1})
+	fake.recordInvocation("SetupMounts", []interface{}{arg1, arg2Copy})
 	fake.setupMountsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -211,17 +218,17 @@ func (fake *FakeNetwork) SetupMountsCallCount() int {
 	return len(fake.setupMountsArgsForCall)
 }
 
-func (fake *FakeNetwork) SetupMountsCalls(stub func(string) ([]specs.Mount, error)) {
+func (fake *FakeNetwork) SetupMountsCalls(stub func(string, []string) ([]specs.Mount, error)) {
 	fake.setupMountsMutex.Lock()
 	defer fake.setupMountsMutex.Unlock()
 	fake.SetupMountsStub = stub
 }
 
-func (fake *FakeNetwork) SetupMountsArgsForCall(i int) string {
+func (fake *FakeNetwork) SetupMountsArgsForCall(i int) (string, []string) {
 	fake.setupMountsMutex.RLock()
 	defer fake.setupMountsMutex.RUnlock()
 	argsForCall := fake.setupMountsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeNetwork) SetupMountsReturns(result1 []specs.Mount, result2 error) {
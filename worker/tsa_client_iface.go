@@ -3,6 +3,7 @@ package worker
 import (
 	"context"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/tsa"
 )
 
@@ -21,4 +22,7 @@ type TSAClient interface {
 
 	ReportVolumes(context.Context, []string) error
 	VolumesToDestroy(context.Context) ([]string, error)
+
+	ReportDiskUsage(context.Context, atc.WorkerDiskUsage) error
+	ReportVolumeSizes(context.Context, map[string]uint64) error
 }
@@ -0,0 +1,152 @@
+package worker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/baggageclaim"
+)
+
+// SizeLimitedPropertyName is the property the ATC sets on the volumes of
+// outputs with a size limit, which are measured more often than the rest.
+const SizeLimitedPropertyName = "concourse:size-limited"
+
+// DirSizeFunc measures the size of the contents of a directory.
+type DirSizeFunc func(dir string) (uint64, error)
+
+// VolumeSizeReporter is an ifrit.Runner that periodically measures the sizes
+// of a worker's volumes and reports them, so that the disk usage of builds can
+// be looked into.
+//
+// Measuring walks every volume, so it runs on its own interval, which should
+// be much longer than the sweep interval. The volumes of outputs with a size
+// limit are measured on a separate, shorter interval, so that the ATC notices
+// soon enough when one of them grows past its limit. Either interval may be 0
+// to disable it.
+type VolumeSizeReporter struct {
+	logger             lager.Logger
+	interval           time.Duration
+	limitedInterval    time.Duration
+	tsaClient          TSAClient
+	baggageclaimClient baggageclaim.Client
+	dirSize            DirSizeFunc
+}
+
+func NewVolumeSizeReporter(
+	logger lager.Logger,
+	interval time.Duration,
+	limitedInterval time.Duration,
+	tsaClient TSAClient,
+	bcClient baggageclaim.Client,
+	dirSize DirSizeFunc,
+) *VolumeSizeReporter {
+	return &VolumeSizeReporter{
+		logger:             logger,
+		interval:           interval,
+		limitedInterval:    limitedInterval,
+		tsaClient:          tsaClient,
+		baggageclaimClient: bcClient,
+		dirSize:            dirSize,
+	}
+}
+
+func (reporter *VolumeSizeReporter) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	var ticks, limitedTicks <-chan time.Time
+
+	if reporter.interval > 0 {
+		ticker := time.NewTicker(reporter.interval)
+		defer ticker.Stop()
+
+		ticks = ticker.C
+	}
+
+	if reporter.limitedInterval > 0 {
+		ticker := time.NewTicker(reporter.limitedInterval)
+		defer ticker.Stop()
+
+		limitedTicks = ticker.C
+	}
+
+	close(ready)
+
+	for {
+		select {
+		case <-ticks:
+			reporter.report(reporter.logger.Session("tick"), baggageclaim.VolumeProperties{})
+
+		case <-limitedTicks:
+			reporter.report(reporter.logger.Session("limited-tick"), baggageclaim.VolumeProperties{
+				SizeLimitedPropertyName: "true",
+			})
+
+		case sig := <-signals:
+			reporter.logger.Info("reporting-cancelled-by-signal", lager.Data{"signal": sig})
+			return nil
+		}
+	}
+}
+
+func (reporter *VolumeSizeReporter) report(logger lager.Logger, properties baggageclaim.VolumeProperties) {
+	ctx := lagerctx.NewContext(context.Background(), logger)
+
+	volumes, err := reporter.baggageclaimClient.ListVolumes(logger.Session("list-volumes"), properties)
+	if err != nil {
+		logger.Error("failed-to-list-volumes", err)
+		return
+	}
+
+	if len(volumes) == 0 {
+		return
+	}
+
+	sizes := make(map[string]uint64, len(volumes))
+	for _, volume := range volumes {
+		size, err := reporter.dirSize(volume.Path())
+		if err != nil {
+			// the volume may have been destroyed while it was being measured
+			logger.Info("failed-to-measure-volume", lager.Data{
+				"handle": volume.Handle(),
+				"error":  err.Error(),
+			})
+			continue
+		}
+
+		sizes[volume.Handle()] = size
+	}
+
+	err = reporter.tsaClient.ReportVolumeSizes(ctx, sizes)
+	if err != nil {
+		logger.Error("failed-to-report-volume-sizes", err)
+	}
+}
+
+// DirSize sums up the sizes of the files under dir. For a copy-on-write volume
+// this includes the files it shares with its parent. Files which disappear
+// while dir is being walked are skipped.
+func DirSize(dir string) (uint64, error) {
+	var size uint64
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path != dir {
+				return nil
+			}
+
+			return err
+		}
+
+		if info.Mode().IsRegular() {
+			size += uint64(info.Size())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
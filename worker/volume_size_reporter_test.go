@@ -0,0 +1,175 @@
+package worker_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/baggageclaim/baggageclaimfakes"
+	"github.com/concourse/concourse/worker"
+	"github.com/concourse/concourse/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Volume Size Reporter", func() {
+	var (
+		testLogger = lagertest.NewTestLogger("volume-size-reporter")
+
+		fakeTSAClient      *workerfakes.FakeTSAClient
+		fakeBaggageclaim   *baggageclaimfakes.FakeClient
+		dirSize            worker.DirSizeFunc
+		interval           time.Duration
+		limitedInterval    time.Duration
+		measuredDirs       chan string
+		volumeSizeReporter *worker.VolumeSizeReporter
+
+		osSignal chan os.Signal
+		exited   chan struct{}
+	)
+
+	newVolume := func(handle string, path string) baggageclaim.Volume {
+		volume := new(baggageclaimfakes.FakeVolume)
+		volume.HandleReturns(handle)
+		volume.PathReturns(path)
+		return volume
+	}
+
+	BeforeEach(func() {
+		osSignal = make(chan os.Signal)
+		exited = make(chan struct{})
+
+		interval = 10 * time.Millisecond
+		limitedInterval = 0
+
+		fakeTSAClient = new(workerfakes.FakeTSAClient)
+
+		fakeBaggageclaim = new(baggageclaimfakes.FakeClient)
+		fakeBaggageclaim.ListVolumesReturns(baggageclaim.Volumes{
+			newVolume("some-handle", "/volumes/some-handle"),
+			newVolume("gone-handle", "/volumes/gone-handle"),
+		}, nil)
+
+		measuredDirs = make(chan string, 100)
+		dirSize = func(dir string) (uint64, error) {
+			select {
+			case measuredDirs <- dir:
+			default:
+			}
+
+			if dir == "/volumes/gone-handle" {
+				return 0, errors.New("gone")
+			}
+
+			return 1024, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		volumeSizeReporter = worker.NewVolumeSizeReporter(testLogger, interval, limitedInterval, fakeTSAClient, fakeBaggageclaim, dirSize)
+
+		go func() {
+			_ = volumeSizeReporter.Run(osSignal, make(chan struct{}))
+			close(exited)
+		}()
+	})
+
+	AfterEach(func() {
+		close(osSignal)
+		<-exited
+	})
+
+	It("measures every volume", func() {
+		Eventually(fakeBaggageclaim.ListVolumesCallCount).ShouldNot(BeZero())
+
+		_, properties := fakeBaggageclaim.ListVolumesArgsForCall(0)
+		Expect(properties).To(BeEmpty())
+
+		Eventually(measuredDirs).Should(Receive(Equal("/volumes/some-handle")))
+		Eventually(measuredDirs).Should(Receive(Equal("/volumes/gone-handle")))
+	})
+
+	It("reports the sizes of the volumes it could measure", func() {
+		Eventually(fakeTSAClient.ReportVolumeSizesCallCount).ShouldNot(BeZero())
+
+		_, sizes := fakeTSAClient.ReportVolumeSizesArgsForCall(0)
+		Expect(sizes).To(Equal(map[string]uint64{"some-handle": 1024}))
+	})
+
+	Context("when only the size-limited volumes are measured", func() {
+		BeforeEach(func() {
+			interval = 0
+			limitedInterval = 10 * time.Millisecond
+		})
+
+		It("lists only the volumes with a size limit", func() {
+			Eventually(fakeBaggageclaim.ListVolumesCallCount).ShouldNot(BeZero())
+
+			_, properties := fakeBaggageclaim.ListVolumesArgsForCall(0)
+			Expect(properties).To(Equal(baggageclaim.VolumeProperties{
+				worker.SizeLimitedPropertyName: "true",
+			}))
+		})
+
+		It("reports the sizes of the volumes it could measure", func() {
+			Eventually(fakeTSAClient.ReportVolumeSizesCallCount).ShouldNot(BeZero())
+
+			_, sizes := fakeTSAClient.ReportVolumeSizesArgsForCall(0)
+			Expect(sizes).To(Equal(map[string]uint64{"some-handle": 1024}))
+		})
+
+		Context("when there are no such volumes", func() {
+			BeforeEach(func() {
+				fakeBaggageclaim.ListVolumesReturns(baggageclaim.Volumes{}, nil)
+			})
+
+			It("does not report anything", func() {
+				Eventually(fakeBaggageclaim.ListVolumesCallCount).Should(BeNumerically(">", 1))
+				Expect(fakeTSAClient.ReportVolumeSizesCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when listing the volumes fails", func() {
+		BeforeEach(func() {
+			fakeBaggageclaim.ListVolumesReturns(nil, errors.New("nope"))
+		})
+
+		It("does not report anything", func() {
+			Eventually(fakeBaggageclaim.ListVolumesCallCount).Should(BeNumerically(">", 1))
+			Expect(fakeTSAClient.ReportVolumeSizesCallCount()).To(BeZero())
+		})
+	})
+})
+
+var _ = Describe("DirSize", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "dir-size")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(os.MkdirAll(filepath.Join(dir, "sub"), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(dir, "a"), make([]byte, 100), 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(dir, "sub", "b"), make([]byte, 24), 0644)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("sums up the sizes of the files under the dir", func() {
+		Expect(worker.DirSize(dir)).To(Equal(uint64(124)))
+	})
+
+	It("errors when the dir does not exist", func() {
+		_, err := worker.DirSize(filepath.Join(dir, "missing"))
+		Expect(err).To(HaveOccurred())
+	})
+})
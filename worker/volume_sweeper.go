@@ -9,8 +9,13 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
 )
 
+// DiskUsageFunc measures how much of the disk holding a worker's volumes is in
+// use.
+type DiskUsageFunc func() (atc.WorkerDiskUsage, error)
+
 // volumeSweeper is an ifrit.Runner that periodically reports and
 // garbage-collects a worker's volumes
 type volumeSweeper struct {
@@ -19,14 +24,19 @@ type volumeSweeper struct {
 	tsaClient          TSAClient
 	baggageclaimClient baggageclaim.Client
 	maxInFlight        uint16
+	diskUsage          DiskUsageFunc
 }
 
+// NewVolumeSweeper returns a volume sweeper which, unless diskUsage is nil,
+// also reports the worker's disk usage so that caches can be evicted from it
+// under disk pressure.
 func NewVolumeSweeper(
 	logger lager.Logger,
 	sweepInterval time.Duration,
 	tsaClient TSAClient,
 	bcClient baggageclaim.Client,
 	maxInFlight uint16,
+	diskUsage DiskUsageFunc,
 ) *volumeSweeper {
 	return &volumeSweeper{
 		logger:             logger,
@@ -34,6 +44,7 @@ func NewVolumeSweeper(
 		tsaClient:          tsaClient,
 		baggageclaimClient: bcClient,
 		maxInFlight:        maxInFlight,
+		diskUsage:          diskUsage,
 	}
 }
 
@@ -72,6 +83,18 @@ func (sweeper *volumeSweeper) sweep(logger lager.Logger) {
 		}
 	}
 
+	if sweeper.diskUsage != nil {
+		usage, err := sweeper.diskUsage()
+		if err != nil {
+			logger.Error("failed-to-measure-disk-usage", err)
+		} else {
+			err := sweeper.tsaClient.ReportDiskUsage(ctx, usage)
+			if err != nil {
+				logger.Error("failed-to-report-disk-usage", err)
+			}
+		}
+	}
+
 	volumeHandles, err := sweeper.tsaClient.VolumesToDestroy(ctx)
 	if err != nil {
 		logger.Error("failed-to-get-volumes-to-destroy", err)
@@ -11,6 +11,7 @@ import (
 	"github.com/concourse/baggageclaim/baggageclaimcmd"
 	bclient "github.com/concourse/baggageclaim/client"
 	"github.com/concourse/concourse"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/worker/gclient"
 	concourseCmd "github.com/concourse/concourse/cmd"
 	"github.com/concourse/concourse/worker"
@@ -21,6 +22,10 @@ import (
 	"github.com/tedsuo/ifrit/sigmon"
 )
 
+const containerdRuntime = atc.WorkerRuntimeContainerd
+const guardianRuntime = atc.WorkerRuntimeGuardian
+const houdiniRuntime = atc.WorkerRuntimeHoudini
+
 type WorkerCommand struct {
 	Worker WorkerConfig
 
@@ -44,6 +49,9 @@ type WorkerCommand struct {
 	VolumeSweeperMaxInFlight    uint16        `long:"volume-sweeper-max-in-flight" default:"3" description:"Maximum number of volumes which can be swept in parallel."`
 	ContainerSweeperMaxInFlight uint16        `long:"container-sweeper-max-in-flight" default:"5" description:"Maximum number of containers which can be swept in parallel."`
 
+	VolumeSizeReportInterval        time.Duration `long:"volume-size-report-interval" default:"0" description:"Interval on which the sizes of the worker's volumes will be measured and reported. Measuring walks the contents of every volume, so it is disabled (0) by default."`
+	LimitedVolumeSizeReportInterval time.Duration `long:"limited-volume-size-report-interval" default:"10s" description:"Interval on which the sizes of the volumes of task outputs with a size limit will be measured and reported, for the ATC to enforce the limit with."`
+
 	RebalanceInterval time.Duration `long:"rebalance-interval" default:"4h" description:"Duration after which the registration should be swapped to another random SSH gateway."`
 
 	ConnectionDrainTimeout time.Duration `long:"connection-drain-timeout" default:"1h" description:"Duration after which a worker should give up draining forwarded connections on shutdown."`
@@ -88,6 +96,11 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 
 	atcWorker.Version = concourse.WorkerVersion
 
+	if cmd.gardenServerIsExternal() {
+		// the runtime behind an external Garden server is unknown
+		atcWorker.Runtime = ""
+	}
+
 	baggageclaimRunner, err := cmd.baggageclaimRunner(logger.Session("baggageclaim"))
 	if err != nil {
 		return nil, err
@@ -151,6 +164,7 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 		tsaClient,
 		baggageclaimClient,
 		cmd.VolumeSweeperMaxInFlight,
+		cmd.diskUsage,
 	)
 
 	var members grouper.Members
@@ -210,6 +224,23 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 		},
 	}...)
 
+	if cmd.VolumeSizeReportInterval > 0 || cmd.LimitedVolumeSizeReportInterval > 0 {
+		members = append(members, grouper.Member{
+			Name: "volume-size-reporter",
+			Runner: concourseCmd.NewLoggingRunner(
+				logger.Session("volume-size-reporter"),
+				worker.NewVolumeSizeReporter(
+					logger.Session("volume-size-reporter"),
+					cmd.VolumeSizeReportInterval,
+					cmd.LimitedVolumeSizeReportInterval,
+					tsaClient,
+					baggageclaimClient,
+					worker.DirSize,
+				),
+			),
+		})
+	}
+
 	return grouper.NewParallel(os.Interrupt, members), nil
 }
 
@@ -233,6 +264,10 @@ func (cmd *WorkerCommand) baggageclaimAddr() string {
 	return fmt.Sprintf("%s:%d", cmd.Baggageclaim.BindIP, cmd.Baggageclaim.BindPort)
 }
 
+func (cmd *WorkerCommand) diskUsage() (atc.WorkerDiskUsage, error) {
+	return worker.DirDiskUsage(cmd.WorkDir.Path())
+}
+
 func (cmd *WorkerCommand) baggageclaimURL() string {
 	return fmt.Sprintf("http://%s", cmd.baggageclaimAddr())
 }
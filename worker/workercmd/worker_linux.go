@@ -61,10 +61,6 @@ type DNSConfig struct {
 	Enable bool `long:"enable" description:"Enable proxy DNS server."`
 }
 
-const containerdRuntime = "containerd"
-const guardianRuntime = "guardian"
-const houdiniRuntime = "houdini"
-
 func (cmd WorkerCommand) LessenRequirements(prefix string, command *flags.Command) {
 	// configured as work-dir/volumes
 	command.FindOptionByLongName(prefix + "baggageclaim-volumes").Required = false
@@ -87,6 +83,7 @@ func (cmd *WorkerCommand) gardenServerRunner(logger lager.Logger) (atc.Worker, i
 
 	worker := cmd.Worker.Worker()
 	worker.Platform = "linux"
+	worker.Runtime = cmd.Runtime
 
 	if cmd.Certs.Dir != "" {
 		worker.CertsPath = &cmd.Certs.Dir
@@ -32,6 +32,7 @@ func (cmd WorkerCommand) LessenRequirements(prefix string, command *flags.Comman
 func (cmd *WorkerCommand) gardenServerRunner(logger lager.Logger) (atc.Worker, ifrit.Runner, error) {
 	worker := cmd.Worker.Worker()
 	worker.Platform = runtime.GOOS
+	worker.Runtime = houdiniRuntime
 	var err error
 	worker.Name, err = cmd.workerName()
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/tsa"
 	"github.com/concourse/concourse/worker"
 )
@@ -69,6 +70,30 @@ type FakeTSAClient struct {
 	reportContainersReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ReportDiskUsageStub        func(context.Context, atc.WorkerDiskUsage) error
+	reportDiskUsageMutex       sync.RWMutex
+	reportDiskUsageArgsForCall []struct {
+		arg1 context.Context
+		arg2 atc.WorkerDiskUsage
+	}
+	reportDiskUsageReturns struct {
+		result1 error
+	}
+	reportDiskUsageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReportVolumeSizesStub        func(context.Context, map[string]uint64) error
+	reportVolumeSizesMutex       sync.RWMutex
+	reportVolumeSizesArgsForCall []struct {
+		arg1 context.Context
+		arg2 map[string]uint64
+	}
+	reportVolumeSizesReturns struct {
+		result1 error
+	}
+	reportVolumeSizesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ReportVolumesStub        func(context.Context, []string) error
 	reportVolumesMutex       sync.RWMutex
 	reportVolumesArgsForCall []struct {
@@ -424,6 +449,130 @@ func (fake *FakeTSAClient) ReportContainersReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTSAClient) ReportDiskUsage(arg1 context.Context, arg2 atc.WorkerDiskUsage) error {
+	fake.reportDiskUsageMutex.Lock()
+	ret, specificReturn := fake.reportDiskUsageReturnsOnCall[len(fake.reportDiskUsageArgsForCall)]
+	fake.reportDiskUsageArgsForCall = append(fake.reportDiskUsageArgsForCall, struct {
+		arg1 context.Context
+		arg2 atc.WorkerDiskUsage
+	}{arg1, arg2})
+	stub := fake.ReportDiskUsageStub
+	fakeReturns := fake.reportDiskUsageReturns
+	fake.recordInvocation("ReportDiskUsage", []interface{}{arg1, arg2})
+	fake.reportDiskUsageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTSAClient) ReportDiskUsageCallCount() int {
+	fake.reportDiskUsageMutex.RLock()
+	defer fake.reportDiskUsageMutex.RUnlock()
+	return len(fake.reportDiskUsageArgsForCall)
+}
+
+func (fake *FakeTSAClient) ReportDiskUsageCalls(stub func(context.Context, atc.WorkerDiskUsage) error) {
+	fake.reportDiskUsageMutex.Lock()
+	defer fake.reportDiskUsageMutex.Unlock()
+	fake.ReportDiskUsageStub = stub
+}
+
+func (fake *FakeTSAClient) ReportDiskUsageArgsForCall(i int) (context.Context, atc.WorkerDiskUsage) {
+	fake.reportDiskUsageMutex.RLock()
+	defer fake.reportDiskUsageMutex.RUnlock()
+	argsForCall := fake.reportDiskUsageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTSAClient) ReportDiskUsageReturns(result1 error) {
+	fake.reportDiskUsageMutex.Lock()
+	defer fake.reportDiskUsageMutex.Unlock()
+	fake.ReportDiskUsageStub = nil
+	fake.reportDiskUsageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTSAClient) ReportDiskUsageReturnsOnCall(i int, result1 error) {
+	fake.reportDiskUsageMutex.Lock()
+	defer fake.reportDiskUsageMutex.Unlock()
+	fake.ReportDiskUsageStub = nil
+	if fake.reportDiskUsageReturnsOnCall == nil {
+		fake.reportDiskUsageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.reportDiskUsageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTSAClient) ReportVolumeSizes(arg1 context.Context, arg2 map[string]uint64) error {
+	fake.reportVolumeSizesMutex.Lock()
+	ret, specificReturn := fake.reportVolumeSizesReturnsOnCall[len(fake.reportVolumeSizesArgsForCall)]
+	fake.reportVolumeSizesArgsForCall = append(fake.reportVolumeSizesArgsForCall, struct {
+		arg1 context.Context
+		arg2 map[string]uint64
+	}{arg1, arg2})
+	stub := fake.ReportVolumeSizesStub
+	fakeReturns := fake.reportVolumeSizesReturns
+	fake.recordInvocation("ReportVolumeSizes", []interface{}{arg1, arg2})
+	fake.reportVolumeSizesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTSAClient) ReportVolumeSizesCallCount() int {
+	fake.reportVolumeSizesMutex.RLock()
+	defer fake.reportVolumeSizesMutex.RUnlock()
+	return len(fake.reportVolumeSizesArgsForCall)
+}
+
+func (fake *FakeTSAClient) ReportVolumeSizesCalls(stub func(context.Context, map[string]uint64) error) {
+	fake.reportVolumeSizesMutex.Lock()
+	defer fake.reportVolumeSizesMutex.Unlock()
+	fake.ReportVolumeSizesStub = stub
+}
+
+func (fake *FakeTSAClient) ReportVolumeSizesArgsForCall(i int) (context.Context, map[string]uint64) {
+	fake.reportVolumeSizesMutex.RLock()
+	defer fake.reportVolumeSizesMutex.RUnlock()
+	argsForCall := fake.reportVolumeSizesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTSAClient) ReportVolumeSizesReturns(result1 error) {
+	fake.reportVolumeSizesMutex.Lock()
+	defer fake.reportVolumeSizesMutex.Unlock()
+	fake.ReportVolumeSizesStub = nil
+	fake.reportVolumeSizesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTSAClient) ReportVolumeSizesReturnsOnCall(i int, result1 error) {
+	fake.reportVolumeSizesMutex.Lock()
+	defer fake.reportVolumeSizesMutex.Unlock()
+	fake.ReportVolumeSizesStub = nil
+	if fake.reportVolumeSizesReturnsOnCall == nil {
+		fake.reportVolumeSizesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.reportVolumeSizesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTSAClient) ReportVolumes(arg1 context.Context, arg2 []string) error {
 	var arg2Copy []string
 	if arg2 != nil {
@@ -629,6 +778,10 @@ func (fake *FakeTSAClient) Invocations() map[string][][]interface{} {
 	defer fake.registerMutex.RUnlock()
 	fake.reportContainersMutex.RLock()
 	defer fake.reportContainersMutex.RUnlock()
+	fake.reportDiskUsageMutex.RLock()
+	defer fake.reportDiskUsageMutex.RUnlock()
+	fake.reportVolumeSizesMutex.RLock()
+	defer fake.reportVolumeSizesMutex.RUnlock()
 	fake.reportVolumesMutex.RLock()
 	defer fake.reportVolumesMutex.RUnlock()
 	fake.retireMutex.RLock()